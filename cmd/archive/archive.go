@@ -0,0 +1,152 @@
+package archive
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/backend/v3/instrumentation/logging"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type Config struct {
+	Database   database.Config
+	Eventstore struct {
+		Archive eventstore.ArchiveConfig
+	}
+}
+
+func New() *cobra.Command {
+	return &cobra.Command{
+		Use:   "archive",
+		Short: "moves the events of aggregates which are not changed anymore to the archive",
+		Long: `moves the events of aggregates which are not changed anymore to the archive
+An aggregate is archived if its latest event is one of the final event types configured in Eventstore.Archive.Aggregates
+and older than the retention. The events of the aggregate are compressed into a single row of eventstore.archived_aggregates,
+only the final event remains in the events table.
+Archived events are still returned by the audit log if they are requested explicitly.
+Pushes to an instance are blocked while a bulk of its aggregates is archived.
+The space of the archived events is reused by the database after the events table is vacuumed.
+The command can be stopped at any time and continues with the remaining aggregates on the next run.
+Requirements:
+- postgreSQL`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				logging.OnError(cmd.Context(), err).Error("zitadel archive command failed")
+			}()
+			config := new(Config)
+			if err = viper.Unmarshal(config); err != nil {
+				return err
+			}
+			client, err := database.Connect(config.Database, false)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return archive(ctx, client, &config.Eventstore.Archive)
+		},
+	}
+}
+
+func archive(ctx context.Context, client *database.DB, config *eventstore.ArchiveConfig) error {
+	if config.Retention <= 0 || config.BulkSize == 0 {
+		return zerrors.ThrowPreconditionFailed(nil, "ARCHI-Rt4vq", "retention and bulk size of the archive must be greater than 0")
+	}
+	instanceIDs, err := instanceIDs(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	before := start.Add(-config.Retention)
+	es := new_es.NewEventstore(client)
+	total := new(new_es.ArchiveResult)
+	defer func() {
+		logArchiveResult(context.WithoutCancel(ctx), client, total, time.Since(start))
+	}()
+
+	for _, instanceID := range instanceIDs {
+		for _, aggregateType := range config.Aggregates {
+			// every run starts with the first final event, so aggregates of newly configured final event types are archived as well
+			var after decimal.Decimal
+			for {
+				if ctx.Err() != nil {
+					logging.Info(ctx, "archive stopped, remaining aggregates are archived on the next run")
+					return nil
+				}
+				// the bulk is finished even if the command is stopped
+				result, err := es.ArchiveAggregates(context.WithoutCancel(ctx), instanceID, aggregateType, before, after, config.BulkSize)
+				if err != nil {
+					return err
+				}
+				total.Add(result)
+				after = result.FinalPosition
+				if result.Aggregates < uint64(config.BulkSize) {
+					break
+				}
+			}
+		}
+		logging.Debug(ctx, "instance archived", "instance_id", instanceID, "aggregates", total.Aggregates)
+	}
+	return nil
+}
+
+func instanceIDs(ctx context.Context, client *database.DB) (ids []string, err error) {
+	err = client.QueryContext(ctx,
+		func(rows *sql.Rows) error {
+			for rows.Next() {
+				var id string
+				if err := rows.Scan(&id); err != nil {
+					return err
+				}
+				ids = append(ids, id)
+			}
+			return rows.Err()
+		},
+		"SELECT DISTINCT instance_id FROM eventstore.events2 WHERE aggregate_type = 'instance' AND event_type = 'instance.added'",
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "ARCHI-Iq8ne", "unable to query instances")
+	}
+	return ids, nil
+}
+
+// logArchiveResult reports the archived events and the current sizes of the tables
+func logArchiveResult(ctx context.Context, client *database.DB, result *new_es.ArchiveResult, took time.Duration) {
+	var eventsTableSize, archiveTableSize int64
+	err := client.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(&eventsTableSize, &archiveTableSize)
+		},
+		"SELECT pg_total_relation_size('eventstore.events2'), pg_total_relation_size('eventstore.archived_aggregates')",
+	)
+	logging.OnError(ctx, err).Warn("unable to query table sizes")
+
+	var ratio float64
+	if result.EventsSize > 0 {
+		ratio = float64(result.ArchivedSize) / float64(result.EventsSize)
+	}
+	logging.Info(ctx, "events archived",
+		"took", took,
+		"aggregates", result.Aggregates,
+		"events", result.Events,
+		"reclaimed_bytes", result.EventsSize,
+		"archived_bytes", result.ArchivedSize,
+		"compression_ratio", ratio,
+		"events_table_bytes", eventsTableSize,
+		"archive_table_bytes", archiveTableSize,
+	)
+}
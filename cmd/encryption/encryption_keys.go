@@ -18,6 +18,8 @@ var (
 		"smtpKey",
 		"userKey",
 		"targetKey",
+		"radiusKey",
+		"personalDataKey",
 		"csrfCookieKey",
 		"userAgentCookieKey",
 	}
@@ -33,6 +35,8 @@ type EncryptionKeyConfig struct {
 	SMTP                 *crypto.KeyConfig
 	User                 *crypto.KeyConfig
 	Target               *crypto.KeyConfig
+	RADIUS               *crypto.KeyConfig
+	PersonalData         *crypto.KeyConfig
 	CSRFCookieKeyID      string
 	UserAgentCookieKeyID string
 }
@@ -47,6 +51,8 @@ type EncryptionKeys struct {
 	SMTP               crypto.EncryptionAlgorithm
 	User               crypto.EncryptionAlgorithm
 	Target             crypto.EncryptionAlgorithm
+	RADIUS             crypto.EncryptionAlgorithm
+	PersonalData       crypto.EncryptionAlgorithm
 	CSRFCookieKey      []byte
 	UserAgentCookieKey []byte
 	OIDCKey            []byte
@@ -98,6 +104,14 @@ func EnsureEncryptionKeys(ctx context.Context, keyConfig *EncryptionKeyConfig, k
 	if err != nil {
 		return nil, err
 	}
+	keys.RADIUS, err = crypto.NewAESCrypto(keyConfig.RADIUS, keyStorage)
+	if err != nil {
+		return nil, err
+	}
+	keys.PersonalData, err = crypto.NewAESCrypto(keyConfig.PersonalData, keyStorage)
+	if err != nil {
+		return nil, err
+	}
 	key, err = crypto.LoadKey(keyConfig.CSRFCookieKeyID, keyStorage)
 	if err != nil {
 		return nil, err
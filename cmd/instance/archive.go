@@ -0,0 +1,247 @@
+package instance
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/shopspring/decimal"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// archiveFormatVersion is increased on breaking changes of the archive
+const archiveFormatVersion = 4
+
+// the files of the archive in the order they are written and read
+const (
+	manifestFile          = "manifest.json"
+	eventsFile            = "events.jsonl"
+	uniqueConstraintsFile = "unique_constraints.jsonl"
+	assetsFile            = "assets.jsonl"
+	personalDataKeysFile  = "personal_data_keys.jsonl"
+)
+
+// manifest describes the exported instance
+type manifest struct {
+	FormatVersion  uint16 `json:"formatVersion"`
+	ZitadelVersion string `json:"zitadelVersion"`
+	InstanceID     string `json:"instanceId"`
+	// GeneratedDomain is the domain generated by ZITADEL when the instance was created,
+	// it's replaced if the archive is imported with a new domain.
+	GeneratedDomain string          `json:"generatedDomain"`
+	Domains         []string        `json:"domains"`
+	ExportedAt      time.Time       `json:"exportedAt"`
+	Position        decimal.Decimal `json:"position"`
+	// ExportKeyChecksum is the HMAC of the instance id, it verifies the export key on import.
+	ExportKeyChecksum string `json:"exportKeyChecksum"`
+
+	Events            uint64 `json:"events"`
+	UniqueConstraints uint64 `json:"uniqueConstraints"`
+	Assets            uint64 `json:"assets"`
+	PersonalDataKeys  uint64 `json:"personalDataKeys"`
+}
+
+// event is exported without position as it's recomputed on import,
+// the crypto values of the payload are encrypted with the export key.
+type event struct {
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	EventType     string          `json:"eventType"`
+	Sequence      uint64          `json:"sequence"`
+	Revision      uint16          `json:"revision"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+	Creator       string          `json:"creator"`
+	Owner         string          `json:"owner"`
+}
+
+// uniqueConstraint is global if it's not bound to the instance, e.g. instance domains
+type uniqueConstraint struct {
+	Global bool   `json:"global,omitempty"`
+	Type   string `json:"type"`
+	Field  string `json:"field"`
+}
+
+type asset struct {
+	AssetType     string    `json:"assetType"`
+	ResourceOwner string    `json:"resourceOwner"`
+	Name          string    `json:"name"`
+	ContentType   string    `json:"contentType"`
+	Data          []byte    `json:"data"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
+
+// personalDataKey is the key of the personal data in the events of a subject,
+// the key is a crypto value re-encrypted with the export key like the values in the event payloads.
+type personalDataKey struct {
+	KeyID     string          `json:"keyId"`
+	SubjectID string          `json:"subjectId"`
+	Key       json.RawMessage `json:"key"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+// section is a file of the archive, which is buffered in a temporary file
+// because the size must be known before it's added to the archive.
+type section struct {
+	name    string
+	file    *os.File
+	buffer  *bufio.Writer
+	encoder *json.Encoder
+	count   uint64
+}
+
+func newSection(name string) (*section, error) {
+	file, err := os.CreateTemp("", "zitadel-instance-*")
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Rk3bm", "unable to create temporary file")
+	}
+	buffer := bufio.NewWriter(file)
+	return &section{
+		name:    name,
+		file:    file,
+		buffer:  buffer,
+		encoder: json.NewEncoder(buffer),
+	}, nil
+}
+
+// write appends the object as JSON line
+func (s *section) write(object any) error {
+	if err := s.encoder.Encode(object); err != nil {
+		return zerrors.ThrowInternalf(err, "INSTA-Ux8qe", "unable to write %s", s.name)
+	}
+	s.count++
+	return nil
+}
+
+func (s *section) close() error {
+	return errors.Join(s.file.Close(), os.Remove(s.file.Name()))
+}
+
+// writeArchive writes the manifest and the sections as zstd compressed tar archive
+func writeArchive(w io.Writer, manifest *manifest, sections ...*section) (err error) {
+	compressed, err := zstd.NewWriter(w)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Wd2fo", "unable to create archive")
+	}
+	archive := tar.NewWriter(compressed)
+	defer func() {
+		err = errors.Join(err, archive.Close(), compressed.Close())
+	}()
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Ew4ju", "unable to marshal manifest")
+	}
+	if err = writeArchiveFile(archive, manifestFile, int64(len(data)), bytes.NewReader(data)); err != nil {
+		return err
+	}
+	for _, section := range sections {
+		if err = section.buffer.Flush(); err != nil {
+			return zerrors.ThrowInternalf(err, "INSTA-Ja7vn", "unable to write %s", section.name)
+		}
+		size, err := section.file.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return zerrors.ThrowInternalf(err, "INSTA-Mc1pk", "unable to read %s", section.name)
+		}
+		if _, err = section.file.Seek(0, io.SeekStart); err != nil {
+			return zerrors.ThrowInternalf(err, "INSTA-Bo8ys", "unable to read %s", section.name)
+		}
+		if err = writeArchiveFile(archive, section.name, size, section.file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeArchiveFile(archive *tar.Writer, name string, size int64, content io.Reader) error {
+	err := archive.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o600,
+		Size:     size,
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return zerrors.ThrowInternalf(err, "INSTA-Tq5ha", "unable to write %s", name)
+	}
+	if _, err = io.Copy(archive, content); err != nil {
+		return zerrors.ThrowInternalf(err, "INSTA-Zf6wc", "unable to write %s", name)
+	}
+	return nil
+}
+
+// archiveReader reads the files of the archive in the order they were written
+type archiveReader struct {
+	compressed *zstd.Decoder
+	archive    *tar.Reader
+}
+
+func newArchiveReader(r io.Reader) (*archiveReader, error) {
+	compressed, err := zstd.NewReader(r)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "INSTA-Lp2sd", "unable to read archive")
+	}
+	return &archiveReader{
+		compressed: compressed,
+		archive:    tar.NewReader(compressed),
+	}, nil
+}
+
+func (r *archiveReader) close() {
+	r.compressed.Close()
+}
+
+func (r *archiveReader) manifest() (*manifest, error) {
+	content, err := r.next(manifestFile)
+	if err != nil {
+		return nil, err
+	}
+	manifest := new(manifest)
+	if err = json.NewDecoder(content).Decode(manifest); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "INSTA-Gk9rf", "unable to read manifest")
+	}
+	if manifest.FormatVersion != archiveFormatVersion {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "INSTA-Vy3an", "unsupported archive format version %d", manifest.FormatVersion)
+	}
+	return manifest, nil
+}
+
+// next returns the content of the next file, which must have the given name
+func (r *archiveReader) next(name string) (io.Reader, error) {
+	header, err := r.archive.Next()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgumentf(err, "INSTA-Ci4ex", "unable to read %s", name)
+	}
+	if header.Name != name {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "INSTA-Hn6tb", "expected %s but got %s", name, header.Name)
+	}
+	return r.archive, nil
+}
+
+// readSection calls the reduce function for each JSON line of the next file
+func readSection[T any](r *archiveReader, name string, reduce func(object *T) error) (count uint64, err error) {
+	content, err := r.next(name)
+	if err != nil {
+		return 0, err
+	}
+	decoder := json.NewDecoder(content)
+	for {
+		object := new(T)
+		if err = decoder.Decode(object); errors.Is(err, io.EOF) {
+			return count, nil
+		} else if err != nil {
+			return count, zerrors.ThrowInvalidArgumentf(err, "INSTA-Ps7kw", "unable to read %s", name)
+		}
+		if err = reduce(object); err != nil {
+			return count, err
+		}
+		count++
+	}
+}
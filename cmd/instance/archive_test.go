@@ -0,0 +1,90 @@
+package instance
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_archive(t *testing.T) {
+	events, err := newSection(eventsFile)
+	require.NoError(t, err)
+	defer events.close()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, id := range []string{"1", "2"} {
+		require.NoError(t, events.write(&event{AggregateType: "user", AggregateID: id, EventType: "user.added", Sequence: 1, CreatedAt: createdAt, Payload: []byte(`{"userName":"user"}`)}))
+	}
+
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, &manifest{
+		FormatVersion: archiveFormatVersion,
+		InstanceID:    "instance",
+		Events:        events.count,
+	}, events))
+
+	reader, err := newArchiveReader(&archive)
+	require.NoError(t, err)
+	defer reader.close()
+
+	gotManifest, err := reader.manifest()
+	require.NoError(t, err)
+	assert.Equal(t, "instance", gotManifest.InstanceID)
+	assert.Equal(t, uint64(2), gotManifest.Events)
+
+	var gotIDs []string
+	count, err := readSection(reader, eventsFile, func(e *event) error {
+		assert.Equal(t, createdAt, e.CreatedAt.UTC())
+		assert.JSONEq(t, `{"userName":"user"}`, string(e.Payload))
+		gotIDs = append(gotIDs, e.AggregateID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), count)
+	assert.Equal(t, []string{"1", "2"}, gotIDs)
+
+	_, err = readSection(reader, uniqueConstraintsFile, func(*uniqueConstraint) error { return nil })
+	assert.True(t, zerrors.IsErrorInvalidArgument(err))
+}
+
+func Test_archiveReader_order(t *testing.T) {
+	events, err := newSection(eventsFile)
+	require.NoError(t, err)
+	defer events.close()
+
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, &manifest{FormatVersion: archiveFormatVersion}, events))
+
+	reader, err := newArchiveReader(&archive)
+	require.NoError(t, err)
+	defer reader.close()
+	_, err = reader.manifest()
+	require.NoError(t, err)
+
+	_, err = readSection(reader, uniqueConstraintsFile, func(*uniqueConstraint) error { return nil })
+	assert.True(t, zerrors.IsErrorInvalidArgument(err))
+}
+
+func Test_archiveReader_manifest_version(t *testing.T) {
+	var archive bytes.Buffer
+	require.NoError(t, writeArchive(&archive, &manifest{FormatVersion: archiveFormatVersion + 1}))
+
+	reader, err := newArchiveReader(&archive)
+	require.NoError(t, err)
+	defer reader.close()
+
+	_, err = reader.manifest()
+	assert.True(t, zerrors.IsErrorInvalidArgument(err))
+}
+
+func Test_keyChecksum(t *testing.T) {
+	exportKey := "01234567890123456789012345678901"
+	assert.Equal(t, keyChecksum("key", exportKey), keyChecksum("key", exportKey))
+	assert.NotEqual(t, keyChecksum("key", exportKey), keyChecksum("other", exportKey))
+	assert.NotEqual(t, keyChecksum("key", exportKey), keyChecksum("key", "10987654321098765432109876543210"))
+}
@@ -0,0 +1,363 @@
+package instance
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/backend/v3/instrumentation/logging"
+	"github.com/zitadel/zitadel/cmd/build"
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	flagInstance = "instance"
+	flagOut      = "out"
+)
+
+func exportCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "exports an instance to an archive",
+		Long: `exports an instance to an archive
+The archive contains the events, unique constraints, assets and personal data keys of the instance.
+The encrypted values of the events and the personal data keys are re-encrypted with the export key, which is required to import the archive.
+The encryption keys of the deployment are not exported.
+Projections are not exported, they are recomputed after the import.
+Requirements:
+- postgreSQL`,
+		Example: `export --instance 69629023906488334 --out instance.tar.zst --export-key-file export.key`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				logging.OnError(cmd.Context(), err).Error("zitadel instance export command failed")
+			}()
+			config := new(Config)
+			if err = viper.Unmarshal(config); err != nil {
+				return err
+			}
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			exportKey, err := readExportKey(cmd)
+			if err != nil {
+				return err
+			}
+			instanceID, _ := cmd.Flags().GetString(flagInstance)
+			path, _ := cmd.Flags().GetString(flagOut)
+
+			client, err := database.Connect(config.Database, false)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			out, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+			if err != nil {
+				return err
+			}
+			manifest, err := exportInstance(cmd.Context(), client, masterKey, exportKey, instanceID, out)
+			if err = errors.Join(err, out.Close()); err != nil {
+				return errors.Join(err, os.Remove(path))
+			}
+			logging.Info(cmd.Context(), "instance exported",
+				"instance", manifest.InstanceID,
+				"events", manifest.Events,
+				"unique_constraints", manifest.UniqueConstraints,
+				"assets", manifest.Assets,
+				"personal_data_keys", manifest.PersonalDataKeys,
+				"path", path,
+			)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagInstance, "", "id of the instance to export")
+	cmd.Flags().String(flagOut, "", "path of the archive, the file must not exist")
+	_ = cmd.MarkFlagRequired(flagInstance)
+	_ = cmd.MarkFlagRequired(flagOut)
+	return cmd
+}
+
+func exportInstance(ctx context.Context, client *database.DB, masterKey, exportKey, instanceID string, out io.Writer) (_ *manifest, err error) {
+	// all data is read from the same snapshot
+	tx, err := client.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Fs2ov", "unable to begin transaction")
+	}
+	defer func() {
+		err = errors.Join(err, tx.Rollback())
+	}()
+
+	manifest, err := exportManifest(ctx, tx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	manifest.ExportKeyChecksum = keyChecksum(instanceID, exportKey)
+	keys, err := deploymentKeys(client, masterKey)
+	if err != nil {
+		return nil, err
+	}
+	reencrypt := newExportReencrypter(keys, exportKey)
+
+	sections := make([]*section, 0, 4)
+	defer func() {
+		for _, section := range sections {
+			err = errors.Join(err, section.close())
+		}
+	}()
+	for _, export := range []struct {
+		name   string
+		export func(context.Context, *sql.Tx, *section) error
+	}{
+		{
+			name: eventsFile,
+			export: func(ctx context.Context, tx *sql.Tx, s *section) error {
+				return exportEvents(ctx, tx, instanceID, reencrypt, s)
+			},
+		},
+		{
+			name: uniqueConstraintsFile,
+			export: func(ctx context.Context, tx *sql.Tx, s *section) error {
+				return exportUniqueConstraints(ctx, tx, instanceID, manifest.Domains, s)
+			},
+		},
+		{
+			name: assetsFile,
+			export: func(ctx context.Context, tx *sql.Tx, s *section) error {
+				return exportAssets(ctx, tx, instanceID, s)
+			},
+		},
+		{
+			name: personalDataKeysFile,
+			export: func(ctx context.Context, tx *sql.Tx, s *section) error {
+				return exportPersonalDataKeys(ctx, tx, instanceID, reencrypt, s)
+			},
+		},
+	} {
+		section, err := newSection(export.name)
+		if err != nil {
+			return nil, err
+		}
+		sections = append(sections, section)
+		if err = export.export(ctx, tx, section); err != nil {
+			return nil, err
+		}
+	}
+	manifest.Events = sections[0].count
+	manifest.UniqueConstraints = sections[1].count
+	manifest.Assets = sections[2].count
+	manifest.PersonalDataKeys = sections[3].count
+
+	return manifest, writeArchive(out, manifest, sections...)
+}
+
+// exportManifest computes the domains of the instance from its events
+func exportManifest(ctx context.Context, tx *sql.Tx, instanceID string) (*manifest, error) {
+	manifest := &manifest{
+		FormatVersion:  archiveFormatVersion,
+		ZitadelVersion: build.Version(),
+		InstanceID:     instanceID,
+		ExportedAt:     time.Now(),
+	}
+	var exists bool
+	rows, err := tx.QueryContext(ctx,
+		"SELECT event_type, payload FROM eventstore.events2 WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $1 AND event_type = ANY($3) ORDER BY position, in_tx_order",
+		instanceID,
+		instance.AggregateType,
+		[]string{
+			string(instance.InstanceAddedEventType),
+			string(instance.InstanceDomainAddedEventType),
+			string(instance.InstanceDomainRemovedEventType),
+		},
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Oi7wb", "unable to query instance")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			eventType string
+			payload   []byte
+		)
+		if err = rows.Scan(&eventType, &payload); err != nil {
+			return nil, zerrors.ThrowInternal(err, "INSTA-Aq4hn", "unable to query instance")
+		}
+		var domain struct {
+			Domain    string `json:"domain"`
+			Generated bool   `json:"generated"`
+		}
+		if len(payload) > 0 {
+			if err = json.Unmarshal(payload, &domain); err != nil {
+				return nil, zerrors.ThrowInternal(err, "INSTA-Ku3mz", "unable to read instance domain")
+			}
+		}
+		switch eventType {
+		case string(instance.InstanceAddedEventType):
+			exists = true
+		case string(instance.InstanceDomainAddedEventType):
+			manifest.Domains = append(manifest.Domains, strings.ToLower(domain.Domain))
+			if domain.Generated {
+				manifest.GeneratedDomain = strings.ToLower(domain.Domain)
+			}
+		case string(instance.InstanceDomainRemovedEventType):
+			manifest.Domains = slices.DeleteFunc(manifest.Domains, func(d string) bool {
+				return strings.EqualFold(d, domain.Domain)
+			})
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Xr5ul", "unable to query instance")
+	}
+	if !exists {
+		return nil, zerrors.ThrowNotFoundf(nil, "INSTA-Dn8ck", "instance %s not found", instanceID)
+	}
+	var position decimal.NullDecimal
+	err = tx.QueryRowContext(ctx, "SELECT MAX(position) FROM eventstore.events2 WHERE instance_id = $1", instanceID).Scan(&position)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Ty6fe", "unable to query position")
+	}
+	manifest.Position = position.Decimal
+	return manifest, nil
+}
+
+func exportEvents(ctx context.Context, tx *sql.Tx, instanceID string, reencrypt *reencrypter, s *section) error {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT aggregate_type, aggregate_id, event_type, sequence, revision, created_at, payload, creator, owner FROM eventstore.events2 WHERE instance_id = $1 ORDER BY position, in_tx_order",
+		instanceID,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Wm2xo", "unable to query events")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		e := new(event)
+		var payload []byte
+		if err = rows.Scan(&e.AggregateType, &e.AggregateID, &e.EventType, &e.Sequence, &e.Revision, &e.CreatedAt, &payload, &e.Creator, &e.Owner); err != nil {
+			return zerrors.ThrowInternal(err, "INSTA-Ej9ap", "unable to scan event")
+		}
+		if len(payload) > 0 {
+			if e.Payload, err = reencrypt.payload(payload); err != nil {
+				return err
+			}
+		}
+		if err = s.write(e); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Qs3ve", "unable to query events")
+	}
+	return nil
+}
+
+// exportUniqueConstraints exports the constraints of the instance and the global constraints of its domains
+func exportUniqueConstraints(ctx context.Context, tx *sql.Tx, instanceID string, domains []string, s *section) error {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT instance_id, unique_type, unique_field FROM eventstore.unique_constraints WHERE instance_id = $1 OR (instance_id = '' AND unique_type = $2 AND LOWER(unique_field) = ANY($3))",
+		instanceID,
+		instance.UniqueInstanceDomain,
+		domains,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Lg7dk", "unable to query unique constraints")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			constraintInstanceID string
+			constraint           uniqueConstraint
+		)
+		if err = rows.Scan(&constraintInstanceID, &constraint.Type, &constraint.Field); err != nil {
+			return zerrors.ThrowInternal(err, "INSTA-Ib5sg", "unable to scan unique constraint")
+		}
+		constraint.Global = constraintInstanceID == ""
+		if err = s.write(&constraint); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Rv8nw", "unable to query unique constraints")
+	}
+	return nil
+}
+
+func exportAssets(ctx context.Context, tx *sql.Tx, instanceID string, s *section) error {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT asset_type, resource_owner, name, content_type, data, updated_at FROM system.assets WHERE instance_id = $1",
+		instanceID,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Sd4jq", "unable to query assets")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			a                      asset
+			assetType, contentType sql.NullString
+			updatedAt              sql.NullTime
+		)
+		if err = rows.Scan(&assetType, &a.ResourceOwner, &a.Name, &contentType, &a.Data, &updatedAt); err != nil {
+			return zerrors.ThrowInternal(err, "INSTA-Pf1hy", "unable to scan asset")
+		}
+		a.AssetType, a.ContentType, a.UpdatedAt = assetType.String, contentType.String, updatedAt.Time
+		if err = s.write(&a); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Uk6ca", "unable to query assets")
+	}
+	return nil
+}
+
+func exportPersonalDataKeys(ctx context.Context, tx *sql.Tx, instanceID string, reencrypt *reencrypter, s *section) error {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT key_id, subject_id, key, created_at FROM eventstore.personal_data_keys WHERE instance_id = $1 ORDER BY subject_id",
+		instanceID,
+	)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Vb3pq", "unable to query personal data keys")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			k   personalDataKey
+			key []byte
+		)
+		if err = rows.Scan(&k.KeyID, &k.SubjectID, &key, &k.CreatedAt); err != nil {
+			return zerrors.ThrowInternal(err, "INSTA-Nq6zt", "unable to scan personal data key")
+		}
+		if k.Key, err = reencrypt.payload(key); err != nil {
+			return err
+		}
+		if err = s.write(&k); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Hs9ik", "unable to query personal data keys")
+	}
+	return nil
+}
+
+func keyChecksum(key, exportKey string) string {
+	mac := hmac.New(sha256.New, []byte(exportKey))
+	mac.Write([]byte(key))
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,232 @@
+package instance
+
+import (
+	"context"
+	"crypto/hmac"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/backend/v3/instrumentation/logging"
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	flagIn         = "in"
+	flagInstanceID = "instance-id"
+	flagDomain     = "domain"
+
+	// importBatchSize is the amount of rows sent to the database at once
+	importBatchSize = 1000
+)
+
+func importCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "imports an instance from an archive",
+		Long: `imports an instance from an archive created by the export command
+The instance is imported in a single transaction and must not exist in the database.
+The instance can be imported under a new id and generated domain.
+The encrypted values of the archive are re-encrypted with the encryption keys of the deployment, which must contain keys with the same ids as the exporting deployment.
+The projections of the instance are computed as soon as the instance is requested.
+Requirements:
+- postgreSQL`,
+		Example: `import --in instance.tar.zst --export-key-file export.key
+import --in instance.tar.zst --export-key-file export.key --instance-id 69629023906488335 --domain acme.eu1.zitadel.cloud`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				logging.OnError(cmd.Context(), err).Error("zitadel instance import command failed")
+			}()
+			config := new(Config)
+			if err = viper.Unmarshal(config); err != nil {
+				return err
+			}
+			masterKey, err := key.MasterKey(cmd)
+			if err != nil {
+				return err
+			}
+			exportKey, err := readExportKey(cmd)
+			if err != nil {
+				return err
+			}
+			path, _ := cmd.Flags().GetString(flagIn)
+			instanceID, _ := cmd.Flags().GetString(flagInstanceID)
+			domain, _ := cmd.Flags().GetString(flagDomain)
+
+			client, err := database.Connect(config.Database, false)
+			if err != nil {
+				return err
+			}
+			defer client.Close()
+
+			in, err := os.Open(path)
+			if err != nil {
+				return err
+			}
+			defer in.Close()
+
+			manifest, err := importInstance(cmd.Context(), client, masterKey, exportKey, in, instanceID, domain)
+			if err != nil {
+				return err
+			}
+			logging.Info(cmd.Context(), "instance imported",
+				"exported_instance", manifest.InstanceID,
+				"instance", instanceIDOrDefault(instanceID, manifest),
+				"events", manifest.Events,
+				"unique_constraints", manifest.UniqueConstraints,
+				"assets", manifest.Assets,
+				"personal_data_keys", manifest.PersonalDataKeys,
+			)
+			return nil
+		},
+	}
+	cmd.Flags().String(flagIn, "", "path of the archive")
+	cmd.Flags().String(flagInstanceID, "", "new id of the instance, the id of the exported instance is used if empty")
+	cmd.Flags().String(flagDomain, "", "new generated domain of the instance, replaces the generated domain of the exported instance and its subdomains")
+	_ = cmd.MarkFlagRequired(flagIn)
+	return cmd
+}
+
+func instanceIDOrDefault(instanceID string, manifest *manifest) string {
+	if instanceID != "" {
+		return instanceID
+	}
+	return manifest.InstanceID
+}
+
+func importInstance(ctx context.Context, client *database.DB, masterKey, exportKey string, in io.Reader, instanceID, domain string) (_ *manifest, err error) {
+	archive, err := newArchiveReader(in)
+	if err != nil {
+		return nil, err
+	}
+	defer archive.close()
+
+	manifest, err := archive.manifest()
+	if err != nil {
+		return nil, err
+	}
+	if !hmac.Equal([]byte(keyChecksum(manifest.InstanceID, exportKey)), []byte(manifest.ExportKeyChecksum)) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "INSTA-Ov6ep", "unable to decrypt the archive, check the export key")
+	}
+	keys, err := deploymentKeys(client, masterKey)
+	if err != nil {
+		return nil, err
+	}
+	reencrypt := newImportReencrypter(keys, exportKey)
+	instanceID = instanceIDOrDefault(instanceID, manifest)
+	rewrite := newRewriter(manifest.InstanceID, instanceID, manifest.GeneratedDomain, domain)
+
+	tx, err := client.Pool.Begin(ctx)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Jt4ql", "unable to begin transaction")
+	}
+	defer func() {
+		if err != nil {
+			err = errors.Join(err, tx.Rollback(ctx))
+			return
+		}
+		err = tx.Commit(ctx)
+	}()
+
+	var exists bool
+	if err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM eventstore.events2 WHERE instance_id = $1)", instanceID).Scan(&exists); err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Gy2bd", "unable to check instance")
+	}
+	if exists {
+		return nil, zerrors.ThrowAlreadyExistsf(nil, "INSTA-Ko7xm", "instance %s already exists", instanceID)
+	}
+
+	batch := &importBatch{tx: tx}
+	var count uint64
+	if count, err = readSection(archive, eventsFile, func(e *event) error {
+		payload, err := rewrite.payload(e.Payload)
+		if err != nil {
+			return err
+		}
+		if payload, err = reencrypt.payload(payload); err != nil {
+			return err
+		}
+		// events of the import share the position of the transaction, like events pushed together
+		return batch.queue(ctx,
+			"INSERT INTO eventstore.events2 (instance_id, aggregate_type, aggregate_id, event_type, sequence, revision, created_at, payload, creator, owner, position, in_tx_order) "+
+				"VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, EXTRACT(EPOCH FROM NOW()), $11)",
+			instanceID, e.AggregateType, rewrite.string(e.AggregateID), e.EventType, e.Sequence, e.Revision, e.CreatedAt, payload, rewrite.string(e.Creator), rewrite.string(e.Owner), batch.count,
+		)
+	}); err != nil {
+		return nil, err
+	}
+	if count != manifest.Events {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "INSTA-Rd8oj", "archive contains %d events but the manifest %d", count, manifest.Events)
+	}
+	if _, err = readSection(archive, uniqueConstraintsFile, func(constraint *uniqueConstraint) error {
+		constraintInstanceID := instanceID
+		if constraint.Global {
+			constraintInstanceID = ""
+		}
+		return batch.queue(ctx,
+			"INSERT INTO eventstore.unique_constraints (instance_id, unique_type, unique_field) VALUES ($1, $2, $3)",
+			constraintInstanceID, constraint.Type, rewrite.string(constraint.Field),
+		)
+	}); err != nil {
+		return nil, err
+	}
+	if _, err = readSection(archive, assetsFile, func(a *asset) error {
+		return batch.queue(ctx,
+			"INSERT INTO system.assets (instance_id, asset_type, resource_owner, name, content_type, data, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+			instanceID, a.AssetType, rewrite.string(a.ResourceOwner), rewrite.string(a.Name), a.ContentType, a.Data, a.UpdatedAt,
+		)
+	}); err != nil {
+		return nil, err
+	}
+	if _, err = readSection(archive, personalDataKeysFile, func(k *personalDataKey) error {
+		key, err := reencrypt.payload(k.Key)
+		if err != nil {
+			return err
+		}
+		return batch.queue(ctx,
+			"INSERT INTO eventstore.personal_data_keys (instance_id, key_id, subject_id, key, created_at) VALUES ($1, $2, $3, $4, $5)",
+			instanceID, k.KeyID, rewrite.string(k.SubjectID), key, k.CreatedAt,
+		)
+	}); err != nil {
+		return nil, err
+	}
+	if err = batch.flush(ctx); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// importBatch sends the queued statements in batches
+type importBatch struct {
+	tx    pgx.Tx
+	batch pgx.Batch
+	// count is the amount of statements queued, it's used as in_tx_order of the events
+	count int
+}
+
+func (b *importBatch) queue(ctx context.Context, stmt string, args ...any) error {
+	b.batch.Queue(stmt, args...)
+	b.count++
+	if b.batch.Len() < importBatchSize {
+		return nil
+	}
+	return b.flush(ctx)
+}
+
+func (b *importBatch) flush(ctx context.Context) error {
+	if b.batch.Len() == 0 {
+		return nil
+	}
+	err := b.tx.SendBatch(ctx, &b.batch).Close()
+	b.batch = pgx.Batch{}
+	if err != nil {
+		return zerrors.ThrowInternal(err, "INSTA-Wa3ni", "unable to import")
+	}
+	return nil
+}
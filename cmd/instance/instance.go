@@ -0,0 +1,60 @@
+package instance
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/zitadel/zitadel/cmd/key"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	flagExportKeyFile = "export-key-file"
+	flagExportKeyEnv  = "export-key-from-env"
+	envExportKey      = "ZITADEL_EXPORTKEY"
+)
+
+type Config struct {
+	Database database.Config
+}
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "instance",
+		Short: "exports and imports single instances",
+		Long: `exports and imports single instances
+An instance is exported to a zstd compressed tar archive, which can be imported into another ZITADEL deployment.`,
+	}
+	key.AddMasterKeyFlag(cmd)
+	cmd.PersistentFlags().String(flagExportKeyFile, "", "path to the export key, which en/decrypts the encrypted values in the archive")
+	cmd.PersistentFlags().Bool(flagExportKeyEnv, false, "read the export key from environment variable ("+envExportKey+")")
+	cmd.AddCommand(
+		exportCmd(),
+		importCmd(),
+	)
+	return cmd
+}
+
+// readExportKey returns the key, which encrypts the encrypted values in the archive.
+// Like the masterkey, it must be 32 bytes long.
+func readExportKey(cmd *cobra.Command) (string, error) {
+	path, _ := cmd.Flags().GetString(flagExportKeyFile)
+	fromEnv, _ := cmd.Flags().GetBool(flagExportKeyEnv)
+	if (path == "") == !fromEnv {
+		return "", zerrors.ThrowInvalidArgument(nil, "INSTA-Nq3fz", "export key must either be provided by file path or environment variable")
+	}
+	exportKey := os.Getenv(envExportKey)
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", zerrors.ThrowInvalidArgument(err, "INSTA-Ah5ow", "unable to read export key")
+		}
+		exportKey = string(data)
+	}
+	if length := len(exportKey); length != 32 {
+		return "", zerrors.ThrowInvalidArgumentf(nil, "INSTA-Bw8vx", "export key must be 32 bytes, but is %d", length)
+	}
+	return exportKey, nil
+}
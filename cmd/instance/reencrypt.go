@@ -0,0 +1,145 @@
+package instance
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// reencrypter re-encrypts the crypto values in the event payloads.
+// The encryption keys are deployment wide and have the same ids in every deployment,
+// so instead of the keys, the values are moved:
+// on export they are encrypted with the export key, on import with the key of the target deployment.
+// The key id of the values is kept, because it defines the purpose of the key (e.g. the key of the user secrets).
+type reencrypter struct {
+	decryptionKey func(keyID string) (string, error)
+	encryptionKey func(keyID string) (string, error)
+}
+
+// newExportReencrypter encrypts the values of the deployment keys with the export key
+func newExportReencrypter(keys crypto.Keys, exportKey string) *reencrypter {
+	return &reencrypter{
+		decryptionKey: deploymentKey(keys),
+		encryptionKey: func(string) (string, error) { return exportKey, nil },
+	}
+}
+
+// newImportReencrypter encrypts the values of the export key with the deployment keys
+func newImportReencrypter(keys crypto.Keys, exportKey string) *reencrypter {
+	return &reencrypter{
+		decryptionKey: func(string) (string, error) { return exportKey, nil },
+		encryptionKey: deploymentKey(keys),
+	}
+}
+
+// deploymentKeys reads the encryption keys of the deployment
+func deploymentKeys(client *database.DB, masterKey string) (crypto.Keys, error) {
+	storage, err := cryptoDB.NewKeyStorage(client, masterKey)
+	if err != nil {
+		return nil, err
+	}
+	return storage.ReadKeys()
+}
+
+func deploymentKey(keys crypto.Keys) func(keyID string) (string, error) {
+	return func(keyID string) (string, error) {
+		key, ok := keys[keyID]
+		if !ok {
+			return "", zerrors.ThrowPreconditionFailedf(nil, "INSTA-Tw5pn", "encryption key %s does not exist in the deployment", keyID)
+		}
+		return key, nil
+	}
+}
+
+// payload re-encrypts the crypto values of the JSON payload,
+// the payload is returned unchanged if it doesn't contain any.
+func (r *reencrypter) payload(payload json.RawMessage) (json.RawMessage, error) {
+	if len(payload) == 0 || !bytes.Contains(payload, []byte(`"Crypted"`)) {
+		return payload, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	// numbers must not loose precision
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "INSTA-Jc3dk", "unable to read payload")
+	}
+	changed, err := r.value(value)
+	if err != nil {
+		return nil, err
+	}
+	if !changed {
+		return payload, nil
+	}
+	reencrypted, err := json.Marshal(value)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Pu8ze", "unable to write payload")
+	}
+	return reencrypted, nil
+}
+
+func (r *reencrypter) value(value any) (changed bool, err error) {
+	switch v := value.(type) {
+	case []any:
+		for _, item := range v {
+			itemChanged, err := r.value(item)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || itemChanged
+		}
+	case map[string]any:
+		if isEncryptedCryptoValue(v) {
+			return true, r.cryptoValue(v)
+		}
+		for _, item := range v {
+			itemChanged, err := r.value(item)
+			if err != nil {
+				return false, err
+			}
+			changed = changed || itemChanged
+		}
+	}
+	return changed, nil
+}
+
+// isEncryptedCryptoValue returns if the object is a [crypto.CryptoValue] encrypted with AES
+func isEncryptedCryptoValue(object map[string]any) bool {
+	if len(object) != 4 {
+		return false
+	}
+	cryptoType, _ := object["CryptoType"].(json.Number)
+	algorithm, _ := object["Algorithm"].(string)
+	_, hasKeyID := object["KeyID"].(string)
+	_, hasCrypted := object["Crypted"].(string)
+	return cryptoType.String() == "0" && algorithm == "aes" && hasKeyID && hasCrypted
+}
+
+func (r *reencrypter) cryptoValue(object map[string]any) error {
+	keyID := object["KeyID"].(string)
+	crypted, err := base64.StdEncoding.DecodeString(object["Crypted"].(string))
+	if err != nil {
+		return zerrors.ThrowInvalidArgumentf(err, "INSTA-Bq7ys", "unable to read value encrypted with key %s", keyID)
+	}
+	key, err := r.decryptionKey(keyID)
+	if err != nil {
+		return err
+	}
+	plain, err := crypto.DecryptAES(crypted, key)
+	if err != nil {
+		return zerrors.ThrowInvalidArgumentf(err, "INSTA-Xe2lo", "unable to decrypt value encrypted with key %s", keyID)
+	}
+	if key, err = r.encryptionKey(keyID); err != nil {
+		return err
+	}
+	if crypted, err = crypto.EncryptAES(plain, key); err != nil {
+		return zerrors.ThrowInternalf(err, "INSTA-Mi4gv", "unable to encrypt value with key %s", keyID)
+	}
+	object["Crypted"] = base64.StdEncoding.EncodeToString(crypted)
+	return nil
+}
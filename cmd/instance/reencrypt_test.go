@@ -0,0 +1,67 @@
+package instance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_reencrypter_payload(t *testing.T) {
+	const (
+		exportKey = "01234567890123456789012345678901"
+		sourceKey = "abcdefghijklmnopqrstuvwxyz012345"
+		targetKey = "543210zyxwvutsrqponmlkjihgfedcba"
+	)
+	crypted, err := crypto.EncryptAES([]byte("secret"), sourceKey)
+	require.NoError(t, err)
+	payload, err := json.Marshal(map[string]any{
+		"clientId": "client",
+		"clientSecret": &crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "aes",
+			KeyID:      "idpConfigKey",
+			Crypted:    crypted,
+		},
+		"hash": &crypto.CryptoValue{
+			CryptoType: crypto.TypeHash,
+			Algorithm:  "bcrypt",
+			Crypted:    []byte("hash"),
+		},
+	})
+	require.NoError(t, err)
+
+	exported, err := newExportReencrypter(crypto.Keys{"idpConfigKey": sourceKey}, exportKey).payload(payload)
+	require.NoError(t, err)
+	imported, err := newImportReencrypter(crypto.Keys{"idpConfigKey": targetKey}, exportKey).payload(exported)
+	require.NoError(t, err)
+
+	for key, value := range map[string][]byte{exportKey: exported, targetKey: imported} {
+		var got struct {
+			ClientID     string              `json:"clientId"`
+			ClientSecret *crypto.CryptoValue `json:"clientSecret"`
+			Hash         *crypto.CryptoValue `json:"hash"`
+		}
+		require.NoError(t, json.Unmarshal(value, &got))
+		assert.Equal(t, "client", got.ClientID)
+		assert.Equal(t, "idpConfigKey", got.ClientSecret.KeyID)
+		plain, err := crypto.DecryptAES(got.ClientSecret.Crypted, key)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", string(plain))
+		assert.Equal(t, []byte("hash"), got.Hash.Crypted)
+	}
+
+	_, err = newImportReencrypter(crypto.Keys{}, exportKey).payload(exported)
+	assert.True(t, zerrors.IsPreconditionFailed(err))
+}
+
+func Test_reencrypter_payload_unchanged(t *testing.T) {
+	payload := json.RawMessage(`{"userName":"user","age":12345678901234567890}`)
+	got, err := newExportReencrypter(crypto.Keys{}, "01234567890123456789012345678901").payload(payload)
+	require.NoError(t, err)
+	assert.Equal(t, payload, got)
+}
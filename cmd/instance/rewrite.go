@@ -0,0 +1,82 @@
+package instance
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// rewriter moves the imported data to a new instance id and generated domain.
+// The instance id is replaced wherever it occurs, because it's used as id of the instance level aggregates
+// and as part of composed ids.
+// The domain is replaced including its subdomains, e.g. the domain of the default organization.
+type rewriter struct {
+	oldID, newID string
+	domain       *regexp.Regexp
+	newDomain    string
+}
+
+func newRewriter(oldID, newID, oldDomain, newDomain string) *rewriter {
+	r := &rewriter{
+		oldID: oldID,
+		newID: newID,
+	}
+	if newDomain != "" && oldDomain != "" && !strings.EqualFold(oldDomain, newDomain) {
+		// the domain must be a whole host, e.g. it matches in urls, email addresses and as parent domain
+		r.domain = regexp.MustCompile(`(?i)(^|[./@])` + regexp.QuoteMeta(oldDomain) + `($|[:/?#])`)
+		r.newDomain = strings.ToLower(newDomain)
+	}
+	return r
+}
+
+func (r *rewriter) isNoop() bool {
+	return r.oldID == r.newID && r.domain == nil
+}
+
+func (r *rewriter) string(s string) string {
+	if r.oldID != r.newID {
+		s = strings.ReplaceAll(s, r.oldID, r.newID)
+	}
+	if r.domain != nil {
+		s = r.domain.ReplaceAllString(s, "${1}"+r.newDomain+"${2}")
+	}
+	return s
+}
+
+// payload replaces the strings of the JSON payload
+func (r *rewriter) payload(payload json.RawMessage) (json.RawMessage, error) {
+	if r.isNoop() || len(payload) == 0 {
+		return payload, nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	// numbers must not loose precision
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "INSTA-Xb4qa", "unable to read payload")
+	}
+	rewritten, err := json.Marshal(r.value(value))
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "INSTA-Ny2cs", "unable to write payload")
+	}
+	return rewritten, nil
+}
+
+func (r *rewriter) value(value any) any {
+	switch v := value.(type) {
+	case string:
+		return r.string(v)
+	case []any:
+		for i := range v {
+			v[i] = r.value(v[i])
+		}
+	case map[string]any:
+		for key := range v {
+			v[key] = r.value(v[key])
+		}
+	}
+	return value
+}
@@ -0,0 +1,117 @@
+package instance
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_rewriter_string(t *testing.T) {
+	type args struct {
+		oldID, newID, oldDomain, newDomain string
+		s                                  string
+	}
+	tests := []struct {
+		name string
+		args args
+		want string
+	}{
+		{
+			name: "noop",
+			args: args{
+				oldID: "instance", newID: "instance", oldDomain: "acme.zitadel.cloud",
+				s: "instance@acme.zitadel.cloud",
+			},
+			want: "instance@acme.zitadel.cloud",
+		},
+		{
+			name: "instance id",
+			args: args{
+				oldID: "123", newID: "456",
+				s: "123:default",
+			},
+			want: "456:default",
+		},
+		{
+			name: "domain",
+			args: args{
+				oldID: "123", newID: "123", oldDomain: "acme.zitadel.cloud", newDomain: "Other.Zitadel.Cloud",
+				s: "acme.zitadel.cloud",
+			},
+			want: "other.zitadel.cloud",
+		},
+		{
+			name: "subdomain, url and email",
+			args: args{
+				oldID: "123", newID: "123", oldDomain: "acme.zitadel.cloud", newDomain: "other.zitadel.cloud",
+				s: "https://login.ACME.zitadel.cloud:443/ui zitadel-admin@acme.zitadel.cloud",
+			},
+			want: "https://login.other.zitadel.cloud:443/ui zitadel-admin@other.zitadel.cloud",
+		},
+		{
+			name: "email",
+			args: args{
+				oldID: "123", newID: "123", oldDomain: "acme.zitadel.cloud", newDomain: "other.zitadel.cloud",
+				s: "zitadel-admin@acme.zitadel.cloud",
+			},
+			want: "zitadel-admin@other.zitadel.cloud",
+		},
+		{
+			name: "domain as part of other host",
+			args: args{
+				oldID: "123", newID: "123", oldDomain: "acme.zitadel.cloud", newDomain: "other.zitadel.cloud",
+				s: "acme.zitadel.cloud.example.com",
+			},
+			want: "acme.zitadel.cloud.example.com",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRewriter(tt.args.oldID, tt.args.newID, tt.args.oldDomain, tt.args.newDomain)
+			assert.Equal(t, tt.want, r.string(tt.args.s))
+		})
+	}
+}
+
+func Test_rewriter_payload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload json.RawMessage
+		want    json.RawMessage
+		wantErr bool
+	}{
+		{
+			name:    "empty",
+			payload: nil,
+			want:    nil,
+		},
+		{
+			name:    "nested",
+			payload: json.RawMessage(`{"domain":"acme.zitadel.cloud","ids":["123","other"],"nested":{"id":"123"},"number":1234567890123456789}`),
+			want:    json.RawMessage(`{"domain":"other.zitadel.cloud","ids":["456","other"],"nested":{"id":"456"},"number":1234567890123456789}`),
+		},
+		{
+			name:    "invalid",
+			payload: json.RawMessage(`{`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newRewriter("123", "456", "acme.zitadel.cloud", "other.zitadel.cloud")
+			got, err := r.payload(tt.payload)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			if tt.want == nil {
+				assert.Nil(t, got)
+				return
+			}
+			assert.JSONEq(t, string(tt.want), string(got))
+		})
+	}
+}
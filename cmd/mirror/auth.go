@@ -53,15 +53,18 @@ func copyAuth(ctx context.Context, config *Migration) {
 	logging.OnError(ctx, err).Fatal("unable to connect to destination database")
 	defer destClient.Close()
 
+	createAuthRequestsIndex(ctx, sourceClient)
 	copyAuthRequests(ctx, sourceClient, destClient, config.MaxAuthRequestAge)
 }
 
-func copyAuthRequests(ctx context.Context, source, dest *database.DB, maxAuthRequestAge time.Duration) {
-	start := time.Now()
-
+func createAuthRequestsIndex(ctx context.Context, source *database.DB) {
 	logging.Info(ctx, "creating index on auth.auth_requests.change_date to speed up copy in source database")
 	_, err := source.ExecContext(ctx, "CREATE INDEX CONCURRENTLY IF NOT EXISTS auth_requests_change_date ON auth.auth_requests (change_date)")
 	logging.OnError(ctx, err).Fatal("unable to create index on auth.auth_requests.change_date")
+}
+
+func copyAuthRequests(ctx context.Context, source, dest *database.DB, maxAuthRequestAge time.Duration) {
+	start := time.Now()
 
 	sourceConn, err := source.Conn(ctx)
 	logging.OnError(ctx, err).Fatal("unable to acquire connection")
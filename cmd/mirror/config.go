@@ -29,6 +29,7 @@ type Migration struct {
 
 	EventBulkSize     uint32
 	MaxAuthRequestAge time.Duration
+	FollowInterval    time.Duration
 
 	Log             *old_logging.Config
 	Machine         *id.Config
@@ -0,0 +1,146 @@
+package mirror
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/zitadel/zitadel/backend/v3/instrumentation/logging"
+	cryptoDatabase "github.com/zitadel/zitadel/internal/crypto/database"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/v2/eventstore/postgres"
+	"github.com/zitadel/zitadel/internal/v2/readmodel"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// verifiedTables must have the same amount of entries after the cutover,
+// auth requests are not verified because only the recent ones are mirrored.
+var verifiedTables = []string{
+	"eventstore.events2",
+	"eventstore.unique_constraints",
+	"eventstore.personal_data_keys",
+	"system.assets",
+	cryptoDatabase.EncryptionKeysTable,
+}
+
+func cutoverCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "cutover",
+		Short: "blocks writes to the source, mirrors the remaining data and verifies the destination",
+		Long: `blocks writes to the source, mirrors the remaining data and verifies the destination
+Finishes a migration started with "mirror eventstore --follow", the follow command must be stopped before.
+The mirrored tables of the source are locked, reads are still possible but writes wait until the command is stopped.
+After the remaining events are mirrored, the unique constraints, assets, encryption keys and auth requests are replaced
+and the amount of entries is verified.
+As soon as the cutover is done, switch ZITADEL to the destination database and stop the command afterwards.
+Requirements:
+- the source database is postgres`,
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			defer func() {
+				logging.OnError(cmd.Context(), err).Error("zitadel mirror cutover command failed")
+			}()
+			config, shutdown, err := newMigrationConfig(cmd, viper.GetViper())
+			if err != nil {
+				return err
+			}
+			defer func() {
+				err = errors.Join(err, shutdown(cmd.Context()))
+			}()
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			return cutover(ctx, config)
+		},
+	}
+}
+
+func cutover(ctx context.Context, config *Migration) error {
+	sourceClient, err := database.Connect(config.Source, false)
+	logging.OnError(ctx, err).Fatal("unable to connect to source database")
+	defer sourceClient.Close()
+
+	destClient, err := database.Connect(config.Destination, false)
+	logging.OnError(ctx, err).Fatal("unable to connect to destination database")
+	defer destClient.Close()
+
+	destinationES := eventstore.NewEventstoreFromOne(postgres.New(destClient, &postgres.Config{
+		MaxRetries: 3,
+	}))
+	previousMigration, err := queryLastSuccessfulMigration(ctx, destinationES, sourceClient.DatabaseName())
+	if err != nil {
+		return zerrors.ThrowInternal(err, "MIGRA-Wd3nb", "unable to query latest successful migration")
+	}
+	if err = checkCutover(sourceClient.Type(), previousMigration); err != nil {
+		return err
+	}
+	// the index must exist before the lock is acquired, creating it would wait for the lock
+	createAuthRequestsIndex(ctx, sourceClient)
+
+	start := time.Now()
+	logging.Info(ctx, "blocking writes to source, waiting for running transactions")
+	lockConn, err := sourceClient.Conn(ctx)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "MIGRA-Ah6bt", "unable to acquire source connection")
+	}
+	defer lockConn.Close()
+	// the transaction must not be rolled back if the command is stopped during the copy
+	lock, err := lockConn.BeginTx(context.WithoutCancel(ctx), nil)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "MIGRA-Jq2rl", "unable to begin lock transaction")
+	}
+	// the lock is released as soon as the transaction ends
+	defer func() {
+		logging.OnError(ctx, lock.Rollback()).Error("unable to release lock of source")
+	}()
+	// exclusive mode still allows reads
+	if _, err = lock.ExecContext(ctx, "LOCK TABLE "+strings.Join(append(verifiedTables, "auth.auth_requests"), ", ")+" IN EXCLUSIVE MODE"); err != nil {
+		return zerrors.ThrowInternal(err, "MIGRA-Xo3wd", "unable to block writes to source")
+	}
+	logging.Info(ctx, "writes to source blocked", "took", time.Since(start))
+
+	// the source is consistent from now on and the data are copied without interruption
+	copyCtx := context.WithoutCancel(ctx)
+	shouldFollow = false
+	shouldReplace = true
+	copyEvents(copyCtx, sourceClient, destClient, config.EventBulkSize)
+	copyUniqueConstraints(copyCtx, sourceClient, destClient)
+	copyPersonalDataKeys(copyCtx, sourceClient, destClient)
+	copyAssets(copyCtx, sourceClient, destClient)
+	copyEncryptionKeys(copyCtx, sourceClient, destClient)
+	copyAuthRequests(copyCtx, sourceClient, destClient, config.MaxAuthRequestAge)
+
+	var unequal []string
+	for _, table := range verifiedTables {
+		if !verifyTable(copyCtx, sourceClient, destClient, table) {
+			unequal = append(unequal, table)
+		}
+	}
+	if len(unequal) > 0 {
+		return zerrors.ThrowInternalf(nil, "MIGRA-Ms8ew", "verification of %s failed, writes to source are unblocked", strings.Join(unequal, ", "))
+	}
+
+	logging.Info(ctx, "cutover done, switch ZITADEL to the destination database and stop the command to unblock writes to the source", "took", time.Since(start))
+	<-ctx.Done()
+	return nil
+}
+
+// checkCutover verifies that the source is postgres, the only database where writes can be blocked,
+// and that the eventstore was mirrored before so that only the remaining data are copied while writes are blocked.
+func checkCutover(sourceType dialect.DatabaseType, previousMigration *readmodel.LastSuccessfulMirror) error {
+	if sourceType != dialect.DatabaseTypePostgres {
+		return zerrors.ThrowPreconditionFailed(nil, "MIGRA-Ptk4v", "cutover requires a postgres source, stop ZITADEL and mirror the remaining data instead")
+	}
+	if previousMigration == nil || previousMigration.Position.IsZero() {
+		return zerrors.ThrowPreconditionFailed(nil, "MIGRA-Ue8sk", "cutover requires a previous migration, execute \"mirror eventstore --follow\" before")
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package mirror
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/database/dialect"
+	"github.com/zitadel/zitadel/internal/v2/readmodel"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_checkCutover(t *testing.T) {
+	mirrored := readmodel.NewLastSuccessfulMirror("source")
+	mirrored.Position = decimal.NewFromInt(42)
+
+	tests := []struct {
+		name              string
+		sourceType        dialect.DatabaseType
+		previousMigration *readmodel.LastSuccessfulMirror
+		wantErr           bool
+	}{
+		{
+			name:              "cockroach source",
+			sourceType:        dialect.DatabaseTypeCockroach,
+			previousMigration: mirrored,
+			wantErr:           true,
+		},
+		{
+			name:              "no previous migration",
+			sourceType:        dialect.DatabaseTypePostgres,
+			previousMigration: readmodel.NewLastSuccessfulMirror("source"),
+			wantErr:           true,
+		},
+		{
+			name:       "previous migration missing",
+			sourceType: dialect.DatabaseTypePostgres,
+			wantErr:    true,
+		},
+		{
+			name:              "ok",
+			sourceType:        dialect.DatabaseTypePostgres,
+			previousMigration: mirrored,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkCutover(tt.sourceType, tt.previousMigration)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsPreconditionFailed(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -6,6 +6,9 @@ import (
 	_ "embed"
 	"errors"
 	"io"
+	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgconn"
@@ -45,13 +48,21 @@ Migrate only copies events2 and unique constraints`,
 			defer func() {
 				err = errors.Join(err, shutdown(cmd.Context()))
 			}()
-			copyEventstore(cmd.Context(), config)
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			copyEventstore(ctx, config)
+			if shouldFollow {
+				followEventstore(ctx, config)
+			}
 			return nil
 		},
 	}
 
 	cmd.Flags().BoolVar(&shouldReplace, "replace", false, "allow delete unique constraints of defined instances before copy")
 	cmd.Flags().BoolVar(&shouldIgnorePrevious, "ignore-previous", false, "ignores previous migrations of the events table")
+	cmd.Flags().BoolVar(&shouldFollow, "follow", false, `keeps mirroring new events, assets, encryption keys and auth requests after the initial copy until the command is stopped.
+The interval is defined by FollowInterval. Use the "cutover" command to finish the migration.`)
 
 	return cmd
 }
@@ -67,6 +78,7 @@ func copyEventstore(ctx context.Context, config *Migration) {
 
 	copyEvents(ctx, sourceClient, destClient, config.EventBulkSize)
 	copyUniqueConstraints(ctx, sourceClient, destClient)
+	copyPersonalDataKeys(ctx, sourceClient, destClient)
 }
 
 func positionQuery(db *db.DB) (string, error) {
@@ -80,6 +92,16 @@ func positionQuery(db *db.DB) (string, error) {
 	}
 }
 
+func maxPositionQuery(source *db.DB) string {
+	query := "SELECT MAX(position) FROM eventstore.events2 " + instanceClause()
+	if !shouldFollow || source.Type() != dialect.DatabaseTypePostgres {
+		return query
+	}
+	// the position of an event is the start of its transaction,
+	// events of open transactions are mirrored in a later round as they would get lost otherwise
+	return query + " AND position < (SELECT EXTRACT(EPOCH FROM COALESCE(MIN(xact_start), NOW())) FROM pg_stat_activity WHERE datname = current_database() AND backend_type = 'client backend' AND pid <> pg_backend_pid())"
+}
+
 func copyEvents(ctx context.Context, source, dest *db.DB, bulkSize uint32) {
 	logging.Info(ctx, "starting to copy events")
 	start := time.Now()
@@ -90,9 +112,11 @@ func copyEvents(ctx context.Context, source, dest *db.DB, bulkSize uint32) {
 
 	sourceConn, err := source.Conn(ctx)
 	logging.OnError(ctx, err).Fatal("unable to acquire source connection")
+	defer sourceConn.Close()
 
 	destConn, err := dest.Conn(ctx)
 	logging.OnError(ctx, err).Fatal("unable to acquire dest connection")
+	defer destConn.Close()
 
 	destinationES := eventstore.NewEventstoreFromOne(postgres.New(dest, &postgres.Config{
 		MaxRetries: 3,
@@ -106,9 +130,13 @@ func copyEvents(ctx context.Context, source, dest *db.DB, bulkSize uint32) {
 		func(row *sql.Row) error {
 			return row.Scan(&maxPosition)
 		},
-		"SELECT MAX(position) FROM eventstore.events2 "+instanceClause(),
+		maxPositionQuery(source),
 	)
 	logging.OnError(ctx, err).Fatal("unable to query max position from source")
+	if !maxPosition.GreaterThan(previousMigration.Position) {
+		logging.Info(ctx, "no new events to migrate", "position", previousMigration.Position)
+		return
+	}
 	logging.Info(ctx, "start event migration", "from", previousMigration.Position, "to", maxPosition)
 
 	nextPos := make(chan bool, 1)
@@ -234,6 +262,7 @@ func copyUniqueConstraints(ctx context.Context, source, dest *db.DB) {
 
 	sourceConn, err := source.Conn(ctx)
 	logging.OnError(ctx, err).Fatal("unable to acquire source connection")
+	defer sourceConn.Close()
 
 	go func() {
 		err := sourceConn.Raw(func(driverConn interface{}) error {
@@ -252,6 +281,7 @@ func copyUniqueConstraints(ctx context.Context, source, dest *db.DB) {
 
 	destConn, err := dest.Conn(ctx)
 	logging.OnError(ctx, err).Fatal("unable to acquire dest connection")
+	defer destConn.Close()
 
 	var eventCount int64
 	err = destConn.Raw(func(driverConn interface{}) error {
@@ -277,3 +307,59 @@ func copyUniqueConstraints(ctx context.Context, source, dest *db.DB) {
 	logging.OnError(ctx, <-errs).Fatal("unable to copy unique constraints from source")
 	logging.Info(ctx, "unique constraints migrated", "took", time.Since(start), "count", eventCount)
 }
+
+// copyPersonalDataKeys copies the keys of the personal data in the events,
+// without them the personal data of the copied events are redacted.
+func copyPersonalDataKeys(ctx context.Context, source, dest *db.DB) {
+	logging.Info(ctx, "starting to copy personal data keys")
+	start := time.Now()
+	reader, writer := io.Pipe()
+	errs := make(chan error, 1)
+
+	sourceConn, err := source.Conn(ctx)
+	logging.OnError(ctx, err).Fatal("unable to acquire source connection")
+	defer sourceConn.Close()
+
+	go func() {
+		err := sourceConn.Raw(func(driverConn interface{}) error {
+			conn := driverConn.(*stdlib.Conn).Conn()
+			var stmt database.Statement
+			stmt.WriteString("COPY (SELECT instance_id, key_id, subject_id, key, created_at FROM eventstore.personal_data_keys ")
+			stmt.WriteString(instanceClause())
+			stmt.WriteString(") TO stdout")
+
+			_, err := conn.PgConn().CopyTo(ctx, writer, stmt.String())
+			writer.Close()
+			return err
+		})
+		errs <- err
+	}()
+
+	destConn, err := dest.Conn(ctx)
+	logging.OnError(ctx, err).Fatal("unable to acquire dest connection")
+	defer destConn.Close()
+
+	var keyCount int64
+	err = destConn.Raw(func(driverConn interface{}) error {
+		conn := driverConn.(*stdlib.Conn).Conn()
+
+		if shouldReplace {
+			var stmt database.Statement
+			stmt.WriteString("DELETE FROM eventstore.personal_data_keys ")
+			stmt.WriteString(instanceClause())
+
+			_, err := conn.Exec(ctx, stmt.String())
+			if err != nil {
+				return err
+			}
+		}
+
+		tag, err := conn.PgConn().CopyFrom(ctx, reader, "COPY eventstore.personal_data_keys (instance_id, key_id, subject_id, key, created_at) FROM stdin")
+		keyCount = tag.RowsAffected()
+
+		return err
+	})
+	logging.OnError(ctx, err).Fatal("unable to copy personal data keys to destination")
+	logging.OnError(ctx, <-errs).Fatal("unable to copy personal data keys from source")
+	logging.Info(ctx, "personal data keys migrated", "took", time.Since(start), "count", keyCount)
+}
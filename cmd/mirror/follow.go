@@ -0,0 +1,221 @@
+package mirror
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5/stdlib"
+	"github.com/shopspring/decimal"
+
+	"github.com/zitadel/zitadel/backend/v3/instrumentation/logging"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/v2/eventstore"
+	"github.com/zitadel/zitadel/internal/v2/eventstore/postgres"
+)
+
+var shouldFollow bool
+
+// deltaOverlap is subtracted from the start of the previous round
+// so that rows updated by transactions running during the previous round are not missed.
+// Mirroring a row twice is safe because deltas are merged into the destination.
+const deltaOverlap = time.Minute
+
+// followEventstore mirrors the changes of the source in rounds until the context is done.
+// Events are mirrored from the position of the last successful migration,
+// assets and auth requests by their change date and encryption keys if they are missing.
+// Removed rows are not mirrored, they are removed by the cutover.
+func followEventstore(ctx context.Context, config *Migration) {
+	sourceClient, err := database.Connect(config.Source, false)
+	logging.OnError(ctx, err).Fatal("unable to connect to source database")
+	defer sourceClient.Close()
+
+	destClient, err := database.Connect(config.Destination, false)
+	logging.OnError(ctx, err).Fatal("unable to connect to destination database")
+	defer destClient.Close()
+
+	destinationES := eventstore.NewEventstoreFromOne(postgres.New(destClient, &postgres.Config{
+		MaxRetries: 3,
+	}))
+
+	// the initial copy is done, following rounds start at its position
+	shouldIgnorePrevious = false
+	createAuthRequestsIndex(ctx, sourceClient)
+
+	logging.Info(ctx, "start following source", "interval", config.FollowInterval)
+	follow(ctx, config.FollowInterval, func(ctx context.Context, since time.Time) time.Time {
+		return followRound(ctx, sourceClient, destClient, destinationES, config, since)
+	})
+	logging.Info(ctx, "stopped following source")
+}
+
+// follow executes a round in each interval until the context is done.
+// The start of a round is passed to the next one, the first round starts at the zero time.
+// A running round is finished even if the context is done.
+func follow(ctx context.Context, interval time.Duration, round func(ctx context.Context, since time.Time) time.Time) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var since time.Time
+	for {
+		since = round(context.WithoutCancel(ctx), since)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// followRound mirrors the changes since the previous round and returns the start of this round
+func followRound(ctx context.Context, source, dest *database.DB, destinationES *eventstore.EventStore, config *Migration, since time.Time) (roundStart time.Time) {
+	start := time.Now()
+	err := source.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(&roundStart)
+		},
+		"SELECT NOW()",
+	)
+	logging.OnError(ctx, err).Fatal("unable to query time of source")
+
+	previousMigration, err := queryLastSuccessfulMigration(ctx, destinationES, source.DatabaseName())
+	logging.OnError(ctx, err).Fatal("unable to query latest successful migration")
+
+	pending, lag := pendingEvents(ctx, source, previousMigration.Position)
+	if pending > 0 {
+		copyEvents(ctx, source, dest, config.EventBulkSize)
+	}
+
+	since, authRequestsSince := deltaSince(since, roundStart, config.MaxAuthRequestAge)
+	assetCount := copyDelta(ctx, source, dest,
+		"SELECT instance_id, asset_type, resource_owner, name, content_type, data, updated_at FROM system.assets "+instanceClause()+" AND updated_at > "+timestampLiteral(since),
+		"system.assets",
+		"instance_id, asset_type, resource_owner, name, content_type, data, updated_at",
+		"ON CONFLICT (instance_id, resource_owner, name) DO UPDATE SET asset_type = EXCLUDED.asset_type, content_type = EXCLUDED.content_type, data = EXCLUDED.data, updated_at = EXCLUDED.updated_at",
+	)
+	keyCount := copyDelta(ctx, source, dest,
+		"SELECT id, key FROM system.encryption_keys",
+		"system.encryption_keys",
+		"id, key",
+		"ON CONFLICT (id) DO NOTHING",
+	)
+	// destroyed keys are removed from the destination by the cutover,
+	// the new key of a reused subject id replaces the destroyed key.
+	personalDataKeyCount := copyDelta(ctx, source, dest,
+		"SELECT instance_id, key_id, subject_id, key, created_at FROM eventstore.personal_data_keys "+instanceClause()+" AND created_at > "+timestampLiteral(since),
+		"eventstore.personal_data_keys",
+		"instance_id, key_id, subject_id, key, created_at",
+		"ON CONFLICT (instance_id, subject_id) DO UPDATE SET key_id = EXCLUDED.key_id, key = EXCLUDED.key, created_at = EXCLUDED.created_at",
+	)
+	authRequestCount := copyDelta(ctx, source, dest,
+		"SELECT id, regexp_replace(request::TEXT, '\\\\u0000', '', 'g')::JSON request, code, request_type, creation_date, change_date, instance_id FROM auth.auth_requests "+instanceClause()+" AND change_date > "+timestampLiteral(authRequestsSince),
+		"auth.auth_requests",
+		"id, request, code, request_type, creation_date, change_date, instance_id",
+		"ON CONFLICT (id, instance_id) DO UPDATE SET request = EXCLUDED.request, code = EXCLUDED.code, request_type = EXCLUDED.request_type, change_date = EXCLUDED.change_date",
+	)
+
+	logging.Info(ctx, "follow round done",
+		"took", time.Since(start),
+		"pending_events", pending,
+		"lag", lag,
+		"assets", assetCount,
+		"encryption_keys", keyCount,
+		"personal_data_keys", personalDataKeyCount,
+		"auth_requests", authRequestCount,
+	)
+	return roundStart
+}
+
+// deltaSince returns the time since when rows are mirrored in the round starting at roundStart.
+// The zero time of the first round mirrors all rows, auth requests are limited by their max age.
+func deltaSince(previousStart, roundStart time.Time, maxAuthRequestAge time.Duration) (since, authRequestsSince time.Time) {
+	if !previousStart.IsZero() {
+		since = previousStart.Add(-deltaOverlap)
+	}
+	authRequestsSince = since
+	if oldest := roundStart.Add(-maxAuthRequestAge); authRequestsSince.Before(oldest) {
+		authRequestsSince = oldest
+	}
+	return since, authRequestsSince
+}
+
+// pendingEvents returns the amount of events which are not mirrored yet
+// and the lag, which is the age of the oldest of them.
+func pendingEvents(ctx context.Context, source *database.DB, position decimal.Decimal) (count int64, lag time.Duration) {
+	var oldest sql.NullTime
+	err := source.QueryRowContext(ctx,
+		func(row *sql.Row) error {
+			return row.Scan(&count, &oldest)
+		},
+		"SELECT COUNT(*), MIN(created_at) FROM eventstore.events2 "+instanceClause()+" AND position > $1",
+		position,
+	)
+	logging.OnError(ctx, err).Fatal("unable to query pending events of source")
+	if oldest.Valid {
+		lag = time.Since(oldest.Time)
+	}
+	return count, lag
+}
+
+// copyDelta copies the result of the source query into a temporary table of the destination
+// and merges it into the destination table using the conflict clause.
+// It returns the amount of rows changed in the destination.
+func copyDelta(ctx context.Context, source, dest *database.DB, query, table, columns, onConflict string) int64 {
+	sourceConn, err := source.Conn(ctx)
+	logging.OnError(ctx, err).Fatal("unable to acquire source connection")
+	defer sourceConn.Close()
+
+	r, w := io.Pipe()
+	errs := make(chan error, 1)
+
+	go func() {
+		err := sourceConn.Raw(func(driverConn any) error {
+			conn := driverConn.(*stdlib.Conn).Conn()
+			// COPY does not allow parameters so the query contains the values directly
+			_, err := conn.PgConn().CopyTo(ctx, w, "COPY ("+query+") TO STDOUT")
+			w.Close()
+			return err
+		})
+		errs <- err
+	}()
+
+	destConn, err := dest.Conn(ctx)
+	logging.OnError(ctx, err).Fatal("unable to acquire dest connection")
+	defer destConn.Close()
+
+	var count int64
+	err = destConn.Raw(func(driverConn any) (err error) {
+		conn := driverConn.(*stdlib.Conn).Conn()
+
+		tx, err := conn.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				err = errors.Join(err, tx.Rollback(ctx))
+				return
+			}
+			err = tx.Commit(ctx)
+		}()
+
+		if _, err = tx.Exec(ctx, "CREATE TEMPORARY TABLE mirror_delta (LIKE "+table+") ON COMMIT DROP"); err != nil {
+			return err
+		}
+		if _, err = tx.Conn().PgConn().CopyFrom(ctx, r, "COPY mirror_delta ("+columns+") FROM STDIN"); err != nil {
+			return err
+		}
+		tag, err := tx.Exec(ctx, "INSERT INTO "+table+" ("+columns+") SELECT "+columns+" FROM mirror_delta "+onConflict)
+		count = tag.RowsAffected()
+		return err
+	})
+	logging.OnError(ctx, err).Fatal("unable to mirror delta to destination", "table", table)
+	logging.OnError(ctx, <-errs).Fatal("unable to mirror delta from source", "table", table)
+	return count
+}
+
+func timestampLiteral(t time.Time) string {
+	return "'" + t.UTC().Format(time.RFC3339Nano) + "'::TIMESTAMPTZ"
+}
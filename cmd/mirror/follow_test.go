@@ -0,0 +1,179 @@
+package mirror
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/database/mock"
+)
+
+func Test_deltaSince(t *testing.T) {
+	roundStart := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	type args struct {
+		previousStart     time.Time
+		maxAuthRequestAge time.Duration
+	}
+	tests := []struct {
+		name                  string
+		args                  args
+		wantSince             time.Time
+		wantAuthRequestsSince time.Time
+	}{
+		{
+			name: "first round",
+			args: args{
+				maxAuthRequestAge: time.Hour,
+			},
+			wantSince:             time.Time{},
+			wantAuthRequestsSince: roundStart.Add(-time.Hour),
+		},
+		{
+			name: "following round overlaps previous",
+			args: args{
+				previousStart:     roundStart.Add(-10 * time.Second),
+				maxAuthRequestAge: time.Hour,
+			},
+			wantSince:             roundStart.Add(-10*time.Second - deltaOverlap),
+			wantAuthRequestsSince: roundStart.Add(-10*time.Second - deltaOverlap),
+		},
+		{
+			name: "following round limited by max auth request age",
+			args: args{
+				previousStart:     roundStart.Add(-2 * time.Hour),
+				maxAuthRequestAge: time.Hour,
+			},
+			wantSince:             roundStart.Add(-2*time.Hour - deltaOverlap),
+			wantAuthRequestsSince: roundStart.Add(-time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, authRequestsSince := deltaSince(tt.args.previousStart, roundStart, tt.args.maxAuthRequestAge)
+			assert.Equal(t, tt.wantSince, since)
+			assert.Equal(t, tt.wantAuthRequestsSince, authRequestsSince)
+		})
+	}
+}
+
+func Test_pendingEvents(t *testing.T) {
+	isSystem = false
+	instanceIDs = []string{"instance"}
+	t.Cleanup(func() {
+		instanceIDs = nil
+	})
+	const stmt = "SELECT COUNT(*), MIN(created_at) FROM eventstore.events2 WHERE instance_id IN ('instance') AND position > $1"
+
+	tests := []struct {
+		name      string
+		mock      *mock.SQLMock
+		wantCount int64
+		wantLag   time.Duration
+	}{
+		{
+			name: "up to date",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectQuery(stmt,
+					mock.WithQueryArgs(decimal.NewFromInt(42)),
+					mock.WithQueryResult([]string{"count", "min"}, [][]driver.Value{{int64(0), nil}}),
+				),
+			),
+			wantCount: 0,
+			wantLag:   0,
+		},
+		{
+			name: "pending events",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectQuery(stmt,
+					mock.WithQueryArgs(decimal.NewFromInt(42)),
+					mock.WithQueryResult([]string{"count", "min"}, [][]driver.Value{{int64(3), time.Now().Add(-time.Hour)}}),
+				),
+			),
+			wantCount: 3,
+			wantLag:   time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, lag := pendingEvents(context.Background(), &database.DB{DB: tt.mock.DB}, decimal.NewFromInt(42))
+			tt.mock.Assert(t)
+			assert.Equal(t, tt.wantCount, count)
+			assert.GreaterOrEqual(t, lag, tt.wantLag)
+			assert.Less(t, lag, tt.wantLag+time.Minute)
+		})
+	}
+}
+
+func Test_follow(t *testing.T) {
+	t.Run("rounds pass their start", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		starts := []time.Time{
+			time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC),
+			time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+		}
+		var received []time.Time
+		follow(ctx, time.Millisecond, func(_ context.Context, since time.Time) time.Time {
+			received = append(received, since)
+			if len(received) >= len(starts) {
+				cancel()
+				return starts[len(starts)-1]
+			}
+			return starts[len(received)-1]
+		})
+		// the ticker and the stop can be ready at the same time
+		require.GreaterOrEqual(t, len(received), len(starts))
+		assert.Equal(t, []time.Time{{}, starts[0], starts[1]}, received[:len(starts)])
+	})
+	t.Run("stopped during round, round finished", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		rounds := 0
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			follow(ctx, time.Hour, func(ctx context.Context, _ time.Time) time.Time {
+				rounds++
+				cancel()
+				assert.NoError(t, ctx.Err(), "round must not be canceled")
+				return time.Now()
+			})
+		}()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "follow did not stop")
+		}
+		assert.Equal(t, 1, rounds)
+	})
+	t.Run("stopped between rounds", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		rounds := make(chan struct{}, 1)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			follow(ctx, time.Hour, func(context.Context, time.Time) time.Time {
+				rounds <- struct{}{}
+				return time.Now()
+			})
+		}()
+		<-rounds
+		cancel()
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "follow did not stop")
+		}
+		assert.Empty(t, rounds, "no round must be started after stop")
+	})
+}
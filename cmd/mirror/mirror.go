@@ -35,7 +35,10 @@ Order of execution:
 2. mirror auth tables
 3. mirror event store tables
 4. recompute projections
-5. verify`,
+5. verify
+
+For migrations without downtime, the event store can be followed after the initial copy
+by "mirror eventstore --follow" and switched over by "mirror cutover".`,
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) (err error) {
 			defer func() {
 				logging.OnError(cmd.Context(), err).Error("zitadel mirror (sub)command failed")
@@ -98,6 +101,7 @@ The flag should be provided if you want to execute the mirror command multiple t
 		projectionsCmd(),
 		authCmd(),
 		verifyCmd(),
+		cutoverCmd(),
 	)
 
 	return cmd
@@ -114,10 +118,11 @@ func instanceClause() string {
 	if isSystem {
 		return "WHERE instance_id <> ''"
 	}
-	for i := range instanceIDs {
-		instanceIDs[i] = "'" + instanceIDs[i] + "'"
+	quoted := make([]string, len(instanceIDs))
+	for i, instanceID := range instanceIDs {
+		quoted[i] = "'" + instanceID + "'"
 	}
 
 	// COPY does not allow parameters so we need to set them directly
-	return "WHERE instance_id IN (" + strings.Join(instanceIDs, ", ") + ")"
+	return "WHERE instance_id IN (" + strings.Join(quoted, ", ") + ")"
 }
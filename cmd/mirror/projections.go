@@ -136,10 +136,11 @@ func projections(
 	staticStorage, err := config.AssetStorage.NewStorage(client.DB)
 	logging.OnError(ctx, err).Fatal("unable create static storage")
 
-	newEventstore := new_es.NewEventstore(client)
+	newEventstore := new_es.NewEventstore(client, new_es.WithPersonalDataKeyEncryptionOption(keys.PersonalData))
 	config.Eventstore.Querier = old_es.NewPostgres(client)
 	config.Eventstore.Pusher = newEventstore
 	config.Eventstore.Searcher = newEventstore
+	config.Eventstore.PersonalDataKeyStore = newEventstore
 
 	es := eventstore.NewEventstore(config.Eventstore)
 	esV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(client, &es_v4_pg.Config{
@@ -167,6 +168,7 @@ func projections(
 		keys.Target,
 		keys.SMS,
 		keys.SMTP,
+		keys.RADIUS,
 		config.InternalAuthZ.RolePermissionMappings,
 		sessionTokenVerifier,
 		func(q *query.Queries) domain.PermissionCheck {
@@ -205,6 +207,7 @@ func projections(
 		keys.DomainVerification,
 		keys.SAML,
 		keys.Target,
+		keys.RADIUS,
 		keys.OIDC,
 		&http.Client{},
 		func(ctx context.Context, permission, orgID, resourceID string) (err error) {
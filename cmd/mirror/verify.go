@@ -57,19 +57,25 @@ func verifyMigration(ctx context.Context, config *Migration) {
 
 	for _, schema := range schemas {
 		for _, table := range append(getTables(ctx, destClient, schema), getViews(ctx, destClient, schema)...) {
-			sourceCount := countEntries(ctx, sourceClient, table)
-			destCount := countEntries(ctx, destClient, table)
-
-			logger := logging.FromCtx(ctx).With("table", table, "dest", destCount, "source", sourceCount)
-			if sourceCount == destCount {
-				logger.DebugContext(ctx, "equal count")
-				continue
-			}
-			logger.InfoContext(ctx, "unequal count", "diff", destCount-sourceCount)
+			verifyTable(ctx, sourceClient, destClient, table)
 		}
 	}
 }
 
+// verifyTable returns true if source and dest have the same amount of entries in the table
+func verifyTable(ctx context.Context, source, dest *database.DB, table string) bool {
+	sourceCount := countEntries(ctx, source, table)
+	destCount := countEntries(ctx, dest, table)
+
+	logger := logging.FromCtx(ctx).With("table", table, "dest", destCount, "source", sourceCount)
+	if sourceCount == destCount {
+		logger.DebugContext(ctx, "equal count")
+		return true
+	}
+	logger.InfoContext(ctx, "unequal count", "diff", destCount-sourceCount)
+	return false
+}
+
 func getTables(ctx context.Context, dest *database.DB, schema string) (tables []string) {
 	err := dest.QueryContext(
 		ctx,
@@ -88,6 +88,7 @@ func (mig *FirstInstance) Execute(ctx context.Context, _ eventstore.Event) error
 		nil,
 		nil,
 		nil,
+		nil,
 		oidcEncryption,
 		nil,
 		nil,
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 70.sql
+	addOIDCAppRefreshTokenSettings string
+)
+
+type Apps7OIDCConfigsRefreshTokenSettings struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsRefreshTokenSettings) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addOIDCAppRefreshTokenSettings)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsRefreshTokenSettings) String() string {
+	return "70_apps7_oidc_configs_refresh_token_settings"
+}
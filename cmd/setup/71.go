@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 71.sql
+	addAPIAppResourceURI string
+)
+
+type Apps7APIConfigsResourceURI struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7APIConfigsResourceURI) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addAPIAppResourceURI)
+	return err
+}
+
+func (mig *Apps7APIConfigsResourceURI) String() string {
+	return "71_apps7_api_configs_resource_uri"
+}
@@ -0,0 +1 @@
+ALTER TABLE IF EXISTS projections.apps7_api_configs ADD COLUMN IF NOT EXISTS resource_uri TEXT DEFAULT '';
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 72.sql
+	addAPIAppIntrospectionResponse string
+)
+
+type Apps7APIConfigsIntrospectionResponse struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7APIConfigsIntrospectionResponse) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addAPIAppIntrospectionResponse)
+	return err
+}
+
+func (mig *Apps7APIConfigsIntrospectionResponse) String() string {
+	return "72_apps7_api_configs_introspection_response"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS projections.apps7_api_configs ADD COLUMN IF NOT EXISTS introspection_signed_response BOOLEAN DEFAULT FALSE;
+ALTER TABLE IF EXISTS projections.apps7_api_configs ADD COLUMN IF NOT EXISTS introspection_encryption_key BYTEA;
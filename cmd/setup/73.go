@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 73.sql
+	addOIDCAppEncryptionSettings string
+)
+
+type Apps7OIDCConfigsEncryptionSettings struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsEncryptionSettings) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addOIDCAppEncryptionSettings)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsEncryptionSettings) String() string {
+	return "73_apps7_oidc_configs_encryption_settings"
+}
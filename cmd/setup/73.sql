@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS projections.apps7_oidc_configs ADD COLUMN IF NOT EXISTS id_token_encrypted_response_alg TEXT DEFAULT '';
+ALTER TABLE IF EXISTS projections.apps7_oidc_configs ADD COLUMN IF NOT EXISTS id_token_encrypted_response_enc TEXT DEFAULT '';
+ALTER TABLE IF EXISTS projections.apps7_oidc_configs ADD COLUMN IF NOT EXISTS userinfo_encrypted_response_alg TEXT DEFAULT '';
+ALTER TABLE IF EXISTS projections.apps7_oidc_configs ADD COLUMN IF NOT EXISTS userinfo_encrypted_response_enc TEXT DEFAULT '';
+ALTER TABLE IF EXISTS projections.apps7_oidc_configs ADD COLUMN IF NOT EXISTS jwks TEXT DEFAULT '';
+ALTER TABLE IF EXISTS projections.apps7_oidc_configs ADD COLUMN IF NOT EXISTS jwks_uri TEXT DEFAULT '';
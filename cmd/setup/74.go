@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 74.sql
+	addOIDCAppFrontChannelLogoutURI string
+)
+
+type Apps7OIDCConfigsFrontChannelLogoutURI struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7OIDCConfigsFrontChannelLogoutURI) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addOIDCAppFrontChannelLogoutURI)
+	return err
+}
+
+func (mig *Apps7OIDCConfigsFrontChannelLogoutURI) String() string {
+	return "74_apps7_oidc_configs_front_channel_logout_uri"
+}
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 75.sql
+	addSAMLAppAttributeRelease string
+)
+
+type Apps7SAMLConfigsAttributeRelease struct {
+	dbClient *database.DB
+}
+
+func (mig *Apps7SAMLConfigsAttributeRelease) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, addSAMLAppAttributeRelease)
+	return err
+}
+
+func (mig *Apps7SAMLConfigsAttributeRelease) String() string {
+	return "75_apps7_saml_configs_attribute_release"
+}
@@ -0,0 +1,2 @@
+ALTER TABLE IF EXISTS projections.apps7_saml_configs ADD COLUMN IF NOT EXISTS name_id_source SMALLINT DEFAULT 0;
+ALTER TABLE IF EXISTS projections.apps7_saml_configs ADD COLUMN IF NOT EXISTS attribute_mappings JSONB;
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 76.sql
+	createSnapshotsTable string
+)
+
+type CreateSnapshotsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateSnapshotsTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createSnapshotsTable)
+	return err
+}
+
+func (mig *CreateSnapshotsTable) String() string {
+	return "76_create_snapshots_table"
+}
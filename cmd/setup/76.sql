@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS eventstore.snapshots (
+    instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    , model_type TEXT NOT NULL
+    , model_version TEXT NOT NULL
+    , resource_owner TEXT NOT NULL
+    , "sequence" BIGINT NOT NULL
+    , change_date TIMESTAMPTZ NOT NULL
+    , payload JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+
+    , PRIMARY KEY (instance_id, aggregate_type, aggregate_id, model_type)
+);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 77.sql
+	createArchivedAggregatesTable string
+)
+
+type CreateArchivedAggregatesTable struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateArchivedAggregatesTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createArchivedAggregatesTable)
+	return err
+}
+
+func (mig *CreateArchivedAggregatesTable) String() string {
+	return "77_create_archived_aggregates_table"
+}
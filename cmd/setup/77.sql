@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS eventstore.archived_aggregates (
+    instance_id TEXT NOT NULL
+    , aggregate_type TEXT NOT NULL
+    , aggregate_id TEXT NOT NULL
+    , owner TEXT NOT NULL
+    , event_count INTEGER NOT NULL
+    , first_position NUMERIC NOT NULL
+    , last_position NUMERIC NOT NULL
+    , first_created_at TIMESTAMPTZ NOT NULL
+    , last_created_at TIMESTAMPTZ NOT NULL
+    -- zstd compressed events without the final event, which remains in the events table.
+    -- An aggregate can be archived multiple times if its id is reused
+    , events BYTEA NOT NULL
+    , archived_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- the events are compressed already
+ALTER TABLE eventstore.archived_aggregates ALTER COLUMN events SET STORAGE EXTERNAL;
+
+CREATE INDEX IF NOT EXISTS archived_aggregates_aggregate_idx ON eventstore.archived_aggregates (instance_id, aggregate_type, aggregate_id);
+CREATE INDEX IF NOT EXISTS archived_aggregates_position_idx ON eventstore.archived_aggregates (instance_id, last_position);
@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 78.sql
+	createPersonalDataKeysTable string
+)
+
+type CreatePersonalDataKeysTable struct {
+	dbClient *database.DB
+}
+
+func (mig *CreatePersonalDataKeysTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createPersonalDataKeysTable)
+	return err
+}
+
+func (mig *CreatePersonalDataKeysTable) String() string {
+	return "78_create_personal_data_keys_table"
+}
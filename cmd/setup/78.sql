@@ -0,0 +1,14 @@
+-- the keys encrypting the personal data in the events of a subject,
+-- the personal data are unreadable as soon as the key of the subject is deleted.
+-- The encrypted values reference the key_id, so a new key of a reused subject_id can't decrypt the events of the removed subject.
+-- The key is a crypto value encrypted with the personal data encryption key of the deployment.
+CREATE TABLE IF NOT EXISTS eventstore.personal_data_keys (
+    instance_id TEXT NOT NULL
+    , key_id TEXT NOT NULL
+    , subject_id TEXT NOT NULL
+    , key JSONB NOT NULL
+    , created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+
+    , PRIMARY KEY (instance_id, key_id)
+    , UNIQUE (instance_id, subject_id)
+);
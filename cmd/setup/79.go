@@ -0,0 +1,27 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+var (
+	//go:embed 79.sql
+	createEventUserAgentsTable string
+)
+
+type CreateEventUserAgentsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *CreateEventUserAgentsTable) Execute(ctx context.Context, _ eventstore.Event) error {
+	_, err := mig.dbClient.ExecContext(ctx, createEventUserAgentsTable)
+	return err
+}
+
+func (mig *CreateEventUserAgentsTable) String() string {
+	return "79_create_event_user_agents_table"
+}
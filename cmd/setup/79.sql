@@ -0,0 +1,9 @@
+-- the user agents of the requests which pushed the events,
+-- all events pushed in the same transaction share the position
+CREATE TABLE IF NOT EXISTS eventstore.event_user_agents (
+    instance_id TEXT NOT NULL
+    , "position" NUMERIC NOT NULL
+    , user_agent TEXT NOT NULL
+
+    , PRIMARY KEY (instance_id, "position")
+);
@@ -121,71 +121,81 @@ func NewConfig(cmd *cobra.Command, v *viper.Viper) (*Config, instrumentation.Shu
 }
 
 type Steps struct {
-	s1ProjectionTable                       *ProjectionTable
-	s2AssetsTable                           *AssetTable
-	FirstInstance                           *FirstInstance
-	s5LastFailed                            *LastFailed
-	s6OwnerRemoveColumns                    *OwnerRemoveColumns
-	s7LogstoreTables                        *LogstoreTables
-	s8AuthTokens                            *AuthTokenIndexes
-	CorrectCreationDate                     *CorrectCreationDate
-	s12AddOTPColumns                        *AddOTPColumns
-	s13FixQuotaProjection                   *FixQuotaConstraints
-	s14NewEventsTable                       *NewEventsTable
-	s15CurrentStates                        *CurrentProjectionState
-	s16UniqueConstraintsLower               *UniqueConstraintToLower
-	s17AddOffsetToUniqueConstraints         *AddOffsetToCurrentStates
-	s18AddLowerFieldsToLoginNames           *AddLowerFieldsToLoginNames
-	s19AddCurrentStatesIndex                *AddCurrentSequencesIndex
-	s20AddByUserSessionIndex                *AddByUserIndexToSession
-	s21AddBlockFieldToLimits                *AddBlockFieldToLimits
-	s22ActiveInstancesIndex                 *ActiveInstanceEvents
-	s23CorrectGlobalUniqueConstraints       *CorrectGlobalUniqueConstraints
-	s24AddActorToAuthTokens                 *AddActorToAuthTokens
-	s25User11AddLowerFieldsToVerifiedEmail  *User11AddLowerFieldsToVerifiedEmail
-	s26AuthUsers3                           *AuthUsers3
-	s27IDPTemplate6SAMLNameIDFormat         *IDPTemplate6SAMLNameIDFormat
-	s28AddFieldTable                        *AddFieldTable
-	s29FillFieldsForProjectGrant            *FillFieldsForProjectGrant
-	s30FillFieldsForOrgDomainVerified       *FillFieldsForOrgDomainVerified
-	s31AddAggregateIndexToFields            *AddAggregateIndexToFields
-	s32AddAuthSessionID                     *AddAuthSessionID
-	s33SMSConfigs3TwilioAddVerifyServiceSid *SMSConfigs3TwilioAddVerifyServiceSid
-	s34AddCacheSchema                       *AddCacheSchema
-	s35AddPositionToIndexEsWm               *AddPositionToIndexEsWm
-	s36FillV2Milestones                     *FillV3Milestones
-	s37Apps7OIDConfigsBackChannelLogoutURI  *Apps7OIDConfigsBackChannelLogoutURI
-	s38BackChannelLogoutNotificationStart   *BackChannelLogoutNotificationStart
-	s40InitPushFunc                         *InitPushFunc
-	s42Apps7OIDCConfigsLoginVersion         *Apps7OIDCConfigsLoginVersion
-	s43CreateFieldsDomainIndex              *CreateFieldsDomainIndex
-	s44ReplaceCurrentSequencesIndex         *ReplaceCurrentSequencesIndex
-	s45CorrectProjectOwners                 *CorrectProjectOwners
-	s46InitPermissionFunctions              *InitPermissionFunctions
-	s47FillMembershipFields                 *FillMembershipFields
-	s48Apps7SAMLConfigsLoginVersion         *Apps7SAMLConfigsLoginVersion
-	s49InitPermittedOrgsFunction            *InitPermittedOrgsFunction
-	s50IDPTemplate6UsePKCE                  *IDPTemplate6UsePKCE
-	s51IDPTemplate6RootCA                   *IDPTemplate6RootCA
-	s52IDPTemplate6LDAP2                    *IDPTemplate6LDAP2
-	s53InitPermittedOrgsFunction            *InitPermittedOrgsFunction53
-	s54InstancePositionIndex                *InstancePositionIndex
-	s55ExecutionHandlerStart                *ExecutionHandlerStart
-	s56IDPTemplate6SAMLFederatedLogout      *IDPTemplate6SAMLFederatedLogout
-	s57CreateResourceCounts                 *CreateResourceCounts
-	s58ReplaceLoginNames3View               *ReplaceLoginNames3View
-	s59SetupWebkeys                         *SetupWebkeys
-	s60GenerateSystemID                     *GenerateSystemID
-	s61IDPTemplate6SAMLSignatureAlgorithm   *IDPTemplate6SAMLSignatureAlgorithm
-	s62HTTPProviderAddSigningKey            *HTTPProviderAddSigningKey
-	s63AlterResourceCounts                  *AlterResourceCounts
-	s64ChangePushPosition                   *ChangePushPosition
-	s65FixUserMetadata5Index                *FixUserMetadata5Index
-	s66SessionRecoveryCodeCheckedAt         *SessionRecoveryCodeCheckedAt
-	s67SyncMemberRoleFields                 *SyncMemberRoleFields
-	s68TargetAddPayloadTypeColumn           *TargetAddPayloadTypeColumn
-	s69CacheTablesLogged                    *CacheTablesLogged
-	RelationalTables                        *TransactionalTables
+	s1ProjectionTable                        *ProjectionTable
+	s2AssetsTable                            *AssetTable
+	FirstInstance                            *FirstInstance
+	s5LastFailed                             *LastFailed
+	s6OwnerRemoveColumns                     *OwnerRemoveColumns
+	s7LogstoreTables                         *LogstoreTables
+	s8AuthTokens                             *AuthTokenIndexes
+	CorrectCreationDate                      *CorrectCreationDate
+	s12AddOTPColumns                         *AddOTPColumns
+	s13FixQuotaProjection                    *FixQuotaConstraints
+	s14NewEventsTable                        *NewEventsTable
+	s15CurrentStates                         *CurrentProjectionState
+	s16UniqueConstraintsLower                *UniqueConstraintToLower
+	s17AddOffsetToUniqueConstraints          *AddOffsetToCurrentStates
+	s18AddLowerFieldsToLoginNames            *AddLowerFieldsToLoginNames
+	s19AddCurrentStatesIndex                 *AddCurrentSequencesIndex
+	s20AddByUserSessionIndex                 *AddByUserIndexToSession
+	s21AddBlockFieldToLimits                 *AddBlockFieldToLimits
+	s22ActiveInstancesIndex                  *ActiveInstanceEvents
+	s23CorrectGlobalUniqueConstraints        *CorrectGlobalUniqueConstraints
+	s24AddActorToAuthTokens                  *AddActorToAuthTokens
+	s25User11AddLowerFieldsToVerifiedEmail   *User11AddLowerFieldsToVerifiedEmail
+	s26AuthUsers3                            *AuthUsers3
+	s27IDPTemplate6SAMLNameIDFormat          *IDPTemplate6SAMLNameIDFormat
+	s28AddFieldTable                         *AddFieldTable
+	s29FillFieldsForProjectGrant             *FillFieldsForProjectGrant
+	s30FillFieldsForOrgDomainVerified        *FillFieldsForOrgDomainVerified
+	s31AddAggregateIndexToFields             *AddAggregateIndexToFields
+	s32AddAuthSessionID                      *AddAuthSessionID
+	s33SMSConfigs3TwilioAddVerifyServiceSid  *SMSConfigs3TwilioAddVerifyServiceSid
+	s34AddCacheSchema                        *AddCacheSchema
+	s35AddPositionToIndexEsWm                *AddPositionToIndexEsWm
+	s36FillV2Milestones                      *FillV3Milestones
+	s37Apps7OIDConfigsBackChannelLogoutURI   *Apps7OIDConfigsBackChannelLogoutURI
+	s38BackChannelLogoutNotificationStart    *BackChannelLogoutNotificationStart
+	s40InitPushFunc                          *InitPushFunc
+	s42Apps7OIDCConfigsLoginVersion          *Apps7OIDCConfigsLoginVersion
+	s43CreateFieldsDomainIndex               *CreateFieldsDomainIndex
+	s44ReplaceCurrentSequencesIndex          *ReplaceCurrentSequencesIndex
+	s45CorrectProjectOwners                  *CorrectProjectOwners
+	s46InitPermissionFunctions               *InitPermissionFunctions
+	s47FillMembershipFields                  *FillMembershipFields
+	s48Apps7SAMLConfigsLoginVersion          *Apps7SAMLConfigsLoginVersion
+	s49InitPermittedOrgsFunction             *InitPermittedOrgsFunction
+	s50IDPTemplate6UsePKCE                   *IDPTemplate6UsePKCE
+	s51IDPTemplate6RootCA                    *IDPTemplate6RootCA
+	s52IDPTemplate6LDAP2                     *IDPTemplate6LDAP2
+	s53InitPermittedOrgsFunction             *InitPermittedOrgsFunction53
+	s54InstancePositionIndex                 *InstancePositionIndex
+	s55ExecutionHandlerStart                 *ExecutionHandlerStart
+	s56IDPTemplate6SAMLFederatedLogout       *IDPTemplate6SAMLFederatedLogout
+	s57CreateResourceCounts                  *CreateResourceCounts
+	s58ReplaceLoginNames3View                *ReplaceLoginNames3View
+	s59SetupWebkeys                          *SetupWebkeys
+	s60GenerateSystemID                      *GenerateSystemID
+	s61IDPTemplate6SAMLSignatureAlgorithm    *IDPTemplate6SAMLSignatureAlgorithm
+	s62HTTPProviderAddSigningKey             *HTTPProviderAddSigningKey
+	s63AlterResourceCounts                   *AlterResourceCounts
+	s64ChangePushPosition                    *ChangePushPosition
+	s65FixUserMetadata5Index                 *FixUserMetadata5Index
+	s66SessionRecoveryCodeCheckedAt          *SessionRecoveryCodeCheckedAt
+	s67SyncMemberRoleFields                  *SyncMemberRoleFields
+	s68TargetAddPayloadTypeColumn            *TargetAddPayloadTypeColumn
+	s69CacheTablesLogged                     *CacheTablesLogged
+	s70Apps7OIDCConfigsRefreshTokenSettings  *Apps7OIDCConfigsRefreshTokenSettings
+	s71Apps7APIConfigsResourceURI            *Apps7APIConfigsResourceURI
+	s72Apps7APIConfigsIntrospectionResponse  *Apps7APIConfigsIntrospectionResponse
+	s73Apps7OIDCConfigsEncryptionSettings    *Apps7OIDCConfigsEncryptionSettings
+	s74Apps7OIDCConfigsFrontChannelLogoutURI *Apps7OIDCConfigsFrontChannelLogoutURI
+	s75Apps7SAMLConfigsAttributeRelease      *Apps7SAMLConfigsAttributeRelease
+	s76CreateSnapshotsTable                  *CreateSnapshotsTable
+	s77CreateArchivedAggregatesTable         *CreateArchivedAggregatesTable
+	s78CreatePersonalDataKeysTable           *CreatePersonalDataKeysTable
+	s79CreateEventUserAgentsTable            *CreateEventUserAgentsTable
+	RelationalTables                         *TransactionalTables
 }
 
 func NewSteps(ctx context.Context, v *viper.Viper) (*Steps, error) {
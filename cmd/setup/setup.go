@@ -159,10 +159,19 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	dbClient, err := database.Connect(config.Database, false)
 	logging.OnError(ctx, err).Fatal("unable to connect to database")
 
+	keyStorage, err := cryptoDB.NewKeyStorage(dbClient, masterKey)
+	logging.OnError(ctx, err).Fatal("unable to start key storage")
+	keys, err := encryption.EnsureEncryptionKeys(ctx, config.EncryptionKeys, keyStorage)
+	logging.OnError(ctx, err).Fatal("unable to ensure encryption keys")
+
 	config.Eventstore.Querier = old_es.NewPostgres(dbClient)
-	esV3 := new_es.NewEventstore(dbClient)
+	esV3 := new_es.NewEventstore(dbClient,
+		new_es.WithPersonalDataEncryptionOption(config.Eventstore.PersonalData.Encrypt),
+		new_es.WithPersonalDataKeyEncryptionOption(keys.PersonalData),
+	)
 	config.Eventstore.Pusher = esV3
 	config.Eventstore.Searcher = esV3
+	config.Eventstore.PersonalDataKeyStore = esV3
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
 
 	logging.OnError(ctx, err).Fatal("unable to start eventstore")
@@ -248,6 +257,16 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 	steps.s67SyncMemberRoleFields = &SyncMemberRoleFields{dbClient: dbClient}
 	steps.s68TargetAddPayloadTypeColumn = &TargetAddPayloadTypeColumn{dbClient: dbClient}
 	steps.s69CacheTablesLogged = &CacheTablesLogged{dbClient: dbClient}
+	steps.s70Apps7OIDCConfigsRefreshTokenSettings = &Apps7OIDCConfigsRefreshTokenSettings{dbClient: dbClient}
+	steps.s71Apps7APIConfigsResourceURI = &Apps7APIConfigsResourceURI{dbClient: dbClient}
+	steps.s72Apps7APIConfigsIntrospectionResponse = &Apps7APIConfigsIntrospectionResponse{dbClient: dbClient}
+	steps.s73Apps7OIDCConfigsEncryptionSettings = &Apps7OIDCConfigsEncryptionSettings{dbClient: dbClient}
+	steps.s74Apps7OIDCConfigsFrontChannelLogoutURI = &Apps7OIDCConfigsFrontChannelLogoutURI{dbClient: dbClient}
+	steps.s75Apps7SAMLConfigsAttributeRelease = &Apps7SAMLConfigsAttributeRelease{dbClient: dbClient}
+	steps.s76CreateSnapshotsTable = &CreateSnapshotsTable{dbClient: dbClient}
+	steps.s77CreateArchivedAggregatesTable = &CreateArchivedAggregatesTable{dbClient: dbClient}
+	steps.s78CreatePersonalDataKeysTable = &CreatePersonalDataKeysTable{dbClient: dbClient}
+	steps.s79CreateEventUserAgentsTable = &CreateEventUserAgentsTable{dbClient: dbClient}
 
 	err = projection.Create(ctx, dbClient, eventstoreClient, config.Projections, nil, nil, nil)
 	if err != nil {
@@ -262,6 +281,10 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s28AddFieldTable,
 		steps.s31AddAggregateIndexToFields,
 		steps.s46InitPermissionFunctions,
+		// the keys of the personal data are created by the pushes of the first instance
+		steps.s78CreatePersonalDataKeysTable,
+		// the user agents are recorded by the pushes of the first instance
+		steps.s79CreateEventUserAgentsTable,
 		steps.FirstInstance,
 		steps.s5LastFailed,
 		steps.s6OwnerRemoveColumns,
@@ -305,6 +328,8 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s65FixUserMetadata5Index,
 		steps.s67SyncMemberRoleFields,
 		steps.s69CacheTablesLogged,
+		steps.s76CreateSnapshotsTable,
+		steps.s77CreateArchivedAggregatesTable,
 	} {
 		setupErr = executeMigration(ctx, eventstoreClient, step, "migration failed")
 		if setupErr != nil {
@@ -370,6 +395,12 @@ func Setup(ctx context.Context, config *Config, steps *Steps, masterKey string)
 		steps.s59SetupWebkeys, // this step needs commands.
 		steps.s66SessionRecoveryCodeCheckedAt,
 		steps.s68TargetAddPayloadTypeColumn,
+		steps.s70Apps7OIDCConfigsRefreshTokenSettings,
+		steps.s71Apps7APIConfigsResourceURI,
+		steps.s72Apps7APIConfigsIntrospectionResponse,
+		steps.s73Apps7OIDCConfigsEncryptionSettings,
+		steps.s74Apps7OIDCConfigsFrontChannelLogoutURI,
+		steps.s75Apps7SAMLConfigsAttributeRelease,
 	} {
 		setupErr = executeMigration(ctx, eventstoreClient, step, "migration failed")
 		if setupErr != nil {
@@ -514,6 +545,7 @@ func startCommandsQueries(
 		keys.Target,
 		keys.SMS,
 		keys.SMTP,
+		keys.RADIUS,
 		config.InternalAuthZ.RolePermissionMappings,
 		sessionTokenVerifier,
 		func(q *query.Queries) domain.PermissionCheck {
@@ -567,6 +599,7 @@ func startCommandsQueries(
 		keys.DomainVerification,
 		keys.SAML,
 		keys.Target,
+		keys.RADIUS,
 		keys.OIDC,
 		&http.Client{},
 		permissionCheck,
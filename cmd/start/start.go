@@ -38,6 +38,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/cas"
 	action_v2 "github.com/zitadel/zitadel/internal/api/grpc/action/v2"
 	action_v2_beta "github.com/zitadel/zitadel/internal/api/grpc/action/v2beta"
 	"github.com/zitadel/zitadel/internal/api/grpc/admin"
@@ -46,6 +47,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/auth"
 	authorization_v2 "github.com/zitadel/zitadel/internal/api/grpc/authorization/v2"
 	authorization_v2beta "github.com/zitadel/zitadel/internal/api/grpc/authorization/v2beta"
+	change_v2 "github.com/zitadel/zitadel/internal/api/grpc/change/v2"
+	event_v2 "github.com/zitadel/zitadel/internal/api/grpc/event/v2"
 	feature_v2 "github.com/zitadel/zitadel/internal/api/grpc/feature/v2"
 	feature_v2beta "github.com/zitadel/zitadel/internal/api/grpc/feature/v2beta"
 	group_v2 "github.com/zitadel/zitadel/internal/api/grpc/group/v2"
@@ -77,7 +80,9 @@ import (
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/idp"
+	"github.com/zitadel/zitadel/internal/api/ldap"
 	"github.com/zitadel/zitadel/internal/api/oidc"
+	"github.com/zitadel/zitadel/internal/api/radius"
 	"github.com/zitadel/zitadel/internal/api/robots_txt"
 	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/scim"
@@ -97,12 +102,15 @@ import (
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/domain/federatedlogout"
+	"github.com/zitadel/zitadel/internal/eventsink"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
 	"github.com/zitadel/zitadel/internal/execution"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/idp/ldapsync"
 	"github.com/zitadel/zitadel/internal/integration/sink"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
@@ -112,9 +120,11 @@ import (
 	"github.com/zitadel/zitadel/internal/net"
 	"github.com/zitadel/zitadel/internal/notification"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
 	"github.com/zitadel/zitadel/internal/queue"
 	"github.com/zitadel/zitadel/internal/serviceping"
 	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/userdataexport"
 	es_v4 "github.com/zitadel/zitadel/internal/v2/eventstore"
 	es_v4_pg "github.com/zitadel/zitadel/internal/v2/eventstore/postgres"
 	"github.com/zitadel/zitadel/internal/webauthn"
@@ -199,10 +209,24 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		return err
 	}
 
-	config.Eventstore.Pusher = new_es.NewEventstore(dbClient, new_es.WithExecutionQueueOption(q))
+	config.Eventstore.Pusher = new_es.NewEventstore(dbClient,
+		new_es.WithExecutionQueueOption(q),
+		new_es.WithPushNotificationsOption(config.Eventstore.PushNotifications),
+		new_es.WithPersonalDataEncryptionOption(config.Eventstore.PersonalData.Encrypt),
+		new_es.WithPersonalDataKeyEncryptionOption(keys.PersonalData),
+		new_es.WithRecordUserAgentsOption(config.Eventstore.RecordUserAgents),
+	)
 	config.Eventstore.Searcher = new_es.NewEventstore(dbClient, new_es.WithExecutionQueueOption(q))
 	config.Eventstore.Querier = old_es.NewPostgres(dbClient)
+	config.Eventstore.SnapshotStore = new_es.NewEventstore(dbClient)
+	config.Eventstore.ArchiveStore = new_es.NewEventstore(dbClient)
+	config.Eventstore.PersonalDataKeyStore = new_es.NewEventstore(dbClient, new_es.WithPersonalDataKeyEncryptionOption(keys.PersonalData))
+	config.Eventstore.UserAgentStore = new_es.NewEventstore(dbClient)
 	eventstoreClient := eventstore.NewEventstore(config.Eventstore)
+	eventstoreClient.StartSnapshotWriter(ctx)
+	if config.Eventstore.PushNotifications {
+		handler.StartPushNotificationListener(ctx, dbClient)
+	}
 	eventstoreV4 := es_v4.NewEventstoreFromOne(es_v4_pg.New(dbClient, &es_v4_pg.Config{
 		MaxRetries: config.Eventstore.MaxRetries,
 	}))
@@ -238,6 +262,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys.Target,
 		keys.SMS,
 		keys.SMTP,
+		keys.RADIUS,
 		config.InternalAuthZ.RolePermissionMappings,
 		sessionTokenVerifier,
 		func(q *query.Queries) domain.PermissionCheck {
@@ -290,6 +315,7 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 		keys.DomainVerification,
 		keys.SAML,
 		keys.Target,
+		keys.RADIUS,
 		keys.OIDC,
 		&http.Client{},
 		permissionCheck,
@@ -348,6 +374,10 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	)
 	notification.Start(ctx)
 
+	if err = eventsink.Start(ctx, config.EventSink, projection.ApplyCustomConfig(config.Projections.Customizations["eventsink"])); err != nil {
+		return err
+	}
+
 	execution.Register(
 		ctx,
 		config.Executions,
@@ -361,6 +391,19 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	if err := serviceping.Register(ctx, q, queries, eventstoreClient, config.ServicePing); err != nil {
 		return err
 	}
+	ldapsync.Register(ctx, q, commands, queries, keys.User, config.LDAPSync)
+	userdataexport.Register(
+		ctx,
+		q,
+		commands,
+		queries,
+		eventstoreClient,
+		storage,
+		keys.User,
+		config.Projections.Customizations["userdataexport"],
+		config.UserDataExport,
+	)
+	userdataexport.Start(ctx)
 
 	if err = q.Start(ctx); err != nil {
 		return err
@@ -370,6 +413,9 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	if err = serviceping.Start(ctx, config.ServicePing, q); err != nil {
 		return err
 	}
+	if err = ldapsync.Start(ctx, config.LDAPSync, q); err != nil {
+		return err
+	}
 
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
@@ -397,6 +443,13 @@ func startZitadel(ctx context.Context, config *Config, masterKey string, server
 	commands.GrpcMethodExisting = checkExisting(api.ListGrpcMethods())
 	commands.GrpcServiceExisting = checkExisting(api.ListGrpcServices())
 
+	if err = ldap.Start(ctx, config.LDAP, commands, queries, permissionCheck); err != nil {
+		return err
+	}
+	if err = radius.Start(ctx, config.RADIUS, commands, queries); err != nil {
+		return err
+	}
+
 	shutdown := make(chan os.Signal, 1)
 	signal.Notify(shutdown, os.Interrupt, syscall.SIGTERM)
 
@@ -613,6 +666,12 @@ func startAPIs(
 	if err := apis.RegisterService(ctx, group_v2.CreateServer(config.SystemDefaults, commands, queries, permissionCheck)); err != nil {
 		return nil, err
 	}
+	if err := apis.RegisterService(ctx, change_v2.CreateServer(config.SystemDefaults, queries, config.AuditLogRetention)); err != nil {
+		return nil, err
+	}
+	if err := apis.RegisterService(ctx, event_v2.CreateServer(queries)); err != nil {
+		return nil, err
+	}
 
 	instanceInterceptor := middleware.InstanceInterceptor(queries, config.ExternalDomain, translator, login.IgnoreInstanceEndpoints...)
 	assetsCache := middleware.AssetsCacheInterceptor(config.AssetStorage.Cache.MaxAge, config.AssetStorage.Cache.SharedMaxAge)
@@ -681,12 +740,18 @@ func startAPIs(
 	}
 	apis.RegisterHandlerPrefixes(oidcServer, oidcPrefixes...)
 
-	samlProvider, err := saml.NewProvider(config.SAML, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.SAML, keys.Target, eventstore, dbClient, instanceInterceptor.Handler, userAgentInterceptor, limitingAccessInterceptor)
+	samlProvider, err := saml.NewProvider(config.SAML, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.SAML, keys.Target, eventstore, dbClient, instanceInterceptor.Handler, userAgentInterceptor, limitingAccessInterceptor, cacheConnectors)
 	if err != nil {
 		return nil, fmt.Errorf("unable to start saml provider: %w", err)
 	}
 	apis.RegisterHandlerOnPrefix(saml.HandlerPrefix, samlProvider.HttpHandler())
 
+	casProvider, err := cas.NewProvider(config.CAS, commands, queries, authRepo, keys.OIDC, instanceInterceptor.Handler, userAgentInterceptor, limitingAccessInterceptor, cacheConnectors)
+	if err != nil {
+		return nil, fmt.Errorf("unable to start cas provider: %w", err)
+	}
+	apis.RegisterHandlerOnPrefix(cas.HandlerPrefix, casProvider.HttpHandler())
+
 	apis.RegisterHandlerOnPrefix(
 		schemas.HandlerPrefix,
 		scim.NewServer(
@@ -714,6 +779,7 @@ func startAPIs(
 		managementConsolePath,
 		oidcServer.AuthCallbackURL(),
 		samlProvider.AuthCallbackURL(),
+		casProvider.AuthCallbackURL(),
 		config.ExternalSecure,
 		userAgentInterceptor,
 		op.NewIssuerInterceptor(oidcServer.IssuerFromRequest).Handler,
@@ -13,8 +13,10 @@ import (
 
 	"github.com/zitadel/zitadel/backend/v3/instrumentation/logging"
 	"github.com/zitadel/zitadel/cmd/admin"
+	"github.com/zitadel/zitadel/cmd/archive"
 	"github.com/zitadel/zitadel/cmd/build"
 	"github.com/zitadel/zitadel/cmd/initialise"
+	"github.com/zitadel/zitadel/cmd/instance"
 	"github.com/zitadel/zitadel/cmd/key"
 	"github.com/zitadel/zitadel/cmd/mirror"
 	"github.com/zitadel/zitadel/cmd/ready"
@@ -60,6 +62,8 @@ func New(out io.Writer, in io.Reader, args []string, server chan<- *start.Server
 		start.NewStartFromSetup(server),
 		mirror.New(&configFiles),
 		key.New(),
+		instance.New(),
+		archive.New(),
 		ready.New(),
 	)
 
@@ -18,6 +18,7 @@ import (
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/i18n"
 	"github.com/zitadel/zitadel/internal/id"
 	"github.com/zitadel/zitadel/internal/query"
@@ -118,6 +119,7 @@ func NewHandler(
 	csp := http_mw.SecurityHeaders(&http_mw.DefaultSCP, nil)
 	router.Use(callDurationInterceptor, instanceInterceptor, assetCacheInterceptor, accessInterceptor, csp)
 	RegisterRoutes(router, h)
+	router.Path(domain.UserDataExportsDownloadPath + "/{userID}/{exportID}").Methods("GET").HandlerFunc(h.DownloadUserDataExport)
 	router.PathPrefix("/{owner}").Methods("GET").HandlerFunc(DownloadHandleFunc(h, h.GetFile()))
 	return http_util.CopyHeadersToContext(http_mw.CORSInterceptor(router))
 }
@@ -129,6 +131,10 @@ func (h *Handler) GetFile() Downloader {
 type publicFileDownloader struct{}
 
 func (l *publicFileDownloader) ObjectName(_ context.Context, path string) (string, error) {
+	// archives of user data exports can only be downloaded with their code
+	if domain.IsUserDataExportAssetPath(path) {
+		return "", nil
+	}
 	return path, nil
 }
 
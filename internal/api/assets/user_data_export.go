@@ -0,0 +1,28 @@
+package assets
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+)
+
+// DownloadUserDataExport returns the archive of an export of the personal data of a user.
+// Instead of an access token, the code sent to the user is required.
+func (h *Handler) DownloadUserDataExport(w http.ResponseWriter, r *http.Request) {
+	if h.storage == nil {
+		return
+	}
+	vars := mux.Vars(r)
+	export, err := h.commands.DownloadUserDataExport(r.Context(), vars["userID"], vars["exportID"], r.URL.Query().Get("code"))
+	if err != nil {
+		h.errorHandler(w, r, err, http.StatusNotFound)
+		return
+	}
+	w.Header().Set(http_util.CacheControl, "no-store")
+	w.Header().Set(http_util.ContentDisposition, `attachment; filename="`+export.ExportID+`.json"`)
+	if err = GetAsset(w, r, export.ResourceOwner, export.ObjectName, h.storage); err != nil {
+		h.errorHandler(w, r, err, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,274 @@
+package cas
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// handleLogin starts the authentication of a CAS request (section 2.1 of the CAS protocol)
+// by creating an auth request for the login (v1 or v2) of the service.
+func (p *Provider) handleLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	serviceURL := r.FormValue(paramService)
+	if serviceURL == "" {
+		http.Error(w, "service is missing", http.StatusBadRequest)
+		return
+	}
+	service, err := p.query.ActiveCASServiceByURL(ctx, serviceURL)
+	if err != nil {
+		casError(w, err)
+		return
+	}
+	renew := isTrue(r.FormValue(paramRenew))
+	// renew takes precedence over gateway (section 2.1.1)
+	gateway := !renew && isTrue(r.FormValue(paramGateway))
+
+	var loginURL string
+	if p.useLoginV2(ctx, service) {
+		loginURL, err = p.createAuthRequestV2(ctx, service, serviceURL, renew, gateway)
+	} else {
+		loginURL, err = p.createAuthRequest(ctx, service, serviceURL, renew, gateway)
+	}
+	if err != nil {
+		casError(w, err)
+		return
+	}
+	http.Redirect(w, r, loginURL, http.StatusFound)
+}
+
+func (p *Provider) useLoginV2(ctx context.Context, service *query.CASService) bool {
+	// for backwards compatibility we'll use the new login if the header is set (no matter the other configs)
+	if loginClient(ctx) != "" {
+		return true
+	}
+	// if the instance requires the v2 login, use it no matter what the application configured
+	if authz.GetFeatures(ctx).LoginV2.Required {
+		return true
+	}
+	return service.LoginVersion == domain.LoginVersion2
+}
+
+func loginClient(ctx context.Context) string {
+	headers, _ := http_utils.HeadersFromCtx(ctx)
+	return headers.Get(LoginClientHeader)
+}
+
+// createAuthRequest creates an auth request for the login (v1) and returns its url.
+// Without any session of the user agent, a gateway request is directly sent back to the service.
+func (p *Provider) createAuthRequest(ctx context.Context, service *query.CASService, serviceURL string, renew, gateway bool) (string, error) {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return "", zerrors.ThrowPreconditionFailed(nil, "CAS-aiP2e", "no user agent id")
+	}
+	if gateway {
+		sessions, err := p.repo.UserSessionsByAgentID(ctx, userAgentID)
+		if err != nil {
+			return "", err
+		}
+		if len(sessions) == 0 {
+			return serviceURL, nil
+		}
+	}
+	authRequest, err := p.repo.CreateAuthRequest(ctx, &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		ApplicationID: service.AppID,
+		CallbackURI:   serviceURL,
+		Prompt:        prompt(renew, gateway),
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		Request: &domain.AuthRequestCAS{
+			Service: serviceURL,
+			Renew:   renew,
+			Gateway: gateway,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	return p.defaultLoginURL + authRequest.ID, nil
+}
+
+// createAuthRequestV2 creates an auth request for the login (v2) and returns its url.
+// The login UI links the session and returns a code to the [CallbackEndpoint] as for OIDC,
+// with the service as state.
+func (p *Provider) createAuthRequestV2(ctx context.Context, service *query.CASService, serviceURL string, renew, gateway bool) (string, error) {
+	authRequest, err := p.command.AddAuthRequest(ctx, &command.AuthRequest{
+		LoginClient:  loginClient(ctx),
+		ClientID:     service.AppID,
+		RedirectURI:  ContextToIssuer(ctx) + CallbackEndpoint,
+		State:        serviceURL,
+		Scope:        []string{"openid"},
+		ResponseType: domain.OIDCResponseTypeCode,
+		ResponseMode: domain.OIDCResponseModeQuery,
+		Prompt:       prompt(renew, gateway),
+		Issuer:       http_utils.DomainContext(ctx).Origin(),
+	})
+	if err != nil {
+		return "", err
+	}
+	return p.loginURLV2(service, authRequest.ID), nil
+}
+
+func (p *Provider) loginURLV2(service *query.CASService, id string) string {
+	// any v2 login without a specific base uri will be sent to the configured login v2 UI
+	if service.LoginBaseURI == nil || *service.LoginBaseURI == "" {
+		return p.defaultLoginURLV2 + id
+	}
+	uri, err := url.Parse(*service.LoginBaseURI)
+	if err != nil {
+		logging.WithFields("app", service.AppID).OnError(err).Warn("invalid login base uri")
+		return p.defaultLoginURLV2 + id
+	}
+	// for applications with a specific URI (internal or external) we only need to add the auth request id
+	uri = uri.JoinPath(LoginPath)
+	q := uri.Query()
+	q.Set(LoginAuthRequestParam, id)
+	uri.RawQuery = q.Encode()
+	return uri.String()
+}
+
+func prompt(renew, gateway bool) []domain.Prompt {
+	switch {
+	case renew:
+		return []domain.Prompt{domain.PromptLogin}
+	case gateway:
+		return []domain.Prompt{domain.PromptNone}
+	default:
+		return nil
+	}
+}
+
+// handleCallback issues a service ticket for the authenticated user and redirects to the service.
+// Requests of the login (v1) provide the id of the auth request,
+// the ones of the login (v2) the code and state (service) as an OIDC redirect.
+func (p *Provider) handleCallback(w http.ResponseWriter, r *http.Request) {
+	if r.FormValue(paramID) != "" {
+		p.handleCallbackV1(w, r)
+		return
+	}
+	p.handleCallbackV2(w, r)
+}
+
+func (p *Provider) handleCallbackV1(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		http.Error(w, "no user agent id", http.StatusBadRequest)
+		return
+	}
+	authRequest, err := p.repo.AuthRequestByIDCheckLoggedIn(ctx, r.FormValue(paramID), userAgentID)
+	if err != nil {
+		casError(w, err)
+		return
+	}
+	casRequest, ok := authRequest.Request.(*domain.AuthRequestCAS)
+	if !ok {
+		http.Error(w, "not a cas request", http.StatusBadRequest)
+		return
+	}
+	if !authRequest.Done() {
+		if casRequest.Gateway {
+			http.Redirect(w, r, casRequest.Service, http.StatusFound)
+			return
+		}
+		http.Error(w, "authentication not completed", http.StatusBadRequest)
+		return
+	}
+	redirect, err := p.issueServiceTicket(ctx, authRequest.ApplicationID, casRequest.Service, authRequest.UserID, authRequest.AuthTime, casRequest.Renew)
+	if err != nil {
+		casError(w, err)
+		return
+	}
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+func (p *Provider) handleCallbackV2(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	serviceURL := r.FormValue(paramState)
+	service, err := p.query.ActiveCASServiceByURL(ctx, serviceURL)
+	if err != nil {
+		casError(w, err)
+		return
+	}
+	// the login UI failed the auth request, e.g. for a gateway request (prompt=none) without session
+	if r.FormValue(paramError) != "" {
+		http.Redirect(w, r, serviceURL, http.StatusFound)
+		return
+	}
+	authRequestID, err := p.encAlg.DecryptToken(r.FormValue(paramCode))
+	if err != nil || !strings.HasPrefix(authRequestID, command.IDPrefixV2) {
+		http.Error(w, "invalid code", http.StatusBadRequest)
+		return
+	}
+	authRequest, err := p.command.ExchangeAuthRequestCode(authz.SetCtxData(ctx, authz.CtxData{UserID: "SYSTEM"}), authRequestID)
+	if err != nil {
+		casError(w, err)
+		return
+	}
+	if authRequest.ClientID != service.AppID || authRequest.State != serviceURL {
+		http.Error(w, "service does not match the request", http.StatusBadRequest)
+		return
+	}
+	redirect, err := p.issueServiceTicket(ctx, service.AppID, serviceURL, authRequest.UserID, authRequest.AuthTime, domain.IsPrompt(authRequest.Prompt, domain.PromptLogin))
+	if err != nil {
+		casError(w, err)
+		return
+	}
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+// issueServiceTicket stores a new service ticket and returns the service url containing it.
+func (p *Provider) issueServiceTicket(ctx context.Context, appID, serviceURL, userID string, authTime time.Time, newLogin bool) (string, error) {
+	ticket, err := newServiceTicket()
+	if err != nil {
+		return "", err
+	}
+	uri, err := url.Parse(serviceURL)
+	if err != nil {
+		return "", zerrors.ThrowInvalidArgument(err, "CAS-ohV3e", "invalid service")
+	}
+	p.tickets.Set(ctx, &serviceTicket{
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+		Ticket:     ticket,
+		Service:    serviceURL,
+		AppID:      appID,
+		UserID:     userID,
+		AuthTime:   authTime,
+		NewLogin:   newLogin,
+	})
+	values := uri.Query()
+	values.Set(paramTicket, ticket)
+	uri.RawQuery = values.Encode()
+	return uri.String(), nil
+}
+
+func isTrue(value string) bool {
+	b, err := strconv.ParseBool(value)
+	return err == nil && b
+}
+
+func casError(w http.ResponseWriter, err error) {
+	switch {
+	case zerrors.IsNotFound(err):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case zerrors.IsErrorInvalidArgument(err), zerrors.IsPreconditionFailed(err), zerrors.IsPermissionDenied(err):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		logging.WithError(err).Error("cas request failed")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
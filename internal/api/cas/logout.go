@@ -0,0 +1,39 @@
+package cas
+
+import (
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+)
+
+// handleLogout terminates the sessions of the user agent (section 2.3 of the CAS protocol).
+// The user agent is only redirected to the service if it is registered for a CAS application.
+func (p *Provider) handleLogout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if userAgentID, ok := middleware.UserAgentIDFromCtx(ctx); ok {
+		sessions, err := p.repo.UserSessionsByAgentID(ctx, userAgentID)
+		if err != nil {
+			casError(w, err)
+			return
+		}
+		if len(sessions) > 0 {
+			ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: sessions[0].UserID})
+			if err = p.command.HumansSignOut(ctx, userAgentID, sessions); err != nil {
+				casError(w, err)
+				return
+			}
+		}
+	}
+	if serviceURL := r.FormValue(paramService); serviceURL != "" {
+		if _, err := p.query.ActiveCASServiceByURL(ctx, serviceURL); err == nil {
+			http.Redirect(w, r, serviceURL, http.StatusFound)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, err := w.Write([]byte("signed out"))
+	logging.OnError(err).Error("unable to write cas logout response")
+}
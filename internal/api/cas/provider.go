@@ -0,0 +1,144 @@
+package cas
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/zitadel/zitadel/backend/v3/instrumentation/metrics"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	HandlerPrefix = "/cas"
+
+	// LoginEndpoint is the credential requestor of the CAS protocol, where services send the user agent to.
+	LoginEndpoint = "/login"
+	// CallbackEndpoint receives the user agent after the login UI (v1 and v2) completed the authentication.
+	CallbackEndpoint = "/callback"
+	// ServiceValidateEndpoint validates service tickets (CAS 2.0 response without attributes).
+	ServiceValidateEndpoint = "/serviceValidate"
+	// P3ServiceValidateEndpoint validates service tickets and releases the user attributes (CAS 3.0).
+	P3ServiceValidateEndpoint = "/p3/serviceValidate"
+	// LogoutEndpoint terminates the (v1) sessions of the user agent.
+	LogoutEndpoint = "/logout"
+
+	LoginClientHeader = "x-zitadel-login-client"
+	LoginPath         = "/login"
+	// LoginAuthRequestParam is used for login v2 UIs with a custom base URI.
+	// CAS requests are auth requests (as for OIDC) for the login v2.
+	LoginAuthRequestParam = "authRequest"
+
+	paramService = "service"
+	paramTicket  = "ticket"
+	paramRenew   = "renew"
+	paramGateway = "gateway"
+	paramFormat  = "format"
+	paramID      = "id"
+	paramCode    = "code"
+	paramState   = "state"
+	paramError   = "error"
+)
+
+type Config struct {
+	DefaultLoginURLV2 string
+}
+
+// Provider implements the server side of the Apereo CAS protocol (version 3.0)
+// for CAS applications. The authentication is done by the login (v1 or v2)
+// the same way as for OIDC auth requests.
+type Provider struct {
+	command           *command.Commands
+	query             *query.Queries
+	repo              repository.Repository
+	encAlg            crypto.AuthAlgorithm
+	tickets           cache.Cache[serviceTicketIndex, string, *serviceTicket]
+	defaultLoginURL   string
+	defaultLoginURLV2 string
+	handler           http.Handler
+}
+
+func NewProvider(
+	conf Config,
+	command *command.Commands,
+	query *query.Queries,
+	repo repository.Repository,
+	encAlg crypto.AuthAlgorithm,
+	instanceHandler,
+	userAgentCookie func(http.Handler) http.Handler,
+	accessHandler *middleware.AccessInterceptor,
+	cacheConnectors connector.Connectors,
+) (*Provider, error) {
+	tickets, err := connector.StartCache[serviceTicketIndex, string, *serviceTicket](context.Background(), []serviceTicketIndex{serviceTicketIndexTicket}, cache.PurposeCASServiceTicket, cacheConnectors.Config.CASServiceTickets, cacheConnectors)
+	if err != nil {
+		return nil, err
+	}
+	p := &Provider{
+		command:           command,
+		query:             query,
+		repo:              repo,
+		encAlg:            encAlg,
+		tickets:           tickets,
+		defaultLoginURL:   fmt.Sprintf("%s%s?%s=", login.HandlerPrefix, login.EndpointLogin, login.QueryAuthRequestID),
+		defaultLoginURLV2: conf.DefaultLoginURLV2,
+	}
+
+	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
+	interceptors := []func(http.Handler) http.Handler{
+		middleware.CallDurationHandler,
+		middleware.RequestDetailsHandler(),
+		middleware.MetricsHandler(metricTypes),
+		middleware.TraceHandler(),
+		middleware.LogHandler("cas"),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		userAgentCookie,
+		accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes()),
+		http_utils.CopyHeadersToContext,
+		middleware.ActivityHandler,
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(LoginEndpoint, p.handleLogin)
+	mux.HandleFunc(CallbackEndpoint, p.handleCallback)
+	mux.HandleFunc(ServiceValidateEndpoint, p.handleServiceValidate(false))
+	mux.HandleFunc(P3ServiceValidateEndpoint, p.handleServiceValidate(true))
+	mux.HandleFunc(LogoutEndpoint, p.handleLogout)
+	var handler http.Handler = mux
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		handler = interceptors[i](handler)
+	}
+	p.handler = handler
+	return p, nil
+}
+
+func (p *Provider) HttpHandler() http.Handler {
+	return p.handler
+}
+
+// AuthCallbackURL returns the url the login (v1) redirects to after the authentication of a CAS request.
+func (p *Provider) AuthCallbackURL() func(context.Context, string) string {
+	return func(ctx context.Context, id string) string {
+		return ContextToIssuer(ctx) + CallbackEndpoint + "?" + paramID + "=" + url.QueryEscape(id)
+	}
+}
+
+func ContextToIssuer(ctx context.Context) string {
+	return http_utils.DomainContext(ctx).Origin() + HandlerPrefix
+}
+
+func publicAuthPathPrefixes() []string {
+	return []string{
+		HandlerPrefix + LoginEndpoint,
+		HandlerPrefix + ServiceValidateEndpoint,
+		HandlerPrefix + P3ServiceValidateEndpoint,
+	}
+}
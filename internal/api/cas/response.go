@@ -0,0 +1,153 @@
+package cas
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	// Namespace is the XML namespace of the CAS service responses.
+	Namespace = "http://www.yale.edu/tp/cas"
+
+	formatJSON = "JSON"
+)
+
+// FailureCode is the code of an authenticationFailure as defined in section 2.5.3 of the CAS protocol.
+type FailureCode string
+
+const (
+	FailureInvalidRequest    FailureCode = "INVALID_REQUEST"
+	FailureInvalidTicketSpec FailureCode = "INVALID_TICKET_SPEC"
+	FailureInvalidTicket     FailureCode = "INVALID_TICKET"
+	FailureInvalidService    FailureCode = "INVALID_SERVICE"
+	FailureInternalError     FailureCode = "INTERNAL_ERROR"
+)
+
+// serviceResponse is the result of a ticket validation.
+// Exactly one of success and failure is set.
+type serviceResponse struct {
+	success *authenticationSuccess
+	failure *authenticationFailure
+}
+
+type authenticationSuccess struct {
+	User string `json:"user"`
+	// Attributes are only returned by the CAS 3.0 validation ([P3ServiceValidateEndpoint]).
+	Attributes map[string][]string `json:"attributes,omitempty"`
+}
+
+type authenticationFailure struct {
+	Code        FailureCode `json:"code"`
+	Description string      `json:"description"`
+}
+
+func successResponse(user string, attributes map[string][]string) *serviceResponse {
+	return &serviceResponse{success: &authenticationSuccess{User: user, Attributes: attributes}}
+}
+
+func failureResponse(code FailureCode, description string) *serviceResponse {
+	return &serviceResponse{failure: &authenticationFailure{Code: code, Description: description}}
+}
+
+// write sends the response in the requested format, which is XML unless JSON is requested.
+func (r *serviceResponse) write(w http.ResponseWriter, format string) error {
+	if strings.EqualFold(format, formatJSON) {
+		w.Header().Set("Content-Type", "application/json")
+		return r.writeJSON(w)
+	}
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	return r.writeXML(w)
+}
+
+func (r *serviceResponse) writeJSON(w io.Writer) error {
+	type jsonServiceResponse struct {
+		AuthenticationSuccess *authenticationSuccess `json:"authenticationSuccess,omitempty"`
+		AuthenticationFailure *authenticationFailure `json:"authenticationFailure,omitempty"`
+	}
+	return json.NewEncoder(w).Encode(struct {
+		ServiceResponse jsonServiceResponse `json:"serviceResponse"`
+	}{
+		ServiceResponse: jsonServiceResponse{
+			AuthenticationSuccess: r.success,
+			AuthenticationFailure: r.failure,
+		},
+	})
+}
+
+// writeXML encodes the response token by token,
+// because the attribute names of a success are only known at runtime.
+func (r *serviceResponse) writeXML(w io.Writer) error {
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	root := casElement("serviceResponse")
+	root.Attr = []xml.Attr{{Name: xml.Name{Local: "xmlns:cas"}, Value: Namespace}}
+	if err := enc.EncodeToken(root); err != nil {
+		return err
+	}
+	if r.failure != nil {
+		failure := casElement("authenticationFailure")
+		failure.Attr = []xml.Attr{{Name: xml.Name{Local: "code"}, Value: string(r.failure.Code)}}
+		if err := encodeText(enc, failure, r.failure.Description); err != nil {
+			return err
+		}
+	}
+	if r.success != nil {
+		if err := r.success.encodeXML(enc); err != nil {
+			return err
+		}
+	}
+	if err := enc.EncodeToken(root.End()); err != nil {
+		return err
+	}
+	return enc.Flush()
+}
+
+func (s *authenticationSuccess) encodeXML(enc *xml.Encoder) error {
+	success := casElement("authenticationSuccess")
+	if err := enc.EncodeToken(success); err != nil {
+		return err
+	}
+	if err := encodeText(enc, casElement("user"), s.User); err != nil {
+		return err
+	}
+	if len(s.Attributes) > 0 {
+		attributes := casElement("attributes")
+		if err := enc.EncodeToken(attributes); err != nil {
+			return err
+		}
+		names := make([]string, 0, len(s.Attributes))
+		for name := range s.Attributes {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+		for _, name := range names {
+			for _, value := range s.Attributes[name] {
+				if err := encodeText(enc, casElement(name), value); err != nil {
+					return err
+				}
+			}
+		}
+		if err := enc.EncodeToken(attributes.End()); err != nil {
+			return err
+		}
+	}
+	return enc.EncodeToken(success.End())
+}
+
+func casElement(name string) xml.StartElement {
+	return xml.StartElement{Name: xml.Name{Local: "cas:" + name}}
+}
+
+func encodeText(enc *xml.Encoder, element xml.StartElement, text string) error {
+	if err := enc.EncodeToken(element); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(xml.CharData(text)); err != nil {
+		return err
+	}
+	return enc.EncodeToken(element.End())
+}
@@ -0,0 +1,90 @@
+package cas
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_serviceResponse_write(t *testing.T) {
+	tests := []struct {
+		name            string
+		response        *serviceResponse
+		format          string
+		wantContentType string
+		want            string
+	}{
+		{
+			name:            "success xml",
+			response:        successResponse("user@example.com", nil),
+			wantContentType: "application/xml; charset=utf-8",
+			want: `<cas:serviceResponse xmlns:cas="http://www.yale.edu/tp/cas">
+  <cas:authenticationSuccess>
+    <cas:user>user@example.com</cas:user>
+  </cas:authenticationSuccess>
+</cas:serviceResponse>`,
+		},
+		{
+			name: "success with attributes xml",
+			response: successResponse("user@example.com", map[string][]string{
+				AttributeRoles: {"teacher", "student"},
+				AttributeEmail: {"user@example.com"},
+			}),
+			format:          "XML",
+			wantContentType: "application/xml; charset=utf-8",
+			want: `<cas:serviceResponse xmlns:cas="http://www.yale.edu/tp/cas">
+  <cas:authenticationSuccess>
+    <cas:user>user@example.com</cas:user>
+    <cas:attributes>
+      <cas:email>user@example.com</cas:email>
+      <cas:roles>teacher</cas:roles>
+      <cas:roles>student</cas:roles>
+    </cas:attributes>
+  </cas:authenticationSuccess>
+</cas:serviceResponse>`,
+		},
+		{
+			name:            "failure xml",
+			response:        failureResponse(FailureInvalidTicket, "ticket ST-1 not recognized"),
+			wantContentType: "application/xml; charset=utf-8",
+			want: `<cas:serviceResponse xmlns:cas="http://www.yale.edu/tp/cas">
+  <cas:authenticationFailure code="INVALID_TICKET">ticket ST-1 not recognized</cas:authenticationFailure>
+</cas:serviceResponse>`,
+		},
+		{
+			name: "success with attributes json",
+			response: successResponse("user@example.com", map[string][]string{
+				AttributeEmail: {"user@example.com"},
+			}),
+			format:          "json",
+			wantContentType: "application/json",
+			want:            `{"serviceResponse":{"authenticationSuccess":{"user":"user@example.com","attributes":{"email":["user@example.com"]}}}}` + "\n",
+		},
+		{
+			name:            "failure json",
+			response:        failureResponse(FailureInvalidService, "service is not registered"),
+			format:          "JSON",
+			wantContentType: "application/json",
+			want:            `{"serviceResponse":{"authenticationFailure":{"code":"INVALID_SERVICE","description":"service is not registered"}}}` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			err := tt.response.write(w, tt.format)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantContentType, w.Header().Get("Content-Type"))
+			assert.Equal(t, tt.want, w.Body.String())
+		})
+	}
+}
+
+func Test_isServiceTicket(t *testing.T) {
+	ticket, err := newServiceTicket()
+	require.NoError(t, err)
+	assert.True(t, isServiceTicket(ticket))
+	assert.False(t, isServiceTicket("PT-1"))
+	assert.False(t, isServiceTicket(""))
+}
@@ -0,0 +1,59 @@
+package cas
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+)
+
+const (
+	serviceTicketPrefix = "ST-"
+	serviceTicketLength = 32
+)
+
+type serviceTicketIndex int
+
+const (
+	serviceTicketIndexUnspecified serviceTicketIndex = iota
+	serviceTicketIndexTicket
+)
+
+// serviceTicket is issued to the service after a successful login
+// and kept until the service validates it (once) on the [ServiceValidateEndpoint].
+type serviceTicket struct {
+	InstanceID string
+	Ticket     string
+	// Service is the url the ticket was issued for. It must match the service of the validation exactly.
+	Service  string
+	AppID    string
+	UserID   string
+	AuthTime time.Time
+	// NewLogin is set if the ticket was issued for a request with renew, where the user had to authenticate again.
+	NewLogin bool
+}
+
+// Keys implements cache.Entry
+func (t *serviceTicket) Keys(i serviceTicketIndex) []string {
+	if i == serviceTicketIndexTicket {
+		return []string{serviceTicketKey(t.InstanceID, t.Ticket)}
+	}
+	return nil
+}
+
+func serviceTicketKey(instanceID, ticket string) string {
+	return instanceID + "-" + ticket
+}
+
+// newServiceTicket creates a random ticket with the "ST-" prefix required by the CAS protocol.
+func newServiceTicket() (string, error) {
+	random := make([]byte, serviceTicketLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", err
+	}
+	return serviceTicketPrefix + base64.RawURLEncoding.EncodeToString(random), nil
+}
+
+func isServiceTicket(ticket string) bool {
+	return strings.HasPrefix(ticket, serviceTicketPrefix)
+}
@@ -0,0 +1,157 @@
+package cas
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	AttributeEmail                                  = "email"
+	AttributeGivenName                              = "givenName"
+	AttributeFamilyName                             = "familyName"
+	AttributeDisplayName                            = "displayName"
+	AttributeUserID                                 = "userId"
+	AttributeRoles                                  = "roles"
+	AttributeAuthenticationDate                     = "authenticationDate"
+	AttributeIsFromNewLogin                         = "isFromNewLogin"
+	AttributeLongTermAuthenticationRequestTokenUsed = "longTermAuthenticationRequestTokenUsed"
+)
+
+// handleServiceValidate validates a service ticket (section 2.5 and 2.6 of the CAS protocol).
+// Tickets are invalidated on the first validation attempt, successful or not.
+// Proxy tickets (pgtUrl) are not supported and therefore ignored.
+func (p *Provider) handleServiceValidate(withAttributes bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		response := p.serviceValidate(r.Context(), r.FormValue(paramService), r.FormValue(paramTicket), isTrue(r.FormValue(paramRenew)), withAttributes)
+		err := response.write(w, r.FormValue(paramFormat))
+		logging.OnError(err).Error("unable to write cas service response")
+	}
+}
+
+func (p *Provider) serviceValidate(ctx context.Context, serviceURL, ticketValue string, renew, withAttributes bool) *serviceResponse {
+	if serviceURL == "" || ticketValue == "" {
+		return failureResponse(FailureInvalidRequest, "service and ticket parameters are both required")
+	}
+	if !isServiceTicket(ticketValue) {
+		return failureResponse(FailureInvalidTicketSpec, "ticket "+ticketValue+" is not a service ticket")
+	}
+	ticket, ok := p.useServiceTicket(ctx, ticketValue)
+	if !ok {
+		return failureResponse(FailureInvalidTicket, "ticket "+ticketValue+" not recognized")
+	}
+	if ticket.Service != serviceURL {
+		return failureResponse(FailureInvalidService, "ticket "+ticketValue+" was not issued for service "+serviceURL)
+	}
+	if renew && !ticket.NewLogin {
+		return failureResponse(FailureInvalidTicket, "ticket "+ticketValue+" was not issued from a new login")
+	}
+	service, err := p.query.ActiveCASServiceByURL(ctx, serviceURL)
+	if err != nil || service.AppID != ticket.AppID {
+		return failureResponse(FailureInvalidService, "service "+serviceURL+" is not registered")
+	}
+	user, err := p.query.GetUserByID(ctx, false, ticket.UserID)
+	if err != nil {
+		if zerrors.IsNotFound(err) {
+			return failureResponse(FailureInvalidTicket, "user of ticket "+ticketValue+" not found")
+		}
+		logging.WithError(err).Error("unable to get user of cas service ticket")
+		return failureResponse(FailureInternalError, "unable to get user")
+	}
+	if user.State != domain.UserStateActive {
+		return failureResponse(FailureInvalidTicket, "user of ticket "+ticketValue+" is not active")
+	}
+	if !withAttributes {
+		return successResponse(user.PreferredLoginName, nil)
+	}
+	attributes := userAttributes(user, ticket)
+	if service.ProjectRoleAssertion {
+		roles, err := p.userRoles(ctx, user.ID, service.ProjectID)
+		if err != nil {
+			logging.WithError(err).Error("unable to get roles of cas service ticket")
+			return failureResponse(FailureInternalError, "unable to get roles")
+		}
+		if len(roles) > 0 {
+			attributes[AttributeRoles] = roles
+		}
+	}
+	return successResponse(user.PreferredLoginName, attributes)
+}
+
+// useServiceTicket returns the ticket and invalidates it, so it can only be used once.
+func (p *Provider) useServiceTicket(ctx context.Context, ticketValue string) (*serviceTicket, bool) {
+	key := serviceTicketKey(authz.GetInstance(ctx).InstanceID(), ticketValue)
+	ticket, ok := p.tickets.Get(ctx, serviceTicketIndexTicket, key)
+	if !ok {
+		return nil, false
+	}
+	err := p.tickets.Invalidate(ctx, serviceTicketIndexTicket, key)
+	logging.OnError(err).Warn("unable to invalidate cas service ticket")
+	return ticket, true
+}
+
+func userAttributes(user *query.User, ticket *serviceTicket) map[string][]string {
+	attributes := map[string][]string{
+		AttributeUserID:                                 {user.ID},
+		AttributeAuthenticationDate:                     {ticket.AuthTime.UTC().Format(time.RFC3339)},
+		AttributeIsFromNewLogin:                         {strconv.FormatBool(ticket.NewLogin)},
+		AttributeLongTermAuthenticationRequestTokenUsed: {"false"},
+	}
+	if user.Human == nil {
+		return attributes
+	}
+	setAttribute(attributes, AttributeEmail, string(user.Human.Email))
+	setAttribute(attributes, AttributeGivenName, user.Human.FirstName)
+	setAttribute(attributes, AttributeFamilyName, user.Human.LastName)
+	setAttribute(attributes, AttributeDisplayName, user.Human.DisplayName)
+	return attributes
+}
+
+func setAttribute(attributes map[string][]string, name, value string) {
+	if value != "" {
+		attributes[name] = []string{value}
+	}
+}
+
+func (p *Provider) userRoles(ctx context.Context, userID, projectID string) ([]string, error) {
+	projectQuery, err := query.NewUserGrantProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	activeQuery, err := query.NewUserGrantStateQuery(domain.UserGrantStateActive)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := p.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{
+			projectQuery,
+			userIDQuery,
+			activeQuery,
+		},
+	}, true, nil)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0)
+	for _, grant := range grants.UserGrants {
+		for _, role := range grant.Roles {
+			if !slices.Contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles, nil
+}
@@ -100,6 +100,10 @@ func eventRequestToFilter(ctx context.Context, req *admin_pb.ListEventsRequest)
 			Builder()
 	}
 
+	if req.GetIncludeArchived() {
+		builder.IncludeArchived()
+	}
+
 	if req.GetAsc() {
 		builder.OrderAsc()
 		builder.CreationDateAfter(fromTime)
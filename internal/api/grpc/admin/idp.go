@@ -426,6 +426,27 @@ func (s *Server) UpdateAppleProvider(ctx context.Context, req *admin_pb.UpdateAp
 	}, nil
 }
 
+func (s *Server) AddX509Provider(ctx context.Context, req *admin_pb.AddX509ProviderRequest) (*admin_pb.AddX509ProviderResponse, error) {
+	id, details, err := s.command.AddInstanceX509Provider(ctx, addX509ProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddX509ProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateX509Provider(ctx context.Context, req *admin_pb.UpdateX509ProviderRequest) (*admin_pb.UpdateX509ProviderResponse, error) {
+	details, err := s.command.UpdateInstanceX509Provider(ctx, req.Id, updateX509ProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateX509ProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) AddSAMLProvider(ctx context.Context, req *admin_pb.AddSAMLProviderRequest) (*admin_pb.AddSAMLProviderResponse, error) {
 	id, details, err := s.command.AddInstanceSAMLProvider(ctx, addSAMLProviderToCommand(req))
 	if err != nil {
@@ -457,6 +478,76 @@ func (s *Server) RegenerateSAMLProviderCertificate(ctx context.Context, req *adm
 	}, nil
 }
 
+func (s *Server) SetProviderMappingRules(ctx context.Context, req *admin_pb.SetProviderMappingRulesRequest) (*admin_pb.SetProviderMappingRulesResponse, error) {
+	details, err := s.command.SetInstanceIDPMappingRules(ctx, req.Id, idp_grpc.MappingRulesToDomain(req.Rules))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetProviderMappingRulesResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) PreviewProviderMapping(ctx context.Context, req *admin_pb.PreviewProviderMappingRequest) (*admin_pb.PreviewProviderMappingResponse, error) {
+	rules := idp_grpc.MappingRulesToDomain(req.GetRules().GetRules())
+	if req.GetId() != "" {
+		instanceIDQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(authz.GetInstance(ctx).InstanceID())
+		if err != nil {
+			return nil, err
+		}
+		idp, err := s.query.IDPTemplateByID(ctx, true, req.GetId(), false, nil, instanceIDQuery)
+		if err != nil {
+			return nil, err
+		}
+		rules = idp.MappingRules
+	}
+	result, err := idp_grpc.PreviewMapping(rules, req.GetUserinfo(), req.GetClaims(), req.GetAttributes())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.PreviewProviderMappingResponse{Result: result}, nil
+}
+
+func (s *Server) GetLDAPProviderSync(ctx context.Context, req *admin_pb.GetLDAPProviderSyncRequest) (*admin_pb.GetLDAPProviderSyncResponse, error) {
+	sync, err := s.query.LDAPSyncByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetLDAPProviderSyncResponse{
+		Sync: idp_grpc.LDAPSyncToPb(sync),
+	}, nil
+}
+
+func (s *Server) SetLDAPProviderSync(ctx context.Context, req *admin_pb.SetLDAPProviderSyncRequest) (*admin_pb.SetLDAPProviderSyncResponse, error) {
+	details, err := s.command.SetInstanceLDAPSync(ctx, req.Id, idp_grpc.LDAPSyncConfigToDomain(req.Config))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListLDAPProviderSyncRuns(ctx context.Context, req *admin_pb.ListLDAPProviderSyncRunsRequest) (*admin_pb.ListLDAPProviderSyncRunsResponse, error) {
+	// ensures the identity provider belongs to the instance
+	if _, err := s.query.LDAPSyncByIDPID(ctx, req.Id, authz.GetInstance(ctx).InstanceID()); err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object_pb.ListQueryToModel(req.Query)
+	resp, err := s.query.SearchLDAPSyncRuns(ctx, req.Id, &query.SearchRequest{
+		Offset: offset,
+		Limit:  limit,
+		Asc:    asc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListLDAPProviderSyncRunsResponse{
+		Result:  idp_grpc.LDAPSyncRunsToPb(resp.Runs),
+		Details: object_pb.ToListDetails(resp.Count, resp.Sequence, resp.LastRun),
+	}, nil
+}
+
 func (s *Server) DeleteProvider(ctx context.Context, req *admin_pb.DeleteProviderRequest) (*admin_pb.DeleteProviderResponse, error) {
 	details, err := s.command.DeleteInstanceProvider(ctx, req.Id)
 	if err != nil {
@@ -0,0 +1,70 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	object_pb "github.com/zitadel/zitadel/internal/api/grpc/object"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) AddRADIUSClient(ctx context.Context, req *admin_pb.AddRADIUSClientRequest) (*admin_pb.AddRADIUSClientResponse, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	add := addRADIUSClientToCommand(req)
+	creationDate, err := s.command.AddRADIUSClient(ctx, add, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddRADIUSClientResponse{
+		Id:      add.AggregateID,
+		Details: object_pb.AddToDetailsPb(0, creationDate, instanceID),
+	}, nil
+}
+
+func (s *Server) UpdateRADIUSClient(ctx context.Context, req *admin_pb.UpdateRADIUSClientRequest) (*admin_pb.UpdateRADIUSClientResponse, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	changeDate, err := s.command.ChangeRADIUSClient(ctx, updateRADIUSClientToCommand(req), instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateRADIUSClientResponse{
+		Details: object_pb.ChangeToDetailsPb(0, changeDate, instanceID),
+	}, nil
+}
+
+func (s *Server) RemoveRADIUSClient(ctx context.Context, req *admin_pb.RemoveRADIUSClientRequest) (*admin_pb.RemoveRADIUSClientResponse, error) {
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	changeDate, err := s.command.DeleteRADIUSClient(ctx, req.Id, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveRADIUSClientResponse{
+		Details: object_pb.ChangeToDetailsPb(0, changeDate, instanceID),
+	}, nil
+}
+
+func (s *Server) GetRADIUSClientByID(ctx context.Context, req *admin_pb.GetRADIUSClientByIDRequest) (*admin_pb.GetRADIUSClientByIDResponse, error) {
+	client, err := s.query.GetRADIUSClientByID(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetRADIUSClientByIDResponse{
+		Client: radiusClientToPb(client),
+	}, nil
+}
+
+func (s *Server) ListRADIUSClients(ctx context.Context, req *admin_pb.ListRADIUSClientsRequest) (*admin_pb.ListRADIUSClientsResponse, error) {
+	queries, err := listRADIUSClientsToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.query.SearchRADIUSClients(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListRADIUSClientsResponse{
+		Result:        radiusClientsToPb(resp.RADIUSClients),
+		SortingColumn: req.SortingColumn,
+		Details:       object_pb.ToListDetails(resp.Count, resp.Sequence, resp.LastRun),
+	}, nil
+}
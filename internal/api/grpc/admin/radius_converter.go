@@ -0,0 +1,160 @@
+package admin
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	radius_pb "github.com/zitadel/zitadel/pkg/grpc/radius"
+)
+
+func addRADIUSClientToCommand(req *admin_pb.AddRADIUSClientRequest) *command.AddRADIUSClient {
+	return &command.AddRADIUSClient{
+		Name:           req.Name,
+		Network:        req.Network,
+		NASIdentifier:  req.NasIdentifier,
+		Secret:         req.Secret,
+		ProjectID:      req.ProjectId,
+		RequireGrant:   req.RequireGrant,
+		RoleAttributes: radiusRoleAttributesToDomain(req.RoleAttributes),
+	}
+}
+
+func updateRADIUSClientToCommand(req *admin_pb.UpdateRADIUSClientRequest) *command.ChangeRADIUSClient {
+	change := &command.ChangeRADIUSClient{
+		Name:          req.Name,
+		Network:       req.Network,
+		NASIdentifier: req.NasIdentifier,
+		Secret:        req.Secret,
+		ProjectID:     req.ProjectId,
+		RequireGrant:  req.RequireGrant,
+	}
+	change.AggregateID = req.Id
+	if req.RoleAttributes != nil {
+		attributes := radiusRoleAttributesToDomain(req.RoleAttributes.Attributes)
+		change.RoleAttributes = &attributes
+	}
+	return change
+}
+
+func radiusRoleAttributesToDomain(attributes []*radius_pb.RoleAttribute) []*domain.RADIUSRoleAttribute {
+	if len(attributes) == 0 {
+		return nil
+	}
+	roleAttributes := make([]*domain.RADIUSRoleAttribute, len(attributes))
+	for i, attr := range attributes {
+		roleAttributes[i] = &domain.RADIUSRoleAttribute{
+			Role:       attr.Role,
+			Type:       uint8(attr.Type),
+			VendorID:   attr.VendorId,
+			VendorType: uint8(attr.VendorType),
+			Format:     radiusAttributeFormatToDomain(attr.Format),
+			Value:      attr.Value,
+		}
+	}
+	return roleAttributes
+}
+
+func radiusAttributeFormatToDomain(format radius_pb.AttributeFormat) domain.RADIUSAttributeFormat {
+	switch format {
+	case radius_pb.AttributeFormat_ATTRIBUTE_FORMAT_INTEGER:
+		return domain.RADIUSAttributeFormatInteger
+	default:
+		return domain.RADIUSAttributeFormatString
+	}
+}
+
+func radiusAttributeFormatToPb(format domain.RADIUSAttributeFormat) radius_pb.AttributeFormat {
+	switch format {
+	case domain.RADIUSAttributeFormatInteger:
+		return radius_pb.AttributeFormat_ATTRIBUTE_FORMAT_INTEGER
+	default:
+		return radius_pb.AttributeFormat_ATTRIBUTE_FORMAT_STRING
+	}
+}
+
+func listRADIUSClientsToModel(req *admin_pb.ListRADIUSClientsRequest) (*query.RADIUSClientSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := radiusClientQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.RADIUSClientSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: radiusClientFieldNameToSortingColumn(req.SortingColumn),
+		},
+		Queries: queries,
+	}, nil
+}
+
+func radiusClientQueriesToModel(queries []*radius_pb.RADIUSClientQuery) (q []query.SearchQuery, err error) {
+	q = make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = radiusClientQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func radiusClientQueryToModel(radiusClientQuery *radius_pb.RADIUSClientQuery) (query.SearchQuery, error) {
+	switch q := radiusClientQuery.Query.(type) {
+	case *radius_pb.RADIUSClientQuery_NameQuery:
+		return query.NewRADIUSClientNameSearchQuery(object.TextMethodToQuery(q.NameQuery.Method), q.NameQuery.Name)
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "ADMIN-Zr6xm", "List.Query.Invalid")
+	}
+}
+
+func radiusClientFieldNameToSortingColumn(field radius_pb.RADIUSClientFieldName) query.Column {
+	switch field {
+	case radius_pb.RADIUSClientFieldName_RADIUS_CLIENT_FIELD_NAME_NAME:
+		return query.RADIUSClientColumnName
+	case radius_pb.RADIUSClientFieldName_RADIUS_CLIENT_FIELD_NAME_CREATION_DATE:
+		return query.RADIUSClientColumnCreationDate
+	default:
+		return query.Column{}
+	}
+}
+
+func radiusClientsToPb(clients []*query.RADIUSClient) []*radius_pb.RADIUSClient {
+	resp := make([]*radius_pb.RADIUSClient, len(clients))
+	for i, client := range clients {
+		resp[i] = radiusClientToPb(client)
+	}
+	return resp
+}
+
+func radiusClientToPb(client *query.RADIUSClient) *radius_pb.RADIUSClient {
+	return &radius_pb.RADIUSClient{
+		Id:             client.ID,
+		Details:        object.ToViewDetailsPb(client.Sequence, client.CreationDate, client.EventDate, client.ResourceOwner),
+		Name:           client.Name,
+		Network:        client.Network,
+		NasIdentifier:  client.NASIdentifier,
+		ProjectId:      client.ProjectID,
+		RequireGrant:   client.RequireGrant,
+		RoleAttributes: radiusRoleAttributesToPb(client.RoleAttributes),
+	}
+}
+
+func radiusRoleAttributesToPb(attributes []*domain.RADIUSRoleAttribute) []*radius_pb.RoleAttribute {
+	roleAttributes := make([]*radius_pb.RoleAttribute, len(attributes))
+	for i, attr := range attributes {
+		roleAttributes[i] = &radius_pb.RoleAttribute{
+			Role:       attr.Role,
+			Type:       uint32(attr.Type),
+			VendorId:   attr.VendorID,
+			VendorType: uint32(attr.VendorType),
+			Format:     radiusAttributeFormatToPb(attr.Format),
+			Value:      attr.Value,
+		}
+	}
+	return roleAttributes
+}
@@ -75,6 +75,39 @@ func (s *Server) CreateApplication(ctx context.Context, req *connect.Request[app
 				SamlConfiguration: &application.CreateSAMLApplicationResponse{},
 			},
 		}), nil
+
+	case *application.CreateApplicationRequest_WsfedConfiguration:
+		wsFedApp, err := s.command.AddWSFedApplication(ctx, convert.CreateWSFedAppRequestToDomain(req.Msg.GetName(), req.Msg.GetProjectId(), req.Msg.GetWsfedConfiguration()), "")
+		if err != nil {
+			return nil, err
+		}
+
+		return connect.NewResponse(&application.CreateApplicationResponse{
+			ApplicationId: wsFedApp.AppID,
+			CreationDate:  timestamppb.New(wsFedApp.ChangeDate),
+			ApplicationType: &application.CreateApplicationResponse_WsfedConfiguration{
+				WsfedConfiguration: &application.CreateWSFedApplicationResponse{},
+			},
+		}), nil
+
+	case *application.CreateApplicationRequest_CasConfiguration:
+		casAppReq, err := convert.CreateCASAppRequestToDomain(req.Msg.GetName(), req.Msg.GetProjectId(), req.Msg.GetCasConfiguration())
+		if err != nil {
+			return nil, err
+		}
+
+		casApp, err := s.command.AddCASApplication(ctx, casAppReq, "")
+		if err != nil {
+			return nil, err
+		}
+
+		return connect.NewResponse(&application.CreateApplicationResponse{
+			ApplicationId: casApp.AppID,
+			CreationDate:  timestamppb.New(casApp.ChangeDate),
+			ApplicationType: &application.CreateApplicationResponse_CasConfiguration{
+				CasConfiguration: &application.CreateCASApplicationResponse{},
+			},
+		}), nil
 	default:
 		return nil, zerrors.ThrowInvalidArgument(nil, "APP-0iiN46", "unknown app type")
 	}
@@ -134,6 +167,27 @@ func (s *Server) UpdateApplication(ctx context.Context, req *connect.Request[app
 		}
 
 		changedTime = updatedSAMLApp.ChangeDate
+
+	case *application.UpdateApplicationRequest_WsfedConfiguration:
+		updatedWSFedApp, err := s.command.UpdateWSFedApplication(ctx, convert.UpdateWSFedAppConfigRequestToDomain(req.Msg.GetApplicationId(), req.Msg.GetProjectId(), t.WsfedConfiguration), "")
+		if err != nil {
+			return nil, err
+		}
+
+		changedTime = updatedWSFedApp.ChangeDate
+
+	case *application.UpdateApplicationRequest_CasConfiguration:
+		casApp, err := convert.UpdateCASAppConfigRequestToDomain(req.Msg.GetApplicationId(), req.Msg.GetProjectId(), t.CasConfiguration)
+		if err != nil {
+			return nil, err
+		}
+
+		updatedCASApp, err := s.command.UpdateCASApplication(ctx, casApp, "")
+		if err != nil {
+			return nil, err
+		}
+
+		changedTime = updatedCASApp.ChangeDate
 	}
 
 	return connect.NewResponse(&application.UpdateApplicationResponse{
@@ -1,6 +1,8 @@
 package convert
 
 import (
+	"github.com/muhlemmer/gu"
+
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/query"
@@ -15,6 +17,7 @@ func CreateAPIApplicationRequestToDomain(name, projectID, appID string, app *app
 		AppName:        name,
 		AppID:          appID,
 		AuthMethodType: apiAuthMethodTypeToDomain(app.GetAuthMethodType()),
+		ResourceURI:    gu.Ptr(app.GetResourceUri()),
 	}
 }
 
@@ -25,6 +28,10 @@ func UpdateAPIApplicationConfigurationRequestToDomain(appID, projectID string, a
 		},
 		AppID:          appID,
 		AuthMethodType: apiAuthMethodTypeToDomain(app.GetAuthMethodType()),
+		ResourceURI:    app.ResourceUri,
+
+		IntrospectionSignedResponse: app.IntrospectionSignedResponse,
+		IntrospectionEncryptionKey:  app.IntrospectionEncryptionKey,
 	}
 }
 
@@ -33,6 +40,10 @@ func appAPIConfigToPb(apiApp *query.APIApp) application.IsApplicationConfigurati
 		ApiConfiguration: &application.APIConfiguration{
 			ClientId:       apiApp.ClientID,
 			AuthMethodType: apiAuthMethodTypeToPb(apiApp.AuthMethodType),
+			ResourceUri:    apiApp.ResourceURI,
+
+			IntrospectionSignedResponse: apiApp.IntrospectionSignedResponse,
+			IntrospectionEncryptionKey:  apiApp.IntrospectionEncryptionKey,
 		},
 	}
 }
@@ -3,6 +3,7 @@ package convert
 import (
 	"testing"
 
+	"github.com/muhlemmer/gu"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/domain"
@@ -34,6 +35,7 @@ func TestCreateAPIApplicationRequestToDomain(t *testing.T) {
 				AppName:        "my-application",
 				AuthMethodType: domain.APIAuthMethodTypeBasic,
 				AppID:          "someID",
+				ResourceURI:    gu.Ptr(""),
 			},
 		},
 		{
@@ -42,11 +44,13 @@ func TestCreateAPIApplicationRequestToDomain(t *testing.T) {
 			projectID: "proj-2",
 			req: &application.CreateAPIApplicationRequest{
 				AuthMethodType: application.APIAuthMethodType_API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT,
+				ResourceUri:    "https://api.example.com/orders",
 			},
 			want: &domain.APIApp{
 				ObjectRoot:     models.ObjectRoot{AggregateID: "proj-2"},
 				AppName:        "jwt-application",
 				AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+				ResourceURI:    gu.Ptr("https://api.example.com/orders"),
 			},
 		},
 	}
@@ -98,6 +102,38 @@ func TestUpdateAPIApplicationConfigurationRequestToDomain(t *testing.T) {
 				AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
 			},
 		},
+		{
+			name:      "resource uri",
+			appID:     "application-3",
+			projectID: "proj-3",
+			req: &application.UpdateAPIApplicationConfigurationRequest{
+				AuthMethodType: application.APIAuthMethodType_API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT,
+				ResourceUri:    gu.Ptr("https://api.example.com/orders"),
+			},
+			want: &domain.APIApp{
+				ObjectRoot:     models.ObjectRoot{AggregateID: "proj-3"},
+				AppID:          "application-3",
+				AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+				ResourceURI:    gu.Ptr("https://api.example.com/orders"),
+			},
+		},
+		{
+			name:      "introspection response",
+			appID:     "application-4",
+			projectID: "proj-4",
+			req: &application.UpdateAPIApplicationConfigurationRequest{
+				AuthMethodType:              application.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC,
+				IntrospectionSignedResponse: gu.Ptr(true),
+				IntrospectionEncryptionKey:  []byte("key"),
+			},
+			want: &domain.APIApp{
+				ObjectRoot:                  models.ObjectRoot{AggregateID: "proj-4"},
+				AppID:                       "application-4",
+				AuthMethodType:              domain.APIAuthMethodTypeBasic,
+				IntrospectionSignedResponse: gu.Ptr(true),
+				IntrospectionEncryptionKey:  []byte("key"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,62 @@
+package convert
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/application/v2"
+)
+
+func CreateCASAppRequestToDomain(name, projectID string, req *application.CreateCASApplicationRequest) (*domain.CASApp, error) {
+	loginVersion, loginBaseURI, err := loginVersionToDomain(req.GetLoginVersion())
+	if err != nil {
+		return nil, err
+	}
+	return &domain.CASApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: projectID,
+		},
+		AppName:      name,
+		ServiceURLs:  req.GetServiceUrls(),
+		LoginVersion: loginVersion,
+		LoginBaseURI: loginBaseURI,
+	}, nil
+}
+
+// UpdateCASAppConfigRequestToDomain keeps the service urls and login version empty if they are not set,
+// the command then keeps the current values.
+func UpdateCASAppConfigRequestToDomain(appID, projectID string, app *application.UpdateCASApplicationConfigurationRequest) (*domain.CASApp, error) {
+	var (
+		loginVersion *domain.LoginVersion
+		loginBaseURI *string
+		err          error
+	)
+	if app.LoginVersion != nil {
+		loginVersion, loginBaseURI, err = loginVersionToDomain(app.GetLoginVersion())
+		if err != nil {
+			return nil, err
+		}
+	}
+	var serviceURLs []string
+	if app.ServiceUrls != nil {
+		serviceURLs = app.GetServiceUrls().GetServiceUrls()
+	}
+	return &domain.CASApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: projectID,
+		},
+		AppID:        appID,
+		ServiceURLs:  serviceURLs,
+		LoginVersion: loginVersion,
+		LoginBaseURI: loginBaseURI,
+	}, nil
+}
+
+func appCASConfigToPb(casApp *query.CASApp) application.IsApplicationConfiguration {
+	return &application.Application_CasConfiguration{
+		CasConfiguration: &application.CASConfiguration{
+			ServiceUrls:  casApp.ServiceURLs,
+			LoginVersion: loginVersionToPb(casApp.LoginVersion, casApp.LoginBaseURI),
+		},
+	}
+}
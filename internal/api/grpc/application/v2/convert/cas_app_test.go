@@ -0,0 +1,102 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/application/v2"
+)
+
+func TestCreateCASAppRequestToDomain(t *testing.T) {
+	t.Parallel()
+
+	req := &application.CreateCASApplicationRequest{
+		ServiceUrls: []string{"https://moodle.test.com/login/"},
+		LoginVersion: &application.LoginVersion{
+			Version: &application.LoginVersion_LoginV2{LoginV2: &application.LoginV2{BaseUri: gu.Ptr("https://login.test.com")}},
+		},
+	}
+
+	res, err := CreateCASAppRequestToDomain("test-application", "proj-1", req)
+
+	require.NoError(t, err)
+	assert.Equal(t, &domain.CASApp{
+		ObjectRoot:   models.ObjectRoot{AggregateID: "proj-1"},
+		AppName:      "test-application",
+		ServiceURLs:  []string{"https://moodle.test.com/login/"},
+		LoginVersion: gu.Ptr(domain.LoginVersion2),
+		LoginBaseURI: gu.Ptr("https://login.test.com"),
+	}, res)
+}
+
+func TestUpdateCASAppConfigRequestToDomain(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		testName string
+		req      *application.UpdateCASApplicationConfigurationRequest
+
+		expectedResponse *domain.CASApp
+	}{
+		{
+			testName: "nothing set",
+			req:      &application.UpdateCASApplicationConfigurationRequest{},
+			expectedResponse: &domain.CASApp{
+				ObjectRoot: models.ObjectRoot{AggregateID: "proj-1"},
+				AppID:      "app-1",
+			},
+		},
+		{
+			testName: "all set",
+			req: &application.UpdateCASApplicationConfigurationRequest{
+				ServiceUrls: &application.CASServiceURLs{ServiceUrls: []string{"https://moodle.test.com/login/"}},
+				LoginVersion: &application.LoginVersion{
+					Version: &application.LoginVersion_LoginV1{LoginV1: &application.LoginV1{}},
+				},
+			},
+			expectedResponse: &domain.CASApp{
+				ObjectRoot:   models.ObjectRoot{AggregateID: "proj-1"},
+				AppID:        "app-1",
+				ServiceURLs:  []string{"https://moodle.test.com/login/"},
+				LoginVersion: gu.Ptr(domain.LoginVersion1),
+				LoginBaseURI: gu.Ptr(""),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			res, err := UpdateCASAppConfigRequestToDomain("app-1", "proj-1", tc.req)
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedResponse, res)
+		})
+	}
+}
+
+func TestAppCASConfigToPb(t *testing.T) {
+	t.Parallel()
+
+	res := appCASConfigToPb(&query.CASApp{
+		ServiceURLs:  []string{"https://moodle.test.com/login/"},
+		LoginVersion: domain.LoginVersion2,
+		LoginBaseURI: gu.Ptr("https://login.test.com"),
+	})
+
+	assert.Equal(t, &application.Application_CasConfiguration{
+		CasConfiguration: &application.CASConfiguration{
+			ServiceUrls: []string{"https://moodle.test.com/login/"},
+			LoginVersion: &application.LoginVersion{
+				Version: &application.LoginVersion_LoginV2{LoginV2: &application.LoginV2{BaseUri: gu.Ptr("https://login.test.com")}},
+			},
+		},
+	}, res)
+}
@@ -103,6 +103,12 @@ func appConfigToPb(app *query.App) application.IsApplicationConfiguration {
 	if app.SAMLConfig != nil {
 		return appSAMLConfigToPb(app.SAMLConfig)
 	}
+	if app.WSFedConfig != nil {
+		return appWSFedConfigToPb(app.WSFedConfig)
+	}
+	if app.CASConfig != nil {
+		return appCASConfigToPb(app.CASConfig)
+	}
 	return appAPIConfigToPb(app.APIConfig)
 }
 
@@ -171,6 +177,10 @@ func applicationTypeFilterToQuery(t application.ApplicationType) (*query.NotNull
 		return query.NewNotNullQuery(query.AppAPIConfigColumnAppID)
 	case application.ApplicationType_APPLICATION_TYPE_SAML:
 		return query.NewNotNullQuery(query.AppSAMLConfigColumnAppID)
+	case application.ApplicationType_APPLICATION_TYPE_WS_FED:
+		return query.NewNotNullQuery(query.AppWSFedConfigColumnAppID)
+	case application.ApplicationType_APPLICATION_TYPE_CAS:
+		return query.NewNotNullQuery(query.AppCASConfigColumnAppID)
 	case application.ApplicationType_APPLICATION_TYPE_UNSPECIFIED:
 		return nil, zerrors.ThrowInvalidArgument(nil, "CONV-Jke83s", "List.Query.Invalid")
 	default:
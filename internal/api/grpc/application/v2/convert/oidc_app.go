@@ -1,6 +1,8 @@
 package convert
 
 import (
+	"time"
+
 	"github.com/muhlemmer/gu"
 	"google.golang.org/protobuf/types/known/durationpb"
 
@@ -19,26 +21,36 @@ func CreateOIDCAppRequestToDomain(name, appID, projectID string, req *applicatio
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: projectID,
 		},
-		AppID:                    appID,
-		AppName:                  name,
-		OIDCVersion:              gu.Ptr(domain.OIDCVersionV1),
-		RedirectUris:             req.GetRedirectUris(),
-		ResponseTypes:            oidcResponseTypesToDomain(req.GetResponseTypes()),
-		GrantTypes:               oidcGrantTypesToDomain(req.GetGrantTypes()),
-		ApplicationType:          gu.Ptr(oidcApplicationTypeToDomain(req.GetApplicationType())),
-		AuthMethodType:           gu.Ptr(oidcAuthMethodTypeToDomain(req.GetAuthMethodType())),
-		PostLogoutRedirectUris:   req.GetPostLogoutRedirectUris(),
-		DevMode:                  &req.DevelopmentMode,
-		AccessTokenType:          gu.Ptr(oidcTokenTypeToDomain(req.GetAccessTokenType())),
-		AccessTokenRoleAssertion: gu.Ptr(req.GetAccessTokenRoleAssertion()),
-		IDTokenRoleAssertion:     gu.Ptr(req.GetIdTokenRoleAssertion()),
-		IDTokenUserinfoAssertion: gu.Ptr(req.GetIdTokenUserinfoAssertion()),
-		ClockSkew:                gu.Ptr(req.GetClockSkew().AsDuration()),
-		AdditionalOrigins:        req.GetAdditionalOrigins(),
-		SkipNativeAppSuccessPage: gu.Ptr(req.GetSkipNativeAppSuccessPage()),
-		BackChannelLogoutURI:     gu.Ptr(req.GetBackChannelLogoutUri()),
-		LoginVersion:             loginVersion,
-		LoginBaseURI:             loginBaseURI,
+		AppID:                        appID,
+		AppName:                      name,
+		OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+		RedirectUris:                 req.GetRedirectUris(),
+		ResponseTypes:                oidcResponseTypesToDomain(req.GetResponseTypes()),
+		GrantTypes:                   oidcGrantTypesToDomain(req.GetGrantTypes()),
+		ApplicationType:              gu.Ptr(oidcApplicationTypeToDomain(req.GetApplicationType())),
+		AuthMethodType:               gu.Ptr(oidcAuthMethodTypeToDomain(req.GetAuthMethodType())),
+		PostLogoutRedirectUris:       req.GetPostLogoutRedirectUris(),
+		DevMode:                      &req.DevelopmentMode,
+		AccessTokenType:              gu.Ptr(oidcTokenTypeToDomain(req.GetAccessTokenType())),
+		AccessTokenRoleAssertion:     gu.Ptr(req.GetAccessTokenRoleAssertion()),
+		IDTokenRoleAssertion:         gu.Ptr(req.GetIdTokenRoleAssertion()),
+		IDTokenUserinfoAssertion:     gu.Ptr(req.GetIdTokenUserinfoAssertion()),
+		ClockSkew:                    gu.Ptr(req.GetClockSkew().AsDuration()),
+		AdditionalOrigins:            req.GetAdditionalOrigins(),
+		SkipNativeAppSuccessPage:     gu.Ptr(req.GetSkipNativeAppSuccessPage()),
+		BackChannelLogoutURI:         gu.Ptr(req.GetBackChannelLogoutUri()),
+		LoginVersion:                 loginVersion,
+		LoginBaseURI:                 loginBaseURI,
+		RefreshTokenRotation:         gu.Ptr(oidcRefreshTokenRotationToDomain(req.GetRefreshTokenRotation())),
+		RefreshTokenLifetime:         gu.Ptr(req.GetRefreshTokenLifetime().AsDuration()),
+		RefreshTokenIdleLifetime:     gu.Ptr(req.GetRefreshTokenIdleLifetime().AsDuration()),
+		IDTokenEncryptedResponseAlg:  gu.Ptr(req.GetIdTokenEncryptedResponseAlg()),
+		IDTokenEncryptedResponseEnc:  gu.Ptr(req.GetIdTokenEncryptedResponseEnc()),
+		UserinfoEncryptedResponseAlg: gu.Ptr(req.GetUserinfoEncryptedResponseAlg()),
+		UserinfoEncryptedResponseEnc: gu.Ptr(req.GetUserinfoEncryptedResponseEnc()),
+		JWKS:                         gu.Ptr(req.GetJwks()),
+		JWKSURI:                      gu.Ptr(req.GetJwksUri()),
+		FrontChannelLogoutURI:        gu.Ptr(req.GetFrontChannelLogoutUri()),
 	}, nil
 }
 
@@ -51,24 +63,34 @@ func UpdateOIDCAppConfigRequestToDomain(appID, projectID string, app *applicatio
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: projectID,
 		},
-		AppID:                    appID,
-		RedirectUris:             app.RedirectUris,
-		ResponseTypes:            oidcResponseTypesToDomain(app.ResponseTypes),
-		GrantTypes:               oidcGrantTypesToDomain(app.GrantTypes),
-		ApplicationType:          oidcApplicationTypeToDomainPtr(app.ApplicationType),
-		AuthMethodType:           oidcAuthMethodTypeToDomainPtr(app.AuthMethodType),
-		PostLogoutRedirectUris:   app.PostLogoutRedirectUris,
-		DevMode:                  app.DevelopmentMode,
-		AccessTokenType:          oidcTokenTypeToDomainPtr(app.AccessTokenType),
-		AccessTokenRoleAssertion: app.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     app.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: app.IdTokenUserinfoAssertion,
-		ClockSkew:                gu.Ptr(app.GetClockSkew().AsDuration()),
-		AdditionalOrigins:        app.AdditionalOrigins,
-		SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
-		BackChannelLogoutURI:     app.BackChannelLogoutUri,
-		LoginVersion:             loginVersion,
-		LoginBaseURI:             loginBaseURI,
+		AppID:                        appID,
+		RedirectUris:                 app.RedirectUris,
+		ResponseTypes:                oidcResponseTypesToDomain(app.ResponseTypes),
+		GrantTypes:                   oidcGrantTypesToDomain(app.GrantTypes),
+		ApplicationType:              oidcApplicationTypeToDomainPtr(app.ApplicationType),
+		AuthMethodType:               oidcAuthMethodTypeToDomainPtr(app.AuthMethodType),
+		PostLogoutRedirectUris:       app.PostLogoutRedirectUris,
+		DevMode:                      app.DevelopmentMode,
+		AccessTokenType:              oidcTokenTypeToDomainPtr(app.AccessTokenType),
+		AccessTokenRoleAssertion:     app.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:         app.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:     app.IdTokenUserinfoAssertion,
+		ClockSkew:                    gu.Ptr(app.GetClockSkew().AsDuration()),
+		AdditionalOrigins:            app.AdditionalOrigins,
+		SkipNativeAppSuccessPage:     app.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:         app.BackChannelLogoutUri,
+		LoginVersion:                 loginVersion,
+		LoginBaseURI:                 loginBaseURI,
+		RefreshTokenRotation:         oidcRefreshTokenRotationToDomainPtr(app.RefreshTokenRotation),
+		RefreshTokenLifetime:         durationToDomainPtr(app.RefreshTokenLifetime),
+		RefreshTokenIdleLifetime:     durationToDomainPtr(app.RefreshTokenIdleLifetime),
+		IDTokenEncryptedResponseAlg:  app.IdTokenEncryptedResponseAlg,
+		IDTokenEncryptedResponseEnc:  app.IdTokenEncryptedResponseEnc,
+		UserinfoEncryptedResponseAlg: app.UserinfoEncryptedResponseAlg,
+		UserinfoEncryptedResponseEnc: app.UserinfoEncryptedResponseEnc,
+		JWKS:                         app.Jwks,
+		JWKSURI:                      app.JwksUri,
+		FrontChannelLogoutURI:        app.FrontChannelLogoutUri,
 	}, nil
 }
 
@@ -180,6 +202,35 @@ func oidcTokenTypeToDomain(tokenType application.OIDCTokenType) domain.OIDCToken
 	}
 }
 
+func oidcRefreshTokenRotationToDomainPtr(rotation *application.OIDCRefreshTokenRotation) *domain.OIDCRefreshTokenRotation {
+	if rotation == nil {
+		return nil
+	}
+
+	res := oidcRefreshTokenRotationToDomain(*rotation)
+	return &res
+}
+
+func oidcRefreshTokenRotationToDomain(rotation application.OIDCRefreshTokenRotation) domain.OIDCRefreshTokenRotation {
+	switch rotation {
+	case application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_UNSPECIFIED:
+		return domain.OIDCRefreshTokenRotationUnspecified
+	case application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ON_EVERY_USE:
+		return domain.OIDCRefreshTokenRotationOnEveryUse
+	case application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_NEVER:
+		return domain.OIDCRefreshTokenRotationNever
+	default:
+		return domain.OIDCRefreshTokenRotationUnspecified
+	}
+}
+
+func durationToDomainPtr(d *durationpb.Duration) *time.Duration {
+	if d == nil {
+		return nil
+	}
+	return gu.Ptr(d.AsDuration())
+}
+
 func ComplianceProblemsToLocalizedMessages(complianceProblems []string) []*application.OIDCLocalizedMessage {
 	converted := make([]*application.OIDCLocalizedMessage, len(complianceProblems))
 	for i, p := range complianceProblems {
@@ -192,27 +243,37 @@ func ComplianceProblemsToLocalizedMessages(complianceProblems []string) []*appli
 func appOIDCConfigToPb(oidcApp *query.OIDCApp) *application.Application_OidcConfiguration {
 	return &application.Application_OidcConfiguration{
 		OidcConfiguration: &application.OIDCConfiguration{
-			RedirectUris:             oidcApp.RedirectURIs,
-			ResponseTypes:            oidcResponseTypesFromModel(oidcApp.ResponseTypes),
-			GrantTypes:               oidcGrantTypesFromModel(oidcApp.GrantTypes),
-			ApplicationType:          oidcApplicationTypeToPb(oidcApp.AppType),
-			ClientId:                 oidcApp.ClientID,
-			AuthMethodType:           oidcAuthMethodTypeToPb(oidcApp.AuthMethodType),
-			PostLogoutRedirectUris:   oidcApp.PostLogoutRedirectURIs,
-			Version:                  application.OIDCVersion_OIDC_VERSION_1_0,
-			NonCompliant:             len(oidcApp.ComplianceProblems) != 0,
-			ComplianceProblems:       ComplianceProblemsToLocalizedMessages(oidcApp.ComplianceProblems),
-			DevelopmentMode:          oidcApp.IsDevMode,
-			AccessTokenType:          oidcTokenTypeToPb(oidcApp.AccessTokenType),
-			AccessTokenRoleAssertion: oidcApp.AssertAccessTokenRole,
-			IdTokenRoleAssertion:     oidcApp.AssertIDTokenRole,
-			IdTokenUserinfoAssertion: oidcApp.AssertIDTokenUserinfo,
-			ClockSkew:                durationpb.New(oidcApp.ClockSkew),
-			AdditionalOrigins:        oidcApp.AdditionalOrigins,
-			AllowedOrigins:           oidcApp.AllowedOrigins,
-			SkipNativeAppSuccessPage: oidcApp.SkipNativeAppSuccessPage,
-			BackChannelLogoutUri:     oidcApp.BackChannelLogoutURI,
-			LoginVersion:             loginVersionToPb(oidcApp.LoginVersion, oidcApp.LoginBaseURI),
+			RedirectUris:                 oidcApp.RedirectURIs,
+			ResponseTypes:                oidcResponseTypesFromModel(oidcApp.ResponseTypes),
+			GrantTypes:                   oidcGrantTypesFromModel(oidcApp.GrantTypes),
+			ApplicationType:              oidcApplicationTypeToPb(oidcApp.AppType),
+			ClientId:                     oidcApp.ClientID,
+			AuthMethodType:               oidcAuthMethodTypeToPb(oidcApp.AuthMethodType),
+			PostLogoutRedirectUris:       oidcApp.PostLogoutRedirectURIs,
+			Version:                      application.OIDCVersion_OIDC_VERSION_1_0,
+			NonCompliant:                 len(oidcApp.ComplianceProblems) != 0,
+			ComplianceProblems:           ComplianceProblemsToLocalizedMessages(oidcApp.ComplianceProblems),
+			DevelopmentMode:              oidcApp.IsDevMode,
+			AccessTokenType:              oidcTokenTypeToPb(oidcApp.AccessTokenType),
+			AccessTokenRoleAssertion:     oidcApp.AssertAccessTokenRole,
+			IdTokenRoleAssertion:         oidcApp.AssertIDTokenRole,
+			IdTokenUserinfoAssertion:     oidcApp.AssertIDTokenUserinfo,
+			ClockSkew:                    durationpb.New(oidcApp.ClockSkew),
+			AdditionalOrigins:            oidcApp.AdditionalOrigins,
+			AllowedOrigins:               oidcApp.AllowedOrigins,
+			SkipNativeAppSuccessPage:     oidcApp.SkipNativeAppSuccessPage,
+			BackChannelLogoutUri:         oidcApp.BackChannelLogoutURI,
+			LoginVersion:                 loginVersionToPb(oidcApp.LoginVersion, oidcApp.LoginBaseURI),
+			RefreshTokenRotation:         oidcRefreshTokenRotationToPb(oidcApp.RefreshTokenRotation),
+			RefreshTokenLifetime:         durationpb.New(oidcApp.RefreshTokenLifetime),
+			RefreshTokenIdleLifetime:     durationpb.New(oidcApp.RefreshTokenIdleLifetime),
+			IdTokenEncryptedResponseAlg:  oidcApp.IDTokenEncryptedResponseAlg,
+			IdTokenEncryptedResponseEnc:  oidcApp.IDTokenEncryptedResponseEnc,
+			UserinfoEncryptedResponseAlg: oidcApp.UserinfoEncryptedResponseAlg,
+			UserinfoEncryptedResponseEnc: oidcApp.UserinfoEncryptedResponseEnc,
+			Jwks:                         oidcApp.JWKS,
+			JwksUri:                      oidcApp.JWKSURI,
+			FrontChannelLogoutUri:        oidcApp.FrontChannelLogoutURI,
 		},
 	}
 }
@@ -291,3 +352,16 @@ func oidcTokenTypeToPb(tokenType domain.OIDCTokenType) application.OIDCTokenType
 		return application.OIDCTokenType_OIDC_TOKEN_TYPE_BEARER
 	}
 }
+
+func oidcRefreshTokenRotationToPb(rotation domain.OIDCRefreshTokenRotation) application.OIDCRefreshTokenRotation {
+	switch rotation {
+	case domain.OIDCRefreshTokenRotationUnspecified:
+		return application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_UNSPECIFIED
+	case domain.OIDCRefreshTokenRotationOnEveryUse:
+		return application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ON_EVERY_USE
+	case domain.OIDCRefreshTokenRotationNever:
+		return application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_NEVER
+	default:
+		return application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_UNSPECIFIED
+	}
+}
@@ -66,29 +66,47 @@ func TestCreateOIDCAppRequestToDomain(t *testing.T) {
 				LoginVersion: &application.LoginVersion{Version: &application.LoginVersion_LoginV2{LoginV2: &application.LoginV2{
 					BaseUri: gu.Ptr("https://login"),
 				}}},
+				RefreshTokenRotation:         application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ON_EVERY_USE,
+				RefreshTokenLifetime:         durationpb.New(720 * time.Hour),
+				RefreshTokenIdleLifetime:     durationpb.New(24 * time.Hour),
+				IdTokenEncryptedResponseAlg:  "RSA-OAEP-256",
+				UserinfoEncryptedResponseAlg: "ECDH-ES+A256KW",
+				UserinfoEncryptedResponseEnc: "A256GCM",
+				JwksUri:                      "https://jwks",
+				FrontChannelLogoutUri:        "https://frontchannel",
 			},
 			expectedModel: &domain.OIDCApp{
-				ObjectRoot:               models.ObjectRoot{AggregateID: "project1"},
-				AppName:                  "all fields set",
-				AppID:                    "app1",
-				OIDCVersion:              gu.Ptr(domain.OIDCVersionV1),
-				RedirectUris:             []string{"https://redirect"},
-				ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-				GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-				ApplicationType:          gu.Ptr(domain.OIDCApplicationTypeWeb),
-				AuthMethodType:           gu.Ptr(domain.OIDCAuthMethodTypeBasic),
-				PostLogoutRedirectUris:   []string{"https://logout"},
-				DevMode:                  gu.Ptr(true),
-				AccessTokenType:          gu.Ptr(domain.OIDCTokenTypeBearer),
-				AccessTokenRoleAssertion: gu.Ptr(true),
-				IDTokenRoleAssertion:     gu.Ptr(true),
-				IDTokenUserinfoAssertion: gu.Ptr(true),
-				ClockSkew:                gu.Ptr(5 * time.Second),
-				AdditionalOrigins:        []string{"https://origin"},
-				SkipNativeAppSuccessPage: gu.Ptr(true),
-				BackChannelLogoutURI:     gu.Ptr("https://backchannel"),
-				LoginVersion:             gu.Ptr(domain.LoginVersion2),
-				LoginBaseURI:             gu.Ptr("https://login"),
+				ObjectRoot:                   models.ObjectRoot{AggregateID: "project1"},
+				AppName:                      "all fields set",
+				AppID:                        "app1",
+				OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+				RedirectUris:                 []string{"https://redirect"},
+				ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeWeb),
+				AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypeBasic),
+				PostLogoutRedirectUris:       []string{"https://logout"},
+				DevMode:                      gu.Ptr(true),
+				AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+				AccessTokenRoleAssertion:     gu.Ptr(true),
+				IDTokenRoleAssertion:         gu.Ptr(true),
+				IDTokenUserinfoAssertion:     gu.Ptr(true),
+				ClockSkew:                    gu.Ptr(5 * time.Second),
+				AdditionalOrigins:            []string{"https://origin"},
+				SkipNativeAppSuccessPage:     gu.Ptr(true),
+				BackChannelLogoutURI:         gu.Ptr("https://backchannel"),
+				LoginVersion:                 gu.Ptr(domain.LoginVersion2),
+				LoginBaseURI:                 gu.Ptr("https://login"),
+				RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationOnEveryUse),
+				RefreshTokenLifetime:         gu.Ptr(720 * time.Hour),
+				RefreshTokenIdleLifetime:     gu.Ptr(24 * time.Hour),
+				IDTokenEncryptedResponseAlg:  gu.Ptr("RSA-OAEP-256"),
+				IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+				UserinfoEncryptedResponseAlg: gu.Ptr("ECDH-ES+A256KW"),
+				UserinfoEncryptedResponseEnc: gu.Ptr("A256GCM"),
+				JWKS:                         gu.Ptr(""),
+				JWKSURI:                      gu.Ptr("https://jwks"),
+				FrontChannelLogoutURI:        gu.Ptr("https://frontchannel"),
 			},
 		},
 	}
@@ -159,27 +177,37 @@ func TestUpdateOIDCAppConfigRequestToDomain(t *testing.T) {
 				LoginVersion: &application.LoginVersion{Version: &application.LoginVersion_LoginV2{
 					LoginV2: &application.LoginV2{BaseUri: gu.Ptr("https://login")},
 				}},
+				RefreshTokenRotation:        gu.Ptr(application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_NEVER),
+				RefreshTokenIdleLifetime:    durationpb.New(24 * time.Hour),
+				IdTokenEncryptedResponseAlg: gu.Ptr(""),
+				Jwks:                        gu.Ptr(`{"keys":[]}`),
+				FrontChannelLogoutUri:       gu.Ptr("https://frontchannel"),
 			},
 			expectedModel: &domain.OIDCApp{
-				ObjectRoot:               models.ObjectRoot{AggregateID: "proj1"},
-				AppID:                    "app1",
-				RedirectUris:             []string{"https://redirect"},
-				ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-				GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-				ApplicationType:          gu.Ptr(domain.OIDCApplicationTypeWeb),
-				AuthMethodType:           gu.Ptr(domain.OIDCAuthMethodTypeBasic),
-				PostLogoutRedirectUris:   []string{"https://logout"},
-				DevMode:                  gu.Ptr(true),
-				AccessTokenType:          gu.Ptr(domain.OIDCTokenTypeBearer),
-				AccessTokenRoleAssertion: gu.Ptr(true),
-				IDTokenRoleAssertion:     gu.Ptr(true),
-				IDTokenUserinfoAssertion: gu.Ptr(true),
-				ClockSkew:                gu.Ptr(5 * time.Second),
-				AdditionalOrigins:        []string{"https://origin"},
-				SkipNativeAppSuccessPage: gu.Ptr(true),
-				BackChannelLogoutURI:     gu.Ptr("https://backchannel"),
-				LoginVersion:             gu.Ptr(domain.LoginVersion2),
-				LoginBaseURI:             gu.Ptr("https://login"),
+				ObjectRoot:                  models.ObjectRoot{AggregateID: "proj1"},
+				AppID:                       "app1",
+				RedirectUris:                []string{"https://redirect"},
+				ResponseTypes:               []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:                  []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				ApplicationType:             gu.Ptr(domain.OIDCApplicationTypeWeb),
+				AuthMethodType:              gu.Ptr(domain.OIDCAuthMethodTypeBasic),
+				PostLogoutRedirectUris:      []string{"https://logout"},
+				DevMode:                     gu.Ptr(true),
+				AccessTokenType:             gu.Ptr(domain.OIDCTokenTypeBearer),
+				AccessTokenRoleAssertion:    gu.Ptr(true),
+				IDTokenRoleAssertion:        gu.Ptr(true),
+				IDTokenUserinfoAssertion:    gu.Ptr(true),
+				ClockSkew:                   gu.Ptr(5 * time.Second),
+				AdditionalOrigins:           []string{"https://origin"},
+				SkipNativeAppSuccessPage:    gu.Ptr(true),
+				BackChannelLogoutURI:        gu.Ptr("https://backchannel"),
+				LoginVersion:                gu.Ptr(domain.LoginVersion2),
+				LoginBaseURI:                gu.Ptr("https://login"),
+				RefreshTokenRotation:        gu.Ptr(domain.OIDCRefreshTokenRotationNever),
+				RefreshTokenIdleLifetime:    gu.Ptr(24 * time.Hour),
+				IDTokenEncryptedResponseAlg: gu.Ptr(""),
+				JWKS:                        gu.Ptr(`{"keys":[]}`),
+				FrontChannelLogoutURI:       gu.Ptr("https://frontchannel"),
 			},
 		},
 	}
@@ -442,37 +470,47 @@ func TestAppOIDCConfigToPb(t *testing.T) {
 			input: &query.OIDCApp{},
 			expected: &application.Application_OidcConfiguration{
 				OidcConfiguration: &application.OIDCConfiguration{
-					Version:            application.OIDCVersion_OIDC_VERSION_1_0,
-					ComplianceProblems: []*application.OIDCLocalizedMessage{},
-					ClockSkew:          durationpb.New(0),
-					ResponseTypes:      []application.OIDCResponseType{},
-					GrantTypes:         []application.OIDCGrantType{},
+					Version:                  application.OIDCVersion_OIDC_VERSION_1_0,
+					ComplianceProblems:       []*application.OIDCLocalizedMessage{},
+					ClockSkew:                durationpb.New(0),
+					ResponseTypes:            []application.OIDCResponseType{},
+					GrantTypes:               []application.OIDCGrantType{},
+					RefreshTokenLifetime:     durationpb.New(0),
+					RefreshTokenIdleLifetime: durationpb.New(0),
 				},
 			},
 		},
 		{
 			name: "full config",
 			input: &query.OIDCApp{
-				RedirectURIs:             []string{"https://example.com/callback"},
-				ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-				GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-				AppType:                  domain.OIDCApplicationTypeWeb,
-				ClientID:                 "client123",
-				AuthMethodType:           domain.OIDCAuthMethodTypeBasic,
-				PostLogoutRedirectURIs:   []string{"https://example.com/logout"},
-				ComplianceProblems:       []string{"problem1", "problem2"},
-				IsDevMode:                true,
-				AccessTokenType:          domain.OIDCTokenTypeBearer,
-				AssertAccessTokenRole:    true,
-				AssertIDTokenRole:        true,
-				AssertIDTokenUserinfo:    true,
-				ClockSkew:                5 * time.Second,
-				AdditionalOrigins:        []string{"https://app.example.com"},
-				AllowedOrigins:           []string{"https://allowed.example.com"},
-				SkipNativeAppSuccessPage: true,
-				BackChannelLogoutURI:     "https://example.com/backchannel",
-				LoginVersion:             domain.LoginVersion2,
-				LoginBaseURI:             gu.Ptr("https://login.example.com"),
+				RedirectURIs:                 []string{"https://example.com/callback"},
+				ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				AppType:                      domain.OIDCApplicationTypeWeb,
+				ClientID:                     "client123",
+				AuthMethodType:               domain.OIDCAuthMethodTypeBasic,
+				PostLogoutRedirectURIs:       []string{"https://example.com/logout"},
+				ComplianceProblems:           []string{"problem1", "problem2"},
+				IsDevMode:                    true,
+				AccessTokenType:              domain.OIDCTokenTypeBearer,
+				AssertAccessTokenRole:        true,
+				AssertIDTokenRole:            true,
+				AssertIDTokenUserinfo:        true,
+				ClockSkew:                    5 * time.Second,
+				AdditionalOrigins:            []string{"https://app.example.com"},
+				AllowedOrigins:               []string{"https://allowed.example.com"},
+				SkipNativeAppSuccessPage:     true,
+				BackChannelLogoutURI:         "https://example.com/backchannel",
+				LoginVersion:                 domain.LoginVersion2,
+				LoginBaseURI:                 gu.Ptr("https://login.example.com"),
+				RefreshTokenRotation:         domain.OIDCRefreshTokenRotationOnEveryUse,
+				RefreshTokenLifetime:         720 * time.Hour,
+				RefreshTokenIdleLifetime:     24 * time.Hour,
+				IDTokenEncryptedResponseAlg:  "RSA-OAEP-256",
+				IDTokenEncryptedResponseEnc:  "A256GCM",
+				UserinfoEncryptedResponseAlg: "RSA-OAEP",
+				JWKS:                         `{"keys":[]}`,
+				FrontChannelLogoutURI:        "https://example.com/frontchannel",
 			},
 			expected: &application.Application_OidcConfiguration{
 				OidcConfiguration: &application.OIDCConfiguration{
@@ -506,6 +544,14 @@ func TestAppOIDCConfigToPb(t *testing.T) {
 							},
 						},
 					},
+					RefreshTokenRotation:         application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ON_EVERY_USE,
+					RefreshTokenLifetime:         durationpb.New(720 * time.Hour),
+					RefreshTokenIdleLifetime:     durationpb.New(24 * time.Hour),
+					IdTokenEncryptedResponseAlg:  "RSA-OAEP-256",
+					IdTokenEncryptedResponseEnc:  "A256GCM",
+					UserinfoEncryptedResponseAlg: "RSA-OAEP",
+					Jwks:                         `{"keys":[]}`,
+					FrontChannelLogoutUri:        "https://example.com/frontchannel",
 				},
 			},
 		},
@@ -757,3 +803,89 @@ func TestOIDCTokenTypeToPb(t *testing.T) {
 		})
 	}
 }
+
+func TestOIDCRefreshTokenRotationToDomain(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		rotation application.OIDCRefreshTokenRotation
+		expected domain.OIDCRefreshTokenRotation
+	}{
+		{
+			name:     "unspecified",
+			rotation: application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_UNSPECIFIED,
+			expected: domain.OIDCRefreshTokenRotationUnspecified,
+		},
+		{
+			name:     "on every use",
+			rotation: application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ON_EVERY_USE,
+			expected: domain.OIDCRefreshTokenRotationOnEveryUse,
+		},
+		{
+			name:     "never",
+			rotation: application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_NEVER,
+			expected: domain.OIDCRefreshTokenRotationNever,
+		},
+		{
+			name:     "unknown rotation defaults to unspecified",
+			rotation: application.OIDCRefreshTokenRotation(999),
+			expected: domain.OIDCRefreshTokenRotationUnspecified,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			result := oidcRefreshTokenRotationToDomain(tc.rotation)
+
+			// Then
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
+
+func TestOIDCRefreshTokenRotationToPb(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		name     string
+		rotation domain.OIDCRefreshTokenRotation
+		expected application.OIDCRefreshTokenRotation
+	}{
+		{
+			name:     "unspecified",
+			rotation: domain.OIDCRefreshTokenRotationUnspecified,
+			expected: application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_UNSPECIFIED,
+		},
+		{
+			name:     "on every use",
+			rotation: domain.OIDCRefreshTokenRotationOnEveryUse,
+			expected: application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_ON_EVERY_USE,
+		},
+		{
+			name:     "never",
+			rotation: domain.OIDCRefreshTokenRotationNever,
+			expected: application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_NEVER,
+		},
+		{
+			name:     "unknown rotation defaults to unspecified",
+			rotation: domain.OIDCRefreshTokenRotation(999),
+			expected: application.OIDCRefreshTokenRotation_OIDC_REFRESH_TOKEN_ROTATION_UNSPECIFIED,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			// When
+			result := oidcRefreshTokenRotationToPb(tc.rotation)
+
+			// Then
+			assert.Equal(t, tc.expected, result)
+		})
+	}
+}
@@ -18,11 +18,13 @@ func CreateSAMLAppRequestToDomain(name, projectID string, req *application.Creat
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: projectID,
 		},
-		AppName:      name,
-		Metadata:     req.GetMetadataXml(),
-		MetadataURL:  gu.Ptr(req.GetMetadataUrl()),
-		LoginVersion: loginVersion,
-		LoginBaseURI: loginBaseURI,
+		AppName:           name,
+		Metadata:          req.GetMetadataXml(),
+		MetadataURL:       gu.Ptr(req.GetMetadataUrl()),
+		LoginVersion:      loginVersion,
+		LoginBaseURI:      loginBaseURI,
+		NameIDSource:      gu.Ptr(samlNameIDSourceToDomain(req.GetNameIdSource())),
+		AttributeMappings: samlAttributeMappingsToDomain(req.GetAttributeMappings()),
 	}, nil
 }
 
@@ -37,14 +39,104 @@ func UpdateSAMLAppConfigRequestToDomain(appID, projectID string, app *applicatio
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: projectID,
 		},
-		AppID:        appID,
-		Metadata:     metasXML,
-		MetadataURL:  metasURL,
-		LoginVersion: loginVersion,
-		LoginBaseURI: loginBaseURI,
+		AppID:             appID,
+		Metadata:          metasXML,
+		MetadataURL:       metasURL,
+		LoginVersion:      loginVersion,
+		LoginBaseURI:      loginBaseURI,
+		NameIDSource:      samlNameIDSourceToDomainPtr(app.NameIdSource),
+		AttributeMappings: samlAttributeMappingsUpdateToDomain(app.GetAttributeMappings()),
 	}, nil
 }
 
+func samlNameIDSourceToDomainPtr(source *application.SAMLNameIDSource) *domain.SAMLNameIDSource {
+	if source == nil {
+		return nil
+	}
+	res := samlNameIDSourceToDomain(*source)
+	return &res
+}
+
+func samlNameIDSourceToDomain(source application.SAMLNameIDSource) domain.SAMLNameIDSource {
+	switch source {
+	case application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_UNSPECIFIED:
+		return domain.SAMLNameIDSourceUnspecified
+	case application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_USER_ID:
+		return domain.SAMLNameIDSourceUserID
+	case application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_EMAIL:
+		return domain.SAMLNameIDSourceEmail
+	case application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_USERNAME:
+		return domain.SAMLNameIDSourceUsername
+	default:
+		return domain.SAMLNameIDSourceUnspecified
+	}
+}
+
+// samlAttributeMappingsUpdateToDomain returns nil if the mappings are not set,
+// so they will not be changed, but an empty list if the mappings should be removed.
+func samlAttributeMappingsUpdateToDomain(mappings *application.SAMLAttributeMappings) []*domain.SAMLAttributeMapping {
+	if mappings == nil {
+		return nil
+	}
+	res := samlAttributeMappingsToDomain(mappings.GetMappings())
+	if res == nil {
+		return []*domain.SAMLAttributeMapping{}
+	}
+	return res
+}
+
+func samlAttributeMappingsToDomain(mappings []*application.SAMLAttributeMapping) []*domain.SAMLAttributeMapping {
+	if len(mappings) == 0 {
+		return nil
+	}
+	res := make([]*domain.SAMLAttributeMapping, len(mappings))
+	for i, mapping := range mappings {
+		res[i] = &domain.SAMLAttributeMapping{
+			Name:         mapping.GetName(),
+			FriendlyName: mapping.GetFriendlyName(),
+			NameFormat:   mapping.GetNameFormat(),
+			Source:       samlAttributeSourceToDomain(mapping.GetSource()),
+			MetadataKey:  mapping.GetMetadataKey(),
+		}
+	}
+	return res
+}
+
+func samlAttributeSourceToDomain(source application.SAMLAttributeSource) domain.SAMLAttributeSource {
+	switch source {
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USER_ID:
+		return domain.SAMLAttributeSourceUserID
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USERNAME:
+		return domain.SAMLAttributeSourceUsername
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PREFERRED_LOGIN_NAME:
+		return domain.SAMLAttributeSourcePreferredLoginName
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_EMAIL:
+		return domain.SAMLAttributeSourceEmail
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_FIRST_NAME:
+		return domain.SAMLAttributeSourceFirstName
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_LAST_NAME:
+		return domain.SAMLAttributeSourceLastName
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_DISPLAY_NAME:
+		return domain.SAMLAttributeSourceDisplayName
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_NICK_NAME:
+		return domain.SAMLAttributeSourceNickName
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PHONE:
+		return domain.SAMLAttributeSourcePhone
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PREFERRED_LANGUAGE:
+		return domain.SAMLAttributeSourcePreferredLanguage
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_ORGANIZATION_ID:
+		return domain.SAMLAttributeSourceOrganizationID
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USER_METADATA:
+		return domain.SAMLAttributeSourceUserMetadata
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PROJECT_ROLES:
+		return domain.SAMLAttributeSourceProjectRoles
+	case application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_UNSPECIFIED:
+		return domain.SAMLAttributeSourceUnspecified
+	default:
+		return domain.SAMLAttributeSourceUnspecified
+	}
+}
+
 func metasToDomain(metas application.MetaType) ([]byte, *string) {
 	switch t := metas.(type) {
 	case *application.UpdateSAMLApplicationConfigurationRequest_MetadataXml:
@@ -69,9 +161,78 @@ func appSAMLConfigToPb(samlApp *query.SAMLApp) application.IsApplicationConfigur
 
 	return &application.Application_SamlConfiguration{
 		SamlConfiguration: &application.SAMLConfiguration{
-			MetadataXml:  samlApp.Metadata,
-			MetadataUrl:  samlApp.MetadataURL,
-			LoginVersion: loginVersionToPb(samlApp.LoginVersion, samlApp.LoginBaseURI),
+			MetadataXml:       samlApp.Metadata,
+			MetadataUrl:       samlApp.MetadataURL,
+			LoginVersion:      loginVersionToPb(samlApp.LoginVersion, samlApp.LoginBaseURI),
+			NameIdSource:      samlNameIDSourceToPb(samlApp.NameIDSource),
+			AttributeMappings: samlAttributeMappingsToPb(samlApp.AttributeMappings),
 		},
 	}
 }
+
+func samlNameIDSourceToPb(source domain.SAMLNameIDSource) application.SAMLNameIDSource {
+	switch source {
+	case domain.SAMLNameIDSourceUnspecified:
+		return application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_UNSPECIFIED
+	case domain.SAMLNameIDSourceUserID:
+		return application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_USER_ID
+	case domain.SAMLNameIDSourceEmail:
+		return application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_EMAIL
+	case domain.SAMLNameIDSourceUsername:
+		return application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_USERNAME
+	default:
+		return application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_UNSPECIFIED
+	}
+}
+
+func samlAttributeMappingsToPb(mappings []*domain.SAMLAttributeMapping) []*application.SAMLAttributeMapping {
+	if len(mappings) == 0 {
+		return nil
+	}
+	res := make([]*application.SAMLAttributeMapping, len(mappings))
+	for i, mapping := range mappings {
+		res[i] = &application.SAMLAttributeMapping{
+			Name:         mapping.Name,
+			FriendlyName: mapping.FriendlyName,
+			NameFormat:   mapping.NameFormat,
+			Source:       samlAttributeSourceToPb(mapping.Source),
+			MetadataKey:  mapping.MetadataKey,
+		}
+	}
+	return res
+}
+
+func samlAttributeSourceToPb(source domain.SAMLAttributeSource) application.SAMLAttributeSource {
+	switch source {
+	case domain.SAMLAttributeSourceUserID:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USER_ID
+	case domain.SAMLAttributeSourceUsername:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USERNAME
+	case domain.SAMLAttributeSourcePreferredLoginName:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PREFERRED_LOGIN_NAME
+	case domain.SAMLAttributeSourceEmail:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_EMAIL
+	case domain.SAMLAttributeSourceFirstName:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_FIRST_NAME
+	case domain.SAMLAttributeSourceLastName:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_LAST_NAME
+	case domain.SAMLAttributeSourceDisplayName:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_DISPLAY_NAME
+	case domain.SAMLAttributeSourceNickName:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_NICK_NAME
+	case domain.SAMLAttributeSourcePhone:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PHONE
+	case domain.SAMLAttributeSourcePreferredLanguage:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PREFERRED_LANGUAGE
+	case domain.SAMLAttributeSourceOrganizationID:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_ORGANIZATION_ID
+	case domain.SAMLAttributeSourceUserMetadata:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USER_METADATA
+	case domain.SAMLAttributeSourceProjectRoles:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PROJECT_ROLES
+	case domain.SAMLAttributeSourceUnspecified:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_UNSPECIFIED
+	default:
+		return application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_UNSPECIFIED
+	}
+}
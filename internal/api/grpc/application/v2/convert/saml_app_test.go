@@ -87,9 +87,57 @@ func TestCreateSAMLAppRequestToDomain(t *testing.T) {
 				MetadataURL:  gu.Ptr(""),
 				LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
 				LoginBaseURI: gu.Ptr(""),
+				NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUnspecified),
 				State:        0,
 			},
 		},
+		{
+			testName:  "valid request, attribute release",
+			appName:   "test-application",
+			projectID: "proj-1",
+			req: &application.CreateSAMLApplicationRequest{
+				Metadata: &application.CreateSAMLApplicationRequest_MetadataXml{
+					MetadataXml: genMetaForValidRequest,
+				},
+				NameIdSource: application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_USER_ID,
+				AttributeMappings: []*application.SAMLAttributeMapping{
+					{
+						Name:         "urn:oid:0.9.2342.19200300.100.1.3",
+						FriendlyName: "mail",
+						NameFormat:   "urn:oasis:names:tc:SAML:2.0:attrname-format:uri",
+						Source:       application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_EMAIL,
+					},
+					{
+						Name:        "department",
+						Source:      application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USER_METADATA,
+						MetadataKey: "department",
+					},
+				},
+			},
+
+			expectedResponse: &domain.SAMLApp{
+				ObjectRoot:   models.ObjectRoot{AggregateID: "proj-1"},
+				AppName:      "test-application",
+				Metadata:     genMetaForValidRequest,
+				MetadataURL:  gu.Ptr(""),
+				LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
+				LoginBaseURI: gu.Ptr(""),
+				NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUserID),
+				AttributeMappings: []*domain.SAMLAttributeMapping{
+					{
+						Name:         "urn:oid:0.9.2342.19200300.100.1.3",
+						FriendlyName: "mail",
+						NameFormat:   "urn:oasis:names:tc:SAML:2.0:attrname-format:uri",
+						Source:       domain.SAMLAttributeSourceEmail,
+					},
+					{
+						Name:        "department",
+						Source:      domain.SAMLAttributeSourceUserMetadata,
+						MetadataKey: "department",
+					},
+				},
+			},
+		},
 		{
 			testName:  "nil request",
 			appName:   "test-application",
@@ -102,6 +150,7 @@ func TestCreateSAMLAppRequestToDomain(t *testing.T) {
 				MetadataURL:  gu.Ptr(""),
 				LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
 				LoginBaseURI: gu.Ptr(""),
+				NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUnspecified),
 			},
 		},
 	}
@@ -171,6 +220,50 @@ func TestUpdateSAMLAppConfigRequestToDomain(t *testing.T) {
 				LoginBaseURI: gu.Ptr(""),
 			},
 		},
+		{
+			testName:  "valid request, attribute release",
+			appID:     "application-1",
+			projectID: "proj-1",
+			req: &application.UpdateSAMLApplicationConfigurationRequest{
+				NameIdSource: gu.Ptr(application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_EMAIL),
+				AttributeMappings: &application.SAMLAttributeMappings{
+					Mappings: []*application.SAMLAttributeMapping{
+						{
+							Name:   "roles",
+							Source: application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_PROJECT_ROLES,
+						},
+					},
+				},
+			},
+			expectedResponse: &domain.SAMLApp{
+				ObjectRoot:   models.ObjectRoot{AggregateID: "proj-1"},
+				AppID:        "application-1",
+				LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
+				LoginBaseURI: gu.Ptr(""),
+				NameIDSource: gu.Ptr(domain.SAMLNameIDSourceEmail),
+				AttributeMappings: []*domain.SAMLAttributeMapping{
+					{
+						Name:   "roles",
+						Source: domain.SAMLAttributeSourceProjectRoles,
+					},
+				},
+			},
+		},
+		{
+			testName:  "valid request, remove attribute mappings",
+			appID:     "application-1",
+			projectID: "proj-1",
+			req: &application.UpdateSAMLApplicationConfigurationRequest{
+				AttributeMappings: &application.SAMLAttributeMappings{},
+			},
+			expectedResponse: &domain.SAMLApp{
+				ObjectRoot:        models.ObjectRoot{AggregateID: "proj-1"},
+				AppID:             "application-1",
+				LoginVersion:      gu.Ptr(domain.LoginVersionUnspecified),
+				LoginBaseURI:      gu.Ptr(""),
+				AttributeMappings: []*domain.SAMLAttributeMapping{},
+			},
+		},
 		{
 			testName:  "nil request",
 			appID:     "application-1",
@@ -216,6 +309,14 @@ func TestAppSAMLConfigToPb(t *testing.T) {
 				Metadata:     metadata,
 				LoginVersion: domain.LoginVersion2,
 				LoginBaseURI: gu.Ptr("https://example.com"),
+				NameIDSource: domain.SAMLNameIDSourceUserID,
+				AttributeMappings: []*domain.SAMLAttributeMapping{
+					{
+						Name:        "department",
+						Source:      domain.SAMLAttributeSourceUserMetadata,
+						MetadataKey: "department",
+					},
+				},
 			},
 			expectedPbApp: &application.Application_SamlConfiguration{
 				SamlConfiguration: &application.SAMLConfiguration{
@@ -225,6 +326,14 @@ func TestAppSAMLConfigToPb(t *testing.T) {
 							LoginV2: &application.LoginV2{BaseUri: gu.Ptr("https://example.com")},
 						},
 					},
+					NameIdSource: application.SAMLNameIDSource_SAML_NAME_ID_SOURCE_USER_ID,
+					AttributeMappings: []*application.SAMLAttributeMapping{
+						{
+							Name:        "department",
+							Source:      application.SAMLAttributeSource_SAML_ATTRIBUTE_SOURCE_USER_METADATA,
+							MetadataKey: "department",
+						},
+					},
 				},
 			},
 		},
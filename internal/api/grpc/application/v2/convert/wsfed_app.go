@@ -0,0 +1,80 @@
+package convert
+
+import (
+	"github.com/muhlemmer/gu"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/application/v2"
+)
+
+func CreateWSFedAppRequestToDomain(name, projectID string, req *application.CreateWSFedApplicationRequest) *domain.WSFedApp {
+	return &domain.WSFedApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: projectID,
+		},
+		AppName:   name,
+		Realm:     req.GetRealm(),
+		ReplyURLs: req.GetReplyUrls(),
+		TokenType: gu.Ptr(wsFedTokenTypeToDomain(req.GetTokenType())),
+	}
+}
+
+// UpdateWSFedAppConfigRequestToDomain keeps the realm and reply urls empty if they are not set,
+// the command then falls back to the current values.
+func UpdateWSFedAppConfigRequestToDomain(appID, projectID string, app *application.UpdateWSFedApplicationConfigurationRequest) *domain.WSFedApp {
+	var tokenType *domain.WSFedTokenType
+	if app.TokenType != nil {
+		tokenType = gu.Ptr(wsFedTokenTypeToDomain(app.GetTokenType()))
+	}
+	var replyURLs []string
+	if app.ReplyUrls != nil {
+		replyURLs = app.GetReplyUrls().GetReplyUrls()
+	}
+	return &domain.WSFedApp{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: projectID,
+		},
+		AppID:     appID,
+		Realm:     app.GetRealm(),
+		ReplyURLs: replyURLs,
+		TokenType: tokenType,
+	}
+}
+
+func wsFedTokenTypeToDomain(tokenType application.WSFedTokenType) domain.WSFedTokenType {
+	switch tokenType {
+	case application.WSFedTokenType_WS_FED_TOKEN_TYPE_SAML_1_1:
+		return domain.WSFedTokenTypeSAML11
+	case application.WSFedTokenType_WS_FED_TOKEN_TYPE_SAML_2_0:
+		return domain.WSFedTokenTypeSAML20
+	case application.WSFedTokenType_WS_FED_TOKEN_TYPE_UNSPECIFIED:
+		return domain.WSFedTokenTypeUnspecified
+	default:
+		return domain.WSFedTokenTypeUnspecified
+	}
+}
+
+func appWSFedConfigToPb(wsFedApp *query.WSFedApp) application.IsApplicationConfiguration {
+	return &application.Application_WsfedConfiguration{
+		WsfedConfiguration: &application.WSFedConfiguration{
+			Realm:     wsFedApp.Realm,
+			ReplyUrls: wsFedApp.ReplyURLs,
+			TokenType: wsFedTokenTypeToPb(wsFedApp.TokenType),
+		},
+	}
+}
+
+func wsFedTokenTypeToPb(tokenType domain.WSFedTokenType) application.WSFedTokenType {
+	switch tokenType {
+	case domain.WSFedTokenTypeSAML11:
+		return application.WSFedTokenType_WS_FED_TOKEN_TYPE_SAML_1_1
+	case domain.WSFedTokenTypeSAML20:
+		return application.WSFedTokenType_WS_FED_TOKEN_TYPE_SAML_2_0
+	case domain.WSFedTokenTypeUnspecified:
+		return application.WSFedTokenType_WS_FED_TOKEN_TYPE_UNSPECIFIED
+	default:
+		return application.WSFedTokenType_WS_FED_TOKEN_TYPE_UNSPECIFIED
+	}
+}
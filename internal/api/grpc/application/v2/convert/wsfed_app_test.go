@@ -0,0 +1,96 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/application/v2"
+)
+
+func TestCreateWSFedAppRequestToDomain(t *testing.T) {
+	t.Parallel()
+
+	req := &application.CreateWSFedApplicationRequest{
+		Realm:     "urn:sharepoint:test",
+		ReplyUrls: []string{"https://sharepoint.test.com/_trust/"},
+		TokenType: application.WSFedTokenType_WS_FED_TOKEN_TYPE_SAML_2_0,
+	}
+
+	res := CreateWSFedAppRequestToDomain("test-application", "proj-1", req)
+
+	assert.Equal(t, &domain.WSFedApp{
+		ObjectRoot: models.ObjectRoot{AggregateID: "proj-1"},
+		AppName:    "test-application",
+		Realm:      "urn:sharepoint:test",
+		ReplyURLs:  []string{"https://sharepoint.test.com/_trust/"},
+		TokenType:  gu.Ptr(domain.WSFedTokenTypeSAML20),
+	}, res)
+}
+
+func TestUpdateWSFedAppConfigRequestToDomain(t *testing.T) {
+	t.Parallel()
+
+	tt := []struct {
+		testName string
+		req      *application.UpdateWSFedApplicationConfigurationRequest
+
+		expectedResponse *domain.WSFedApp
+	}{
+		{
+			testName: "nothing set",
+			req:      &application.UpdateWSFedApplicationConfigurationRequest{},
+			expectedResponse: &domain.WSFedApp{
+				ObjectRoot: models.ObjectRoot{AggregateID: "proj-1"},
+				AppID:      "app-1",
+			},
+		},
+		{
+			testName: "all set",
+			req: &application.UpdateWSFedApplicationConfigurationRequest{
+				Realm:     gu.Ptr("urn:sharepoint:test"),
+				ReplyUrls: &application.WSFedReplyURLs{ReplyUrls: []string{"https://sharepoint.test.com/_trust/"}},
+				TokenType: gu.Ptr(application.WSFedTokenType_WS_FED_TOKEN_TYPE_SAML_1_1),
+			},
+			expectedResponse: &domain.WSFedApp{
+				ObjectRoot: models.ObjectRoot{AggregateID: "proj-1"},
+				AppID:      "app-1",
+				Realm:      "urn:sharepoint:test",
+				ReplyURLs:  []string{"https://sharepoint.test.com/_trust/"},
+				TokenType:  gu.Ptr(domain.WSFedTokenTypeSAML11),
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.testName, func(t *testing.T) {
+			t.Parallel()
+
+			res := UpdateWSFedAppConfigRequestToDomain("app-1", "proj-1", tc.req)
+
+			assert.Equal(t, tc.expectedResponse, res)
+		})
+	}
+}
+
+func TestAppWSFedConfigToPb(t *testing.T) {
+	t.Parallel()
+
+	res := appWSFedConfigToPb(&query.WSFedApp{
+		Realm:     "urn:sharepoint:test",
+		ReplyURLs: []string{"https://sharepoint.test.com/_trust/"},
+		TokenType: domain.WSFedTokenTypeSAML20,
+	})
+
+	assert.Equal(t, &application.Application_WsfedConfiguration{
+		WsfedConfiguration: &application.WSFedConfiguration{
+			Realm:     "urn:sharepoint:test",
+			ReplyUrls: []string{"https://sharepoint.test.com/_trust/"},
+			TokenType: application.WSFedTokenType_WS_FED_TOKEN_TYPE_SAML_2_0,
+		},
+	}, res)
+}
@@ -14,6 +14,9 @@ import (
 )
 
 func (s *Server) GetApplication(ctx context.Context, req *connect.Request[application.GetApplicationRequest]) (*connect.Response[application.GetApplicationResponse], error) {
+	if req.Msg.AsOf != nil {
+		return s.getApplicationAsOf(ctx, req.Msg)
+	}
 	res, err := s.query.AppByIDWithPermission(ctx, req.Msg.GetApplicationId(), false, s.checkPermission)
 	if err != nil {
 		return nil, err
@@ -24,6 +27,20 @@ func (s *Server) GetApplication(ctx context.Context, req *connect.Request[applic
 	}), nil
 }
 
+func (s *Server) getApplicationAsOf(ctx context.Context, req *application.GetApplicationRequest) (*connect.Response[application.GetApplicationResponse], error) {
+	asOf, err := filter.AsOfPbToQuery(req.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.AppAsOf(ctx, req.GetApplicationId(), asOf, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&application.GetApplicationResponse{
+		Application: convert.AppToPb(res),
+	}), nil
+}
+
 func (s *Server) ListApplications(ctx context.Context, req *connect.Request[application.ListApplicationsRequest]) (*connect.Response[application.ListApplicationsResponse], error) {
 	queries, err := convert.ListApplicationsRequestToModel(s.systemDefaults, req.Msg)
 	if err != nil {
@@ -29,6 +29,20 @@ func (s *Server) ListAuthorizations(ctx context.Context, req *connect.Request[au
 	}), nil
 }
 
+func (s *Server) ListAuthorizationsAsOf(ctx context.Context, req *connect.Request[authorization.ListAuthorizationsAsOfRequest]) (*connect.Response[authorization.ListAuthorizationsAsOfResponse], error) {
+	asOf, err := filter.AsOfPbToQuery(req.Msg.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	grants, err := s.query.UserGrantsAsOf(ctx, req.Msg.GetUserId(), asOf, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&authorization.ListAuthorizationsAsOfResponse{
+		Authorizations: userGrantsToPb(grants),
+	}), nil
+}
+
 func (s *Server) listAuthorizationsRequestToModel(req *authorization.ListAuthorizationsRequest) (*query.UserGrantsQueries, error) {
 	offset, limit, asc, err := filter.PaginationPbToQuery(s.systemDefaults, req.Pagination)
 	if err != nil {
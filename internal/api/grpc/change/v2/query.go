@@ -0,0 +1,159 @@
+package change
+
+import (
+	"context"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/api/grpc/filter/v2"
+	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	change_v2 "github.com/zitadel/zitadel/pkg/grpc/change/v2"
+	filter_pb "github.com/zitadel/zitadel/pkg/grpc/filter/v2"
+)
+
+func (s *Server) ListResourceChanges(ctx context.Context, req *connect.Request[change_v2.ListResourceChangesRequest]) (*connect.Response[change_v2.ListResourceChangesResponse], error) {
+	changesQuery, err := listResourceChangesRequestToQuery(s.systemDefaults, req.Msg, s.oldestChange(ctx))
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.query.ResourceChanges(ctx, changesQuery)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&change_v2.ListResourceChangesResponse{
+		Pagination: &filter_pb.PaginationResponse{
+			TotalResult:  changes.TotalCount,
+			AppliedLimit: changesQuery.Limit,
+		},
+		Changes: resourceChangesToPb(changes.Changes, s.editors(ctx)),
+	}), nil
+}
+
+// oldestChange returns the creation date of the oldest change allowed by the audit log retention,
+// the retention of the instance overwrites the default.
+// The time is zero if the changes are retained forever.
+func (s *Server) oldestChange(ctx context.Context) time.Time {
+	retention := s.auditLogRetention
+	if instanceRetention := authz.GetInstance(ctx).AuditLogRetention(); instanceRetention != nil {
+		retention = *instanceRetention
+	}
+	if retention == 0 {
+		return time.Time{}
+	}
+	callTime := call.FromContext(ctx)
+	if callTime.IsZero() {
+		callTime = time.Now()
+	}
+	return callTime.Add(-retention)
+}
+
+// editors returns a function which resolves the users who made the changes, each user is only queried once.
+func (s *Server) editors(ctx context.Context) func(string) *query.EventEditor {
+	editors := make(map[string]*query.EventEditor)
+	return func(id string) *query.EventEditor {
+		editor, ok := editors[id]
+		if !ok {
+			editor = s.query.EventEditor(ctx, id)
+			editors[id] = editor
+		}
+		return editor
+	}
+}
+
+func listResourceChangesRequestToQuery(defaults systemdefaults.SystemDefaults, req *change_v2.ListResourceChangesRequest, oldestChange time.Time) (*query.ResourceChangesQuery, error) {
+	offset, limit, asc, err := filter.PaginationPbToQuery(defaults, req.GetPagination())
+	if err != nil {
+		return nil, err
+	}
+	resourceType, err := resourceTypeToQuery(req.GetResourceType())
+	if err != nil {
+		return nil, err
+	}
+	changesQuery := &query.ResourceChangesQuery{
+		Type:   resourceType,
+		ID:     req.GetResourceId(),
+		Fields: req.GetFields(),
+		Offset: offset,
+		Limit:  limit,
+		Asc:    asc,
+	}
+	if req.From != nil {
+		changesQuery.From = req.GetFrom().AsTime()
+	}
+	if req.To != nil {
+		changesQuery.To = req.GetTo().AsTime()
+	}
+	if changesQuery.From.Before(oldestChange) {
+		changesQuery.From = oldestChange
+	}
+	return changesQuery, nil
+}
+
+func resourceTypeToQuery(resourceType change_v2.ResourceType) (query.ResourceChangesType, error) {
+	switch resourceType {
+	case change_v2.ResourceType_RESOURCE_TYPE_USER:
+		return query.ResourceChangesTypeUser, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_ORGANIZATION:
+		return query.ResourceChangesTypeOrg, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_PROJECT:
+		return query.ResourceChangesTypeProject, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_APPLICATION:
+		return query.ResourceChangesTypeApplication, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_LOGIN_POLICY:
+		return query.ResourceChangesTypeLoginPolicy, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_PASSWORD_COMPLEXITY_POLICY:
+		return query.ResourceChangesTypePasswordComplexityPolicy, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_PASSWORD_EXPIRY_POLICY:
+		return query.ResourceChangesTypePasswordAgePolicy, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_LOCKOUT_POLICY:
+		return query.ResourceChangesTypeLockoutPolicy, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_LEGAL_AND_SUPPORT_POLICY:
+		return query.ResourceChangesTypePrivacyPolicy, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_NOTIFICATION_POLICY:
+		return query.ResourceChangesTypeNotificationPolicy, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_DOMAIN_POLICY:
+		return query.ResourceChangesTypeDomainPolicy, nil
+	case change_v2.ResourceType_RESOURCE_TYPE_UNSPECIFIED:
+		fallthrough
+	default:
+		return query.ResourceChangesTypeUnspecified, zerrors.ThrowInvalidArgument(nil, "CHANGE-Tq7nd", "Errors.ResourceChanges.TypeMissing")
+	}
+}
+
+func resourceChangesToPb(changes []*query.ResourceChange, editor func(string) *query.EventEditor) []*change_v2.ResourceChange {
+	result := make([]*change_v2.ResourceChange, len(changes))
+	for i, change := range changes {
+		result[i] = resourceChangeToPb(change, editor(change.EditorID))
+	}
+	return result
+}
+
+func resourceChangeToPb(change *query.ResourceChange, editor *query.EventEditor) *change_v2.ResourceChange {
+	fields := make([]*change_v2.FieldChange, len(change.Fields))
+	for i, field := range change.Fields {
+		fields[i] = &change_v2.FieldChange{
+			Name:   field.Name,
+			Before: field.Before,
+			After:  field.After,
+		}
+	}
+	return &change_v2.ResourceChange{
+		ChangeDate:     timestamppb.New(change.CreationDate),
+		EventType:      string(change.EventType),
+		Sequence:       change.Sequence,
+		OrganizationId: change.ResourceOwner,
+		Editor: &change_v2.Editor{
+			Id:                 change.EditorID,
+			DisplayName:        editor.DisplayName,
+			PreferredLoginName: editor.PreferedLoginName,
+		},
+		UserAgent: change.UserAgent,
+		Fields:    fields,
+	}
+}
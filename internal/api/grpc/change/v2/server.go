@@ -0,0 +1,55 @@
+package change
+
+import (
+	"net/http"
+	"time"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/config/systemdefaults"
+	"github.com/zitadel/zitadel/internal/query"
+	change_v2 "github.com/zitadel/zitadel/pkg/grpc/change/v2"
+	"github.com/zitadel/zitadel/pkg/grpc/change/v2/changeconnect"
+)
+
+var _ changeconnect.ChangeServiceHandler = (*Server)(nil)
+
+type Server struct {
+	systemDefaults    systemdefaults.SystemDefaults
+	query             *query.Queries
+	auditLogRetention time.Duration
+}
+
+func CreateServer(
+	systemDefaults systemdefaults.SystemDefaults,
+	query *query.Queries,
+	auditLogRetention time.Duration,
+) *Server {
+	return &Server{
+		systemDefaults:    systemDefaults,
+		query:             query,
+		auditLogRetention: auditLogRetention,
+	}
+}
+
+func (s *Server) RegisterConnectServer(interceptors ...connect.Interceptor) (string, http.Handler) {
+	return changeconnect.NewChangeServiceHandler(s, connect.WithInterceptors(interceptors...))
+}
+
+func (s *Server) FileDescriptor() protoreflect.FileDescriptor {
+	return change_v2.File_zitadel_change_v2_change_service_proto
+}
+
+func (s *Server) AppName() string {
+	return change_v2.ChangeService_ServiceDesc.ServiceName
+}
+
+func (s *Server) MethodPrefix() string {
+	return change_v2.ChangeService_ServiceDesc.ServiceName
+}
+
+func (s *Server) AuthMethods() authz.MethodMapping {
+	return change_v2.ChangeService_AuthMethods
+}
@@ -0,0 +1,155 @@
+package event
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+	event "github.com/zitadel/zitadel/pkg/grpc/event/v2"
+)
+
+const (
+	// eventStreamBatchSize is the maximum amount of events queried at once by [Server.SubscribeEvents]
+	eventStreamBatchSize = 200
+	// eventStreamPollInterval is the interval [Server.SubscribeEvents] checks for new events after all events were sent
+	eventStreamPollInterval = time.Second
+)
+
+func (s *Server) SubscribeEvents(req *event.SubscribeEventsRequest, stream event.EventService_SubscribeEventsServer) error {
+	ctx := stream.Context()
+	cursor, err := eventStreamCursorFromRequest(req)
+	if err != nil {
+		return err
+	}
+	ticker := time.NewTicker(eventStreamPollInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := s.query.SearchEvents(ctx, subscribeEventsRequestToFilter(ctx, req, cursor))
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			cursor.next(e.Position)
+			pbEvent, err := eventToPb(e)
+			if err != nil {
+				return err
+			}
+			err = stream.Send(&event.SubscribeEventsResponse{
+				Event:    pbEvent,
+				Position: cursor.position.String(),
+				Offset:   cursor.offset,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		// more events are available, query the next batch immediately
+		if len(events) == eventStreamBatchSize {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// eventStreamCursor is the position of the last event sent on the stream.
+// Events written in the same transaction share their position, the offset counts the events sent of the position.
+type eventStreamCursor struct {
+	position decimal.Decimal
+	offset   uint32
+}
+
+func eventStreamCursorFromRequest(req *event.SubscribeEventsRequest) (*eventStreamCursor, error) {
+	cursor := new(eventStreamCursor)
+	if req.GetPosition() == "" {
+		return cursor, nil
+	}
+	position, err := decimal.NewFromString(req.GetPosition())
+	if err != nil || position.IsNegative() {
+		return nil, zerrors.ThrowInvalidArgument(err, "EVENT-Ux4sd", "Errors.Event.InvalidPosition")
+	}
+	cursor.position = position
+	cursor.offset = req.GetOffset()
+	return cursor, nil
+}
+
+func (c *eventStreamCursor) next(position decimal.Decimal) {
+	if c.position.Equal(position) {
+		c.offset++
+		return
+	}
+	c.position = position
+	c.offset = 1
+}
+
+func subscribeEventsRequestToFilter(ctx context.Context, req *event.SubscribeEventsRequest, cursor *eventStreamCursor) *eventstore.SearchQueryBuilder {
+	eventTypes := make([]eventstore.EventType, len(req.GetEventTypes()))
+	for i, eventType := range req.GetEventTypes() {
+		eventTypes[i] = eventstore.EventType(eventType)
+	}
+	aggregateTypes := make([]eventstore.AggregateType, len(req.GetAggregateTypes()))
+	for i, aggregateType := range req.GetAggregateTypes() {
+		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	if len(aggregateTypes) == 0 {
+		for _, eventType := range eventTypes {
+			aggregateTypes = append(aggregateTypes, eventstore.AggregateTypeFromEventType(eventType))
+		}
+	}
+	aggregateTypes = slices.Compact(aggregateTypes)
+
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderAsc().
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		Limit(eventStreamBatchSize).
+		AwaitOpenTransactions().
+		ResourceOwner(req.GetOrganizationId())
+	if cursor.position.IsPositive() {
+		builder.PositionAtLeast(cursor.position).Offset(cursor.offset)
+	}
+	if len(aggregateTypes) > 0 || len(eventTypes) > 0 {
+		builder.AddQuery().
+			AggregateTypes(aggregateTypes...).
+			EventTypes(eventTypes...).
+			Builder()
+	}
+	return builder
+}
+
+func eventToPb(e *query.Event) (*event.Event, error) {
+	var payload *structpb.Struct
+	if len(e.Payload) > 0 {
+		payload = new(structpb.Struct)
+		if err := payload.UnmarshalJSON(e.Payload); err != nil {
+			return nil, zerrors.ThrowInternal(err, "EVENT-Rk2vd", "Errors.Internal")
+		}
+	}
+	var editorID string
+	if e.Editor != nil {
+		editorID = e.Editor.ID
+	}
+	return &event.Event{
+		Type: e.Type,
+		Aggregate: &event.Aggregate{
+			Id:             e.Aggregate.ID,
+			Type:           string(e.Aggregate.Type),
+			OrganizationId: e.Aggregate.ResourceOwner,
+		},
+		Sequence:     e.Sequence,
+		CreationDate: timestamppb.New(e.CreationDate),
+		EditorId:     editorID,
+		Payload:      payload,
+	}, nil
+}
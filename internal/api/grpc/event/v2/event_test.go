@@ -0,0 +1,66 @@
+package event
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+	event "github.com/zitadel/zitadel/pkg/grpc/event/v2"
+)
+
+func Test_eventStreamCursorFromRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *event.SubscribeEventsRequest
+		want    *eventStreamCursor
+		wantErr bool
+	}{
+		{
+			name: "no position",
+			req:  &event.SubscribeEventsRequest{Offset: 3},
+			want: &eventStreamCursor{},
+		},
+		{
+			name: "position and offset",
+			req:  &event.SubscribeEventsRequest{Position: "1700000000.123456", Offset: 3},
+			want: &eventStreamCursor{position: decimal.RequireFromString("1700000000.123456"), offset: 3},
+		},
+		{
+			name:    "invalid position",
+			req:     &event.SubscribeEventsRequest{Position: "yesterday"},
+			wantErr: true,
+		},
+		{
+			name:    "negative position",
+			req:     &event.SubscribeEventsRequest{Position: "-1"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := eventStreamCursorFromRequest(tt.req)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, tt.want.position.Equal(got.position))
+			assert.Equal(t, tt.want.offset, got.offset)
+		})
+	}
+}
+
+func Test_eventStreamCursor_next(t *testing.T) {
+	cursor := &eventStreamCursor{position: decimal.NewFromInt(1), offset: 2}
+
+	cursor.next(decimal.NewFromInt(1))
+	assert.True(t, cursor.position.Equal(decimal.NewFromInt(1)))
+	assert.Equal(t, uint32(3), cursor.offset)
+
+	cursor.next(decimal.NewFromInt(2))
+	assert.True(t, cursor.position.Equal(decimal.NewFromInt(2)))
+	assert.Equal(t, uint32(1), cursor.offset)
+}
@@ -0,0 +1,47 @@
+package event
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/server"
+	"github.com/zitadel/zitadel/internal/query"
+	event "github.com/zitadel/zitadel/pkg/grpc/event/v2"
+)
+
+var _ event.EventServiceServer = (*Server)(nil)
+
+// Server is registered on the gRPC server instead of connect,
+// because the connect interceptors only intercept unary calls.
+type Server struct {
+	event.UnimplementedEventServiceServer
+	query *query.Queries
+}
+
+func CreateServer(
+	query *query.Queries,
+) *Server {
+	return &Server{
+		query: query,
+	}
+}
+
+func (s *Server) RegisterServer(grpcServer *grpc.Server) {
+	event.RegisterEventServiceServer(grpcServer, s)
+}
+
+func (s *Server) AppName() string {
+	return event.EventService_ServiceDesc.ServiceName
+}
+
+func (s *Server) MethodPrefix() string {
+	return event.EventService_ServiceDesc.ServiceName
+}
+
+func (s *Server) AuthMethods() authz.MethodMapping {
+	return event.EventService_AuthMethods
+}
+
+func (s *Server) RegisterGateway() server.RegisterGatewayFunc {
+	return event.RegisterEventServiceHandler
+}
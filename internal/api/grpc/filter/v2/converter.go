@@ -3,6 +3,8 @@ package filter
 import (
 	"fmt"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -82,3 +84,20 @@ func QueryToPaginationPb(request query.SearchRequest, response query.SearchRespo
 		TotalResult:  response.Count,
 	}
 }
+
+// AsOfPbToQuery returns the point in time a resource is reconstructed at,
+// nil is returned if no point in time is requested.
+func AsOfPbToQuery(asOf *filter.AsOf) (*query.AsOf, error) {
+	switch point := asOf.GetPoint().(type) {
+	case *filter.AsOf_Timestamp:
+		return &query.AsOf{Date: point.Timestamp.AsTime()}, nil
+	case *filter.AsOf_Position:
+		position, err := decimal.NewFromString(point.Position)
+		if err != nil {
+			return nil, zerrors.ThrowInvalidArgument(err, "FILTER-Ub3ke", "Errors.AsOf.Invalid")
+		}
+		return &query.AsOf{Position: position}, nil
+	default:
+		return nil, nil
+	}
+}
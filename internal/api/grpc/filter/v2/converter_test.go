@@ -2,9 +2,12 @@ package filter
 
 import (
 	"testing"
+	"time"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/query"
@@ -162,3 +165,46 @@ func TestQueryToPaginationPb(t *testing.T) {
 	assert.Equal(t, req.Limit, got.AppliedLimit)
 	assert.Equal(t, resp.Count, got.TotalResult)
 }
+
+func TestAsOfPbToQuery(t *testing.T) {
+	t.Parallel()
+
+	date := time.Date(2024, 12, 18, 7, 50, 47, 0, time.UTC)
+	tt := []struct {
+		name    string
+		asOf    *filter.AsOf
+		want    *query.AsOf
+		wantErr bool
+	}{
+		{
+			name: "not requested",
+			asOf: nil,
+			want: nil,
+		},
+		{
+			name: "timestamp",
+			asOf: &filter.AsOf{Point: &filter.AsOf_Timestamp{Timestamp: timestamppb.New(date)}},
+			want: &query.AsOf{Date: date},
+		},
+		{
+			name: "position",
+			asOf: &filter.AsOf{Point: &filter.AsOf_Position{Position: "1734508247.492034"}},
+			want: &query.AsOf{Position: decimal.RequireFromString("1734508247.492034")},
+		},
+		{
+			name:    "invalid position",
+			asOf:    &filter.AsOf{Point: &filter.AsOf_Position{Position: "yesterday"}},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := AsOfPbToQuery(tc.asOf)
+
+			require.Equal(t, tc.wantErr, err != nil)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -5,12 +5,16 @@ import (
 	"github.com/muhlemmer/gu"
 	dsig "github.com/russellhaering/goxmldsig"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/zerrors"
 	idp_pb "github.com/zitadel/zitadel/pkg/grpc/idp"
 )
 
@@ -415,6 +419,8 @@ func providerTypeToPb(idpType domain.IDPType) idp_pb.ProviderType {
 		return idp_pb.ProviderType_PROVIDER_TYPE_APPLE
 	case domain.IDPTypeSAML:
 		return idp_pb.ProviderType_PROVIDER_TYPE_SAML
+	case domain.IDPTypeX509:
+		return idp_pb.ProviderType_PROVIDER_TYPE_X509
 	case domain.IDPTypeUnspecified:
 		return idp_pb.ProviderType_PROVIDER_TYPE_UNSPECIFIED
 	default:
@@ -431,6 +437,7 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 			IsAutoUpdate:      config.IsAutoUpdate,
 			AutoLinking:       autoLinkingOptionToPb(config.AutoLinking),
 		},
+		MappingRules: MappingRulesToPb(config.MappingRules),
 	}
 	if config.OAuthIDPTemplate != nil {
 		oauthConfigToPb(providerConfig, config.OAuthIDPTemplate)
@@ -480,6 +487,10 @@ func configToPb(config *query.IDPTemplate) *idp_pb.ProviderConfig {
 		samlConfigToPb(providerConfig, config.SAMLIDPTemplate)
 		return providerConfig
 	}
+	if config.X509IDPTemplate != nil {
+		x509ConfigToPb(providerConfig, config.X509IDPTemplate)
+		return providerConfig
+	}
 	return providerConfig
 }
 
@@ -658,6 +669,63 @@ func appleConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.Appl
 	}
 }
 
+func x509ConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.X509IDPTemplate) {
+	providerConfig.Config = &idp_pb.ProviderConfig_X509{
+		X509: &idp_pb.X509Config{
+			CertificateHeader:       template.CertificateHeader,
+			TrustedProxies:          template.TrustedProxies,
+			CaCertificates:          template.CACertificates,
+			Crls:                    template.CRLs,
+			OcspResponses:           template.OCSPResponses,
+			RevocationCheckRequired: template.RevocationCheckRequired,
+			IdField:                 X509CertificateFieldToPb(template.IDField),
+			UsernameField:           X509CertificateFieldToPb(template.UsernameField),
+		},
+	}
+}
+
+func X509CertificateFieldToPb(field domain.X509CertificateField) idp_pb.X509CertificateField {
+	switch field {
+	case domain.X509CertificateFieldUnspecified:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_UNSPECIFIED
+	case domain.X509CertificateFieldSubject:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT
+	case domain.X509CertificateFieldSubjectCommonName:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT_COMMON_NAME
+	case domain.X509CertificateFieldSubjectSerialNumber:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT_SERIAL_NUMBER
+	case domain.X509CertificateFieldSANEmail:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_EMAIL
+	case domain.X509CertificateFieldSANUPN:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_UPN
+	case domain.X509CertificateFieldSANURI:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_URI
+	default:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_UNSPECIFIED
+	}
+}
+
+func X509CertificateFieldToDomain(field idp_pb.X509CertificateField) domain.X509CertificateField {
+	switch field {
+	case idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_UNSPECIFIED:
+		return domain.X509CertificateFieldUnspecified
+	case idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT:
+		return domain.X509CertificateFieldSubject
+	case idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT_COMMON_NAME:
+		return domain.X509CertificateFieldSubjectCommonName
+	case idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT_SERIAL_NUMBER:
+		return domain.X509CertificateFieldSubjectSerialNumber
+	case idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_EMAIL:
+		return domain.X509CertificateFieldSANEmail
+	case idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_UPN:
+		return domain.X509CertificateFieldSANUPN
+	case idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_URI:
+		return domain.X509CertificateFieldSANURI
+	default:
+		return domain.X509CertificateFieldUnspecified
+	}
+}
+
 func samlConfigToPb(providerConfig *idp_pb.ProviderConfig, template *query.SAMLIDPTemplate) {
 	nameIDFormat := idp_pb.SAMLNameIDFormat_SAML_NAME_ID_FORMAT_PERSISTENT
 	if template.NameIDFormat.Valid {
@@ -718,3 +786,283 @@ func signatureAlgorithmToPb(signatureAlgorithm string) idp_pb.SAMLSignatureAlgor
 		return idp_pb.SAMLSignatureAlgorithm_SAML_SIGNATURE_UNSPECIFIED
 	}
 }
+
+func MappingRulesToPb(rules []domain.IDPMappingRule) []*idp_pb.MappingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	pbRules := make([]*idp_pb.MappingRule, len(rules))
+	for i, rule := range rules {
+		pbRules[i] = mappingRuleToPb(rule)
+	}
+	return pbRules
+}
+
+func mappingRuleToPb(rule domain.IDPMappingRule) *idp_pb.MappingRule {
+	return &idp_pb.MappingRule{
+		Target:     mappingTargetToPb(rule.Target),
+		Key:        rule.Key,
+		Language:   mappingLanguageToPb(rule.Language),
+		Expression: rule.Expression,
+	}
+}
+
+func MappingRulesToDomain(rules []*idp_pb.MappingRule) []domain.IDPMappingRule {
+	if len(rules) == 0 {
+		return nil
+	}
+	domainRules := make([]domain.IDPMappingRule, len(rules))
+	for i, rule := range rules {
+		domainRules[i] = domain.IDPMappingRule{
+			Target:     mappingTargetToDomain(rule.GetTarget()),
+			Key:        rule.GetKey(),
+			Language:   mappingLanguageToDomain(rule.GetLanguage()),
+			Expression: rule.GetExpression(),
+		}
+	}
+	return domainRules
+}
+
+func mappingTargetToPb(target domain.IDPMappingTarget) idp_pb.MappingTarget {
+	switch target {
+	case domain.IDPMappingTargetFirstName:
+		return idp_pb.MappingTarget_MAPPING_TARGET_FIRST_NAME
+	case domain.IDPMappingTargetLastName:
+		return idp_pb.MappingTarget_MAPPING_TARGET_LAST_NAME
+	case domain.IDPMappingTargetDisplayName:
+		return idp_pb.MappingTarget_MAPPING_TARGET_DISPLAY_NAME
+	case domain.IDPMappingTargetNickName:
+		return idp_pb.MappingTarget_MAPPING_TARGET_NICK_NAME
+	case domain.IDPMappingTargetPreferredUsername:
+		return idp_pb.MappingTarget_MAPPING_TARGET_PREFERRED_USERNAME
+	case domain.IDPMappingTargetEmail:
+		return idp_pb.MappingTarget_MAPPING_TARGET_EMAIL
+	case domain.IDPMappingTargetEmailVerified:
+		return idp_pb.MappingTarget_MAPPING_TARGET_EMAIL_VERIFIED
+	case domain.IDPMappingTargetPhone:
+		return idp_pb.MappingTarget_MAPPING_TARGET_PHONE
+	case domain.IDPMappingTargetPhoneVerified:
+		return idp_pb.MappingTarget_MAPPING_TARGET_PHONE_VERIFIED
+	case domain.IDPMappingTargetPreferredLanguage:
+		return idp_pb.MappingTarget_MAPPING_TARGET_PREFERRED_LANGUAGE
+	case domain.IDPMappingTargetAvatarURL:
+		return idp_pb.MappingTarget_MAPPING_TARGET_AVATAR_URL
+	case domain.IDPMappingTargetProfile:
+		return idp_pb.MappingTarget_MAPPING_TARGET_PROFILE
+	case domain.IDPMappingTargetMetadata:
+		return idp_pb.MappingTarget_MAPPING_TARGET_METADATA
+	case domain.IDPMappingTargetOrganization:
+		return idp_pb.MappingTarget_MAPPING_TARGET_ORGANIZATION
+	case domain.IDPMappingTargetProjectRoles:
+		return idp_pb.MappingTarget_MAPPING_TARGET_PROJECT_ROLES
+	case domain.IDPMappingTargetGroups:
+		return idp_pb.MappingTarget_MAPPING_TARGET_GROUPS
+	case domain.IDPMappingTargetUnspecified:
+		return idp_pb.MappingTarget_MAPPING_TARGET_UNSPECIFIED
+	default:
+		return idp_pb.MappingTarget_MAPPING_TARGET_UNSPECIFIED
+	}
+}
+
+func mappingTargetToDomain(target idp_pb.MappingTarget) domain.IDPMappingTarget {
+	switch target {
+	case idp_pb.MappingTarget_MAPPING_TARGET_FIRST_NAME:
+		return domain.IDPMappingTargetFirstName
+	case idp_pb.MappingTarget_MAPPING_TARGET_LAST_NAME:
+		return domain.IDPMappingTargetLastName
+	case idp_pb.MappingTarget_MAPPING_TARGET_DISPLAY_NAME:
+		return domain.IDPMappingTargetDisplayName
+	case idp_pb.MappingTarget_MAPPING_TARGET_NICK_NAME:
+		return domain.IDPMappingTargetNickName
+	case idp_pb.MappingTarget_MAPPING_TARGET_PREFERRED_USERNAME:
+		return domain.IDPMappingTargetPreferredUsername
+	case idp_pb.MappingTarget_MAPPING_TARGET_EMAIL:
+		return domain.IDPMappingTargetEmail
+	case idp_pb.MappingTarget_MAPPING_TARGET_EMAIL_VERIFIED:
+		return domain.IDPMappingTargetEmailVerified
+	case idp_pb.MappingTarget_MAPPING_TARGET_PHONE:
+		return domain.IDPMappingTargetPhone
+	case idp_pb.MappingTarget_MAPPING_TARGET_PHONE_VERIFIED:
+		return domain.IDPMappingTargetPhoneVerified
+	case idp_pb.MappingTarget_MAPPING_TARGET_PREFERRED_LANGUAGE:
+		return domain.IDPMappingTargetPreferredLanguage
+	case idp_pb.MappingTarget_MAPPING_TARGET_AVATAR_URL:
+		return domain.IDPMappingTargetAvatarURL
+	case idp_pb.MappingTarget_MAPPING_TARGET_PROFILE:
+		return domain.IDPMappingTargetProfile
+	case idp_pb.MappingTarget_MAPPING_TARGET_METADATA:
+		return domain.IDPMappingTargetMetadata
+	case idp_pb.MappingTarget_MAPPING_TARGET_ORGANIZATION:
+		return domain.IDPMappingTargetOrganization
+	case idp_pb.MappingTarget_MAPPING_TARGET_PROJECT_ROLES:
+		return domain.IDPMappingTargetProjectRoles
+	case idp_pb.MappingTarget_MAPPING_TARGET_GROUPS:
+		return domain.IDPMappingTargetGroups
+	case idp_pb.MappingTarget_MAPPING_TARGET_UNSPECIFIED:
+		return domain.IDPMappingTargetUnspecified
+	default:
+		return domain.IDPMappingTargetUnspecified
+	}
+}
+
+func mappingLanguageToPb(language domain.IDPMappingLanguage) idp_pb.MappingLanguage {
+	switch language {
+	case domain.IDPMappingLanguageJSONPath:
+		return idp_pb.MappingLanguage_MAPPING_LANGUAGE_JSONPATH
+	case domain.IDPMappingLanguageCEL:
+		return idp_pb.MappingLanguage_MAPPING_LANGUAGE_CEL
+	case domain.IDPMappingLanguageUnspecified:
+		return idp_pb.MappingLanguage_MAPPING_LANGUAGE_UNSPECIFIED
+	default:
+		return idp_pb.MappingLanguage_MAPPING_LANGUAGE_UNSPECIFIED
+	}
+}
+
+func mappingLanguageToDomain(language idp_pb.MappingLanguage) domain.IDPMappingLanguage {
+	switch language {
+	case idp_pb.MappingLanguage_MAPPING_LANGUAGE_JSONPATH:
+		return domain.IDPMappingLanguageJSONPath
+	case idp_pb.MappingLanguage_MAPPING_LANGUAGE_CEL:
+		return domain.IDPMappingLanguageCEL
+	case idp_pb.MappingLanguage_MAPPING_LANGUAGE_UNSPECIFIED:
+		return domain.IDPMappingLanguageUnspecified
+	default:
+		return domain.IDPMappingLanguageUnspecified
+	}
+}
+
+// PreviewMapping evaluates the rules on the sample payload of an identity provider.
+func PreviewMapping(rules []domain.IDPMappingRule, userinfo, claims *structpb.Struct, attributes map[string]*idp_pb.MappingAttributeValues) (*idp_pb.MappingResult, error) {
+	mapper, err := mapping.New(rules)
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "IDP-Pv4kd", "Errors.IDP.InvalidMappingRule")
+	}
+	input := &mapping.Input{
+		UserInfo:   userinfo.AsMap(),
+		Claims:     claims.AsMap(),
+		Attributes: make(map[string][]string, len(attributes)),
+	}
+	for key, values := range attributes {
+		input.Attributes[key] = values.GetValues()
+	}
+	result, err := mapper.Map(input)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Hn3sw", "Errors.Internal")
+	}
+	return mappingResultToPb(result), nil
+}
+
+func mappingResultToPb(result *mapping.Result) *idp_pb.MappingResult {
+	pbResult := &idp_pb.MappingResult{
+		FirstName:         result.FirstName,
+		LastName:          result.LastName,
+		DisplayName:       result.DisplayName,
+		NickName:          result.NickName,
+		PreferredUsername: result.PreferredUsername,
+		Email:             string(result.Email),
+		EmailVerified:     result.EmailVerified,
+		Phone:             string(result.Phone),
+		PhoneVerified:     result.PhoneVerified,
+		PreferredLanguage: result.PreferredLanguage,
+		AvatarUrl:         result.AvatarURL,
+		Profile:           result.Profile,
+		Metadata:          result.Metadata,
+		OrganizationId:    result.OrganizationID,
+		ProjectRoles:      make([]*idp_pb.MappingProjectRoles, 0, len(result.ProjectRoles)),
+		GroupIds:          result.GroupIDs,
+		Errors:            make([]*idp_pb.MappingRuleError, len(result.Errors)),
+	}
+	for projectID, roleKeys := range result.ProjectRoles {
+		pbResult.ProjectRoles = append(pbResult.ProjectRoles, &idp_pb.MappingProjectRoles{
+			ProjectId: projectID,
+			RoleKeys:  roleKeys,
+		})
+	}
+	for i, ruleErr := range result.Errors {
+		pbResult.Errors[i] = &idp_pb.MappingRuleError{
+			Index:   uint32(ruleErr.Index),
+			Rule:    mappingRuleToPb(ruleErr.Rule),
+			Message: ruleErr.Err.Error(),
+		}
+	}
+	return pbResult
+}
+
+func LDAPSyncConfigToDomain(config *idp_pb.LDAPSyncConfig) *domain.LDAPSyncConfig {
+	return &domain.LDAPSyncConfig{
+		Enabled:           config.GetEnabled(),
+		OrganizationID:    config.GetOrganizationId(),
+		Filter:            config.GetFilter(),
+		Interval:          config.GetInterval().AsDuration(),
+		FullSyncInterval:  config.GetFullSyncInterval().AsDuration(),
+		DeltaAttribute:    ldapSyncDeltaAttributeToDomain(config.GetDeltaAttribute()),
+		DeactivateMissing: config.GetDeactivateMissing(),
+	}
+}
+
+func LDAPSyncToPb(sync *query.LDAPSync) *idp_pb.LDAPSync {
+	pbSync := &idp_pb.LDAPSync{
+		Details: obj_grpc.ChangeToDetailsPb(sync.Sequence, sync.ChangeDate, sync.ResourceOwner),
+		Config: &idp_pb.LDAPSyncConfig{
+			Enabled:           sync.Enabled,
+			OrganizationId:    sync.OrganizationID,
+			Filter:            sync.Filter,
+			Interval:          durationpb.New(sync.Interval),
+			FullSyncInterval:  durationpb.New(sync.FullSyncInterval),
+			DeltaAttribute:    ldapSyncDeltaAttributeToPb(sync.DeltaAttribute),
+			DeactivateMissing: sync.DeactivateMissing,
+		},
+	}
+	if sync.Enabled && !sync.NextRunAt.IsZero() {
+		pbSync.NextRunAt = timestamppb.New(sync.NextRunAt)
+	}
+	return pbSync
+}
+
+func LDAPSyncRunsToPb(runs []*query.LDAPSyncRun) []*idp_pb.LDAPSyncRun {
+	pbRuns := make([]*idp_pb.LDAPSyncRun, len(runs))
+	for i, run := range runs {
+		pbRuns[i] = &idp_pb.LDAPSyncRun{
+			Id:         run.ID,
+			StartedAt:  timestamppb.New(run.StartedAt),
+			FinishedAt: timestamppb.New(run.FinishedAt),
+			FullSync:   run.FullSync,
+			Stats: &idp_pb.LDAPSyncStats{
+				Created:     run.Stats.Created,
+				Updated:     run.Stats.Updated,
+				Unchanged:   run.Stats.Unchanged,
+				Deactivated: run.Stats.Deactivated,
+				Reactivated: run.Stats.Reactivated,
+				Failed:      run.Stats.Failed,
+			},
+			Error: run.Error,
+		}
+	}
+	return pbRuns
+}
+
+func ldapSyncDeltaAttributeToDomain(attribute idp_pb.LDAPSyncDeltaAttribute) domain.LDAPSyncDeltaAttribute {
+	switch attribute {
+	case idp_pb.LDAPSyncDeltaAttribute_LDAP_SYNC_DELTA_ATTRIBUTE_MODIFY_TIMESTAMP:
+		return domain.LDAPSyncDeltaAttributeModifyTimestamp
+	case idp_pb.LDAPSyncDeltaAttribute_LDAP_SYNC_DELTA_ATTRIBUTE_USN_CHANGED:
+		return domain.LDAPSyncDeltaAttributeUSNChanged
+	case idp_pb.LDAPSyncDeltaAttribute_LDAP_SYNC_DELTA_ATTRIBUTE_NONE:
+		return domain.LDAPSyncDeltaAttributeNone
+	default:
+		return domain.LDAPSyncDeltaAttributeNone
+	}
+}
+
+func ldapSyncDeltaAttributeToPb(attribute domain.LDAPSyncDeltaAttribute) idp_pb.LDAPSyncDeltaAttribute {
+	switch attribute {
+	case domain.LDAPSyncDeltaAttributeModifyTimestamp:
+		return idp_pb.LDAPSyncDeltaAttribute_LDAP_SYNC_DELTA_ATTRIBUTE_MODIFY_TIMESTAMP
+	case domain.LDAPSyncDeltaAttributeUSNChanged:
+		return idp_pb.LDAPSyncDeltaAttribute_LDAP_SYNC_DELTA_ATTRIBUTE_USN_CHANGED
+	case domain.LDAPSyncDeltaAttributeNone:
+		return idp_pb.LDAPSyncDeltaAttribute_LDAP_SYNC_DELTA_ATTRIBUTE_NONE
+	default:
+		return idp_pb.LDAPSyncDeltaAttribute_LDAP_SYNC_DELTA_ATTRIBUTE_NONE
+	}
+}
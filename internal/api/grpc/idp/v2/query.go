@@ -85,6 +85,8 @@ func idpTypeToPb(idpType domain.IDPType) idp_pb.IDPType {
 		return idp_pb.IDPType_IDP_TYPE_APPLE
 	case domain.IDPTypeSAML:
 		return idp_pb.IDPType_IDP_TYPE_SAML
+	case domain.IDPTypeX509:
+		return idp_pb.IDPType_IDP_TYPE_X509
 	case domain.IDPTypeUnspecified:
 		return idp_pb.IDPType_IDP_TYPE_UNSPECIFIED
 	default:
@@ -150,6 +152,10 @@ func configToPb(config *query.IDPTemplate) *idp_pb.IDPConfig {
 		samlConfigToPb(idpConfig, config.SAMLIDPTemplate)
 		return idpConfig
 	}
+	if config.X509IDPTemplate != nil {
+		x509ConfigToPb(idpConfig, config.X509IDPTemplate)
+		return idpConfig
+	}
 	return idpConfig
 }
 
@@ -386,3 +392,39 @@ func signatureAlgorithmToPb(signatureAlgorithm string) idp_pb.SAMLSignatureAlgor
 		return idp_pb.SAMLSignatureAlgorithm_SAML_SIGNATURE_UNSPECIFIED
 	}
 }
+
+func x509ConfigToPb(idpConfig *idp_pb.IDPConfig, template *query.X509IDPTemplate) {
+	idpConfig.Config = &idp_pb.IDPConfig_X509{
+		X509: &idp_pb.X509Config{
+			CertificateHeader:       template.CertificateHeader,
+			TrustedProxies:          template.TrustedProxies,
+			CaCertificates:          template.CACertificates,
+			Crls:                    template.CRLs,
+			OcspResponses:           template.OCSPResponses,
+			RevocationCheckRequired: template.RevocationCheckRequired,
+			IdField:                 x509CertificateFieldToPb(template.IDField),
+			UsernameField:           x509CertificateFieldToPb(template.UsernameField),
+		},
+	}
+}
+
+func x509CertificateFieldToPb(field domain.X509CertificateField) idp_pb.X509CertificateField {
+	switch field {
+	case domain.X509CertificateFieldUnspecified:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_UNSPECIFIED
+	case domain.X509CertificateFieldSubject:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT
+	case domain.X509CertificateFieldSubjectCommonName:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT_COMMON_NAME
+	case domain.X509CertificateFieldSubjectSerialNumber:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SUBJECT_SERIAL_NUMBER
+	case domain.X509CertificateFieldSANEmail:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_EMAIL
+	case domain.X509CertificateFieldSANUPN:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_UPN
+	case domain.X509CertificateFieldSANURI:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_SAN_URI
+	default:
+		return idp_pb.X509CertificateField_X509_CERTIFICATE_FIELD_UNSPECIFIED
+	}
+}
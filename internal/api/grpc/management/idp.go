@@ -418,6 +418,27 @@ func (s *Server) UpdateAppleProvider(ctx context.Context, req *mgmt_pb.UpdateApp
 	}, nil
 }
 
+func (s *Server) AddX509Provider(ctx context.Context, req *mgmt_pb.AddX509ProviderRequest) (*mgmt_pb.AddX509ProviderResponse, error) {
+	id, details, err := s.command.AddOrgX509Provider(ctx, authz.GetCtxData(ctx).OrgID, addX509ProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddX509ProviderResponse{
+		Id:      id,
+		Details: object_pb.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) UpdateX509Provider(ctx context.Context, req *mgmt_pb.UpdateX509ProviderRequest) (*mgmt_pb.UpdateX509ProviderResponse, error) {
+	details, err := s.command.UpdateOrgX509Provider(ctx, authz.GetCtxData(ctx).OrgID, req.Id, updateX509ProviderToCommand(req))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateX509ProviderResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
 func (s *Server) AddSAMLProvider(ctx context.Context, req *mgmt_pb.AddSAMLProviderRequest) (*mgmt_pb.AddSAMLProviderResponse, error) {
 	id, details, err := s.command.AddOrgSAMLProvider(ctx, authz.GetCtxData(ctx).OrgID, addSAMLProviderToCommand(req))
 	if err != nil {
@@ -449,6 +470,76 @@ func (s *Server) RegenerateSAMLProviderCertificate(ctx context.Context, req *mgm
 	}, nil
 }
 
+func (s *Server) SetProviderMappingRules(ctx context.Context, req *mgmt_pb.SetProviderMappingRulesRequest) (*mgmt_pb.SetProviderMappingRulesResponse, error) {
+	details, err := s.command.SetOrgIDPMappingRules(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.MappingRulesToDomain(req.Rules))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetProviderMappingRulesResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) PreviewProviderMapping(ctx context.Context, req *mgmt_pb.PreviewProviderMappingRequest) (*mgmt_pb.PreviewProviderMappingResponse, error) {
+	rules := idp_grpc.MappingRulesToDomain(req.GetRules().GetRules())
+	if req.GetId() != "" {
+		orgIDQuery, err := query.NewIDPTemplateResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+		if err != nil {
+			return nil, err
+		}
+		idp, err := s.query.IDPTemplateByID(ctx, true, req.GetId(), false, nil, orgIDQuery)
+		if err != nil {
+			return nil, err
+		}
+		rules = idp.MappingRules
+	}
+	result, err := idp_grpc.PreviewMapping(rules, req.GetUserinfo(), req.GetClaims(), req.GetAttributes())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.PreviewProviderMappingResponse{Result: result}, nil
+}
+
+func (s *Server) GetLDAPProviderSync(ctx context.Context, req *mgmt_pb.GetLDAPProviderSyncRequest) (*mgmt_pb.GetLDAPProviderSyncResponse, error) {
+	sync, err := s.query.LDAPSyncByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetLDAPProviderSyncResponse{
+		Sync: idp_grpc.LDAPSyncToPb(sync),
+	}, nil
+}
+
+func (s *Server) SetLDAPProviderSync(ctx context.Context, req *mgmt_pb.SetLDAPProviderSyncRequest) (*mgmt_pb.SetLDAPProviderSyncResponse, error) {
+	details, err := s.command.SetOrgLDAPSync(ctx, authz.GetCtxData(ctx).OrgID, req.Id, idp_grpc.LDAPSyncConfigToDomain(req.Config))
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetLDAPProviderSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListLDAPProviderSyncRuns(ctx context.Context, req *mgmt_pb.ListLDAPProviderSyncRunsRequest) (*mgmt_pb.ListLDAPProviderSyncRunsResponse, error) {
+	// ensures the identity provider belongs to the organization
+	if _, err := s.query.LDAPSyncByIDPID(ctx, req.Id, authz.GetCtxData(ctx).OrgID); err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object_pb.ListQueryToModel(req.Query)
+	resp, err := s.query.SearchLDAPSyncRuns(ctx, req.Id, &query.SearchRequest{
+		Offset: offset,
+		Limit:  limit,
+		Asc:    asc,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListLDAPProviderSyncRunsResponse{
+		Result:  idp_grpc.LDAPSyncRunsToPb(resp.Runs),
+		Details: object_pb.ToListDetails(resp.Count, resp.Sequence, resp.LastRun),
+	}, nil
+}
+
 func (s *Server) DeleteProvider(ctx context.Context, req *mgmt_pb.DeleteProviderRequest) (*mgmt_pb.DeleteProviderResponse, error) {
 	details, err := s.command.DeleteOrgProvider(ctx, authz.GetCtxData(ctx).OrgID, req.Id)
 	if err != nil {
@@ -470,6 +470,36 @@ func updateAppleProviderToCommand(req *mgmt_pb.UpdateAppleProviderRequest) comma
 	}
 }
 
+func addX509ProviderToCommand(req *mgmt_pb.AddX509ProviderRequest) command.X509Provider {
+	return command.X509Provider{
+		Name:                    req.Name,
+		CertificateHeader:       req.CertificateHeader,
+		TrustedProxies:          req.TrustedProxies,
+		CACertificates:          req.CaCertificates,
+		CRLs:                    req.Crls,
+		OCSPResponses:           req.OcspResponses,
+		RevocationCheckRequired: req.RevocationCheckRequired,
+		IDField:                 idp_grpc.X509CertificateFieldToDomain(req.IdField),
+		UsernameField:           idp_grpc.X509CertificateFieldToDomain(req.UsernameField),
+		IDPOptions:              idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
+func updateX509ProviderToCommand(req *mgmt_pb.UpdateX509ProviderRequest) command.X509Provider {
+	return command.X509Provider{
+		Name:                    req.Name,
+		CertificateHeader:       req.CertificateHeader,
+		TrustedProxies:          req.TrustedProxies,
+		CACertificates:          req.CaCertificates,
+		CRLs:                    req.Crls,
+		OCSPResponses:           req.OcspResponses,
+		RevocationCheckRequired: req.RevocationCheckRequired,
+		IDField:                 idp_grpc.X509CertificateFieldToDomain(req.IdField),
+		UsernameField:           idp_grpc.X509CertificateFieldToDomain(req.UsernameField),
+		IDPOptions:              idp_grpc.OptionsToCommand(req.ProviderOptions),
+	}
+}
+
 func addSAMLProviderToCommand(req *mgmt_pb.AddSAMLProviderRequest) *command.SAMLProvider {
 	var nameIDFormat *domain.SAMLNameIDFormat
 	if req.NameIdFormat != nil {
@@ -18,6 +18,36 @@ import (
 	"github.com/zitadel/zitadel/pkg/grpc/org/v2"
 )
 
+func (s *Server) GetOrganization(ctx context.Context, req *connect.Request[org.GetOrganizationRequest]) (*connect.Response[org.GetOrganizationResponse], error) {
+	if req.Msg.AsOf != nil {
+		return s.getOrganizationAsOf(ctx, req.Msg)
+	}
+	organization, err := s.query.OrgByID(ctx, req.Msg.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkPermission(ctx, domain.PermissionOrgRead, organization.ID, organization.ID); err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&org.GetOrganizationResponse{
+		Organization: organizationToPb(organization),
+	}), nil
+}
+
+func (s *Server) getOrganizationAsOf(ctx context.Context, req *org.GetOrganizationRequest) (*connect.Response[org.GetOrganizationResponse], error) {
+	asOf, err := filter.AsOfPbToQuery(req.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	organization, err := s.query.OrgAsOf(ctx, req.GetOrganizationId(), asOf, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&org.GetOrganizationResponse{
+		Organization: organizationToPb(organization),
+	}), nil
+}
+
 func (s *Server) ListOrganizations(ctx context.Context, req *connect.Request[org.ListOrganizationsRequest]) (*connect.Response[org.ListOrganizationsResponse], error) {
 	if authz.GetFeatures(ctx).EnableRelationalTables {
 		return orgv2.ListOrganizations(ctx, req)
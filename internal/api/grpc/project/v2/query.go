@@ -15,6 +15,9 @@ import (
 )
 
 func (s *Server) GetProject(ctx context.Context, req *connect.Request[project_pb.GetProjectRequest]) (*connect.Response[project_pb.GetProjectResponse], error) {
+	if req.Msg.AsOf != nil {
+		return s.getProjectAsOf(ctx, req.Msg)
+	}
 	project, err := s.query.GetProjectByIDWithPermission(ctx, true, req.Msg.GetProjectId(), s.checkPermission)
 	if err != nil {
 		return nil, err
@@ -24,6 +27,20 @@ func (s *Server) GetProject(ctx context.Context, req *connect.Request[project_pb
 	}), nil
 }
 
+func (s *Server) getProjectAsOf(ctx context.Context, req *project_pb.GetProjectRequest) (*connect.Response[project_pb.GetProjectResponse], error) {
+	asOf, err := filter.AsOfPbToQuery(req.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	project, err := s.query.ProjectAsOf(ctx, req.GetProjectId(), asOf, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&project_pb.GetProjectResponse{
+		Project: projectToPb(project),
+	}), nil
+}
+
 func (s *Server) ListProjects(ctx context.Context, req *connect.Request[project_pb.ListProjectsRequest]) (*connect.Response[project_pb.ListProjectsResponse], error) {
 	queries, err := s.listProjectRequestToModel(req.Msg)
 	if err != nil {
@@ -52,7 +52,7 @@ func (s *Server) failSAMLRequest(ctx context.Context, samlRequestID string, ae *
 		return nil, err
 	}
 	authReq := &saml.AuthRequestV2{CurrentSAMLRequest: aar}
-	url, body, err := s.idp.CreateErrorResponse(authReq, errorReasonToDomain(ae.GetError()), ae.GetErrorDescription())
+	url, body, err := s.idp.CreateErrorResponse(ctx, authReq, errorReasonToDomain(ae.GetError()), ae.GetErrorDescription())
 	if err != nil {
 		return nil, err
 	}
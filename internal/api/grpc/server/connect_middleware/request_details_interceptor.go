@@ -10,7 +10,7 @@ import (
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 )
 
-// RequestDetailsHandler is a connect interceptor that sets request details in the context
+// RequestDetailsHandler is a connect interceptor that sets request details and the user agent in the context
 // and adds the ID to the response headers.
 // It depends on [CallDurationHandler] to set the request start time in the context.
 func RequestDetailsHandler() connect.UnaryInterceptorFunc {
@@ -18,6 +18,7 @@ func RequestDetailsHandler() connect.UnaryInterceptorFunc {
 		return func(ctx context.Context, req connect.AnyRequest) (connect.AnyResponse, error) {
 			domainCtx := http_util.DomainContext(ctx)
 			reqCtx := instrumentation.WithRequestDetails(ctx, domainCtx.InstanceHost, domainCtx.PublicHost)
+			reqCtx = http_util.WithUserAgent(reqCtx, req.Header().Get(http_util.UserAgentHeader))
 			id := instrumentation.GetRequestID(reqCtx)
 
 			resp, err := next(reqCtx, req)
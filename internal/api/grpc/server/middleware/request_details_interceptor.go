@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 
+	"github.com/grpc-ecosystem/go-grpc-middleware/util/metautils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
@@ -11,13 +12,14 @@ import (
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 )
 
-// RequestDetailsHandler is a gRPC interceptor that sets a request ID in the context
+// RequestDetailsHandler is a gRPC interceptor that sets a request ID and the user agent in the context
 // and adds the ID to the response headers.
 // It depends on [CallDurationHandler] to set the request start time in the context.
 func RequestDetailsHandler() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		domainCtx := http_util.DomainContext(ctx)
 		reqCtx := instrumentation.WithRequestDetails(ctx, domainCtx.InstanceHost, domainCtx.PublicHost)
+		reqCtx = http_util.WithUserAgent(reqCtx, requestUserAgent(ctx))
 		id := instrumentation.GetRequestID(reqCtx)
 		md := metadata.New(map[string]string{http_util.XRequestID: id.String()})
 
@@ -27,3 +29,13 @@ func RequestDetailsHandler() grpc.UnaryServerInterceptor {
 		return handler(reqCtx, req)
 	}
 }
+
+// requestUserAgent returns the user agent of the client,
+// the gRPC gateway forwards the user agent of REST calls with a prefix.
+func requestUserAgent(ctx context.Context) string {
+	md := metautils.ExtractIncoming(ctx)
+	if agent := md.Get("grpcgateway-" + http_util.UserAgentHeader); agent != "" {
+		return agent
+	}
+	return md.Get(http_util.UserAgentHeader)
+}
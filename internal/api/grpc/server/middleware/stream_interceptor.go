@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// StreamInterceptor runs the unary interceptor for server streams.
+// The request of a stream is only received by the handler, therefore the interceptor is called without request.
+// Only interceptors which can handle a nil request and response must be used,
+// interceptors depending on the request (e.g. the validation) need a dedicated stream interceptor (e.g. [ValidationStreamHandler]).
+func StreamInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		unaryInfo := &grpc.UnaryServerInfo{
+			Server:     srv,
+			FullMethod: info.FullMethod,
+		}
+		_, err := interceptor(stream.Context(), nil, unaryInfo, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			return nil, handler(srv, &serverStream{ServerStream: stream, ctx: ctx})
+		})
+		return err
+	}
+}
+
+// serverStream overwrites the context of the stream with the context set by the interceptors
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type ctxKey struct{}
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (m *mockServerStream) Context() context.Context {
+	return m.ctx
+}
+
+func TestStreamInterceptor(t *testing.T) {
+	tests := []struct {
+		name        string
+		interceptor grpc.UnaryServerInterceptor
+		handlerErr  error
+		wantValue   any
+		wantCode    codes.Code
+	}{
+		{
+			name: "context passed to handler",
+			interceptor: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				return handler(context.WithValue(ctx, ctxKey{}, "value"), req)
+			},
+			wantValue: "value",
+		},
+		{
+			name: "interceptor error",
+			interceptor: func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+				return nil, status.Error(codes.Unauthenticated, "auth header missing")
+			},
+			wantCode: codes.Unauthenticated,
+		},
+		{
+			name:        "handler error",
+			interceptor: ErrorHandler(),
+			handlerErr:  errors.New("stream failed"),
+			wantCode:    codes.Unknown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotValue any
+			err := StreamInterceptor(tt.interceptor)(
+				nil,
+				&mockServerStream{ctx: context.Background()},
+				&grpc.StreamServerInfo{FullMethod: "/zitadel.event.v2.EventService/SubscribeEvents", IsServerStream: true},
+				func(_ any, stream grpc.ServerStream) error {
+					gotValue = stream.Context().Value(ctxKey{})
+					return tt.handlerErr
+				},
+			)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+			assert.Equal(t, tt.wantValue, gotValue)
+		})
+	}
+}
+
+type mockRecvStream struct {
+	mockServerStream
+	msg *validatedRequest
+}
+
+func (m *mockRecvStream) RecvMsg(msg any) error {
+	*msg.(*validatedRequest) = *m.msg
+	return nil
+}
+
+type validatedRequest struct {
+	valid bool
+}
+
+func (m *validatedRequest) Validate() error {
+	if !m.valid {
+		return errors.New("invalid request")
+	}
+	return nil
+}
+
+func TestValidationStreamHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      *validatedRequest
+		wantCode codes.Code
+	}{
+		{
+			name: "valid request",
+			req:  &validatedRequest{valid: true},
+		},
+		{
+			name:     "invalid request",
+			req:      &validatedRequest{valid: false},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidationStreamHandler()(
+				nil,
+				&mockRecvStream{mockServerStream: mockServerStream{ctx: context.Background()}, msg: tt.req},
+				&grpc.StreamServerInfo{FullMethod: "/zitadel.event.v2.EventService/SubscribeEvents", IsServerStream: true},
+				func(_ any, stream grpc.ServerStream) error {
+					return stream.RecvMsg(new(validatedRequest))
+				},
+			)
+			assert.Equal(t, tt.wantCode, status.Code(err))
+		})
+	}
+}
@@ -32,3 +32,25 @@ func TranslationHandler() func(ctx context.Context, req interface{}, info *grpc.
 func getTranslator(ctx context.Context) *i18n.Translator {
 	return i18n.NewZitadelTranslator(authz.GetInstance(ctx).DefaultLanguage())
 }
+
+// TranslationStreamHandler translates the messages sent on server streams and the returned error.
+func TranslationStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		err := handler(srv, &translationStream{ServerStream: stream})
+		if err != nil {
+			err = translateError(stream.Context(), err, getTranslator(stream.Context()))
+		}
+		return err
+	}
+}
+
+type translationStream struct {
+	grpc.ServerStream
+}
+
+func (s *translationStream) SendMsg(m interface{}) error {
+	if loc, ok := m.(localizers); ok {
+		translateFields(s.Context(), loc, getTranslator(s.Context()))
+	}
+	return s.ServerStream.SendMsg(m)
+}
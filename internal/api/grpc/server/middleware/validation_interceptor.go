@@ -34,3 +34,29 @@ func validate(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	}
 	return handler(ctx, req)
 }
+
+// ValidationStreamHandler validates the messages received on streams,
+// the request of a server stream is only received by the handler, so it can't be validated by the [ValidationHandler].
+func ValidationStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &validationStream{ServerStream: stream})
+	}
+}
+
+type validationStream struct {
+	grpc.ServerStream
+}
+
+func (s *validationStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	validate, ok := m.(validator)
+	if !ok {
+		return nil
+	}
+	if err := validate.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return nil
+}
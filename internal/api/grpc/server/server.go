@@ -93,6 +93,20 @@ func CreateServer(
 				middleware.ActivityInterceptor(),
 			),
 		),
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				middleware.StreamInterceptor(middleware.CallDurationHandler()),
+				middleware.StreamInterceptor(middleware.RequestDetailsHandler()),
+				middleware.StreamInterceptor(middleware.LogHandler(grpc_api.Probes...)),
+				middleware.StreamInterceptor(middleware.InstanceInterceptor(queries, externalDomain, translator, system_pb.SystemService_ServiceDesc.ServiceName, healthpb.Health_ServiceDesc.ServiceName)),
+				middleware.StreamInterceptor(middleware.ErrorHandler()),
+				middleware.StreamInterceptor(middleware.LimitsInterceptor(system_pb.SystemService_ServiceDesc.ServiceName)),
+				middleware.StreamInterceptor(middleware.AuthorizationInterceptor(verifier, systemAuthz, authConfig)),
+				middleware.TranslationStreamHandler(),
+				middleware.ValidationStreamHandler(),
+				middleware.StreamInterceptor(middleware.ServiceHandler()),
+			),
+		),
 		grpc.StatsHandler(middleware.DefaultTracingServer()),
 		grpc.MaxSendMsgSize(MaxSendMsgSize),
 	}
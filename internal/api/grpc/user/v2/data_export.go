@@ -0,0 +1,78 @@
+package user
+
+import (
+	"context"
+
+	"connectrpc.com/connect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/pkg/grpc/user/v2"
+)
+
+func (s *Server) ExportUserData(ctx context.Context, req *connect.Request[user.ExportUserDataRequest]) (*connect.Response[user.ExportUserDataResponse], error) {
+	exportID, details, err := s.command.RequestUserDataExport(ctx, req.Msg.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&user.ExportUserDataResponse{
+		ExportId:     exportID,
+		CreationDate: timestamppb.New(details.EventDate),
+	}), nil
+}
+
+func (s *Server) GetUserDataExport(ctx context.Context, req *connect.Request[user.GetUserDataExportRequest]) (*connect.Response[user.GetUserDataExportResponse], error) {
+	export, err := s.query.UserDataExportByID(ctx, req.Msg.GetUserId(), req.Msg.GetExportId(), s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	pb, err := s.userDataExportToPb(ctx, export)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&user.GetUserDataExportResponse{
+		Export: pb,
+	}), nil
+}
+
+func (s *Server) userDataExportToPb(ctx context.Context, export *query.UserDataExport) (*user.UserDataExport, error) {
+	pb := &user.UserDataExport{
+		ExportId:     export.ID,
+		UserId:       export.UserID,
+		State:        userDataExportStateToPb(export.State),
+		CreationDate: timestamppb.New(export.CreationDate),
+		ChangeDate:   timestamppb.New(export.ChangeDate),
+	}
+	if !export.ExpirationDate.IsZero() {
+		pb.ExpirationDate = timestamppb.New(export.ExpirationDate)
+	}
+	if export.State != domain.UserDataExportStateReady {
+		return pb, nil
+	}
+	code, err := crypto.DecryptString(export.Code, s.userCodeAlg)
+	if err != nil {
+		return nil, err
+	}
+	pb.Size = export.Size
+	pb.DownloadUrl = domain.UserDataExportLink(s.assetAPIPrefix(ctx), export.UserID, export.ID, code)
+	return pb, nil
+}
+
+func userDataExportStateToPb(state domain.UserDataExportState) user.UserDataExportState {
+	switch state {
+	case domain.UserDataExportStateRequested:
+		return user.UserDataExportState_USER_DATA_EXPORT_STATE_REQUESTED
+	case domain.UserDataExportStateReady:
+		return user.UserDataExportState_USER_DATA_EXPORT_STATE_READY
+	case domain.UserDataExportStateFailed:
+		return user.UserDataExportState_USER_DATA_EXPORT_STATE_FAILED
+	case domain.UserDataExportStateExpired:
+		return user.UserDataExportState_USER_DATA_EXPORT_STATE_EXPIRED
+	case domain.UserDataExportStateUnspecified:
+		return user.UserDataExportState_USER_DATA_EXPORT_STATE_UNSPECIFIED
+	default:
+		return user.UserDataExportState_USER_DATA_EXPORT_STATE_UNSPECIFIED
+	}
+}
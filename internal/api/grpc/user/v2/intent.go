@@ -17,6 +17,7 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
@@ -201,9 +202,11 @@ func (s *Server) RetrieveIdentityProviderIntent(ctx context.Context, req *connec
 	if err != nil {
 		return nil, err
 	}
+	idpUser, mappingResult := s.mapIDPUser(ctx, intent, idpUser)
 	if idpIntent.UserId == "" {
 		idpIntent.AddHumanUser = idpUserToAddHumanUser(idpUser, idpIntent.IdpInformation.IdpId) //nolint:staticcheck
 		idpIntent.UserAction = idpUserToCreateUser(idpUser, idpIntent.IdpInformation.IdpId)
+		applyMappingResultToCreateUser(idpIntent, mappingResult)
 	} else {
 		idpIntent.UpdateHumanUser = idpUserToUpdateHumanUser(intent.UserID, idpUser) //nolint:staticcheck
 		idpIntent.UserAction = idpUserToUpdateUser(intent.UserID, idpUser)
@@ -211,6 +214,55 @@ func (s *Server) RetrieveIdentityProviderIntent(ctx context.Context, req *connec
 	return connect.NewResponse(idpIntent), nil
 }
 
+// mapIDPUser applies the mapping rules of the identity provider (if any) to the user.
+// Failing rules are ignored, so the value provided by the identity provider is used instead.
+func (s *Server) mapIDPUser(ctx context.Context, intent *command.IDPIntentWriteModel, idpUser idp.User) (idp.User, *mapping.Result) {
+	template, err := s.query.IDPTemplateByID(ctx, false, intent.IDPID, false, nil)
+	if err != nil || len(template.MappingRules) == 0 {
+		return idpUser, nil
+	}
+	mapper, err := mapping.New(template.MappingRules)
+	if err != nil {
+		return idpUser, nil
+	}
+	input, err := mapping.InputFromUser(idpUser, nil)
+	if err != nil {
+		return idpUser, nil
+	}
+	if intent.IDPIDToken != "" {
+		input.Claims, err = mapping.ClaimsFromIDToken(intent.IDPIDToken)
+		if err != nil {
+			return idpUser, nil
+		}
+	}
+	result, err := mapper.Map(input)
+	if err != nil {
+		return idpUser, nil
+	}
+	return result.User(idpUser), result
+}
+
+func applyMappingResultToCreateUser(idpIntent *user.RetrieveIdentityProviderIntentResponse, result *mapping.Result) {
+	if result == nil {
+		return
+	}
+	addHumanUser := idpIntent.AddHumanUser //nolint:staticcheck
+	for _, metadata := range result.MetadataList() {
+		addHumanUser.Metadata = append(addHumanUser.Metadata, &user.SetMetadataEntry{
+			Key:   metadata.Key,
+			Value: metadata.Value,
+		})
+	}
+	if result.OrganizationID != "" {
+		addHumanUser.Organization = &object_pb.Organization{
+			Org: &object_pb.Organization_OrgId{OrgId: result.OrganizationID},
+		}
+		if createUser, ok := idpIntent.UserAction.(*user.RetrieveIdentityProviderIntentResponse_CreateUser); ok {
+			createUser.CreateUser.OrganizationId = result.OrganizationID
+		}
+	}
+}
+
 type rawUserMapper struct {
 	RawInfo map[string]interface{}
 }
@@ -5,6 +5,7 @@ import (
 
 	"connectrpc.com/connect"
 
+	"github.com/zitadel/zitadel/internal/api/grpc/filter/v2"
 	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
 	"github.com/zitadel/zitadel/internal/api/grpc/user/v2/convert"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -12,6 +13,9 @@ import (
 )
 
 func (s *Server) GetUserByID(ctx context.Context, req *connect.Request[user.GetUserByIDRequest]) (_ *connect.Response[user.GetUserByIDResponse], err error) {
+	if req.Msg.AsOf != nil {
+		return s.getUserByIDAsOf(ctx, req.Msg)
+	}
 	resp, err := s.query.GetUserByIDWithPermission(ctx, true, req.Msg.GetUserId(), s.checkPermission)
 	if err != nil {
 		return nil, err
@@ -27,6 +31,26 @@ func (s *Server) GetUserByID(ctx context.Context, req *connect.Request[user.GetU
 	}), nil
 }
 
+func (s *Server) getUserByIDAsOf(ctx context.Context, req *user.GetUserByIDRequest) (*connect.Response[user.GetUserByIDResponse], error) {
+	asOf, err := filter.AsOfPbToQuery(req.GetAsOf())
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.query.UserAsOf(ctx, req.GetUserId(), asOf, s.checkPermission)
+	if err != nil {
+		return nil, err
+	}
+	return connect.NewResponse(&user.GetUserByIDResponse{
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      resp.Sequence,
+			CreationDate:  resp.CreationDate,
+			EventDate:     resp.ChangeDate,
+			ResourceOwner: resp.ResourceOwner,
+		}),
+		User: convert.UserToPb(resp, s.assetAPIPrefix(ctx)),
+	}), nil
+}
+
 func (s *Server) ListUsers(ctx context.Context, req *connect.Request[user.ListUsersRequest]) (*connect.Response[user.ListUsersResponse], error) {
 	queries, err := convert.ListUsersRequestToModel(req.Msg)
 	if err != nil {
@@ -15,6 +15,7 @@ const (
 	AcceptLanguage         = "accept-language"
 	CacheControl           = "cache-control"
 	ContentType            = "content-type"
+	ContentDisposition     = "content-disposition"
 	ContentLength          = "content-length"
 	ContentLocation        = "content-location"
 	Expires                = "expires"
@@ -64,6 +65,7 @@ const (
 	httpHeaders key = iota
 	remoteAddr
 	domainCtx
+	userAgent
 )
 
 func CopyHeadersToContext(h http.Handler) http.Handler {
@@ -80,6 +82,25 @@ func HeadersFromCtx(ctx context.Context) (http.Header, bool) {
 	return headers, ok
 }
 
+// WithUserAgent sets the user agent of the request to the context,
+// for requests whose headers are not copied by [CopyHeadersToContext].
+func WithUserAgent(ctx context.Context, agent string) context.Context {
+	return context.WithValue(ctx, userAgent, agent)
+}
+
+// UserAgentFromCtx returns the user agent set by [WithUserAgent]
+// or from the headers copied by [CopyHeadersToContext].
+func UserAgentFromCtx(ctx context.Context) string {
+	if agent, ok := ctx.Value(userAgent).(string); ok {
+		return agent
+	}
+	headers, ok := HeadersFromCtx(ctx)
+	if !ok {
+		return ""
+	}
+	return headers.Get(UserAgentHeader)
+}
+
 func OriginHeader(ctx context.Context) string {
 	headers, ok := ctx.Value(httpHeaders).(http.Header)
 	if !ok {
@@ -35,6 +35,7 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
 	saml2 "github.com/zitadel/zitadel/internal/idp/providers/saml"
+	"github.com/zitadel/zitadel/internal/idp/providers/x509"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -557,7 +558,7 @@ func (h *Handler) fetchIDPUserFromCode(ctx context.Context, identityProvider idp
 		session = openid.NewSession(provider.Provider, code, idpArguments)
 	case *apple.Provider:
 		session = apple.NewSession(provider, code, appleUser)
-	case *jwt.Provider, *ldap.Provider, *saml2.Provider:
+	case *jwt.Provider, *ldap.Provider, *saml2.Provider, *x509.Provider:
 		return nil, nil, zerrors.ThrowInvalidArgument(nil, "IDP-52jmn", "Errors.ExternalIDP.IDPTypeNotImplemented")
 	default:
 		return nil, nil, zerrors.ThrowUnimplemented(nil, "IDP-SSDg", "Errors.ExternalIDP.IDPTypeNotImplemented")
@@ -0,0 +1,186 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// session is the authentication state of a connection after a successful bind.
+type session struct {
+	instance       authz.Instance
+	instanceDomain string
+	orgID          string
+	userID         string
+}
+
+func (s *session) context(ctx context.Context) context.Context {
+	ctx = authz.WithInstance(ctx, s.instance)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: s.userID, OrgID: s.orgID})
+}
+
+func (c *conn) bind(ctx context.Context, req *request) *ber.Packet {
+	bind, err := parseBindRequest(req.op)
+	if err != nil {
+		return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultProtocolError, "", err.Error())
+	}
+	// every bind request resets the authentication of the connection
+	c.session = nil
+	if bind.version != 3 {
+		return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultProtocolError, "", "only LDAPv3 is supported")
+	}
+	if !bind.simple {
+		return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultAuthMethodNotSupported, "", "only simple bind is supported")
+	}
+	if bind.name == "" && bind.password == "" {
+		return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultSuccess, "", "")
+	}
+	if bind.password == "" {
+		return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultUnwillingToPerform, "", "unauthenticated bind is not allowed")
+	}
+	if c.server.config.RequireTLS && !c.tls {
+		return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultConfidentialityRequired, "", "StartTLS is required")
+	}
+	session, err := c.server.authenticate(ctx, bind.name, bind.password)
+	if err != nil {
+		logging.WithFields("dn", bind.name).WithError(err).Info("ldap bind failed")
+		if zerrors.IsInternal(err) {
+			return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultOther, "", "")
+		}
+		if errors.Is(err, errSecondFactorRequired) {
+			return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultStrongAuthRequired, "", "a second factor is required")
+		}
+		return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultInvalidCredentials, "", "")
+	}
+	c.session = session
+	return newResult(goldap.ApplicationBindResponse, goldap.LDAPResultSuccess, "", "")
+}
+
+// errSecondFactorRequired is returned if the user has to authenticate with a second factor,
+// which is not possible with a simple bind.
+var errSecondFactorRequired = errors.New("second factor required")
+
+// authenticate checks the password of a human or the secret of a machine user identified by the DN.
+// Humans are only authenticated if the login policy and their second factors allow a login with the password only.
+// The secret of a machine user is only accepted as app password if enabled by the config.
+func (s *Server) authenticate(ctx context.Context, dn, password string) (_ *session, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	loc, err := parseLocation(dn)
+	if err != nil || loc.unit != unitUsers || loc.name == "" {
+		return nil, zerrors.ThrowNotFound(err, "LDAP-Ws4vk", "Errors.User.NotFound")
+	}
+	instance, err := s.queries.InstanceByHost(ctx, loc.domain, "")
+	if err != nil {
+		return nil, zerrors.ThrowNotFound(err, "LDAP-Lz8pe", "Errors.Instance.NotFound")
+	}
+	ctx = authz.WithInstance(ctx, instance)
+	user, err := s.user(ctx, loc.orgID, loc.name)
+	if err != nil {
+		return nil, err
+	}
+	if user.State != domain.UserStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "LDAP-Xo2nq", "Errors.User.NotActive")
+	}
+	ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: user.ID, OrgID: user.ResourceOwner})
+	switch {
+	case user.Human != nil:
+		err = s.checkHumanPassword(ctx, user, password)
+	case user.Machine != nil && s.config.MachineSecrets:
+		err = s.checkMachineSecret(ctx, user, password)
+	case user.Machine != nil:
+		err = zerrors.ThrowPreconditionFailed(nil, "LDAP-Ny4ke", "Errors.User.NotHuman")
+	default:
+		err = zerrors.ThrowPreconditionFailed(nil, "LDAP-Gr6tb", "Errors.User.NotFound")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session{
+		instance:       instance,
+		instanceDomain: loc.domain,
+		orgID:          user.ResourceOwner,
+		userID:         user.ID,
+	}, nil
+}
+
+// checkHumanPassword verifies the password of a human user,
+// if the login policy of the organization and the second factors of the user allow it.
+// The password is verified last, so the result of a bind never reveals if the password was correct.
+func (s *Server) checkHumanPassword(ctx context.Context, user *query.User, password string) error {
+	policy, err := s.queries.LoginPolicyByID(ctx, false, user.ResourceOwner, false)
+	if err != nil {
+		return err
+	}
+	if err = checkPasswordAllowed(policy); err != nil {
+		return err
+	}
+	requirements, err := s.queries.ListUserAuthMethodTypesRequired(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	if err = checkPasswordOnly(policy, requirements.SetUpFactors); err != nil {
+		return err
+	}
+	return s.commands.HumanCheckPassword(ctx, user.ResourceOwner, user.ID, password, nil)
+}
+
+// checkPasswordAllowed returns an error if the login policy doesn't allow a login with username and password.
+func checkPasswordAllowed(policy *query.LoginPolicy) error {
+	if !policy.AllowUsernamePassword {
+		return zerrors.ThrowPreconditionFailed(nil, "LDAP-Dv8qe", "Errors.Org.LoginPolicy.UsernamePasswordNotAllowed")
+	}
+	return nil
+}
+
+// checkPasswordOnly returns [errSecondFactorRequired] if the login policy forces multiple factors
+// or the user has set up a second factor, as a simple bind can only provide the password.
+func checkPasswordOnly(policy *query.LoginPolicy, setUpFactors []domain.UserAuthMethodType) error {
+	// the password is always a local authentication
+	if policy.ForceMFA || policy.ForceMFALocalOnly {
+		return zerrors.ThrowPreconditionFailed(errSecondFactorRequired, "LDAP-Mf3pz", "Errors.User.MFA.PasswordOnly")
+	}
+	if domain.Has2FA(setUpFactors) {
+		return zerrors.ThrowPreconditionFailed(errSecondFactorRequired, "LDAP-Kq7wb", "Errors.User.MFA.PasswordOnly")
+	}
+	return nil
+}
+
+// checkMachineSecret verifies the client secret of a service account, which is used as app password.
+// Failed checks are recorded and lock the service account the same way as failed password checks of humans.
+func (s *Server) checkMachineSecret(ctx context.Context, user *query.User, secret string) error {
+	if user.Machine.EncodedSecret == "" {
+		return zerrors.ThrowPreconditionFailed(nil, "LDAP-Va7fm", "Errors.User.Machine.Secret.NotExisting")
+	}
+	return s.commands.MachineSecretCheck(ctx, user.ID, user.ResourceOwner, secret)
+}
+
+// user returns the user of the organization with the username (case-insensitive).
+func (s *Server) user(ctx context.Context, orgID, username string) (*query.User, error) {
+	ownerQuery, err := query.NewUserResourceOwnerSearchQuery(orgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	usernameQuery, err := query.NewUserUsernameSearchQuery(username, query.TextEqualsIgnoreCase)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.queries.SearchUsers(ctx, &query.UserSearchQueries{Queries: []query.SearchQuery{ownerQuery, usernameQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(users.Users) != 1 {
+		return nil, zerrors.ThrowNotFound(nil, "LDAP-Pf3ds", "Errors.User.NotFound")
+	}
+	return users.Users[0], nil
+}
@@ -0,0 +1,73 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_checkPasswordAllowed(t *testing.T) {
+	assert.NoError(t, checkPasswordAllowed(&query.LoginPolicy{AllowUsernamePassword: true}))
+	assert.True(t, zerrors.IsPreconditionFailed(checkPasswordAllowed(&query.LoginPolicy{AllowUsernamePassword: false})))
+}
+
+func Test_checkPasswordOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       *query.LoginPolicy
+		setUpFactors []domain.UserAuthMethodType
+		wantErr      bool
+	}{
+		{
+			name:         "password only, ok",
+			policy:       &query.LoginPolicy{AllowUsernamePassword: true},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+		},
+		{
+			name:         "idp linked, ok",
+			policy:       &query.LoginPolicy{AllowUsernamePassword: true},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword, domain.UserAuthMethodTypeIDP},
+		},
+		{
+			name:    "mfa forced, error",
+			policy:  &query.LoginPolicy{AllowUsernamePassword: true, ForceMFA: true},
+			wantErr: true,
+		},
+		{
+			name:    "mfa forced for local authentication, error",
+			policy:  &query.LoginPolicy{AllowUsernamePassword: true, ForceMFALocalOnly: true},
+			wantErr: true,
+		},
+		{
+			name:         "totp set up, error",
+			policy:       &query.LoginPolicy{AllowUsernamePassword: true},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword, domain.UserAuthMethodTypeTOTP},
+			wantErr:      true,
+		},
+		{
+			name:         "u2f set up, error",
+			policy:       &query.LoginPolicy{AllowUsernamePassword: true},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypeU2F},
+			wantErr:      true,
+		},
+		{
+			name:         "passkey set up, ok",
+			policy:       &query.LoginPolicy{AllowUsernamePassword: true},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword, domain.UserAuthMethodTypePasswordless},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPasswordOnly(tt.policy, tt.setUpFactors)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, errSecondFactorRequired)
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package ldap
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/config/network"
+)
+
+type Config struct {
+	// Enabled starts the LDAP listener, which exposes the users and groups of the organizations as read-only directory.
+	Enabled bool
+	Port    uint16
+	// TLS is used for StartTLS, the extended operation is not supported if TLS is disabled.
+	TLS network.TLS
+	// RequireTLS rejects simple binds over connections without StartTLS.
+	RequireTLS bool
+	// SizeLimit is the maximum number of entries returned by a search, a smaller limit of the client is respected.
+	SizeLimit uint64
+	// IdleTimeout closes connections without requests.
+	IdleTimeout time.Duration
+	// MachineSecrets allows service accounts to bind with their client secret as app password.
+	MachineSecrets bool
+}
@@ -0,0 +1,114 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/zitadel/logging"
+)
+
+// conn handles the requests of a client connection in order.
+type conn struct {
+	server  *Server
+	conn    net.Conn
+	tls     bool
+	session *session
+}
+
+func newConn(server *Server, netConn net.Conn) *conn {
+	return &conn{
+		server: server,
+		conn:   netConn,
+	}
+}
+
+func (c *conn) serve(ctx context.Context) {
+	defer func() {
+		logging.OnError(c.conn.Close()).Debug("unable to close ldap connection")
+	}()
+	for ctx.Err() == nil {
+		if c.server.config.IdleTimeout > 0 {
+			if err := c.conn.SetReadDeadline(time.Now().Add(c.server.config.IdleTimeout)); err != nil {
+				return
+			}
+		}
+		req, err := readRequest(c.conn)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) {
+				logging.WithError(err).Debug("unable to read ldap request")
+			}
+			return
+		}
+		if !c.handle(ctx, req) {
+			return
+		}
+	}
+}
+
+// handle processes the request and returns false if the connection must be closed.
+func (c *conn) handle(ctx context.Context, req *request) bool {
+	switch req.op.Tag {
+	case goldap.ApplicationBindRequest:
+		return c.write(req.messageID, c.bind(ctx, req))
+	case goldap.ApplicationUnbindRequest:
+		return false
+	case goldap.ApplicationSearchRequest:
+		return c.search(ctx, req)
+	case goldap.ApplicationExtendedRequest:
+		return c.extended(req)
+	case goldap.ApplicationAbandonRequest:
+		// requests are processed in order, there is no running operation to abandon
+		return true
+	case goldap.ApplicationModifyRequest,
+		goldap.ApplicationAddRequest,
+		goldap.ApplicationDelRequest,
+		goldap.ApplicationModifyDNRequest:
+		return c.write(req.messageID, newResult(req.op.Tag+1, goldap.LDAPResultUnwillingToPerform, "", "the directory is read-only"))
+	case goldap.ApplicationCompareRequest:
+		return c.write(req.messageID, newResult(req.op.Tag+1, goldap.LDAPResultUnwillingToPerform, "", "operation not supported"))
+	}
+	return false
+}
+
+// extended handles StartTLS (RFC 4511, section 4.14), other extended operations are not supported.
+func (c *conn) extended(req *request) bool {
+	extended, err := parseExtendedRequest(req.op)
+	if err != nil {
+		return c.write(req.messageID, newExtendedResult(goldap.LDAPResultProtocolError, err.Error(), ""))
+	}
+	if extended.name != startTLSOID {
+		return c.write(req.messageID, newExtendedResult(goldap.LDAPResultProtocolError, "unsupported extended operation", ""))
+	}
+	if c.server.tlsConfig == nil {
+		return c.write(req.messageID, newExtendedResult(goldap.LDAPResultUnavailable, "StartTLS is not configured", startTLSOID))
+	}
+	if c.tls {
+		return c.write(req.messageID, newExtendedResult(goldap.LDAPResultOperationsError, "TLS is already established", startTLSOID))
+	}
+	if !c.write(req.messageID, newExtendedResult(goldap.LDAPResultSuccess, "", startTLSOID)) {
+		return false
+	}
+	tlsConn := tls.Server(c.conn, c.server.tlsConfig)
+	if err := tlsConn.Handshake(); err != nil {
+		logging.WithError(err).Info("ldap tls handshake failed")
+		return false
+	}
+	c.conn = tlsConn
+	c.tls = true
+	return true
+}
+
+// write sends the response and returns false if the connection is broken.
+func (c *conn) write(messageID int64, op *ber.Packet, controls ...goldap.Control) bool {
+	if _, err := c.conn.Write(newMessage(messageID, op, controls...).Bytes()); err != nil {
+		logging.WithError(err).Debug("unable to write ldap response")
+		return false
+	}
+	return true
+}
@@ -0,0 +1,90 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient connects an LDAP client to a connection of the server without queries and commands,
+// so only requests which don't need a bound user can be tested.
+func newTestClient(t *testing.T, config Config) *goldap.Conn {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	serverConn, clientConn := net.Pipe()
+	go newConn(&Server{config: config}, serverConn).serve(ctx)
+	client := goldap.NewConn(clientConn, false)
+	client.Start()
+	t.Cleanup(func() {
+		client.Close()
+		cancel()
+	})
+	return client
+}
+
+func TestConn_rootDSE(t *testing.T) {
+	client := newTestClient(t, Config{})
+	result, err := client.Search(goldap.NewSearchRequest("", goldap.ScopeBaseObject, goldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", []string{"supportedLDAPVersion", "supportedControl", "supportedExtension", "namingContexts"}, nil,
+	))
+	require.NoError(t, err)
+	require.Len(t, result.Entries, 1)
+	assert.Equal(t, "", result.Entries[0].DN)
+	assert.Equal(t, []string{"3"}, result.Entries[0].GetAttributeValues("supportedLDAPVersion"))
+	assert.Equal(t, []string{goldap.ControlTypePaging}, result.Entries[0].GetAttributeValues("supportedControl"))
+	assert.Empty(t, result.Entries[0].GetAttributeValues("supportedExtension"))
+	assert.Empty(t, result.Entries[0].GetAttributeValues("namingContexts"))
+}
+
+func TestConn_rootDSE_paging(t *testing.T) {
+	client := newTestClient(t, Config{})
+	result, err := client.SearchWithPaging(goldap.NewSearchRequest("", goldap.ScopeBaseObject, goldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", nil, nil,
+	), 10)
+	require.NoError(t, err)
+	assert.Len(t, result.Entries, 1)
+}
+
+func TestConn_searchWithoutBind(t *testing.T) {
+	client := newTestClient(t, Config{})
+	_, err := client.Search(goldap.NewSearchRequest("dc=acme,dc=com", goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		"(objectClass=*)", nil, nil,
+	))
+	assert.True(t, goldap.IsErrorWithCode(err, goldap.LDAPResultInsufficientAccessRights), err)
+}
+
+func TestConn_bind(t *testing.T) {
+	t.Run("anonymous", func(t *testing.T) {
+		client := newTestClient(t, Config{RequireTLS: true})
+		assert.NoError(t, client.UnauthenticatedBind(""))
+	})
+	t.Run("unauthenticated", func(t *testing.T) {
+		client := newTestClient(t, Config{})
+		err := client.UnauthenticatedBind("uid=alice,ou=users,o=org1,dc=acme,dc=com")
+		assert.True(t, goldap.IsErrorWithCode(err, goldap.LDAPResultUnwillingToPerform), err)
+	})
+	t.Run("tls required", func(t *testing.T) {
+		client := newTestClient(t, Config{RequireTLS: true})
+		err := client.Bind("uid=alice,ou=users,o=org1,dc=acme,dc=com", "password")
+		assert.True(t, goldap.IsErrorWithCode(err, goldap.LDAPResultConfidentialityRequired), err)
+	})
+}
+
+func TestConn_startTLSNotConfigured(t *testing.T) {
+	client := newTestClient(t, Config{})
+	err := client.StartTLS(&tls.Config{InsecureSkipVerify: true})
+	assert.True(t, goldap.IsErrorWithCode(err, goldap.LDAPResultUnavailable), err)
+}
+
+func TestConn_readOnly(t *testing.T) {
+	client := newTestClient(t, Config{})
+	err := client.Add(goldap.NewAddRequest("uid=bob,ou=users,o=org1,dc=acme,dc=com", nil))
+	assert.True(t, goldap.IsErrorWithCode(err, goldap.LDAPResultUnwillingToPerform), err)
+	err = client.Del(goldap.NewDelRequest("uid=alice,ou=users,o=org1,dc=acme,dc=com", nil))
+	assert.True(t, goldap.IsErrorWithCode(err, goldap.LDAPResultUnwillingToPerform), err)
+}
@@ -0,0 +1,360 @@
+package ldap
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// The directory of an instance is structured as follows, where the suffix is derived from the instance domain
+// (e.g. dc=acme,dc=zitadel,dc=cloud for acme.zitadel.cloud):
+//
+//	<suffix>
+//	└── o=<organization id>
+//	    ├── ou=users
+//	    │   └── uid=<username>
+//	    └── ou=groups
+//	        └── cn=<group name>
+const (
+	attributeDomainComponent = "dc"
+	attributeOrganization    = "o"
+	attributeUnit            = "ou"
+	attributeUserID          = "uid"
+	attributeCommonName      = "cn"
+
+	unitUsers  = "users"
+	unitGroups = "groups"
+
+	generalizedTimeFormat = "20060102150405Z"
+)
+
+var (
+	errInvalidDN = errors.New("dn is not part of the directory")
+
+	operationalAttributes = []string{"entryUUID", "entryDN", "createTimestamp", "modifyTimestamp", "hasSubordinates"}
+)
+
+// location is the position of a DN in the directory tree.
+type location struct {
+	domain string
+	orgID  string
+	unit   string
+	// name is the username or group name of an entry in a unit.
+	name string
+}
+
+// parseLocation parses a DN of the directory, the DN is read from the suffix to the entry.
+func parseLocation(dn string) (*location, error) {
+	parsed, err := goldap.ParseDN(dn)
+	if err != nil {
+		return nil, err
+	}
+	rdns := parsed.RDNs
+	components := make([]string, 0, len(rdns))
+	for len(rdns) > 0 {
+		attribute, ok := singleAttribute(rdns[len(rdns)-1])
+		if !ok || !strings.EqualFold(attribute.Type, attributeDomainComponent) {
+			break
+		}
+		components = append([]string{attribute.Value}, components...)
+		rdns = rdns[:len(rdns)-1]
+	}
+	if len(components) == 0 {
+		return nil, errInvalidDN
+	}
+	loc := &location{domain: strings.ToLower(strings.Join(components, "."))}
+	for i, expected := range []string{attributeOrganization, attributeUnit, ""} {
+		if len(rdns) == 0 {
+			return loc, nil
+		}
+		attribute, ok := singleAttribute(rdns[len(rdns)-1])
+		if !ok {
+			return nil, errInvalidDN
+		}
+		rdns = rdns[:len(rdns)-1]
+		switch i {
+		case 0:
+			if !strings.EqualFold(attribute.Type, expected) {
+				return nil, errInvalidDN
+			}
+			loc.orgID = attribute.Value
+		case 1:
+			if !strings.EqualFold(attribute.Type, expected) {
+				return nil, errInvalidDN
+			}
+			loc.unit = strings.ToLower(attribute.Value)
+			if loc.unit != unitUsers && loc.unit != unitGroups {
+				return nil, errInvalidDN
+			}
+		case 2:
+			if loc.unit == unitUsers && !strings.EqualFold(attribute.Type, attributeUserID) ||
+				loc.unit == unitGroups && !strings.EqualFold(attribute.Type, attributeCommonName) {
+				return nil, errInvalidDN
+			}
+			loc.name = attribute.Value
+		}
+	}
+	if len(rdns) > 0 {
+		return nil, errInvalidDN
+	}
+	return loc, nil
+}
+
+func singleAttribute(rdn *goldap.RelativeDN) (*goldap.AttributeTypeAndValue, bool) {
+	if len(rdn.Attributes) != 1 {
+		return nil, false
+	}
+	return rdn.Attributes[0], true
+}
+
+func suffixDN(instanceDomain string) string {
+	components := strings.Split(instanceDomain, ".")
+	for i, component := range components {
+		components[i] = attributeDomainComponent + "=" + goldap.EscapeDN(component)
+	}
+	return strings.Join(components, ",")
+}
+
+func orgDN(instanceDomain, orgID string) string {
+	return attributeOrganization + "=" + goldap.EscapeDN(orgID) + "," + suffixDN(instanceDomain)
+}
+
+func unitDN(instanceDomain, orgID, unit string) string {
+	return attributeUnit + "=" + unit + "," + orgDN(instanceDomain, orgID)
+}
+
+func userDN(instanceDomain, orgID, username string) string {
+	return attributeUserID + "=" + goldap.EscapeDN(username) + "," + unitDN(instanceDomain, orgID, unitUsers)
+}
+
+func groupDN(instanceDomain, orgID, name string) string {
+	return attributeCommonName + "=" + goldap.EscapeDN(name) + "," + unitDN(instanceDomain, orgID, unitGroups)
+}
+
+func isOperationalAttribute(name string) bool {
+	return containsFold(operationalAttributes, name)
+}
+
+// directory contains the structural entries of the organization of the bound user.
+// The user and group entries are loaded by the [directorySearch].
+type directory struct {
+	entries []*goldap.Entry
+}
+
+func newDirectory(instanceDomain string, org *query.Org) *directory {
+	return &directory{
+		entries: []*goldap.Entry{
+			suffixEntry(instanceDomain),
+			orgEntry(instanceDomain, org),
+			unitEntry(instanceDomain, org.ID, unitUsers),
+			unitEntry(instanceDomain, org.ID, unitGroups),
+		},
+	}
+}
+
+// memberOfDNs returns the DNs of the groups per user ID, memberships of unknown groups are ignored.
+func memberOfDNs(instanceDomain, orgID string, groups []*query.Group, members []*query.GroupUser) map[string][]string {
+	groupNames := make(map[string]string, len(groups))
+	for _, group := range groups {
+		groupNames[group.ID] = group.Name
+	}
+	memberOf := make(map[string][]string)
+	for _, member := range members {
+		if groupName, ok := groupNames[member.GroupID]; ok {
+			memberOf[member.UserID] = append(memberOf[member.UserID], groupDN(instanceDomain, orgID, groupName))
+		}
+	}
+	return memberOf
+}
+
+// memberDNs returns the DNs of the users per group ID, memberships of unknown (or inactive) users are ignored.
+func memberDNs(instanceDomain, orgID string, users []*query.User, members []*query.GroupUser) map[string][]string {
+	usernames := make(map[string]string, len(users))
+	for _, user := range users {
+		usernames[user.ID] = user.Username
+	}
+	memberDNs := make(map[string][]string)
+	for _, member := range members {
+		if username, ok := usernames[member.UserID]; ok {
+			memberDNs[member.GroupID] = append(memberDNs[member.GroupID], userDN(instanceDomain, orgID, username))
+		}
+	}
+	return memberDNs
+}
+
+// unitInScope returns if the user or group entries of the unit are in the scope of the base.
+func unitInScope(base *location, unit string, scope int64) bool {
+	switch {
+	case base.name != "":
+		return base.unit == unit && scope != goldap.ScopeSingleLevel
+	case base.unit != "":
+		return base.unit == unit && scope != goldap.ScopeBaseObject
+	default:
+		return scope == goldap.ScopeWholeSubtree
+	}
+}
+
+// search returns the entries in the scope of the base DN matching the filter.
+// If the base DN does not exist, the closest existing ancestor is returned as matched DN.
+func (d *directory) search(baseDN *goldap.DN, scope int64, match func(*goldap.Entry) (bool, error)) (entries []*goldap.Entry, found bool, matchedDN string, err error) {
+	for _, entry := range d.entries {
+		dn, err := goldap.ParseDN(entry.DN)
+		if err != nil {
+			return nil, false, "", err
+		}
+		if dn.EqualFold(baseDN) {
+			found = true
+		} else if dn.AncestorOfFold(baseDN) && len(matchedDN) < len(entry.DN) {
+			matchedDN = entry.DN
+		}
+		if !inScope(baseDN, dn, scope) {
+			continue
+		}
+		ok, err := match(entry)
+		if err != nil {
+			return nil, false, "", err
+		}
+		if ok {
+			entries = append(entries, entry)
+		}
+	}
+	if !found {
+		return nil, false, matchedDN, nil
+	}
+	return entries, true, "", nil
+}
+
+func inScope(base, dn *goldap.DN, scope int64) bool {
+	switch scope {
+	case goldap.ScopeBaseObject:
+		return dn.EqualFold(base)
+	case goldap.ScopeSingleLevel:
+		return len(dn.RDNs) == len(base.RDNs)+1 && base.AncestorOfFold(dn)
+	case goldap.ScopeWholeSubtree:
+		return dn.EqualFold(base) || base.AncestorOfFold(dn)
+	}
+	return false
+}
+
+func suffixEntry(instanceDomain string) *goldap.Entry {
+	return newEntry(suffixDN(instanceDomain), map[string][]string{
+		"objectClass":            {"top", "domain"},
+		attributeDomainComponent: {strings.Split(instanceDomain, ".")[0]},
+		"hasSubordinates":        {"TRUE"},
+	})
+}
+
+func orgEntry(instanceDomain string, org *query.Org) *goldap.Entry {
+	return newEntry(orgDN(instanceDomain, org.ID), map[string][]string{
+		"objectClass":         {"top", "organization"},
+		attributeOrganization: {org.ID},
+		"description":         {org.Name},
+		"entryUUID":           {org.ID},
+		"createTimestamp":     {generalizedTime(org.CreationDate)},
+		"modifyTimestamp":     {generalizedTime(org.ChangeDate)},
+		"hasSubordinates":     {"TRUE"},
+	})
+}
+
+func unitEntry(instanceDomain, orgID, unit string) *goldap.Entry {
+	return newEntry(unitDN(instanceDomain, orgID, unit), map[string][]string{
+		"objectClass":     {"top", "organizationalUnit"},
+		attributeUnit:     {unit},
+		"hasSubordinates": {"TRUE"},
+	})
+}
+
+func userEntry(instanceDomain string, user *query.User, memberOf []string) *goldap.Entry {
+	attributes := map[string][]string{
+		attributeUserID:   {user.Username},
+		"memberOf":        memberOf,
+		"entryUUID":       {user.ID},
+		"createTimestamp": {generalizedTime(user.CreationDate)},
+		"modifyTimestamp": {generalizedTime(user.ChangeDate)},
+		"hasSubordinates": {"FALSE"},
+	}
+	if user.Human != nil {
+		attributes["objectClass"] = []string{"top", "person", "organizationalPerson", "inetOrgPerson"}
+		attributes[attributeCommonName] = []string{firstNonEmpty(user.Human.DisplayName, user.Username)}
+		attributes["sn"] = []string{firstNonEmpty(user.Human.LastName, user.Username)}
+		attributes["givenName"] = nonEmpty(user.Human.FirstName)
+		attributes["displayName"] = nonEmpty(user.Human.DisplayName)
+		attributes["mail"] = nonEmpty(string(user.Human.Email))
+		attributes["telephoneNumber"] = nonEmpty(string(user.Human.Phone))
+		if !user.Human.PreferredLanguage.IsRoot() {
+			attributes["preferredLanguage"] = []string{user.Human.PreferredLanguage.String()}
+		}
+	}
+	if user.Machine != nil {
+		attributes["objectClass"] = []string{"top", "account"}
+		attributes[attributeCommonName] = []string{firstNonEmpty(user.Machine.Name, user.Username)}
+		attributes["description"] = nonEmpty(user.Machine.Description)
+	}
+	return newEntry(userDN(instanceDomain, user.ResourceOwner, user.Username), attributes)
+}
+
+func groupEntry(instanceDomain string, group *query.Group, members []string) *goldap.Entry {
+	return newEntry(groupDN(instanceDomain, group.ResourceOwner, group.Name), map[string][]string{
+		"objectClass":       {"top", "groupOfNames"},
+		attributeCommonName: {group.Name},
+		"description":       nonEmpty(group.Description),
+		"member":            members,
+		"entryUUID":         {group.ID},
+		"createTimestamp":   {generalizedTime(group.CreationDate)},
+		"modifyTimestamp":   {generalizedTime(group.ChangeDate)},
+		"hasSubordinates":   {"FALSE"},
+	})
+}
+
+// rootDSE describes the server to clients (RFC 4512, section 5.1).
+func rootDSE(namingContext string, startTLS bool) *goldap.Entry {
+	attributes := map[string][]string{
+		"objectClass":          {"top"},
+		"supportedLDAPVersion": {"3"},
+		"supportedControl":     {goldap.ControlTypePaging},
+		"vendorName":           {"ZITADEL"},
+	}
+	if namingContext != "" {
+		attributes["namingContexts"] = []string{namingContext}
+	}
+	if startTLS {
+		attributes["supportedExtension"] = []string{startTLSOID}
+	}
+	return goldap.NewEntry("", attributes)
+}
+
+// newEntry creates an entry without the attributes with no values.
+func newEntry(dn string, attributes map[string][]string) *goldap.Entry {
+	for name, values := range attributes {
+		if len(values) == 0 {
+			delete(attributes, name)
+		}
+	}
+	entry := goldap.NewEntry(dn, attributes)
+	entry.Attributes = append(entry.Attributes, goldap.NewEntryAttribute("entryDN", []string{dn}))
+	return entry
+}
+
+func generalizedTime(t time.Time) string {
+	return t.UTC().Format(generalizedTimeFormat)
+}
+
+func nonEmpty(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
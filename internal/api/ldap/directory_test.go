@@ -0,0 +1,327 @@
+package ldap
+
+import (
+	"testing"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_parseLocation(t *testing.T) {
+	tests := []struct {
+		name    string
+		dn      string
+		want    *location
+		wantErr bool
+	}{
+		{
+			name: "suffix",
+			dn:   "dc=acme,dc=zitadel,dc=cloud",
+			want: &location{domain: "acme.zitadel.cloud"},
+		},
+		{
+			name: "organization",
+			dn:   "o=org1,dc=acme,dc=zitadel,dc=cloud",
+			want: &location{domain: "acme.zitadel.cloud", orgID: "org1"},
+		},
+		{
+			name: "unit",
+			dn:   "ou=Groups,o=org1,dc=acme,dc=zitadel,dc=cloud",
+			want: &location{domain: "acme.zitadel.cloud", orgID: "org1", unit: unitGroups},
+		},
+		{
+			name: "user",
+			dn:   "UID=alice,OU=users,O=org1,DC=Acme,DC=zitadel,DC=cloud",
+			want: &location{domain: "acme.zitadel.cloud", orgID: "org1", unit: unitUsers, name: "alice"},
+		},
+		{
+			name: "user with escaped characters",
+			dn:   `uid=doe\, john,ou=users,o=org1,dc=localhost`,
+			want: &location{domain: "localhost", orgID: "org1", unit: unitUsers, name: "doe, john"},
+		},
+		{
+			name: "group",
+			dn:   "cn=admins,ou=groups,o=org1,dc=localhost",
+			want: &location{domain: "localhost", orgID: "org1", unit: unitGroups, name: "admins"},
+		},
+		{
+			name:    "no suffix",
+			dn:      "uid=alice,ou=users,o=org1",
+			wantErr: true,
+		},
+		{
+			name:    "unknown unit",
+			dn:      "ou=devices,o=org1,dc=localhost",
+			wantErr: true,
+		},
+		{
+			name:    "user in groups",
+			dn:      "uid=alice,ou=groups,o=org1,dc=localhost",
+			wantErr: true,
+		},
+		{
+			name:    "too deep",
+			dn:      "cn=x,uid=alice,ou=users,o=org1,dc=localhost",
+			wantErr: true,
+		},
+		{
+			name:    "multi valued rdn",
+			dn:      "uid=alice+cn=alice,ou=users,o=org1,dc=localhost",
+			wantErr: true,
+		},
+		{
+			name:    "invalid dn",
+			dn:      "uid",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLocation(tt.dn)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_directory_search(t *testing.T) {
+	date := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	dir := newDirectory("acme.com", &query.Org{ID: "org1", Name: "ACME", CreationDate: date, ChangeDate: date})
+	type args struct {
+		baseDN string
+		scope  int64
+		filter string
+	}
+	type want struct {
+		dns       []string
+		found     bool
+		matchedDN string
+	}
+	tests := []struct {
+		name string
+		args args
+		want want
+	}{
+		{
+			name: "base object",
+			args: args{
+				baseDN: "ou=users,o=org1,dc=acme,dc=com",
+				scope:  goldap.ScopeBaseObject,
+				filter: "(objectClass=*)",
+			},
+			want: want{
+				dns:   []string{"ou=users,o=org1,dc=acme,dc=com"},
+				found: true,
+			},
+		},
+		{
+			name: "single level",
+			args: args{
+				baseDN: "o=org1,dc=acme,dc=com",
+				scope:  goldap.ScopeSingleLevel,
+				filter: "(objectClass=*)",
+			},
+			want: want{
+				dns:   []string{"ou=users,o=org1,dc=acme,dc=com", "ou=groups,o=org1,dc=acme,dc=com"},
+				found: true,
+			},
+		},
+		{
+			name: "subtree with filter",
+			args: args{
+				baseDN: "DC=acme,DC=com",
+				scope:  goldap.ScopeWholeSubtree,
+				filter: "(objectClass=organization)",
+			},
+			want: want{
+				dns:   []string{"o=org1,dc=acme,dc=com"},
+				found: true,
+			},
+		},
+		{
+			name: "no match",
+			args: args{
+				baseDN: "ou=users,o=org1,dc=acme,dc=com",
+				scope:  goldap.ScopeSingleLevel,
+				filter: "(uid=bob)",
+			},
+			want: want{
+				found: true,
+			},
+		},
+		{
+			name: "other organization",
+			args: args{
+				baseDN: "ou=users,o=org2,dc=acme,dc=com",
+				scope:  goldap.ScopeWholeSubtree,
+				filter: "(objectClass=*)",
+			},
+			want: want{
+				matchedDN: "dc=acme,dc=com",
+			},
+		},
+		{
+			name: "user",
+			args: args{
+				baseDN: "uid=bob,ou=users,o=org1,dc=acme,dc=com",
+				scope:  goldap.ScopeBaseObject,
+				filter: "(objectClass=*)",
+			},
+			want: want{
+				matchedDN: "ou=users,o=org1,dc=acme,dc=com",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseDN, err := goldap.ParseDN(tt.args.baseDN)
+			require.NoError(t, err)
+			filter, err := goldap.CompileFilter(tt.args.filter)
+			require.NoError(t, err)
+			entries, found, matchedDN, err := dir.search(baseDN, tt.args.scope, func(entry *goldap.Entry) (bool, error) {
+				return matchFilter(filter, entry)
+			})
+			require.NoError(t, err)
+			dns := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				dns = append(dns, entry.DN)
+			}
+			assert.ElementsMatch(t, tt.want.dns, dns)
+			assert.Equal(t, tt.want.found, found)
+			assert.Equal(t, tt.want.matchedDN, matchedDN)
+		})
+	}
+}
+
+func Test_unitInScope(t *testing.T) {
+	tests := []struct {
+		name  string
+		base  *location
+		unit  string
+		scope int64
+		want  bool
+	}{
+		{
+			name:  "organization subtree",
+			base:  &location{orgID: "org1"},
+			unit:  unitUsers,
+			scope: goldap.ScopeWholeSubtree,
+			want:  true,
+		},
+		{
+			name:  "organization single level",
+			base:  &location{orgID: "org1"},
+			unit:  unitUsers,
+			scope: goldap.ScopeSingleLevel,
+			want:  false,
+		},
+		{
+			name:  "unit single level",
+			base:  &location{orgID: "org1", unit: unitUsers},
+			unit:  unitUsers,
+			scope: goldap.ScopeSingleLevel,
+			want:  true,
+		},
+		{
+			name:  "unit base object",
+			base:  &location{orgID: "org1", unit: unitUsers},
+			unit:  unitUsers,
+			scope: goldap.ScopeBaseObject,
+			want:  false,
+		},
+		{
+			name:  "other unit",
+			base:  &location{orgID: "org1", unit: unitGroups},
+			unit:  unitUsers,
+			scope: goldap.ScopeWholeSubtree,
+			want:  false,
+		},
+		{
+			name:  "entry base object",
+			base:  &location{orgID: "org1", unit: unitUsers, name: "alice"},
+			unit:  unitUsers,
+			scope: goldap.ScopeBaseObject,
+			want:  true,
+		},
+		{
+			name:  "entry single level",
+			base:  &location{orgID: "org1", unit: unitUsers, name: "alice"},
+			unit:  unitUsers,
+			scope: goldap.ScopeSingleLevel,
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, unitInScope(tt.base, tt.unit, tt.scope))
+		})
+	}
+}
+
+func Test_memberDNs(t *testing.T) {
+	users := []*query.User{{ID: "user1", Username: "alice"}}
+	groups := []*query.Group{{ID: "group1", Name: "admins"}}
+	members := []*query.GroupUser{
+		{GroupID: "group1", UserID: "user1"},
+		{GroupID: "group1", UserID: "unknown"},
+		{GroupID: "unknown", UserID: "user1"},
+	}
+	assert.Equal(t,
+		map[string][]string{"group1": {"uid=alice,ou=users,o=org1,dc=acme,dc=com"}},
+		memberDNs("acme.com", "org1", users, members),
+	)
+	assert.Equal(t,
+		map[string][]string{"user1": {"cn=admins,ou=groups,o=org1,dc=acme,dc=com"}},
+		memberOfDNs("acme.com", "org1", groups, members),
+	)
+}
+
+func Test_userEntry(t *testing.T) {
+	date := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	entry := userEntry("acme.com",
+		&query.User{
+			ID:            "user1",
+			ResourceOwner: "org1",
+			Username:      "alice",
+			CreationDate:  date,
+			ChangeDate:    date,
+			Human: &query.Human{
+				LastName:          "Doe",
+				Email:             "alice@acme.com",
+				PreferredLanguage: language.German,
+			},
+		},
+		[]string{"cn=admins,ou=groups,o=org1,dc=acme,dc=com"},
+	)
+	assert.Equal(t, "uid=alice,ou=users,o=org1,dc=acme,dc=com", entry.DN)
+	assert.Equal(t, []string{"top", "person", "organizationalPerson", "inetOrgPerson"}, entry.GetAttributeValues("objectClass"))
+	assert.Equal(t, []string{"alice"}, entry.GetAttributeValues("cn"))
+	assert.Equal(t, []string{"Doe"}, entry.GetAttributeValues("sn"))
+	assert.Equal(t, []string{"alice@acme.com"}, entry.GetAttributeValues("mail"))
+	assert.Equal(t, []string{"de"}, entry.GetAttributeValues("preferredLanguage"))
+	assert.Equal(t, []string{"cn=admins,ou=groups,o=org1,dc=acme,dc=com"}, entry.GetAttributeValues("memberOf"))
+	assert.Equal(t, []string{"20240101100000Z"}, entry.GetAttributeValues("createTimestamp"))
+	assert.Empty(t, entry.GetAttributeValues("givenName"))
+	assert.Empty(t, entry.GetAttributeValues("telephoneNumber"))
+
+	names := make([]string, 0, len(entry.Attributes))
+	for _, attribute := range selectAttributes(entry, nil) {
+		names = append(names, attribute.Name)
+	}
+	assert.NotContains(t, names, "entryUUID")
+	assert.Contains(t, names, "uid")
+	names = names[:0]
+	for _, attribute := range selectAttributes(entry, []string{"UID", "+"}) {
+		names = append(names, attribute.Name)
+	}
+	assert.ElementsMatch(t, []string{"uid", "entryUUID", "entryDN", "createTimestamp", "modifyTimestamp", "hasSubordinates"}, names)
+}
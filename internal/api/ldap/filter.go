@@ -0,0 +1,170 @@
+package ldap
+
+import (
+	"errors"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+var errInvalidFilter = errors.New("invalid filter")
+
+// matchFilter evaluates a search filter (RFC 4511, section 4.5.1.7) against the entry.
+// All values are compared case-insensitive, extensible matches are not supported and never match.
+func matchFilter(filter *ber.Packet, entry *goldap.Entry) (bool, error) {
+	if filter.ClassType != ber.ClassContext {
+		return false, errInvalidFilter
+	}
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		for _, child := range filter.Children {
+			ok, err := matchFilter(child, entry)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+		return true, nil
+	case goldap.FilterOr:
+		for _, child := range filter.Children {
+			ok, err := matchFilter(child, entry)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+		return false, nil
+	case goldap.FilterNot:
+		if len(filter.Children) != 1 {
+			return false, errInvalidFilter
+		}
+		ok, err := matchFilter(filter.Children[0], entry)
+		return !ok, err
+	case goldap.FilterPresent:
+		return len(attributeValues(entry, filter.Data.String())) > 0, nil
+	case goldap.FilterEqualityMatch, goldap.FilterApproxMatch:
+		return matchValues(filter, entry, func(value, assertion string) bool {
+			return strings.EqualFold(value, assertion)
+		})
+	case goldap.FilterGreaterOrEqual:
+		return matchValues(filter, entry, func(value, assertion string) bool {
+			return strings.ToLower(value) >= strings.ToLower(assertion)
+		})
+	case goldap.FilterLessOrEqual:
+		return matchValues(filter, entry, func(value, assertion string) bool {
+			return strings.ToLower(value) <= strings.ToLower(assertion)
+		})
+	case goldap.FilterSubstrings:
+		return matchSubstrings(filter, entry)
+	case goldap.FilterExtensibleMatch:
+		return false, nil
+	}
+	return false, errInvalidFilter
+}
+
+func matchValues(filter *ber.Packet, entry *goldap.Entry, match func(value, assertion string) bool) (bool, error) {
+	if len(filter.Children) != 2 {
+		return false, errInvalidFilter
+	}
+	assertion := filter.Children[1].Data.String()
+	for _, value := range attributeValues(entry, filter.Children[0].Data.String()) {
+		if match(value, assertion) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func matchSubstrings(filter *ber.Packet, entry *goldap.Entry) (bool, error) {
+	if len(filter.Children) != 2 {
+		return false, errInvalidFilter
+	}
+	substrings := filter.Children[1].Children
+	for _, value := range attributeValues(entry, filter.Children[0].Data.String()) {
+		ok, err := matchSubstring(strings.ToLower(value), substrings)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+	return false, nil
+}
+
+// matchSubstring checks the initial, any and final parts of the assertion in order.
+func matchSubstring(value string, substrings []*ber.Packet) (bool, error) {
+	for i, substring := range substrings {
+		part := strings.ToLower(substring.Data.String())
+		switch substring.Tag {
+		case goldap.FilterSubstringsInitial:
+			if i != 0 || !strings.HasPrefix(value, part) {
+				return false, nil
+			}
+			value = value[len(part):]
+		case goldap.FilterSubstringsAny:
+			index := strings.Index(value, part)
+			if index < 0 {
+				return false, nil
+			}
+			value = value[index+len(part):]
+		case goldap.FilterSubstringsFinal:
+			if i != len(substrings)-1 || !strings.HasSuffix(value, part) {
+				return false, nil
+			}
+		default:
+			return false, errInvalidFilter
+		}
+	}
+	return true, nil
+}
+
+// attributeValues returns the values of the attribute, options of the description (e.g. ;binary) are ignored.
+func attributeValues(entry *goldap.Entry, description string) []string {
+	name, _, _ := strings.Cut(description, ";")
+	return entry.GetEqualFoldAttributeValues(name)
+}
+
+// assertion is a condition, which every entry matching a filter fulfils.
+type assertion struct {
+	// attribute is the lower case name of the attribute without options
+	attribute string
+	value     string
+	// prefix is set for substring filters with only an initial part
+	prefix bool
+}
+
+// requiredAssertions returns the equality and prefix assertions, which every entry matching the filter fulfils.
+// They restrict the queries of the directory, the filter itself is still evaluated on the loaded entries.
+func requiredAssertions(filter *ber.Packet) []assertion {
+	if filter.ClassType != ber.ClassContext {
+		return nil
+	}
+	switch filter.Tag {
+	case goldap.FilterAnd:
+		var assertions []assertion
+		for _, child := range filter.Children {
+			assertions = append(assertions, requiredAssertions(child)...)
+		}
+		return assertions
+	case goldap.FilterEqualityMatch:
+		if len(filter.Children) != 2 {
+			return nil
+		}
+		return []assertion{{
+			attribute: assertionAttribute(filter.Children[0].Data.String()),
+			value:     filter.Children[1].Data.String(),
+		}}
+	case goldap.FilterSubstrings:
+		if len(filter.Children) != 2 || len(filter.Children[1].Children) != 1 || filter.Children[1].Children[0].Tag != goldap.FilterSubstringsInitial {
+			return nil
+		}
+		return []assertion{{
+			attribute: assertionAttribute(filter.Children[0].Data.String()),
+			value:     filter.Children[1].Children[0].Data.String(),
+			prefix:    true,
+		}}
+	}
+	return nil
+}
+
+func assertionAttribute(description string) string {
+	name, _, _ := strings.Cut(description, ";")
+	return strings.ToLower(name)
+}
@@ -0,0 +1,222 @@
+package ldap
+
+import (
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_matchFilter(t *testing.T) {
+	entry := goldap.NewEntry("uid=alice,ou=users,o=org1,dc=acme,dc=com", map[string][]string{
+		"objectClass": {"top", "person", "inetOrgPerson"},
+		"uid":         {"alice"},
+		"cn":          {"Alice Doe"},
+		"mail":        {"Alice@Example.com"},
+		"memberOf":    {"cn=admins,ou=groups,o=org1,dc=acme,dc=com", "cn=sales,ou=groups,o=org1,dc=acme,dc=com"},
+	})
+	tests := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{
+			name:   "present",
+			filter: "(objectClass=*)",
+			want:   true,
+		},
+		{
+			name:   "present missing attribute",
+			filter: "(telephoneNumber=*)",
+			want:   false,
+		},
+		{
+			name:   "equality case insensitive",
+			filter: "(mail=alice@example.COM)",
+			want:   true,
+		},
+		{
+			name:   "equality attribute name case insensitive",
+			filter: "(OBJECTCLASS=inetorgperson)",
+			want:   true,
+		},
+		{
+			name:   "equality no match",
+			filter: "(uid=bob)",
+			want:   false,
+		},
+		{
+			name:   "equality with attribute option",
+			filter: "(uid;lang-en=alice)",
+			want:   true,
+		},
+		{
+			name:   "substring initial",
+			filter: "(cn=ali*)",
+			want:   true,
+		},
+		{
+			name:   "substring any and final",
+			filter: "(cn=*ce*doe)",
+			want:   true,
+		},
+		{
+			name:   "substring order",
+			filter: "(cn=*doe*alice*)",
+			want:   false,
+		},
+		{
+			name:   "substring initial and final overlapping",
+			filter: "(uid=ali*ice)",
+			want:   false,
+		},
+		{
+			name:   "and",
+			filter: "(&(objectClass=person)(uid=alice)(memberOf=cn=admins,ou=groups,o=org1,dc=acme,dc=com))",
+			want:   true,
+		},
+		{
+			name:   "and no match",
+			filter: "(&(objectClass=person)(uid=bob))",
+			want:   false,
+		},
+		{
+			name:   "or",
+			filter: "(|(uid=bob)(mail=alice@example.com))",
+			want:   true,
+		},
+		{
+			name:   "not",
+			filter: "(!(uid=bob))",
+			want:   true,
+		},
+		{
+			name:   "greater or equal",
+			filter: "(uid>=al)",
+			want:   true,
+		},
+		{
+			name:   "less or equal",
+			filter: "(uid<=al)",
+			want:   false,
+		},
+		{
+			name:   "approx",
+			filter: "(uid~=ALICE)",
+			want:   true,
+		},
+		{
+			name:   "extensible never matches",
+			filter: "(uid:caseExactMatch:=alice)",
+			want:   false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := goldap.CompileFilter(tt.filter)
+			require.NoError(t, err)
+			got, err := matchFilter(filter, entry)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_requiredAssertions(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		want   []assertion
+	}{
+		{
+			name:   "equality",
+			filter: "(UID;binary=alice)",
+			want:   []assertion{{attribute: "uid", value: "alice"}},
+		},
+		{
+			name:   "and",
+			filter: "(&(objectClass=inetOrgPerson)(mail=alice*))",
+			want: []assertion{
+				{attribute: "objectclass", value: "inetOrgPerson"},
+				{attribute: "mail", value: "alice", prefix: true},
+			},
+		},
+		{
+			name:   "or is ignored",
+			filter: "(&(objectClass=person)(|(uid=alice)(uid=bob)))",
+			want:   []assertion{{attribute: "objectclass", value: "person"}},
+		},
+		{
+			name:   "not is ignored",
+			filter: "(!(uid=alice))",
+		},
+		{
+			name:   "substring with final part is ignored",
+			filter: "(mail=alice*.com)",
+		},
+		{
+			name:   "present is ignored",
+			filter: "(objectClass=*)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := goldap.CompileFilter(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, requiredAssertions(filter))
+		})
+	}
+}
+
+func Test_filterQueries(t *testing.T) {
+	tests := []struct {
+		name            string
+		filter          string
+		wantUsers       int
+		wantUsersMatch  bool
+		wantGroups      int
+		wantGroupsMatch bool
+	}{
+		{
+			name:            "all",
+			filter:          "(objectClass=*)",
+			wantUsersMatch:  true,
+			wantGroupsMatch: true,
+		},
+		{
+			name:           "persons",
+			filter:         "(&(objectClass=inetOrgPerson)(uid=ali*)(mail=alice@acme.com))",
+			wantUsers:      3,
+			wantUsersMatch: true,
+		},
+		{
+			name:            "groups",
+			filter:          "(&(objectClass=groupOfNames)(cn=admins))",
+			wantGroups:      1,
+			wantGroupsMatch: true,
+		},
+		{
+			name:            "entry uuid",
+			filter:          "(entryUUID=id1)",
+			wantUsers:       1,
+			wantUsersMatch:  true,
+			wantGroups:      1,
+			wantGroupsMatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := goldap.CompileFilter(tt.filter)
+			require.NoError(t, err)
+			userQueries, ok, err := userFilterQueries(filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantUsersMatch, ok)
+			assert.Len(t, userQueries, tt.wantUsers)
+			groupQueries, ok, err := groupFilterQueries(filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantGroupsMatch, ok)
+			assert.Len(t, groupQueries, tt.wantGroups)
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package ldap
+
+import (
+	"errors"
+	"io"
+	"slices"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+const (
+	// startTLSOID is the name of the StartTLS extended operation (RFC 4511, section 4.14).
+	startTLSOID = "1.3.6.1.4.1.1466.20037"
+	// noAttributesOID requests no attributes of the entries (RFC 4511, section 4.5.1.8).
+	noAttributesOID = "1.1"
+
+	allUserAttributes        = "*"
+	allOperationalAttributes = "+"
+)
+
+var errProtocol = errors.New("malformed ldap message")
+
+// request is an LDAPMessage sent by a client.
+type request struct {
+	messageID int64
+	op        *ber.Packet
+	controls  []goldap.Control
+	// critical are the types of the controls the client marked as critical
+	critical []string
+}
+
+func readRequest(r io.Reader) (*request, error) {
+	packet, err := ber.ReadPacket(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(packet.Children) < 2 {
+		return nil, errProtocol
+	}
+	messageID, ok := packet.Children[0].Value.(int64)
+	if !ok {
+		return nil, errProtocol
+	}
+	req := &request{
+		messageID: messageID,
+		op:        packet.Children[1],
+	}
+	if req.op.ClassType != ber.ClassApplication {
+		return nil, errProtocol
+	}
+	if len(packet.Children) > 2 {
+		for _, child := range packet.Children[2].Children {
+			control, err := goldap.DecodeControl(child)
+			if err != nil {
+				return nil, errProtocol
+			}
+			req.controls = append(req.controls, control)
+			if len(child.Children) > 1 {
+				if criticality, _ := child.Children[1].Value.(bool); criticality {
+					req.critical = append(req.critical, control.GetControlType())
+				}
+			}
+		}
+	}
+	return req, nil
+}
+
+// control returns the control of the request with the type or nil.
+func (r *request) control(controlType string) goldap.Control {
+	for _, control := range r.controls {
+		if control.GetControlType() == controlType {
+			return control
+		}
+	}
+	return nil
+}
+
+// unsupportedCriticalControl returns the type of a control which is marked as critical but not supported.
+func (r *request) unsupportedCriticalControl(supported ...string) string {
+	for _, controlType := range r.critical {
+		if !slices.Contains(supported, controlType) {
+			return controlType
+		}
+	}
+	return ""
+}
+
+type bindRequest struct {
+	version  int64
+	name     string
+	simple   bool
+	password string
+}
+
+func parseBindRequest(op *ber.Packet) (*bindRequest, error) {
+	if len(op.Children) != 3 {
+		return nil, errProtocol
+	}
+	version, ok := op.Children[0].Value.(int64)
+	if !ok {
+		return nil, errProtocol
+	}
+	name, ok := op.Children[1].Value.(string)
+	if !ok {
+		return nil, errProtocol
+	}
+	auth := op.Children[2]
+	return &bindRequest{
+		version:  version,
+		name:     name,
+		simple:   auth.ClassType == ber.ClassContext && auth.Tag == 0,
+		password: auth.Data.String(),
+	}, nil
+}
+
+type searchRequest struct {
+	baseDN     string
+	scope      int64
+	sizeLimit  int64
+	typesOnly  bool
+	filter     *ber.Packet
+	attributes []string
+}
+
+func parseSearchRequest(op *ber.Packet) (*searchRequest, error) {
+	if len(op.Children) != 8 {
+		return nil, errProtocol
+	}
+	baseDN, ok := op.Children[0].Value.(string)
+	if !ok {
+		return nil, errProtocol
+	}
+	scope, ok := op.Children[1].Value.(int64)
+	if !ok || scope < goldap.ScopeBaseObject || scope > goldap.ScopeWholeSubtree {
+		return nil, errProtocol
+	}
+	sizeLimit, ok := op.Children[3].Value.(int64)
+	if !ok || sizeLimit < 0 {
+		return nil, errProtocol
+	}
+	typesOnly, ok := op.Children[5].Value.(bool)
+	if !ok {
+		return nil, errProtocol
+	}
+	attributes := make([]string, 0, len(op.Children[7].Children))
+	for _, attribute := range op.Children[7].Children {
+		name, ok := attribute.Value.(string)
+		if !ok {
+			return nil, errProtocol
+		}
+		attributes = append(attributes, name)
+	}
+	return &searchRequest{
+		baseDN:     baseDN,
+		scope:      scope,
+		sizeLimit:  sizeLimit,
+		typesOnly:  typesOnly,
+		filter:     op.Children[6],
+		attributes: attributes,
+	}, nil
+}
+
+type extendedRequest struct {
+	name string
+}
+
+func parseExtendedRequest(op *ber.Packet) (*extendedRequest, error) {
+	if len(op.Children) == 0 || op.Children[0].ClassType != ber.ClassContext || op.Children[0].Tag != 0 {
+		return nil, errProtocol
+	}
+	return &extendedRequest{name: op.Children[0].Data.String()}, nil
+}
+
+func newMessage(messageID int64, op *ber.Packet, controls ...goldap.Control) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, messageID, "Message ID"))
+	packet.AppendChild(op)
+	if len(controls) > 0 {
+		list := ber.Encode(ber.ClassContext, ber.TypeConstructed, 0, nil, "Controls")
+		for _, control := range controls {
+			list.AppendChild(control.Encode())
+		}
+		packet.AppendChild(list)
+	}
+	return packet
+}
+
+// newResult creates an LDAPResult for the response operation.
+func newResult(tag ber.Tag, code uint16, matchedDN, message string) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, goldap.ApplicationMap[uint8(tag)])
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, matchedDN, "Matched DN"))
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "Diagnostic Message"))
+	return packet
+}
+
+func newExtendedResult(code uint16, message, name string) *ber.Packet {
+	packet := newResult(goldap.ApplicationExtendedResponse, code, "", message)
+	if name != "" {
+		packet.AppendChild(ber.NewString(ber.ClassContext, ber.TypePrimitive, 10, name, "Response Name"))
+	}
+	return packet
+}
+
+// newSearchResultEntry encodes the selected attributes of the entry.
+func newSearchResultEntry(entry *goldap.Entry, attributes []string, typesOnly bool) *ber.Packet {
+	packet := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Search Result Entry")
+	packet.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, entry.DN, "Object Name"))
+	list := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for _, attribute := range selectAttributes(entry, attributes) {
+		partial := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Partial Attribute")
+		partial.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, attribute.Name, "Type"))
+		values := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+		if !typesOnly {
+			for _, value := range attribute.Values {
+				values.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, value, "Value"))
+			}
+		}
+		partial.AppendChild(values)
+		list.AppendChild(partial)
+	}
+	packet.AppendChild(list)
+	return packet
+}
+
+// selectAttributes returns the attributes of the entry requested by the client (RFC 4511, section 4.5.1.8).
+// No requested attributes or "*" select all user attributes, operational attributes must be requested by name or "+".
+func selectAttributes(entry *goldap.Entry, requested []string) []*goldap.EntryAttribute {
+	if len(requested) == 1 && requested[0] == noAttributesOID {
+		return nil
+	}
+	allUser := len(requested) == 0 || containsFold(requested, allUserAttributes)
+	allOperational := containsFold(requested, allOperationalAttributes)
+	selected := make([]*goldap.EntryAttribute, 0, len(entry.Attributes))
+	for _, attribute := range entry.Attributes {
+		operational := isOperationalAttribute(attribute.Name)
+		if (allUser && !operational) || (allOperational && operational) || containsFold(requested, attribute.Name) {
+			selected = append(selected, attribute)
+		}
+	}
+	return selected
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,475 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// searchPageSize is the number of users or groups loaded at once, until the size limit is exceeded.
+const searchPageSize = 100
+
+var (
+	userObjectClasses  = []string{"top", "person", "organizationalPerson", "inetOrgPerson", "account"}
+	humanObjectClasses = []string{"person", "organizationalPerson", "inetOrgPerson"}
+	groupObjectClasses = []string{"top", "groupOfNames"}
+)
+
+// searchResult is the outcome of a search, it contains one entry more than the size limit, if the limit was exceeded.
+type searchResult struct {
+	entries   []*goldap.Entry
+	code      uint16
+	matchedDN string
+	message   string
+}
+
+func (c *conn) search(ctx context.Context, req *request) bool {
+	search, err := parseSearchRequest(req.op)
+	if err != nil {
+		return c.write(req.messageID, newResult(goldap.ApplicationSearchResultDone, goldap.LDAPResultProtocolError, "", err.Error()))
+	}
+	if controlType := req.unsupportedCriticalControl(goldap.ControlTypePaging); controlType != "" {
+		return c.write(req.messageID, newResult(goldap.ApplicationSearchResultDone, goldap.LDAPResultUnavailableCriticalExtension, "", "unsupported critical control "+controlType))
+	}
+	var controls []goldap.Control
+	if req.control(goldap.ControlTypePaging) != nil {
+		// all entries are returned in a single page, the empty cookie ends the paged search
+		controls = append(controls, &goldap.ControlPaging{})
+	}
+	sizeLimit := c.server.config.SizeLimit
+	if search.sizeLimit > 0 && (sizeLimit == 0 || uint64(search.sizeLimit) < sizeLimit) {
+		sizeLimit = uint64(search.sizeLimit)
+	}
+	result := c.searchEntries(ctx, search, sizeLimit)
+	for i, entry := range result.entries {
+		if sizeLimit > 0 && uint64(i) >= sizeLimit {
+			result.code = goldap.LDAPResultSizeLimitExceeded
+			break
+		}
+		if !c.write(req.messageID, newSearchResultEntry(entry, search.attributes, search.typesOnly)) {
+			return false
+		}
+	}
+	return c.write(req.messageID, newResult(goldap.ApplicationSearchResultDone, result.code, result.matchedDN, result.message), controls...)
+}
+
+func (c *conn) searchEntries(ctx context.Context, search *searchRequest, sizeLimit uint64) *searchResult {
+	// the root DSE can be read without authentication
+	if search.baseDN == "" && search.scope == goldap.ScopeBaseObject {
+		var namingContext string
+		if c.session != nil {
+			namingContext = suffixDN(c.session.instanceDomain)
+		}
+		entry := rootDSE(namingContext, c.server.tlsConfig != nil)
+		ok, err := matchFilter(search.filter, entry)
+		if err != nil {
+			return &searchResult{code: goldap.LDAPResultProtocolError, message: err.Error()}
+		}
+		if !ok {
+			return &searchResult{code: goldap.LDAPResultSuccess}
+		}
+		return &searchResult{entries: []*goldap.Entry{entry}, code: goldap.LDAPResultSuccess}
+	}
+	if c.session == nil {
+		return &searchResult{code: goldap.LDAPResultInsufficientAccessRights, message: "bind required"}
+	}
+	baseDN, err := goldap.ParseDN(search.baseDN)
+	if err != nil {
+		return &searchResult{code: goldap.LDAPResultInvalidDNSyntax, message: err.Error()}
+	}
+	base, err := parseLocation(search.baseDN)
+	if err != nil {
+		return &searchResult{code: goldap.LDAPResultNoSuchObject}
+	}
+	s := &directorySearch{
+		Server:    c.server,
+		session:   c.session,
+		base:      base,
+		scope:     search.scope,
+		filter:    search.filter,
+		sizeLimit: sizeLimit,
+	}
+	result, err := s.search(c.session.context(ctx), baseDN)
+	if errors.Is(err, errInvalidFilter) {
+		return &searchResult{code: goldap.LDAPResultProtocolError, message: err.Error()}
+	}
+	if err != nil {
+		logging.WithError(err).Warn("ldap search failed")
+		return &searchResult{code: goldap.LDAPResultOther}
+	}
+	return result
+}
+
+// directorySearch searches the directory of the organization of the bound user.
+// The base DN, the assertions of the filter and the size limit are pushed down to the queries,
+// the complete filter is evaluated on the loaded entries.
+type directorySearch struct {
+	*Server
+	session   *session
+	base      *location
+	scope     int64
+	filter    *ber.Packet
+	sizeLimit uint64
+	// readAll is false if the bound user is not allowed to read the users of its organization,
+	// it only finds its own entry then.
+	readAll bool
+}
+
+// pageLoader loads the entries of a page, the last page contains less entries than the limit.
+type pageLoader func(ctx context.Context, offset, limit uint64) ([]*goldap.Entry, error)
+
+func (s *directorySearch) search(ctx context.Context, baseDN *goldap.DN) (_ *searchResult, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	org, err := s.queries.OrgByID(ctx, s.session.orgID)
+	if err != nil {
+		return nil, err
+	}
+	entries, found, matchedDN, err := newDirectory(s.session.instanceDomain, org).search(baseDN, s.scope, s.match)
+	if err != nil {
+		return nil, err
+	}
+	// the base DN might be a user or group of the organization, which is checked by loading it
+	entryBase := !found && s.base.name != "" && matchedDN == unitDN(s.session.instanceDomain, s.session.orgID, s.base.unit)
+	if !found && !entryBase {
+		return &searchResult{code: goldap.LDAPResultNoSuchObject, matchedDN: matchedDN}, nil
+	}
+	s.readAll = s.checkPermission(ctx, domain.PermissionUserRead, s.session.orgID, "") == nil
+
+	var loadedUsers, loadedGroups bool
+	if unitInScope(s.base, unitUsers, s.scope) || entryBase && s.base.unit == unitUsers {
+		queries, ok, err := s.userQueries()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			entries, loadedUsers, err = s.collect(ctx, entries, unitInScope(s.base, unitUsers, s.scope), s.userPage(queries))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	// groups reveal the members, so they are only listed to users allowed to read all users
+	if s.readAll && (unitInScope(s.base, unitGroups, s.scope) || entryBase && s.base.unit == unitGroups) {
+		queries, ok, err := s.groupQueries()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			entries, loadedGroups, err = s.collect(ctx, entries, unitInScope(s.base, unitGroups, s.scope), s.groupPage(queries))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	if entryBase && !loadedUsers && !loadedGroups {
+		return &searchResult{code: goldap.LDAPResultNoSuchObject, matchedDN: matchedDN}, nil
+	}
+	return &searchResult{entries: entries, code: goldap.LDAPResultSuccess}, nil
+}
+
+func (s *directorySearch) match(entry *goldap.Entry) (bool, error) {
+	return matchFilter(s.filter, entry)
+}
+
+// collect adds the matching entries of the pages until the size limit is exceeded.
+// It returns if any entry was loaded, so the existence of a base entry can be checked without adding it (inScope false).
+func (s *directorySearch) collect(ctx context.Context, entries []*goldap.Entry, inScope bool, load pageLoader) (_ []*goldap.Entry, loaded bool, err error) {
+	for offset := uint64(0); !s.limitExceeded(entries); offset += searchPageSize {
+		page, err := load(ctx, offset, searchPageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		loaded = loaded || len(page) > 0
+		if !inScope {
+			return entries, loaded, nil
+		}
+		for _, entry := range page {
+			ok, err := s.match(entry)
+			if err != nil {
+				return nil, false, err
+			}
+			if ok {
+				entries = append(entries, entry)
+			}
+		}
+		if len(page) < searchPageSize {
+			break
+		}
+	}
+	return entries, loaded, nil
+}
+
+func (s *directorySearch) limitExceeded(entries []*goldap.Entry) bool {
+	return s.sizeLimit > 0 && uint64(len(entries)) > s.sizeLimit
+}
+
+// userQueries returns the queries for the users in scope, ok is false if no user can match the filter.
+func (s *directorySearch) userQueries() (_ []query.SearchQuery, ok bool, err error) {
+	ownerQuery, err := query.NewUserResourceOwnerSearchQuery(s.session.orgID, query.TextEquals)
+	if err != nil {
+		return nil, false, err
+	}
+	activeQuery, err := activeUsersQuery()
+	if err != nil {
+		return nil, false, err
+	}
+	queries := []query.SearchQuery{ownerQuery, activeQuery}
+	if !s.readAll {
+		ownQuery, err := query.NewUserInUserIdsSearchQuery([]string{s.session.userID})
+		if err != nil {
+			return nil, false, err
+		}
+		queries = append(queries, ownQuery)
+	}
+	// the filter is not pushed down for a user as base, so its existence can be checked
+	if s.base.name != "" {
+		usernameQuery, err := query.NewUserUsernameSearchQuery(s.base.name, query.TextEqualsIgnoreCase)
+		if err != nil {
+			return nil, false, err
+		}
+		return append(queries, usernameQuery), true, nil
+	}
+	filterQueries, ok, err := userFilterQueries(s.filter)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return append(queries, filterQueries...), true, nil
+}
+
+// groupQueries returns the queries for the groups in scope, ok is false if no group can match the filter.
+func (s *directorySearch) groupQueries() (_ []query.SearchQuery, ok bool, err error) {
+	orgQuery, err := query.NewGroupOrganizationIdSearchQuery(s.session.orgID)
+	if err != nil {
+		return nil, false, err
+	}
+	// the filter is not pushed down for a group as base, so its existence can be checked
+	if s.base.name != "" {
+		nameQuery, err := query.NewGroupNameSearchQuery(s.base.name, query.TextEqualsIgnoreCase)
+		if err != nil {
+			return nil, false, err
+		}
+		return []query.SearchQuery{orgQuery, nameQuery}, true, nil
+	}
+	filterQueries, ok, err := groupFilterQueries(s.filter)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	return append([]query.SearchQuery{orgQuery}, filterQueries...), true, nil
+}
+
+func (s *directorySearch) userPage(queries []query.SearchQuery) pageLoader {
+	return func(ctx context.Context, offset, limit uint64) ([]*goldap.Entry, error) {
+		users, err := s.queries.SearchUsers(ctx, &query.UserSearchQueries{
+			SearchRequest: query.SearchRequest{Offset: offset, Limit: limit, SortingColumn: query.UserIDCol, Asc: true},
+			Queries:       queries,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		memberOf, err := s.memberOf(ctx, users.Users)
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]*goldap.Entry, len(users.Users))
+		for i, user := range users.Users {
+			entries[i] = userEntry(s.session.instanceDomain, user, memberOf[user.ID])
+		}
+		return entries, nil
+	}
+}
+
+func (s *directorySearch) groupPage(queries []query.SearchQuery) pageLoader {
+	return func(ctx context.Context, offset, limit uint64) ([]*goldap.Entry, error) {
+		groups, err := s.queries.SearchGroups(ctx, &query.GroupSearchQuery{
+			SearchRequest: query.SearchRequest{Offset: offset, Limit: limit, SortingColumn: query.GroupColumnID, Asc: true},
+			Queries:       queries,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		members, err := s.members(ctx, groups.Groups)
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]*goldap.Entry, len(groups.Groups))
+		for i, group := range groups.Groups {
+			entries[i] = groupEntry(s.session.instanceDomain, group, members[group.ID])
+		}
+		return entries, nil
+	}
+}
+
+// memberOf returns the DNs of the groups of the users, they are only listed to users allowed to read all users.
+func (s *directorySearch) memberOf(ctx context.Context, users []*query.User) (map[string][]string, error) {
+	if !s.readAll || len(users) == 0 {
+		return nil, nil
+	}
+	userIDs := make([]string, len(users))
+	for i, user := range users {
+		userIDs[i] = user.ID
+	}
+	membersQuery, err := query.NewGroupUsersUserIDsSearchQuery(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.queries.SearchGroupUsers(ctx, &query.GroupUsersSearchQuery{Queries: []query.SearchQuery{membersQuery}}, nil)
+	if err != nil || len(members.GroupUsers) == 0 {
+		return nil, err
+	}
+	groupIDs := make([]string, 0, len(members.GroupUsers))
+	for _, member := range members.GroupUsers {
+		if !slices.Contains(groupIDs, member.GroupID) {
+			groupIDs = append(groupIDs, member.GroupID)
+		}
+	}
+	orgQuery, err := query.NewGroupOrganizationIdSearchQuery(s.session.orgID)
+	if err != nil {
+		return nil, err
+	}
+	idsQuery, err := query.NewGroupIDsSearchQuery(groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := s.queries.SearchGroups(ctx, &query.GroupSearchQuery{Queries: []query.SearchQuery{orgQuery, idsQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return memberOfDNs(s.session.instanceDomain, s.session.orgID, groups.Groups, members.GroupUsers), nil
+}
+
+// members returns the DNs of the active users of the groups.
+func (s *directorySearch) members(ctx context.Context, groups []*query.Group) (map[string][]string, error) {
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	groupIDs := make([]string, len(groups))
+	for i, group := range groups {
+		groupIDs[i] = group.ID
+	}
+	membersQuery, err := query.NewGroupUsersGroupIDsSearchQuery(groupIDs)
+	if err != nil {
+		return nil, err
+	}
+	members, err := s.queries.SearchGroupUsers(ctx, &query.GroupUsersSearchQuery{Queries: []query.SearchQuery{membersQuery}}, nil)
+	if err != nil || len(members.GroupUsers) == 0 {
+		return nil, err
+	}
+	userIDs := make([]string, 0, len(members.GroupUsers))
+	for _, member := range members.GroupUsers {
+		if !slices.Contains(userIDs, member.UserID) {
+			userIDs = append(userIDs, member.UserID)
+		}
+	}
+	ownerQuery, err := query.NewUserResourceOwnerSearchQuery(s.session.orgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	activeQuery, err := activeUsersQuery()
+	if err != nil {
+		return nil, err
+	}
+	idsQuery, err := query.NewUserInUserIdsSearchQuery(userIDs)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.queries.SearchUsers(ctx, &query.UserSearchQueries{Queries: []query.SearchQuery{ownerQuery, activeQuery, idsQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return memberDNs(s.session.instanceDomain, s.session.orgID, users.Users, members.GroupUsers), nil
+}
+
+// activeUsersQuery restricts the users to the ones listed in the directory.
+func activeUsersQuery() (query.SearchQuery, error) {
+	activeQuery, err := query.NewUserStateSearchQuery(domain.UserStateActive)
+	if err != nil {
+		return nil, err
+	}
+	initialQuery, err := query.NewUserStateSearchQuery(domain.UserStateInitial)
+	if err != nil {
+		return nil, err
+	}
+	return query.NewUserOrSearchQuery([]query.SearchQuery{activeQuery, initialQuery})
+}
+
+// userFilterQueries translates the assertions of the filter on user attributes to queries.
+// ok is false if no user can match the filter.
+func userFilterQueries(filter *ber.Packet) (queries []query.SearchQuery, ok bool, err error) {
+	for _, a := range requiredAssertions(filter) {
+		var q query.SearchQuery
+		switch a.attribute {
+		case "objectclass":
+			if a.prefix {
+				continue
+			}
+			if !containsFold(userObjectClasses, a.value) {
+				return nil, false, nil
+			}
+			if containsFold(humanObjectClasses, a.value) {
+				q, err = query.NewUserTypeSearchQuery(domain.UserTypeHuman)
+			} else if strings.EqualFold(a.value, "account") {
+				q, err = query.NewUserTypeSearchQuery(domain.UserTypeMachine)
+			}
+		case attributeUserID:
+			q, err = query.NewUserUsernameSearchQuery(a.value, a.comparison())
+		case "mail":
+			q, err = query.NewUserEmailSearchQuery(a.value, a.comparison())
+		case "entryuuid":
+			if !a.prefix {
+				q, err = query.NewUserInUserIdsSearchQuery([]string{a.value})
+			}
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if q != nil {
+			queries = append(queries, q)
+		}
+	}
+	return queries, true, nil
+}
+
+// groupFilterQueries translates the assertions of the filter on group attributes to queries.
+// ok is false if no group can match the filter.
+func groupFilterQueries(filter *ber.Packet) (queries []query.SearchQuery, ok bool, err error) {
+	for _, a := range requiredAssertions(filter) {
+		var q query.SearchQuery
+		switch a.attribute {
+		case "objectclass":
+			if !a.prefix && !containsFold(groupObjectClasses, a.value) {
+				return nil, false, nil
+			}
+		case attributeCommonName:
+			q, err = query.NewGroupNameSearchQuery(a.value, a.comparison())
+		case "entryuuid":
+			if !a.prefix {
+				q, err = query.NewGroupIDsSearchQuery([]string{a.value})
+			}
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		if q != nil {
+			queries = append(queries, q)
+		}
+	}
+	return queries, true, nil
+}
+
+func (a assertion) comparison() query.TextComparison {
+	if a.prefix {
+		return query.TextStartsWithIgnoreCase
+	}
+	return query.TextEqualsIgnoreCase
+}
@@ -0,0 +1,79 @@
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Server is a read-only LDAPv3 server (RFC 4511) for applications which only support LDAP authentication.
+// Clients bind with the DN and password of a user (or the secret of a service account, if enabled),
+// afterwards the users and groups of the organization of the bound user can be searched.
+// Users without the permission to read the users of their organization only find their own entry.
+type Server struct {
+	config          Config
+	commands        *command.Commands
+	queries         *query.Queries
+	checkPermission domain.PermissionCheck
+	tlsConfig       *tls.Config
+}
+
+func NewServer(config Config, commands *command.Commands, queries *query.Queries, checkPermission domain.PermissionCheck) (*Server, error) {
+	tlsConfig, err := config.TLS.Config()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "LDAP-Hm8sd", "invalid tls config")
+	}
+	return &Server{
+		config:          config,
+		commands:        commands,
+		queries:         queries,
+		checkPermission: checkPermission,
+		tlsConfig:       tlsConfig,
+	}, nil
+}
+
+// Start listens on the configured port until the context is done.
+func Start(ctx context.Context, config Config, commands *command.Commands, queries *query.Queries, checkPermission domain.PermissionCheck) error {
+	if !config.Enabled {
+		return nil
+	}
+	server, err := NewServer(config, commands, queries, checkPermission)
+	if err != nil {
+		return err
+	}
+	lis, err := new(net.ListenConfig).Listen(ctx, "tcp", fmt.Sprintf(":%d", config.Port))
+	if err != nil {
+		return fmt.Errorf("ldap listener on %d failed: %w", config.Port, err)
+	}
+	logging.WithFields("address", lis.Addr().String()).Info("ldap server is listening")
+	go server.Serve(ctx, lis)
+	return nil
+}
+
+// Serve accepts connections on the listener until the context is done.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) {
+	go func() {
+		<-ctx.Done()
+		logging.OnError(lis.Close()).Debug("unable to close ldap listener")
+	}()
+	for {
+		netConn, err := lis.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			logging.WithError(err).Warn("ldap accept failed")
+			continue
+		}
+		go newConn(s, netConn).serve(ctx)
+	}
+}
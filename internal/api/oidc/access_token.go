@@ -98,6 +98,10 @@ func accessTokenV1(tokenID, subject string, token *model.TokenView) *accessToken
 }
 
 func accessTokenV2(tokenID, subject string, token *query.OIDCSessionAccessTokenReadModel) *accessToken {
+	audience := token.Audience
+	if len(token.AccessTokenAudience) > 0 {
+		audience = token.AccessTokenAudience
+	}
 	return &accessToken{
 		tokenID:           tokenID,
 		userID:            token.UserID,
@@ -105,7 +109,7 @@ func accessTokenV2(tokenID, subject string, token *query.OIDCSessionAccessTokenR
 		subject:           subject,
 		preferredLanguage: token.PreferredLanguage,
 		clientID:          token.ClientID,
-		audience:          token.Audience,
+		audience:          audience,
 		scope:             token.Scope,
 		authMethods:       token.AuthMethods,
 		authTime:          token.AuthTime,
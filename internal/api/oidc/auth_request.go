@@ -101,6 +101,15 @@ func (o *OPStorage) createAuthRequestScopeAndAudience(ctx context.Context, clien
 	if err != nil {
 		return nil, nil, "", err
 	}
+	if resources := requestedResources(ctx); len(resources) > 0 {
+		// the audience is restricted to the requested resources only,
+		// the client is added to the audience of the ID token by the oidc library
+		// and is always allowed to refresh and revoke the tokens of its sessions.
+		audience, err = resourceAudience(ctx, o.query.ActiveAPIResourcesByURI, resources, audience)
+		if err != nil {
+			return nil, nil, "", err
+		}
+	}
 	return scope, audience, orgID, nil
 }
 
@@ -318,6 +327,11 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 		if err != nil {
 			return "", err
 		}
+		sessionIDs := make([]string, len(sessions))
+		for i, session := range sessions {
+			sessionIDs[i] = session.ID
+		}
+		o.frontChannelLogout(ctx, sessionIDs...)
 		if len(sessions) == 1 {
 			if path := o.federatedLogout(ctx, sessions[0].ID, endSessionRequest.RedirectURI); path != "" {
 				return path, nil
@@ -334,6 +348,7 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 		if err != nil {
 			return "", err
 		}
+		o.frontChannelLogout(ctx, endSessionRequest.IDTokenHintClaims.SessionID)
 		if path := o.federatedLogout(ctx, endSessionRequest.IDTokenHintClaims.SessionID, endSessionRequest.RedirectURI); path != "" {
 			return path, nil
 		}
@@ -350,12 +365,14 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 		); err != nil {
 			return "", err
 		}
+		o.frontChannelLogout(ctx, endSessionRequest.IDTokenHintClaims.SessionID)
 		return v2PostLogoutRedirectURI(endSessionRequest.RedirectURI), nil
 	}
 	_, err = o.command.TerminateSessionWithoutTokenCheck(ctx, endSessionRequest.IDTokenHintClaims.SessionID)
 	if err != nil {
 		return "", err
 	}
+	o.frontChannelLogout(ctx, endSessionRequest.IDTokenHintClaims.SessionID)
 	return v2PostLogoutRedirectURI(endSessionRequest.RedirectURI), nil
 }
 
@@ -653,7 +670,7 @@ func (s *Server) CreateTokenCallbackURL(ctx context.Context, req op.AuthRequest)
 		req.GetID(),
 		implicitFlowComplianceChecker(),
 		slices.Contains(client.GrantTypes(), oidc.GrantTypeRefreshToken),
-		client.client.BackChannelLogoutURI,
+		client.oidcSessionOptions(nil),
 	)
 	if err != nil {
 		return "", err
@@ -669,6 +686,13 @@ func (s *Server) CreateTokenCallbackURL(ctx context.Context, req op.AuthRequest)
 	return callback, err
 }
 
+// implicitAuthResponse extends the token response of the implicit flow
+// with the session_state used by the check_session iframe.
+type implicitAuthResponse struct {
+	*oidc.AccessTokenResponse
+	SessionState string `schema:"session_state,omitempty"`
+}
+
 func implicitFlowComplianceChecker() command.AuthRequestComplianceChecker {
 	return func(_ context.Context, authReq *command.AuthRequestWriteModel) error {
 		if err := authReq.CheckAuthenticated(); err != nil {
@@ -696,6 +720,10 @@ func (s *Server) authorizeCallbackHandler(w http.ResponseWriter, r *http.Request
 		if !authReq.Done() {
 			return authReq, oidc.ErrInteractionRequired().WithDescription("Unfortunately, the user may be not logged in and/or additional interaction is required.")
 		}
+		authReq.sessionState, err = s.browserState.sessionState(w, r, authReq.GetClientID(), authReq.GetRedirectURI())
+		if err != nil {
+			return authReq, err
+		}
 		return authReq, s.authResponse(authReq, authorizer, w, r)
 	}(r.Context())
 	if err != nil {
@@ -740,7 +768,6 @@ func (s *Server) authResponseToken(authReq *AuthRequest, authorizer op.Authorize
 		authReq.UserID,
 		authReq.UserOrgID,
 		client.client.ClientID,
-		client.client.BackChannelLogoutURI,
 		scope,
 		authReq.Audience,
 		authReq.AuthMethods(),
@@ -753,16 +780,21 @@ func (s *Server) authResponseToken(authReq *AuthRequest, authorizer op.Authorize
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
 		authReq.SessionID,
 		authReq.oidc().ResponseType,
+		client.oidcSessionOptions(nil),
 	)
 	if err != nil {
 		op.AuthRequestError(w, r, authReq, err, authorizer)
 		return err
 	}
-	resp, err := s.accessTokenResponseFromSession(ctx, client, session, authReq.GetState(), client.client.ProjectID, client.client.ProjectRoleAssertion, client.client.AccessTokenRoleAssertion, client.client.IDTokenRoleAssertion, client.client.IDTokenUserinfoAssertion)
+	tokens, err := s.accessTokenResponseFromSession(ctx, client, session, authReq.GetState(), client.client.ProjectID, client.client.ProjectRoleAssertion, client.client.AccessTokenRoleAssertion, client.client.IDTokenRoleAssertion, client.client.IDTokenUserinfoAssertion)
 	if err != nil {
 		op.AuthRequestError(w, r, authReq, err, authorizer)
 		return err
 	}
+	resp := &implicitAuthResponse{
+		AccessTokenResponse: tokens,
+		SessionState:        authReq.GetSessionState(),
+	}
 
 	if authReq.GetResponseMode() == oidc.ResponseModeFormPost {
 		if err = op.AuthResponseFormPost(w, authReq.GetRedirectURI(), resp, authorizer.Encoder()); err != nil {
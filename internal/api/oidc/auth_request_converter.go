@@ -20,6 +20,7 @@ import (
 
 type AuthRequest struct {
 	*domain.AuthRequest
+	sessionState string
 }
 
 func (a *AuthRequest) GetID() string {
@@ -87,6 +88,12 @@ func (a *AuthRequest) GetState() string {
 	return a.TransferState
 }
 
+// GetSessionState implements [op.AuthRequestSessionState].
+// It is only set for requests returned to the user agent by the authorize callback.
+func (a *AuthRequest) GetSessionState() string {
+	return a.sessionState
+}
+
 func (a *AuthRequest) GetSubject() string {
 	return a.UserID
 }
@@ -99,7 +106,7 @@ func AuthRequestFromBusiness(authReq *domain.AuthRequest) (_ *AuthRequest, err e
 	if _, ok := authReq.Request.(*domain.AuthRequestOIDC); !ok {
 		return nil, zerrors.ThrowInvalidArgument(nil, "OIDC-Haz7A", "auth request is not of type oidc")
 	}
-	return &AuthRequest{authReq}, nil
+	return &AuthRequest{AuthRequest: authReq}, nil
 }
 
 func CreateAuthRequestToBusiness(ctx context.Context, authReq *oidc.AuthRequest, userAgentID, userID string, audience []string) *domain.AuthRequest {
@@ -0,0 +1,174 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+)
+
+// checkSessionPath is the path of the check_session iframe as defined in
+// https://openid.net/specs/openid-connect-session-1_0.html#OPiframe
+const checkSessionPath = "/oidc/v1/check_session"
+
+// browserStateCookieName is the cookie holding the browser state (opbs) of the OpenID Provider,
+// which is read by the check_session iframe to detect changes of the login status.
+const browserStateCookieName = "zitadel.browser_state"
+
+const (
+	browserStateLength     = 32
+	sessionStateSaltLength = 16
+)
+
+var checkSessionTemplate = template.Must(template.New("checkSession").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Check Session</title>
+</head>
+<body>
+<script>
+(function() {
+	function browserState() {
+		var cookies = document.cookie.split(";");
+		for (var i = 0; i < cookies.length; i++) {
+			var cookie = cookies[i].trim();
+			if (cookie.indexOf({{.CookieName}} + "=") === 0) {
+				return decodeURIComponent(cookie.substring({{.CookieName}}.length + 1));
+			}
+		}
+		return "";
+	}
+	function sessionState(clientID, origin, opbs, salt) {
+		var data = new TextEncoder().encode(clientID + " " + origin + " " + opbs + " " + salt);
+		return window.crypto.subtle.digest("SHA-256", data).then(function(hash) {
+			var binary = String.fromCharCode.apply(null, new Uint8Array(hash));
+			return btoa(binary).replace(/\+/g, "-").replace(/\//g, "_").replace(/=+$/, "") + "." + salt;
+		});
+	}
+	window.addEventListener("message", function(e) {
+		if (typeof e.data !== "string") {
+			return;
+		}
+		var parts = e.data.split(" ");
+		var salt = parts.length === 2 ? parts[1].split(".")[1] : "";
+		if (!salt) {
+			e.source.postMessage("error", e.origin);
+			return;
+		}
+		var opbs = browserState();
+		if (!opbs) {
+			e.source.postMessage("changed", e.origin);
+			return;
+		}
+		sessionState(parts[0], e.origin, opbs, salt).then(function(state) {
+			e.source.postMessage(state === parts[1] ? "unchanged" : "changed", e.origin);
+		}, function() {
+			e.source.postMessage("error", e.origin);
+		});
+	}, false);
+})();
+</script>
+</body>
+</html>
+`))
+
+// checkSessionIframe returns the check_session_iframe advertised in the discovery.
+// The session_state can only be computed if the authorization response is sent through
+// the user agent by ZITADEL itself, which is not the case with the login v2,
+// so the iframe is not advertised if the instance requires it.
+func checkSessionIframe(ctx context.Context, issuer string) string {
+	if authz.GetFeatures(ctx).LoginV2.Required {
+		return ""
+	}
+	return issuer + checkSessionPath
+}
+
+// checkSessionHandler serves the check_session iframe, which is embedded by the applications
+// to poll the login status of the user agent using the session_state of the authentication response.
+func (s *Server) checkSessionHandler(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := checkSessionTemplate.Execute(w, &struct {
+		CookieName string
+	}{
+		CookieName: browserStateCookieName,
+	})
+	logging.OnError(err).Error("unable to render check session iframe")
+}
+
+// browserState manages the browser state (opbs) cookie,
+// which is set on login and reset on logout.
+type browserState struct {
+	cookieHandler *http_utils.CookieHandler
+}
+
+func newBrowserState(externalSecure bool) *browserState {
+	opts := []http_utils.CookieHandlerOpt{
+		// the cookie needs to be readable by the check_session iframe,
+		// which is embedded by the applications on other sites.
+		http_utils.WithNonHttpOnly(),
+		http_utils.WithSameSite(http.SameSiteNoneMode),
+	}
+	if !externalSecure {
+		opts = append(opts, http_utils.WithUnsecure())
+	}
+	return &browserState{cookieHandler: http_utils.NewCookieHandler(opts...)}
+}
+
+// sessionState returns the session_state for the client as defined in
+// https://openid.net/specs/openid-connect-session-1_0.html#CreatingUpdatingSessions.
+// The browser state is created and set as cookie, if the user agent does not have one yet.
+func (b *browserState) sessionState(w http.ResponseWriter, r *http.Request, clientID, redirectURI string) (string, error) {
+	opbs, err := b.cookieHandler.GetCookieValue(r, browserStateCookieName)
+	if err != nil || opbs == "" {
+		opbs, err = randomURLString(browserStateLength)
+		if err != nil {
+			return "", err
+		}
+		b.cookieHandler.SetCookie(w, browserStateCookieName, r.Host, opbs)
+	}
+	salt, err := randomURLString(sessionStateSaltLength)
+	if err != nil {
+		return "", err
+	}
+	return computeSessionState(clientID, redirectURIOrigin(redirectURI), opbs, salt), nil
+}
+
+// reset removes the browser state, so all session states become invalid.
+func (b *browserState) reset(w http.ResponseWriter, r *http.Request) {
+	if b == nil {
+		return
+	}
+	// the cookie is set for the requested host and can therefore not be removed by [http_utils.CookieHandler.DeleteCookie].
+	b.cookieHandler.SetCookie(w, browserStateCookieName, r.Host, "")
+}
+
+func computeSessionState(clientID, origin, opbs, salt string) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{clientID, origin, opbs, salt}, " ")))
+	return base64.RawURLEncoding.EncodeToString(hash[:]) + "." + salt
+}
+
+func redirectURIOrigin(redirectURI string) string {
+	uri, err := url.Parse(redirectURI)
+	if err != nil {
+		return ""
+	}
+	return uri.Scheme + "://" + uri.Host
+}
+
+func randomURLString(length int) (string, error) {
+	b := make([]byte, length)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,96 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/feature"
+)
+
+func Test_computeSessionState(t *testing.T) {
+	got := computeSessionState("client1", "https://app.com", "opbs", "salt")
+	assert.Equal(t, "qosdXYSx6_HzkCrbGLrmmCE-2xYIffEIa5fLJQbnbzw.salt", got)
+	assert.NotEqual(t, got, computeSessionState("client1", "https://app.com", "other", "salt"))
+	assert.NotEqual(t, got, computeSessionState("client2", "https://app.com", "opbs", "salt"))
+}
+
+func Test_redirectURIOrigin(t *testing.T) {
+	tests := []struct {
+		name        string
+		redirectURI string
+		want        string
+	}{
+		{
+			name:        "https",
+			redirectURI: "https://app.com/auth/callback?foo=bar",
+			want:        "https://app.com",
+		},
+		{
+			name:        "port",
+			redirectURI: "http://localhost:8080/callback",
+			want:        "http://localhost:8080",
+		},
+		{
+			name:        "invalid",
+			redirectURI: "://app.com",
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, redirectURIOrigin(tt.redirectURI))
+		})
+	}
+}
+
+func Test_browserState_sessionState(t *testing.T) {
+	b := newBrowserState(true)
+
+	t.Run("new browser state", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/oauth/v2/authorize/callback", nil)
+		rec := httptest.NewRecorder()
+		got, err := b.sessionState(rec, req, "client1", "https://app.com/callback")
+		require.NoError(t, err)
+
+		cookies := rec.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, browserStateCookieName, cookies[0].Name)
+		assert.False(t, cookies[0].HttpOnly)
+		assert.Equal(t, http.SameSiteNoneMode, cookies[0].SameSite)
+		_, salt, ok := strings.Cut(got, ".")
+		require.True(t, ok)
+		assert.Equal(t, computeSessionState("client1", "https://app.com", cookies[0].Value, salt), got)
+	})
+	t.Run("existing browser state", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/oauth/v2/authorize/callback", nil)
+		req.AddCookie(&http.Cookie{Name: browserStateCookieName, Value: "opbs"})
+		rec := httptest.NewRecorder()
+		got, err := b.sessionState(rec, req, "client1", "https://app.com/callback")
+		require.NoError(t, err)
+
+		assert.Empty(t, rec.Result().Cookies())
+		_, salt, ok := strings.Cut(got, ".")
+		require.True(t, ok)
+		assert.Equal(t, computeSessionState("client1", "https://app.com", "opbs", salt), got)
+	})
+}
+
+func Test_checkSessionIframe(t *testing.T) {
+	t.Run("login v1", func(t *testing.T) {
+		got := checkSessionIframe(context.Background(), "https://issuer.com")
+		assert.Equal(t, "https://issuer.com/oidc/v1/check_session", got)
+	})
+	t.Run("login v2 required", func(t *testing.T) {
+		ctx := authz.WithFeatures(context.Background(), feature.Features{
+			LoginV2: feature.LoginV2{Required: true},
+		})
+		assert.Empty(t, checkSessionIframe(ctx, "https://issuer.com"))
+	})
+}
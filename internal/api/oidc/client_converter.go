@@ -128,6 +128,25 @@ func (c *Client) ClockSkew() time.Duration {
 	return c.client.ClockSkew
 }
 
+// RefreshTokenSettings returns the client specific refresh token rotation and lifetimes.
+func (c *Client) RefreshTokenSettings() *command.RefreshTokenSettings {
+	return &command.RefreshTokenSettings{
+		Rotation:     c.client.RefreshTokenRotation,
+		Lifetime:     c.client.RefreshTokenLifetime,
+		IdleLifetime: c.client.RefreshTokenIdleLifetime,
+	}
+}
+
+// oidcSessionOptions returns the client specific logout and refresh token options of a new OIDC session.
+func (c *Client) oidcSessionOptions(accessTokenAudience command.AccessTokenAudienceResolver) *command.OIDCSessionOptions {
+	return &command.OIDCSessionOptions{
+		BackChannelLogoutURI:  c.client.BackChannelLogoutURI,
+		FrontChannelLogoutURI: c.client.FrontChannelLogoutURI,
+		RefreshTokenSettings:  c.RefreshTokenSettings(),
+		AccessTokenAudience:   accessTokenAudience,
+	}
+}
+
 func (c *Client) IDTokenUserinfoClaimsAssertion() bool {
 	return c.client.IDTokenUserinfoAssertion
 }
@@ -0,0 +1,174 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/hashicorp/golang-lru/v2/expirable"
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/denylist"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// jwtContentType is the media type of encrypted userinfo responses.
+	jwtContentType = "application/jwt"
+
+	jwksURITimeout = 10 * time.Second
+	// jwksURICacheTTL is how long a key set fetched from the JWKS URI of a client is used,
+	// before it's fetched again.
+	jwksURICacheTTL  = 5 * time.Minute
+	jwksURICacheSize = 1000
+)
+
+func supportedEncryptionAlgs() []string {
+	algs := make([]string, len(crypto.JWEKeyAlgorithms))
+	for i, alg := range crypto.JWEKeyAlgorithms {
+		algs[i] = string(alg)
+	}
+	return algs
+}
+
+func supportedContentEncryptions() []string {
+	encs := make([]string, len(crypto.JWEContentEncryptions))
+	for i, enc := range crypto.JWEContentEncryptions {
+		encs[i] = string(enc)
+	}
+	return encs
+}
+
+// responseEncryption is the encryption of a response (ID token or userinfo) configured for a client.
+type responseEncryption struct {
+	algorithm  string
+	encryption string
+	jwks       string
+	jwksURI    string
+	keySets    *clientKeySets
+}
+
+func (e *responseEncryption) enabled() bool {
+	return e != nil && e.algorithm != ""
+}
+
+// idTokenEncryption returns the ID token encryption configured for the client.
+// Only OIDC applications are able to configure it.
+func idTokenEncryption(client op.Client, keySets *clientKeySets) *responseEncryption {
+	c, ok := client.(*Client)
+	if !ok {
+		return nil
+	}
+	return &responseEncryption{
+		algorithm:  c.client.IDTokenEncryptedResponseAlg,
+		encryption: c.client.IDTokenEncryptedResponseEnc,
+		jwks:       c.client.JWKS,
+		jwksURI:    c.client.JWKSURI,
+		keySets:    keySets,
+	}
+}
+
+// userinfoEncryption returns the userinfo encryption configured for the client.
+func userinfoEncryption(client *query.OIDCUserinfoClient, keySets *clientKeySets) *responseEncryption {
+	if client == nil {
+		return nil
+	}
+	return &responseEncryption{
+		algorithm:  client.UserinfoEncryptedResponseAlg,
+		encryption: client.UserinfoEncryptedResponseEnc,
+		jwks:       client.JWKS,
+		jwksURI:    client.JWKSURI,
+		keySets:    keySets,
+	}
+}
+
+// encrypt encrypts the payload for the client with a key of its JWKS.
+// If nested is set, the payload must be a signed JWT.
+func (e *responseEncryption) encrypt(ctx context.Context, payload []byte, nested bool) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	keySet, err := e.keySets.keySet(ctx, e.jwks, e.jwksURI)
+	if err != nil {
+		return "", err
+	}
+	encrypter, err := crypto.NewKeySetEncrypter(keySet, jose.KeyAlgorithm(e.algorithm), domain.JWEContentEncryption(e.encryption), nested)
+	if err != nil {
+		return "", zerrors.ThrowPreconditionFailed(err, "OIDC-Ek3ns", "Errors.Internal")
+	}
+	encrypted, err := encrypter.Encrypt(payload)
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "OIDC-Ek4ot", "Errors.Internal")
+	}
+	token, err := encrypted.CompactSerialize()
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "OIDC-Ek5pu", "Errors.Internal")
+	}
+	return token, nil
+}
+
+// clientKeySets returns the key sets of clients used for the encryption of responses.
+// Key sets of JWKS URIs are cached, so they are not fetched on every response.
+// JWKS URIs resolving to denied addresses are not fetched.
+type clientKeySets struct {
+	httpClient *http.Client
+	denyList   []denylist.AddressChecker
+	cache      *expirable.LRU[string, *jose.JSONWebKeySet]
+}
+
+func newClientKeySets(denyList []denylist.AddressChecker) *clientKeySets {
+	k := &clientKeySets{
+		denyList: denyList,
+		cache:    expirable.NewLRU[string, *jose.JSONWebKeySet](jwksURICacheSize, nil, jwksURICacheTTL),
+	}
+	k.httpClient = &http.Client{
+		Timeout: jwksURITimeout,
+		// redirects must not lead to denied addresses either
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return denylist.IsHostBlocked(k.denyList, req.URL, nil)
+		},
+	}
+	return k
+}
+
+// keySet returns the inline JWKS of the client or fetches it from the JWKS URI.
+func (k *clientKeySets) keySet(ctx context.Context, jwks, jwksURI string) (*jose.JSONWebKeySet, error) {
+	if jwks != "" {
+		keySet, err := domain.ParseJWKS(jwks)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "OIDC-Ek6qv", "Errors.Internal")
+		}
+		return keySet, nil
+	}
+	if jwksURI == "" {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "OIDC-Ek7rw", "Errors.Internal")
+	}
+	if keySet, ok := k.cache.Get(jwksURI); ok {
+		return keySet, nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, jwksURITimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "OIDC-Ek8sx", "Errors.Internal")
+	}
+	if err = denylist.IsHostBlocked(k.denyList, req.URL, nil); err != nil {
+		return nil, zerrors.ThrowPreconditionFailed(err, "OIDC-Ek0uz", "Errors.Internal")
+	}
+	keySet := new(jose.JSONWebKeySet)
+	if err = httphelper.HttpRequest(k.httpClient, req, keySet); err != nil {
+		return nil, zerrors.ThrowUnavailable(err, "OIDC-Ek9ty", "Errors.Internal")
+	}
+	k.cache.Add(jwksURI, keySet)
+	return keySet, nil
+}
@@ -0,0 +1,153 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/denylist"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_responseEncryption_enabled(t *testing.T) {
+	var encryption *responseEncryption
+	assert.False(t, encryption.enabled())
+	assert.False(t, (&responseEncryption{jwksURI: "https://client.example.com/jwks"}).enabled())
+	assert.True(t, (&responseEncryption{algorithm: "RSA-OAEP-256"}).enabled())
+}
+
+func Test_responseEncryption_encrypt(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	jwks, err := json.Marshal(&jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{{Key: &key.PublicKey, KeyID: "enc", Use: "enc"}},
+	})
+	require.NoError(t, err)
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/jwks" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(jwks)
+	}))
+	defer jwksServer.Close()
+
+	tests := []struct {
+		name           string
+		encryption     *responseEncryption
+		nested         bool
+		wantEncryption jose.ContentEncryption
+		wantErr        func(error) bool
+	}{
+		{
+			name: "no key set",
+			encryption: &responseEncryption{
+				algorithm: "RSA-OAEP-256",
+			},
+			wantErr: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "invalid jwks",
+			encryption: &responseEncryption{
+				algorithm: "RSA-OAEP-256",
+				jwks:      "keys",
+			},
+			wantErr: zerrors.IsInternal,
+		},
+		{
+			name: "no key for algorithm",
+			encryption: &responseEncryption{
+				algorithm: "ECDH-ES",
+				jwks:      string(jwks),
+			},
+			wantErr: zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "invalid jwks uri",
+			encryption: &responseEncryption{
+				algorithm: "RSA-OAEP-256",
+				jwksURI:   jwksServer.URL + "/jwks\x00",
+			},
+			wantErr: zerrors.IsInternal,
+		},
+		{
+			name: "jwks uri not found",
+			encryption: &responseEncryption{
+				algorithm: "RSA-OAEP-256",
+				jwksURI:   jwksServer.URL + "/unknown",
+			},
+			wantErr: zerrors.IsUnavailable,
+		},
+		{
+			name: "inline jwks, default encryption",
+			encryption: &responseEncryption{
+				algorithm: "RSA-OAEP-256",
+				jwks:      string(jwks),
+			},
+			nested:         true,
+			wantEncryption: jose.A128CBC_HS256,
+		},
+		{
+			name: "jwks uri",
+			encryption: &responseEncryption{
+				algorithm:  "RSA-OAEP",
+				encryption: "A256GCM",
+				jwksURI:    jwksServer.URL + "/jwks",
+			},
+			wantEncryption: jose.A256GCM,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.encryption.keySets = newClientKeySets(nil)
+			token, err := tt.encryption.encrypt(context.Background(), []byte("payload"), tt.nested)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			encrypted, err := jose.ParseEncrypted(token, []jose.KeyAlgorithm{jose.KeyAlgorithm(tt.encryption.algorithm)}, []jose.ContentEncryption{tt.wantEncryption})
+			require.NoError(t, err)
+			assert.Equal(t, "enc", encrypted.Header.KeyID)
+			_, hasContentType := encrypted.Header.ExtraHeaders[jose.HeaderContentType]
+			assert.Equal(t, tt.nested, hasContentType)
+			payload, err := encrypted.Decrypt(key)
+			require.NoError(t, err)
+			assert.Equal(t, "payload", string(payload))
+		})
+	}
+}
+
+func Test_clientKeySets_keySet(t *testing.T) {
+	var fetched int
+	jwksServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched++
+		_, _ = w.Write([]byte(`{"keys":[]}`))
+	}))
+	defer jwksServer.Close()
+
+	t.Run("denied", func(t *testing.T) {
+		denyList, err := denylist.ParseDenyList([]string{"127.0.0.0/8", "::1"})
+		require.NoError(t, err)
+		_, err = newClientKeySets(denyList).keySet(context.Background(), "", jwksServer.URL)
+		assert.True(t, zerrors.IsPreconditionFailed(err), "unexpected error: %v", err)
+		assert.Equal(t, 0, fetched)
+	})
+	t.Run("cached", func(t *testing.T) {
+		keySets := newClientKeySets(nil)
+		for range 2 {
+			keySet, err := keySets.keySet(context.Background(), "", jwksServer.URL)
+			require.NoError(t, err)
+			assert.Empty(t, keySet.Keys)
+		}
+		assert.Equal(t, 1, fetched)
+	})
+}
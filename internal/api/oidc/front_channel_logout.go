@@ -0,0 +1,148 @@
+package oidc
+
+import (
+	"context"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	frontChannelLogoutIssuerParam    = "iss"
+	frontChannelLogoutSessionIDParam = "sid"
+)
+
+var frontChannelLogoutTemplate = template.Must(template.New("frontChannelLogout").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="5;url={{.RedirectURI}}">
+<title>Logout</title>
+</head>
+<body>
+{{range .LogoutURIs}}<iframe src="{{.}}" style="display:none" width="0" height="0"></iframe>
+{{end}}<script>
+(function() {
+	var pending = document.getElementsByTagName("iframe").length;
+	var redirect = function() { window.location.replace({{.RedirectURI}}); };
+	Array.prototype.forEach.call(document.getElementsByTagName("iframe"), function(frame) {
+		frame.addEventListener("load", function() { if (--pending <= 0) { redirect(); } });
+		frame.addEventListener("error", function() { if (--pending <= 0) { redirect(); } });
+	});
+	if (pending <= 0) { redirect(); }
+})();
+</script>
+</body>
+</html>
+`))
+
+type frontChannelLogoutWriterKey struct{}
+
+// frontChannelLogoutWriter renders an HTML page instead of the redirect of the end_session endpoint,
+// which loads the front channel logout URIs of the applications in hidden iframes
+// before redirecting the user agent to the original location.
+type frontChannelLogoutWriter struct {
+	http.ResponseWriter
+	request      *http.Request
+	browserState *browserState
+	terminated   bool
+	logoutURIs   []string
+	rendered     bool
+}
+
+func (w *frontChannelLogoutWriter) WriteHeader(statusCode int) {
+	if !w.terminated {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	w.browserState.reset(w.ResponseWriter, w.request)
+	location := w.Header().Get("Location")
+	if len(w.logoutURIs) == 0 || statusCode != http.StatusFound || location == "" {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	w.rendered = true
+	w.Header().Del("Location")
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.ResponseWriter.WriteHeader(http.StatusOK)
+	err := frontChannelLogoutTemplate.Execute(w.ResponseWriter, &struct {
+		RedirectURI string
+		LogoutURIs  []string
+	}{
+		RedirectURI: location,
+		LogoutURIs:  w.logoutURIs,
+	})
+	logging.OnError(err).Error("unable to render front channel logout page")
+}
+
+func (w *frontChannelLogoutWriter) Write(b []byte) (int, error) {
+	// the body of the redirect is replaced by the rendered page
+	if w.rendered {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// frontChannelLogoutHandler prepares the requests, so the end_session endpoint is able to
+// inform the applications through the user agent using [setFrontChannelLogouts].
+func frontChannelLogoutHandler(browserState *browserState) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writer := &frontChannelLogoutWriter{ResponseWriter: w, request: r, browserState: browserState}
+			next.ServeHTTP(writer, r.WithContext(context.WithValue(r.Context(), frontChannelLogoutWriterKey{}, writer)))
+		})
+	}
+}
+
+// setFrontChannelLogouts marks the session(s) of the user agent as terminated and sets the applications,
+// which need to be informed about it through their front channel logout URI.
+// It returns false if the request was not prepared by [frontChannelLogoutHandler].
+func setFrontChannelLogouts(ctx context.Context, issuer string, logouts []*query.FrontChannelLogout) bool {
+	writer, ok := ctx.Value(frontChannelLogoutWriterKey{}).(*frontChannelLogoutWriter)
+	if !ok {
+		return false
+	}
+	writer.terminated = true
+	writer.logoutURIs = make([]string, 0, len(logouts))
+	for _, logout := range logouts {
+		logoutURI, err := frontChannelLogoutURI(logout.FrontChannelLogoutURI, issuer, logout.SessionID)
+		if err != nil {
+			logging.WithFields("clientID", logout.ClientID).WithError(err).Warn("invalid front channel logout uri")
+			continue
+		}
+		writer.logoutURIs = append(writer.logoutURIs, logoutURI)
+	}
+	return true
+}
+
+// frontChannelLogoutURI adds the issuer and session id to the front channel logout URI of the application
+// as defined in https://openid.net/specs/openid-connect-frontchannel-1_0.html#RPLogout
+func frontChannelLogoutURI(logoutURI, issuer, sessionID string) (string, error) {
+	uri, err := url.Parse(logoutURI)
+	if err != nil {
+		return "", err
+	}
+	q := uri.Query()
+	q.Set(frontChannelLogoutIssuerParam, issuer)
+	q.Set(frontChannelLogoutSessionIDParam, sessionID)
+	uri.RawQuery = q.Encode()
+	return uri.String(), nil
+}
+
+// frontChannelLogout queries the applications with a front channel logout URI of the terminated sessions,
+// so they can be rendered on the end_session response.
+// Errors are only logged, since the sessions are already terminated.
+func (o *OPStorage) frontChannelLogout(ctx context.Context, sessionIDs ...string) {
+	logouts, err := o.query.FrontChannelLogouts(ctx, sessionIDs...)
+	if err != nil {
+		logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID()).
+			WithError(err).Error("error retrieving front channel logouts")
+	}
+	setFrontChannelLogouts(ctx, op.IssuerFromContext(ctx), logouts)
+}
@@ -0,0 +1,94 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_frontChannelLogoutHandler(t *testing.T) {
+	tests := []struct {
+		name         string
+		terminated   bool
+		logouts      []*query.FrontChannelLogout
+		wantCode     int
+		wantLocation string
+		wantIframes  []string
+		wantCookie   bool
+	}{
+		{
+			name:         "not terminated",
+			wantCode:     http.StatusFound,
+			wantLocation: "https://example.com/logged-out",
+		},
+		{
+			name:         "terminated, no front channel logouts",
+			terminated:   true,
+			wantCode:     http.StatusFound,
+			wantLocation: "https://example.com/logged-out",
+			wantCookie:   true,
+		},
+		{
+			name:       "terminated, front channel logouts",
+			terminated: true,
+			logouts: []*query.FrontChannelLogout{
+				{
+					SessionID:             "session1",
+					ClientID:              "client1",
+					FrontChannelLogoutURI: "https://client1.com/logout",
+				},
+				{
+					SessionID:             "session1",
+					ClientID:              "client2",
+					FrontChannelLogoutURI: "https://client2.com/logout?tenant=1",
+				},
+			},
+			wantCode: http.StatusOK,
+			wantIframes: []string{
+				`<iframe src="https://client1.com/logout?iss=https%3A%2F%2Fissuer.com&amp;sid=session1"`,
+				`<iframe src="https://client2.com/logout?iss=https%3A%2F%2Fissuer.com&amp;sid=session1&amp;tenant=1"`,
+			},
+			wantCookie: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := frontChannelLogoutHandler(newBrowserState(true))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.terminated {
+					assert.True(t, setFrontChannelLogouts(r.Context(), "https://issuer.com", tt.logouts))
+				}
+				http.Redirect(w, r, "https://example.com/logged-out", http.StatusFound)
+			}))
+			req := httptest.NewRequest(http.MethodGet, "/oidc/v1/end_session", nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantCode, rec.Code)
+			assert.Equal(t, tt.wantLocation, rec.Header().Get("Location"))
+			for _, iframe := range tt.wantIframes {
+				assert.Contains(t, rec.Body.String(), iframe)
+			}
+			if len(tt.wantIframes) > 0 {
+				assert.Contains(t, rec.Body.String(), `window.location.replace("https://example.com/logged-out")`)
+			}
+			cookies := rec.Result().Cookies()
+			if !tt.wantCookie {
+				assert.Empty(t, cookies)
+				return
+			}
+			require.Len(t, cookies, 1)
+			assert.Equal(t, browserStateCookieName, cookies[0].Name)
+			assert.Empty(t, cookies[0].Value)
+		})
+	}
+}
+
+func Test_setFrontChannelLogouts_withoutHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/oidc/v1/end_session", nil)
+	assert.False(t, setFrontChannelLogouts(req.Context(), "https://issuer.com", nil))
+}
@@ -19,8 +19,10 @@ import (
 	"google.golang.org/grpc/status"
 
 	oidc_api "github.com/zitadel/zitadel/internal/api/oidc"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/integration"
 	"github.com/zitadel/zitadel/pkg/grpc/admin"
+	"github.com/zitadel/zitadel/pkg/grpc/app"
 )
 
 func setImpersonationPolicy(t *testing.T, instance *integration.Instance, value bool) {
@@ -73,6 +75,15 @@ func accessTokenVerifier(ctx context.Context, server rs.ResourceServer, subject,
 	}
 }
 
+func accessTokenAudienceVerifier(ctx context.Context, server rs.ResourceServer, audience []string) func(t *testing.T, token string) {
+	return func(t *testing.T, token string) {
+		resp, err := rs.Introspect[*oidc.IntrospectionResponse](ctx, server, token)
+		require.NoError(t, err)
+		assert.True(t, resp.Active)
+		assert.ElementsMatch(t, audience, resp.Audience)
+	}
+}
+
 func idTokenVerifier(ctx context.Context, provider rp.RelyingParty, subject, actorSubject string) func(t *testing.T, token string) {
 	return func(t *testing.T, token string) {
 		verifier := provider.IDTokenVerifier()
@@ -111,7 +122,8 @@ func TestServer_TokenExchange(t *testing.T) {
 	ctx := instance.WithAuthorization(CTX, integration.UserTypeIAMOwner)
 	userResp := instance.CreateHumanUser(ctx)
 
-	client, keyData, err := instance.CreateOIDCTokenExchangeClient(ctx, t)
+	project := instance.CreateProject(ctx, t, "", integration.ProjectName(), false, false)
+	client, keyData, err := instance.CreateOIDCWebClientJWT(ctx, "", "", project.GetId(), app.OIDCGrantType_OIDC_GRANT_TYPE_TOKEN_EXCHANGE, app.OIDCGrantType_OIDC_GRANT_TYPE_AUTHORIZATION_CODE, app.OIDCGrantType_OIDC_GRANT_TYPE_REFRESH_TOKEN)
 	require.NoError(t, err)
 	signer, err := rp.SignerFromKeyFile(keyData)()
 	require.NoError(t, err)
@@ -157,7 +169,7 @@ func TestServer_TokenExchange(t *testing.T) {
 		wantErr bool
 	}{
 		{
-			name: "unsupported resource parameter",
+			name: "unknown resource parameter",
 			args: args{
 				SubjectToken:     noPermPAT,
 				SubjectTokenType: oidc.AccessTokenType,
@@ -165,6 +177,40 @@ func TestServer_TokenExchange(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "EXCHANGE: access token restricted to resource",
+			args: args{
+				SubjectToken:       noPermPAT,
+				SubjectTokenType:   oidc.AccessTokenType,
+				RequestedTokenType: oidc.AccessTokenType,
+				Resource:           []string{domain.ProjectIDScope + project.GetId()},
+			},
+			want: result{
+				issuedTokenType:   oidc.AccessTokenType,
+				tokenType:         oidc.BearerToken,
+				expiresIn:         43100,
+				scopes:            patScopes,
+				verifyAccessToken: accessTokenAudienceVerifier(ctx, resourceServer, []string{project.GetId()}),
+				verifyIDToken:     idTokenVerifier(ctx, relyingParty, serviceUserID, ""),
+			},
+		},
+		{
+			name: "EXCHANGE: JWT restricted to resource",
+			args: args{
+				SubjectToken:       noPermPAT,
+				SubjectTokenType:   oidc.AccessTokenType,
+				RequestedTokenType: oidc.JWTTokenType,
+				Resource:           []string{domain.ProjectIDScope + project.GetId()},
+			},
+			want: result{
+				issuedTokenType:   oidc.JWTTokenType,
+				tokenType:         oidc.BearerToken,
+				expiresIn:         43100,
+				scopes:            patScopes,
+				verifyAccessToken: accessTokenAudienceVerifier(ctx, resourceServer, []string{project.GetId()}),
+				verifyIDToken:     idTokenVerifier(ctx, relyingParty, serviceUserID, ""),
+			},
+		},
 		{
 			name: "invalid subject token",
 			args: args{
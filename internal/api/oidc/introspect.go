@@ -78,7 +78,7 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 			} else {
 				s.getLogger(ctx).InfoContext(ctx, "oidc introspection", "err", err)
 			}
-			resp, err = op.NewResponse(new(oidc.IntrospectionResponse)), nil
+			resp, err = s.introspectionResponse(ctx, r.Header, client, new(oidc.IntrospectionResponse))
 		}
 	}()
 
@@ -124,14 +124,16 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 		Actor:                           actorDomainToClaims(token.actor),
 	}
 	introspectionResp.SetUserInfo(userInfo)
-	return op.NewResponse(introspectionResp), nil
+	return s.introspectionResponse(ctx, r.Header, client, introspectionResp)
 }
 
 type introspectionClientResult struct {
-	clientID             string
-	projectID            string
-	projectRoleAssertion bool
-	err                  error
+	clientID                    string
+	projectID                   string
+	projectRoleAssertion        bool
+	introspectionSignedResponse bool
+	introspectionEncryptionKey  []byte
+	err                         error
 }
 
 var errNoClientSecret = errors.New("client has no configured secret")
@@ -139,36 +141,41 @@ var errNoClientSecret = errors.New("client has no configured secret")
 func (s *Server) introspectionClientAuth(ctx context.Context, cc *op.ClientCredentials, rc chan<- *introspectionClientResult) {
 	ctx, span := tracing.NewSpan(ctx)
 
-	clientID, projectID, projectRoleAssertion, err := func() (string, string, bool, error) {
+	client, err := func() (*query.IntrospectionClient, error) {
 		client, err := s.clientFromCredentials(ctx, cc)
 		if err != nil {
-			return "", "", false, err
+			return nil, err
 		}
 
 		if cc.ClientAssertion != "" {
 			verifier := op.NewJWTProfileVerifierKeySet(keySetMap(client.PublicKeys), op.IssuerFromContext(ctx), time.Hour, time.Second)
 			if _, err := op.VerifyJWTAssertion(ctx, cc.ClientAssertion, verifier); err != nil {
-				return "", "", false, oidc.ErrUnauthorizedClient().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
+				return nil, oidc.ErrUnauthorizedClient().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
 			}
-			return client.ClientID, client.ProjectID, client.ProjectRoleAssertion, nil
+			return client, nil
 
 		}
 		if client.HashedSecret != "" {
 			if err := s.introspectionClientSecretAuth(ctx, client, cc.ClientSecret); err != nil {
-				return "", "", false, oidc.ErrUnauthorizedClient().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
+				return nil, oidc.ErrUnauthorizedClient().WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
 			}
-			return client.ClientID, client.ProjectID, client.ProjectRoleAssertion, nil
+			return client, nil
 		}
-		return "", "", false, oidc.ErrUnauthorizedClient().WithParent(errNoClientSecret).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
+		return nil, oidc.ErrUnauthorizedClient().WithParent(errNoClientSecret).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError)
 	}()
 
 	span.EndWithError(err)
 
+	if err != nil {
+		rc <- &introspectionClientResult{err: err}
+		return
+	}
 	rc <- &introspectionClientResult{
-		clientID:             clientID,
-		projectID:            projectID,
-		projectRoleAssertion: projectRoleAssertion,
-		err:                  err,
+		clientID:                    client.ClientID,
+		projectID:                   client.ProjectID,
+		projectRoleAssertion:        client.ProjectRoleAssertion,
+		introspectionSignedResponse: client.IntrospectionSignedResponse,
+		introspectionEncryptionKey:  client.IntrospectionEncryptionKey,
 	}
 }
 
@@ -0,0 +1,114 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/oidc/sign"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// introspectionJWTContentType is the media type of JWT introspection responses.
+	// See [RFC 9701](https://www.rfc-editor.org/rfc/rfc9701)
+	introspectionJWTContentType = "application/token-introspection+jwt"
+	// introspectionJWTType is the typ header of the signed JWT introspection responses.
+	introspectionJWTType = "token-introspection+jwt"
+)
+
+// introspectionJWTClaims are the claims of a JWT introspection response.
+// The actual introspection response is nested in the token_introspection claim.
+type introspectionJWTClaims struct {
+	Issuer             string                      `json:"iss"`
+	Audience           string                      `json:"aud"`
+	IssuedAt           oidc.Time                   `json:"iat"`
+	TokenIntrospection *oidc.IntrospectionResponse `json:"token_introspection"`
+}
+
+func acceptsIntrospectionJWT(header http.Header) bool {
+	for _, accept := range header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, _, err := mime.ParseMediaType(mediaRange)
+			if err == nil && mediaType == introspectionJWTContentType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// introspectionResponse returns the JSON introspection response,
+// unless the client requested a JWT response and the API application allows it.
+// In that case the response is signed with the instance's web key
+// and encrypted if the API application has an encryption key.
+func (s *Server) introspectionResponse(ctx context.Context, header http.Header, client *introspectionClientResult, resp *oidc.IntrospectionResponse) (*op.Response, error) {
+	if client == nil || !client.introspectionSignedResponse || !acceptsIntrospectionJWT(header) {
+		return op.NewResponse(resp), nil
+	}
+	token, err := createIntrospectionJWT(ctx,
+		sign.GetTypedSignerOnce(s.query.GetActiveSigningWebKey, introspectionJWTType),
+		client.clientID,
+		client.introspectionEncryptionKey,
+		resp,
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !setJWTResponse(ctx, introspectionJWTContentType, token) {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-Ij4gu", "Errors.Internal")
+	}
+	return op.NewResponse(nil), nil
+}
+
+func createIntrospectionJWT(ctx context.Context, getSigner sign.SignerFunc, clientID string, encryptionKey []byte, resp *oidc.IntrospectionResponse, now time.Time) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	signer, _, err := getSigner(ctx)
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(&introspectionJWTClaims{
+		Issuer:             op.IssuerFromContext(ctx),
+		Audience:           clientID,
+		IssuedAt:           oidc.FromTime(now),
+		TokenIntrospection: resp,
+	})
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "OIDC-Ij8ao", "Errors.Internal")
+	}
+	signed, err := signer.Sign(payload)
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "OIDC-Ij9bp", "Errors.Internal")
+	}
+	token, err := signed.CompactSerialize()
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "OIDC-Ij0cq", "Errors.Internal")
+	}
+	if len(encryptionKey) == 0 {
+		return token, nil
+	}
+	encrypter, err := crypto.NewNestedJWTEncrypter(encryptionKey, "")
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "OIDC-Ij1dr", "Errors.Internal")
+	}
+	encrypted, err := encrypter.Encrypt([]byte(token))
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "OIDC-Ij2es", "Errors.Internal")
+	}
+	token, err = encrypted.CompactSerialize()
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "OIDC-Ij3ft", "Errors.Internal")
+	}
+	return token, nil
+}
@@ -0,0 +1,129 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/oidc/sign"
+)
+
+func Test_acceptsIntrospectionJWT(t *testing.T) {
+	tests := []struct {
+		name   string
+		accept []string
+		want   bool
+	}{
+		{
+			name: "no accept",
+			want: false,
+		},
+		{
+			name:   "json",
+			accept: []string{"application/json"},
+			want:   false,
+		},
+		{
+			name:   "jwt",
+			accept: []string{"application/token-introspection+jwt"},
+			want:   true,
+		},
+		{
+			name:   "jwt in list with parameters",
+			accept: []string{"application/json;q=0.5, application/token-introspection+jwt;q=1"},
+			want:   true,
+		},
+		{
+			name:   "jwt in second header",
+			accept: []string{"application/json", "application/token-introspection+jwt"},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := make(http.Header)
+			for _, accept := range tt.accept {
+				header.Add("Accept", accept)
+			}
+			assert.Equal(t, tt.want, acceptsIntrospectionJWT(header))
+		})
+	}
+}
+
+func Test_createIntrospectionJWT(t *testing.T) {
+	signingKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	getSigner := sign.GetTypedSignerOnce(func(context.Context) (*jose.JSONWebKey, error) {
+		return &jose.JSONWebKey{Key: signingKey, KeyID: "keyID", Algorithm: string(jose.RS256), Use: "sig"}, nil
+	}, introspectionJWTType)
+
+	encryptionKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	encryptionPublicKey, err := x509.MarshalPKIXPublicKey(&encryptionKey.PublicKey)
+	require.NoError(t, err)
+
+	ctx := op.ContextWithIssuer(context.Background(), "https://issuer.example.com")
+	now := time.Unix(1700000000, 0)
+	resp := &oidc.IntrospectionResponse{
+		Active:   true,
+		ClientID: "client",
+		Subject:  "user",
+	}
+	wantClaims := map[string]any{
+		"iss": "https://issuer.example.com",
+		"aud": "api",
+		"iat": float64(1700000000),
+		"token_introspection": map[string]any{
+			"active":    true,
+			"client_id": "client",
+			"sub":       "user",
+		},
+	}
+
+	t.Run("signed", func(t *testing.T) {
+		token, err := createIntrospectionJWT(ctx, getSigner, "api", nil, resp, now)
+		require.NoError(t, err)
+		assertIntrospectionJWT(t, token, &signingKey.PublicKey, wantClaims)
+	})
+	t.Run("signed and encrypted", func(t *testing.T) {
+		token, err := createIntrospectionJWT(ctx, getSigner, "api",
+			pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: encryptionPublicKey}),
+			resp, now,
+		)
+		require.NoError(t, err)
+		encrypted, err := jose.ParseEncrypted(token, []jose.KeyAlgorithm{jose.RSA_OAEP_256}, []jose.ContentEncryption{jose.A256GCM})
+		require.NoError(t, err)
+		assert.Equal(t, "JWT", encrypted.Header.ExtraHeaders[jose.HeaderContentType])
+		nested, err := encrypted.Decrypt(encryptionKey)
+		require.NoError(t, err)
+		assertIntrospectionJWT(t, string(nested), &signingKey.PublicKey, wantClaims)
+	})
+	t.Run("invalid encryption key", func(t *testing.T) {
+		_, err := createIntrospectionJWT(ctx, getSigner, "api", []byte("invalid"), resp, now)
+		require.Error(t, err)
+	})
+}
+
+func assertIntrospectionJWT(t *testing.T, token string, key *rsa.PublicKey, wantClaims map[string]any) {
+	t.Helper()
+	signed, err := jose.ParseSigned(token, []jose.SignatureAlgorithm{jose.RS256})
+	require.NoError(t, err)
+	assert.Equal(t, introspectionJWTType, signed.Signatures[0].Header.ExtraHeaders[jose.HeaderType])
+	payload, err := signed.Verify(key)
+	require.NoError(t, err)
+	var claims map[string]any
+	require.NoError(t, json.Unmarshal(payload, &claims))
+	assert.Equal(t, wantClaims, claims)
+}
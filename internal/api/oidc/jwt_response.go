@@ -0,0 +1,47 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+)
+
+type jwtResponseWriterKey struct{}
+
+// jwtResponseWriter writes a JWT response instead of the JSON body,
+// which the OP always writes for an [op.Response].
+type jwtResponseWriter struct {
+	http.ResponseWriter
+	contentType string
+	token       string
+}
+
+func (w *jwtResponseWriter) WriteHeader(statusCode int) {
+	if w.token == "" || statusCode != http.StatusOK {
+		w.ResponseWriter.WriteHeader(statusCode)
+		return
+	}
+	w.Header().Set("Content-Type", w.contentType)
+	w.ResponseWriter.WriteHeader(statusCode)
+	_, _ = w.ResponseWriter.Write([]byte(w.token))
+}
+
+// jwtResponseHandler prepares the requests, so the endpoints are able to respond with a JWT
+// using [setJWTResponse].
+func jwtResponseHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writer := &jwtResponseWriter{ResponseWriter: w}
+		next.ServeHTTP(writer, r.WithContext(context.WithValue(r.Context(), jwtResponseWriterKey{}, writer)))
+	})
+}
+
+// setJWTResponse sets the token to be written as response body instead of the JSON [op.Response].
+// It returns false if the request was not prepared by [jwtResponseHandler].
+func setJWTResponse(ctx context.Context, contentType, token string) bool {
+	writer, ok := ctx.Value(jwtResponseWriterKey{}).(*jwtResponseWriter)
+	if !ok {
+		return false
+	}
+	writer.contentType = contentType
+	writer.token = token
+	return true
+}
@@ -0,0 +1,62 @@
+package oidc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+)
+
+func Test_jwtResponseHandler(t *testing.T) {
+	tests := []struct {
+		name            string
+		contentType     string
+		token           string
+		wantContentType string
+		wantBody        string
+	}{
+		{
+			name:            "no token",
+			wantContentType: "application/json",
+			wantBody:        "",
+		},
+		{
+			name:            "introspection token",
+			contentType:     introspectionJWTContentType,
+			token:           "token",
+			wantContentType: introspectionJWTContentType,
+			wantBody:        "token",
+		},
+		{
+			name:            "jwt",
+			contentType:     jwtContentType,
+			token:           "token",
+			wantContentType: jwtContentType,
+			wantBody:        "token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := jwtResponseHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.token != "" {
+					assert.True(t, setJWTResponse(r.Context(), tt.contentType, tt.token))
+				}
+				httphelper.MarshalJSON(w, nil)
+			}))
+			req := httptest.NewRequest(http.MethodPost, "/oidc/v1/userinfo", nil)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantContentType, rec.Header().Get("Content-Type"))
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+		})
+	}
+}
+
+func Test_setJWTResponse_withoutHandler(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/oidc/v1/userinfo", nil)
+	assert.False(t, setJWTResponse(req.Context(), jwtContentType, "token"))
+}
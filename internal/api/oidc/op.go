@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
 
@@ -189,11 +190,16 @@ func NewServer(
 		targetEncryptionAlgorithm:  targetEncryptionAlgorithm,
 		opCrypto:                   alg,
 		assetAPIPrefix:             assets.AssetAPI(),
+		browserState:               newBrowserState(externalSecure),
+		clientKeySets:              newClientKeySets(command.ActionsV2DenyList),
 	}
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 	server.Handler = op.RegisterLegacyServer(server,
 		server.authorizeCallbackHandler,
 		op.WithFallbackLogger(fallbackLogger),
+		op.WithSetRouter(func(r chi.Router) {
+			r.HandleFunc(checkSessionPath, server.checkSessionHandler)
+		}),
 		op.WithHTTPMiddleware(
 			middleware.CallDurationHandler,
 			middleware.RequestDetailsHandler(),
@@ -205,6 +211,8 @@ func NewServer(
 			instanceHandler,
 			userAgentCookie,
 			http_utils.CopyHeadersToContext,
+			jwtResponseHandler,
+			frontChannelLogoutHandler(server.browserState),
 			accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(config.CustomEndpoints)),
 			middleware.ActivityHandler,
 		))
@@ -226,7 +234,7 @@ func publicAuthPathPrefixes(endpoints *EndpointConfig) []string {
 	authURL := op.DefaultEndpoints.Authorization.Relative()
 	keysURL := op.DefaultEndpoints.JwksURI.Relative()
 	if endpoints == nil {
-		return []string{oidc.DiscoveryEndpoint, authURL, keysURL}
+		return []string{oidc.DiscoveryEndpoint, authURL, keysURL, checkSessionPath}
 	}
 	if endpoints.Auth != nil && endpoints.Auth.Path != "" {
 		authURL = endpoints.Auth.Path
@@ -234,7 +242,7 @@ func publicAuthPathPrefixes(endpoints *EndpointConfig) []string {
 	if endpoints.Keys != nil && endpoints.Keys.Path != "" {
 		keysURL = endpoints.Keys.Path
 	}
-	return []string{oidc.DiscoveryEndpoint, authURL, keysURL}
+	return []string{oidc.DiscoveryEndpoint, authURL, keysURL, checkSessionPath}
 }
 
 func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []byte) (*op.Config, error) {
@@ -0,0 +1,154 @@
+package oidc
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// paramResource is the resource indicator parameter of authorization and token requests.
+// See [RFC 8707](https://www.rfc-editor.org/rfc/rfc8707)
+const paramResource = "resource"
+
+type apiResourcesByURI func(ctx context.Context, resourceURI string) ([]*query.APIResource, error)
+
+type requestedResourcesKey struct{}
+
+// withRequestedResources passes the resources of the authorization request
+// to the creation of the auth request, which is not able to receive them otherwise.
+func withRequestedResources(ctx context.Context, resources []string) context.Context {
+	if len(resources) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, requestedResourcesKey{}, resources)
+}
+
+func requestedResources(ctx context.Context) []string {
+	resources, _ := ctx.Value(requestedResourcesKey{}).([]string)
+	return resources
+}
+
+// accessTokenAudience returns a resolver, which restricts the audience of the access token
+// to the requested resources.
+// If no resource was requested, nil is returned and the access token will have the granted audience.
+func (s *Server) accessTokenAudience(resources []string) command.AccessTokenAudienceResolver {
+	if len(resources) == 0 {
+		return nil
+	}
+	return func(ctx context.Context, grantedAudience []string) ([]string, error) {
+		return resourceAudience(ctx, s.query.ActiveAPIResourcesByURI, resources, grantedAudience)
+	}
+}
+
+// restrictedAudience returns a resolver, which restricts the audience of the access token
+// to the already resolved audience of the requested resources.
+// If the audience is empty, nil is returned and the access token will have the granted audience.
+func restrictedAudience(audience []string) command.AccessTokenAudienceResolver {
+	if len(audience) == 0 {
+		return nil
+	}
+	return func(context.Context, []string) ([]string, error) {
+		return audience, nil
+	}
+}
+
+// serviceAccountAudience resolves the resources requested by a service account (client credentials and JWT profile grants).
+// Service accounts can request any project as audience by scope, so the resources are not restricted to a granted audience,
+// but unknown resources still result in an invalid_target error.
+// The resolved resources are added to the audience of the session and the access token is restricted to them.
+// If no resource was requested, the audience is returned unchanged together with a nil resolver.
+func (s *Server) serviceAccountAudience(ctx context.Context, resources, audience []string) ([]string, command.AccessTokenAudienceResolver, error) {
+	if len(resources) == 0 {
+		return audience, nil, nil
+	}
+	for _, resource := range resources {
+		if err := s.checkProjectResource(ctx, resource); err != nil {
+			return nil, nil, err
+		}
+	}
+	resolved, err := resolveResources(ctx, s.query.ActiveAPIResourcesByURI, resources, func(string) bool { return true })
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, aud := range resolved {
+		if !slices.Contains(audience, aud) {
+			audience = append(audience, aud)
+		}
+	}
+	return audience, restrictedAudience(resolved), nil
+}
+
+// checkProjectResource returns an invalid_target error if the resource is the urn of a project, which isn't active.
+func (s *Server) checkProjectResource(ctx context.Context, resource string) error {
+	projectID, ok := strings.CutPrefix(resource, domain.ProjectIDScope)
+	if !ok || projectID == "" || strings.Contains(projectID, ":") {
+		return nil
+	}
+	project, err := s.query.ProjectByID(ctx, false, projectID)
+	if zerrors.IsNotFound(err) || (err == nil && project.State != domain.ProjectStateActive) {
+		return oidc.ErrInvalidTarget().WithDescription("resource %q is unknown or not granted", resource)
+	}
+	return err
+}
+
+// resourceAudience restricts the granted audience to the requested resources.
+// A resource is either the resource uri registered on an API application
+// or the project urn (urn:zitadel:iam:org:project:id:{projectID}).
+// Resources which are unknown or not part of the granted audience result in an invalid_target error,
+// so a token is never issued for a service the client has no access to.
+func resourceAudience(ctx context.Context, apiResources apiResourcesByURI, resources, grantedAudience []string) ([]string, error) {
+	return resolveResources(ctx, apiResources, resources, func(aud string) bool {
+		return slices.Contains(grantedAudience, aud)
+	})
+}
+
+// resolveResources returns the audience of the requested resources,
+// granted reports whether a resolved project or client id may be part of the audience.
+func resolveResources(ctx context.Context, apiResources apiResourcesByURI, resources []string, granted func(aud string) bool) ([]string, error) {
+	audience := make([]string, 0, len(resources))
+	for _, resource := range resources {
+		if !domain.IsValidResourceIndicator(resource) {
+			return nil, oidc.ErrInvalidTarget().WithDescription("resource %q must be an absolute URI without fragment", resource)
+		}
+		resolved, err := resolveResource(ctx, apiResources, resource, granted)
+		if err != nil {
+			return nil, err
+		}
+		if len(resolved) == 0 {
+			return nil, oidc.ErrInvalidTarget().WithDescription("resource %q is unknown or not granted", resource)
+		}
+		for _, aud := range resolved {
+			if !slices.Contains(audience, aud) {
+				audience = append(audience, aud)
+			}
+		}
+	}
+	return audience, nil
+}
+
+func resolveResource(ctx context.Context, apiResources apiResourcesByURI, resource string, granted func(aud string) bool) ([]string, error) {
+	if projectID, ok := strings.CutPrefix(resource, domain.ProjectIDScope); ok {
+		if projectID == "" || strings.Contains(projectID, ":") || !granted(projectID) {
+			return nil, nil
+		}
+		return []string{projectID}, nil
+	}
+	apis, err := apiResources(ctx, resource)
+	if err != nil {
+		return nil, err
+	}
+	audience := make([]string, 0, len(apis))
+	for _, api := range apis {
+		if granted(api.ClientID) || granted(api.ProjectID) {
+			audience = append(audience, api.ClientID)
+		}
+	}
+	return audience, nil
+}
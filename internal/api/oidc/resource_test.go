@@ -0,0 +1,159 @@
+package oidc
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_resourceAudience(t *testing.T) {
+	apiResources := func(_ context.Context, resourceURI string) ([]*query.APIResource, error) {
+		switch resourceURI {
+		case "https://orders.example.com":
+			return []*query.APIResource{{ClientID: "orders", ProjectID: "project1"}}, nil
+		case "https://billing.example.com":
+			return []*query.APIResource{{ClientID: "billing", ProjectID: "project2"}}, nil
+		case "https://error.example.com":
+			return nil, io.ErrClosedPipe
+		}
+		return []*query.APIResource{}, nil
+	}
+	type args struct {
+		resources       []string
+		grantedAudience []string
+	}
+	tests := []struct {
+		name        string
+		args        args
+		want        []string
+		wantErr     error
+		wantInvalid bool
+	}{
+		{
+			name: "relative resource",
+			args: args{
+				resources:       []string{"/orders"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "resource with fragment",
+			args: args{
+				resources:       []string{"https://orders.example.com#v1"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "unknown resource",
+			args: args{
+				resources:       []string{"https://unknown.example.com"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "resource not granted",
+			args: args{
+				resources:       []string{"https://billing.example.com"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "project not granted",
+			args: args{
+				resources:       []string{"urn:zitadel:iam:org:project:id:project2"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			wantInvalid: true,
+		},
+		{
+			name: "query error",
+			args: args{
+				resources:       []string{"https://error.example.com"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			wantErr: io.ErrClosedPipe,
+		},
+		{
+			name: "api resource granted by project",
+			args: args{
+				resources:       []string{"https://orders.example.com"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			want: []string{"orders"},
+		},
+		{
+			name: "api resource granted by client id",
+			args: args{
+				resources:       []string{"https://billing.example.com"},
+				grantedAudience: []string{"client", "project1", "billing"},
+			},
+			want: []string{"billing"},
+		},
+		{
+			name: "project resource",
+			args: args{
+				resources:       []string{"urn:zitadel:iam:org:project:id:project1"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			want: []string{"project1"},
+		},
+		{
+			name: "multiple resources",
+			args: args{
+				resources:       []string{"https://orders.example.com", "urn:zitadel:iam:org:project:id:project1", "https://orders.example.com"},
+				grantedAudience: []string{"client", "project1"},
+			},
+			want: []string{"orders", "project1"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resourceAudience(context.Background(), apiResources, tt.args.resources, tt.args.grantedAudience)
+			if tt.wantInvalid {
+				var oidcErr *oidc.Error
+				require.ErrorAs(t, err, &oidcErr)
+				assert.Equal(t, oidc.InvalidTarget, oidcErr.ErrorType)
+				return
+			}
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_resolveResources_unrestricted(t *testing.T) {
+	apiResources := func(_ context.Context, resourceURI string) ([]*query.APIResource, error) {
+		if resourceURI == "https://orders.example.com" {
+			return []*query.APIResource{{ClientID: "orders", ProjectID: "project1"}}, nil
+		}
+		return []*query.APIResource{}, nil
+	}
+	granted := func(string) bool { return true }
+
+	got, err := resolveResources(context.Background(), apiResources, []string{"https://orders.example.com", "urn:zitadel:iam:org:project:id:project2"}, granted)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders", "project2"}, got)
+
+	_, err = resolveResources(context.Background(), apiResources, []string{"https://unknown.example.com"}, granted)
+	var oidcErr *oidc.Error
+	require.ErrorAs(t, err, &oidcErr)
+	assert.Equal(t, oidc.InvalidTarget, oidcErr.ErrorType)
+}
+
+func Test_restrictedAudience(t *testing.T) {
+	assert.Nil(t, restrictedAudience(nil))
+
+	got, err := restrictedAudience([]string{"orders"})(context.Background(), []string{"client", "orders", "billing"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"orders"}, got)
+}
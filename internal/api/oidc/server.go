@@ -42,6 +42,8 @@ type Server struct {
 	encAlg                    crypto.AuthAlgorithm
 	targetEncryptionAlgorithm crypto.EncryptionAlgorithm
 	opCrypto                  op.Crypto
+	browserState              *browserState
+	clientKeySets             *clientKeySets
 
 	assetAPIPrefix func(ctx context.Context) string
 }
@@ -124,7 +126,19 @@ func (s *Server) Discovery(ctx context.Context, r *op.Request[struct{}]) (_ *op.
 	if len(allowedLanguages) == 0 {
 		allowedLanguages = i18n.SupportedLanguages()
 	}
-	return op.NewResponse(s.createDiscoveryConfig(ctx, allowedLanguages)), nil
+	return op.NewResponse(&discoveryConfiguration{
+		DiscoveryConfiguration:             s.createDiscoveryConfig(ctx, allowedLanguages),
+		FrontChannelLogoutSupported:        true,
+		FrontChannelLogoutSessionSupported: true,
+	}), nil
+}
+
+// discoveryConfiguration extends the [oidc.DiscoveryConfiguration] with the metadata of
+// https://openid.net/specs/openid-connect-frontchannel-1_0.html#OPLogout
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	FrontChannelLogoutSupported        bool `json:"frontchannel_logout_supported,omitempty"`
+	FrontChannelLogoutSessionSupported bool `json:"frontchannel_logout_session_supported,omitempty"`
 }
 
 func (s *Server) VerifyAuthRequest(ctx context.Context, r *op.Request[oidc.AuthRequest]) (_ *op.ClientRequest[oidc.AuthRequest], err error) {
@@ -144,6 +158,7 @@ func (s *Server) Authorize(ctx context.Context, r *op.ClientRequest[oidc.AuthReq
 	logging.WithFields("instanceID", authz.GetInstance(ctx).InstanceID()).
 		OnError(err).Error("invalid id_token_hint")
 
+	ctx = withRequestedResources(ctx, r.Form[paramResource])
 	req, err := s.Provider().Storage().CreateAuthRequest(ctx, r.Data, userID)
 	if err != nil {
 		return op.TryErrorRedirect(ctx, r.Data, oidc.DefaultToServerError(err, "unable to save auth request"), s.Provider().Encoder(), s.Provider().Logger())
@@ -183,6 +198,7 @@ func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales o
 		UserinfoEndpoint:            s.Endpoints().Userinfo.Absolute(issuer),
 		RevocationEndpoint:          s.Endpoints().Revocation.Absolute(issuer),
 		EndSessionEndpoint:          s.Endpoints().EndSession.Absolute(issuer),
+		CheckSessionIframe:          checkSessionIframe(ctx, issuer),
 		JwksURI:                     s.Endpoints().JwksURI.Absolute(issuer),
 		DeviceAuthorizationEndpoint: s.Endpoints().DeviceAuthorization.Absolute(issuer),
 		ScopesSupported:             op.Scopes(s.Provider()),
@@ -195,6 +211,10 @@ func (s *Server) createDiscoveryConfig(ctx context.Context, supportedUILocales o
 		GrantTypesSupported:                                op.GrantTypes(s.Provider()),
 		SubjectTypesSupported:                              op.SubjectTypes(s.Provider()),
 		IDTokenSigningAlgValuesSupported:                   supportedSigningAlgs(),
+		IDTokenEncryptionAlgValuesSupported:                supportedEncryptionAlgs(),
+		IDTokenEncryptionEncValuesSupported:                supportedContentEncryptions(),
+		UserinfoEncryptionAlgValuesSupported:               supportedEncryptionAlgs(),
+		UserinfoEncryptionEncValuesSupported:               supportedContentEncryptions(),
 		RequestObjectSigningAlgValuesSupported:             op.RequestObjectSigAlgorithms(s.Provider()),
 		TokenEndpointAuthMethodsSupported:                  op.AuthMethodsTokenEndpoint(s.Provider()),
 		TokenEndpointAuthSigningAlgValuesSupported:         op.TokenSigAlgorithms(s.Provider()),
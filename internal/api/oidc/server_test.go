@@ -69,7 +69,7 @@ func TestServer_createDiscoveryConfig(t *testing.T) {
 				RevocationEndpoint:                                 "https://issuer.com/revoke",
 				EndSessionEndpoint:                                 "https://issuer.com/logout",
 				DeviceAuthorizationEndpoint:                        "https://issuer.com/device",
-				CheckSessionIframe:                                 "",
+				CheckSessionIframe:                                 "https://issuer.com/oidc/v1/check_session",
 				JwksURI:                                            "https://issuer.com/keys",
 				RegistrationEndpoint:                               "",
 				ScopesSupported:                                    []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeAddress, oidc.ScopeOfflineAccess},
@@ -79,11 +79,11 @@ func TestServer_createDiscoveryConfig(t *testing.T) {
 				ACRValuesSupported:                                 nil,
 				SubjectTypesSupported:                              []string{"public"},
 				IDTokenSigningAlgValuesSupported:                   supportedWebKeyAlgs,
-				IDTokenEncryptionAlgValuesSupported:                nil,
-				IDTokenEncryptionEncValuesSupported:                nil,
+				IDTokenEncryptionAlgValuesSupported:                []string{"RSA-OAEP", "RSA-OAEP-256", "ECDH-ES", "ECDH-ES+A128KW", "ECDH-ES+A192KW", "ECDH-ES+A256KW"},
+				IDTokenEncryptionEncValuesSupported:                []string{"A128CBC-HS256", "A192CBC-HS384", "A256CBC-HS512", "A128GCM", "A192GCM", "A256GCM"},
 				UserinfoSigningAlgValuesSupported:                  nil,
-				UserinfoEncryptionAlgValuesSupported:               nil,
-				UserinfoEncryptionEncValuesSupported:               nil,
+				UserinfoEncryptionAlgValuesSupported:               []string{"RSA-OAEP", "RSA-OAEP-256", "ECDH-ES", "ECDH-ES+A128KW", "ECDH-ES+A192KW", "ECDH-ES+A256KW"},
+				UserinfoEncryptionEncValuesSupported:               []string{"A128CBC-HS256", "A192CBC-HS384", "A256CBC-HS512", "A128GCM", "A192GCM", "A256GCM"},
 				RequestObjectSigningAlgValuesSupported:             []string{"RS256"},
 				RequestObjectEncryptionAlgValuesSupported:          nil,
 				RequestObjectEncryptionEncValuesSupported:          nil,
@@ -17,6 +17,15 @@ type SignerFunc func(ctx context.Context) (jose.Signer, jose.SignatureAlgorithm,
 // Repeated calls of the returned function return the same results.
 func GetSignerOnce(
 	getActiveSigningWebKey func(ctx context.Context) (*jose.JSONWebKey, error),
+) SignerFunc {
+	return GetTypedSignerOnce(getActiveSigningWebKey, "JWT")
+}
+
+// GetTypedSignerOnce is like [GetSignerOnce],
+// but the signer sets the passed value as typ header instead of JWT.
+func GetTypedSignerOnce(
+	getActiveSigningWebKey func(ctx context.Context) (*jose.JSONWebKey, error),
+	typ jose.ContentType,
 ) SignerFunc {
 	var (
 		once    sync.Once
@@ -34,20 +43,20 @@ func GetSignerOnce(
 			if err != nil {
 				return
 			}
-			signer, signAlg, err = signerFromWebKey(webKey)
+			signer, signAlg, err = signerFromWebKey(webKey, typ)
 		})
 		return signer, signAlg, err
 	}
 }
 
-func signerFromWebKey(signingKey *jose.JSONWebKey) (jose.Signer, jose.SignatureAlgorithm, error) {
+func signerFromWebKey(signingKey *jose.JSONWebKey, typ jose.ContentType) (jose.Signer, jose.SignatureAlgorithm, error) {
 	signAlg := jose.SignatureAlgorithm(signingKey.Algorithm)
 	signer, err := jose.NewSigner(
 		jose.SigningKey{
 			Algorithm: signAlg,
 			Key:       signingKey,
 		},
-		(&jose.SignerOptions{}).WithType("JWT"),
+		(&jose.SignerOptions{}).WithType(typ),
 	)
 	if err != nil {
 		return nil, "", zerrors.ThrowInternal(err, "OIDC-oaF0s", "Errors.Internal")
@@ -72,7 +72,7 @@ func (s *Server) getUserInfo(userID, projectID, clientID string, projectRoleAsse
 	}
 }
 
-func (*Server) createIDToken(ctx context.Context, client op.Client, getUserInfo userInfoFunc, roleAssertion bool, getSigningKey sign.SignerFunc, sessionID, accessToken string, audience []string, authMethods []domain.UserAuthMethodType, authTime time.Time, nonce string, actor *domain.TokenActor) (idToken string, exp uint64, err error) {
+func (s *Server) createIDToken(ctx context.Context, client op.Client, getUserInfo userInfoFunc, roleAssertion bool, getSigningKey sign.SignerFunc, sessionID, accessToken string, audience []string, authMethods []domain.UserAuthMethodType, authTime time.Time, nonce string, actor *domain.TokenActor) (idToken string, exp uint64, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -109,6 +109,12 @@ func (*Server) createIDToken(ctx context.Context, client op.Client, getUserInfo
 		}
 	}
 	idToken, err = crypto.Sign(claims, signer)
+	if err != nil {
+		return "", 0, err
+	}
+	if encryption := idTokenEncryption(client, s.clientKeySets); encryption.enabled() {
+		idToken, err = encryption.encrypt(ctx, []byte(idToken), true)
+	}
 	return idToken, timeToOIDCExpiresIn(expTime), err
 }
 
@@ -129,11 +135,15 @@ func (s *Server) createJWT(ctx context.Context, client op.Client, session *comma
 		return "", err
 	}
 
+	audience := session.Audience
+	if len(session.AccessTokenAudience) > 0 {
+		audience = session.AccessTokenAudience
+	}
 	expTime := session.Expiration.Add(client.ClockSkew())
 	claims := oidc.NewAccessTokenClaims(
 		op.IssuerFromContext(ctx),
 		userInfo.Subject,
-		session.Audience,
+		audience,
 		expTime,
 		session.TokenID,
 		client.GetID(),
@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
 
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -31,13 +32,17 @@ func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequ
 		return nil, err
 	}
 
+	audience, accessTokenAudience, err := s.serviceAccountAudience(ctx, r.Form[paramResource], domain.AddAudScopeToAudience(ctx, nil, r.Data.Scope))
+	if err != nil {
+		return nil, err
+	}
+
 	session, err := s.command.CreateOIDCSession(ctx,
 		client.userID,
 		client.resourceOwner,
 		client.clientID,
-		"", // backChannelLogoutURI not needed for service account session
 		scope,
-		domain.AddAudScopeToAudience(ctx, nil, r.Data.Scope),
+		audience,
 		[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
 		time.Now(),
 		"",
@@ -48,6 +53,8 @@ func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequ
 		false,
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		// logout and client specific refresh tokens are not needed for service account session
+		&command.OIDCSessionOptions{AccessTokenAudience: accessTokenAudience},
 	)
 	if err != nil {
 		return nil, err
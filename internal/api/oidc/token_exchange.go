@@ -41,10 +41,6 @@ func (s *Server) tokenExchange(ctx context.Context, r *op.ClientRequest[oidc.Tok
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	if len(r.Data.Resource) > 0 {
-		return nil, oidc.ErrInvalidTarget().WithDescription("resource parameter not supported")
-	}
-
 	client, ok := r.Client.(*Client)
 	if !ok {
 		// not supposed to happen, but just preventing a panic if it does.
@@ -75,12 +71,19 @@ func (s *Server) tokenExchange(ctx context.Context, r *op.ClientRequest[oidc.Tok
 	if err != nil {
 		return nil, err
 	}
+	var accessTokenAudience []string
+	if len(r.Data.Resource) > 0 {
+		accessTokenAudience, err = resourceAudience(ctx, s.query.ActiveAPIResourcesByURI, r.Data.Resource, audience)
+		if err != nil {
+			return nil, err
+		}
+	}
 	scopes, err := validateTokenExchangeScopes(client, r.Data.Scopes, subjectToken.scopes, actorToken.scopes)
 	if err != nil {
 		return nil, err
 	}
 
-	resp, err := s.createExchangeTokens(ctx, r.Data.RequestedTokenType, client, subjectToken, actorToken, audience, scopes)
+	resp, err := s.createExchangeTokens(ctx, r.Data.RequestedTokenType, client, subjectToken, actorToken, audience, accessTokenAudience, scopes)
 	if err != nil {
 		return nil, err
 	}
@@ -215,7 +218,9 @@ func validateTokenExchangeAudience(requestedAudience, subjectAudience, actorAudi
 // The actorToken is used to set the new token's auth time AMR and actor.
 // Both tokens may point to the same object (subjectToken) in case of a regular Token Exchange.
 // When the subject and actor Tokens point to different objects, the new tokens will be for impersonation / delegation.
-func (s *Server) createExchangeTokens(ctx context.Context, tokenType oidc.TokenType, client *Client, subjectToken, actorToken *exchangeToken, audience, scopes []string) (_ *oidc.TokenExchangeResponse, err error) {
+// The audience of issued access tokens is restricted to the accessTokenAudience, if set.
+// ID tokens always receive the audience.
+func (s *Server) createExchangeTokens(ctx context.Context, tokenType oidc.TokenType, client *Client, subjectToken, actorToken *exchangeToken, audience, accessTokenAudience, scopes []string) (_ *oidc.TokenExchangeResponse, err error) {
 	getUserInfo := s.getUserInfo(subjectToken.userID, client.client.ProjectID, client.GetID(), client.client.ProjectRoleAssertion, client.IDTokenUserinfoClaimsAssertion(), scopes)
 	getSigner := s.getSignerOnce()
 
@@ -233,12 +238,12 @@ func (s *Server) createExchangeTokens(ctx context.Context, tokenType oidc.TokenT
 	var sessionID string
 	switch tokenType {
 	case oidc.AccessTokenType, "":
-		resp.AccessToken, resp.RefreshToken, sessionID, resp.ExpiresIn, err = s.createExchangeAccessToken(ctx, client, subjectToken.userID, subjectToken.resourceOwner, audience, scopes, actorToken.authMethods, actorToken.authTime, subjectToken.preferredLanguage, reason, actor)
+		resp.AccessToken, resp.RefreshToken, sessionID, resp.ExpiresIn, err = s.createExchangeAccessToken(ctx, client, subjectToken.userID, subjectToken.resourceOwner, audience, accessTokenAudience, scopes, actorToken.authMethods, actorToken.authTime, subjectToken.preferredLanguage, reason, actor)
 		resp.TokenType = oidc.BearerToken
 		resp.IssuedTokenType = oidc.AccessTokenType
 
 	case oidc.JWTTokenType:
-		resp.AccessToken, resp.RefreshToken, resp.ExpiresIn, err = s.createExchangeJWT(ctx, client, getUserInfo, client.client.AccessTokenRoleAssertion, getSigner, subjectToken.userID, subjectToken.resourceOwner, audience, scopes, actorToken.authMethods, actorToken.authTime, subjectToken.preferredLanguage, reason, actor)
+		resp.AccessToken, resp.RefreshToken, resp.ExpiresIn, err = s.createExchangeJWT(ctx, client, getUserInfo, client.client.AccessTokenRoleAssertion, getSigner, subjectToken.userID, subjectToken.resourceOwner, audience, accessTokenAudience, scopes, actorToken.authMethods, actorToken.authTime, subjectToken.preferredLanguage, reason, actor)
 		resp.TokenType = oidc.BearerToken
 		resp.IssuedTokenType = oidc.JWTTokenType
 
@@ -272,6 +277,7 @@ func (s *Server) createExchangeAccessToken(
 	userID,
 	resourceOwner string,
 	audience,
+	accessTokenAudience,
 	scope []string,
 	authMethods []domain.UserAuthMethodType,
 	authTime time.Time,
@@ -286,7 +292,6 @@ func (s *Server) createExchangeAccessToken(
 		userID,
 		resourceOwner,
 		client.client.ClientID,
-		client.client.BackChannelLogoutURI,
 		scope,
 		audience,
 		authMethods,
@@ -299,6 +304,7 @@ func (s *Server) createExchangeAccessToken(
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		client.oidcSessionOptions(restrictedAudience(accessTokenAudience)),
 	)
 	if err != nil {
 		return "", "", "", 0, err
@@ -319,6 +325,7 @@ func (s *Server) createExchangeJWT(
 	userID,
 	resourceOwner string,
 	audience,
+	accessTokenAudience,
 	scope []string,
 	authMethods []domain.UserAuthMethodType,
 	authTime time.Time,
@@ -333,7 +340,6 @@ func (s *Server) createExchangeJWT(
 		userID,
 		resourceOwner,
 		client.client.ClientID,
-		client.client.BackChannelLogoutURI,
 		scope,
 		audience,
 		authMethods,
@@ -346,6 +352,7 @@ func (s *Server) createExchangeJWT(
 		slices.Contains(scope, oidc.ScopeOfflineAccess),
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		client.oidcSessionOptions(restrictedAudience(accessTokenAudience)),
 	)
 	if err != nil {
 		return "", "", 0, err
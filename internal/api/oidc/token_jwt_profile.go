@@ -9,6 +9,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
@@ -42,13 +43,17 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 		return nil, err
 	}
 
+	audience, accessTokenAudience, err := s.serviceAccountAudience(ctx, r.Form[paramResource], domain.AddAudScopeToAudience(ctx, nil, r.Data.Scope))
+	if err != nil {
+		return nil, err
+	}
+
 	session, err := s.command.CreateOIDCSession(ctx,
 		client.userID,
 		client.resourceOwner,
 		client.clientID,
-		"", // backChannelLogoutURI not needed for service account session
 		scope,
-		domain.AddAudScopeToAudience(ctx, nil, r.Data.Scope),
+		audience,
 		[]domain.UserAuthMethodType{domain.UserAuthMethodTypePrivateKey},
 		time.Now(),
 		"",
@@ -59,6 +64,8 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 		false,
 		"",
 		domain.OIDCResponseTypeUnspecified,
+		// logout and client specific refresh tokens are not needed for service account session
+		&command.OIDCSessionOptions{AccessTokenAudience: accessTokenAudience},
 	)
 	if err != nil {
 		return nil, err
@@ -39,15 +39,19 @@ func (s *Server) UserInfo(ctx context.Context, r *op.Request[oidc.UserInfoReques
 	}
 
 	var (
+		client    *query.OIDCUserinfoClient
 		projectID string
 		assertion bool
 	)
 	if token.clientID != "" {
-		projectID, assertion, err = s.query.GetOIDCUserinfoClientByID(ctx, token.clientID)
+		client, err = s.query.GetOIDCUserinfoClientByID(ctx, token.clientID)
 		// token.clientID might contain a username (e.g. client credentials) -> ignore the not found
 		if err != nil && !zerrors.IsNotFound(err) {
 			return nil, err
 		}
+		if client != nil {
+			projectID, assertion = client.ProjectID, client.ProjectRoleAssertion
+		}
 	}
 
 	userInfo, err := s.userInfo(
@@ -65,9 +69,28 @@ func (s *Server) UserInfo(ctx context.Context, r *op.Request[oidc.UserInfoReques
 		}
 		return nil, op.NewStatusError(oidc.ErrAccessDenied().WithDescription("no active user").WithParent(err).WithReturnParentToClient(authz.GetFeatures(ctx).DebugOIDCParentError), http.StatusUnauthorized)
 	}
+	if encryption := userinfoEncryption(client, s.clientKeySets); encryption.enabled() {
+		return encryptedUserInfoResponse(ctx, encryption, userInfo)
+	}
 	return op.NewResponse(userInfo), nil
 }
 
+// encryptedUserInfoResponse responds with the userinfo as JWE (not signed) instead of plain JSON.
+func encryptedUserInfoResponse(ctx context.Context, encryption *responseEncryption, userInfo *oidc.UserInfo) (*op.Response, error) {
+	payload, err := json.Marshal(userInfo)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "OIDC-Ui2ne", "Errors.Internal")
+	}
+	token, err := encryption.encrypt(ctx, payload, false)
+	if err != nil {
+		return nil, err
+	}
+	if !setJWTResponse(ctx, jwtContentType, token) {
+		return nil, zerrors.ThrowInternal(nil, "OIDC-Ui3of", "Errors.Internal")
+	}
+	return op.NewResponse(nil), nil
+}
+
 // userInfo gets the user's data based on the scope.
 // The returned UserInfo contains standard and reserved claims, documented
 // here: https://zitadel.com/docs/apis/openidoauth/claims.
@@ -0,0 +1,122 @@
+package radius
+
+import (
+	"context"
+	"encoding/binary"
+	"slices"
+	"strconv"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const otpPrompt = "Enter the code of your authenticator app"
+
+// access handles an Access-Request with PAP or EAP.
+func (s *Server) access(ctx context.Context, client *client, request *packet) *packet {
+	if _, ok := request.get(attributeEAPMessage); ok {
+		return s.eap(ctx, client, request)
+	}
+	username, _ := request.get(attributeUserName)
+	encrypted, ok := request.get(attributeUserPassword)
+	if !ok || len(username) == 0 {
+		// CHAP can't be verified, as the passwords are hashed
+		return request.newResponse(codeAccessReject)
+	}
+	password, err := decryptPassword(client.secret, request.authenticator, encrypted)
+	if err != nil {
+		return request.newResponse(codeAccessReject)
+	}
+	if state, ok := request.get(attributeState); ok {
+		return s.answerChallenge(ctx, client, request, state, string(username), string(password))
+	}
+	identity, err := s.backend.checkPassword(ctx, client, string(username), string(password))
+	if err != nil {
+		logging.WithFields("client", client.id).WithError(err).Debug("radius password check failed")
+		return request.newResponse(codeAccessReject)
+	}
+	if identity.otp {
+		sess, err := s.sessions.create(client.id)
+		if err != nil {
+			logging.WithFields("client", client.id).WithError(err).Warn("unable to create radius session")
+			return nil
+		}
+		sess.identity = identity
+		sess.username = string(username)
+		response := request.newResponse(codeAccessChallenge)
+		response.add(attributeReplyMessage, []byte(otpPrompt))
+		response.add(attributeState, []byte(sess.state))
+		return response
+	}
+	return s.accept(ctx, client, request, identity)
+}
+
+// answerChallenge verifies the code sent in the User-Password of the request answering the challenge.
+// Sessions can only be answered once, so codes can't be guessed.
+func (s *Server) answerChallenge(ctx context.Context, client *client, request *packet, state []byte, username, code string) *packet {
+	sess := s.sessions.get(state, client.id)
+	if sess == nil || sess.eap != nil {
+		return request.newResponse(codeAccessReject)
+	}
+	s.sessions.remove(sess)
+	if sess.username != username {
+		return request.newResponse(codeAccessReject)
+	}
+	if err := s.backend.checkOTP(ctx, sess.identity, code); err != nil {
+		logging.WithFields("client", client.id).WithError(err).Debug("radius otp check failed")
+		return request.newResponse(codeAccessReject)
+	}
+	return s.accept(ctx, client, request, sess.identity)
+}
+
+func (s *Server) accept(ctx context.Context, client *client, request *packet, identity *identity) *packet {
+	roles, ok := s.authorize(ctx, client, identity)
+	if !ok {
+		return request.newResponse(codeAccessReject)
+	}
+	response := request.newResponse(codeAccessAccept)
+	response.add(attributeUserName, []byte(identity.loginName))
+	addRoleAttributes(response, client.roleAttributes, roles)
+	return response
+}
+
+// authorize returns the roles of the user on the project of the client,
+// ok is false if the client requires a grant the user does not have.
+func (s *Server) authorize(ctx context.Context, client *client, identity *identity) (roles []string, ok bool) {
+	if client.projectID == "" {
+		return nil, true
+	}
+	granted, roles, err := s.backend.grant(ctx, client, identity)
+	if err != nil {
+		logging.WithFields("client", client.id).WithError(err).Warn("unable to get radius user grant")
+		return nil, false
+	}
+	if !granted && client.requireGrant {
+		return nil, false
+	}
+	return roles, true
+}
+
+// addRoleAttributes adds the attributes mapped from the granted roles.
+func addRoleAttributes(response *packet, attributes []*domain.RADIUSRoleAttribute, roles []string) {
+	for _, attr := range attributes {
+		if !slices.Contains(roles, attr.Role) {
+			continue
+		}
+		value := []byte(attr.AttributeValue())
+		if attr.Format == domain.RADIUSAttributeFormatInteger {
+			// the format is validated when the attribute is configured
+			i, err := strconv.ParseUint(attr.AttributeValue(), 10, 32)
+			if err != nil {
+				continue
+			}
+			value = binary.BigEndian.AppendUint32(nil, uint32(i))
+		}
+		if attr.Type == domain.RADIUSAttributeTypeVendorSpecific {
+			response.addVendorSpecific(attr.VendorID, attr.VendorType, value)
+			continue
+		}
+		response.add(attr.Type, value)
+	}
+}
@@ -0,0 +1,107 @@
+package radius
+
+import (
+	"crypto/hmac"
+	"crypto/md5" //nolint:gosec // required by RFC 2865 and RFC 3579
+	"errors"
+)
+
+const maxPasswordLength = 128
+
+var errInvalidPassword = errors.New("invalid User-Password attribute")
+
+// decryptPassword reverses the hiding of the User-Password attribute (RFC 2865, section 5.2).
+func decryptPassword(secret []byte, requestAuthenticator [authenticatorLength]byte, value []byte) ([]byte, error) {
+	if len(value) < 16 || len(value) > maxPasswordLength || len(value)%16 != 0 {
+		return nil, errInvalidPassword
+	}
+	password := make([]byte, len(value))
+	previous := requestAuthenticator[:]
+	for i := 0; i < len(value); i += 16 {
+		b := md5.Sum(append(append([]byte{}, secret...), previous...)) //nolint:gosec
+		for j := 0; j < 16; j++ {
+			password[i+j] = value[i+j] ^ b[j]
+		}
+		previous = value[i : i+16]
+	}
+	for len(password) > 0 && password[len(password)-1] == 0 {
+		password = password[:len(password)-1]
+	}
+	return password, nil
+}
+
+// verifyMessageAuthenticator checks the Message-Authenticator of a request (RFC 3579, section 3.2),
+// present is false if the request does not contain the attribute.
+func verifyMessageAuthenticator(secret []byte, p *packet) (present, valid bool) {
+	expected, ok := p.get(attributeMessageAuthenticator)
+	if !ok {
+		return false, false
+	}
+	if len(expected) != md5.Size {
+		return true, false
+	}
+	zeroed := &packet{
+		code:          p.code,
+		identifier:    p.identifier,
+		authenticator: p.authenticator,
+		attributes:    make([]attribute, len(p.attributes)),
+	}
+	for i, attr := range p.attributes {
+		if attr.typ == attributeMessageAuthenticator {
+			attr.value = make([]byte, md5.Size)
+		}
+		zeroed.attributes[i] = attr
+	}
+	b, err := zeroed.encode()
+	if err != nil {
+		return true, false
+	}
+	return true, hmac.Equal(expected, messageAuthenticator(secret, b))
+}
+
+func messageAuthenticator(secret, b []byte) []byte {
+	mac := hmac.New(md5.New, secret)
+	mac.Write(b)
+	return mac.Sum(nil)
+}
+
+// encodeResponse signs the response to the request.
+// The Message-Authenticator is always added as first attribute, so responses can't be forged
+// by choosing the attributes of a request (RFC 3579, section 3.2 and Blast-RADIUS).
+func (p *packet) encodeResponse(secret []byte, requestAuthenticator [authenticatorLength]byte) ([]byte, error) {
+	p.attributes = append([]attribute{{typ: attributeMessageAuthenticator, value: make([]byte, md5.Size)}}, p.attributes...)
+	p.authenticator = requestAuthenticator
+	b, err := p.encode()
+	if err != nil {
+		return nil, err
+	}
+	copy(b[headerLength+2:], messageAuthenticator(secret, b))
+	// ResponseAuth = MD5(Code+ID+Length+RequestAuth+Attributes+Secret) (RFC 2865, section 3)
+	hash := md5.New() //nolint:gosec
+	hash.Write(b)
+	hash.Write(secret)
+	copy(b[4:headerLength], hash.Sum(nil))
+	copy(p.authenticator[:], b[4:headerLength])
+	return b, nil
+}
+
+// encryptMPPEKey encrypts a key for the MS-MPPE-Send-Key and MS-MPPE-Recv-Key attributes (RFC 2548, section 2.4.2),
+// the high bit of the salt must be set and the salt must be unique per key of a response.
+func encryptMPPEKey(secret []byte, requestAuthenticator [authenticatorLength]byte, salt [2]byte, key []byte) []byte {
+	plain := append([]byte{uint8(len(key))}, key...)
+	if padding := len(plain) % 16; padding != 0 {
+		plain = append(plain, make([]byte, 16-padding)...)
+	}
+	encrypted := append([]byte{}, salt[:]...)
+	previous := append(requestAuthenticator[:], salt[:]...)
+	for i := 0; i < len(plain); i += 16 {
+		b := md5.Sum(append(append([]byte{}, secret...), previous...)) //nolint:gosec
+		block := make([]byte, 16)
+		for j := 0; j < 16; j++ {
+			block[j] = plain[i+j] ^ b[j]
+		}
+		encrypted = append(encrypted, block...)
+		previous = block
+	}
+	return encrypted
+}
@@ -0,0 +1,181 @@
+package radius
+
+import (
+	"context"
+	"net/netip"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// client is a network access server of an instance, which is allowed to send requests.
+type client struct {
+	id             string
+	instanceID     string
+	nasIdentifier  string
+	secret         []byte
+	projectID      string
+	requireGrant   bool
+	roleAttributes []*domain.RADIUSRoleAttribute
+}
+
+// identity is a user whose password was verified.
+type identity struct {
+	instanceID string
+	orgID      string
+	userID     string
+	loginName  string
+	// otp is true if the user has to answer a challenge with the code of the authenticator app
+	otp bool
+}
+
+// backend verifies the users, it's implemented by [zitadel] and replaced in the tests.
+type backend interface {
+	clients(ctx context.Context, addr netip.Addr) ([]*client, error)
+	checkPassword(ctx context.Context, client *client, username, password string) (*identity, error)
+	checkOTP(ctx context.Context, identity *identity, code string) error
+	// grant returns if the user is granted the project of the client and the granted roles
+	grant(ctx context.Context, client *client, identity *identity) (granted bool, roles []string, err error)
+}
+
+type zitadel struct {
+	commands *command.Commands
+	queries  *query.Queries
+}
+
+func (z *zitadel) clients(ctx context.Context, addr netip.Addr) ([]*client, error) {
+	radiusClients, err := z.queries.RADIUSClientsByAddress(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	clients := make([]*client, len(radiusClients))
+	for i, radiusClient := range radiusClients {
+		clients[i] = &client{
+			id:             radiusClient.ID,
+			instanceID:     radiusClient.InstanceID,
+			nasIdentifier:  radiusClient.NASIdentifier,
+			secret:         radiusClient.Secret,
+			projectID:      radiusClient.ProjectID,
+			requireGrant:   radiusClient.RequireGrant,
+			roleAttributes: radiusClient.RoleAttributes,
+		}
+	}
+	return clients, nil
+}
+
+func (z *zitadel) checkPassword(ctx context.Context, client *client, username, password string) (_ *identity, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instance, err := z.queries.InstanceByID(ctx, client.instanceID)
+	if err != nil {
+		return nil, err
+	}
+	ctx = authz.WithInstance(ctx, instance)
+	user, err := z.queries.GetUserByLoginName(ctx, true, username)
+	if err != nil {
+		return nil, err
+	}
+	if user.Human == nil {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "RADIUS-Bq5ts", "Errors.User.NotHuman")
+	}
+	if user.State != domain.UserStateActive {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "RADIUS-Ej2ka", "Errors.User.NotActive")
+	}
+	ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: user.ID, OrgID: user.ResourceOwner})
+	policy, err := z.queries.LoginPolicyByID(ctx, false, user.ResourceOwner, false)
+	if err != nil {
+		return nil, err
+	}
+	if !policy.AllowUsernamePassword {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "RADIUS-Hs4vk", "Errors.Org.LoginPolicy.UsernamePasswordNotAllowed")
+	}
+	if err := z.commands.HumanCheckPassword(ctx, user.ResourceOwner, user.ID, password, nil); err != nil {
+		return nil, err
+	}
+	requirements, err := z.queries.ListUserAuthMethodTypesRequired(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	otp, err := otpRequired(policy, requirements.SetUpFactors)
+	if err != nil {
+		return nil, err
+	}
+	return &identity{
+		instanceID: client.instanceID,
+		orgID:      user.ResourceOwner,
+		userID:     user.ID,
+		loginName:  user.PreferredLoginName,
+		otp:        otp,
+	}, nil
+}
+
+// otpRequired returns if the user has to answer a challenge with the code of the authenticator app.
+// Other second factors can't be used over RADIUS, so the authentication fails
+// if the user has set up or is forced to use a second factor, but has no authenticator app.
+func otpRequired(policy *query.LoginPolicy, setUpFactors []domain.UserAuthMethodType) (bool, error) {
+	if slices.Contains(setUpFactors, domain.UserAuthMethodTypeTOTP) {
+		return true, nil
+	}
+	// the password is always a local authentication
+	if policy.ForceMFA || policy.ForceMFALocalOnly || domain.Has2FA(setUpFactors) {
+		return false, zerrors.ThrowPreconditionFailed(nil, "RADIUS-Wc7nz", "Errors.User.MFA.OTP.NotReady")
+	}
+	return false, nil
+}
+
+func (z *zitadel) checkOTP(ctx context.Context, identity *identity, code string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	ctx, err = z.userContext(ctx, identity)
+	if err != nil {
+		return err
+	}
+	return z.commands.HumanCheckMFATOTP(ctx, identity.userID, code, identity.orgID, nil)
+}
+
+func (z *zitadel) grant(ctx context.Context, client *client, identity *identity) (_ bool, _ []string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	ctx, err = z.userContext(ctx, identity)
+	if err != nil {
+		return false, nil, err
+	}
+	userQuery, err := query.NewUserGrantUserIDSearchQuery(identity.userID)
+	if err != nil {
+		return false, nil, err
+	}
+	projectQuery, err := query.NewUserGrantProjectIDSearchQuery(client.projectID)
+	if err != nil {
+		return false, nil, err
+	}
+	stateQuery, err := query.NewUserGrantStateQuery(domain.UserGrantStateActive)
+	if err != nil {
+		return false, nil, err
+	}
+	grants, err := z.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userQuery, projectQuery, stateQuery}}, true, nil)
+	if err != nil {
+		return false, nil, err
+	}
+	var roles []string
+	for _, grant := range grants.UserGrants {
+		roles = append(roles, grant.Roles...)
+	}
+	return len(grants.UserGrants) > 0, roles, nil
+}
+
+func (z *zitadel) userContext(ctx context.Context, identity *identity) (context.Context, error) {
+	instance, err := z.queries.InstanceByID(ctx, identity.instanceID)
+	if err != nil {
+		return nil, err
+	}
+	ctx = authz.WithInstance(ctx, instance)
+	return authz.SetCtxData(ctx, authz.CtxData{UserID: identity.userID, OrgID: identity.orgID}), nil
+}
@@ -0,0 +1,72 @@
+package radius
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_otpRequired(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       *query.LoginPolicy
+		setUpFactors []domain.UserAuthMethodType
+		want         bool
+		wantErr      bool
+	}{
+		{
+			name:   "password only",
+			policy: &query.LoginPolicy{},
+			want:   false,
+		},
+		{
+			name:         "totp set up",
+			policy:       &query.LoginPolicy{},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword, domain.UserAuthMethodTypeTOTP},
+			want:         true,
+		},
+		{
+			name:         "mfa forced with totp",
+			policy:       &query.LoginPolicy{ForceMFA: true},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypeTOTP},
+			want:         true,
+		},
+		{
+			name:    "mfa forced without totp",
+			policy:  &query.LoginPolicy{ForceMFA: true},
+			wantErr: true,
+		},
+		{
+			name:    "local mfa forced without totp",
+			policy:  &query.LoginPolicy{ForceMFALocalOnly: true},
+			wantErr: true,
+		},
+		{
+			name:         "other second factor set up",
+			policy:       &query.LoginPolicy{},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypeU2F},
+			wantErr:      true,
+		},
+		{
+			name:         "passkey set up",
+			policy:       &query.LoginPolicy{},
+			setUpFactors: []domain.UserAuthMethodType{domain.UserAuthMethodTypePasswordless},
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := otpRequired(tt.policy, tt.setUpFactors)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsPreconditionFailed(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
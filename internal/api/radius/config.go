@@ -0,0 +1,19 @@
+package radius
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/config/network"
+)
+
+type Config struct {
+	// Enabled starts the RADIUS listener, which authenticates users for the registered network access servers.
+	Enabled bool
+	Port    uint16
+	// TLS is used for EAP-TTLS, only PAP is supported if TLS is disabled.
+	TLS network.TLS
+	// RequireMessageAuthenticator drops Access-Requests without a valid Message-Authenticator attribute.
+	RequireMessageAuthenticator bool
+	// ChallengeTimeout is the time a user has to answer a challenge.
+	ChallengeTimeout time.Duration
+}
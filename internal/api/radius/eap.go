@@ -0,0 +1,170 @@
+package radius
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// EAP codes and types (RFC 3748, section 4 and 5)
+const (
+	eapCodeRequest  uint8 = 1
+	eapCodeResponse uint8 = 2
+	eapCodeSuccess  uint8 = 3
+	eapCodeFailure  uint8 = 4
+
+	eapTypeIdentity uint8 = 1
+	eapTypeNak      uint8 = 3
+	eapTypeTTLS     uint8 = 21
+)
+
+// EAP-TTLS flags (RFC 5281, section 9.1)
+const (
+	ttlsFlagLength    uint8 = 0x80
+	ttlsFlagMore      uint8 = 0x40
+	ttlsFlagStart     uint8 = 0x20
+	ttlsVersionMask   uint8 = 0x07
+	ttlsHeaderLength        = 1
+	ttlsLengthLength        = 4
+	ttlsMaxFragment         = 1024
+	ttlsMaxMessageLen       = 64 * 1024
+)
+
+var errMalformedEAP = errors.New("malformed eap packet")
+
+type eapPacket struct {
+	code       uint8
+	identifier uint8
+	typ        uint8
+	data       []byte
+}
+
+func parseEAP(b []byte) (*eapPacket, error) {
+	if len(b) < 4 {
+		return nil, errMalformedEAP
+	}
+	length := int(binary.BigEndian.Uint16(b[2:4]))
+	if length < 4 || length > len(b) {
+		return nil, errMalformedEAP
+	}
+	p := &eapPacket{
+		code:       b[0],
+		identifier: b[1],
+	}
+	if p.code == eapCodeRequest || p.code == eapCodeResponse {
+		if length < 5 {
+			return nil, errMalformedEAP
+		}
+		p.typ = b[4]
+		p.data = b[5:length]
+	}
+	return p, nil
+}
+
+func (p *eapPacket) encode() []byte {
+	length := 4
+	if p.code == eapCodeRequest || p.code == eapCodeResponse {
+		length += 1 + len(p.data)
+	}
+	b := make([]byte, 4, length)
+	b[0] = p.code
+	b[1] = p.identifier
+	binary.BigEndian.PutUint16(b[2:4], uint16(length))
+	if p.code == eapCodeRequest || p.code == eapCodeResponse {
+		b = append(b, p.typ)
+		b = append(b, p.data...)
+	}
+	return b
+}
+
+// ttlsMessage is the data of an EAP-TTLS packet (RFC 5281, section 9.1).
+type ttlsMessage struct {
+	flags uint8
+	// length is the total length of a fragmented message
+	length uint32
+	data   []byte
+}
+
+func parseTTLS(b []byte) (*ttlsMessage, error) {
+	if len(b) < ttlsHeaderLength {
+		return nil, errMalformedEAP
+	}
+	m := &ttlsMessage{flags: b[0]}
+	b = b[ttlsHeaderLength:]
+	if m.flags&ttlsFlagLength != 0 {
+		if len(b) < ttlsLengthLength {
+			return nil, errMalformedEAP
+		}
+		m.length = binary.BigEndian.Uint32(b)
+		b = b[ttlsLengthLength:]
+	}
+	m.data = b
+	return m, nil
+}
+
+func (m *ttlsMessage) encode() []byte {
+	b := []byte{m.flags}
+	if m.flags&ttlsFlagLength != 0 {
+		b = binary.BigEndian.AppendUint32(b, m.length)
+	}
+	return append(b, m.data...)
+}
+
+// Diameter AVPs used as inner attributes of EAP-TTLS (RFC 5281, section 10.1)
+const (
+	avpFlagVendor    uint8 = 0x80
+	avpFlagMandatory uint8 = 0x40
+	avpHeaderLength        = 8
+)
+
+var errMalformedAVP = errors.New("malformed diameter avp")
+
+type avp struct {
+	code     uint32
+	flags    uint8
+	vendorID uint32
+	data     []byte
+}
+
+func parseAVPs(b []byte) ([]*avp, error) {
+	var avps []*avp
+	for len(b) > 0 {
+		if len(b) < avpHeaderLength {
+			return nil, errMalformedAVP
+		}
+		a := &avp{
+			code:  binary.BigEndian.Uint32(b),
+			flags: b[4],
+		}
+		length := int(b[5])<<16 | int(b[6])<<8 | int(b[7])
+		headerLength := avpHeaderLength
+		if a.flags&avpFlagVendor != 0 {
+			headerLength += 4
+		}
+		if length < headerLength || length > len(b) {
+			return nil, errMalformedAVP
+		}
+		if a.flags&avpFlagVendor != 0 {
+			a.vendorID = binary.BigEndian.Uint32(b[8:12])
+		}
+		a.data = b[headerLength:length]
+		avps = append(avps, a)
+		// AVPs are padded to a multiple of 4 octets
+		padded := (length + 3) &^ 3
+		if padded > len(b) {
+			padded = len(b)
+		}
+		b = b[padded:]
+	}
+	return avps, nil
+}
+
+func encodeAVP(code uint32, data []byte) []byte {
+	length := avpHeaderLength + len(data)
+	b := binary.BigEndian.AppendUint32(nil, code)
+	b = append(b, avpFlagMandatory, uint8(length>>16), uint8(length>>8), uint8(length))
+	b = append(b, data...)
+	for len(b)%4 != 0 {
+		b = append(b, 0)
+	}
+	return b
+}
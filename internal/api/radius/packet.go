@@ -0,0 +1,159 @@
+package radius
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// packet codes (RFC 2865, section 3 and RFC 5997)
+const (
+	codeAccessRequest   uint8 = 1
+	codeAccessAccept    uint8 = 2
+	codeAccessReject    uint8 = 3
+	codeAccessChallenge uint8 = 11
+	codeStatusServer    uint8 = 12
+)
+
+// attribute types (RFC 2865, section 5 and RFC 3579, section 3)
+const (
+	attributeUserName             uint8 = 1
+	attributeUserPassword         uint8 = 2
+	attributeCHAPPassword         uint8 = 3
+	attributeReplyMessage         uint8 = 18
+	attributeState                uint8 = 24
+	attributeVendorSpecific       uint8 = 26
+	attributeNASIdentifier        uint8 = 32
+	attributeProxyState           uint8 = 33
+	attributeEAPMessage           uint8 = 79
+	attributeMessageAuthenticator uint8 = 80
+)
+
+const (
+	headerLength            = 20
+	authenticatorLength     = 16
+	maxPacketLength         = 4096
+	maxAttributeValueLength = 253
+)
+
+var (
+	errMalformedPacket = errors.New("malformed radius packet")
+	errPacketTooLarge  = errors.New("radius packet exceeds the maximum length")
+)
+
+type attribute struct {
+	typ   uint8
+	value []byte
+}
+
+type packet struct {
+	code          uint8
+	identifier    uint8
+	authenticator [authenticatorLength]byte
+	attributes    []attribute
+}
+
+// parsePacket decodes a packet, octets after the length of the packet are ignored as padding.
+func parsePacket(b []byte) (*packet, error) {
+	if len(b) < headerLength {
+		return nil, errMalformedPacket
+	}
+	length := int(binary.BigEndian.Uint16(b[2:4]))
+	if length < headerLength || length > maxPacketLength || length > len(b) {
+		return nil, errMalformedPacket
+	}
+	p := &packet{
+		code:       b[0],
+		identifier: b[1],
+	}
+	copy(p.authenticator[:], b[4:headerLength])
+	for data := b[headerLength:length]; len(data) > 0; {
+		if len(data) < 2 || int(data[1]) < 2 || int(data[1]) > len(data) {
+			return nil, errMalformedPacket
+		}
+		p.attributes = append(p.attributes, attribute{typ: data[0], value: data[2:data[1]]})
+		data = data[data[1]:]
+	}
+	return p, nil
+}
+
+// encode returns the wire format of the packet with the authenticator as is.
+func (p *packet) encode() ([]byte, error) {
+	length := headerLength
+	for _, attr := range p.attributes {
+		if len(attr.value) > maxAttributeValueLength {
+			return nil, errMalformedPacket
+		}
+		length += 2 + len(attr.value)
+	}
+	if length > maxPacketLength {
+		return nil, errPacketTooLarge
+	}
+	b := make([]byte, headerLength, length)
+	b[0] = p.code
+	b[1] = p.identifier
+	binary.BigEndian.PutUint16(b[2:4], uint16(length))
+	copy(b[4:headerLength], p.authenticator[:])
+	for _, attr := range p.attributes {
+		b = append(b, attr.typ, uint8(2+len(attr.value)))
+		b = append(b, attr.value...)
+	}
+	return b, nil
+}
+
+// get returns the value of the first attribute of the type.
+func (p *packet) get(typ uint8) ([]byte, bool) {
+	for _, attr := range p.attributes {
+		if attr.typ == typ {
+			return attr.value, true
+		}
+	}
+	return nil, false
+}
+
+// concat returns the concatenated values of all attributes of the type,
+// which is used for values split into multiple attributes like EAP-Message.
+func (p *packet) concat(typ uint8) []byte {
+	var value []byte
+	for _, attr := range p.attributes {
+		if attr.typ == typ {
+			value = append(value, attr.value...)
+		}
+	}
+	return value
+}
+
+func (p *packet) add(typ uint8, value []byte) {
+	p.attributes = append(p.attributes, attribute{typ: typ, value: value})
+}
+
+// addSplit adds the value in as many attributes of the type as needed.
+func (p *packet) addSplit(typ uint8, value []byte) {
+	for len(value) > maxAttributeValueLength {
+		p.add(typ, value[:maxAttributeValueLength])
+		value = value[maxAttributeValueLength:]
+	}
+	p.add(typ, value)
+}
+
+// addVendorSpecific adds a Vendor-Specific attribute with a single sub-attribute (RFC 2865, section 5.26).
+func (p *packet) addVendorSpecific(vendorID uint32, vendorType uint8, value []byte) {
+	b := make([]byte, 6, 6+len(value))
+	binary.BigEndian.PutUint32(b, vendorID)
+	b[4] = vendorType
+	b[5] = uint8(2 + len(value))
+	p.add(attributeVendorSpecific, append(b, value...))
+}
+
+// newResponse creates the response to the request including its Proxy-State attributes (RFC 2865, section 5.33).
+func (p *packet) newResponse(code uint8) *packet {
+	response := &packet{
+		code:       code,
+		identifier: p.identifier,
+	}
+	for _, attr := range p.attributes {
+		if attr.typ == attributeProxyState {
+			response.add(attr.typ, attr.value)
+		}
+	}
+	return response
+}
@@ -0,0 +1,215 @@
+package radius
+
+import (
+	"crypto/md5" //nolint:gosec
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// encryptPassword hides the password like a network access server (RFC 2865, section 5.2).
+func encryptPassword(secret []byte, requestAuthenticator [authenticatorLength]byte, password []byte) []byte {
+	padded := append([]byte{}, password...)
+	if len(padded) == 0 || len(padded)%16 != 0 {
+		padded = append(padded, make([]byte, 16-len(padded)%16)...)
+	}
+	encrypted := make([]byte, len(padded))
+	previous := requestAuthenticator[:]
+	for i := 0; i < len(padded); i += 16 {
+		b := md5.Sum(append(append([]byte{}, secret...), previous...)) //nolint:gosec
+		for j := 0; j < 16; j++ {
+			encrypted[i+j] = padded[i+j] ^ b[j]
+		}
+		previous = encrypted[i : i+16]
+	}
+	return encrypted
+}
+
+// decryptMPPEKey reverses [encryptMPPEKey] like a network access server.
+func decryptMPPEKey(secret []byte, requestAuthenticator [authenticatorLength]byte, value []byte) []byte {
+	salt, encrypted := value[:2], value[2:]
+	plain := make([]byte, len(encrypted))
+	previous := append(requestAuthenticator[:], salt...)
+	for i := 0; i < len(encrypted); i += 16 {
+		b := md5.Sum(append(append([]byte{}, secret...), previous...)) //nolint:gosec
+		for j := 0; j < 16; j++ {
+			plain[i+j] = encrypted[i+j] ^ b[j]
+		}
+		previous = encrypted[i : i+16]
+	}
+	return plain[1 : 1+plain[0]]
+}
+
+func TestParsePacket(t *testing.T) {
+	tests := []struct {
+		name    string
+		b       []byte
+		want    *packet
+		wantErr error
+	}{
+		{
+			name:    "too short",
+			b:       make([]byte, headerLength-1),
+			wantErr: errMalformedPacket,
+		},
+		{
+			name:    "length exceeds data",
+			b:       append([]byte{1, 1, 0, 30}, make([]byte, 16)...),
+			wantErr: errMalformedPacket,
+		},
+		{
+			name:    "attribute exceeds packet",
+			b:       append(append([]byte{1, 1, 0, 23}, make([]byte, 16)...), 1, 5, 'a'),
+			wantErr: errMalformedPacket,
+		},
+		{
+			name:    "attribute length too small",
+			b:       append(append([]byte{1, 1, 0, 22}, make([]byte, 16)...), 1, 1),
+			wantErr: errMalformedPacket,
+		},
+		{
+			name: "padding ignored",
+			b:    append(append([]byte{1, 7, 0, 25}, make([]byte, 16)...), 1, 5, 'a', 'b', 'c', 0, 0),
+			want: &packet{
+				code:       codeAccessRequest,
+				identifier: 7,
+				attributes: []attribute{{typ: attributeUserName, value: []byte("abc")}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePacket(tt.b)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPacket_encode(t *testing.T) {
+	p := &packet{code: codeAccessRequest, identifier: 3, authenticator: [authenticatorLength]byte{1, 2, 3}}
+	p.add(attributeUserName, []byte("alice"))
+	p.addSplit(attributeEAPMessage, make([]byte, 300))
+	p.addVendorSpecific(vendorMicrosoft, vendorTypeMPPERecvKey, []byte{1, 2})
+
+	b, err := p.encode()
+	require.NoError(t, err)
+	got, err := parsePacket(b)
+	require.NoError(t, err)
+	assert.Equal(t, p, got)
+	assert.Len(t, got.attributes, 4)
+	assert.Len(t, got.concat(attributeEAPMessage), 300)
+	vsa, _ := got.get(attributeVendorSpecific)
+	assert.Equal(t, []byte{0, 0, 1, 55, vendorTypeMPPERecvKey, 4, 1, 2}, vsa)
+
+	p.add(attributeReplyMessage, make([]byte, maxAttributeValueLength+1))
+	_, err = p.encode()
+	assert.ErrorIs(t, err, errMalformedPacket)
+}
+
+func TestPacket_newResponse(t *testing.T) {
+	request := &packet{code: codeAccessRequest, identifier: 9}
+	request.add(attributeUserName, []byte("alice"))
+	request.add(attributeProxyState, []byte("proxy-1"))
+	request.add(attributeProxyState, []byte("proxy-2"))
+
+	response := request.newResponse(codeAccessReject)
+	assert.Equal(t, codeAccessReject, response.code)
+	assert.Equal(t, uint8(9), response.identifier)
+	assert.Equal(t, []attribute{
+		{typ: attributeProxyState, value: []byte("proxy-1")},
+		{typ: attributeProxyState, value: []byte("proxy-2")},
+	}, response.attributes)
+}
+
+func TestDecryptPassword(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	authenticator := [authenticatorLength]byte{0xde, 0xad, 0xbe, 0xef}
+	tests := []struct {
+		name     string
+		password string
+	}{
+		{name: "single block", password: "Password1!"},
+		{name: "exact block", password: "0123456789abcdef"},
+		{name: "multiple blocks", password: "a rather long password with several blocks"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decryptPassword(secret, authenticator, encryptPassword(secret, authenticator, []byte(tt.password)))
+			require.NoError(t, err)
+			assert.Equal(t, tt.password, string(got))
+		})
+	}
+	t.Run("invalid length", func(t *testing.T) {
+		_, err := decryptPassword(secret, authenticator, make([]byte, 17))
+		assert.ErrorIs(t, err, errInvalidPassword)
+	})
+}
+
+func TestMPPEKey(t *testing.T) {
+	secret := []byte("0123456789abcdef")
+	authenticator := [authenticatorLength]byte{1}
+	key := []byte("0123456789abcdef0123456789abcdef")
+
+	encrypted := encryptMPPEKey(secret, authenticator, [2]byte{0x80, 1}, key)
+	assert.Len(t, encrypted, 2+48)
+	assert.Equal(t, key, decryptMPPEKey(secret, authenticator, encrypted))
+}
+
+func TestParseAVPs(t *testing.T) {
+	b := encodeAVP(avpUserName, []byte("alice"))
+	b = append(b, encodeAVP(avpUserPassword, []byte("secret\x00\x00"))...)
+	// vendor specific AVP without mandatory flag
+	b = append(b, 0, 0, 0, 1, avpFlagVendor, 0, 0, 14, 0, 0, 1, 55, 'x', 'y', 0, 0)
+
+	avps, err := parseAVPs(b)
+	require.NoError(t, err)
+	require.Len(t, avps, 3)
+	assert.Equal(t, &avp{code: avpUserName, flags: avpFlagMandatory, data: []byte("alice")}, avps[0])
+	assert.Equal(t, &avp{code: 1, flags: avpFlagVendor, vendorID: vendorMicrosoft, data: []byte("xy")}, avps[2])
+
+	username, password, err := innerCredentials(b)
+	require.NoError(t, err)
+	assert.Equal(t, "alice", username)
+	assert.Equal(t, "secret", password)
+
+	_, err = parseAVPs(b[:10])
+	assert.ErrorIs(t, err, errMalformedAVP)
+
+	_, _, err = innerCredentials(append(encodeAVP(avpUserPassword, []byte("secret")), encodeAVP(100, nil)...))
+	assert.ErrorIs(t, err, errMandatoryAVP)
+
+	_, _, err = innerCredentials(encodeAVP(avpUserName, []byte("alice")))
+	assert.ErrorIs(t, err, errMissingCredentials)
+}
+
+func TestEAPSession_nextFragment(t *testing.T) {
+	data := make([]byte, 2*ttlsMaxFragment+10)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	s := new(eapSession)
+
+	first, err := parseTTLS(s.send(data))
+	require.NoError(t, err)
+	assert.Equal(t, ttlsFlagLength|ttlsFlagMore, first.flags)
+	assert.Equal(t, uint32(len(data)), first.length)
+	assert.True(t, s.fragmenting)
+
+	second, err := parseTTLS(s.nextFragment())
+	require.NoError(t, err)
+	assert.Equal(t, ttlsFlagMore, second.flags)
+
+	last, err := parseTTLS(s.nextFragment())
+	require.NoError(t, err)
+	assert.Equal(t, uint8(0), last.flags)
+	assert.False(t, s.fragmenting)
+
+	assert.Equal(t, data, append(append(first.data, second.data...), last.data...))
+
+	single, err := parseTTLS(s.send([]byte("records")))
+	require.NoError(t, err)
+	assert.Equal(t, &ttlsMessage{data: []byte("records")}, single)
+	assert.False(t, s.fragmenting)
+}
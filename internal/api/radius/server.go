@@ -0,0 +1,215 @@
+package radius
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// duplicateDetectionTime is the time retransmissions of a request are answered from the cache
+	duplicateDetectionTime = 30 * time.Second
+	cleanupInterval        = 10 * time.Second
+	defaultChallengeTTL    = 2 * time.Minute
+)
+
+// Server is a RADIUS server (RFC 2865) authenticating the users of an instance for network access servers
+// like VPN gateways or wireless access points.
+// Passwords are verified with PAP or inside an EAP-TTLS tunnel, users with an authenticator app
+// are challenged for the code.
+type Server struct {
+	config    Config
+	backend   backend
+	tlsConfig *tls.Config
+	sessions  *sessions
+	cache     *responseCache
+}
+
+func NewServer(config Config, commands *command.Commands, queries *query.Queries) (*Server, error) {
+	tlsConfig, err := config.TLS.Config()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "RADIUS-Kd8vh", "invalid tls config")
+	}
+	return newServer(config, &zitadel{commands: commands, queries: queries}, tlsConfig), nil
+}
+
+func newServer(config Config, backend backend, tlsConfig *tls.Config) *Server {
+	if tlsConfig != nil {
+		tlsConfig = tlsConfig.Clone()
+		// EAP-TTLS keying material is only defined up to TLS 1.2 (RFC 5281 and RFC 9427)
+		tlsConfig.MinVersion = tls.VersionTLS12
+		tlsConfig.MaxVersion = tls.VersionTLS12
+		tlsConfig.SessionTicketsDisabled = true
+	}
+	ttl := config.ChallengeTimeout
+	if ttl <= 0 {
+		ttl = defaultChallengeTTL
+	}
+	return &Server{
+		config:    config,
+		backend:   backend,
+		tlsConfig: tlsConfig,
+		sessions:  newSessions(ttl),
+		cache:     newResponseCache(duplicateDetectionTime),
+	}
+}
+
+// Start listens on the configured port until the context is done.
+func Start(ctx context.Context, config Config, commands *command.Commands, queries *query.Queries) error {
+	if !config.Enabled {
+		return nil
+	}
+	server, err := NewServer(config, commands, queries)
+	if err != nil {
+		return err
+	}
+	conn, err := new(net.ListenConfig).ListenPacket(ctx, "udp", fmt.Sprintf(":%d", config.Port))
+	if err != nil {
+		return fmt.Errorf("radius listener on %d failed: %w", config.Port, err)
+	}
+	logging.WithFields("address", conn.LocalAddr().String()).Info("radius server is listening")
+	go server.Serve(ctx, conn)
+	return nil
+}
+
+// Serve handles the requests received on the connection until the context is done.
+func (s *Server) Serve(ctx context.Context, conn net.PacketConn) {
+	go func() {
+		ticker := time.NewTicker(cleanupInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				logging.OnError(conn.Close()).Debug("unable to close radius listener")
+				return
+			case now := <-ticker.C:
+				s.sessions.cleanup(now)
+				s.cache.cleanup(now)
+			}
+		}
+	}()
+	buf := make([]byte, maxPacketLength)
+	for {
+		n, addr, err := conn.ReadFrom(buf)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return
+			}
+			logging.WithError(err).Warn("radius read failed")
+			continue
+		}
+		udpAddr, ok := addr.(*net.UDPAddr)
+		if !ok {
+			continue
+		}
+		b := make([]byte, n)
+		copy(b, buf[:n])
+		go s.handle(ctx, conn, udpAddr, b)
+	}
+}
+
+func (s *Server) handle(ctx context.Context, conn net.PacketConn, addr *net.UDPAddr, b []byte) {
+	request, err := parsePacket(b)
+	if err != nil {
+		logging.WithFields("address", addr.String()).WithError(err).Debug("invalid radius packet")
+		return
+	}
+	if request.code != codeAccessRequest && request.code != codeStatusServer {
+		return
+	}
+	addrPort := addr.AddrPort()
+	key := requestKey{
+		addr:          netip.AddrPortFrom(addrPort.Addr().Unmap(), addrPort.Port()),
+		identifier:    request.identifier,
+		authenticator: request.authenticator,
+	}
+	response, ok := s.cache.start(key)
+	if ok {
+		response = s.process(ctx, key.addr.Addr(), request)
+		s.cache.finish(key, response)
+	}
+	if response == nil {
+		return
+	}
+	if _, err = conn.WriteTo(response, addr); err != nil {
+		logging.WithFields("address", addr.String()).WithError(err).Warn("radius write failed")
+	}
+}
+
+// process returns the signed response to the request, or nil if the request is silently discarded.
+func (s *Server) process(ctx context.Context, addr netip.Addr, request *packet) []byte {
+	candidates, err := s.backend.clients(ctx, addr)
+	if err != nil {
+		logging.WithFields("address", addr.String()).WithError(err).Warn("unable to get radius clients")
+		return nil
+	}
+	client := s.selectClient(candidates, request)
+	if client == nil {
+		logging.WithFields("address", addr.String()).Debug("radius request of unknown client discarded")
+		return nil
+	}
+	var response *packet
+	switch request.code {
+	case codeStatusServer:
+		// RFC 5997, section 3
+		response = request.newResponse(codeAccessAccept)
+	default:
+		response = s.access(ctx, client, request)
+	}
+	if response == nil {
+		return nil
+	}
+	b, err := response.encodeResponse(client.secret, request.authenticator)
+	if err != nil {
+		logging.WithFields("client", client.id).WithError(err).Warn("unable to encode radius response")
+		return nil
+	}
+	return b
+}
+
+// selectClient returns the client which sent the request.
+// Clients registered with the NAS-Identifier of the request are preferred over clients without identifier,
+// multiple clients of the same network are told apart by the Message-Authenticator signed with their secret.
+func (s *Server) selectClient(candidates []*client, request *packet) *client {
+	nasIdentifier, _ := request.get(attributeNASIdentifier)
+	var matching []*client
+	for _, c := range candidates {
+		if c.nasIdentifier != "" && c.nasIdentifier == string(nasIdentifier) {
+			matching = append(matching, c)
+		}
+	}
+	if len(matching) == 0 {
+		for _, c := range candidates {
+			if c.nasIdentifier == "" {
+				matching = append(matching, c)
+			}
+		}
+	}
+	_, eap := request.get(attributeEAPMessage)
+	// the attribute is mandatory for EAP (RFC 3579, section 3.2) and Status-Server (RFC 5997, section 3)
+	required := s.config.RequireMessageAuthenticator || eap || request.code == codeStatusServer
+	for _, c := range matching {
+		present, valid := verifyMessageAuthenticator(c.secret, request)
+		if !present {
+			// without signature the client can't be told apart from others of the same network
+			if required || len(matching) > 1 {
+				return nil
+			}
+			return c
+		}
+		if valid {
+			return c
+		}
+	}
+	return nil
+}
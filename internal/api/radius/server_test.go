@@ -0,0 +1,624 @@
+package radius
+
+import (
+	"context"
+	"crypto/md5" //nolint:gosec
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"net"
+	"net/netip"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	testSecret = "0123456789abcdef"
+	testOTP    = "123456"
+)
+
+type fakeUser struct {
+	password string
+	otp      bool
+	// roles are granted on the project of the client, the user is not granted if nil
+	roles []string
+}
+
+// fakeBackend replaces the commands and queries, all users belong to the same instance.
+type fakeBackend struct {
+	clientList     []*client
+	users          map[string]fakeUser
+	passwordChecks atomic.Int32
+}
+
+func (b *fakeBackend) clients(_ context.Context, _ netip.Addr) ([]*client, error) {
+	return b.clientList, nil
+}
+
+func (b *fakeBackend) checkPassword(_ context.Context, client *client, username, password string) (*identity, error) {
+	b.passwordChecks.Add(1)
+	user, ok := b.users[username]
+	if !ok || user.password != password {
+		return nil, errors.New("invalid credentials")
+	}
+	return &identity{
+		instanceID: client.instanceID,
+		orgID:      "org1",
+		userID:     username,
+		loginName:  username + "@acme.com",
+		otp:        user.otp,
+	}, nil
+}
+
+func (b *fakeBackend) checkOTP(_ context.Context, identity *identity, code string) error {
+	if !b.users[identity.userID].otp || code != testOTP {
+		return errors.New("invalid code")
+	}
+	return nil
+}
+
+func (b *fakeBackend) grant(_ context.Context, _ *client, identity *identity) (bool, []string, error) {
+	roles := b.users[identity.userID].roles
+	return roles != nil, roles, nil
+}
+
+func newTestBackend(clients ...*client) *fakeBackend {
+	if len(clients) == 0 {
+		clients = []*client{{id: "client1", instanceID: "instance1", secret: []byte(testSecret)}}
+	}
+	return &fakeBackend{
+		clientList: clients,
+		users: map[string]fakeUser{
+			"alice": {password: "Password1!", roles: []string{"admin", "user"}},
+			"bob":   {password: "Password1!", otp: true, roles: []string{}},
+			"carol": {password: "Password1!"},
+		},
+	}
+}
+
+// startTestServer serves the backend on a loopback address and returns the address.
+func startTestServer(t *testing.T, config Config, backend backend, tlsConfig *tls.Config) net.Addr {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	go newServer(config, backend, tlsConfig).Serve(ctx, conn)
+	return conn.LocalAddr()
+}
+
+// testClient is a network access server sending requests to the server.
+type testClient struct {
+	t                    *testing.T
+	conn                 net.Conn
+	secret               []byte
+	messageAuthenticator bool
+	identifier           uint8
+}
+
+func newTestClient(t *testing.T, addr net.Addr) *testClient {
+	t.Helper()
+	conn, err := net.Dial("udp", addr.String())
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return &testClient{t: t, conn: conn, secret: []byte(testSecret), messageAuthenticator: true}
+}
+
+// newRequest creates a request with a random authenticator.
+func (c *testClient) newRequest(code uint8) *packet {
+	c.identifier++
+	p := &packet{code: code, identifier: c.identifier}
+	_, err := rand.Read(p.authenticator[:])
+	require.NoError(c.t, err)
+	return p
+}
+
+func (c *testClient) encode(p *packet) []byte {
+	if c.messageAuthenticator {
+		p.attributes = append([]attribute{{typ: attributeMessageAuthenticator, value: make([]byte, md5.Size)}}, p.attributes...)
+	}
+	b, err := p.encode()
+	require.NoError(c.t, err)
+	if c.messageAuthenticator {
+		copy(b[headerLength+2:], messageAuthenticator(c.secret, b))
+	}
+	return b
+}
+
+// send sends the encoded request and returns the verified response, or nil if the request was discarded.
+func (c *testClient) send(request *packet, b []byte) *packet {
+	_, err := c.conn.Write(b)
+	require.NoError(c.t, err)
+	require.NoError(c.t, c.conn.SetReadDeadline(time.Now().Add(500*time.Millisecond)))
+	buf := make([]byte, maxPacketLength)
+	n, err := c.conn.Read(buf)
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return nil
+	}
+	require.NoError(c.t, err)
+	response, err := parsePacket(buf[:n])
+	require.NoError(c.t, err)
+	require.Equal(c.t, request.identifier, response.identifier)
+
+	// ResponseAuth = MD5(Code+ID+Length+RequestAuth+Attributes+Secret)
+	signed := append([]byte{}, buf[:n]...)
+	copy(signed[4:headerLength], request.authenticator[:])
+	hash := md5.Sum(append(signed, c.secret...)) //nolint:gosec
+	require.Equal(c.t, hash[:], response.authenticator[:], "response authenticator")
+	unsigned := *response
+	unsigned.authenticator = request.authenticator
+	present, valid := verifyMessageAuthenticator(c.secret, &unsigned)
+	require.True(c.t, present && valid, "response message authenticator")
+	return response
+}
+
+func (c *testClient) exchange(request *packet) *packet {
+	return c.send(request, c.encode(request))
+}
+
+func (c *testClient) pap(username, password string, attributes ...attribute) (*packet, *packet) {
+	request := c.newRequest(codeAccessRequest)
+	request.add(attributeUserName, []byte(username))
+	request.add(attributeUserPassword, encryptPassword(c.secret, request.authenticator, []byte(password)))
+	request.attributes = append(request.attributes, attributes...)
+	return request, c.exchange(request)
+}
+
+func TestServer_PAP(t *testing.T) {
+	roleAttributes := []*domain.RADIUSRoleAttribute{
+		{Role: "admin", Type: 25, Value: "admins"},
+		{Role: "user", Type: 11, Format: domain.RADIUSAttributeFormatInteger, Value: "42"},
+		{Role: "admin", Type: domain.RADIUSAttributeTypeVendorSpecific, VendorID: 9, VendorType: 1},
+		{Role: "guest", Type: 25, Value: "guests"},
+	}
+	tests := []struct {
+		name           string
+		client         *client
+		username       string
+		password       string
+		wantCode       uint8
+		wantAttributes []attribute
+	}{
+		{
+			name:     "accepted",
+			client:   &client{id: "client1", secret: []byte(testSecret)},
+			username: "alice",
+			password: "Password1!",
+			wantCode: codeAccessAccept,
+			wantAttributes: []attribute{
+				{typ: attributeProxyState, value: []byte("proxy")},
+				{typ: attributeUserName, value: []byte("alice@acme.com")},
+			},
+		},
+		{
+			name:     "accepted with role attributes",
+			client:   &client{id: "client1", secret: []byte(testSecret), projectID: "project1", roleAttributes: roleAttributes},
+			username: "alice",
+			password: "Password1!",
+			wantCode: codeAccessAccept,
+			wantAttributes: []attribute{
+				{typ: attributeProxyState, value: []byte("proxy")},
+				{typ: attributeUserName, value: []byte("alice@acme.com")},
+				{typ: 25, value: []byte("admins")},
+				{typ: 11, value: []byte{0, 0, 0, 42}},
+				{typ: attributeVendorSpecific, value: []byte{0, 0, 0, 9, 1, 7, 'a', 'd', 'm', 'i', 'n'}},
+			},
+		},
+		{
+			name:     "wrong password",
+			client:   &client{id: "client1", secret: []byte(testSecret)},
+			username: "alice",
+			password: "wrong",
+			wantCode: codeAccessReject,
+			wantAttributes: []attribute{
+				{typ: attributeProxyState, value: []byte("proxy")},
+			},
+		},
+		{
+			name:     "grant required",
+			client:   &client{id: "client1", secret: []byte(testSecret), projectID: "project1", requireGrant: true},
+			username: "carol",
+			password: "Password1!",
+			wantCode: codeAccessReject,
+			wantAttributes: []attribute{
+				{typ: attributeProxyState, value: []byte("proxy")},
+			},
+		},
+		{
+			name:     "grant not required",
+			client:   &client{id: "client1", secret: []byte(testSecret), projectID: "project1", roleAttributes: roleAttributes},
+			username: "carol",
+			password: "Password1!",
+			wantCode: codeAccessAccept,
+			wantAttributes: []attribute{
+				{typ: attributeProxyState, value: []byte("proxy")},
+				{typ: attributeUserName, value: []byte("carol@acme.com")},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startTestServer(t, Config{RequireMessageAuthenticator: true}, newTestBackend(tt.client), nil)
+			client := newTestClient(t, addr)
+
+			_, response := client.pap(tt.username, tt.password, attribute{typ: attributeProxyState, value: []byte("proxy")})
+			require.NotNil(t, response)
+			assert.Equal(t, tt.wantCode, response.code)
+			// the first attribute is the Message-Authenticator
+			assert.Equal(t, tt.wantAttributes, response.attributes[1:])
+		})
+	}
+}
+
+func TestServer_PAP_CHAP(t *testing.T) {
+	client := newTestClient(t, startTestServer(t, Config{}, newTestBackend(), nil))
+	request := client.newRequest(codeAccessRequest)
+	request.add(attributeUserName, []byte("alice"))
+	request.add(attributeCHAPPassword, make([]byte, 17))
+	response := client.exchange(request)
+	require.NotNil(t, response)
+	assert.Equal(t, codeAccessReject, response.code)
+}
+
+func TestServer_PAP_OTP(t *testing.T) {
+	client := newTestClient(t, startTestServer(t, Config{}, newTestBackend(), nil))
+
+	_, challenge := client.pap("bob", "Password1!")
+	require.NotNil(t, challenge)
+	require.Equal(t, codeAccessChallenge, challenge.code)
+	message, _ := challenge.get(attributeReplyMessage)
+	assert.Equal(t, otpPrompt, string(message))
+	state, ok := challenge.get(attributeState)
+	require.True(t, ok)
+
+	_, response := client.pap("bob", "000000", attribute{typ: attributeState, value: state})
+	require.NotNil(t, response)
+	assert.Equal(t, codeAccessReject, response.code)
+	// the session is removed after the first answer
+	_, response = client.pap("bob", testOTP, attribute{typ: attributeState, value: state})
+	require.NotNil(t, response)
+	assert.Equal(t, codeAccessReject, response.code)
+
+	_, challenge = client.pap("bob", "Password1!")
+	require.NotNil(t, challenge)
+	state, _ = challenge.get(attributeState)
+	_, response = client.pap("alice", testOTP, attribute{typ: attributeState, value: state})
+	require.NotNil(t, response)
+	assert.Equal(t, codeAccessReject, response.code, "other user")
+
+	_, challenge = client.pap("bob", "Password1!")
+	require.NotNil(t, challenge)
+	state, _ = challenge.get(attributeState)
+	_, response = client.pap("bob", testOTP, attribute{typ: attributeState, value: state})
+	require.NotNil(t, response)
+	assert.Equal(t, codeAccessAccept, response.code)
+}
+
+func TestServer_messageAuthenticator(t *testing.T) {
+	t.Run("missing", func(t *testing.T) {
+		client := newTestClient(t, startTestServer(t, Config{RequireMessageAuthenticator: true}, newTestBackend(), nil))
+		client.messageAuthenticator = false
+		_, response := client.pap("alice", "Password1!")
+		assert.Nil(t, response)
+	})
+	t.Run("not required", func(t *testing.T) {
+		client := newTestClient(t, startTestServer(t, Config{}, newTestBackend(), nil))
+		client.messageAuthenticator = false
+		_, response := client.pap("alice", "Password1!")
+		require.NotNil(t, response)
+		assert.Equal(t, codeAccessAccept, response.code)
+	})
+	t.Run("invalid", func(t *testing.T) {
+		client := newTestClient(t, startTestServer(t, Config{}, newTestBackend(), nil))
+		request := client.newRequest(codeAccessRequest)
+		request.add(attributeUserName, []byte("alice"))
+		request.add(attributeUserPassword, encryptPassword(client.secret, request.authenticator, []byte("Password1!")))
+		b := client.encode(request)
+		b[headerLength+2] ^= 0xff
+		assert.Nil(t, client.send(request, b))
+	})
+}
+
+func TestServer_selectClient(t *testing.T) {
+	other := &client{id: "other", secret: []byte("fedcba9876543210")}
+	tests := []struct {
+		name          string
+		clients       []*client
+		nasIdentifier string
+		wantResponse  bool
+	}{
+		{
+			name:          "unknown nas identifier",
+			clients:       []*client{{id: "client1", nasIdentifier: "nas1", secret: []byte(testSecret)}},
+			nasIdentifier: "nas2",
+		},
+		{
+			name:          "nas identifier",
+			clients:       []*client{other, {id: "client1", nasIdentifier: "nas1", secret: []byte(testSecret)}},
+			nasIdentifier: "nas1",
+			wantResponse:  true,
+		},
+		{
+			name:         "secret",
+			clients:      []*client{other, {id: "client1", secret: []byte(testSecret)}},
+			wantResponse: true,
+		},
+		{
+			name:    "no client",
+			clients: []*client{other},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := newTestClient(t, startTestServer(t, Config{}, newTestBackend(tt.clients...), nil))
+			var attributes []attribute
+			if tt.nasIdentifier != "" {
+				attributes = append(attributes, attribute{typ: attributeNASIdentifier, value: []byte(tt.nasIdentifier)})
+			}
+			_, response := client.pap("alice", "Password1!", attributes...)
+			if !tt.wantResponse {
+				assert.Nil(t, response)
+				return
+			}
+			require.NotNil(t, response)
+			assert.Equal(t, codeAccessAccept, response.code)
+		})
+	}
+}
+
+func TestServer_duplicateRequest(t *testing.T) {
+	backend := newTestBackend()
+	client := newTestClient(t, startTestServer(t, Config{}, backend, nil))
+	request := client.newRequest(codeAccessRequest)
+	request.add(attributeUserName, []byte("alice"))
+	request.add(attributeUserPassword, encryptPassword(client.secret, request.authenticator, []byte("Password1!")))
+	b := client.encode(request)
+
+	first := client.send(request, b)
+	second := client.send(request, b)
+	require.NotNil(t, first)
+	assert.Equal(t, first, second)
+	assert.Equal(t, int32(1), backend.passwordChecks.Load())
+}
+
+func TestServer_statusServer(t *testing.T) {
+	client := newTestClient(t, startTestServer(t, Config{}, newTestBackend(), nil))
+	response := client.exchange(client.newRequest(codeStatusServer))
+	require.NotNil(t, response)
+	assert.Equal(t, codeAccessAccept, response.code)
+
+	client.messageAuthenticator = false
+	assert.Nil(t, client.exchange(client.newRequest(codeStatusServer)))
+}
+
+func newTestTLSConfig(t *testing.T) (server, client *tls.Config) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "radius.acme.com"},
+		// many names to enforce the fragmentation of the handshake
+		DNSNames:    []string{"radius.acme.com", "radius1.acme.com", "radius2.acme.com", "radius3.acme.com", "radius4.acme.com", "radius5.acme.com"},
+		NotBefore:   time.Now().Add(-time.Hour),
+		NotAfter:    time.Now().Add(time.Hour),
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(cert)
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}},
+		&tls.Config{RootCAs: pool, ServerName: "radius.acme.com"}
+}
+
+// eapTestClient is the supplicant of an EAP-TTLS authentication relayed by the network access server.
+type eapTestClient struct {
+	*testClient
+	username   string
+	state      []byte
+	identifier uint8
+	tunnel     *tunnel
+	// fragments counts the fragments received from the server
+	fragments int
+}
+
+func newEAPTestClient(t *testing.T, addr net.Addr, username string, tlsConfig *tls.Config) *eapTestClient {
+	conn := &tunnelConn{blocking: true}
+	conn.cond = sync.NewCond(&conn.mu)
+	tun := &tunnel{conn: conn, tls: tls.Client(conn, tlsConfig)}
+	go func() {
+		tun.err = tun.tls.Handshake()
+		conn.handshakeDone()
+	}()
+	t.Cleanup(tun.close)
+	return &eapTestClient{testClient: newTestClient(t, addr), username: username, tunnel: tun}
+}
+
+func (c *eapTestClient) sendEAP(message *eapPacket) (*packet, *packet) {
+	request := c.newRequest(codeAccessRequest)
+	request.add(attributeUserName, []byte(c.username))
+	request.addSplit(attributeEAPMessage, message.encode())
+	if c.state != nil {
+		request.add(attributeState, c.state)
+	}
+	response := c.exchange(request)
+	require.NotNil(c.t, response)
+	if response.code == codeAccessChallenge {
+		c.state, _ = response.get(attributeState)
+		eap, err := parseEAP(response.concat(attributeEAPMessage))
+		require.NoError(c.t, err)
+		require.Equal(c.t, eapCodeRequest, eap.code)
+		c.identifier = eap.identifier
+	}
+	return request, response
+}
+
+func (c *eapTestClient) sendTTLS(m *ttlsMessage) (*packet, *packet) {
+	return c.sendEAP(&eapPacket{code: eapCodeResponse, identifier: c.identifier, typ: eapTypeTTLS, data: m.encode()})
+}
+
+// exchangeRecords sends the records in small fragments and returns the reassembled records of the server,
+// or the final response if the server accepted or rejected the request.
+func (c *eapTestClient) exchangeRecords(records []byte) (received []byte, request, final *packet) {
+	const fragment = 100
+	for first := true; len(records) > fragment; first = false {
+		m := &ttlsMessage{flags: ttlsFlagMore, data: records[:fragment]}
+		if first {
+			m.flags |= ttlsFlagLength
+			m.length = uint32(len(records))
+		}
+		records = records[fragment:]
+		_, ack := c.sendTTLS(m)
+		require.Equal(c.t, codeAccessChallenge, ack.code)
+		ackMessage := c.ttls(ack)
+		require.Equal(c.t, &ttlsMessage{data: []byte{}}, ackMessage)
+	}
+	request, response := c.sendTTLS(&ttlsMessage{data: records})
+	for response.code == codeAccessChallenge {
+		m := c.ttls(response)
+		received = append(received, m.data...)
+		if m.flags&ttlsFlagMore == 0 {
+			return received, request, nil
+		}
+		c.fragments++
+		request, response = c.sendTTLS(&ttlsMessage{})
+	}
+	return nil, request, response
+}
+
+func (c *eapTestClient) ttls(response *packet) *ttlsMessage {
+	eap, err := parseEAP(response.concat(attributeEAPMessage))
+	require.NoError(c.t, err)
+	require.Equal(c.t, eapTypeTTLS, eap.typ)
+	m, err := parseTTLS(eap.data)
+	require.NoError(c.t, err)
+	return m
+}
+
+// authenticate runs the EAP-TTLS handshake and sends the inner credentials,
+// it returns the answers of the server and the final response.
+func (c *eapTestClient) authenticate(credentials ...[]byte) (request, final *packet) {
+	_, response := c.sendEAP(&eapPacket{code: eapCodeResponse, identifier: 1, typ: eapTypeIdentity, data: []byte("anonymous")})
+	require.Equal(c.t, codeAccessChallenge, response.code)
+	require.Equal(c.t, ttlsFlagStart, c.ttls(response).flags)
+
+	records, _ := c.tunnel.conn.exchange(nil)
+	for {
+		received, request, final := c.exchangeRecords(records)
+		require.Nil(c.t, final, "handshake failed")
+		out, done := c.tunnel.conn.exchange(received)
+		require.NoError(c.t, c.tunnel.err)
+		if done && len(out) == 0 {
+			break
+		}
+		records = out
+		_ = request
+	}
+	for i, data := range credentials {
+		records, err := c.tunnel.write(data)
+		require.NoError(c.t, err)
+		received, request, final := c.exchangeRecords(records)
+		if final != nil || i == len(credentials)-1 {
+			return request, final
+		}
+		answer, err := c.tunnel.read(received)
+		require.NoError(c.t, err)
+		avps, err := parseAVPs(answer)
+		require.NoError(c.t, err)
+		require.Len(c.t, avps, 1)
+		require.Equal(c.t, avpReplyMessage, avps[0].code)
+		require.Equal(c.t, otpPrompt, string(avps[0].data))
+	}
+	return nil, nil
+}
+
+func credentials(username, password string) []byte {
+	return append(encodeAVP(avpUserName, []byte(username)), encodeAVP(avpUserPassword, []byte(password))...)
+}
+
+func TestServer_EAPTTLS(t *testing.T) {
+	serverTLS, clientTLS := newTestTLSConfig(t)
+	tests := []struct {
+		name        string
+		credentials [][]byte
+		wantCode    uint8
+	}{
+		{
+			name:        "accepted",
+			credentials: [][]byte{credentials("alice", "Password1!")},
+			wantCode:    codeAccessAccept,
+		},
+		{
+			name:        "wrong password",
+			credentials: [][]byte{credentials("alice", "wrong")},
+			wantCode:    codeAccessReject,
+		},
+		{
+			name:        "otp",
+			credentials: [][]byte{credentials("bob", "Password1!"), encodeAVP(avpUserPassword, []byte(testOTP))},
+			wantCode:    codeAccessAccept,
+		},
+		{
+			name:        "wrong otp",
+			credentials: [][]byte{credentials("bob", "Password1!"), encodeAVP(avpUserPassword, []byte("000000"))},
+			wantCode:    codeAccessReject,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := startTestServer(t, Config{}, newTestBackend(), serverTLS)
+			client := newEAPTestClient(t, addr, "anonymous", clientTLS)
+
+			request, response := client.authenticate(tt.credentials...)
+			require.NotNil(t, response)
+			assert.Equal(t, tt.wantCode, response.code)
+			assert.Positive(t, client.fragments, "handshake not fragmented")
+			eap, err := parseEAP(response.concat(attributeEAPMessage))
+			require.NoError(t, err)
+			assert.Equal(t, client.identifier, eap.identifier)
+			if tt.wantCode != codeAccessAccept {
+				assert.Equal(t, eapCodeFailure, eap.code)
+				return
+			}
+			assert.Equal(t, eapCodeSuccess, eap.code)
+
+			msk, err := client.tunnel.keyingMaterial()
+			require.NoError(t, err)
+			keys := make(map[uint8][]byte)
+			for _, attr := range response.attributes {
+				if attr.typ != attributeVendorSpecific {
+					continue
+				}
+				require.Equal(t, []byte{0, 0, 1, 55}, attr.value[:4])
+				keys[attr.value[4]] = decryptMPPEKey(client.secret, request.authenticator, attr.value[6:])
+			}
+			assert.Equal(t, msk[:32], keys[vendorTypeMPPERecvKey])
+			assert.Equal(t, msk[32:64], keys[vendorTypeMPPESendKey])
+		})
+	}
+}
+
+func TestServer_EAPWithoutTLS(t *testing.T) {
+	client := newEAPTestClient(t, startTestServer(t, Config{}, newTestBackend(), nil), "anonymous", &tls.Config{})
+	_, response := client.sendEAP(&eapPacket{code: eapCodeResponse, identifier: 1, typ: eapTypeIdentity, data: []byte("anonymous")})
+	assert.Equal(t, codeAccessReject, response.code)
+	eap, err := parseEAP(response.concat(attributeEAPMessage))
+	require.NoError(t, err)
+	assert.Equal(t, eapCodeFailure, eap.code)
+}
@@ -0,0 +1,177 @@
+package radius
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"net/netip"
+	"sync"
+	"time"
+)
+
+const stateLength = 16
+
+// session is the state of an authentication spanning multiple requests,
+// it's referenced by the State attribute of the challenges.
+type session struct {
+	mu       sync.Mutex
+	state    string
+	clientID string
+	expires  time.Time
+	// identity is set as soon as the password was verified
+	identity *identity
+	// username is the User-Name of the request which started the session
+	username string
+
+	// eap is set for EAP-TTLS sessions
+	eap *eapSession
+}
+
+type sessions struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]*session
+}
+
+func newSessions(ttl time.Duration) *sessions {
+	return &sessions{
+		ttl:     ttl,
+		entries: make(map[string]*session),
+	}
+}
+
+func (s *sessions) create(clientID string) (*session, error) {
+	state := make([]byte, stateLength)
+	if _, err := rand.Read(state); err != nil {
+		return nil, err
+	}
+	sess := &session{
+		state:    base64.RawURLEncoding.EncodeToString(state),
+		clientID: clientID,
+		expires:  time.Now().Add(s.ttl),
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries[sess.state] = sess
+	return sess, nil
+}
+
+// get returns the session of the State if it was created for the client and did not expire.
+func (s *sessions) get(state []byte, clientID string) *session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.entries[string(state)]
+	if !ok || sess.clientID != clientID || time.Now().After(sess.expires) {
+		return nil
+	}
+	return sess
+}
+
+// touch extends the lifetime of the session for the next round trip.
+func (s *sessions) touch(sess *session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess.expires = time.Now().Add(s.ttl)
+}
+
+func (s *sessions) remove(sess *session) {
+	s.mu.Lock()
+	delete(s.entries, sess.state)
+	s.mu.Unlock()
+	sess.close()
+}
+
+func (s *sessions) cleanup(now time.Time) {
+	s.mu.Lock()
+	var expired []*session
+	for state, sess := range s.entries {
+		if now.After(sess.expires) {
+			delete(s.entries, state)
+			expired = append(expired, sess)
+		}
+	}
+	s.mu.Unlock()
+	for _, sess := range expired {
+		sess.close()
+	}
+}
+
+func (s *session) close() {
+	if s.eap != nil && s.eap.tunnel != nil {
+		s.eap.tunnel.close()
+	}
+}
+
+// responseCache answers retransmitted requests with the same response
+// instead of processing them again (RFC 5080, section 2.2.2).
+type responseCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[requestKey]*cachedResponse
+}
+
+type requestKey struct {
+	addr          netip.AddrPort
+	identifier    uint8
+	authenticator [authenticatorLength]byte
+}
+
+type cachedResponse struct {
+	// done is closed as soon as the response is set, retransmissions during the processing wait for it
+	done     chan struct{}
+	response []byte
+	expires  time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{
+		ttl:     ttl,
+		entries: make(map[requestKey]*cachedResponse),
+	}
+}
+
+// start returns the cached response of the request, or ok=true if the request has to be processed.
+// Responses of requests which are still processed are awaited.
+func (c *responseCache) start(key requestKey) (response []byte, ok bool) {
+	c.mu.Lock()
+	entry, found := c.entries[key]
+	if !found {
+		c.entries[key] = &cachedResponse{
+			done:    make(chan struct{}),
+			expires: time.Now().Add(c.ttl),
+		}
+		c.mu.Unlock()
+		return nil, true
+	}
+	c.mu.Unlock()
+	<-entry.done
+	return entry.response, false
+}
+
+// finish stores the response of the request, nil responses aren't cached so the request can be retried.
+func (c *responseCache) finish(key requestKey, response []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return
+	}
+	entry.response = response
+	close(entry.done)
+	if response == nil {
+		delete(c.entries, key)
+	}
+}
+
+func (c *responseCache) cleanup(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		select {
+		case <-entry.done:
+			if now.After(entry.expires) {
+				delete(c.entries, key)
+			}
+		default:
+		}
+	}
+}
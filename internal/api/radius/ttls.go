@@ -0,0 +1,277 @@
+package radius
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+
+	"github.com/zitadel/logging"
+)
+
+// Diameter AVP codes used inside the tunnel, they match the RADIUS attribute types (RFC 5281, section 10.2)
+const (
+	avpUserName     uint32 = 1
+	avpUserPassword uint32 = 2
+	avpReplyMessage uint32 = 18
+)
+
+// Microsoft vendor specific attributes carrying the keys of the session (RFC 2548, section 2.4)
+const (
+	vendorMicrosoft       uint32 = 311
+	vendorTypeMPPESendKey uint8  = 16
+	vendorTypeMPPERecvKey uint8  = 17
+)
+
+type ttlsPhase int
+
+const (
+	ttlsPhaseHandshake ttlsPhase = iota
+	// ttlsPhaseCredentials expects the User-Name and User-Password AVPs
+	ttlsPhaseCredentials
+	// ttlsPhaseOTP expects the code of the authenticator app in the User-Password AVP
+	ttlsPhaseOTP
+)
+
+var (
+	errTTLSMessageTooLarge = errors.New("eap-ttls message too large")
+	errUnexpectedTTLS      = errors.New("unexpected eap-ttls message")
+	errMissingCredentials  = errors.New("eap-ttls inner credentials missing")
+	errMandatoryAVP        = errors.New("unsupported mandatory diameter avp")
+)
+
+// eapSession is the state of an EAP-TTLS authentication (RFC 5281).
+type eapSession struct {
+	// identifier is the identifier of the last EAP-Request, the response must use the same
+	identifier uint8
+	phase      ttlsPhase
+	tunnel     *tunnel
+	// incoming buffers the fragments of a message of the peer
+	incoming []byte
+	// outgoing are the remaining fragments of a message to the peer
+	outgoing    []byte
+	fragmenting bool
+}
+
+// eap handles an Access-Request carrying an EAP-Message.
+// The first request contains the EAP-Response/Identity and starts the session,
+// the following requests reference the session by the State of the previous Access-Challenge.
+func (s *Server) eap(ctx context.Context, client *client, request *packet) *packet {
+	message, err := parseEAP(request.concat(attributeEAPMessage))
+	if err != nil || message.code != eapCodeResponse {
+		return eapFailure(request, 0)
+	}
+	state, ok := request.get(attributeState)
+	if !ok {
+		return s.startEAP(client, request, message)
+	}
+	sess := s.sessions.get(state, client.id)
+	if sess == nil || sess.eap == nil {
+		return eapFailure(request, message.identifier)
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if message.identifier != sess.eap.identifier {
+		// retransmissions of previous responses are answered by the response cache
+		return nil
+	}
+	if message.typ != eapTypeTTLS {
+		s.sessions.remove(sess)
+		return eapFailure(request, message.identifier)
+	}
+	response, err := s.continueTTLS(ctx, client, request, sess, message)
+	if err != nil {
+		logging.WithFields("client", client.id).WithError(err).Debug("eap-ttls authentication failed")
+		s.sessions.remove(sess)
+		return eapFailure(request, message.identifier)
+	}
+	return response
+}
+
+func (s *Server) startEAP(client *client, request *packet, message *eapPacket) *packet {
+	if message.typ != eapTypeIdentity || s.tlsConfig == nil {
+		return eapFailure(request, message.identifier)
+	}
+	sess, err := s.sessions.create(client.id)
+	if err != nil {
+		logging.WithFields("client", client.id).WithError(err).Warn("unable to create radius session")
+		return nil
+	}
+	sess.username = string(message.data)
+	sess.eap = &eapSession{tunnel: newTunnel(s.tlsConfig)}
+	start := &ttlsMessage{flags: ttlsFlagStart}
+	return s.eapChallenge(request, sess, message.identifier, start.encode())
+}
+
+func (s *Server) continueTTLS(ctx context.Context, client *client, request *packet, sess *session, message *eapPacket) (*packet, error) {
+	ttls, err := parseTTLS(message.data)
+	if err != nil {
+		return nil, err
+	}
+	// the peer acknowledges a fragment of the server
+	if sess.eap.fragmenting {
+		if len(ttls.data) > 0 {
+			return nil, errUnexpectedTTLS
+		}
+		return s.eapChallenge(request, sess, message.identifier, sess.eap.nextFragment()), nil
+	}
+	if len(sess.eap.incoming)+len(ttls.data) > ttlsMaxMessageLen {
+		return nil, errTTLSMessageTooLarge
+	}
+	sess.eap.incoming = append(sess.eap.incoming, ttls.data...)
+	if ttls.flags&ttlsFlagMore != 0 {
+		ack := &ttlsMessage{}
+		return s.eapChallenge(request, sess, message.identifier, ack.encode()), nil
+	}
+	records := sess.eap.incoming
+	sess.eap.incoming = nil
+
+	if sess.eap.phase == ttlsPhaseHandshake {
+		out, done, err := sess.eap.tunnel.handshake(records)
+		if err != nil {
+			return nil, err
+		}
+		if done {
+			sess.eap.phase = ttlsPhaseCredentials
+		}
+		return s.eapChallenge(request, sess, message.identifier, sess.eap.send(out)), nil
+	}
+	data, err := sess.eap.tunnel.read(records)
+	if err != nil {
+		return nil, err
+	}
+	username, password, err := innerCredentials(data)
+	if err != nil {
+		return nil, err
+	}
+	if sess.eap.phase == ttlsPhaseOTP {
+		if username != "" && username != sess.username {
+			return nil, errUnexpectedTTLS
+		}
+		if err = s.backend.checkOTP(ctx, sess.identity, password); err != nil {
+			return nil, err
+		}
+		return s.eapSuccess(ctx, client, request, sess, message.identifier)
+	}
+	if username == "" {
+		return nil, errMissingCredentials
+	}
+	identity, err := s.backend.checkPassword(ctx, client, username, password)
+	if err != nil {
+		return nil, err
+	}
+	sess.identity = identity
+	sess.username = username
+	if !identity.otp {
+		return s.eapSuccess(ctx, client, request, sess, message.identifier)
+	}
+	sess.eap.phase = ttlsPhaseOTP
+	out, err := sess.eap.tunnel.write(encodeAVP(avpReplyMessage, []byte(otpPrompt)))
+	if err != nil {
+		return nil, err
+	}
+	return s.eapChallenge(request, sess, message.identifier, sess.eap.send(out)), nil
+}
+
+// innerCredentials returns the User-Name and User-Password AVPs sent through the tunnel (RFC 5281, section 11.2.5).
+func innerCredentials(data []byte) (username, password string, err error) {
+	avps, err := parseAVPs(data)
+	if err != nil {
+		return "", "", err
+	}
+	var passwordFound bool
+	for _, a := range avps {
+		switch {
+		case a.flags&avpFlagVendor == 0 && a.code == avpUserName:
+			username = string(a.data)
+		case a.flags&avpFlagVendor == 0 && a.code == avpUserPassword:
+			// the password is padded with NULs to a multiple of 16 octets
+			password = string(bytes.TrimRight(a.data, "\x00"))
+			passwordFound = true
+		case a.flags&avpFlagMandatory != 0:
+			return "", "", errMandatoryAVP
+		}
+	}
+	if !passwordFound {
+		return "", "", errMissingCredentials
+	}
+	return username, password, nil
+}
+
+// eapChallenge sends the next EAP-TTLS request to the peer.
+func (s *Server) eapChallenge(request *packet, sess *session, responseIdentifier uint8, data []byte) *packet {
+	sess.eap.identifier = responseIdentifier + 1
+	s.sessions.touch(sess)
+	eap := &eapPacket{
+		code:       eapCodeRequest,
+		identifier: sess.eap.identifier,
+		typ:        eapTypeTTLS,
+		data:       data,
+	}
+	response := request.newResponse(codeAccessChallenge)
+	response.addSplit(attributeEAPMessage, eap.encode())
+	response.add(attributeState, []byte(sess.state))
+	return response
+}
+
+// eapSuccess accepts the user and passes the keys derived from the tunnel to the network access server.
+func (s *Server) eapSuccess(ctx context.Context, client *client, request *packet, sess *session, responseIdentifier uint8) (*packet, error) {
+	roles, ok := s.authorize(ctx, client, sess.identity)
+	if !ok {
+		return nil, errors.New("user not authorized for the radius client")
+	}
+	msk, err := sess.eap.tunnel.keyingMaterial()
+	if err != nil {
+		return nil, err
+	}
+	var salts [4]byte
+	if _, err = rand.Read(salts[:]); err != nil {
+		return nil, err
+	}
+	recvSalt := [2]byte{salts[0] | 0x80, salts[1]}
+	sendSalt := [2]byte{salts[2] | 0x80, salts[3]}
+	if sendSalt == recvSalt {
+		sendSalt[1]++
+	}
+	s.sessions.remove(sess)
+
+	response := request.newResponse(codeAccessAccept)
+	success := &eapPacket{code: eapCodeSuccess, identifier: responseIdentifier}
+	response.add(attributeEAPMessage, success.encode())
+	response.add(attributeUserName, []byte(sess.identity.loginName))
+	addRoleAttributes(response, client.roleAttributes, roles)
+	response.addVendorSpecific(vendorMicrosoft, vendorTypeMPPERecvKey, encryptMPPEKey(client.secret, request.authenticator, recvSalt, msk[:32]))
+	response.addVendorSpecific(vendorMicrosoft, vendorTypeMPPESendKey, encryptMPPEKey(client.secret, request.authenticator, sendSalt, msk[32:64]))
+	return response, nil
+}
+
+func eapFailure(request *packet, identifier uint8) *packet {
+	response := request.newResponse(codeAccessReject)
+	failure := &eapPacket{code: eapCodeFailure, identifier: identifier}
+	response.add(attributeEAPMessage, failure.encode())
+	return response
+}
+
+// send returns the first fragment of the message, the others are sent as soon as the peer acknowledges them.
+func (e *eapSession) send(data []byte) []byte {
+	e.outgoing = data
+	e.fragmenting = false
+	return e.nextFragment()
+}
+
+// nextFragment returns the next fragment of the outgoing message (RFC 5281, section 9.2.2),
+// the first fragment of a fragmented message contains the total length.
+func (e *eapSession) nextFragment() []byte {
+	m := &ttlsMessage{data: e.outgoing}
+	if len(e.outgoing) > ttlsMaxFragment {
+		if !e.fragmenting {
+			m.flags |= ttlsFlagLength
+			m.length = uint32(len(e.outgoing))
+		}
+		m.flags |= ttlsFlagMore
+		m.data = e.outgoing[:ttlsMaxFragment]
+	}
+	e.outgoing = e.outgoing[len(m.data):]
+	e.fragmenting = len(e.outgoing) > 0
+	return m.encode()
+}
@@ -0,0 +1,173 @@
+package radius
+
+import (
+	"crypto/tls"
+	"io"
+	"net"
+	"sync"
+	"time"
+)
+
+// tunnel is the TLS connection of an EAP-TTLS session.
+// The TLS records are carried in EAP packets, so the connection is not backed by a socket:
+// the handshake runs in its own goroutine, which blocks until the next records of the peer are passed to it.
+type tunnel struct {
+	conn *tunnelConn
+	tls  *tls.Conn
+	err  error
+}
+
+func newTunnel(config *tls.Config) *tunnel {
+	conn := &tunnelConn{blocking: true}
+	conn.cond = sync.NewCond(&conn.mu)
+	t := &tunnel{
+		conn: conn,
+		tls:  tls.Server(conn, config),
+	}
+	go func() {
+		t.err = t.tls.Handshake()
+		conn.handshakeDone()
+	}()
+	return t
+}
+
+// handshake passes the records of the peer to the TLS handshake and returns the records to send back.
+// done is true as soon as the handshake finished, err is set if it failed.
+func (t *tunnel) handshake(records []byte) (out []byte, done bool, err error) {
+	out, done = t.conn.exchange(records)
+	if done {
+		return out, true, t.err
+	}
+	return out, false, nil
+}
+
+// read decrypts the application data of the records.
+func (t *tunnel) read(records []byte) ([]byte, error) {
+	t.conn.push(records)
+	var data []byte
+	buf := make([]byte, ttlsMaxFragment)
+	for t.conn.buffered() {
+		n, err := t.tls.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, buf[:n]...)
+	}
+	return data, nil
+}
+
+// write encrypts the application data and returns the records to send.
+func (t *tunnel) write(data []byte) ([]byte, error) {
+	if _, err := t.tls.Write(data); err != nil {
+		return nil, err
+	}
+	return t.conn.pull(), nil
+}
+
+// keyingMaterial derives the MSK of the session (RFC 5281, section 8).
+func (t *tunnel) keyingMaterial() ([]byte, error) {
+	state := t.tls.ConnectionState()
+	return state.ExportKeyingMaterial("ttls keying material", nil, 64)
+}
+
+func (t *tunnel) close() {
+	t.conn.Close()
+}
+
+// tunnelConn buffers the records exchanged with the peer.
+// During the handshake, reads block until records are pushed, afterwards reads never block.
+type tunnelConn struct {
+	mu       sync.Mutex
+	cond     *sync.Cond
+	in       []byte
+	out      []byte
+	blocking bool
+	// waiting is true while the handshake waits for records of the peer
+	waiting bool
+	closed  bool
+}
+
+func (c *tunnelConn) exchange(records []byte) (out []byte, done bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.in = append(c.in, records...)
+	c.waiting = false
+	c.cond.Broadcast()
+	for c.blocking && !c.closed && !(c.waiting && len(c.in) == 0) {
+		c.cond.Wait()
+	}
+	out, c.out = c.out, nil
+	return out, !c.blocking || c.closed
+}
+
+func (c *tunnelConn) handshakeDone() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.blocking = false
+	c.cond.Broadcast()
+}
+
+func (c *tunnelConn) push(records []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.in = append(c.in, records...)
+}
+
+func (c *tunnelConn) pull() []byte {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	out := c.out
+	c.out = nil
+	return out
+}
+
+func (c *tunnelConn) buffered() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.in) > 0
+}
+
+func (c *tunnelConn) Read(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.in) == 0 {
+		if c.closed || !c.blocking {
+			return 0, io.EOF
+		}
+		c.waiting = true
+		c.cond.Broadcast()
+		c.cond.Wait()
+	}
+	n := copy(b, c.in)
+	c.in = c.in[n:]
+	return n, nil
+}
+
+func (c *tunnelConn) Write(b []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return 0, net.ErrClosed
+	}
+	c.out = append(c.out, b...)
+	return len(b), nil
+}
+
+func (c *tunnelConn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closed = true
+	c.cond.Broadcast()
+	return nil
+}
+
+func (c *tunnelConn) LocalAddr() net.Addr                { return tunnelAddr{} }
+func (c *tunnelConn) RemoteAddr() net.Addr               { return tunnelAddr{} }
+func (c *tunnelConn) SetDeadline(_ time.Time) error      { return nil }
+func (c *tunnelConn) SetReadDeadline(_ time.Time) error  { return nil }
+func (c *tunnelConn) SetWriteDeadline(_ time.Time) error { return nil }
+
+type tunnelAddr struct{}
+
+func (tunnelAddr) Network() string { return "eap-ttls" }
+func (tunnelAddr) String() string  { return "eap-ttls" }
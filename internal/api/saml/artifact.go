@@ -0,0 +1,425 @@
+package saml
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1" //nolint:gosec // the source id of an artifact is defined as SHA-1 hash of the entity id
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/beevik/etree"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/models"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+	"github.com/zitadel/saml/pkg/provider/xml/soap"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	ArtifactBinding = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Artifact"
+	SOAPBinding     = "urn:oasis:names:tc:SAML:2.0:bindings:SOAP"
+
+	// ArtifactResolutionEndpoint is the endpoint (relative to the [HandlerPrefix])
+	// where service providers resolve the artifacts using the SOAP binding.
+	ArtifactResolutionEndpoint = "/artifact"
+
+	artifactParam          = "SAMLart"
+	relayStateParam        = "RelayState"
+	artifactTypeCode       = 0x0004
+	artifactEndpointIndex  = 0
+	artifactMessageLength  = 20
+	artifactResolveMaxSize = 1 << 20
+)
+
+type artifactIndex int
+
+const (
+	artifactIndexUnspecified artifactIndex = iota
+	artifactIndexArtifact
+)
+
+// artifact is a SAML response, which is kept until the service provider resolves it
+// using the HTTP-Artifact binding.
+type artifact struct {
+	InstanceID string
+	Artifact   string
+	// EntityID of the service provider the response is issued to.
+	EntityID string
+	// Response is the marshalled and signed SAML response.
+	Response string
+}
+
+// Keys implements cache.Entry
+func (a *artifact) Keys(i artifactIndex) []string {
+	if i == artifactIndexArtifact {
+		return []string{artifactKey(a.InstanceID, a.Artifact)}
+	}
+	return nil
+}
+
+func artifactKey(instanceID, artifact string) string {
+	return instanceID + "-" + artifact
+}
+
+// newArtifact creates a type 0x0004 artifact as defined in section 3.6.4 of
+// https://docs.oasis-open.org/security/saml/v2.0/saml-bindings-2.0-os.pdf
+func newArtifact(issuer string) (string, error) {
+	sourceID := sha1.Sum([]byte(issuer)) //nolint:gosec
+	art := make([]byte, 4, 4+len(sourceID)+artifactMessageLength)
+	binary.BigEndian.PutUint16(art[0:2], artifactTypeCode)
+	binary.BigEndian.PutUint16(art[2:4], artifactEndpointIndex)
+	art = append(art, sourceID[:]...)
+	messageHandle := make([]byte, artifactMessageLength)
+	if _, err := rand.Read(messageHandle); err != nil {
+		return "", err
+	}
+	art = append(art, messageHandle...)
+	return base64.StdEncoding.EncodeToString(art), nil
+}
+
+// artifacts implements the HTTP-Artifact binding for SAML responses, which is not supported by the SAML library:
+// The response is stored and only an artifact is sent to the service provider through the user agent,
+// which then resolves the response on the [ArtifactResolutionEndpoint] through the back channel.
+type artifacts struct {
+	cache        cache.Cache[artifactIndex, string, *artifact]
+	storage      *Storage
+	provider     *provider.Provider
+	callbackPath string
+	metadataPath string
+	// metadataSignatureAlgorithm is used to sign the metadata again after the artifact resolution service was added.
+	metadataSignatureAlgorithm string
+}
+
+func newArtifacts(artifactCache cache.Cache[artifactIndex, string, *artifact], storage *Storage, config *provider.Config) *artifacts {
+	a := &artifacts{
+		cache:        artifactCache,
+		storage:      storage,
+		callbackPath: "/" + provider.DefaultCallbackEndpoint,
+		metadataPath: provider.DefaultMetadataEndpoint,
+	}
+	if config == nil {
+		return a
+	}
+	if config.MetadataConfig != nil {
+		if config.MetadataConfig.Path != "" {
+			a.metadataPath = config.MetadataConfig.Path
+		}
+		a.metadataSignatureAlgorithm = config.MetadataConfig.SignatureAlgorithm
+	}
+	if config.IDPConfig != nil && config.IDPConfig.Endpoints != nil &&
+		config.IDPConfig.Endpoints.Callback != nil && config.IDPConfig.Endpoints.Callback.Relative() != "" {
+		a.callbackPath = config.IDPConfig.Endpoints.Callback.Relative()
+	}
+	return a
+}
+
+// responseProtocolBinding returns the binding the SAML library creates the response for.
+// Responses of the HTTP-Artifact binding are signed the same way as for the HTTP-POST binding.
+func responseProtocolBinding(binding string) string {
+	if binding == ArtifactBinding {
+		return provider.PostBinding
+	}
+	return binding
+}
+
+// redirectURL stores the response and returns the URL of the assertion consumer service
+// with the artifact referencing it.
+func (a *artifacts) redirectURL(ctx context.Context, samlResponse *samlp.ResponseType, entityID, acsURL, relayState string) (string, error) {
+	response, err := saml_xml.Marshal(samlResponse)
+	if err != nil {
+		return "", err
+	}
+	art, err := newArtifact(a.provider.GetEntityID(ctx))
+	if err != nil {
+		return "", err
+	}
+	a.cache.Set(ctx, &artifact{
+		InstanceID: authz.GetInstance(ctx).InstanceID(),
+		Artifact:   art,
+		EntityID:   entityID,
+		Response:   string(bytes.TrimPrefix(response, []byte(xml.Header))),
+	})
+	uri, err := url.Parse(acsURL)
+	if err != nil {
+		return "", err
+	}
+	values := uri.Query()
+	values.Set(artifactParam, art)
+	if relayState != "" {
+		values.Set(relayStateParam, relayState)
+	}
+	uri.RawQuery = values.Encode()
+	return uri.String(), nil
+}
+
+// Handler intercepts the requests of the SAML library, which need to be extended for the HTTP-Artifact binding:
+//   - the callback of the login (v1) for requests using the HTTP-Artifact binding
+//   - the metadata to contain the artifact resolution service
+func (a *artifacts) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case a.callbackPath:
+			authRequest, err := a.storage.AuthRequestByID(r.Context(), r.FormValue("id"))
+			if err != nil || authRequest.GetBindingType() != ArtifactBinding {
+				next.ServeHTTP(w, r)
+				return
+			}
+			a.handleCallback(w, r, authRequest)
+		case a.metadataPath:
+			a.handleMetadata(w, r)
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+func (a *artifacts) handleCallback(w http.ResponseWriter, r *http.Request, authRequest models.AuthRequestInt) {
+	ctx := r.Context()
+	entityID, err := a.storage.GetEntityIDByAppID(ctx, authRequest.GetApplicationID())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	response := &provider.Response{
+		ProtocolBinding: responseProtocolBinding(authRequest.GetBindingType()),
+		RelayState:      authRequest.GetRelayState(),
+		AcsUrl:          authRequest.GetAccessConsumerServiceURL(),
+		RequestID:       authRequest.GetAuthRequestID(),
+		Issuer:          a.provider.GetEntityID(ctx),
+		Audience:        entityID,
+	}
+	samlResponse, err := a.provider.AuthCallbackResponse(ctx, authRequest, response)
+	if err != nil {
+		samlResponse = a.provider.AuthCallbackErrorResponse(response, err.Error(), "failed to create response")
+	}
+	redirectURL, err := a.redirectURL(ctx, samlResponse, entityID, response.AcsUrl, response.RelayState)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, redirectURL, http.StatusFound)
+}
+
+func (a *artifacts) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	metadata, err := a.metadata(ctx)
+	if err != nil {
+		logging.WithError(err).Error("error while getting metadata")
+		http.Error(w, "error while getting metadata", http.StatusInternalServerError)
+		return
+	}
+	if err := saml_xml.WriteXMLMarshalled(w, metadata); err != nil {
+		http.Error(w, "failed to respond with metadata", http.StatusInternalServerError)
+	}
+}
+
+func (a *artifacts) metadata(ctx context.Context) (*md.EntityDescriptorType, error) {
+	metadata, err := a.provider.GetMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if metadata.IDPSSODescriptor == nil {
+		return metadata, nil
+	}
+	metadata.IDPSSODescriptor.ArtifactResolutionService = append(metadata.IDPSSODescriptor.ArtifactResolutionService, md.IndexedEndpointType{
+		Index:     strconv.Itoa(artifactEndpointIndex),
+		IsDefault: "true",
+		Binding:   SOAPBinding,
+		Location:  provider.IssuerFromContext(ctx) + ArtifactResolutionEndpoint,
+	})
+	if metadata.Signature == nil || a.metadataSignatureAlgorithm == "" {
+		return metadata, nil
+	}
+	// the signature of the library is no longer valid because of the added service
+	metadata.Signature = nil
+	certAndKey, err := a.storage.GetMetadataSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signer, err := signature.GetSigner(certAndKey.Certificate, certAndKey.Key, a.metadataSignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	metadata.Signature, err = signature.Create(signer, metadata)
+	if err != nil {
+		return nil, err
+	}
+	return metadata, nil
+}
+
+// handleArtifactResolve resolves the artifact of an ArtifactResolve request of a service provider.
+// The service provider has to authenticate the request, see [verifyArtifactResolve].
+// Each artifact can only be resolved once and only by the service provider it was issued to.
+// Otherwise, the ArtifactResponse will not contain a message as defined in section 3.5.3 of
+// https://docs.oasis-open.org/security/saml/v2.0/saml-core-2.0-os.pdf
+func (a *artifacts) handleArtifactResolve(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	body, err := io.ReadAll(io.LimitReader(r.Body, artifactResolveMaxSize))
+	if err != nil {
+		http.Error(w, "failed to read request", http.StatusBadRequest)
+		return
+	}
+	resolve, resolveElement, err := parseArtifactResolve(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	destination := provider.IssuerFromContext(ctx) + ArtifactResolutionEndpoint
+	if resolve.Destination != "" && resolve.Destination != destination {
+		http.Error(w, "destination of request is unknown", http.StatusBadRequest)
+		return
+	}
+	sp, err := a.storage.GetEntityByID(ctx, resolve.Issuer.Text)
+	if err != nil {
+		http.Error(w, "failed to find registered serviceprovider", http.StatusBadRequest)
+		return
+	}
+	if err = verifyArtifactResolve(sp, resolve, resolveElement, r.TLS); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	message := a.resolve(ctx, resolve.Artifact, sp.GetEntityID())
+	response := &artifactResponseEnvelope{
+		Body: artifactResponseBody{
+			ArtifactResponse: &artifactResponse{
+				ID:           provider.NewID(),
+				InResponseTo: resolve.Id,
+				Version:      "2.0",
+				IssueInstant: time.Now().UTC().Format(a.provider.Timeformat()),
+				Issuer: &artifactIssuer{
+					Format: "urn:oasis:names:tc:SAML:2.0:nameid-format:entity",
+					Text:   a.provider.GetEntityID(ctx),
+				},
+				Status: samlp.StatusType{
+					StatusCode: samlp.StatusCodeType{Value: provider.StatusCodeSuccess},
+				},
+				Message: message,
+			},
+		},
+	}
+	w.Header().Set("Content-Type", "text/xml")
+	if err := saml_xml.WriteXMLMarshalled(w, response); err != nil {
+		logging.WithError(err).Error("failed to send artifact response")
+	}
+}
+
+// resolve returns the response referenced by the artifact, if it was issued to the service provider.
+// The artifact is only invalidated when resolved by its service provider,
+// so others are not able to prevent the resolution.
+func (a *artifacts) resolve(ctx context.Context, artifactValue, entityID string) string {
+	key := artifactKey(authz.GetInstance(ctx).InstanceID(), artifactValue)
+	art, ok := a.cache.Get(ctx, artifactIndexArtifact, key)
+	if !ok || art.EntityID != entityID {
+		return ""
+	}
+	err := a.cache.Invalidate(ctx, artifactIndexArtifact, key)
+	logging.OnError(err).Warn("unable to invalidate saml artifact")
+	return art.Response
+}
+
+func parseArtifactResolve(body []byte) (*samlp.ArtifactResolveType, *etree.Element, error) {
+	envelope := new(soap.ArtifactResolveEnvelope)
+	if err := xml.Unmarshal(body, envelope); err != nil {
+		return nil, nil, zerrors.ThrowInvalidArgument(err, "SAML-Ahn3o", "failed to decode request")
+	}
+	resolve := envelope.Body.ArtifactResolve
+	if resolve == nil || resolve.Issuer == nil || resolve.Artifact == "" {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SAML-aeJ4i", "invalid artifact resolve request")
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(body); err != nil {
+		return nil, nil, zerrors.ThrowInvalidArgument(err, "SAML-Oof8a", "failed to decode request")
+	}
+	element := doc.FindElement("./Envelope/Body/ArtifactResolve")
+	if element == nil {
+		return nil, nil, zerrors.ThrowInvalidArgument(nil, "SAML-ieG8u", "invalid artifact resolve request")
+	}
+	return resolve, element, nil
+}
+
+// verifyArtifactResolve authenticates the service provider of the request,
+// as the issuer is self-asserted and the artifact is exposed to the user agent.
+// The request must either be signed or sent with a TLS client certificate,
+// which are both verified against the signing certificates of the service provider.
+func verifyArtifactResolve(sp *serviceprovider.ServiceProvider, resolve *samlp.ArtifactResolveType, element *etree.Element, tlsState *tls.ConnectionState) error {
+	var certs []string
+	if sp.Metadata.SPSSODescriptor != nil {
+		certs = saml_xml.GetCertsFromKeyDescriptors(sp.Metadata.SPSSODescriptor.KeyDescriptor)
+	}
+	if len(certs) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "SAML-Vai3e", "serviceprovider has no signing certificate to authenticate the request")
+	}
+	certificates, err := signature.ParseCertificates(certs)
+	if err != nil {
+		return zerrors.ThrowInvalidArgument(err, "SAML-Iek4o", "failed to parse certificates of serviceprovider")
+	}
+	if resolve.Signature == nil {
+		if hasClientCertificate(tlsState, certificates) {
+			return nil
+		}
+		return zerrors.ThrowInvalidArgument(nil, "SAML-uu5Ai", "signature of request missing")
+	}
+	if err = signature.ValidatePost(certificates, element); err != nil {
+		return zerrors.ThrowInvalidArgument(err, "SAML-ohP2e", "failed to verify signature of request")
+	}
+	return nil
+}
+
+// hasClientCertificate returns true if the client authenticated the TLS connection
+// with one of the certificates.
+func hasClientCertificate(tlsState *tls.ConnectionState, certificates []*x509.Certificate) bool {
+	if tlsState == nil || len(tlsState.PeerCertificates) == 0 {
+		return false
+	}
+	for _, certificate := range certificates {
+		if certificate.Equal(tlsState.PeerCertificates[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+type artifactResponseEnvelope struct {
+	XMLName xml.Name `xml:"http://schemas.xmlsoap.org/soap/envelope/ Envelope"`
+	Body    artifactResponseBody
+}
+
+type artifactResponseBody struct {
+	XMLName          xml.Name `xml:"http://schemas.xmlsoap.org/soap/envelope/ Body"`
+	ArtifactResponse *artifactResponse
+}
+
+// artifactResponse contains the resolved message as is,
+// so the signature of the response stays valid.
+type artifactResponse struct {
+	XMLName      xml.Name         `xml:"urn:oasis:names:tc:SAML:2.0:protocol ArtifactResponse"`
+	ID           string           `xml:"ID,attr"`
+	InResponseTo string           `xml:"InResponseTo,attr"`
+	Version      string           `xml:"Version,attr"`
+	IssueInstant string           `xml:"IssueInstant,attr"`
+	Issuer       *artifactIssuer  `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	Status       samlp.StatusType `xml:"urn:oasis:names:tc:SAML:2.0:protocol Status"`
+	Message      string           `xml:",innerxml"`
+}
+
+type artifactIssuer struct {
+	Format string `xml:"Format,attr,omitempty"`
+	Text   string `xml:",chardata"`
+}
@@ -0,0 +1,262 @@
+package saml
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1" //nolint:gosec
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/binary"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+	"github.com/zitadel/saml/pkg/provider/xml/xml_dsig"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector/gomap"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const testArtifactResolve = `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">
+<soap:Body>
+<samlp:ArtifactResolve xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_id" Version="2.0" IssueInstant="2024-01-01T00:00:00Z">
+<saml:Issuer>https://sp.example.com/metadata</saml:Issuer>
+<samlp:Artifact>artifact</samlp:Artifact>
+</samlp:ArtifactResolve>
+</soap:Body>
+</soap:Envelope>`
+
+func TestNewArtifact(t *testing.T) {
+	got, err := newArtifact("https://idp.example.com/metadata")
+	require.NoError(t, err)
+	art, err := base64.StdEncoding.DecodeString(got)
+	require.NoError(t, err)
+	require.Len(t, art, 44)
+	assert.Equal(t, uint16(artifactTypeCode), binary.BigEndian.Uint16(art[0:2]))
+	assert.Equal(t, uint16(artifactEndpointIndex), binary.BigEndian.Uint16(art[2:4]))
+	sourceID := sha1.Sum([]byte("https://idp.example.com/metadata")) //nolint:gosec
+	assert.Equal(t, sourceID[:], art[4:24])
+
+	other, err := newArtifact("https://idp.example.com/metadata")
+	require.NoError(t, err)
+	assert.NotEqual(t, got, other)
+}
+
+func Test_responseProtocolBinding(t *testing.T) {
+	assert.Equal(t, provider.PostBinding, responseProtocolBinding(ArtifactBinding))
+	assert.Equal(t, provider.PostBinding, responseProtocolBinding(provider.PostBinding))
+	assert.Equal(t, provider.RedirectBinding, responseProtocolBinding(provider.RedirectBinding))
+}
+
+func Test_parseArtifactResolve(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		err  func(error) bool
+	}{
+		{
+			name: "invalid xml",
+			body: "<soap:Envelope",
+			err:  zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "missing artifact resolve",
+			body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body></soap:Body></soap:Envelope>`,
+			err:  zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "missing artifact",
+			body: `<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Body>
+<samlp:ArtifactResolve xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion" ID="_id">
+<saml:Issuer>https://sp.example.com/metadata</saml:Issuer>
+</samlp:ArtifactResolve>
+</soap:Body></soap:Envelope>`,
+			err: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "ok",
+			body: testArtifactResolve,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolve, element, err := parseArtifactResolve([]byte(tt.body))
+			if tt.err != nil {
+				assert.True(t, tt.err(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "_id", resolve.Id)
+			assert.Equal(t, "artifact", resolve.Artifact)
+			assert.Equal(t, "https://sp.example.com/metadata", resolve.Issuer.Text)
+			assert.Equal(t, "ArtifactResolve", element.Tag)
+		})
+	}
+}
+
+func Test_verifyArtifactResolve(t *testing.T) {
+	spCert := newTestCertificate(t)
+	otherCert := newTestCertificate(t)
+
+	tests := []struct {
+		name     string
+		sp       *serviceprovider.ServiceProvider
+		body     []byte
+		tlsState *tls.ConnectionState
+		wantErr  bool
+	}{
+		{
+			name:    "unsigned, serviceprovider without certificate, error",
+			sp:      testServiceProvider(nil),
+			body:    []byte(testArtifactResolve),
+			wantErr: true,
+		},
+		{
+			name:    "signed, serviceprovider without certificate, error",
+			sp:      testServiceProvider(nil),
+			body:    signArtifactResolve(t, spCert),
+			wantErr: true,
+		},
+		{
+			name:    "unsigned, error",
+			sp:      testServiceProvider(spCert.Leaf),
+			body:    []byte(testArtifactResolve),
+			wantErr: true,
+		},
+		{
+			name: "unsigned, other client certificate, error",
+			sp:   testServiceProvider(spCert.Leaf),
+			body: []byte(testArtifactResolve),
+			tlsState: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{otherCert.Leaf},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsigned, client certificate, ok",
+			sp:   testServiceProvider(spCert.Leaf),
+			body: []byte(testArtifactResolve),
+			tlsState: &tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{spCert.Leaf},
+			},
+		},
+		{
+			name:    "signed by other key, error",
+			sp:      testServiceProvider(spCert.Leaf),
+			body:    signArtifactResolve(t, otherCert),
+			wantErr: true,
+		},
+		{
+			name: "signed, ok",
+			sp:   testServiceProvider(spCert.Leaf),
+			body: signArtifactResolve(t, spCert),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolve, element, err := parseArtifactResolve(tt.body)
+			require.NoError(t, err)
+			err = verifyArtifactResolve(tt.sp, resolve, element, tt.tlsState)
+			if tt.wantErr {
+				assert.True(t, zerrors.IsErrorInvalidArgument(err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func Test_artifacts_resolve(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	a := &artifacts{
+		cache: gomap.NewCache[artifactIndex, string, *artifact](
+			context.Background(),
+			[]artifactIndex{artifactIndexArtifact},
+			cache.Config{Connector: cache.ConnectorMemory},
+		),
+	}
+	a.cache.Set(ctx, &artifact{
+		InstanceID: "instance1",
+		Artifact:   "artifact",
+		EntityID:   "https://sp.example.com/metadata",
+		Response:   "<samlp:Response/>",
+	})
+
+	assert.Empty(t, a.resolve(ctx, "unknown", "https://sp.example.com/metadata"))
+	assert.Empty(t, a.resolve(authz.WithInstanceID(context.Background(), "instance2"), "artifact", "https://sp.example.com/metadata"))
+	// other service providers must not be able to burn the artifact
+	assert.Empty(t, a.resolve(ctx, "artifact", "https://other.example.com/metadata"))
+	assert.Equal(t, "<samlp:Response/>", a.resolve(ctx, "artifact", "https://sp.example.com/metadata"))
+	// artifacts can only be resolved once
+	assert.Empty(t, a.resolve(ctx, "artifact", "https://sp.example.com/metadata"))
+}
+
+func newTestCertificate(t *testing.T) *tls.Certificate {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sp.example.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &tls.Certificate{
+		Certificate: [][]byte{der},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+}
+
+func testServiceProvider(certificate *x509.Certificate) *serviceprovider.ServiceProvider {
+	descriptor := &md.SPSSODescriptorType{}
+	if certificate != nil {
+		descriptor.KeyDescriptor = []md.KeyDescriptorType{{
+			Use: "signing",
+			KeyInfo: xml_dsig.KeyInfoType{
+				X509Data: []xml_dsig.X509DataType{{
+					X509Certificate: base64.StdEncoding.EncodeToString(certificate.Raw),
+				}},
+			},
+		}}
+	}
+	return &serviceprovider.ServiceProvider{
+		Metadata: &md.EntityDescriptorType{
+			EntityID:        "https://sp.example.com/metadata",
+			SPSSODescriptor: descriptor,
+		},
+	}
+}
+
+func signArtifactResolve(t *testing.T, certificate *tls.Certificate) []byte {
+	t.Helper()
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromString(testArtifactResolve))
+	element := doc.FindElement("./Envelope/Body/ArtifactResolve")
+	signingContext := dsig.NewDefaultSigningContext(dsig.TLSCertKeyStore(*certificate))
+	signingContext.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signed, err := signingContext.SignEnveloped(element)
+	require.NoError(t, err)
+	body := element.Parent()
+	body.RemoveChild(element)
+	body.AddChild(signed)
+	out, err := doc.WriteToBytes()
+	require.NoError(t, err)
+	return out
+}
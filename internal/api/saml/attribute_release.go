@@ -0,0 +1,144 @@
+package saml
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/saml/pkg/provider/models"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const attributeNameFormatBasic = "urn:oasis:names:tc:SAML:2.0:attrname-format:basic"
+
+// hasAttributeRelease returns true if the application customizes the NameID or the released attributes.
+func hasAttributeRelease(app *query.SAMLApp) bool {
+	return app != nil && (app.NameIDSource != domain.SAMLNameIDSourceUnspecified || len(app.AttributeMappings) > 0)
+}
+
+// setReleasedUserinfo sets the NameID and attributes as configured on the service provider.
+// Without attribute mappings, the default attributes are released.
+// Note that the NameID is also released as the UserName attribute.
+func (p *Storage) setReleasedUserinfo(ctx context.Context, app *query.SAMLApp, user *query.User, userGrants *query.UserGrants, userinfo models.AttributeSetter, customAttributes map[string]*customAttribute) error {
+	for name, attr := range customAttributes {
+		userinfo.SetCustomAttribute(name, "", attr.nameFormat, attr.attributeValue)
+	}
+	userinfo.SetUsername(nameIDValue(app, user))
+	if len(app.AttributeMappings) == 0 {
+		userinfo.SetUserID(user.ID)
+		if user.Human == nil {
+			return nil
+		}
+		userinfo.SetEmail(string(user.Human.Email))
+		userinfo.SetSurname(user.Human.LastName)
+		userinfo.SetGivenName(user.Human.FirstName)
+		userinfo.SetFullName(user.Human.DisplayName)
+		return nil
+	}
+	for _, mapping := range app.AttributeMappings {
+		values, err := p.attributeValues(ctx, mapping, user, userGrants)
+		if err != nil {
+			return err
+		}
+		if len(values) == 0 {
+			continue
+		}
+		nameFormat := mapping.NameFormat
+		if nameFormat == "" {
+			nameFormat = attributeNameFormatBasic
+		}
+		userinfo.SetCustomAttribute(mapping.Name, mapping.FriendlyName, nameFormat, values)
+	}
+	return nil
+}
+
+// nameIDValue returns the value of the NameID as configured on the service provider.
+// Sources not available for the user fall back to the preferred login name.
+func nameIDValue(app *query.SAMLApp, user *query.User) string {
+	switch app.NameIDSource {
+	case domain.SAMLNameIDSourceUserID:
+		return user.ID
+	case domain.SAMLNameIDSourceEmail:
+		if user.Human != nil && user.Human.Email != "" {
+			return string(user.Human.Email)
+		}
+	case domain.SAMLNameIDSourceUsername:
+		return user.Username
+	case domain.SAMLNameIDSourceUnspecified:
+		// the preferred login name is used by default
+	}
+	return user.PreferredLoginName
+}
+
+func (p *Storage) attributeValues(ctx context.Context, mapping *domain.SAMLAttributeMapping, user *query.User, userGrants *query.UserGrants) ([]string, error) {
+	switch mapping.Source {
+	case domain.SAMLAttributeSourceUserMetadata:
+		metadata, err := p.query.GetUserMetadataByKey(ctx, true, user.ID, mapping.MetadataKey, false)
+		if zerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(metadata.Value)}, nil
+	case domain.SAMLAttributeSourceProjectRoles:
+		return projectRoles(userGrants), nil
+	default:
+		return userAttributeValues(mapping.Source, user), nil
+	}
+}
+
+func userAttributeValues(source domain.SAMLAttributeSource, user *query.User) []string {
+	var value string
+	switch source {
+	case domain.SAMLAttributeSourceUserID:
+		value = user.ID
+	case domain.SAMLAttributeSourceUsername:
+		value = user.Username
+	case domain.SAMLAttributeSourcePreferredLoginName:
+		value = user.PreferredLoginName
+	case domain.SAMLAttributeSourceOrganizationID:
+		value = user.ResourceOwner
+	}
+	if user.Human != nil {
+		switch source {
+		case domain.SAMLAttributeSourceEmail:
+			value = string(user.Human.Email)
+		case domain.SAMLAttributeSourceFirstName:
+			value = user.Human.FirstName
+		case domain.SAMLAttributeSourceLastName:
+			value = user.Human.LastName
+		case domain.SAMLAttributeSourceDisplayName:
+			value = user.Human.DisplayName
+		case domain.SAMLAttributeSourceNickName:
+			value = user.Human.NickName
+		case domain.SAMLAttributeSourcePhone:
+			value = string(user.Human.Phone)
+		case domain.SAMLAttributeSourcePreferredLanguage:
+			if !user.Human.PreferredLanguage.IsRoot() {
+				value = user.Human.PreferredLanguage.String()
+			}
+		}
+	}
+	if value == "" {
+		return nil
+	}
+	return []string{value}
+}
+
+func projectRoles(userGrants *query.UserGrants) []string {
+	if userGrants == nil {
+		return nil
+	}
+	roles := make([]string, 0)
+	for _, grant := range userGrants.UserGrants {
+		for _, role := range grant.Roles {
+			if !slices.Contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	return roles
+}
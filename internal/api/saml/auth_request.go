@@ -8,27 +8,32 @@ import (
 	"github.com/zitadel/saml/pkg/provider"
 	"github.com/zitadel/saml/pkg/provider/models"
 	"github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 )
 
-func (p *Provider) CreateErrorResponse(authReq models.AuthRequestInt, reason domain.SAMLErrorReason, description string) (string, string, error) {
+func (p *Provider) CreateErrorResponse(ctx context.Context, authReq models.AuthRequestInt, reason domain.SAMLErrorReason, description string) (string, string, error) {
 	resp := &provider.Response{
-		ProtocolBinding: authReq.GetBindingType(),
+		ProtocolBinding: responseProtocolBinding(authReq.GetBindingType()),
 		RelayState:      authReq.GetRelayState(),
 		AcsUrl:          authReq.GetAccessConsumerServiceURL(),
 		RequestID:       authReq.GetAuthRequestID(),
 		Issuer:          authReq.GetDestination(),
 		Audience:        authReq.GetIssuer(),
 	}
-	return createResponse(p.AuthCallbackErrorResponse(resp, domain.SAMLErrorReasonToString(reason), description), authReq.GetBindingType(), authReq.GetAccessConsumerServiceURL(), resp.RelayState, resp.SigAlg, resp.Signature)
+	samlResponse := p.AuthCallbackErrorResponse(resp, domain.SAMLErrorReasonToString(reason), description)
+	if authReq.GetBindingType() == ArtifactBinding {
+		return p.createArtifactResponse(ctx, samlResponse, authReq.GetIssuer(), resp.AcsUrl, resp.RelayState)
+	}
+	return createResponse(samlResponse, authReq.GetBindingType(), authReq.GetAccessConsumerServiceURL(), resp.RelayState, resp.SigAlg, resp.Signature)
 }
 
 func (p *Provider) CreateResponse(ctx context.Context, authReq models.AuthRequestInt) (string, string, error) {
 	resp := &provider.Response{
-		ProtocolBinding: authReq.GetBindingType(),
+		ProtocolBinding: responseProtocolBinding(authReq.GetBindingType()),
 		RelayState:      authReq.GetRelayState(),
 		AcsUrl:          authReq.GetAccessConsumerServiceURL(),
 		RequestID:       authReq.GetAuthRequestID(),
@@ -51,9 +56,21 @@ func (p *Provider) CreateResponse(ctx context.Context, authReq models.AuthReques
 		return "", "", err
 	}
 
+	if authReq.GetBindingType() == ArtifactBinding {
+		return p.createArtifactResponse(ctx, samlResponse, authReq.GetIssuer(), resp.AcsUrl, resp.RelayState)
+	}
 	return createResponse(samlResponse, authReq.GetBindingType(), authReq.GetAccessConsumerServiceURL(), resp.RelayState, resp.SigAlg, resp.Signature)
 }
 
+// createArtifactResponse stores the response and returns the URL to redirect to with the artifact.
+func (p *Provider) createArtifactResponse(ctx context.Context, samlResponse *samlp.ResponseType, entityID, acs, relayState string) (string, string, error) {
+	redirectURL, err := p.artifacts.redirectURL(ctx, samlResponse, entityID, acs, relayState)
+	if err != nil {
+		return "", "", err
+	}
+	return redirectURL, "", nil
+}
+
 func createResponse(samlResponse interface{}, binding, acs, relayState, sigAlg, sig string) (string, string, error) {
 	respData, err := xml.Marshal(samlResponse)
 	if err != nil {
@@ -89,3 +89,21 @@ func CreateAuthRequestToBusiness(ctx context.Context, authReq *samlp.AuthnReques
 		},
 	}
 }
+
+// CreateWSFedAuthRequestToBusiness creates an auth request for a WS-Federation sign-in.
+// The realm of the relying party is stored as issuer and the reply url as callback.
+func CreateWSFedAuthRequestToBusiness(ctx context.Context, applicationID, realm, reply, wctx, userAgentID string) *domain.AuthRequest {
+	return &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		ApplicationID: applicationID,
+		CallbackURI:   reply,
+		TransferState: wctx,
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		Request: &domain.AuthRequestSAML{
+			BindingType: WSFedBinding,
+			Issuer:      realm,
+			Destination: reply,
+		},
+	}
+}
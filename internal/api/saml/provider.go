@@ -12,6 +12,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
 	"github.com/zitadel/zitadel/internal/auth/repository"
+	"github.com/zitadel/zitadel/internal/cache"
+	"github.com/zitadel/zitadel/internal/cache/connector"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
@@ -31,7 +33,10 @@ type Config struct {
 
 type Provider struct {
 	*provider.Provider
-	command *command.Commands
+	command   *command.Commands
+	artifacts *artifacts
+	wsFed     *wsFederation
+	handler   http.Handler
 }
 
 func NewProvider(
@@ -48,6 +53,7 @@ func NewProvider(
 	instanceHandler,
 	userAgentCookie func(http.Handler) http.Handler,
 	accessHandler *middleware.AccessInterceptor,
+	cacheConnectors connector.Connectors,
 ) (*Provider, error) {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 
@@ -68,20 +74,28 @@ func NewProvider(
 		return nil, err
 	}
 
+	artifactCache, err := connector.StartCache[artifactIndex, string, *artifact](context.Background(), []artifactIndex{artifactIndexArtifact}, cache.PurposeSAMLArtifact, cacheConnectors.Config.SAMLArtifacts, cacheConnectors)
+	if err != nil {
+		return nil, err
+	}
+	artifacts := newArtifacts(artifactCache, provStorage, conf.ProviderConfig)
+	wsFed := &wsFederation{storage: provStorage, callbackPath: artifacts.callbackPath}
+
+	interceptors := []provider.HttpInterceptor{
+		middleware.CallDurationHandler,
+		middleware.RequestDetailsHandler(),
+		middleware.MetricsHandler(metricTypes),
+		middleware.TraceHandler(),
+		middleware.LogHandler("saml"),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		userAgentCookie,
+		accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(conf.ProviderConfig)),
+		http_utils.CopyHeadersToContext,
+		middleware.ActivityHandler,
+	}
 	options := []provider.Option{
-		provider.WithHttpInterceptors(
-			middleware.CallDurationHandler,
-			middleware.RequestDetailsHandler(),
-			middleware.MetricsHandler(metricTypes),
-			middleware.TraceHandler(),
-			middleware.LogHandler("saml"),
-			middleware.NoCacheInterceptor().Handler,
-			instanceHandler,
-			userAgentCookie,
-			accessHandler.HandleWithPublicAuthPathPrefixes(publicAuthPathPrefixes(conf.ProviderConfig)),
-			http_utils.CopyHeadersToContext,
-			middleware.ActivityHandler,
-		),
+		provider.WithHttpInterceptors(append(interceptors, artifacts.Handler, wsFed.Handler)...),
 		provider.WithCustomTimeFormat("2006-01-02T15:04:05.999Z"),
 	}
 	if !externalSecure {
@@ -97,12 +111,40 @@ func NewProvider(
 	if err != nil {
 		return nil, err
 	}
+	artifacts.provider = p
+	wsFed.provider = p
 	return &Provider{
-		p,
-		command,
+		Provider:  p,
+		command:   command,
+		artifacts: artifacts,
+		wsFed:     wsFed,
+		handler:   newHandler(p, artifacts, wsFed, interceptors),
 	}, nil
 }
 
+// HttpHandler returns the handler of the SAML library extended by the [ArtifactResolutionEndpoint]
+// and the WS-Federation endpoints ([WSFedEndpoint] and [WSFedMetadataEndpoint]).
+func (p *Provider) HttpHandler() http.Handler {
+	return p.handler
+}
+
+func newHandler(p *provider.Provider, artifacts *artifacts, wsFed *wsFederation, interceptors []provider.HttpInterceptor) http.Handler {
+	issuerFromRequest, _ := IssuerFromContext(false)
+	intercept := func(handler http.HandlerFunc) http.Handler {
+		var h http.Handler = handler
+		for i := len(interceptors) - 1; i >= 0; i-- {
+			h = interceptors[i](h)
+		}
+		return provider.NewIssuerInterceptor(issuerFromRequest).Handler(h)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("POST "+ArtifactResolutionEndpoint, intercept(artifacts.handleArtifactResolve))
+	mux.Handle(WSFedEndpoint, intercept(wsFed.handleRequest))
+	mux.Handle("GET "+WSFedMetadataEndpoint, intercept(wsFed.handleMetadata))
+	mux.Handle("/", p.HttpHandler())
+	return mux
+}
+
 func ContextToIssuer(ctx context.Context) string {
 	return http_utils.DomainContext(ctx).Origin() + HandlerPrefix
 }
@@ -145,11 +187,13 @@ func publicAuthPathPrefixes(config *provider.Config) []string {
 	metadataEndpoint := HandlerPrefix + provider.DefaultMetadataEndpoint
 	certificateEndpoint := HandlerPrefix + provider.DefaultCertificateEndpoint
 	ssoEndpoint := HandlerPrefix + provider.DefaultSingleSignOnEndpoint
+	artifactEndpoint := HandlerPrefix + ArtifactResolutionEndpoint
+	wsFedEndpoint := HandlerPrefix + WSFedEndpoint
 	if config.MetadataConfig != nil && config.MetadataConfig.Path != "" {
 		metadataEndpoint = HandlerPrefix + config.MetadataConfig.Path
 	}
 	if config.IDPConfig == nil || config.IDPConfig.Endpoints == nil {
-		return []string{metadataEndpoint, certificateEndpoint, ssoEndpoint}
+		return []string{metadataEndpoint, certificateEndpoint, ssoEndpoint, artifactEndpoint, wsFedEndpoint}
 	}
 	if config.IDPConfig.Endpoints.Certificate != nil && config.IDPConfig.Endpoints.Certificate.Relative() != "" {
 		certificateEndpoint = HandlerPrefix + config.IDPConfig.Endpoints.Certificate.Relative()
@@ -157,5 +201,5 @@ func publicAuthPathPrefixes(config *provider.Config) []string {
 	if config.IDPConfig.Endpoints.SingleSignOn != nil && config.IDPConfig.Endpoints.SingleSignOn.Relative() != "" {
 		ssoEndpoint = HandlerPrefix + config.IDPConfig.Endpoints.SingleSignOn.Relative()
 	}
-	return []string{metadataEndpoint, certificateEndpoint, ssoEndpoint}
+	return []string{metadataEndpoint, certificateEndpoint, ssoEndpoint, artifactEndpoint, wsFedEndpoint}
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -74,7 +75,52 @@ func (p *Storage) GetEntityByID(ctx context.Context, entityID string) (*servicep
 		return nil, err
 	}
 
-	return ServiceProviderFromBusiness(sp, p.defaultLoginURL, p.defaultLoginURLv2)
+	serviceProvider, err := ServiceProviderFromBusiness(sp, p.defaultLoginURL, p.defaultLoginURLv2)
+	if err != nil {
+		return nil, err
+	}
+	// the SAML library only supports the HTTP-POST and HTTP-Redirect binding for the response,
+	// the HTTP-Artifact binding is restored in [Storage.CreateAuthRequest]
+	if serviceProvider.Metadata.SPSSODescriptor != nil {
+		for i, acs := range serviceProvider.Metadata.SPSSODescriptor.AssertionConsumerService {
+			if acs.Binding == ArtifactBinding {
+				serviceProvider.Metadata.SPSSODescriptor.AssertionConsumerService[i].Binding = provider.PostBinding
+			}
+		}
+	}
+	return serviceProvider, nil
+}
+
+// artifactAssertionConsumerService returns the assertion consumer service and binding for the response
+// based on the original metadata of the service provider, which might use the HTTP-Artifact binding.
+func (p *Storage) artifactAssertionConsumerService(ctx context.Context, req *samlp.AuthnRequestType, acsURL, protocolBinding string) (string, string, error) {
+	sp, err := p.query.ActiveSAMLServiceProviderByID(ctx, req.Issuer.Text)
+	if err != nil {
+		return "", "", err
+	}
+	serviceProvider, err := ServiceProviderFromBusiness(sp, p.defaultLoginURL, p.defaultLoginURLv2)
+	if err != nil {
+		return "", "", err
+	}
+	if serviceProvider.Metadata.SPSSODescriptor == nil {
+		return acsURL, protocolBinding, nil
+	}
+	var acsIndex *int
+	if req.AssertionConsumerServiceIndex != "" {
+		if i, err := strconv.Atoi(req.AssertionConsumerServiceIndex); err == nil {
+			acsIndex = &i
+		}
+	}
+	artifactURL, binding := provider.GetAcsUrlAndBindingForResponse(
+		serviceProvider.Metadata.SPSSODescriptor.AssertionConsumerService,
+		req.ProtocolBinding,
+		req.AssertionConsumerServiceURL,
+		acsIndex,
+	)
+	if binding != ArtifactBinding {
+		return acsURL, protocolBinding, nil
+	}
+	return artifactURL, binding, nil
 }
 
 func (p *Storage) GetEntityIDByAppID(ctx context.Context, appID string) (string, error) {
@@ -82,6 +128,9 @@ func (p *Storage) GetEntityIDByAppID(ctx context.Context, appID string) (string,
 	if err != nil {
 		return "", err
 	}
+	if app.SAMLConfig == nil && app.WSFedConfig != nil {
+		return app.WSFedConfig.Realm, nil
+	}
 	return app.SAMLConfig.EntityID, nil
 }
 
@@ -105,6 +154,13 @@ func (p *Storage) CreateAuthRequest(ctx context.Context, req *samlp.AuthnRequest
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	if req.Issuer != nil {
+		acsUrl, protocolBinding, err = p.artifactAssertionConsumerService(ctx, req, acsUrl, protocolBinding)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// for backwards compatibility we pass the login client if set
 	headers, _ := http_utils.HeadersFromCtx(ctx)
 	loginClient := headers.Get(LoginClientHeader)
@@ -219,7 +275,17 @@ func (p *Storage) SetUserinfoWithUserID(ctx context.Context, applicationID strin
 		return err
 	}
 
-	setUserinfo(user, userinfo, attributes, customAttributes)
+	app, err := p.query.AppByID(ctx, applicationID, true)
+	if err != nil {
+		return err
+	}
+	if hasAttributeRelease(app.SAMLConfig) {
+		if err = p.setReleasedUserinfo(ctx, app.SAMLConfig, user, userGrants, userinfo, customAttributes); err != nil {
+			return err
+		}
+	} else {
+		setUserinfo(user, userinfo, attributes, customAttributes)
+	}
 
 	// trigger activity log for authentication for user
 	activity.Trigger(ctx, user.ResourceOwner, user.ID, activity.SAMLResponse, p.eventstore.FilterToQueryReducer)
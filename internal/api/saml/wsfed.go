@@ -0,0 +1,245 @@
+package saml
+
+import (
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/models"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/saml/wsfed"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// WSFedEndpoint is the passive requestor endpoint of the WS-Federation protocol.
+	WSFedEndpoint = "/wsfed"
+	// WSFedMetadataEndpoint is the well-known location of the federation metadata relative to the [WSFedEndpoint].
+	WSFedMetadataEndpoint = WSFedEndpoint + "/FederationMetadata/2007-06/FederationMetadata.xml"
+	// WSFedBinding is stored as binding type of the auth request to identify WS-Federation sign-ins on the callback.
+	WSFedBinding = wsfed.ProtocolNamespace
+
+	wsFedTokenLifetime = time.Hour
+)
+
+// wsFederation implements the WS-Federation passive requestor profile for relying parties
+// which do not support SAML 2.0 (e.g. applications based on .NET / SharePoint).
+// The sign-in is handled by the login (v1) as for SAML requests and the session and
+// certificates of the SAML provider are reused to issue the SAML 1.1 or 2.0 tokens.
+type wsFederation struct {
+	storage      *Storage
+	provider     *provider.Provider
+	callbackPath string
+}
+
+// Handler intercepts the callback of the login (v1) for WS-Federation sign-ins.
+func (f *wsFederation) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != f.callbackPath {
+			next.ServeHTTP(w, r)
+			return
+		}
+		authRequest, err := f.storage.AuthRequestByID(r.Context(), r.FormValue("id"))
+		if err != nil || authRequest.GetBindingType() != WSFedBinding {
+			next.ServeHTTP(w, r)
+			return
+		}
+		f.handleCallback(w, r, authRequest)
+	})
+}
+
+func (f *wsFederation) handleRequest(w http.ResponseWriter, r *http.Request) {
+	request, err := wsfed.ParseRequest(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if request.Action == wsfed.ActionSignIn {
+		f.handleSignIn(w, r, request)
+		return
+	}
+	f.handleSignOut(w, r, request)
+}
+
+// handleSignIn creates an auth request for the relying party and redirects to the login (v1).
+// The token is issued on the callback of the login, see [wsFederation.handleCallback].
+func (f *wsFederation) handleSignIn(w http.ResponseWriter, r *http.Request, request *wsfed.Request) {
+	ctx := r.Context()
+	rp, err := f.storage.query.ActiveWSFedRelyingPartyByRealm(ctx, request.Realm)
+	if err != nil {
+		wsFedError(w, err)
+		return
+	}
+	reply, ok := rp.ReplyURL(request.Reply)
+	if !ok {
+		http.Error(w, "wreply is not registered for the realm", http.StatusBadRequest)
+		return
+	}
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		http.Error(w, "no user agent id", http.StatusBadRequest)
+		return
+	}
+	authRequest, err := f.storage.repo.CreateAuthRequest(ctx, CreateWSFedAuthRequestToBusiness(ctx, rp.AppID, rp.Realm, reply, request.Context, userAgentID))
+	if err != nil {
+		wsFedError(w, err)
+		return
+	}
+	http.Redirect(w, r, f.storage.defaultLoginURL+authRequest.ID, http.StatusFound)
+}
+
+// handleCallback issues the token of the authenticated user and posts it to the relying party.
+func (f *wsFederation) handleCallback(w http.ResponseWriter, r *http.Request, authRequest models.AuthRequestInt) {
+	ctx := r.Context()
+	if !authRequest.Done() {
+		http.Error(w, "authentication not completed", http.StatusBadRequest)
+		return
+	}
+	rp, err := f.storage.query.ActiveWSFedRelyingPartyByRealm(ctx, authRequest.GetIssuer())
+	if err != nil {
+		wsFedError(w, err)
+		return
+	}
+	claims := new(wsfed.Claims)
+	if err = f.storage.SetUserinfoWithUserID(ctx, rp.AppID, claims, authRequest.GetUserID(), nil); err != nil {
+		wsFedError(w, err)
+		return
+	}
+	if rp.ProjectRoleAssertion {
+		if err = f.setRoles(r, claims, authRequest.GetUserID(), rp.AppID); err != nil {
+			wsFedError(w, err)
+			return
+		}
+	}
+	certificateAndKey, err := f.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		wsFedError(w, err)
+		return
+	}
+
+	now := time.Now().UTC()
+	token := &wsfed.Token{
+		Type:         wsFedTokenType(rp.TokenType),
+		ID:           provider.NewID(),
+		Issuer:       f.provider.GetEntityID(ctx),
+		Audience:     rp.Realm,
+		Subject:      claims.UserID,
+		IssueInstant: now,
+		NotOnOrAfter: now.Add(wsFedTokenLifetime),
+		AuthnInstant: now,
+		Claims:       claims.List(),
+	}
+	if authReq, ok := authRequest.(*AuthRequest); ok && !authReq.AuthTime.IsZero() {
+		token.AuthnInstant = authReq.AuthTime
+	}
+	result, err := wsfed.SignInResponse(token, certificateAndKey.Certificate, certificateAndKey.Key)
+	if err != nil {
+		wsFedError(w, err)
+		return
+	}
+	err = wsfed.WritePostForm(w, authRequest.GetAccessConsumerServiceURL(), result, authRequest.GetRelayState())
+	logging.OnError(err).Error("unable to write wsfed sign-in response")
+}
+
+func (f *wsFederation) setRoles(r *http.Request, claims *wsfed.Claims, userID, appID string) error {
+	grants, err := f.storage.getGrants(r.Context(), userID, appID)
+	if err != nil {
+		return err
+	}
+	roles := make([]string, 0)
+	for _, grant := range grants.UserGrants {
+		for _, role := range grant.Roles {
+			if !slices.Contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+	}
+	if len(roles) > 0 {
+		claims.SetCustomAttribute(wsfed.ClaimRole, "", "", roles)
+	}
+	return nil
+}
+
+// handleSignOut terminates the sessions of the user agent (wsignout1.0 and wsignoutcleanup1.0).
+// The user agent is only redirected to wreply if it is registered for the realm.
+func (f *wsFederation) handleSignOut(w http.ResponseWriter, r *http.Request, request *wsfed.Request) {
+	ctx := r.Context()
+	if userAgentID, ok := middleware.UserAgentIDFromCtx(ctx); ok {
+		sessions, err := f.storage.repo.UserSessionsByAgentID(ctx, userAgentID)
+		if err != nil {
+			wsFedError(w, err)
+			return
+		}
+		if len(sessions) > 0 {
+			ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: sessions[0].UserID})
+			if err = f.storage.command.HumansSignOut(ctx, userAgentID, sessions); err != nil {
+				wsFedError(w, err)
+				return
+			}
+		}
+	}
+	if request.Reply != "" && request.Realm != "" {
+		rp, err := f.storage.query.ActiveWSFedRelyingPartyByRealm(ctx, request.Realm)
+		if err == nil {
+			if reply, ok := rp.ReplyURL(request.Reply); ok {
+				http.Redirect(w, r, reply, http.StatusFound)
+				return
+			}
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	_, err := w.Write([]byte("signed out"))
+	logging.OnError(err).Error("unable to write wsfed sign-out response")
+}
+
+// handleMetadata publishes the federation metadata of the security token service.
+func (f *wsFederation) handleMetadata(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	responseKey, err := f.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		wsFedError(w, err)
+		return
+	}
+	metadataKey, err := f.storage.GetMetadataSigningKey(ctx)
+	if err != nil {
+		wsFedError(w, err)
+		return
+	}
+	metadata, err := (&wsfed.Metadata{
+		ID:                 provider.NewID(),
+		EntityID:           f.provider.GetEntityID(ctx),
+		Endpoint:           f.storage.contextToIssuer(ctx) + WSFedEndpoint,
+		SigningCertificate: responseKey.Certificate,
+	}).Sign(metadataKey.Certificate, metadataKey.Key)
+	if err != nil {
+		wsFedError(w, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/samlmetadata+xml")
+	_, err = w.Write([]byte(metadata))
+	logging.OnError(err).Error("unable to write wsfed metadata")
+}
+
+func wsFedTokenType(tokenType domain.WSFedTokenType) wsfed.TokenType {
+	if tokenType == domain.WSFedTokenTypeSAML20 {
+		return wsfed.TokenTypeSAML20
+	}
+	return wsfed.TokenTypeSAML11
+}
+
+func wsFedError(w http.ResponseWriter, err error) {
+	switch {
+	case zerrors.IsNotFound(err):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	case zerrors.IsErrorInvalidArgument(err), zerrors.IsPreconditionFailed(err):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		logging.WithError(err).Error("wsfed request failed")
+		http.Error(w, "internal error", http.StatusInternalServerError)
+	}
+}
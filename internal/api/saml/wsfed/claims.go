@@ -0,0 +1,105 @@
+package wsfed
+
+import (
+	"strings"
+)
+
+const (
+	claimsNamespace = "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/"
+
+	ClaimName         = claimsNamespace + "name"
+	ClaimUPN          = claimsNamespace + "upn"
+	ClaimEmail        = claimsNamespace + "emailaddress"
+	ClaimGivenName    = claimsNamespace + "givenname"
+	ClaimSurname      = claimsNamespace + "surname"
+	ClaimNameID       = claimsNamespace + "nameidentifier"
+	ClaimDisplayName  = "http://schemas.microsoft.com/identity/claims/displayname"
+	ClaimRole         = "http://schemas.microsoft.com/ws/2008/06/identity/claims/role"
+	customClaimPrefix = "urn:zitadel:iam:claims:"
+)
+
+// OfferedClaims are advertised in the federation metadata.
+var OfferedClaims = []string{
+	ClaimNameID,
+	ClaimName,
+	ClaimUPN,
+	ClaimEmail,
+	ClaimGivenName,
+	ClaimSurname,
+	ClaimDisplayName,
+	ClaimRole,
+}
+
+// Claim is a single attribute of the issued token.
+type Claim struct {
+	// Type is the claim type URI.
+	Type   string
+	Values []string
+}
+
+// split returns the namespace and the name of the claim as used by SAML 1.1 attributes.
+func (c Claim) split() (namespace, name string) {
+	i := strings.LastIndexAny(c.Type, "/:")
+	if i <= 0 || i == len(c.Type)-1 {
+		return strings.TrimSuffix(customClaimPrefix, ":"), c.Type
+	}
+	return c.Type[:i], c.Type[i+1:]
+}
+
+// Claims collects the user information released to the relying party.
+// It implements the [models.AttributeSetter] of the SAML provider,
+// so the attribute release of SAML applications can be reused.
+type Claims struct {
+	UserID string
+	claims []Claim
+}
+
+// List returns the claims in the order they were set.
+func (c *Claims) List() []Claim {
+	return c.claims
+}
+
+func (c *Claims) set(claimType string, values ...string) {
+	for i, claim := range c.claims {
+		if claim.Type == claimType {
+			c.claims[i].Values = values
+			return
+		}
+	}
+	c.claims = append(c.claims, Claim{Type: claimType, Values: values})
+}
+
+func (c *Claims) SetEmail(value string) {
+	c.set(ClaimEmail, value)
+}
+
+func (c *Claims) SetFullName(value string) {
+	c.set(ClaimDisplayName, value)
+}
+
+func (c *Claims) SetGivenName(value string) {
+	c.set(ClaimGivenName, value)
+}
+
+func (c *Claims) SetSurname(value string) {
+	c.set(ClaimSurname, value)
+}
+
+func (c *Claims) SetUserID(value string) {
+	c.UserID = value
+	c.set(ClaimNameID, value)
+}
+
+func (c *Claims) SetUsername(value string) {
+	c.set(ClaimName, value)
+	c.set(ClaimUPN, value)
+}
+
+// SetCustomAttribute adds the attribute as claim.
+// Names which are not URIs are prefixed with the zitadel claims namespace.
+func (c *Claims) SetCustomAttribute(name, _, _ string, values []string) {
+	if !strings.Contains(name, ":") {
+		name = customClaimPrefix + name
+	}
+	c.set(name, values...)
+}
@@ -0,0 +1,83 @@
+package wsfed
+
+import (
+	"crypto"
+	"encoding/base64"
+
+	"github.com/beevik/etree"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	ProtocolNamespace = "http://docs.oasis-open.org/wsfed/federation/200706"
+
+	nsMetadata = "urn:oasis:names:tc:SAML:2.0:metadata"
+	nsXSI      = "http://www.w3.org/2001/XMLSchema-instance"
+	nsAuth     = "http://docs.oasis-open.org/wsfed/authorization/200706"
+)
+
+// Metadata describes the security token service published as federation metadata.
+type Metadata struct {
+	ID       string
+	EntityID string
+	// Endpoint is the absolute URL of the passive requestor endpoint.
+	Endpoint string
+	// SigningCertificate (DER) is used to verify the issued tokens.
+	SigningCertificate []byte
+}
+
+// Sign creates the federation metadata document signed with the key.
+func (m *Metadata) Sign(certificate []byte, key crypto.Signer) (string, error) {
+	descriptor := etree.NewElement("md:EntityDescriptor")
+	descriptor.CreateAttr("xmlns:md", nsMetadata)
+	descriptor.CreateAttr("ID", m.ID)
+	descriptor.CreateAttr("entityID", m.EntityID)
+
+	role := descriptor.CreateElement("md:RoleDescriptor")
+	role.CreateAttr("xmlns:xsi", nsXSI)
+	role.CreateAttr("xmlns:fed", ProtocolNamespace)
+	role.CreateAttr("xsi:type", "fed:SecurityTokenServiceType")
+	role.CreateAttr("protocolSupportEnumeration", ProtocolNamespace)
+
+	keyDescriptor := role.CreateElement("md:KeyDescriptor")
+	keyDescriptor.CreateAttr("use", "signing")
+	keyInfo := keyDescriptor.CreateElement("KeyInfo")
+	keyInfo.CreateAttr("xmlns", "http://www.w3.org/2000/09/xmldsig#")
+	keyInfo.CreateElement("X509Data").
+		CreateElement("X509Certificate").SetText(base64.StdEncoding.EncodeToString(m.SigningCertificate))
+
+	tokenTypes := role.CreateElement("fed:TokenTypesOffered")
+	for _, tokenType := range []TokenType{TokenTypeSAML11, TokenTypeSAML20} {
+		tokenTypes.CreateElement("fed:TokenType").CreateAttr("Uri", string(tokenType))
+	}
+	claimTypes := role.CreateElement("fed:ClaimTypesOffered")
+	for _, claimType := range OfferedClaims {
+		claim := claimTypes.CreateElement("auth:ClaimType")
+		claim.CreateAttr("xmlns:auth", nsAuth)
+		claim.CreateAttr("Uri", claimType)
+		claim.CreateAttr("Optional", "true")
+	}
+
+	for _, tag := range []string{"fed:SecurityTokenServiceEndpoint", "fed:PassiveRequestorEndpoint"} {
+		endpoint := role.CreateElement(tag).CreateElement("wsa:EndpointReference")
+		endpoint.CreateAttr("xmlns:wsa", nsAddressing)
+		endpoint.CreateElement("wsa:Address").SetText(m.Endpoint)
+	}
+
+	signed, err := signEnveloped(descriptor, "ID", certificate, key)
+	if err != nil {
+		return "", err
+	}
+	// the schema of the metadata requires the signature to be the first child
+	moveSignature(signed, 0)
+
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	doc.SetRoot(signed)
+	metadata, err := doc.WriteToString()
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "WSFED-Iek2o", "unable to marshal metadata")
+	}
+	return metadata, nil
+}
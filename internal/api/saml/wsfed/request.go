@@ -0,0 +1,58 @@
+// Package wsfed implements the message formats of the WS-Federation passive requestor profile:
+// the sign-in and sign-out requests, the SAML tokens wrapped into a WS-Trust response
+// and the federation metadata of the security token service.
+package wsfed
+
+import (
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	ActionSignIn         = "wsignin1.0"
+	ActionSignOut        = "wsignout1.0"
+	ActionSignOutCleanup = "wsignoutcleanup1.0"
+)
+
+const (
+	paramAction  = "wa"
+	paramRealm   = "wtrealm"
+	paramReply   = "wreply"
+	paramContext = "wctx"
+)
+
+// Request is a message sent by the relying party to the passive requestor endpoint.
+type Request struct {
+	Action string
+	// Realm identifies the relying party.
+	Realm string
+	// Reply is the location the response is sent to.
+	// If empty, the registered default of the relying party is used.
+	Reply string
+	// Context is opaque to the identity provider and returned unchanged on sign-in.
+	Context string
+}
+
+// ParseRequest reads the WS-Federation parameters from the query or the form body.
+func ParseRequest(r *http.Request) (*Request, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "WSFED-Aiw4a", "unable to parse request")
+	}
+	req := &Request{
+		Action:  r.Form.Get(paramAction),
+		Realm:   r.Form.Get(paramRealm),
+		Reply:   r.Form.Get(paramReply),
+		Context: r.Form.Get(paramContext),
+	}
+	switch req.Action {
+	case ActionSignIn:
+		if req.Realm == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "WSFED-Oop3a", "wtrealm is required")
+		}
+	case ActionSignOut, ActionSignOutCleanup:
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "WSFED-eeM4u", "unsupported action %q", req.Action)
+	}
+	return req, nil
+}
@@ -0,0 +1,97 @@
+package wsfed
+
+import (
+	"crypto"
+	"html/template"
+	"net/http"
+
+	"github.com/beevik/etree"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	nsTrust      = "http://schemas.xmlsoap.org/ws/2005/02/trust"
+	nsUtility    = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-utility-1.0.xsd"
+	nsPolicy     = "http://schemas.xmlsoap.org/ws/2004/09/policy"
+	nsAddressing = "http://www.w3.org/2005/08/addressing"
+
+	requestTypeIssue = nsTrust + "/Issue"
+	keyTypeNoProof   = "http://schemas.xmlsoap.org/ws/2005/05/identity/NoProofKey"
+)
+
+// SignInResponse signs the token and wraps it into the WS-Trust RequestSecurityTokenResponse,
+// which is sent as wresult to the relying party.
+func SignInResponse(token *Token, certificate []byte, key crypto.Signer) (string, error) {
+	assertion, err := token.Sign(certificate, key)
+	if err != nil {
+		return "", err
+	}
+
+	rstr := etree.NewElement("t:RequestSecurityTokenResponse")
+	rstr.CreateAttr("xmlns:t", nsTrust)
+
+	lifetime := rstr.CreateElement("t:Lifetime")
+	created := lifetime.CreateElement("wsu:Created")
+	created.CreateAttr("xmlns:wsu", nsUtility)
+	created.SetText(formatTime(token.IssueInstant))
+	expires := lifetime.CreateElement("wsu:Expires")
+	expires.CreateAttr("xmlns:wsu", nsUtility)
+	expires.SetText(formatTime(token.NotOnOrAfter))
+
+	appliesTo := rstr.CreateElement("wsp:AppliesTo")
+	appliesTo.CreateAttr("xmlns:wsp", nsPolicy)
+	endpoint := appliesTo.CreateElement("wsa:EndpointReference")
+	endpoint.CreateAttr("xmlns:wsa", nsAddressing)
+	endpoint.CreateElement("wsa:Address").SetText(token.Audience)
+
+	rstr.CreateElement("t:RequestedSecurityToken").AddChild(assertion)
+	rstr.CreateElement("t:TokenType").SetText(string(token.Type))
+	rstr.CreateElement("t:RequestType").SetText(requestTypeIssue)
+	rstr.CreateElement("t:KeyType").SetText(keyTypeNoProof)
+
+	doc := etree.NewDocument()
+	doc.SetRoot(rstr)
+	result, err := doc.WriteToString()
+	if err != nil {
+		return "", zerrors.ThrowInternal(err, "WSFED-Reih7", "unable to marshal response")
+	}
+	return result, nil
+}
+
+var postTemplate = template.Must(template.New("post").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.getElementById('wsfedpost').submit()">
+<noscript>
+<p>Note: Since your browser does not support JavaScript, you must press the Continue button once to proceed.</p>
+</noscript>
+<form action="{{ .Reply }}" method="post" id="wsfedpost">
+<input type="hidden" name="wa" value="{{ .Action }}"/>
+<input type="hidden" name="wresult" value="{{ .Result }}"/>
+{{- if .Context }}
+<input type="hidden" name="wctx" value="{{ .Context }}"/>
+{{- end }}
+<noscript><input type="submit" value="Continue"/></noscript>
+</form>
+</body>
+</html>`))
+
+// WritePostForm renders the form posting the sign-in response to the reply location of the relying party.
+func WritePostForm(w http.ResponseWriter, reply, result, wctx string) error {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err := postTemplate.Execute(w, struct {
+		Reply   string
+		Action  string
+		Result  string
+		Context string
+	}{
+		Reply:   reply,
+		Action:  ActionSignIn,
+		Result:  result,
+		Context: wctx,
+	})
+	if err != nil {
+		return zerrors.ThrowInternal(err, "WSFED-ahV5o", "unable to write form")
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package wsfed
+
+import (
+	"crypto"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// TokenType is the URI identifying the format of the issued security token.
+type TokenType string
+
+const (
+	TokenTypeSAML11 TokenType = "urn:oasis:names:tc:SAML:1.0:assertion"
+	TokenTypeSAML20 TokenType = "urn:oasis:names:tc:SAML:2.0:assertion"
+)
+
+const (
+	nsSAML11 = "urn:oasis:names:tc:SAML:1.0:assertion"
+	nsSAML20 = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+	confirmationBearer11 = "urn:oasis:names:tc:SAML:1.0:cm:bearer"
+	confirmationBearer20 = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	authMethodPassword11 = "urn:oasis:names:tc:SAML:1.0:am:password"
+	authnContextPassword = "urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport"
+	nameIDUnspecified    = "urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified"
+
+	timeFormat = "2006-01-02T15:04:05Z"
+)
+
+// Token contains the information of the security token issued to the relying party.
+type Token struct {
+	Type TokenType
+	// ID must start with a letter or underscore, as it is referenced by the signature.
+	ID       string
+	Issuer   string
+	Audience string
+	Subject  string
+
+	IssueInstant time.Time
+	NotOnOrAfter time.Time
+	AuthnInstant time.Time
+
+	Claims []Claim
+}
+
+func formatTime(t time.Time) string {
+	return t.UTC().Format(timeFormat)
+}
+
+// Sign creates the assertion of the token and signs it enveloped with the key.
+// The certificate (DER) is included in the signature.
+func (t *Token) Sign(certificate []byte, key crypto.Signer) (*etree.Element, error) {
+	idAttribute := "ID"
+	assertion := t.saml20()
+	if t.Type == TokenTypeSAML11 {
+		idAttribute = "AssertionID"
+		assertion = t.saml11()
+	}
+	signed, err := signEnveloped(assertion, idAttribute, certificate, key)
+	if err != nil {
+		return nil, err
+	}
+	if t.Type != TokenTypeSAML11 {
+		// the schema of SAML 2.0 requires the signature to follow the issuer
+		moveSignature(signed, 1)
+	}
+	return signed, nil
+}
+
+func (t *Token) saml11() *etree.Element {
+	assertion := etree.NewElement("saml:Assertion")
+	assertion.CreateAttr("xmlns:saml", nsSAML11)
+	assertion.CreateAttr("MajorVersion", "1")
+	assertion.CreateAttr("MinorVersion", "1")
+	assertion.CreateAttr("AssertionID", t.ID)
+	assertion.CreateAttr("Issuer", t.Issuer)
+	assertion.CreateAttr("IssueInstant", formatTime(t.IssueInstant))
+
+	conditions := assertion.CreateElement("saml:Conditions")
+	conditions.CreateAttr("NotBefore", formatTime(t.IssueInstant))
+	conditions.CreateAttr("NotOnOrAfter", formatTime(t.NotOnOrAfter))
+	conditions.CreateElement("saml:AudienceRestrictionCondition").
+		CreateElement("saml:Audience").SetText(t.Audience)
+
+	subject11 := func(parent *etree.Element) {
+		subject := parent.CreateElement("saml:Subject")
+		subject.CreateElement("saml:NameIdentifier").SetText(t.Subject)
+		subject.CreateElement("saml:SubjectConfirmation").
+			CreateElement("saml:ConfirmationMethod").SetText(confirmationBearer11)
+	}
+
+	if len(t.Claims) > 0 {
+		statement := assertion.CreateElement("saml:AttributeStatement")
+		subject11(statement)
+		for _, claim := range t.Claims {
+			namespace, name := claim.split()
+			attribute := statement.CreateElement("saml:Attribute")
+			attribute.CreateAttr("AttributeName", name)
+			attribute.CreateAttr("AttributeNamespace", namespace)
+			for _, value := range claim.Values {
+				attribute.CreateElement("saml:AttributeValue").SetText(value)
+			}
+		}
+	}
+
+	authn := assertion.CreateElement("saml:AuthenticationStatement")
+	authn.CreateAttr("AuthenticationMethod", authMethodPassword11)
+	authn.CreateAttr("AuthenticationInstant", formatTime(t.AuthnInstant))
+	subject11(authn)
+	return assertion
+}
+
+func (t *Token) saml20() *etree.Element {
+	assertion := etree.NewElement("saml:Assertion")
+	assertion.CreateAttr("xmlns:saml", nsSAML20)
+	assertion.CreateAttr("ID", t.ID)
+	assertion.CreateAttr("Version", "2.0")
+	assertion.CreateAttr("IssueInstant", formatTime(t.IssueInstant))
+	assertion.CreateElement("saml:Issuer").SetText(t.Issuer)
+
+	subject := assertion.CreateElement("saml:Subject")
+	nameID := subject.CreateElement("saml:NameID")
+	nameID.CreateAttr("Format", nameIDUnspecified)
+	nameID.SetText(t.Subject)
+	confirmation := subject.CreateElement("saml:SubjectConfirmation")
+	confirmation.CreateAttr("Method", confirmationBearer20)
+	confirmation.CreateElement("saml:SubjectConfirmationData").
+		CreateAttr("NotOnOrAfter", formatTime(t.NotOnOrAfter))
+
+	conditions := assertion.CreateElement("saml:Conditions")
+	conditions.CreateAttr("NotBefore", formatTime(t.IssueInstant))
+	conditions.CreateAttr("NotOnOrAfter", formatTime(t.NotOnOrAfter))
+	conditions.CreateElement("saml:AudienceRestriction").
+		CreateElement("saml:Audience").SetText(t.Audience)
+
+	if len(t.Claims) > 0 {
+		statement := assertion.CreateElement("saml:AttributeStatement")
+		for _, claim := range t.Claims {
+			attribute := statement.CreateElement("saml:Attribute")
+			attribute.CreateAttr("Name", claim.Type)
+			for _, value := range claim.Values {
+				attribute.CreateElement("saml:AttributeValue").SetText(value)
+			}
+		}
+	}
+
+	authn := assertion.CreateElement("saml:AuthnStatement")
+	authn.CreateAttr("AuthnInstant", formatTime(t.AuthnInstant))
+	authn.CreateElement("saml:AuthnContext").
+		CreateElement("saml:AuthnContextClassRef").SetText(authnContextPassword)
+	return assertion
+}
+
+func signEnveloped(element *etree.Element, idAttribute string, certificate []byte, key crypto.Signer) (*etree.Element, error) {
+	ctx, err := dsig.NewSigningContext(key, [][]byte{certificate})
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WSFED-ieR7u", "unable to create signing context")
+	}
+	ctx.IdAttribute = idAttribute
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	if err = ctx.SetSignatureMethod(dsig.RSASHA256SignatureMethod); err != nil {
+		return nil, zerrors.ThrowInternal(err, "WSFED-Ea9ie", "unable to set signature method")
+	}
+	signed, err := ctx.SignEnveloped(element)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WSFED-yoo0E", "unable to sign")
+	}
+	return signed, nil
+}
+
+// moveSignature moves the signature, which is appended as last child, to the position.
+// The enveloped signature does not cover its own position.
+func moveSignature(element *etree.Element, position int) {
+	// the signature is appended to the child tokens without setting its parent,
+	// so it has to be removed by index
+	signature := element.RemoveChildAt(len(element.Child) - 1)
+	element.InsertChildAt(element.ChildElements()[position].Index(), signature)
+}
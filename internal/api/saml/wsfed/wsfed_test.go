@@ -0,0 +1,200 @@
+package wsfed
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestParseRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		want    *Request
+		wantErr func(error) bool
+	}{
+		{
+			name:    "unsupported action",
+			target:  "/wsfed?wa=wattr1.0",
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "sign in without realm",
+			target:  "/wsfed?wa=wsignin1.0",
+			wantErr: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name:   "sign in",
+			target: "/wsfed?wa=wsignin1.0&wtrealm=urn%3Arp&wreply=https%3A%2F%2Frp.example.com%2F&wctx=rm%3D0",
+			want: &Request{
+				Action:  ActionSignIn,
+				Realm:   "urn:rp",
+				Reply:   "https://rp.example.com/",
+				Context: "rm=0",
+			},
+		},
+		{
+			name:   "sign out without realm",
+			target: "/wsfed?wa=wsignout1.0",
+			want:   &Request{Action: ActionSignOut},
+		},
+		{
+			name:   "sign out cleanup",
+			target: "/wsfed?wa=wsignoutcleanup1.0&wreply=https%3A%2F%2Frp.example.com%2F",
+			want:   &Request{Action: ActionSignOutCleanup, Reply: "https://rp.example.com/"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRequest(httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestClaim_split(t *testing.T) {
+	tests := []struct {
+		claimType     string
+		wantNamespace string
+		wantName      string
+	}{
+		{ClaimEmail, "http://schemas.xmlsoap.org/ws/2005/05/identity/claims", "emailaddress"},
+		{"urn:zitadel:iam:claims:department", "urn:zitadel:iam:claims", "department"},
+		{"department", "urn:zitadel:iam:claims", "department"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.claimType, func(t *testing.T) {
+			namespace, name := Claim{Type: tt.claimType}.split()
+			assert.Equal(t, tt.wantNamespace, namespace)
+			assert.Equal(t, tt.wantName, name)
+		})
+	}
+}
+
+func TestSignInResponse(t *testing.T) {
+	certificate, key := testCertificate(t)
+	claims := new(Claims)
+	claims.SetUserID("userID")
+	claims.SetUsername("user@example.com")
+	claims.SetEmail("user@example.com")
+	claims.SetCustomAttribute("department", "", "", []string{"a", "b"})
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		tokenType TokenType
+		assertion string
+		audience  string
+	}{
+		{
+			tokenType: TokenTypeSAML11,
+			assertion: "./t:RequestSecurityTokenResponse/t:RequestedSecurityToken/saml:Assertion",
+			audience:  "./saml:Conditions/saml:AudienceRestrictionCondition/saml:Audience",
+		},
+		{
+			tokenType: TokenTypeSAML20,
+			assertion: "./t:RequestSecurityTokenResponse/t:RequestedSecurityToken/saml:Assertion",
+			audience:  "./saml:Conditions/saml:AudienceRestriction/saml:Audience",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.tokenType), func(t *testing.T) {
+			result, err := SignInResponse(&Token{
+				Type:         tt.tokenType,
+				ID:           "_id",
+				Issuer:       "https://issuer.example.com/saml/v2/metadata",
+				Audience:     "urn:rp",
+				Subject:      claims.UserID,
+				IssueInstant: now,
+				NotOnOrAfter: now.Add(time.Hour),
+				AuthnInstant: now,
+				Claims:       claims.List(),
+			}, certificate, key)
+			require.NoError(t, err)
+
+			doc := etree.NewDocument()
+			require.NoError(t, doc.ReadFromString(result))
+			assert.Equal(t, string(tt.tokenType), doc.FindElement("./t:RequestSecurityTokenResponse/t:TokenType").Text())
+			assertion := doc.FindElement(tt.assertion)
+			require.NotNil(t, assertion)
+			assert.Equal(t, "urn:rp", assertion.FindElement(tt.audience).Text())
+			if tt.tokenType == TokenTypeSAML20 {
+				assert.Equal(t, "Signature", assertion.ChildElements()[1].Tag)
+			}
+			validateSignature(t, certificate, assertion)
+		})
+	}
+}
+
+func TestMetadata_Sign(t *testing.T) {
+	certificate, key := testCertificate(t)
+	metadata, err := (&Metadata{
+		ID:                 "_id",
+		EntityID:           "https://issuer.example.com/saml/v2/metadata",
+		Endpoint:           "https://issuer.example.com/saml/v2/wsfed",
+		SigningCertificate: certificate,
+	}).Sign(certificate, key)
+	require.NoError(t, err)
+
+	doc := etree.NewDocument()
+	require.NoError(t, doc.ReadFromString(metadata))
+	root := doc.Root()
+	assert.Equal(t, "Signature", root.ChildElements()[0].Tag)
+	assert.Equal(t, "https://issuer.example.com/saml/v2/wsfed",
+		root.FindElement("./md:RoleDescriptor/fed:PassiveRequestorEndpoint/wsa:EndpointReference/wsa:Address").Text())
+	validateSignature(t, certificate, root)
+}
+
+func TestWritePostForm(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	require.NoError(t, WritePostForm(recorder, "https://rp.example.com/", `<t:RequestSecurityTokenResponse a="b"/>`, "rm=0"))
+	body := recorder.Body.String()
+	assert.Contains(t, body, `action="https://rp.example.com/"`)
+	assert.Contains(t, body, `name="wa" value="wsignin1.0"`)
+	assert.Contains(t, body, `name="wctx" value="rm=0"`)
+	assert.False(t, strings.Contains(body, `a="b"`), "result must be escaped")
+}
+
+func validateSignature(t *testing.T, certificate []byte, element *etree.Element) {
+	cert, err := x509.ParseCertificate(certificate)
+	require.NoError(t, err)
+	ctx := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: []*x509.Certificate{cert}})
+	ctx.Clock = dsig.NewFakeClockAt(cert.NotBefore.Add(time.Minute))
+	if element.SelectAttr("AssertionID") != nil {
+		ctx.IdAttribute = "AssertionID"
+	}
+	_, err = ctx.Validate(element.Copy())
+	require.NoError(t, err)
+}
+
+func testCertificate(t *testing.T) ([]byte, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "wsfed"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	certificate, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return certificate, key
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain/federatedlogout"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
@@ -36,6 +37,7 @@ import (
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml/requesttracker"
+	"github.com/zitadel/zitadel/internal/idp/providers/x509"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -190,6 +192,8 @@ func (l *Login) handleIDP(w http.ResponseWriter, r *http.Request, authReq *domai
 		provider, err = l.ldapProvider(r.Context(), identityProvider)
 	case domain.IDPTypeSAML:
 		provider, err = l.samlProvider(r.Context(), identityProvider)
+	case domain.IDPTypeX509:
+		provider, err = l.x509Provider(r.Context(), identityProvider)
 	case domain.IDPTypeUnspecified:
 		fallthrough
 	default:
@@ -370,6 +374,7 @@ func (l *Login) handleExternalLoginCallback(w http.ResponseWriter, r *http.Reque
 		}
 	case domain.IDPTypeJWT,
 		domain.IDPTypeLDAP,
+		domain.IDPTypeX509,
 		domain.IDPTypeUnspecified:
 		fallthrough
 	default:
@@ -458,7 +463,7 @@ func (l *Login) handleExternalUserAuthenticated(
 	user idp.User,
 	callback func(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest),
 ) {
-	externalUser := mapIDPUserToExternalUser(user, provider.ID)
+	user, externalUser := l.mapIDPUserWithRules(r.Context(), provider, session, user)
 	// ensure the linked IDP is added to the login policy
 	if err := l.authRepo.SelectExternalIDP(r.Context(), authReq.ID, provider.ID, authReq.AgentID, authReq.SelectedIDPConfigArgs); err != nil {
 		l.renderError(w, r, authReq, err)
@@ -516,6 +521,7 @@ func (l *Login) handleExternalUserAuthenticated(
 			return
 		}
 	}
+	l.reconcileIDPAssignments(r.Context(), authReq.UserID, authReq.UserOrgID, externalUser)
 	callback(w, r, authReq)
 }
 
@@ -610,7 +616,7 @@ func (l *Login) autoLinkUser(r *http.Request, authReq *domain.AuthRequest, user
 //   - creation by user
 //   - linking to existing user
 func (l *Login) createOrLinkUser(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, provider *query.IDPTemplate, externalUser *domain.ExternalUser, changed bool) (userLinked bool) {
-	resourceOwner := determineResourceOwner(r.Context(), authReq)
+	resourceOwner := determineExternalUserResourceOwner(r.Context(), authReq, externalUser)
 	orgIAMPolicy, err := l.getOrgDomainPolicy(r, resourceOwner)
 	if err != nil {
 		l.renderExternalNotFoundOption(w, r, authReq, nil, nil, nil, err)
@@ -786,6 +792,12 @@ func (l *Login) handleExternalNotFoundOptionCheck(w http.ResponseWriter, r *http
 		return
 	}
 	linkingUser := mapExternalNotFoundOptionFormDataToLoginUser(data)
+	// keep the organization and roles mapped by the IdP mapping rules
+	if len(authReq.LinkingUsers) > 0 {
+		mappedUser := authReq.LinkingUsers[len(authReq.LinkingUsers)-1]
+		linkingUser.OrganizationID = mappedUser.OrganizationID
+		linkingUser.Assignments = mappedUser.Assignments
+	}
 	l.registerExternalUser(w, r, authReq, linkingUser)
 }
 
@@ -794,7 +806,7 @@ func (l *Login) handleExternalNotFoundOptionCheck(w http.ResponseWriter, r *http
 //
 // it is called from either the [autoCreateExternalUser] or [handleExternalNotFoundOptionCheck]
 func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, externalUser *domain.ExternalUser) {
-	resourceOwner := determineResourceOwner(r.Context(), authReq)
+	resourceOwner := determineExternalUserResourceOwner(r.Context(), authReq, externalUser)
 
 	orgIamPolicy, err := l.getOrgDomainPolicy(r, resourceOwner)
 	if err != nil {
@@ -829,6 +841,7 @@ func (l *Login) registerExternalUser(w http.ResponseWriter, r *http.Request, aut
 		l.renderError(w, r, authReq, err)
 		return
 	}
+	l.reconcileIDPAssignments(r.Context(), authReq.UserID, resourceOwner, externalUser)
 	l.renderNextStep(w, r, authReq)
 }
 
@@ -1038,6 +1051,26 @@ func (l *Login) ldapProvider(ctx context.Context, identityProvider *query.IDPTem
 	), nil
 }
 
+func (l *Login) x509Provider(ctx context.Context, identityProvider *query.IDPTemplate) (*x509.Provider, error) {
+	opts := []x509.ProviderOpts{
+		x509.WithIDField(identityProvider.X509IDPTemplate.IDField),
+		x509.WithUsernameField(identityProvider.X509IDPTemplate.UsernameField),
+	}
+	if identityProvider.X509IDPTemplate.RevocationCheckRequired {
+		opts = append(opts, x509.WithRevocationCheckRequired())
+	}
+	return x509.New(
+		identityProvider.Name,
+		identityProvider.X509IDPTemplate.CertificateHeader,
+		identityProvider.X509IDPTemplate.TrustedProxies,
+		identityProvider.X509IDPTemplate.CACertificates,
+		identityProvider.X509IDPTemplate.CRLs,
+		identityProvider.X509IDPTemplate.OCSPResponses,
+		l.baseURL(ctx)+EndpointX509Login+"?"+QueryAuthRequestID+"=",
+		opts...,
+	)
+}
+
 func (l *Login) googleProvider(ctx context.Context, identityProvider *query.IDPTemplate) (*google.Provider, error) {
 	errorHandler := func(w http.ResponseWriter, r *http.Request, errorType string, errorDesc string, state string) {
 		logging.Errorf("token exchanged failed: %s - %s (state: %s)", errorType, errorType, state)
@@ -1348,6 +1381,71 @@ func tokens(session idp.Session) *oidc.Tokens[*oidc.IDTokenClaims] {
 	return nil
 }
 
+// mapIDPUserWithRules evaluates the mapping rules of the provider (if any) and returns the user with the mapped values applied.
+// Failing rules are ignored, so the value provided by the IdP is used instead.
+func (l *Login) mapIDPUserWithRules(ctx context.Context, provider *query.IDPTemplate, session idp.Session, user idp.User) (idp.User, *domain.ExternalUser) {
+	if len(provider.MappingRules) == 0 {
+		return user, mapIDPUserToExternalUser(user, provider.ID)
+	}
+	logger := logging.WithFields("instance", authz.GetInstance(ctx).InstanceID(), "idp", provider.ID)
+	mapper, err := mapping.New(provider.MappingRules)
+	if err != nil {
+		logger.WithError(err).Warn("invalid idp mapping rules")
+		return user, mapIDPUserToExternalUser(user, provider.ID)
+	}
+	input, err := mapping.InputFromUser(user, session)
+	if err != nil {
+		logger.WithError(err).Warn("unable to read idp user for mapping")
+		return user, mapIDPUserToExternalUser(user, provider.ID)
+	}
+	result, err := mapper.Map(input)
+	if err != nil {
+		logger.WithError(err).Warn("unable to map idp user")
+		return user, mapIDPUserToExternalUser(user, provider.ID)
+	}
+	for _, ruleErr := range result.Errors {
+		logger.WithError(ruleErr).Info("idp mapping rule failed")
+	}
+	user = result.User(user)
+	externalUser := mapIDPUserToExternalUser(user, provider.ID)
+	externalUser.Metadatas = result.MetadataList()
+	externalUser.Assignments = result.Assignments()
+	if result.OrganizationID != "" {
+		if _, err := l.query.OrgByID(ctx, result.OrganizationID); err != nil {
+			logger.WithError(err).WithField("org", result.OrganizationID).Warn("mapped organization not found")
+		} else {
+			externalUser.OrganizationID = result.OrganizationID
+		}
+	}
+	return user, externalUser
+}
+
+// determineExternalUserResourceOwner returns the organization mapped by the IdP mapping rules,
+// unless a specific organization was requested.
+func determineExternalUserResourceOwner(ctx context.Context, authReq *domain.AuthRequest, externalUser *domain.ExternalUser) string {
+	if (authReq == nil || authReq.RequestedOrgID == "") && externalUser != nil && externalUser.OrganizationID != "" {
+		return externalUser.OrganizationID
+	}
+	return determineResourceOwner(ctx, authReq)
+}
+
+// reconcileIDPAssignments applies the project roles and groups mapped by the IdP mapping rules to the user.
+// Failures must not prevent the login, so they are only logged.
+func (l *Login) reconcileIDPAssignments(ctx context.Context, userID, resourceOwner string, externalUser *domain.ExternalUser) {
+	if externalUser.Assignments == nil || userID == "" {
+		return
+	}
+	logger := logging.WithFields("user", userID, "idp", externalUser.IDPConfigID)
+	details, err := l.command.ReconcileIDPAssignments(setContext(ctx, resourceOwner), userID, resourceOwner, externalUser.IDPConfigID, externalUser.Assignments)
+	if err != nil {
+		logger.WithError(err).Error("unable to reconcile idp assignments")
+		return
+	}
+	if len(details.InvalidGroupIDs) > 0 {
+		logger.WithField("groups", details.InvalidGroupIDs).Warn("mapped groups not found")
+	}
+}
+
 func mapIDPUserToExternalUser(user idp.User, id string) *domain.ExternalUser {
 	return &domain.ExternalUser{
 		IDPConfigID:       id,
@@ -41,6 +41,7 @@ type Login struct {
 	consolePath         string
 	oidcAuthCallbackURL func(context.Context, string) string
 	samlAuthCallbackURL func(context.Context, string) string
+	casAuthCallbackURL  func(context.Context, string) string
 	idpConfigAlg        crypto.EncryptionAlgorithm
 	userCodeAlg         crypto.EncryptionAlgorithm
 	caches              *Caches
@@ -191,7 +192,7 @@ func CreateLogin(
 	authRepo *eventsourcing.EsRepository,
 	staticStorage static.Storage,
 	consolePath string,
-	oidcAuthCallbackURL, samlAuthCallbackURL func(context.Context, string) string,
+	oidcAuthCallbackURL, samlAuthCallbackURL, casAuthCallbackURL func(context.Context, string) string,
 	externalSecure bool,
 	userAgentCookie, issuerInterceptor, oidcInstanceHandler, samlInstanceHandler, assetCache, accessHandler mux.MiddlewareFunc,
 	userCodeAlg, idpConfigAlg crypto.EncryptionAlgorithm,
@@ -202,6 +203,7 @@ func CreateLogin(
 	login := &Login{
 		oidcAuthCallbackURL: oidcAuthCallbackURL,
 		samlAuthCallbackURL: samlAuthCallbackURL,
+		casAuthCallbackURL:  casAuthCallbackURL,
 		externalSecure:      externalSecure,
 		consolePath:         consolePath,
 		command:             command,
@@ -22,6 +22,7 @@ const (
 	EndpointJWTCallback                   = "/login/jwt/callback"
 	EndpointLDAPLogin                     = "/login/ldap"
 	EndpointLDAPCallback                  = "/login/ldap/callback"
+	EndpointX509Login                     = "/login/x509"
 	EndpointPasswordlessLogin             = "/login/passwordless"
 	EndpointPasswordlessRegistration      = "/login/passwordless/init"
 	EndpointPasswordlessPrompt            = "/login/passwordless/prompt"
@@ -129,6 +130,7 @@ func CreateRouter(login *Login, interceptors ...mux.MiddlewareFunc) *mux.Router
 	router.HandleFunc(EndpointLoginSuccess, login.handleLoginSuccess).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLDAPLogin, login.handleLDAP).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLDAPCallback, login.handleLDAPCallback).Methods(http.MethodPost)
+	router.HandleFunc(EndpointX509Login, login.handleX509).Methods(http.MethodGet)
 	router.SkipClean(true).Handle("", http.RedirectHandler(HandlerPrefix+"/", http.StatusMovedPermanently))
 	router.HandleFunc(EndpointDeviceAuth, login.handleDeviceAuthUserCode).Methods(http.MethodGet, http.MethodPost)
 	router.HandleFunc(EndpointDeviceAuthAction, login.handleDeviceAuthAction).Methods(http.MethodGet, http.MethodPost)
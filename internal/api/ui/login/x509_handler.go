@@ -0,0 +1,51 @@
+package login
+
+import (
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// handleX509 authenticates the user with the client certificate forwarded by the trusted proxy
+// in the header configured on the selected X.509 IDP.
+// The header is ignored on requests not sent by one of the trusted proxies of the IDP.
+func (l *Login) handleX509(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+	identityProvider, err := l.getIDPByID(r, authReq.SelectedIDPConfigID)
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, err)
+		return
+	}
+	if identityProvider.Type != domain.IDPTypeX509 {
+		l.externalAuthFailed(w, r, authReq, zerrors.ThrowInvalidArgument(nil, "LOGIN-Ckw3x", "Errors.ExternalIDP.IDPTypeNotImplemented"))
+		return
+	}
+	provider, err := l.x509Provider(r.Context(), identityProvider)
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, err)
+		return
+	}
+	certificate, err := provider.ForwardedCertificate(r)
+	if err != nil {
+		l.externalAuthFailed(w, r, authReq, zerrors.ThrowUnauthenticated(err, "LOGIN-Tq7xp", "Errors.IDP.UntrustedProxy"))
+		return
+	}
+	session := provider.GetSession(certificate)
+
+	user, err := session.FetchUser(r.Context())
+	if err != nil {
+		if _, _, actionErr := l.runPostExternalAuthenticationActions(new(domain.ExternalUser), nil, authReq, r, nil, err); actionErr != nil {
+			logging.WithError(err).Error("both external user authentication and action post authentication failed")
+		}
+		l.externalAuthFailed(w, r, authReq, zerrors.ThrowUnauthenticated(err, "LOGIN-Rf4ty", "Errors.IDP.InvalidClientCertificate"))
+		return
+	}
+	l.handleExternalUserAuthenticated(w, r, authReq, identityProvider, session, user, l.renderNextStep)
+}
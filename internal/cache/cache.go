@@ -19,6 +19,8 @@ const (
 	PurposeOrganization
 	PurposeIdPFormCallback
 	PurposeFederatedLogout
+	PurposeSAMLArtifact
+	PurposeCASServiceTicket
 )
 
 // Cache stores objects with a value of type `V`.
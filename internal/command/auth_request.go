@@ -173,6 +173,41 @@ func (c *Commands) AddAuthRequestCode(ctx context.Context, authRequestID, code s
 		&authrequest.NewAggregate(writeModel.AggregateID, authz.GetInstance(ctx).InstanceID()).Aggregate))
 }
 
+// ExchangeAuthRequestCode marks the code of the auth request as exchanged and the auth request as succeeded,
+// without creating an OIDC session. It's used by protocols (e.g. CAS) which only borrow the auth request
+// to drive the login UI and issue their own tickets.
+func (c *Commands) ExchangeAuthRequestCode(ctx context.Context, authRequestID string) (_ *CurrentAuthRequest, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if authRequestID == "" {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieK3u", "Errors.AuthRequest.InvalidCode")
+	}
+	writeModel, err := c.getAuthRequestWriteModel(ctx, authRequestID)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.AuthRequestState != domain.AuthRequestStateCodeAdded {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ub3ee", "Errors.AuthRequest.NoCode")
+	}
+	sessionModel := NewSessionWriteModel(writeModel.SessionID, authz.GetInstance(ctx).InstanceID())
+	if err = c.eventstore.FilterToQueryReducer(ctx, sessionModel); err != nil {
+		return nil, err
+	}
+	if err = sessionModel.CheckIsActive(); err != nil {
+		return nil, err
+	}
+	authRequestAgg := &authrequest.NewAggregate(writeModel.AggregateID, authz.GetInstance(ctx).InstanceID()).Aggregate
+	err = c.pushAppendAndReduce(ctx, writeModel,
+		authrequest.NewCodeExchangedEvent(ctx, authRequestAgg),
+		authrequest.NewSucceededEvent(ctx, authRequestAgg),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return authRequestWriteModelToCurrentAuthRequest(writeModel), nil
+}
+
 func authRequestWriteModelToCurrentAuthRequest(writeModel *AuthRequestWriteModel) (_ *CurrentAuthRequest) {
 	return &CurrentAuthRequest{
 		AuthRequest: &AuthRequest{
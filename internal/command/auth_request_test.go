@@ -1361,3 +1361,179 @@ func TestCommands_AddAuthRequestCode(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_ExchangeAuthRequestCode(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	authRequestAddedEvent := func() eventstore.Event {
+		return eventFromEventPusher(
+			authrequest.NewAddedEvent(mockCtx, &authrequest.NewAggregate("V2_authRequestID", "instanceID").Aggregate,
+				"loginClient",
+				"appID",
+				"https://issuer.com/cas/callback",
+				"https://service.com/login",
+				"",
+				[]string{"openid"},
+				nil,
+				domain.OIDCResponseTypeCode,
+				domain.OIDCResponseModeQuery,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				nil,
+				false,
+				"issuer",
+				"",
+			),
+		)
+	}
+	sessionLinkedEvent := func() eventstore.Event {
+		return eventFromEventPusher(
+			authrequest.NewSessionLinkedEvent(mockCtx, &authrequest.NewAggregate("V2_authRequestID", "instanceID").Aggregate,
+				"sessionID",
+				"userID",
+				testNow,
+				[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+			),
+		)
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+	}
+	type res struct {
+		authReq *CurrentAuthRequest
+		wantErr error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"empty id error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: mockCtx,
+				id:  "",
+			},
+			res{
+				wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-ieK3u", "Errors.AuthRequest.InvalidCode"),
+			},
+		},
+		{
+			"no code added error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						authRequestAddedEvent(),
+						sessionLinkedEvent(),
+					),
+				),
+			},
+			args{
+				ctx: mockCtx,
+				id:  "V2_authRequestID",
+			},
+			res{
+				wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ub3ee", "Errors.AuthRequest.NoCode"),
+			},
+		},
+		{
+			"session terminated error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						authRequestAddedEvent(),
+						sessionLinkedEvent(),
+						eventFromEventPusher(
+							authrequest.NewCodeAddedEvent(mockCtx, &authrequest.NewAggregate("V2_authRequestID", "instanceID").Aggregate),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(mockCtx, &session.NewAggregate("sessionID", "instanceID").Aggregate, nil),
+						),
+						eventFromEventPusher(
+							session.NewTerminateEvent(mockCtx, &session.NewAggregate("sessionID", "instanceID").Aggregate),
+						),
+					),
+				),
+			},
+			args{
+				ctx: mockCtx,
+				id:  "V2_authRequestID",
+			},
+			res{
+				wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Hewfq", "Errors.Session.Terminated"),
+			},
+		},
+		{
+			"success",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						authRequestAddedEvent(),
+						sessionLinkedEvent(),
+						eventFromEventPusher(
+							authrequest.NewCodeAddedEvent(mockCtx, &authrequest.NewAggregate("V2_authRequestID", "instanceID").Aggregate),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							session.NewAddedEvent(mockCtx, &session.NewAggregate("sessionID", "instanceID").Aggregate, nil),
+						),
+						eventFromEventPusher(
+							session.NewUserCheckedEvent(mockCtx, &session.NewAggregate("sessionID", "instanceID").Aggregate,
+								"userID", "org1", testNow, nil),
+						),
+					),
+					expectPush(
+						authrequest.NewCodeExchangedEvent(mockCtx, &authrequest.NewAggregate("V2_authRequestID", "instanceID").Aggregate),
+						authrequest.NewSucceededEvent(mockCtx, &authrequest.NewAggregate("V2_authRequestID", "instanceID").Aggregate),
+					),
+				),
+			},
+			args{
+				ctx: mockCtx,
+				id:  "V2_authRequestID",
+			},
+			res{
+				authReq: &CurrentAuthRequest{
+					AuthRequest: &AuthRequest{
+						ID:           "V2_authRequestID",
+						LoginClient:  "loginClient",
+						ClientID:     "appID",
+						RedirectURI:  "https://issuer.com/cas/callback",
+						State:        "https://service.com/login",
+						Scope:        []string{"openid"},
+						ResponseType: domain.OIDCResponseTypeCode,
+						ResponseMode: domain.OIDCResponseModeQuery,
+						Issuer:       "issuer",
+					},
+					SessionID:   "sessionID",
+					UserID:      "userID",
+					AuthMethods: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+					AuthTime:    testNow,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.ExchangeAuthRequestCode(tt.args.ctx, tt.args.id)
+			require.ErrorIs(t, err, tt.res.wantErr)
+			assert.Equal(t, tt.res.authReq, got)
+		})
+	}
+}
@@ -933,16 +933,18 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 		keyAlgorithm                    crypto.EncryptionAlgorithm
 	}
 	type args struct {
-		ctx                  context.Context
-		deviceCode           string
-		backChannelLogoutURI string
+		ctx                   context.Context
+		deviceCode            string
+		backChannelLogoutURI  string
+		frontChannelLogoutURI string
 	}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    *OIDCSession
-		wantErr error
+		name                string
+		fields              fields
+		args                args
+		accessTokenAudience AccessTokenAudienceResolver
+		want                *OIDCSession
+		wantErr             error
 	}{
 		{
 			name: "device auth filter error",
@@ -955,6 +957,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"device1",
 				"",
+				"",
 			},
 			wantErr: io.ErrClosedPipe,
 		},
@@ -980,6 +983,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			wantErr: DeviceAuthStateError(domain.DeviceAuthStateInitiated),
 		},
@@ -994,6 +998,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			wantErr: zerrors.ThrowNotFound(nil, "COMMAND-ua1Vo", "Errors.DeviceAuth.NotFound"),
 		},
@@ -1023,6 +1028,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			wantErr: DeviceAuthStateError(domain.DeviceAuthStateExpired),
 		},
@@ -1055,6 +1061,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			wantErr: DeviceAuthStateError(domain.DeviceAuthStateExpired),
 		},
@@ -1087,6 +1094,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			wantErr: DeviceAuthStateError(domain.DeviceAuthStateDenied),
 		},
@@ -1125,6 +1133,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			wantErr: DeviceAuthStateError(domain.DeviceAuthStateDone),
 		},
@@ -1189,6 +1198,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			wantErr: zerrors.ThrowPreconditionFailed(nil, "OIDCS-kj3g2", "Errors.User.NotActive"),
 		},
@@ -1252,6 +1262,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 						oidcsession.NewAccessTokenAddedEvent(context.Background(),
 							&oidcsession.NewAggregate("V2_oidcSessionID", "org1").Aggregate,
 							"at_accessTokenID", []string{"openid", "offline_access"}, time.Hour, domain.TokenReasonAuthRequest, nil,
+							nil,
 						),
 						deviceauth.NewDoneEvent(ctx,
 							deviceauth.NewAggregate("123", "instance1"),
@@ -1268,6 +1279,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			want: &OIDCSession{
 				TokenID:           "V2_oidcSessionID-at_accessTokenID",
@@ -1289,6 +1301,174 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				SessionID: "sessionID",
 			},
 		},
+		{
+			name: "approved with resource, access token audience restricted",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusherWithInstanceID(
+							"instance1",
+							deviceauth.NewAddedEvent(
+								ctx,
+								deviceauth.NewAggregate("123", "instance1"),
+								"clientID", "123", "456", time.Now().Add(-time.Minute),
+								[]string{"openid", "offline_access"},
+								[]string{"audience"}, false, "",
+							),
+						),
+						eventFromEventPusherWithInstanceID(
+							"instance1",
+							deviceauth.NewApprovedEvent(ctx,
+								deviceauth.NewAggregate("123", "instance1"),
+								"userID", "org1",
+								[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+								testNow, &language.Afrikaans, &domain.UserAgent{
+									FingerprintID: gu.Ptr("fp1"),
+									IP:            net.ParseIP("1.2.3.4"),
+									Description:   gu.Ptr("firefox"),
+									Header:        http.Header{"foo": []string{"bar"}},
+								},
+								"sessionID",
+							),
+						),
+					),
+					expectFilter(
+						user.NewHumanAddedEvent(
+							ctx,
+							&user.NewAggregate("userID", "org1").Aggregate,
+							"username",
+							"firstname",
+							"lastname",
+							"nickname",
+							"displayname",
+							language.English,
+							domain.GenderUnspecified,
+							"email",
+							false,
+						),
+					),
+					expectFilter(), // token lifetime
+					expectPush(
+						oidcsession.NewAddedEvent(context.Background(), &oidcsession.NewAggregate("V2_oidcSessionID", "org1").Aggregate,
+							"userID", "org1", "sessionID", "clientID", []string{"audience"}, []string{"openid", "offline_access"},
+							[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword}, testNow, "", &language.Afrikaans, &domain.UserAgent{
+								FingerprintID: gu.Ptr("fp1"),
+								IP:            net.ParseIP("1.2.3.4"),
+								Description:   gu.Ptr("firefox"),
+								Header:        http.Header{"foo": []string{"bar"}},
+							},
+						),
+						oidcsession.NewAccessTokenAddedEvent(context.Background(),
+							&oidcsession.NewAggregate("V2_oidcSessionID", "org1").Aggregate,
+							"at_accessTokenID", []string{"openid", "offline_access"}, time.Hour, domain.TokenReasonAuthRequest, nil,
+							[]string{"audience"},
+						),
+						deviceauth.NewDoneEvent(ctx,
+							deviceauth.NewAggregate("123", "instance1"),
+						),
+					),
+				),
+				idGenerator:                     mock.NewIDGeneratorExpectIDs(t, "oidcSessionID", "accessTokenID"),
+				defaultAccessTokenLifetime:      time.Hour,
+				defaultRefreshTokenLifetime:     7 * 24 * time.Hour,
+				defaultRefreshTokenIdleLifetime: 24 * time.Hour,
+				keyAlgorithm:                    crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx,
+				"123",
+				"",
+				"",
+			},
+			accessTokenAudience: func(_ context.Context, granted []string) ([]string, error) {
+				return granted, nil
+			},
+			want: &OIDCSession{
+				TokenID:           "V2_oidcSessionID-at_accessTokenID",
+				ClientID:          "clientID",
+				UserID:            "userID",
+				Audience:          []string{"audience"},
+				Expiration:        time.Time{}.Add(time.Hour),
+				Scope:             []string{"openid", "offline_access"},
+				AuthMethods:       []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+				AuthTime:          testNow,
+				PreferredLanguage: &language.Afrikaans,
+				UserAgent: &domain.UserAgent{
+					FingerprintID: gu.Ptr("fp1"),
+					IP:            net.ParseIP("1.2.3.4"),
+					Description:   gu.Ptr("firefox"),
+					Header:        http.Header{"foo": []string{"bar"}},
+				},
+				Reason:              domain.TokenReasonAuthRequest,
+				SessionID:           "sessionID",
+				AccessTokenAudience: []string{"audience"},
+			},
+		},
+		{
+			name: "approved with unknown resource, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusherWithInstanceID(
+							"instance1",
+							deviceauth.NewAddedEvent(
+								ctx,
+								deviceauth.NewAggregate("123", "instance1"),
+								"clientID", "123", "456", time.Now().Add(-time.Minute),
+								[]string{"openid", "offline_access"},
+								[]string{"audience"}, false, "",
+							),
+						),
+						eventFromEventPusherWithInstanceID(
+							"instance1",
+							deviceauth.NewApprovedEvent(ctx,
+								deviceauth.NewAggregate("123", "instance1"),
+								"userID", "org1",
+								[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+								testNow, &language.Afrikaans, &domain.UserAgent{
+									FingerprintID: gu.Ptr("fp1"),
+									IP:            net.ParseIP("1.2.3.4"),
+									Description:   gu.Ptr("firefox"),
+									Header:        http.Header{"foo": []string{"bar"}},
+								},
+								"sessionID",
+							),
+						),
+					),
+					expectFilter(
+						user.NewHumanAddedEvent(
+							ctx,
+							&user.NewAggregate("userID", "org1").Aggregate,
+							"username",
+							"firstname",
+							"lastname",
+							"nickname",
+							"displayname",
+							language.English,
+							domain.GenderUnspecified,
+							"email",
+							false,
+						),
+					),
+					expectFilter(), // token lifetime
+				),
+				idGenerator:                     mock.NewIDGeneratorExpectIDs(t, "oidcSessionID"),
+				defaultAccessTokenLifetime:      time.Hour,
+				defaultRefreshTokenLifetime:     7 * 24 * time.Hour,
+				defaultRefreshTokenIdleLifetime: 24 * time.Hour,
+				keyAlgorithm:                    crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			},
+			args: args{
+				ctx,
+				"123",
+				"",
+				"",
+			},
+			accessTokenAudience: func(context.Context, []string) ([]string, error) {
+				return nil, io.ErrClosedPipe
+			},
+			wantErr: io.ErrClosedPipe,
+		},
 		{
 			name: "approved with backChannelLogout, success",
 			fields: fields{
@@ -1356,6 +1536,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 						oidcsession.NewAccessTokenAddedEvent(context.Background(),
 							&oidcsession.NewAggregate("V2_oidcSessionID", "org1").Aggregate,
 							"at_accessTokenID", []string{"openid", "offline_access"}, time.Hour, domain.TokenReasonAuthRequest, nil,
+							nil,
 						),
 						deviceauth.NewDoneEvent(ctx,
 							deviceauth.NewAggregate("123", "instance1"),
@@ -1372,6 +1553,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"backChannelLogoutURI",
+				"",
 			},
 			want: &OIDCSession{
 				TokenID:           "V2_oidcSessionID-at_accessTokenID",
@@ -1453,6 +1635,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 						oidcsession.NewAccessTokenAddedEvent(context.Background(),
 							&oidcsession.NewAggregate("V2_oidcSessionID", "org1").Aggregate,
 							"at_accessTokenID", []string{"openid", "offline_access"}, time.Hour, domain.TokenReasonAuthRequest, nil,
+							nil,
 						),
 						oidcsession.NewRefreshTokenAddedEvent(context.Background(), &oidcsession.NewAggregate("V2_oidcSessionID", "org1").Aggregate,
 							"rt_refreshTokenID", 7*24*time.Hour, 24*time.Hour,
@@ -1472,6 +1655,7 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				ctx,
 				"123",
 				"",
+				"",
 			},
 			want: &OIDCSession{
 				TokenID:           "V2_oidcSessionID-at_accessTokenID",
@@ -1506,7 +1690,11 @@ func TestCommands_CreateOIDCSessionFromDeviceAuth(t *testing.T) {
 				keyAlgorithm:                    tt.fields.keyAlgorithm,
 				authAlgorithm:                   &mockAuthCrypto{},
 			}
-			got, err := c.CreateOIDCSessionFromDeviceAuth(tt.args.ctx, tt.args.deviceCode, tt.args.backChannelLogoutURI)
+			got, err := c.CreateOIDCSessionFromDeviceAuth(tt.args.ctx, tt.args.deviceCode, &OIDCSessionOptions{
+				BackChannelLogoutURI:  tt.args.backChannelLogoutURI,
+				FrontChannelLogoutURI: tt.args.frontChannelLogoutURI,
+				AccessTokenAudience:   tt.accessTokenAudience,
+			})
 			c.jobs.Wait()
 
 			require.ErrorIs(t, err, tt.wantErr)
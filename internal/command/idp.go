@@ -2,10 +2,13 @@ package command
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/providers/x509"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
@@ -137,6 +140,19 @@ type AppleProvider struct {
 	IDPOptions idp.Options
 }
 
+type X509Provider struct {
+	Name                    string
+	CertificateHeader       string
+	TrustedProxies          []string
+	CACertificates          []byte
+	CRLs                    [][]byte
+	OCSPResponses           [][]byte
+	RevocationCheckRequired bool
+	IDField                 domain.X509CertificateField
+	UsernameField           domain.X509CertificateField
+	IDPOptions              idp.Options
+}
+
 type ZitadelProvider struct {
 	Name              string
 	Issuer            string
@@ -232,3 +248,37 @@ func IDPProviderWriteModel(ctx context.Context, filter preparation.FilterToQuery
 
 	return allWriteModel, err
 }
+
+// validateX509Provider trims and checks the provider, especially that the CA certificates and revocation lists can be parsed.
+func validateX509Provider(provider *X509Provider) error {
+	if provider.Name = strings.TrimSpace(provider.Name); provider.Name == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gk2ls", "Errors.Invalid.Argument")
+	}
+	if provider.CertificateHeader = strings.TrimSpace(provider.CertificateHeader); provider.CertificateHeader == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Vq4bn", "Errors.IDP.CertificateHeaderMissing")
+	}
+	if len(provider.TrustedProxies) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Pz3gq", "Errors.IDP.TrustedProxiesMissing")
+	}
+	for i, proxy := range provider.TrustedProxies {
+		provider.TrustedProxies[i] = strings.TrimSpace(proxy)
+	}
+	if len(provider.CACertificates) == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Hs9cd", "Errors.IDP.CACertificatesMissing")
+	}
+	if !provider.IDField.Valid() || !provider.UsernameField.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Ob3jw", "Errors.IDP.InvalidCertificateField")
+	}
+	_, err := x509.New(provider.Name, provider.CertificateHeader, provider.TrustedProxies, provider.CACertificates, provider.CRLs, provider.OCSPResponses, "")
+	switch {
+	case errors.Is(err, x509.ErrInvalidProxy):
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-Kd8wu", "Errors.IDP.InvalidTrustedProxy")
+	case errors.Is(err, x509.ErrNoCACertificates):
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-Tn6xe", "Errors.IDP.InvalidCACertificates")
+	case errors.Is(err, x509.ErrInvalidCRL):
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-Lp1zs", "Errors.IDP.InvalidCRL")
+	case err != nil:
+		return zerrors.ThrowInvalidArgument(err, "COMMAND-Ie7ra", "Errors.Invalid.Argument")
+	}
+	return nil
+}
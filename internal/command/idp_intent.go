@@ -8,6 +8,7 @@ import (
 	"net/url"
 
 	"github.com/crewjam/saml/samlsp"
+	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 
 	"github.com/zitadel/zitadel/internal/command/preparation"
@@ -15,6 +16,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
 	"github.com/zitadel/zitadel/internal/idp/providers/apple"
 	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
 	"github.com/zitadel/zitadel/internal/idp/providers/github"
@@ -178,6 +180,10 @@ func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWr
 	if err != nil {
 		return "", err
 	}
+	assignments, err := c.idpAssignments(ctx, writeModel.IDPID, idpUser, idpSession)
+	if err != nil {
+		return "", err
+	}
 	cmd := idpintent.NewSucceededEvent(
 		ctx,
 		IDPIntentAggregateFromWriteModel(&writeModel.WriteModel),
@@ -190,6 +196,7 @@ func (c *Commands) SucceedIDPIntent(ctx context.Context, writeModel *IDPIntentWr
 		idToken,
 		idpSession.ExpiresAt(),
 	)
+	cmd.Assignments = assignments
 	err = c.pushAppendAndReduce(ctx, writeModel, cmd)
 	if err != nil {
 		return "", err
@@ -214,6 +221,10 @@ func (c *Commands) SucceedSAMLIDPIntent(ctx context.Context, writeModel *IDPInte
 	if err != nil {
 		return "", err
 	}
+	assignments, err := c.idpAssignments(ctx, writeModel.IDPID, idpUser, session)
+	if err != nil {
+		return "", err
+	}
 	cmd := idpintent.NewSAMLSucceededEvent(
 		ctx,
 		IDPIntentAggregateFromWriteModel(&writeModel.WriteModel),
@@ -224,6 +235,7 @@ func (c *Commands) SucceedSAMLIDPIntent(ctx context.Context, writeModel *IDPInte
 		assertionEnc,
 		session.ExpiresAt(),
 	)
+	cmd.Assignments = assignments
 	err = c.pushAppendAndReduce(ctx, writeModel, cmd)
 	if err != nil {
 		return "", err
@@ -260,6 +272,10 @@ func (c *Commands) SucceedLDAPIDPIntent(ctx context.Context, writeModel *IDPInte
 	for _, item := range session.Entry.Attributes {
 		attributes[item.Name] = item.Values
 	}
+	assignments, err := c.idpAssignments(ctx, writeModel.IDPID, idpUser, session)
+	if err != nil {
+		return "", err
+	}
 	cmd := idpintent.NewLDAPSucceededEvent(
 		ctx,
 		IDPIntentAggregateFromWriteModel(&writeModel.WriteModel),
@@ -270,6 +286,7 @@ func (c *Commands) SucceedLDAPIDPIntent(ctx context.Context, writeModel *IDPInte
 		attributes,
 		session.ExpiresAt(),
 	)
+	cmd.Assignments = assignments
 	err = c.pushAppendAndReduce(ctx, writeModel, cmd)
 	if err != nil {
 		return "", err
@@ -277,6 +294,39 @@ func (c *Commands) SucceedLDAPIDPIntent(ctx context.Context, writeModel *IDPInte
 	return token, nil
 }
 
+// idpAssignments evaluates the mapping rules of the IdP to determine the project roles and groups of the user.
+// If the IdP has no mapping rules, nil is returned, so the assignments of the user are left untouched.
+// Mapping errors must not prevent the login, so they are only logged and the assignments are left untouched as well.
+func (c *Commands) idpAssignments(ctx context.Context, idpID string, idpUser idp.User, session idp.Session) (*domain.IDPAssignments, error) {
+	rulesWriteModel := NewIDPMappingRulesWriteModel(idpID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, rulesWriteModel); err != nil {
+		return nil, err
+	}
+	if len(rulesWriteModel.Rules) == 0 {
+		return nil, nil
+	}
+	logger := logging.WithFields("idp", idpID)
+	mapper, err := mapping.New(rulesWriteModel.Rules)
+	if err != nil {
+		logger.WithError(err).Warn("invalid idp mapping rules")
+		return nil, nil
+	}
+	input, err := mapping.InputFromUser(idpUser, session)
+	if err != nil {
+		logger.WithError(err).Warn("unable to read idp user for mapping")
+		return nil, nil
+	}
+	result, err := mapper.Map(input)
+	if err != nil {
+		logger.WithError(err).Warn("unable to map idp user")
+		return nil, nil
+	}
+	for _, ruleErr := range result.Errors {
+		logger.WithError(ruleErr).Info("idp mapping rule failed")
+	}
+	return result.Assignments(), nil
+}
+
 func (c *Commands) FailIDPIntent(ctx context.Context, writeModel *IDPIntentWriteModel, reason string) error {
 	cmd := idpintent.NewFailedEvent(
 		ctx,
@@ -32,6 +32,10 @@ type IDPIntentWriteModel struct {
 	RequestID string
 	Assertion *crypto.CryptoValue
 
+	// Assignments are the project roles and groups mapped by the rules of the IdP,
+	// which are reconciled once the intent is checked in a session.
+	Assignments *domain.IDPAssignments
+
 	State                domain.IDPIntentState
 	succeededAt          time.Time
 	maxIdPIntentLifetime time.Duration
@@ -110,6 +114,7 @@ func (wm *IDPIntentWriteModel) reduceSAMLSucceededEvent(e *idpintent.SAMLSucceed
 	wm.IDPUserID = e.IDPUserID
 	wm.IDPUserName = e.IDPUserName
 	wm.Assertion = e.Assertion
+	wm.Assignments = e.Assignments
 	wm.State = domain.IDPIntentStateSucceeded
 	wm.succeededAt = e.CreationDate()
 	wm.expiresAt = e.ExpiresAt
@@ -121,6 +126,7 @@ func (wm *IDPIntentWriteModel) reduceLDAPSucceededEvent(e *idpintent.LDAPSucceed
 	wm.IDPUserID = e.IDPUserID
 	wm.IDPUserName = e.IDPUserName
 	wm.IDPEntryAttributes = e.EntryAttributes
+	wm.Assignments = e.Assignments
 	wm.State = domain.IDPIntentStateSucceeded
 	wm.succeededAt = e.CreationDate()
 	wm.expiresAt = e.ExpiresAt
@@ -134,6 +140,7 @@ func (wm *IDPIntentWriteModel) reduceOAuthSucceededEvent(e *idpintent.SucceededE
 	wm.IDPAccessToken = e.IDPAccessToken
 	wm.IDPRefreshToken = e.IDPRefreshToken
 	wm.IDPIDToken = e.IDPIDToken
+	wm.Assignments = e.Assignments
 	wm.State = domain.IDPIntentStateSucceeded
 	wm.succeededAt = e.CreationDate()
 	wm.expiresAt = e.ExpiresAt
@@ -1124,6 +1124,7 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 			fields{
 				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 				eventstore: expectEventstore(
+					expectFilter(),
 					expectPush(
 						func() eventstore.Command {
 							event := idpintent.NewSucceededEvent(
@@ -1176,6 +1177,91 @@ func TestCommands_SucceedIDPIntent(t *testing.T) {
 				token: "aWQ",
 			},
 		},
+		{
+			"push with assignments",
+			fields{
+				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewOIDCIDPAddedEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+								"idp",
+								"name",
+								"issuer",
+								"clientID",
+								nil,
+								nil,
+								false,
+								false,
+								rep_idp.Options{},
+							),
+						),
+						eventFromEventPusher(
+							instance.NewIDPMappingRulesSetEvent(context.Background(), &instance.NewAggregate("instance").Aggregate,
+								"idp",
+								[]domain.IDPMappingRule{
+									{
+										Target:     domain.IDPMappingTargetGroups,
+										Language:   domain.IDPMappingLanguageCEL,
+										Expression: "['group1']",
+									},
+								},
+							),
+						),
+					),
+					expectPush(
+						func() eventstore.Command {
+							event := idpintent.NewSucceededEvent(
+								context.Background(),
+								&idpintent.NewAggregate("id", "instance").Aggregate,
+								[]byte(`{"sub":"id","preferred_username":"username"}`),
+								"id",
+								"username",
+								"",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("accessToken"),
+								},
+								nil,
+								"",
+								time.Time{},
+							)
+							event.Assignments = &domain.IDPAssignments{
+								ProjectRoles: map[string][]string{},
+								GroupIDs:     []string{"group1"},
+							}
+							return event
+						}(),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				writeModel: func() *IDPIntentWriteModel {
+					writeModel := NewIDPIntentWriteModel("id", "instance", 0)
+					writeModel.IDPID = "idp"
+					return writeModel
+				}(),
+				idpSession: &openid.Session{
+					Tokens: &oidc.Tokens[*oidc.IDTokenClaims]{
+						Token: &oauth2.Token{
+							AccessToken: "accessToken",
+						},
+					},
+				},
+				idpUser: openid.NewUser(&oidc.UserInfo{
+					Subject: "id",
+					UserInfoProfile: oidc.UserInfoProfile{
+						PreferredUsername: "username",
+					},
+				}),
+			},
+			res{
+				token: "aWQ",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -1235,6 +1321,7 @@ func TestCommands_SucceedSAMLIDPIntent(t *testing.T) {
 			fields{
 				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 				eventstore: expectEventstore(
+					expectFilter(),
 					expectPush(
 						idpintent.NewSAMLSucceededEvent(
 							context.Background(),
@@ -1276,6 +1363,7 @@ func TestCommands_SucceedSAMLIDPIntent(t *testing.T) {
 			fields{
 				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 				eventstore: expectEventstore(
+					expectFilter(),
 					expectPush(
 						idpintent.NewSAMLSucceededEvent(
 							context.Background(),
@@ -1423,6 +1511,7 @@ func TestCommands_SucceedLDAPIDPIntent(t *testing.T) {
 			fields{
 				idpConfigEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
 				eventstore: expectEventstore(
+					expectFilter(),
 					expectPush(
 						idpintent.NewLDAPSucceededEvent(
 							context.Background(),
@@ -0,0 +1,151 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const minLDAPSyncInterval = 5 * time.Minute
+
+// SetInstanceLDAPSync replaces the synchronization configuration of an instance LDAP IdP.
+// The users are synced into the organization of the configuration.
+func (c *Commands) SetInstanceLDAPSync(ctx context.Context, id string, config *domain.LDAPSyncConfig) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if config.Enabled {
+		if config.OrganizationID == "" {
+			return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Px7dk", "Errors.IDP.LDAPSyncOrganizationMissing")
+		}
+		if err := c.checkOrgExists(ctx, config.OrganizationID); err != nil {
+			return nil, err
+		}
+	}
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	return c.setLDAPSync(ctx, instanceID, id, config, func(ctx context.Context, config *domain.LDAPSyncConfig) eventstore.Command {
+		return instance.NewIDPLDAPSyncSetEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, config)
+	})
+}
+
+// SetOrgLDAPSync replaces the synchronization configuration of an organization LDAP IdP.
+// The users are always synced into the organization of the IdP.
+func (c *Commands) SetOrgLDAPSync(ctx context.Context, resourceOwner, id string, config *domain.LDAPSyncConfig) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Zr5gm", "Errors.ResourceOwnerMissing")
+	}
+	if config.OrganizationID != "" && config.OrganizationID != resourceOwner {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Dq2wv", "Errors.IDP.InvalidLDAPSyncConfig")
+	}
+	config.OrganizationID = resourceOwner
+	return c.setLDAPSync(ctx, resourceOwner, id, config, func(ctx context.Context, config *domain.LDAPSyncConfig) eventstore.Command {
+		return org.NewIDPLDAPSyncSetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, config)
+	})
+}
+
+func (c *Commands) setLDAPSync(
+	ctx context.Context,
+	resourceOwner, id string,
+	config *domain.LDAPSyncConfig,
+	setEvent func(context.Context, *domain.LDAPSyncConfig) eventstore.Command,
+) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Fh6sj", "Errors.IDMissing")
+	}
+	if err := validateLDAPSyncConfig(config); err != nil {
+		return nil, err
+	}
+	writeModel := NewLDAPSyncWriteModel(id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() || writeModel.ResourceOwner != resourceOwner {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ys3nb", "Errors.IDPConfig.NotExisting")
+	}
+	if writeModel.Type != domain.IDPTypeLDAP {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Kv9te", "Errors.IDP.LDAPSyncNotSupported")
+	}
+	if writeModel.Config == *config {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	if err := c.pushAppendAndReduce(ctx, writeModel, setEvent(ctx, config)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func validateLDAPSyncConfig(config *domain.LDAPSyncConfig) error {
+	if !config.DeltaAttribute.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Bt4xa", "Errors.IDP.InvalidLDAPSyncConfig")
+	}
+	if config.Filter != "" {
+		if _, err := goldap.CompileFilter(config.Filter); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "COMMAND-Wc8pe", "Errors.IDP.InvalidLDAPSyncConfig")
+		}
+	}
+	if !config.Enabled {
+		return nil
+	}
+	if config.Interval < minLDAPSyncInterval {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Gq5hn", "Errors.IDP.InvalidLDAPSyncConfig")
+	}
+	// users removed from the directory are only detected by full runs
+	if config.FullSyncInterval != 0 && config.FullSyncInterval < config.Interval ||
+		config.DeactivateMissing && config.DeltaAttribute != domain.LDAPSyncDeltaAttributeNone && config.FullSyncInterval == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Jx2mu", "Errors.IDP.InvalidLDAPSyncConfig")
+	}
+	return nil
+}
+
+// LDAPSync returns the synchronization configuration and the state of the previous runs of an LDAP IdP.
+func (c *Commands) LDAPSync(ctx context.Context, id string) (_ *LDAPSyncWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel := NewLDAPSyncWriteModel(id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() || writeModel.Type != domain.IDPTypeLDAP {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Ht3vw", "Errors.IDPConfig.NotExisting")
+	}
+	return writeModel, nil
+}
+
+// FinishLDAPSyncRun records the result of a synchronization run and schedules the next one.
+func (c *Commands) FinishLDAPSyncRun(ctx context.Context, id string, run *domain.LDAPSyncRun) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel, err := c.LDAPSync(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	runID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	nextRunAt := run.FinishedAt.Add(writeModel.Config.Interval)
+	var event eventstore.Command
+	if writeModel.IsInstanceIDP() {
+		event = instance.NewIDPLDAPSyncRunFinishedEvent(ctx, &instance.NewAggregate(writeModel.InstanceID).Aggregate, id, runID, run, nextRunAt)
+	} else {
+		event = org.NewIDPLDAPSyncRunFinishedEvent(ctx, &org.NewAggregate(writeModel.ResourceOwner).Aggregate, id, runID, run, nextRunAt)
+	}
+	if err := c.pushAppendAndReduce(ctx, writeModel, event); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
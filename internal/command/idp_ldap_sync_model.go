@@ -0,0 +1,126 @@
+package command
+
+import (
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type LDAPSyncWriteModel struct {
+	IDPTypeWriteModel
+
+	Config domain.LDAPSyncConfig
+	// Cursor is the highest value of the delta attribute of the last successful run.
+	Cursor         string
+	LastFullSyncAt time.Time
+	// DeactivatedUserIDs are the users deactivated by a run, which have not been active since.
+	// Only these users are reactivated, if they are found in the directory again.
+	DeactivatedUserIDs []string
+}
+
+func NewLDAPSyncWriteModel(id string) *LDAPSyncWriteModel {
+	return &LDAPSyncWriteModel{
+		IDPTypeWriteModel: *NewIDPTypeWriteModel(id),
+	}
+}
+
+func (wm *LDAPSyncWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.IDPLDAPSyncSetEvent:
+			wm.reduceSet(&e.LDAPSyncSetEvent)
+		case *org.IDPLDAPSyncSetEvent:
+			wm.reduceSet(&e.LDAPSyncSetEvent)
+		case *instance.IDPLDAPSyncRunFinishedEvent:
+			wm.reduceRunFinished(&e.LDAPSyncRunFinishedEvent)
+		case *org.IDPLDAPSyncRunFinishedEvent:
+			wm.reduceRunFinished(&e.LDAPSyncRunFinishedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.reduceRemoved(e.ID)
+		case *org.IDPRemovedEvent:
+			wm.reduceRemoved(e.ID)
+		}
+	}
+	return wm.IDPTypeWriteModel.Reduce()
+}
+
+func (wm *LDAPSyncWriteModel) reduceSet(e *idp.LDAPSyncSetEvent) {
+	if wm.ID != e.ID {
+		return
+	}
+	config := e.Config()
+	// a changed search or target invalidates the state of previous runs, so the next run is a full sync
+	if config.OrganizationID != wm.Config.OrganizationID ||
+		config.Filter != wm.Config.Filter ||
+		config.DeltaAttribute != wm.Config.DeltaAttribute {
+		wm.Cursor = ""
+		wm.LastFullSyncAt = time.Time{}
+	}
+	wm.Config = *config
+}
+
+func (wm *LDAPSyncWriteModel) reduceRunFinished(e *idp.LDAPSyncRunFinishedEvent) {
+	if wm.ID != e.ID {
+		return
+	}
+	// (re)activations are recorded by aborted runs as well
+	wm.DeactivatedUserIDs = slices.DeleteFunc(wm.DeactivatedUserIDs, func(userID string) bool {
+		return slices.Contains(e.ReleasedUserIDs, userID)
+	})
+	for _, userID := range e.DeactivatedUserIDs {
+		if !slices.Contains(wm.DeactivatedUserIDs, userID) {
+			wm.DeactivatedUserIDs = append(wm.DeactivatedUserIDs, userID)
+		}
+	}
+	if e.Error != "" {
+		return
+	}
+	if e.Cursor != "" {
+		wm.Cursor = e.Cursor
+	}
+	if e.FullSync {
+		wm.LastFullSyncAt = e.FinishedAt
+	}
+}
+
+func (wm *LDAPSyncWriteModel) reduceRemoved(id string) {
+	if wm.ID != id {
+		return
+	}
+	wm.Config = domain.LDAPSyncConfig{}
+	wm.Cursor = ""
+	wm.LastFullSyncAt = time.Time{}
+	wm.DeactivatedUserIDs = nil
+}
+
+// IsInstanceIDP returns true if the IdP is defined on the instance instead of an organization.
+func (wm *LDAPSyncWriteModel) IsInstanceIDP() bool {
+	return wm.ResourceOwner == wm.InstanceID
+}
+
+// NeedsFullSync returns true if the next run must search all entries of the directory.
+func (wm *LDAPSyncWriteModel) NeedsFullSync(now time.Time) bool {
+	if wm.Config.DeltaAttribute == domain.LDAPSyncDeltaAttributeNone || wm.Cursor == "" {
+		return true
+	}
+	return wm.Config.FullSyncInterval > 0 && !now.Before(wm.LastFullSyncAt.Add(wm.Config.FullSyncInterval))
+}
+
+func (wm *LDAPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return wm.IDPTypeWriteModel.Query().
+		AddQuery().
+		AggregateTypes(instance.AggregateType, org.AggregateType).
+		EventTypes(
+			instance.IDPLDAPSyncSetEventType,
+			instance.IDPLDAPSyncRunFinishedEventType,
+			org.IDPLDAPSyncSetEventType,
+			org.IDPLDAPSyncRunFinishedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
@@ -0,0 +1,656 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func ldapIDPAddedEvent(aggregate *eventstore.Aggregate) eventstore.Command {
+	if aggregate.Type == org.AggregateType {
+		return org.NewLDAPIDPAddedEvent(context.Background(), aggregate,
+			"id1", "name", []string{"server"}, false, "baseDN", "dn", nil, "user", []string{"object"}, []string{"filter"}, time.Second*30, nil, idp.LDAPAttributes{}, idp.Options{},
+		)
+	}
+	return instance.NewLDAPIDPAddedEvent(context.Background(), aggregate,
+		"id1", "name", []string{"server"}, false, "baseDN", "dn", nil, "user", []string{"object"}, []string{"filter"}, time.Second*30, nil, idp.LDAPAttributes{}, idp.Options{},
+	)
+}
+
+func TestCommandSide_SetInstanceLDAPSync(t *testing.T) {
+	validConfig := func() *domain.LDAPSyncConfig {
+		return &domain.LDAPSyncConfig{
+			Enabled:           true,
+			OrganizationID:    "org1",
+			Filter:            "(department=sales)",
+			Interval:          15 * time.Minute,
+			FullSyncInterval:  24 * time.Hour,
+			DeltaAttribute:    domain.LDAPSyncDeltaAttributeModifyTimestamp,
+			DeactivateMissing: true,
+		}
+	}
+	orgAdded := expectFilter(
+		eventFromEventPusher(
+			org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org1"),
+		),
+	)
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		id     string
+		config *domain.LDAPSyncConfig
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "organization missing",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				config: &domain.LDAPSyncConfig{
+					Enabled:  true,
+					Interval: time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Px7dk", "Errors.IDP.LDAPSyncOrganizationMissing"),
+			},
+		},
+		{
+			name: "organization not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				config: validConfig(),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-QXPGs", "Errors.Org.NotFound"),
+			},
+		},
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: expectEventstore(
+					orgAdded,
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				config: validConfig(),
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Fh6sj", "Errors.IDMissing"),
+			},
+		},
+		{
+			name: "invalid filter",
+			fields: fields{
+				eventstore: expectEventstore(
+					orgAdded,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				config: func() *domain.LDAPSyncConfig {
+					config := validConfig()
+					config.Filter = "department=sales"
+					return config
+				}(),
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Wc8pe", "Errors.IDP.InvalidLDAPSyncConfig"),
+			},
+		},
+		{
+			name: "interval too short",
+			fields: fields{
+				eventstore: expectEventstore(
+					orgAdded,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				config: func() *domain.LDAPSyncConfig {
+					config := validConfig()
+					config.Interval = time.Minute
+					return config
+				}(),
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Gq5hn", "Errors.IDP.InvalidLDAPSyncConfig"),
+			},
+		},
+		{
+			name: "deactivate missing without full sync",
+			fields: fields{
+				eventstore: expectEventstore(
+					orgAdded,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				config: func() *domain.LDAPSyncConfig {
+					config := validConfig()
+					config.FullSyncInterval = 0
+					return config
+				}(),
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Jx2mu", "Errors.IDP.InvalidLDAPSyncConfig"),
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					orgAdded,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				config: validConfig(),
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Ys3nb", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "not ldap",
+			fields: fields{
+				eventstore: expectEventstore(
+					orgAdded,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewOIDCIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								"name",
+								"issuer",
+								"clientID",
+								nil,
+								nil,
+								false,
+								false,
+								idp.Options{},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				config: validConfig(),
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Kv9te", "Errors.IDP.LDAPSyncNotSupported"),
+			},
+		},
+		{
+			name: "unchanged, no push",
+			fields: fields{
+				eventstore: expectEventstore(
+					orgAdded,
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent(&instance.NewAggregate("instance1").Aggregate)),
+						eventFromEventPusher(
+							instance.NewIDPLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								validConfig(),
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				config: validConfig(),
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					orgAdded,
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent(&instance.NewAggregate("instance1").Aggregate)),
+					),
+					expectPush(
+						instance.NewIDPLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							validConfig(),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				config: validConfig(),
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "disable ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent(&instance.NewAggregate("instance1").Aggregate)),
+						eventFromEventPusher(
+							instance.NewIDPLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								validConfig(),
+							),
+						),
+					),
+					expectPush(
+						instance.NewIDPLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							&domain.LDAPSyncConfig{},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				config: &domain.LDAPSyncConfig{},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceLDAPSync(tt.args.ctx, tt.args.id, tt.args.config)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgLDAPSync(t *testing.T) {
+	validConfig := func() *domain.LDAPSyncConfig {
+		return &domain.LDAPSyncConfig{
+			Enabled:  true,
+			Interval: time.Hour,
+		}
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		config        *domain.LDAPSyncConfig
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resource owner",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:    authz.WithInstanceID(context.Background(), "instance1"),
+				id:     "id1",
+				config: validConfig(),
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Zr5gm", "Errors.ResourceOwnerMissing"),
+			},
+		},
+		{
+			name: "other organization",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+				config: &domain.LDAPSyncConfig{
+					Enabled:        true,
+					OrganizationID: "org2",
+					Interval:       time.Hour,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Dq2wv", "Errors.IDP.InvalidLDAPSyncConfig"),
+			},
+		},
+		{
+			name: "idp of other organization",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent(&org.NewAggregate("org2").Aggregate)),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+				config:        validConfig(),
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Ys3nb", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent(&org.NewAggregate("org1").Aggregate)),
+					),
+					expectPush(
+						org.NewIDPLDAPSyncSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							&domain.LDAPSyncConfig{
+								Enabled:        true,
+								OrganizationID: "org1",
+								Interval:       time.Hour,
+							},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+				config:        validConfig(),
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetOrgLDAPSync(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.config)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_FinishLDAPSyncRun(t *testing.T) {
+	config := &domain.LDAPSyncConfig{
+		Enabled:        true,
+		OrganizationID: "org1",
+		Interval:       15 * time.Minute,
+	}
+	run := &domain.LDAPSyncRun{
+		StartedAt:  time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		FinishedAt: time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC),
+		FullSync:   true,
+		Stats: domain.LDAPSyncStats{
+			Created: 1,
+		},
+	}
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx context.Context
+		id  string
+		run *domain.LDAPSyncRun
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "removed idp",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(ldapIDPAddedEvent(&org.NewAggregate("org1").Aggregate)),
+						eventFromEventPusher(
+							org.NewIDPRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "id1"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				run: run,
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Ht3vw", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "instance idp, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", ldapIDPAddedEvent(&instance.NewAggregate("instance1").Aggregate)),
+						eventFromEventPusherWithInstanceID("instance1",
+							instance.NewIDPLDAPSyncSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate, "id1", config),
+						),
+					),
+					expectPush(
+						instance.NewIDPLDAPSyncRunFinishedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							"run1",
+							run,
+							time.Date(2024, 1, 1, 10, 16, 0, 0, time.UTC),
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "run1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				run: run,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "org idp, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1", ldapIDPAddedEvent(&org.NewAggregate("org1").Aggregate)),
+						eventFromEventPusherWithInstanceID("instance1",
+							org.NewIDPLDAPSyncSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "id1", config),
+						),
+					),
+					expectPush(
+						org.NewIDPLDAPSyncRunFinishedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							"run1",
+							run,
+							time.Date(2024, 1, 1, 10, 16, 0, 0, time.UTC),
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "run1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				run: run,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.FinishLDAPSyncRun(tt.args.ctx, tt.args.id, tt.args.run)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestLDAPSyncWriteModel_NeedsFullSync(t *testing.T) {
+	now := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name           string
+		config         domain.LDAPSyncConfig
+		cursor         string
+		lastFullSyncAt time.Time
+		want           bool
+	}{
+		{
+			name: "no delta attribute",
+			config: domain.LDAPSyncConfig{
+				DeltaAttribute: domain.LDAPSyncDeltaAttributeNone,
+			},
+			cursor: "20240101100000Z",
+			want:   true,
+		},
+		{
+			name: "no cursor",
+			config: domain.LDAPSyncConfig{
+				DeltaAttribute: domain.LDAPSyncDeltaAttributeModifyTimestamp,
+			},
+			want: true,
+		},
+		{
+			name: "delta without full sync interval",
+			config: domain.LDAPSyncConfig{
+				DeltaAttribute: domain.LDAPSyncDeltaAttributeModifyTimestamp,
+			},
+			cursor: "20240101100000Z",
+			want:   false,
+		},
+		{
+			name: "full sync interval not elapsed",
+			config: domain.LDAPSyncConfig{
+				DeltaAttribute:   domain.LDAPSyncDeltaAttributeUSNChanged,
+				FullSyncInterval: 24 * time.Hour,
+			},
+			cursor:         "12345",
+			lastFullSyncAt: now.Add(-time.Hour),
+			want:           false,
+		},
+		{
+			name: "full sync interval elapsed",
+			config: domain.LDAPSyncConfig{
+				DeltaAttribute:   domain.LDAPSyncDeltaAttributeUSNChanged,
+				FullSyncInterval: 24 * time.Hour,
+			},
+			cursor:         "12345",
+			lastFullSyncAt: now.Add(-24 * time.Hour),
+			want:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wm := NewLDAPSyncWriteModel("id1")
+			wm.Config = tt.config
+			wm.Cursor = tt.cursor
+			wm.LastFullSyncAt = tt.lastFullSyncAt
+			assert.Equal(t, tt.want, wm.NeedsFullSync(now))
+		})
+	}
+}
+
+func TestCommands_LDAPSync_deactivatedUserIDs(t *testing.T) {
+	agg := &instance.NewAggregate("instance1").Aggregate
+	runFinished := func(runID string, run *domain.LDAPSyncRun) eventstore.Event {
+		return eventFromEventPusherWithInstanceID("instance1",
+			instance.NewIDPLDAPSyncRunFinishedEvent(context.Background(), agg, "id1", runID, run, time.Time{}),
+		)
+	}
+	c := &Commands{
+		eventstore: expectEventstore(
+			expectFilter(
+				eventFromEventPusherWithInstanceID("instance1", ldapIDPAddedEvent(agg)),
+				runFinished("run1", &domain.LDAPSyncRun{DeactivatedUserIDs: []string{"user1", "user2"}}),
+				// releases are recorded by aborted runs as well
+				runFinished("run2", &domain.LDAPSyncRun{ReleasedUserIDs: []string{"user1"}, Error: "aborted"}),
+				runFinished("run3", &domain.LDAPSyncRun{DeactivatedUserIDs: []string{"user2", "user3"}}),
+			),
+		)(t),
+	}
+	got, err := c.LDAPSync(authz.WithInstanceID(context.Background(), "instance1"), "id1")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{"user2", "user3"}, got.DeactivatedUserIDs)
+	}
+}
@@ -0,0 +1,78 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// SetInstanceIDPMappingRules replaces the attribute mapping rules of an instance IdP.
+// Passing no rules removes the mapping.
+func (c *Commands) SetInstanceIDPMappingRules(ctx context.Context, id string, rules []domain.IDPMappingRule) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceID := authz.GetInstance(ctx).InstanceID()
+	return c.setIDPMappingRules(ctx, instanceID, id, rules, func(ctx context.Context, rules []domain.IDPMappingRule) eventstore.Command {
+		return instance.NewIDPMappingRulesSetEvent(ctx, &instance.NewAggregate(instanceID).Aggregate, id, rules)
+	})
+}
+
+// SetOrgIDPMappingRules replaces the attribute mapping rules of an organization IdP.
+// Passing no rules removes the mapping.
+// Organization IdPs must not assign users to other organizations, therefore [domain.IDPMappingTargetOrganization] is not allowed.
+func (c *Commands) SetOrgIDPMappingRules(ctx context.Context, resourceOwner, id string, rules []domain.IDPMappingRule) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if resourceOwner == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Jm4ps", "Errors.ResourceOwnerMissing")
+	}
+	for _, rule := range rules {
+		if rule.Target == domain.IDPMappingTargetOrganization {
+			return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ln8cw", "Errors.IDP.MappingTargetNotAllowed")
+		}
+	}
+	return c.setIDPMappingRules(ctx, resourceOwner, id, rules, func(ctx context.Context, rules []domain.IDPMappingRule) eventstore.Command {
+		return org.NewIDPMappingRulesSetEvent(ctx, &org.NewAggregate(resourceOwner).Aggregate, id, rules)
+	})
+}
+
+func (c *Commands) setIDPMappingRules(
+	ctx context.Context,
+	resourceOwner, id string,
+	rules []domain.IDPMappingRule,
+	setEvent func(context.Context, []domain.IDPMappingRule) eventstore.Command,
+) (*domain.ObjectDetails, error) {
+	if id == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Vb3qe", "Errors.IDMissing")
+	}
+	if _, err := mapping.New(rules); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "COMMAND-Hx9tr", "Errors.IDP.InvalidMappingRule")
+	}
+	writeModel := NewIDPMappingRulesWriteModel(id)
+	if err := c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() || writeModel.ResourceOwner != resourceOwner {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Qz2ka", "Errors.IDPConfig.NotExisting")
+	}
+	if !domain.IDPTypeSupportsMapping(writeModel.Type) {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Tc6fn", "Errors.IDP.MappingNotSupported")
+	}
+	if slices.Equal(writeModel.Rules, rules) {
+		return writeModelToObjectDetails(&writeModel.WriteModel), nil
+	}
+	if err := c.pushAppendAndReduce(ctx, writeModel, setEvent(ctx, rules)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
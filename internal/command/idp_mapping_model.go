@@ -0,0 +1,55 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+type IDPMappingRulesWriteModel struct {
+	IDPTypeWriteModel
+
+	Rules []domain.IDPMappingRule
+}
+
+func NewIDPMappingRulesWriteModel(id string) *IDPMappingRulesWriteModel {
+	return &IDPMappingRulesWriteModel{
+		IDPTypeWriteModel: *NewIDPTypeWriteModel(id),
+	}
+}
+
+func (wm *IDPMappingRulesWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *instance.IDPMappingRulesSetEvent:
+			wm.reduceSet(e.ID, e.Rules)
+		case *org.IDPMappingRulesSetEvent:
+			wm.reduceSet(e.ID, e.Rules)
+		case *instance.IDPRemovedEvent:
+			wm.reduceSet(e.ID, nil)
+		case *org.IDPRemovedEvent:
+			wm.reduceSet(e.ID, nil)
+		}
+	}
+	return wm.IDPTypeWriteModel.Reduce()
+}
+
+func (wm *IDPMappingRulesWriteModel) reduceSet(id string, rules []domain.IDPMappingRule) {
+	if wm.ID != id {
+		return
+	}
+	wm.Rules = rules
+}
+
+func (wm *IDPMappingRulesWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return wm.IDPTypeWriteModel.Query().
+		AddQuery().
+		AggregateTypes(instance.AggregateType, org.AggregateType).
+		EventTypes(
+			instance.IDPMappingRulesSetEventType,
+			org.IDPMappingRulesSetEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
@@ -0,0 +1,418 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_SetInstanceIDPMappingRules(t *testing.T) {
+	validRules := []domain.IDPMappingRule{
+		{
+			Target:     domain.IDPMappingTargetFirstName,
+			Language:   domain.IDPMappingLanguageJSONPath,
+			Expression: "$.userinfo.given_name",
+		},
+		{
+			Target:     domain.IDPMappingTargetOrganization,
+			Language:   domain.IDPMappingLanguageCEL,
+			Expression: "claims.tenant",
+		},
+	}
+	oidcIDPAdded := func() eventstore.Event {
+		return eventFromEventPusher(
+			instance.NewOIDCIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+				"id1",
+				"name",
+				"issuer",
+				"clientID",
+				nil,
+				nil,
+				false,
+				false,
+				idp.Options{},
+			),
+		)
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx   context.Context
+		id    string
+		rules []domain.IDPMappingRule
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing id",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				rules: validRules,
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Vb3qe", "Errors.IDMissing"),
+			},
+		},
+		{
+			name: "invalid rule",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				rules: []domain.IDPMappingRule{
+					{
+						Target:     domain.IDPMappingTargetFirstName,
+						Language:   domain.IDPMappingLanguageCEL,
+						Expression: "userinfo.",
+					},
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Hx9tr", "Errors.IDP.InvalidMappingRule"),
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				rules: validRules,
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Qz2ka", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "org idp, not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOIDCIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								"issuer",
+								"clientID",
+								nil,
+								nil,
+								false,
+								false,
+								idp.Options{},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				rules: validRules,
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Qz2ka", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "unsupported type",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewJWTIDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								"name",
+								"issuer",
+								"jwtEndpoint",
+								"keysEndpoint",
+								"headerName",
+								idp.Options{},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				rules: validRules,
+			},
+			res: res{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Tc6fn", "Errors.IDP.MappingNotSupported"),
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						oidcIDPAdded(),
+						eventFromEventPusher(
+							instance.NewIDPMappingRulesSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								validRules,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				rules: validRules,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						oidcIDPAdded(),
+					),
+					expectPush(
+						instance.NewIDPMappingRulesSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							validRules,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				rules: validRules,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "remove ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						oidcIDPAdded(),
+						eventFromEventPusher(
+							instance.NewIDPMappingRulesSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								validRules,
+							),
+						),
+					),
+					expectPush(
+						instance.NewIDPMappingRulesSetEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							nil,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetInstanceIDPMappingRules(tt.args.ctx, tt.args.id, tt.args.rules)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_SetOrgIDPMappingRules(t *testing.T) {
+	validRules := []domain.IDPMappingRule{
+		{
+			Target:     domain.IDPMappingTargetMetadata,
+			Key:        "department",
+			Language:   domain.IDPMappingLanguageJSONPath,
+			Expression: "$.attributes.department[0]",
+		},
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		rules         []domain.IDPMappingRule
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing resource owner",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:   authz.WithInstanceID(context.Background(), "instance1"),
+				id:    "id1",
+				rules: validRules,
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Jm4ps", "Errors.ResourceOwnerMissing"),
+			},
+		},
+		{
+			name: "organization target not allowed",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+				rules: []domain.IDPMappingRule{
+					{
+						Target:     domain.IDPMappingTargetOrganization,
+						Language:   domain.IDPMappingLanguageCEL,
+						Expression: "claims.tenant",
+					},
+				},
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ln8cw", "Errors.IDP.MappingTargetNotAllowed"),
+			},
+		},
+		{
+			name: "removed idp",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								[]byte("metadata"),
+								nil,
+								[]byte("certificate"),
+								"",
+								false,
+								"",
+								nil,
+								"",
+								false,
+								idp.Options{},
+							),
+						),
+						eventFromEventPusher(
+							org.NewIDPRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+				rules:         validRules,
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Qz2ka", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "set ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewSAMLIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								[]byte("metadata"),
+								nil,
+								[]byte("certificate"),
+								"",
+								false,
+								"",
+								nil,
+								"",
+								false,
+								idp.Options{},
+							),
+						),
+					),
+					expectPush(
+						org.NewIDPMappingRulesSetEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							validRules,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instance1"),
+				resourceOwner: "org1",
+				id:            "id1",
+				rules:         validRules,
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.SetOrgIDPMappingRules(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.rules)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/zitadel/zitadel/internal/idp/providers/oidc"
 	saml2 "github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml/requesttracker"
+	"github.com/zitadel/zitadel/internal/idp/providers/x509"
 	"github.com/zitadel/zitadel/internal/repository/idp"
 	"github.com/zitadel/zitadel/internal/repository/idpconfig"
 	"github.com/zitadel/zitadel/internal/repository/instance"
@@ -1947,6 +1948,164 @@ func (wm *SAMLIDPWriteModel) GetProviderOptions() idp.Options {
 	return wm.Options
 }
 
+type X509IDPWriteModel struct {
+	eventstore.WriteModel
+
+	ID                      string
+	Name                    string
+	CertificateHeader       string
+	TrustedProxies          []string
+	CACertificates          []byte
+	CRLs                    [][]byte
+	OCSPResponses           [][]byte
+	RevocationCheckRequired bool
+	IDField                 domain.X509CertificateField
+	UsernameField           domain.X509CertificateField
+	idp.Options
+
+	State domain.IDPState
+}
+
+func (wm *X509IDPWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *idp.X509IDPAddedEvent:
+			wm.reduceAddedEvent(e)
+		case *idp.X509IDPChangedEvent:
+			wm.reduceChangedEvent(e)
+		case *idp.RemovedEvent:
+			wm.State = domain.IDPStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *X509IDPWriteModel) reduceAddedEvent(e *idp.X509IDPAddedEvent) {
+	wm.Name = e.Name
+	wm.CertificateHeader = e.CertificateHeader
+	wm.TrustedProxies = e.TrustedProxies
+	wm.CACertificates = e.CACertificates
+	wm.CRLs = e.CRLs
+	wm.OCSPResponses = e.OCSPResponses
+	wm.RevocationCheckRequired = e.RevocationCheckRequired
+	wm.IDField = e.IDField
+	wm.UsernameField = e.UsernameField
+	wm.Options = e.Options
+	wm.State = domain.IDPStateActive
+}
+
+func (wm *X509IDPWriteModel) reduceChangedEvent(e *idp.X509IDPChangedEvent) {
+	if e.Name != nil {
+		wm.Name = *e.Name
+	}
+	if e.CertificateHeader != nil {
+		wm.CertificateHeader = *e.CertificateHeader
+	}
+	if e.TrustedProxies != nil {
+		wm.TrustedProxies = *e.TrustedProxies
+	}
+	if e.CACertificates != nil {
+		wm.CACertificates = e.CACertificates
+	}
+	if e.CRLs != nil {
+		wm.CRLs = *e.CRLs
+	}
+	if e.OCSPResponses != nil {
+		wm.OCSPResponses = *e.OCSPResponses
+	}
+	if e.RevocationCheckRequired != nil {
+		wm.RevocationCheckRequired = *e.RevocationCheckRequired
+	}
+	if e.IDField != nil {
+		wm.IDField = *e.IDField
+	}
+	if e.UsernameField != nil {
+		wm.UsernameField = *e.UsernameField
+	}
+	wm.Options.ReduceChanges(e.OptionChanges)
+}
+
+func (wm *X509IDPWriteModel) NewChanges(
+	name,
+	certificateHeader string,
+	trustedProxies []string,
+	caCertificates []byte,
+	crls,
+	ocspResponses [][]byte,
+	revocationCheckRequired bool,
+	idField,
+	usernameField domain.X509CertificateField,
+	options idp.Options,
+) []idp.X509IDPChanges {
+	changes := make([]idp.X509IDPChanges, 0)
+	if wm.Name != name {
+		changes = append(changes, idp.ChangeX509Name(name))
+	}
+	if wm.CertificateHeader != certificateHeader {
+		changes = append(changes, idp.ChangeX509CertificateHeader(certificateHeader))
+	}
+	if !slices.Equal(wm.TrustedProxies, trustedProxies) {
+		changes = append(changes, idp.ChangeX509TrustedProxies(trustedProxies))
+	}
+	if !bytes.Equal(wm.CACertificates, caCertificates) {
+		changes = append(changes, idp.ChangeX509CACertificates(caCertificates))
+	}
+	if !slices.EqualFunc(wm.CRLs, crls, bytes.Equal) {
+		changes = append(changes, idp.ChangeX509CRLs(crls))
+	}
+	if !slices.EqualFunc(wm.OCSPResponses, ocspResponses, bytes.Equal) {
+		changes = append(changes, idp.ChangeX509OCSPResponses(ocspResponses))
+	}
+	if wm.RevocationCheckRequired != revocationCheckRequired {
+		changes = append(changes, idp.ChangeX509RevocationCheckRequired(revocationCheckRequired))
+	}
+	if wm.IDField != idField {
+		changes = append(changes, idp.ChangeX509IDField(idField))
+	}
+	if wm.UsernameField != usernameField {
+		changes = append(changes, idp.ChangeX509UsernameField(usernameField))
+	}
+	opts := wm.Options.Changes(options)
+	if !opts.IsZero() {
+		changes = append(changes, idp.ChangeX509Options(opts))
+	}
+	return changes
+}
+
+func (wm *X509IDPWriteModel) ToProvider(callbackURL string, idpAlg crypto.EncryptionAlgorithm) (providers.Provider, error) {
+	opts := make([]x509.ProviderOpts, 0, 7)
+	if wm.IsLinkingAllowed {
+		opts = append(opts, x509.WithLinkingAllowed())
+	}
+	if wm.IsCreationAllowed {
+		opts = append(opts, x509.WithCreationAllowed())
+	}
+	if wm.IsAutoCreation {
+		opts = append(opts, x509.WithAutoCreation())
+	}
+	if wm.IsAutoUpdate {
+		opts = append(opts, x509.WithAutoUpdate())
+	}
+	if wm.RevocationCheckRequired {
+		opts = append(opts, x509.WithRevocationCheckRequired())
+	}
+	opts = append(opts, x509.WithIDField(wm.IDField), x509.WithUsernameField(wm.UsernameField))
+	return x509.New(
+		wm.Name,
+		wm.CertificateHeader,
+		wm.TrustedProxies,
+		wm.CACertificates,
+		wm.CRLs,
+		wm.OCSPResponses,
+		callbackURL,
+		opts...,
+	)
+}
+
+func (wm *X509IDPWriteModel) GetProviderOptions() idp.Options {
+	return wm.Options
+}
+
 type IDPRemoveWriteModel struct {
 	eventstore.WriteModel
 
@@ -1981,6 +2140,8 @@ func (wm *IDPRemoveWriteModel) Reduce() error {
 			wm.reduceAdded(e.ID)
 		case *idp.SAMLIDPAddedEvent:
 			wm.reduceAdded(e.ID)
+		case *idp.X509IDPAddedEvent:
+			wm.reduceAdded(e.ID)
 		case *idp.RemovedEvent:
 			wm.reduceRemoved(e.ID)
 		case *idpconfig.IDPConfigAddedEvent:
@@ -2069,8 +2230,12 @@ func (wm *IDPTypeWriteModel) Reduce() error {
 			wm.reduceAdded(e.ID, domain.IDPTypeApple, e.Aggregate())
 		case *instance.SAMLIDPAddedEvent:
 			wm.reduceAdded(e.ID, domain.IDPTypeSAML, e.Aggregate())
+		case *instance.X509IDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeX509, e.Aggregate())
 		case *org.SAMLIDPAddedEvent:
 			wm.reduceAdded(e.ID, domain.IDPTypeSAML, e.Aggregate())
+		case *org.X509IDPAddedEvent:
+			wm.reduceAdded(e.ID, domain.IDPTypeX509, e.Aggregate())
 		case *instance.OIDCIDPMigratedAzureADEvent:
 			wm.reduceChanged(e.ID, domain.IDPTypeAzureAD)
 		case *org.OIDCIDPMigratedAzureADEvent:
@@ -2148,6 +2313,7 @@ func (wm *IDPTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.LDAPIDPAddedEventType,
 			instance.AppleIDPAddedEventType,
 			instance.SAMLIDPAddedEventType,
+			instance.X509IDPAddedEventType,
 			instance.OIDCIDPMigratedAzureADEventType,
 			instance.OIDCIDPMigratedGoogleEventType,
 			instance.IDPRemovedEventType,
@@ -2168,6 +2334,7 @@ func (wm *IDPTypeWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.LDAPIDPAddedEventType,
 			org.AppleIDPAddedEventType,
 			org.SAMLIDPAddedEventType,
+			org.X509IDPAddedEventType,
 			org.OIDCIDPMigratedAzureADEventType,
 			org.OIDCIDPMigratedGoogleEventType,
 			org.IDPRemovedEventType,
@@ -2246,6 +2413,8 @@ func NewAllIDPWriteModel(resourceOwner string, instanceBool bool, id string, idp
 			writeModel.model = NewAppleInstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeSAML:
 			writeModel.samlModel = NewSAMLInstanceIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeX509:
+			writeModel.model = NewX509InstanceIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeUnspecified:
 			fallthrough
 		default:
@@ -2277,6 +2446,8 @@ func NewAllIDPWriteModel(resourceOwner string, instanceBool bool, id string, idp
 			writeModel.model = NewAppleOrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeSAML:
 			writeModel.samlModel = NewSAMLOrgIDPWriteModel(resourceOwner, id)
+		case domain.IDPTypeX509:
+			writeModel.model = NewX509OrgIDPWriteModel(resourceOwner, id)
 		case domain.IDPTypeUnspecified:
 			fallthrough
 		default:
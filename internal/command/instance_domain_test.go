@@ -158,6 +158,16 @@ func TestCommandSide_AddInstanceDomain(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
 							),
 						),
 					),
@@ -947,6 +947,85 @@ func (wm *InstanceSAMLIDPWriteModel) NewChangedEvent(
 	return instance.NewSAMLIDPChangedEvent(ctx, aggregate, id, changes)
 }
 
+type InstanceX509IDPWriteModel struct {
+	X509IDPWriteModel
+}
+
+func NewX509InstanceIDPWriteModel(instanceID, id string) *InstanceX509IDPWriteModel {
+	return &InstanceX509IDPWriteModel{
+		X509IDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   instanceID,
+				ResourceOwner: instanceID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *InstanceX509IDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.X509IDPAddedEvent:
+			wm.X509IDPWriteModel.AppendEvents(&e.X509IDPAddedEvent)
+		case *instance.X509IDPChangedEvent:
+			wm.X509IDPWriteModel.AppendEvents(&e.X509IDPChangedEvent)
+		case *instance.IDPRemovedEvent:
+			wm.X509IDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.X509IDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *InstanceX509IDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			instance.X509IDPAddedEventType,
+			instance.X509IDPChangedEventType,
+			instance.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *InstanceX509IDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	certificateHeader string,
+	trustedProxies []string,
+	caCertificates []byte,
+	crls,
+	ocspResponses [][]byte,
+	revocationCheckRequired bool,
+	idField,
+	usernameField domain.X509CertificateField,
+	options idp.Options,
+) (*instance.X509IDPChangedEvent, error) {
+	changes := wm.X509IDPWriteModel.NewChanges(
+		name,
+		certificateHeader,
+		trustedProxies,
+		caCertificates,
+		crls,
+		ocspResponses,
+		revocationCheckRequired,
+		idField,
+		usernameField,
+		options,
+	)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return instance.NewX509IDPChangedEvent(ctx, aggregate, id, changes)
+}
+
 type InstanceIDPRemoveWriteModel struct {
 	IDPRemoveWriteModel
 }
@@ -986,6 +1065,8 @@ func (wm *InstanceIDPRemoveWriteModel) AppendEvents(events ...eventstore.Event)
 			wm.IDPRemoveWriteModel.AppendEvents(&e.GoogleIDPAddedEvent)
 		case *instance.SAMLIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.SAMLIDPAddedEvent)
+		case *instance.X509IDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.X509IDPAddedEvent)
 		case *instance.LDAPIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.LDAPIDPAddedEvent)
 		case *instance.AppleIDPAddedEvent:
@@ -1021,6 +1102,7 @@ func (wm *InstanceIDPRemoveWriteModel) Query() *eventstore.SearchQueryBuilder {
 			instance.LDAPIDPAddedEventType,
 			instance.AppleIDPAddedEventType,
 			instance.SAMLIDPAddedEventType,
+			instance.X509IDPAddedEventType,
 			instance.IDPRemovedEventType,
 		).
 		EventData(map[string]interface{}{"id": wm.ID}).
@@ -5364,6 +5364,465 @@ func TestCommandSide_UpdateInstanceAppleIDP(t *testing.T) {
 	}
 }
 
+func TestCommandSide_AddInstanceX509IDP(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx      context.Context
+		provider X509Provider
+	}
+	type res struct {
+		id   string
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid name",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Gk2ls", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			"invalid certificate header",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name: "name",
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Vq4bn", "Errors.IDP.CertificateHeaderMissing"),
+			},
+		},
+		{
+			"no trusted proxies",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Pz3gq", "Errors.IDP.TrustedProxiesMissing"),
+			},
+		},
+		{
+			"invalid trusted proxy",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"proxy"},
+					CACertificates:    validLDAPRootCA,
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Kd8wu", "Errors.IDP.InvalidTrustedProxy"),
+			},
+		},
+		{
+			"no ca certificates",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Hs9cd", "Errors.IDP.CACertificatesMissing"),
+			},
+		},
+		{
+			"invalid certificate field",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+					IDField:           domain.X509CertificateField(100),
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ob3jw", "Errors.IDP.InvalidCertificateField"),
+			},
+		},
+		{
+			"invalid ca certificates",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    []byte("invalid"),
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Tn6xe", "Errors.IDP.InvalidCACertificates"),
+			},
+		},
+		{
+			"invalid crl",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+					CRLs:              [][]byte{[]byte("invalid")},
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Lp1zs", "Errors.IDP.InvalidCRL"),
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewX509IDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							"name",
+							"X-SSL-Client-Cert",
+							[]string{"10.0.0.1"},
+							validLDAPRootCA,
+							nil,
+							nil,
+							false,
+							domain.X509CertificateFieldUnspecified,
+							domain.X509CertificateFieldUnspecified,
+							idp.Options{},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+				},
+			},
+			res: res{
+				id:   "id1",
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "ok all set",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						instance.NewX509IDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+							"id1",
+							"name",
+							"X-SSL-Client-Cert",
+							[]string{"10.0.0.1"},
+							validLDAPRootCA,
+							nil,
+							[][]byte{[]byte("ocsp")},
+							true,
+							domain.X509CertificateFieldSANUPN,
+							domain.X509CertificateFieldSANEmail,
+							idp.Options{
+								IsCreationAllowed: true,
+								IsLinkingAllowed:  true,
+								IsAutoCreation:    true,
+								IsAutoUpdate:      true,
+								AutoLinkingOption: domain.AutoLinkingOptionEmail,
+							},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{
+					Name:                    "name",
+					CertificateHeader:       "X-SSL-Client-Cert",
+					TrustedProxies:          []string{"10.0.0.1"},
+					CACertificates:          validLDAPRootCA,
+					OCSPResponses:           [][]byte{[]byte("ocsp")},
+					RevocationCheckRequired: true,
+					IDField:                 domain.X509CertificateFieldSANUPN,
+					UsernameField:           domain.X509CertificateFieldSANEmail,
+					IDPOptions: idp.Options{
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+						AutoLinkingOption: domain.AutoLinkingOptionEmail,
+					},
+				},
+			},
+			res: res{
+				id:   "id1",
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			id, got, err := c.AddInstanceX509Provider(tt.args.ctx, tt.args.provider)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_UpdateInstanceX509IDP(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		provider X509Provider
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid id",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				provider: X509Provider{},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "INST-MI2Sx", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			"invalid name",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:      authz.WithInstanceID(context.Background(), "instance1"),
+				id:       "id1",
+				provider: X509Provider{},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Gk2ls", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "INST-rbjrn", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewX509IDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								"name",
+								"X-SSL-Client-Cert",
+								[]string{"10.0.0.1"},
+								validLDAPRootCA,
+								nil,
+								nil,
+								false,
+								domain.X509CertificateFieldUnspecified,
+								domain.X509CertificateFieldUnspecified,
+								idp.Options{},
+							)),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+		{
+			name: "change ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewX509IDPAddedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								"name",
+								"X-SSL-Client-Cert",
+								[]string{"10.0.0.1"},
+								validLDAPRootCA,
+								nil,
+								nil,
+								false,
+								domain.X509CertificateFieldUnspecified,
+								domain.X509CertificateFieldUnspecified,
+								idp.Options{},
+							)),
+					),
+					expectPush(
+						func() eventstore.Command {
+							t := true
+							event, _ := instance.NewX509IDPChangedEvent(context.Background(), &instance.NewAggregate("instance1").Aggregate,
+								"id1",
+								[]idp.X509IDPChanges{
+									idp.ChangeX509Name("new name"),
+									idp.ChangeX509CertificateHeader("X-Client-Cert"),
+									idp.ChangeX509TrustedProxies([]string{"10.0.0.0/8"}),
+									idp.ChangeX509OCSPResponses([][]byte{[]byte("ocsp")}),
+									idp.ChangeX509RevocationCheckRequired(true),
+									idp.ChangeX509IDField(domain.X509CertificateFieldSANUPN),
+									idp.ChangeX509UsernameField(domain.X509CertificateFieldSANEmail),
+									idp.ChangeX509Options(idp.OptionChanges{
+										IsCreationAllowed: &t,
+										IsLinkingAllowed:  &t,
+										IsAutoCreation:    &t,
+										IsAutoUpdate:      &t,
+									}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instance1"),
+				id:  "id1",
+				provider: X509Provider{
+					Name:                    "new name",
+					CertificateHeader:       "X-Client-Cert",
+					TrustedProxies:          []string{"10.0.0.0/8"},
+					CACertificates:          validLDAPRootCA,
+					OCSPResponses:           [][]byte{[]byte("ocsp")},
+					RevocationCheckRequired: true,
+					IDField:                 domain.X509CertificateFieldSANUPN,
+					UsernameField:           domain.X509CertificateFieldSANEmail,
+					IDPOptions: idp.Options{
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "instance1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.UpdateInstanceX509Provider(tt.args.ctx, tt.args.id, tt.args.provider)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
 func TestCommandSide_AddInstanceSAMLIDP(t *testing.T) {
 	type fields struct {
 		eventstore                 func(*testing.T) *eventstore.Eventstore
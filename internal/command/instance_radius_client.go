@@ -0,0 +1,242 @@
+package command
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/radiusclient"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// radiusClientSecretMinLength is the length recommended by RFC 2865, section 3
+const radiusClientSecretMinLength = 16
+
+type AddRADIUSClient struct {
+	models.ObjectRoot
+
+	Name string
+	// Network is the address or CIDR network the requests of the client are sent from.
+	Network string
+	// NASIdentifier distinguishes clients sending from the same network.
+	NASIdentifier  string
+	Secret         string
+	ProjectID      string
+	RequireGrant   bool
+	RoleAttributes []*domain.RADIUSRoleAttribute
+}
+
+func (a *AddRADIUSClient) isValid() error {
+	if a.Name == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Rb4xq", "Errors.RADIUSClient.Invalid")
+	}
+	network, err := parseRADIUSClientNetwork(a.Network)
+	if err != nil {
+		return err
+	}
+	a.Network = network
+	if len(a.Secret) < radiusClientSecretMinLength {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Vn6hp", "Errors.RADIUSClient.SecretTooShort")
+	}
+	return validateRADIUSClientRoles(a.ProjectID, a.RequireGrant, a.RoleAttributes)
+}
+
+func (c *Commands) AddRADIUSClient(ctx context.Context, add *AddRADIUSClient, resourceOwner string) (_ time.Time, err error) {
+	if resourceOwner == "" {
+		return time.Time{}, zerrors.ThrowInvalidArgument(nil, "COMMAND-Xk8ud", "Errors.IDMissing")
+	}
+	if err := add.isValid(); err != nil {
+		return time.Time{}, err
+	}
+	if add.ProjectID != "" {
+		if _, err := c.checkProjectExists(ctx, add.ProjectID, ""); err != nil {
+			return time.Time{}, err
+		}
+	}
+	if add.AggregateID == "" {
+		add.AggregateID, err = c.idGenerator.Next()
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	wm, err := c.getRADIUSClientWriteModelByID(ctx, add.AggregateID, resourceOwner)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if wm.State.Exists() {
+		return time.Time{}, zerrors.ThrowAlreadyExists(nil, "COMMAND-Dq2ob", "Errors.RADIUSClient.AlreadyExists")
+	}
+	secret, err := crypto.Encrypt([]byte(add.Secret), c.radiusEncryption)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if err := c.pushAppendAndReduce(ctx, wm, radiusclient.NewAddedEvent(
+		ctx,
+		RADIUSClientAggregateFromWriteModel(&wm.WriteModel),
+		add.Name,
+		add.Network,
+		add.NASIdentifier,
+		secret,
+		add.ProjectID,
+		add.RequireGrant,
+		add.RoleAttributes,
+	)); err != nil {
+		return time.Time{}, err
+	}
+	return wm.ChangeDate, nil
+}
+
+type ChangeRADIUSClient struct {
+	models.ObjectRoot
+
+	Name           *string
+	Network        *string
+	NASIdentifier  *string
+	Secret         *string
+	ProjectID      *string
+	RequireGrant   *bool
+	RoleAttributes *[]*domain.RADIUSRoleAttribute
+}
+
+func (a *ChangeRADIUSClient) isValid() error {
+	if a.AggregateID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Mz3wf", "Errors.IDMissing")
+	}
+	if a.Name != nil && *a.Name == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Hc9tl", "Errors.RADIUSClient.Invalid")
+	}
+	if a.Network != nil {
+		network, err := parseRADIUSClientNetwork(*a.Network)
+		if err != nil {
+			return err
+		}
+		a.Network = &network
+	}
+	if a.Secret != nil && len(*a.Secret) < radiusClientSecretMinLength {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Fy5rk", "Errors.RADIUSClient.SecretTooShort")
+	}
+	return nil
+}
+
+func (c *Commands) ChangeRADIUSClient(ctx context.Context, change *ChangeRADIUSClient, resourceOwner string) (time.Time, error) {
+	if resourceOwner == "" {
+		return time.Time{}, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ua7je", "Errors.IDMissing")
+	}
+	if err := change.isValid(); err != nil {
+		return time.Time{}, err
+	}
+	existing, err := c.getRADIUSClientWriteModelByID(ctx, change.AggregateID, resourceOwner)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !existing.State.Exists() {
+		return time.Time{}, zerrors.ThrowNotFound(nil, "COMMAND-Pw4gs", "Errors.RADIUSClient.NotFound")
+	}
+	projectID, requireGrant, roleAttributes := existing.ProjectID, existing.RequireGrant, existing.RoleAttributes
+	if change.ProjectID != nil {
+		projectID = *change.ProjectID
+	}
+	if change.RequireGrant != nil {
+		requireGrant = *change.RequireGrant
+	}
+	if change.RoleAttributes != nil {
+		roleAttributes = *change.RoleAttributes
+	}
+	if err := validateRADIUSClientRoles(projectID, requireGrant, roleAttributes); err != nil {
+		return time.Time{}, err
+	}
+	if change.ProjectID != nil && *change.ProjectID != "" && *change.ProjectID != existing.ProjectID {
+		if _, err := c.checkProjectExists(ctx, *change.ProjectID, ""); err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	var secret *crypto.CryptoValue
+	if change.Secret != nil {
+		secret, err = crypto.Encrypt([]byte(*change.Secret), c.radiusEncryption)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+	changedEvent := existing.NewChangedEvent(
+		ctx,
+		RADIUSClientAggregateFromWriteModel(&existing.WriteModel),
+		change.Name,
+		change.Network,
+		change.NASIdentifier,
+		secret,
+		change.ProjectID,
+		change.RequireGrant,
+		change.RoleAttributes,
+	)
+	if changedEvent == nil {
+		return existing.WriteModel.ChangeDate, nil
+	}
+	if err := c.pushAppendAndReduce(ctx, existing, changedEvent); err != nil {
+		return time.Time{}, err
+	}
+	return existing.WriteModel.ChangeDate, nil
+}
+
+func (c *Commands) DeleteRADIUSClient(ctx context.Context, id, resourceOwner string) (time.Time, error) {
+	if id == "" || resourceOwner == "" {
+		return time.Time{}, zerrors.ThrowInvalidArgument(nil, "COMMAND-Lr8nc", "Errors.IDMissing")
+	}
+	existing, err := c.getRADIUSClientWriteModelByID(ctx, id, resourceOwner)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !existing.State.Exists() {
+		return existing.WriteModel.ChangeDate, nil
+	}
+	if err := c.pushAppendAndReduce(ctx,
+		existing,
+		radiusclient.NewRemovedEvent(ctx,
+			RADIUSClientAggregateFromWriteModel(&existing.WriteModel),
+			existing.Name,
+		),
+	); err != nil {
+		return time.Time{}, err
+	}
+	return existing.WriteModel.ChangeDate, nil
+}
+
+func (c *Commands) getRADIUSClientWriteModelByID(ctx context.Context, id string, resourceOwner string) (*RADIUSClientWriteModel, error) {
+	wm := NewRADIUSClientWriteModel(id, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, wm)
+	if err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+// parseRADIUSClientNetwork returns the network in CIDR notation, single addresses are converted to a host network.
+func parseRADIUSClientNetwork(network string) (string, error) {
+	prefix, err := netip.ParsePrefix(network)
+	if err != nil {
+		addr, addrErr := netip.ParseAddr(network)
+		if addrErr != nil {
+			return "", zerrors.ThrowInvalidArgument(err, "COMMAND-Tg2ev", "Errors.RADIUSClient.InvalidNetwork")
+		}
+		prefix = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	if prefix.Addr().Zone() != "" {
+		return "", zerrors.ThrowInvalidArgument(nil, "COMMAND-Jd6om", "Errors.RADIUSClient.InvalidNetwork")
+	}
+	return prefix.Masked().String(), nil
+}
+
+func validateRADIUSClientRoles(projectID string, requireGrant bool, roleAttributes []*domain.RADIUSRoleAttribute) error {
+	if projectID == "" && (requireGrant || len(roleAttributes) > 0) {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Oe5sy", "Errors.RADIUSClient.ProjectMissing")
+	}
+	for _, attribute := range roleAttributes {
+		if err := attribute.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
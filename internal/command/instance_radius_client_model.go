@@ -0,0 +1,140 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/radiusclient"
+)
+
+type RADIUSClientWriteModel struct {
+	eventstore.WriteModel
+
+	Name           string
+	Network        string
+	NASIdentifier  string
+	Secret         *crypto.CryptoValue
+	ProjectID      string
+	RequireGrant   bool
+	RoleAttributes []*domain.RADIUSRoleAttribute
+
+	State domain.RADIUSClientState
+}
+
+func NewRADIUSClientWriteModel(id string, resourceOwner string) *RADIUSClientWriteModel {
+	return &RADIUSClientWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+			InstanceID:    resourceOwner,
+		},
+	}
+}
+
+func (wm *RADIUSClientWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *radiusclient.AddedEvent:
+			wm.Name = e.Name
+			wm.Network = e.Network
+			wm.NASIdentifier = e.NASIdentifier
+			wm.Secret = e.Secret
+			wm.ProjectID = e.ProjectID
+			wm.RequireGrant = e.RequireGrant
+			wm.RoleAttributes = e.RoleAttributes
+			wm.State = domain.RADIUSClientStateActive
+		case *radiusclient.ChangedEvent:
+			if e.Name != nil {
+				wm.Name = *e.Name
+			}
+			if e.Network != nil {
+				wm.Network = *e.Network
+			}
+			if e.NASIdentifier != nil {
+				wm.NASIdentifier = *e.NASIdentifier
+			}
+			if e.Secret != nil {
+				wm.Secret = e.Secret
+			}
+			if e.ProjectID != nil {
+				wm.ProjectID = *e.ProjectID
+			}
+			if e.RequireGrant != nil {
+				wm.RequireGrant = *e.RequireGrant
+			}
+			if e.RoleAttributes != nil {
+				wm.RoleAttributes = *e.RoleAttributes
+			}
+		case *radiusclient.RemovedEvent:
+			wm.State = domain.RADIUSClientStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *RADIUSClientWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(radiusclient.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(radiusclient.AddedEventType,
+			radiusclient.ChangedEventType,
+			radiusclient.RemovedEventType).
+		Builder()
+}
+
+func (wm *RADIUSClientWriteModel) NewChangedEvent(
+	ctx context.Context,
+	agg *eventstore.Aggregate,
+	name *string,
+	network *string,
+	nasIdentifier *string,
+	secret *crypto.CryptoValue,
+	projectID *string,
+	requireGrant *bool,
+	roleAttributes *[]*domain.RADIUSRoleAttribute,
+) *radiusclient.ChangedEvent {
+	changes := make([]radiusclient.Changes, 0)
+	if name != nil && wm.Name != *name {
+		changes = append(changes, radiusclient.ChangeName(wm.Name, *name))
+	}
+	if network != nil && wm.Network != *network {
+		changes = append(changes, radiusclient.ChangeNetwork(*network))
+	}
+	if nasIdentifier != nil && wm.NASIdentifier != *nasIdentifier {
+		changes = append(changes, radiusclient.ChangeNASIdentifier(*nasIdentifier))
+	}
+	// the secret is encrypted, so it can't be compared and is always changed if set
+	if secret != nil {
+		changes = append(changes, radiusclient.ChangeSecret(secret))
+	}
+	if projectID != nil && wm.ProjectID != *projectID {
+		changes = append(changes, radiusclient.ChangeProjectID(*projectID))
+	}
+	if requireGrant != nil && wm.RequireGrant != *requireGrant {
+		changes = append(changes, radiusclient.ChangeRequireGrant(*requireGrant))
+	}
+	if roleAttributes != nil && !slices.EqualFunc(wm.RoleAttributes, *roleAttributes, func(a, b *domain.RADIUSRoleAttribute) bool {
+		return *a == *b
+	}) {
+		changes = append(changes, radiusclient.ChangeRoleAttributes(*roleAttributes))
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+	return radiusclient.NewChangedEvent(ctx, agg, changes)
+}
+
+func RADIUSClientAggregateFromWriteModel(wm *eventstore.WriteModel) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            wm.AggregateID,
+		Type:          radiusclient.AggregateType,
+		ResourceOwner: wm.ResourceOwner,
+		InstanceID:    wm.InstanceID,
+		Version:       radiusclient.AggregateVersion,
+	}
+}
@@ -0,0 +1,593 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/radiusclient"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const testRADIUSSecret = "0123456789abcdef"
+
+func radiusClientAddedEvent(id, instanceID string) *radiusclient.AddedEvent {
+	return radiusclient.NewAddedEvent(context.Background(),
+		radiusclient.NewAggregate(id, instanceID),
+		"vpn",
+		"10.0.0.0/24",
+		"",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte(testRADIUSSecret),
+		},
+		"project1",
+		true,
+		[]*domain.RADIUSRoleAttribute{
+			{Role: "admin", Type: 11},
+		},
+	)
+}
+
+func TestCommands_AddRADIUSClient(t *testing.T) {
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		add           *AddRADIUSClient
+		resourceOwner string
+	}
+	type res struct {
+		id      string
+		network string
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no resourceowner, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				add:           &AddRADIUSClient{},
+				resourceOwner: "",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no name, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				add:           &AddRADIUSClient{},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"invalid network, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddRADIUSClient{
+					Name:    "vpn",
+					Network: "10.0.0.0/33",
+					Secret:  testRADIUSSecret,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"secret too short, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddRADIUSClient{
+					Name:    "vpn",
+					Network: "10.0.0.0/24",
+					Secret:  "secret",
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"role attributes without project, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddRADIUSClient{
+					Name:    "vpn",
+					Network: "10.0.0.0/24",
+					Secret:  testRADIUSSecret,
+					RoleAttributes: []*domain.RADIUSRoleAttribute{
+						{Role: "admin", Type: 11},
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"reserved attribute, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddRADIUSClient{
+					Name:      "vpn",
+					Network:   "10.0.0.0/24",
+					Secret:    testRADIUSSecret,
+					ProjectID: "project1",
+					RoleAttributes: []*domain.RADIUSRoleAttribute{
+						{Role: "admin", Type: 80},
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"project not found, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddRADIUSClient{
+					Name:      "vpn",
+					Network:   "10.0.0.0/24",
+					Secret:    testRADIUSSecret,
+					ProjectID: "project1",
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			"already existing, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							radiusClientAddedEvent("id1", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddRADIUSClient{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:    "vpn",
+					Network: "10.0.0.0/24",
+					Secret:  testRADIUSSecret,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorAlreadyExists,
+			},
+		},
+		{
+			"push ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						radiusClientAddedEvent("id1", "instance"),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddRADIUSClient{
+					Name:         "vpn",
+					Network:      "10.0.0.17/24",
+					Secret:       testRADIUSSecret,
+					ProjectID:    "project1",
+					RequireGrant: true,
+					RoleAttributes: []*domain.RADIUSRoleAttribute{
+						{Role: "admin", Type: 11},
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				id:      "id1",
+				network: "10.0.0.0/24",
+			},
+		},
+		{
+			"single address, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						radiusclient.NewAddedEvent(context.Background(),
+							radiusclient.NewAggregate("id1", "instance"),
+							"vpn",
+							"192.168.1.1/32",
+							"nas1",
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte(testRADIUSSecret),
+							},
+							"",
+							false,
+							nil,
+						),
+					),
+				),
+				idGenerator: mock.ExpectID(t, "id1"),
+			},
+			args{
+				ctx: context.Background(),
+				add: &AddRADIUSClient{
+					Name:          "vpn",
+					Network:       "192.168.1.1",
+					NASIdentifier: "nas1",
+					Secret:        testRADIUSSecret,
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				id:      "id1",
+				network: "192.168.1.1/32",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:       tt.fields.eventstore(t),
+				idGenerator:      tt.fields.idGenerator,
+				radiusEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			_, err := c.AddRADIUSClient(tt.args.ctx, tt.args.add, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, tt.args.add.AggregateID)
+				assert.Equal(t, tt.res.network, tt.args.add.Network)
+			}
+		})
+	}
+}
+
+func TestCommands_ChangeRADIUSClient(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		change        *ChangeRADIUSClient
+		resourceOwner string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no id, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				change:        &ChangeRADIUSClient{},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"secret too short, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeRADIUSClient{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Secret: gu.Ptr("short"),
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeRADIUSClient{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name: gu.Ptr("name"),
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			"removing project with role attributes, error",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							radiusClientAddedEvent("id1", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeRADIUSClient{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					ProjectID: gu.Ptr(""),
+				},
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"no changes",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							radiusClientAddedEvent("id1", "instance"),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeRADIUSClient{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:    gu.Ptr("vpn"),
+					Network: gu.Ptr("10.0.0.1/24"),
+					RoleAttributes: &[]*domain.RADIUSRoleAttribute{
+						{Role: "admin", Type: 11},
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{},
+		},
+		{
+			"change ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							radiusClientAddedEvent("id1", "instance"),
+						),
+					),
+					expectPush(
+						radiusclient.NewChangedEvent(context.Background(),
+							radiusclient.NewAggregate("id1", "instance"),
+							[]radiusclient.Changes{
+								radiusclient.ChangeName("vpn", "wifi"),
+								radiusclient.ChangeNetwork("fd00::/64"),
+								radiusclient.ChangeSecret(&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("fedcba9876543210"),
+								}),
+								radiusclient.ChangeRoleAttributes([]*domain.RADIUSRoleAttribute{
+									{Role: "admin", Type: 26, VendorID: 14823, VendorType: 1, Value: "superuser"},
+								}),
+							},
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				change: &ChangeRADIUSClient{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:    gu.Ptr("wifi"),
+					Network: gu.Ptr("fd00::1/64"),
+					Secret:  gu.Ptr("fedcba9876543210"),
+					RoleAttributes: &[]*domain.RADIUSRoleAttribute{
+						{Role: "admin", Type: 26, VendorID: 14823, VendorType: 1, Value: "superuser"},
+					},
+				},
+				resourceOwner: "instance",
+			},
+			res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:       tt.fields.eventstore(t),
+				radiusEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			_, err := c.ChangeRADIUSClient(tt.args.ctx, tt.args.change, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
+
+func TestCommands_DeleteRADIUSClient(t *testing.T) {
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		id            string
+		resourceOwner string
+	}
+	type res struct {
+		err func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"no id, error",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "instance",
+			},
+			res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{},
+		},
+		{
+			"remove ok",
+			fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							radiusClientAddedEvent("id1", "instance"),
+						),
+					),
+					expectPush(
+						radiusclient.NewRemovedEvent(context.Background(),
+							radiusclient.NewAggregate("id1", "instance"),
+							"vpn",
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				id:            "id1",
+				resourceOwner: "instance",
+			},
+			res{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			_, err := c.DeleteRADIUSClient(tt.args.ctx, tt.args.id, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+		})
+	}
+}
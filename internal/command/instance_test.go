@@ -90,6 +90,7 @@ func apiAppEvents(ctx context.Context, orgID, projectID, id, name string) []even
 			"clientID",
 			"",
 			domain.APIAuthMethodTypePrivateKeyJWT,
+			"",
 		),
 	}
 }
@@ -125,6 +126,16 @@ func oidcAppEvents(ctx context.Context, orgID, projectID, id, name, clientID str
 			"",
 			domain.LoginVersionUnspecified,
 			"",
+			domain.OIDCRefreshTokenRotationUnspecified,
+			0,
+			0,
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
+			"",
 		),
 	}
 }
@@ -465,6 +476,16 @@ func generatedDomainFilters(instanceID, orgID, projectID, appID, generatedDomain
 				"",
 				domain.LoginVersionUnspecified,
 				"",
+				domain.OIDCRefreshTokenRotationUnspecified,
+				0,
+				0,
+				"",
+				"",
+				"",
+				"",
+				"",
+				"",
+				"",
 			),
 		),
 		expectFilter(
@@ -47,6 +47,80 @@ type OIDCSession struct {
 	Reason            domain.TokenReason
 	Actor             *domain.TokenActor
 	RefreshToken      string
+	// AccessTokenAudience is set if the audience of the access token
+	// was restricted to a subset of the session Audience.
+	AccessTokenAudience []string
+}
+
+// AccessTokenAudienceResolver restricts the audience of an access token to a subset of the granted audience,
+// e.g. to the resources requested by the client (RFC 8707).
+// A nil audience returned by the resolver keeps the granted audience.
+type AccessTokenAudienceResolver func(ctx context.Context, grantedAudience []string) ([]string, error)
+
+func (r AccessTokenAudienceResolver) resolve(ctx context.Context, grantedAudience []string) ([]string, error) {
+	if r == nil {
+		return nil, nil
+	}
+	return r(ctx, grantedAudience)
+}
+
+// RefreshTokenSettings are the client specific refresh token settings.
+// Lifetimes of 0 fall back to the instance OIDC settings.
+type RefreshTokenSettings struct {
+	Rotation     domain.OIDCRefreshTokenRotation
+	Lifetime     time.Duration
+	IdleLifetime time.Duration
+}
+
+func (s *RefreshTokenSettings) rotation() domain.OIDCRefreshTokenRotation {
+	if s == nil {
+		return domain.OIDCRefreshTokenRotationUnspecified
+	}
+	return s.Rotation
+}
+
+// lifetimes returns the client specific lifetimes or the passed instance lifetimes if not set.
+func (s *RefreshTokenSettings) lifetimes(lifetime, idleLifetime time.Duration) (time.Duration, time.Duration) {
+	if s == nil {
+		return lifetime, idleLifetime
+	}
+	if s.Lifetime > 0 {
+		lifetime = s.Lifetime
+	}
+	if s.IdleLifetime > 0 {
+		idleLifetime = s.IdleLifetime
+	}
+	return lifetime, idleLifetime
+}
+
+// OIDCSessionOptions are the client specific options of a new OIDC session.
+// A nil OIDCSessionOptions uses the instance settings and registers no logout.
+type OIDCSessionOptions struct {
+	BackChannelLogoutURI  string
+	FrontChannelLogoutURI string
+	RefreshTokenSettings  *RefreshTokenSettings
+	AccessTokenAudience   AccessTokenAudienceResolver
+}
+
+func (o *OIDCSessionOptions) refreshTokenSettings() *RefreshTokenSettings {
+	if o == nil {
+		return nil
+	}
+	return o.RefreshTokenSettings
+}
+
+func (o *OIDCSessionOptions) accessTokenAudience() AccessTokenAudienceResolver {
+	if o == nil {
+		return nil
+	}
+	return o.AccessTokenAudience
+}
+
+func (o *OIDCSessionOptions) logoutURIs() (backChannel, frontChannel string) {
+	if o == nil {
+		return "", ""
+	}
+	return o.BackChannelLogoutURI, o.FrontChannelLogoutURI
 }
 
 type AuthRequestComplianceChecker func(context.Context, *AuthRequestWriteModel) error
@@ -59,7 +133,7 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(
 	authReqId string,
 	complianceCheck AuthRequestComplianceChecker,
 	needRefreshToken bool,
-	backChannelLogoutURI string,
+	opts *OIDCSessionOptions,
 ) (session *OIDCSession, state string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -87,7 +161,7 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(
 		return nil, "", err
 	}
 
-	cmd, err := c.newOIDCSessionAddEvents(ctx, sessionModel.UserID, sessionModel.UserResourceOwner)
+	cmd, err := c.newOIDCSessionAddEvents(ctx, sessionModel.UserID, sessionModel.UserResourceOwner, opts.refreshTokenSettings())
 	if err != nil {
 		return nil, "", err
 	}
@@ -113,10 +187,15 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(
 		sessionModel.PreferredLanguage,
 		sessionModel.UserAgent,
 	)
-	cmd.RegisterLogout(ctx, sessionModel.AggregateID, sessionModel.UserID, authReqModel.ClientID, backChannelLogoutURI)
+	backChannelLogoutURI, frontChannelLogoutURI := opts.logoutURIs()
+	cmd.RegisterLogout(ctx, sessionModel.AggregateID, sessionModel.UserID, authReqModel.ClientID, backChannelLogoutURI, frontChannelLogoutURI)
 
 	if authReqModel.ResponseType != domain.OIDCResponseTypeIDToken {
-		if err = cmd.AddAccessToken(ctx, authReqModel.Scope, sessionModel.UserID, sessionModel.UserResourceOwner, domain.TokenReasonAuthRequest, nil); err != nil {
+		audience, err := opts.accessTokenAudience().resolve(ctx, authReqModel.Audience)
+		if err != nil {
+			return nil, "", err
+		}
+		if err = cmd.AddAccessToken(ctx, authReqModel.Scope, sessionModel.UserID, sessionModel.UserResourceOwner, domain.TokenReasonAuthRequest, nil, audience); err != nil {
 			return nil, "", err
 		}
 	}
@@ -140,8 +219,7 @@ func (c *Commands) CreateOIDCSessionFromAuthRequest(
 func (c *Commands) CreateOIDCSession(ctx context.Context,
 	userID,
 	resourceOwner,
-	clientID,
-	backChannelLogoutURI string,
+	clientID string,
 	scope,
 	audience []string,
 	authMethods []domain.UserAuthMethodType,
@@ -154,11 +232,12 @@ func (c *Commands) CreateOIDCSession(ctx context.Context,
 	needRefreshToken bool,
 	sessionID string,
 	responseType domain.OIDCResponseType,
+	opts *OIDCSessionOptions,
 ) (session *OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	cmd, err := c.newOIDCSessionAddEvents(ctx, userID, resourceOwner)
+	cmd, err := c.newOIDCSessionAddEvents(ctx, userID, resourceOwner, opts.refreshTokenSettings())
 	if err != nil {
 		return nil, err
 	}
@@ -170,9 +249,14 @@ func (c *Commands) CreateOIDCSession(ctx context.Context,
 	}
 
 	cmd.AddSession(ctx, userID, resourceOwner, sessionID, clientID, audience, scope, authMethods, authTime, nonce, preferredLanguage, userAgent)
-	cmd.RegisterLogout(ctx, sessionID, userID, clientID, backChannelLogoutURI)
+	backChannelLogoutURI, frontChannelLogoutURI := opts.logoutURIs()
+	cmd.RegisterLogout(ctx, sessionID, userID, clientID, backChannelLogoutURI, frontChannelLogoutURI)
 	if responseType != domain.OIDCResponseTypeIDToken {
-		if err = cmd.AddAccessToken(ctx, scope, userID, resourceOwner, reason, actor); err != nil {
+		accessTokenAud, err := opts.accessTokenAudience().resolve(ctx, audience)
+		if err != nil {
+			return nil, err
+		}
+		if err = cmd.AddAccessToken(ctx, scope, userID, resourceOwner, reason, actor, accessTokenAud); err != nil {
 			return nil, err
 		}
 	}
@@ -196,11 +280,14 @@ type RefreshTokenComplianceChecker func(ctx context.Context, wm *OIDCSessionWrit
 
 // ExchangeOIDCSessionRefreshAndAccessToken updates an existing OIDC Session, creates a new access and refresh token.
 // It returns the access token id and expiration and the new refresh token.
-func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, refreshToken string, scope []string, complianceCheck RefreshTokenComplianceChecker) (_ *OIDCSession, err error) {
+// Depending on the rotation of the refreshTokenSettings, the refresh token is either kept or replaced by a new one.
+// If reuse detection is enabled and an already rotated refresh token is presented,
+// all tokens of the session are revoked and the session is terminated.
+func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, refreshToken string, scope []string, complianceCheck RefreshTokenComplianceChecker, refreshTokenSettings *RefreshTokenSettings, accessTokenAudience AccessTokenAudienceResolver) (_ *OIDCSession, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	cmd, err := c.newOIDCSessionUpdateEvents(ctx, refreshToken)
+	cmd, err := c.newOIDCSessionUpdateEvents(ctx, refreshToken, refreshTokenSettings)
 	if err != nil {
 		return nil, err
 	}
@@ -208,11 +295,16 @@ func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
+	audience, err := accessTokenAudience.resolve(ctx, cmd.oidcSessionWriteModel.Audience)
+	if err != nil {
+		return nil, err
+	}
 	err = cmd.AddAccessToken(ctx, scope,
 		cmd.oidcSessionWriteModel.UserID,
 		cmd.oidcSessionWriteModel.UserResourceOwner,
 		domain.TokenReasonRefresh,
 		cmd.oidcSessionWriteModel.AccessTokenActor,
+		audience,
 	)
 	if err != nil {
 		return nil, err
@@ -293,7 +385,7 @@ func (c *Commands) RevokeOIDCSessionToken(ctx context.Context, token, clientID s
 	return c.pushAppendAndReduce(ctx, writeModel, oidcsession.NewAccessTokenRevokedEvent(ctx, writeModel.aggregate))
 }
 
-func (c *Commands) newOIDCSessionAddEvents(ctx context.Context, userID, resourceOwner string, pending ...eventstore.Command) (*OIDCSessionEvents, error) {
+func (c *Commands) newOIDCSessionAddEvents(ctx context.Context, userID, resourceOwner string, refreshTokenSettings *RefreshTokenSettings, pending ...eventstore.Command) (*OIDCSessionEvents, error) {
 	userStateModel, err := c.userStateWriteModel(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -305,6 +397,7 @@ func (c *Commands) newOIDCSessionAddEvents(ctx context.Context, userID, resource
 	if err != nil {
 		return nil, err
 	}
+	refreshTokenLifeTime, refreshTokenIdleLifetime = refreshTokenSettings.lifetimes(refreshTokenLifeTime, refreshTokenIdleLifetime)
 	sessionID, err := c.idGenerator.Next()
 	if err != nil {
 		return nil, err
@@ -341,7 +434,7 @@ func parseRefreshToken(refreshToken string) (oidcSessionID, refreshTokenID strin
 	return split[0], strings.Split(split[1], oidcTokenSubjectDelimiter)[0], nil
 }
 
-func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, refreshToken string) (*OIDCSessionEvents, error) {
+func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, refreshToken string, refreshTokenSettings *RefreshTokenSettings) (*OIDCSessionEvents, error) {
 	oidcSessionID, refreshTokenID, err := c.decryptRefreshToken(refreshToken)
 	if err != nil {
 		return nil, err
@@ -351,6 +444,9 @@ func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, refreshToken
 		return nil, err
 	}
 	if err = sessionWriteModel.CheckRefreshToken(refreshTokenID); err != nil {
+		if refreshTokenSettings.rotation().DetectReuse() && sessionWriteModel.IsRotatedRefreshToken(refreshTokenID) {
+			return nil, c.revokeReusedRefreshToken(ctx, sessionWriteModel, refreshTokenID)
+		}
 		return nil, err
 	}
 	userStateWriteModel, err := c.userStateWriteModel(ctx, sessionWriteModel.UserID)
@@ -364,6 +460,7 @@ func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, refreshToken
 	if err != nil {
 		return nil, err
 	}
+	refreshTokenLifeTime, refreshTokenIdleLifetime = refreshTokenSettings.lifetimes(refreshTokenLifeTime, refreshTokenIdleLifetime)
 	return &OIDCSessionEvents{
 		commands:                 c,
 		idGenerator:              c.idGenerator,
@@ -372,9 +469,24 @@ func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, refreshToken
 		accessTokenLifetime:      accessTokenLifetime,
 		refreshTokenLifeTime:     refreshTokenLifeTime,
 		refreshTokenIdleLifetime: refreshTokenIdleLifetime,
+		refreshTokenRotation:     refreshTokenSettings.rotation(),
+		refreshToken:             refreshToken,
 	}, nil
 }
 
+// revokeReusedRefreshToken revokes all tokens of the OIDC session and terminates it,
+// because an already rotated refresh token was presented.
+// It always returns an error, which can be directly returned to the client.
+func (c *Commands) revokeReusedRefreshToken(ctx context.Context, writeModel *OIDCSessionWriteModel, refreshTokenID string) error {
+	logging.WithFields("oidcSessionID", writeModel.AggregateID, "refreshTokenID", refreshTokenID, "userID", writeModel.UserID, "clientID", writeModel.ClientID).
+		Warn("reuse of rotated refresh token detected, terminating oidc session")
+	_, err := c.eventstore.Push(ctx, oidcsession.NewRefreshTokenReusedEvent(ctx, writeModel.aggregate, refreshTokenID, writeModel.UserID, writeModel.ClientID))
+	if err != nil {
+		return err
+	}
+	return zerrors.ThrowPreconditionFailed(nil, "OIDCS-Reu5e", "Errors.OIDCSession.RefreshTokenInvalid")
+}
+
 type OIDCSessionEvents struct {
 	commands              *Commands
 	idGenerator           id.Generator
@@ -386,6 +498,7 @@ type OIDCSessionEvents struct {
 	accessTokenLifetime      time.Duration
 	refreshTokenLifeTime     time.Duration
 	refreshTokenIdleLifetime time.Duration
+	refreshTokenRotation     domain.OIDCRefreshTokenRotation
 
 	// accessTokenID is set by the command
 	accessTokenID string
@@ -441,30 +554,43 @@ func (c *OIDCSessionEvents) SetAuthRequestFailed(ctx context.Context, authReques
 	c.events = append(c.events, authrequest.NewFailedEvent(ctx, authRequestAggregate, domain.OIDCErrorReasonFromError(err)))
 }
 
-func (c *OIDCSessionEvents) RegisterLogout(ctx context.Context, sessionID, userID, clientID, backChannelLogoutURI string) {
+func (c *OIDCSessionEvents) RegisterLogout(ctx context.Context, sessionID, userID, clientID, backChannelLogoutURI, frontChannelLogoutURI string) {
 	// If there's no SSO session (e.g. service accounts) we do not need to register a logout handler.
-	// Also, if the client did not register a backchannel_logout_uri it will not support it (https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRegistration)
-	if sessionID == "" || backChannelLogoutURI == "" {
+	if sessionID == "" {
 		return
 	}
-
-	c.events = append(c.events, sessionlogout.NewBackChannelLogoutRegisteredEvent(
-		ctx,
-		&sessionlogout.NewAggregate(sessionID, authz.GetInstance(ctx).InstanceID()).Aggregate,
-		c.oidcSessionWriteModel.AggregateID,
-		userID,
-		clientID,
-		backChannelLogoutURI,
-	))
+	aggregate := &sessionlogout.NewAggregate(sessionID, authz.GetInstance(ctx).InstanceID()).Aggregate
+	// If the client did not register a backchannel_logout_uri it will not support it (https://openid.net/specs/openid-connect-backchannel-1_0.html#BCRegistration)
+	if backChannelLogoutURI != "" {
+		c.events = append(c.events, sessionlogout.NewBackChannelLogoutRegisteredEvent(
+			ctx,
+			aggregate,
+			c.oidcSessionWriteModel.AggregateID,
+			userID,
+			clientID,
+			backChannelLogoutURI,
+		))
+	}
+	// The same applies to the frontchannel_logout_uri (https://openid.net/specs/openid-connect-frontchannel-1_0.html#RPLogout)
+	if frontChannelLogoutURI != "" {
+		c.events = append(c.events, sessionlogout.NewFrontChannelLogoutRegisteredEvent(
+			ctx,
+			aggregate,
+			c.oidcSessionWriteModel.AggregateID,
+			userID,
+			clientID,
+			frontChannelLogoutURI,
+		))
+	}
 }
 
-func (c *OIDCSessionEvents) AddAccessToken(ctx context.Context, scope []string, userID, resourceOwner string, reason domain.TokenReason, actor *domain.TokenActor) error {
+func (c *OIDCSessionEvents) AddAccessToken(ctx context.Context, scope []string, userID, resourceOwner string, reason domain.TokenReason, actor *domain.TokenActor, audience []string) error {
 	accessTokenID, err := c.idGenerator.Next()
 	if err != nil {
 		return err
 	}
 	c.accessTokenID = AccessTokenPrefix + accessTokenID
-	c.events = append(c.events, oidcsession.NewAccessTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.accessTokenID, scope, c.accessTokenLifetime, reason, actor))
+	c.events = append(c.events, oidcsession.NewAccessTokenAddedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.accessTokenID, scope, c.accessTokenLifetime, reason, actor, audience))
 	return nil
 }
 
@@ -477,7 +603,14 @@ func (c *OIDCSessionEvents) AddRefreshToken(ctx context.Context, userID string)
 	return nil
 }
 
+// RenewRefreshToken extends the idle expiration of the refresh token.
+// Unless the rotation is disabled, the current refresh token is replaced by a new one.
 func (c *OIDCSessionEvents) RenewRefreshToken(ctx context.Context) (err error) {
+	if c.refreshTokenRotation == domain.OIDCRefreshTokenRotationNever {
+		// keep the presented refresh token
+		c.events = append(c.events, oidcsession.NewRefreshTokenRenewedEvent(ctx, c.oidcSessionWriteModel.aggregate, c.oidcSessionWriteModel.RefreshTokenID, c.refreshTokenIdleLifetime))
+		return nil
+	}
 	var refreshTokenID string
 	refreshTokenID, c.refreshToken, err = c.generateRefreshToken(c.oidcSessionWriteModel.UserID)
 	if err != nil {
@@ -514,20 +647,21 @@ func (c *OIDCSessionEvents) PushEvents(ctx context.Context) (*OIDCSession, error
 		return nil, err
 	}
 	session := &OIDCSession{
-		SessionID:         c.oidcSessionWriteModel.SessionID,
-		ClientID:          c.oidcSessionWriteModel.ClientID,
-		UserID:            c.oidcSessionWriteModel.UserID,
-		Audience:          c.oidcSessionWriteModel.Audience,
-		Expiration:        c.oidcSessionWriteModel.AccessTokenExpiration,
-		Scope:             c.oidcSessionWriteModel.Scope,
-		AuthMethods:       c.oidcSessionWriteModel.AuthMethods,
-		AuthTime:          c.oidcSessionWriteModel.AuthTime,
-		Nonce:             c.oidcSessionWriteModel.Nonce,
-		PreferredLanguage: c.oidcSessionWriteModel.PreferredLanguage,
-		UserAgent:         c.oidcSessionWriteModel.UserAgent,
-		Reason:            c.oidcSessionWriteModel.AccessTokenReason,
-		Actor:             c.oidcSessionWriteModel.AccessTokenActor,
-		RefreshToken:      c.refreshToken,
+		SessionID:           c.oidcSessionWriteModel.SessionID,
+		ClientID:            c.oidcSessionWriteModel.ClientID,
+		UserID:              c.oidcSessionWriteModel.UserID,
+		Audience:            c.oidcSessionWriteModel.Audience,
+		Expiration:          c.oidcSessionWriteModel.AccessTokenExpiration,
+		Scope:               c.oidcSessionWriteModel.Scope,
+		AuthMethods:         c.oidcSessionWriteModel.AuthMethods,
+		AuthTime:            c.oidcSessionWriteModel.AuthTime,
+		Nonce:               c.oidcSessionWriteModel.Nonce,
+		PreferredLanguage:   c.oidcSessionWriteModel.PreferredLanguage,
+		UserAgent:           c.oidcSessionWriteModel.UserAgent,
+		Reason:              c.oidcSessionWriteModel.AccessTokenReason,
+		Actor:               c.oidcSessionWriteModel.AccessTokenActor,
+		RefreshToken:        c.refreshToken,
+		AccessTokenAudience: c.oidcSessionWriteModel.AccessTokenAudience,
 	}
 	if c.accessTokenID != "" {
 		// prefix the returned id with the oidcSessionID so that we can retrieve it later on
@@ -31,10 +31,13 @@ type OIDCSessionWriteModel struct {
 	AccessTokenExpiration      time.Time
 	AccessTokenReason          domain.TokenReason
 	AccessTokenActor           *domain.TokenActor
+	AccessTokenAudience        []string
 	RefreshTokenID             string
 	RefreshToken               string
 	RefreshTokenExpiration     time.Time
 	RefreshTokenIdleExpiration time.Time
+	// RefreshTokenGeneration counts how often the refresh token of the session has been replaced by a newer one.
+	RefreshTokenGeneration uint32
 
 	aggregate *eventstore.Aggregate
 }
@@ -64,6 +67,8 @@ func (wm *OIDCSessionWriteModel) Reduce() error {
 			wm.reduceRefreshTokenRenewed(e)
 		case *oidcsession.RefreshTokenRevokedEvent:
 			wm.reduceRefreshTokenRevoked(e)
+		case *oidcsession.RefreshTokenReusedEvent:
+			wm.reduceRefreshTokenReused(e)
 		}
 	}
 	return wm.WriteModel.Reduce()
@@ -80,6 +85,7 @@ func (wm *OIDCSessionWriteModel) Query() *eventstore.SearchQueryBuilder {
 			oidcsession.RefreshTokenAddedType,
 			oidcsession.RefreshTokenRenewedType,
 			oidcsession.RefreshTokenRevokedType,
+			oidcsession.RefreshTokenReusedType,
 		).
 		Builder()
 
@@ -114,6 +120,7 @@ func (wm *OIDCSessionWriteModel) reduceAccessTokenAdded(e *oidcsession.AccessTok
 	wm.AccessTokenExpiration = e.CreationDate().Add(e.Lifetime)
 	wm.AccessTokenReason = e.Reason
 	wm.AccessTokenActor = e.Actor
+	wm.AccessTokenAudience = e.Audience
 }
 
 func (wm *OIDCSessionWriteModel) reduceAccessTokenRevoked(e *oidcsession.AccessTokenRevokedEvent) {
@@ -128,6 +135,9 @@ func (wm *OIDCSessionWriteModel) reduceRefreshTokenAdded(e *oidcsession.RefreshT
 }
 
 func (wm *OIDCSessionWriteModel) reduceRefreshTokenRenewed(e *oidcsession.RefreshTokenRenewedEvent) {
+	if wm.RefreshTokenID != "" && wm.RefreshTokenID != e.ID {
+		wm.RefreshTokenGeneration++
+	}
 	wm.RefreshTokenID = e.ID
 	wm.RefreshTokenIdleExpiration = e.CreationDate().Add(e.IdleLifetime)
 }
@@ -140,6 +150,15 @@ func (wm *OIDCSessionWriteModel) reduceRefreshTokenRevoked(e *oidcsession.Refres
 	wm.AccessTokenExpiration = e.CreationDate()
 }
 
+func (wm *OIDCSessionWriteModel) reduceRefreshTokenReused(e *oidcsession.RefreshTokenReusedEvent) {
+	wm.State = domain.OIDCSessionStateTerminated
+	wm.RefreshTokenID = ""
+	wm.RefreshTokenExpiration = e.CreationDate()
+	wm.RefreshTokenIdleExpiration = e.CreationDate()
+	wm.AccessTokenID = ""
+	wm.AccessTokenExpiration = e.CreationDate()
+}
+
 func (wm *OIDCSessionWriteModel) CheckRefreshToken(refreshTokenID string) error {
 	if wm.State != domain.OIDCSessionStateActive {
 		return zerrors.ThrowPreconditionFailed(nil, "OIDCS-s3hjk", "Errors.OIDCSession.RefreshTokenInvalid")
@@ -154,6 +173,17 @@ func (wm *OIDCSessionWriteModel) CheckRefreshToken(refreshTokenID string) error
 	return nil
 }
 
+// IsRotatedRefreshToken returns true if the refresh token was issued for the session,
+// but has already been replaced by a newer one.
+// Refresh tokens are encrypted and contain the session id, so every token of an active session
+// which is not the current one must be of an earlier generation, as long as the token was rotated at all.
+func (wm *OIDCSessionWriteModel) IsRotatedRefreshToken(refreshTokenID string) bool {
+	return wm.State == domain.OIDCSessionStateActive &&
+		wm.RefreshTokenGeneration > 0 &&
+		wm.RefreshTokenID != "" &&
+		wm.RefreshTokenID != refreshTokenID
+}
+
 func (wm *OIDCSessionWriteModel) CheckAccessToken(accessTokenID string) error {
 	if wm.State != domain.OIDCSessionStateActive {
 		return zerrors.ThrowPreconditionFailed(nil, "OIDCS-KL2pk", "Errors.OIDCSession.Token.Invalid")
@@ -167,7 +197,12 @@ func (wm *OIDCSessionWriteModel) CheckAccessToken(accessTokenID string) error {
 	return nil
 }
 
+// CheckClient checks if the client is allowed to use the tokens of the session.
+// Besides the client the session was created for, every client of the audience is allowed.
 func (wm *OIDCSessionWriteModel) CheckClient(clientID string) error {
+	if wm.ClientID == clientID {
+		return nil
+	}
 	for _, aud := range wm.Audience {
 		if aud == clientID {
 			return nil
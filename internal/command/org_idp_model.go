@@ -959,6 +959,85 @@ func (wm *OrgSAMLIDPWriteModel) NewChangedEvent(
 	return org.NewSAMLIDPChangedEvent(ctx, aggregate, id, changes)
 }
 
+type OrgX509IDPWriteModel struct {
+	X509IDPWriteModel
+}
+
+func NewX509OrgIDPWriteModel(orgID, id string) *OrgX509IDPWriteModel {
+	return &OrgX509IDPWriteModel{
+		X509IDPWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+			ID: id,
+		},
+	}
+}
+
+func (wm *OrgX509IDPWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.X509IDPAddedEvent:
+			wm.X509IDPWriteModel.AppendEvents(&e.X509IDPAddedEvent)
+		case *org.X509IDPChangedEvent:
+			wm.X509IDPWriteModel.AppendEvents(&e.X509IDPChangedEvent)
+		case *org.IDPRemovedEvent:
+			wm.X509IDPWriteModel.AppendEvents(&e.RemovedEvent)
+		default:
+			wm.X509IDPWriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *OrgX509IDPWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			org.X509IDPAddedEventType,
+			org.X509IDPChangedEventType,
+			org.IDPRemovedEventType,
+		).
+		EventData(map[string]interface{}{"id": wm.ID}).
+		Builder()
+}
+
+func (wm *OrgX509IDPWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	certificateHeader string,
+	trustedProxies []string,
+	caCertificates []byte,
+	crls,
+	ocspResponses [][]byte,
+	revocationCheckRequired bool,
+	idField,
+	usernameField domain.X509CertificateField,
+	options idp.Options,
+) (*org.X509IDPChangedEvent, error) {
+	changes := wm.X509IDPWriteModel.NewChanges(
+		name,
+		certificateHeader,
+		trustedProxies,
+		caCertificates,
+		crls,
+		ocspResponses,
+		revocationCheckRequired,
+		idField,
+		usernameField,
+		options,
+	)
+	if len(changes) == 0 {
+		return nil, nil
+	}
+	return org.NewX509IDPChangedEvent(ctx, aggregate, id, changes)
+}
+
 type OrgIDPRemoveWriteModel struct {
 	IDPRemoveWriteModel
 }
@@ -1002,6 +1081,8 @@ func (wm *OrgIDPRemoveWriteModel) AppendEvents(events ...eventstore.Event) {
 			wm.IDPRemoveWriteModel.AppendEvents(&e.AppleIDPAddedEvent)
 		case *org.SAMLIDPAddedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.SAMLIDPAddedEvent)
+		case *org.X509IDPAddedEvent:
+			wm.IDPRemoveWriteModel.AppendEvents(&e.X509IDPAddedEvent)
 		case *org.IDPRemovedEvent:
 			wm.IDPRemoveWriteModel.AppendEvents(&e.RemovedEvent)
 		case *org.IDPConfigAddedEvent:
@@ -1033,6 +1114,7 @@ func (wm *OrgIDPRemoveWriteModel) Query() *eventstore.SearchQueryBuilder {
 			org.LDAPIDPAddedEventType,
 			org.AppleIDPAddedEventType,
 			org.SAMLIDPAddedEventType,
+			org.X509IDPAddedEventType,
 			org.IDPRemovedEventType,
 		).
 		EventData(map[string]interface{}{"id": wm.ID}).
@@ -5432,6 +5432,482 @@ func stringPointer(s string) *string {
 	return &s
 }
 
+func TestCommandSide_AddOrgX509IDP(t *testing.T) {
+	type fields struct {
+		eventstore  func(*testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		provider      X509Provider
+	}
+	type res struct {
+		id   string
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid name",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider:      X509Provider{},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Gk2ls", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			"invalid certificate header",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name: "name",
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Vq4bn", "Errors.IDP.CertificateHeaderMissing"),
+			},
+		},
+		{
+			"no trusted proxies",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Pz3gq", "Errors.IDP.TrustedProxiesMissing"),
+			},
+		},
+		{
+			"invalid trusted proxy",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"proxy"},
+					CACertificates:    validLDAPRootCA,
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Kd8wu", "Errors.IDP.InvalidTrustedProxy"),
+			},
+		},
+		{
+			"no ca certificates",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Hs9cd", "Errors.IDP.CACertificatesMissing"),
+			},
+		},
+		{
+			"invalid certificate field",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+					IDField:           domain.X509CertificateField(100),
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ob3jw", "Errors.IDP.InvalidCertificateField"),
+			},
+		},
+		{
+			"invalid ca certificates",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    []byte("invalid"),
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Tn6xe", "Errors.IDP.InvalidCACertificates"),
+			},
+		},
+		{
+			"invalid crl",
+			fields{
+				eventstore:  expectEventstore(),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+					CRLs:              [][]byte{[]byte("invalid")},
+				},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Lp1zs", "Errors.IDP.InvalidCRL"),
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						org.NewX509IDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							"name",
+							"X-SSL-Client-Cert",
+							[]string{"10.0.0.1"},
+							validLDAPRootCA,
+							nil,
+							nil,
+							false,
+							domain.X509CertificateFieldUnspecified,
+							domain.X509CertificateFieldUnspecified,
+							idp.Options{},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+				},
+			},
+			res: res{
+				id:   "id1",
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "ok all set",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectPush(
+						org.NewX509IDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+							"id1",
+							"name",
+							"X-SSL-Client-Cert",
+							[]string{"10.0.0.1"},
+							validLDAPRootCA,
+							nil,
+							[][]byte{[]byte("ocsp")},
+							true,
+							domain.X509CertificateFieldSANUPN,
+							domain.X509CertificateFieldSANEmail,
+							idp.Options{
+								IsCreationAllowed: true,
+								IsLinkingAllowed:  true,
+								IsAutoCreation:    true,
+								IsAutoUpdate:      true,
+								AutoLinkingOption: domain.AutoLinkingOptionEmail,
+							},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "id1"),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider: X509Provider{
+					Name:                    "name",
+					CertificateHeader:       "X-SSL-Client-Cert",
+					TrustedProxies:          []string{"10.0.0.1"},
+					CACertificates:          validLDAPRootCA,
+					OCSPResponses:           [][]byte{[]byte("ocsp")},
+					RevocationCheckRequired: true,
+					IDField:                 domain.X509CertificateFieldSANUPN,
+					UsernameField:           domain.X509CertificateFieldSANEmail,
+					IDPOptions: idp.Options{
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+						AutoLinkingOption: domain.AutoLinkingOptionEmail,
+					},
+				},
+			},
+			res: res{
+				id:   "id1",
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore(t),
+				idGenerator: tt.fields.idGenerator,
+			}
+			id, got, err := c.AddOrgX509Provider(tt.args.ctx, tt.args.resourceOwner, tt.args.provider)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, id)
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_UpdateOrgX509IDP(t *testing.T) {
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		resourceOwner string
+		id            string
+		provider      X509Provider
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"invalid id",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				provider:      X509Provider{},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "ORG-zPy1z", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			"invalid name",
+			fields{
+				eventstore: expectEventstore(),
+			},
+			args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider:      X509Provider{},
+			},
+			res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Gk2ls", "Errors.Invalid.Argument"),
+			},
+		},
+		{
+			name: "not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+				},
+			},
+			res: res{
+				err: zerrors.ThrowNotFound(nil, "ORG-t67N2", "Errors.IDPConfig.NotExisting"),
+			},
+		},
+		{
+			name: "no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewX509IDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								"X-SSL-Client-Cert",
+								[]string{"10.0.0.1"},
+								validLDAPRootCA,
+								nil,
+								nil,
+								false,
+								domain.X509CertificateFieldUnspecified,
+								domain.X509CertificateFieldUnspecified,
+								idp.Options{},
+							)),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider: X509Provider{
+					Name:              "name",
+					CertificateHeader: "X-SSL-Client-Cert",
+					TrustedProxies:    []string{"10.0.0.1"},
+					CACertificates:    validLDAPRootCA,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "change ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							org.NewX509IDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								"name",
+								"X-SSL-Client-Cert",
+								[]string{"10.0.0.1"},
+								validLDAPRootCA,
+								nil,
+								nil,
+								false,
+								domain.X509CertificateFieldUnspecified,
+								domain.X509CertificateFieldUnspecified,
+								idp.Options{},
+							)),
+					),
+					expectPush(
+						func() eventstore.Command {
+							t := true
+							event, _ := org.NewX509IDPChangedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+								"id1",
+								[]idp.X509IDPChanges{
+									idp.ChangeX509Name("new name"),
+									idp.ChangeX509CertificateHeader("X-Client-Cert"),
+									idp.ChangeX509TrustedProxies([]string{"10.0.0.0/8"}),
+									idp.ChangeX509OCSPResponses([][]byte{[]byte("ocsp")}),
+									idp.ChangeX509RevocationCheckRequired(true),
+									idp.ChangeX509IDField(domain.X509CertificateFieldSANUPN),
+									idp.ChangeX509UsernameField(domain.X509CertificateFieldSANEmail),
+									idp.ChangeX509Options(idp.OptionChanges{
+										IsCreationAllowed: &t,
+										IsLinkingAllowed:  &t,
+										IsAutoCreation:    &t,
+										IsAutoUpdate:      &t,
+									}),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx:           context.Background(),
+				resourceOwner: "org1",
+				id:            "id1",
+				provider: X509Provider{
+					Name:                    "new name",
+					CertificateHeader:       "X-Client-Cert",
+					TrustedProxies:          []string{"10.0.0.0/8"},
+					CACertificates:          validLDAPRootCA,
+					OCSPResponses:           [][]byte{[]byte("ocsp")},
+					RevocationCheckRequired: true,
+					IDField:                 domain.X509CertificateFieldSANUPN,
+					UsernameField:           domain.X509CertificateFieldSANEmail,
+					IDPOptions: idp.Options{
+						IsCreationAllowed: true,
+						IsLinkingAllowed:  true,
+						IsAutoCreation:    true,
+						IsAutoUpdate:      true,
+					},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.UpdateOrgX509Provider(tt.args.ctx, tt.args.resourceOwner, tt.args.id, tt.args.provider)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
 func TestCommandSide_AddOrgSAMLIDP(t *testing.T) {
 	type fields struct {
 		eventstore                 func(*testing.T) *eventstore.Eventstore
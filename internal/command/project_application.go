@@ -144,6 +144,9 @@ func (c *Commands) RemoveApplication(ctx context.Context, projectID, appID, reso
 	if err == nil && samlWriteModel.State != domain.AppStateUnspecified && samlWriteModel.State != domain.AppStateRemoved && samlWriteModel.saml {
 		entityID = samlWriteModel.EntityID
 	}
+	if existingApp.Realm != "" {
+		entityID = existingApp.Realm
+	}
 
 	pushedEvents, err := c.eventstore.Push(ctx, project.NewApplicationRemovedEvent(ctx, projectAgg, appID, existingApp.Name, entityID))
 	if err != nil {
@@ -4,6 +4,8 @@ import (
 	"context"
 	"strings"
 
+	"github.com/muhlemmer/gu"
+
 	"github.com/zitadel/zitadel/internal/command/preparation"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -15,6 +17,7 @@ import (
 type addAPIApp struct {
 	AddApp
 	AuthMethodType domain.APIAuthMethodType
+	ResourceURI    string
 
 	ClientID          string
 	EncodedHash       string
@@ -29,6 +32,9 @@ func (c *Commands) AddAPIAppCommand(app *addAPIApp) preparation.Validation {
 		if app.Name = strings.TrimSpace(app.Name); app.Name == "" {
 			return nil, zerrors.ThrowInvalidArgument(nil, "PROJE-F7g21", "Errors.Invalid.Argument")
 		}
+		if app.ResourceURI != "" && !domain.IsValidResourceIndicator(app.ResourceURI) {
+			return nil, zerrors.ThrowInvalidArgument(nil, "PROJE-Ra8ic", "Errors.Project.App.APIConfigInvalid")
+		}
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			project, err := projectWriteModel(ctx, filter, app.Aggregate.ID, app.Aggregate.ResourceOwner)
 			if err != nil || !project.State.Valid() {
@@ -61,6 +67,7 @@ func (c *Commands) AddAPIAppCommand(app *addAPIApp) preparation.Validation {
 					app.ClientID,
 					app.EncodedHash,
 					app.AuthMethodType,
+					app.ResourceURI,
 				),
 			}, nil
 		}, nil
@@ -149,7 +156,8 @@ func (c *Commands) addAPIApplicationWithID(ctx context.Context, apiApp *domain.A
 		apiApp.AppID,
 		apiApp.ClientID,
 		apiApp.EncodedHash,
-		apiApp.AuthMethodType))
+		apiApp.AuthMethodType,
+		gu.Value(apiApp.ResourceURI)))
 
 	addedApplication.AppID = apiApp.AppID
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
@@ -169,6 +177,12 @@ func (c *Commands) UpdateAPIApplication(ctx context.Context, apiApp *domain.APIA
 	if apiApp.AppID == "" || apiApp.AggregateID == "" {
 		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-1m900", "Errors.Project.App.APIConfigInvalid")
 	}
+	if !apiApp.IsResourceURIValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ra8ic", "Errors.Project.App.APIConfigInvalid")
+	}
+	if !apiApp.IsIntrospectionEncryptionKeyValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ie9nk", "Errors.Project.App.APIConfigInvalid")
+	}
 
 	existingAPI, err := c.getAPIAppWriteModel(ctx, apiApp.AggregateID, apiApp.AppID, resourceOwner)
 	if err != nil {
@@ -192,7 +206,10 @@ func (c *Commands) UpdateAPIApplication(ctx context.Context, apiApp *domain.APIA
 		ctx,
 		projectAgg,
 		apiApp.AppID,
-		apiApp.AuthMethodType)
+		apiApp.AuthMethodType,
+		apiApp.ResourceURI,
+		apiApp.IntrospectionSignedResponse,
+		apiApp.IntrospectionEncryptionKey)
 	if err != nil {
 		return nil, err
 	}
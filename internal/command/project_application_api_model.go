@@ -1,6 +1,7 @@
 package command
 
 import (
+	"bytes"
 	"context"
 
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -18,8 +19,12 @@ type APIApplicationWriteModel struct {
 	HashedSecret       string
 	ClientSecretString string
 	AuthMethodType     domain.APIAuthMethodType
+	ResourceURI        string
 	State              domain.AppState
-	api                bool
+
+	IntrospectionSignedResponse bool
+	IntrospectionEncryptionKey  []byte
+	api                         bool
 }
 
 func NewAPIApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *APIApplicationWriteModel {
@@ -134,12 +139,22 @@ func (wm *APIApplicationWriteModel) appendAddAPIEvent(e *project.APIConfigAddedE
 	wm.ClientID = e.ClientID
 	wm.HashedSecret = crypto.SecretOrEncodedHash(e.ClientSecret, e.HashedSecret)
 	wm.AuthMethodType = e.AuthMethodType
+	wm.ResourceURI = e.ResourceURI
 }
 
 func (wm *APIApplicationWriteModel) appendChangeAPIEvent(e *project.APIConfigChangedEvent) {
 	if e.AuthMethodType != nil {
 		wm.AuthMethodType = *e.AuthMethodType
 	}
+	if e.ResourceURI != nil {
+		wm.ResourceURI = *e.ResourceURI
+	}
+	if e.IntrospectionSignedResponse != nil {
+		wm.IntrospectionSignedResponse = *e.IntrospectionSignedResponse
+	}
+	if e.IntrospectionEncryptionKey != nil {
+		wm.IntrospectionEncryptionKey = *e.IntrospectionEncryptionKey
+	}
 }
 
 func (wm *APIApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -167,6 +182,9 @@ func (wm *APIApplicationWriteModel) NewChangedEvent(
 	aggregate *eventstore.Aggregate,
 	appID string,
 	authMethodType domain.APIAuthMethodType,
+	resourceURI *string,
+	introspectionSignedResponse *bool,
+	introspectionEncryptionKey []byte,
 ) (*project.APIConfigChangedEvent, bool, error) {
 	changes := make([]project.APIConfigChanges, 0)
 	var err error
@@ -174,6 +192,15 @@ func (wm *APIApplicationWriteModel) NewChangedEvent(
 	if wm.AuthMethodType != authMethodType {
 		changes = append(changes, project.ChangeAPIAuthMethodType(authMethodType))
 	}
+	if resourceURI != nil && wm.ResourceURI != *resourceURI {
+		changes = append(changes, project.ChangeAPIResourceURI(*resourceURI))
+	}
+	if introspectionSignedResponse != nil && wm.IntrospectionSignedResponse != *introspectionSignedResponse {
+		changes = append(changes, project.ChangeAPIIntrospectionSignedResponse(*introspectionSignedResponse))
+	}
+	if introspectionEncryptionKey != nil && !bytes.Equal(wm.IntrospectionEncryptionKey, introspectionEncryptionKey) {
+		changes = append(changes, project.ChangeAPIIntrospectionEncryptionKey(introspectionEncryptionKey))
+	}
 	if len(changes) == 0 {
 		return nil, false, nil
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/muhlemmer/gu"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/command/preparation"
@@ -115,6 +116,7 @@ func TestAddAPIConfig(t *testing.T) {
 						"clientID",
 						"",
 						domain.APIAuthMethodTypePrivateKeyJWT,
+						"",
 					),
 				},
 			},
@@ -251,7 +253,8 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 							"app1",
 							"client1",
 							"secret",
-							domain.APIAuthMethodTypeBasic),
+							domain.APIAuthMethodTypeBasic,
+							""),
 					),
 				),
 				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "app1", "client1"),
@@ -273,12 +276,14 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:              "app1",
-					AppName:            "app",
-					ClientID:           "client1",
-					ClientSecretString: "secret",
-					AuthMethodType:     domain.APIAuthMethodTypeBasic,
-					State:              domain.AppStateActive,
+					AppID:                       "app1",
+					AppName:                     "app",
+					ClientID:                    "client1",
+					ClientSecretString:          "secret",
+					AuthMethodType:              domain.APIAuthMethodTypeBasic,
+					ResourceURI:                 gu.Ptr(""),
+					IntrospectionSignedResponse: gu.Ptr(false),
+					State:                       domain.AppStateActive,
 				},
 			},
 		},
@@ -306,7 +311,8 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 							"app1",
 							"client1@project1",
 							"secret",
-							domain.APIAuthMethodTypeBasic),
+							domain.APIAuthMethodTypeBasic,
+							""),
 					),
 				),
 				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "app1", "client1@project1"),
@@ -328,12 +334,14 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:              "app1",
-					AppName:            "app",
-					ClientID:           "client1@project1",
-					ClientSecretString: "secret",
-					AuthMethodType:     domain.APIAuthMethodTypeBasic,
-					State:              domain.AppStateActive,
+					AppID:                       "app1",
+					AppName:                     "app",
+					ClientID:                    "client1@project1",
+					ClientSecretString:          "secret",
+					AuthMethodType:              domain.APIAuthMethodTypeBasic,
+					ResourceURI:                 gu.Ptr(""),
+					IntrospectionSignedResponse: gu.Ptr(false),
+					State:                       domain.AppStateActive,
 				},
 			},
 		},
@@ -361,7 +369,8 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 							"app1",
 							"client1",
 							"",
-							domain.APIAuthMethodTypePrivateKeyJWT),
+							domain.APIAuthMethodTypePrivateKeyJWT,
+							""),
 					),
 				),
 				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "app1", "client1"),
@@ -383,11 +392,13 @@ func TestCommandSide_AddAPIApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:          "app1",
-					AppName:        "app",
-					ClientID:       "client1",
-					AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
-					State:          domain.AppStateActive,
+					AppID:                       "app1",
+					AppName:                     "app",
+					ClientID:                    "client1",
+					AuthMethodType:              domain.APIAuthMethodTypePrivateKeyJWT,
+					ResourceURI:                 gu.Ptr(""),
+					IntrospectionSignedResponse: gu.Ptr(false),
+					State:                       domain.AppStateActive,
 				},
 			},
 		},
@@ -522,7 +533,8 @@ func TestCommandSide_ChangeAPIApplication(t *testing.T) {
 								"app1",
 								"client1@project",
 								"",
-								domain.APIAuthMethodTypePrivateKeyJWT),
+								domain.APIAuthMethodTypePrivateKeyJWT,
+								""),
 						),
 					),
 					expectFilter(),
@@ -562,7 +574,8 @@ func TestCommandSide_ChangeAPIApplication(t *testing.T) {
 								"app1",
 								"client1@project",
 								"secret",
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								""),
 						),
 					),
 					expectFilter(),
@@ -593,11 +606,190 @@ func TestCommandSide_ChangeAPIApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
+					AppID:                       "app1",
+					AppName:                     "app",
+					ClientID:                    "client1@project",
+					AuthMethodType:              domain.APIAuthMethodTypePrivateKeyJWT,
+					ResourceURI:                 gu.Ptr(""),
+					IntrospectionSignedResponse: gu.Ptr(false),
+					State:                       domain.AppStateActive,
+				},
+			},
+		},
+		{
+			name: "invalid resource uri, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+				apiApp: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:          "app1",
+					AuthMethodType: domain.APIAuthMethodTypeBasic,
+					ResourceURI:    gu.Ptr("/orders#fragment"),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "change resource uri, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewAPIConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"client1@project",
+								"secret",
+								domain.APIAuthMethodTypeBasic,
+								""),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := project.NewAPIConfigChangedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								[]project.APIConfigChanges{
+									project.ChangeAPIResourceURI("https://api.example.com/orders"),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				apiApp: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
 					AppID:          "app1",
 					AppName:        "app",
-					ClientID:       "client1@project",
-					AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
-					State:          domain.AppStateActive,
+					AuthMethodType: domain.APIAuthMethodTypeBasic,
+					ResourceURI:    gu.Ptr("https://api.example.com/orders"),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:                       "app1",
+					AppName:                     "app",
+					ClientID:                    "client1@project",
+					AuthMethodType:              domain.APIAuthMethodTypeBasic,
+					ResourceURI:                 gu.Ptr("https://api.example.com/orders"),
+					IntrospectionSignedResponse: gu.Ptr(false),
+					State:                       domain.AppStateActive,
+				},
+			},
+		},
+		{
+			name: "invalid introspection encryption key, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+				apiApp: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:                      "app1",
+					AuthMethodType:             domain.APIAuthMethodTypeBasic,
+					IntrospectionEncryptionKey: []byte("invalid"),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "change introspection response, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewAPIConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"client1@project",
+								"secret",
+								domain.APIAuthMethodTypeBasic,
+								""),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := project.NewAPIConfigChangedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								[]project.APIConfigChanges{
+									project.ChangeAPIIntrospectionSignedResponse(true),
+									project.ChangeAPIIntrospectionEncryptionKey([]byte(fakePubkey)),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				apiApp: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:                       "app1",
+					AppName:                     "app",
+					AuthMethodType:              domain.APIAuthMethodTypeBasic,
+					IntrospectionSignedResponse: gu.Ptr(true),
+					IntrospectionEncryptionKey:  []byte(fakePubkey),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.APIApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:                       "app1",
+					AppName:                     "app",
+					ClientID:                    "client1@project",
+					AuthMethodType:              domain.APIAuthMethodTypeBasic,
+					ResourceURI:                 gu.Ptr(""),
+					IntrospectionSignedResponse: gu.Ptr(true),
+					IntrospectionEncryptionKey:  []byte(fakePubkey),
+					State:                       domain.AppStateActive,
 				},
 			},
 		},
@@ -714,7 +906,8 @@ func TestCommandSide_ChangeAPIApplicationSecret(t *testing.T) {
 								"app1",
 								"client1@project",
 								"secret",
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								""),
 						),
 					),
 					expectPush(
@@ -738,12 +931,14 @@ func TestCommandSide_ChangeAPIApplicationSecret(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:              "app1",
-					AppName:            "app",
-					ClientID:           "client1@project",
-					ClientSecretString: "secret",
-					AuthMethodType:     domain.APIAuthMethodTypeBasic,
-					State:              domain.AppStateActive,
+					AppID:                       "app1",
+					AppName:                     "app",
+					ClientID:                    "client1@project",
+					ClientSecretString:          "secret",
+					AuthMethodType:              domain.APIAuthMethodTypeBasic,
+					ResourceURI:                 gu.Ptr(""),
+					IntrospectionSignedResponse: gu.Ptr(false),
+					State:                       domain.AppStateActive,
 				},
 			},
 		},
@@ -0,0 +1,142 @@
+package command
+
+import (
+	"context"
+
+	"github.com/muhlemmer/gu"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func (c *Commands) AddCASApplication(ctx context.Context, application *domain.CASApp, resourceOwner string) (_ *domain.CASApp, err error) {
+	if application == nil || application.AggregateID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-Ohy4a", "Errors.Project.App.Invalid")
+	}
+
+	projectResOwner, err := c.checkProjectExists(ctx, application.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if resourceOwner == "" {
+		resourceOwner = projectResOwner
+	}
+
+	addedApplication := NewCASApplicationWriteModel(application.AggregateID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, addedApplication); err != nil {
+		return nil, err
+	}
+	if err := c.checkPermissionUpdateApplication(ctx, addedApplication.ResourceOwner, addedApplication.AggregateID); err != nil {
+		return nil, err
+	}
+
+	projectAgg := ProjectAggregateFromWriteModel(&addedApplication.WriteModel)
+	events, err := c.addCASApplication(ctx, projectAgg, application)
+	if err != nil {
+		return nil, err
+	}
+	addedApplication.AppID = application.AppID
+	postCommit, err := c.applicationCreatedMilestone(ctx, &events)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	postCommit(ctx)
+	err = AppendAndReduce(addedApplication, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return casWriteModelToCASConfig(addedApplication), nil
+}
+
+func (c *Commands) addCASApplication(ctx context.Context, projectAgg *eventstore.Aggregate, casApp *domain.CASApp) (events []eventstore.Command, err error) {
+	if casApp.AppName == "" || !casApp.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-iu2Ae", "Errors.Project.App.Invalid")
+	}
+
+	casApp.AppID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	return []eventstore.Command{
+		project.NewApplicationAddedEvent(ctx, projectAgg, casApp.AppID, casApp.AppName),
+		project.NewCASConfigAddedEvent(ctx,
+			projectAgg,
+			casApp.AppID,
+			casApp.ServiceURLs,
+			gu.Value(casApp.LoginVersion),
+			gu.Value(casApp.LoginBaseURI),
+		),
+	}, nil
+}
+
+func (c *Commands) UpdateCASApplication(ctx context.Context, casApp *domain.CASApp, resourceOwner string) (*domain.CASApp, error) {
+	if casApp.AppID == "" || casApp.AggregateID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Eev5u", "Errors.Project.App.CASConfigInvalid")
+	}
+
+	existingCAS, err := c.getCASAppWriteModel(ctx, casApp.AggregateID, casApp.AppID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingCAS.State == domain.AppStateUnspecified || existingCAS.State == domain.AppStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-ahG7i", "Errors.Project.App.NotExisting")
+	}
+	if !existingCAS.IsCAS() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Tho0e", "Errors.Project.App.IsNotCAS")
+	}
+
+	// unset service urls are not changed
+	if casApp.ServiceURLs == nil {
+		casApp.ServiceURLs = existingCAS.ServiceURLs
+	}
+	if !casApp.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ieNg4", "Errors.Project.App.CASConfigInvalid")
+	}
+
+	if err := c.checkPermissionUpdateApplication(ctx, existingCAS.ResourceOwner, existingCAS.AggregateID); err != nil {
+		return nil, err
+	}
+
+	projectAgg := ProjectAggregateFromWriteModel(&existingCAS.WriteModel)
+	changedEvent, hasChanged, err := existingCAS.NewChangedEvent(
+		ctx,
+		projectAgg,
+		casApp.AppID,
+		casApp.ServiceURLs,
+		casApp.LoginVersion,
+		casApp.LoginBaseURI,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-oY6ie", "Errors.NoChangesFound")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingCAS, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+
+	return casWriteModelToCASConfig(existingCAS), nil
+}
+
+func (c *Commands) getCASAppWriteModel(ctx context.Context, projectID, appID, resourceOwner string) (*CASApplicationWriteModel, error) {
+	appWriteModel := NewCASApplicationWriteModelWithAppID(projectID, appID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, appWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return appWriteModel, nil
+}
@@ -114,7 +114,8 @@ func TestCommandSide_AddAPIApplicationKey(t *testing.T) {
 								"app1",
 								"client1@project",
 								"secret",
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								""),
 						),
 					),
 				),
@@ -155,7 +156,8 @@ func TestCommandSide_AddAPIApplicationKey(t *testing.T) {
 								"app1",
 								"client1@project",
 								"secret",
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								""),
 						),
 					),
 				),
@@ -197,7 +199,8 @@ func TestCommandSide_AddAPIApplicationKey(t *testing.T) {
 								"app1",
 								"client1@project",
 								"secret",
-								domain.APIAuthMethodTypeBasic),
+								domain.APIAuthMethodTypeBasic,
+								""),
 						),
 					),
 				),
@@ -12,6 +12,8 @@ type ApplicationWriteModel struct {
 	AppID string
 	State domain.AppState
 	Name  string
+	// Realm of a WS-Federation application, which is released on removal.
+	Realm string
 }
 
 func NewApplicationWriteModelWithAppIDC(projectID, appID, resourceOwner string) *ApplicationWriteModel {
@@ -60,6 +62,16 @@ func (wm *ApplicationWriteModel) AppendEvents(events ...eventstore.Event) {
 				continue
 			}
 			wm.WriteModel.AppendEvents(e)
+		case *project.WSFedConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.WSFedConfigChangedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
 		case *project.ProjectRemovedEvent:
 			wm.WriteModel.AppendEvents(e)
 		}
@@ -86,6 +98,12 @@ func (wm *ApplicationWriteModel) Reduce() error {
 			wm.State = domain.AppStateActive
 		case *project.ApplicationRemovedEvent:
 			wm.State = domain.AppStateRemoved
+		case *project.WSFedConfigAddedEvent:
+			wm.Realm = e.Realm
+		case *project.WSFedConfigChangedEvent:
+			if e.Realm != "" {
+				wm.Realm = e.Realm
+			}
 		case *project.ProjectRemovedEvent:
 			wm.State = domain.AppStateRemoved
 		}
@@ -105,6 +123,8 @@ func (wm *ApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
 			project.ApplicationDeactivatedType,
 			project.ApplicationReactivatedType,
 			project.ApplicationRemovedType,
+			project.WSFedConfigAddedType,
+			project.WSFedConfigChangedType,
 			project.ProjectRemovedType).
 		Builder()
 }
@@ -36,6 +36,9 @@ type addOIDCApp struct {
 	BackChannelLogoutURI        string
 	LoginVersion                domain.LoginVersion
 	LoginBaseURI                string
+	RefreshTokenRotation        domain.OIDCRefreshTokenRotation
+	RefreshTokenLifetime        time.Duration
+	RefreshTokenIdleLifetime    time.Duration
 
 	ClientID          string
 	ClientSecret      string
@@ -67,6 +70,10 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp) preparation.Validation {
 			return nil, zerrors.ThrowInvalidArgument(nil, "V2-sLpW1", "Errors.Invalid.Argument")
 		}
 
+		if !app.RefreshTokenRotation.Valid() || app.RefreshTokenLifetime < 0 || app.RefreshTokenIdleLifetime < 0 {
+			return nil, zerrors.ThrowInvalidArgument(nil, "V2-Rt0Lf", "Errors.Invalid.Argument")
+		}
+
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) (_ []eventstore.Command, err error) {
 			project, err := projectWriteModel(ctx, filter, app.Aggregate.ID, app.Aggregate.ResourceOwner)
 			if err != nil || !project.State.Valid() {
@@ -116,6 +123,16 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp) preparation.Validation {
 					app.BackChannelLogoutURI,
 					app.LoginVersion,
 					app.LoginBaseURI,
+					app.RefreshTokenRotation,
+					app.RefreshTokenLifetime,
+					app.RefreshTokenIdleLifetime,
+					"",
+					"",
+					"",
+					"",
+					"",
+					"",
+					"",
 				),
 			}, nil
 		}, nil
@@ -157,6 +174,14 @@ func (c *Commands) AddOIDCApplication(ctx context.Context, oidcApp *domain.OIDCA
 	if oidcApp.AppName == "" || !oidcApp.IsValid() {
 		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-1n8df", "Errors.Project.App.Invalid")
 	}
+	if domain.OIDCEncryptionKeySetMissing(
+		gu.Value(oidcApp.IDTokenEncryptedResponseAlg),
+		gu.Value(oidcApp.UserinfoEncryptedResponseAlg),
+		gu.Value(oidcApp.JWKS),
+		gu.Value(oidcApp.JWKSURI),
+	) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-Jw3ks", "Errors.Project.App.Invalid")
+	}
 
 	appID := oidcApp.AppID
 	if appID == "" {
@@ -230,6 +255,16 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		strings.TrimSpace(gu.Value(oidcApp.BackChannelLogoutURI)),
 		gu.Value(oidcApp.LoginVersion),
 		strings.TrimSpace(gu.Value(oidcApp.LoginBaseURI)),
+		gu.Value(oidcApp.RefreshTokenRotation),
+		gu.Value(oidcApp.RefreshTokenLifetime),
+		gu.Value(oidcApp.RefreshTokenIdleLifetime),
+		gu.Value(oidcApp.IDTokenEncryptedResponseAlg),
+		gu.Value(oidcApp.IDTokenEncryptedResponseEnc),
+		gu.Value(oidcApp.UserinfoEncryptedResponseAlg),
+		gu.Value(oidcApp.UserinfoEncryptedResponseEnc),
+		strings.TrimSpace(gu.Value(oidcApp.JWKS)),
+		strings.TrimSpace(gu.Value(oidcApp.JWKSURI)),
+		strings.TrimSpace(gu.Value(oidcApp.FrontChannelLogoutURI)),
 	))
 
 	addedApplication.AppID = oidcApp.AppID
@@ -274,15 +309,28 @@ func (c *Commands) UpdateOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		return nil, err
 	}
 
+	if existingOIDC.encryptionKeySetMissing(oidc) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Jw4ks", "Errors.Project.App.OIDCConfigInvalid")
+	}
+
 	projectAgg := ProjectAggregateFromWriteModel(&existingOIDC.WriteModel)
-	var backChannelLogout, loginBaseURI *string
+	var backChannelLogout, frontChannelLogout, loginBaseURI, jwks, jwksURI *string
 	if oidc.BackChannelLogoutURI != nil {
 		backChannelLogout = gu.Ptr(strings.TrimSpace(*oidc.BackChannelLogoutURI))
 	}
+	if oidc.FrontChannelLogoutURI != nil {
+		frontChannelLogout = gu.Ptr(strings.TrimSpace(*oidc.FrontChannelLogoutURI))
+	}
 
 	if oidc.LoginBaseURI != nil {
 		loginBaseURI = gu.Ptr(strings.TrimSpace(*oidc.LoginBaseURI))
 	}
+	if oidc.JWKS != nil {
+		jwks = gu.Ptr(strings.TrimSpace(*oidc.JWKS))
+	}
+	if oidc.JWKSURI != nil {
+		jwksURI = gu.Ptr(strings.TrimSpace(*oidc.JWKSURI))
+	}
 
 	changedEvent, hasChanged, err := existingOIDC.NewChangedEvent(
 		ctx,
@@ -306,6 +354,16 @@ func (c *Commands) UpdateOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		backChannelLogout,
 		oidc.LoginVersion,
 		loginBaseURI,
+		oidc.RefreshTokenRotation,
+		oidc.RefreshTokenLifetime,
+		oidc.RefreshTokenIdleLifetime,
+		oidc.IDTokenEncryptedResponseAlg,
+		oidc.IDTokenEncryptedResponseEnc,
+		oidc.UserinfoEncryptedResponseAlg,
+		oidc.UserinfoEncryptedResponseEnc,
+		jwks,
+		jwksURI,
+		frontChannelLogout,
 	)
 	if err != nil {
 		return nil, err
@@ -3,6 +3,7 @@ package command
 import (
 	"context"
 	"slices"
+	"strings"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
@@ -14,32 +15,42 @@ import (
 type OIDCApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	HashedSecret             string
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              domain.OIDCVersion
-	Compliance               *domain.Compliance
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	State                    domain.AppState
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
-	BackChannelLogoutURI     string
-	LoginVersion             domain.LoginVersion
-	LoginBaseURI             string
-	oidc                     bool
+	AppID                        string
+	AppName                      string
+	ClientID                     string
+	HashedSecret                 string
+	ClientSecretString           string
+	RedirectUris                 []string
+	ResponseTypes                []domain.OIDCResponseType
+	GrantTypes                   []domain.OIDCGrantType
+	ApplicationType              domain.OIDCApplicationType
+	AuthMethodType               domain.OIDCAuthMethodType
+	PostLogoutRedirectUris       []string
+	OIDCVersion                  domain.OIDCVersion
+	Compliance                   *domain.Compliance
+	DevMode                      bool
+	AccessTokenType              domain.OIDCTokenType
+	AccessTokenRoleAssertion     bool
+	IDTokenRoleAssertion         bool
+	IDTokenUserinfoAssertion     bool
+	ClockSkew                    time.Duration
+	State                        domain.AppState
+	AdditionalOrigins            []string
+	SkipNativeAppSuccessPage     bool
+	BackChannelLogoutURI         string
+	LoginVersion                 domain.LoginVersion
+	LoginBaseURI                 string
+	RefreshTokenRotation         domain.OIDCRefreshTokenRotation
+	RefreshTokenLifetime         time.Duration
+	RefreshTokenIdleLifetime     time.Duration
+	IDTokenEncryptedResponseAlg  string
+	IDTokenEncryptedResponseEnc  string
+	UserinfoEncryptedResponseAlg string
+	UserinfoEncryptedResponseEnc string
+	JWKS                         string
+	JWKSURI                      string
+	FrontChannelLogoutURI        string
+	oidc                         bool
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -171,6 +182,16 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
 	wm.LoginVersion = e.LoginVersion
 	wm.LoginBaseURI = e.LoginBaseURI
+	wm.RefreshTokenRotation = e.RefreshTokenRotation
+	wm.RefreshTokenLifetime = e.RefreshTokenLifetime
+	wm.RefreshTokenIdleLifetime = e.RefreshTokenIdleLifetime
+	wm.IDTokenEncryptedResponseAlg = e.IDTokenEncryptedResponseAlg
+	wm.IDTokenEncryptedResponseEnc = e.IDTokenEncryptedResponseEnc
+	wm.UserinfoEncryptedResponseAlg = e.UserinfoEncryptedResponseAlg
+	wm.UserinfoEncryptedResponseEnc = e.UserinfoEncryptedResponseEnc
+	wm.JWKS = e.JWKS
+	wm.JWKSURI = e.JWKSURI
+	wm.FrontChannelLogoutURI = e.FrontChannelLogoutURI
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -228,6 +249,36 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.LoginBaseURI != nil {
 		wm.LoginBaseURI = *e.LoginBaseURI
 	}
+	if e.RefreshTokenRotation != nil {
+		wm.RefreshTokenRotation = *e.RefreshTokenRotation
+	}
+	if e.RefreshTokenLifetime != nil {
+		wm.RefreshTokenLifetime = *e.RefreshTokenLifetime
+	}
+	if e.RefreshTokenIdleLifetime != nil {
+		wm.RefreshTokenIdleLifetime = *e.RefreshTokenIdleLifetime
+	}
+	if e.IDTokenEncryptedResponseAlg != nil {
+		wm.IDTokenEncryptedResponseAlg = *e.IDTokenEncryptedResponseAlg
+	}
+	if e.IDTokenEncryptedResponseEnc != nil {
+		wm.IDTokenEncryptedResponseEnc = *e.IDTokenEncryptedResponseEnc
+	}
+	if e.UserinfoEncryptedResponseAlg != nil {
+		wm.UserinfoEncryptedResponseAlg = *e.UserinfoEncryptedResponseAlg
+	}
+	if e.UserinfoEncryptedResponseEnc != nil {
+		wm.UserinfoEncryptedResponseEnc = *e.UserinfoEncryptedResponseEnc
+	}
+	if e.JWKS != nil {
+		wm.JWKS = *e.JWKS
+	}
+	if e.JWKSURI != nil {
+		wm.JWKSURI = *e.JWKSURI
+	}
+	if e.FrontChannelLogoutURI != nil {
+		wm.FrontChannelLogoutURI = *e.FrontChannelLogoutURI
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -272,6 +323,16 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	backChannelLogoutURI *string,
 	loginVersion *domain.LoginVersion,
 	loginBaseURI *string,
+	refreshTokenRotation *domain.OIDCRefreshTokenRotation,
+	refreshTokenLifetime,
+	refreshTokenIdleLifetime *time.Duration,
+	idTokenEncryptedResponseAlg,
+	idTokenEncryptedResponseEnc,
+	userinfoEncryptedResponseAlg,
+	userinfoEncryptedResponseEnc,
+	jwks,
+	jwksURI,
+	frontChannelLogoutURI *string,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -330,6 +391,36 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if loginBaseURI != nil && wm.LoginBaseURI != *loginBaseURI {
 		changes = append(changes, project.ChangeOIDCLoginBaseURI(*loginBaseURI))
 	}
+	if refreshTokenRotation != nil && wm.RefreshTokenRotation != *refreshTokenRotation {
+		changes = append(changes, project.ChangeRefreshTokenRotation(*refreshTokenRotation))
+	}
+	if refreshTokenLifetime != nil && wm.RefreshTokenLifetime != *refreshTokenLifetime {
+		changes = append(changes, project.ChangeRefreshTokenLifetime(*refreshTokenLifetime))
+	}
+	if refreshTokenIdleLifetime != nil && wm.RefreshTokenIdleLifetime != *refreshTokenIdleLifetime {
+		changes = append(changes, project.ChangeRefreshTokenIdleLifetime(*refreshTokenIdleLifetime))
+	}
+	if idTokenEncryptedResponseAlg != nil && wm.IDTokenEncryptedResponseAlg != *idTokenEncryptedResponseAlg {
+		changes = append(changes, project.ChangeIDTokenEncryptedResponseAlg(*idTokenEncryptedResponseAlg))
+	}
+	if idTokenEncryptedResponseEnc != nil && wm.IDTokenEncryptedResponseEnc != *idTokenEncryptedResponseEnc {
+		changes = append(changes, project.ChangeIDTokenEncryptedResponseEnc(*idTokenEncryptedResponseEnc))
+	}
+	if userinfoEncryptedResponseAlg != nil && wm.UserinfoEncryptedResponseAlg != *userinfoEncryptedResponseAlg {
+		changes = append(changes, project.ChangeUserinfoEncryptedResponseAlg(*userinfoEncryptedResponseAlg))
+	}
+	if userinfoEncryptedResponseEnc != nil && wm.UserinfoEncryptedResponseEnc != *userinfoEncryptedResponseEnc {
+		changes = append(changes, project.ChangeUserinfoEncryptedResponseEnc(*userinfoEncryptedResponseEnc))
+	}
+	if jwks != nil && wm.JWKS != *jwks {
+		changes = append(changes, project.ChangeJWKS(*jwks))
+	}
+	if jwksURI != nil && wm.JWKSURI != *jwksURI {
+		changes = append(changes, project.ChangeJWKSURI(*jwksURI))
+	}
+	if frontChannelLogoutURI != nil && wm.FrontChannelLogoutURI != *frontChannelLogoutURI {
+		changes = append(changes, project.ChangeFrontChannelLogoutURI(*frontChannelLogoutURI))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -341,6 +432,22 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	return changeEvent, true, nil
 }
 
+// encryptionKeySetMissing checks the encryption settings resulting from the update of the application.
+func (wm *OIDCApplicationWriteModel) encryptionKeySetMissing(update *domain.OIDCApp) bool {
+	valueOrCurrent := func(value *string, current string) string {
+		if value == nil {
+			return current
+		}
+		return strings.TrimSpace(*value)
+	}
+	return domain.OIDCEncryptionKeySetMissing(
+		valueOrCurrent(update.IDTokenEncryptedResponseAlg, wm.IDTokenEncryptedResponseAlg),
+		valueOrCurrent(update.UserinfoEncryptedResponseAlg, wm.UserinfoEncryptedResponseAlg),
+		valueOrCurrent(update.JWKS, wm.JWKS),
+		valueOrCurrent(update.JWKSURI, wm.JWKSURI),
+	)
+}
+
 func (wm *OIDCApplicationWriteModel) IsOIDC() bool {
 	return wm.oidc
 }
@@ -174,6 +174,16 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						domain.OIDCRefreshTokenRotationUnspecified,
+						0,
+						0,
+						"",
+						"",
+						"",
+						"",
+						"",
+						"",
+						"",
 					),
 				},
 			},
@@ -242,6 +252,16 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						domain.OIDCRefreshTokenRotationUnspecified,
+						0,
+						0,
+						"",
+						"",
+						"",
+						"",
+						"",
+						"",
+						"",
 					),
 				},
 			},
@@ -310,6 +330,16 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						domain.OIDCRefreshTokenRotationUnspecified,
+						0,
+						0,
+						"",
+						"",
+						"",
+						"",
+						"",
+						"",
+						"",
 					),
 				},
 			},
@@ -378,6 +408,16 @@ func TestAddOIDCApp(t *testing.T) {
 						"",
 						domain.LoginVersionUnspecified,
 						"",
+						domain.OIDCRefreshTokenRotationUnspecified,
+						0,
+						0,
+						"",
+						"",
+						"",
+						"",
+						"",
+						"",
+						"",
 					),
 				},
 			},
@@ -531,6 +571,16 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							"https://test.ch/backchannel",
 							domain.LoginVersion2,
 							"https://login.test.ch",
+							domain.OIDCRefreshTokenRotationUnspecified,
+							0,
+							0,
+							"",
+							"",
+							"",
+							"",
+							"",
+							"",
+							"",
 						),
 					),
 				),
@@ -570,30 +620,40 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:                    "app1",
-					AppName:                  "app",
-					ClientID:                 "client1",
-					ClientSecretString:       "secret",
-					AuthMethodType:           gu.Ptr(domain.OIDCAuthMethodTypePost),
-					OIDCVersion:              gu.Ptr(domain.OIDCVersionV1),
-					RedirectUris:             []string{"https://test.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          gu.Ptr(domain.OIDCApplicationTypeWeb),
-					PostLogoutRedirectUris:   []string{"https://test.ch/logout"},
-					DevMode:                  gu.Ptr(true),
-					AccessTokenType:          gu.Ptr(domain.OIDCTokenTypeBearer),
-					AccessTokenRoleAssertion: gu.Ptr(true),
-					IDTokenRoleAssertion:     gu.Ptr(true),
-					IDTokenUserinfoAssertion: gu.Ptr(true),
-					ClockSkew:                gu.Ptr(time.Second * 1),
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: gu.Ptr(true),
-					BackChannelLogoutURI:     gu.Ptr("https://test.ch/backchannel"),
-					LoginVersion:             gu.Ptr(domain.LoginVersion2),
-					LoginBaseURI:             gu.Ptr("https://login.test.ch"),
-					State:                    domain.AppStateActive,
-					Compliance:               &domain.Compliance{},
+					AppID:                        "app1",
+					AppName:                      "app",
+					ClientID:                     "client1",
+					ClientSecretString:           "secret",
+					AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypePost),
+					OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+					RedirectUris:                 []string{"https://test.ch"},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeWeb),
+					PostLogoutRedirectUris:       []string{"https://test.ch/logout"},
+					DevMode:                      gu.Ptr(true),
+					AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+					AccessTokenRoleAssertion:     gu.Ptr(true),
+					IDTokenRoleAssertion:         gu.Ptr(true),
+					IDTokenUserinfoAssertion:     gu.Ptr(true),
+					ClockSkew:                    gu.Ptr(time.Second * 1),
+					AdditionalOrigins:            []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:     gu.Ptr(true),
+					BackChannelLogoutURI:         gu.Ptr("https://test.ch/backchannel"),
+					LoginVersion:                 gu.Ptr(domain.LoginVersion2),
+					LoginBaseURI:                 gu.Ptr("https://login.test.ch"),
+					RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationUnspecified),
+					RefreshTokenLifetime:         gu.Ptr(time.Duration(0)),
+					RefreshTokenIdleLifetime:     gu.Ptr(time.Duration(0)),
+					IDTokenEncryptedResponseAlg:  gu.Ptr(""),
+					IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+					UserinfoEncryptedResponseAlg: gu.Ptr(""),
+					UserinfoEncryptedResponseEnc: gu.Ptr(""),
+					JWKS:                         gu.Ptr(""),
+					JWKSURI:                      gu.Ptr(""),
+					FrontChannelLogoutURI:        gu.Ptr(""),
+					State:                        domain.AppStateActive,
+					Compliance:                   &domain.Compliance{},
 				},
 			},
 		},
@@ -640,6 +700,16 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							"https://test.ch/backchannel",
 							domain.LoginVersion2,
 							"https://login.test.ch",
+							domain.OIDCRefreshTokenRotationUnspecified,
+							0,
+							0,
+							"",
+							"",
+							"",
+							"",
+							"",
+							"",
+							"",
 						),
 					),
 				),
@@ -680,30 +750,40 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:                    "app2",
-					AppName:                  "app",
-					ClientID:                 "client1",
-					ClientSecretString:       "secret",
-					AuthMethodType:           gu.Ptr(domain.OIDCAuthMethodTypePost),
-					OIDCVersion:              gu.Ptr(domain.OIDCVersionV1),
-					RedirectUris:             []string{"https://test.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          gu.Ptr(domain.OIDCApplicationTypeWeb),
-					PostLogoutRedirectUris:   []string{"https://test.ch/logout"},
-					DevMode:                  gu.Ptr(true),
-					AccessTokenType:          gu.Ptr(domain.OIDCTokenTypeBearer),
-					AccessTokenRoleAssertion: gu.Ptr(true),
-					IDTokenRoleAssertion:     gu.Ptr(true),
-					IDTokenUserinfoAssertion: gu.Ptr(true),
-					ClockSkew:                gu.Ptr(time.Second * 1),
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: gu.Ptr(true),
-					BackChannelLogoutURI:     gu.Ptr("https://test.ch/backchannel"),
-					LoginVersion:             gu.Ptr(domain.LoginVersion2),
-					LoginBaseURI:             gu.Ptr("https://login.test.ch"),
-					State:                    domain.AppStateActive,
-					Compliance:               &domain.Compliance{},
+					AppID:                        "app2",
+					AppName:                      "app",
+					ClientID:                     "client1",
+					ClientSecretString:           "secret",
+					AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypePost),
+					OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+					RedirectUris:                 []string{"https://test.ch"},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeWeb),
+					PostLogoutRedirectUris:       []string{"https://test.ch/logout"},
+					DevMode:                      gu.Ptr(true),
+					AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+					AccessTokenRoleAssertion:     gu.Ptr(true),
+					IDTokenRoleAssertion:         gu.Ptr(true),
+					IDTokenUserinfoAssertion:     gu.Ptr(true),
+					ClockSkew:                    gu.Ptr(time.Second * 1),
+					AdditionalOrigins:            []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:     gu.Ptr(true),
+					BackChannelLogoutURI:         gu.Ptr("https://test.ch/backchannel"),
+					LoginVersion:                 gu.Ptr(domain.LoginVersion2),
+					LoginBaseURI:                 gu.Ptr("https://login.test.ch"),
+					RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationUnspecified),
+					RefreshTokenLifetime:         gu.Ptr(time.Duration(0)),
+					RefreshTokenIdleLifetime:     gu.Ptr(time.Duration(0)),
+					IDTokenEncryptedResponseAlg:  gu.Ptr(""),
+					IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+					UserinfoEncryptedResponseAlg: gu.Ptr(""),
+					UserinfoEncryptedResponseEnc: gu.Ptr(""),
+					JWKS:                         gu.Ptr(""),
+					JWKSURI:                      gu.Ptr(""),
+					FrontChannelLogoutURI:        gu.Ptr(""),
+					State:                        domain.AppStateActive,
+					Compliance:                   &domain.Compliance{},
 				},
 			},
 		},
@@ -750,6 +830,16 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							"https://test.ch/backchannel",
 							domain.LoginVersion2,
 							"https://login.test.ch",
+							domain.OIDCRefreshTokenRotationUnspecified,
+							0,
+							0,
+							"",
+							"",
+							"",
+							"",
+							"",
+							"",
+							"",
 						),
 					),
 				),
@@ -789,30 +879,40 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:                    "app1",
-					AppName:                  "app",
-					ClientID:                 "client1",
-					ClientSecretString:       "secret",
-					AuthMethodType:           gu.Ptr(domain.OIDCAuthMethodTypePost),
-					OIDCVersion:              gu.Ptr(domain.OIDCVersionV1),
-					RedirectUris:             []string{"https://test.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          gu.Ptr(domain.OIDCApplicationTypeWeb),
-					PostLogoutRedirectUris:   []string{"https://test.ch/logout"},
-					DevMode:                  gu.Ptr(true),
-					AccessTokenType:          gu.Ptr(domain.OIDCTokenTypeBearer),
-					AccessTokenRoleAssertion: gu.Ptr(true),
-					IDTokenRoleAssertion:     gu.Ptr(true),
-					IDTokenUserinfoAssertion: gu.Ptr(true),
-					ClockSkew:                gu.Ptr(time.Second * 1),
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: gu.Ptr(true),
-					BackChannelLogoutURI:     gu.Ptr("https://test.ch/backchannel"),
-					LoginVersion:             gu.Ptr(domain.LoginVersion2),
-					LoginBaseURI:             gu.Ptr("https://login.test.ch"),
-					State:                    domain.AppStateActive,
-					Compliance:               &domain.Compliance{},
+					AppID:                        "app1",
+					AppName:                      "app",
+					ClientID:                     "client1",
+					ClientSecretString:           "secret",
+					AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypePost),
+					OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+					RedirectUris:                 []string{"https://test.ch"},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeWeb),
+					PostLogoutRedirectUris:       []string{"https://test.ch/logout"},
+					DevMode:                      gu.Ptr(true),
+					AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+					AccessTokenRoleAssertion:     gu.Ptr(true),
+					IDTokenRoleAssertion:         gu.Ptr(true),
+					IDTokenUserinfoAssertion:     gu.Ptr(true),
+					ClockSkew:                    gu.Ptr(time.Second * 1),
+					AdditionalOrigins:            []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:     gu.Ptr(true),
+					BackChannelLogoutURI:         gu.Ptr("https://test.ch/backchannel"),
+					LoginVersion:                 gu.Ptr(domain.LoginVersion2),
+					LoginBaseURI:                 gu.Ptr("https://login.test.ch"),
+					RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationUnspecified),
+					RefreshTokenLifetime:         gu.Ptr(time.Duration(0)),
+					RefreshTokenIdleLifetime:     gu.Ptr(time.Duration(0)),
+					IDTokenEncryptedResponseAlg:  gu.Ptr(""),
+					IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+					UserinfoEncryptedResponseAlg: gu.Ptr(""),
+					UserinfoEncryptedResponseEnc: gu.Ptr(""),
+					JWKS:                         gu.Ptr(""),
+					JWKSURI:                      gu.Ptr(""),
+					FrontChannelLogoutURI:        gu.Ptr(""),
+					State:                        domain.AppStateActive,
+					Compliance:                   &domain.Compliance{},
 				},
 			},
 		},
@@ -987,6 +1087,16 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion2,
 								"https://login.test.ch",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
 							),
 						),
 					),
@@ -1062,6 +1172,16 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion2,
 								"https://login.test.ch",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
 							),
 						),
 					),
@@ -1137,6 +1257,16 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								"https://test.ch/backchannel",
 								domain.LoginVersion1,
 								"",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
 							),
 						),
 					),
@@ -1169,29 +1299,472 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
+					AppID:                        "app1",
+					ClientID:                     "client1@project",
+					AppName:                      "app",
+					AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypeBasic),
+					OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+					RedirectUris:                 []string{"https://test.ch"},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeWeb),
+					PostLogoutRedirectUris:       []string{"https://test.ch/logout"},
+					DevMode:                      gu.Ptr(false),
+					AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+					AccessTokenRoleAssertion:     gu.Ptr(true),
+					IDTokenRoleAssertion:         gu.Ptr(true),
+					IDTokenUserinfoAssertion:     gu.Ptr(true),
+					ClockSkew:                    gu.Ptr(time.Second * 1),
+					AdditionalOrigins:            []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:     gu.Ptr(true),
+					BackChannelLogoutURI:         gu.Ptr("https://test.ch/backchannel"),
+					LoginVersion:                 gu.Ptr(domain.LoginVersion1),
+					LoginBaseURI:                 gu.Ptr(""),
+					RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationUnspecified),
+					RefreshTokenLifetime:         gu.Ptr(time.Duration(0)),
+					RefreshTokenIdleLifetime:     gu.Ptr(time.Duration(0)),
+					IDTokenEncryptedResponseAlg:  gu.Ptr(""),
+					IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+					UserinfoEncryptedResponseAlg: gu.Ptr(""),
+					UserinfoEncryptedResponseEnc: gu.Ptr(""),
+					JWKS:                         gu.Ptr(""),
+					JWKSURI:                      gu.Ptr(""),
+					FrontChannelLogoutURI:        gu.Ptr(""),
+					Compliance:                   &domain.Compliance{},
+					State:                        domain.AppStateActive,
+				},
+			},
+		},
+		{
+			name: "change refresh token settings, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1,
+								"app1",
+								"client1@project",
+								"secret",
+								[]string{"https://test.ch"},
+								[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+								[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+								domain.OIDCApplicationTypeUserAgent,
+								domain.OIDCAuthMethodTypeNone,
+								[]string{"https://test.ch/logout"},
+								false,
+								domain.OIDCTokenTypeBearer,
+								false,
+								false,
+								false,
+								0,
+								nil,
+								false,
+								"",
+								domain.LoginVersion2,
+								"",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := project.NewOIDCConfigChangedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								[]project.OIDCConfigChanges{
+									project.ChangeRefreshTokenRotation(domain.OIDCRefreshTokenRotationOnEveryUse),
+									project.ChangeRefreshTokenLifetime(30 * 24 * time.Hour),
+									project.ChangeRefreshTokenIdleLifetime(24 * time.Hour),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
 					AppID:                    "app1",
-					ClientID:                 "client1@project",
-					AppName:                  "app",
-					AuthMethodType:           gu.Ptr(domain.OIDCAuthMethodTypeBasic),
-					OIDCVersion:              gu.Ptr(domain.OIDCVersionV1),
-					RedirectUris:             []string{"https://test.ch"},
+					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
 					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          gu.Ptr(domain.OIDCApplicationTypeWeb),
-					PostLogoutRedirectUris:   []string{"https://test.ch/logout"},
-					DevMode:                  gu.Ptr(false),
-					AccessTokenType:          gu.Ptr(domain.OIDCTokenTypeBearer),
-					AccessTokenRoleAssertion: gu.Ptr(true),
-					IDTokenRoleAssertion:     gu.Ptr(true),
-					IDTokenUserinfoAssertion: gu.Ptr(true),
-					ClockSkew:                gu.Ptr(time.Second * 1),
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: gu.Ptr(true),
-					BackChannelLogoutURI:     gu.Ptr("https://test.ch/backchannel"),
-					LoginVersion:             gu.Ptr(domain.LoginVersion1),
-					LoginBaseURI:             gu.Ptr(""),
-					Compliance:               &domain.Compliance{},
-					State:                    domain.AppStateActive,
+					RefreshTokenRotation:     gu.Ptr(domain.OIDCRefreshTokenRotationOnEveryUse),
+					RefreshTokenLifetime:     gu.Ptr(30 * 24 * time.Hour),
+					RefreshTokenIdleLifetime: gu.Ptr(24 * time.Hour),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:                        "app1",
+					ClientID:                     "client1@project",
+					AppName:                      "app",
+					AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypeNone),
+					OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+					RedirectUris:                 []string{"https://test.ch"},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+					ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeUserAgent),
+					PostLogoutRedirectUris:       []string{"https://test.ch/logout"},
+					DevMode:                      gu.Ptr(false),
+					AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+					AccessTokenRoleAssertion:     gu.Ptr(false),
+					IDTokenRoleAssertion:         gu.Ptr(false),
+					IDTokenUserinfoAssertion:     gu.Ptr(false),
+					ClockSkew:                    gu.Ptr(time.Duration(0)),
+					SkipNativeAppSuccessPage:     gu.Ptr(false),
+					BackChannelLogoutURI:         gu.Ptr(""),
+					LoginVersion:                 gu.Ptr(domain.LoginVersion2),
+					LoginBaseURI:                 gu.Ptr(""),
+					RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationOnEveryUse),
+					RefreshTokenLifetime:         gu.Ptr(30 * 24 * time.Hour),
+					RefreshTokenIdleLifetime:     gu.Ptr(24 * time.Hour),
+					IDTokenEncryptedResponseAlg:  gu.Ptr(""),
+					IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+					UserinfoEncryptedResponseAlg: gu.Ptr(""),
+					UserinfoEncryptedResponseEnc: gu.Ptr(""),
+					JWKS:                         gu.Ptr(""),
+					JWKSURI:                      gu.Ptr(""),
+					FrontChannelLogoutURI:        gu.Ptr(""),
+					Compliance:                   &domain.Compliance{},
+					State:                        domain.AppStateActive,
+				},
+			},
+		},
+		{
+			name: "encryption without key set, invalid",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1,
+								"app1",
+								"client1@project",
+								"secret",
+								[]string{"https://test.ch"},
+								[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+								[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+								domain.OIDCApplicationTypeUserAgent,
+								domain.OIDCAuthMethodTypeNone,
+								[]string{"https://test.ch/logout"},
+								false,
+								domain.OIDCTokenTypeBearer,
+								false,
+								false,
+								false,
+								0,
+								nil,
+								false,
+								"",
+								domain.LoginVersion2,
+								"",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+							),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:                       "app1",
+					GrantTypes:                  []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+					ResponseTypes:               []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					IDTokenEncryptedResponseAlg: gu.Ptr("RSA-OAEP-256"),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "change encryption settings, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1,
+								"app1",
+								"client1@project",
+								"secret",
+								[]string{"https://test.ch"},
+								[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+								[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+								domain.OIDCApplicationTypeUserAgent,
+								domain.OIDCAuthMethodTypeNone,
+								[]string{"https://test.ch/logout"},
+								false,
+								domain.OIDCTokenTypeBearer,
+								false,
+								false,
+								false,
+								0,
+								nil,
+								false,
+								"",
+								domain.LoginVersion2,
+								"",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := project.NewOIDCConfigChangedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								[]project.OIDCConfigChanges{
+									project.ChangeIDTokenEncryptedResponseAlg("RSA-OAEP-256"),
+									project.ChangeUserinfoEncryptedResponseAlg("ECDH-ES+A256KW"),
+									project.ChangeUserinfoEncryptedResponseEnc("A256GCM"),
+									project.ChangeJWKSURI("https://test.ch/jwks"),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:                        "app1",
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					IDTokenEncryptedResponseAlg:  gu.Ptr("RSA-OAEP-256"),
+					UserinfoEncryptedResponseAlg: gu.Ptr("ECDH-ES+A256KW"),
+					UserinfoEncryptedResponseEnc: gu.Ptr("A256GCM"),
+					JWKSURI:                      gu.Ptr(" https://test.ch/jwks "),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:                        "app1",
+					ClientID:                     "client1@project",
+					AppName:                      "app",
+					AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypeNone),
+					OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+					RedirectUris:                 []string{"https://test.ch"},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+					ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeUserAgent),
+					PostLogoutRedirectUris:       []string{"https://test.ch/logout"},
+					DevMode:                      gu.Ptr(false),
+					AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+					AccessTokenRoleAssertion:     gu.Ptr(false),
+					IDTokenRoleAssertion:         gu.Ptr(false),
+					IDTokenUserinfoAssertion:     gu.Ptr(false),
+					ClockSkew:                    gu.Ptr(time.Duration(0)),
+					SkipNativeAppSuccessPage:     gu.Ptr(false),
+					BackChannelLogoutURI:         gu.Ptr(""),
+					LoginVersion:                 gu.Ptr(domain.LoginVersion2),
+					LoginBaseURI:                 gu.Ptr(""),
+					RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationUnspecified),
+					RefreshTokenLifetime:         gu.Ptr(time.Duration(0)),
+					RefreshTokenIdleLifetime:     gu.Ptr(time.Duration(0)),
+					IDTokenEncryptedResponseAlg:  gu.Ptr("RSA-OAEP-256"),
+					IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+					UserinfoEncryptedResponseAlg: gu.Ptr("ECDH-ES+A256KW"),
+					UserinfoEncryptedResponseEnc: gu.Ptr("A256GCM"),
+					JWKS:                         gu.Ptr(""),
+					JWKSURI:                      gu.Ptr("https://test.ch/jwks"),
+					FrontChannelLogoutURI:        gu.Ptr(""),
+					Compliance:                   &domain.Compliance{},
+					State:                        domain.AppStateActive,
+				},
+			},
+		},
+		{
+			name: "change front channel logout uri, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewOIDCConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								domain.OIDCVersionV1,
+								"app1",
+								"client1@project",
+								"secret",
+								[]string{"https://test.ch"},
+								[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+								[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+								domain.OIDCApplicationTypeUserAgent,
+								domain.OIDCAuthMethodTypeNone,
+								[]string{"https://test.ch/logout"},
+								false,
+								domain.OIDCTokenTypeBearer,
+								false,
+								false,
+								false,
+								0,
+								nil,
+								false,
+								"",
+								domain.LoginVersion2,
+								"",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+							),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						func() eventstore.Command {
+							event, _ := project.NewOIDCConfigChangedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								[]project.OIDCConfigChanges{
+									project.ChangeFrontChannelLogoutURI("https://test.ch/frontchannel-logout"),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				oidcApp: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:                 "app1",
+					GrantTypes:            []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+					ResponseTypes:         []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					FrontChannelLogoutURI: gu.Ptr(" https://test.ch/frontchannel-logout "),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:                        "app1",
+					ClientID:                     "client1@project",
+					AppName:                      "app",
+					AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypeNone),
+					OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+					RedirectUris:                 []string{"https://test.ch"},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+					ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeUserAgent),
+					PostLogoutRedirectUris:       []string{"https://test.ch/logout"},
+					DevMode:                      gu.Ptr(false),
+					AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+					AccessTokenRoleAssertion:     gu.Ptr(false),
+					IDTokenRoleAssertion:         gu.Ptr(false),
+					IDTokenUserinfoAssertion:     gu.Ptr(false),
+					ClockSkew:                    gu.Ptr(time.Duration(0)),
+					SkipNativeAppSuccessPage:     gu.Ptr(false),
+					BackChannelLogoutURI:         gu.Ptr(""),
+					LoginVersion:                 gu.Ptr(domain.LoginVersion2),
+					LoginBaseURI:                 gu.Ptr(""),
+					RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationUnspecified),
+					RefreshTokenLifetime:         gu.Ptr(time.Duration(0)),
+					RefreshTokenIdleLifetime:     gu.Ptr(time.Duration(0)),
+					IDTokenEncryptedResponseAlg:  gu.Ptr(""),
+					IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+					UserinfoEncryptedResponseAlg: gu.Ptr(""),
+					UserinfoEncryptedResponseEnc: gu.Ptr(""),
+					JWKS:                         gu.Ptr(""),
+					JWKSURI:                      gu.Ptr(""),
+					FrontChannelLogoutURI:        gu.Ptr("https://test.ch/frontchannel-logout"),
+					Compliance:                   &domain.Compliance{},
+					State:                        domain.AppStateActive,
 				},
 			},
 		},
@@ -1321,6 +1894,16 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
 							),
 						),
 					),
@@ -1345,29 +1928,39 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 						AggregateID:   "project1",
 						ResourceOwner: "org1",
 					},
-					AppID:                    "app1",
-					AppName:                  "app",
-					ClientID:                 "client1@project",
-					ClientSecretString:       "secret",
-					AuthMethodType:           gu.Ptr(domain.OIDCAuthMethodTypePost),
-					OIDCVersion:              gu.Ptr(domain.OIDCVersionV1),
-					RedirectUris:             []string{"https://test.ch"},
-					ResponseTypes:            []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
-					GrantTypes:               []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
-					ApplicationType:          gu.Ptr(domain.OIDCApplicationTypeWeb),
-					PostLogoutRedirectUris:   []string{"https://test.ch/logout"},
-					DevMode:                  gu.Ptr(true),
-					AccessTokenType:          gu.Ptr(domain.OIDCTokenTypeBearer),
-					AccessTokenRoleAssertion: gu.Ptr(true),
-					IDTokenRoleAssertion:     gu.Ptr(true),
-					IDTokenUserinfoAssertion: gu.Ptr(true),
-					ClockSkew:                gu.Ptr(time.Second * 1),
-					AdditionalOrigins:        []string{"https://sub.test.ch"},
-					SkipNativeAppSuccessPage: gu.Ptr(false),
-					BackChannelLogoutURI:     gu.Ptr(""),
-					LoginVersion:             gu.Ptr(domain.LoginVersionUnspecified),
-					LoginBaseURI:             gu.Ptr(""),
-					State:                    domain.AppStateActive,
+					AppID:                        "app1",
+					AppName:                      "app",
+					ClientID:                     "client1@project",
+					ClientSecretString:           "secret",
+					AuthMethodType:               gu.Ptr(domain.OIDCAuthMethodTypePost),
+					OIDCVersion:                  gu.Ptr(domain.OIDCVersionV1),
+					RedirectUris:                 []string{"https://test.ch"},
+					ResponseTypes:                []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:                   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType:              gu.Ptr(domain.OIDCApplicationTypeWeb),
+					PostLogoutRedirectUris:       []string{"https://test.ch/logout"},
+					DevMode:                      gu.Ptr(true),
+					AccessTokenType:              gu.Ptr(domain.OIDCTokenTypeBearer),
+					AccessTokenRoleAssertion:     gu.Ptr(true),
+					IDTokenRoleAssertion:         gu.Ptr(true),
+					IDTokenUserinfoAssertion:     gu.Ptr(true),
+					ClockSkew:                    gu.Ptr(time.Second * 1),
+					AdditionalOrigins:            []string{"https://sub.test.ch"},
+					SkipNativeAppSuccessPage:     gu.Ptr(false),
+					BackChannelLogoutURI:         gu.Ptr(""),
+					LoginVersion:                 gu.Ptr(domain.LoginVersionUnspecified),
+					LoginBaseURI:                 gu.Ptr(""),
+					RefreshTokenRotation:         gu.Ptr(domain.OIDCRefreshTokenRotationUnspecified),
+					RefreshTokenLifetime:         gu.Ptr(time.Duration(0)),
+					RefreshTokenIdleLifetime:     gu.Ptr(time.Duration(0)),
+					IDTokenEncryptedResponseAlg:  gu.Ptr(""),
+					IDTokenEncryptedResponseEnc:  gu.Ptr(""),
+					UserinfoEncryptedResponseAlg: gu.Ptr(""),
+					UserinfoEncryptedResponseEnc: gu.Ptr(""),
+					JWKS:                         gu.Ptr(""),
+					JWKSURI:                      gu.Ptr(""),
+					FrontChannelLogoutURI:        gu.Ptr(""),
+					State:                        domain.AppStateActive,
 				},
 			},
 		},
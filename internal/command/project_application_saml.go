@@ -90,6 +90,8 @@ func (c *Commands) addSAMLApplication(ctx context.Context, projectAgg *eventstor
 			gu.Value(samlApp.MetadataURL),
 			gu.Value(samlApp.LoginVersion),
 			gu.Value(samlApp.LoginBaseURI),
+			gu.Value(samlApp.NameIDSource),
+			samlApp.AttributeMappings,
 		),
 	}, nil
 }
@@ -138,6 +140,8 @@ func (c *Commands) UpdateSAMLApplication(ctx context.Context, samlApp *domain.SA
 		samlApp.MetadataURL,
 		samlApp.LoginVersion,
 		samlApp.LoginBaseURI,
+		samlApp.NameIDSource,
+		samlApp.AttributeMappings,
 	)
 	if err != nil {
 		return nil, err
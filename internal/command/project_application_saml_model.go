@@ -12,13 +12,15 @@ import (
 type SAMLApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID        string
-	AppName      string
-	EntityID     string
-	Metadata     []byte
-	MetadataURL  string
-	LoginVersion domain.LoginVersion
-	LoginBaseURI string
+	AppID             string
+	AppName           string
+	EntityID          string
+	Metadata          []byte
+	MetadataURL       string
+	LoginVersion      domain.LoginVersion
+	LoginBaseURI      string
+	NameIDSource      domain.SAMLNameIDSource
+	AttributeMappings []*domain.SAMLAttributeMapping
 
 	State domain.AppState
 	saml  bool
@@ -125,6 +127,8 @@ func (wm *SAMLApplicationWriteModel) appendAddSAMLEvent(e *project.SAMLConfigAdd
 	wm.EntityID = e.EntityID
 	wm.LoginVersion = e.LoginVersion
 	wm.LoginBaseURI = e.LoginBaseURI
+	wm.NameIDSource = e.NameIDSource
+	wm.AttributeMappings = e.AttributeMappings
 }
 
 func (wm *SAMLApplicationWriteModel) appendChangeSAMLEvent(e *project.SAMLConfigChangedEvent) {
@@ -144,6 +148,12 @@ func (wm *SAMLApplicationWriteModel) appendChangeSAMLEvent(e *project.SAMLConfig
 	if e.LoginBaseURI != nil {
 		wm.LoginBaseURI = *e.LoginBaseURI
 	}
+	if e.NameIDSource != nil {
+		wm.NameIDSource = *e.NameIDSource
+	}
+	if e.AttributeMappings != nil {
+		wm.AttributeMappings = *e.AttributeMappings
+	}
 }
 
 func (wm *SAMLApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -173,6 +183,8 @@ func (wm *SAMLApplicationWriteModel) NewChangedEvent(
 	metadataURL *string,
 	loginVersion *domain.LoginVersion,
 	loginBaseURI *string,
+	nameIDSource *domain.SAMLNameIDSource,
+	attributeMappings []*domain.SAMLAttributeMapping,
 ) (*project.SAMLConfigChangedEvent, bool, error) {
 	changes := make([]project.SAMLConfigChanges, 0)
 	var err error
@@ -191,6 +203,14 @@ func (wm *SAMLApplicationWriteModel) NewChangedEvent(
 	if loginBaseURI != nil && wm.LoginBaseURI != *loginBaseURI {
 		changes = append(changes, project.ChangeSAMLLoginBaseURI(*loginBaseURI))
 	}
+	if nameIDSource != nil && wm.NameIDSource != *nameIDSource {
+		changes = append(changes, project.ChangeSAMLNameIDSource(*nameIDSource))
+	}
+	if attributeMappings != nil && !slices.EqualFunc(wm.AttributeMappings, attributeMappings, func(a, b *domain.SAMLAttributeMapping) bool {
+		return *a == *b
+	}) {
+		changes = append(changes, project.ChangeSAMLAttributeMappings(attributeMappings))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
@@ -236,7 +256,9 @@ func (wm *SAMLEntityIDsWriteModel) Query() *eventstore.SearchQueryBuilder {
 		EventTypes(
 			project.ApplicationRemovedType,
 			project.SAMLConfigAddedType,
-			project.SAMLConfigChangedType).
+			project.SAMLConfigChangedType,
+			project.WSFedConfigAddedType,
+			project.WSFedConfigChangedType).
 		Builder()
 }
 
@@ -251,6 +273,12 @@ func (wm *SAMLEntityIDsWriteModel) AppendEvents(events ...eventstore.Event) {
 			if e.EntityID != "" {
 				wm.WriteModel.AppendEvents(e)
 			}
+		case *project.WSFedConfigAddedEvent:
+			wm.WriteModel.AppendEvents(e)
+		case *project.WSFedConfigChangedEvent:
+			if e.Realm != "" {
+				wm.WriteModel.AppendEvents(e)
+			}
 		}
 	}
 }
@@ -268,6 +296,15 @@ func (wm *SAMLEntityIDsWriteModel) Reduce() error {
 					item.EntityID = e.EntityID
 				}
 			}
+		// the realms of WS-Federation applications share the unique constraint of the entity ids
+		case *project.WSFedConfigAddedEvent:
+			wm.EntityIDs = append(wm.EntityIDs, &AppIDToEntityID{AppID: e.AppID, EntityID: e.Realm})
+		case *project.WSFedConfigChangedEvent:
+			for _, item := range wm.EntityIDs {
+				if e.AppID == item.AppID && e.Realm != "" {
+					item.EntityID = e.Realm
+				}
+			}
 		}
 	}
 	return wm.WriteModel.Reduce()
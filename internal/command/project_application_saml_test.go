@@ -229,6 +229,8 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							"",
 							domain.LoginVersionUnspecified,
 							"",
+							domain.SAMLNameIDSourceUnspecified,
+							nil,
 						),
 					),
 				),
@@ -261,6 +263,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 					State:        domain.AppStateActive,
 					LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
 					LoginBaseURI: gu.Ptr(""),
+					NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUnspecified),
 				},
 			},
 		},
@@ -291,6 +294,8 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							"",
 							domain.LoginVersion2,
 							"https://test.com/login",
+							domain.SAMLNameIDSourceUnspecified,
+							nil,
 						),
 					),
 				),
@@ -325,6 +330,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 					State:        domain.AppStateActive,
 					LoginVersion: gu.Ptr(domain.LoginVersion2),
 					LoginBaseURI: gu.Ptr("https://test.com/login"),
+					NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUnspecified),
 				},
 			},
 		},
@@ -355,6 +361,8 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							"http://localhost:8080/saml/metadata",
 							domain.LoginVersionUnspecified,
 							"",
+							domain.SAMLNameIDSourceUnspecified,
+							nil,
 						),
 					),
 				),
@@ -388,6 +396,7 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 					State:        domain.AppStateActive,
 					LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
 					LoginBaseURI: gu.Ptr(""),
+					NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUnspecified),
 				},
 			},
 		},
@@ -576,6 +585,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"http://localhost:8080/saml/metadata",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
@@ -622,6 +633,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
@@ -668,6 +681,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"http://localhost:8080/saml/metadata",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
@@ -713,6 +728,7 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 					State:        domain.AppStateActive,
 					LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
 					LoginBaseURI: gu.Ptr(""),
+					NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUnspecified),
 				},
 			},
 		},
@@ -737,6 +753,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
@@ -782,6 +800,7 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 					State:        domain.AppStateActive,
 					LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
 					LoginBaseURI: gu.Ptr(""),
+					NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUnspecified),
 				},
 			},
 		},
@@ -806,6 +825,8 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
@@ -855,6 +876,139 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 					State:        domain.AppStateActive,
 					LoginVersion: gu.Ptr(domain.LoginVersion2),
 					LoginBaseURI: gu.Ptr("https://test.com/login"),
+					NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUnspecified),
+				},
+			},
+		},
+		{
+			name: "invalid attribute mapping, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:    "app1",
+					Metadata: testMetadata,
+					AttributeMappings: []*domain.SAMLAttributeMapping{
+						{
+							Name:   "department",
+							Source: domain.SAMLAttributeSourceUserMetadata,
+						},
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "change saml app, ok, attribute release",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://test.com/saml/metadata",
+								testMetadata,
+								"",
+								domain.LoginVersionUnspecified,
+								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
+							),
+						),
+					),
+					expectPush(
+						newSAMLAppChangedEventAttributeRelease(context.Background(),
+							"app1",
+							"project1",
+							"org1",
+							"https://test.com/saml/metadata",
+							domain.SAMLNameIDSourceUserID,
+							[]*domain.SAMLAttributeMapping{
+								{
+									Name:       "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+									NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:uri",
+									Source:     domain.SAMLAttributeSourceEmail,
+								},
+								{
+									Name:        "department",
+									Source:      domain.SAMLAttributeSourceUserMetadata,
+									MetadataKey: "department",
+								},
+							},
+						),
+					),
+				),
+				httpClient: nil,
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:        "app1",
+					AppName:      "app",
+					Metadata:     testMetadata,
+					NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUserID),
+					AttributeMappings: []*domain.SAMLAttributeMapping{
+						{
+							Name:       "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+							NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:uri",
+							Source:     domain.SAMLAttributeSourceEmail,
+						},
+						{
+							Name:        "department",
+							Source:      domain.SAMLAttributeSourceUserMetadata,
+							MetadataKey: "department",
+						},
+					},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:        "app1",
+					AppName:      "app",
+					EntityID:     "https://test.com/saml/metadata",
+					Metadata:     testMetadata,
+					MetadataURL:  gu.Ptr(""),
+					State:        domain.AppStateActive,
+					LoginVersion: gu.Ptr(domain.LoginVersionUnspecified),
+					LoginBaseURI: gu.Ptr(""),
+					NameIDSource: gu.Ptr(domain.SAMLNameIDSourceUserID),
+					AttributeMappings: []*domain.SAMLAttributeMapping{
+						{
+							Name:       "http://schemas.xmlsoap.org/ws/2005/05/identity/claims/emailaddress",
+							NameFormat: "urn:oasis:names:tc:SAML:2.0:attrname-format:uri",
+							Source:     domain.SAMLAttributeSourceEmail,
+						},
+						{
+							Name:        "department",
+							Source:      domain.SAMLAttributeSourceUserMetadata,
+							MetadataKey: "department",
+						},
+					},
 				},
 			},
 		},
@@ -927,6 +1081,20 @@ func newSAMLAppChangedEventLoginVersion(ctx context.Context, appID, projectID, r
 	return event
 }
 
+func newSAMLAppChangedEventAttributeRelease(ctx context.Context, appID, projectID, resourceOwner, entityID string, nameIDSource domain.SAMLNameIDSource, attributeMappings []*domain.SAMLAttributeMapping) *project.SAMLConfigChangedEvent {
+	changes := []project.SAMLConfigChanges{
+		project.ChangeSAMLNameIDSource(nameIDSource),
+		project.ChangeSAMLAttributeMappings(attributeMappings),
+	}
+	event, _ := project.NewSAMLConfigChangedEvent(ctx,
+		&project.NewAggregate(projectID, resourceOwner).Aggregate,
+		appID,
+		entityID,
+		changes,
+	)
+	return event
+}
+
 type roundTripperFunc func(*http.Request) *http.Response
 
 // RoundTrip implements the http.RoundTripper interface.
@@ -118,6 +118,16 @@ func TestCommandSide_ChangeApplicationSecret(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								0,
+								0,
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
 							),
 						),
 					),
@@ -159,6 +169,7 @@ func TestCommandSide_ChangeApplicationSecret(t *testing.T) {
 								"client1@project",
 								"secret",
 								domain.APIAuthMethodTypeBasic,
+								"",
 							),
 						),
 					),
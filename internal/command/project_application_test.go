@@ -596,6 +596,8 @@ func TestCommandSide_RemoveApplication(t *testing.T) {
 							"",
 							domain.LoginVersionUnspecified,
 							"",
+							domain.SAMLNameIDSourceUnspecified,
+							nil,
 						)),
 					),
 					expectPush(
@@ -0,0 +1,148 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/muhlemmer/gu"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func (c *Commands) AddWSFedApplication(ctx context.Context, application *domain.WSFedApp, resourceOwner string) (_ *domain.WSFedApp, err error) {
+	if application == nil || application.AggregateID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-aeV6o", "Errors.Project.App.Invalid")
+	}
+
+	projectResOwner, err := c.checkProjectExists(ctx, application.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if resourceOwner == "" {
+		resourceOwner = projectResOwner
+	}
+
+	addedApplication := NewWSFedApplicationWriteModel(application.AggregateID, resourceOwner)
+	if err := c.eventstore.FilterToQueryReducer(ctx, addedApplication); err != nil {
+		return nil, err
+	}
+	if err := c.checkPermissionUpdateApplication(ctx, addedApplication.ResourceOwner, addedApplication.AggregateID); err != nil {
+		return nil, err
+	}
+
+	projectAgg := ProjectAggregateFromWriteModel(&addedApplication.WriteModel)
+	events, err := c.addWSFedApplication(ctx, projectAgg, application)
+	if err != nil {
+		return nil, err
+	}
+	addedApplication.AppID = application.AppID
+	postCommit, err := c.applicationCreatedMilestone(ctx, &events)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	postCommit(ctx)
+	err = AppendAndReduce(addedApplication, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return wsFedWriteModelToWSFedConfig(addedApplication), nil
+}
+
+func (c *Commands) addWSFedApplication(ctx context.Context, projectAgg *eventstore.Aggregate, wsFedApp *domain.WSFedApp) (events []eventstore.Command, err error) {
+	wsFedApp.Realm = strings.TrimSpace(wsFedApp.Realm)
+	if wsFedApp.AppName == "" || !wsFedApp.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "PROJECT-Eic4o", "Errors.Project.App.Invalid")
+	}
+
+	wsFedApp.AppID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	return []eventstore.Command{
+		project.NewApplicationAddedEvent(ctx, projectAgg, wsFedApp.AppID, wsFedApp.AppName),
+		project.NewWSFedConfigAddedEvent(ctx,
+			projectAgg,
+			wsFedApp.AppID,
+			wsFedApp.Realm,
+			wsFedApp.ReplyURLs,
+			gu.Value(wsFedApp.TokenType),
+		),
+	}, nil
+}
+
+func (c *Commands) UpdateWSFedApplication(ctx context.Context, wsFedApp *domain.WSFedApp, resourceOwner string) (*domain.WSFedApp, error) {
+	if wsFedApp.AppID == "" || wsFedApp.AggregateID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-ohB3u", "Errors.Project.App.WSFedConfigInvalid")
+	}
+
+	existingWSFed, err := c.getWSFedAppWriteModel(ctx, wsFedApp.AggregateID, wsFedApp.AppID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingWSFed.State == domain.AppStateUnspecified || existingWSFed.State == domain.AppStateRemoved {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Quo2e", "Errors.Project.App.NotExisting")
+	}
+	if !existingWSFed.IsWSFed() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ahc4i", "Errors.Project.App.IsNotWSFed")
+	}
+
+	// unset values are not changed
+	wsFedApp.Realm = strings.TrimSpace(wsFedApp.Realm)
+	if wsFedApp.Realm == "" {
+		wsFedApp.Realm = existingWSFed.Realm
+	}
+	if wsFedApp.ReplyURLs == nil {
+		wsFedApp.ReplyURLs = existingWSFed.ReplyURLs
+	}
+	if !wsFedApp.IsValid() {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Uu9ie", "Errors.Project.App.WSFedConfigInvalid")
+	}
+
+	if err := c.checkPermissionUpdateApplication(ctx, existingWSFed.ResourceOwner, existingWSFed.AggregateID); err != nil {
+		return nil, err
+	}
+
+	projectAgg := ProjectAggregateFromWriteModel(&existingWSFed.WriteModel)
+	changedEvent, hasChanged, err := existingWSFed.NewChangedEvent(
+		ctx,
+		projectAgg,
+		wsFedApp.AppID,
+		wsFedApp.Realm,
+		wsFedApp.ReplyURLs,
+		wsFedApp.TokenType,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Eeth8", "Errors.NoChangesFound")
+	}
+
+	pushedEvents, err := c.eventstore.Push(ctx, changedEvent)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(existingWSFed, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+
+	return wsFedWriteModelToWSFedConfig(existingWSFed), nil
+}
+
+func (c *Commands) getWSFedAppWriteModel(ctx context.Context, projectID, appID, resourceOwner string) (*WSFedApplicationWriteModel, error) {
+	appWriteModel := NewWSFedApplicationWriteModelWithAppID(projectID, appID, resourceOwner)
+	err := c.eventstore.FilterToQueryReducer(ctx, appWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	return appWriteModel, nil
+}
@@ -0,0 +1,184 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type WSFedApplicationWriteModel struct {
+	eventstore.WriteModel
+
+	AppID     string
+	AppName   string
+	Realm     string
+	ReplyURLs []string
+	TokenType domain.WSFedTokenType
+
+	State domain.AppState
+	wsFed bool
+}
+
+func NewWSFedApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *WSFedApplicationWriteModel {
+	return &WSFedApplicationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func NewWSFedApplicationWriteModel(projectID, resourceOwner string) *WSFedApplicationWriteModel {
+	return &WSFedApplicationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *WSFedApplicationWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationChangedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationDeactivatedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationReactivatedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.WSFedConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.WSFedConfigChangedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *WSFedApplicationWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			wm.AppName = e.Name
+			wm.State = domain.AppStateActive
+		case *project.ApplicationChangedEvent:
+			wm.AppName = e.Name
+		case *project.ApplicationDeactivatedEvent:
+			if wm.State == domain.AppStateRemoved {
+				continue
+			}
+			wm.State = domain.AppStateInactive
+		case *project.ApplicationReactivatedEvent:
+			if wm.State == domain.AppStateRemoved {
+				continue
+			}
+			wm.State = domain.AppStateActive
+		case *project.ApplicationRemovedEvent:
+			wm.State = domain.AppStateRemoved
+		case *project.WSFedConfigAddedEvent:
+			wm.wsFed = true
+			wm.Realm = e.Realm
+			wm.ReplyURLs = e.ReplyURLs
+			wm.TokenType = e.TokenType
+		case *project.WSFedConfigChangedEvent:
+			wm.appendChangeWSFedEvent(e)
+		case *project.ProjectRemovedEvent:
+			wm.State = domain.AppStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *WSFedApplicationWriteModel) appendChangeWSFedEvent(e *project.WSFedConfigChangedEvent) {
+	wm.wsFed = true
+	if e.Realm != "" {
+		wm.Realm = e.Realm
+	}
+	if e.ReplyURLs != nil {
+		wm.ReplyURLs = *e.ReplyURLs
+	}
+	if e.TokenType != nil {
+		wm.TokenType = *e.TokenType
+	}
+}
+
+func (wm *WSFedApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.ApplicationChangedType,
+			project.ApplicationDeactivatedType,
+			project.ApplicationReactivatedType,
+			project.ApplicationRemovedType,
+			project.WSFedConfigAddedType,
+			project.WSFedConfigChangedType,
+			project.ProjectRemovedType).
+		Builder()
+}
+
+func (wm *WSFedApplicationWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	realm string,
+	replyURLs []string,
+	tokenType *domain.WSFedTokenType,
+) (*project.WSFedConfigChangedEvent, bool, error) {
+	changes := make([]project.WSFedConfigChanges, 0)
+	if wm.Realm != realm {
+		changes = append(changes, project.ChangeWSFedRealm(realm))
+	}
+	if replyURLs != nil && !slices.Equal(wm.ReplyURLs, replyURLs) {
+		changes = append(changes, project.ChangeWSFedReplyURLs(replyURLs))
+	}
+	if tokenType != nil && wm.TokenType != *tokenType {
+		changes = append(changes, project.ChangeWSFedTokenType(*tokenType))
+	}
+
+	if len(changes) == 0 {
+		return nil, false, nil
+	}
+	changeEvent, err := project.NewWSFedConfigChangedEvent(ctx, aggregate, appID, wm.Realm, changes)
+	if err != nil {
+		return nil, false, err
+	}
+	return changeEvent, true, nil
+}
+
+func (wm *WSFedApplicationWriteModel) IsWSFed() bool {
+	return wm.wsFed
+}
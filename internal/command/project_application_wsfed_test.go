@@ -0,0 +1,457 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommandSide_AddWSFedApplication(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		wsFedApp      *domain.WSFedApp
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.WSFedApp
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no aggregate id, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:           authz.WithInstanceID(context.Background(), "instanceID"),
+				wsFedApp:      &domain.WSFedApp{},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				wsFedApp: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppName: "app",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "missing reply urls, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				wsFedApp: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppName: "app",
+					Realm:   "urn:sharepoint:test",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "create wsfed app, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectFilter(),
+					expectPush(
+						project.NewApplicationAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+						),
+						project.NewWSFedConfigAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"urn:sharepoint:test",
+							[]string{"https://sharepoint.test.com/_trust/"},
+							domain.WSFedTokenTypeSAML11,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "app1"),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "instanceID"),
+				wsFedApp: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppName:   "app",
+					Realm:     " urn:sharepoint:test ",
+					ReplyURLs: []string{"https://sharepoint.test.com/_trust/"},
+					TokenType: gu.Ptr(domain.WSFedTokenTypeSAML11),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:     "app1",
+					AppName:   "app",
+					Realm:     "urn:sharepoint:test",
+					ReplyURLs: []string{"https://sharepoint.test.com/_trust/"},
+					TokenType: gu.Ptr(domain.WSFedTokenTypeSAML11),
+					State:     domain.AppStateActive,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				idGenerator:     tt.fields.idGenerator,
+				checkPermission: newMockPermissionCheckAllowed(),
+			}
+			c.setMilestonesCompletedForTest("instanceID")
+			got, err := c.AddWSFedApplication(tt.args.ctx, tt.args.wsFedApp, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ChangeWSFedApplication(t *testing.T) {
+	t.Parallel()
+
+	type fields struct {
+		eventstore func(t *testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		wsFedApp      *domain.WSFedApp
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.WSFedApp
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing aggregateid, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx: context.Background(),
+				wsFedApp: &domain.WSFedApp{
+					AppID: "app1",
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "app not existing, not found error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				wsFedApp: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:     "app1",
+					Realm:     "urn:sharepoint:test",
+					ReplyURLs: []string{"https://sharepoint.test.com/_trust/"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "no wsfed app, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				wsFedApp: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:     "app1",
+					Realm:     "urn:sharepoint:test",
+					ReplyURLs: []string{"https://sharepoint.test.com/_trust/"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid reply url, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewWSFedConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"urn:sharepoint:test",
+								[]string{"https://sharepoint.test.com/_trust/"},
+								domain.WSFedTokenTypeUnspecified,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				wsFedApp: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:     "app1",
+					ReplyURLs: []string{"/_trust/"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no changes, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewWSFedConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"urn:sharepoint:test",
+								[]string{"https://sharepoint.test.com/_trust/"},
+								domain.WSFedTokenTypeUnspecified,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				wsFedApp: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:     "app1",
+					TokenType: gu.Ptr(domain.WSFedTokenTypeUnspecified),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "change wsfed app, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewWSFedConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"urn:sharepoint:test",
+								[]string{"https://sharepoint.test.com/_trust/"},
+								domain.WSFedTokenTypeUnspecified,
+							),
+						),
+					),
+					expectPush(
+						newWSFedAppChangedEvent(context.Background(),
+							"app1",
+							"project1",
+							"org1",
+							"urn:sharepoint:test",
+							"urn:sharepoint:test2",
+							[]string{"https://sharepoint2.test.com/_trust/"},
+							domain.WSFedTokenTypeSAML20,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				wsFedApp: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "project1",
+					},
+					AppID:     "app1",
+					Realm:     "urn:sharepoint:test2",
+					ReplyURLs: []string{"https://sharepoint2.test.com/_trust/"},
+					TokenType: gu.Ptr(domain.WSFedTokenTypeSAML20),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.WSFedApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:     "app1",
+					AppName:   "app",
+					Realm:     "urn:sharepoint:test2",
+					ReplyURLs: []string{"https://sharepoint2.test.com/_trust/"},
+					TokenType: gu.Ptr(domain.WSFedTokenTypeSAML20),
+					State:     domain.AppStateActive,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: newMockPermissionCheckAllowed(),
+			}
+			got, err := r.UpdateWSFedApplication(tt.args.ctx, tt.args.wsFedApp, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func newWSFedAppChangedEvent(ctx context.Context, appID, projectID, resourceOwner, oldRealm, realm string, replyURLs []string, tokenType domain.WSFedTokenType) *project.WSFedConfigChangedEvent {
+	event, _ := project.NewWSFedConfigChangedEvent(ctx,
+		&project.NewAggregate(projectID, resourceOwner).Aggregate,
+		appID,
+		oldRealm,
+		[]project.WSFedConfigChanges{
+			project.ChangeWSFedRealm(realm),
+			project.ChangeWSFedReplyURLs(replyURLs),
+			project.ChangeWSFedTokenType(tokenType),
+		},
+	)
+	return event
+}
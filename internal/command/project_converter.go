@@ -29,41 +29,77 @@ func projectGrantWriteModelToProjectGrant(writeModel *ProjectGrantWriteModel) *d
 
 func oidcWriteModelToOIDCConfig(writeModel *OIDCApplicationWriteModel) *domain.OIDCApp {
 	return &domain.OIDCApp{
-		ObjectRoot:               writeModelToObjectRoot(writeModel.WriteModel),
-		AppID:                    writeModel.AppID,
-		AppName:                  writeModel.AppName,
-		State:                    writeModel.State,
-		ClientID:                 writeModel.ClientID,
-		RedirectUris:             writeModel.RedirectUris,
-		ResponseTypes:            writeModel.ResponseTypes,
-		GrantTypes:               writeModel.GrantTypes,
-		ApplicationType:          gu.Ptr(writeModel.ApplicationType),
-		AuthMethodType:           gu.Ptr(writeModel.AuthMethodType),
-		PostLogoutRedirectUris:   writeModel.PostLogoutRedirectUris,
-		OIDCVersion:              gu.Ptr(writeModel.OIDCVersion),
-		DevMode:                  gu.Ptr(writeModel.DevMode),
-		AccessTokenType:          gu.Ptr(writeModel.AccessTokenType),
-		AccessTokenRoleAssertion: gu.Ptr(writeModel.AccessTokenRoleAssertion),
-		IDTokenRoleAssertion:     gu.Ptr(writeModel.IDTokenRoleAssertion),
-		IDTokenUserinfoAssertion: gu.Ptr(writeModel.IDTokenUserinfoAssertion),
-		ClockSkew:                gu.Ptr(writeModel.ClockSkew),
-		AdditionalOrigins:        writeModel.AdditionalOrigins,
-		SkipNativeAppSuccessPage: gu.Ptr(writeModel.SkipNativeAppSuccessPage),
-		BackChannelLogoutURI:     gu.Ptr(writeModel.BackChannelLogoutURI),
-		LoginVersion:             gu.Ptr(writeModel.LoginVersion),
-		LoginBaseURI:             gu.Ptr(writeModel.LoginBaseURI),
+		ObjectRoot:                   writeModelToObjectRoot(writeModel.WriteModel),
+		AppID:                        writeModel.AppID,
+		AppName:                      writeModel.AppName,
+		State:                        writeModel.State,
+		ClientID:                     writeModel.ClientID,
+		RedirectUris:                 writeModel.RedirectUris,
+		ResponseTypes:                writeModel.ResponseTypes,
+		GrantTypes:                   writeModel.GrantTypes,
+		ApplicationType:              gu.Ptr(writeModel.ApplicationType),
+		AuthMethodType:               gu.Ptr(writeModel.AuthMethodType),
+		PostLogoutRedirectUris:       writeModel.PostLogoutRedirectUris,
+		OIDCVersion:                  gu.Ptr(writeModel.OIDCVersion),
+		DevMode:                      gu.Ptr(writeModel.DevMode),
+		AccessTokenType:              gu.Ptr(writeModel.AccessTokenType),
+		AccessTokenRoleAssertion:     gu.Ptr(writeModel.AccessTokenRoleAssertion),
+		IDTokenRoleAssertion:         gu.Ptr(writeModel.IDTokenRoleAssertion),
+		IDTokenUserinfoAssertion:     gu.Ptr(writeModel.IDTokenUserinfoAssertion),
+		ClockSkew:                    gu.Ptr(writeModel.ClockSkew),
+		AdditionalOrigins:            writeModel.AdditionalOrigins,
+		SkipNativeAppSuccessPage:     gu.Ptr(writeModel.SkipNativeAppSuccessPage),
+		BackChannelLogoutURI:         gu.Ptr(writeModel.BackChannelLogoutURI),
+		LoginVersion:                 gu.Ptr(writeModel.LoginVersion),
+		LoginBaseURI:                 gu.Ptr(writeModel.LoginBaseURI),
+		RefreshTokenRotation:         gu.Ptr(writeModel.RefreshTokenRotation),
+		RefreshTokenLifetime:         gu.Ptr(writeModel.RefreshTokenLifetime),
+		RefreshTokenIdleLifetime:     gu.Ptr(writeModel.RefreshTokenIdleLifetime),
+		IDTokenEncryptedResponseAlg:  gu.Ptr(writeModel.IDTokenEncryptedResponseAlg),
+		IDTokenEncryptedResponseEnc:  gu.Ptr(writeModel.IDTokenEncryptedResponseEnc),
+		UserinfoEncryptedResponseAlg: gu.Ptr(writeModel.UserinfoEncryptedResponseAlg),
+		UserinfoEncryptedResponseEnc: gu.Ptr(writeModel.UserinfoEncryptedResponseEnc),
+		JWKS:                         gu.Ptr(writeModel.JWKS),
+		JWKSURI:                      gu.Ptr(writeModel.JWKSURI),
+		FrontChannelLogoutURI:        gu.Ptr(writeModel.FrontChannelLogoutURI),
 	}
 }
 
 func samlWriteModelToSAMLConfig(writeModel *SAMLApplicationWriteModel) *domain.SAMLApp {
 	return &domain.SAMLApp{
+		ObjectRoot:        writeModelToObjectRoot(writeModel.WriteModel),
+		AppID:             writeModel.AppID,
+		AppName:           writeModel.AppName,
+		State:             writeModel.State,
+		Metadata:          writeModel.Metadata,
+		MetadataURL:       gu.Ptr(writeModel.MetadataURL),
+		EntityID:          writeModel.EntityID,
+		LoginVersion:      gu.Ptr(writeModel.LoginVersion),
+		LoginBaseURI:      gu.Ptr(writeModel.LoginBaseURI),
+		NameIDSource:      gu.Ptr(writeModel.NameIDSource),
+		AttributeMappings: writeModel.AttributeMappings,
+	}
+}
+
+func wsFedWriteModelToWSFedConfig(writeModel *WSFedApplicationWriteModel) *domain.WSFedApp {
+	return &domain.WSFedApp{
+		ObjectRoot: writeModelToObjectRoot(writeModel.WriteModel),
+		AppID:      writeModel.AppID,
+		AppName:    writeModel.AppName,
+		State:      writeModel.State,
+		Realm:      writeModel.Realm,
+		ReplyURLs:  writeModel.ReplyURLs,
+		TokenType:  gu.Ptr(writeModel.TokenType),
+	}
+}
+
+func casWriteModelToCASConfig(writeModel *CASApplicationWriteModel) *domain.CASApp {
+	return &domain.CASApp{
 		ObjectRoot:   writeModelToObjectRoot(writeModel.WriteModel),
 		AppID:        writeModel.AppID,
 		AppName:      writeModel.AppName,
 		State:        writeModel.State,
-		Metadata:     writeModel.Metadata,
-		MetadataURL:  gu.Ptr(writeModel.MetadataURL),
-		EntityID:     writeModel.EntityID,
+		ServiceURLs:  writeModel.ServiceURLs,
 		LoginVersion: gu.Ptr(writeModel.LoginVersion),
 		LoginBaseURI: gu.Ptr(writeModel.LoginBaseURI),
 	}
@@ -77,6 +113,10 @@ func apiWriteModelToAPIConfig(writeModel *APIApplicationWriteModel) *domain.APIA
 		State:          writeModel.State,
 		ClientID:       writeModel.ClientID,
 		AuthMethodType: writeModel.AuthMethodType,
+		ResourceURI:    gu.Ptr(writeModel.ResourceURI),
+
+		IntrospectionSignedResponse: gu.Ptr(writeModel.IntrospectionSignedResponse),
+		IntrospectionEncryptionKey:  writeModel.IntrospectionEncryptionKey,
 	}
 }
 
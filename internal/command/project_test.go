@@ -1112,6 +1112,8 @@ func TestCommandSide_RemoveProject(t *testing.T) {
 								"http://localhost:8080/saml/metadata",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
@@ -1165,6 +1167,8 @@ func TestCommandSide_RemoveProject(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(project.NewApplicationAddedEvent(context.Background(),
@@ -1181,6 +1185,8 @@ func TestCommandSide_RemoveProject(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(project.NewApplicationAddedEvent(context.Background(),
@@ -1197,6 +1203,8 @@ func TestCommandSide_RemoveProject(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
@@ -1441,6 +1449,8 @@ func TestCommandSide_DeleteProject(t *testing.T) {
 								"http://localhost:8080/saml/metadata",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
@@ -1492,6 +1502,8 @@ func TestCommandSide_DeleteProject(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(project.NewApplicationAddedEvent(context.Background(),
@@ -1508,6 +1520,8 @@ func TestCommandSide_DeleteProject(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 						eventFromEventPusher(project.NewApplicationAddedEvent(context.Background(),
@@ -1524,6 +1538,8 @@ func TestCommandSide_DeleteProject(t *testing.T) {
 								"",
 								domain.LoginVersionUnspecified,
 								"",
+								domain.SAMLNameIDSourceUnspecified,
+								nil,
 							),
 						),
 					),
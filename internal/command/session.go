@@ -401,11 +401,30 @@ func (c *Commands) updateSession(ctx context.Context, checks *SessionCommands, m
 	if err != nil {
 		return nil, err
 	}
+	c.reconcileSessionIDPAssignments(ctx, checks)
 	changed := sessionWriteModelToSessionChanged(checks.sessionWriteModel)
 	changed.NewToken = sessionToken
 	return changed, nil
 }
 
+// reconcileSessionIDPAssignments applies the project roles and groups mapped by the IdP of a checked intent to the session user.
+// A failure must not invalidate the already stored session, so it is only logged.
+func (c *Commands) reconcileSessionIDPAssignments(ctx context.Context, checks *SessionCommands) {
+	intent := checks.intentWriteModel
+	if intent == nil || intent.Assignments == nil {
+		return
+	}
+	logger := logging.WithFields("user", checks.sessionWriteModel.UserID, "idp", intent.IDPID)
+	details, err := c.ReconcileIDPAssignments(ctx, checks.sessionWriteModel.UserID, checks.sessionWriteModel.UserResourceOwner, intent.IDPID, intent.Assignments)
+	if err != nil {
+		logger.WithError(err).Error("unable to reconcile idp assignments")
+		return
+	}
+	if len(details.InvalidGroupIDs) > 0 {
+		logger.WithField("groups", details.InvalidGroupIDs).Warn("mapped groups not found")
+	}
+}
+
 // checkSessionWritePermission will check that the caller is granted the "session.write" permission on the resource owner of the authenticated user.
 // In case the user is not set, and the userOrganizationID is not set (also the case for the session creation),
 // it will check permission on the instance.
@@ -0,0 +1,223 @@
+package command
+
+import (
+	"context"
+	"maps"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ReconcileIDPAssignments applies the project roles and groups mapped by the rules of an identity provider to the user.
+// Roles and groups assigned on a previous login, which are no longer returned by the rules, are removed again.
+// Roles and groups the user already had (e.g. granted manually) are not tracked and therefore never removed.
+// Roles and groups of targets, for which a rule failed, are not removed, as the mapped values are incomplete.
+// Mapped groups which do not exist in the organization of the user are skipped and returned in the details.
+// Every assignment and removal is recorded as event on the user.
+func (c *Commands) ReconcileIDPAssignments(ctx context.Context, userID, resourceOwner, idpID string, assignments *domain.IDPAssignments) (_ *IDPAssignmentsDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || resourceOwner == "" || idpID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ua3fk", "Errors.IDMissing")
+	}
+	if assignments == nil {
+		assignments = new(domain.IDPAssignments)
+	}
+	writeModel := NewUserIDPAssignmentsWriteModel(userID, resourceOwner, idpID)
+	if err = c.eventstore.FilterToQueryReducer(ctx, writeModel); err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModelCtx(ctx, &writeModel.WriteModel)
+
+	projectIDs := slices.Sorted(maps.Keys(writeModel.ProjectRoles))
+	for projectID := range assignments.ProjectRoles {
+		if !slices.Contains(projectIDs, projectID) {
+			projectIDs = append(projectIDs, projectID)
+		}
+	}
+	cmds := make([]eventstore.Command, 0)
+	for _, projectID := range projectIDs {
+		keepAssigned := slices.Contains(assignments.FailedProjectIDs, projectID)
+		projectCmds, err := c.reconcileIDPProjectRoles(ctx, userAgg, idpID, projectID, writeModel.ProjectRoles[projectID], assignments.ProjectRoles[projectID], keepAssigned)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, projectCmds...)
+	}
+	groupCmds, invalidGroupIDs, err := c.reconcileIDPGroups(ctx, userAgg, idpID, writeModel.GroupIDs, assignments.GroupIDs, assignments.GroupsFailed)
+	if err != nil {
+		return nil, err
+	}
+	cmds = append(cmds, groupCmds...)
+	if len(cmds) == 0 {
+		return idpAssignmentsDetails(writeModel, invalidGroupIDs), nil
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, cmds...)
+	if err != nil {
+		return nil, err
+	}
+	userEvents := slices.DeleteFunc(pushedEvents, func(event eventstore.Event) bool {
+		return event.Aggregate().ID != userID || event.Aggregate().Type != user.AggregateType
+	})
+	if err = AppendAndReduce(writeModel, userEvents...); err != nil {
+		return nil, err
+	}
+	return idpAssignmentsDetails(writeModel, invalidGroupIDs), nil
+}
+
+// IDPAssignmentsDetails are the details of a reconciliation of the assignments of an identity provider.
+type IDPAssignmentsDetails struct {
+	*domain.ObjectDetails
+	// InvalidGroupIDs are the mapped groups, which do not exist in the organization of the user and were skipped.
+	InvalidGroupIDs []string
+}
+
+func idpAssignmentsDetails(writeModel *UserIDPAssignmentsWriteModel, invalidGroupIDs []string) *IDPAssignmentsDetails {
+	return &IDPAssignmentsDetails{
+		ObjectDetails:   writeModelToObjectDetails(&writeModel.WriteModel),
+		InvalidGroupIDs: invalidGroupIDs,
+	}
+}
+
+// reconcileIDPProjectRoles adds the roles newly returned by the rules to the user grant of the project (or creates it)
+// and removes the previously assigned ones, which are no longer returned.
+// If no role is left, the user grant is removed.
+// If keepAssigned is set, the previously assigned roles are not removed.
+func (c *Commands) reconcileIDPProjectRoles(ctx context.Context, userAgg *eventstore.Aggregate, idpID, projectID string, assigned, mapped []string, keepAssigned bool) ([]eventstore.Command, error) {
+	toAdd := missingValues(mapped, assigned)
+	var toRemove []string
+	if !keepAssigned {
+		toRemove = missingValues(assigned, mapped)
+	}
+	if len(toAdd) == 0 && len(toRemove) == 0 {
+		return nil, nil
+	}
+	grant, err := c.userProjectGrantWriteModel(ctx, userAgg.ID, projectID)
+	if err != nil {
+		return nil, err
+	}
+	var existing []string
+	if grant != nil {
+		existing = grant.RoleKeys
+	}
+	// roles the user already has, are not tracked, so they are not removed on a later login
+	added := missingValues(toAdd, existing)
+	roleKeys := append(slices.DeleteFunc(slices.Clone(existing), func(key string) bool {
+		return slices.Contains(toRemove, key)
+	}), added...)
+
+	cmds := make([]eventstore.Command, 0, len(added)+len(toRemove)+1)
+	switch {
+	case grant == nil && len(added) > 0:
+		cmd, _, err := c.addUserGrant(ctx, &domain.UserGrant{
+			ObjectRoot: models.ObjectRoot{ResourceOwner: userAgg.ResourceOwner},
+			UserID:     userAgg.ID,
+			ProjectID:  projectID,
+			RoleKeys:   added,
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		cmds = append(cmds, cmd)
+	case grant != nil && len(roleKeys) == 0:
+		cmds = append(cmds, usergrant.NewUserGrantRemovedEvent(ctx, UserGrantAggregateFromWriteModel(&grant.WriteModel), grant.UserID, grant.ProjectID, grant.ProjectGrantID))
+	case grant != nil && !slices.Equal(existing, roleKeys):
+		if len(added) > 0 {
+			err = c.checkUserGrantPreCondition(ctx, &domain.UserGrant{
+				ObjectRoot:     models.ObjectRoot{ResourceOwner: grant.ResourceOwner},
+				UserID:         grant.UserID,
+				ProjectID:      grant.ProjectID,
+				ProjectGrantID: grant.ProjectGrantID,
+				RoleKeys:       roleKeys,
+			}, nil)
+			if err != nil {
+				return nil, err
+			}
+		}
+		cmds = append(cmds, usergrant.NewUserGrantChangedEvent(ctx, UserGrantAggregateFromWriteModel(&grant.WriteModel), grant.UserID, roleKeys))
+	}
+	for _, roleKey := range added {
+		cmds = append(cmds, user.NewUserIDPAssignmentAddedEvent(ctx, userAgg, idpID, projectID, roleKey, ""))
+	}
+	for _, roleKey := range toRemove {
+		cmds = append(cmds, user.NewUserIDPAssignmentRemovedEvent(ctx, userAgg, idpID, projectID, roleKey, ""))
+	}
+	return cmds, nil
+}
+
+// reconcileIDPGroups adds the user to the groups newly returned by the rules
+// and removes them from the previously assigned ones, which are no longer returned.
+// Only groups of the organization of the user can be assigned, the IDs of other groups are returned as invalid.
+// If keepAssigned is set, the user is not removed from the previously assigned groups.
+func (c *Commands) reconcileIDPGroups(ctx context.Context, userAgg *eventstore.Aggregate, idpID string, assigned, mapped []string, keepAssigned bool) (_ []eventstore.Command, invalidGroupIDs []string, err error) {
+	cmds := make([]eventstore.Command, 0)
+	for _, groupID := range missingValues(mapped, assigned) {
+		groupWriteModel, err := c.getGroupWriteModelByID(ctx, groupID, userAgg.ResourceOwner, []string{userAgg.ID})
+		if err != nil {
+			return nil, nil, err
+		}
+		if !groupWriteModel.State.Exists() {
+			invalidGroupIDs = append(invalidGroupIDs, groupID)
+			continue
+		}
+		// memberships the user already has, are not tracked, so they are not removed on a later login
+		if len(groupWriteModel.getUserIDsToAdd()) == 0 {
+			continue
+		}
+		cmds = append(cmds,
+			group.NewGroupUsersAddedEvent(ctx, GroupAggregateFromWriteModel(ctx, &groupWriteModel.WriteModel), []string{userAgg.ID}),
+			user.NewUserIDPAssignmentAddedEvent(ctx, userAgg, idpID, "", "", groupID),
+		)
+	}
+	if keepAssigned {
+		return cmds, invalidGroupIDs, nil
+	}
+	for _, groupID := range missingValues(assigned, mapped) {
+		groupWriteModel, err := c.getGroupWriteModelByID(ctx, groupID, userAgg.ResourceOwner, []string{userAgg.ID})
+		if err != nil {
+			return nil, nil, err
+		}
+		if groupWriteModel.State.Exists() && len(groupWriteModel.getUserIDsToRemove()) > 0 {
+			cmds = append(cmds, group.NewGroupUsersRemovedEvent(ctx, GroupAggregateFromWriteModel(ctx, &groupWriteModel.WriteModel), []string{userAgg.ID}))
+		}
+		cmds = append(cmds, user.NewUserIDPAssignmentRemovedEvent(ctx, userAgg, idpID, "", "", groupID))
+	}
+	return cmds, invalidGroupIDs, nil
+}
+
+// userProjectGrantWriteModel returns the (not removed) user grant of the user on the project or nil if there is none.
+func (c *Commands) userProjectGrantWriteModel(ctx context.Context, userID, projectID string) (*UserGrantWriteModel, error) {
+	grantIDs := newUserProjectGrantIDsWriteModel(userID, projectID)
+	if err := c.eventstore.FilterToQueryReducer(ctx, grantIDs); err != nil {
+		return nil, err
+	}
+	for _, grantID := range grantIDs.GrantIDs {
+		grant, err := c.userGrantWriteModelByID(ctx, grantID, "")
+		if err != nil {
+			return nil, err
+		}
+		if grant.State == domain.UserGrantStateActive || grant.State == domain.UserGrantStateInactive {
+			return grant, nil
+		}
+	}
+	return nil, nil
+}
+
+// missingValues returns the values which are not contained in the existing ones.
+func missingValues(values, existing []string) []string {
+	missing := make([]string, 0, len(values))
+	for _, value := range values {
+		if !slices.Contains(existing, value) && !slices.Contains(missing, value) {
+			missing = append(missing, value)
+		}
+	}
+	return missing
+}
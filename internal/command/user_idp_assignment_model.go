@@ -0,0 +1,111 @@
+package command
+
+import (
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// UserIDPAssignmentsWriteModel contains the project roles and groups currently assigned to a user
+// by the mapping rules of an identity provider.
+type UserIDPAssignmentsWriteModel struct {
+	eventstore.WriteModel
+
+	IDPConfigID string
+	// ProjectRoles contains the role keys per project ID
+	ProjectRoles map[string][]string
+	GroupIDs     []string
+}
+
+func NewUserIDPAssignmentsWriteModel(userID, resourceOwner, idpConfigID string) *UserIDPAssignmentsWriteModel {
+	return &UserIDPAssignmentsWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		IDPConfigID:  idpConfigID,
+		ProjectRoles: make(map[string][]string),
+	}
+}
+
+func (wm *UserIDPAssignmentsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.UserIDPAssignmentAddedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			if e.GroupID != "" {
+				wm.GroupIDs = append(wm.GroupIDs, e.GroupID)
+				continue
+			}
+			wm.ProjectRoles[e.ProjectID] = append(wm.ProjectRoles[e.ProjectID], e.RoleKey)
+		case *user.UserIDPAssignmentRemovedEvent:
+			if e.IDPConfigID != wm.IDPConfigID {
+				continue
+			}
+			if e.GroupID != "" {
+				wm.GroupIDs = slices.DeleteFunc(wm.GroupIDs, func(id string) bool { return id == e.GroupID })
+				continue
+			}
+			wm.ProjectRoles[e.ProjectID] = slices.DeleteFunc(wm.ProjectRoles[e.ProjectID], func(key string) bool { return key == e.RoleKey })
+			if len(wm.ProjectRoles[e.ProjectID]) == 0 {
+				delete(wm.ProjectRoles, e.ProjectID)
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserIDPAssignmentsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserIDPAssignmentAddedType,
+			user.UserIDPAssignmentRemovedType,
+		).
+		EventData(map[string]interface{}{"idpConfigId": wm.IDPConfigID}).
+		Builder()
+}
+
+// userProjectGrantIDsWriteModel collects the IDs of all user grants ever added for a user on a project.
+type userProjectGrantIDsWriteModel struct {
+	eventstore.WriteModel
+
+	userID    string
+	projectID string
+	GrantIDs  []string
+}
+
+func newUserProjectGrantIDsWriteModel(userID, projectID string) *userProjectGrantIDsWriteModel {
+	return &userProjectGrantIDsWriteModel{
+		userID:    userID,
+		projectID: projectID,
+	}
+}
+
+func (wm *userProjectGrantIDsWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		if e, ok := event.(*usergrant.UserGrantAddedEvent); ok && !slices.Contains(wm.GrantIDs, e.Aggregate().ID) {
+			wm.GrantIDs = append(wm.GrantIDs, e.Aggregate().ID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *userProjectGrantIDsWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		EventTypes(usergrant.UserGrantAddedType).
+		EventData(map[string]interface{}{
+			"userId":    wm.userID,
+			"projectId": wm.projectID,
+		}).
+		Builder()
+}
@@ -0,0 +1,321 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/group"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestCommands_ReconcileIDPAssignments(t *testing.T) {
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID        string
+		resourceOwner string
+		idpID         string
+		assignments   *domain.IDPAssignments
+	}
+	type res struct {
+		want            *domain.ObjectDetails
+		invalidGroupIDs []string
+		err             error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing idp id",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ua3fk", "Errors.IDMissing"),
+			},
+		},
+		{
+			name: "no assignments, no changes",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				assignments:   &domain.IDPAssignments{},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "role already granted, not tracked",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				assignments: &domain.IDPAssignments{
+					ProjectRoles: map[string][]string{"project1": {"admin"}},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "role no longer mapped, removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPAssignmentAddedEvent(context.Background(), userAgg, "idp1", "project1", "admin", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"user", "admin"},
+							),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantChangedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+							"user1", []string{"user"},
+						),
+						user.NewUserIDPAssignmentRemovedEvent(context.Background(), userAgg, "idp1", "project1", "admin", ""),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				assignments:   &domain.IDPAssignments{},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "last role no longer mapped, grant removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPAssignmentAddedEvent(context.Background(), userAgg, "idp1", "project1", "admin", ""),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"},
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+								"user1", "project1", "", []string{"admin"},
+							),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantRemovedEvent(context.Background(), &usergrant.NewAggregate("grant1", "org1").Aggregate,
+							"user1", "project1", "",
+						),
+						user.NewUserIDPAssignmentRemovedEvent(context.Background(), userAgg, "idp1", "project1", "admin", ""),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "project rule failed, roles kept",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPAssignmentAddedEvent(context.Background(), userAgg, "idp1", "project1", "admin", ""),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				assignments: &domain.IDPAssignments{
+					FailedProjectIDs: []string{"project1"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "group not found, skipped",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							addNewGroupEvent("group2", "org1"),
+						),
+					),
+					expectPush(
+						group.NewGroupUsersAddedEvent(context.Background(), &group.NewAggregate("group2", "org1").Aggregate,
+							[]string{"user1"},
+						),
+						user.NewUserIDPAssignmentAddedEvent(context.Background(), userAgg, "idp1", "", "", "group2"),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				assignments: &domain.IDPAssignments{
+					GroupIDs: []string{"group1", "group2"},
+				},
+			},
+			res: res{
+				want:            &domain.ObjectDetails{ResourceOwner: "org1"},
+				invalidGroupIDs: []string{"group1"},
+			},
+		},
+		{
+			name: "group rule failed, groups kept",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPAssignmentAddedEvent(context.Background(), userAgg, "idp1", "", "", "group2"),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				assignments: &domain.IDPAssignments{
+					GroupsFailed: true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+		{
+			name: "groups reconciled",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(
+							user.NewUserIDPAssignmentAddedEvent(context.Background(), userAgg, "idp1", "", "", "group2"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							addNewGroupEvent("group1", "org1"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							addNewGroupEvent("group2", "org1"),
+						),
+						eventFromEventPusher(
+							addNewGroupUsersAddedEvent("group2", "org1", []string{"user1"}),
+						),
+					),
+					expectPush(
+						group.NewGroupUsersAddedEvent(context.Background(), &group.NewAggregate("group1", "org1").Aggregate,
+							[]string{"user1"},
+						),
+						user.NewUserIDPAssignmentAddedEvent(context.Background(), userAgg, "idp1", "", "", "group1"),
+						group.NewGroupUsersRemovedEvent(context.Background(), &group.NewAggregate("group2", "org1").Aggregate,
+							[]string{"user1"},
+						),
+						user.NewUserIDPAssignmentRemovedEvent(context.Background(), userAgg, "idp1", "", "", "group2"),
+					),
+				),
+			},
+			args: args{
+				userID:        "user1",
+				resourceOwner: "org1",
+				idpID:         "idp1",
+				assignments: &domain.IDPAssignments{
+					GroupIDs: []string{"group1"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{ResourceOwner: "org1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := c.ReconcileIDPAssignments(context.Background(), tt.args.userID, tt.args.resourceOwner, tt.args.idpID, tt.args.assignments)
+			assert.ErrorIs(t, err, tt.res.err)
+			if tt.res.err == nil {
+				assertObjectDetails(t, tt.res.want, got.ObjectDetails)
+				assert.Equal(t, tt.res.invalidGroupIDs, got.InvalidGroupIDs)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
 
@@ -116,3 +117,52 @@ func (c *Commands) MachineSecretCheckSucceeded(ctx context.Context, userID, reso
 		c.asyncPush(ctx, cmds...)
 	}
 }
+
+// MachineSecretCheck verifies the secret of a machine user, which is used as password (e.g. as LDAP app password).
+// Other than the client credentials, the checks are recorded and the user is locked after too many failed checks,
+// as defined by the password attempts of the lockout policy.
+func (c *Commands) MachineSecretCheck(ctx context.Context, userID, resourceOwner, secret string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Lq4vd", "Errors.User.UserIDMissing")
+	}
+	wm := NewMachineSecretCheckWriteModel(userID, resourceOwner)
+	if err = c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return err
+	}
+	if !isUserStateExists(wm.UserState) {
+		return zerrors.ThrowNotFound(nil, "COMMAND-Hs8zp", "Errors.User.NotFound")
+	}
+	if wm.UserState == domain.UserStateLocked {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Ye2wm", "Errors.User.Locked")
+	}
+	if wm.HashedSecret == "" {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Tb6rk", "Errors.User.Machine.Secret.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModelCtx(ctx, &wm.WriteModel)
+	ctx, spanPasswordComparison := tracing.NewNamedSpan(ctx, "passwap.Verify")
+	updated, verifyErr := c.secretHasher.Verify(wm.HashedSecret, secret)
+	spanPasswordComparison.EndWithError(verifyErr)
+	if verifyErr == nil {
+		cmds := []eventstore.Command{user.NewMachineSecretCheckSucceededEvent(ctx, userAgg)}
+		if updated != "" {
+			cmds = append(cmds, user.NewMachineSecretHashUpdatedEvent(ctx, userAgg, updated))
+		}
+		_, err = c.eventstore.Push(ctx, cmds...)
+		return err
+	}
+	cmds := []eventstore.Command{user.NewMachineSecretCheckFailedEvent(ctx, userAgg)}
+	lockoutPolicy, err := getLockoutPolicy(ctx, wm.ResourceOwner, c.eventstore.FilterToQueryReducer)
+	if err != nil {
+		return err
+	}
+	if lockoutPolicy != nil && lockoutPolicy.MaxPasswordAttempts > 0 && wm.CheckFailedCount+1 >= lockoutPolicy.MaxPasswordAttempts {
+		cmds = append(cmds, user.NewUserLockedEvent(ctx, userAgg))
+	}
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return err
+	}
+	return zerrors.ThrowInvalidArgument(verifyErr, "COMMAND-Vq3nx", "Errors.User.Machine.Secret.Invalid")
+}
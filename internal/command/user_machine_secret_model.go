@@ -0,0 +1,86 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// MachineSecretCheckWriteModel contains the secret of a machine user and the failed checks since the last successful one.
+type MachineSecretCheckWriteModel struct {
+	eventstore.WriteModel
+
+	UserState        domain.UserState
+	HashedSecret     string
+	CheckFailedCount uint64
+}
+
+func NewMachineSecretCheckWriteModel(userID, resourceOwner string) *MachineSecretCheckWriteModel {
+	return &MachineSecretCheckWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *MachineSecretCheckWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.MachineAddedEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.UserLockedEvent:
+			if wm.UserState != domain.UserStateDeleted {
+				wm.UserState = domain.UserStateLocked
+			}
+		case *user.UserUnlockedEvent:
+			if wm.UserState != domain.UserStateDeleted {
+				wm.UserState = domain.UserStateActive
+			}
+			wm.CheckFailedCount = 0
+		case *user.UserDeactivatedEvent:
+			if wm.UserState != domain.UserStateDeleted {
+				wm.UserState = domain.UserStateInactive
+			}
+		case *user.UserReactivatedEvent:
+			if wm.UserState != domain.UserStateDeleted {
+				wm.UserState = domain.UserStateActive
+			}
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		case *user.MachineSecretSetEvent:
+			wm.HashedSecret = crypto.SecretOrEncodedHash(e.ClientSecret, e.HashedSecret)
+			wm.CheckFailedCount = 0
+		case *user.MachineSecretRemovedEvent:
+			wm.HashedSecret = ""
+		case *user.MachineSecretHashUpdatedEvent:
+			wm.HashedSecret = e.HashedSecret
+		case *user.MachineSecretCheckSucceededEvent:
+			wm.CheckFailedCount = 0
+		case *user.MachineSecretCheckFailedEvent:
+			wm.CheckFailedCount++
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *MachineSecretCheckWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(user.MachineAddedEventType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.UserDeactivatedType,
+			user.UserReactivatedType,
+			user.UserRemovedType,
+			user.MachineSecretSetType,
+			user.MachineSecretRemovedType,
+			user.MachineSecretHashUpdatedType,
+			user.MachineSecretCheckSucceededType,
+			user.MachineSecretCheckFailedType,
+		).Builder()
+}
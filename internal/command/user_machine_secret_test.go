@@ -10,6 +10,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
 	"github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -346,3 +347,117 @@ func TestCommands_MachineSecretCheckSucceeded(t *testing.T) {
 		})
 	}
 }
+
+func TestCommands_MachineSecretCheck(t *testing.T) {
+	userAgg := &user.NewAggregate("user1", "org1").Aggregate
+	machineAdded := func() eventstore.Event {
+		return eventFromEventPusher(
+			user.NewMachineAddedEvent(context.Background(), userAgg, "user1", "username", "user", false, domain.OIDCTokenTypeBearer),
+		)
+	}
+	secretSet := func() eventstore.Event {
+		return eventFromEventPusher(user.NewMachineSecretSetEvent(context.Background(), userAgg, "$plain$x$secret"))
+	}
+	lockoutPolicy := func() expect {
+		return expectFilter(
+			eventFromEventPusher(
+				org.NewLockoutPolicyAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, 2, 0, false),
+			),
+		)
+	}
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		secret     string
+		err        func(error) bool
+	}{
+		{
+			name:       "user not found, error",
+			eventstore: expectEventstore(expectFilter()),
+			secret:     "secret",
+			err:        zerrors.IsNotFound,
+		},
+		{
+			name: "locked, error",
+			eventstore: expectEventstore(
+				expectFilter(
+					machineAdded(),
+					secretSet(),
+					eventFromEventPusher(user.NewUserLockedEvent(context.Background(), userAgg)),
+				),
+			),
+			secret: "secret",
+			err:    zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "no secret, error",
+			eventstore: expectEventstore(
+				expectFilter(
+					machineAdded(),
+				),
+			),
+			secret: "secret",
+			err:    zerrors.IsPreconditionFailed,
+		},
+		{
+			name: "wrong secret, failed check recorded",
+			eventstore: expectEventstore(
+				expectFilter(
+					machineAdded(),
+					secretSet(),
+				),
+				lockoutPolicy(),
+				expectPush(
+					user.NewMachineSecretCheckFailedEvent(context.Background(), userAgg),
+				),
+			),
+			secret: "wrong",
+			err:    zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "wrong secret, max attempts reached, locked",
+			eventstore: expectEventstore(
+				expectFilter(
+					machineAdded(),
+					secretSet(),
+					eventFromEventPusher(user.NewMachineSecretCheckFailedEvent(context.Background(), userAgg)),
+				),
+				lockoutPolicy(),
+				expectPush(
+					user.NewMachineSecretCheckFailedEvent(context.Background(), userAgg),
+					user.NewUserLockedEvent(context.Background(), userAgg),
+				),
+			),
+			secret: "wrong",
+			err:    zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "correct secret, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					machineAdded(),
+					secretSet(),
+					eventFromEventPusher(user.NewMachineSecretCheckFailedEvent(context.Background(), userAgg)),
+				),
+				expectPush(
+					user.NewMachineSecretCheckSucceededEvent(context.Background(), userAgg),
+				),
+			),
+			secret: "secret",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:   tt.eventstore(t),
+				secretHasher: mockPasswordHasher("x"),
+			}
+			err := c.MachineSecretCheck(context.Background(), "user1", "org1", tt.secret)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.err(err), "unexpected error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// RequestUserDataExport requests the export of all personal data of a human user (right of access).
+// The archive is generated asynchronously and the user is notified as soon as it can be downloaded.
+// Users can request the export of their own data, others need the permission to read the user.
+func (c *Commands) RequestUserDataExport(ctx context.Context, userID string) (exportID string, details *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return "", nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Xe4ra", "Errors.User.UserIDMissing")
+	}
+	wm, err := c.userDataExportWriteModel(ctx, userID, "")
+	if err != nil {
+		return "", nil, err
+	}
+	if !wm.UserState.Exists() {
+		return "", nil, zerrors.ThrowNotFound(nil, "COMMAND-Pj3sv", "Errors.User.NotFound")
+	}
+	if err = c.checkPermissionOnUser(ctx, domain.PermissionUserRead, true)(wm.ResourceOwner, wm.AggregateID); err != nil {
+		return "", nil, err
+	}
+	if wm.PendingExportID != "" {
+		return "", nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Vu8gk", "Errors.User.DataExport.AlreadyRequested")
+	}
+	exportID, err = c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	err = c.pushAppendAndReduce(ctx, wm, user.NewHumanDataExportRequestedEvent(ctx, UserAggregateFromWriteModelCtx(ctx, &wm.WriteModel), exportID))
+	if err != nil {
+		return "", nil, err
+	}
+	return exportID, writeModelToObjectDetails(&wm.WriteModel), nil
+}
+
+// UserDataExport returns the state of the export.
+// It's used by the worker generating the archive, therefore no permission is checked.
+func (c *Commands) UserDataExport(ctx context.Context, userID, exportID string) (*UserDataExportWriteModel, error) {
+	if userID == "" || exportID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Ok2ay", "Errors.IDMissing")
+	}
+	return c.userDataExportWriteModel(ctx, userID, exportID)
+}
+
+// UserDataExportSucceeded sets the export ready for download.
+// The download code is generated using the codeGenerator, which also defines how long the archive can be downloaded.
+func (c *Commands) UserDataExportSucceeded(ctx context.Context, userID, exportID, objectName string, size int64, codeGenerator crypto.Generator) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm, err := c.requestedUserDataExport(ctx, userID, exportID)
+	if err != nil {
+		return err
+	}
+	code, _, err := crypto.NewCode(codeGenerator)
+	if err != nil {
+		return err
+	}
+	return c.pushAppendAndReduce(ctx, wm, user.NewHumanDataExportSucceededEvent(
+		ctx,
+		UserAggregateFromWriteModelCtx(ctx, &wm.WriteModel),
+		exportID,
+		objectName,
+		size,
+		code,
+		codeGenerator.Expiry(),
+		wm.TriggeredAtOrigin,
+	))
+}
+
+// UserDataExportFailed marks the export as failed, a new export can be requested afterwards.
+func (c *Commands) UserDataExportFailed(ctx context.Context, userID, exportID, reason string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm, err := c.requestedUserDataExport(ctx, userID, exportID)
+	if err != nil {
+		return err
+	}
+	return c.pushAppendAndReduce(ctx, wm, user.NewHumanDataExportFailedEvent(ctx, UserAggregateFromWriteModelCtx(ctx, &wm.WriteModel), exportID, reason))
+}
+
+func (c *Commands) requestedUserDataExport(ctx context.Context, userID, exportID string) (*UserDataExportWriteModel, error) {
+	wm, err := c.UserDataExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if wm.State != domain.UserDataExportStateRequested {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-Hd7qe", "Errors.User.DataExport.NotRequested")
+	}
+	return wm, nil
+}
+
+// UserDataExportLinkSent records the notification of the user about the ready export.
+func (c *Commands) UserDataExportLinkSent(ctx context.Context, userID, resourceOwner, exportID string) error {
+	if userID == "" || exportID == "" {
+		return zerrors.ThrowInvalidArgument(nil, "COMMAND-Rb5wz", "Errors.IDMissing")
+	}
+	wm, err := c.userDataExportWriteModel(ctx, userID, exportID)
+	if err != nil {
+		return err
+	}
+	if wm.State != domain.UserDataExportStateReady {
+		return zerrors.ThrowPreconditionFailed(nil, "COMMAND-Tn4ec", "Errors.User.DataExport.NotReady")
+	}
+	_, err = c.eventstore.Push(ctx, user.NewHumanDataExportLinkSentEvent(ctx, UserAggregateFromWriteModelCtx(ctx, &wm.WriteModel), exportID))
+	return err
+}
+
+// DownloadUserDataExport verifies the code of a ready export and records the download.
+// The returned write model contains the name and the resource owner of the stored archive.
+// Archives of removed users can't be downloaded anymore.
+func (c *Commands) DownloadUserDataExport(ctx context.Context, userID, exportID, code string) (_ *UserDataExportWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || exportID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "COMMAND-Lw3fo", "Errors.IDMissing")
+	}
+	wm, err := c.userDataExportWriteModel(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+	if !wm.UserState.Exists() || wm.State != domain.UserDataExportStateReady {
+		return nil, zerrors.ThrowNotFound(nil, "COMMAND-Zq9xm", "Errors.User.DataExport.NotFound")
+	}
+	if err = crypto.VerifyCode(wm.CodeCreationDate, wm.Expiry, wm.Code, code, c.userEncryption); err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "COMMAND-Ga6kp", "Errors.User.Code.Invalid")
+	}
+	if _, err = c.eventstore.Push(ctx, user.NewHumanDataExportDownloadedEvent(ctx, UserAggregateFromWriteModelCtx(ctx, &wm.WriteModel), exportID)); err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
+
+func (c *Commands) userDataExportWriteModel(ctx context.Context, userID, exportID string) (_ *UserDataExportWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	wm := NewUserDataExportWriteModel(userID, exportID, "")
+	if err = c.eventstore.FilterToQueryReducer(ctx, wm); err != nil {
+		return nil, err
+	}
+	return wm, nil
+}
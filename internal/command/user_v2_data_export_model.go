@@ -0,0 +1,104 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// UserDataExportWriteModel is the state of a single export of the personal data of a user.
+// If the ExportID is empty, only the state of the user and its pending export are reduced.
+type UserDataExportWriteModel struct {
+	eventstore.WriteModel
+
+	ExportID  string
+	UserState domain.UserState
+	// PendingExportID is the export of the user which is not yet generated.
+	PendingExportID string
+
+	State             domain.UserDataExportState
+	TriggeredAtOrigin string
+	ObjectName        string
+	Size              int64
+	Code              *crypto.CryptoValue
+	CodeCreationDate  time.Time
+	Expiry            time.Duration
+}
+
+func NewUserDataExportWriteModel(userID, exportID, resourceOwner string) *UserDataExportWriteModel {
+	return &UserDataExportWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   userID,
+			ResourceOwner: resourceOwner,
+		},
+		ExportID: exportID,
+	}
+}
+
+func (wm *UserDataExportWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent, *user.HumanRegisteredEvent:
+			wm.UserState = domain.UserStateActive
+		case *user.UserRemovedEvent:
+			wm.UserState = domain.UserStateDeleted
+		case *user.HumanDataExportRequestedEvent:
+			wm.PendingExportID = e.ID
+			if e.ID == wm.ExportID {
+				wm.State = domain.UserDataExportStateRequested
+				wm.TriggeredAtOrigin = e.TriggeredAtOrigin
+			}
+		case *user.HumanDataExportSucceededEvent:
+			wm.reduceFinished(e.ID)
+			if e.ID == wm.ExportID {
+				wm.State = domain.UserDataExportStateReady
+				wm.ObjectName = e.ObjectName
+				wm.Size = e.Size
+				wm.Code = e.Code
+				wm.CodeCreationDate = e.CreationDate()
+				wm.Expiry = e.Expiry
+			}
+		case *user.HumanDataExportFailedEvent:
+			wm.reduceFinished(e.ID)
+			if e.ID == wm.ExportID {
+				wm.State = domain.UserDataExportStateFailed
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserDataExportWriteModel) reduceFinished(id string) {
+	if wm.PendingExportID == id {
+		wm.PendingExportID = ""
+	}
+}
+
+func (wm *UserDataExportWriteModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.UserRemovedType,
+			user.HumanDataExportRequestedType,
+			user.HumanDataExportSucceededType,
+			user.HumanDataExportFailedType,
+		).Builder()
+	if wm.ResourceOwner != "" {
+		query.ResourceOwner(wm.ResourceOwner)
+	}
+	return query
+}
+
+// Expired checks if the archive of a ready export can't be downloaded anymore.
+func (wm *UserDataExportWriteModel) Expired() bool {
+	return crypto.IsCodeExpired(wm.CodeCreationDate, wm.Expiry)
+}
@@ -0,0 +1,536 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func dataExportHumanAddedEvent() *repository.Event {
+	return eventFromEventPusher(
+		user.NewHumanAddedEvent(context.Background(),
+			&user.NewAggregate("userID", "org1").Aggregate,
+			"username",
+			"firstName",
+			"lastName",
+			"nickName",
+			"displayName",
+			language.English,
+			domain.GenderUnspecified,
+			"email@example.com",
+			false,
+		),
+	)
+}
+
+func dataExportCode() *crypto.CryptoValue {
+	return &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("a"),
+	}
+}
+
+func TestCommands_RequestUserDataExport(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		idGenerator     id.Generator
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx    context.Context
+		userID string
+	}
+	type want struct {
+		exportID string
+		details  *domain.ObjectDetails
+		err      error
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "missing user id",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: " ",
+			},
+			want: want{
+				err: zerrors.ThrowInvalidArgument(nil, "COMMAND-Xe4ra", "Errors.User.UserIDMissing"),
+			},
+		},
+		{
+			name: "user not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+			},
+			want: want{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Pj3sv", "Errors.User.NotFound"),
+			},
+		},
+		{
+			name: "user removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewUserRemovedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"username",
+								nil,
+								true,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    context.Background(),
+				userID: "userID",
+			},
+			want: want{
+				err: zerrors.ThrowNotFound(nil, "COMMAND-Pj3sv", "Errors.User.NotFound"),
+			},
+		},
+		{
+			name: "other user, permission denied",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx:    authz.SetCtxData(context.Background(), authz.CtxData{UserID: "admin"}),
+				userID: "userID",
+			},
+			want: want{
+				err: zerrors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied"),
+			},
+		},
+		{
+			name: "export already requested",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanDataExportRequestedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"export1",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:    authz.SetCtxData(context.Background(), authz.CtxData{UserID: "userID"}),
+				userID: "userID",
+			},
+			want: want{
+				err: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Vu8gk", "Errors.User.DataExport.AlreadyRequested"),
+			},
+		},
+		{
+			name: "self, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanDataExportRequestedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"export1",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanDataExportFailedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"export1",
+								"failed",
+							),
+						),
+					),
+					expectPush(
+						user.NewHumanDataExportRequestedEvent(authz.SetCtxData(context.Background(), authz.CtxData{UserID: "userID"}),
+							&user.NewAggregate("userID", "org1").Aggregate,
+							"export2",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "export2"),
+			},
+			args: args{
+				ctx:    authz.SetCtxData(context.Background(), authz.CtxData{UserID: "userID"}),
+				userID: "userID",
+			},
+			want: want{
+				exportID: "export2",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "userID",
+				},
+			},
+		},
+		{
+			name: "other user with permission, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+					),
+					expectPush(
+						user.NewHumanDataExportRequestedEvent(authz.SetCtxData(context.Background(), authz.CtxData{UserID: "admin"}),
+							&user.NewAggregate("userID", "org1").Aggregate,
+							"export1",
+						),
+					),
+				),
+				idGenerator:     id_mock.NewIDGeneratorExpectIDs(t, "export1"),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx:    authz.SetCtxData(context.Background(), authz.CtxData{UserID: "admin"}),
+				userID: "userID",
+			},
+			want: want{
+				exportID: "export1",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+					ID:            "userID",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &Commands{
+				eventstore:      tt.fields.eventstore(t),
+				idGenerator:     tt.fields.idGenerator,
+				checkPermission: tt.fields.checkPermission,
+			}
+			exportID, details, err := c.RequestUserDataExport(tt.args.ctx, tt.args.userID)
+			require.ErrorIs(t, err, tt.want.err)
+			assert.Equal(t, tt.want.exportID, exportID)
+			assertObjectDetails(t, tt.want.details, details)
+		})
+	}
+}
+
+func TestCommands_UserDataExportSucceeded(t *testing.T) {
+	t.Parallel()
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		userID   string
+		exportID string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr error
+	}{
+		{
+			name: "missing export id",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				userID: "userID",
+			},
+			wantErr: zerrors.ThrowInvalidArgument(nil, "COMMAND-Ok2ay", "Errors.IDMissing"),
+		},
+		{
+			name: "export not requested",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+					),
+				),
+			},
+			args: args{
+				userID:   "userID",
+				exportID: "export1",
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Hd7qe", "Errors.User.DataExport.NotRequested"),
+		},
+		{
+			name: "export already failed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						eventFromEventPusher(
+							user.NewHumanDataExportRequestedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"export1",
+							),
+						),
+						eventFromEventPusher(
+							user.NewHumanDataExportFailedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"export1",
+								"failed",
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				userID:   "userID",
+				exportID: "export1",
+			},
+			wantErr: zerrors.ThrowPreconditionFailed(nil, "COMMAND-Hd7qe", "Errors.User.DataExport.NotRequested"),
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						eventFromEventPusher(
+							&user.HumanDataExportRequestedEvent{
+								BaseEvent: eventstore.NewBaseEventForPush(context.Background(),
+									&user.NewAggregate("userID", "org1").Aggregate,
+									user.HumanDataExportRequestedType,
+								),
+								ID:                "export1",
+								TriggeredAtOrigin: "https://example.com",
+							},
+						),
+					),
+					expectPush(
+						user.NewHumanDataExportSucceededEvent(context.Background(),
+							&user.NewAggregate("userID", "org1").Aggregate,
+							"export1",
+							"users/userID/data-exports/export1.json",
+							42,
+							dataExportCode(),
+							time.Hour,
+							"https://example.com",
+						),
+					),
+				),
+			},
+			args: args{
+				userID:   "userID",
+				exportID: "export1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &Commands{
+				eventstore: tt.fields.eventstore(t),
+			}
+			err := c.UserDataExportSucceeded(context.Background(), tt.args.userID, tt.args.exportID, domain.GetUserDataExportAssetPath(tt.args.userID, tt.args.exportID), 42, GetMockSecretGenerator(t))
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCommands_DownloadUserDataExport(t *testing.T) {
+	t.Parallel()
+	succeeded := func(creationDate time.Time) *repository.Event {
+		return eventFromEventPusherWithCreationDate(
+			user.NewHumanDataExportSucceededEvent(context.Background(),
+				&user.NewAggregate("userID", "org1").Aggregate,
+				"export1",
+				"users/userID/data-exports/export1.json",
+				42,
+				dataExportCode(),
+				time.Hour,
+				"",
+			),
+			creationDate,
+		)
+	}
+	requested := eventFromEventPusher(
+		user.NewHumanDataExportRequestedEvent(context.Background(),
+			&user.NewAggregate("userID", "org1").Aggregate,
+			"export1",
+		),
+	)
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		exportID string
+		code     string
+	}
+	type want struct {
+		objectName    string
+		resourceOwner string
+		err           func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   want
+	}{
+		{
+			name: "export not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						requested,
+					),
+				),
+			},
+			args: args{
+				exportID: "export1",
+				code:     "a",
+			},
+			want: want{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "user removed",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						requested,
+						succeeded(time.Now()),
+						eventFromEventPusher(
+							user.NewUserRemovedEvent(context.Background(),
+								&user.NewAggregate("userID", "org1").Aggregate,
+								"username",
+								nil,
+								true,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				exportID: "export1",
+				code:     "a",
+			},
+			want: want{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "wrong code",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						requested,
+						succeeded(time.Now()),
+					),
+				),
+			},
+			args: args{
+				exportID: "export1",
+				code:     "b",
+			},
+			want: want{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "expired",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						requested,
+						succeeded(time.Now().Add(-2*time.Hour)),
+					),
+				),
+			},
+			args: args{
+				exportID: "export1",
+				code:     "a",
+			},
+			want: want{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						dataExportHumanAddedEvent(),
+						requested,
+						succeeded(time.Now()),
+					),
+					expectPush(
+						user.NewHumanDataExportDownloadedEvent(context.Background(),
+							&user.NewAggregate("userID", "org1").Aggregate,
+							"export1",
+						),
+					),
+				),
+			},
+			args: args{
+				exportID: "export1",
+				code:     "a",
+			},
+			want: want{
+				objectName:    "users/userID/data-exports/export1.json",
+				resourceOwner: "org1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := &Commands{
+				eventstore:     tt.fields.eventstore(t),
+				userEncryption: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := c.DownloadUserDataExport(context.Background(), "userID", tt.args.exportID, tt.args.code)
+			if tt.want.err != nil {
+				assert.True(t, tt.want.err(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.objectName, got.ObjectName)
+			assert.Equal(t, tt.want.resourceOwner, got.ResourceOwner)
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"errors"
+	"slices"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+var (
+	// JWEKeyAlgorithms are the supported key management algorithms to encrypt JWTs and responses for clients.
+	JWEKeyAlgorithms = []jose.KeyAlgorithm{
+		jose.RSA_OAEP,
+		jose.RSA_OAEP_256,
+		jose.ECDH_ES,
+		jose.ECDH_ES_A128KW,
+		jose.ECDH_ES_A192KW,
+		jose.ECDH_ES_A256KW,
+	}
+	// JWEContentEncryptions are the supported content encryption algorithms to encrypt JWTs and responses for clients.
+	JWEContentEncryptions = []jose.ContentEncryption{
+		jose.A128CBC_HS256,
+		jose.A192CBC_HS384,
+		jose.A256CBC_HS512,
+		jose.A128GCM,
+		jose.A192GCM,
+		jose.A256GCM,
+	}
+)
+
+var ErrNoEncryptionKey = errors.New("no key found for the encryption algorithm")
+
+// NewNestedJWTEncrypter creates an encrypter for nested JWTs (signed and then encrypted)
+// for the recipient of the PEM encoded public key.
+// RSA keys use RSA-OAEP-256 and EC keys ECDH-ES+A256KW as key management algorithm,
+// the content is always encrypted using A256GCM.
+func NewNestedJWTEncrypter(publicKey []byte, keyID string) (jose.Encrypter, error) {
+	key, err := BytesToPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	var algorithm jose.KeyAlgorithm
+	switch key.(type) {
+	case *rsa.PublicKey:
+		algorithm = jose.RSA_OAEP_256
+	case *ecdsa.PublicKey:
+		algorithm = jose.ECDH_ES_A256KW
+	default:
+		return nil, ErrNoPublicKey
+	}
+	return newJWEEncrypter(jose.Recipient{
+		Algorithm: algorithm,
+		Key:       key,
+		KeyID:     keyID,
+	}, jose.A256GCM, true)
+}
+
+// NewKeySetEncrypter creates an encrypter for the first key of the key set,
+// which can be used with the key management algorithm.
+// If nested is set, the encrypted content is marked as signed JWT.
+func NewKeySetEncrypter(keySet *jose.JSONWebKeySet, algorithm jose.KeyAlgorithm, encryption jose.ContentEncryption, nested bool) (jose.Encrypter, error) {
+	if !slices.Contains(JWEKeyAlgorithms, algorithm) || !slices.Contains(JWEContentEncryptions, encryption) {
+		return nil, ErrNoEncryptionKey
+	}
+	for _, key := range keySet.Keys {
+		if !isEncryptionKeyFor(key, algorithm) {
+			continue
+		}
+		return newJWEEncrypter(jose.Recipient{
+			Algorithm: algorithm,
+			Key:       key.Key,
+			KeyID:     key.KeyID,
+		}, encryption, nested)
+	}
+	return nil, ErrNoEncryptionKey
+}
+
+func isEncryptionKeyFor(key jose.JSONWebKey, algorithm jose.KeyAlgorithm) bool {
+	if key.Use != "" && key.Use != "enc" {
+		return false
+	}
+	if key.Algorithm != "" && key.Algorithm != string(algorithm) {
+		return false
+	}
+	switch key.Key.(type) {
+	case *rsa.PublicKey:
+		return algorithm == jose.RSA_OAEP || algorithm == jose.RSA_OAEP_256
+	case *ecdsa.PublicKey:
+		return algorithm != jose.RSA_OAEP && algorithm != jose.RSA_OAEP_256
+	default:
+		return false
+	}
+}
+
+func newJWEEncrypter(recipient jose.Recipient, encryption jose.ContentEncryption, nested bool) (jose.Encrypter, error) {
+	options := new(jose.EncrypterOptions)
+	if nested {
+		options = options.WithType("JWT").WithContentType("JWT")
+	}
+	return jose.NewEncrypter(encryption, recipient, options)
+}
@@ -0,0 +1,146 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"testing"
+
+	"github.com/go-jose/go-jose/v4"
+)
+
+func TestNewNestedJWTEncrypter_RSA(t *testing.T) {
+	encrypter, err := NewNestedJWTEncrypter(mustGenerateRSAPEM(t), "keyID")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNestedJWE(t, encrypter, jose.RSA_OAEP_256)
+}
+
+func TestNewNestedJWTEncrypter_ECDSA(t *testing.T) {
+	encrypter, err := NewNestedJWTEncrypter(mustGenerateECDSAPEM(t), "keyID")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	assertNestedJWE(t, encrypter, jose.ECDH_ES_A256KW)
+}
+
+func TestNewNestedJWTEncrypter_Ed25519(t *testing.T) {
+	_, err := NewNestedJWTEncrypter(mustGenerateEd25519PEM(t), "keyID")
+	if !errors.Is(err, ErrNoPublicKey) {
+		t.Fatalf("expected ErrNoPublicKey, got %v", err)
+	}
+}
+
+func TestNewNestedJWTEncrypter_Empty(t *testing.T) {
+	_, err := NewNestedJWTEncrypter(nil, "")
+	if !errors.Is(err, ErrEmpty) {
+		t.Fatalf("expected ErrEmpty, got %v", err)
+	}
+}
+
+func assertNestedJWE(t *testing.T, encrypter jose.Encrypter, algorithm jose.KeyAlgorithm) {
+	t.Helper()
+	object, err := encrypter.Encrypt([]byte("header.payload.signature"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	compact, err := object.CompactSerialize()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := jose.ParseEncrypted(compact, []jose.KeyAlgorithm{algorithm}, []jose.ContentEncryption{jose.A256GCM})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if parsed.Header.ExtraHeaders[jose.HeaderContentType] != "JWT" {
+		t.Fatalf("expected content type JWT, got %v", parsed.Header.ExtraHeaders[jose.HeaderContentType])
+	}
+}
+
+func TestNewKeySetEncrypter(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keySet := &jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: &rsaKey.PublicKey, KeyID: "sig", Use: "sig"},
+			{Key: &rsaKey.PublicKey, KeyID: "rsa", Use: "enc"},
+			{Key: &ecKey.PublicKey, KeyID: "ec", Algorithm: string(jose.ECDH_ES_A256KW)},
+		},
+	}
+	tests := []struct {
+		name       string
+		algorithm  jose.KeyAlgorithm
+		encryption jose.ContentEncryption
+		wantKeyID  string
+		wantErr    error
+	}{
+		{
+			name:       "unsupported algorithm",
+			algorithm:  jose.RSA1_5,
+			encryption: jose.A128CBC_HS256,
+			wantErr:    ErrNoEncryptionKey,
+		},
+		{
+			name:       "unsupported encryption",
+			algorithm:  jose.RSA_OAEP,
+			encryption: "unknown",
+			wantErr:    ErrNoEncryptionKey,
+		},
+		{
+			name:       "no key for algorithm",
+			algorithm:  jose.ECDH_ES,
+			encryption: jose.A128CBC_HS256,
+			wantErr:    ErrNoEncryptionKey,
+		},
+		{
+			name:       "rsa",
+			algorithm:  jose.RSA_OAEP_256,
+			encryption: jose.A128CBC_HS256,
+			wantKeyID:  "rsa",
+		},
+		{
+			name:       "ec",
+			algorithm:  jose.ECDH_ES_A256KW,
+			encryption: jose.A256GCM,
+			wantKeyID:  "ec",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			encrypter, err := NewKeySetEncrypter(keySet, tt.algorithm, tt.encryption, false)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			object, err := encrypter.Encrypt([]byte(`{"sub":"user"}`))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			compact, err := object.CompactSerialize()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			object, err = jose.ParseEncrypted(compact, []jose.KeyAlgorithm{tt.algorithm}, []jose.ContentEncryption{tt.encryption})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if object.Header.KeyID != tt.wantKeyID {
+				t.Fatalf("expected key id %s, got %s", tt.wantKeyID, object.Header.KeyID)
+			}
+			if _, ok := object.Header.ExtraHeaders[jose.HeaderContentType]; ok {
+				t.Fatal("expected no content type")
+			}
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package domain
 
 import (
+	"net/url"
+	"strings"
+
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
@@ -14,6 +17,13 @@ type APIApp struct {
 	EncodedHash        string
 	ClientSecretString string
 	AuthMethodType     APIAuthMethodType
+	ResourceURI        *string
+
+	// IntrospectionSignedResponse allows the API to request JWT introspection responses (RFC 9701).
+	IntrospectionSignedResponse *bool
+	// IntrospectionEncryptionKey is the PEM encoded public key used to encrypt JWT introspection responses.
+	// An empty (non-nil) key removes the encryption.
+	IntrospectionEncryptionKey []byte
 
 	State AppState
 }
@@ -34,7 +44,33 @@ const (
 )
 
 func (a *APIApp) IsValid() bool {
-	return a.AppName != ""
+	return a.AppName != "" && a.IsResourceURIValid()
+}
+
+// IsResourceURIValid checks the optional resource uri of the API.
+// An empty uri is valid, as it removes the resource indicator.
+func (a *APIApp) IsResourceURIValid() bool {
+	return a.ResourceURI == nil || *a.ResourceURI == "" || IsValidResourceIndicator(*a.ResourceURI)
+}
+
+// IsValidResourceIndicator checks that the resource can be used as resource indicator
+// as defined in RFC 8707: an absolute URI without a fragment component.
+func IsValidResourceIndicator(resource string) bool {
+	uri, err := url.Parse(resource)
+	if err != nil {
+		return false
+	}
+	return uri.IsAbs() && !strings.Contains(resource, "#")
+}
+
+// IsIntrospectionEncryptionKeyValid checks that the optional encryption key
+// can be used to encrypt the JWT introspection responses.
+func (a *APIApp) IsIntrospectionEncryptionKeyValid() bool {
+	if len(a.IntrospectionEncryptionKey) == 0 {
+		return true
+	}
+	_, err := crypto.NewNestedJWTEncrypter(a.IntrospectionEncryptionKey, "")
+	return err == nil
 }
 
 func (a *APIApp) setClientID(clientID string) {
@@ -0,0 +1,91 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidResourceIndicator(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource string
+		want     bool
+	}{
+		{
+			name:     "empty",
+			resource: "",
+			want:     false,
+		},
+		{
+			name:     "relative",
+			resource: "/api/v1",
+			want:     false,
+		},
+		{
+			name:     "fragment",
+			resource: "https://api.example.com/#orders",
+			want:     false,
+		},
+		{
+			name:     "https",
+			resource: "https://api.example.com/orders",
+			want:     true,
+		},
+		{
+			name:     "urn",
+			resource: "urn:example:orders",
+			want:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsValidResourceIndicator(tt.resource))
+		})
+	}
+}
+
+func TestAPIApp_IsIntrospectionEncryptionKeyValid(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		key  []byte
+		want bool
+	}{
+		{
+			name: "unset",
+			key:  nil,
+			want: true,
+		},
+		{
+			name: "removed",
+			key:  []byte{},
+			want: true,
+		},
+		{
+			name: "invalid",
+			key:  []byte("invalid"),
+			want: false,
+		},
+		{
+			name: "rsa public key",
+			key:  pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := &APIApp{IntrospectionEncryptionKey: tt.key}
+			assert.Equal(t, tt.want, app.IsIntrospectionEncryptionKeyValid())
+		})
+	}
+}
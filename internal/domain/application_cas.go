@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// CASApp is a service using the Apereo CAS protocol (version 3.0).
+type CASApp struct {
+	models.ObjectRoot
+
+	AppID   string
+	AppName string
+	// ServiceURLs identify the application by the service parameter of the CAS requests.
+	// A service matches if it is equal to one of the urls,
+	// or starts with one of them if the registered url ends with a slash.
+	ServiceURLs  []string
+	LoginVersion *LoginVersion
+	LoginBaseURI *string
+
+	State AppState
+}
+
+func (a *CASApp) GetApplicationName() string {
+	return a.AppName
+}
+
+func (a *CASApp) GetState() AppState {
+	return a.State
+}
+
+func (a *CASApp) IsValid() bool {
+	if len(a.ServiceURLs) == 0 {
+		return false
+	}
+	for _, serviceURL := range a.ServiceURLs {
+		if !isValidCASServiceURL(serviceURL) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidCASServiceURL(serviceURL string) bool {
+	uri, err := url.Parse(serviceURL)
+	if err != nil {
+		return false
+	}
+	return (uri.Scheme == "https" || uri.Scheme == "http") && uri.Host != "" && uri.Fragment == ""
+}
+
+// CASServiceMatches checks if the requested service is covered by one of the registered service urls.
+func CASServiceMatches(serviceURLs []string, service string) bool {
+	for _, serviceURL := range serviceURLs {
+		if service == serviceURL {
+			return true
+		}
+		if strings.HasSuffix(serviceURL, "/") && strings.HasPrefix(service, serviceURL) {
+			return true
+		}
+	}
+	return false
+}
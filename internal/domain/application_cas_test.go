@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCASApp_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		app  *CASApp
+		want bool
+	}{
+		{
+			name: "missing service urls",
+			app:  &CASApp{},
+			want: false,
+		},
+		{
+			name: "relative service url",
+			app:  &CASApp{ServiceURLs: []string{"/cas"}},
+			want: false,
+		},
+		{
+			name: "invalid scheme",
+			app:  &CASApp{ServiceURLs: []string{"ftp://service.example.com/"}},
+			want: false,
+		},
+		{
+			name: "fragment",
+			app:  &CASApp{ServiceURLs: []string{"https://service.example.com/#login"}},
+			want: false,
+		},
+		{
+			name: "valid",
+			app:  &CASApp{ServiceURLs: []string{"https://service.example.com/", "http://localhost:8080/login"}},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.app.IsValid())
+		})
+	}
+}
+
+func TestCASServiceMatches(t *testing.T) {
+	serviceURLs := []string{"https://service.example.com/app/", "https://other.example.com/login"}
+	tests := []struct {
+		service string
+		want    bool
+	}{
+		{"https://service.example.com/app/", true},
+		{"https://service.example.com/app/login?next=%2F", true},
+		{"https://service.example.com/application", false},
+		{"https://other.example.com/login", true},
+		{"https://other.example.com/login/other", false},
+		{"https://other.example.com.evil.com/login", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.service, func(t *testing.T) {
+			assert.Equal(t, tt.want, CASServiceMatches(serviceURLs, tt.service))
+		})
+	}
+}
@@ -1,13 +1,18 @@
 package domain
 
 import (
+	"encoding/json"
 	"net/netip"
 	"net/url"
 	"slices"
 	"strings"
 	"time"
 
+	"github.com/go-jose/go-jose/v4"
+	"github.com/muhlemmer/gu"
+
 	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -42,8 +47,20 @@ type OIDCApp struct {
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage *bool
 	BackChannelLogoutURI     *string
+	FrontChannelLogoutURI    *string
 	LoginVersion             *LoginVersion
 	LoginBaseURI             *string
+	RefreshTokenRotation     *OIDCRefreshTokenRotation
+	RefreshTokenLifetime     *time.Duration
+	RefreshTokenIdleLifetime *time.Duration
+	// IDTokenEncryptedResponseAlg and UserinfoEncryptedResponseAlg enable the encryption
+	// of the corresponding responses with a key of the client's JWKS.
+	IDTokenEncryptedResponseAlg  *string
+	IDTokenEncryptedResponseEnc  *string
+	UserinfoEncryptedResponseAlg *string
+	UserinfoEncryptedResponseEnc *string
+	JWKS                         *string
+	JWKSURI                      *string
 
 	State AppState
 }
@@ -120,6 +137,33 @@ const (
 	OIDCAuthMethodTypePrivateKeyJWT
 )
 
+// OIDCRefreshTokenRotation defines how refresh tokens of a client are handled on use.
+type OIDCRefreshTokenRotation int32
+
+const (
+	// OIDCRefreshTokenRotationUnspecified rotates the refresh token on every use,
+	// but does not detect the reuse of an already rotated token.
+	// It's the default of existing applications, so that concurrent refreshes of the same token
+	// (e.g. of multiple browser tabs or retries) don't terminate their sessions.
+	OIDCRefreshTokenRotationUnspecified OIDCRefreshTokenRotation = iota
+	// OIDCRefreshTokenRotationOnEveryUse rotates the refresh token on every use.
+	// If an already rotated refresh token is presented again, all tokens of the OIDC session are revoked
+	// and the session is terminated.
+	OIDCRefreshTokenRotationOnEveryUse
+	// OIDCRefreshTokenRotationNever keeps the refresh token for its whole lifetime.
+	OIDCRefreshTokenRotationNever
+)
+
+func (r OIDCRefreshTokenRotation) Valid() bool {
+	return r >= OIDCRefreshTokenRotationUnspecified && r <= OIDCRefreshTokenRotationNever
+}
+
+// DetectReuse returns true if the presentation of an already rotated refresh token must revoke the whole token family.
+// Reuse is only detected if the application explicitly opted in.
+func (r OIDCRefreshTokenRotation) DetectReuse() bool {
+	return r == OIDCRefreshTokenRotationOnEveryUse
+}
+
 type Compliance struct {
 	NoneCompliant bool
 	Problems      []string
@@ -136,6 +180,12 @@ func (a *OIDCApp) IsValid() bool {
 	if (a.ClockSkew != nil && (*a.ClockSkew > time.Second*5 || *a.ClockSkew < time.Second*0)) || !a.OriginsValid() {
 		return false
 	}
+	if !a.RefreshTokenSettingsValid() {
+		return false
+	}
+	if !a.EncryptionSettingsValid() {
+		return false
+	}
 	grantTypes := a.getRequiredGrantTypes()
 	if len(grantTypes) == 0 {
 		return false
@@ -149,6 +199,89 @@ func (a *OIDCApp) IsValid() bool {
 	return true
 }
 
+// RefreshTokenSettingsValid checks the client specific refresh token settings.
+// Lifetimes of 0 fall back to the instance settings.
+func (a *OIDCApp) RefreshTokenSettingsValid() bool {
+	if a.RefreshTokenRotation != nil && !a.RefreshTokenRotation.Valid() {
+		return false
+	}
+	if a.RefreshTokenLifetime != nil && *a.RefreshTokenLifetime < 0 {
+		return false
+	}
+	if a.RefreshTokenIdleLifetime != nil && *a.RefreshTokenIdleLifetime < 0 {
+		return false
+	}
+	if a.RefreshTokenLifetime != nil && a.RefreshTokenIdleLifetime != nil &&
+		*a.RefreshTokenLifetime > 0 && *a.RefreshTokenIdleLifetime > *a.RefreshTokenLifetime {
+		return false
+	}
+	return true
+}
+
+// EncryptionSettingsValid checks the client specific response encryption settings.
+// An empty content encryption falls back to [DefaultJWEContentEncryption] at runtime.
+// Whether the client provides a key set if encryption is enabled,
+// must be checked against the resulting state of the application.
+func (a *OIDCApp) EncryptionSettingsValid() bool {
+	if !jweAlgorithmsValid(a.IDTokenEncryptedResponseAlg, a.IDTokenEncryptedResponseEnc) ||
+		!jweAlgorithmsValid(a.UserinfoEncryptedResponseAlg, a.UserinfoEncryptedResponseEnc) {
+		return false
+	}
+	jwks, jwksURI := gu.Value(a.JWKS), gu.Value(a.JWKSURI)
+	if jwks != "" && jwksURI != "" {
+		return false
+	}
+	if jwks != "" {
+		if _, err := ParseJWKS(jwks); err != nil {
+			return false
+		}
+	}
+	if jwksURI != "" {
+		uri, err := url.Parse(jwksURI)
+		if err != nil || !uri.IsAbs() || uri.Host == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// OIDCEncryptionKeySetMissing returns true if a response encryption is enabled,
+// but the client has neither an inline JWKS nor a JWKS URI.
+func OIDCEncryptionKeySetMissing(idTokenAlgorithm, userinfoAlgorithm, jwks, jwksURI string) bool {
+	return (idTokenAlgorithm != "" || userinfoAlgorithm != "") && jwks == "" && jwksURI == ""
+}
+
+func jweAlgorithmsValid(algorithm, encryption *string) bool {
+	alg, enc := gu.Value(algorithm), gu.Value(encryption)
+	if alg == "" {
+		return enc == ""
+	}
+	if !slices.Contains(crypto.JWEKeyAlgorithms, jose.KeyAlgorithm(alg)) {
+		return false
+	}
+	return enc == "" || slices.Contains(crypto.JWEContentEncryptions, jose.ContentEncryption(enc))
+}
+
+// DefaultJWEContentEncryption is used if only the key management algorithm of a response encryption is set.
+const DefaultJWEContentEncryption = jose.A128CBC_HS256
+
+// JWEContentEncryption returns the configured content encryption or the [DefaultJWEContentEncryption].
+func JWEContentEncryption(encryption string) jose.ContentEncryption {
+	if encryption == "" {
+		return DefaultJWEContentEncryption
+	}
+	return jose.ContentEncryption(encryption)
+}
+
+// ParseJWKS parses the inline JSON Web Key Set of a client.
+func ParseJWKS(jwks string) (*jose.JSONWebKeySet, error) {
+	keySet := new(jose.JSONWebKeySet)
+	if err := json.Unmarshal([]byte(jwks), keySet); err != nil {
+		return nil, err
+	}
+	return keySet, nil
+}
+
 func (a *OIDCApp) OriginsValid() bool {
 	for _, origin := range a.AdditionalOrigins {
 		if !http_util.IsOrigin(strings.TrimSpace(origin)) {
@@ -48,6 +48,155 @@ func TestApplicationValid(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			name: "invalid refresh token rotation",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:           models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                "AppID",
+					AppName:              "AppName",
+					ResponseTypes:        []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:           []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					RefreshTokenRotation: gu.Ptr(OIDCRefreshTokenRotation(42)),
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid refresh token lifetime minus",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:           models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                "AppID",
+					AppName:              "AppName",
+					ResponseTypes:        []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:           []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					RefreshTokenLifetime: gu.Ptr(-time.Hour),
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid refresh token idle lifetime longer than lifetime",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:               models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                    "AppID",
+					AppName:                  "AppName",
+					ResponseTypes:            []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:               []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					RefreshTokenLifetime:     gu.Ptr(time.Hour),
+					RefreshTokenIdleLifetime: gu.Ptr(2 * time.Hour),
+				},
+			},
+			result: false,
+		},
+		{
+			name: "valid refresh token settings",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:               models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                    "AppID",
+					AppName:                  "AppName",
+					ResponseTypes:            []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:               []OIDCGrantType{OIDCGrantTypeAuthorizationCode, OIDCGrantTypeRefreshToken},
+					RefreshTokenRotation:     gu.Ptr(OIDCRefreshTokenRotationOnEveryUse),
+					RefreshTokenLifetime:     gu.Ptr(30 * 24 * time.Hour),
+					RefreshTokenIdleLifetime: gu.Ptr(24 * time.Hour),
+				},
+			},
+			result: true,
+		},
+		{
+			name: "invalid id token encryption algorithm",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                  models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                       "AppID",
+					AppName:                     "AppName",
+					ResponseTypes:               []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                  []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					IDTokenEncryptedResponseAlg: gu.Ptr("RSA1_5"),
+					JWKSURI:                     gu.Ptr("https://client.example.com/jwks"),
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid userinfo encryption without algorithm",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                   models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                        "AppID",
+					AppName:                      "AppName",
+					ResponseTypes:                []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					UserinfoEncryptedResponseEnc: gu.Ptr("A256GCM"),
+					JWKSURI:                      gu.Ptr("https://client.example.com/jwks"),
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid jwks and jwks uri",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:    models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:         "AppID",
+					AppName:       "AppName",
+					ResponseTypes: []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:    []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					JWKS:          gu.Ptr(`{"keys":[]}`),
+					JWKSURI:       gu.Ptr("https://client.example.com/jwks"),
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid jwks",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:    models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:         "AppID",
+					AppName:       "AppName",
+					ResponseTypes: []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:    []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					JWKS:          gu.Ptr("keys"),
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid jwks uri",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:    models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:         "AppID",
+					AppName:       "AppName",
+					ResponseTypes: []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:    []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					JWKSURI:       gu.Ptr("/jwks"),
+				},
+			},
+			result: false,
+		},
+		{
+			name: "valid encryption settings",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:                   models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                        "AppID",
+					AppName:                      "AppName",
+					ResponseTypes:                []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:                   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					IDTokenEncryptedResponseAlg:  gu.Ptr("RSA-OAEP-256"),
+					UserinfoEncryptedResponseAlg: gu.Ptr("ECDH-ES+A256KW"),
+					UserinfoEncryptedResponseEnc: gu.Ptr("A256GCM"),
+					JWKS:                         gu.Ptr(`{"keys":[]}`),
+				},
+			},
+			result: true,
+		},
 		{
 			name: "valid oidc application: responsetype code",
 			args: args{
@@ -669,3 +818,19 @@ func TestOIDCOriginAllowList(t *testing.T) {
 		})
 	}
 }
+
+func TestOIDCRefreshTokenRotation_DetectReuse(t *testing.T) {
+	tests := []struct {
+		rotation OIDCRefreshTokenRotation
+		want     bool
+	}{
+		{OIDCRefreshTokenRotationUnspecified, false},
+		{OIDCRefreshTokenRotationOnEveryUse, true},
+		{OIDCRefreshTokenRotationNever, false},
+	}
+	for _, tt := range tests {
+		if got := tt.rotation.DetectReuse(); got != tt.want {
+			t.Errorf("DetectReuse() of %d = %v, want %v", tt.rotation, got, tt.want)
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package domain
 
 import (
+	"strings"
+
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 )
 
@@ -14,6 +16,10 @@ type SAMLApp struct {
 	MetadataURL  *string
 	LoginVersion *LoginVersion
 	LoginBaseURI *string
+	// NameIDSource and AttributeMappings define which user information is released to the service provider.
+	// If no mappings are configured, the default attributes are released.
+	NameIDSource      *SAMLNameIDSource
+	AttributeMappings []*SAMLAttributeMapping
 
 	State AppState
 }
@@ -41,5 +47,91 @@ func (a *SAMLApp) IsValid() bool {
 	if (a.MetadataURL == nil || *a.MetadataURL == "") && a.Metadata == nil {
 		return false
 	}
+	return a.AttributeReleaseValid()
+}
+
+// AttributeReleaseValid checks the NameID source and the attribute mappings.
+// Attribute names must be unique, since the service provider identifies the attributes by their name.
+func (a *SAMLApp) AttributeReleaseValid() bool {
+	if a.NameIDSource != nil && !a.NameIDSource.Valid() {
+		return false
+	}
+	names := make(map[string]struct{}, len(a.AttributeMappings))
+	for _, mapping := range a.AttributeMappings {
+		if !mapping.Valid() {
+			return false
+		}
+		if _, ok := names[mapping.Name]; ok {
+			return false
+		}
+		names[mapping.Name] = struct{}{}
+	}
 	return true
 }
+
+// SAMLNameIDSource defines the user information used as NameID of the SAML assertion.
+// The NameID is always released with the emailAddress format,
+// so sources requiring another format (e.g. a pairwise persistent identifier) are not supported.
+type SAMLNameIDSource int32
+
+const (
+	// SAMLNameIDSourceUnspecified uses the preferred login name of the user.
+	SAMLNameIDSourceUnspecified SAMLNameIDSource = iota
+	SAMLNameIDSourceUserID
+	SAMLNameIDSourceEmail
+	SAMLNameIDSourceUsername
+
+	samlNameIDSourceCount
+)
+
+func (s SAMLNameIDSource) Valid() bool {
+	return s >= SAMLNameIDSourceUnspecified && s < samlNameIDSourceCount
+}
+
+// SAMLAttributeSource defines the user information released as SAML attribute.
+type SAMLAttributeSource int32
+
+const (
+	SAMLAttributeSourceUnspecified SAMLAttributeSource = iota
+	SAMLAttributeSourceUserID
+	SAMLAttributeSourceUsername
+	SAMLAttributeSourcePreferredLoginName
+	SAMLAttributeSourceEmail
+	SAMLAttributeSourceFirstName
+	SAMLAttributeSourceLastName
+	SAMLAttributeSourceDisplayName
+	SAMLAttributeSourceNickName
+	SAMLAttributeSourcePhone
+	SAMLAttributeSourcePreferredLanguage
+	SAMLAttributeSourceOrganizationID
+	// SAMLAttributeSourceUserMetadata releases the value of the user metadata with the key of the mapping.
+	SAMLAttributeSourceUserMetadata
+	// SAMLAttributeSourceProjectRoles releases the role keys the user is granted on the project of the application.
+	SAMLAttributeSourceProjectRoles
+
+	samlAttributeSourceCount
+)
+
+func (s SAMLAttributeSource) Valid() bool {
+	return s > SAMLAttributeSourceUnspecified && s < samlAttributeSourceCount
+}
+
+// SAMLAttributeMapping maps user information to a SAML attribute.
+type SAMLAttributeMapping struct {
+	Name         string              `json:"name"`
+	FriendlyName string              `json:"friendlyName,omitempty"`
+	NameFormat   string              `json:"nameFormat,omitempty"`
+	Source       SAMLAttributeSource `json:"source"`
+	// MetadataKey is required for [SAMLAttributeSourceUserMetadata].
+	MetadataKey string `json:"metadataKey,omitempty"`
+}
+
+func (m *SAMLAttributeMapping) Valid() bool {
+	if m == nil || strings.TrimSpace(m.Name) == "" || !m.Source.Valid() {
+		return false
+	}
+	if m.Source == SAMLAttributeSourceUserMetadata {
+		return m.MetadataKey != ""
+	}
+	return m.MetadataKey == ""
+}
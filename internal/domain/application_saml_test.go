@@ -0,0 +1,80 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSAMLApp_AttributeReleaseValid(t *testing.T) {
+	tests := []struct {
+		name string
+		app  *SAMLApp
+		want bool
+	}{
+		{
+			name: "nothing set",
+			app:  &SAMLApp{},
+			want: true,
+		},
+		{
+			name: "invalid name id source",
+			app:  &SAMLApp{NameIDSource: gu.Ptr(samlNameIDSourceCount)},
+			want: false,
+		},
+		{
+			name: "missing attribute name",
+			app: &SAMLApp{AttributeMappings: []*SAMLAttributeMapping{
+				{Name: " ", Source: SAMLAttributeSourceEmail},
+			}},
+			want: false,
+		},
+		{
+			name: "unspecified attribute source",
+			app: &SAMLApp{AttributeMappings: []*SAMLAttributeMapping{
+				{Name: "mail"},
+			}},
+			want: false,
+		},
+		{
+			name: "metadata without key",
+			app: &SAMLApp{AttributeMappings: []*SAMLAttributeMapping{
+				{Name: "department", Source: SAMLAttributeSourceUserMetadata},
+			}},
+			want: false,
+		},
+		{
+			name: "metadata key on other source",
+			app: &SAMLApp{AttributeMappings: []*SAMLAttributeMapping{
+				{Name: "mail", Source: SAMLAttributeSourceEmail, MetadataKey: "mail"},
+			}},
+			want: false,
+		},
+		{
+			name: "duplicate attribute name",
+			app: &SAMLApp{AttributeMappings: []*SAMLAttributeMapping{
+				{Name: "mail", Source: SAMLAttributeSourceEmail},
+				{Name: "mail", Source: SAMLAttributeSourceUsername},
+			}},
+			want: false,
+		},
+		{
+			name: "valid",
+			app: &SAMLApp{
+				NameIDSource: gu.Ptr(SAMLNameIDSourceUserID),
+				AttributeMappings: []*SAMLAttributeMapping{
+					{Name: "mail", Source: SAMLAttributeSourceEmail},
+					{Name: "department", Source: SAMLAttributeSourceUserMetadata, MetadataKey: "department"},
+					{Name: "roles", Source: SAMLAttributeSourceProjectRoles},
+				},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.app.AttributeReleaseValid())
+		})
+	}
+}
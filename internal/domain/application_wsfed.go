@@ -0,0 +1,72 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// WSFedApp is a relying party using the WS-Federation passive requestor profile.
+type WSFedApp struct {
+	models.ObjectRoot
+
+	AppID   string
+	AppName string
+	// Realm (wtrealm) identifies the relying party.
+	// It shares the uniqueness with the entity ids of SAML applications.
+	Realm string
+	// ReplyURLs are the allowed locations (wreply) to return the token to.
+	// The first one is used if the relying party does not send one.
+	ReplyURLs []string
+	TokenType *WSFedTokenType
+
+	State AppState
+}
+
+func (a *WSFedApp) GetApplicationName() string {
+	return a.AppName
+}
+
+func (a *WSFedApp) GetState() AppState {
+	return a.State
+}
+
+func (a *WSFedApp) IsValid() bool {
+	if strings.TrimSpace(a.Realm) == "" || len(a.ReplyURLs) == 0 {
+		return false
+	}
+	if a.TokenType != nil && !a.TokenType.Valid() {
+		return false
+	}
+	for _, replyURL := range a.ReplyURLs {
+		if !isValidWSFedReplyURL(replyURL) {
+			return false
+		}
+	}
+	return true
+}
+
+func isValidWSFedReplyURL(replyURL string) bool {
+	uri, err := url.Parse(replyURL)
+	if err != nil {
+		return false
+	}
+	return (uri.Scheme == "https" || uri.Scheme == "http") && uri.Host != ""
+}
+
+// WSFedTokenType defines the type of the security token issued to the relying party.
+type WSFedTokenType int32
+
+const (
+	// WSFedTokenTypeUnspecified issues SAML 1.1 tokens, which are expected by most relying parties.
+	WSFedTokenTypeUnspecified WSFedTokenType = iota
+	WSFedTokenTypeSAML11
+	WSFedTokenTypeSAML20
+
+	wsFedTokenTypeCount
+)
+
+func (t WSFedTokenType) Valid() bool {
+	return t >= WSFedTokenTypeUnspecified && t < wsFedTokenTypeCount
+}
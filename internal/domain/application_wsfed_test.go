@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWSFedApp_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		app  *WSFedApp
+		want bool
+	}{
+		{
+			name: "missing realm",
+			app:  &WSFedApp{ReplyURLs: []string{"https://rp.example.com/"}},
+			want: false,
+		},
+		{
+			name: "missing reply urls",
+			app:  &WSFedApp{Realm: "urn:rp"},
+			want: false,
+		},
+		{
+			name: "relative reply url",
+			app:  &WSFedApp{Realm: "urn:rp", ReplyURLs: []string{"/signin"}},
+			want: false,
+		},
+		{
+			name: "invalid scheme",
+			app:  &WSFedApp{Realm: "urn:rp", ReplyURLs: []string{"javascript:alert(1)"}},
+			want: false,
+		},
+		{
+			name: "invalid token type",
+			app:  &WSFedApp{Realm: "urn:rp", ReplyURLs: []string{"https://rp.example.com/"}, TokenType: gu.Ptr(wsFedTokenTypeCount)},
+			want: false,
+		},
+		{
+			name: "valid",
+			app: &WSFedApp{
+				Realm:     "urn:rp",
+				ReplyURLs: []string{"https://rp.example.com/", "http://localhost:8080/signin"},
+				TokenType: gu.Ptr(WSFedTokenTypeSAML20),
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.app.IsValid())
+		})
+	}
+}
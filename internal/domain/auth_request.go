@@ -104,6 +104,10 @@ type ExternalUser struct {
 	Phone             PhoneNumber
 	IsPhoneVerified   bool
 	Metadatas         []*Metadata
+	// OrganizationID is the organization the user is created in, as mapped by the IdP mapping rules
+	OrganizationID string
+	// Assignments are the project roles and groups of the user, as mapped by the IdP mapping rules
+	Assignments *IDPAssignments
 }
 
 type Prompt int32
@@ -190,6 +194,8 @@ func NewAuthRequestFromType(requestType AuthRequestType) (*AuthRequest, error) {
 		return &AuthRequest{Request: &AuthRequestSAML{}}, nil
 	case AuthRequestTypeDevice:
 		return &AuthRequest{Request: &AuthRequestDevice{}}, nil
+	case AuthRequestTypeCAS:
+		return &AuthRequest{Request: &AuthRequestCAS{}}, nil
 	}
 	return nil, zerrors.ThrowInvalidArgument(nil, "DOMAIN-ds2kl", "invalid request type")
 }
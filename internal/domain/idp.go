@@ -39,6 +39,7 @@ const (
 	IDPTypeApple
 	IDPTypeSAML
 	IDPTypeZitadel
+	IDPTypeX509
 )
 
 func (t IDPType) GetCSSClass() string {
@@ -60,7 +61,8 @@ func (t IDPType) GetCSSClass() string {
 		IDPTypeJWT,
 		IDPTypeOAuth,
 		IDPTypeLDAP,
-		IDPTypeSAML:
+		IDPTypeSAML,
+		IDPTypeX509:
 		fallthrough
 	default:
 		return ""
@@ -94,7 +96,8 @@ func (t IDPType) DisplayName() string {
 		IDPTypeAzureAD,
 		IDPTypeGitHubEnterprise,
 		IDPTypeGitLabSelfHosted,
-		IDPTypeSAML:
+		IDPTypeSAML,
+		IDPTypeX509:
 		fallthrough
 	default:
 		// we should never get here, so log it
@@ -145,3 +148,22 @@ const (
 	SAMLNameIDFormatPersistent
 	SAMLNameIDFormatTransient
 )
+
+// X509CertificateField is a field of a client certificate, which is mapped to the federated user.
+type X509CertificateField uint8
+
+const (
+	X509CertificateFieldUnspecified X509CertificateField = iota
+	X509CertificateFieldSubject
+	X509CertificateFieldSubjectCommonName
+	X509CertificateFieldSubjectSerialNumber
+	X509CertificateFieldSANEmail
+	X509CertificateFieldSANUPN
+	X509CertificateFieldSANURI
+
+	x509CertificateFieldCount
+)
+
+func (f X509CertificateField) Valid() bool {
+	return f < x509CertificateFieldCount
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"time"
+)
+
+// LDAPSyncConfig configures the scheduled synchronization of the users of an LDAP IdP into an organization.
+type LDAPSyncConfig struct {
+	Enabled bool `json:"enabled,omitempty"`
+	// OrganizationID is the organization the users are created in.
+	// Organization IdPs always sync into their own organization.
+	OrganizationID string `json:"organizationId,omitempty"`
+	// Filter is an additional LDAP filter combined with the user object classes of the IdP.
+	Filter string `json:"filter,omitempty"`
+	// Interval is the time between two runs.
+	Interval time.Duration `json:"interval,omitempty"`
+	// FullSyncInterval is the time after which a full instead of a delta run is done.
+	// Only full runs detect users removed from the directory.
+	FullSyncInterval  time.Duration          `json:"fullSyncInterval,omitempty"`
+	DeltaAttribute    LDAPSyncDeltaAttribute `json:"deltaAttribute,omitempty"`
+	DeactivateMissing bool                   `json:"deactivateMissing,omitempty"`
+}
+
+// LDAPSyncDeltaAttribute is the attribute used to only search entries changed since the last run.
+type LDAPSyncDeltaAttribute uint8
+
+const (
+	LDAPSyncDeltaAttributeNone LDAPSyncDeltaAttribute = iota
+	// LDAPSyncDeltaAttributeModifyTimestamp uses the operational attribute of RFC 4512
+	LDAPSyncDeltaAttributeModifyTimestamp
+	// LDAPSyncDeltaAttributeUSNChanged uses the update sequence number of Active Directory
+	LDAPSyncDeltaAttributeUSNChanged
+
+	ldapSyncDeltaAttributeCount
+)
+
+func (a LDAPSyncDeltaAttribute) Valid() bool {
+	return a < ldapSyncDeltaAttributeCount
+}
+
+// Name returns the LDAP attribute name or an empty string if no delta sync is used.
+func (a LDAPSyncDeltaAttribute) Name() string {
+	switch a {
+	case LDAPSyncDeltaAttributeModifyTimestamp:
+		return "modifyTimestamp"
+	case LDAPSyncDeltaAttributeUSNChanged:
+		return "uSNChanged"
+	case LDAPSyncDeltaAttributeNone, ldapSyncDeltaAttributeCount:
+		fallthrough
+	default:
+		return ""
+	}
+}
+
+// LDAPSyncRun is the result of a single synchronization run.
+type LDAPSyncRun struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	FullSync   bool
+	// Cursor is the highest value of the delta attribute seen during the run.
+	Cursor string
+	Stats  LDAPSyncStats
+	// Error is set if the run was aborted.
+	Error string
+	// DeactivatedUserIDs are the users deactivated by the run, because they were missing in the directory.
+	DeactivatedUserIDs []string
+	// ReleasedUserIDs are the users deactivated by a previous run, which are active again.
+	// They were either reactivated by the run or by an administrator.
+	ReleasedUserIDs []string
+}
+
+type LDAPSyncStats struct {
+	Created     uint32 `json:"created,omitempty"`
+	Updated     uint32 `json:"updated,omitempty"`
+	Unchanged   uint32 `json:"unchanged,omitempty"`
+	Deactivated uint32 `json:"deactivated,omitempty"`
+	Reactivated uint32 `json:"reactivated,omitempty"`
+	Failed      uint32 `json:"failed,omitempty"`
+}
@@ -0,0 +1,97 @@
+package domain
+
+// IDPMappingRule maps the result of an expression over the data received from an external IdP
+// (userinfo, ID token claims, SAML or LDAP attributes) to a field of the user.
+type IDPMappingRule struct {
+	Target IDPMappingTarget `json:"target"`
+	// Key is the metadata key for [IDPMappingTargetMetadata] and the project ID for [IDPMappingTargetProjectRoles].
+	Key        string             `json:"key,omitempty"`
+	Language   IDPMappingLanguage `json:"language"`
+	Expression string             `json:"expression"`
+}
+
+type IDPMappingTarget uint8
+
+const (
+	IDPMappingTargetUnspecified IDPMappingTarget = iota
+	IDPMappingTargetFirstName
+	IDPMappingTargetLastName
+	IDPMappingTargetDisplayName
+	IDPMappingTargetNickName
+	IDPMappingTargetPreferredUsername
+	IDPMappingTargetEmail
+	IDPMappingTargetEmailVerified
+	IDPMappingTargetPhone
+	IDPMappingTargetPhoneVerified
+	IDPMappingTargetPreferredLanguage
+	IDPMappingTargetAvatarURL
+	IDPMappingTargetProfile
+	IDPMappingTargetMetadata
+	IDPMappingTargetOrganization
+	IDPMappingTargetProjectRoles
+	IDPMappingTargetGroups
+
+	idpMappingTargetCount
+)
+
+func (t IDPMappingTarget) Valid() bool {
+	return t > IDPMappingTargetUnspecified && t < idpMappingTargetCount
+}
+
+// RequiresKey returns if the target needs the [IDPMappingRule.Key] to be set.
+func (t IDPMappingTarget) RequiresKey() bool {
+	return t == IDPMappingTargetMetadata || t == IDPMappingTargetProjectRoles
+}
+
+type IDPMappingLanguage uint8
+
+const (
+	IDPMappingLanguageUnspecified IDPMappingLanguage = iota
+	IDPMappingLanguageJSONPath
+	IDPMappingLanguageCEL
+
+	idpMappingLanguageCount
+)
+
+func (l IDPMappingLanguage) Valid() bool {
+	return l > IDPMappingLanguageUnspecified && l < idpMappingLanguageCount
+}
+
+// IDPTypeSupportsMapping returns if mapping rules can be configured for the IdP type.
+func IDPTypeSupportsMapping(idpType IDPType) bool {
+	switch idpType {
+	case IDPTypeOAuth,
+		IDPTypeOIDC,
+		IDPTypeSAML,
+		IDPTypeLDAP,
+		IDPTypeAzureAD:
+		return true
+	case IDPTypeUnspecified,
+		IDPTypeJWT,
+		IDPTypeGitHub,
+		IDPTypeGitHubEnterprise,
+		IDPTypeGitLab,
+		IDPTypeGitLabSelfHosted,
+		IDPTypeGoogle,
+		IDPTypeApple,
+		IDPTypeZitadel,
+		IDPTypeX509:
+		fallthrough
+	default:
+		return false
+	}
+}
+
+// IDPAssignments are the project roles and groups a user is assigned to by the mapping rules of an IdP.
+// They are reconciled on every login, so assignments no longer returned by the rules are removed again.
+type IDPAssignments struct {
+	// ProjectRoles contains the role keys per project ID
+	ProjectRoles map[string][]string `json:"projectRoles,omitempty"`
+	GroupIDs     []string            `json:"groupIds,omitempty"`
+	// FailedProjectIDs contains the projects of the rules which could not be evaluated.
+	// The roles previously assigned on these projects are kept, as the mapped roles are incomplete.
+	FailedProjectIDs []string `json:"failedProjectIds,omitempty"`
+	// GroupsFailed is set if a group rule could not be evaluated.
+	// The previously assigned groups are kept, as the mapped groups are incomplete.
+	GroupsFailed bool `json:"groupsFailed,omitempty"`
+}
@@ -0,0 +1,107 @@
+package domain
+
+import (
+	"strconv"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type RADIUSClientState int32
+
+const (
+	RADIUSClientStateUnspecified RADIUSClientState = iota
+	RADIUSClientStateActive
+	RADIUSClientStateRemoved
+	radiusClientStateCount
+)
+
+func (s RADIUSClientState) Valid() bool {
+	return s >= 0 && s < radiusClientStateCount
+}
+
+func (s RADIUSClientState) Exists() bool {
+	return s != RADIUSClientStateUnspecified && s != RADIUSClientStateRemoved
+}
+
+// RADIUSAttributeFormat defines how the value of a RADIUS attribute is encoded (RFC 2865, section 5).
+type RADIUSAttributeFormat int32
+
+const (
+	RADIUSAttributeFormatString RADIUSAttributeFormat = iota
+	// RADIUSAttributeFormatInteger encodes the value as 32 bit unsigned integer in network byte order
+	RADIUSAttributeFormatInteger
+
+	radiusAttributeFormatCount
+)
+
+func (f RADIUSAttributeFormat) Valid() bool {
+	return f >= 0 && f < radiusAttributeFormatCount
+}
+
+const (
+	// RADIUSAttributeTypeVendorSpecific is the type of the attributes carrying a vendor specific attribute (RFC 2865, section 5.26).
+	RADIUSAttributeTypeVendorSpecific uint8 = 26
+)
+
+// radiusReservedAttributeTypes are set by the server itself and can't be mapped from roles:
+// User-Name, User-Password, CHAP-Password, Reply-Message, State, EAP-Message and Message-Authenticator
+var radiusReservedAttributeTypes = []uint8{1, 2, 3, 18, 24, 79, 80}
+
+// RADIUSRoleAttribute is added to the Access-Accept of a user who is granted the role on the project of the RADIUS client.
+type RADIUSRoleAttribute struct {
+	Role string `json:"role,omitempty"`
+	// Type is the attribute type, vendor specific attributes use type 26.
+	Type uint8 `json:"type,omitempty"`
+	// VendorID is the SMI network management private enterprise code of a vendor specific attribute.
+	VendorID   uint32                `json:"vendorId,omitempty"`
+	VendorType uint8                 `json:"vendorType,omitempty"`
+	Format     RADIUSAttributeFormat `json:"format,omitempty"`
+	// Value is the value of the attribute, the role key is used if it is empty.
+	Value string `json:"value,omitempty"`
+}
+
+// AttributeValue returns the value of the attribute for the role.
+func (a *RADIUSRoleAttribute) AttributeValue() string {
+	if a.Value == "" {
+		return a.Role
+	}
+	return a.Value
+}
+
+func (a *RADIUSRoleAttribute) Validate() error {
+	if a.Role == "" {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Rq2mx", "Errors.RADIUSClient.InvalidAttribute")
+	}
+	if a.Type == 0 {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Gt7vb", "Errors.RADIUSClient.InvalidAttribute")
+	}
+	for _, reserved := range radiusReservedAttributeTypes {
+		if a.Type == reserved {
+			return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Kp4wd", "Errors.RADIUSClient.ReservedAttribute")
+		}
+	}
+	if (a.Type == RADIUSAttributeTypeVendorSpecific) != (a.VendorID != 0) {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Ux8nf", "Errors.RADIUSClient.InvalidAttribute")
+	}
+	if a.VendorID == 0 && a.VendorType != 0 {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Nb3sl", "Errors.RADIUSClient.InvalidAttribute")
+	}
+	if !a.Format.Valid() {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Wd5zh", "Errors.RADIUSClient.InvalidAttribute")
+	}
+	if a.Format == RADIUSAttributeFormatInteger {
+		if _, err := strconv.ParseUint(a.AttributeValue(), 10, 32); err != nil {
+			return zerrors.ThrowInvalidArgument(err, "DOMAIN-Jy6ce", "Errors.RADIUSClient.InvalidAttribute")
+		}
+	}
+	// the value must fit into a single attribute (RFC 2865, section 5),
+	// vendor specific attributes need 6 bytes for the vendor id, type and length
+	maxLength := 253
+	if a.VendorID != 0 {
+		maxLength = 247
+	}
+	if len(a.AttributeValue()) > maxLength {
+		return zerrors.ThrowInvalidArgument(nil, "DOMAIN-Hs1qo", "Errors.RADIUSClient.InvalidAttribute")
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRADIUSRoleAttribute_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute *RADIUSRoleAttribute
+		wantErr   bool
+	}{
+		{
+			name:      "string attribute",
+			attribute: &RADIUSRoleAttribute{Role: "admin", Type: 11},
+		},
+		{
+			name:      "integer attribute",
+			attribute: &RADIUSRoleAttribute{Role: "admin", Type: 64, Format: RADIUSAttributeFormatInteger, Value: "13"},
+		},
+		{
+			name:      "vendor specific attribute",
+			attribute: &RADIUSRoleAttribute{Role: "admin", Type: 26, VendorID: 14823, VendorType: 1, Value: "superuser"},
+		},
+		{
+			name:      "missing role",
+			attribute: &RADIUSRoleAttribute{Type: 11},
+			wantErr:   true,
+		},
+		{
+			name:      "missing type",
+			attribute: &RADIUSRoleAttribute{Role: "admin"},
+			wantErr:   true,
+		},
+		{
+			name:      "reserved type",
+			attribute: &RADIUSRoleAttribute{Role: "admin", Type: 24},
+			wantErr:   true,
+		},
+		{
+			name:      "vendor specific without vendor",
+			attribute: &RADIUSRoleAttribute{Role: "admin", Type: 26},
+			wantErr:   true,
+		},
+		{
+			name:      "vendor without vendor specific type",
+			attribute: &RADIUSRoleAttribute{Role: "admin", Type: 11, VendorID: 14823},
+			wantErr:   true,
+		},
+		{
+			name:      "integer role key",
+			attribute: &RADIUSRoleAttribute{Role: "admin", Type: 64, Format: RADIUSAttributeFormatInteger},
+			wantErr:   true,
+		},
+		{
+			name:      "value too long",
+			attribute: &RADIUSRoleAttribute{Role: "admin", Type: 11, Value: strings.Repeat("a", 254)},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.attribute.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
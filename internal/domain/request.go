@@ -26,6 +26,7 @@ const (
 	AuthRequestTypeOIDC AuthRequestType = iota
 	AuthRequestTypeSAML
 	AuthRequestTypeDevice
+	AuthRequestTypeCAS
 )
 
 type AuthRequestOIDC struct {
@@ -117,3 +118,25 @@ func (a *AuthRequestDevice) IsValid() bool {
 func (a *AuthRequestDevice) GetScopes() []string {
 	return a.Scopes
 }
+
+// AuthRequestCAS is the login (v1) request of a CAS service.
+type AuthRequestCAS struct {
+	// Service is the url the service ticket is returned to.
+	Service string
+	// Renew requires the user to authenticate again, even if a session exists.
+	Renew bool
+	// Gateway must not ask the user for credentials; the user is sent back to the service without a ticket instead.
+	Gateway bool
+}
+
+func (*AuthRequestCAS) Type() AuthRequestType {
+	return AuthRequestTypeCAS
+}
+
+func (a *AuthRequestCAS) IsValid() bool {
+	return a.Service != ""
+}
+
+func (*AuthRequestCAS) GetScopes() []string {
+	return nil
+}
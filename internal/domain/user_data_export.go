@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+)
+
+// UserDataExportState is the state of the export of the personal data of a user (right of access).
+type UserDataExportState int32
+
+const (
+	UserDataExportStateUnspecified UserDataExportState = iota
+	// UserDataExportStateRequested is the state until the archive is generated.
+	UserDataExportStateRequested
+	// UserDataExportStateReady means the archive can be downloaded.
+	UserDataExportStateReady
+	// UserDataExportStateFailed means the archive could not be generated.
+	UserDataExportStateFailed
+	// UserDataExportStateExpired means the archive was ready but can't be downloaded anymore.
+	UserDataExportStateExpired
+)
+
+const (
+	userDataExportsAssetPath = "/data-exports/"
+	// UserDataExportsDownloadPath is the path of the asset API the archives are downloaded from.
+	UserDataExportsDownloadPath = "/user_data_exports"
+	// UserDataExportContentType is the content type of the archive.
+	UserDataExportContentType = "application/json"
+)
+
+// GetUserDataExportAssetPath returns the name of the stored archive of the export.
+func GetUserDataExportAssetPath(userID, exportID string) string {
+	return UsersAssetPath + "/" + userID + userDataExportsAssetPath + exportID + ".json"
+}
+
+// IsUserDataExportAssetPath checks if the asset is the archive of an export,
+// which must only be downloaded with its code.
+func IsUserDataExportAssetPath(path string) bool {
+	return strings.HasPrefix(path, UsersAssetPath+"/") && strings.Contains(path, userDataExportsAssetPath)
+}
+
+// UserDataExportLink returns the link to download the archive of an export,
+// assetAPI is the URL of the asset API including its prefix.
+func UserDataExportLink(assetAPI, userID, exportID, code string) string {
+	return assetAPI + UserDataExportsDownloadPath + "/" + url.PathEscape(userID) + "/" + url.PathEscape(exportID) + "?code=" + code
+}
@@ -0,0 +1,46 @@
+package eventsink
+
+import (
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type Config struct {
+	// Enabled starts the sink, which publishes the events of all instances to the configured publisher.
+	Enabled bool
+	// Name identifies the cursor of the sink.
+	// Changing the name publishes all events again.
+	Name string
+	// SubjectPrefix is prepended to the subject of the messages, which is built from the instance and the event type.
+	SubjectPrefix string
+	// AggregateTypes only publishes events of the aggregate types, all events are published if empty.
+	AggregateTypes []string
+	// EventTypes only publishes events of the event types, all events are published if empty.
+	EventTypes []string
+	// Publisher writes the messages to the message broker.
+	Publisher PublisherConfig
+}
+
+type PublisherType string
+
+const (
+	// PublisherTypeFile appends the messages as JSON lines to a file, it's a local stand-in for a message broker.
+	PublisherTypeFile PublisherType = "file"
+	// PublisherTypeLog logs the messages.
+	PublisherTypeLog PublisherType = "log"
+)
+
+type PublisherConfig struct {
+	Type PublisherType
+	File FilePublisherConfig
+}
+
+func (c *PublisherConfig) publisher() (Publisher, error) {
+	switch c.Type {
+	case PublisherTypeFile:
+		return newFilePublisher(c.File)
+	case PublisherTypeLog:
+		return new(logPublisher), nil
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "SINK-Ok3ve", "unknown publisher type %q", c.Type)
+	}
+}
@@ -0,0 +1,61 @@
+package eventsink
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// Message is the representation of an event published to the message broker.
+type Message struct {
+	// ID is unique per event, consumers use it to deduplicate messages which were delivered more than once.
+	ID string `json:"id"`
+	// Subject is used by the message broker to route the message.
+	Subject string `json:"subject"`
+
+	InstanceID       string          `json:"instanceId"`
+	ResourceOwner    string          `json:"resourceOwner"`
+	AggregateType    string          `json:"aggregateType"`
+	AggregateID      string          `json:"aggregateId"`
+	AggregateVersion string          `json:"aggregateVersion"`
+	EventType        string          `json:"eventType"`
+	Revision         uint16          `json:"revision"`
+	Sequence         uint64          `json:"sequence"`
+	Position         string          `json:"position"`
+	CreatedAt        time.Time       `json:"createdAt"`
+	Creator          string          `json:"creator"`
+	Payload          json.RawMessage `json:"payload,omitempty"`
+}
+
+func messageFromEvent(subjectPrefix string, event eventstore.Event) *Message {
+	aggregate := event.Aggregate()
+	message := &Message{
+		ID:               strings.Join([]string{aggregate.InstanceID, string(aggregate.Type), aggregate.ID, strconv.FormatUint(event.Sequence(), 10)}, ":"),
+		Subject:          subject(subjectPrefix, aggregate.InstanceID, event.Type()),
+		InstanceID:       aggregate.InstanceID,
+		ResourceOwner:    aggregate.ResourceOwner,
+		AggregateType:    string(aggregate.Type),
+		AggregateID:      aggregate.ID,
+		AggregateVersion: string(aggregate.Version),
+		EventType:        string(event.Type()),
+		Revision:         event.Revision(),
+		Sequence:         event.Sequence(),
+		Position:         event.Position().String(),
+		CreatedAt:        event.CreatedAt(),
+		Creator:          event.Creator(),
+	}
+	if payload := event.DataAsBytes(); len(payload) > 0 && json.Valid(payload) {
+		message.Payload = payload
+	}
+	return message
+}
+
+func subject(prefix, instanceID string, eventType eventstore.EventType) string {
+	if prefix == "" {
+		return instanceID + "." + string(eventType)
+	}
+	return prefix + "." + instanceID + "." + string(eventType)
+}
@@ -0,0 +1,66 @@
+package eventsink
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// Publisher writes the messages to the message broker.
+// Publish must only return without error after the broker acknowledged the message,
+// the cursor of the sink is moved afterwards.
+type Publisher interface {
+	Publish(ctx context.Context, message *Message) error
+}
+
+type FilePublisherConfig struct {
+	// Path of the file the messages are appended to.
+	Path string
+}
+
+// filePublisher appends the messages as JSON lines to a file.
+// A message is acknowledged after it was synced to the disk.
+type filePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newFilePublisher(config FilePublisherConfig) (*filePublisher, error) {
+	if config.Path == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "SINK-Ga5wo", "path of the file publisher missing")
+	}
+	file, err := os.OpenFile(config.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "SINK-mE1xr", "unable to open file of the publisher")
+	}
+	return &filePublisher{file: file}, nil
+}
+
+func (p *filePublisher) Publish(_ context.Context, message *Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Pq8tz", "unable to marshal message")
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err = p.file.Write(append(line, '\n')); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Dk2ua", "unable to write message")
+	}
+	if err = p.file.Sync(); err != nil {
+		return zerrors.ThrowInternal(err, "SINK-Yc6nh", "unable to sync messages")
+	}
+	return nil
+}
+
+// logPublisher logs the messages, it's useful for debugging.
+type logPublisher struct{}
+
+func (*logPublisher) Publish(_ context.Context, message *Message) error {
+	logging.WithFields("id", message.ID, "subject", message.Subject, "position", message.Position).Info("event published")
+	return nil
+}
@@ -0,0 +1,96 @@
+package eventsink
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	// sinkNamePrefix is prepended to the name of the sink to build the name of the cursor.
+	// The cursors are stored as current states of the projections.
+	sinkNamePrefix = "event_sinks."
+)
+
+// Start publishes the events of all instances in the order they were written.
+// Events are published at least once, the cursor per instance is moved after the publisher acknowledged the messages.
+// Failed messages are retried until they succeed, events are never skipped.
+func Start(ctx context.Context, config Config, handlerConfig handler.Config) error {
+	if !config.Enabled {
+		return nil
+	}
+	if config.Name == "" {
+		return zerrors.ThrowInvalidArgument(nil, "SINK-Hw3pq", "name of the event sink missing")
+	}
+	publisher, err := config.Publisher.publisher()
+	if err != nil {
+		return err
+	}
+	handler.NewHandler(ctx, &handlerConfig, newSink(config, publisher)).Start(ctx)
+	logging.WithFields("name", config.Name, "publisher", config.Publisher.Type).Info("event sink started")
+	return nil
+}
+
+var _ handler.StreamProjection = (*sink)(nil)
+
+type sink struct {
+	name           string
+	subjectPrefix  string
+	aggregateTypes []eventstore.AggregateType
+	eventTypes     []eventstore.EventType
+	publisher      Publisher
+}
+
+func newSink(config Config, publisher Publisher) *sink {
+	s := &sink{
+		name:           sinkNamePrefix + config.Name,
+		subjectPrefix:  config.SubjectPrefix,
+		aggregateTypes: make([]eventstore.AggregateType, len(config.AggregateTypes)),
+		eventTypes:     make([]eventstore.EventType, len(config.EventTypes)),
+		publisher:      publisher,
+	}
+	for i, aggregateType := range config.AggregateTypes {
+		s.aggregateTypes[i] = eventstore.AggregateType(aggregateType)
+	}
+	for i, eventType := range config.EventTypes {
+		s.eventTypes[i] = eventstore.EventType(eventType)
+	}
+	return s
+}
+
+// Name implements [handler.Projection]
+func (s *sink) Name() string {
+	return s.name
+}
+
+// Reducers implements [handler.Projection]
+// The events are reduced by [sink.Reduce].
+func (*sink) Reducers() []handler.AggregateReducer {
+	return nil
+}
+
+// FilterGlobalEvents implements [handler.GlobalProjection]
+func (*sink) FilterGlobalEvents() {}
+
+// Reduce implements [handler.StreamProjection]
+func (s *sink) Reduce(event eventstore.Event) (*handler.Statement, error) {
+	if !s.publishes(event) {
+		return handler.NewNoOpStatement(event), nil
+	}
+	message := messageFromEvent(s.subjectPrefix, event)
+	return handler.NewStatement(event, func(ctx context.Context, _ handler.Executer, _ string) error {
+		return s.publisher.Publish(ctx, message)
+	}), nil
+}
+
+func (s *sink) publishes(event eventstore.Event) bool {
+	if len(s.aggregateTypes) > 0 && !slices.Contains(s.aggregateTypes, event.Aggregate().Type) {
+		return false
+	}
+	return len(s.eventTypes) == 0 || slices.Contains(s.eventTypes, event.Type())
+}
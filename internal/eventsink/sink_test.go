@@ -0,0 +1,160 @@
+package eventsink
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type publisherFunc func(ctx context.Context, message *Message) error
+
+func (f publisherFunc) Publish(ctx context.Context, message *Message) error {
+	return f(ctx, message)
+}
+
+func testEvent(aggregateType eventstore.AggregateType, eventType eventstore.EventType, data []byte) eventstore.Event {
+	return &eventstore.BaseEvent{
+		EventType: eventType,
+		Agg: &eventstore.Aggregate{
+			ID:            "agg1",
+			Type:          aggregateType,
+			ResourceOwner: "org1",
+			InstanceID:    "instance1",
+			Version:       "v2",
+		},
+		Seq:      3,
+		Pos:      decimal.RequireFromString("1700000000.123"),
+		Creation: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		User:     "user1",
+		Data:     data,
+	}
+}
+
+func Test_messageFromEvent(t *testing.T) {
+	got := messageFromEvent("zitadel.events", testEvent("user", "user.human.added", []byte(`{"userName":"gigi"}`)))
+	assert.Equal(t, &Message{
+		ID:               "instance1:user:agg1:3",
+		Subject:          "zitadel.events.instance1.user.human.added",
+		InstanceID:       "instance1",
+		ResourceOwner:    "org1",
+		AggregateType:    "user",
+		AggregateID:      "agg1",
+		AggregateVersion: "v2",
+		EventType:        "user.human.added",
+		Revision:         2,
+		Sequence:         3,
+		Position:         "1700000000.123",
+		CreatedAt:        time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		Creator:          "user1",
+		Payload:          json.RawMessage(`{"userName":"gigi"}`),
+	}, got)
+}
+
+func Test_sink_Reduce(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      Config
+		event       eventstore.Event
+		wantPublish bool
+	}{
+		{
+			name:        "no filter",
+			event:       testEvent("user", "user.human.added", nil),
+			wantPublish: true,
+		},
+		{
+			name:        "aggregate type matches",
+			config:      Config{AggregateTypes: []string{"org", "user"}},
+			event:       testEvent("user", "user.human.added", nil),
+			wantPublish: true,
+		},
+		{
+			name:   "aggregate type filtered",
+			config: Config{AggregateTypes: []string{"org"}},
+			event:  testEvent("user", "user.human.added", nil),
+		},
+		{
+			name:        "event type matches",
+			config:      Config{EventTypes: []string{"user.human.added"}},
+			event:       testEvent("user", "user.human.added", nil),
+			wantPublish: true,
+		},
+		{
+			name:   "event type filtered",
+			config: Config{AggregateTypes: []string{"user"}, EventTypes: []string{"user.removed"}},
+			event:  testEvent("user", "user.human.added", nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var published []*Message
+			s := newSink(tt.config, publisherFunc(func(_ context.Context, message *Message) error {
+				published = append(published, message)
+				return nil
+			}))
+			stmt, err := s.Reduce(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.event.Sequence(), stmt.Sequence)
+			if !tt.wantPublish {
+				assert.Nil(t, stmt.Execute)
+				return
+			}
+			require.NoError(t, stmt.Execute(context.Background(), nil, s.Name()))
+			require.Len(t, published, 1)
+			assert.Equal(t, "instance1:user:agg1:3", published[0].ID)
+		})
+	}
+}
+
+func Test_sink_Reduce_publishError(t *testing.T) {
+	publishErr := errors.New("broker unavailable")
+	s := newSink(Config{}, publisherFunc(func(context.Context, *Message) error {
+		return publishErr
+	}))
+	stmt, err := s.Reduce(testEvent("user", "user.human.added", nil))
+	require.NoError(t, err)
+	assert.ErrorIs(t, stmt.Execute(context.Background(), nil, s.Name()), publishErr)
+}
+
+func Test_filePublisher_Publish(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	publisher, err := (&PublisherConfig{Type: PublisherTypeFile, File: FilePublisherConfig{Path: path}}).publisher()
+	require.NoError(t, err)
+
+	first := messageFromEvent("", testEvent("user", "user.human.added", nil))
+	second := messageFromEvent("", testEvent("org", "org.added", []byte(`{"name":"zitadel"}`)))
+	require.NoError(t, publisher.Publish(context.Background(), first))
+	require.NoError(t, publisher.Publish(context.Background(), second))
+
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	var got []*Message
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		message := new(Message)
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), message))
+		got = append(got, message)
+	}
+	require.NoError(t, scanner.Err())
+	require.Len(t, got, 2)
+	assert.Equal(t, first.ID, got[0].ID)
+	assert.Equal(t, "instance1.org.added", got[1].Subject)
+	assert.JSONEq(t, `{"name":"zitadel"}`, string(got[1].Payload))
+}
+
+func TestPublisherConfig_unknownType(t *testing.T) {
+	_, err := (&PublisherConfig{Type: "kafka"}).publisher()
+	assert.Error(t, err)
+}
@@ -0,0 +1,287 @@
+package eventstore
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/shopspring/decimal"
+)
+
+type ArchiveConfig struct {
+	// Retention is the minimum age of the latest event of an aggregate before the aggregate is archived
+	Retention time.Duration
+	// BulkSize is the maximum amount of aggregates archived in a single transaction
+	BulkSize uint32
+	// Aggregates defines which aggregates are archived
+	Aggregates []ArchivedAggregateType
+}
+
+// ArchivedAggregateType defines the events after which an aggregate is not changed anymore,
+// for example the removal of a user or the termination of a session.
+// The events of an aggregate are archived as soon as its latest event is of one of the final event types
+// and older than the retention. Only the final event remains in the events table,
+// so write models and projections see the aggregate in its final state
+// and the sequence continues if the aggregate id is reused.
+type ArchivedAggregateType struct {
+	Type            AggregateType
+	FinalEventTypes []EventType
+}
+
+// ArchivedAggregate contains the compressed events of an archived aggregate
+type ArchivedAggregate struct {
+	Aggregate     *Aggregate
+	FirstPosition decimal.Decimal
+	LastPosition  decimal.Decimal
+	// Events are compressed by [CompressArchivedEvents]
+	Events []byte
+}
+
+// ArchivedEvent is the stored representation of an event of an archived aggregate
+type ArchivedEvent struct {
+	Type      EventType       `json:"type"`
+	Revision  uint16          `json:"revision"`
+	Sequence  uint64          `json:"sequence"`
+	Position  decimal.Decimal `json:"position"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Creator   string          `json:"creator"`
+	Payload   json.RawMessage `json:"payload,omitempty"`
+}
+
+type ArchiveStore interface {
+	// FilterArchivedAggregates calls reduce for each archived aggregate which might contain events of the search query.
+	// The aggregates are ordered by their last position if the query is descending, by their first position otherwise.
+	FilterArchivedAggregates(ctx context.Context, searchQuery *SearchQueryBuilder, reduce func(*ArchivedAggregate) error) error
+}
+
+var (
+	// the options are static, so the errors can be ignored
+	archiveEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedBetterCompression))
+	archiveDecoder, _ = zstd.NewReader(nil)
+
+	errArchiveSearched = errors.New("archive searched")
+)
+
+// CompressArchivedEvents marshals and compresses the events of an aggregate
+func CompressArchivedEvents(events []*ArchivedEvent) ([]byte, error) {
+	data, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+	return archiveEncoder.EncodeAll(data, nil), nil
+}
+
+// DecompressArchivedEvents decompresses and unmarshals the events of an aggregate
+func DecompressArchivedEvents(compressed []byte) ([]*ArchivedEvent, error) {
+	data, err := archiveDecoder.DecodeAll(compressed, nil)
+	if err != nil {
+		return nil, err
+	}
+	var events []*ArchivedEvent
+	if err = json.Unmarshal(data, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+func (e *ArchivedEvent) event(aggregate *Aggregate) Event {
+	agg := *aggregate
+	agg.Version = Version("v" + strconv.Itoa(int(e.Revision)))
+	return &BaseEvent{
+		EventType: e.Type,
+		Agg:       &agg,
+		Seq:       e.Sequence,
+		Pos:       e.Position,
+		Creation:  e.CreatedAt,
+		User:      e.Creator,
+		Data:      e.Payload,
+	}
+}
+
+// archiveRequested returns if the query includes archived events and the archive is configured
+func (es *Eventstore) archiveRequested(searchQuery *SearchQueryBuilder) bool {
+	return searchQuery.includeArchived && es.archive != nil
+}
+
+// filterWithArchive merges the events of the archive into the events of the events table.
+// Archived aggregates are searched until no further aggregate can contain events within the offset and limit,
+// which are applied to the merged events.
+func (es *Eventstore) filterWithArchive(ctx context.Context, searchQuery *SearchQueryBuilder, r reducer) error {
+	// archived events can be sorted before the events skipped by the offset,
+	// so the events table returns them as well
+	query := *searchQuery
+	query.offset = 0
+	if query.limit > 0 {
+		query.limit += uint64(searchQuery.offset)
+	}
+
+	events := make([]Event, 0, query.limit)
+	err := es.querier.FilterToReducer(ctx, &query, func(event Event) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	err = es.archive.FilterArchivedAggregates(ctx, &query, func(aggregate *ArchivedAggregate) error {
+		if query.limit > 0 && uint64(len(events)) >= query.limit && !query.archivedAggregateRequired(aggregate, events[len(events)-1]) {
+			return errArchiveSearched
+		}
+		archived, err := DecompressArchivedEvents(aggregate.Events)
+		if err != nil {
+			return err
+		}
+		for _, archivedEvent := range archived {
+			if event := archivedEvent.event(aggregate.Aggregate); query.matchArchivedEvent(event) {
+				events = append(events, event)
+			}
+		}
+		query.sortEvents(events)
+		if query.limit > 0 && uint64(len(events)) > query.limit {
+			events = events[:query.limit]
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errArchiveSearched) {
+		return err
+	}
+	events = events[min(int(searchQuery.offset), len(events)):]
+
+	mapper := es.newEventMapper(ctx, func(event Event) error {
+		r.AppendEvents(event)
+		return r.Reduce()
+	})
+	for _, event := range events {
+		if err = mapper.Map(event); err != nil {
+			return err
+		}
+	}
+	return mapper.Flush()
+}
+
+// archivedAggregateRequired checks if the aggregate can contain events which are sorted before the last event
+func (builder *SearchQueryBuilder) archivedAggregateRequired(aggregate *ArchivedAggregate, last Event) bool {
+	if builder.desc {
+		return aggregate.LastPosition.GreaterThanOrEqual(last.Position())
+	}
+	return aggregate.FirstPosition.LessThanOrEqual(last.Position())
+}
+
+// matchArchivedEvent applies the filters of the query which are not applied by the [ArchiveStore]
+func (builder *SearchQueryBuilder) matchArchivedEvent(event Event) bool {
+	if builder.resourceOwner != "" && event.Aggregate().ResourceOwner != builder.resourceOwner {
+		return false
+	}
+	if builder.instanceID != nil && *builder.instanceID != "" && event.Aggregate().InstanceID != *builder.instanceID {
+		return false
+	}
+	if len(builder.instanceIDs) > 0 && !slices.Contains(builder.instanceIDs, event.Aggregate().InstanceID) {
+		return false
+	}
+	if builder.editorUser != "" && event.Creator() != builder.editorUser {
+		return false
+	}
+	if builder.eventSequenceGreater > 0 {
+		if builder.desc && event.Sequence() >= builder.eventSequenceGreater {
+			return false
+		}
+		if !builder.desc && event.Sequence() <= builder.eventSequenceGreater {
+			return false
+		}
+	}
+	if !builder.creationDateAfter.IsZero() && !event.CreatedAt().After(builder.creationDateAfter) {
+		return false
+	}
+	if !builder.creationDateBefore.IsZero() && !event.CreatedAt().Before(builder.creationDateBefore) {
+		return false
+	}
+	if !builder.positionAtLeast.IsZero() && event.Position().LessThan(builder.positionAtLeast) {
+		return false
+	}
+	if !builder.positionAtMost.IsZero() && event.Position().GreaterThan(builder.positionAtMost) {
+		return false
+	}
+	if len(builder.queries) == 0 {
+		return true
+	}
+	for _, query := range builder.queries {
+		if query.eventData != nil && !matchEventData(event, query.eventData) {
+			continue
+		}
+		if !query.positionAfter.IsZero() && !event.Position().GreaterThan(query.positionAfter) {
+			continue
+		}
+		if query.matches(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchEventData checks if the payload contains the data like the containment operator of the events table
+func matchEventData(event Event, data map[string]any) bool {
+	var payload any
+	if err := json.Unmarshal(event.DataAsBytes(), &payload); err != nil {
+		return false
+	}
+	// the values are compared like unmarshaled JSON
+	marshaled, err := json.Marshal(data)
+	if err != nil {
+		return false
+	}
+	var want any
+	if err = json.Unmarshal(marshaled, &want); err != nil {
+		return false
+	}
+	return jsonContains(payload, want)
+}
+
+func jsonContains(value, want any) bool {
+	switch want := want.(type) {
+	case map[string]any:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return false
+		}
+		for key, wantValue := range want {
+			if objectValue, ok := object[key]; !ok || !jsonContains(objectValue, wantValue) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		array, ok := value.([]any)
+		if !ok {
+			return false
+		}
+		for _, wantValue := range want {
+			if !slices.ContainsFunc(array, func(arrayValue any) bool { return jsonContains(arrayValue, wantValue) }) {
+				return false
+			}
+		}
+		return true
+	default:
+		return value == want
+	}
+}
+
+// sortEvents sorts the events by their position in the order of the query,
+// events of the same aggregate and position are sorted by their sequence.
+func (builder *SearchQueryBuilder) sortEvents(events []Event) {
+	slices.SortStableFunc(events, func(a, b Event) int {
+		c := a.Position().Cmp(b.Position())
+		if c == 0 && a.Aggregate().ID == b.Aggregate().ID {
+			c = cmp.Compare(a.Sequence(), b.Sequence())
+		}
+		if builder.desc {
+			return -c
+		}
+		return c
+	})
+}
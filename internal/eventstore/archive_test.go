@@ -0,0 +1,389 @@
+package eventstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type testArchiveStore struct {
+	aggregates []*ArchivedAggregate
+	reduced    int
+}
+
+func (s *testArchiveStore) FilterArchivedAggregates(_ context.Context, _ *SearchQueryBuilder, reduce func(*ArchivedAggregate) error) error {
+	for _, aggregate := range s.aggregates {
+		s.reduced++
+		if err := reduce(aggregate); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testArchiveReducer struct {
+	events []Event
+}
+
+func (r *testArchiveReducer) AppendEvents(events ...Event) {
+	r.events = append(r.events, events...)
+}
+
+func (r *testArchiveReducer) Reduce() error { return nil }
+
+func testArchiveAggregate() *Aggregate {
+	return &Aggregate{
+		ID:            "id",
+		Type:          "test.archive",
+		ResourceOwner: "ro",
+		InstanceID:    "instance",
+		Version:       "v1",
+	}
+}
+
+func testArchivedEvent(sequence uint64, position int64, eventType EventType) *ArchivedEvent {
+	return &ArchivedEvent{
+		Type:      eventType,
+		Revision:  1,
+		Sequence:  sequence,
+		Position:  decimal.NewFromInt(position),
+		CreatedAt: time.Unix(position, 0).UTC(),
+		Creator:   "creator",
+		Payload:   []byte(`{"key":"value"}`),
+	}
+}
+
+func testArchivedAggregate(t *testing.T, id string, events ...*ArchivedEvent) *ArchivedAggregate {
+	compressed, err := CompressArchivedEvents(events)
+	require.NoError(t, err)
+	aggregate := testArchiveAggregate()
+	aggregate.ID = id
+	return &ArchivedAggregate{
+		Aggregate:     aggregate,
+		FirstPosition: events[0].Position,
+		LastPosition:  events[len(events)-1].Position,
+		Events:        compressed,
+	}
+}
+
+func testLiveEvent(position int64) Event {
+	return &BaseEvent{
+		EventType: "test.archive.changed",
+		Agg:       testArchiveAggregate(),
+		Seq:       uint64(position),
+		Pos:       decimal.NewFromInt(position),
+		Creation:  time.Unix(position, 0).UTC(),
+	}
+}
+
+func TestCompressArchivedEvents(t *testing.T) {
+	events := []*ArchivedEvent{
+		testArchivedEvent(1, 1, "test.archive.added"),
+		testArchivedEvent(2, 2, "test.archive.removed"),
+	}
+	compressed, err := CompressArchivedEvents(events)
+	require.NoError(t, err)
+
+	decompressed, err := DecompressArchivedEvents(compressed)
+	require.NoError(t, err)
+	assert.Len(t, decompressed, 2)
+	for i, event := range decompressed {
+		assert.Equal(t, events[i].Type, event.Type)
+		assert.Equal(t, events[i].Sequence, event.Sequence)
+		assert.True(t, events[i].Position.Equal(event.Position))
+		assert.True(t, events[i].CreatedAt.Equal(event.CreatedAt))
+		assert.JSONEq(t, string(events[i].Payload), string(event.Payload))
+	}
+}
+
+func positions(events []Event) []int64 {
+	result := make([]int64, len(events))
+	for i, event := range events {
+		result[i] = event.Position().IntPart()
+	}
+	return result
+}
+
+func TestEventstore_FilterToReducer_archive(t *testing.T) {
+	tests := []struct {
+		name              string
+		query             *SearchQueryBuilder
+		live              []Event
+		archived          func(t *testing.T) []*ArchivedAggregate
+		wantPositions     []int64
+		wantReducedBefore int
+	}{
+		{
+			name: "desc, merged and limited",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				OrderDesc().
+				Limit(3).
+				IncludeArchived(),
+			live: []Event{testLiveEvent(10), testLiveEvent(9)},
+			archived: func(t *testing.T) []*ArchivedAggregate {
+				return []*ArchivedAggregate{
+					testArchivedAggregate(t, "1", testArchivedEvent(1, 5, "test.archive.added"), testArchivedEvent(2, 6, "test.archive.changed"), testArchivedEvent(3, 8, "test.archive.removed")),
+					testArchivedAggregate(t, "2", testArchivedEvent(1, 1, "test.archive.added"), testArchivedEvent(2, 2, "test.archive.removed")),
+				}
+			},
+			wantPositions: []int64{10, 9, 8},
+			// the second aggregate only contains older events
+			wantReducedBefore: 2,
+		},
+		{
+			name: "asc, event types filtered",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				OrderAsc().
+				IncludeArchived().
+				AddQuery().
+				EventTypes("test.archive.removed", "test.archive.changed").
+				Builder(),
+			live: []Event{testLiveEvent(9), testLiveEvent(10)},
+			archived: func(t *testing.T) []*ArchivedAggregate {
+				return []*ArchivedAggregate{
+					testArchivedAggregate(t, "2", testArchivedEvent(1, 1, "test.archive.added"), testArchivedEvent(2, 2, "test.archive.removed")),
+					testArchivedAggregate(t, "1", testArchivedEvent(1, 5, "test.archive.added"), testArchivedEvent(2, 6, "test.archive.changed"), testArchivedEvent(3, 8, "test.archive.removed")),
+				}
+			},
+			wantPositions:     []int64{2, 6, 8, 9, 10},
+			wantReducedBefore: 2,
+		},
+		{
+			name: "desc, offset applied to merged events",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				OrderDesc().
+				Limit(2).
+				Offset(1).
+				IncludeArchived(),
+			live: []Event{testLiveEvent(10), testLiveEvent(9)},
+			archived: func(t *testing.T) []*ArchivedAggregate {
+				return []*ArchivedAggregate{
+					testArchivedAggregate(t, "1", testArchivedEvent(1, 5, "test.archive.added"), testArchivedEvent(2, 6, "test.archive.changed"), testArchivedEvent(3, 8, "test.archive.removed")),
+					testArchivedAggregate(t, "2", testArchivedEvent(1, 1, "test.archive.added"), testArchivedEvent(2, 2, "test.archive.removed")),
+				}
+			},
+			wantPositions:     []int64{9, 8},
+			wantReducedBefore: 2,
+		},
+		{
+			name: "asc, offset greater than events",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				OrderAsc().
+				Offset(5).
+				IncludeArchived(),
+			live: []Event{testLiveEvent(10)},
+			archived: func(t *testing.T) []*ArchivedAggregate {
+				return []*ArchivedAggregate{
+					testArchivedAggregate(t, "1", testArchivedEvent(1, 1, "test.archive.added")),
+				}
+			},
+			wantPositions:     []int64{},
+			wantReducedBefore: 1,
+		},
+		{
+			name: "payload filtered",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				OrderAsc().
+				IncludeArchived().
+				AddQuery().
+				EventData(map[string]interface{}{"key": "value"}).
+				Builder(),
+			archived: func(t *testing.T) []*ArchivedAggregate {
+				other := testArchivedEvent(2, 2, "test.archive.removed")
+				other.Payload = []byte(`{"key":"other"}`)
+				return []*ArchivedAggregate{
+					testArchivedAggregate(t, "1", testArchivedEvent(1, 1, "test.archive.added"), other),
+				}
+			},
+			wantPositions:     []int64{1},
+			wantReducedBefore: 1,
+		},
+		{
+			name: "other instance",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("other").
+				IncludeArchived(),
+			archived: func(t *testing.T) []*ArchivedAggregate {
+				return []*ArchivedAggregate{
+					testArchivedAggregate(t, "1", testArchivedEvent(1, 1, "test.archive.added")),
+				}
+			},
+			wantPositions:     []int64{},
+			wantReducedBefore: 1,
+		},
+		{
+			name: "archive not included",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance"),
+			live: []Event{testLiveEvent(10)},
+			archived: func(t *testing.T) []*ArchivedAggregate {
+				return []*ArchivedAggregate{
+					testArchivedAggregate(t, "1", testArchivedEvent(1, 1, "test.archive.added")),
+				}
+			},
+			wantPositions: []int64{10},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &testArchiveStore{aggregates: tt.archived(t)}
+			es := NewEventstore(&Config{
+				Querier:      &testQuerier{events: tt.live},
+				ArchiveStore: store,
+			})
+			reducer := new(testArchiveReducer)
+
+			err := es.FilterToReducer(context.Background(), tt.query, reducer)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantPositions, positions(reducer.events))
+			assert.Equal(t, tt.wantReducedBefore, store.reduced)
+		})
+	}
+}
+
+func TestSearchQueryBuilder_matchArchivedEvent(t *testing.T) {
+	event := testArchivedEvent(2, 5, "test.archive.removed").event(testArchiveAggregate())
+	tests := []struct {
+		name  string
+		query *SearchQueryBuilder
+		want  bool
+	}{
+		{
+			name:  "no filter",
+			query: NewSearchQueryBuilder(ColumnsEvent),
+			want:  true,
+		},
+		{
+			name:  "editor",
+			query: NewSearchQueryBuilder(ColumnsEvent).EditorUser("other"),
+			want:  false,
+		},
+		{
+			name:  "resource owner",
+			query: NewSearchQueryBuilder(ColumnsEvent).ResourceOwner("ro"),
+			want:  true,
+		},
+		{
+			name:  "created before",
+			query: NewSearchQueryBuilder(ColumnsEvent).CreationDateBefore(time.Unix(5, 0)),
+			want:  false,
+		},
+		{
+			name:  "sequence greater, desc",
+			query: NewSearchQueryBuilder(ColumnsEvent).OrderDesc().SequenceGreater(3),
+			want:  true,
+		},
+		{
+			name: "aggregate id",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				AddQuery().AggregateTypes("test.archive").AggregateIDs("other").
+				Or().AggregateTypes("test.archive").AggregateIDs("id").
+				Builder(),
+			want: true,
+		},
+		{
+			name: "payload filter",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				AddQuery().EventData(map[string]interface{}{"key": "value"}).
+				Builder(),
+			want: true,
+		},
+		{
+			name: "payload filter, other value",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				AddQuery().EventData(map[string]interface{}{"key": "other"}).
+				Builder(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.query.matchArchivedEvent(event))
+		})
+	}
+}
+
+func Test_jsonContains(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  string
+		match bool
+	}{
+		{
+			name:  "nested object",
+			value: `{"a":{"b":1,"c":"d"},"e":true}`,
+			want:  `{"a":{"b":1}}`,
+			match: true,
+		},
+		{
+			name:  "missing key",
+			value: `{"a":1}`,
+			want:  `{"b":1}`,
+			match: false,
+		},
+		{
+			name:  "array elements",
+			value: `{"a":["x","y","z"]}`,
+			want:  `{"a":["z","x"]}`,
+			match: true,
+		},
+		{
+			name:  "array element missing",
+			value: `{"a":["x"]}`,
+			want:  `{"a":["y"]}`,
+			match: false,
+		},
+		{
+			name:  "other type",
+			value: `{"a":"1"}`,
+			want:  `{"a":1}`,
+			match: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value, want any
+			require.NoError(t, json.Unmarshal([]byte(tt.value), &value))
+			require.NoError(t, json.Unmarshal([]byte(tt.want), &want))
+			assert.Equal(t, tt.match, jsonContains(value, want))
+		})
+	}
+}
+
+func TestEventstore_archiveUnsupported(t *testing.T) {
+	es := NewEventstore(&Config{
+		Querier:      &testQuerier{},
+		ArchiveStore: new(testArchiveStore),
+	})
+	t.Run("excluded aggregates", func(t *testing.T) {
+		query := NewSearchQueryBuilder(ColumnsEvent).
+			InstanceID("instance").
+			IncludeArchived().
+			AddQuery().AggregateTypes("test.archive").Builder().
+			ExcludeAggregateIDs().AggregateTypes("test.archive").EventTypes("test.archive.removed").Builder()
+		err := es.FilterToReducer(context.Background(), query, new(testArchiveReducer))
+		assert.True(t, zerrors.IsUnimplemented(err))
+	})
+	t.Run("filter", func(t *testing.T) {
+		_, err := es.Filter(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance").IncludeArchived().SetTx(new(sql.Tx)))
+		assert.True(t, zerrors.IsUnimplemented(err))
+	})
+	t.Run("latest position", func(t *testing.T) {
+		_, err := es.LatestPosition(context.Background(), NewSearchQueryBuilder(ColumnsEvent).IncludeArchived())
+		assert.True(t, zerrors.IsUnimplemented(err))
+	})
+}
@@ -7,9 +7,27 @@ import (
 type Config struct {
 	PushTimeout time.Duration
 	MaxRetries  uint32
+	// PushNotifications sends a notification to all nodes after events were pushed,
+	// so their projections are triggered immediately instead of after the requeue interval.
+	PushNotifications bool
+	// RecordUserAgents stores the user agent of the request which pushed the events,
+	// so the change history can show which client made a change.
+	RecordUserAgents bool
+	// Snapshots of write models reduce the events which must be reduced on each command
+	Snapshots SnapshotConfig
+	// PersonalData configures the encryption of personal data in events
+	PersonalData PersonalDataConfig
 
-	Pusher   Pusher
-	Querier  Querier
-	Searcher Searcher
-	Queue    ExecutionQueue
+	Pusher        Pusher
+	Querier       Querier
+	Searcher      Searcher
+	Queue         ExecutionQueue
+	SnapshotStore SnapshotStore
+	// ArchiveStore is searched for archived events if the query includes them
+	ArchiveStore ArchiveStore
+	// PersonalDataKeyStore decrypts the personal data of the events,
+	// personal data stay encrypted if not set.
+	PersonalDataKeyStore PersonalDataKeyStore
+	// UserAgentStore provides the recorded user agents of the events
+	UserAgentStore UserAgentStore
 }
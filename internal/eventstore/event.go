@@ -104,7 +104,7 @@ func GenericEventMapper[T any, PT BaseEventSetter[T]](event Event) (Event, error
 	return e, nil
 }
 
-func isEventTypes(command Command, types ...EventType) bool {
+func isEventTypes(command action, types ...EventType) bool {
 	for _, typ := range types {
 		if command.Type() == typ {
 			return true
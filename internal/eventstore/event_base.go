@@ -36,6 +36,8 @@ type BaseEvent struct {
 	//Service which created the event
 	Service string `json:"-"`
 	Data    []byte `json:"-"`
+
+	personalDataRedacted bool
 }
 
 // Position implements Event.
@@ -95,6 +97,10 @@ func (e *BaseEvent) Revision() uint16 {
 	return uint16(revision)
 }
 
+func (e *BaseEvent) isPersonalDataRedacted() bool {
+	return e.personalDataRedacted
+}
+
 // Unmarshal implements Event
 func (e *BaseEvent) Unmarshal(ptr any) error {
 	if len(e.Data) == 0 {
@@ -116,6 +122,8 @@ func BaseEventFromRepo(event Event) *BaseEvent {
 		User:      event.Creator(),
 		Data:      event.DataAsBytes(),
 		Pos:       event.Position(),
+
+		personalDataRedacted: IsPersonalDataRedacted(event),
 	}
 }
 
@@ -35,6 +35,18 @@ type Eventstore struct {
 	querier  Querier
 	searcher Searcher
 
+	snapshots      SnapshotStore
+	snapshotConfig SnapshotConfig
+	// snapshotQueue is nil if snapshots are disabled
+	snapshotQueue chan *Snapshot
+
+	archive ArchiveStore
+
+	// personalData is nil if no key store is configured
+	personalData *personalDataKeys
+
+	userAgents UserAgentStore
+
 	logger *slog.Logger
 }
 
@@ -64,20 +76,42 @@ func RegisterFilterEventMapper(aggregateType AggregateType, eventType EventType,
 	eventTypeMapping[eventType] = aggregateType
 }
 
+// EventMappers returns the mappers registered for the event types of the aggregate type
+func EventMappers(aggregateType AggregateType) map[EventType]func(Event) (Event, error) {
+	mappers := make(map[EventType]func(Event) (Event, error))
+	for eventType, interceptors := range eventInterceptors {
+		if eventTypeMapping[eventType] == aggregateType && interceptors.eventMapper != nil {
+			mappers[eventType] = interceptors.eventMapper
+		}
+	}
+	return mappers
+}
+
 type eventTypeInterceptors struct {
 	eventMapper func(Event) (Event, error)
 }
 
 func NewEventstore(config *Config) *Eventstore {
-	return &Eventstore{
+	es := &Eventstore{
 		PushTimeout: config.PushTimeout,
 		maxRetries:  int(config.MaxRetries),
 
-		pusher:   config.Pusher,
-		querier:  config.Querier,
-		searcher: config.Searcher,
-		logger:   logging.New(logging.StreamEventPusher),
+		pusher:     config.Pusher,
+		querier:    config.Querier,
+		searcher:   config.Searcher,
+		archive:    config.ArchiveStore,
+		userAgents: config.UserAgentStore,
+		logger:     logging.New(logging.StreamEventPusher),
 	}
+	if config.Snapshots.Enabled && config.SnapshotStore != nil {
+		es.snapshots = config.SnapshotStore
+		es.snapshotConfig = config.Snapshots
+		es.snapshotQueue = make(chan *Snapshot, config.Snapshots.QueueSize)
+	}
+	if config.PersonalDataKeyStore != nil {
+		es.personalData = newPersonalDataKeys(config.PersonalDataKeyStore, config.PersonalData)
+	}
+	return es
 }
 
 // Health checks if the eventstore can properly work
@@ -155,6 +189,7 @@ retry:
 		return nil, err
 	}
 
+	es.evictPersonalDataKeys(events)
 	mappedEvents, err := es.mapEvents(events)
 	if err != nil {
 		return mappedEvents, err
@@ -194,20 +229,24 @@ func (es *Eventstore) Search(ctx context.Context, conditions ...map[FieldType]an
 //
 // Deprecated: Use [FilterToQueryReducer] instead to avoid allocations.
 func (es *Eventstore) Filter(ctx context.Context, searchQuery *SearchQueryBuilder) ([]Event, error) {
-	events := make([]Event, 0, searchQuery.GetLimit())
-	searchQuery.ensureInstanceID(ctx)
-	err := es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
-		event, err := es.mapEvent(event)
-		if err != nil {
-			return err
-		}
-		events = append(events, event)
-		return nil
-	})
-	if err != nil {
+	events := &eventCollector{events: make([]Event, 0, searchQuery.GetLimit())}
+	if err := es.FilterToReducer(ctx, searchQuery, events); err != nil {
 		return nil, err
 	}
-	return events, nil
+	return events.events, nil
+}
+
+// eventCollector is the reducer of [Eventstore.Filter]
+type eventCollector struct {
+	events []Event
+}
+
+func (c *eventCollector) AppendEvents(events ...Event) {
+	c.events = append(c.events, events...)
+}
+
+func (c *eventCollector) Reduce() error {
+	return nil
 }
 
 func (es *Eventstore) mapEvents(events []Event) (mappedEvents []Event, err error) {
@@ -221,10 +260,6 @@ func (es *Eventstore) mapEvents(events []Event) (mappedEvents []Event, err error
 	return mappedEvents, nil
 }
 
-func (es *Eventstore) mapEvent(event Event) (Event, error) {
-	return es.mapEventLocked(event)
-}
-
 func (es *Eventstore) mapEventLocked(event Event) (Event, error) {
 	interceptors, ok := eventInterceptors[event.Type()]
 	if !ok || interceptors.eventMapper == nil {
@@ -250,21 +285,31 @@ type reducer interface {
 }
 
 // FilterToReducer filters the events based on the search query, appends all events to the reducer and calls it's reduce function
+// Archived events are included if requested by the search query.
 func (es *Eventstore) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, r reducer) error {
 	searchQuery.ensureInstanceID(ctx)
-	return es.querier.FilterToReducer(ctx, searchQuery, func(event Event) error {
-		event, err := es.mapEvent(event)
-		if err != nil {
-			return err
+	if es.archiveRequested(searchQuery) {
+		if searchQuery.excludeAggregateIDs != nil || searchQuery.tx != nil {
+			return zerrors.ThrowUnimplemented(nil, "V2-Ar4xq", "archived events can't be searched with excluded aggregates or within a transaction")
 		}
+		return es.filterWithArchive(ctx, searchQuery, r)
+	}
+	mapper := es.newEventMapper(ctx, func(event Event) error {
 		r.AppendEvents(event)
 		return r.Reduce()
 	})
+	if err := es.querier.FilterToReducer(ctx, searchQuery, mapper.Map); err != nil {
+		return err
+	}
+	return mapper.Flush()
 }
 
 // LatestPosition filters the latest position for the given search query
 func (es *Eventstore) LatestPosition(ctx context.Context, queryFactory *SearchQueryBuilder) (decimal.Decimal, error) {
 	queryFactory.InstanceID(authz.GetInstance(ctx).InstanceID())
+	if es.archiveRequested(queryFactory) {
+		return decimal.Decimal{}, zerrors.ThrowUnimplemented(nil, "V2-Lp8wd", "the latest position of archived events can't be searched")
+	}
 
 	return es.querier.LatestPosition(ctx, queryFactory)
 }
@@ -272,6 +317,9 @@ func (es *Eventstore) LatestPosition(ctx context.Context, queryFactory *SearchQu
 // InstanceIDs returns the distinct instance ids found by the search query
 // Warning: this function can have high impact on performance, only use this function during setup
 func (es *Eventstore) InstanceIDs(ctx context.Context, queryFactory *SearchQueryBuilder) ([]string, error) {
+	if es.archiveRequested(queryFactory) {
+		return nil, zerrors.ThrowUnimplemented(nil, "V2-In5vq", "instances of archived events can't be searched")
+	}
 	return es.querier.InstanceIDs(ctx, queryFactory)
 }
 
@@ -287,7 +335,11 @@ type QueryReducer interface {
 
 // FilterToQueryReducer filters the events based on the search query of the query function,
 // appends all events to the reducer and calls it's reduce function
+// Write models implementing [Snapshotter] are restored from their latest snapshot if snapshots are enabled.
 func (es *Eventstore) FilterToQueryReducer(ctx context.Context, r QueryReducer) error {
+	if s, ok := r.(Snapshotter); ok && es.snapshotQueue != nil {
+		return es.filterToSnapshotter(ctx, r.Query(), s)
+	}
 	return es.FilterToReducer(ctx, r.Query(), r)
 }
 
@@ -69,7 +69,7 @@ func (h *Handler) handleFailedStmt(ctx context.Context, tx *sql.Tx, f *failure)
 	err = h.setFailureCount(tx, failureCount, f)
 	logging.OnError(ctx, err).Warn("unable to update failure count")
 
-	return failureCount >= h.maxFailureCount
+	return !h.isStream && failureCount >= h.maxFailureCount
 }
 
 func (h *Handler) failureCount(tx *sql.Tx, f *failure) (count uint8, err error) {
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"log/slog"
+	"maps"
 	"math/rand"
 	"slices"
 	"sync"
@@ -64,6 +65,7 @@ type Handler struct {
 	txDuration       time.Duration
 	now              nowFunc
 	queryGlobal      bool
+	isStream         bool
 
 	triggeredInstancesSync sync.Map
 
@@ -155,6 +157,13 @@ type GlobalProjection interface {
 	FilterGlobalEvents()
 }
 
+// StreamProjection reduces all events of an instance in the order they were written.
+// Failed statements are retried until they succeed, the events are never skipped.
+type StreamProjection interface {
+	GlobalProjection
+	Reduce(event eventstore.Event) (*Statement, error)
+}
+
 func NewHandler(
 	ctx context.Context,
 	config *Config,
@@ -201,6 +210,13 @@ func NewHandler(
 	if _, ok := projection.(GlobalProjection); ok {
 		handler.queryGlobal = true
 	}
+	if _, ok := projection.(StreamProjection); ok {
+		handler.isStream = true
+		// streams must process the events of all instances, not only of the active ones
+		handler.queryInstances = func() ([]string, error) {
+			return handler.existingInstances(ctx)
+		}
+	}
 
 	return handler
 }
@@ -311,11 +327,30 @@ func (h *Handler) subscribe(ctx context.Context) {
 	defer logging.Info(ctx, "handler has shutdown")
 	queue := make(chan eventstore.Event, 100)
 	subscription := eventstore.SubscribeEventTypes(queue, h.eventTypes)
+	pushQueue := make(chan *eventstore.PushNotification, 100)
+	unsubscribePush := subscribePushNotifications(pushQueue, slices.Collect(maps.Keys(h.eventTypes)))
 	for {
 		select {
 		case <-ctx.Done():
 			subscription.Unsubscribe()
+			unsubscribePush()
 			return
+		case notification := <-pushQueue:
+			notifications := checkAdditionalPushNotifications(pushQueue, notification)
+			queueCtx := call.WithTimestamp(ctx)
+			queueCtx = logging.With(queueCtx, "job_id", xid.New(), "invoker", "push_notification")
+			solvedInstances := make([]string, 0, len(notifications))
+			for _, n := range notifications {
+				if slices.Contains(solvedInstances, n.InstanceID) {
+					continue
+				}
+				instanceCtx := authz.WithInstanceID(queueCtx, n.InstanceID)
+				if _, err := h.Trigger(instanceCtx); err != nil {
+					logging.Warn(instanceCtx, "trigger of push notification failed", "err", err)
+					continue
+				}
+				solvedInstances = append(solvedInstances, n.InstanceID)
+			}
 		case event := <-queue:
 			events := checkAdditionalEvents(queue, event)
 			solvedInstances := make([]string, 0, len(events))
@@ -350,6 +385,19 @@ func checkAdditionalEvents(eventQueue chan eventstore.Event, event eventstore.Ev
 	}
 }
 
+func checkAdditionalPushNotifications(queue chan *eventstore.PushNotification, notification *eventstore.PushNotification) []*eventstore.PushNotification {
+	notifications := []*eventstore.PushNotification{notification}
+	for {
+		wait := time.NewTimer(1 * time.Millisecond)
+		select {
+		case notification := <-queue:
+			notifications = append(notifications, notification)
+		case <-wait.C:
+			return notifications
+		}
+	}
+}
+
 type existingInstances []string
 
 // AppendEvents implements eventstore.QueryReducer.
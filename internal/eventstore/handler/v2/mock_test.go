@@ -1,5 +1,7 @@
 package handler
 
+import "github.com/zitadel/zitadel/internal/eventstore"
+
 var _ Projection = (*projection)(nil)
 
 type projection struct {
@@ -21,3 +23,18 @@ func (p *projection) Name() string {
 func (p *projection) Reducers() []AggregateReducer {
 	return p.reducers
 }
+
+var _ StreamProjection = (*streamProjection)(nil)
+
+type streamProjection struct {
+	projection
+	reduce func(event eventstore.Event) (*Statement, error)
+}
+
+// FilterGlobalEvents implements [GlobalProjection]
+func (p *streamProjection) FilterGlobalEvents() {}
+
+// Reduce implements [StreamProjection]
+func (p *streamProjection) Reduce(event eventstore.Event) (*Statement, error) {
+	return p.reduce(event)
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/zitadel/zitadel/backend/v3/instrumentation/logging"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// pushNotificationRetryAfter is the time waited before the listener reconnects,
+// the handlers are still triggered by their schedule in the meantime.
+const pushNotificationRetryAfter = 5 * time.Second
+
+var (
+	pushSubscriptions   = map[eventstore.AggregateType][]chan<- *eventstore.PushNotification{}
+	pushSubscriptionsMu sync.RWMutex
+	pushListenerStart   sync.Once
+)
+
+// StartPushNotificationListener listens for the notifications of events pushed by other nodes
+// and triggers the handlers of the aggregate types on the instance of the events.
+// The listener is started once per process and uses a dedicated connection of the pool.
+func StartPushNotificationListener(ctx context.Context, client *database.DB) {
+	pushListenerStart.Do(func() {
+		ctx = logging.NewCtx(ctx, logging.StreamEventHandler, slog.String("listener", eventstore.PushNotificationChannel))
+		go listenPushNotifications(ctx, client)
+	})
+}
+
+func listenPushNotifications(ctx context.Context, client *database.DB) {
+	for {
+		err := waitForPushNotifications(ctx, client)
+		if ctx.Err() != nil {
+			return
+		}
+		logging.Warn(ctx, "push notification listener failed, handlers are triggered by schedule until reconnected", "err", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pushNotificationRetryAfter):
+		}
+	}
+}
+
+func waitForPushNotifications(ctx context.Context, client *database.DB) error {
+	poolConn, err := client.Pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// the connection stays in listening state, so it must not be returned to the pool
+	conn := poolConn.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{eventstore.PushNotificationChannel}.Sanitize()); err != nil {
+		return err
+	}
+	logging.Info(ctx, "listening for push notifications")
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+		dispatchPushNotification(ctx, []byte(notification.Payload))
+	}
+}
+
+func dispatchPushNotification(ctx context.Context, payload []byte) {
+	notification := new(eventstore.PushNotification)
+	if err := json.Unmarshal(payload, notification); err != nil {
+		logging.Debug(ctx, "invalid push notification", "err", err)
+		return
+	}
+	// local subscriptions are notified by the eventstore directly
+	if notification.IsLocal() {
+		return
+	}
+	pushSubscriptionsMu.RLock()
+	defer pushSubscriptionsMu.RUnlock()
+	notified := make(map[chan<- *eventstore.PushNotification]struct{})
+	for _, aggregateType := range notification.AggregateTypes {
+		for _, queue := range pushSubscriptions[aggregateType] {
+			if _, ok := notified[queue]; ok {
+				continue
+			}
+			notified[queue] = struct{}{}
+			select {
+			case queue <- notification:
+			default:
+				logging.Debug(ctx, "unable to queue push notification", "instance", notification.InstanceID)
+			}
+		}
+	}
+}
+
+// subscribePushNotifications queues the notifications for the aggregate types until unsubscribe is called.
+func subscribePushNotifications(queue chan<- *eventstore.PushNotification, aggregateTypes []eventstore.AggregateType) (unsubscribe func()) {
+	pushSubscriptionsMu.Lock()
+	defer pushSubscriptionsMu.Unlock()
+	for _, aggregateType := range aggregateTypes {
+		pushSubscriptions[aggregateType] = append(pushSubscriptions[aggregateType], queue)
+	}
+	return func() {
+		pushSubscriptionsMu.Lock()
+		defer pushSubscriptionsMu.Unlock()
+		for _, aggregateType := range aggregateTypes {
+			subs := pushSubscriptions[aggregateType]
+			for i := len(subs) - 1; i >= 0; i-- {
+				if subs[i] == queue {
+					subs = append(subs[:i], subs[i+1:]...)
+				}
+			}
+			pushSubscriptions[aggregateType] = subs
+		}
+	}
+}
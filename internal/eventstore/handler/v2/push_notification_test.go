@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_dispatchPushNotification(t *testing.T) {
+	users := make(chan *eventstore.PushNotification, 10)
+	usersAndOrgs := make(chan *eventstore.PushNotification, 10)
+	unsubscribeUsers := subscribePushNotifications(users, []eventstore.AggregateType{"user"})
+	defer unsubscribeUsers()
+	unsubscribeUsersAndOrgs := subscribePushNotifications(usersAndOrgs, []eventstore.AggregateType{"user", "org"})
+	defer unsubscribeUsersAndOrgs()
+
+	remote := &eventstore.PushNotification{
+		Node:           "other",
+		InstanceID:     "instance1",
+		AggregateTypes: []eventstore.AggregateType{"user", "org"},
+		Position:       decimal.NewFromInt(42),
+	}
+	payload, err := json.Marshal(remote)
+	require.NoError(t, err)
+	dispatchPushNotification(context.Background(), payload)
+
+	require.Len(t, users, 1)
+	assert.Equal(t, remote.InstanceID, (<-users).InstanceID)
+	// the queue is only notified once, even if it's subscribed for multiple aggregate types of the notification
+	require.Len(t, usersAndOrgs, 1)
+	assert.True(t, remote.Position.Equal((<-usersAndOrgs).Position))
+
+	t.Run("local notifications are skipped", func(t *testing.T) {
+		local := eventstore.NewPushNotifications([]eventstore.Event{
+			&eventstore.BaseEvent{Agg: &eventstore.Aggregate{Type: "user", InstanceID: "instance1"}},
+		})
+		payload, err := json.Marshal(local[0])
+		require.NoError(t, err)
+		dispatchPushNotification(context.Background(), payload)
+		assert.Empty(t, users)
+		assert.Empty(t, usersAndOrgs)
+	})
+	t.Run("other aggregate types are skipped", func(t *testing.T) {
+		payload, err := json.Marshal(&eventstore.PushNotification{Node: "other", InstanceID: "instance1", AggregateTypes: []eventstore.AggregateType{"project"}})
+		require.NoError(t, err)
+		dispatchPushNotification(context.Background(), payload)
+		assert.Empty(t, users)
+		assert.Empty(t, usersAndOrgs)
+	})
+	t.Run("invalid payload", func(t *testing.T) {
+		dispatchPushNotification(context.Background(), []byte("invalid"))
+		assert.Empty(t, users)
+	})
+	t.Run("unsubscribed", func(t *testing.T) {
+		unsubscribeUsers()
+		dispatchPushNotification(context.Background(), payload)
+		assert.Empty(t, users)
+		require.Len(t, usersAndOrgs, 1)
+	})
+}
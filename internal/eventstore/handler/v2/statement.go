@@ -67,6 +67,9 @@ func (h *Handler) eventsToStatements(ctx context.Context, tx *sql.Tx, events []e
 }
 
 func (h *Handler) reduce(event eventstore.Event) (*Statement, error) {
+	if stream, ok := h.projection.(StreamProjection); ok {
+		return stream.Reduce(event)
+	}
 	for _, reducer := range h.projection.Reducers() {
 		if reducer.Aggregate != event.Aggregate().Type {
 			continue
@@ -1733,3 +1733,47 @@ func TestParameterOpts(t *testing.T) {
 // 		})
 // 	}
 // }
+
+func TestHandler_reduceStream(t *testing.T) {
+	var reduced []eventstore.Event
+	h := &Handler{
+		projection: &streamProjection{
+			projection: projection{
+				name: "stream",
+				reducers: []AggregateReducer{
+					{
+						Aggregate: "agg",
+						EventReducers: []EventReducer{
+							{
+								Event: "agg.added",
+								Reduce: func(eventstore.Event) (*Statement, error) {
+									return nil, errTest
+								},
+							},
+						},
+					},
+				},
+			},
+			reduce: func(event eventstore.Event) (*Statement, error) {
+				reduced = append(reduced, event)
+				return NewNoOpStatement(event), nil
+			},
+		},
+	}
+	events := []eventstore.Event{
+		&testEvent{aggregateType: "agg", sequence: 1, BaseEvent: eventstore.BaseEvent{EventType: "agg.added"}},
+		&testEvent{aggregateType: "other", sequence: 2, BaseEvent: eventstore.BaseEvent{EventType: "other.added"}},
+	}
+	for _, event := range events {
+		stmt, err := h.reduce(event)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stmt.Sequence != event.Sequence() {
+			t.Errorf("wrong statement sequence: want %d, got %d", event.Sequence(), stmt.Sequence)
+		}
+	}
+	if !reflect.DeepEqual(events, reduced) {
+		t.Errorf("all events must be reduced by the stream, got %v", reduced)
+	}
+}
@@ -0,0 +1,423 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/golang-lru/v2/expirable"
+
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type PersonalDataConfig struct {
+	// Encrypt encrypts the personal data of newly pushed events with the key of the user,
+	// events encrypted before are decrypted even if disabled.
+	Encrypt bool
+	// KeyCacheTTL is the duration a key is cached after it was queried,
+	// a removed user can be rendered by other nodes until the cached key expires.
+	KeyCacheTTL time.Duration
+	// KeyCacheSize is the maximum amount of cached keys,
+	// the least recently used keys are removed if the cache is full.
+	KeyCacheSize uint32
+}
+
+// PersonalDataKey encrypts the personal data in the events of a subject.
+// The id of the key is stored with the encrypted values, so events encrypted with a destroyed key stay redacted
+// even if a new key is created for a reused subject id.
+type PersonalDataKey struct {
+	ID        string
+	SubjectID string
+	Key       []byte
+}
+
+// PersonalDataKeyStore provides the keys which encrypt the personal data of the events
+type PersonalDataKeyStore interface {
+	// PersonalDataKeys returns the keys by their id,
+	// destroyed keys are missing in the result, the personal data encrypted with them are redacted.
+	PersonalDataKeys(ctx context.Context, instanceID string, keyIDs ...string) (map[string]*PersonalDataKey, error)
+}
+
+const (
+	// personalDataPrefix marks encrypted values in the payload,
+	// values without the prefix were pushed before the encryption was enabled.
+	personalDataPrefix = "zitadel.pii.v1:"
+	// PersonalDataRedacted replaces the personal data whose key was destroyed
+	PersonalDataRedacted = "redacted"
+	// PersonalDataKeyLength is the length of the AES-256 key of a subject
+	PersonalDataKeyLength = 32
+)
+
+var (
+	personalDataFields  = map[EventType][]string{}
+	personalDataErasers = map[EventType]struct{}{}
+)
+
+// RegisterPersonalData marks the fields of the event payload as personal data of the aggregate,
+// the values of the fields must be strings.
+// The fields are encrypted with the key of the aggregate if the encryption is enabled.
+func RegisterPersonalData(eventType EventType, fields ...string) {
+	personalDataFields[eventType] = append(personalDataFields[eventType], fields...)
+}
+
+// RegisterPersonalDataErasure destroys the key of the aggregate as soon as an event of the type is pushed,
+// all personal data of the aggregate are redacted afterwards.
+func RegisterPersonalDataErasure(eventType EventType) {
+	personalDataErasers[eventType] = struct{}{}
+}
+
+// PersonalDataFields returns the fields of the event type which contain personal data
+func PersonalDataFields(eventType EventType) []string {
+	return personalDataFields[eventType]
+}
+
+// ErasesPersonalData checks if the key of the aggregate is destroyed by the event type
+func ErasesPersonalData(eventType EventType) bool {
+	_, ok := personalDataErasers[eventType]
+	return ok
+}
+
+// NewPersonalDataKey generates a random key for a subject
+func NewPersonalDataKey(subjectID string) (*PersonalDataKey, error) {
+	id := make([]byte, 16)
+	key := make([]byte, PersonalDataKeyLength)
+	if _, err := rand.Read(id); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Kq2xs", "Errors.Internal")
+	}
+	if _, err := rand.Read(key); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Hm6dz", "Errors.Internal")
+	}
+	return &PersonalDataKey{
+		ID:        base64.RawURLEncoding.EncodeToString(id),
+		SubjectID: subjectID,
+		Key:       key,
+	}, nil
+}
+
+// EncryptPersonalData encrypts the values of the fields in the payload using the key,
+// the encrypted values are prefixed with the id of the key.
+func EncryptPersonalData(key *PersonalDataKey, payload []byte, fields []string) ([]byte, error) {
+	gcm, err := personalDataCipher(key.Key)
+	if err != nil {
+		return nil, err
+	}
+	return replacePersonalData(payload, fields, func(value json.RawMessage) (json.RawMessage, error) {
+		if isEncryptedPersonalData(value) {
+			return value, nil
+		}
+		nonce := make([]byte, gcm.NonceSize())
+		if _, err := rand.Read(nonce); err != nil {
+			return nil, err
+		}
+		encrypted := gcm.Seal(nonce, nonce, value, nil)
+		return json.Marshal(personalDataPrefix + key.ID + ":" + base64.RawStdEncoding.EncodeToString(encrypted))
+	})
+}
+
+// DecryptPersonalData decrypts the values of the fields in the payload using the keys returned by the id of the key.
+// The values are redacted if no key is returned or they can't be decrypted with the returned key.
+func DecryptPersonalData(keyByID func(id string) []byte, payload []byte, fields []string) (_ []byte, redacted bool, err error) {
+	payload, err = replacePersonalData(payload, fields, func(value json.RawMessage) (json.RawMessage, error) {
+		if !isEncryptedPersonalData(value) {
+			return value, nil
+		}
+		if keyID, encrypted, ok := parsePersonalData(value); ok {
+			if decrypted, err := openPersonalData(keyByID(keyID), encrypted); err == nil {
+				return decrypted, nil
+			}
+		}
+		redacted = true
+		return json.Marshal(PersonalDataRedacted)
+	})
+	if err != nil {
+		return nil, false, err
+	}
+	return payload, redacted, nil
+}
+
+// PersonalDataKeyIDs returns the distinct ids of the keys the values of the fields in the payload are encrypted with
+func PersonalDataKeyIDs(payload []byte, fields []string) []string {
+	var ids []string
+	// the callback never fails and the unchanged payload is discarded
+	_, _ = replacePersonalData(payload, fields, func(value json.RawMessage) (json.RawMessage, error) {
+		if keyID, _, ok := parsePersonalData(value); ok && !slices.Contains(ids, keyID) {
+			ids = append(ids, keyID)
+		}
+		return value, nil
+	})
+	return ids
+}
+
+func personalDataCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Bt7ma", "Errors.Internal")
+	}
+	return cipher.NewGCM(block)
+}
+
+func openPersonalData(key, encrypted []byte) ([]byte, error) {
+	if key == nil {
+		return nil, zerrors.ThrowNotFound(nil, "EVENT-Wq8rt", "Errors.Internal")
+	}
+	gcm, err := personalDataCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(encrypted) < gcm.NonceSize() {
+		return nil, zerrors.ThrowInternal(nil, "EVENT-Nc4vd", "Errors.Internal")
+	}
+	return gcm.Open(nil, encrypted[:gcm.NonceSize()], encrypted[gcm.NonceSize():], nil)
+}
+
+func isEncryptedPersonalData(value json.RawMessage) bool {
+	return strings.HasPrefix(string(value), `"`+personalDataPrefix)
+}
+
+// parsePersonalData splits an encrypted value into the id of the key and the encrypted data
+func parsePersonalData(value json.RawMessage) (keyID string, encrypted []byte, ok bool) {
+	if !isEncryptedPersonalData(value) {
+		return "", nil, false
+	}
+	var encoded string
+	if err := json.Unmarshal(value, &encoded); err != nil {
+		return "", nil, false
+	}
+	keyID, data, ok := strings.Cut(strings.TrimPrefix(encoded, personalDataPrefix), ":")
+	if !ok || keyID == "" {
+		return "", nil, false
+	}
+	encrypted, err := base64.RawStdEncoding.DecodeString(data)
+	if err != nil {
+		return "", nil, false
+	}
+	return keyID, encrypted, true
+}
+
+// replacePersonalData replaces the values of the fields in the json object
+func replacePersonalData(payload []byte, fields []string, replace func(json.RawMessage) (json.RawMessage, error)) ([]byte, error) {
+	if len(payload) == 0 || len(fields) == 0 {
+		return payload, nil
+	}
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &object); err != nil {
+		return nil, zerrors.ThrowInternal(err, "EVENT-Ux8wq", "Errors.Internal")
+	}
+	var replaced bool
+	for _, field := range fields {
+		value, ok := object[field]
+		if !ok || string(value) == "null" {
+			continue
+		}
+		newValue, err := replace(value)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "EVENT-Rj5ol", "Errors.Internal")
+		}
+		object[field] = newValue
+		replaced = true
+	}
+	if !replaced {
+		return payload, nil
+	}
+	return json.Marshal(object)
+}
+
+// personalDataEvent is an event whose personal data are decrypted
+type personalDataEvent struct {
+	Event
+	payload  []byte
+	redacted bool
+}
+
+func (e *personalDataEvent) DataAsBytes() []byte {
+	return e.payload
+}
+
+func (e *personalDataEvent) Unmarshal(ptr any) error {
+	if len(e.payload) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(e.payload, ptr); err != nil {
+		return zerrors.ThrowInternal(err, "EVENT-Wp3cv", "Errors.Internal")
+	}
+	return nil
+}
+
+func (e *personalDataEvent) isPersonalDataRedacted() bool {
+	return e.redacted
+}
+
+// IsPersonalDataRedacted checks if personal data of the event were redacted because its key was destroyed,
+// e.g. to skip the event or to render the value as [PersonalDataRedacted].
+func IsPersonalDataRedacted(event Event) bool {
+	redacted, ok := event.(interface{ isPersonalDataRedacted() bool })
+	return ok && redacted.isPersonalDataRedacted()
+}
+
+func hasEncryptedPersonalData(event Event) bool {
+	return len(personalDataFields[event.Type()]) > 0 && strings.Contains(string(event.DataAsBytes()), personalDataPrefix)
+}
+
+// decryptPersonalData returns the event with decrypted personal data using the prefetched keys
+func decryptPersonalData(event Event, keys map[personalDataKeyID]*PersonalDataKey) (Event, error) {
+	if !hasEncryptedPersonalData(event) {
+		return event, nil
+	}
+	payload, redacted, err := DecryptPersonalData(func(id string) []byte {
+		key := keys[personalDataKeyID{instanceID: event.Aggregate().InstanceID, keyID: id}]
+		// a key of another subject must not decrypt the event
+		if key == nil || key.SubjectID != event.Aggregate().ID {
+			return nil
+		}
+		return key.Key
+	}, event.DataAsBytes(), personalDataFields[event.Type()])
+	if err != nil {
+		return nil, err
+	}
+	return &personalDataEvent{Event: event, payload: payload, redacted: redacted}, nil
+}
+
+// evictPersonalDataKeys removes the keys of the subjects whose personal data were erased by the events
+func (es *Eventstore) evictPersonalDataKeys(events []Event) {
+	if es.personalData == nil {
+		return
+	}
+	for _, event := range events {
+		if ErasesPersonalData(event.Type()) {
+			es.personalData.evict(event.Aggregate().InstanceID, event.Aggregate().ID)
+		}
+	}
+}
+
+type personalDataKeyID struct {
+	instanceID string
+	keyID      string
+}
+
+// personalDataKeys caches the keys of the [PersonalDataKeyStore],
+// destroyed keys are cached as nil.
+type personalDataKeys struct {
+	store PersonalDataKeyStore
+	cache *expirable.LRU[personalDataKeyID, *PersonalDataKey]
+}
+
+func newPersonalDataKeys(store PersonalDataKeyStore, config PersonalDataConfig) *personalDataKeys {
+	return &personalDataKeys{
+		store: store,
+		cache: expirable.NewLRU[personalDataKeyID, *PersonalDataKey](int(config.KeyCacheSize), nil, config.KeyCacheTTL),
+	}
+}
+
+// keys returns the keys of the encrypted personal data in the events,
+// the keys missing in the cache are queried once per instance.
+func (k *personalDataKeys) keys(ctx context.Context, events []Event) (map[personalDataKeyID]*PersonalDataKey, error) {
+	keys := make(map[personalDataKeyID]*PersonalDataKey)
+	missing := make(map[string][]string)
+	for _, event := range events {
+		if !hasEncryptedPersonalData(event) {
+			continue
+		}
+		for _, keyID := range PersonalDataKeyIDs(event.DataAsBytes(), personalDataFields[event.Type()]) {
+			id := personalDataKeyID{instanceID: event.Aggregate().InstanceID, keyID: keyID}
+			if _, ok := keys[id]; ok {
+				continue
+			}
+			key, ok := k.cache.Get(id)
+			keys[id] = key
+			if !ok {
+				missing[id.instanceID] = append(missing[id.instanceID], keyID)
+			}
+		}
+	}
+	for instanceID, keyIDs := range missing {
+		found, err := k.store.PersonalDataKeys(ctx, instanceID, keyIDs...)
+		if err != nil {
+			return nil, err
+		}
+		for _, keyID := range keyIDs {
+			id := personalDataKeyID{instanceID: instanceID, keyID: keyID}
+			keys[id] = found[keyID]
+			k.cache.Add(id, found[keyID])
+		}
+	}
+	return keys, nil
+}
+
+// evict removes the keys of the subject from the cache,
+// all cached keys are checked because the erasing event doesn't know the ids of the keys.
+func (k *personalDataKeys) evict(instanceID, subjectID string) {
+	for _, id := range k.cache.Keys() {
+		if id.instanceID != instanceID {
+			continue
+		}
+		if key, ok := k.cache.Peek(id); ok && key != nil && key.SubjectID == subjectID {
+			k.cache.Remove(id)
+		}
+	}
+}
+
+// personalDataBatchSize is the maximum amount of events whose keys are queried at once
+const personalDataBatchSize = 100
+
+// eventMapper decrypts and maps the events before they are reduced.
+// Events with encrypted personal data are buffered so the keys of a batch are queried at once,
+// the order of the reduced events is preserved.
+type eventMapper struct {
+	ctx     context.Context
+	es      *Eventstore
+	reduce  func(Event) error
+	pending []Event
+}
+
+func (es *Eventstore) newEventMapper(ctx context.Context, reduce func(Event) error) *eventMapper {
+	return &eventMapper{ctx: ctx, es: es, reduce: reduce}
+}
+
+// Map maps and reduces the event or buffers it until its key is queried
+func (m *eventMapper) Map(event Event) error {
+	if m.es.personalData == nil || (len(m.pending) == 0 && !hasEncryptedPersonalData(event)) {
+		return m.mapAndReduce(event, nil)
+	}
+	m.pending = append(m.pending, event)
+	if len(m.pending) < personalDataBatchSize {
+		return nil
+	}
+	return m.Flush()
+}
+
+// Flush maps and reduces the buffered events, it must be called after the last event was passed to [eventMapper.Map]
+func (m *eventMapper) Flush() error {
+	if len(m.pending) == 0 {
+		return nil
+	}
+	keys, err := m.es.personalData.keys(m.ctx, m.pending)
+	if err != nil {
+		return err
+	}
+	for _, event := range m.pending {
+		if err = m.mapAndReduce(event, keys); err != nil {
+			return err
+		}
+	}
+	m.pending = m.pending[:0]
+	return nil
+}
+
+func (m *eventMapper) mapAndReduce(event Event, keys map[personalDataKeyID]*PersonalDataKey) error {
+	var err error
+	if m.es.personalData != nil {
+		if event, err = decryptPersonalData(event, keys); err != nil {
+			return err
+		}
+	}
+	if event, err = m.es.mapEventLocked(event); err != nil {
+		return err
+	}
+	return m.reduce(event)
+}
@@ -0,0 +1,280 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testPersonalDataType EventType = "test.personal.added"
+
+func init() {
+	RegisterPersonalData(testPersonalDataType, "email", "name")
+}
+
+type testPersonalDataKeyStore struct {
+	keys    map[string]*PersonalDataKey
+	queried int
+}
+
+func (s *testPersonalDataKeyStore) PersonalDataKeys(_ context.Context, _ string, keyIDs ...string) (map[string]*PersonalDataKey, error) {
+	s.queried++
+	keys := make(map[string]*PersonalDataKey, len(keyIDs))
+	for _, id := range keyIDs {
+		if key, ok := s.keys[id]; ok {
+			keys[id] = key
+		}
+	}
+	return keys, nil
+}
+
+func (s *testPersonalDataKeyStore) add(keys ...*PersonalDataKey) {
+	for _, key := range keys {
+		s.keys[key.ID] = key
+	}
+}
+
+func testPersonalDataKey(t *testing.T, subjectID string) *PersonalDataKey {
+	key, err := NewPersonalDataKey(subjectID)
+	require.NoError(t, err)
+	return key
+}
+
+func testPersonalDataEvent(t *testing.T, key *PersonalDataKey, subjectID string) Event {
+	payload := []byte(`{"email":"user@example.com","name":"User","other":"value"}`)
+	if key != nil {
+		var err error
+		payload, err = EncryptPersonalData(key, payload, PersonalDataFields(testPersonalDataType))
+		require.NoError(t, err)
+	}
+	return &BaseEvent{
+		EventType: testPersonalDataType,
+		Agg: &Aggregate{
+			ID:         subjectID,
+			Type:       "test.personal",
+			InstanceID: "instance",
+			Version:    "v1",
+		},
+		Data: payload,
+	}
+}
+
+func testPersonalDataEventstore(store *testPersonalDataKeyStore, events ...Event) *Eventstore {
+	return NewEventstore(&Config{
+		Querier:              &testQuerier{events: events},
+		PersonalDataKeyStore: store,
+		PersonalData: PersonalDataConfig{
+			KeyCacheTTL:  time.Minute,
+			KeyCacheSize: 10,
+		},
+	})
+}
+
+type testPersonalData struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	Other string `json:"other"`
+}
+
+var (
+	testPersonalDataPlain    = testPersonalData{Email: "user@example.com", Name: "User", Other: "value"}
+	testPersonalDataRedacted = testPersonalData{Email: PersonalDataRedacted, Name: PersonalDataRedacted, Other: "value"}
+)
+
+func assertPersonalData(t *testing.T, want []testPersonalData, events []Event) {
+	t.Helper()
+	require.Len(t, events, len(want))
+	for i, event := range events {
+		got := new(testPersonalData)
+		require.NoError(t, event.Unmarshal(got))
+		assert.Equal(t, want[i], *got, "event %d", i)
+		assert.Equal(t, want[i] == testPersonalDataRedacted, IsPersonalDataRedacted(event), "event %d", i)
+	}
+}
+
+func TestPersonalData(t *testing.T) {
+	key := testPersonalDataKey(t, "subject")
+	otherKey := testPersonalDataKey(t, "subject")
+	payload := []byte(`{"email":"user@example.com","name":"User","other":"value","empty":null}`)
+	fields := []string{"email", "name", "empty"}
+	keyByID := func(keys ...*PersonalDataKey) func(string) []byte {
+		return func(id string) []byte {
+			for _, key := range keys {
+				if key.ID == id {
+					return key.Key
+				}
+			}
+			return nil
+		}
+	}
+
+	encrypted, err := EncryptPersonalData(key, payload, fields)
+	require.NoError(t, err)
+	assert.NotContains(t, string(encrypted), "user@example.com")
+	assert.NotContains(t, string(encrypted), `"User"`)
+	assert.Contains(t, string(encrypted), `"other":"value"`)
+	assert.Equal(t, []string{key.ID}, PersonalDataKeyIDs(encrypted, fields))
+
+	t.Run("encrypted twice", func(t *testing.T) {
+		twice, err := EncryptPersonalData(otherKey, encrypted, fields)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(encrypted), string(twice))
+	})
+	t.Run("decrypted", func(t *testing.T) {
+		decrypted, redacted, err := DecryptPersonalData(keyByID(key), encrypted, fields)
+		require.NoError(t, err)
+		assert.False(t, redacted)
+		assert.JSONEq(t, string(payload), string(decrypted))
+	})
+	t.Run("redacted", func(t *testing.T) {
+		decrypted, redacted, err := DecryptPersonalData(keyByID(), encrypted, fields)
+		require.NoError(t, err)
+		assert.True(t, redacted)
+		assert.JSONEq(t, `{"email":"redacted","name":"redacted","other":"value","empty":null}`, string(decrypted))
+	})
+	t.Run("plain values", func(t *testing.T) {
+		decrypted, redacted, err := DecryptPersonalData(keyByID(), payload, fields)
+		require.NoError(t, err)
+		assert.False(t, redacted)
+		assert.JSONEq(t, string(payload), string(decrypted))
+		assert.Empty(t, PersonalDataKeyIDs(payload, fields))
+	})
+	t.Run("wrong key", func(t *testing.T) {
+		wrongKey := &PersonalDataKey{ID: key.ID, SubjectID: key.SubjectID, Key: otherKey.Key}
+		decrypted, redacted, err := DecryptPersonalData(keyByID(wrongKey), encrypted, fields)
+		require.NoError(t, err)
+		assert.True(t, redacted)
+		assert.JSONEq(t, `{"email":"redacted","name":"redacted","other":"value","empty":null}`, string(decrypted))
+	})
+	t.Run("malformed value", func(t *testing.T) {
+		malformed := []byte(`{"email":"zitadel.pii.v1:no-key-id","name":"User"}`)
+		decrypted, redacted, err := DecryptPersonalData(keyByID(key), malformed, fields)
+		require.NoError(t, err)
+		assert.True(t, redacted)
+		assert.JSONEq(t, `{"email":"redacted","name":"User"}`, string(decrypted))
+	})
+}
+
+func TestEventstore_FilterToReducer_personalData(t *testing.T) {
+	key := testPersonalDataKey(t, "existing")
+	removedKey := testPersonalDataKey(t, "removed")
+	store := &testPersonalDataKeyStore{keys: map[string]*PersonalDataKey{}}
+	store.add(key)
+	es := testPersonalDataEventstore(store,
+		testPersonalDataEvent(t, key, "existing"),
+		testPersonalDataEvent(t, removedKey, "removed"),
+		testPersonalDataEvent(t, nil, "plain"),
+		testPersonalDataEvent(t, key, "existing"),
+	)
+	reducer := new(testArchiveReducer)
+
+	err := es.FilterToReducer(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"), reducer)
+	require.NoError(t, err)
+	assertPersonalData(t, []testPersonalData{
+		testPersonalDataPlain,
+		testPersonalDataRedacted,
+		testPersonalDataPlain,
+		testPersonalDataPlain,
+	}, reducer.events)
+	// the keys of all events are queried at once
+	assert.Equal(t, 1, store.queried)
+
+	reducer = new(testArchiveReducer)
+	err = es.FilterToReducer(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"), reducer)
+	require.NoError(t, err)
+	require.Len(t, reducer.events, 4)
+	// existing and destroyed keys are cached
+	assert.Equal(t, 1, store.queried)
+}
+
+func TestEventstore_FilterToReducer_personalDataRecreatedSubject(t *testing.T) {
+	removedKey := testPersonalDataKey(t, "subject")
+	recreatedKey := testPersonalDataKey(t, "subject")
+	// the key of the removed subject was destroyed, the subject id was reused afterwards
+	store := &testPersonalDataKeyStore{keys: map[string]*PersonalDataKey{}}
+	store.add(recreatedKey)
+	es := testPersonalDataEventstore(store,
+		testPersonalDataEvent(t, removedKey, "subject"),
+		testPersonalDataEvent(t, recreatedKey, "subject"),
+	)
+	reducer := new(testArchiveReducer)
+
+	err := es.FilterToReducer(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"), reducer)
+	require.NoError(t, err)
+	assertPersonalData(t, []testPersonalData{
+		testPersonalDataRedacted,
+		testPersonalDataPlain,
+	}, reducer.events)
+}
+
+func TestEventstore_FilterToReducer_personalDataOtherSubject(t *testing.T) {
+	key := testPersonalDataKey(t, "other")
+	store := &testPersonalDataKeyStore{keys: map[string]*PersonalDataKey{}}
+	store.add(key)
+	es := testPersonalDataEventstore(store, testPersonalDataEvent(t, key, "subject"))
+	reducer := new(testArchiveReducer)
+
+	err := es.FilterToReducer(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"), reducer)
+	require.NoError(t, err)
+	assertPersonalData(t, []testPersonalData{testPersonalDataRedacted}, reducer.events)
+}
+
+func TestEventstore_FilterToReducer_personalDataBatches(t *testing.T) {
+	store := &testPersonalDataKeyStore{keys: map[string]*PersonalDataKey{}}
+	events := make([]Event, 0, personalDataBatchSize+1)
+	want := make([]testPersonalData, 0, personalDataBatchSize+1)
+	for i := range personalDataBatchSize + 1 {
+		key := testPersonalDataKey(t, fmt.Sprint(i))
+		store.add(key)
+		events = append(events, testPersonalDataEvent(t, key, key.SubjectID))
+		want = append(want, testPersonalDataPlain)
+	}
+	es := testPersonalDataEventstore(store, events...)
+	reducer := new(testArchiveReducer)
+
+	err := es.FilterToReducer(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"), reducer)
+	require.NoError(t, err)
+	assertPersonalData(t, want, reducer.events)
+	for i, event := range reducer.events {
+		assert.Equal(t, fmt.Sprint(i), event.Aggregate().ID)
+	}
+	// a full batch and the remaining event
+	assert.Equal(t, 2, store.queried)
+}
+
+func TestEventstore_evictPersonalDataKeys(t *testing.T) {
+	key := testPersonalDataKey(t, "removed")
+	otherKey := testPersonalDataKey(t, "other")
+	store := &testPersonalDataKeyStore{keys: map[string]*PersonalDataKey{}}
+	store.add(key, otherKey)
+	es := testPersonalDataEventstore(store,
+		testPersonalDataEvent(t, key, "removed"),
+		testPersonalDataEvent(t, otherKey, "other"),
+	)
+	RegisterPersonalDataErasure("test.personal.removed")
+	t.Cleanup(func() { delete(personalDataErasers, "test.personal.removed") })
+
+	err := es.FilterToReducer(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"), new(testArchiveReducer))
+	require.NoError(t, err)
+	delete(store.keys, key.ID)
+	es.evictPersonalDataKeys([]Event{&BaseEvent{
+		EventType: "test.personal.removed",
+		Agg:       &Aggregate{ID: "removed", InstanceID: "instance"},
+	}})
+
+	reducer := new(testArchiveReducer)
+	err = es.FilterToReducer(context.Background(), NewSearchQueryBuilder(ColumnsEvent).InstanceID("instance"), reducer)
+	require.NoError(t, err)
+	assertPersonalData(t, []testPersonalData{
+		testPersonalDataRedacted,
+		testPersonalDataPlain,
+	}, reducer.events)
+	// only the evicted key is queried again
+	assert.Equal(t, 2, store.queried)
+	_, ok := es.personalData.cache.Peek(personalDataKeyID{instanceID: "instance", keyID: otherKey.ID})
+	assert.True(t, ok)
+}
@@ -0,0 +1,55 @@
+package eventstore
+
+import (
+	"slices"
+
+	"github.com/rs/xid"
+	"github.com/shopspring/decimal"
+)
+
+// PushNotificationChannel is the Postgres channel on which a [PushNotification] is sent after events were pushed.
+// It allows nodes to react on events pushed by other nodes without polling.
+const PushNotificationChannel = "eventstore_pushed"
+
+// pushNotificationNode identifies the notifications sent by this process.
+var pushNotificationNode = xid.New().String()
+
+// PushNotification is the payload sent on [PushNotificationChannel],
+// it only contains the information needed to decide which handlers to trigger.
+type PushNotification struct {
+	Node           string          `json:"node"`
+	InstanceID     string          `json:"instanceID"`
+	AggregateTypes []AggregateType `json:"aggregateTypes"`
+	// Position is the highest position of the pushed events
+	Position decimal.Decimal `json:"position"`
+}
+
+// NewPushNotifications returns a notification per instance of the events.
+func NewPushNotifications(events []Event) []*PushNotification {
+	notifications := make([]*PushNotification, 0, 1)
+	for _, event := range events {
+		instanceID := event.Aggregate().InstanceID
+		i := slices.IndexFunc(notifications, func(n *PushNotification) bool { return n.InstanceID == instanceID })
+		if i < 0 {
+			notifications = append(notifications, &PushNotification{
+				Node:       pushNotificationNode,
+				InstanceID: instanceID,
+			})
+			i = len(notifications) - 1
+		}
+		notification := notifications[i]
+		if !slices.Contains(notification.AggregateTypes, event.Aggregate().Type) {
+			notification.AggregateTypes = append(notification.AggregateTypes, event.Aggregate().Type)
+		}
+		if event.Position().GreaterThan(notification.Position) {
+			notification.Position = event.Position()
+		}
+	}
+	return notifications
+}
+
+// IsLocal returns true if the events were pushed by this process,
+// the subscriptions of this process were already notified.
+func (n *PushNotification) IsLocal() bool {
+	return n.Node == pushNotificationNode
+}
@@ -0,0 +1,77 @@
+package eventstore
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPushNotifications(t *testing.T) {
+	newEvent := func(instanceID string, aggregateType AggregateType, position int64) Event {
+		return &BaseEvent{
+			Agg: &Aggregate{
+				ID:         "id",
+				Type:       aggregateType,
+				InstanceID: instanceID,
+			},
+			Pos: decimal.NewFromInt(position),
+		}
+	}
+	tests := []struct {
+		name   string
+		events []Event
+		want   []*PushNotification
+	}{
+		{
+			name: "no events",
+			want: []*PushNotification{},
+		},
+		{
+			name: "aggregate types of instance",
+			events: []Event{
+				newEvent("instance1", "user", 3),
+				newEvent("instance1", "org", 5),
+				newEvent("instance1", "user", 4),
+			},
+			want: []*PushNotification{
+				{
+					Node:           pushNotificationNode,
+					InstanceID:     "instance1",
+					AggregateTypes: []AggregateType{"user", "org"},
+					Position:       decimal.NewFromInt(5),
+				},
+			},
+		},
+		{
+			name: "multiple instances",
+			events: []Event{
+				newEvent("instance1", "user", 3),
+				newEvent("", "system", 4),
+			},
+			want: []*PushNotification{
+				{
+					Node:           pushNotificationNode,
+					InstanceID:     "instance1",
+					AggregateTypes: []AggregateType{"user"},
+					Position:       decimal.NewFromInt(3),
+				},
+				{
+					Node:           pushNotificationNode,
+					InstanceID:     "",
+					AggregateTypes: []AggregateType{"system"},
+					Position:       decimal.NewFromInt(4),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPushNotifications(tt.events)
+			assert.Equal(t, tt.want, got)
+			for _, notification := range got {
+				assert.True(t, notification.IsLocal())
+			}
+		})
+	}
+}
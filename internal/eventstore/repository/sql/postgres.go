@@ -211,6 +211,8 @@ func (db *Postgres) operation(operation repository.Operation) string {
 		return ">="
 	case repository.OperationLess:
 		return "<"
+	case repository.OperationLessOrEquals:
+		return "<="
 	case repository.OperationJSONContains:
 		return "@>"
 	case repository.OperationNotIn:
@@ -265,6 +265,7 @@ func prepareConditions(criteria querier, query *repository.SearchQuery, useV1 bo
 
 	additionalClauses, additionalArgs := prepareQuery(criteria, useV1,
 		query.Position,
+		query.PositionAtMost,
 		query.Owner,
 		query.Sequence,
 		query.CreatedAfter,
@@ -281,7 +282,7 @@ func prepareConditions(criteria querier, query *repository.SearchQuery, useV1 bo
 
 	excludeAggregateIDs := query.ExcludeAggregateIDs
 	if len(excludeAggregateIDs) > 0 {
-		excludeAggregateIDs = append(excludeAggregateIDs, query.InstanceID, query.InstanceIDs, query.Position, query.CreatedAfter, query.CreatedBefore)
+		excludeAggregateIDs = append(excludeAggregateIDs, query.InstanceID, query.InstanceIDs, query.Position, query.PositionAtMost, query.CreatedAfter, query.CreatedBefore)
 	}
 	excludeAggregateIDsClauses, excludeAggregateIDsArgs := prepareQuery(criteria, useV1, excludeAggregateIDs...)
 	if excludeAggregateIDsClauses != "" {
@@ -912,6 +912,29 @@ func Test_query_events_mocked(t *testing.T) {
 				wantErr: false,
 			},
 		},
+		{
+			name: "aggregate id, position at most, v2",
+			args: args{
+				dest: &[]*repository.Event{},
+				query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+					InstanceID("instanceID").
+					PositionAtMost(decimal.NewFromFloat(123.456)).
+					AddQuery().
+					AggregateTypes("user").
+					AggregateIDs("userID").
+					Builder(),
+				useV1: false,
+			},
+			fields: fields{
+				mock: newMockClient(t).expectQuery(
+					regexp.QuoteMeta(`SELECT created_at, event_type, "sequence", "position", payload, creator, "owner", instance_id, aggregate_type, aggregate_id, revision FROM eventstore.events2 WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = $3 AND "position" <= $4 ORDER BY "position", in_tx_order`),
+					[]driver.Value{"instanceID", eventstore.AggregateType("user"), "userID", decimal.NewFromFloat(123.456)},
+				),
+			},
+			res: res{
+				wantErr: false,
+			},
+		},
 		{
 			name: "aggregate / event type, created after and exclusion, v2",
 			args: args{
@@ -26,10 +26,12 @@ type SearchQueryBuilder struct {
 	excludeAggregateIDs   *ExclusionQuery
 	tx                    *sql.Tx
 	positionAtLeast       decimal.Decimal
+	positionAtMost        decimal.Decimal
 	awaitOpenTransactions bool
 	creationDateAfter     time.Time
 	creationDateBefore    time.Time
 	eventSequenceGreater  uint64
+	includeArchived       bool
 }
 
 func (b *SearchQueryBuilder) GetColumns() Columns {
@@ -80,6 +82,10 @@ func (b SearchQueryBuilder) GetPositionAtLeast() decimal.Decimal {
 	return b.positionAtLeast
 }
 
+func (b SearchQueryBuilder) GetPositionAtMost() decimal.Decimal {
+	return b.positionAtMost
+}
+
 func (b SearchQueryBuilder) GetAwaitOpenTransactions() bool {
 	return b.awaitOpenTransactions
 }
@@ -286,12 +292,25 @@ func (builder *SearchQueryBuilder) PositionAtLeast(position decimal.Decimal) *Se
 	return builder
 }
 
+// PositionAtMost filters for events which happened at or before the specified position
+func (builder *SearchQueryBuilder) PositionAtMost(position decimal.Decimal) *SearchQueryBuilder {
+	builder.positionAtMost = position
+	return builder
+}
+
 // AwaitOpenTransactions filters for events which are older than the oldest transaction of the database
 func (builder *SearchQueryBuilder) AwaitOpenTransactions() *SearchQueryBuilder {
 	builder.awaitOpenTransactions = true
 	return builder
 }
 
+// IncludeArchived also searches the events moved to the archive if it is configured.
+// Queries which exclude aggregates or use a transaction can't search the archive and return an error.
+func (builder *SearchQueryBuilder) IncludeArchived() *SearchQueryBuilder {
+	builder.includeArchived = true
+	return builder
+}
+
 // SequenceGreater filters for events with sequence greater the requested sequence
 func (builder *SearchQueryBuilder) SequenceGreater(sequence uint64) *SearchQueryBuilder {
 	builder.eventSequenceGreater = sequence
@@ -378,7 +397,7 @@ func (query *SearchQuery) Builder() *SearchQueryBuilder {
 	return query.builder
 }
 
-func (query *SearchQuery) matches(command Command) bool {
+func (query *SearchQuery) matches(command action) bool {
 	if ok := isAggregateTypes(command.Aggregate(), query.aggregateTypes...); len(query.aggregateTypes) > 0 && !ok {
 		return false
 	}
@@ -0,0 +1,295 @@
+package eventstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/zitadel/zitadel/backend/v3/instrumentation/logging"
+)
+
+type SnapshotConfig struct {
+	// Enabled restores write models from snapshots and writes new snapshots
+	Enabled bool
+	// MinEvents is the amount of events of the aggregate
+	// after the latest snapshot before a new snapshot is written.
+	// Events not reduced by the write model are counted as well.
+	MinEvents uint32
+	// WriteInterval is the interval in which the queued snapshots are written
+	WriteInterval time.Duration
+	// QueueSize is the maximum amount of snapshots waiting to be written,
+	// further snapshots are dropped until the queue is written
+	QueueSize uint32
+}
+
+// Snapshotter is a write model which can be restored from a snapshot,
+// so only the events after the snapshot are reduced.
+// The state is stored as JSON of the write model,
+// all fields set by the reducer must therefore be marshaled and unmarshaled.
+// Snapshots are only used if the query filters the events of a single aggregate.
+// Snapshots are stored unencrypted, write models containing secrets, hashes or keys must not implement it.
+//
+// Snapshots are versioned by the fields of the state, the filtered event types and the SnapshotVersion,
+// snapshots of other versions are ignored and overwritten.
+type Snapshotter interface {
+	QueryReducer
+	// SnapshotType identifies the write model
+	SnapshotType() string
+	// SnapshotVersion must be increased as soon as the reducer changes the state differently.
+	// Changes of the fields or the filtered event types are detected automatically.
+	SnapshotVersion() uint16
+	writeModel() *WriteModel
+}
+
+// Snapshot is the state of a write model after the event with the sequence was reduced
+type Snapshot struct {
+	InstanceID    string
+	AggregateType AggregateType
+	AggregateID   string
+	ResourceOwner string
+	ModelType     string
+	ModelVersion  string
+	Sequence      uint64
+	ChangeDate    time.Time
+	Payload       []byte
+}
+
+type SnapshotStore interface {
+	// Snapshot returns the snapshot of the write model or nil if there is none
+	Snapshot(ctx context.Context, instanceID string, aggregateType AggregateType, aggregateID, modelType, modelVersion string) (*Snapshot, error)
+	// SetSnapshots stores the snapshots, existing snapshots of the write models are replaced
+	SetSnapshots(ctx context.Context, snapshots ...*Snapshot) error
+}
+
+// StartSnapshotWriter writes the queued snapshots in the configured interval until the context is done.
+func (es *Eventstore) StartSnapshotWriter(ctx context.Context) {
+	if es.snapshotQueue == nil {
+		return
+	}
+	go es.writeSnapshots(ctx)
+}
+
+func (es *Eventstore) writeSnapshots(ctx context.Context) {
+	ticker := time.NewTicker(es.snapshotConfig.WriteInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		snapshots := drainSnapshots(es.snapshotQueue)
+		if len(snapshots) == 0 {
+			continue
+		}
+		err := es.snapshots.SetSnapshots(ctx, snapshots...)
+		logging.OnError(ctx, err).Warn("unable to write snapshots", "count", len(snapshots))
+	}
+}
+
+// drainSnapshots returns the queued snapshots,
+// only the latest snapshot of a write model is returned.
+func drainSnapshots(queue <-chan *Snapshot) []*Snapshot {
+	type key struct {
+		instanceID    string
+		aggregateType AggregateType
+		aggregateID   string
+		modelType     string
+	}
+	latest := make(map[key]int)
+	snapshots := make([]*Snapshot, 0, len(queue))
+	for {
+		select {
+		case snapshot := <-queue:
+			k := key{snapshot.InstanceID, snapshot.AggregateType, snapshot.AggregateID, snapshot.ModelType}
+			i, ok := latest[k]
+			if !ok {
+				latest[k] = len(snapshots)
+				snapshots = append(snapshots, snapshot)
+				continue
+			}
+			if snapshots[i].Sequence < snapshot.Sequence {
+				snapshots[i] = snapshot
+			}
+		default:
+			return snapshots
+		}
+	}
+}
+
+// filterToSnapshotter restores the write model from its snapshot and reduces the newer events.
+// A new snapshot is queued if enough events were reduced.
+func (es *Eventstore) filterToSnapshotter(ctx context.Context, searchQuery *SearchQueryBuilder, s Snapshotter) error {
+	searchQuery.ensureInstanceID(ctx)
+	aggregateType, aggregateID, ok := searchQuery.snapshotAggregate()
+	if !ok {
+		return es.FilterToReducer(ctx, searchQuery, s)
+	}
+	instanceID := *searchQuery.instanceID
+	version := snapshotVersion(s, searchQuery)
+
+	snapshot, err := es.snapshots.Snapshot(ctx, instanceID, aggregateType, aggregateID, s.SnapshotType(), version)
+	// the write model can still be reduced from all events
+	logging.OnError(ctx, err).Warn("unable to query snapshot", "model", s.SnapshotType(), "aggregate_id", aggregateID)
+	var snapshotSequence uint64
+	if snapshot != nil {
+		if err = restoreSnapshot(s, snapshot); err != nil {
+			return err
+		}
+		snapshotSequence = snapshot.Sequence
+		searchQuery.SequenceGreater(snapshotSequence)
+	}
+
+	mapper := es.newEventMapper(ctx, func(event Event) error {
+		s.AppendEvents(event)
+		return s.Reduce()
+	})
+	err = es.querier.FilterToReducer(ctx, searchQuery, mapper.Map)
+	if err == nil {
+		err = mapper.Flush()
+	}
+	// the sequence of the aggregate also counts the events the write model doesn't filter
+	if err != nil || s.writeModel().ProcessedSequence-snapshotSequence < uint64(es.snapshotConfig.MinEvents) {
+		return err
+	}
+	es.queueSnapshot(ctx, instanceID, aggregateType, version, s)
+	return nil
+}
+
+func restoreSnapshot(s Snapshotter, snapshot *Snapshot) error {
+	if err := json.Unmarshal(snapshot.Payload, s); err != nil {
+		return err
+	}
+	wm := s.writeModel()
+	wm.AggregateID = snapshot.AggregateID
+	wm.ResourceOwner = snapshot.ResourceOwner
+	wm.InstanceID = snapshot.InstanceID
+	wm.ProcessedSequence = snapshot.Sequence
+	wm.ChangeDate = snapshot.ChangeDate
+	return nil
+}
+
+func (es *Eventstore) queueSnapshot(ctx context.Context, instanceID string, aggregateType AggregateType, version string, s Snapshotter) {
+	payload, err := json.Marshal(s)
+	if err != nil {
+		logging.WithError(ctx, err).Warn("unable to marshal snapshot", "model", s.SnapshotType())
+		return
+	}
+	wm := s.writeModel()
+	select {
+	case es.snapshotQueue <- &Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: aggregateType,
+		AggregateID:   wm.AggregateID,
+		ResourceOwner: wm.ResourceOwner,
+		ModelType:     s.SnapshotType(),
+		ModelVersion:  version,
+		Sequence:      wm.ProcessedSequence,
+		ChangeDate:    wm.ChangeDate,
+		Payload:       payload,
+	}:
+	default:
+		logging.Debug(ctx, "snapshot queue full", "model", s.SnapshotType())
+	}
+}
+
+// snapshotLayouts caches the layout of the state by the type of the write model
+var snapshotLayouts sync.Map
+
+// snapshotVersion returns the hash of the SnapshotVersion, the layout of the state
+// and the filtered event types, so that snapshots of a changed write model are ignored.
+func snapshotVersion(s Snapshotter, searchQuery *SearchQueryBuilder) string {
+	t := reflect.TypeOf(s)
+	layout, ok := snapshotLayouts.Load(t)
+	if !ok {
+		var b strings.Builder
+		writeStateLayout(&b, t, make(map[reflect.Type]bool))
+		layout, _ = snapshotLayouts.LoadOrStore(t, b.String())
+	}
+	var eventTypes []string
+	for _, query := range searchQuery.queries {
+		for _, eventType := range query.eventTypes {
+			eventTypes = append(eventTypes, string(eventType))
+		}
+	}
+	slices.Sort(eventTypes)
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d\n%s\n%s", s.SnapshotVersion(), layout, strings.Join(slices.Compact(eventTypes), ","))
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+var jsonMarshaler = reflect.TypeFor[json.Marshaler]()
+
+// writeStateLayout writes the types and names of the fields which are marshaled to JSON.
+func writeStateLayout(b *strings.Builder, t reflect.Type, visited map[reflect.Type]bool) {
+	b.WriteString(t.String())
+	if t.Implements(jsonMarshaler) || reflect.PointerTo(t).Implements(jsonMarshaler) {
+		return
+	}
+	//nolint:exhaustive
+	// other kinds are described by their type
+	switch t.Kind() {
+	case reflect.Map:
+		b.WriteString("[")
+		writeStateLayout(b, t.Key(), visited)
+		b.WriteString("]")
+		writeStateLayout(b, t.Elem(), visited)
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		writeStateLayout(b, t.Elem(), visited)
+	case reflect.Struct:
+		if visited[t] {
+			return
+		}
+		visited[t] = true
+		b.WriteString("{")
+		for i := range t.NumField() {
+			field := t.Field(i)
+			tag := field.Tag.Get("json")
+			if (!field.IsExported() && !field.Anonymous) || tag == "-" {
+				continue
+			}
+			b.WriteString(field.Name + " " + tag + " ")
+			writeStateLayout(b, field.Type, visited)
+			b.WriteString(";")
+		}
+		b.WriteString("}")
+	}
+}
+
+// snapshotAggregate returns the aggregate if the query filters all events of a single aggregate,
+// so that the events after a snapshot can be queried by their sequence.
+func (builder *SearchQueryBuilder) snapshotAggregate() (aggregateType AggregateType, aggregateID string, ok bool) {
+	if builder.instanceID == nil || *builder.instanceID == "" || len(builder.instanceIDs) > 0 ||
+		builder.tx != nil || builder.includeArchived || builder.desc || builder.limit > 0 || builder.offset > 0 ||
+		builder.eventSequenceGreater > 0 || !builder.positionAtLeast.IsZero() || !builder.positionAtMost.IsZero() ||
+		!builder.creationDateAfter.IsZero() || !builder.creationDateBefore.IsZero() ||
+		builder.excludeAggregateIDs != nil || len(builder.queries) == 0 {
+		return "", "", false
+	}
+	for _, query := range builder.queries {
+		if len(query.aggregateTypes) != 1 || len(query.aggregateIDs) != 1 ||
+			query.eventData != nil || !query.positionAfter.IsZero() {
+			return "", "", false
+		}
+		if aggregateID == "" {
+			aggregateType, aggregateID = query.aggregateTypes[0], query.aggregateIDs[0]
+			continue
+		}
+		if aggregateType != query.aggregateTypes[0] || aggregateID != query.aggregateIDs[0] {
+			return "", "", false
+		}
+	}
+	return aggregateType, aggregateID, aggregateID != ""
+}
+
+func (wm *WriteModel) writeModel() *WriteModel {
+	return wm
+}
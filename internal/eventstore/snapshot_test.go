@@ -0,0 +1,431 @@
+package eventstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSnapshotModel struct {
+	WriteModel
+
+	Count int
+}
+
+func newTestSnapshotModel(aggregateIDs ...string) *testSnapshotModel {
+	return &testSnapshotModel{
+		WriteModel: WriteModel{
+			AggregateID: aggregateIDs[0],
+		},
+	}
+}
+
+func (wm *testSnapshotModel) Reduce() error {
+	wm.Count += len(wm.Events)
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *testSnapshotModel) Query() *SearchQueryBuilder {
+	return NewSearchQueryBuilder(ColumnsEvent).
+		InstanceID("instance").
+		AddQuery().
+		AggregateTypes("test.aggregate").
+		AggregateIDs(wm.AggregateID).
+		Builder()
+}
+
+func (wm *testSnapshotModel) SnapshotType() string {
+	return "test"
+}
+
+func (wm *testSnapshotModel) SnapshotVersion() uint16 {
+	return 1
+}
+
+// testSnapshotQuerier returns the events with a sequence greater than the one of the query
+type testSnapshotQuerier struct {
+	testQuerier
+	query *SearchQueryBuilder
+}
+
+func (repo *testSnapshotQuerier) FilterToReducer(ctx context.Context, searchQuery *SearchQueryBuilder, reduce Reducer) error {
+	repo.query = searchQuery
+	for _, event := range repo.events {
+		if event.Sequence() <= searchQuery.GetEventSequenceGreater() {
+			continue
+		}
+		if err := reduce(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type testSnapshotStore struct {
+	snapshot *Snapshot
+	err      error
+	called   bool
+}
+
+func (s *testSnapshotStore) Snapshot(context.Context, string, AggregateType, string, string, string) (*Snapshot, error) {
+	s.called = true
+	return s.snapshot, s.err
+}
+
+func (s *testSnapshotStore) SetSnapshots(context.Context, ...*Snapshot) error {
+	return nil
+}
+
+func testSnapshotEvents(count int) []Event {
+	events := make([]Event, count)
+	for i := range events {
+		events[i] = &BaseEvent{
+			// other tests register a mapper for test.event
+			EventType: "test.snapshot.event",
+			Agg: &Aggregate{
+				ID:            "id",
+				Type:          "test.aggregate",
+				ResourceOwner: "ro",
+				InstanceID:    "instance",
+			},
+			Seq:      uint64(i + 1),
+			Creation: time.Date(2024, 1, 1, 0, 0, i, 0, time.UTC),
+		}
+	}
+	return events
+}
+
+func TestEventstore_FilterToQueryReducer_snapshot(t *testing.T) {
+	type fields struct {
+		events []Event
+		store  *testSnapshotStore
+	}
+	type want struct {
+		count            int
+		sequenceGreater  uint64
+		snapshot         *Snapshot
+		snapshotRequired bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		model  *testSnapshotModel
+		want   want
+	}{
+		{
+			name: "no snapshot, snapshot queued",
+			fields: fields{
+				events: testSnapshotEvents(3),
+				store:  &testSnapshotStore{},
+			},
+			model: newTestSnapshotModel("id"),
+			want: want{
+				count: 3,
+				snapshot: &Snapshot{
+					InstanceID:    "instance",
+					AggregateType: "test.aggregate",
+					AggregateID:   "id",
+					ResourceOwner: "ro",
+					ModelType:     "test",
+					ModelVersion:  snapshotVersion(newTestSnapshotModel("id"), newTestSnapshotModel("id").Query()),
+					Sequence:      3,
+					ChangeDate:    time.Date(2024, 1, 1, 0, 0, 2, 0, time.UTC),
+					Payload:       []byte(`{"Count":3}`),
+				},
+				snapshotRequired: true,
+			},
+		},
+		{
+			name: "snapshot restored, newer events reduced",
+			fields: fields{
+				events: testSnapshotEvents(6),
+				store: &testSnapshotStore{
+					snapshot: &Snapshot{
+						InstanceID:    "instance",
+						AggregateType: "test.aggregate",
+						AggregateID:   "id",
+						ResourceOwner: "ro",
+						Sequence:      5,
+						Payload:       []byte(`{"Count":50}`),
+					},
+				},
+			},
+			model: newTestSnapshotModel("id"),
+			want: want{
+				count:           51,
+				sequenceGreater: 5,
+			},
+		},
+		{
+			name: "snapshot restored, snapshot queued after min events",
+			fields: fields{
+				events: testSnapshotEvents(8),
+				store: &testSnapshotStore{
+					snapshot: &Snapshot{
+						InstanceID:    "instance",
+						AggregateType: "test.aggregate",
+						AggregateID:   "id",
+						ResourceOwner: "ro",
+						Sequence:      5,
+						Payload:       []byte(`{"Count":50}`),
+					},
+				},
+			},
+			model: newTestSnapshotModel("id"),
+			want: want{
+				count:           53,
+				sequenceGreater: 5,
+				snapshot: &Snapshot{
+					InstanceID:    "instance",
+					AggregateType: "test.aggregate",
+					AggregateID:   "id",
+					ResourceOwner: "ro",
+					ModelType:     "test",
+					ModelVersion:  snapshotVersion(newTestSnapshotModel("id"), newTestSnapshotModel("id").Query()),
+					Sequence:      8,
+					ChangeDate:    time.Date(2024, 1, 1, 0, 0, 7, 0, time.UTC),
+					Payload:       []byte(`{"Count":53}`),
+				},
+				snapshotRequired: true,
+			},
+		},
+		{
+			name: "events not reduced are counted, snapshot queued",
+			fields: fields{
+				events: testSnapshotEvents(4)[2:],
+				store:  &testSnapshotStore{},
+			},
+			model: newTestSnapshotModel("id"),
+			want: want{
+				count: 2,
+				snapshot: &Snapshot{
+					InstanceID:    "instance",
+					AggregateType: "test.aggregate",
+					AggregateID:   "id",
+					ResourceOwner: "ro",
+					ModelType:     "test",
+					ModelVersion:  snapshotVersion(newTestSnapshotModel("id"), newTestSnapshotModel("id").Query()),
+					Sequence:      4,
+					ChangeDate:    time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC),
+					Payload:       []byte(`{"Count":2}`),
+				},
+				snapshotRequired: true,
+			},
+		},
+		{
+			name: "snapshot error, all events reduced",
+			fields: fields{
+				events: testSnapshotEvents(2),
+				store: &testSnapshotStore{
+					err: errors.New("error"),
+				},
+			},
+			model: newTestSnapshotModel("id"),
+			want: want{
+				count: 2,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			querier := &testSnapshotQuerier{testQuerier: testQuerier{events: tt.fields.events}}
+			es := NewEventstore(&Config{
+				Querier:       querier,
+				SnapshotStore: tt.fields.store,
+				Snapshots: SnapshotConfig{
+					Enabled:   true,
+					MinEvents: 3,
+					QueueSize: 1,
+				},
+			})
+
+			err := es.FilterToQueryReducer(context.Background(), tt.model)
+			require.NoError(t, err)
+			assert.True(t, tt.fields.store.called)
+			assert.Equal(t, tt.want.count, tt.model.Count)
+			assert.Equal(t, tt.fields.events[len(tt.fields.events)-1].Sequence(), tt.model.ProcessedSequence)
+			assert.Equal(t, tt.want.sequenceGreater, querier.query.GetEventSequenceGreater())
+
+			snapshots := drainSnapshots(es.snapshotQueue)
+			if !tt.want.snapshotRequired {
+				assert.Empty(t, snapshots)
+				return
+			}
+			require.Len(t, snapshots, 1)
+			assert.Equal(t, tt.want.snapshot, snapshots[0])
+		})
+	}
+}
+
+func TestEventstore_FilterToQueryReducer_snapshotDisabled(t *testing.T) {
+	store := &testSnapshotStore{}
+	es := NewEventstore(&Config{
+		Querier:       &testSnapshotQuerier{testQuerier: testQuerier{events: testSnapshotEvents(2)}},
+		SnapshotStore: store,
+	})
+	model := newTestSnapshotModel("id")
+
+	err := es.FilterToQueryReducer(context.Background(), model)
+	require.NoError(t, err)
+	assert.False(t, store.called)
+	assert.Equal(t, 2, model.Count)
+}
+
+func TestSearchQueryBuilder_snapshotAggregate(t *testing.T) {
+	tests := []struct {
+		name              string
+		query             *SearchQueryBuilder
+		wantAggregateType AggregateType
+		wantAggregateID   string
+		wantOK            bool
+	}{
+		{
+			name: "single aggregate",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("id").EventTypes("user.added").
+				Or().AggregateTypes("user").AggregateIDs("id").EventTypes("user.removed").
+				Builder(),
+			wantAggregateType: "user",
+			wantAggregateID:   "id",
+			wantOK:            true,
+		},
+		{
+			name: "no instance",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				AddQuery().AggregateTypes("user").AggregateIDs("id").
+				Builder(),
+		},
+		{
+			name: "multiple aggregate ids",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("id", "id2").
+				Builder(),
+		},
+		{
+			name: "different aggregates",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("id").
+				Or().AggregateTypes("org").AggregateIDs("id").
+				Builder(),
+		},
+		{
+			name: "sequence greater",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				SequenceGreater(2).
+				AddQuery().AggregateTypes("user").AggregateIDs("id").
+				Builder(),
+		},
+		{
+			name: "position after",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				AddQuery().AggregateTypes("user").AggregateIDs("id").PositionAfter(decimal.NewFromInt(1)).
+				Builder(),
+		},
+		{
+			name: "limit",
+			query: NewSearchQueryBuilder(ColumnsEvent).
+				InstanceID("instance").
+				Limit(1).
+				AddQuery().AggregateTypes("user").AggregateIDs("id").
+				Builder(),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aggregateType, aggregateID, ok := tt.query.snapshotAggregate()
+			assert.Equal(t, tt.wantAggregateType, aggregateType)
+			assert.Equal(t, tt.wantAggregateID, aggregateID)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+type testSnapshotModelV2 struct {
+	testSnapshotModel
+
+	Name string
+}
+
+type testSnapshotModelTag struct {
+	WriteModel
+
+	Count int `json:"count"`
+}
+
+func (wm *testSnapshotModelTag) Reduce() error {
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *testSnapshotModelTag) Query() *SearchQueryBuilder {
+	return newTestSnapshotModel(wm.AggregateID).Query()
+}
+
+func (wm *testSnapshotModelTag) SnapshotType() string {
+	return "test"
+}
+
+func (wm *testSnapshotModelTag) SnapshotVersion() uint16 {
+	return 1
+}
+
+type testSnapshotModelBumped struct {
+	testSnapshotModel
+}
+
+func (wm *testSnapshotModelBumped) SnapshotVersion() uint16 {
+	return 2
+}
+
+func Test_snapshotVersion(t *testing.T) {
+	model := newTestSnapshotModel("id")
+	version := snapshotVersion(model, model.Query())
+
+	t.Run("same model, same version", func(t *testing.T) {
+		other := newTestSnapshotModel("other")
+		other.Count = 42
+		assert.Equal(t, version, snapshotVersion(other, other.Query()))
+	})
+	t.Run("field added", func(t *testing.T) {
+		assert.NotEqual(t, version, snapshotVersion(&testSnapshotModelV2{testSnapshotModel: *model}, model.Query()))
+	})
+	t.Run("json name changed", func(t *testing.T) {
+		assert.NotEqual(t, version, snapshotVersion(&testSnapshotModelTag{}, model.Query()))
+	})
+	t.Run("version increased", func(t *testing.T) {
+		assert.NotEqual(t, version, snapshotVersion(&testSnapshotModelBumped{testSnapshotModel: *model}, model.Query()))
+	})
+	t.Run("event types changed", func(t *testing.T) {
+		query := model.Query().AddQuery().AggregateTypes("test.aggregate").AggregateIDs("id").EventTypes("test.snapshot.event").Builder()
+		assert.NotEqual(t, version, snapshotVersion(model, query))
+	})
+	t.Run("event types order ignored", func(t *testing.T) {
+		first := NewSearchQueryBuilder(ColumnsEvent).AddQuery().EventTypes("a", "b").Builder()
+		second := NewSearchQueryBuilder(ColumnsEvent).AddQuery().EventTypes("b", "a").Builder()
+		assert.Equal(t, snapshotVersion(model, first), snapshotVersion(model, second))
+	})
+}
+
+func Test_drainSnapshots(t *testing.T) {
+	queue := make(chan *Snapshot, 4)
+	queue <- &Snapshot{AggregateID: "1", ModelType: "test", Sequence: 2}
+	queue <- &Snapshot{AggregateID: "2", ModelType: "test", Sequence: 1}
+	queue <- &Snapshot{AggregateID: "1", ModelType: "test", Sequence: 3}
+	queue <- &Snapshot{AggregateID: "1", ModelType: "test", Sequence: 1}
+
+	snapshots := drainSnapshots(queue)
+	assert.Equal(t, []*Snapshot{
+		{AggregateID: "1", ModelType: "test", Sequence: 3},
+		{AggregateID: "2", ModelType: "test", Sequence: 1},
+	}, snapshots)
+	assert.Empty(t, queue)
+}
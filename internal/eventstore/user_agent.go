@@ -0,0 +1,25 @@
+package eventstore
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+)
+
+// UserAgentStore provides the user agents of the requests which pushed events.
+// The events pushed in the same transaction share the position and therefore the user agent.
+type UserAgentStore interface {
+	// UserAgents returns the user agents by the position of the events,
+	// positions without a recorded user agent are missing in the result.
+	UserAgents(ctx context.Context, instanceID string, positions ...decimal.Decimal) (map[string]string, error)
+}
+
+// UserAgents returns the user agents of the requests which pushed the events at the positions,
+// the result is keyed by the string representation of the position.
+// The result is empty if no [UserAgentStore] is configured.
+func (es *Eventstore) UserAgents(ctx context.Context, instanceID string, positions ...decimal.Decimal) (map[string]string, error) {
+	if es.userAgents == nil || len(positions) == 0 {
+		return map[string]string{}, nil
+	}
+	return es.userAgents.UserAgents(ctx, instanceID, positions...)
+}
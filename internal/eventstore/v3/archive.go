@@ -0,0 +1,335 @@
+package eventstore
+
+import (
+	"cmp"
+	"context"
+	_ "embed"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/zitadel/zitadel/backend/v3/storage/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed archive_candidates.sql
+	archiveCandidatesStmt string
+	//go:embed archive_delete_events.sql
+	archiveDeleteEventsStmt string
+	//go:embed archive_insert.sql
+	archiveInsertStmt string
+
+	_ eventstore.ArchiveStore = (*Eventstore)(nil)
+)
+
+// ArchiveResult reports the aggregates moved to the archive
+type ArchiveResult struct {
+	Aggregates uint64
+	Events     uint64
+	// EventsSize is the size in bytes of the events removed from the events table
+	EventsSize int64
+	// ArchivedSize is the size in bytes of the compressed events in the archive
+	ArchivedSize int64
+	// FinalPosition is the position of the final event of the last archived aggregate,
+	// the next bulk continues after it.
+	FinalPosition decimal.Decimal
+}
+
+func (r *ArchiveResult) Add(other *ArchiveResult) {
+	r.Aggregates += other.Aggregates
+	r.Events += other.Events
+	r.EventsSize += other.EventsSize
+	r.ArchivedSize += other.ArchivedSize
+}
+
+type archivedRow struct {
+	aggregateID string
+	owner       string
+	event       *eventstore.ArchivedEvent
+	size        int64
+}
+
+// ArchiveAggregates moves the events of at most bulkSize aggregates of the type to the archive,
+// if the latest event of the aggregate is a final event created before the given time and after the given position.
+// The position is the [ArchiveResult.FinalPosition] of the previous bulk, or zero for the first bulk.
+// The final event remains in the events table, so that the sequence continues if the aggregate id is reused.
+// Pushes to the instance are blocked until the aggregates are archived.
+// Snapshots of the archived aggregates are removed.
+func (es *Eventstore) ArchiveAggregates(ctx context.Context, instanceID string, aggregateType eventstore.ArchivedAggregateType, before time.Time, after decimal.Decimal, bulkSize uint32) (_ *ArchiveResult, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tx, err := es.client.Begin(ctx, nil)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Ar7cz", "Errors.Internal")
+	}
+	defer func() {
+		err = tx.End(ctx, err)
+	}()
+
+	// the exclusive lock waits for running pushes of the instance, see [Eventstore.Push]
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock('eventstore.events2'::REGCLASS::OID::INTEGER, hashtext($1))", instanceID); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Lk3ow", "Errors.Internal")
+	}
+
+	eventTypes := make([]string, len(aggregateType.FinalEventTypes))
+	for i, eventType := range aggregateType.FinalEventTypes {
+		eventTypes[i] = string(eventType)
+	}
+	aggregateIDs, finalSequences, finalPosition, err := archiveCandidates(ctx, tx, instanceID, aggregateType.Type, eventTypes, before, after, bulkSize)
+	if err != nil || len(aggregateIDs) == 0 {
+		return new(ArchiveResult), err
+	}
+
+	rows, err := tx.Query(ctx, archiveDeleteEventsStmt, instanceID, string(aggregateType.Type), aggregateIDs, finalSequences)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Dq8nf", "Errors.Internal")
+	}
+	defer rows.Close()
+	archived := make([]*archivedRow, 0, len(aggregateIDs))
+	for rows.Next() {
+		row := &archivedRow{event: new(eventstore.ArchivedEvent)}
+		var (
+			eventType string
+			payload   []byte
+		)
+		err = rows.Scan(
+			&row.aggregateID,
+			&row.owner,
+			&eventType,
+			&row.event.Revision,
+			&row.event.Sequence,
+			&row.event.Position,
+			&row.event.CreatedAt,
+			&row.event.Creator,
+			&payload,
+			&row.size,
+		)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "V3-Ml2xq", "Errors.Internal")
+		}
+		row.event.Type = eventstore.EventType(eventType)
+		row.event.Payload = payload
+		archived = append(archived, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Vb5ki", "Errors.Internal")
+	}
+
+	result, err := insertArchivedAggregates(ctx, tx, instanceID, aggregateType.Type, archived)
+	if err != nil {
+		return nil, err
+	}
+	result.FinalPosition = finalPosition
+	if _, err = tx.Exec(ctx, "DELETE FROM eventstore.snapshots WHERE instance_id = $1 AND aggregate_type = $2 AND aggregate_id = ANY($3)", instanceID, string(aggregateType.Type), aggregateIDs); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Sx0ra", "Errors.Internal")
+	}
+	return result, nil
+}
+
+// archiveCandidates returns the ids of the aggregates to archive, the sequences of their final events
+// and the position of the last final event
+func archiveCandidates(ctx context.Context, tx database.Transaction, instanceID string, aggregateType eventstore.AggregateType, eventTypes []string, before time.Time, after decimal.Decimal, limit uint32) (aggregateIDs []string, finalSequences []uint64, finalPosition decimal.Decimal, err error) {
+	rows, err := tx.Query(ctx, archiveCandidatesStmt, instanceID, string(aggregateType), eventTypes, before, after, limit)
+	if err != nil {
+		return nil, nil, finalPosition, zerrors.ThrowInternal(err, "V3-Cn4dw", "Errors.Internal")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			aggregateID   string
+			finalSequence uint64
+		)
+		if err = rows.Scan(&aggregateID, &finalSequence, &finalPosition); err != nil {
+			return nil, nil, finalPosition, zerrors.ThrowInternal(err, "V3-Hf9ys", "Errors.Internal")
+		}
+		aggregateIDs = append(aggregateIDs, aggregateID)
+		finalSequences = append(finalSequences, finalSequence)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, finalPosition, zerrors.ThrowInternal(err, "V3-Pz6gl", "Errors.Internal")
+	}
+	return aggregateIDs, finalSequences, finalPosition, nil
+}
+
+// insertArchivedAggregates groups the removed events by their aggregate and stores them compressed
+func insertArchivedAggregates(ctx context.Context, tx database.Transaction, instanceID string, aggregateType eventstore.AggregateType, rows []*archivedRow) (*ArchiveResult, error) {
+	result := new(ArchiveResult)
+	aggregates := make(map[string][]*archivedRow)
+	for _, row := range rows {
+		aggregates[row.aggregateID] = append(aggregates[row.aggregateID], row)
+		result.Events++
+		result.EventsSize += row.size
+	}
+	if len(aggregates) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, 0, len(aggregates))
+	args := make([]any, 0, len(aggregates)*10)
+	for aggregateID, aggregateRows := range aggregates {
+		slices.SortFunc(aggregateRows, func(a, b *archivedRow) int {
+			return cmp.Compare(a.event.Sequence, b.event.Sequence)
+		})
+		events := make([]*eventstore.ArchivedEvent, len(aggregateRows))
+		for i, row := range aggregateRows {
+			events[i] = row.event
+		}
+		compressed, err := eventstore.CompressArchivedEvents(events)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "V3-Zw1ub", "Errors.Internal")
+		}
+		first, last := events[0], events[len(events)-1]
+
+		placeholders = append(placeholders, "("+archivePlaceholders(len(args), 10)+")")
+		args = append(args,
+			instanceID,
+			string(aggregateType),
+			aggregateID,
+			aggregateRows[len(aggregateRows)-1].owner,
+			len(events),
+			first.Position,
+			last.Position,
+			first.CreatedAt,
+			last.CreatedAt,
+			compressed,
+		)
+		result.Aggregates++
+		result.ArchivedSize += int64(len(compressed))
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf(archiveInsertStmt, strings.Join(placeholders, ", ")), args...); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Gi8pv", "Errors.Internal")
+	}
+	return result, nil
+}
+
+func archivePlaceholders(offset, count int) string {
+	placeholders := make([]string, count)
+	for i := range placeholders {
+		placeholders[i] = "$" + strconv.Itoa(offset+i+1)
+	}
+	return strings.Join(placeholders, ", ")
+}
+
+// FilterArchivedAggregates implements [eventstore.ArchiveStore]
+// The archived aggregates are filtered by the instance, resource owner, aggregates, creation date and position of the query,
+// the events are filtered by [eventstore.Eventstore].
+func (es *Eventstore) FilterArchivedAggregates(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder, reduce func(*eventstore.ArchivedAggregate) error) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, args := archivedAggregatesQuery(searchQuery)
+	rows, err := es.client.Query(ctx, stmt, args...)
+	if err != nil {
+		return zerrors.ThrowInternal(err, "V3-Qe2jr", "Errors.Internal")
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var aggregateType string
+		aggregate := &eventstore.ArchivedAggregate{Aggregate: new(eventstore.Aggregate)}
+		err = rows.Scan(
+			&aggregate.Aggregate.InstanceID,
+			&aggregateType,
+			&aggregate.Aggregate.ID,
+			&aggregate.Aggregate.ResourceOwner,
+			&aggregate.FirstPosition,
+			&aggregate.LastPosition,
+			&aggregate.Events,
+		)
+		if err != nil {
+			return zerrors.ThrowInternal(err, "V3-Ro5na", "Errors.Internal")
+		}
+		aggregate.Aggregate.Type = eventstore.AggregateType(aggregateType)
+		if err = reduce(aggregate); err != nil {
+			return err
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return zerrors.ThrowInternal(err, "V3-Ty3vb", "Errors.Internal")
+	}
+	return nil
+}
+
+func archivedAggregatesQuery(searchQuery *eventstore.SearchQueryBuilder) (string, []any) {
+	var (
+		conditions []string
+		args       []any
+	)
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if instanceID := searchQuery.GetInstanceID(); instanceID != nil && *instanceID != "" {
+		addCondition("instance_id = $%d", *instanceID)
+	}
+	if instanceIDs := searchQuery.GetInstanceIDs(); len(instanceIDs) > 0 {
+		addCondition("instance_id = ANY($%d)", instanceIDs)
+	}
+	if resourceOwner := searchQuery.GetResourceOwner(); resourceOwner != "" {
+		addCondition("owner = $%d", resourceOwner)
+	}
+	if after := searchQuery.GetCreationDateAfter(); !after.IsZero() {
+		addCondition("last_created_at > $%d", after)
+	}
+	if before := searchQuery.GetCreationDateBefore(); !before.IsZero() {
+		addCondition("first_created_at < $%d", before)
+	}
+	if position := searchQuery.GetPositionAtLeast(); !position.IsZero() {
+		addCondition("last_position >= $%d", position)
+	}
+	if position := searchQuery.GetPositionAtMost(); !position.IsZero() {
+		addCondition("first_position <= $%d", position)
+	}
+
+	// the aggregates are only restricted if every sub query restricts them
+	aggregateConditions := make([]string, 0, len(searchQuery.GetQueries()))
+	aggregateArgs := make([]any, 0, len(searchQuery.GetQueries())*2)
+	for _, query := range searchQuery.GetQueries() {
+		var condition []string
+		if types := query.GetAggregateTypes(); len(types) > 0 {
+			aggregateArgs = append(aggregateArgs, aggregateTypesToStrings(types))
+			condition = append(condition, "aggregate_type = ANY($"+strconv.Itoa(len(args)+len(aggregateArgs))+")")
+		}
+		if ids := query.GetAggregateIDs(); len(ids) > 0 {
+			aggregateArgs = append(aggregateArgs, ids)
+			condition = append(condition, "aggregate_id = ANY($"+strconv.Itoa(len(args)+len(aggregateArgs))+")")
+		}
+		if len(condition) == 0 {
+			aggregateConditions = nil
+			break
+		}
+		aggregateConditions = append(aggregateConditions, "("+strings.Join(condition, " AND ")+")")
+	}
+	if len(aggregateConditions) > 0 {
+		conditions = append(conditions, "("+strings.Join(aggregateConditions, " OR ")+")")
+		args = append(args, aggregateArgs...)
+	}
+
+	var stmt strings.Builder
+	stmt.WriteString("SELECT instance_id, aggregate_type, aggregate_id, owner, first_position, last_position, events FROM eventstore.archived_aggregates")
+	if len(conditions) > 0 {
+		stmt.WriteString(" WHERE ")
+		stmt.WriteString(strings.Join(conditions, " AND "))
+	}
+	if searchQuery.GetDesc() {
+		stmt.WriteString(" ORDER BY last_position DESC")
+	} else {
+		stmt.WriteString(" ORDER BY first_position")
+	}
+	return stmt.String(), args
+}
+
+func aggregateTypesToStrings(types []eventstore.AggregateType) []string {
+	strs := make([]string, len(types))
+	for i, typ := range types {
+		strs[i] = string(typ)
+	}
+	return strs
+}
@@ -0,0 +1,37 @@
+-- aggregates whose latest event is a final event older than the retention.
+-- The final events are scanned by the es_projection index in the order of their position,
+-- starting after the final event of the previous bulk of the same run.
+-- Each run starts at the beginning, so aggregates become candidates as soon as their type is configured.
+-- Older and newer events of the aggregate are searched by the primary key.
+SELECT
+    e.aggregate_id
+    , e."sequence"
+    , e."position"
+FROM
+    eventstore.events2 e
+WHERE
+    e.instance_id = $1
+    AND e.aggregate_type = $2
+    AND e.event_type = ANY($3)
+    AND e."position" < EXTRACT(EPOCH FROM $4::TIMESTAMPTZ)
+    AND e."position" > $5
+    -- the final event remains in the events table, aggregates without older events are archived already
+    AND EXISTS (
+        SELECT 1 FROM eventstore.events2 o
+        WHERE
+            o.instance_id = e.instance_id
+            AND o.aggregate_type = e.aggregate_type
+            AND o.aggregate_id = e.aggregate_id
+            AND o."sequence" < e."sequence"
+    )
+    AND NOT EXISTS (
+        SELECT 1 FROM eventstore.events2 n
+        WHERE
+            n.instance_id = e.instance_id
+            AND n.aggregate_type = e.aggregate_type
+            AND n.aggregate_id = e.aggregate_id
+            AND n."sequence" > e."sequence"
+    )
+ORDER BY
+    e."position"
+LIMIT $6
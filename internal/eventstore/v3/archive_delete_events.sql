@@ -0,0 +1,21 @@
+-- the final event remains so that the sequence continues if the aggregate id is reused
+DELETE FROM
+    eventstore.events2 e
+USING
+    UNNEST($3::TEXT[], $4::BIGINT[]) AS f(aggregate_id, final_sequence)
+WHERE
+    e.instance_id = $1
+    AND e.aggregate_type = $2
+    AND e.aggregate_id = f.aggregate_id
+    AND e."sequence" < f.final_sequence
+RETURNING
+    e.aggregate_id
+    , e.owner
+    , e.event_type
+    , e.revision
+    , e."sequence"
+    , e.position
+    , e.created_at
+    , e.creator
+    , e.payload
+    , pg_column_size(e.*)
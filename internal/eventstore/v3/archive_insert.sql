@@ -0,0 +1,13 @@
+INSERT INTO eventstore.archived_aggregates (
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , owner
+    , event_count
+    , first_position
+    , last_position
+    , first_created_at
+    , last_created_at
+    , events
+) VALUES
+    %s
@@ -0,0 +1,62 @@
+package eventstore
+
+import (
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func Test_archivedAggregatesQuery(t *testing.T) {
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		query    *eventstore.SearchQueryBuilder
+		wantStmt string
+		wantArgs []any
+	}{
+		{
+			name: "aggregates of instance",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("instance").
+				OrderDesc().
+				CreationDateAfter(after).
+				AddQuery().AggregateTypes("user").AggregateIDs("id").
+				Or().AggregateTypes("session").
+				Builder(),
+			wantStmt: "SELECT instance_id, aggregate_type, aggregate_id, owner, first_position, last_position, events FROM eventstore.archived_aggregates WHERE instance_id = $1 AND last_created_at > $2 AND ((aggregate_type = ANY($3) AND aggregate_id = ANY($4)) OR (aggregate_type = ANY($5))) ORDER BY last_position DESC",
+			wantArgs: []any{"instance", after, []string{"user"}, []string{"id"}, []string{"session"}},
+		},
+		{
+			name: "sub query without aggregates",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("instance").
+				ResourceOwner("org").
+				AddQuery().AggregateTypes("user").
+				Or().EventTypes("session.terminated").
+				Builder(),
+			wantStmt: "SELECT instance_id, aggregate_type, aggregate_id, owner, first_position, last_position, events FROM eventstore.archived_aggregates WHERE instance_id = $1 AND owner = $2 ORDER BY first_position",
+			wantArgs: []any{"instance", "org"},
+		},
+		{
+			name: "aggregate at position",
+			query: eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+				InstanceID("instance").
+				PositionAtMost(decimal.NewFromInt(42)).
+				AddQuery().AggregateTypes("user").AggregateIDs("id").
+				Builder(),
+			wantStmt: "SELECT instance_id, aggregate_type, aggregate_id, owner, first_position, last_position, events FROM eventstore.archived_aggregates WHERE instance_id = $1 AND first_position <= $2 AND ((aggregate_type = ANY($3) AND aggregate_id = ANY($4))) ORDER BY first_position",
+			wantArgs: []any{"instance", decimal.NewFromInt(42), []string{"user"}, []string{"id"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmt, args := archivedAggregatesQuery(tt.query)
+			assert.Equal(t, tt.wantStmt, stmt)
+			assert.Equal(t, tt.wantArgs, args)
+		})
+	}
+}
@@ -15,6 +15,7 @@ import (
 
 	new_db "github.com/zitadel/zitadel/backend/v3/storage/database"
 	new_sql "github.com/zitadel/zitadel/backend/v3/storage/database/dialect/sql"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/database/dialect"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -36,6 +37,14 @@ var (
 type Eventstore struct {
 	client new_db.Pool
 	queue  eventstore.ExecutionQueue
+	// pushNotifications sends a [eventstore.PushNotification] in the transaction of the push
+	pushNotifications bool
+	// encryptPersonalData encrypts the registered personal data of pushed events
+	encryptPersonalData bool
+	// personalDataKeyEncryption encrypts the keys of the personal data in the database
+	personalDataKeyEncryption crypto.EncryptionAlgorithm
+	// recordUserAgents stores the user agent of the request which pushed the events
+	recordUserAgents bool
 }
 
 var (
@@ -212,3 +221,10 @@ func WithExecutionQueueOption(queue eventstore.ExecutionQueue) EventstoreOption
 		es.queue = queue
 	}
 }
+
+// WithPushNotificationsOption enables the notification of other nodes about pushed events.
+func WithPushNotificationsOption(enabled bool) EventstoreOption {
+	return func(es *Eventstore) {
+		es.pushNotifications = enabled
+	}
+}
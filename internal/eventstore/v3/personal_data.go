@@ -0,0 +1,192 @@
+package eventstore
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/zitadel/zitadel/backend/v3/storage/database"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var _ eventstore.PersonalDataKeyStore = (*Eventstore)(nil)
+
+// WithPersonalDataEncryptionOption encrypts the personal data of pushed events with the key of their aggregate.
+func WithPersonalDataEncryptionOption(enabled bool) EventstoreOption {
+	return func(es *Eventstore) {
+		es.encryptPersonalData = enabled
+	}
+}
+
+// WithPersonalDataKeyEncryptionOption encrypts the keys of the personal data before they are stored.
+// It's required to encrypt the personal data of pushed events and to query the keys.
+func WithPersonalDataKeyEncryptionOption(keyEncryption crypto.EncryptionAlgorithm) EventstoreOption {
+	return func(es *Eventstore) {
+		es.personalDataKeyEncryption = keyEncryption
+	}
+}
+
+// PersonalDataKeys implements [eventstore.PersonalDataKeyStore]
+func (es *Eventstore) PersonalDataKeys(ctx context.Context, instanceID string, keyIDs ...string) (_ map[string]*eventstore.PersonalDataKey, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if es.personalDataKeyEncryption == nil {
+		return nil, zerrors.ThrowInternal(nil, "V3-Xn4rb", "Errors.Internal")
+	}
+	keys, err := queryPersonalDataKeys(ctx, es.client, es.personalDataKeyEncryption, "key_id", instanceID, keyIDs)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Pk3xa", "Errors.Internal")
+	}
+	byID := make(map[string]*eventstore.PersonalDataKey, len(keys))
+	for _, key := range keys {
+		byID[key.ID] = key
+	}
+	return byID, nil
+}
+
+// queryPersonalDataKeys queries and decrypts the keys of the instance where the column matches any of the ids
+func queryPersonalDataKeys(ctx context.Context, client database.Querier, keyEncryption crypto.EncryptionAlgorithm, column, instanceID string, ids []string) ([]*eventstore.PersonalDataKey, error) {
+	rows, err := client.Query(ctx,
+		"SELECT key_id, subject_id, key FROM eventstore.personal_data_keys WHERE instance_id = $1 AND "+column+" = ANY($2)",
+		instanceID, ids,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := make([]*eventstore.PersonalDataKey, 0, len(ids))
+	for rows.Next() {
+		var (
+			key       = new(eventstore.PersonalDataKey)
+			encrypted crypto.CryptoValue
+		)
+		if err = rows.Scan(&key.ID, &key.SubjectID, &encrypted); err != nil {
+			return nil, err
+		}
+		if key.Key, err = crypto.Decrypt(&encrypted, keyEncryption); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, rows.Err()
+}
+
+// encryptCommands returns copies of the commands whose personal data are encrypted,
+// the keys of the aggregates are created if they do not exist yet.
+// The commands passed are not modified, so the pushed events still contain the plain values.
+func (es *Eventstore) encryptCommands(ctx context.Context, tx database.Transaction, commands []*command) (_ []*command, err error) {
+	if !es.encryptPersonalData {
+		return commands, nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	subjects := make(map[string][]string)
+	for _, cmd := range commands {
+		if len(cmd.Payload) == 0 || len(eventstore.PersonalDataFields(eventstore.EventType(cmd.CommandType))) == 0 {
+			continue
+		}
+		if !slices.Contains(subjects[cmd.InstanceID], cmd.AggregateID) {
+			subjects[cmd.InstanceID] = append(subjects[cmd.InstanceID], cmd.AggregateID)
+		}
+	}
+	if len(subjects) == 0 {
+		return commands, nil
+	}
+	if es.personalDataKeyEncryption == nil {
+		return nil, zerrors.ThrowInternal(nil, "V3-Ue8jw", "Errors.Internal")
+	}
+
+	keys := make(map[string]map[string]*eventstore.PersonalDataKey, len(subjects))
+	for instanceID, subjectIDs := range subjects {
+		if keys[instanceID], err = ensurePersonalDataKeys(ctx, tx, es.personalDataKeyEncryption, instanceID, subjectIDs); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V3-Ek7vr", "Errors.Internal")
+		}
+	}
+
+	encrypted := make([]*command, len(commands))
+	for i, cmd := range commands {
+		encrypted[i] = cmd
+		fields := eventstore.PersonalDataFields(eventstore.EventType(cmd.CommandType))
+		if len(cmd.Payload) == 0 || len(fields) == 0 {
+			continue
+		}
+		encryptedCmd := *cmd
+		encryptedCmd.Payload, err = eventstore.EncryptPersonalData(keys[cmd.InstanceID][cmd.AggregateID], cmd.Payload, fields)
+		if err != nil {
+			return nil, err
+		}
+		encrypted[i] = &encryptedCmd
+	}
+	return encrypted, nil
+}
+
+// ensurePersonalDataKeys creates the missing keys of the subjects and returns the keys of all subjects by subject id
+func ensurePersonalDataKeys(ctx context.Context, tx database.Transaction, keyEncryption crypto.EncryptionAlgorithm, instanceID string, subjectIDs []string) (map[string]*eventstore.PersonalDataKey, error) {
+	keyIDs := make([]string, len(subjectIDs))
+	newKeys := make([]string, len(subjectIDs))
+	for i, subjectID := range subjectIDs {
+		key, err := eventstore.NewPersonalDataKey(subjectID)
+		if err != nil {
+			return nil, err
+		}
+		encrypted, err := crypto.Encrypt(key.Key, keyEncryption)
+		if err != nil {
+			return nil, err
+		}
+		encoded, err := json.Marshal(encrypted)
+		if err != nil {
+			return nil, err
+		}
+		keyIDs[i], newKeys[i] = key.ID, string(encoded)
+	}
+	_, err := tx.Exec(ctx,
+		"INSERT INTO eventstore.personal_data_keys (instance_id, key_id, subject_id, key) SELECT $1, * FROM UNNEST($2::TEXT[], $3::TEXT[], $4::JSONB[]) ON CONFLICT (instance_id, subject_id) DO NOTHING",
+		instanceID, keyIDs, subjectIDs, newKeys,
+	)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := queryPersonalDataKeys(ctx, tx, keyEncryption, "subject_id", instanceID, subjectIDs)
+	if err != nil {
+		return nil, err
+	}
+	bySubject := make(map[string]*eventstore.PersonalDataKey, len(keys))
+	for _, key := range keys {
+		bySubject[key.SubjectID] = key
+	}
+	return bySubject, nil
+}
+
+// erasePersonalData destroys the keys of the aggregates whose personal data are erased by the commands,
+// the snapshots of the aggregates are removed as they contain the decrypted values.
+// The keys are destroyed even if the encryption is disabled, so events encrypted before are redacted.
+// A subject whose id is reused gets a new key, the events encrypted with the destroyed key stay redacted.
+func erasePersonalData(ctx context.Context, tx database.Transaction, commands []eventstore.Command) (err error) {
+	subjects := make(map[string][]string)
+	for _, cmd := range commands {
+		if eventstore.ErasesPersonalData(cmd.Type()) {
+			subjects[cmd.Aggregate().InstanceID] = append(subjects[cmd.Aggregate().InstanceID], cmd.Aggregate().ID)
+		}
+	}
+	if len(subjects) == 0 {
+		return nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	for instanceID, subjectIDs := range subjects {
+		if _, err = tx.Exec(ctx, "DELETE FROM eventstore.personal_data_keys WHERE instance_id = $1 AND subject_id = ANY($2)", instanceID, subjectIDs); err != nil {
+			return zerrors.ThrowInternal(err, "V3-Dq5ne", "Errors.Internal")
+		}
+		if _, err = tx.Exec(ctx, "DELETE FROM eventstore.snapshots WHERE instance_id = $1 AND aggregate_id = ANY($2)", instanceID, subjectIDs); err != nil {
+			return zerrors.ThrowInternal(err, "V3-Sx2fm", "Errors.Internal")
+		}
+	}
+	return nil
+}
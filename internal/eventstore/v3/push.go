@@ -3,6 +3,7 @@ package eventstore
 import (
 	"context"
 	_ "embed"
+	"encoding/json"
 	"fmt"
 
 	"github.com/riverqueue/river"
@@ -50,10 +51,16 @@ func (es *Eventstore) writeCommands(ctx context.Context, client database.QueryEx
 		return nil, err
 	}
 
-	events, err := writeEvents(ctx, tx, commands)
+	events, err := es.writeEvents(ctx, tx, commands)
 	if err != nil {
 		return nil, err
 	}
+	if err = erasePersonalData(ctx, tx, commands); err != nil {
+		return nil, err
+	}
+	if err = es.recordUserAgent(ctx, tx, events); err != nil {
+		return nil, err
+	}
 	if err = handleUniqueConstraints(ctx, tx, commands); err != nil {
 		return nil, err
 	}
@@ -68,10 +75,15 @@ func (es *Eventstore) writeCommands(ctx context.Context, client database.QueryEx
 		return nil, err
 	}
 
+	err = es.notifyPush(ctx, tx, events)
+	if err != nil {
+		return nil, err
+	}
+
 	return events, nil
 }
 
-func writeEvents(ctx context.Context, tx database.Transaction, commands []eventstore.Command) (_ []eventstore.Event, err error) {
+func (es *Eventstore) writeEvents(ctx context.Context, tx database.Transaction, commands []eventstore.Command) (_ []eventstore.Event, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -79,6 +91,11 @@ func writeEvents(ctx context.Context, tx database.Transaction, commands []events
 	if err != nil {
 		return nil, err
 	}
+	// the returned events keep the plain payload
+	cmds, err = es.encryptCommands(ctx, tx, cmds)
+	if err != nil {
+		return nil, err
+	}
 
 	rows, err := tx.Query(ctx, `select owner, created_at, "sequence", position from eventstore.push($1::eventstore.command[])`, cmds)
 	if err != nil {
@@ -126,6 +143,24 @@ func (es *Eventstore) queueExecutions(ctx context.Context, tx database.Transacti
 	)
 }
 
+// notifyPush sends the notifications about the pushed events,
+// Postgres delivers them as soon as the transaction is committed.
+func (es *Eventstore) notifyPush(ctx context.Context, tx database.Transaction, events []eventstore.Event) error {
+	if !es.pushNotifications {
+		return nil
+	}
+	for _, notification := range eventstore.NewPushNotifications(events) {
+		payload, err := json.Marshal(notification)
+		if err != nil {
+			return err
+		}
+		if _, err = tx.Exec(ctx, "SELECT pg_notify($1, $2)", eventstore.PushNotificationChannel, string(payload)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func eventsToJobArgs(ctx context.Context, events []eventstore.Event) ([]river.JobArgs, error) {
 	if len(events) == 0 {
 		return nil, nil
@@ -11,6 +11,7 @@ import (
 	"go.uber.org/mock/gomock"
 
 	new_db "github.com/zitadel/zitadel/backend/v3/storage/database"
+	"github.com/zitadel/zitadel/backend/v3/storage/database/dbmock"
 	new_pg "github.com/zitadel/zitadel/backend/v3/storage/database/dialect/postgres"
 	"github.com/zitadel/zitadel/backend/v3/storage/database/dialect/sql"
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -430,3 +431,41 @@ func mustNewRequest(t *testing.T, e eventstore.Event, targets []target.Target) *
 	require.NoError(t, err, "exec_repo.NewRequest")
 	return req
 }
+
+func TestEventstore_notifyPush(t *testing.T) {
+	events := []eventstore.Event{
+		mockEventType(mockAggregateWithInstance("1", "instance1"), 1, nil, "ex.foo"),
+		mockEventType(mockAggregateWithInstance("2", "instance2"), 1, nil, "ex.bar"),
+	}
+	tests := []struct {
+		name              string
+		pushNotifications bool
+		tx                func(t *testing.T) new_db.Transaction
+	}{
+		{
+			name:              "disabled, noop",
+			pushNotifications: false,
+			tx: func(t *testing.T) new_db.Transaction {
+				return dbmock.NewMockTransaction(gomock.NewController(t))
+			},
+		},
+		{
+			name:              "notification per instance",
+			pushNotifications: true,
+			tx: func(t *testing.T) new_db.Transaction {
+				tx := dbmock.NewMockTransaction(gomock.NewController(t))
+				tx.EXPECT().Exec(gomock.Any(), "SELECT pg_notify($1, $2)", eventstore.PushNotificationChannel, gomock.Any()).
+					Times(2).
+					Return(int64(1), nil)
+				return tx
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			es := &Eventstore{pushNotifications: tt.pushNotifications}
+			err := es.notifyPush(context.Background(), tt.tx(t), events)
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+package eventstore
+
+import (
+	"context"
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/zitadel/zitadel/backend/v3/storage/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	//go:embed snapshot_query.sql
+	snapshotQueryStmt string
+	//go:embed snapshot_set.sql
+	snapshotSetStmt string
+
+	_ eventstore.SnapshotStore = (*Eventstore)(nil)
+)
+
+// Snapshot implements [eventstore.SnapshotStore]
+func (es *Eventstore) Snapshot(ctx context.Context, instanceID string, aggregateType eventstore.AggregateType, aggregateID, modelType, modelVersion string) (_ *eventstore.Snapshot, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	snapshot := &eventstore.Snapshot{
+		InstanceID:    instanceID,
+		AggregateType: aggregateType,
+		AggregateID:   aggregateID,
+		ModelType:     modelType,
+		ModelVersion:  modelVersion,
+	}
+	err = es.client.QueryRow(ctx, snapshotQueryStmt, instanceID, aggregateType, aggregateID, modelType, modelVersion).
+		Scan(&snapshot.ResourceOwner, &snapshot.Sequence, &snapshot.ChangeDate, &snapshot.Payload)
+	if errors.Is(err, new(database.NoRowFoundError)) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Sn4pq", "Errors.Internal")
+	}
+	return snapshot, nil
+}
+
+// SetSnapshots implements [eventstore.SnapshotStore]
+func (es *Eventstore) SetSnapshots(ctx context.Context, snapshots ...*eventstore.Snapshot) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if len(snapshots) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(snapshots))
+	args := make([]any, 0, len(snapshots)*9)
+	for i, snapshot := range snapshots {
+		placeholders[i] = fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)",
+			len(args)+1, len(args)+2, len(args)+3, len(args)+4, len(args)+5, len(args)+6, len(args)+7, len(args)+8, len(args)+9,
+		)
+		args = append(args,
+			snapshot.InstanceID,
+			snapshot.AggregateType,
+			snapshot.AggregateID,
+			snapshot.ModelType,
+			snapshot.ModelVersion,
+			snapshot.ResourceOwner,
+			snapshot.Sequence,
+			snapshot.ChangeDate,
+			string(snapshot.Payload),
+		)
+	}
+	if _, err = es.client.Exec(ctx, fmt.Sprintf(snapshotSetStmt, strings.Join(placeholders, ", ")), args...); err != nil {
+		return zerrors.ThrowInternal(err, "V3-Wq7ns", "Errors.Internal")
+	}
+	return nil
+}
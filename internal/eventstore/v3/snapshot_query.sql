@@ -0,0 +1,13 @@
+SELECT
+    resource_owner
+    , "sequence"
+    , change_date
+    , payload
+FROM
+    eventstore.snapshots
+WHERE
+    instance_id = $1
+    AND aggregate_type = $2
+    AND aggregate_id = $3
+    AND model_type = $4
+    AND model_version = $5
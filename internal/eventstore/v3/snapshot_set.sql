@@ -0,0 +1,23 @@
+INSERT INTO eventstore.snapshots (
+    instance_id
+    , aggregate_type
+    , aggregate_id
+    , model_type
+    , model_version
+    , resource_owner
+    , "sequence"
+    , change_date
+    , payload
+) VALUES
+    %s
+ON CONFLICT (instance_id, aggregate_type, aggregate_id, model_type) DO UPDATE SET
+    model_version = EXCLUDED.model_version
+    , resource_owner = EXCLUDED.resource_owner
+    , "sequence" = EXCLUDED."sequence"
+    , change_date = EXCLUDED.change_date
+    , payload = EXCLUDED.payload
+    , created_at = NOW()
+-- snapshots of other versions are invalid and replaced
+WHERE
+    snapshots.model_version <> EXCLUDED.model_version
+    OR snapshots."sequence" < EXCLUDED."sequence"
@@ -0,0 +1,84 @@
+package eventstore
+
+import (
+	"context"
+
+	"github.com/shopspring/decimal"
+
+	"github.com/zitadel/zitadel/backend/v3/storage/database"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var _ eventstore.UserAgentStore = (*Eventstore)(nil)
+
+// WithRecordUserAgentsOption stores the user agent of the request which pushed the events.
+func WithRecordUserAgentsOption(enabled bool) EventstoreOption {
+	return func(es *Eventstore) {
+		es.recordUserAgents = enabled
+	}
+}
+
+// UserAgents implements [eventstore.UserAgentStore]
+func (es *Eventstore) UserAgents(ctx context.Context, instanceID string, positions ...decimal.Decimal) (_ map[string]string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	rows, err := es.client.Query(ctx,
+		`SELECT "position", user_agent FROM eventstore.event_user_agents WHERE instance_id = $1 AND "position" = ANY($2)`,
+		instanceID, positions,
+	)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Ua4gd", "Errors.Internal")
+	}
+	defer rows.Close()
+
+	agents := make(map[string]string, len(positions))
+	for rows.Next() {
+		var (
+			position decimal.Decimal
+			agent    string
+		)
+		if err = rows.Scan(&position, &agent); err != nil {
+			return nil, zerrors.ThrowInternal(err, "V3-Kx9pe", "Errors.Internal")
+		}
+		agents[position.String()] = agent
+	}
+	if err = rows.Err(); err != nil {
+		return nil, zerrors.ThrowInternal(err, "V3-Wm2rj", "Errors.Internal")
+	}
+	return agents, nil
+}
+
+// recordUserAgent stores the user agent of the request for the position of the pushed events.
+// Pushes without a request, for example by projections or the queue, are not recorded.
+func (es *Eventstore) recordUserAgent(ctx context.Context, tx database.Transaction, events []eventstore.Event) (err error) {
+	if !es.recordUserAgents || len(events) == 0 {
+		return nil
+	}
+	agent := http_util.UserAgentFromCtx(ctx)
+	if agent == "" {
+		return nil
+	}
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	recorded := make(map[string]struct{}, 1)
+	for _, event := range events {
+		instanceID := event.Aggregate().InstanceID
+		if _, ok := recorded[instanceID]; ok {
+			continue
+		}
+		recorded[instanceID] = struct{}{}
+		_, err = tx.Exec(ctx,
+			`INSERT INTO eventstore.event_user_agents (instance_id, "position", user_agent) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`,
+			instanceID, event.Position(), agent,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,11 +3,7 @@ package execution
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
-	"crypto/rsa"
-	"crypto/x509"
 	"encoding/json"
-	"encoding/pem"
 	"io"
 	"net"
 	"net/http"
@@ -168,24 +164,9 @@ func loadEncrypter(target target_domain.Target, encrypters *sync.Map) (jose.Encr
 	if len(encryptionKey) == 0 {
 		return nil, zerrors.ThrowInternal(nil, "EXEC-2n8fhs7g", "Errors.Execution.MissingEncryptionKey")
 	}
-	publicKey, algorithm, err := publicKeyFromBytes(encryptionKey)
+	encrypter, err := crypto.NewNestedJWTEncrypter(encryptionKey, target.GetEncryptionKeyID())
 	if err != nil {
-		return nil, err
-	}
-
-	encrypter, err := jose.NewEncrypter(
-		jose.A256GCM,
-		jose.Recipient{
-			Algorithm: algorithm,
-			Key:       publicKey,
-			KeyID:     target.GetEncryptionKeyID(),
-		},
-		(&jose.EncrypterOptions{}).
-			WithType("JWT").
-			WithContentType("JWT"),
-	)
-	if err != nil {
-		return nil, err
+		return nil, zerrors.ThrowInternal(err, "EXEC-3n8fhs7g", "Errors.Execution.InvalidPublicKey")
 	}
 	encrypters.Store(target.GetEncryptionKeyID(), encrypter)
 	return encrypter, nil
@@ -218,25 +199,6 @@ func payloadJWE(
 	return []byte(crypted), nil
 }
 
-func publicKeyFromBytes(data []byte) (any, jose.KeyAlgorithm, error) {
-	block, _ := pem.Decode(data)
-	if block == nil {
-		return nil, "", zerrors.ThrowInternal(nil, "EXEC-3n8fhs7g", "Errors.Execution.InvalidPublicKey")
-	}
-	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
-	if err != nil {
-		return nil, "", err
-	}
-	switch pk := publicKey.(type) {
-	case *rsa.PublicKey:
-		return pk, jose.RSA_OAEP_256, nil
-	case *ecdsa.PublicKey:
-		return pk, jose.ECDH_ES_A256KW, nil
-	default:
-		return nil, "", zerrors.ThrowInternal(nil, "EXEC-NKJe2", "Errors.Execution.InvalidPublicKey")
-	}
-}
-
 // webhook call a webhook, ignore the response but return the errror
 func webhook(ctx context.Context, url string, timeout time.Duration, body []byte, signingKey string) error {
 	_, err := Call(ctx, url, timeout, body, signingKey)
@@ -0,0 +1,15 @@
+package ldapsync
+
+type Config struct {
+	Enabled bool
+	// Interval at which due synchronizations are scheduled, in the format of a cron expression.
+	Interval string
+	// MaxAttempts of a single synchronization job.
+	MaxAttempts uint8
+	// Workers is the amount of synchronizations run in parallel.
+	Workers int
+	// BulkLimit is the maximum amount of synchronizations scheduled per interval.
+	BulkLimit uint64
+	// PageSize is the amount of entries requested from the directory at once.
+	PageSize uint32
+}
@@ -0,0 +1,18 @@
+package ldapsync
+
+// ScheduleSyncs is the periodic job, which schedules a [SyncDirectory] job for every due synchronization.
+type ScheduleSyncs struct{}
+
+func (*ScheduleSyncs) Kind() string {
+	return "ldap_sync_schedule"
+}
+
+// SyncDirectory is the job running a single synchronization of an LDAP IdP.
+type SyncDirectory struct {
+	InstanceID string
+	IDPID      string
+}
+
+func (*SyncDirectory) Kind() string {
+	return "ldap_sync"
+}
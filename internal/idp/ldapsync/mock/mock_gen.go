@@ -0,0 +1,5 @@
+package mock
+
+//go:generate mockgen -package mock -destination commands.mock.go github.com/zitadel/zitadel/internal/idp/ldapsync Commands
+//go:generate mockgen -package mock -destination queries.mock.go github.com/zitadel/zitadel/internal/idp/ldapsync Queries
+//go:generate mockgen -package mock -destination queue.mock.go github.com/zitadel/zitadel/internal/idp/ldapsync Queue
@@ -0,0 +1,461 @@
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/mapping"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const defaultPageSize = 100
+
+var (
+	ErrMissingExternalID = errors.New("entry has no id attribute")
+	// ErrNoEntries prevents the deactivation of all users, e.g. in case of a misconfigured filter.
+	ErrNoEntries = errors.New("no entries found")
+)
+
+// syncer holds the state of a single synchronization run.
+type syncer struct {
+	*Worker
+
+	idpID     string
+	orgID     string
+	config    domain.LDAPSyncConfig
+	cursor    string
+	directory Directory
+	mapper    *mapping.Mapper
+	run       *domain.LDAPSyncRun
+
+	// links are the users of the organization linked to the IdP by their external ID
+	links map[string]*query.IDPUserLink
+	// seen are the external IDs found in the directory during the run
+	seen map[string]struct{}
+	// deactivated are the users deactivated by previous runs, only they are reactivated by the sync
+	deactivated []string
+
+	emailCodeGenerator crypto.Generator
+	phoneCodeGenerator crypto.Generator
+}
+
+func (w *Worker) newSyncer(ctx context.Context, writeModel *command.LDAPSyncWriteModel, directory Directory, run *domain.LDAPSyncRun) (_ *syncer, err error) {
+	s := &syncer{
+		Worker:    w,
+		idpID:     writeModel.ID,
+		orgID:     writeModel.Config.OrganizationID,
+		config:    writeModel.Config,
+		directory: directory,
+		run:       run,
+		seen:      make(map[string]struct{}),
+
+		deactivated: writeModel.DeactivatedUserIDs,
+	}
+	if !run.FullSync {
+		s.cursor = writeModel.Cursor
+	}
+	template, err := w.queries.IDPTemplateByID(ctx, false, s.idpID, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	if len(template.MappingRules) > 0 {
+		if s.mapper, err = mapping.New(template.MappingRules); err != nil {
+			return nil, err
+		}
+	}
+	if s.links, err = s.userLinks(ctx); err != nil {
+		return nil, err
+	}
+	if s.emailCodeGenerator, err = w.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyEmailCode, w.userCodeAlg); err != nil {
+		return nil, err
+	}
+	if s.phoneCodeGenerator, err = w.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyPhoneCode, w.userCodeAlg); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *syncer) userLinks(ctx context.Context) (map[string]*query.IDPUserLink, error) {
+	idpIDQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(s.idpID)
+	if err != nil {
+		return nil, err
+	}
+	resourceOwnerQuery, err := query.NewIDPUserLinksResourceOwnerSearchQuery(s.orgID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpIDQuery, resourceOwnerQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	userLinks := make(map[string]*query.IDPUserLink, len(links.Links))
+	for _, link := range links.Links {
+		userLinks[link.ProvidedUserID] = link
+	}
+	return userLinks, nil
+}
+
+func (s *syncer) sync(ctx context.Context) error {
+	deltaAttribute := s.config.DeltaAttribute.Name()
+	attributes := s.directory.UserAttributes()
+	if deltaAttribute != "" {
+		attributes = append(attributes, deltaAttribute)
+	}
+	if s.mapper != nil {
+		// mapping rules might use any (operational) attribute of the entry
+		attributes = append(attributes, "*", "+")
+	}
+	err := s.directory.SearchUsers(
+		ctx,
+		s.directory.UserSearchFilter(s.config.Filter, deltaAttribute, s.cursor),
+		attributes,
+		s.pageSize(),
+		func(entries []*goldap.Entry) error {
+			for _, entry := range entries {
+				if err := s.syncEntry(ctx, entry); err != nil {
+					s.run.Stats.Failed++
+					logging.WithFields("idp", s.idpID, "dn", entry.DN).WithError(err).Info("unable to sync ldap entry")
+				}
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return err
+	}
+	s.run.Cursor = s.cursor
+	if !s.run.FullSync || !s.config.DeactivateMissing {
+		return nil
+	}
+	return s.deactivateMissing(ctx)
+}
+
+func (s *syncer) pageSize() uint32 {
+	if s.Worker.config.PageSize == 0 {
+		return defaultPageSize
+	}
+	return s.Worker.config.PageSize
+}
+
+func (s *syncer) syncEntry(ctx context.Context, entry *goldap.Entry) error {
+	if deltaAttribute := s.config.DeltaAttribute.Name(); deltaAttribute != "" {
+		s.cursor = laterCursor(s.config.DeltaAttribute, s.cursor, entry.GetAttributeValue(deltaAttribute))
+	}
+	user, err := s.directory.MapEntry(entry)
+	if err != nil {
+		return err
+	}
+	if user.GetID() == "" {
+		return ErrMissingExternalID
+	}
+	s.seen[user.GetID()] = struct{}{}
+	externalUser := s.mapUser(user, entry)
+
+	link, ok := s.links[externalUser.ExternalUserID]
+	if !ok {
+		userID, err := s.createUser(ctx, externalUser)
+		if err != nil {
+			return err
+		}
+		s.run.Stats.Created++
+		return s.reconcileAssignments(ctx, userID, externalUser)
+	}
+	changed, err := s.updateUser(ctx, link, externalUser)
+	if err != nil {
+		return err
+	}
+	if changed {
+		s.run.Stats.Updated++
+	} else {
+		s.run.Stats.Unchanged++
+	}
+	return s.reconcileAssignments(ctx, link.UserID, externalUser)
+}
+
+// mapUser evaluates the mapping rules of the IdP (if any) on the entry.
+// Failing rules are ignored, so the value of the directory is used instead.
+func (s *syncer) mapUser(user *ldap.User, entry *goldap.Entry) *domain.ExternalUser {
+	if s.mapper == nil {
+		return s.externalUser(user)
+	}
+	logger := logging.WithFields("idp", s.idpID, "dn", entry.DN)
+	input, err := mapping.InputFromUser(user, &ldap.Session{Entry: entry})
+	if err != nil {
+		logger.WithError(err).Warn("unable to read ldap entry for mapping")
+		return s.externalUser(user)
+	}
+	result, err := s.mapper.Map(input)
+	if err != nil {
+		logger.WithError(err).Warn("unable to map ldap entry")
+		return s.externalUser(user)
+	}
+	for _, ruleErr := range result.Errors {
+		logger.WithError(ruleErr).Info("idp mapping rule failed")
+	}
+	externalUser := s.externalUser(result.User(user))
+	externalUser.Metadatas = result.MetadataList()
+	externalUser.Assignments = result.Assignments()
+	return externalUser
+}
+
+func (s *syncer) externalUser(user idp.User) *domain.ExternalUser {
+	return &domain.ExternalUser{
+		IDPConfigID:       s.idpID,
+		ExternalUserID:    user.GetID(),
+		PreferredUsername: user.GetPreferredUsername(),
+		DisplayName:       user.GetDisplayName(),
+		FirstName:         user.GetFirstName(),
+		LastName:          user.GetLastName(),
+		NickName:          user.GetNickname(),
+		Email:             user.GetEmail(),
+		IsEmailVerified:   user.IsEmailVerified(),
+		PreferredLanguage: user.GetPreferredLanguage(),
+		Phone:             user.GetPhone(),
+		IsPhoneVerified:   user.IsPhoneVerified(),
+	}
+}
+
+// createUser adds a new user linked to the IdP.
+// No verification codes are sent, since the user did not request the creation.
+func (s *syncer) createUser(ctx context.Context, externalUser *domain.ExternalUser) (string, error) {
+	human := &command.AddHuman{
+		Username:          username(externalUser),
+		FirstName:         externalUser.FirstName,
+		LastName:          externalUser.LastName,
+		NickName:          externalUser.NickName,
+		DisplayName:       externalUser.DisplayName,
+		PreferredLanguage: externalUser.PreferredLanguage,
+		Email: command.Email{
+			Address:             externalUser.Email,
+			Verified:            externalUser.IsEmailVerified,
+			NoEmailVerification: true,
+		},
+		Phone: command.Phone{
+			Number:     externalUser.Phone,
+			Verified:   externalUser.IsPhoneVerified,
+			ReturnCode: true,
+		},
+		ExternalIDP: true,
+		Links: []*command.AddLink{
+			{
+				IDPID:         s.idpID,
+				DisplayName:   externalUser.PreferredUsername,
+				IDPExternalID: externalUser.ExternalUserID,
+			},
+		},
+	}
+	for _, metadata := range externalUser.Metadatas {
+		human.Metadata = append(human.Metadata, &command.AddMetadataEntry{Key: metadata.Key, Value: metadata.Value})
+	}
+	if err := s.commands.AddHuman(ctx, s.orgID, human, false); err != nil {
+		return "", err
+	}
+	s.links[externalUser.ExternalUserID] = &query.IDPUserLink{
+		IDPID:            s.idpID,
+		UserID:           human.ID,
+		ProvidedUserID:   externalUser.ExternalUserID,
+		ProvidedUsername: externalUser.PreferredUsername,
+		ResourceOwner:    s.orgID,
+	}
+	return human.ID, nil
+}
+
+func username(externalUser *domain.ExternalUser) string {
+	if externalUser.PreferredUsername != "" {
+		return externalUser.PreferredUsername
+	}
+	if externalUser.Email != "" {
+		return string(externalUser.Email)
+	}
+	return externalUser.ExternalUserID
+}
+
+// updateUser applies the changed values of the directory to the user, the same way an update on login does.
+// Users deactivated by a previous run are reactivated, users deactivated by an administrator are kept inactive.
+func (s *syncer) updateUser(ctx context.Context, link *query.IDPUserLink, externalUser *domain.ExternalUser) (changed bool, err error) {
+	user, err := s.queries.GetUserByID(ctx, true, link.UserID)
+	if err != nil {
+		return false, err
+	}
+	if user.Human == nil {
+		return false, zerrors.ThrowPreconditionFailed(nil, "LDAPS-Hn5dc", "Errors.User.NotHuman")
+	}
+	if slices.Contains(s.deactivated, user.ID) {
+		if user.State == domain.UserStateInactive {
+			if _, err = s.commands.ReactivateUser(ctx, user.ID, user.ResourceOwner); err != nil {
+				return false, err
+			}
+			s.run.Stats.Reactivated++
+		}
+		// a user reactivated by an administrator might be deactivated on purpose later on
+		s.run.ReleasedUserIDs = append(s.run.ReleasedUserIDs, user.ID)
+	}
+	if emailChanged(user, externalUser) {
+		_, err = s.commands.ChangeHumanEmail(ctx,
+			&domain.Email{
+				ObjectRoot:      models.ObjectRoot{AggregateID: user.ID, ResourceOwner: user.ResourceOwner},
+				EmailAddress:    externalUser.Email,
+				IsEmailVerified: externalUser.IsEmailVerified,
+			},
+			s.emailCodeGenerator,
+		)
+		if err != nil {
+			return false, err
+		}
+		changed = true
+	}
+	phoneChanged, err := phoneChanged(user, externalUser)
+	if err != nil {
+		return changed, err
+	}
+	if phoneChanged {
+		_, err = s.commands.ChangeHumanPhone(ctx,
+			&domain.Phone{
+				ObjectRoot:      models.ObjectRoot{AggregateID: user.ID},
+				PhoneNumber:     externalUser.Phone,
+				IsPhoneVerified: externalUser.IsPhoneVerified,
+			},
+			user.ResourceOwner,
+			s.phoneCodeGenerator,
+		)
+		if err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+	if profileChanged(user, externalUser) {
+		_, err = s.commands.ChangeHumanProfile(ctx, &domain.Profile{
+			ObjectRoot:        models.ObjectRoot{AggregateID: user.ID, ResourceOwner: user.ResourceOwner},
+			FirstName:         externalUser.FirstName,
+			LastName:          externalUser.LastName,
+			NickName:          externalUser.NickName,
+			DisplayName:       externalUser.DisplayName,
+			PreferredLanguage: externalUser.PreferredLanguage,
+			Gender:            user.Human.Gender,
+		})
+		if err != nil {
+			return changed, err
+		}
+		changed = true
+	}
+	if link.ProvidedUsername != externalUser.PreferredUsername {
+		err = s.commands.UpdateUserIDPLinkUsername(ctx, user.ID, user.ResourceOwner, s.idpID, externalUser.ExternalUserID, externalUser.PreferredUsername)
+		if err != nil {
+			return changed, err
+		}
+		link.ProvidedUsername = externalUser.PreferredUsername
+		changed = true
+	}
+	return changed, nil
+}
+
+func emailChanged(user *query.User, externalUser *domain.ExternalUser) bool {
+	externalUser.Email = externalUser.Email.Normalize()
+	if externalUser.Email == "" {
+		return false
+	}
+	// ignore if the same email is not set to verified anymore
+	if externalUser.Email == user.Human.Email && user.Human.IsEmailVerified {
+		return false
+	}
+	return externalUser.Email != user.Human.Email || externalUser.IsEmailVerified != user.Human.IsEmailVerified
+}
+
+func phoneChanged(user *query.User, externalUser *domain.ExternalUser) (_ bool, err error) {
+	if externalUser.Phone == "" {
+		return false, nil
+	}
+	externalUser.Phone, err = externalUser.Phone.Normalize()
+	if err != nil {
+		return false, err
+	}
+	// ignore if the same phone is not set to verified anymore
+	if externalUser.Phone == user.Human.Phone && user.Human.IsPhoneVerified {
+		return false, nil
+	}
+	return externalUser.Phone != user.Human.Phone || externalUser.IsPhoneVerified != user.Human.IsPhoneVerified, nil
+}
+
+func profileChanged(user *query.User, externalUser *domain.ExternalUser) bool {
+	return externalUser.FirstName != user.Human.FirstName ||
+		externalUser.LastName != user.Human.LastName ||
+		externalUser.NickName != user.Human.NickName ||
+		externalUser.DisplayName != user.Human.DisplayName ||
+		externalUser.PreferredLanguage != user.Human.PreferredLanguage
+}
+
+// reconcileAssignments applies the project roles and groups mapped by the rules of the IdP.
+func (s *syncer) reconcileAssignments(ctx context.Context, userID string, externalUser *domain.ExternalUser) error {
+	if externalUser.Assignments == nil {
+		return nil
+	}
+	details, err := s.commands.ReconcileIDPAssignments(ctx, userID, s.orgID, s.idpID, externalUser.Assignments)
+	if err != nil {
+		return err
+	}
+	if len(details.InvalidGroupIDs) > 0 {
+		logging.WithFields("idp", s.idpID, "user", userID, "groups", details.InvalidGroupIDs).Warn("mapped groups not found")
+	}
+	return nil
+}
+
+// deactivateMissing deactivates the linked users which were not found in the directory during a full run.
+func (s *syncer) deactivateMissing(ctx context.Context) error {
+	if len(s.seen) == 0 && len(s.links) > 0 {
+		return ErrNoEntries
+	}
+	for externalID, link := range s.links {
+		if _, ok := s.seen[externalID]; ok {
+			continue
+		}
+		_, err := s.commands.DeactivateUser(ctx, link.UserID, link.ResourceOwner)
+		// the user is already inactive
+		if zerrors.IsPreconditionFailed(err) {
+			continue
+		}
+		if err != nil {
+			s.run.Stats.Failed++
+			logging.WithFields("idp", s.idpID, "user", link.UserID).WithError(err).Info("unable to deactivate user")
+			continue
+		}
+		s.run.Stats.Deactivated++
+		s.run.DeactivatedUserIDs = append(s.run.DeactivatedUserIDs, link.UserID)
+	}
+	slices.Sort(s.run.DeactivatedUserIDs)
+	return nil
+}
+
+// laterCursor returns the later of the two values of the delta attribute.
+// USNs are compared as numbers, timestamps in the generalized time format of the directory can be compared as strings.
+func laterCursor(attribute domain.LDAPSyncDeltaAttribute, current, value string) string {
+	if value == "" {
+		return current
+	}
+	if current == "" {
+		return value
+	}
+	if attribute == domain.LDAPSyncDeltaAttributeUSNChanged {
+		currentUSN, err := strconv.ParseUint(current, 10, 64)
+		if err != nil {
+			return value
+		}
+		usn, err := strconv.ParseUint(value, 10, 64)
+		if err != nil || usn <= currentUSN {
+			return current
+		}
+		return value
+	}
+	return max(current, value)
+}
@@ -0,0 +1,226 @@
+package ldapsync
+
+import (
+	"context"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/riverqueue/river"
+	"github.com/robfig/cron/v3"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	QueueName = "ldap_sync"
+	// SyncUserID is the editor of all changes made by a synchronization.
+	SyncUserID = "LDAP-SYNC"
+)
+
+var (
+	_ river.Worker[*ScheduleSyncs] = (*Scheduler)(nil)
+	_ river.Worker[*SyncDirectory] = (*Worker)(nil)
+)
+
+type Commands interface {
+	LDAPSync(ctx context.Context, id string) (*command.LDAPSyncWriteModel, error)
+	FinishLDAPSyncRun(ctx context.Context, id string, run *domain.LDAPSyncRun) (*domain.ObjectDetails, error)
+	AddHuman(ctx context.Context, resourceOwner string, human *command.AddHuman, allowInitMail bool) error
+	ChangeHumanProfile(ctx context.Context, profile *domain.Profile) (*domain.Profile, error)
+	ChangeHumanEmail(ctx context.Context, email *domain.Email, emailCodeGenerator crypto.Generator) (*domain.Email, error)
+	ChangeHumanPhone(ctx context.Context, phone *domain.Phone, resourceOwner string, phoneCodeGenerator crypto.Generator) (*domain.Phone, error)
+	UpdateUserIDPLinkUsername(ctx context.Context, userID, orgID, idpConfigID, externalID, newUsername string) error
+	DeactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+	ReactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+	ReconcileIDPAssignments(ctx context.Context, userID, resourceOwner, idpID string, assignments *domain.IDPAssignments) (*command.IDPAssignmentsDetails, error)
+}
+
+type Queries interface {
+	DueLDAPSyncs(ctx context.Context, now time.Time, limit uint64) ([]*query.DueLDAPSync, error)
+	IDPTemplateByID(ctx context.Context, shouldTriggerBulk bool, id string, withOwnerRemoved bool, permissionCheck domain.PermissionCheck, queries ...query.SearchQuery) (*query.IDPTemplate, error)
+	IDPUserLinks(ctx context.Context, queries *query.IDPUserLinksSearchQuery, permissionCheck domain.PermissionCheck) (*query.IDPUserLinks, error)
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string) (*query.User, error)
+	InitEncryptionGenerator(ctx context.Context, generatorType domain.SecretGeneratorType, algorithm crypto.EncryptionAlgorithm) (crypto.Generator, error)
+}
+
+type Queue interface {
+	Insert(ctx context.Context, args river.JobArgs, opts ...queue.InsertOpt) error
+}
+
+// Directory is the LDAP server searched by a synchronization, implemented by [ldap.Provider].
+type Directory interface {
+	UserAttributes() []string
+	UserSearchFilter(filter, deltaAttribute, cursor string) string
+	SearchUsers(ctx context.Context, filter string, attributes []string, pageSize uint32, fn func(entries []*goldap.Entry) error) error
+	MapEntry(entry *goldap.Entry) (*ldap.User, error)
+}
+
+// Scheduler periodically schedules the due synchronizations of all instances.
+type Scheduler struct {
+	river.WorkerDefaults[*ScheduleSyncs]
+
+	queries Queries
+	queue   Queue
+	config  *Config
+	now     func() time.Time
+}
+
+// Register implements the [queue.Worker] interface.
+func (s *Scheduler) Register(workers *river.Workers, _ map[string]river.QueueConfig) {
+	river.AddWorker[*ScheduleSyncs](workers, s)
+}
+
+// Work implements the [river.Worker] interface.
+func (s *Scheduler) Work(ctx context.Context, _ *river.Job[*ScheduleSyncs]) error {
+	syncs, err := s.queries.DueLDAPSyncs(ctx, s.now(), s.config.BulkLimit)
+	if err != nil {
+		return err
+	}
+	for _, sync := range syncs {
+		// a synchronization which is still queued or running is not scheduled again
+		err = s.queue.Insert(ctx,
+			&SyncDirectory{
+				InstanceID: sync.InstanceID,
+				IDPID:      sync.IDPID,
+			},
+			queue.WithQueueName(QueueName),
+			queue.WithMaxAttempts(s.config.MaxAttempts),
+			queue.WithUniqueArgs(),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Worker runs the synchronization of a single LDAP IdP.
+type Worker struct {
+	river.WorkerDefaults[*SyncDirectory]
+
+	commands    Commands
+	queries     Queries
+	directory   func(ctx context.Context, idpID string) (Directory, error)
+	userCodeAlg crypto.EncryptionAlgorithm
+	config      *Config
+	now         func() time.Time
+}
+
+// Register implements the [queue.Worker] interface.
+func (w *Worker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker[*SyncDirectory](workers, w)
+	queues[QueueName] = river.QueueConfig{
+		MaxWorkers: w.config.Workers,
+	}
+}
+
+// Work implements the [river.Worker] interface.
+// Failures of the synchronization itself are recorded on the run and not retried,
+// the next run is scheduled after the configured interval.
+func (w *Worker) Work(ctx context.Context, job *river.Job[*SyncDirectory]) error {
+	ctx = authz.WithInstanceID(authz.SetCtxData(ctx, authz.CtxData{UserID: SyncUserID}), job.Args.InstanceID)
+	writeModel, err := w.commands.LDAPSync(ctx, job.Args.IDPID)
+	if zerrors.IsNotFound(err) {
+		return river.JobCancel(err)
+	}
+	if err != nil {
+		return err
+	}
+	if !writeModel.Config.Enabled {
+		return nil
+	}
+	run := &domain.LDAPSyncRun{
+		StartedAt: w.now(),
+		FullSync:  writeModel.NeedsFullSync(w.now()),
+	}
+	if err = w.sync(ctx, writeModel, run); err != nil {
+		logging.WithFields("instance", job.Args.InstanceID, "idp", job.Args.IDPID).WithError(err).Warn("ldap synchronization failed")
+		run.Error = err.Error()
+	}
+	run.FinishedAt = w.now()
+	_, err = w.commands.FinishLDAPSyncRun(ctx, job.Args.IDPID, run)
+	return err
+}
+
+func (w *Worker) sync(ctx context.Context, writeModel *command.LDAPSyncWriteModel, run *domain.LDAPSyncRun) error {
+	ctx = authz.SetCtxData(ctx, authz.CtxData{UserID: SyncUserID, OrgID: writeModel.Config.OrganizationID})
+	directory, err := w.directory(ctx, writeModel.ID)
+	if err != nil {
+		return err
+	}
+	s, err := w.newSyncer(ctx, writeModel, directory, run)
+	if err != nil {
+		return err
+	}
+	return s.sync(ctx)
+}
+
+// ldapDirectory returns the [ldap.Provider] of the IdP as [Directory].
+func ldapDirectory(commands *command.Commands) func(ctx context.Context, idpID string) (Directory, error) {
+	return func(ctx context.Context, idpID string) (Directory, error) {
+		provider, err := commands.GetProvider(ctx, idpID, "", "")
+		if err != nil {
+			return nil, err
+		}
+		directory, ok := provider.(*ldap.Provider)
+		if !ok {
+			return nil, zerrors.ThrowPreconditionFailed(nil, "LDAPS-Pq4rt", "Errors.IDP.LDAPSyncNotSupported")
+		}
+		return directory, nil
+	}
+}
+
+func Register(
+	ctx context.Context,
+	q *queue.Queue,
+	commands *command.Commands,
+	queries *query.Queries,
+	userCodeAlg crypto.EncryptionAlgorithm,
+	config *Config,
+) {
+	if !config.Enabled {
+		return
+	}
+	q.AddWorkers(ctx,
+		&Scheduler{
+			queries: queries,
+			queue:   q,
+			config:  config,
+			now:     time.Now,
+		},
+		&Worker{
+			commands:    commands,
+			queries:     queries,
+			directory:   ldapDirectory(commands),
+			userCodeAlg: userCodeAlg,
+			config:      config,
+			now:         time.Now,
+		},
+	)
+}
+
+func Start(ctx context.Context, config *Config, q *queue.Queue) error {
+	if !config.Enabled {
+		return nil
+	}
+	schedule, err := cron.ParseStandard(config.Interval)
+	if err != nil {
+		return zerrors.ThrowInvalidArgument(err, "LDAPS-Wv8cz", "invalid interval")
+	}
+	q.AddPeriodicJob(
+		ctx,
+		schedule,
+		&ScheduleSyncs{},
+		queue.WithQueueName(QueueName),
+		queue.WithMaxAttempts(1),
+	)
+	return nil
+}
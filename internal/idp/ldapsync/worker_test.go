@@ -0,0 +1,432 @@
+package ldapsync
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/riverqueue/river"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/ldapsync/mock"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	testNow     = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	errTest     = errors.New("test error")
+	testConfig  = &Config{Enabled: true, MaxAttempts: 3, BulkLimit: 10, PageSize: 1}
+	testFilter  = "(&(objectClass=person)(department=sales))"
+	deltaFilter = "(&(objectClass=person)(department=sales)(modifyTimestamp>=20240101080000Z))"
+)
+
+// directory is a stand-in for an LDAP server, which returns the entries page by page.
+type directory struct {
+	*ldap.Provider
+
+	t              *testing.T
+	expectedFilter string
+	entries        []*goldap.Entry
+	err            error
+}
+
+func newDirectory(t *testing.T, expectedFilter string, entries ...*goldap.Entry) *directory {
+	return &directory{
+		Provider: ldap.New("ldap", nil, "dc=example,dc=com", "", "", "uid", []string{"person"}, nil, 0, nil, "",
+			ldap.WithCustomIDAttribute("uid"),
+			ldap.WithFirstNameAttribute("givenName"),
+			ldap.WithLastNameAttribute("sn"),
+			ldap.WithEmailAttribute("mail"),
+		),
+		t:              t,
+		expectedFilter: expectedFilter,
+		entries:        entries,
+	}
+}
+
+func (d *directory) SearchUsers(ctx context.Context, filter string, attributes []string, pageSize uint32, fn func(entries []*goldap.Entry) error) error {
+	assert.Equal(d.t, d.expectedFilter, filter)
+	assert.Contains(d.t, attributes, "uid")
+	if d.err != nil {
+		return d.err
+	}
+	for i := 0; i < len(d.entries); i += int(pageSize) {
+		if err := fn(d.entries[i:min(i+int(pageSize), len(d.entries))]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func entry(uid, modifyTimestamp string) *goldap.Entry {
+	return goldap.NewEntry("uid="+uid+",dc=example,dc=com", map[string][]string{
+		"uid":             {uid},
+		"givenName":       {"first " + uid},
+		"sn":              {"last " + uid},
+		"mail":            {uid + "@example.com"},
+		"modifyTimestamp": {modifyTimestamp},
+	})
+}
+
+func humanUser(uid string, state domain.UserState) *query.User {
+	return &query.User{
+		ID:            "user-" + uid,
+		ResourceOwner: "org",
+		State:         state,
+		Human: &query.Human{
+			FirstName:         "first " + uid,
+			LastName:          "last " + uid,
+			PreferredLanguage: language.Und,
+			Email:             domain.EmailAddress(uid + "@example.com"),
+			IsEmailVerified:   true,
+		},
+	}
+}
+
+func syncWriteModel(config domain.LDAPSyncConfig, cursor string, lastFullSyncAt time.Time) *command.LDAPSyncWriteModel {
+	writeModel := command.NewLDAPSyncWriteModel("idp")
+	writeModel.ResourceOwner = "instance"
+	writeModel.InstanceID = "instance"
+	writeModel.Type = domain.IDPTypeLDAP
+	writeModel.State = domain.IDPStateActive
+	writeModel.Config = config
+	writeModel.Cursor = cursor
+	writeModel.LastFullSyncAt = lastFullSyncAt
+	return writeModel
+}
+
+func expectSetup(queries *mock.MockQueries, links ...*query.IDPUserLink) {
+	queries.EXPECT().IDPTemplateByID(gomock.Any(), false, "idp", false, nil).Return(&query.IDPTemplate{}, nil)
+	queries.EXPECT().IDPUserLinks(gomock.Any(), gomock.Any(), nil).Return(&query.IDPUserLinks{Links: links}, nil)
+	queries.EXPECT().InitEncryptionGenerator(gomock.Any(), domain.SecretGeneratorTypeVerifyEmailCode, nil).Return(nil, nil)
+	queries.EXPECT().InitEncryptionGenerator(gomock.Any(), domain.SecretGeneratorTypeVerifyPhoneCode, nil).Return(nil, nil)
+}
+
+func TestScheduler_Work(t *testing.T) {
+	tests := []struct {
+		name    string
+		queries func(*testing.T) Queries
+		queue   func(*testing.T) Queue
+		wantErr error
+	}{
+		{
+			name: "query error",
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				queries.EXPECT().DueLDAPSyncs(gomock.Any(), testNow, uint64(10)).Return(nil, errTest)
+				return queries
+			},
+			queue: func(t *testing.T) Queue {
+				return mock.NewMockQueue(gomock.NewController(t))
+			},
+			wantErr: errTest,
+		},
+		{
+			name: "insert error",
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				queries.EXPECT().DueLDAPSyncs(gomock.Any(), testNow, uint64(10)).Return([]*query.DueLDAPSync{{InstanceID: "instance", IDPID: "idp"}}, nil)
+				return queries
+			},
+			queue: func(t *testing.T) Queue {
+				q := mock.NewMockQueue(gomock.NewController(t))
+				q.EXPECT().Insert(gomock.Any(), &SyncDirectory{InstanceID: "instance", IDPID: "idp"}, gomock.Any()).Return(errTest)
+				return q
+			},
+			wantErr: errTest,
+		},
+		{
+			name: "due syncs scheduled",
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				queries.EXPECT().DueLDAPSyncs(gomock.Any(), testNow, uint64(10)).Return([]*query.DueLDAPSync{
+					{InstanceID: "instance1", IDPID: "idp1"},
+					{InstanceID: "instance2", IDPID: "idp2"},
+				}, nil)
+				return queries
+			},
+			queue: func(t *testing.T) Queue {
+				q := mock.NewMockQueue(gomock.NewController(t))
+				q.EXPECT().Insert(gomock.Any(), &SyncDirectory{InstanceID: "instance1", IDPID: "idp1"}, gomock.Len(3)).Return(nil)
+				q.EXPECT().Insert(gomock.Any(), &SyncDirectory{InstanceID: "instance2", IDPID: "idp2"}, gomock.Len(3)).Return(nil)
+				return q
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &Scheduler{
+				queries: tt.queries(t),
+				queue:   tt.queue(t),
+				config:  testConfig,
+				now:     func() time.Time { return testNow },
+			}
+			err := s.Work(context.Background(), &river.Job[*ScheduleSyncs]{Args: &ScheduleSyncs{}})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestWorker_Work(t *testing.T) {
+	fullSyncConfig := domain.LDAPSyncConfig{
+		Enabled:           true,
+		OrganizationID:    "org",
+		Filter:            "(department=sales)",
+		Interval:          15 * time.Minute,
+		FullSyncInterval:  24 * time.Hour,
+		DeltaAttribute:    domain.LDAPSyncDeltaAttributeModifyTimestamp,
+		DeactivateMissing: true,
+	}
+	tests := []struct {
+		name      string
+		commands  func(*testing.T) Commands
+		queries   func(*testing.T) Queries
+		directory func(*testing.T) Directory
+		wantErr   func(error) bool
+	}{
+		{
+			name: "idp not found, cancel",
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().LDAPSync(gomock.Any(), "idp").Return(nil, zerrors.ThrowNotFound(nil, "COMMAND-Ht3vw", "Errors.IDPConfig.NotExisting"))
+				return commands
+			},
+			queries: func(t *testing.T) Queries {
+				return mock.NewMockQueries(gomock.NewController(t))
+			},
+			wantErr: func(err error) bool {
+				var cancel *river.JobCancelError
+				return errors.As(err, &cancel)
+			},
+		},
+		{
+			name: "disabled, no run",
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().LDAPSync(gomock.Any(), "idp").Return(syncWriteModel(domain.LDAPSyncConfig{}, "", time.Time{}), nil)
+				return commands
+			},
+			queries: func(t *testing.T) Queries {
+				return mock.NewMockQueries(gomock.NewController(t))
+			},
+		},
+		{
+			name: "directory error, recorded",
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().LDAPSync(gomock.Any(), "idp").Return(syncWriteModel(fullSyncConfig, "", time.Time{}), nil)
+				commands.EXPECT().FinishLDAPSyncRun(gomock.Any(), "idp", &domain.LDAPSyncRun{
+					StartedAt:  testNow,
+					FinishedAt: testNow,
+					FullSync:   true,
+					Error:      errTest.Error(),
+				}).Return(&domain.ObjectDetails{}, nil)
+				return commands
+			},
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				expectSetup(queries)
+				return queries
+			},
+			directory: func(t *testing.T) Directory {
+				d := newDirectory(t, testFilter)
+				d.err = errTest
+				return d
+			},
+		},
+		{
+			name: "full sync, created, unchanged, reactivated and deactivated",
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				writeModel := syncWriteModel(fullSyncConfig, "20240101080000Z", testNow.Add(-25*time.Hour))
+				// user1 was reactivated by an administrator, user6 was deactivated by an administrator
+				writeModel.DeactivatedUserIDs = []string{"user-user1", "user-user4"}
+				commands.EXPECT().LDAPSync(gomock.Any(), "idp").Return(writeModel, nil)
+				commands.EXPECT().AddHuman(gomock.Any(), "org", gomock.Any(), false).DoAndReturn(
+					func(_ context.Context, _ string, human *command.AddHuman, _ bool) error {
+						assert.Equal(t, "user3@example.com", human.Username)
+						assert.True(t, human.ExternalIDP)
+						assert.True(t, human.Email.NoEmailVerification)
+						assert.Equal(t, []*command.AddLink{{IDPID: "idp", IDPExternalID: "user3"}}, human.Links)
+						human.ID = "user-user3"
+						return nil
+					},
+				)
+				commands.EXPECT().ReactivateUser(gomock.Any(), "user-user4", "org").Return(&domain.ObjectDetails{}, nil)
+				commands.EXPECT().DeactivateUser(gomock.Any(), "user-user2", "org").Return(&domain.ObjectDetails{}, nil)
+				commands.EXPECT().DeactivateUser(gomock.Any(), "user-user5", "org").Return(nil, zerrors.ThrowPreconditionFailed(nil, "COMMAND-3M9ds", "Errors.User.AlreadyInactive"))
+				commands.EXPECT().FinishLDAPSyncRun(gomock.Any(), "idp", &domain.LDAPSyncRun{
+					StartedAt:  testNow,
+					FinishedAt: testNow,
+					FullSync:   true,
+					Cursor:     "20240101090000Z",
+					Stats: domain.LDAPSyncStats{
+						Created:     1,
+						Unchanged:   3,
+						Deactivated: 1,
+						Reactivated: 1,
+					},
+					DeactivatedUserIDs: []string{"user-user2"},
+					ReleasedUserIDs:    []string{"user-user1", "user-user4"},
+				}).Return(&domain.ObjectDetails{}, nil)
+				return commands
+			},
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				expectSetup(queries,
+					&query.IDPUserLink{UserID: "user-user1", ProvidedUserID: "user1", ResourceOwner: "org"},
+					&query.IDPUserLink{UserID: "user-user2", ProvidedUserID: "user2", ResourceOwner: "org"},
+					&query.IDPUserLink{UserID: "user-user4", ProvidedUserID: "user4", ResourceOwner: "org"},
+					&query.IDPUserLink{UserID: "user-user5", ProvidedUserID: "user5", ResourceOwner: "org"},
+					&query.IDPUserLink{UserID: "user-user6", ProvidedUserID: "user6", ResourceOwner: "org"},
+				)
+				queries.EXPECT().GetUserByID(gomock.Any(), true, "user-user1").Return(humanUser("user1", domain.UserStateActive), nil)
+				queries.EXPECT().GetUserByID(gomock.Any(), true, "user-user4").Return(humanUser("user4", domain.UserStateInactive), nil)
+				queries.EXPECT().GetUserByID(gomock.Any(), true, "user-user6").Return(humanUser("user6", domain.UserStateInactive), nil)
+				return queries
+			},
+			directory: func(t *testing.T) Directory {
+				return newDirectory(t, testFilter,
+					entry("user1", "20240101090000Z"),
+					entry("user3", "20240101083000Z"),
+					entry("user4", "20240101070000Z"),
+					entry("user6", "20240101070000Z"),
+				)
+			},
+		},
+		{
+			name: "delta sync, updated and failed",
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().LDAPSync(gomock.Any(), "idp").Return(syncWriteModel(fullSyncConfig, "20240101080000Z", testNow.Add(-time.Hour)), nil)
+				commands.EXPECT().ChangeHumanProfile(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, profile *domain.Profile) (*domain.Profile, error) {
+						assert.Equal(t, "user-user1", profile.AggregateID)
+						assert.Equal(t, "first user1", profile.FirstName)
+						return profile, nil
+					},
+				)
+				commands.EXPECT().AddHuman(gomock.Any(), "org", gomock.Any(), false).Return(zerrors.ThrowAlreadyExists(nil, "COMMAND-k2unb", "Errors.User.AlreadyExisting"))
+				commands.EXPECT().FinishLDAPSyncRun(gomock.Any(), "idp", &domain.LDAPSyncRun{
+					StartedAt:  testNow,
+					FinishedAt: testNow,
+					Cursor:     "20240101083000Z",
+					Stats: domain.LDAPSyncStats{
+						Updated: 1,
+						Failed:  1,
+					},
+				}).Return(&domain.ObjectDetails{}, nil)
+				return commands
+			},
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				expectSetup(queries,
+					&query.IDPUserLink{UserID: "user-user1", ProvidedUserID: "user1", ResourceOwner: "org"},
+					&query.IDPUserLink{UserID: "user-user2", ProvidedUserID: "user2", ResourceOwner: "org"},
+				)
+				user := humanUser("user1", domain.UserStateActive)
+				user.Human.FirstName = "old"
+				queries.EXPECT().GetUserByID(gomock.Any(), true, "user-user1").Return(user, nil)
+				return queries
+			},
+			directory: func(t *testing.T) Directory {
+				return newDirectory(t, deltaFilter,
+					entry("user1", "20240101083000Z"),
+					entry("user3", "20240101081000Z"),
+				)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Worker{
+				commands: tt.commands(t),
+				queries:  tt.queries(t),
+				directory: func(context.Context, string) (Directory, error) {
+					require.NotNil(t, tt.directory, "unexpected directory access")
+					return tt.directory(t), nil
+				},
+				config: testConfig,
+				now:    func() time.Time { return testNow },
+			}
+			err := w.Work(context.Background(), &river.Job[*SyncDirectory]{Args: &SyncDirectory{InstanceID: "instance", IDPID: "idp"}})
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+		})
+	}
+}
+
+func Test_laterCursor(t *testing.T) {
+	tests := []struct {
+		name      string
+		attribute domain.LDAPSyncDeltaAttribute
+		current   string
+		value     string
+		want      string
+	}{
+		{
+			name:      "empty value",
+			attribute: domain.LDAPSyncDeltaAttributeModifyTimestamp,
+			current:   "20240101100000Z",
+			want:      "20240101100000Z",
+		},
+		{
+			name:      "empty current",
+			attribute: domain.LDAPSyncDeltaAttributeModifyTimestamp,
+			value:     "20240101100000Z",
+			want:      "20240101100000Z",
+		},
+		{
+			name:      "timestamp later",
+			attribute: domain.LDAPSyncDeltaAttributeModifyTimestamp,
+			current:   "20240101100000Z",
+			value:     "20240102080000Z",
+			want:      "20240102080000Z",
+		},
+		{
+			name:      "timestamp earlier",
+			attribute: domain.LDAPSyncDeltaAttributeModifyTimestamp,
+			current:   "20240101100000Z",
+			value:     "20231231100000Z",
+			want:      "20240101100000Z",
+		},
+		{
+			name:      "usn compared as number",
+			attribute: domain.LDAPSyncDeltaAttributeUSNChanged,
+			current:   "9999",
+			value:     "10000",
+			want:      "10000",
+		},
+		{
+			name:      "usn earlier",
+			attribute: domain.LDAPSyncDeltaAttributeUSNChanged,
+			current:   "10000",
+			value:     "9999",
+			want:      "10000",
+		},
+		{
+			name:      "usn invalid value",
+			attribute: domain.LDAPSyncDeltaAttributeUSNChanged,
+			current:   "10000",
+			value:     "invalid",
+			want:      "10000",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, laterCursor(tt.attribute, tt.current, tt.value))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package mapping
+
+import (
+	"reflect"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// celCostLimit prevents expensive expressions from being evaluated during the login.
+const celCostLimit = 100_000
+
+var celEnv = sync.OnceValues(func() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable(variableUserInfo, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(variableClaims, cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable(variableAttributes, cel.MapType(cel.StringType, cel.ListType(cel.StringType))),
+		ext.Strings(),
+	)
+})
+
+var structValueType = reflect.TypeOf(&structpb.Value{})
+
+func compileCEL(expression string) (func(data map[string]any) (any, error), error) {
+	env, err := celEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(expression)
+	if issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	program, err := env.Program(ast, cel.CostLimit(celCostLimit))
+	if err != nil {
+		return nil, err
+	}
+	return func(data map[string]any) (any, error) {
+		value, _, err := program.Eval(data)
+		if err != nil {
+			return nil, err
+		}
+		native, err := value.ConvertToNative(structValueType)
+		if err != nil {
+			return nil, err
+		}
+		return native.(*structpb.Value).AsInterface(), nil
+	}, nil
+}
@@ -0,0 +1,108 @@
+package mapping
+
+import (
+	"encoding/json"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/idp"
+	"github.com/zitadel/zitadel/internal/idp/providers/azuread"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
+)
+
+const (
+	variableUserInfo   = "userinfo"
+	variableClaims     = "claims"
+	variableAttributes = "attributes"
+)
+
+// Input is the data received from the external IdP, on which the mapping rules are evaluated.
+// The expressions can access it as `userinfo`, `claims` and `attributes` (CEL)
+// resp. `$.userinfo`, `$.claims` and `$.attributes` (JSONPath).
+type Input struct {
+	// UserInfo is the (raw) user information returned by the IdP.
+	UserInfo map[string]any `json:"userinfo"`
+	// Claims are the claims of the ID token (OIDC only).
+	Claims map[string]any `json:"claims"`
+	// Attributes are the attributes of the SAML assertion resp. the LDAP entry (SAML and LDAP only).
+	Attributes map[string][]string `json:"attributes"`
+}
+
+// InputFromUser creates the input from the user and session returned by the provider.
+func InputFromUser(user idp.User, session idp.Session) (*Input, error) {
+	input := new(Input)
+	switch u := user.(type) {
+	case *oauth.UserMapper:
+		input.UserInfo = u.RawInfo
+	case *saml.UserMapper:
+		input.Attributes = u.Attributes
+	default:
+		if err := remarshal(user, &input.UserInfo); err != nil {
+			return nil, err
+		}
+	}
+	switch s := session.(type) {
+	case *openid.Session:
+		if s.Tokens != nil && s.Tokens.IDTokenClaims != nil {
+			if err := remarshal(s.Tokens.IDTokenClaims, &input.Claims); err != nil {
+				return nil, err
+			}
+		}
+	case *azuread.Session:
+		if s.OAuthSession != nil && s.OAuthSession.Tokens != nil && s.OAuthSession.Tokens.IDTokenClaims != nil {
+			if err := remarshal(s.OAuthSession.Tokens.IDTokenClaims, &input.Claims); err != nil {
+				return nil, err
+			}
+		}
+	case *ldap.Session:
+		if s.Entry != nil {
+			input.Attributes = make(map[string][]string, len(s.Entry.Attributes))
+			for _, attribute := range s.Entry.Attributes {
+				input.Attributes[attribute.Name] = attribute.Values
+			}
+		}
+	}
+	return input, nil
+}
+
+// ClaimsFromIDToken returns the claims of an already verified ID token.
+func ClaimsFromIDToken(idToken string) (map[string]any, error) {
+	claims := make(map[string]any)
+	if _, err := oidc.ParseToken(idToken, &claims); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// data returns the input in a generic (JSON) form used for the evaluation of the expressions.
+func (i *Input) data() (map[string]any, error) {
+	data := map[string]any{
+		variableUserInfo:   map[string]any{},
+		variableClaims:     map[string]any{},
+		variableAttributes: map[string]any{},
+	}
+	if i == nil {
+		return data, nil
+	}
+	generic := make(map[string]any, len(data))
+	if err := remarshal(i, &generic); err != nil {
+		return nil, err
+	}
+	for key, value := range generic {
+		if value != nil {
+			data[key] = value
+		}
+	}
+	return data, nil
+}
+
+func remarshal(from, to any) error {
+	data, err := json.Marshal(from)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, to)
+}
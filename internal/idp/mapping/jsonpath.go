@@ -0,0 +1,171 @@
+package mapping
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var (
+	errJSONPathRoot    = errors.New("JSONPath must start with $")
+	errJSONPathSyntax  = errors.New("invalid JSONPath syntax")
+	errJSONPathIndex   = errors.New("invalid JSONPath index")
+	errJSONPathEmpty   = errors.New("empty JSONPath segment")
+	errJSONPathUnended = errors.New("unterminated JSONPath bracket")
+)
+
+// pathSegment is a single step of a JSONPath.
+// Only the subset of child (`.name`, `['name']`), index (`[0]`) and wildcard (`.*`, `[*]`) selectors is supported.
+type pathSegment struct {
+	key      string
+	index    int
+	isIndex  bool
+	wildcard bool
+}
+
+func compileJSONPath(expression string) (func(data map[string]any) (any, error), error) {
+	path, err := parseJSONPath(expression)
+	if err != nil {
+		return nil, err
+	}
+	return func(data map[string]any) (any, error) {
+		value, _ := evaluatePath(data, path)
+		return value, nil
+	}, nil
+}
+
+func parseJSONPath(expression string) ([]pathSegment, error) {
+	expression = strings.TrimSpace(expression)
+	if !strings.HasPrefix(expression, "$") {
+		return nil, errJSONPathRoot
+	}
+	rest := expression[1:]
+	path := make([]pathSegment, 0, 4)
+	for rest != "" {
+		var (
+			segment pathSegment
+			err     error
+		)
+		switch rest[0] {
+		case '.':
+			segment, rest, err = parseDotSegment(rest[1:])
+		case '[':
+			segment, rest, err = parseBracketSegment(rest[1:])
+		default:
+			err = errJSONPathSyntax
+		}
+		if err != nil {
+			return nil, err
+		}
+		path = append(path, segment)
+	}
+	return path, nil
+}
+
+func parseDotSegment(rest string) (pathSegment, string, error) {
+	end := strings.IndexAny(rest, ".[")
+	if end < 0 {
+		end = len(rest)
+	}
+	name := rest[:end]
+	if name == "" {
+		return pathSegment{}, "", errJSONPathEmpty
+	}
+	if name == "*" {
+		return pathSegment{wildcard: true}, rest[end:], nil
+	}
+	return pathSegment{key: name}, rest[end:], nil
+}
+
+func parseBracketSegment(rest string) (pathSegment, string, error) {
+	if rest == "" {
+		return pathSegment{}, "", errJSONPathUnended
+	}
+	if quote := rest[0]; quote == '\'' || quote == '"' {
+		end := strings.IndexByte(rest[1:], quote)
+		if end < 0 || len(rest) < end+3 || rest[end+2] != ']' {
+			return pathSegment{}, "", errJSONPathUnended
+		}
+		return pathSegment{key: rest[1 : end+1]}, rest[end+3:], nil
+	}
+	end := strings.IndexByte(rest, ']')
+	if end < 0 {
+		return pathSegment{}, "", errJSONPathUnended
+	}
+	selector := strings.TrimSpace(rest[:end])
+	if selector == "*" {
+		return pathSegment{wildcard: true}, rest[end+1:], nil
+	}
+	index, err := strconv.Atoi(selector)
+	if err != nil {
+		return pathSegment{}, "", errors.Join(errJSONPathIndex, err)
+	}
+	return pathSegment{index: index, isIndex: true}, rest[end+1:], nil
+}
+
+// evaluatePath returns the value at the path and whether it exists.
+// If the path contains a wildcard, all matching values are returned as list.
+func evaluatePath(value any, path []pathSegment) (any, bool) {
+	for i, segment := range path {
+		if segment.wildcard {
+			return evaluateWildcard(value, path[i+1:])
+		}
+		var ok bool
+		value, ok = selectChild(value, segment)
+		if !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func evaluateWildcard(value any, rest []pathSegment) (any, bool) {
+	var children []any
+	switch v := value.(type) {
+	case []any:
+		children = v
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		children = make([]any, len(keys))
+		for i, key := range keys {
+			children[i] = v[key]
+		}
+	default:
+		return nil, false
+	}
+	results := make([]any, 0, len(children))
+	for _, child := range children {
+		if result, ok := evaluatePath(child, rest); ok {
+			results = append(results, result)
+		}
+	}
+	return results, true
+}
+
+func selectChild(value any, segment pathSegment) (any, bool) {
+	if segment.isIndex {
+		list, ok := value.([]any)
+		if !ok {
+			return nil, false
+		}
+		index := segment.index
+		if index < 0 {
+			index += len(list)
+		}
+		if index < 0 || index >= len(list) {
+			return nil, false
+		}
+		return list[index], true
+	}
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	child, ok := object[segment.key]
+	return child, ok
+}
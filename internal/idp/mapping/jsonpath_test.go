@@ -0,0 +1,55 @@
+package mapping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_compileJSONPath(t *testing.T) {
+	data := map[string]any{
+		"userinfo": map[string]any{
+			"name":     "Jane",
+			"x-custom": "custom",
+			"emails":   []any{"a@example.com", "b@example.com"},
+			"groups": []any{
+				map[string]any{"name": "admin"},
+				map[string]any{"name": "user"},
+			},
+		},
+	}
+	tests := []struct {
+		name       string
+		expression string
+		want       any
+		wantErr    bool
+	}{
+		{name: "missing root", expression: "userinfo.name", wantErr: true},
+		{name: "empty segment", expression: "$..name", wantErr: true},
+		{name: "unterminated bracket", expression: "$.userinfo['name'", wantErr: true},
+		{name: "invalid index", expression: "$.userinfo.emails[a]", wantErr: true},
+		{name: "root", expression: "$", want: data},
+		{name: "child", expression: "$.userinfo.name", want: "Jane"},
+		{name: "bracket child", expression: "$.userinfo['x-custom']", want: "custom"},
+		{name: "index", expression: "$.userinfo.emails[1]", want: "b@example.com"},
+		{name: "negative index", expression: "$.userinfo.emails[-1]", want: "b@example.com"},
+		{name: "index out of range", expression: "$.userinfo.emails[2]", want: nil},
+		{name: "missing", expression: "$.userinfo.missing.name", want: nil},
+		{name: "wildcard", expression: "$.userinfo.groups[*].name", want: []any{"admin", "user"}},
+		{name: "object wildcard", expression: "$.userinfo.groups[0].*", want: []any{"admin"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluate, err := compileJSONPath(tt.expression)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			got, err := evaluate(data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,107 @@
+package mapping
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	ErrInvalidTarget     = errors.New("invalid mapping target")
+	ErrMissingKey        = errors.New("mapping key is missing")
+	ErrInvalidLanguage   = errors.New("invalid mapping language")
+	ErrInvalidExpression = errors.New("invalid mapping expression")
+	ErrUnsupportedValue  = errors.New("unsupported value for mapping target")
+)
+
+// RuleError is returned if a mapping rule is invalid or could not be evaluated.
+type RuleError struct {
+	// Index of the rule in the list of the configured rules
+	Index int
+	Rule  domain.IDPMappingRule
+	Err   error
+}
+
+func (e *RuleError) Error() string {
+	return fmt.Sprintf("mapping rule %d (%q): %v", e.Index, e.Rule.Expression, e.Err)
+}
+
+func (e *RuleError) Unwrap() error {
+	return e.Err
+}
+
+// Mapper evaluates the configured mapping rules of an IdP.
+type Mapper struct {
+	rules []*rule
+}
+
+type rule struct {
+	domain.IDPMappingRule
+	index    int
+	evaluate func(data map[string]any) (any, error)
+}
+
+// New compiles the rules and returns a [*RuleError] for the first invalid rule.
+func New(rules []domain.IDPMappingRule) (*Mapper, error) {
+	mapper := &Mapper{
+		rules: make([]*rule, len(rules)),
+	}
+	for i, r := range rules {
+		compiled, err := compile(r)
+		if err != nil {
+			return nil, &RuleError{Index: i, Rule: r, Err: err}
+		}
+		compiled.index = i
+		mapper.rules[i] = compiled
+	}
+	return mapper, nil
+}
+
+func compile(r domain.IDPMappingRule) (_ *rule, err error) {
+	if !r.Target.Valid() {
+		return nil, ErrInvalidTarget
+	}
+	if r.Target.RequiresKey() && r.Key == "" {
+		return nil, ErrMissingKey
+	}
+	compiled := &rule{IDPMappingRule: r}
+	switch r.Language {
+	case domain.IDPMappingLanguageJSONPath:
+		compiled.evaluate, err = compileJSONPath(r.Expression)
+	case domain.IDPMappingLanguageCEL:
+		compiled.evaluate, err = compileCEL(r.Expression)
+	case domain.IDPMappingLanguageUnspecified:
+		fallthrough
+	default:
+		return nil, ErrInvalidLanguage
+	}
+	if err != nil {
+		return nil, errors.Join(ErrInvalidExpression, err)
+	}
+	return compiled, nil
+}
+
+// Map evaluates all rules on the input.
+// Rules which could not be evaluated do not abort the mapping, but are returned in [Result.Errors].
+// Rules without a result (e.g. a missing attribute) are ignored.
+func (m *Mapper) Map(input *Input) (*Result, error) {
+	result := newResult()
+	if m == nil || len(m.rules) == 0 {
+		return result, nil
+	}
+	data, err := input.data()
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range m.rules {
+		value, err := r.evaluate(data)
+		if err == nil {
+			err = result.set(r.IDPMappingRule, value)
+		}
+		if err != nil {
+			result.Errors = append(result.Errors, &RuleError{Index: r.index, Rule: r.IDPMappingRule, Err: err})
+		}
+	}
+	return result, nil
+}
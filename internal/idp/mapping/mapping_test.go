@@ -0,0 +1,284 @@
+package mapping
+
+import (
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/idp/providers/oauth"
+	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
+	"github.com/zitadel/zitadel/internal/idp/providers/saml"
+)
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   []domain.IDPMappingRule
+		wantErr error
+	}{
+		{
+			name:  "no rules",
+			rules: nil,
+		},
+		{
+			name: "invalid target",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetUnspecified, Language: domain.IDPMappingLanguageCEL, Expression: "'a'"},
+			},
+			wantErr: ErrInvalidTarget,
+		},
+		{
+			name: "missing key",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetMetadata, Language: domain.IDPMappingLanguageCEL, Expression: "'a'"},
+			},
+			wantErr: ErrMissingKey,
+		},
+		{
+			name: "invalid language",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetFirstName, Expression: "'a'"},
+			},
+			wantErr: ErrInvalidLanguage,
+		},
+		{
+			name: "invalid cel",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetFirstName, Language: domain.IDPMappingLanguageCEL, Expression: "userinfo."},
+			},
+			wantErr: ErrInvalidExpression,
+		},
+		{
+			name: "undeclared cel variable",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetFirstName, Language: domain.IDPMappingLanguageCEL, Expression: "user.name"},
+			},
+			wantErr: ErrInvalidExpression,
+		},
+		{
+			name: "invalid jsonpath",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetFirstName, Language: domain.IDPMappingLanguageJSONPath, Expression: "userinfo.name"},
+			},
+			wantErr: ErrInvalidExpression,
+		},
+		{
+			name: "valid rules",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetFirstName, Language: domain.IDPMappingLanguageJSONPath, Expression: "$.userinfo.given_name"},
+				{Target: domain.IDPMappingTargetProjectRoles, Key: "project", Language: domain.IDPMappingLanguageCEL, Expression: "claims.groups"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.rules)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				var ruleErr *RuleError
+				require.ErrorAs(t, err, &ruleErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Len(t, got.rules, len(tt.rules))
+		})
+	}
+}
+
+func TestMapper_Map(t *testing.T) {
+	tests := []struct {
+		name           string
+		rules          []domain.IDPMappingRule
+		input          *Input
+		want           *Result
+		wantErrIndexes []int
+	}{
+		{
+			name:  "nil input",
+			rules: []domain.IDPMappingRule{{Target: domain.IDPMappingTargetFirstName, Language: domain.IDPMappingLanguageJSONPath, Expression: "$.userinfo.given_name"}},
+			input: nil,
+			want:  newResult(),
+		},
+		{
+			name: "profile",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetFirstName, Language: domain.IDPMappingLanguageJSONPath, Expression: "$.userinfo.given_name"},
+				{Target: domain.IDPMappingTargetLastName, Language: domain.IDPMappingLanguageCEL, Expression: "userinfo.family_name.upperAscii()"},
+				{Target: domain.IDPMappingTargetDisplayName, Language: domain.IDPMappingLanguageCEL, Expression: "userinfo.given_name + ' ' + userinfo.family_name"},
+				{Target: domain.IDPMappingTargetEmail, Language: domain.IDPMappingLanguageJSONPath, Expression: "$.claims.email"},
+				{Target: domain.IDPMappingTargetEmailVerified, Language: domain.IDPMappingLanguageCEL, Expression: "claims.email.endsWith('@example.com')"},
+				{Target: domain.IDPMappingTargetPreferredLanguage, Language: domain.IDPMappingLanguageJSONPath, Expression: "$.userinfo.locale"},
+			},
+			input: &Input{
+				UserInfo: map[string]any{"given_name": "Jane", "family_name": "Doe", "locale": "de"},
+				Claims:   map[string]any{"email": "jane@example.com"},
+			},
+			want: &Result{
+				FirstName:         "Jane",
+				LastName:          "DOE",
+				DisplayName:       "Jane Doe",
+				Email:             "jane@example.com",
+				EmailVerified:     gu.Ptr(true),
+				PreferredLanguage: "de",
+				Metadata:          map[string][]byte{},
+				ProjectRoles:      map[string][]string{},
+			},
+		},
+		{
+			name: "metadata, organization and roles",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetMetadata, Key: "department", Language: domain.IDPMappingLanguageJSONPath, Expression: "$.attributes.department[0]"},
+				{Target: domain.IDPMappingTargetMetadata, Key: "groups", Language: domain.IDPMappingLanguageJSONPath, Expression: "$.attributes.groups"},
+				{Target: domain.IDPMappingTargetOrganization, Language: domain.IDPMappingLanguageCEL, Expression: "'department' in attributes ? 'org-' + attributes.department[0] : ''"},
+				{Target: domain.IDPMappingTargetProjectRoles, Key: "project1", Language: domain.IDPMappingLanguageCEL, Expression: "attributes.groups.filter(g, g.startsWith('app-')).map(g, g.substring(4))"},
+				{Target: domain.IDPMappingTargetProjectRoles, Key: "project1", Language: domain.IDPMappingLanguageJSONPath, Expression: "$.attributes.role"},
+				{Target: domain.IDPMappingTargetGroups, Language: domain.IDPMappingLanguageCEL, Expression: "'other' in attributes.groups ? ['group1'] : []"},
+			},
+			input: &Input{
+				Attributes: map[string][]string{
+					"department": {"sales"},
+					"groups":     {"app-admin", "app-user", "other"},
+				},
+			},
+			want: &Result{
+				Metadata: map[string][]byte{
+					"department": []byte("sales"),
+					"groups":     []byte(`["app-admin","app-user","other"]`),
+				},
+				OrganizationID: "org-sales",
+				ProjectRoles: map[string][]string{
+					"project1": {"admin", "user"},
+				},
+				GroupIDs: []string{"group1"},
+			},
+		},
+		{
+			name: "evaluation errors",
+			rules: []domain.IDPMappingRule{
+				{Target: domain.IDPMappingTargetFirstName, Language: domain.IDPMappingLanguageCEL, Expression: "userinfo.missing"},
+				{Target: domain.IDPMappingTargetEmailVerified, Language: domain.IDPMappingLanguageJSONPath, Expression: "$.userinfo.verified"},
+				{Target: domain.IDPMappingTargetLastName, Language: domain.IDPMappingLanguageJSONPath, Expression: "$.userinfo.family_name"},
+			},
+			input: &Input{
+				UserInfo: map[string]any{"verified": "maybe", "family_name": "Doe"},
+			},
+			want: &Result{
+				LastName:     "Doe",
+				Metadata:     map[string][]byte{},
+				ProjectRoles: map[string][]string{},
+			},
+			wantErrIndexes: []int{0, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapper, err := New(tt.rules)
+			require.NoError(t, err)
+			got, err := mapper.Map(tt.input)
+			require.NoError(t, err)
+			errIndexes := make([]int, len(got.Errors))
+			for i, err := range got.Errors {
+				errIndexes[i] = err.Index
+			}
+			got.Errors = nil
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, len(tt.wantErrIndexes), len(errIndexes))
+			if len(tt.wantErrIndexes) > 0 {
+				assert.Equal(t, tt.wantErrIndexes, errIndexes)
+			}
+		})
+	}
+}
+
+func TestInputFromUser(t *testing.T) {
+	oauthUser := oauth.NewUserMapper("sub")
+	oauthUser.RawInfo = map[string]any{"sub": "id", "name": "Jane"}
+	samlUser := saml.NewUser()
+	samlUser.Attributes = map[string][]string{"mail": {"jane@example.com"}}
+
+	got, err := InputFromUser(oauthUser, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &Input{UserInfo: oauthUser.RawInfo}, got)
+
+	got, err = InputFromUser(samlUser, nil)
+	require.NoError(t, err)
+	assert.Equal(t, &Input{Attributes: samlUser.Attributes}, got)
+
+	ldapUser := ldap.NewUser("id", "", "", "", "", "", "", false, "", false, language.Und, "", "")
+	ldapSession := &ldap.Session{
+		Entry: &goldap.Entry{
+			Attributes: []*goldap.EntryAttribute{
+				{Name: "memberOf", Values: []string{"cn=admins,dc=example,dc=com"}},
+			},
+		},
+	}
+	got, err = InputFromUser(ldapUser, ldapSession)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"memberOf": {"cn=admins,dc=example,dc=com"}}, got.Attributes)
+}
+
+func TestResult_Assignments(t *testing.T) {
+	result := &Result{
+		ProjectRoles: map[string][]string{
+			"project1": {"user", "admin", "user"},
+		},
+		GroupIDs: []string{"group2", "group1", "group2"},
+	}
+	assert.Equal(t, &domain.IDPAssignments{
+		ProjectRoles: map[string][]string{
+			"project1": {"admin", "user"},
+		},
+		GroupIDs: []string{"group1", "group2"},
+	}, result.Assignments())
+	assert.Equal(t, &domain.IDPAssignments{ProjectRoles: map[string][]string{}}, newResult().Assignments())
+
+	failed := &Result{
+		ProjectRoles: map[string][]string{
+			"project1": {"user"},
+		},
+		Errors: []*RuleError{
+			{Rule: domain.IDPMappingRule{Target: domain.IDPMappingTargetProjectRoles, Key: "project2"}},
+			{Rule: domain.IDPMappingRule{Target: domain.IDPMappingTargetProjectRoles, Key: "project2"}},
+			{Rule: domain.IDPMappingRule{Target: domain.IDPMappingTargetGroups}},
+			{Rule: domain.IDPMappingRule{Target: domain.IDPMappingTargetFirstName}},
+		},
+	}
+	assert.Equal(t, &domain.IDPAssignments{
+		ProjectRoles: map[string][]string{
+			"project1": {"user"},
+		},
+		FailedProjectIDs: []string{"project2"},
+		GroupsFailed:     true,
+	}, failed.Assignments())
+}
+
+func TestResult_User(t *testing.T) {
+	user := openid.DefaultMapper(&oidc.UserInfo{
+		Subject: "id",
+		UserInfoProfile: oidc.UserInfoProfile{
+			GivenName:  "Jane",
+			FamilyName: "Doe",
+		},
+		UserInfoEmail: oidc.UserInfoEmail{
+			EmailVerified: true,
+		},
+	})
+	result := &Result{
+		FirstName:         "Janet",
+		EmailVerified:     gu.Ptr(false),
+		PreferredLanguage: "invalid language",
+	}
+	got := result.User(user)
+	assert.Equal(t, "id", got.GetID())
+	assert.Equal(t, "Janet", got.GetFirstName())
+	assert.Equal(t, "Doe", got.GetLastName())
+	assert.False(t, got.IsEmailVerified())
+	assert.Equal(t, language.Und, got.GetPreferredLanguage())
+}
@@ -0,0 +1,290 @@
+package mapping
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+// Result contains the values of all successfully evaluated mapping rules.
+// Empty values are not mapped and the value of the external user is used instead.
+type Result struct {
+	FirstName         string
+	LastName          string
+	DisplayName       string
+	NickName          string
+	PreferredUsername string
+	Email             domain.EmailAddress
+	EmailVerified     *bool
+	Phone             domain.PhoneNumber
+	PhoneVerified     *bool
+	PreferredLanguage string
+	AvatarURL         string
+	Profile           string
+	Metadata          map[string][]byte
+	OrganizationID    string
+	// ProjectRoles contains the role keys per project ID
+	ProjectRoles map[string][]string
+	GroupIDs     []string
+
+	Errors []*RuleError
+}
+
+func newResult() *Result {
+	return &Result{
+		Metadata:     make(map[string][]byte),
+		ProjectRoles: make(map[string][]string),
+	}
+}
+
+// MetadataList returns the mapped metadata as [domain.Metadata].
+func (r *Result) MetadataList() []*domain.Metadata {
+	metadata := make([]*domain.Metadata, 0, len(r.Metadata))
+	for key, value := range r.Metadata {
+		metadata = append(metadata, &domain.Metadata{Key: key, Value: value})
+	}
+	return metadata
+}
+
+// Assignments returns the mapped project roles and groups.
+// Duplicate values are removed, so the result can be compared to the current assignments of the user.
+// Targets of failed rules are marked, so the assignments of the user are not removed because of an incomplete result.
+func (r *Result) Assignments() *domain.IDPAssignments {
+	assignments := &domain.IDPAssignments{
+		ProjectRoles: make(map[string][]string, len(r.ProjectRoles)),
+		GroupIDs:     uniqueSorted(r.GroupIDs),
+	}
+	for projectID, roleKeys := range r.ProjectRoles {
+		assignments.ProjectRoles[projectID] = uniqueSorted(roleKeys)
+	}
+	for _, ruleErr := range r.Errors {
+		if ruleErr.Rule.Target == domain.IDPMappingTargetGroups {
+			assignments.GroupsFailed = true
+		}
+		if ruleErr.Rule.Target == domain.IDPMappingTargetProjectRoles && !slices.Contains(assignments.FailedProjectIDs, ruleErr.Rule.Key) {
+			assignments.FailedProjectIDs = append(assignments.FailedProjectIDs, ruleErr.Rule.Key)
+		}
+	}
+	return assignments
+}
+
+func uniqueSorted(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	values = slices.Clone(values)
+	slices.Sort(values)
+	return slices.Compact(values)
+}
+
+// User returns the external user with the mapped profile fields applied.
+func (r *Result) User(user idp.User) idp.User {
+	return &User{User: user, result: r}
+}
+
+func (r *Result) set(rule domain.IDPMappingRule, value any) (err error) {
+	if value == nil {
+		return nil
+	}
+	switch rule.Target {
+	case domain.IDPMappingTargetFirstName:
+		r.FirstName, err = stringValue(value)
+	case domain.IDPMappingTargetLastName:
+		r.LastName, err = stringValue(value)
+	case domain.IDPMappingTargetDisplayName:
+		r.DisplayName, err = stringValue(value)
+	case domain.IDPMappingTargetNickName:
+		r.NickName, err = stringValue(value)
+	case domain.IDPMappingTargetPreferredUsername:
+		r.PreferredUsername, err = stringValue(value)
+	case domain.IDPMappingTargetEmail:
+		var email string
+		email, err = stringValue(value)
+		r.Email = domain.EmailAddress(email)
+	case domain.IDPMappingTargetEmailVerified:
+		r.EmailVerified, err = boolValue(value)
+	case domain.IDPMappingTargetPhone:
+		var phone string
+		phone, err = stringValue(value)
+		r.Phone = domain.PhoneNumber(phone)
+	case domain.IDPMappingTargetPhoneVerified:
+		r.PhoneVerified, err = boolValue(value)
+	case domain.IDPMappingTargetPreferredLanguage:
+		r.PreferredLanguage, err = stringValue(value)
+	case domain.IDPMappingTargetAvatarURL:
+		r.AvatarURL, err = stringValue(value)
+	case domain.IDPMappingTargetProfile:
+		r.Profile, err = stringValue(value)
+	case domain.IDPMappingTargetMetadata:
+		var metadata []byte
+		metadata, err = bytesValue(value)
+		if err == nil && len(metadata) > 0 {
+			r.Metadata[rule.Key] = metadata
+		}
+	case domain.IDPMappingTargetOrganization:
+		r.OrganizationID, err = stringValue(value)
+	case domain.IDPMappingTargetProjectRoles:
+		var roles []string
+		roles, err = stringsValue(value)
+		if err == nil && len(roles) > 0 {
+			r.ProjectRoles[rule.Key] = append(r.ProjectRoles[rule.Key], roles...)
+		}
+	case domain.IDPMappingTargetGroups:
+		var groupIDs []string
+		groupIDs, err = stringsValue(value)
+		r.GroupIDs = append(r.GroupIDs, groupIDs...)
+	case domain.IDPMappingTargetUnspecified:
+		fallthrough
+	default:
+		return ErrInvalidTarget
+	}
+	return err
+}
+
+// stringValue converts scalar values to a string, for lists (e.g. SAML attributes) the first value is used.
+func stringValue(value any) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		if len(v) == 0 {
+			return "", nil
+		}
+		return stringValue(v[0])
+	default:
+		return "", ErrUnsupportedValue
+	}
+}
+
+func boolValue(value any) (*bool, error) {
+	switch v := value.(type) {
+	case bool:
+		return &v, nil
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, ErrUnsupportedValue
+		}
+		return &b, nil
+	case []any:
+		if len(v) == 0 {
+			return nil, nil
+		}
+		return boolValue(v[0])
+	default:
+		return nil, ErrUnsupportedValue
+	}
+}
+
+func stringsValue(value any) ([]string, error) {
+	list, ok := value.([]any)
+	if !ok {
+		s, err := stringValue(value)
+		if err != nil || s == "" {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		s, err := stringValue(item)
+		if err != nil {
+			return nil, err
+		}
+		if s != "" {
+			values = append(values, s)
+		}
+	}
+	return values, nil
+}
+
+// bytesValue stores strings as is and any other value JSON encoded.
+func bytesValue(value any) ([]byte, error) {
+	if s, ok := value.(string); ok {
+		return []byte(s), nil
+	}
+	return json.Marshal(value)
+}
+
+// User is an [idp.User] overriding the fields of the external user with the mapped values.
+type User struct {
+	idp.User
+	result *Result
+}
+
+func (u *User) GetFirstName() string {
+	return valueOrDefault(u.result.FirstName, u.User.GetFirstName)
+}
+
+func (u *User) GetLastName() string {
+	return valueOrDefault(u.result.LastName, u.User.GetLastName)
+}
+
+func (u *User) GetDisplayName() string {
+	return valueOrDefault(u.result.DisplayName, u.User.GetDisplayName)
+}
+
+func (u *User) GetNickname() string {
+	return valueOrDefault(u.result.NickName, u.User.GetNickname)
+}
+
+func (u *User) GetPreferredUsername() string {
+	return valueOrDefault(u.result.PreferredUsername, u.User.GetPreferredUsername)
+}
+
+func (u *User) GetEmail() domain.EmailAddress {
+	return valueOrDefault(u.result.Email, u.User.GetEmail)
+}
+
+func (u *User) IsEmailVerified() bool {
+	if u.result.EmailVerified != nil {
+		return *u.result.EmailVerified
+	}
+	return u.User.IsEmailVerified()
+}
+
+func (u *User) GetPhone() domain.PhoneNumber {
+	return valueOrDefault(u.result.Phone, u.User.GetPhone)
+}
+
+func (u *User) IsPhoneVerified() bool {
+	if u.result.PhoneVerified != nil {
+		return *u.result.PhoneVerified
+	}
+	return u.User.IsPhoneVerified()
+}
+
+func (u *User) GetPreferredLanguage() language.Tag {
+	if u.result.PreferredLanguage != "" {
+		if tag, err := language.Parse(u.result.PreferredLanguage); err == nil {
+			return tag
+		}
+	}
+	return u.User.GetPreferredLanguage()
+}
+
+func (u *User) GetAvatarURL() string {
+	return valueOrDefault(u.result.AvatarURL, u.User.GetAvatarURL)
+}
+
+func (u *User) GetProfile() string {
+	return valueOrDefault(u.result.Profile, u.User.GetProfile)
+}
+
+func valueOrDefault[T ~string](value T, defaultValue func() T) T {
+	if value != "" {
+		return value
+	}
+	return defaultValue()
+}
@@ -0,0 +1,107 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var ErrNoServerAvailable = errors.New("no ldap server available")
+
+// UserAttributes returns the attributes needed to map an entry to a [User].
+func (p *Provider) UserAttributes() []string {
+	return p.getNecessaryAttributes()
+}
+
+// UserSearchFilter returns the filter for all user entries (object classes) of the provider,
+// which additionally match the (optional) filter.
+// If a delta attribute and cursor are passed, only entries changed since the cursor are returned.
+func (p *Provider) UserSearchFilter(filter, deltaAttribute, cursor string) string {
+	queries := make([]string, 0, len(p.userObjectClasses)+2)
+	for _, class := range p.userObjectClasses {
+		queries = append(queries, objectClassesToSearchQuery([]string{class}))
+	}
+	if filter != "" {
+		queries = append(queries, filter)
+	}
+	if deltaAttribute != "" && cursor != "" {
+		queries = append(queries, "("+deltaAttribute+">="+ldap.EscapeFilter(cursor)+")")
+	}
+	if len(queries) == 0 {
+		return "(objectClass=*)"
+	}
+	return queriesAndToSearchQuery(queries...)
+}
+
+// SearchUsers pages through all entries below the base DN matching the filter.
+// The entries of every page are passed to fn. The search stops on the first error returned by fn.
+func (p *Provider) SearchUsers(ctx context.Context, filter string, attributes []string, pageSize uint32, fn func(entries []*ldap.Entry) error) (err error) {
+	var conn *ldap.Conn
+	for _, server := range p.servers {
+		conn, err = getConnection(server, p.startTLS, p.timeout, p.rootCA)
+		if err == nil {
+			break
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if conn == nil {
+		return ErrNoServerAvailable
+	}
+	defer conn.Close()
+
+	if err = conn.Bind(p.bindDN, p.bindPassword); err != nil {
+		return err
+	}
+	return searchPaged(ctx, conn, p.baseDN, filter, attributes, pageSize, int(p.timeout.Seconds()), fn)
+}
+
+func searchPaged(ctx context.Context, conn *ldap.Conn, baseDN, filter string, attributes []string, pageSize uint32, timeLimit int, fn func(entries []*ldap.Entry) error) error {
+	paging := ldap.NewControlPaging(pageSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		result, err := conn.Search(ldap.NewSearchRequest(
+			baseDN,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, timeLimit, false,
+			filter,
+			attributes,
+			[]ldap.Control{paging},
+		))
+		if err != nil {
+			return err
+		}
+		if err = fn(result.Entries); err != nil {
+			return err
+		}
+		// an empty cookie marks the last page
+		control, ok := ldap.FindControl(result.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+		if !ok || len(control.Cookie) == 0 {
+			return nil
+		}
+		paging.SetCookie(control.Cookie)
+	}
+}
+
+// MapEntry maps an entry returned by [Provider.SearchUsers] to a [User].
+func (p *Provider) MapEntry(entry *ldap.Entry) (*User, error) {
+	return mapLDAPEntryToUser(
+		entry,
+		p.idAttribute,
+		p.firstNameAttribute,
+		p.lastNameAttribute,
+		p.displayNameAttribute,
+		p.nickNameAttribute,
+		p.preferredUsernameAttribute,
+		p.emailAttribute,
+		p.emailVerifiedAttribute,
+		p.phoneAttribute,
+		p.phoneVerifiedAttribute,
+		p.preferredLanguageAttribute,
+		p.avatarURLAttribute,
+		p.profileAttribute,
+	)
+}
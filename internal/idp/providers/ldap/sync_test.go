@@ -0,0 +1,115 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/go-ldap/ldap/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProvider_UserSearchFilter(t *testing.T) {
+	type fields struct {
+		userObjectClasses []string
+	}
+	type args struct {
+		filter         string
+		deltaAttribute string
+		cursor         string
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   string
+	}{
+		{
+			name:   "no object classes",
+			fields: fields{},
+			args:   args{},
+			want:   "(objectClass=*)",
+		},
+		{
+			name: "object class",
+			fields: fields{
+				userObjectClasses: []string{"user"},
+			},
+			args: args{},
+			want: "(objectClass=user)",
+		},
+		{
+			name: "object classes and filter",
+			fields: fields{
+				userObjectClasses: []string{"top", "person"},
+			},
+			args: args{
+				filter: "(department=sales)",
+			},
+			want: "(&(objectClass=top)(objectClass=person)(department=sales))",
+		},
+		{
+			name: "delta without cursor",
+			fields: fields{
+				userObjectClasses: []string{"person"},
+			},
+			args: args{
+				deltaAttribute: "modifyTimestamp",
+			},
+			want: "(objectClass=person)",
+		},
+		{
+			name: "delta with cursor",
+			fields: fields{
+				userObjectClasses: []string{"person"},
+			},
+			args: args{
+				filter:         "(department=sales)",
+				deltaAttribute: "uSNChanged",
+				cursor:         "12345",
+			},
+			want: "(&(objectClass=person)(department=sales)(uSNChanged>=12345))",
+		},
+		{
+			name: "delta cursor escaped",
+			fields: fields{
+				userObjectClasses: []string{"person"},
+			},
+			args: args{
+				deltaAttribute: "modifyTimestamp",
+				cursor:         "2024*)(uid=*",
+			},
+			want: "(&(objectClass=person)(modifyTimestamp>=2024\\2a\\29\\28uid=\\2a))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &Provider{userObjectClasses: tt.fields.userObjectClasses}
+			got := p.UserSearchFilter(tt.args.filter, tt.args.deltaAttribute, tt.args.cursor)
+			assert.Equal(t, tt.want, got)
+			_, err := ldap.CompileFilter(got)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestProvider_MapEntry(t *testing.T) {
+	p := New("ldap", nil, "", "", "", "uid", nil, nil, 0, nil, "",
+		WithCustomIDAttribute("uid"),
+		WithFirstNameAttribute("givenName"),
+		WithLastNameAttribute("sn"),
+		WithEmailAttribute("mail"),
+	)
+	entry := ldap.NewEntry("uid=user,dc=example,dc=com", map[string][]string{
+		"uid":       {"user"},
+		"givenName": {"first"},
+		"sn":        {"last"},
+		"mail":      {"user@example.com"},
+	})
+	user, err := p.MapEntry(entry)
+	require.NoError(t, err)
+	assert.Equal(t, "user", user.GetID())
+	assert.Equal(t, "first", user.GetFirstName())
+	assert.Equal(t, "last", user.GetLastName())
+	assert.Equal(t, "user@example.com", string(user.GetEmail()))
+	assert.Equal(t, []string{"uid", "uid", "givenName", "sn", "mail"}, p.UserAttributes())
+}
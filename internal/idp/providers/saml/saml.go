@@ -79,6 +79,10 @@ func WithSignedRequest() ProviderOpts {
 	}
 }
 
+// WithBinding sets the binding used for the authentication request.
+// In case of the HTTP-Artifact binding, the request is sent using the redirect (or POST) binding
+// and the IdP is asked to respond with an artifact, which is then resolved through the back channel,
+// so the assertion never passes the user agent.
 func WithBinding(binding string) ProviderOpts {
 	return func(p *Provider) {
 		p.binding = binding
@@ -223,7 +227,9 @@ func (p *Provider) GetSP() (*samlsp.Middleware, error) {
 	if p.requestTracker != nil {
 		sp.RequestTracker = p.requestTracker
 	}
-	if p.binding != "" {
+	if p.binding == saml.HTTPArtifactBinding {
+		sp.ResponseBinding = saml.HTTPArtifactBinding
+	} else if p.binding != "" {
 		sp.Binding = p.binding
 	}
 	if p.signatureAlgorithm != "" {
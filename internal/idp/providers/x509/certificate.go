@@ -0,0 +1,306 @@
+package x509
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/cryptobyte"
+	cryptobyte_asn1 "golang.org/x/crypto/cryptobyte/asn1"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var (
+	ErrNoCertificate            = errors.New("no client certificate provided")
+	ErrInvalidCertificate       = errors.New("invalid client certificate")
+	ErrUntrustedCertificate     = errors.New("client certificate is not trusted")
+	ErrRevokedCertificate       = errors.New("client certificate is revoked")
+	ErrUnknownRevocationStatus  = errors.New("revocation status of client certificate is unknown")
+	ErrMissingCertificateField  = errors.New("mapped field is missing in client certificate")
+	errNoMatchingRevocationData = errors.New("no matching revocation data")
+)
+
+const (
+	xfccCertKey         = "Cert="
+	pemCertificateBegin = "-----BEGIN CERTIFICATE-----"
+	pemCertificateEnd   = "-----END CERTIFICATE-----"
+)
+
+var (
+	oidSubjectAltName    = asn1.ObjectIdentifier{2, 5, 29, 17}
+	oidUserPrincipalName = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 3}
+	oidSmartcardLogon    = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2, 2}
+	oidEmailAddress      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+	oidGivenName         = asn1.ObjectIdentifier{2, 5, 4, 42}
+	oidSurname           = asn1.ObjectIdentifier{2, 5, 4, 4}
+
+	// otherName is [0] of the GeneralName, its value is an explicitly tagged [0] as well
+	contextSpecific0 = cryptobyte_asn1.Tag(0).ContextSpecific().Constructed()
+)
+
+// parseForwardedCertificates parses the client certificate (and optional intermediates)
+// as forwarded by the common proxies:
+//   - PEM, possibly URL encoded (e.g. NGINX `$ssl_client_escaped_cert`, AWS ALB) or with spaces instead of newlines (e.g. Apache)
+//   - base64 encoded DER, possibly URL encoded and comma separated (e.g. Traefik, HAProxy)
+//   - the Cert value of the Envoy `x-forwarded-client-cert` header
+//
+// The first certificate is the client certificate.
+func parseForwardedCertificates(value string) ([]*x509.Certificate, error) {
+	value = strings.TrimSpace(value)
+	if i := strings.Index(value, xfccCertKey); i >= 0 {
+		value = value[i+len(xfccCertKey):]
+		if end := strings.IndexAny(value, ";,"); end >= 0 {
+			value = value[:end]
+		}
+		value = strings.Trim(value, `"`)
+	}
+	if value == "" {
+		return nil, ErrNoCertificate
+	}
+	if strings.Contains(value, "%") {
+		unescaped, err := url.QueryUnescape(value)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidCertificate, err)
+		}
+		value = unescaped
+	}
+	ders, err := certificateDERs(value)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidCertificate, err)
+	}
+	certificates := make([]*x509.Certificate, len(ders))
+	for i, der := range ders {
+		certificates[i], err = x509.ParseCertificate(der)
+		if err != nil {
+			return nil, errors.Join(ErrInvalidCertificate, err)
+		}
+	}
+	if len(certificates) == 0 {
+		return nil, ErrNoCertificate
+	}
+	return certificates, nil
+}
+
+func certificateDERs(value string) ([][]byte, error) {
+	if !strings.Contains(value, pemCertificateBegin) {
+		return base64DERs(strings.Split(value, ","))
+	}
+	var ders [][]byte
+	for _, part := range strings.SplitAfter(value, pemCertificateEnd) {
+		begin := strings.Index(part, pemCertificateBegin)
+		if begin < 0 {
+			continue
+		}
+		block, _ := pem.Decode([]byte(part[begin:]))
+		if block != nil {
+			ders = append(ders, block.Bytes)
+			continue
+		}
+		// the newlines of the PEM were replaced (e.g. by spaces), so the body is decoded directly
+		body := strings.TrimSuffix(strings.TrimPrefix(part[begin:], pemCertificateBegin), pemCertificateEnd)
+		der, err := base64DERs([]string{body})
+		if err != nil {
+			return nil, err
+		}
+		ders = append(ders, der...)
+	}
+	return ders, nil
+}
+
+func base64DERs(values []string) ([][]byte, error) {
+	ders := make([][]byte, 0, len(values))
+	for _, value := range values {
+		value = strings.Join(strings.Fields(value), "")
+		if value == "" {
+			continue
+		}
+		der, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, err
+		}
+		ders = append(ders, der)
+	}
+	return ders, nil
+}
+
+// verify checks the client certificate against the trusted CA certificates and returns the verified chain.
+func (p *Provider) verify(certificates []*x509.Certificate, now time.Time) ([]*x509.Certificate, error) {
+	intermediates := x509.NewCertPool()
+	for _, certificate := range certificates[1:] {
+		intermediates.AddCert(certificate)
+	}
+	options := x509.VerifyOptions{
+		Roots:         p.roots,
+		Intermediates: intermediates,
+		CurrentTime:   now,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	// smart card certificates might only allow the smart card logon (and no client authentication)
+	if hasExtKeyUsage(certificates[0], oidSmartcardLogon) {
+		options.KeyUsages = []x509.ExtKeyUsage{x509.ExtKeyUsageAny}
+	}
+	chains, err := certificates[0].Verify(options)
+	if err != nil {
+		return nil, errors.Join(ErrUntrustedCertificate, err)
+	}
+	return chains[0], nil
+}
+
+func hasExtKeyUsage(certificate *x509.Certificate, usage asn1.ObjectIdentifier) bool {
+	for _, unknown := range certificate.UnknownExtKeyUsage {
+		if unknown.Equal(usage) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkRevocation checks every certificate of the chain (except the trust anchor)
+// against the certificate revocation lists and the OCSP responses.
+// A missing revocation status is only an error for the client certificate and only if a check is required.
+func (p *Provider) checkRevocation(chain []*x509.Certificate, now time.Time) error {
+	for i := 0; i < len(chain)-1; i++ {
+		err := p.revocationStatus(chain[i], chain[i+1], now)
+		if errors.Is(err, errNoMatchingRevocationData) {
+			if i == 0 && p.revocationCheckRequired {
+				return ErrUnknownRevocationStatus
+			}
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Provider) revocationStatus(certificate, issuer *x509.Certificate, now time.Time) error {
+	known := false
+	for _, crl := range p.crls {
+		if !bytes.Equal(crl.RawIssuer, issuer.RawSubject) || crl.CheckSignatureFrom(issuer) != nil {
+			continue
+		}
+		if !crl.NextUpdate.IsZero() && crl.NextUpdate.Before(now) {
+			continue
+		}
+		for _, revoked := range crl.RevokedCertificateEntries {
+			if revoked.SerialNumber.Cmp(certificate.SerialNumber) == 0 {
+				return ErrRevokedCertificate
+			}
+		}
+		known = true
+	}
+	for _, raw := range p.ocspResponses {
+		response, err := ocsp.ParseResponseForCert(raw, certificate, issuer)
+		if err != nil {
+			continue
+		}
+		if response.ThisUpdate.After(now) || (!response.NextUpdate.IsZero() && response.NextUpdate.Before(now)) {
+			continue
+		}
+		switch response.Status {
+		case ocsp.Revoked:
+			return ErrRevokedCertificate
+		case ocsp.Good:
+			known = true
+		}
+	}
+	if !known {
+		return errNoMatchingRevocationData
+	}
+	return nil
+}
+
+// certificateField returns the value of the field, an empty string if it is not present.
+func certificateField(certificate *x509.Certificate, field domain.X509CertificateField) string {
+	switch field {
+	case domain.X509CertificateFieldUnspecified,
+		domain.X509CertificateFieldSubject:
+		return certificate.Subject.String()
+	case domain.X509CertificateFieldSubjectCommonName:
+		return certificate.Subject.CommonName
+	case domain.X509CertificateFieldSubjectSerialNumber:
+		return certificate.Subject.SerialNumber
+	case domain.X509CertificateFieldSANEmail:
+		return emailAddress(certificate)
+	case domain.X509CertificateFieldSANUPN:
+		return userPrincipalName(certificate)
+	case domain.X509CertificateFieldSANURI:
+		if len(certificate.URIs) > 0 {
+			return certificate.URIs[0].String()
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+
+// emailAddress returns the first email address of the subject alternative names,
+// falling back to the (deprecated) emailAddress attribute of the subject.
+func emailAddress(certificate *x509.Certificate) string {
+	if len(certificate.EmailAddresses) > 0 {
+		return certificate.EmailAddresses[0]
+	}
+	return subjectAttribute(certificate, oidEmailAddress)
+}
+
+func subjectAttribute(certificate *x509.Certificate, oid asn1.ObjectIdentifier) string {
+	for _, name := range certificate.Subject.Names {
+		if !name.Type.Equal(oid) {
+			continue
+		}
+		if value, ok := name.Value.(string); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// userPrincipalName returns the UPN (Microsoft) of the subject alternative names,
+// which is commonly used for smart card logins.
+func userPrincipalName(certificate *x509.Certificate) string {
+	for _, extension := range certificate.Extensions {
+		if !extension.Id.Equal(oidSubjectAltName) {
+			continue
+		}
+		return otherNameValue(extension.Value, oidUserPrincipalName)
+	}
+	return ""
+}
+
+// otherNameValue parses the GeneralNames and returns the (UTF8String) value of the first otherName with the type id.
+func otherNameValue(raw []byte, typeID asn1.ObjectIdentifier) string {
+	var names cryptobyte.String
+	input := cryptobyte.String(raw)
+	if !input.ReadASN1(&names, cryptobyte_asn1.SEQUENCE) {
+		return ""
+	}
+	for !names.Empty() {
+		var name cryptobyte.String
+		var tag cryptobyte_asn1.Tag
+		if !names.ReadAnyASN1(&name, &tag) {
+			return ""
+		}
+		if tag != contextSpecific0 {
+			continue
+		}
+		var id asn1.ObjectIdentifier
+		var value, utf8 cryptobyte.String
+		if !name.ReadASN1ObjectIdentifier(&id) || !id.Equal(typeID) {
+			continue
+		}
+		if !name.ReadASN1(&value, contextSpecific0) || !value.ReadASN1(&utf8, cryptobyte_asn1.UTF8String) {
+			continue
+		}
+		return string(utf8)
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package x509
+
+import (
+	"context"
+	"crypto/x509"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+var _ idp.Session = (*Session)(nil)
+
+// Session is the [idp.Session] implementation for the X.509 provider.
+type Session struct {
+	Provider *Provider
+	loginURL string
+	// Certificate is the client certificate as forwarded by the proxy
+	Certificate string
+}
+
+func NewSession(provider *Provider, certificate string) *Session {
+	return &Session{Provider: provider, Certificate: certificate}
+}
+
+// GetAuth implements the [idp.Session] interface.
+func (s *Session) GetAuth(ctx context.Context) (idp.Auth, error) {
+	return idp.Redirect(s.loginURL)
+}
+
+// PersistentParameters implements the [idp.Session] interface.
+func (s *Session) PersistentParameters() map[string]any {
+	return nil
+}
+
+// FetchUser implements the [idp.Session] interface.
+// It validates the client certificate including its revocation status
+// and maps the certificate fields to the user.
+func (s *Session) FetchUser(_ context.Context) (idp.User, error) {
+	certificates, err := parseForwardedCertificates(s.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	now := s.Provider.now()
+	chain, err := s.Provider.verify(certificates, now)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.Provider.checkRevocation(chain, now); err != nil {
+		return nil, err
+	}
+	return s.Provider.mapUser(chain[0])
+}
+
+func (s *Session) ExpiresAt() time.Time {
+	return time.Time{} // falls back to the default expiration time
+}
+
+func (p *Provider) mapUser(certificate *x509.Certificate) (*User, error) {
+	id := certificateField(certificate, p.idField)
+	if id == "" {
+		return nil, ErrMissingCertificateField
+	}
+	email := emailAddress(certificate)
+	return &User{
+		ID:                id,
+		FirstName:         subjectAttribute(certificate, oidGivenName),
+		LastName:          subjectAttribute(certificate, oidSurname),
+		DisplayName:       certificate.Subject.CommonName,
+		PreferredUsername: p.username(certificate, email),
+		Email:             domain.EmailAddress(email),
+		// the email address is part of the certificate and therefore verified by the trusted CA
+		EmailVerified: email != "",
+		Subject:       certificate.Subject.String(),
+		Issuer:        certificate.Issuer.String(),
+		SerialNumber:  certificate.SerialNumber.String(),
+	}, nil
+}
+
+func (p *Provider) username(certificate *x509.Certificate, email string) string {
+	if p.usernameField != domain.X509CertificateFieldUnspecified {
+		return certificateField(certificate, p.usernameField)
+	}
+	if upn := userPrincipalName(certificate); upn != "" {
+		return upn
+	}
+	if email != "" {
+		return email
+	}
+	return certificate.Subject.CommonName
+}
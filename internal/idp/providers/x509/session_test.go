@@ -0,0 +1,341 @@
+package x509
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+var testNow = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+type testCA struct {
+	certificate *x509.Certificate
+	key         crypto.Signer
+	pem         []byte
+}
+
+func newTestCA(t *testing.T, name string) *testCA {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             testNow.Add(-time.Hour),
+		NotAfter:              testNow.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	require.NoError(t, err)
+	certificate, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return &testCA{
+		certificate: certificate,
+		key:         key,
+		pem:         pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+	}
+}
+
+func upnExtension(t *testing.T, upn string) pkix.Extension {
+	type otherName struct {
+		TypeID asn1.ObjectIdentifier
+		Value  asn1.RawValue
+	}
+	value, err := asn1.MarshalWithParams(upn, "utf8")
+	require.NoError(t, err)
+	name, err := asn1.MarshalWithParams(otherName{
+		TypeID: oidUserPrincipalName,
+		Value:  asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: value},
+	}, "tag:0")
+	require.NoError(t, err)
+	email, err := asn1.MarshalWithParams("john.doe@example.com", "tag:1")
+	require.NoError(t, err)
+	names, err := asn1.Marshal([]asn1.RawValue{{FullBytes: name}, {FullBytes: email}})
+	require.NoError(t, err)
+	return pkix.Extension{Id: oidSubjectAltName, Value: names}
+}
+
+func (ca *testCA) issue(t *testing.T, serial int64, modify func(*x509.Certificate)) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject: pkix.Name{
+			CommonName:   "John Doe",
+			SerialNumber: "1234",
+			ExtraNames: []pkix.AttributeTypeAndValue{
+				{Type: oidGivenName, Value: "John"},
+				{Type: oidSurname, Value: "Doe"},
+			},
+		},
+		NotBefore:       testNow.Add(-time.Hour),
+		NotAfter:        testNow.Add(time.Hour),
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		ExtraExtensions: []pkix.Extension{upnExtension(t, "john.doe@corp.example.com")},
+	}
+	if modify != nil {
+		modify(template)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.certificate, key.Public(), ca.key)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+}
+
+func (ca *testCA) crl(t *testing.T, nextUpdate time.Time, revoked ...int64) []byte {
+	entries := make([]x509.RevocationListEntry, len(revoked))
+	for i, serial := range revoked {
+		entries[i] = x509.RevocationListEntry{SerialNumber: big.NewInt(serial), RevocationTime: testNow.Add(-time.Minute)}
+	}
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:                    big.NewInt(1),
+		ThisUpdate:                testNow.Add(-time.Hour),
+		NextUpdate:                nextUpdate,
+		RevokedCertificateEntries: entries,
+	}, ca.certificate, ca.key)
+	require.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: der})
+}
+
+func (ca *testCA) ocspResponse(t *testing.T, serial int64, status int) []byte {
+	response, err := ocsp.CreateResponse(ca.certificate, ca.certificate, ocsp.Response{
+		Status:       status,
+		SerialNumber: big.NewInt(serial),
+		ThisUpdate:   testNow.Add(-time.Hour),
+		NextUpdate:   testNow.Add(time.Hour),
+		RevokedAt:    testNow.Add(-time.Minute),
+	}, ca.key)
+	require.NoError(t, err)
+	return response
+}
+
+func newTestProvider(t *testing.T, ca *testCA, crls, ocspResponses [][]byte, options ...ProviderOpts) *Provider {
+	provider, err := New("x509", "X-SSL-Client-Cert", []string{"10.0.0.1"}, ca.pem, crls, ocspResponses, "", options...)
+	require.NoError(t, err)
+	provider.now = func() time.Time { return testNow }
+	return provider
+}
+
+func TestSession_FetchUser(t *testing.T) {
+	ca := newTestCA(t, "Test CA")
+	otherCA := newTestCA(t, "Other CA")
+	certificate := ca.issue(t, 10, nil)
+
+	type args struct {
+		crls          [][]byte
+		ocspResponses [][]byte
+		options       []ProviderOpts
+		certificate   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *User
+		wantErr error
+	}{
+		{
+			name:    "no certificate",
+			args:    args{certificate: ""},
+			wantErr: ErrNoCertificate,
+		},
+		{
+			name:    "invalid certificate",
+			args:    args{certificate: "not a certificate"},
+			wantErr: ErrInvalidCertificate,
+		},
+		{
+			name:    "untrusted certificate",
+			args:    args{certificate: otherCA.issue(t, 10, nil)},
+			wantErr: ErrUntrustedCertificate,
+		},
+		{
+			name: "expired certificate",
+			args: args{certificate: ca.issue(t, 10, func(c *x509.Certificate) {
+				c.NotAfter = testNow.Add(-time.Minute)
+			})},
+			wantErr: ErrUntrustedCertificate,
+		},
+		{
+			name: "server certificate",
+			args: args{certificate: ca.issue(t, 10, func(c *x509.Certificate) {
+				c.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+			})},
+			wantErr: ErrUntrustedCertificate,
+		},
+		{
+			name:    "revoked by crl",
+			args:    args{certificate: certificate, crls: [][]byte{ca.crl(t, testNow.Add(time.Hour), 10)}},
+			wantErr: ErrRevokedCertificate,
+		},
+		{
+			name:    "revoked by ocsp",
+			args:    args{certificate: certificate, ocspResponses: [][]byte{ca.ocspResponse(t, 10, ocsp.Revoked)}},
+			wantErr: ErrRevokedCertificate,
+		},
+		{
+			name: "revocation check required, no revocation data",
+			args: args{
+				certificate: certificate,
+				options:     []ProviderOpts{WithRevocationCheckRequired()},
+			},
+			wantErr: ErrUnknownRevocationStatus,
+		},
+		{
+			name: "revocation check required, expired crl",
+			args: args{
+				certificate: certificate,
+				crls:        [][]byte{ca.crl(t, testNow.Add(-time.Minute))},
+				options:     []ProviderOpts{WithRevocationCheckRequired()},
+			},
+			wantErr: ErrUnknownRevocationStatus,
+		},
+		{
+			name: "revocation check required, ocsp response of other certificate",
+			args: args{
+				certificate:   certificate,
+				ocspResponses: [][]byte{ca.ocspResponse(t, 11, ocsp.Good)},
+				options:       []ProviderOpts{WithRevocationCheckRequired()},
+			},
+			wantErr: ErrUnknownRevocationStatus,
+		},
+		{
+			name: "missing id field",
+			args: args{
+				certificate: certificate,
+				options:     []ProviderOpts{WithIDField(domain.X509CertificateFieldSANURI)},
+			},
+			wantErr: ErrMissingCertificateField,
+		},
+		{
+			name: "ok",
+			args: args{certificate: certificate},
+			want: &User{
+				ID:                "SERIALNUMBER=1234,CN=John Doe,2.5.4.4=Doe,2.5.4.42=John",
+				FirstName:         "John",
+				LastName:          "Doe",
+				DisplayName:       "John Doe",
+				PreferredUsername: "john.doe@corp.example.com",
+				Email:             "john.doe@example.com",
+				EmailVerified:     true,
+				Subject:           "SERIALNUMBER=1234,CN=John Doe,2.5.4.4=Doe,2.5.4.42=John",
+				Issuer:            "CN=Test CA",
+				SerialNumber:      "10",
+			},
+		},
+		{
+			name: "ok, revocation check required, crl and ocsp",
+			args: args{
+				certificate:   url.QueryEscape(certificate),
+				crls:          [][]byte{ca.crl(t, testNow.Add(time.Hour), 11)},
+				ocspResponses: [][]byte{ca.ocspResponse(t, 10, ocsp.Good)},
+				options: []ProviderOpts{
+					WithRevocationCheckRequired(),
+					WithIDField(domain.X509CertificateFieldSANUPN),
+					WithUsernameField(domain.X509CertificateFieldSANEmail),
+				},
+			},
+			want: &User{
+				ID:                "john.doe@corp.example.com",
+				FirstName:         "John",
+				LastName:          "Doe",
+				DisplayName:       "John Doe",
+				PreferredUsername: "john.doe@example.com",
+				Email:             "john.doe@example.com",
+				EmailVerified:     true,
+				Subject:           "SERIALNUMBER=1234,CN=John Doe,2.5.4.4=Doe,2.5.4.42=John",
+				Issuer:            "CN=Test CA",
+				SerialNumber:      "10",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := newTestProvider(t, ca, tt.args.crls, tt.args.ocspResponses, tt.args.options...)
+			user, err := provider.GetSession(tt.args.certificate).FetchUser(context.Background())
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, user)
+		})
+	}
+}
+
+func Test_parseForwardedCertificates(t *testing.T) {
+	ca := newTestCA(t, "Test CA")
+	certificate := ca.issue(t, 10, nil)
+	block, _ := pem.Decode([]byte(certificate))
+	der := base64.StdEncoding.EncodeToString(block.Bytes)
+	caBlock, _ := pem.Decode(ca.pem)
+	caDER := base64.StdEncoding.EncodeToString(caBlock.Bytes)
+
+	tests := []struct {
+		name      string
+		value     string
+		wantCount int
+	}{
+		{
+			name:      "pem",
+			value:     certificate,
+			wantCount: 1,
+		},
+		{
+			name:      "url encoded pem",
+			value:     url.QueryEscape(certificate),
+			wantCount: 1,
+		},
+		{
+			name:      "pem with spaces",
+			value:     strings.ReplaceAll(strings.TrimSpace(certificate), "\n", " "),
+			wantCount: 1,
+		},
+		{
+			name:      "pem chain",
+			value:     certificate + string(ca.pem),
+			wantCount: 2,
+		},
+		{
+			name:      "base64 der",
+			value:     der,
+			wantCount: 1,
+		},
+		{
+			name:      "base64 der chain",
+			value:     url.QueryEscape(der + "," + caDER),
+			wantCount: 2,
+		},
+		{
+			name:      "envoy xfcc",
+			value:     `By=spiffe://cluster.local/ns/default/sa/zitadel;Hash=abc;Cert="` + url.QueryEscape(certificate) + `";Subject="CN=John Doe"`,
+			wantCount: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certificates, err := parseForwardedCertificates(tt.value)
+			require.NoError(t, err)
+			require.Len(t, certificates, tt.wantCount)
+			assert.Equal(t, "John Doe", certificates[0].Subject.CommonName)
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package x509
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// User is the federated user of a client certificate.
+type User struct {
+	ID                string              `json:"id,omitempty"`
+	FirstName         string              `json:"firstName,omitempty"`
+	LastName          string              `json:"lastName,omitempty"`
+	DisplayName       string              `json:"displayName,omitempty"`
+	PreferredUsername string              `json:"preferredUsername,omitempty"`
+	Email             domain.EmailAddress `json:"email,omitempty"`
+	EmailVerified     bool                `json:"emailVerified,omitempty"`
+	// Subject, Issuer and SerialNumber identify the certificate used for the authentication.
+	Subject      string `json:"subject,omitempty"`
+	Issuer       string `json:"issuer,omitempty"`
+	SerialNumber string `json:"serialNumber,omitempty"`
+}
+
+func (u *User) GetID() string {
+	return u.ID
+}
+
+func (u *User) GetFirstName() string {
+	return u.FirstName
+}
+
+func (u *User) GetLastName() string {
+	return u.LastName
+}
+
+func (u *User) GetDisplayName() string {
+	return u.DisplayName
+}
+
+func (u *User) GetNickname() string {
+	return ""
+}
+
+func (u *User) GetPreferredUsername() string {
+	return u.PreferredUsername
+}
+
+func (u *User) GetEmail() domain.EmailAddress {
+	return u.Email
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerified
+}
+
+func (u *User) GetPhone() domain.PhoneNumber {
+	return ""
+}
+
+func (u *User) IsPhoneVerified() bool {
+	return false
+}
+
+func (u *User) GetPreferredLanguage() language.Tag {
+	return language.Und
+}
+
+func (u *User) GetAvatarURL() string {
+	return ""
+}
+
+func (u *User) GetProfile() string {
+	return ""
+}
@@ -0,0 +1,256 @@
+package x509
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/idp"
+)
+
+var (
+	ErrNoCACertificates  = errors.New("no CA certificates provided")
+	ErrInvalidCRL        = errors.New("invalid certificate revocation list")
+	ErrNoCertificateName = errors.New("certificate header name is missing")
+	ErrNoTrustedProxies  = errors.New("no trusted proxies provided")
+	ErrInvalidProxy      = errors.New("invalid trusted proxy")
+	ErrUntrustedProxy    = errors.New("request was not sent by a trusted proxy")
+)
+
+var _ idp.Provider = (*Provider)(nil)
+
+// Provider is the [idp.Provider] implementation for TLS client certificates (X.509),
+// e.g. of smart cards (PIV / CAC), which are forwarded by a trusted proxy terminating the TLS connection.
+// The forwarded certificate is only accepted from the configured proxy addresses,
+// as anyone else could send the header with an arbitrary (public) certificate.
+// The certificates are validated against the configured CA certificates
+// and the provided certificate revocation lists and OCSP responses (no online checks are done).
+type Provider struct {
+	name              string
+	certificateHeader string
+	trustedProxies    []netip.Prefix
+	roots             *x509.CertPool
+	crls              []*x509.RevocationList
+	ocspResponses     [][]byte
+
+	loginURL string
+
+	isLinkingAllowed  bool
+	isCreationAllowed bool
+	isAutoCreation    bool
+	isAutoUpdate      bool
+
+	revocationCheckRequired bool
+	idField                 domain.X509CertificateField
+	usernameField           domain.X509CertificateField
+
+	now func() time.Time
+}
+
+type ProviderOpts func(provider *Provider)
+
+// WithLinkingAllowed allows end users to link the federated user to an existing one.
+func WithLinkingAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isLinkingAllowed = true
+	}
+}
+
+// WithCreationAllowed allows end users to create a new user using the federated information.
+func WithCreationAllowed() ProviderOpts {
+	return func(p *Provider) {
+		p.isCreationAllowed = true
+	}
+}
+
+// WithAutoCreation enables that federated users are automatically created if not already existing.
+func WithAutoCreation() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoCreation = true
+	}
+}
+
+// WithAutoUpdate enables that information retrieved from the provider is automatically used to update
+// the existing user on each authentication.
+func WithAutoUpdate() ProviderOpts {
+	return func(p *Provider) {
+		p.isAutoUpdate = true
+	}
+}
+
+// WithRevocationCheckRequired rejects client certificates, whose revocation status is not known
+// by any of the provided (and not expired) certificate revocation lists or OCSP responses.
+func WithRevocationCheckRequired() ProviderOpts {
+	return func(p *Provider) {
+		p.revocationCheckRequired = true
+	}
+}
+
+// WithIDField configures the certificate field used as id of the federated user, default is the subject.
+func WithIDField(field domain.X509CertificateField) ProviderOpts {
+	return func(p *Provider) {
+		p.idField = field
+	}
+}
+
+// WithUsernameField configures the certificate field used as preferred username of the federated user.
+// By default, the UPN is used, falling back to the email address and the common name of the subject.
+func WithUsernameField(field domain.X509CertificateField) ProviderOpts {
+	return func(p *Provider) {
+		p.usernameField = field
+	}
+}
+
+// New creates a provider trusting the (PEM encoded) CA certificates.
+// The trusted proxies are IP addresses or CIDR ranges, from which the certificate header is accepted.
+// The certificate revocation lists can be PEM or DER encoded, the OCSP responses must be DER encoded.
+func New(
+	name,
+	certificateHeader string,
+	trustedProxies []string,
+	caCertificates []byte,
+	crls [][]byte,
+	ocspResponses [][]byte,
+	loginURL string,
+	options ...ProviderOpts,
+) (*Provider, error) {
+	if certificateHeader == "" {
+		return nil, ErrNoCertificateName
+	}
+	proxies, err := parseTrustedProxies(trustedProxies)
+	if err != nil {
+		return nil, err
+	}
+	roots := x509.NewCertPool()
+	if !roots.AppendCertsFromPEM(caCertificates) {
+		return nil, ErrNoCACertificates
+	}
+	revocationLists, err := parseRevocationLists(crls)
+	if err != nil {
+		return nil, err
+	}
+	provider := &Provider{
+		name:              name,
+		certificateHeader: certificateHeader,
+		trustedProxies:    proxies,
+		roots:             roots,
+		crls:              revocationLists,
+		ocspResponses:     ocspResponses,
+		loginURL:          loginURL,
+		now:               time.Now,
+	}
+	for _, option := range options {
+		option(provider)
+	}
+	return provider, nil
+}
+
+func parseTrustedProxies(trustedProxies []string) ([]netip.Prefix, error) {
+	if len(trustedProxies) == 0 {
+		return nil, ErrNoTrustedProxies
+	}
+	prefixes := make([]netip.Prefix, len(trustedProxies))
+	for i, proxy := range trustedProxies {
+		prefix, err := netip.ParsePrefix(proxy)
+		if err == nil {
+			prefixes[i] = prefix.Masked()
+			continue
+		}
+		addr, addrErr := netip.ParseAddr(proxy)
+		if addrErr != nil {
+			return nil, errors.Join(ErrInvalidProxy, err)
+		}
+		prefixes[i] = netip.PrefixFrom(addr, addr.BitLen())
+	}
+	return prefixes, nil
+}
+
+func parseRevocationLists(crls [][]byte) ([]*x509.RevocationList, error) {
+	revocationLists := make([]*x509.RevocationList, 0, len(crls))
+	for _, crl := range crls {
+		ders := [][]byte{crl}
+		if block, rest := pem.Decode(crl); block != nil {
+			ders = ders[:0]
+			for ; block != nil; block, rest = pem.Decode(rest) {
+				if block.Type == "X509 CRL" {
+					ders = append(ders, block.Bytes)
+				}
+			}
+		}
+		for _, der := range ders {
+			revocationList, err := x509.ParseRevocationList(der)
+			if err != nil {
+				return nil, errors.Join(ErrInvalidCRL, err)
+			}
+			revocationLists = append(revocationLists, revocationList)
+		}
+	}
+	return revocationLists, nil
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// CertificateHeader returns the name of the header, in which the trusted proxy forwards the client certificate.
+func (p *Provider) CertificateHeader() string {
+	return p.certificateHeader
+}
+
+func (p *Provider) BeginAuth(ctx context.Context, state string, _ ...idp.Parameter) (idp.Session, error) {
+	return &Session{
+		Provider: p,
+		loginURL: p.loginURL + state,
+	}, nil
+}
+
+// ForwardedCertificate returns the client certificate of the header,
+// if the request was sent by one of the trusted proxies.
+func (p *Provider) ForwardedCertificate(r *http.Request) (string, error) {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return "", errors.Join(ErrUntrustedProxy, err)
+	}
+	addr = addr.Unmap()
+	for _, proxy := range p.trustedProxies {
+		if proxy.Contains(addr) {
+			return r.Header.Get(p.certificateHeader), nil
+		}
+	}
+	return "", ErrUntrustedProxy
+}
+
+// GetSession returns a session for the forwarded client certificate,
+// which will be validated on [Session.FetchUser].
+func (p *Provider) GetSession(certificate string) *Session {
+	return &Session{
+		Provider:    p,
+		Certificate: certificate,
+	}
+}
+
+func (p *Provider) IsLinkingAllowed() bool {
+	return p.isLinkingAllowed
+}
+
+func (p *Provider) IsCreationAllowed() bool {
+	return p.isCreationAllowed
+}
+
+func (p *Provider) IsAutoCreation() bool {
+	return p.isAutoCreation
+}
+
+func (p *Provider) IsAutoUpdate() bool {
+	return p.isAutoUpdate
+}
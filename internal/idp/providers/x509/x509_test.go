@@ -0,0 +1,179 @@
+package x509
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func TestNew(t *testing.T) {
+	ca := newTestCA(t, "Test CA")
+
+	type args struct {
+		certificateHeader string
+		trustedProxies    []string
+		caCertificates    []byte
+		crls              [][]byte
+		options           []ProviderOpts
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr error
+		check   func(t *testing.T, p *Provider)
+	}{
+		{
+			name:    "missing header",
+			args:    args{caCertificates: ca.pem},
+			wantErr: ErrNoCertificateName,
+		},
+		{
+			name:    "missing trusted proxies",
+			args:    args{certificateHeader: "X-SSL-Client-Cert", caCertificates: ca.pem},
+			wantErr: ErrNoTrustedProxies,
+		},
+		{
+			name:    "invalid trusted proxy",
+			args:    args{certificateHeader: "X-SSL-Client-Cert", trustedProxies: []string{"proxy"}, caCertificates: ca.pem},
+			wantErr: ErrInvalidProxy,
+		},
+		{
+			name:    "missing ca certificates",
+			args:    args{certificateHeader: "X-SSL-Client-Cert", trustedProxies: []string{"10.0.0.1"}, caCertificates: []byte("invalid")},
+			wantErr: ErrNoCACertificates,
+		},
+		{
+			name: "invalid crl",
+			args: args{
+				certificateHeader: "X-SSL-Client-Cert",
+				trustedProxies:    []string{"10.0.0.1"},
+				caCertificates:    ca.pem,
+				crls:              [][]byte{[]byte("invalid")},
+			},
+			wantErr: ErrInvalidCRL,
+		},
+		{
+			name: "default",
+			args: args{
+				certificateHeader: "X-SSL-Client-Cert",
+				trustedProxies:    []string{"10.0.0.1", "192.168.0.0/16", "fd00::/8"},
+				caCertificates:    ca.pem,
+				crls:              [][]byte{append(ca.crl(t, testNow.Add(time.Hour)), ca.crl(t, testNow.Add(time.Hour), 10)...)},
+			},
+			check: func(t *testing.T, p *Provider) {
+				assert.Equal(t, "x509", p.Name())
+				assert.Equal(t, "X-SSL-Client-Cert", p.CertificateHeader())
+				assert.Len(t, p.trustedProxies, 3)
+				assert.Len(t, p.crls, 2)
+				assert.False(t, p.IsLinkingAllowed())
+				assert.False(t, p.IsCreationAllowed())
+				assert.False(t, p.IsAutoCreation())
+				assert.False(t, p.IsAutoUpdate())
+				assert.False(t, p.revocationCheckRequired)
+				assert.Equal(t, domain.X509CertificateFieldUnspecified, p.idField)
+				assert.Equal(t, domain.X509CertificateFieldUnspecified, p.usernameField)
+			},
+		},
+		{
+			name: "all options",
+			args: args{
+				certificateHeader: "X-SSL-Client-Cert",
+				trustedProxies:    []string{"10.0.0.1"},
+				caCertificates:    ca.pem,
+				options: []ProviderOpts{
+					WithLinkingAllowed(),
+					WithCreationAllowed(),
+					WithAutoCreation(),
+					WithAutoUpdate(),
+					WithRevocationCheckRequired(),
+					WithIDField(domain.X509CertificateFieldSANUPN),
+					WithUsernameField(domain.X509CertificateFieldSANEmail),
+				},
+			},
+			check: func(t *testing.T, p *Provider) {
+				assert.True(t, p.IsLinkingAllowed())
+				assert.True(t, p.IsCreationAllowed())
+				assert.True(t, p.IsAutoCreation())
+				assert.True(t, p.IsAutoUpdate())
+				assert.True(t, p.revocationCheckRequired)
+				assert.Equal(t, domain.X509CertificateFieldSANUPN, p.idField)
+				assert.Equal(t, domain.X509CertificateFieldSANEmail, p.usernameField)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := New("x509", tt.args.certificateHeader, tt.args.trustedProxies, tt.args.caCertificates, tt.args.crls, nil, "", tt.args.options...)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			tt.check(t, provider)
+		})
+	}
+}
+
+func TestProvider_ForwardedCertificate(t *testing.T) {
+	ca := newTestCA(t, "Test CA")
+	provider, err := New("x509", "X-SSL-Client-Cert", []string{"10.0.0.1", "192.168.0.0/16", "fd00::/8"}, ca.pem, nil, nil, "")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		want       string
+		wantErr    error
+	}{
+		{
+			name:       "single address",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "certificate",
+		},
+		{
+			name:       "address range",
+			remoteAddr: "192.168.1.1:1234",
+			want:       "certificate",
+		},
+		{
+			name:       "ipv6 address range",
+			remoteAddr: "[fd00::1]:1234",
+			want:       "certificate",
+		},
+		{
+			name:       "ipv4 mapped address",
+			remoteAddr: "[::ffff:10.0.0.1]:1234",
+			want:       "certificate",
+		},
+		{
+			name:       "untrusted address",
+			remoteAddr: "10.0.0.2:1234",
+			wantErr:    ErrUntrustedProxy,
+		},
+		{
+			name:       "invalid address",
+			remoteAddr: "proxy:1234",
+			wantErr:    ErrUntrustedProxy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/login/x509", nil)
+			r.RemoteAddr = tt.remoteAddr
+			r.Header.Set("X-SSL-Client-Cert", "certificate")
+			got, err := provider.ForwardedCertificate(r)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -22,6 +22,7 @@ type Commands interface {
 	PasswordChangeSent(ctx context.Context, orgID, userID string) error
 	HumanPhoneVerificationCodeSent(ctx context.Context, orgID, userID string, generatorInfo *senders.CodeGeneratorInfo) error
 	InviteCodeSent(ctx context.Context, orgID, userID string) error
+	UserDataExportLinkSent(ctx context.Context, userID, resourceOwner, exportID string) error
 	UsageNotificationSent(ctx context.Context, dueEvent *quota.NotificationDueEvent) error
 	MilestonePushed(ctx context.Context, instanceID string, msType milestone.Type, endpoints []string) error
 	BackChannelLogoutSent(ctx context.Context, id, oidcSessionID, instanceID string) (err error)
@@ -239,6 +239,20 @@ func (mr *MockCommandsMockRecorder) UsageNotificationSent(ctx, dueEvent any) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UsageNotificationSent", reflect.TypeOf((*MockCommands)(nil).UsageNotificationSent), ctx, dueEvent)
 }
 
+// UserDataExportLinkSent mocks base method.
+func (m *MockCommands) UserDataExportLinkSent(ctx context.Context, userID, resourceOwner, exportID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UserDataExportLinkSent", ctx, userID, resourceOwner, exportID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UserDataExportLinkSent indicates an expected call of UserDataExportLinkSent.
+func (mr *MockCommandsMockRecorder) UserDataExportLinkSent(ctx, userID, resourceOwner, exportID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UserDataExportLinkSent", reflect.TypeOf((*MockCommands)(nil).UserDataExportLinkSent), ctx, userID, resourceOwner, exportID)
+}
+
 // UserDomainClaimedSent mocks base method.
 func (m *MockCommands) UserDomainClaimedSent(ctx context.Context, orgID, userID string) error {
 	m.ctrl.T.Helper()
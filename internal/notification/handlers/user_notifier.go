@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/api/assets"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
@@ -82,6 +83,11 @@ func init() {
 			return commands.InviteCodeSent(ctx, orgID, id)
 		},
 	)
+	RegisterSentHandler(user.HumanDataExportSucceededType,
+		func(ctx context.Context, commands Commands, id, orgID string, _ *senders.CodeGeneratorInfo, args map[string]any) error {
+			return commands.UserDataExportLinkSent(ctx, id, orgID, args["CodeID"].(string))
+		},
+	)
 }
 
 const (
@@ -182,6 +188,10 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 					Event:  user.HumanInviteCodeAddedType,
 					Reduce: u.reduceInviteCodeAdded,
 				},
+				{
+					Event:  user.HumanDataExportSucceededType,
+					Reduce: u.reduceDataExportSucceeded,
+				},
 			},
 		},
 		{
@@ -824,6 +834,48 @@ func (u *userNotifier) inviteCodeTemplate(origin string, e *user.HumanInviteCode
 	return login.InviteUserLinkTemplate(origin, e.Aggregate().ID, e.Aggregate().ResourceOwner, e.AuthRequestID)
 }
 
+func (u *userNotifier) reduceDataExportSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanDataExportSucceededEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Xn5cw", "reduce.wrong.event.type %s", user.HumanDataExportSucceededType)
+	}
+
+	return handler.NewStatement(event, func(ctx context.Context, ex handler.Executer, projectionName string) error {
+		ctx = HandlerContext(ctx, event.Aggregate())
+		alreadyHandled, err := u.checkIfCodeAlreadyHandledOrExpired(ctx, event, e.Expiry, map[string]interface{}{"id": e.ID}, user.HumanDataExportLinkSentType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		origin := http_util.DomainContext(ctx).Origin()
+		return u.queue.Insert(ctx,
+			&notification.Request{
+				Aggregate:         e.Aggregate(),
+				UserID:            e.Aggregate().ID,
+				UserResourceOwner: e.Aggregate().ResourceOwner,
+				TriggeredAtOrigin: origin,
+				EventType:         e.EventType,
+				NotificationType:  domain.NotificationTypeEmail,
+				MessageType:       domain.UserDataExportMessageType,
+				URLTemplate:       domain.UserDataExportLink(origin+assets.HandlerPrefix, e.Aggregate().ID, e.ID, "{{.Code}}"),
+				Args: &domain.NotificationArguments{
+					CodeID: e.ID,
+				},
+				CodeExpiry: e.Expiry,
+				Code:       e.Code,
+			},
+			queue.WithQueueName(notification.QueueName),
+			queue.WithMaxAttempts(u.maxAttempts),
+		)
+	}), nil
+}
+
 func (u *userNotifier) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
 	if expiry > 0 && event.CreatedAt().Add(expiry).Before(time.Now().UTC()) {
 		return true, nil
@@ -0,0 +1,15 @@
+package types
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/assets"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendUserDataExportLink(ctx context.Context, user *query.NotifyUser, code, exportID string) error {
+	url := domain.UserDataExportLink(http_utils.DomainContext(ctx).Origin()+assets.HandlerPrefix, user.ID, exportID, code)
+	return notify(url, nil, domain.UserDataExportMessageType, true)
+}
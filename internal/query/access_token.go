@@ -36,6 +36,9 @@ type OIDCSessionAccessTokenReadModel struct {
 	UserAgent             *domain.UserAgent
 	Reason                domain.TokenReason
 	Actor                 *domain.TokenActor
+	// AccessTokenAudience is set if the audience of the current access token
+	// was restricted to a subset of the session Audience.
+	AccessTokenAudience []string
 }
 
 func newOIDCSessionAccessTokenReadModel(id string) *OIDCSessionAccessTokenReadModel {
@@ -54,7 +57,8 @@ func (wm *OIDCSessionAccessTokenReadModel) Reduce() error {
 		case *oidcsession.AccessTokenAddedEvent:
 			wm.reduceAccessTokenAdded(e)
 		case *oidcsession.AccessTokenRevokedEvent,
-			*oidcsession.RefreshTokenRevokedEvent:
+			*oidcsession.RefreshTokenRevokedEvent,
+			*oidcsession.RefreshTokenReusedEvent:
 			wm.reduceTokenRevoked(event)
 		}
 	}
@@ -71,6 +75,7 @@ func (wm *OIDCSessionAccessTokenReadModel) Query() *eventstore.SearchQueryBuilde
 			oidcsession.AccessTokenAddedType,
 			oidcsession.AccessTokenRevokedType,
 			oidcsession.RefreshTokenRevokedType,
+			oidcsession.RefreshTokenReusedType,
 		).
 		Builder()
 }
@@ -95,6 +100,7 @@ func (wm *OIDCSessionAccessTokenReadModel) reduceAccessTokenAdded(e *oidcsession
 	wm.AccessTokenExpiration = e.CreationDate().Add(e.Lifetime)
 	wm.Reason = e.Reason
 	wm.Actor = e.Actor
+	wm.AccessTokenAudience = e.Audience
 }
 
 func (wm *OIDCSessionAccessTokenReadModel) reduceTokenRevoked(e eventstore.Event) {
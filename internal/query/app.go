@@ -37,39 +37,65 @@ type App struct {
 	ProjectID string
 	Name      string
 
-	OIDCConfig *OIDCApp
-	SAMLConfig *SAMLApp
-	APIConfig  *APIApp
+	OIDCConfig  *OIDCApp
+	SAMLConfig  *SAMLApp
+	APIConfig   *APIApp
+	WSFedConfig *WSFedApp
+	CASConfig   *CASApp
 }
 
 type OIDCApp struct {
-	RedirectURIs             database.TextArray[string]
-	ResponseTypes            database.NumberArray[domain.OIDCResponseType]
-	GrantTypes               database.NumberArray[domain.OIDCGrantType]
-	AppType                  domain.OIDCApplicationType
-	ClientID                 string
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs   database.TextArray[string]
-	Version                  domain.OIDCVersion
-	ComplianceProblems       database.TextArray[string]
-	IsDevMode                bool
-	AccessTokenType          domain.OIDCTokenType
-	AssertAccessTokenRole    bool
-	AssertIDTokenRole        bool
-	AssertIDTokenUserinfo    bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        database.TextArray[string]
-	AllowedOrigins           database.TextArray[string]
-	SkipNativeAppSuccessPage bool
-	BackChannelLogoutURI     string
-	LoginVersion             domain.LoginVersion
-	LoginBaseURI             *string
+	RedirectURIs                 database.TextArray[string]
+	ResponseTypes                database.NumberArray[domain.OIDCResponseType]
+	GrantTypes                   database.NumberArray[domain.OIDCGrantType]
+	AppType                      domain.OIDCApplicationType
+	ClientID                     string
+	AuthMethodType               domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs       database.TextArray[string]
+	Version                      domain.OIDCVersion
+	ComplianceProblems           database.TextArray[string]
+	IsDevMode                    bool
+	AccessTokenType              domain.OIDCTokenType
+	AssertAccessTokenRole        bool
+	AssertIDTokenRole            bool
+	AssertIDTokenUserinfo        bool
+	ClockSkew                    time.Duration
+	AdditionalOrigins            database.TextArray[string]
+	AllowedOrigins               database.TextArray[string]
+	SkipNativeAppSuccessPage     bool
+	BackChannelLogoutURI         string
+	LoginVersion                 domain.LoginVersion
+	LoginBaseURI                 *string
+	RefreshTokenRotation         domain.OIDCRefreshTokenRotation
+	RefreshTokenLifetime         time.Duration
+	RefreshTokenIdleLifetime     time.Duration
+	IDTokenEncryptedResponseAlg  string
+	IDTokenEncryptedResponseEnc  string
+	UserinfoEncryptedResponseAlg string
+	UserinfoEncryptedResponseEnc string
+	JWKS                         string
+	JWKSURI                      string
+	FrontChannelLogoutURI        string
 }
 
 type SAMLApp struct {
-	Metadata     []byte
-	MetadataURL  string
-	EntityID     string
+	Metadata          []byte
+	MetadataURL       string
+	EntityID          string
+	LoginVersion      domain.LoginVersion
+	LoginBaseURI      *string
+	NameIDSource      domain.SAMLNameIDSource
+	AttributeMappings []*domain.SAMLAttributeMapping
+}
+
+type WSFedApp struct {
+	Realm     string
+	ReplyURLs database.TextArray[string]
+	TokenType domain.WSFedTokenType
+}
+
+type CASApp struct {
+	ServiceURLs  database.TextArray[string]
 	LoginVersion domain.LoginVersion
 	LoginBaseURI *string
 }
@@ -77,6 +103,16 @@ type SAMLApp struct {
 type APIApp struct {
 	ClientID       string
 	AuthMethodType domain.APIAuthMethodType
+	ResourceURI    string
+
+	IntrospectionSignedResponse bool
+	IntrospectionEncryptionKey  []byte
+}
+
+// APIResource is an API application registered for a resource indicator (RFC 8707).
+type APIResource struct {
+	ClientID  string
+	ProjectID string
 }
 
 type AppSearchQueries struct {
@@ -168,6 +204,68 @@ var (
 		name:  projection.AppSAMLConfigColumnLoginBaseURI,
 		table: appSAMLConfigsTable,
 	}
+	AppSAMLConfigColumnNameIDSource = Column{
+		name:  projection.AppSAMLConfigColumnNameIDSource,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnAttributeMappings = Column{
+		name:  projection.AppSAMLConfigColumnAttributeMappings,
+		table: appSAMLConfigsTable,
+	}
+)
+
+var (
+	appWSFedConfigsTable = table{
+		name:          projection.AppWSFedTable,
+		instanceIDCol: projection.AppWSFedConfigColumnInstanceID,
+	}
+	AppWSFedConfigColumnInstanceID = Column{
+		name:  projection.AppWSFedConfigColumnInstanceID,
+		table: appWSFedConfigsTable,
+	}
+	AppWSFedConfigColumnAppID = Column{
+		name:  projection.AppWSFedConfigColumnAppID,
+		table: appWSFedConfigsTable,
+	}
+	AppWSFedConfigColumnRealm = Column{
+		name:  projection.AppWSFedConfigColumnRealm,
+		table: appWSFedConfigsTable,
+	}
+	AppWSFedConfigColumnReplyURLs = Column{
+		name:  projection.AppWSFedConfigColumnReplyURLs,
+		table: appWSFedConfigsTable,
+	}
+	AppWSFedConfigColumnTokenType = Column{
+		name:  projection.AppWSFedConfigColumnTokenType,
+		table: appWSFedConfigsTable,
+	}
+)
+
+var (
+	appCASConfigsTable = table{
+		name:          projection.AppCASTable,
+		instanceIDCol: projection.AppCASConfigColumnInstanceID,
+	}
+	AppCASConfigColumnInstanceID = Column{
+		name:  projection.AppCASConfigColumnInstanceID,
+		table: appCASConfigsTable,
+	}
+	AppCASConfigColumnAppID = Column{
+		name:  projection.AppCASConfigColumnAppID,
+		table: appCASConfigsTable,
+	}
+	AppCASConfigColumnServiceURLs = Column{
+		name:  projection.AppCASConfigColumnServiceURLs,
+		table: appCASConfigsTable,
+	}
+	AppCASConfigColumnLoginVersion = Column{
+		name:  projection.AppCASConfigColumnLoginVersion,
+		table: appCASConfigsTable,
+	}
+	AppCASConfigColumnLoginBaseURI = Column{
+		name:  projection.AppCASConfigColumnLoginBaseURI,
+		table: appCASConfigsTable,
+	}
 )
 
 var (
@@ -187,6 +285,18 @@ var (
 		name:  projection.AppAPIConfigColumnAuthMethod,
 		table: appAPIConfigsTable,
 	}
+	AppAPIConfigColumnResourceURI = Column{
+		name:  projection.AppAPIConfigColumnResourceURI,
+		table: appAPIConfigsTable,
+	}
+	AppAPIConfigColumnIntrospectionSignedResponse = Column{
+		name:  projection.AppAPIConfigColumnIntrospectionSignedResponse,
+		table: appAPIConfigsTable,
+	}
+	AppAPIConfigColumnIntrospectionEncryptionKey = Column{
+		name:  projection.AppAPIConfigColumnIntrospectionEncryptionKey,
+		table: appAPIConfigsTable,
+	}
 )
 
 var (
@@ -278,6 +388,46 @@ var (
 		name:  projection.AppOIDCConfigColumnLoginBaseURI,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnRefreshTokenRotation = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenRotation,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRefreshTokenLifetime = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenLifetime,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRefreshTokenIdleLifetime = Column{
+		name:  projection.AppOIDCConfigColumnRefreshTokenIdleLifetime,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnIDTokenEncryptedResponseAlg = Column{
+		name:  projection.AppOIDCConfigColumnIDTokenEncryptedResponseAlg,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnIDTokenEncryptedResponseEnc = Column{
+		name:  projection.AppOIDCConfigColumnIDTokenEncryptedResponseEnc,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnUserinfoEncryptedResponseAlg = Column{
+		name:  projection.AppOIDCConfigColumnUserinfoEncryptedResponseAlg,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnUserinfoEncryptedResponseEnc = Column{
+		name:  projection.AppOIDCConfigColumnUserinfoEncryptedResponseEnc,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnJWKS = Column{
+		name:  projection.AppOIDCConfigColumnJWKS,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnJWKSURI = Column{
+		name:  projection.AppOIDCConfigColumnJWKSURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnFrontChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnFrontChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string) (app *App, err error) {
@@ -360,6 +510,8 @@ func (q *Queries) ProjectByClientID(ctx context.Context, appID string) (project
 			sq.Eq{AppOIDCConfigColumnClientID.identifier(): appID},
 			sq.Eq{AppAPIConfigColumnClientID.identifier(): appID},
 			sq.Eq{AppSAMLConfigColumnAppID.identifier(): appID},
+			sq.Eq{AppWSFedConfigColumnAppID.identifier(): appID},
+			sq.Eq{AppCASConfigColumnAppID.identifier(): appID},
 		},
 	}).ToSql()
 	if err != nil {
@@ -456,6 +608,8 @@ func (q *Queries) ProjectIDFromClientID(ctx context.Context, appID string) (id s
 			sq.Eq{AppOIDCConfigColumnClientID.identifier(): appID},
 			sq.Eq{AppAPIConfigColumnClientID.identifier(): appID},
 			sq.Eq{AppSAMLConfigColumnAppID.identifier(): appID},
+			sq.Eq{AppWSFedConfigColumnAppID.identifier(): appID},
+			sq.Eq{AppCASConfigColumnAppID.identifier(): appID},
 		},
 	}
 	query, args, err := stmt.Where(where).ToSql()
@@ -599,6 +753,32 @@ func (q *Queries) SearchClientIDs(ctx context.Context, queries *AppSearchQueries
 	return ids, nil
 }
 
+// ActiveAPIResourcesByURI returns the active API applications registered with the resource uri.
+func (q *Queries) ActiveAPIResourcesByURI(ctx context.Context, resourceURI string) (resources []*APIResource, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareAPIResourcesQuery()
+	eq := sq.Eq{
+		AppColumnInstanceID.identifier():           authz.GetInstance(ctx).InstanceID(),
+		AppColumnState.identifier():                domain.AppStateActive,
+		AppAPIConfigColumnResourceURI.identifier(): resourceURI,
+	}
+	stmt, args, err := query.Where(eq).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-Rs8iu", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		resources, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Rs9iv", "Errors.Internal")
+	}
+	return resources, nil
+}
+
 func (q *Queries) OIDCClientLoginVersion(ctx context.Context, clientID string) (loginVersion domain.LoginVersion, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -704,6 +884,9 @@ func prepareAppQuery(activeOnly bool) (sq.SelectBuilder, func(*sql.Row) (*App, e
 		AppAPIConfigColumnAppID.identifier(),
 		AppAPIConfigColumnClientID.identifier(),
 		AppAPIConfigColumnAuthMethod.identifier(),
+		AppAPIConfigColumnResourceURI.identifier(),
+		AppAPIConfigColumnIntrospectionSignedResponse.identifier(),
+		AppAPIConfigColumnIntrospectionEncryptionKey.identifier(),
 
 		AppOIDCConfigColumnAppID.identifier(),
 		AppOIDCConfigColumnVersion.identifier(),
@@ -725,6 +908,16 @@ func prepareAppQuery(activeOnly bool) (sq.SelectBuilder, func(*sql.Row) (*App, e
 		AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 		AppOIDCConfigColumnLoginVersion.identifier(),
 		AppOIDCConfigColumnLoginBaseURI.identifier(),
+		AppOIDCConfigColumnRefreshTokenRotation.identifier(),
+		AppOIDCConfigColumnRefreshTokenLifetime.identifier(),
+		AppOIDCConfigColumnRefreshTokenIdleLifetime.identifier(),
+		AppOIDCConfigColumnIDTokenEncryptedResponseAlg.identifier(),
+		AppOIDCConfigColumnIDTokenEncryptedResponseEnc.identifier(),
+		AppOIDCConfigColumnUserinfoEncryptedResponseAlg.identifier(),
+		AppOIDCConfigColumnUserinfoEncryptedResponseEnc.identifier(),
+		AppOIDCConfigColumnJWKS.identifier(),
+		AppOIDCConfigColumnJWKSURI.identifier(),
+		AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
 
 		AppSAMLConfigColumnAppID.identifier(),
 		AppSAMLConfigColumnEntityID.identifier(),
@@ -732,6 +925,18 @@ func prepareAppQuery(activeOnly bool) (sq.SelectBuilder, func(*sql.Row) (*App, e
 		AppSAMLConfigColumnMetadataURL.identifier(),
 		AppSAMLConfigColumnLoginVersion.identifier(),
 		AppSAMLConfigColumnLoginBaseURI.identifier(),
+		AppSAMLConfigColumnNameIDSource.identifier(),
+		AppSAMLConfigColumnAttributeMappings.identifier(),
+
+		AppWSFedConfigColumnAppID.identifier(),
+		AppWSFedConfigColumnRealm.identifier(),
+		AppWSFedConfigColumnReplyURLs.identifier(),
+		AppWSFedConfigColumnTokenType.identifier(),
+
+		AppCASConfigColumnAppID.identifier(),
+		AppCASConfigColumnServiceURLs.identifier(),
+		AppCASConfigColumnLoginVersion.identifier(),
+		AppCASConfigColumnLoginBaseURI.identifier(),
 	).From(appsTable.identifier()).
 		PlaceholderFormat(sq.Dollar)
 
@@ -740,6 +945,8 @@ func prepareAppQuery(activeOnly bool) (sq.SelectBuilder, func(*sql.Row) (*App, e
 				LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 				LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
 				LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)).
+				LeftJoin(join(AppWSFedConfigColumnAppID, AppColumnID)).
+				LeftJoin(join(AppCASConfigColumnAppID, AppColumnID)).
 				LeftJoin(join(ProjectColumnID, AppColumnProjectID)).
 				LeftJoin(join(OrgColumnID, AppColumnResourceOwner)),
 			scanApp
@@ -747,7 +954,9 @@ func prepareAppQuery(activeOnly bool) (sq.SelectBuilder, func(*sql.Row) (*App, e
 	return query.
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
-			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)),
+			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppWSFedConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppCASConfigColumnAppID, AppColumnID)),
 		scanApp
 }
 
@@ -755,9 +964,11 @@ func scanApp(row *sql.Row) (*App, error) {
 	app := new(App)
 
 	var (
-		apiConfig  = sqlAPIConfig{}
-		oidcConfig = sqlOIDCConfig{}
-		samlConfig = sqlSAMLConfig{}
+		apiConfig   = sqlAPIConfig{}
+		oidcConfig  = sqlOIDCConfig{}
+		samlConfig  = sqlSAMLConfig{}
+		wsFedConfig = sqlWSFedConfig{}
+		casConfig   = sqlCASConfig{}
 	)
 
 	err := row.Scan(
@@ -773,6 +984,9 @@ func scanApp(row *sql.Row) (*App, error) {
 		&apiConfig.appID,
 		&apiConfig.clientID,
 		&apiConfig.authMethod,
+		&apiConfig.resourceURI,
+		&apiConfig.introspectionSignedResponse,
+		&apiConfig.introspectionEncryptionKey,
 
 		&oidcConfig.appID,
 		&oidcConfig.version,
@@ -794,6 +1008,16 @@ func scanApp(row *sql.Row) (*App, error) {
 		&oidcConfig.backChannelLogoutURI,
 		&oidcConfig.loginVersion,
 		&oidcConfig.loginBaseURI,
+		&oidcConfig.refreshTokenRotation,
+		&oidcConfig.refreshTokenLifetime,
+		&oidcConfig.refreshTokenIdleLifetime,
+		&oidcConfig.idTokenEncryptedResponseAlg,
+		&oidcConfig.idTokenEncryptedResponseEnc,
+		&oidcConfig.userinfoEncryptedResponseAlg,
+		&oidcConfig.userinfoEncryptedResponseEnc,
+		&oidcConfig.jwks,
+		&oidcConfig.jwksURI,
+		&oidcConfig.frontChannelLogoutURI,
 
 		&samlConfig.appID,
 		&samlConfig.entityID,
@@ -801,6 +1025,18 @@ func scanApp(row *sql.Row) (*App, error) {
 		&samlConfig.metadataURL,
 		&samlConfig.loginVersion,
 		&samlConfig.loginBaseURI,
+		&samlConfig.nameIDSource,
+		&samlConfig.attributeMappings,
+
+		&wsFedConfig.appID,
+		&wsFedConfig.realm,
+		&wsFedConfig.replyURLs,
+		&wsFedConfig.tokenType,
+
+		&casConfig.appID,
+		&casConfig.serviceURLs,
+		&casConfig.loginVersion,
+		&casConfig.loginBaseURI,
 	)
 
 	if err != nil {
@@ -813,6 +1049,8 @@ func scanApp(row *sql.Row) (*App, error) {
 	apiConfig.set(app)
 	oidcConfig.set(app)
 	samlConfig.set(app)
+	wsFedConfig.set(app)
+	casConfig.set(app)
 
 	return app, nil
 }
@@ -848,6 +1086,16 @@ func prepareOIDCAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 			AppOIDCConfigColumnLoginVersion.identifier(),
 			AppOIDCConfigColumnLoginBaseURI.identifier(),
+			AppOIDCConfigColumnRefreshTokenRotation.identifier(),
+			AppOIDCConfigColumnRefreshTokenLifetime.identifier(),
+			AppOIDCConfigColumnRefreshTokenIdleLifetime.identifier(),
+			AppOIDCConfigColumnIDTokenEncryptedResponseAlg.identifier(),
+			AppOIDCConfigColumnIDTokenEncryptedResponseEnc.identifier(),
+			AppOIDCConfigColumnUserinfoEncryptedResponseAlg.identifier(),
+			AppOIDCConfigColumnUserinfoEncryptedResponseEnc.identifier(),
+			AppOIDCConfigColumnJWKS.identifier(),
+			AppOIDCConfigColumnJWKSURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
 		).From(appsTable.identifier()).
 			Join(join(AppOIDCConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (*App, error) {
@@ -887,6 +1135,16 @@ func prepareOIDCAppQuery() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
 				&oidcConfig.backChannelLogoutURI,
 				&oidcConfig.loginVersion,
 				&oidcConfig.loginBaseURI,
+				&oidcConfig.refreshTokenRotation,
+				&oidcConfig.refreshTokenLifetime,
+				&oidcConfig.refreshTokenIdleLifetime,
+				&oidcConfig.idTokenEncryptedResponseAlg,
+				&oidcConfig.idTokenEncryptedResponseEnc,
+				&oidcConfig.userinfoEncryptedResponseAlg,
+				&oidcConfig.userinfoEncryptedResponseEnc,
+				&oidcConfig.jwks,
+				&oidcConfig.jwksURI,
+				&oidcConfig.frontChannelLogoutURI,
 			)
 
 			if err != nil {
@@ -909,6 +1167,8 @@ func prepareProjectIDByAppQuery() (sq.SelectBuilder, func(*sql.Row) (projectID s
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppWSFedConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppCASConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Row) (projectID string, err error) {
 			err = row.Scan(
 				&projectID,
@@ -943,6 +1203,8 @@ func prepareProjectByAppQuery() (sq.SelectBuilder, func(*sql.Row) (*Project, err
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppWSFedConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppCASConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Project, error) {
 			p := new(Project)
@@ -983,6 +1245,9 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 			AppAPIConfigColumnAppID.identifier(),
 			AppAPIConfigColumnClientID.identifier(),
 			AppAPIConfigColumnAuthMethod.identifier(),
+			AppAPIConfigColumnResourceURI.identifier(),
+			AppAPIConfigColumnIntrospectionSignedResponse.identifier(),
+			AppAPIConfigColumnIntrospectionEncryptionKey.identifier(),
 
 			AppOIDCConfigColumnAppID.identifier(),
 			AppOIDCConfigColumnVersion.identifier(),
@@ -1004,6 +1269,16 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
 			AppOIDCConfigColumnLoginVersion.identifier(),
 			AppOIDCConfigColumnLoginBaseURI.identifier(),
+			AppOIDCConfigColumnRefreshTokenRotation.identifier(),
+			AppOIDCConfigColumnRefreshTokenLifetime.identifier(),
+			AppOIDCConfigColumnRefreshTokenIdleLifetime.identifier(),
+			AppOIDCConfigColumnIDTokenEncryptedResponseAlg.identifier(),
+			AppOIDCConfigColumnIDTokenEncryptedResponseEnc.identifier(),
+			AppOIDCConfigColumnUserinfoEncryptedResponseAlg.identifier(),
+			AppOIDCConfigColumnUserinfoEncryptedResponseEnc.identifier(),
+			AppOIDCConfigColumnJWKS.identifier(),
+			AppOIDCConfigColumnJWKSURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
@@ -1011,20 +1286,36 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 			AppSAMLConfigColumnMetadataURL.identifier(),
 			AppSAMLConfigColumnLoginVersion.identifier(),
 			AppSAMLConfigColumnLoginBaseURI.identifier(),
+			AppSAMLConfigColumnNameIDSource.identifier(),
+			AppSAMLConfigColumnAttributeMappings.identifier(),
+
+			AppWSFedConfigColumnAppID.identifier(),
+			AppWSFedConfigColumnRealm.identifier(),
+			AppWSFedConfigColumnReplyURLs.identifier(),
+			AppWSFedConfigColumnTokenType.identifier(),
+
+			AppCASConfigColumnAppID.identifier(),
+			AppCASConfigColumnServiceURLs.identifier(),
+			AppCASConfigColumnLoginVersion.identifier(),
+			AppCASConfigColumnLoginBaseURI.identifier(),
 			countColumn.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppSAMLConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppWSFedConfigColumnAppID, AppColumnID)).
+			LeftJoin(join(AppCASConfigColumnAppID, AppColumnID)).
 			PlaceholderFormat(sq.Dollar), func(row *sql.Rows) (*Apps, error) {
 			apps := &Apps{Apps: []*App{}}
 
 			for row.Next() {
 				app := new(App)
 				var (
-					apiConfig  = sqlAPIConfig{}
-					oidcConfig = sqlOIDCConfig{}
-					samlConfig = sqlSAMLConfig{}
+					apiConfig   = sqlAPIConfig{}
+					oidcConfig  = sqlOIDCConfig{}
+					samlConfig  = sqlSAMLConfig{}
+					wsFedConfig = sqlWSFedConfig{}
+					casConfig   = sqlCASConfig{}
 				)
 
 				err := row.Scan(
@@ -1040,6 +1331,9 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 					&apiConfig.appID,
 					&apiConfig.clientID,
 					&apiConfig.authMethod,
+					&apiConfig.resourceURI,
+					&apiConfig.introspectionSignedResponse,
+					&apiConfig.introspectionEncryptionKey,
 
 					&oidcConfig.appID,
 					&oidcConfig.version,
@@ -1061,6 +1355,16 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 					&oidcConfig.backChannelLogoutURI,
 					&oidcConfig.loginVersion,
 					&oidcConfig.loginBaseURI,
+					&oidcConfig.refreshTokenRotation,
+					&oidcConfig.refreshTokenLifetime,
+					&oidcConfig.refreshTokenIdleLifetime,
+					&oidcConfig.idTokenEncryptedResponseAlg,
+					&oidcConfig.idTokenEncryptedResponseEnc,
+					&oidcConfig.userinfoEncryptedResponseAlg,
+					&oidcConfig.userinfoEncryptedResponseEnc,
+					&oidcConfig.jwks,
+					&oidcConfig.jwksURI,
+					&oidcConfig.frontChannelLogoutURI,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
@@ -1068,6 +1372,18 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 					&samlConfig.metadataURL,
 					&samlConfig.loginVersion,
 					&samlConfig.loginBaseURI,
+					&samlConfig.nameIDSource,
+					&samlConfig.attributeMappings,
+
+					&wsFedConfig.appID,
+					&wsFedConfig.realm,
+					&wsFedConfig.replyURLs,
+					&wsFedConfig.tokenType,
+
+					&casConfig.appID,
+					&casConfig.serviceURLs,
+					&casConfig.loginVersion,
+					&casConfig.loginBaseURI,
 
 					&apps.Count,
 				)
@@ -1079,6 +1395,8 @@ func prepareAppsQuery() (sq.SelectBuilder, func(*sql.Rows) (*Apps, error)) {
 				apiConfig.set(app)
 				oidcConfig.set(app)
 				samlConfig.set(app)
+				wsFedConfig.set(app)
+				casConfig.set(app)
 
 				apps.Apps = append(apps.Apps, app)
 			}
@@ -1117,6 +1435,28 @@ func prepareClientIDsQuery() (sq.SelectBuilder, func(*sql.Rows) ([]string, error
 		}
 }
 
+func prepareAPIResourcesQuery() (sq.SelectBuilder, func(*sql.Rows) ([]*APIResource, error)) {
+	return sq.Select(
+			AppAPIConfigColumnClientID.identifier(),
+			AppColumnProjectID.identifier(),
+		).From(appsTable.identifier()).
+			Join(join(AppAPIConfigColumnAppID, AppColumnID)).
+			PlaceholderFormat(sq.Dollar), func(rows *sql.Rows) ([]*APIResource, error) {
+			resources := make([]*APIResource, 0)
+			for rows.Next() {
+				resource := new(APIResource)
+				if err := rows.Scan(
+					&resource.ClientID,
+					&resource.ProjectID,
+				); err != nil {
+					return nil, zerrors.ThrowInternal(err, "QUERY-Rs0iw", "Errors.Internal")
+				}
+				resources = append(resources, resource)
+			}
+			return resources, nil
+		}
+}
+
 func prepareLoginVersionByOIDCClientID() (sq.SelectBuilder, func(*sql.Row) (domain.LoginVersion, error)) {
 	return sq.Select(
 			AppOIDCConfigColumnLoginVersion.identifier(),
@@ -1148,26 +1488,36 @@ func prepareLoginVersionBySAMLAppID() (sq.SelectBuilder, func(*sql.Row) (domain.
 }
 
 type sqlOIDCConfig struct {
-	appID                    sql.NullString
-	version                  sql.NullInt32
-	clientID                 sql.NullString
-	redirectUris             database.TextArray[string]
-	applicationType          sql.NullInt16
-	authMethodType           sql.NullInt16
-	postLogoutRedirectUris   database.TextArray[string]
-	devMode                  sql.NullBool
-	accessTokenType          sql.NullInt16
-	accessTokenRoleAssertion sql.NullBool
-	iDTokenRoleAssertion     sql.NullBool
-	iDTokenUserinfoAssertion sql.NullBool
-	clockSkew                sql.NullInt64
-	additionalOrigins        database.TextArray[string]
-	responseTypes            database.NumberArray[domain.OIDCResponseType]
-	grantTypes               database.NumberArray[domain.OIDCGrantType]
-	skipNativeAppSuccessPage sql.NullBool
-	backChannelLogoutURI     sql.NullString
-	loginVersion             sql.NullInt16
-	loginBaseURI             sql.NullString
+	appID                        sql.NullString
+	version                      sql.NullInt32
+	clientID                     sql.NullString
+	redirectUris                 database.TextArray[string]
+	applicationType              sql.NullInt16
+	authMethodType               sql.NullInt16
+	postLogoutRedirectUris       database.TextArray[string]
+	devMode                      sql.NullBool
+	accessTokenType              sql.NullInt16
+	accessTokenRoleAssertion     sql.NullBool
+	iDTokenRoleAssertion         sql.NullBool
+	iDTokenUserinfoAssertion     sql.NullBool
+	clockSkew                    sql.NullInt64
+	additionalOrigins            database.TextArray[string]
+	responseTypes                database.NumberArray[domain.OIDCResponseType]
+	grantTypes                   database.NumberArray[domain.OIDCGrantType]
+	skipNativeAppSuccessPage     sql.NullBool
+	backChannelLogoutURI         sql.NullString
+	loginVersion                 sql.NullInt16
+	loginBaseURI                 sql.NullString
+	refreshTokenRotation         sql.NullInt16
+	refreshTokenLifetime         sql.NullInt64
+	refreshTokenIdleLifetime     sql.NullInt64
+	idTokenEncryptedResponseAlg  sql.NullString
+	idTokenEncryptedResponseEnc  sql.NullString
+	userinfoEncryptedResponseAlg sql.NullString
+	userinfoEncryptedResponseEnc sql.NullString
+	jwks                         sql.NullString
+	jwksURI                      sql.NullString
+	frontChannelLogoutURI        sql.NullString
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -1175,24 +1525,34 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                  domain.OIDCVersion(c.version.Int32),
-		ClientID:                 c.clientID.String,
-		RedirectURIs:             c.redirectUris,
-		AppType:                  domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:           domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs:   c.postLogoutRedirectUris,
-		IsDevMode:                c.devMode.Bool,
-		AccessTokenType:          domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:    c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:        c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:    c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:                time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:        c.additionalOrigins,
-		ResponseTypes:            c.responseTypes,
-		GrantTypes:               c.grantTypes,
-		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
-		BackChannelLogoutURI:     c.backChannelLogoutURI.String,
-		LoginVersion:             domain.LoginVersion(c.loginVersion.Int16),
+		Version:                      domain.OIDCVersion(c.version.Int32),
+		ClientID:                     c.clientID.String,
+		RedirectURIs:                 c.redirectUris,
+		AppType:                      domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:               domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:       c.postLogoutRedirectUris,
+		IsDevMode:                    c.devMode.Bool,
+		AccessTokenType:              domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:        c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:            c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:        c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                    time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:            c.additionalOrigins,
+		ResponseTypes:                c.responseTypes,
+		GrantTypes:                   c.grantTypes,
+		SkipNativeAppSuccessPage:     c.skipNativeAppSuccessPage.Bool,
+		BackChannelLogoutURI:         c.backChannelLogoutURI.String,
+		LoginVersion:                 domain.LoginVersion(c.loginVersion.Int16),
+		RefreshTokenRotation:         domain.OIDCRefreshTokenRotation(c.refreshTokenRotation.Int16),
+		RefreshTokenLifetime:         time.Duration(c.refreshTokenLifetime.Int64),
+		RefreshTokenIdleLifetime:     time.Duration(c.refreshTokenIdleLifetime.Int64),
+		IDTokenEncryptedResponseAlg:  c.idTokenEncryptedResponseAlg.String,
+		IDTokenEncryptedResponseEnc:  c.idTokenEncryptedResponseEnc.String,
+		UserinfoEncryptedResponseAlg: c.userinfoEncryptedResponseAlg.String,
+		UserinfoEncryptedResponseEnc: c.userinfoEncryptedResponseEnc.String,
+		JWKS:                         c.jwks.String,
+		JWKSURI:                      c.jwksURI.String,
+		FrontChannelLogoutURI:        c.frontChannelLogoutURI.String,
 	}
 	if c.loginBaseURI.Valid {
 		app.OIDCConfig.LoginBaseURI = &c.loginBaseURI.String
@@ -1206,33 +1566,79 @@ func (c sqlOIDCConfig) set(app *App) {
 }
 
 type sqlSAMLConfig struct {
+	appID             sql.NullString
+	entityID          sql.NullString
+	metadataURL       sql.NullString
+	metadata          []byte
+	loginVersion      sql.NullInt16
+	loginBaseURI      sql.NullString
+	nameIDSource      sql.NullInt16
+	attributeMappings database.JSONArray[*domain.SAMLAttributeMapping]
+}
+
+func (c sqlSAMLConfig) set(app *App) {
+	if !c.appID.Valid {
+		return
+	}
+	app.SAMLConfig = &SAMLApp{
+		EntityID:          c.entityID.String,
+		MetadataURL:       c.metadataURL.String,
+		Metadata:          c.metadata,
+		LoginVersion:      domain.LoginVersion(c.loginVersion.Int16),
+		NameIDSource:      domain.SAMLNameIDSource(c.nameIDSource.Int16),
+		AttributeMappings: c.attributeMappings,
+	}
+	if c.loginBaseURI.Valid {
+		app.SAMLConfig.LoginBaseURI = &c.loginBaseURI.String
+	}
+}
+
+type sqlWSFedConfig struct {
+	appID     sql.NullString
+	realm     sql.NullString
+	replyURLs database.TextArray[string]
+	tokenType sql.NullInt16
+}
+
+func (c sqlWSFedConfig) set(app *App) {
+	if !c.appID.Valid {
+		return
+	}
+	app.WSFedConfig = &WSFedApp{
+		Realm:     c.realm.String,
+		ReplyURLs: c.replyURLs,
+		TokenType: domain.WSFedTokenType(c.tokenType.Int16),
+	}
+}
+
+type sqlCASConfig struct {
 	appID        sql.NullString
-	entityID     sql.NullString
-	metadataURL  sql.NullString
-	metadata     []byte
+	serviceURLs  database.TextArray[string]
 	loginVersion sql.NullInt16
 	loginBaseURI sql.NullString
 }
 
-func (c sqlSAMLConfig) set(app *App) {
+func (c sqlCASConfig) set(app *App) {
 	if !c.appID.Valid {
 		return
 	}
-	app.SAMLConfig = &SAMLApp{
-		EntityID:     c.entityID.String,
-		MetadataURL:  c.metadataURL.String,
-		Metadata:     c.metadata,
+	app.CASConfig = &CASApp{
+		ServiceURLs:  c.serviceURLs,
 		LoginVersion: domain.LoginVersion(c.loginVersion.Int16),
 	}
 	if c.loginBaseURI.Valid {
-		app.SAMLConfig.LoginBaseURI = &c.loginBaseURI.String
+		app.CASConfig.LoginBaseURI = &c.loginBaseURI.String
 	}
 }
 
 type sqlAPIConfig struct {
-	appID      sql.NullString
-	clientID   sql.NullString
-	authMethod sql.NullInt16
+	appID       sql.NullString
+	clientID    sql.NullString
+	authMethod  sql.NullInt16
+	resourceURI sql.NullString
+
+	introspectionSignedResponse sql.NullBool
+	introspectionEncryptionKey  []byte
 }
 
 func (c sqlAPIConfig) set(app *App) {
@@ -1242,5 +1648,9 @@ func (c sqlAPIConfig) set(app *App) {
 	app.APIConfig = &APIApp{
 		ClientID:       c.clientID.String,
 		AuthMethodType: domain.APIAuthMethodType(c.authMethod.Int16),
+		ResourceURI:    c.resourceURI.String,
+
+		IntrospectionSignedResponse: c.introspectionSignedResponse.Bool,
+		IntrospectionEncryptionKey:  c.introspectionEncryptionKey,
 	}
 }
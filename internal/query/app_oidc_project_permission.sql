@@ -9,12 +9,16 @@ with application as (
          LEFT JOIN projections.apps7_oidc_configs as aoc
                    ON aoc.app_id = a.id
                    AND aoc.instance_id = a.instance_id
+         LEFT JOIN projections.apps7_cas_configs as acc
+                   ON acc.app_id = a.id
+                   AND acc.instance_id = a.instance_id
          INNER JOIN projections.projects4 as p
                     ON p.instance_id = a.instance_id
                     AND p.resource_owner = a.resource_owner
                     AND p.id = a.project_id
     WHERE a.instance_id = $1
-      AND aoc.client_id = $2
+      /* cas applications use the app id as client id of their auth requests */
+      AND (aoc.client_id = $2 OR acc.app_id = $2)
       AND a.state = $3
       AND p.state = $4
 ), user_resourceowner as (
@@ -30,6 +30,9 @@ var (
 		` projections.apps7_api_configs.app_id,` +
 		` projections.apps7_api_configs.client_id,` +
 		` projections.apps7_api_configs.auth_method,` +
+		` projections.apps7_api_configs.resource_uri,` +
+		` projections.apps7_api_configs.introspection_signed_response,` +
+		` projections.apps7_api_configs.introspection_encryption_key,` +
 		// oidc config
 		` projections.apps7_oidc_configs.app_id,` +
 		` projections.apps7_oidc_configs.version,` +
@@ -51,17 +54,41 @@ var (
 		` projections.apps7_oidc_configs.back_channel_logout_uri,` +
 		` projections.apps7_oidc_configs.login_version,` +
 		` projections.apps7_oidc_configs.login_base_uri,` +
+		` projections.apps7_oidc_configs.refresh_token_rotation,` +
+		` projections.apps7_oidc_configs.refresh_token_lifetime,` +
+		` projections.apps7_oidc_configs.refresh_token_idle_lifetime,` +
+		` projections.apps7_oidc_configs.id_token_encrypted_response_alg,` +
+		` projections.apps7_oidc_configs.id_token_encrypted_response_enc,` +
+		` projections.apps7_oidc_configs.userinfo_encrypted_response_alg,` +
+		` projections.apps7_oidc_configs.userinfo_encrypted_response_enc,` +
+		` projections.apps7_oidc_configs.jwks,` +
+		` projections.apps7_oidc_configs.jwks_uri,` +
+		` projections.apps7_oidc_configs.front_channel_logout_uri,` +
 		//saml config
 		` projections.apps7_saml_configs.app_id,` +
 		` projections.apps7_saml_configs.entity_id,` +
 		` projections.apps7_saml_configs.metadata,` +
 		` projections.apps7_saml_configs.metadata_url,` +
 		` projections.apps7_saml_configs.login_version,` +
-		` projections.apps7_saml_configs.login_base_uri` +
+		` projections.apps7_saml_configs.login_base_uri,` +
+		` projections.apps7_saml_configs.name_id_source,` +
+		` projections.apps7_saml_configs.attribute_mappings,` +
+		// wsfed config
+		` projections.apps7_wsfed_configs.app_id,` +
+		` projections.apps7_wsfed_configs.realm,` +
+		` projections.apps7_wsfed_configs.reply_urls,` +
+		` projections.apps7_wsfed_configs.token_type,` +
+		// cas config
+		` projections.apps7_cas_configs.app_id,` +
+		` projections.apps7_cas_configs.service_urls,` +
+		` projections.apps7_cas_configs.login_version,` +
+		` projections.apps7_cas_configs.login_base_uri` +
 		` FROM projections.apps7` +
 		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
 		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id`
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
+		` LEFT JOIN projections.apps7_wsfed_configs ON projections.apps7.id = projections.apps7_wsfed_configs.app_id AND projections.apps7.instance_id = projections.apps7_wsfed_configs.instance_id` +
+		` LEFT JOIN projections.apps7_cas_configs ON projections.apps7.id = projections.apps7_cas_configs.app_id AND projections.apps7.instance_id = projections.apps7_cas_configs.instance_id`
 	expectedAppQuery       = regexp.QuoteMeta(expectedAppQueryBase)
 	expectedActiveAppQuery = regexp.QuoteMeta(expectedAppQueryBase +
 		` LEFT JOIN projections.projects4 ON projections.apps7.project_id = projections.projects4.id AND projections.apps7.instance_id = projections.projects4.instance_id` +
@@ -78,6 +105,9 @@ var (
 		` projections.apps7_api_configs.app_id,` +
 		` projections.apps7_api_configs.client_id,` +
 		` projections.apps7_api_configs.auth_method,` +
+		` projections.apps7_api_configs.resource_uri,` +
+		` projections.apps7_api_configs.introspection_signed_response,` +
+		` projections.apps7_api_configs.introspection_encryption_key,` +
 		// oidc config
 		` projections.apps7_oidc_configs.app_id,` +
 		` projections.apps7_oidc_configs.version,` +
@@ -99,6 +129,16 @@ var (
 		` projections.apps7_oidc_configs.back_channel_logout_uri,` +
 		` projections.apps7_oidc_configs.login_version,` +
 		` projections.apps7_oidc_configs.login_base_uri,` +
+		` projections.apps7_oidc_configs.refresh_token_rotation,` +
+		` projections.apps7_oidc_configs.refresh_token_lifetime,` +
+		` projections.apps7_oidc_configs.refresh_token_idle_lifetime,` +
+		` projections.apps7_oidc_configs.id_token_encrypted_response_alg,` +
+		` projections.apps7_oidc_configs.id_token_encrypted_response_enc,` +
+		` projections.apps7_oidc_configs.userinfo_encrypted_response_alg,` +
+		` projections.apps7_oidc_configs.userinfo_encrypted_response_enc,` +
+		` projections.apps7_oidc_configs.jwks,` +
+		` projections.apps7_oidc_configs.jwks_uri,` +
+		` projections.apps7_oidc_configs.front_channel_logout_uri,` +
 		//saml config
 		` projections.apps7_saml_configs.app_id,` +
 		` projections.apps7_saml_configs.entity_id,` +
@@ -106,21 +146,41 @@ var (
 		` projections.apps7_saml_configs.metadata_url,` +
 		` projections.apps7_saml_configs.login_version,` +
 		` projections.apps7_saml_configs.login_base_uri,` +
+		` projections.apps7_saml_configs.name_id_source,` +
+		` projections.apps7_saml_configs.attribute_mappings,` +
+		// wsfed config
+		` projections.apps7_wsfed_configs.app_id,` +
+		` projections.apps7_wsfed_configs.realm,` +
+		` projections.apps7_wsfed_configs.reply_urls,` +
+		` projections.apps7_wsfed_configs.token_type,` +
+		// cas config
+		` projections.apps7_cas_configs.app_id,` +
+		` projections.apps7_cas_configs.service_urls,` +
+		` projections.apps7_cas_configs.login_version,` +
+		` projections.apps7_cas_configs.login_base_uri,` +
 		` COUNT(*) OVER ()` +
 		` FROM projections.apps7` +
 		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
 		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id`)
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
+		` LEFT JOIN projections.apps7_wsfed_configs ON projections.apps7.id = projections.apps7_wsfed_configs.app_id AND projections.apps7.instance_id = projections.apps7_wsfed_configs.instance_id` +
+		` LEFT JOIN projections.apps7_cas_configs ON projections.apps7.id = projections.apps7_cas_configs.app_id AND projections.apps7.instance_id = projections.apps7_cas_configs.instance_id`)
 	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps7_api_configs.client_id,` +
 		` projections.apps7_oidc_configs.client_id` +
 		` FROM projections.apps7` +
 		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
 		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id`)
+	expectedAPIResourcesQuery = regexp.QuoteMeta(`SELECT projections.apps7_api_configs.client_id,` +
+		` projections.apps7.project_id` +
+		` FROM projections.apps7` +
+		` JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id`)
 	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps7.project_id` +
 		` FROM projections.apps7` +
 		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
 		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id`)
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
+		` LEFT JOIN projections.apps7_wsfed_configs ON projections.apps7.id = projections.apps7_wsfed_configs.app_id AND projections.apps7.instance_id = projections.apps7_wsfed_configs.instance_id` +
+		` LEFT JOIN projections.apps7_cas_configs ON projections.apps7.id = projections.apps7_cas_configs.app_id AND projections.apps7.instance_id = projections.apps7_cas_configs.instance_id`)
 	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects4.id,` +
 		` projections.projects4.creation_date,` +
 		` projections.projects4.change_date,` +
@@ -136,7 +196,9 @@ var (
 		` JOIN projections.apps7 ON projections.projects4.id = projections.apps7.project_id AND projections.projects4.instance_id = projections.apps7.instance_id` +
 		` LEFT JOIN projections.apps7_api_configs ON projections.apps7.id = projections.apps7_api_configs.app_id AND projections.apps7.instance_id = projections.apps7_api_configs.instance_id` +
 		` LEFT JOIN projections.apps7_oidc_configs ON projections.apps7.id = projections.apps7_oidc_configs.app_id AND projections.apps7.instance_id = projections.apps7_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id`)
+		` LEFT JOIN projections.apps7_saml_configs ON projections.apps7.id = projections.apps7_saml_configs.app_id AND projections.apps7.instance_id = projections.apps7_saml_configs.instance_id` +
+		` LEFT JOIN projections.apps7_wsfed_configs ON projections.apps7.id = projections.apps7_wsfed_configs.app_id AND projections.apps7.instance_id = projections.apps7_wsfed_configs.instance_id` +
+		` LEFT JOIN projections.apps7_cas_configs ON projections.apps7.id = projections.apps7_cas_configs.app_id AND projections.apps7.instance_id = projections.apps7_cas_configs.instance_id`)
 
 	appCols = database.TextArray[string]{
 		"id",
@@ -151,6 +213,9 @@ var (
 		"app_id",
 		"client_id",
 		"auth_method",
+		"resource_uri",
+		"introspection_signed_response",
+		"introspection_encryption_key",
 		// oidc config
 		"app_id",
 		"version",
@@ -172,6 +237,16 @@ var (
 		"back_channel_logout_uri",
 		"login_version",
 		"login_base_uri",
+		"refresh_token_rotation",
+		"refresh_token_lifetime",
+		"refresh_token_idle_lifetime",
+		"id_token_encrypted_response_alg",
+		"id_token_encrypted_response_enc",
+		"userinfo_encrypted_response_alg",
+		"userinfo_encrypted_response_enc",
+		"jwks",
+		"jwks_uri",
+		"front_channel_logout_uri",
 		//saml config
 		"app_id",
 		"entity_id",
@@ -179,6 +254,18 @@ var (
 		"metadata_url",
 		"login_version",
 		"login_base_uri",
+		"name_id_source",
+		"attribute_mappings",
+		// wsfed config
+		"app_id",
+		"realm",
+		"reply_urls",
+		"token_type",
+		// cas config
+		"app_id",
+		"service_urls",
+		"login_version",
+		"login_base_uri",
 	}
 	appsCols = append(appCols, "count")
 )
@@ -227,6 +314,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -248,6 +338,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -255,6 +355,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -298,6 +410,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"app-id",
 							"api-client-id",
 							domain.APIAuthMethodTypePrivateKeyJWT,
+							"https://api.example.com/orders",
+							true,
+							[]byte("key"),
 							// oidc config
 							nil,
 							nil,
@@ -319,6 +434,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -326,6 +451,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -347,6 +484,10 @@ func Test_AppsPrepare(t *testing.T) {
 						APIConfig: &APIApp{
 							ClientID:       "api-client-id",
 							AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+							ResourceURI:    "https://api.example.com/orders",
+
+							IntrospectionSignedResponse: true,
+							IntrospectionEncryptionKey:  []byte("key"),
 						},
 					},
 				},
@@ -372,6 +513,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -393,6 +537,16 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -400,6 +554,18 @@ func Test_AppsPrepare(t *testing.T) {
 							"https://test.com/saml/metadata",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -448,6 +614,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -469,6 +638,16 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -476,6 +655,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -542,6 +733,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -563,6 +757,16 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -570,6 +774,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -636,6 +852,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -657,6 +876,16 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -664,6 +893,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -730,6 +971,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -751,6 +995,16 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -758,6 +1012,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -824,6 +1090,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -845,6 +1114,16 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -852,6 +1131,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -918,6 +1209,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -939,6 +1233,16 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -946,6 +1250,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1012,6 +1328,9 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"oidc-app-id",
 							domain.OIDCVersionV1,
@@ -1033,6 +1352,16 @@ func Test_AppsPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersion2,
 							"https://login.ch/",
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1040,6 +1369,18 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"api-app-id",
@@ -1054,6 +1395,9 @@ func Test_AppsPrepare(t *testing.T) {
 							"api-app-id",
 							"api-client-id",
 							domain.APIAuthMethodTypePrivateKeyJWT,
+							"https://api.example.com/orders",
+							true,
+							[]byte("key"),
 							// oidc config
 							nil,
 							nil,
@@ -1075,32 +1419,17 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
-							// saml config
-							nil,
-							nil,
-							nil,
 							nil,
 							nil,
 							nil,
-						},
-						{
-							"saml-app-id",
-							"app-name",
-							"project-id",
-							testNow,
-							testNow,
-							"ro",
-							domain.AppStateActive,
-							uint64(20211109),
-							// api config
 							nil,
 							nil,
 							nil,
-							// oidc config
 							nil,
 							nil,
 							nil,
 							nil,
+							// saml config
 							nil,
 							nil,
 							nil,
@@ -1109,21 +1438,83 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// wsfed config
 							nil,
 							nil,
 							nil,
 							nil,
+							// cas config
 							nil,
 							nil,
 							nil,
 							nil,
-							// saml config
+						},
+						{
+							"saml-app-id",
+							"app-name",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.AppStateActive,
+							uint64(20211109),
+							// api config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
 							domain.LoginVersion2,
 							"https://login.ch/",
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1178,6 +1569,10 @@ func Test_AppsPrepare(t *testing.T) {
 						APIConfig: &APIApp{
 							ClientID:       "api-client-id",
 							AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+							ResourceURI:    "https://api.example.com/orders",
+
+							IntrospectionSignedResponse: true,
+							IntrospectionEncryptionKey:  []byte("key"),
 						},
 					},
 					{
@@ -1281,6 +1676,9 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 						// oidc config
 						nil,
 						nil,
@@ -1302,6 +1700,16 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
@@ -1309,6 +1717,18 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						// wsfed config
+						nil,
+						nil,
+						nil,
+						nil,
+						// cas config
+						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1346,6 +1766,9 @@ func Test_AppPrepare(t *testing.T) {
 							"app-id",
 							"api-client-id",
 							domain.APIAuthMethodTypePrivateKeyJWT,
+							"https://api.example.com/orders",
+							true,
+							[]byte("key"),
 							// oidc config
 							nil,
 							nil,
@@ -1367,6 +1790,16 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1374,6 +1807,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1390,6 +1835,202 @@ func Test_AppPrepare(t *testing.T) {
 				APIConfig: &APIApp{
 					ClientID:       "api-client-id",
 					AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+					ResourceURI:    "https://api.example.com/orders",
+
+					IntrospectionSignedResponse: true,
+					IntrospectionEncryptionKey:  []byte("key"),
+				},
+			},
+		},
+		{
+			name: "prepareAppQuery wsfed app",
+			prepare: func() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
+				return prepareAppQuery(false)
+			},
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedAppQuery,
+					appCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"app-name",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.AppStateActive,
+							uint64(20211109),
+							// api config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// wsfed config
+							"app-id",
+							"urn:sharepoint:test",
+							database.TextArray[string]{"https://sharepoint.test.com/_trust/"},
+							domain.WSFedTokenTypeSAML20,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
+						},
+					},
+				),
+			},
+			object: &App{
+				ID:            "app-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.AppStateActive,
+				Sequence:      20211109,
+				Name:          "app-name",
+				ProjectID:     "project-id",
+				WSFedConfig: &WSFedApp{
+					Realm:     "urn:sharepoint:test",
+					ReplyURLs: database.TextArray[string]{"https://sharepoint.test.com/_trust/"},
+					TokenType: domain.WSFedTokenTypeSAML20,
+				},
+			},
+		},
+		{
+			name: "prepareAppQuery cas app",
+			prepare: func() (sq.SelectBuilder, func(*sql.Row) (*App, error)) {
+				return prepareAppQuery(false)
+			},
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedAppQuery,
+					appCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"app-name",
+							"project-id",
+							testNow,
+							testNow,
+							"ro",
+							domain.AppStateActive,
+							uint64(20211109),
+							// api config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// oidc config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// saml config
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							"app-id",
+							database.TextArray[string]{"https://moodle.test.com/login/"},
+							domain.LoginVersion2,
+							"https://login.test.com",
+						},
+					},
+				),
+			},
+			object: &App{
+				ID:            "app-id",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				ResourceOwner: "ro",
+				State:         domain.AppStateActive,
+				Sequence:      20211109,
+				Name:          "app-name",
+				ProjectID:     "project-id",
+				CASConfig: &CASApp{
+					ServiceURLs:  database.TextArray[string]{"https://moodle.test.com/login/"},
+					LoginVersion: domain.LoginVersion2,
+					LoginBaseURI: gu.Ptr("https://login.test.com"),
 				},
 			},
 		},
@@ -1416,6 +2057,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1437,6 +2081,16 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1444,6 +2098,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1505,6 +2171,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1526,6 +2195,16 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1533,6 +2212,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1594,6 +2285,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							nil,
 							nil,
@@ -1615,6 +2309,16 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
@@ -1622,6 +2326,18 @@ func Test_AppPrepare(t *testing.T) {
 							"https://test.com/saml/metadata",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1667,6 +2383,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1688,6 +2407,16 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1695,6 +2424,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1756,6 +2497,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1777,6 +2521,16 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1784,6 +2538,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1845,6 +2611,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1866,6 +2635,16 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1873,6 +2652,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1934,6 +2725,9 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							// oidc config
 							"app-id",
 							domain.OIDCVersionV1,
@@ -1955,6 +2749,16 @@ func Test_AppPrepare(t *testing.T) {
 							"back.channel.logout.ch",
 							domain.LoginVersionUnspecified,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
@@ -1962,6 +2766,18 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							// wsfed config
+							nil,
+							nil,
+							nil,
+							nil,
+							// cas config
+							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -2113,6 +2929,84 @@ func Test_AppIDsPrepare(t *testing.T) {
 	}
 }
 
+func Test_APIResourcesPrepare(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareAPIResourcesQuery no result",
+			prepare: prepareAPIResourcesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedAPIResourcesQuery,
+					nil,
+					nil,
+				),
+			},
+			object: []*APIResource{},
+		},
+		{
+			name:    "prepareAPIResourcesQuery multiple result",
+			prepare: prepareAPIResourcesQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedAPIResourcesQuery,
+					database.TextArray[string]{"client_id", "project_id"},
+					[][]driver.Value{
+						{
+							"api-client-id",
+							"project-id",
+						},
+						{
+							"api-client-id2",
+							"project-id2",
+						},
+					},
+				),
+			},
+			object: []*APIResource{
+				{
+					ClientID:  "api-client-id",
+					ProjectID: "project-id",
+				},
+				{
+					ClientID:  "api-client-id2",
+					ProjectID: "project-id2",
+				},
+			},
+		},
+		{
+			name:    "prepareAPIResourcesQuery sql err",
+			prepare: prepareAPIResourcesQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedAPIResourcesQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: ([]*APIResource)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err)
+		})
+	}
+}
+
 func Test_ProjectIDByAppPrepare(t *testing.T) {
 	type want struct {
 		sqlExpectations sqlExpectation
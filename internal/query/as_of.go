@@ -0,0 +1,1044 @@
+package query
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/muhlemmer/gu"
+	"github.com/shopspring/decimal"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// AsOf is the point in time a resource is reconstructed at.
+// Only the events created before the date and up to the position are reduced,
+// at least one of them must be set.
+type AsOf struct {
+	Date     time.Time
+	Position decimal.Decimal
+}
+
+func (a *AsOf) validate() error {
+	if a == nil || (a.Date.IsZero() && a.Position.IsZero()) {
+		return zerrors.ThrowInvalidArgument(nil, "QUERY-Wd2kq", "Errors.AsOf.Missing")
+	}
+	if a.Position.IsNegative() {
+		return zerrors.ThrowInvalidArgument(nil, "QUERY-Rz7vn", "Errors.AsOf.Invalid")
+	}
+	return nil
+}
+
+// query restricts the search query to the point in time.
+// Archived events are included, as the resource might have been finished since.
+func (a *AsOf) query(query *eventstore.SearchQueryBuilder) *eventstore.SearchQueryBuilder {
+	return query.
+		CreationDateBefore(a.Date).
+		PositionAtMost(a.Position).
+		IncludeArchived()
+}
+
+// UserAsOf reconstructs the user from its events up to the point in time.
+// Users can read their own state, others need the permission to read the user.
+// Removed users are returned with the deleted state.
+// The login names are not set, as they depend on the domains and policies of the organization.
+// Missing users and missing permissions return the same error, so the existence of a user isn't disclosed.
+func (q *Queries) UserAsOf(ctx context.Context, userID string, asOf *AsOf, permissionCheck domain.PermissionCheck) (_ *User, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Hk3lw", "Errors.User.UserIDMissing")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	rm := newUserAsOfReadModel(userID)
+	if err = q.eventstore.FilterToReducer(ctx, asOf.query(rm.Query()), rm); err != nil {
+		return nil, err
+	}
+	if rm.user.State == domain.UserStateUnspecified ||
+		userCheckPermission(ctx, rm.ResourceOwner, rm.AggregateID, permissionCheck) != nil {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Bq8xt", "Errors.User.NotFound")
+	}
+	return rm.toUser(), nil
+}
+
+// OrgAsOf reconstructs the organization from its events up to the point in time.
+// Removed organizations are returned with the removed state.
+// Missing organizations and missing permissions return the same error.
+func (q *Queries) OrgAsOf(ctx context.Context, orgID string, asOf *AsOf, permissionCheck domain.PermissionCheck) (_ *Org, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	orgID = strings.TrimSpace(orgID)
+	if orgID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Tf5gc", "Errors.Org.Empty")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	rm := newOrgAsOfReadModel(orgID)
+	if err = q.eventstore.FilterToReducer(ctx, asOf.query(rm.Query()), rm); err != nil {
+		return nil, err
+	}
+	if rm.org.State == domain.OrgStateUnspecified ||
+		permissionCheck(ctx, domain.PermissionOrgRead, rm.AggregateID, rm.AggregateID) != nil {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Oy4rp", "Errors.Org.NotFound")
+	}
+	return rm.toOrg(), nil
+}
+
+// ProjectAsOf reconstructs the project from its events up to the point in time.
+// Removed projects are returned with the removed state.
+// Missing projects and missing permissions return the same error.
+func (q *Queries) ProjectAsOf(ctx context.Context, projectID string, asOf *AsOf, permissionCheck domain.PermissionCheck) (_ *Project, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	projectID = strings.TrimSpace(projectID)
+	if projectID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Ja9wd", "Errors.Project.ProjectIDMissing")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	rm := newProjectAsOfReadModel(projectID)
+	if err = q.eventstore.FilterToReducer(ctx, asOf.query(rm.Query()), rm); err != nil {
+		return nil, err
+	}
+	if rm.project.State == domain.ProjectStateUnspecified ||
+		projectCheckPermission(ctx, rm.ResourceOwner, rm.AggregateID, permissionCheck) != nil {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Lc2mv", "Errors.Project.NotFound")
+	}
+	return rm.toProject(), nil
+}
+
+// AppAsOf reconstructs the application from the events of its project up to the point in time.
+// Removed applications are returned with the removed state.
+// Missing applications and missing permissions return the same error.
+func (q *Queries) AppAsOf(ctx context.Context, appID string, asOf *AsOf, permissionCheck domain.PermissionCheck) (_ *App, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	appID = strings.TrimSpace(appID)
+	if appID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Ve3nb", "Errors.IDMissing")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	projectID, err := q.appProjectIDAsOf(ctx, appID, asOf)
+	if err != nil {
+		return nil, err
+	}
+	rm := newAppAsOfReadModel(projectID, appID)
+	if projectID != "" {
+		if err = q.eventstore.FilterToReducer(ctx, asOf.query(rm.Query()), rm); err != nil {
+			return nil, err
+		}
+	}
+	if rm.app.State == domain.AppStateUnspecified ||
+		appCheckPermission(ctx, rm.ResourceOwner, projectID, permissionCheck) != nil {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Xo5ub", "Errors.Project.App.NotExisting")
+	}
+	return rm.toApp(), nil
+}
+
+// appProjectIDAsOf returns the id of the project the application was added to before the point in time,
+// it's empty if the application wasn't added yet.
+// Archived projects are searched, the app id is matched in the payload of the archived events.
+func (q *Queries) appProjectIDAsOf(ctx context.Context, appID string, asOf *AsOf) (string, error) {
+	events, err := q.eventstore.Filter(ctx, asOf.query(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			Limit(1).
+			AddQuery().
+			AggregateTypes(project.AggregateType).
+			EventTypes(project.ApplicationAddedType).
+			EventData(map[string]interface{}{"appId": appID}).
+			Builder(),
+	))
+	if err != nil || len(events) == 0 {
+		return "", err
+	}
+	return events[0].Aggregate().ID, nil
+}
+
+// UserGrantsAsOf reconstructs the grants of the user from their events up to the point in time.
+// Grants which were already removed at that point are omitted.
+// Users can read their own grants, others only get the grants they are allowed to read.
+// Only the ids of the user, project and organizations and the keys of the roles are set.
+func (q *Queries) UserGrantsAsOf(ctx context.Context, userID string, asOf *AsOf, permissionCheck domain.PermissionCheck) (_ []*UserGrant, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	userID = strings.TrimSpace(userID)
+	if userID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Nw4ze", "Errors.User.UserIDMissing")
+	}
+	if err = asOf.validate(); err != nil {
+		return nil, err
+	}
+	// archived grants are searched, the user id is matched in the payload of the archived events
+	added, err := q.eventstore.Filter(ctx, asOf.query(
+		eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+			AddQuery().
+			AggregateTypes(usergrant.AggregateType).
+			EventTypes(usergrant.UserGrantAddedType).
+			EventData(map[string]interface{}{"userId": userID}).
+			Builder(),
+	))
+	if err != nil || len(added) == 0 {
+		return nil, err
+	}
+	grantIDs := make([]string, len(added))
+	for i, event := range added {
+		grantIDs[i] = event.Aggregate().ID
+	}
+	rm := new(userGrantsAsOfReadModel)
+	if err = q.eventstore.FilterToReducer(ctx, asOf.query(rm.Query(grantIDs)), rm); err != nil {
+		return nil, err
+	}
+	grants := make([]*UserGrant, 0, len(rm.grants))
+	for _, grant := range rm.grants {
+		if grant.State == domain.UserGrantStateUnspecified || grant.State == domain.UserGrantStateRemoved {
+			continue
+		}
+		if userGrantCheckPermission(ctx, grant.ResourceOwner, grant.ProjectID, grant.GrantID, grant.UserID, permissionCheck) != nil {
+			continue
+		}
+		grants = append(grants, grant.toUserGrant())
+	}
+	return grants, nil
+}
+
+// userAsOfReadModel reconstructs the state of a user from its events
+type userAsOfReadModel struct {
+	*eventstore.ReadModel
+
+	user    User
+	human   Human
+	machine Machine
+}
+
+func newUserAsOfReadModel(userID string) *userAsOfReadModel {
+	return &userAsOfReadModel{
+		ReadModel: &eventstore.ReadModel{AggregateID: userID},
+	}
+}
+
+func (rm *userAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			rm.reduceHumanAdded(e.UserName, e.FirstName, e.LastName, e.NickName, e.DisplayName, e.EmailAddress, e.PhoneNumber)
+			rm.human.PreferredLanguage = e.PreferredLanguage
+			rm.human.Gender = e.Gender
+			rm.reducePassword(crypto.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.ChangeRequired, e.CreatedAt())
+		case *user.HumanRegisteredEvent:
+			rm.reduceHumanAdded(e.UserName, e.FirstName, e.LastName, e.NickName, e.DisplayName, e.EmailAddress, e.PhoneNumber)
+			rm.human.PreferredLanguage = e.PreferredLanguage
+			rm.human.Gender = e.Gender
+			rm.reducePassword(crypto.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.ChangeRequired, e.CreatedAt())
+		case *user.HumanInitialCodeAddedEvent:
+			rm.user.State = domain.UserStateInitial
+		case *user.HumanInitializedCheckSucceededEvent:
+			rm.user.State = domain.UserStateActive
+		case *user.MachineAddedEvent:
+			rm.user.Type = domain.UserTypeMachine
+			rm.user.Username = e.UserName
+			rm.user.State = domain.UserStateActive
+			rm.machine = Machine{
+				Name:            e.Name,
+				Description:     e.Description,
+				AccessTokenType: e.AccessTokenType,
+			}
+		case *user.MachineChangedEvent:
+			if e.Name != nil {
+				rm.machine.Name = *e.Name
+			}
+			if e.Description != nil {
+				rm.machine.Description = *e.Description
+			}
+			if e.AccessTokenType != nil {
+				rm.machine.AccessTokenType = *e.AccessTokenType
+			}
+		case *user.UsernameChangedEvent:
+			rm.user.Username = e.UserName
+		case *user.DomainClaimedEvent:
+			rm.user.Username = e.UserName
+		case *user.HumanProfileChangedEvent:
+			rm.reduceProfileChanged(e)
+		case *user.HumanEmailChangedEvent:
+			rm.human.Email = e.EmailAddress
+			rm.human.IsEmailVerified = false
+		case *user.HumanEmailCodeAddedEvent:
+			rm.human.IsEmailVerified = false
+		case *user.HumanEmailVerifiedEvent:
+			rm.human.IsEmailVerified = true
+		case *user.HumanPhoneChangedEvent:
+			rm.human.Phone = e.PhoneNumber
+			rm.human.IsPhoneVerified = false
+		case *user.HumanPhoneCodeAddedEvent:
+			rm.human.IsPhoneVerified = false
+		case *user.HumanPhoneVerifiedEvent:
+			rm.human.IsPhoneVerified = true
+		case *user.HumanPhoneRemovedEvent:
+			rm.human.Phone = ""
+			rm.human.IsPhoneVerified = false
+		case *user.HumanAvatarAddedEvent:
+			rm.human.AvatarKey = e.StoreKey
+		case *user.HumanAvatarRemovedEvent:
+			rm.human.AvatarKey = ""
+		case *user.HumanPasswordChangedEvent:
+			rm.reducePassword(crypto.SecretOrEncodedHash(e.Secret, e.EncodedHash), e.ChangeRequired, e.CreatedAt())
+		case *user.UserLockedEvent:
+			rm.user.State = domain.UserStateLocked
+		case *user.UserUnlockedEvent, *user.UserReactivatedEvent:
+			rm.user.State = domain.UserStateActive
+		case *user.UserDeactivatedEvent:
+			rm.user.State = domain.UserStateInactive
+		case *user.UserRemovedEvent:
+			rm.user.State = domain.UserStateDeleted
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *userAsOfReadModel) reduceHumanAdded(username, firstName, lastName, nickName, displayName string, email domain.EmailAddress, phone domain.PhoneNumber) {
+	rm.user.Type = domain.UserTypeHuman
+	rm.user.Username = username
+	rm.user.State = domain.UserStateActive
+	rm.human.FirstName = firstName
+	rm.human.LastName = lastName
+	rm.human.NickName = nickName
+	rm.human.DisplayName = displayName
+	rm.human.Email = email
+	rm.human.Phone = phone
+}
+
+func (rm *userAsOfReadModel) reducePassword(hash string, changeRequired bool, changed time.Time) {
+	rm.human.PasswordChangeRequired = changeRequired
+	if hash != "" {
+		rm.human.PasswordChanged = changed
+	}
+}
+
+func (rm *userAsOfReadModel) reduceProfileChanged(e *user.HumanProfileChangedEvent) {
+	if e.FirstName != "" {
+		rm.human.FirstName = e.FirstName
+	}
+	if e.LastName != "" {
+		rm.human.LastName = e.LastName
+	}
+	if e.NickName != nil {
+		rm.human.NickName = *e.NickName
+	}
+	if e.DisplayName != nil {
+		rm.human.DisplayName = *e.DisplayName
+	}
+	if e.PreferredLanguage != nil {
+		rm.human.PreferredLanguage = *e.PreferredLanguage
+	}
+	if e.Gender != nil {
+		rm.human.Gender = *e.Gender
+	}
+}
+
+func (rm *userAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.UserV1InitialCodeAddedType,
+			user.HumanInitialCodeAddedType,
+			user.UserV1InitializedCheckSucceededType,
+			user.HumanInitializedCheckSucceededType,
+			user.MachineAddedEventType,
+			user.MachineChangedEventType,
+			user.UserUserNameChangedType,
+			user.UserDomainClaimedType,
+			user.UserV1ProfileChangedType,
+			user.HumanProfileChangedType,
+			user.UserV1EmailChangedType,
+			user.HumanEmailChangedType,
+			user.UserV1EmailCodeAddedType,
+			user.HumanEmailCodeAddedType,
+			user.UserV1EmailVerifiedType,
+			user.HumanEmailVerifiedType,
+			user.UserV1PhoneChangedType,
+			user.HumanPhoneChangedType,
+			user.UserV1PhoneCodeAddedType,
+			user.HumanPhoneCodeAddedType,
+			user.UserV1PhoneVerifiedType,
+			user.HumanPhoneVerifiedType,
+			user.UserV1PhoneRemovedType,
+			user.HumanPhoneRemovedType,
+			user.HumanAvatarAddedType,
+			user.HumanAvatarRemovedType,
+			user.UserV1PasswordChangedType,
+			user.HumanPasswordChangedType,
+			user.UserLockedType,
+			user.UserUnlockedType,
+			user.UserDeactivatedType,
+			user.UserReactivatedType,
+			user.UserRemovedType,
+		).
+		Builder()
+}
+
+func (rm *userAsOfReadModel) toUser() *User {
+	u := rm.user
+	u.ID = rm.AggregateID
+	u.CreationDate = rm.CreationDate
+	u.ChangeDate = rm.ChangeDate
+	u.ResourceOwner = rm.ResourceOwner
+	u.Sequence = rm.ProcessedSequence
+	switch u.Type {
+	case domain.UserTypeHuman:
+		u.Human = gu.Ptr(rm.human)
+	case domain.UserTypeMachine:
+		u.Machine = gu.Ptr(rm.machine)
+	case domain.UserTypeUnspecified:
+	}
+	return &u
+}
+
+// orgAsOfReadModel reconstructs the state of an organization from its events
+type orgAsOfReadModel struct {
+	*eventstore.ReadModel
+
+	org Org
+}
+
+func newOrgAsOfReadModel(orgID string) *orgAsOfReadModel {
+	return &orgAsOfReadModel{
+		ReadModel: &eventstore.ReadModel{AggregateID: orgID},
+	}
+}
+
+func (rm *orgAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.OrgAddedEvent:
+			rm.org.Name = e.Name
+			rm.org.State = domain.OrgStateActive
+		case *org.OrgChangedEvent:
+			rm.org.Name = e.Name
+		case *org.OrgDeactivatedEvent:
+			rm.org.State = domain.OrgStateInactive
+		case *org.OrgReactivatedEvent:
+			rm.org.State = domain.OrgStateActive
+		case *org.OrgRemovedEvent:
+			rm.org.State = domain.OrgStateRemoved
+		case *org.DomainPrimarySetEvent:
+			rm.org.Domain = e.Domain
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *orgAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			org.OrgAddedEventType,
+			org.OrgChangedEventType,
+			org.OrgDeactivatedEventType,
+			org.OrgReactivatedEventType,
+			org.OrgRemovedEventType,
+			org.OrgDomainPrimarySetEventType,
+		).
+		Builder()
+}
+
+func (rm *orgAsOfReadModel) toOrg() *Org {
+	o := rm.org
+	o.ID = rm.AggregateID
+	o.CreationDate = rm.CreationDate
+	o.ChangeDate = rm.ChangeDate
+	o.ResourceOwner = rm.ResourceOwner
+	o.Sequence = rm.ProcessedSequence
+	return &o
+}
+
+// projectAsOfReadModel reconstructs the state of a project from its events
+type projectAsOfReadModel struct {
+	*eventstore.ReadModel
+
+	project Project
+}
+
+func newProjectAsOfReadModel(projectID string) *projectAsOfReadModel {
+	return &projectAsOfReadModel{
+		ReadModel: &eventstore.ReadModel{AggregateID: projectID},
+	}
+}
+
+func (rm *projectAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *project.ProjectAddedEvent:
+			rm.project.Name = e.Name
+			rm.project.ProjectRoleAssertion = e.ProjectRoleAssertion
+			rm.project.ProjectRoleCheck = e.ProjectRoleCheck
+			rm.project.HasProjectCheck = e.HasProjectCheck
+			rm.project.PrivateLabelingSetting = e.PrivateLabelingSetting
+			rm.project.State = domain.ProjectStateActive
+		case *project.ProjectChangeEvent:
+			if e.Name != nil {
+				rm.project.Name = *e.Name
+			}
+			if e.ProjectRoleAssertion != nil {
+				rm.project.ProjectRoleAssertion = *e.ProjectRoleAssertion
+			}
+			if e.ProjectRoleCheck != nil {
+				rm.project.ProjectRoleCheck = *e.ProjectRoleCheck
+			}
+			if e.HasProjectCheck != nil {
+				rm.project.HasProjectCheck = *e.HasProjectCheck
+			}
+			if e.PrivateLabelingSetting != nil {
+				rm.project.PrivateLabelingSetting = *e.PrivateLabelingSetting
+			}
+		case *project.ProjectDeactivatedEvent:
+			if rm.project.State != domain.ProjectStateRemoved {
+				rm.project.State = domain.ProjectStateInactive
+			}
+		case *project.ProjectReactivatedEvent:
+			if rm.project.State != domain.ProjectStateRemoved {
+				rm.project.State = domain.ProjectStateActive
+			}
+		case *project.ProjectRemovedEvent:
+			rm.project.State = domain.ProjectStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *projectAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			project.ProjectAddedType,
+			project.ProjectChangedType,
+			project.ProjectDeactivatedType,
+			project.ProjectReactivatedType,
+			project.ProjectRemovedType,
+		).
+		Builder()
+}
+
+func (rm *projectAsOfReadModel) toProject() *Project {
+	p := rm.project
+	p.ID = rm.AggregateID
+	p.CreationDate = rm.CreationDate
+	p.ChangeDate = rm.ChangeDate
+	p.ResourceOwner = rm.ResourceOwner
+	p.Sequence = rm.ProcessedSequence
+	return &p
+}
+
+// appAsOfReadModel reconstructs the state of an application from the events of its project,
+// the events of the other applications of the project are ignored.
+type appAsOfReadModel struct {
+	*eventstore.ReadModel
+
+	app App
+}
+
+func newAppAsOfReadModel(projectID, appID string) *appAsOfReadModel {
+	return &appAsOfReadModel{
+		ReadModel: &eventstore.ReadModel{AggregateID: projectID},
+		app:       App{ID: appID, ProjectID: projectID},
+	}
+}
+
+func (rm *appAsOfReadModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if appID, ok := appEventAppID(event); ok && appID != rm.app.ID {
+			continue
+		}
+		rm.ReadModel.AppendEvents(event)
+	}
+}
+
+// appEventAppID returns the id of the application of the event,
+// ok is false for events of the project.
+func appEventAppID(event eventstore.Event) (appID string, ok bool) {
+	switch e := event.(type) {
+	case *project.ApplicationAddedEvent:
+		return e.AppID, true
+	case *project.ApplicationChangedEvent:
+		return e.AppID, true
+	case *project.ApplicationDeactivatedEvent:
+		return e.AppID, true
+	case *project.ApplicationReactivatedEvent:
+		return e.AppID, true
+	case *project.ApplicationRemovedEvent:
+		return e.AppID, true
+	case *project.OIDCConfigAddedEvent:
+		return e.AppID, true
+	case *project.OIDCConfigChangedEvent:
+		return e.AppID, true
+	case *project.OIDCConfigSecretChangedEvent:
+		return e.AppID, true
+	case *project.OIDCConfigSecretHashUpdatedEvent:
+		return e.AppID, true
+	case *project.APIConfigAddedEvent:
+		return e.AppID, true
+	case *project.APIConfigChangedEvent:
+		return e.AppID, true
+	case *project.APIConfigSecretChangedEvent:
+		return e.AppID, true
+	case *project.APIConfigSecretHashUpdatedEvent:
+		return e.AppID, true
+	case *project.SAMLConfigAddedEvent:
+		return e.AppID, true
+	case *project.SAMLConfigChangedEvent:
+		return e.AppID, true
+	case *project.WSFedConfigAddedEvent:
+		return e.AppID, true
+	case *project.WSFedConfigChangedEvent:
+		return e.AppID, true
+	case *project.CASConfigAddedEvent:
+		return e.AppID, true
+	case *project.CASConfigChangedEvent:
+		return e.AppID, true
+	}
+	return "", false
+}
+
+func (rm *appAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			rm.app.Name = e.Name
+			rm.app.State = domain.AppStateActive
+			rm.app.CreationDate = e.CreatedAt()
+		case *project.ApplicationChangedEvent:
+			rm.app.Name = e.Name
+		case *project.ApplicationDeactivatedEvent:
+			if rm.app.State != domain.AppStateRemoved {
+				rm.app.State = domain.AppStateInactive
+			}
+		case *project.ApplicationReactivatedEvent:
+			if rm.app.State != domain.AppStateRemoved {
+				rm.app.State = domain.AppStateActive
+			}
+		case *project.ApplicationRemovedEvent, *project.ProjectRemovedEvent:
+			rm.app.State = domain.AppStateRemoved
+		case *project.OIDCConfigAddedEvent:
+			rm.reduceOIDCConfigAdded(e)
+		case *project.OIDCConfigChangedEvent:
+			rm.reduceOIDCConfigChanged(e)
+		case *project.APIConfigAddedEvent:
+			rm.app.APIConfig = &APIApp{
+				ClientID:       e.ClientID,
+				AuthMethodType: e.AuthMethodType,
+				ResourceURI:    e.ResourceURI,
+			}
+		case *project.APIConfigChangedEvent:
+			rm.reduceAPIConfigChanged(e)
+		case *project.SAMLConfigAddedEvent:
+			rm.app.SAMLConfig = &SAMLApp{
+				Metadata:          e.Metadata,
+				MetadataURL:       e.MetadataURL,
+				EntityID:          e.EntityID,
+				LoginVersion:      e.LoginVersion,
+				LoginBaseURI:      gu.Ptr(e.LoginBaseURI),
+				NameIDSource:      e.NameIDSource,
+				AttributeMappings: e.AttributeMappings,
+			}
+		case *project.SAMLConfigChangedEvent:
+			rm.reduceSAMLConfigChanged(e)
+		case *project.WSFedConfigAddedEvent:
+			rm.app.WSFedConfig = &WSFedApp{
+				Realm:     e.Realm,
+				ReplyURLs: e.ReplyURLs,
+				TokenType: e.TokenType,
+			}
+		case *project.WSFedConfigChangedEvent:
+			rm.reduceWSFedConfigChanged(e)
+		case *project.CASConfigAddedEvent:
+			rm.app.CASConfig = &CASApp{
+				ServiceURLs:  e.ServiceURLs,
+				LoginVersion: e.LoginVersion,
+				LoginBaseURI: gu.Ptr(e.LoginBaseURI),
+			}
+		case *project.CASConfigChangedEvent:
+			rm.reduceCASConfigChanged(e)
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *appAsOfReadModel) reduceOIDCConfigAdded(e *project.OIDCConfigAddedEvent) {
+	rm.app.OIDCConfig = &OIDCApp{
+		RedirectURIs:                 e.RedirectUris,
+		ResponseTypes:                e.ResponseTypes,
+		GrantTypes:                   e.GrantTypes,
+		AppType:                      e.ApplicationType,
+		ClientID:                     e.ClientID,
+		AuthMethodType:               e.AuthMethodType,
+		PostLogoutRedirectURIs:       e.PostLogoutRedirectUris,
+		Version:                      e.Version,
+		IsDevMode:                    e.DevMode,
+		AccessTokenType:              e.AccessTokenType,
+		AssertAccessTokenRole:        e.AccessTokenRoleAssertion,
+		AssertIDTokenRole:            e.IDTokenRoleAssertion,
+		AssertIDTokenUserinfo:        e.IDTokenUserinfoAssertion,
+		ClockSkew:                    e.ClockSkew,
+		AdditionalOrigins:            e.AdditionalOrigins,
+		SkipNativeAppSuccessPage:     e.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:         e.BackChannelLogoutURI,
+		LoginVersion:                 e.LoginVersion,
+		LoginBaseURI:                 gu.Ptr(e.LoginBaseURI),
+		RefreshTokenRotation:         e.RefreshTokenRotation,
+		RefreshTokenLifetime:         e.RefreshTokenLifetime,
+		RefreshTokenIdleLifetime:     e.RefreshTokenIdleLifetime,
+		IDTokenEncryptedResponseAlg:  e.IDTokenEncryptedResponseAlg,
+		IDTokenEncryptedResponseEnc:  e.IDTokenEncryptedResponseEnc,
+		UserinfoEncryptedResponseAlg: e.UserinfoEncryptedResponseAlg,
+		UserinfoEncryptedResponseEnc: e.UserinfoEncryptedResponseEnc,
+		JWKS:                         e.JWKS,
+		JWKSURI:                      e.JWKSURI,
+		FrontChannelLogoutURI:        e.FrontChannelLogoutURI,
+	}
+}
+
+func (rm *appAsOfReadModel) reduceOIDCConfigChanged(e *project.OIDCConfigChangedEvent) {
+	config := rm.app.OIDCConfig
+	if config == nil {
+		return
+	}
+	if e.RedirectUris != nil {
+		config.RedirectURIs = *e.RedirectUris
+	}
+	if e.ResponseTypes != nil {
+		config.ResponseTypes = *e.ResponseTypes
+	}
+	if e.GrantTypes != nil {
+		config.GrantTypes = *e.GrantTypes
+	}
+	if e.ApplicationType != nil {
+		config.AppType = *e.ApplicationType
+	}
+	if e.AuthMethodType != nil {
+		config.AuthMethodType = *e.AuthMethodType
+	}
+	if e.PostLogoutRedirectUris != nil {
+		config.PostLogoutRedirectURIs = *e.PostLogoutRedirectUris
+	}
+	if e.Version != nil {
+		config.Version = *e.Version
+	}
+	if e.DevMode != nil {
+		config.IsDevMode = *e.DevMode
+	}
+	if e.AccessTokenType != nil {
+		config.AccessTokenType = *e.AccessTokenType
+	}
+	if e.AccessTokenRoleAssertion != nil {
+		config.AssertAccessTokenRole = *e.AccessTokenRoleAssertion
+	}
+	if e.IDTokenRoleAssertion != nil {
+		config.AssertIDTokenRole = *e.IDTokenRoleAssertion
+	}
+	if e.IDTokenUserinfoAssertion != nil {
+		config.AssertIDTokenUserinfo = *e.IDTokenUserinfoAssertion
+	}
+	if e.ClockSkew != nil {
+		config.ClockSkew = *e.ClockSkew
+	}
+	if e.AdditionalOrigins != nil {
+		config.AdditionalOrigins = *e.AdditionalOrigins
+	}
+	if e.SkipNativeAppSuccessPage != nil {
+		config.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
+	}
+	if e.BackChannelLogoutURI != nil {
+		config.BackChannelLogoutURI = *e.BackChannelLogoutURI
+	}
+	if e.LoginVersion != nil {
+		config.LoginVersion = *e.LoginVersion
+	}
+	if e.LoginBaseURI != nil {
+		config.LoginBaseURI = gu.Ptr(*e.LoginBaseURI)
+	}
+	if e.RefreshTokenRotation != nil {
+		config.RefreshTokenRotation = *e.RefreshTokenRotation
+	}
+	if e.RefreshTokenLifetime != nil {
+		config.RefreshTokenLifetime = *e.RefreshTokenLifetime
+	}
+	if e.RefreshTokenIdleLifetime != nil {
+		config.RefreshTokenIdleLifetime = *e.RefreshTokenIdleLifetime
+	}
+	if e.IDTokenEncryptedResponseAlg != nil {
+		config.IDTokenEncryptedResponseAlg = *e.IDTokenEncryptedResponseAlg
+	}
+	if e.IDTokenEncryptedResponseEnc != nil {
+		config.IDTokenEncryptedResponseEnc = *e.IDTokenEncryptedResponseEnc
+	}
+	if e.UserinfoEncryptedResponseAlg != nil {
+		config.UserinfoEncryptedResponseAlg = *e.UserinfoEncryptedResponseAlg
+	}
+	if e.UserinfoEncryptedResponseEnc != nil {
+		config.UserinfoEncryptedResponseEnc = *e.UserinfoEncryptedResponseEnc
+	}
+	if e.JWKS != nil {
+		config.JWKS = *e.JWKS
+	}
+	if e.JWKSURI != nil {
+		config.JWKSURI = *e.JWKSURI
+	}
+	if e.FrontChannelLogoutURI != nil {
+		config.FrontChannelLogoutURI = *e.FrontChannelLogoutURI
+	}
+}
+
+func (rm *appAsOfReadModel) reduceAPIConfigChanged(e *project.APIConfigChangedEvent) {
+	config := rm.app.APIConfig
+	if config == nil {
+		return
+	}
+	if e.AuthMethodType != nil {
+		config.AuthMethodType = *e.AuthMethodType
+	}
+	if e.ResourceURI != nil {
+		config.ResourceURI = *e.ResourceURI
+	}
+	if e.IntrospectionSignedResponse != nil {
+		config.IntrospectionSignedResponse = *e.IntrospectionSignedResponse
+	}
+	if e.IntrospectionEncryptionKey != nil {
+		config.IntrospectionEncryptionKey = *e.IntrospectionEncryptionKey
+	}
+}
+
+func (rm *appAsOfReadModel) reduceSAMLConfigChanged(e *project.SAMLConfigChangedEvent) {
+	config := rm.app.SAMLConfig
+	if config == nil {
+		return
+	}
+	if e.Metadata != nil {
+		config.Metadata = e.Metadata
+	}
+	if e.MetadataURL != nil {
+		config.MetadataURL = *e.MetadataURL
+	}
+	if e.EntityID != "" {
+		config.EntityID = e.EntityID
+	}
+	if e.LoginVersion != nil {
+		config.LoginVersion = *e.LoginVersion
+	}
+	if e.LoginBaseURI != nil {
+		config.LoginBaseURI = gu.Ptr(*e.LoginBaseURI)
+	}
+	if e.NameIDSource != nil {
+		config.NameIDSource = *e.NameIDSource
+	}
+	if e.AttributeMappings != nil {
+		config.AttributeMappings = *e.AttributeMappings
+	}
+}
+
+func (rm *appAsOfReadModel) reduceWSFedConfigChanged(e *project.WSFedConfigChangedEvent) {
+	config := rm.app.WSFedConfig
+	if config == nil {
+		return
+	}
+	if e.Realm != "" {
+		config.Realm = e.Realm
+	}
+	if e.ReplyURLs != nil {
+		config.ReplyURLs = *e.ReplyURLs
+	}
+	if e.TokenType != nil {
+		config.TokenType = *e.TokenType
+	}
+}
+
+func (rm *appAsOfReadModel) reduceCASConfigChanged(e *project.CASConfigChangedEvent) {
+	config := rm.app.CASConfig
+	if config == nil {
+		return
+	}
+	if e.ServiceURLs != nil {
+		config.ServiceURLs = *e.ServiceURLs
+	}
+	if e.LoginVersion != nil {
+		config.LoginVersion = *e.LoginVersion
+	}
+	if e.LoginBaseURI != nil {
+		config.LoginBaseURI = gu.Ptr(*e.LoginBaseURI)
+	}
+}
+
+func (rm *appAsOfReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.ApplicationChangedType,
+			project.ApplicationDeactivatedType,
+			project.ApplicationReactivatedType,
+			project.ApplicationRemovedType,
+			project.OIDCConfigAddedType,
+			project.OIDCConfigChangedType,
+			project.APIConfigAddedType,
+			project.APIConfigChangedType,
+			project.SAMLConfigAddedType,
+			project.SAMLConfigChangedType,
+			project.WSFedConfigAddedType,
+			project.WSFedConfigChangedType,
+			project.CASConfigAddedType,
+			project.CASConfigChangedType,
+			project.ProjectRemovedType,
+		).
+		Builder()
+}
+
+func (rm *appAsOfReadModel) toApp() *App {
+	a := rm.app
+	a.ChangeDate = rm.ChangeDate
+	a.ResourceOwner = rm.ResourceOwner
+	a.Sequence = rm.ProcessedSequence
+	if config := a.OIDCConfig; config != nil {
+		config.ComplianceProblems = domain.GetOIDCCompliance(gu.Ptr(config.Version), gu.Ptr(config.AppType), config.GrantTypes, config.ResponseTypes, gu.Ptr(config.AuthMethodType), config.RedirectURIs).Problems
+		// the allow list can only fail for invalid redirect uris, which are rejected when the app is changed
+		config.AllowedOrigins, _ = domain.OIDCOriginAllowList(config.RedirectURIs, config.AdditionalOrigins)
+	}
+	return &a
+}
+
+// userGrantAsOfReadModel reconstructs the state of a user grant from its events
+type userGrantAsOfReadModel struct {
+	*eventstore.ReadModel
+
+	UserID    string
+	ProjectID string
+	GrantID   string
+	RoleKeys  []string
+	State     domain.UserGrantState
+}
+
+func (rm *userGrantAsOfReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *usergrant.UserGrantAddedEvent:
+			rm.UserID = e.UserID
+			rm.ProjectID = e.ProjectID
+			rm.GrantID = e.ProjectGrantID
+			rm.RoleKeys = e.RoleKeys
+			rm.State = domain.UserGrantStateActive
+		case *usergrant.UserGrantChangedEvent:
+			rm.RoleKeys = e.RoleKeys
+		case *usergrant.UserGrantCascadeChangedEvent:
+			rm.RoleKeys = e.RoleKeys
+		case *usergrant.UserGrantDeactivatedEvent:
+			if rm.State != domain.UserGrantStateRemoved {
+				rm.State = domain.UserGrantStateInactive
+			}
+		case *usergrant.UserGrantReactivatedEvent:
+			if rm.State != domain.UserGrantStateRemoved {
+				rm.State = domain.UserGrantStateActive
+			}
+		case *usergrant.UserGrantRemovedEvent, *usergrant.UserGrantCascadeRemovedEvent:
+			rm.State = domain.UserGrantStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *userGrantAsOfReadModel) toUserGrant() *UserGrant {
+	roles := make([]Role, len(rm.RoleKeys))
+	for i, key := range rm.RoleKeys {
+		roles[i] = Role{Key: key}
+	}
+	return &UserGrant{
+		ID:              rm.AggregateID,
+		CreationDate:    rm.CreationDate,
+		ChangeDate:      rm.ChangeDate,
+		Sequence:        rm.ProcessedSequence,
+		Roles:           rm.RoleKeys,
+		RoleInformation: roles,
+		GrantID:         rm.GrantID,
+		State:           rm.State,
+		UserID:          rm.UserID,
+		ResourceOwner:   rm.ResourceOwner,
+		ProjectID:       rm.ProjectID,
+	}
+}
+
+// userGrantsAsOfReadModel reduces the events of multiple user grants into their read models
+type userGrantsAsOfReadModel struct {
+	grants []*userGrantAsOfReadModel
+}
+
+func (rm *userGrantsAsOfReadModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		rm.grant(event.Aggregate().ID).AppendEvents(event)
+	}
+}
+
+func (rm *userGrantsAsOfReadModel) grant(grantID string) *userGrantAsOfReadModel {
+	for _, grant := range rm.grants {
+		if grant.AggregateID == grantID {
+			return grant
+		}
+	}
+	grant := &userGrantAsOfReadModel{
+		ReadModel: &eventstore.ReadModel{AggregateID: grantID},
+	}
+	rm.grants = append(rm.grants, grant)
+	return grant
+}
+
+func (rm *userGrantsAsOfReadModel) Reduce() error {
+	for _, grant := range rm.grants {
+		if err := grant.Reduce(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (rm *userGrantsAsOfReadModel) Query(grantIDs []string) *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		AggregateIDs(grantIDs...).
+		EventTypes(
+			usergrant.UserGrantAddedType,
+			usergrant.UserGrantChangedType,
+			usergrant.UserGrantCascadeChangedType,
+			usergrant.UserGrantDeactivatedType,
+			usergrant.UserGrantReactivatedType,
+			usergrant.UserGrantRemovedType,
+			usergrant.UserGrantCascadeRemovedType,
+		).
+		Builder()
+}
@@ -0,0 +1,258 @@
+package query
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestAsOf_validate(t *testing.T) {
+	tests := []struct {
+		name string
+		asOf *AsOf
+		err  func(error) bool
+	}{
+		{
+			name: "nil, error",
+			err:  zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "empty, error",
+			asOf: &AsOf{},
+			err:  zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "negative position, error",
+			asOf: &AsOf{Position: decimal.NewFromInt(-1)},
+			err:  zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "date, ok",
+			asOf: &AsOf{Date: time.Now()},
+		},
+		{
+			name: "position, ok",
+			asOf: &AsOf{Position: decimal.NewFromFloat(1234.5)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.asOf.validate()
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.err(err))
+		})
+	}
+}
+
+func TestQueries_OrgAsOf(t *testing.T) {
+	ctx := context.Background()
+	creationDate := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	orgAgg := &org.NewAggregate("org1").Aggregate
+	added := eventFromEventPusher(org.NewOrgAddedEvent(ctx, orgAgg, "org"))
+	added.CreationDate = creationDate
+	allowed := func(context.Context, string, string, string) error { return nil }
+	denied := func(context.Context, string, string, string) error {
+		return zerrors.ThrowPermissionDenied(nil, "id", "denied")
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	type args struct {
+		orgID           string
+		asOf            *AsOf
+		permissionCheck domain.PermissionCheck
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		want   *Org
+		err    func(error) bool
+	}{
+		{
+			name: "missing org id, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				asOf: &AsOf{Date: time.Now()},
+			},
+			err: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "missing point in time, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				orgID: "org1",
+			},
+			err: zerrors.IsErrorInvalidArgument,
+		},
+		{
+			name: "org not existing at point in time, not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			args: args{
+				orgID:           "org1",
+				asOf:            &AsOf{Date: time.Now()},
+				permissionCheck: allowed,
+			},
+			err: zerrors.IsNotFound,
+		},
+		{
+			name: "missing permission, not found",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(added),
+				),
+			},
+			args: args{
+				orgID:           "org1",
+				asOf:            &AsOf{Date: time.Now()},
+				permissionCheck: denied,
+			},
+			err: zerrors.IsNotFound,
+		},
+		{
+			name: "deactivated org, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						added,
+						eventFromEventPusher(org.NewDomainPrimarySetEvent(ctx, orgAgg, "org.example.com")),
+						eventFromEventPusher(org.NewOrgDeactivatedEvent(ctx, orgAgg)),
+					),
+				),
+			},
+			args: args{
+				orgID:           "org1",
+				asOf:            &AsOf{Date: time.Now()},
+				permissionCheck: allowed,
+			},
+			want: &Org{
+				ID:            "org1",
+				CreationDate:  creationDate,
+				ResourceOwner: "org1",
+				State:         domain.OrgStateInactive,
+				Name:          "org",
+				Domain:        "org.example.com",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queries{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := q.OrgAsOf(ctx, tt.args.orgID, tt.args.asOf, tt.args.permissionCheck)
+			if tt.err != nil {
+				assert.True(t, tt.err(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQueries_UserGrantsAsOf(t *testing.T) {
+	ctx := context.Background()
+	grantAgg := func(id string) *eventstore.Aggregate {
+		return &usergrant.NewAggregate(id, "org1").Aggregate
+	}
+	addedEvent := func(id, projectID string) *repository.Event {
+		return eventFromEventPusher(usergrant.NewUserGrantAddedEvent(ctx, grantAgg(id), "user1", projectID, "", []string{"role"}))
+	}
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	tests := []struct {
+		name            string
+		fields          fields
+		permissionCheck domain.PermissionCheck
+		want            []*UserGrant
+		err             error
+	}{
+		{
+			name: "filter error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilterError(io.ErrClosedPipe),
+				),
+			},
+			err: io.ErrClosedPipe,
+		},
+		{
+			name: "no grants",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+		},
+		{
+			name: "removed grants and grants without permission omitted",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						addedEvent("grant1", "project1"),
+						addedEvent("grant2", "project2"),
+						addedEvent("grant3", "project1"),
+					),
+					expectFilter(
+						addedEvent("grant1", "project1"),
+						addedEvent("grant2", "project2"),
+						addedEvent("grant3", "project1"),
+						eventFromEventPusher(usergrant.NewUserGrantRemovedEvent(ctx, grantAgg("grant3"), "user1", "project1", "")),
+					),
+				),
+			},
+			permissionCheck: func(_ context.Context, _, _, projectID string) error {
+				if projectID != "project1" {
+					return zerrors.ThrowPermissionDenied(nil, "id", "denied")
+				}
+				return nil
+			},
+			want: []*UserGrant{
+				{
+					ID:              "grant1",
+					Roles:           []string{"role"},
+					RoleInformation: []Role{{Key: "role"}},
+					State:           domain.UserGrantStateActive,
+					UserID:          "user1",
+					ResourceOwner:   "org1",
+					ProjectID:       "project1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queries{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := q.UserGrantsAsOf(ctx, "user1", &AsOf{Date: time.Now()}, tt.permissionCheck)
+			require.ErrorIs(t, err, tt.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
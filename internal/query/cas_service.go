@@ -0,0 +1,88 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// CASService is an active CAS application, identified by the service url of the CAS requests.
+type CASService struct {
+	InstanceID           string              `json:"instance_id,omitempty"`
+	AppID                string              `json:"app_id,omitempty"`
+	State                domain.AppState     `json:"state,omitempty"`
+	ServiceURLs          []string            `json:"service_urls,omitempty"`
+	LoginVersion         domain.LoginVersion `json:"login_version,omitempty"`
+	LoginBaseURI         *string             `json:"login_base_uri,omitempty"`
+	ProjectID            string              `json:"project_id,omitempty"`
+	ProjectRoleAssertion bool                `json:"project_role_assertion,omitempty"`
+}
+
+//go:embed cas_service_by_url.sql
+var casServiceQuery string
+
+// ActiveCASServiceByURL returns the active CAS application the requested service url belongs to.
+// See [domain.CASServiceMatches] for the matching rules.
+func (q *Queries) ActiveCASServiceByURL(ctx context.Context, service string) (s *CASService, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		s, err = scanCASService(row)
+		return err
+	}, casServiceQuery,
+		authz.GetInstance(ctx).InstanceID(),
+		service,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, zerrors.ThrowNotFound(err, "QUERY-ahF4o", "Errors.App.NotFound")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Lae0o", "Errors.Internal")
+	}
+	return s, nil
+}
+
+func scanCASService(row *sql.Row) (*CASService, error) {
+	var instanceID, appID, projectID, loginBaseURI sql.NullString
+	var serviceURLs database.TextArray[string]
+	var state, loginVersion sql.NullInt16
+	var projectRoleAssertion sql.NullBool
+
+	err := row.Scan(
+		&instanceID,
+		&appID,
+		&state,
+		&serviceURLs,
+		&loginVersion,
+		&loginBaseURI,
+		&projectID,
+		&projectRoleAssertion,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, zerrors.ThrowNotFound(err, "QUERY-iW4oo", "Errors.App.NotFound")
+		}
+		return nil, zerrors.ThrowInternal(err, "QUERY-ohc9A", "Errors.Internal")
+	}
+	s := &CASService{
+		InstanceID:           instanceID.String,
+		AppID:                appID.String,
+		State:                domain.AppState(state.Int16),
+		ServiceURLs:          serviceURLs,
+		LoginVersion:         domain.LoginVersion(loginVersion.Int16),
+		ProjectID:            projectID.String,
+		ProjectRoleAssertion: projectRoleAssertion.Bool,
+	}
+	if loginBaseURI.Valid {
+		s.LoginBaseURI = &loginBaseURI.String
+	}
+	return s, nil
+}
@@ -0,0 +1,87 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestQueries_ActiveCASServiceByURL(t *testing.T) {
+	expQuery := regexp.QuoteMeta(casServiceQuery)
+	cols := []string{
+		"instance_id",
+		"app_id",
+		"state",
+		"service_urls",
+		"login_version",
+		"login_base_uri",
+		"project_id",
+		"project_role_assertion",
+	}
+
+	tests := []struct {
+		name    string
+		mock    sqlExpectation
+		want    *CASService
+		wantErr error
+	}{
+		{
+			name:    "no rows",
+			mock:    mockQueryErr(expQuery, sql.ErrNoRows, "instanceID", "https://moodle.test.com/login/index.php"),
+			wantErr: zerrors.ThrowNotFound(sql.ErrNoRows, "QUERY-ahF4o", "Errors.App.NotFound"),
+		},
+		{
+			name:    "internal error",
+			mock:    mockQueryErr(expQuery, sql.ErrConnDone, "instanceID", "https://moodle.test.com/login/index.php"),
+			wantErr: zerrors.ThrowInternal(sql.ErrConnDone, "QUERY-Lae0o", "Errors.Internal"),
+		},
+		{
+			name: "service",
+			mock: mockQuery(expQuery, cols, []driver.Value{
+				"230690539048009730",
+				"236647088211886082",
+				domain.AppStateActive,
+				database.TextArray[string]{"https://moodle.test.com/login/"},
+				domain.LoginVersion2,
+				"https://login.test.com",
+				"236645808328409090",
+				true,
+			}, "instanceID", "https://moodle.test.com/login/index.php"),
+			want: &CASService{
+				InstanceID:           "230690539048009730",
+				AppID:                "236647088211886082",
+				State:                domain.AppStateActive,
+				ServiceURLs:          []string{"https://moodle.test.com/login/"},
+				LoginVersion:         domain.LoginVersion2,
+				LoginBaseURI:         gu.Ptr("https://login.test.com"),
+				ProjectID:            "236645808328409090",
+				ProjectRoleAssertion: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execMock(t, tt.mock, func(db *sql.DB) {
+				q := &Queries{
+					client: &database.DB{
+						DB: db,
+					},
+				}
+				ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+				got, err := q.ActiveCASServiceByURL(ctx, "https://moodle.test.com/login/index.php")
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			})
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/shopspring/decimal"
+
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -14,6 +16,7 @@ type Event struct {
 	Editor       *EventEditor
 	Aggregate    *eventstore.Aggregate
 	Sequence     uint64
+	Position     decimal.Decimal
 	CreationDate time.Time
 	Type         string
 	Payload      []byte
@@ -104,12 +107,19 @@ func (er *eventsReducer) convertEvent(ctx context.Context, event eventstore.Even
 		},
 		Aggregate:    event.Aggregate(),
 		Sequence:     event.Sequence(),
+		Position:     event.Position(),
 		CreationDate: event.CreatedAt(),
 		Type:         string(event.Type()),
 		Payload:      event.DataAsBytes(),
 	}
 }
 
+// EventEditor returns the user who pushed events,
+// only the ID is set if the user does not exist (anymore).
+func (q *Queries) EventEditor(ctx context.Context, userID string) *EventEditor {
+	return q.editorUserByID(ctx, userID)
+}
+
 func (q *Queries) editorUserByID(ctx context.Context, userID string) *EventEditor {
 	user, err := q.GetUserByID(ctx, false, userID)
 	if err != nil {
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// FrontChannelLogout is an application, which needs to be informed about the termination of the session
+// through the user agent by rendering its front channel logout URI.
+type FrontChannelLogout struct {
+	SessionID             string
+	ClientID              string
+	FrontChannelLogoutURI string
+}
+
+type frontChannelLogoutReadModel struct {
+	eventstore.ReadModel
+
+	sessionIDs []string
+	Logouts    []*FrontChannelLogout
+}
+
+func (rm *frontChannelLogoutReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		e, ok := event.(*sessionlogout.FrontChannelLogoutRegisteredEvent)
+		if !ok {
+			continue
+		}
+		sessionID := e.Aggregate().ID
+		// an application might have multiple OIDC sessions in the same session,
+		// but it only needs to be informed once.
+		if slices.ContainsFunc(rm.Logouts, func(logout *FrontChannelLogout) bool {
+			return logout.SessionID == sessionID && logout.ClientID == e.ClientID
+		}) {
+			continue
+		}
+		rm.Logouts = append(rm.Logouts, &FrontChannelLogout{
+			SessionID:             sessionID,
+			ClientID:              e.ClientID,
+			FrontChannelLogoutURI: e.FrontChannelLogoutURI,
+		})
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *frontChannelLogoutReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(sessionlogout.AggregateType).
+		AggregateIDs(rm.sessionIDs...).
+		EventTypes(sessionlogout.FrontChannelLogoutRegisteredType).
+		Builder()
+}
+
+// FrontChannelLogouts returns the applications, which registered a front channel logout for one of the provided sessions.
+func (q *Queries) FrontChannelLogouts(ctx context.Context, sessionIDs ...string) (_ []*FrontChannelLogout, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if len(sessionIDs) == 0 {
+		return nil, nil
+	}
+	model := &frontChannelLogoutReadModel{
+		sessionIDs: sessionIDs,
+	}
+	if err = q.eventstore.FilterToQueryReducer(ctx, model); err != nil {
+		return nil, err
+	}
+	return model.Logouts, nil
+}
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/sessionlogout"
+)
+
+func TestQueries_FrontChannelLogouts(t *testing.T) {
+	ctx := context.Background()
+	type fields struct {
+		eventstore func(*testing.T) *eventstore.Eventstore
+	}
+	tests := []struct {
+		name       string
+		fields     fields
+		sessionIDs []string
+		want       []*FrontChannelLogout
+		wantErr    error
+	}{
+		{
+			name:       "no sessions",
+			fields:     fields{eventstore: expectEventstore()},
+			sessionIDs: nil,
+			want:       nil,
+		},
+		{
+			name: "filter error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilterError(io.ErrClosedPipe),
+				),
+			},
+			sessionIDs: []string{"session1"},
+			wantErr:    io.ErrClosedPipe,
+		},
+		{
+			name: "none registered",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			sessionIDs: []string{"session1"},
+			want:       nil,
+		},
+		{
+			name: "registered, once per client and session",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(
+						eventFromEventPusher(sessionlogout.NewFrontChannelLogoutRegisteredEvent(ctx,
+							&sessionlogout.NewAggregate("session1", "instance1").Aggregate,
+							"oidcSession1", "user1", "client1", "https://client1.com/logout",
+						)),
+						eventFromEventPusher(sessionlogout.NewFrontChannelLogoutRegisteredEvent(ctx,
+							&sessionlogout.NewAggregate("session1", "instance1").Aggregate,
+							"oidcSession2", "user1", "client1", "https://client1.com/logout",
+						)),
+						eventFromEventPusher(sessionlogout.NewFrontChannelLogoutRegisteredEvent(ctx,
+							&sessionlogout.NewAggregate("session1", "instance1").Aggregate,
+							"oidcSession3", "user1", "client2", "https://client2.com/logout",
+						)),
+						eventFromEventPusher(sessionlogout.NewFrontChannelLogoutRegisteredEvent(ctx,
+							&sessionlogout.NewAggregate("session2", "instance1").Aggregate,
+							"oidcSession4", "user1", "client1", "https://client1.com/logout",
+						)),
+					),
+				),
+			},
+			sessionIDs: []string{"session1", "session2"},
+			want: []*FrontChannelLogout{
+				{
+					SessionID:             "session1",
+					ClientID:              "client1",
+					FrontChannelLogoutURI: "https://client1.com/logout",
+				},
+				{
+					SessionID:             "session1",
+					ClientID:              "client2",
+					FrontChannelLogoutURI: "https://client2.com/logout",
+				},
+				{
+					SessionID:             "session2",
+					ClientID:              "client1",
+					FrontChannelLogoutURI: "https://client1.com/logout",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queries{
+				eventstore: tt.fields.eventstore(t),
+			}
+			got, err := q.FrontChannelLogouts(ctx, tt.sessionIDs...)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
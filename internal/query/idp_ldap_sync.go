@@ -0,0 +1,376 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type LDAPSync struct {
+	IDPID         string
+	InstanceID    string
+	ResourceOwner string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+	domain.LDAPSyncConfig
+	NextRunAt time.Time
+}
+
+type LDAPSyncRun struct {
+	ID    string
+	IDPID string
+	domain.LDAPSyncRun
+}
+
+type LDAPSyncRuns struct {
+	SearchResponse
+	Runs []*LDAPSyncRun
+}
+
+// DueLDAPSync references the LDAP IdP of an instance whose next synchronization run is due.
+type DueLDAPSync struct {
+	InstanceID string
+	IDPID      string
+}
+
+var (
+	ldapSyncTable = table{
+		name:          projection.LDAPSyncTable,
+		instanceIDCol: projection.LDAPSyncInstanceIDCol,
+	}
+	LDAPSyncIDPIDCol = Column{
+		name:  projection.LDAPSyncIDPIDCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncInstanceIDCol = Column{
+		name:  projection.LDAPSyncInstanceIDCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncResourceOwnerCol = Column{
+		name:  projection.LDAPSyncResourceOwnerCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncCreationDateCol = Column{
+		name:  projection.LDAPSyncCreationDateCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncChangeDateCol = Column{
+		name:  projection.LDAPSyncChangeDateCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncSequenceCol = Column{
+		name:  projection.LDAPSyncSequenceCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncEnabledCol = Column{
+		name:  projection.LDAPSyncEnabledCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncOrganizationIDCol = Column{
+		name:  projection.LDAPSyncOrganizationIDCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncFilterCol = Column{
+		name:  projection.LDAPSyncFilterCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncIntervalCol = Column{
+		name:  projection.LDAPSyncIntervalCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncFullSyncIntervalCol = Column{
+		name:  projection.LDAPSyncFullSyncIntervalCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncDeltaAttributeCol = Column{
+		name:  projection.LDAPSyncDeltaAttributeCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncDeactivateMissingCol = Column{
+		name:  projection.LDAPSyncDeactivateMissingCol,
+		table: ldapSyncTable,
+	}
+	LDAPSyncNextRunAtCol = Column{
+		name:  projection.LDAPSyncNextRunAtCol,
+		table: ldapSyncTable,
+	}
+)
+
+var (
+	ldapSyncRunTable = table{
+		name:          projection.LDAPSyncTable + "_" + projection.LDAPSyncRunSuffix,
+		instanceIDCol: projection.LDAPSyncRunInstanceIDCol,
+	}
+	LDAPSyncRunIDCol = Column{
+		name:  projection.LDAPSyncRunIDCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunIDPIDCol = Column{
+		name:  projection.LDAPSyncRunIDPIDCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunInstanceIDCol = Column{
+		name:  projection.LDAPSyncRunInstanceIDCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunStartedAtCol = Column{
+		name:  projection.LDAPSyncRunStartedAtCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunFinishedAtCol = Column{
+		name:  projection.LDAPSyncRunFinishedAtCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunFullSyncCol = Column{
+		name:  projection.LDAPSyncRunFullSyncCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunCreatedCol = Column{
+		name:  projection.LDAPSyncRunCreatedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunUpdatedCol = Column{
+		name:  projection.LDAPSyncRunUpdatedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunUnchangedCol = Column{
+		name:  projection.LDAPSyncRunUnchangedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunDeactivatedCol = Column{
+		name:  projection.LDAPSyncRunDeactivatedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunReactivatedCol = Column{
+		name:  projection.LDAPSyncRunReactivatedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunFailedCol = Column{
+		name:  projection.LDAPSyncRunFailedCol,
+		table: ldapSyncRunTable,
+	}
+	LDAPSyncRunErrorCol = Column{
+		name:  projection.LDAPSyncRunErrorCol,
+		table: ldapSyncRunTable,
+	}
+)
+
+// LDAPSyncByIDPID returns the synchronization configuration of an LDAP IdP owned by the resource owner.
+func (q *Queries) LDAPSyncByIDPID(ctx context.Context, idpID, resourceOwner string) (sync *LDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareLDAPSyncQuery()
+	stmt, args, err := query.Where(sq.Eq{
+		LDAPSyncInstanceIDCol.identifier():    authz.GetInstance(ctx).InstanceID(),
+		LDAPSyncIDPIDCol.identifier():         idpID,
+		LDAPSyncResourceOwnerCol.identifier(): resourceOwner,
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ld3vx", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		sync, err = scan(row)
+		return err
+	}, stmt, args...)
+	return sync, err
+}
+
+// SearchLDAPSyncRuns returns the runs of the synchronization of an LDAP IdP, the latest first.
+func (q *Queries) SearchLDAPSyncRuns(ctx context.Context, idpID string, queries *SearchRequest) (runs *LDAPSyncRuns, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if queries.SortingColumn.isZero() {
+		queries.SortingColumn = LDAPSyncRunStartedAtCol
+	}
+	query, scan := prepareLDAPSyncRunsQuery()
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		LDAPSyncRunInstanceIDCol.identifier(): authz.GetInstance(ctx).InstanceID(),
+		LDAPSyncRunIDPIDCol.identifier():      idpID,
+	}).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInvalidArgument(err, "QUERY-Rn7ca", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		runs, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	runs.State, err = q.latestState(ctx, ldapSyncTable)
+	return runs, err
+}
+
+// DueLDAPSyncs returns the enabled synchronizations of all instances which are due at the given time.
+// It is used by the scheduler of the synchronization jobs and therefore not restricted to the instance of the context.
+func (q *Queries) DueLDAPSyncs(ctx context.Context, now time.Time, limit uint64) (syncs []*DueLDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, args, err := sq.Select(
+		LDAPSyncInstanceIDCol.identifier(),
+		LDAPSyncIDPIDCol.identifier(),
+	).
+		From(ldapSyncTable.identifier()).
+		Where(sq.And{
+			sq.Eq{LDAPSyncEnabledCol.identifier(): true},
+			sq.LtOrEq{LDAPSyncNextRunAtCol.identifier(): now},
+		}).
+		OrderBy(LDAPSyncNextRunAtCol.identifier()).
+		Limit(limit).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Du4nm", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			sync := new(DueLDAPSync)
+			if err := rows.Scan(&sync.InstanceID, &sync.IDPID); err != nil {
+				return err
+			}
+			syncs = append(syncs, sync)
+		}
+		return rows.Err()
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Dk2pw", "Errors.Internal")
+	}
+	return syncs, nil
+}
+
+func prepareLDAPSyncQuery() (sq.SelectBuilder, func(*sql.Row) (*LDAPSync, error)) {
+	return sq.Select(
+			LDAPSyncIDPIDCol.identifier(),
+			LDAPSyncInstanceIDCol.identifier(),
+			LDAPSyncResourceOwnerCol.identifier(),
+			LDAPSyncCreationDateCol.identifier(),
+			LDAPSyncChangeDateCol.identifier(),
+			LDAPSyncSequenceCol.identifier(),
+			LDAPSyncEnabledCol.identifier(),
+			LDAPSyncOrganizationIDCol.identifier(),
+			LDAPSyncFilterCol.identifier(),
+			LDAPSyncIntervalCol.identifier(),
+			LDAPSyncFullSyncIntervalCol.identifier(),
+			LDAPSyncDeltaAttributeCol.identifier(),
+			LDAPSyncDeactivateMissingCol.identifier(),
+			LDAPSyncNextRunAtCol.identifier(),
+		).
+			From(ldapSyncTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*LDAPSync, error) {
+			var (
+				sync             = new(LDAPSync)
+				organizationID   sql.NullString
+				filter           sql.NullString
+				interval         database.NullDuration
+				fullSyncInterval database.NullDuration
+				nextRunAt        sql.NullTime
+			)
+			err := row.Scan(
+				&sync.IDPID,
+				&sync.InstanceID,
+				&sync.ResourceOwner,
+				&sync.CreationDate,
+				&sync.ChangeDate,
+				&sync.Sequence,
+				&sync.Enabled,
+				&organizationID,
+				&filter,
+				&interval,
+				&fullSyncInterval,
+				&sync.DeltaAttribute,
+				&sync.DeactivateMissing,
+				&nextRunAt,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Nf5xe", "Errors.IDPConfig.NotExisting")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Sk8vb", "Errors.Internal")
+			}
+			sync.OrganizationID = organizationID.String
+			sync.Filter = filter.String
+			sync.Interval = interval.Duration
+			sync.FullSyncInterval = fullSyncInterval.Duration
+			sync.NextRunAt = nextRunAt.Time
+			return sync, nil
+		}
+}
+
+func prepareLDAPSyncRunsQuery() (sq.SelectBuilder, func(*sql.Rows) (*LDAPSyncRuns, error)) {
+	return sq.Select(
+			LDAPSyncRunIDCol.identifier(),
+			LDAPSyncRunIDPIDCol.identifier(),
+			LDAPSyncRunStartedAtCol.identifier(),
+			LDAPSyncRunFinishedAtCol.identifier(),
+			LDAPSyncRunFullSyncCol.identifier(),
+			LDAPSyncRunCreatedCol.identifier(),
+			LDAPSyncRunUpdatedCol.identifier(),
+			LDAPSyncRunUnchangedCol.identifier(),
+			LDAPSyncRunDeactivatedCol.identifier(),
+			LDAPSyncRunReactivatedCol.identifier(),
+			LDAPSyncRunFailedCol.identifier(),
+			LDAPSyncRunErrorCol.identifier(),
+			countColumn.identifier(),
+		).
+			From(ldapSyncRunTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*LDAPSyncRuns, error) {
+			runs := make([]*LDAPSyncRun, 0)
+			var count uint64
+			for rows.Next() {
+				var (
+					run    = new(LDAPSyncRun)
+					runErr sql.NullString
+				)
+				err := rows.Scan(
+					&run.ID,
+					&run.IDPID,
+					&run.StartedAt,
+					&run.FinishedAt,
+					&run.FullSync,
+					&run.Stats.Created,
+					&run.Stats.Updated,
+					&run.Stats.Unchanged,
+					&run.Stats.Deactivated,
+					&run.Stats.Reactivated,
+					&run.Stats.Failed,
+					&runErr,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				run.Error = runErr.String
+				runs = append(runs, run)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Cq4hs", "Errors.Query.CloseRows")
+			}
+
+			return &LDAPSyncRuns{
+				Runs: runs,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
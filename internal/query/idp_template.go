@@ -35,6 +35,7 @@ type IDPTemplate struct {
 	IsAutoCreation    bool
 	IsAutoUpdate      bool
 	AutoLinking       domain.AutoLinkingOption
+	MappingRules      database.JSONArray[domain.IDPMappingRule]
 	*OAuthIDPTemplate
 	*OIDCIDPTemplate
 	*JWTIDPTemplate
@@ -47,6 +48,7 @@ type IDPTemplate struct {
 	*LDAPIDPTemplate
 	*AppleIDPTemplate
 	*SAMLIDPTemplate
+	*X509IDPTemplate
 }
 
 type IDPTemplates struct {
@@ -156,6 +158,18 @@ type AppleIDPTemplate struct {
 	Scopes     database.TextArray[string]
 }
 
+type X509IDPTemplate struct {
+	IDPID                   string
+	CertificateHeader       string
+	TrustedProxies          database.TextArray[string]
+	CACertificates          []byte
+	CRLs                    database.JSONArray[[]byte]
+	OCSPResponses           database.JSONArray[[]byte]
+	RevocationCheckRequired bool
+	IDField                 domain.X509CertificateField
+	UsernameField           domain.X509CertificateField
+}
+
 type SAMLIDPTemplate struct {
 	IDPID                         string
 	Metadata                      []byte
@@ -685,6 +699,72 @@ var (
 	}
 )
 
+var (
+	x509IdpTemplateTable = table{
+		name:          projection.IDPTemplateX509Table,
+		instanceIDCol: projection.X509InstanceIDCol,
+	}
+	X509IDCol = Column{
+		name:  projection.X509IDCol,
+		table: x509IdpTemplateTable,
+	}
+	X509InstanceIDCol = Column{
+		name:  projection.X509InstanceIDCol,
+		table: x509IdpTemplateTable,
+	}
+	X509CertificateHeaderCol = Column{
+		name:  projection.X509CertificateHeaderCol,
+		table: x509IdpTemplateTable,
+	}
+	X509TrustedProxiesCol = Column{
+		name:  projection.X509TrustedProxiesCol,
+		table: x509IdpTemplateTable,
+	}
+	X509CACertificatesCol = Column{
+		name:  projection.X509CACertificatesCol,
+		table: x509IdpTemplateTable,
+	}
+	X509CRLsCol = Column{
+		name:  projection.X509CRLsCol,
+		table: x509IdpTemplateTable,
+	}
+	X509OCSPResponsesCol = Column{
+		name:  projection.X509OCSPResponsesCol,
+		table: x509IdpTemplateTable,
+	}
+	X509RevocationCheckRequiredCol = Column{
+		name:  projection.X509RevocationCheckRequiredCol,
+		table: x509IdpTemplateTable,
+	}
+	X509IDFieldCol = Column{
+		name:  projection.X509IDFieldCol,
+		table: x509IdpTemplateTable,
+	}
+	X509UsernameFieldCol = Column{
+		name:  projection.X509UsernameFieldCol,
+		table: x509IdpTemplateTable,
+	}
+)
+
+var (
+	mappingIdpTemplateTable = table{
+		name:          projection.IDPTemplateMappingTable,
+		instanceIDCol: projection.MappingInstanceIDCol,
+	}
+	MappingIDCol = Column{
+		name:  projection.MappingIDCol,
+		table: mappingIdpTemplateTable,
+	}
+	MappingInstanceIDCol = Column{
+		name:  projection.MappingInstanceIDCol,
+		table: mappingIdpTemplateTable,
+	}
+	MappingRulesCol = Column{
+		name:  projection.MappingRulesCol,
+		table: mappingIdpTemplateTable,
+	}
+)
+
 var (
 	samlIdpTemplateTable = table{
 		name:          projection.IDPTemplateSAMLTable,
@@ -992,6 +1072,18 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			AppleKeyIDCol.identifier(),
 			ApplePrivateKeyCol.identifier(),
 			AppleScopesCol.identifier(),
+			// x509
+			X509IDCol.identifier(),
+			X509CertificateHeaderCol.identifier(),
+			X509TrustedProxiesCol.identifier(),
+			X509CACertificatesCol.identifier(),
+			X509CRLsCol.identifier(),
+			X509OCSPResponsesCol.identifier(),
+			X509RevocationCheckRequiredCol.identifier(),
+			X509IDFieldCol.identifier(),
+			X509UsernameFieldCol.identifier(),
+			// mapping
+			MappingRulesCol.identifier(),
 		).From(idpTemplateTable.identifier()).
 			LeftJoin(join(OAuthIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(OIDCIDCol, IDPTemplateIDCol)).
@@ -1005,6 +1097,8 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(AppleIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(X509IDCol, IDPTemplateIDCol)).
+			LeftJoin(join(MappingIDCol, IDPTemplateIDCol)).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*IDPTemplate, error) {
 			idpTemplate := new(IDPTemplate)
@@ -1114,6 +1208,16 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 			applePrivateKey := new(crypto.CryptoValue)
 			appleScopes := database.TextArray[string]{}
 
+			x509ID := sql.NullString{}
+			x509CertificateHeader := sql.NullString{}
+			x509TrustedProxies := database.TextArray[string]{}
+			var x509CACertificates []byte
+			x509CRLs := database.JSONArray[[]byte]{}
+			x509OCSPResponses := database.JSONArray[[]byte]{}
+			x509RevocationCheckRequired := sql.NullBool{}
+			x509IDField := sql.Null[domain.X509CertificateField]{}
+			x509UsernameField := sql.Null[domain.X509CertificateField]{}
+
 			err := row.Scan(
 				&idpTemplate.ID,
 				&idpTemplate.ResourceOwner,
@@ -1232,6 +1336,18 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 				&appleKeyID,
 				&applePrivateKey,
 				&appleScopes,
+				// x509
+				&x509ID,
+				&x509CertificateHeader,
+				&x509TrustedProxies,
+				&x509CACertificates,
+				&x509CRLs,
+				&x509OCSPResponses,
+				&x509RevocationCheckRequired,
+				&x509IDField,
+				&x509UsernameField,
+				// mapping
+				&idpTemplate.MappingRules,
 			)
 			if err != nil {
 				if errors.Is(err, sql.ErrNoRows) {
@@ -1383,6 +1499,19 @@ func prepareIDPTemplateByIDQuery() (sq.SelectBuilder, func(*sql.Row) (*IDPTempla
 					Scopes:     appleScopes,
 				}
 			}
+			if x509ID.Valid {
+				idpTemplate.X509IDPTemplate = &X509IDPTemplate{
+					IDPID:                   x509ID.String,
+					CertificateHeader:       x509CertificateHeader.String,
+					TrustedProxies:          x509TrustedProxies,
+					CACertificates:          x509CACertificates,
+					CRLs:                    x509CRLs,
+					OCSPResponses:           x509OCSPResponses,
+					RevocationCheckRequired: x509RevocationCheckRequired.Bool,
+					IDField:                 x509IDField.V,
+					UsernameField:           x509UsernameField.V,
+				}
+			}
 
 			return idpTemplate, nil
 		}
@@ -1508,6 +1637,18 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 			AppleKeyIDCol.identifier(),
 			ApplePrivateKeyCol.identifier(),
 			AppleScopesCol.identifier(),
+			// x509
+			X509IDCol.identifier(),
+			X509CertificateHeaderCol.identifier(),
+			X509TrustedProxiesCol.identifier(),
+			X509CACertificatesCol.identifier(),
+			X509CRLsCol.identifier(),
+			X509OCSPResponsesCol.identifier(),
+			X509RevocationCheckRequiredCol.identifier(),
+			X509IDFieldCol.identifier(),
+			X509UsernameFieldCol.identifier(),
+			// mapping
+			MappingRulesCol.identifier(),
 			// count
 			countColumn.identifier(),
 		).From(idpTemplateTable.identifier()).
@@ -1523,6 +1664,8 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 			LeftJoin(join(SAMLIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(LDAPIDCol, IDPTemplateIDCol)).
 			LeftJoin(join(AppleIDCol, IDPTemplateIDCol)).
+			LeftJoin(join(X509IDCol, IDPTemplateIDCol)).
+			LeftJoin(join(MappingIDCol, IDPTemplateIDCol)).
 			PlaceholderFormat(sq.Dollar),
 		func(rows *sql.Rows) (*IDPTemplates, error) {
 			templates := make([]*IDPTemplate, 0)
@@ -1635,6 +1778,16 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 				applePrivateKey := new(crypto.CryptoValue)
 				appleScopes := database.TextArray[string]{}
 
+				x509ID := sql.NullString{}
+				x509CertificateHeader := sql.NullString{}
+				x509TrustedProxies := database.TextArray[string]{}
+				var x509CACertificates []byte
+				x509CRLs := database.JSONArray[[]byte]{}
+				x509OCSPResponses := database.JSONArray[[]byte]{}
+				x509RevocationCheckRequired := sql.NullBool{}
+				x509IDField := sql.Null[domain.X509CertificateField]{}
+				x509UsernameField := sql.Null[domain.X509CertificateField]{}
+
 				err := rows.Scan(
 					&idpTemplate.ID,
 					&idpTemplate.ResourceOwner,
@@ -1753,6 +1906,18 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 					&appleKeyID,
 					&applePrivateKey,
 					&appleScopes,
+					// x509
+					&x509ID,
+					&x509CertificateHeader,
+					&x509TrustedProxies,
+					&x509CACertificates,
+					&x509CRLs,
+					&x509OCSPResponses,
+					&x509RevocationCheckRequired,
+					&x509IDField,
+					&x509UsernameField,
+					// mapping
+					&idpTemplate.MappingRules,
 					&count,
 				)
 
@@ -1903,6 +2068,19 @@ func prepareIDPTemplatesQuery() (sq.SelectBuilder, func(*sql.Rows) (*IDPTemplate
 						Scopes:     appleScopes,
 					}
 				}
+				if x509ID.Valid {
+					idpTemplate.X509IDPTemplate = &X509IDPTemplate{
+						IDPID:                   x509ID.String,
+						CertificateHeader:       x509CertificateHeader.String,
+						TrustedProxies:          x509TrustedProxies,
+						CACertificates:          x509CACertificates,
+						CRLs:                    x509CRLs,
+						OCSPResponses:           x509OCSPResponses,
+						RevocationCheckRequired: x509RevocationCheckRequired.Bool,
+						IDField:                 x509IDField.V,
+						UsernameField:           x509UsernameField.V,
+					}
+				}
 				templates = append(templates, idpTemplate)
 			}
 
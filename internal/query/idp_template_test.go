@@ -132,7 +132,19 @@ var (
 		` projections.idp_templates6_apple.team_id,` +
 		` projections.idp_templates6_apple.key_id,` +
 		` projections.idp_templates6_apple.private_key,` +
-		` projections.idp_templates6_apple.scopes` +
+		` projections.idp_templates6_apple.scopes,` +
+		// x509
+		` projections.idp_templates6_x509.idp_id,` +
+		` projections.idp_templates6_x509.certificate_header,` +
+		` projections.idp_templates6_x509.trusted_proxies,` +
+		` projections.idp_templates6_x509.ca_certificates,` +
+		` projections.idp_templates6_x509.crls,` +
+		` projections.idp_templates6_x509.ocsp_responses,` +
+		` projections.idp_templates6_x509.revocation_check_required,` +
+		` projections.idp_templates6_x509.id_field,` +
+		` projections.idp_templates6_x509.username_field,` +
+		// mapping
+		` projections.idp_templates6_mapping.rules` +
 		` FROM projections.idp_templates6` +
 		` LEFT JOIN projections.idp_templates6_oauth2 ON projections.idp_templates6.id = projections.idp_templates6_oauth2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oauth2.instance_id` +
 		` LEFT JOIN projections.idp_templates6_oidc ON projections.idp_templates6.id = projections.idp_templates6_oidc.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oidc.instance_id` +
@@ -145,7 +157,9 @@ var (
 		` LEFT JOIN projections.idp_templates6_google ON projections.idp_templates6.id = projections.idp_templates6_google.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_google.instance_id` +
 		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
 		` LEFT JOIN projections.idp_templates6_ldap2 ON projections.idp_templates6.id = projections.idp_templates6_ldap2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap2.instance_id` +
-		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id`
+		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id` +
+		` LEFT JOIN projections.idp_templates6_x509 ON projections.idp_templates6.id = projections.idp_templates6_x509.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_x509.instance_id` +
+		` LEFT JOIN projections.idp_templates6_mapping ON projections.idp_templates6.id = projections.idp_templates6_mapping.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_mapping.instance_id`
 	idpTemplateCols = []string{
 		"id",
 		"resource_owner",
@@ -264,6 +278,18 @@ var (
 		"key_id",
 		"private_key",
 		"scopes",
+		// x509 config
+		"idp_id",
+		"certificate_header",
+		"trusted_proxies",
+		"ca_certificates",
+		"crls",
+		"ocsp_responses",
+		"revocation_check_required",
+		"id_field",
+		"username_field",
+		// mapping config
+		"rules",
 	}
 	idpTemplatesQuery = `SELECT projections.idp_templates6.id,` +
 		` projections.idp_templates6.resource_owner,` +
@@ -382,6 +408,18 @@ var (
 		` projections.idp_templates6_apple.key_id,` +
 		` projections.idp_templates6_apple.private_key,` +
 		` projections.idp_templates6_apple.scopes,` +
+		// x509
+		` projections.idp_templates6_x509.idp_id,` +
+		` projections.idp_templates6_x509.certificate_header,` +
+		` projections.idp_templates6_x509.trusted_proxies,` +
+		` projections.idp_templates6_x509.ca_certificates,` +
+		` projections.idp_templates6_x509.crls,` +
+		` projections.idp_templates6_x509.ocsp_responses,` +
+		` projections.idp_templates6_x509.revocation_check_required,` +
+		` projections.idp_templates6_x509.id_field,` +
+		` projections.idp_templates6_x509.username_field,` +
+		// mapping
+		` projections.idp_templates6_mapping.rules,` +
 		` COUNT(*) OVER ()` +
 		` FROM projections.idp_templates6` +
 		` LEFT JOIN projections.idp_templates6_oauth2 ON projections.idp_templates6.id = projections.idp_templates6_oauth2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_oauth2.instance_id` +
@@ -395,7 +433,9 @@ var (
 		` LEFT JOIN projections.idp_templates6_google ON projections.idp_templates6.id = projections.idp_templates6_google.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_google.instance_id` +
 		` LEFT JOIN projections.idp_templates6_saml ON projections.idp_templates6.id = projections.idp_templates6_saml.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_saml.instance_id` +
 		` LEFT JOIN projections.idp_templates6_ldap2 ON projections.idp_templates6.id = projections.idp_templates6_ldap2.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_ldap2.instance_id` +
-		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id`
+		` LEFT JOIN projections.idp_templates6_apple ON projections.idp_templates6.id = projections.idp_templates6_apple.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_apple.instance_id` +
+		` LEFT JOIN projections.idp_templates6_x509 ON projections.idp_templates6.id = projections.idp_templates6_x509.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_x509.instance_id` +
+		` LEFT JOIN projections.idp_templates6_mapping ON projections.idp_templates6.id = projections.idp_templates6_mapping.idp_id AND projections.idp_templates6.instance_id = projections.idp_templates6_mapping.instance_id`
 	idpTemplatesCols = []string{
 		"id",
 		"resource_owner",
@@ -514,6 +554,18 @@ var (
 		"key_id",
 		"private_key",
 		"scopes",
+		// x509 config
+		"idp_id",
+		"certificate_header",
+		"trusted_proxies",
+		"ca_certificates",
+		"crls",
+		"ocsp_responses",
+		"revocation_check_required",
+		"id_field",
+		"username_field",
+		// mapping config
+		"rules",
 		"count",
 	}
 )
@@ -672,6 +724,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						[]byte(`[{"target":1,"language":1,"expression":"$.userinfo.given_name"}]`),
 					},
 				),
 			},
@@ -690,6 +754,13 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 				IsAutoCreation:    true,
 				IsAutoUpdate:      true,
 				AutoLinking:       domain.AutoLinkingOptionUsername,
+				MappingRules: database.JSONArray[domain.IDPMappingRule]{
+					{
+						Target:     domain.IDPMappingTargetFirstName,
+						Language:   domain.IDPMappingLanguageJSONPath,
+						Expression: "$.userinfo.given_name",
+					},
+				},
 				OAuthIDPTemplate: &OAuthIDPTemplate{
 					IDPID:                 "idp-id",
 					ClientID:              "client_id",
@@ -828,6 +899,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -982,6 +1065,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -1134,6 +1229,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -1285,6 +1392,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -1436,6 +1555,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -1588,6 +1719,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -1739,6 +1882,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -1896,6 +2051,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -2068,6 +2235,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						"key_id",
 						nil,
 						database.TextArray[string]{"profile"},
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -2096,6 +2275,173 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 				},
 			},
 		},
+		{
+			name:    "prepareIDPTemplateByIDQuery x509 idp",
+			prepare: prepareIDPTemplateByIDQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(idpTemplateQuery),
+					idpTemplateCols,
+					[]driver.Value{
+						"idp-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.IDPConfigStateActive,
+						"idp-name",
+						domain.IDPTypeX509,
+						domain.IdentityProviderTypeOrg,
+						true,
+						true,
+						true,
+						true,
+						domain.AutoLinkingOptionUsername,
+						// oauth
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// oidc
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// jwt
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// azure
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// github
+						nil,
+						nil,
+						nil,
+						nil,
+						// github enterprise
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab
+						nil,
+						nil,
+						nil,
+						nil,
+						// gitlab self hosted
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// google
+						nil,
+						nil,
+						nil,
+						nil,
+						// saml
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// ldap config
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// apple
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// x509
+						"idp-id",
+						"header",
+						database.TextArray[string]{"10.0.0.1"},
+						[]byte("ca"),
+						[]byte(`["Y3Js"]`),
+						nil,
+						true,
+						domain.X509CertificateFieldSANEmail,
+						domain.X509CertificateFieldSANUPN,
+						// mapping
+						nil,
+					},
+				),
+			},
+			object: &IDPTemplate{
+				CreationDate:      testNow,
+				ChangeDate:        testNow,
+				Sequence:          20211109,
+				ResourceOwner:     "ro",
+				ID:                "idp-id",
+				State:             domain.IDPStateActive,
+				Name:              "idp-name",
+				Type:              domain.IDPTypeX509,
+				OwnerType:         domain.IdentityProviderTypeOrg,
+				IsCreationAllowed: true,
+				IsLinkingAllowed:  true,
+				IsAutoCreation:    true,
+				IsAutoUpdate:      true,
+				AutoLinking:       domain.AutoLinkingOptionUsername,
+				X509IDPTemplate: &X509IDPTemplate{
+					IDPID:                   "idp-id",
+					CertificateHeader:       "header",
+					TrustedProxies:          database.TextArray[string]{"10.0.0.1"},
+					CACertificates:          []byte("ca"),
+					CRLs:                    database.JSONArray[[]byte]{[]byte("crl")},
+					RevocationCheckRequired: true,
+					IDField:                 domain.X509CertificateFieldSANEmail,
+					UsernameField:           domain.X509CertificateFieldSANUPN,
+				},
+			},
+		},
 		{
 			name:    "prepareIDPTemplateByIDQuery no config",
 			prepare: prepareIDPTemplateByIDQuery,
@@ -2221,6 +2567,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						// x509
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						// mapping
+						nil,
 					},
 				),
 			},
@@ -2402,6 +2760,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// x509
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// mapping
+							nil,
 						},
 					},
 				),
@@ -2583,6 +2953,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// x509
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// mapping
+							nil,
 						},
 					},
 				),
@@ -2737,6 +3119,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// x509
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// mapping
+							nil,
 						},
 						{
 							"idp-id-saml",
@@ -2856,6 +3250,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// x509
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// mapping
+							nil,
 						},
 						{
 							"idp-id-google",
@@ -2975,6 +3381,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// x509
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// mapping
+							nil,
 						},
 						{
 							"idp-id-oauth",
@@ -3094,6 +3512,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// x509
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// mapping
+							nil,
 						},
 						{
 							"idp-id-oidc",
@@ -3213,6 +3643,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// x509
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// mapping
+							nil,
 						},
 						{
 							"idp-id-jwt",
@@ -3332,6 +3774,18 @@ func Test_IDPTemplateTemplatesPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							// x509
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							// mapping
+							nil,
 						},
 					},
 				),
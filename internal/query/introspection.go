@@ -41,6 +41,10 @@ type IntrospectionClient struct {
 	ResourceOwner        string
 	ProjectRoleAssertion bool
 	PublicKeys           database.Map[[]byte]
+
+	// IntrospectionSignedResponse and IntrospectionEncryptionKey are only set for API applications.
+	IntrospectionSignedResponse bool
+	IntrospectionEncryptionKey  []byte
 }
 
 //go:embed introspection_client_by_id.sql
@@ -65,6 +69,8 @@ func (q *Queries) ActiveIntrospectionClientByID(ctx context.Context, clientID st
 			&client.ResourceOwner,
 			&client.ProjectRoleAssertion,
 			&client.PublicKeys,
+			&client.IntrospectionSignedResponse,
+			&client.IntrospectionEncryptionKey,
 		)
 	},
 		introspectionClientByIDQuery,
@@ -1,10 +1,12 @@
 with config as (
-		select instance_id, app_id, client_id, client_secret, 'api' as app_type
+		select instance_id, app_id, client_id, client_secret, 'api' as app_type,
+			introspection_signed_response, introspection_encryption_key
 		from projections.apps7_api_configs
 		where instance_id = $1
 			and client_id = $2
 	union all
-		select instance_id, app_id, client_id, client_secret, 'oidc' as app_type
+		select instance_id, app_id, client_id, client_secret, 'oidc' as app_type,
+			false as introspection_signed_response, null::bytea as introspection_encryption_key
 		from projections.apps7_oidc_configs
 		where instance_id = $1
 			and client_id = $2
@@ -19,7 +21,7 @@ keys as (
 )
 select c.app_id, c.client_id, c.client_secret, c.app_type, 
        a.project_id, a.resource_owner, p.project_role_assertion, 
-       k.public_keys
+       k.public_keys, c.introspection_signed_response, c.introspection_encryption_key
 from config c
 join projections.apps7 a on a.id = c.app_id and a.instance_id = c.instance_id and a.state = 1
 join projections.projects4 p on p.id = a.project_id and p.instance_id = c.instance_id and p.state = 1
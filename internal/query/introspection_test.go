@@ -50,8 +50,8 @@ func TestQueries_ActiveIntrospectionClientByID(t *testing.T) {
 				getKeys:  false,
 			},
 			mock: mockQuery(expQuery,
-				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys"},
-				[]driver.Value{"appID", "clientID", "secret", "oidc", "projectID", "orgID", true, nil},
+				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys", "introspection_signed_response", "introspection_encryption_key"},
+				[]driver.Value{"appID", "clientID", "secret", "oidc", "projectID", "orgID", true, nil, false, nil},
 				"instanceID", "clientID", false),
 			want: &IntrospectionClient{
 				AppID:                "appID",
@@ -71,8 +71,8 @@ func TestQueries_ActiveIntrospectionClientByID(t *testing.T) {
 				getKeys:  true,
 			},
 			mock: mockQuery(expQuery,
-				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys"},
-				[]driver.Value{"appID", "clientID", "", "oidc", "projectID", "orgID", true, encPubkeys},
+				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys", "introspection_signed_response", "introspection_encryption_key"},
+				[]driver.Value{"appID", "clientID", "", "oidc", "projectID", "orgID", true, encPubkeys, false, nil},
 				"instanceID", "clientID", true),
 			want: &IntrospectionClient{
 				AppID:                "appID",
@@ -85,6 +85,29 @@ func TestQueries_ActiveIntrospectionClientByID(t *testing.T) {
 				PublicKeys:           pubkeys,
 			},
 		},
+		{
+			name: "success, api introspection response",
+			args: args{
+				clientID: "clientID",
+				getKeys:  false,
+			},
+			mock: mockQuery(expQuery,
+				[]string{"app_id", "client_id", "client_secret", "app_type", "project_id", "resource_owner", "project_role_assertion", "public_keys", "introspection_signed_response", "introspection_encryption_key"},
+				[]driver.Value{"appID", "clientID", "secret", "api", "projectID", "orgID", false, nil, true, []byte("key")},
+				"instanceID", "clientID", false),
+			want: &IntrospectionClient{
+				AppID:                       "appID",
+				ClientID:                    "clientID",
+				HashedSecret:                "secret",
+				AppType:                     AppTypeAPI,
+				ProjectID:                   "projectID",
+				ResourceOwner:               "orgID",
+				ProjectRoleAssertion:        false,
+				PublicKeys:                  nil,
+				IntrospectionSignedResponse: true,
+				IntrospectionEncryptionKey:  []byte("key"),
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -19,32 +19,40 @@ import (
 )
 
 type OIDCClient struct {
-	InstanceID               string                     `json:"instance_id,omitempty"`
-	AppID                    string                     `json:"app_id,omitempty"`
-	State                    domain.AppState            `json:"state,omitempty"`
-	ClientID                 string                     `json:"client_id,omitempty"`
-	BackChannelLogoutURI     string                     `json:"back_channel_logout_uri,omitempty"`
-	HashedSecret             string                     `json:"client_secret,omitempty"`
-	RedirectURIs             []string                   `json:"redirect_uris,omitempty"`
-	ResponseTypes            []domain.OIDCResponseType  `json:"response_types,omitempty"`
-	GrantTypes               []domain.OIDCGrantType     `json:"grant_types,omitempty"`
-	ApplicationType          domain.OIDCApplicationType `json:"application_type,omitempty"`
-	AuthMethodType           domain.OIDCAuthMethodType  `json:"auth_method_type,omitempty"`
-	PostLogoutRedirectURIs   []string                   `json:"post_logout_redirect_uris,omitempty"`
-	IsDevMode                bool                       `json:"is_dev_mode,omitempty"`
-	AccessTokenType          domain.OIDCTokenType       `json:"access_token_type,omitempty"`
-	AccessTokenRoleAssertion bool                       `json:"access_token_role_assertion,omitempty"`
-	IDTokenRoleAssertion     bool                       `json:"id_token_role_assertion,omitempty"`
-	IDTokenUserinfoAssertion bool                       `json:"id_token_userinfo_assertion,omitempty"`
-	ClockSkew                time.Duration              `json:"clock_skew,omitempty"`
-	AdditionalOrigins        []string                   `json:"additional_origins,omitempty"`
-	PublicKeys               map[string][]byte          `json:"public_keys,omitempty"`
-	ProjectID                string                     `json:"project_id,omitempty"`
-	ProjectRoleAssertion     bool                       `json:"project_role_assertion,omitempty"`
-	LoginVersion             domain.LoginVersion        `json:"login_version,omitempty"`
-	LoginBaseURI             *URL                       `json:"login_base_uri,omitempty"`
-	ProjectRoleKeys          []string                   `json:"project_role_keys,omitempty"`
-	Settings                 *OIDCSettings              `json:"settings,omitempty"`
+	InstanceID                  string                          `json:"instance_id,omitempty"`
+	AppID                       string                          `json:"app_id,omitempty"`
+	State                       domain.AppState                 `json:"state,omitempty"`
+	ClientID                    string                          `json:"client_id,omitempty"`
+	BackChannelLogoutURI        string                          `json:"back_channel_logout_uri,omitempty"`
+	FrontChannelLogoutURI       string                          `json:"front_channel_logout_uri,omitempty"`
+	HashedSecret                string                          `json:"client_secret,omitempty"`
+	RedirectURIs                []string                        `json:"redirect_uris,omitempty"`
+	ResponseTypes               []domain.OIDCResponseType       `json:"response_types,omitempty"`
+	GrantTypes                  []domain.OIDCGrantType          `json:"grant_types,omitempty"`
+	ApplicationType             domain.OIDCApplicationType      `json:"application_type,omitempty"`
+	AuthMethodType              domain.OIDCAuthMethodType       `json:"auth_method_type,omitempty"`
+	PostLogoutRedirectURIs      []string                        `json:"post_logout_redirect_uris,omitempty"`
+	IsDevMode                   bool                            `json:"is_dev_mode,omitempty"`
+	AccessTokenType             domain.OIDCTokenType            `json:"access_token_type,omitempty"`
+	AccessTokenRoleAssertion    bool                            `json:"access_token_role_assertion,omitempty"`
+	IDTokenRoleAssertion        bool                            `json:"id_token_role_assertion,omitempty"`
+	IDTokenUserinfoAssertion    bool                            `json:"id_token_userinfo_assertion,omitempty"`
+	ClockSkew                   time.Duration                   `json:"clock_skew,omitempty"`
+	AdditionalOrigins           []string                        `json:"additional_origins,omitempty"`
+	PublicKeys                  map[string][]byte               `json:"public_keys,omitempty"`
+	ProjectID                   string                          `json:"project_id,omitempty"`
+	ProjectRoleAssertion        bool                            `json:"project_role_assertion,omitempty"`
+	LoginVersion                domain.LoginVersion             `json:"login_version,omitempty"`
+	LoginBaseURI                *URL                            `json:"login_base_uri,omitempty"`
+	RefreshTokenRotation        domain.OIDCRefreshTokenRotation `json:"refresh_token_rotation,omitempty"`
+	RefreshTokenLifetime        time.Duration                   `json:"refresh_token_lifetime,omitempty"`
+	RefreshTokenIdleLifetime    time.Duration                   `json:"refresh_token_idle_lifetime,omitempty"`
+	IDTokenEncryptedResponseAlg string                          `json:"id_token_encrypted_response_alg,omitempty"`
+	IDTokenEncryptedResponseEnc string                          `json:"id_token_encrypted_response_enc,omitempty"`
+	JWKS                        string                          `json:"jwks,omitempty"`
+	JWKSURI                     string                          `json:"jwks_uri,omitempty"`
+	ProjectRoleKeys             []string                        `json:"project_role_keys,omitempty"`
+	Settings                    *OIDCSettings                   `json:"settings,omitempty"`
 }
 
 type URL url.URL
@@ -1,10 +1,11 @@
 with client as (
 	select c.instance_id,
-		c.app_id, a.state, c.client_id, c.back_channel_logout_uri, c.client_secret, c.redirect_uris, c.response_types,
+		c.app_id, a.state, c.client_id, c.back_channel_logout_uri, c.front_channel_logout_uri, c.client_secret, c.redirect_uris, c.response_types,
 		c.grant_types, c.application_type, c.auth_method_type, c.post_logout_redirect_uris, c.is_dev_mode,
 		c.access_token_type, c.access_token_role_assertion, c.id_token_role_assertion,
 		c.id_token_userinfo_assertion, c.clock_skew, c.additional_origins, a.project_id, p.project_role_assertion,
-		c.login_version, c.login_base_uri
+		c.login_version, c.login_base_uri, c.refresh_token_rotation, c.refresh_token_lifetime, c.refresh_token_idle_lifetime,
+		c.id_token_encrypted_response_alg, c.id_token_encrypted_response_enc, c.jwks, c.jwks_uri
 	from projections.apps7_oidc_configs c
 	join projections.apps7 a on a.id = c.app_id and a.instance_id = c.instance_id and a.state = 1
 	join projections.projects4 p on p.id = a.project_id and p.instance_id = a.instance_id and p.state = 1
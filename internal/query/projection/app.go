@@ -20,6 +20,8 @@ const (
 	AppAPITable        = AppProjectionTable + "_" + appAPITableSuffix
 	AppOIDCTable       = AppProjectionTable + "_" + appOIDCTableSuffix
 	AppSAMLTable       = AppProjectionTable + "_" + appSAMLTableSuffix
+	AppWSFedTable      = AppProjectionTable + "_" + appWSFedTableSuffix
+	AppCASTable        = AppProjectionTable + "_" + appCASTableSuffix
 
 	AppColumnID            = "id"
 	AppColumnName          = "name"
@@ -37,39 +39,69 @@ const (
 	AppAPIConfigColumnClientID     = "client_id"
 	AppAPIConfigColumnClientSecret = "client_secret"
 	AppAPIConfigColumnAuthMethod   = "auth_method"
+	AppAPIConfigColumnResourceURI  = "resource_uri"
 
-	appOIDCTableSuffix                          = "oidc_configs"
-	AppOIDCConfigColumnAppID                    = "app_id"
-	AppOIDCConfigColumnInstanceID               = "instance_id"
-	AppOIDCConfigColumnVersion                  = "version"
-	AppOIDCConfigColumnClientID                 = "client_id"
-	AppOIDCConfigColumnClientSecret             = "client_secret"
-	AppOIDCConfigColumnRedirectUris             = "redirect_uris"
-	AppOIDCConfigColumnResponseTypes            = "response_types"
-	AppOIDCConfigColumnGrantTypes               = "grant_types"
-	AppOIDCConfigColumnApplicationType          = "application_type"
-	AppOIDCConfigColumnAuthMethodType           = "auth_method_type"
-	AppOIDCConfigColumnPostLogoutRedirectUris   = "post_logout_redirect_uris"
-	AppOIDCConfigColumnDevMode                  = "is_dev_mode"
-	AppOIDCConfigColumnAccessTokenType          = "access_token_type"
-	AppOIDCConfigColumnAccessTokenRoleAssertion = "access_token_role_assertion"
-	AppOIDCConfigColumnIDTokenRoleAssertion     = "id_token_role_assertion"
-	AppOIDCConfigColumnIDTokenUserinfoAssertion = "id_token_userinfo_assertion"
-	AppOIDCConfigColumnClockSkew                = "clock_skew"
-	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
-	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
-	AppOIDCConfigColumnBackChannelLogoutURI     = "back_channel_logout_uri"
-	AppOIDCConfigColumnLoginVersion             = "login_version"
-	AppOIDCConfigColumnLoginBaseURI             = "login_base_uri"
-
-	appSAMLTableSuffix              = "saml_configs"
-	AppSAMLConfigColumnAppID        = "app_id"
-	AppSAMLConfigColumnInstanceID   = "instance_id"
-	AppSAMLConfigColumnEntityID     = "entity_id"
-	AppSAMLConfigColumnMetadata     = "metadata"
-	AppSAMLConfigColumnMetadataURL  = "metadata_url"
-	AppSAMLConfigColumnLoginVersion = "login_version"
-	AppSAMLConfigColumnLoginBaseURI = "login_base_uri"
+	AppAPIConfigColumnIntrospectionSignedResponse = "introspection_signed_response"
+	AppAPIConfigColumnIntrospectionEncryptionKey  = "introspection_encryption_key"
+
+	appOIDCTableSuffix                              = "oidc_configs"
+	AppOIDCConfigColumnAppID                        = "app_id"
+	AppOIDCConfigColumnInstanceID                   = "instance_id"
+	AppOIDCConfigColumnVersion                      = "version"
+	AppOIDCConfigColumnClientID                     = "client_id"
+	AppOIDCConfigColumnClientSecret                 = "client_secret"
+	AppOIDCConfigColumnRedirectUris                 = "redirect_uris"
+	AppOIDCConfigColumnResponseTypes                = "response_types"
+	AppOIDCConfigColumnGrantTypes                   = "grant_types"
+	AppOIDCConfigColumnApplicationType              = "application_type"
+	AppOIDCConfigColumnAuthMethodType               = "auth_method_type"
+	AppOIDCConfigColumnPostLogoutRedirectUris       = "post_logout_redirect_uris"
+	AppOIDCConfigColumnDevMode                      = "is_dev_mode"
+	AppOIDCConfigColumnAccessTokenType              = "access_token_type"
+	AppOIDCConfigColumnAccessTokenRoleAssertion     = "access_token_role_assertion"
+	AppOIDCConfigColumnIDTokenRoleAssertion         = "id_token_role_assertion"
+	AppOIDCConfigColumnIDTokenUserinfoAssertion     = "id_token_userinfo_assertion"
+	AppOIDCConfigColumnClockSkew                    = "clock_skew"
+	AppOIDCConfigColumnAdditionalOrigins            = "additional_origins"
+	AppOIDCConfigColumnSkipNativeAppSuccessPage     = "skip_native_app_success_page"
+	AppOIDCConfigColumnBackChannelLogoutURI         = "back_channel_logout_uri"
+	AppOIDCConfigColumnLoginVersion                 = "login_version"
+	AppOIDCConfigColumnLoginBaseURI                 = "login_base_uri"
+	AppOIDCConfigColumnRefreshTokenRotation         = "refresh_token_rotation"
+	AppOIDCConfigColumnRefreshTokenLifetime         = "refresh_token_lifetime"
+	AppOIDCConfigColumnRefreshTokenIdleLifetime     = "refresh_token_idle_lifetime"
+	AppOIDCConfigColumnIDTokenEncryptedResponseAlg  = "id_token_encrypted_response_alg"
+	AppOIDCConfigColumnIDTokenEncryptedResponseEnc  = "id_token_encrypted_response_enc"
+	AppOIDCConfigColumnUserinfoEncryptedResponseAlg = "userinfo_encrypted_response_alg"
+	AppOIDCConfigColumnUserinfoEncryptedResponseEnc = "userinfo_encrypted_response_enc"
+	AppOIDCConfigColumnJWKS                         = "jwks"
+	AppOIDCConfigColumnJWKSURI                      = "jwks_uri"
+	AppOIDCConfigColumnFrontChannelLogoutURI        = "front_channel_logout_uri"
+
+	appSAMLTableSuffix                   = "saml_configs"
+	AppSAMLConfigColumnAppID             = "app_id"
+	AppSAMLConfigColumnInstanceID        = "instance_id"
+	AppSAMLConfigColumnEntityID          = "entity_id"
+	AppSAMLConfigColumnMetadata          = "metadata"
+	AppSAMLConfigColumnMetadataURL       = "metadata_url"
+	AppSAMLConfigColumnLoginVersion      = "login_version"
+	AppSAMLConfigColumnLoginBaseURI      = "login_base_uri"
+	AppSAMLConfigColumnNameIDSource      = "name_id_source"
+	AppSAMLConfigColumnAttributeMappings = "attribute_mappings"
+
+	appWSFedTableSuffix            = "wsfed_configs"
+	AppWSFedConfigColumnAppID      = "app_id"
+	AppWSFedConfigColumnInstanceID = "instance_id"
+	AppWSFedConfigColumnRealm      = "realm"
+	AppWSFedConfigColumnReplyURLs  = "reply_urls"
+	AppWSFedConfigColumnTokenType  = "token_type"
+
+	appCASTableSuffix              = "cas_configs"
+	AppCASConfigColumnAppID        = "app_id"
+	AppCASConfigColumnInstanceID   = "instance_id"
+	AppCASConfigColumnServiceURLs  = "service_urls"
+	AppCASConfigColumnLoginVersion = "login_version"
+	AppCASConfigColumnLoginBaseURI = "login_base_uri"
 )
 
 type appProjection struct{}
@@ -104,6 +136,9 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppAPIConfigColumnClientID, handler.ColumnTypeText),
 			handler.NewColumn(AppAPIConfigColumnClientSecret, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(AppAPIConfigColumnAuthMethod, handler.ColumnTypeEnum),
+			handler.NewColumn(AppAPIConfigColumnResourceURI, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppAPIConfigColumnIntrospectionSignedResponse, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppAPIConfigColumnIntrospectionEncryptionKey, handler.ColumnTypeBytes, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(AppAPIConfigColumnInstanceID, AppAPIConfigColumnAppID),
 			appAPITableSuffix,
@@ -133,6 +168,16 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppOIDCConfigColumnBackChannelLogoutURI, handler.ColumnTypeText, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnLoginVersion, handler.ColumnTypeEnum, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnLoginBaseURI, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AppOIDCConfigColumnRefreshTokenRotation, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(AppOIDCConfigColumnRefreshTokenLifetime, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(AppOIDCConfigColumnRefreshTokenIdleLifetime, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(AppOIDCConfigColumnIDTokenEncryptedResponseAlg, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppOIDCConfigColumnIDTokenEncryptedResponseEnc, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppOIDCConfigColumnUserinfoEncryptedResponseAlg, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppOIDCConfigColumnUserinfoEncryptedResponseEnc, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppOIDCConfigColumnJWKS, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppOIDCConfigColumnJWKSURI, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(AppOIDCConfigColumnFrontChannelLogoutURI, handler.ColumnTypeText, handler.Default("")),
 		},
 			handler.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -147,12 +192,37 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppSAMLConfigColumnMetadataURL, handler.ColumnTypeText),
 			handler.NewColumn(AppSAMLConfigColumnLoginVersion, handler.ColumnTypeEnum, handler.Nullable()),
 			handler.NewColumn(AppSAMLConfigColumnLoginBaseURI, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AppSAMLConfigColumnNameIDSource, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(AppSAMLConfigColumnAttributeMappings, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(AppSAMLConfigColumnInstanceID, AppSAMLConfigColumnAppID),
 			appSAMLTableSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 			handler.WithIndex(handler.NewIndex("entity_id", []string{AppSAMLConfigColumnEntityID})),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(AppWSFedConfigColumnAppID, handler.ColumnTypeText),
+			handler.NewColumn(AppWSFedConfigColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(AppWSFedConfigColumnRealm, handler.ColumnTypeText),
+			handler.NewColumn(AppWSFedConfigColumnReplyURLs, handler.ColumnTypeTextArray),
+			handler.NewColumn(AppWSFedConfigColumnTokenType, handler.ColumnTypeEnum, handler.Default(0)),
+		},
+			handler.NewPrimaryKey(AppWSFedConfigColumnInstanceID, AppWSFedConfigColumnAppID),
+			appWSFedTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+			handler.WithIndex(handler.NewIndex("realm", []string{AppWSFedConfigColumnRealm})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(AppCASConfigColumnAppID, handler.ColumnTypeText),
+			handler.NewColumn(AppCASConfigColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(AppCASConfigColumnServiceURLs, handler.ColumnTypeTextArray),
+			handler.NewColumn(AppCASConfigColumnLoginVersion, handler.ColumnTypeEnum, handler.Nullable()),
+			handler.NewColumn(AppCASConfigColumnLoginBaseURI, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(AppCASConfigColumnInstanceID, AppCASConfigColumnAppID),
+			appCASTableSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 	)
 }
 
@@ -225,6 +295,22 @@ func (p *appProjection) Reducers() []handler.AggregateReducer {
 					Event:  project.SAMLConfigChangedType,
 					Reduce: p.reduceSAMLConfigChanged,
 				},
+				{
+					Event:  project.WSFedConfigAddedType,
+					Reduce: p.reduceWSFedConfigAdded,
+				},
+				{
+					Event:  project.WSFedConfigChangedType,
+					Reduce: p.reduceWSFedConfigChanged,
+				},
+				{
+					Event:  project.CASConfigAddedType,
+					Reduce: p.reduceCASConfigAdded,
+				},
+				{
+					Event:  project.CASConfigChangedType,
+					Reduce: p.reduceCASConfigChanged,
+				},
 			},
 		},
 		{
@@ -371,6 +457,7 @@ func (p *appProjection) reduceAPIConfigAdded(event eventstore.Event) (*handler.S
 				handler.NewCol(AppAPIConfigColumnClientID, e.ClientID),
 				handler.NewCol(AppAPIConfigColumnClientSecret, crypto.SecretOrEncodedHash(e.ClientSecret, e.HashedSecret)),
 				handler.NewCol(AppAPIConfigColumnAuthMethod, e.AuthMethodType),
+				handler.NewCol(AppAPIConfigColumnResourceURI, e.ResourceURI),
 			},
 			handler.WithTableSuffix(appAPITableSuffix),
 		),
@@ -392,10 +479,19 @@ func (p *appProjection) reduceAPIConfigChanged(event eventstore.Event) (*handler
 	if !ok {
 		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-vnZKi", "reduce.wrong.event.type %s", project.APIConfigChangedType)
 	}
-	cols := make([]handler.Column, 0, 2)
+	cols := make([]handler.Column, 0, 4)
 	if e.AuthMethodType != nil {
 		cols = append(cols, handler.NewCol(AppAPIConfigColumnAuthMethod, *e.AuthMethodType))
 	}
+	if e.ResourceURI != nil {
+		cols = append(cols, handler.NewCol(AppAPIConfigColumnResourceURI, *e.ResourceURI))
+	}
+	if e.IntrospectionSignedResponse != nil {
+		cols = append(cols, handler.NewCol(AppAPIConfigColumnIntrospectionSignedResponse, *e.IntrospectionSignedResponse))
+	}
+	if e.IntrospectionEncryptionKey != nil {
+		cols = append(cols, handler.NewCol(AppAPIConfigColumnIntrospectionEncryptionKey, *e.IntrospectionEncryptionKey))
+	}
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
 	}
@@ -513,6 +609,16 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, e.BackChannelLogoutURI),
 				handler.NewCol(AppOIDCConfigColumnLoginVersion, e.LoginVersion),
 				handler.NewCol(AppOIDCConfigColumnLoginBaseURI, e.LoginBaseURI),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenRotation, e.RefreshTokenRotation),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenLifetime, e.RefreshTokenLifetime),
+				handler.NewCol(AppOIDCConfigColumnRefreshTokenIdleLifetime, e.RefreshTokenIdleLifetime),
+				handler.NewCol(AppOIDCConfigColumnIDTokenEncryptedResponseAlg, e.IDTokenEncryptedResponseAlg),
+				handler.NewCol(AppOIDCConfigColumnIDTokenEncryptedResponseEnc, e.IDTokenEncryptedResponseEnc),
+				handler.NewCol(AppOIDCConfigColumnUserinfoEncryptedResponseAlg, e.UserinfoEncryptedResponseAlg),
+				handler.NewCol(AppOIDCConfigColumnUserinfoEncryptedResponseEnc, e.UserinfoEncryptedResponseEnc),
+				handler.NewCol(AppOIDCConfigColumnJWKS, e.JWKS),
+				handler.NewCol(AppOIDCConfigColumnJWKSURI, e.JWKSURI),
+				handler.NewCol(AppOIDCConfigColumnFrontChannelLogoutURI, e.FrontChannelLogoutURI),
 			},
 			handler.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -590,6 +696,36 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.LoginBaseURI != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnLoginBaseURI, *e.LoginBaseURI))
 	}
+	if e.RefreshTokenRotation != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenRotation, *e.RefreshTokenRotation))
+	}
+	if e.RefreshTokenLifetime != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenLifetime, *e.RefreshTokenLifetime))
+	}
+	if e.RefreshTokenIdleLifetime != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRefreshTokenIdleLifetime, *e.RefreshTokenIdleLifetime))
+	}
+	if e.IDTokenEncryptedResponseAlg != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnIDTokenEncryptedResponseAlg, *e.IDTokenEncryptedResponseAlg))
+	}
+	if e.IDTokenEncryptedResponseEnc != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnIDTokenEncryptedResponseEnc, *e.IDTokenEncryptedResponseEnc))
+	}
+	if e.UserinfoEncryptedResponseAlg != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnUserinfoEncryptedResponseAlg, *e.UserinfoEncryptedResponseAlg))
+	}
+	if e.UserinfoEncryptedResponseEnc != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnUserinfoEncryptedResponseEnc, *e.UserinfoEncryptedResponseEnc))
+	}
+	if e.JWKS != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnJWKS, *e.JWKS))
+	}
+	if e.JWKSURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnJWKSURI, *e.JWKSURI))
+	}
+	if e.FrontChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnFrontChannelLogoutURI, *e.FrontChannelLogoutURI))
+	}
 
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
@@ -709,6 +845,8 @@ func (p *appProjection) reduceSAMLConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppSAMLConfigColumnMetadataURL, e.MetadataURL),
 				handler.NewCol(AppSAMLConfigColumnLoginVersion, e.LoginVersion),
 				handler.NewCol(AppSAMLConfigColumnLoginBaseURI, e.LoginBaseURI),
+				handler.NewCol(AppSAMLConfigColumnNameIDSource, e.NameIDSource),
+				handler.NewCol(AppSAMLConfigColumnAttributeMappings, database.NewJSONArray(e.AttributeMappings)),
 			},
 			handler.WithTableSuffix(appSAMLTableSuffix),
 		),
@@ -747,6 +885,12 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 	if e.LoginBaseURI != nil {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnLoginBaseURI, *e.LoginBaseURI))
 	}
+	if e.NameIDSource != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnNameIDSource, *e.NameIDSource))
+	}
+	if e.AttributeMappings != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnAttributeMappings, database.NewJSONArray(*e.AttributeMappings)))
+	}
 
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
@@ -774,3 +918,151 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 		),
 	), nil
 }
+
+func (p *appProjection) reduceWSFedConfigAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.WSFedConfigAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "HANDL-ieB4a", "reduce.wrong.event.type")
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(AppWSFedConfigColumnAppID, e.AppID),
+				handler.NewCol(AppWSFedConfigColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(AppWSFedConfigColumnRealm, e.Realm),
+				handler.NewCol(AppWSFedConfigColumnReplyURLs, database.TextArray[string](e.ReplyURLs)),
+				handler.NewCol(AppWSFedConfigColumnTokenType, e.TokenType),
+			},
+			handler.WithTableSuffix(appWSFedTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(AppColumnChangeDate, e.CreationDate()),
+				handler.NewCol(AppColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(AppColumnID, e.AppID),
+				handler.NewCond(AppColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
+func (p *appProjection) reduceWSFedConfigChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.WSFedConfigChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "HANDL-Oow6e", "reduce.wrong.event.type")
+	}
+
+	cols := make([]handler.Column, 0, 3)
+	if e.Realm != "" {
+		cols = append(cols, handler.NewCol(AppWSFedConfigColumnRealm, e.Realm))
+	}
+	if e.ReplyURLs != nil {
+		cols = append(cols, handler.NewCol(AppWSFedConfigColumnReplyURLs, database.TextArray[string](*e.ReplyURLs)))
+	}
+	if e.TokenType != nil {
+		cols = append(cols, handler.NewCol(AppWSFedConfigColumnTokenType, *e.TokenType))
+	}
+
+	if len(cols) == 0 {
+		return handler.NewNoOpStatement(e), nil
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			cols,
+			[]handler.Condition{
+				handler.NewCond(AppWSFedConfigColumnAppID, e.AppID),
+				handler.NewCond(AppWSFedConfigColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(appWSFedTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(AppColumnChangeDate, e.CreationDate()),
+				handler.NewCol(AppColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(AppColumnID, e.AppID),
+				handler.NewCond(AppColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
+func (p *appProjection) reduceCASConfigAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.CASConfigAddedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "HANDL-Ahx3o", "reduce.wrong.event.type")
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(AppCASConfigColumnAppID, e.AppID),
+				handler.NewCol(AppCASConfigColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(AppCASConfigColumnServiceURLs, database.TextArray[string](e.ServiceURLs)),
+				handler.NewCol(AppCASConfigColumnLoginVersion, e.LoginVersion),
+				handler.NewCol(AppCASConfigColumnLoginBaseURI, e.LoginBaseURI),
+			},
+			handler.WithTableSuffix(appCASTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(AppColumnChangeDate, e.CreationDate()),
+				handler.NewCol(AppColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(AppColumnID, e.AppID),
+				handler.NewCond(AppColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
+
+func (p *appProjection) reduceCASConfigChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*project.CASConfigChangedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgument(nil, "HANDL-ca7Ie", "reduce.wrong.event.type")
+	}
+
+	cols := make([]handler.Column, 0, 3)
+	if e.ServiceURLs != nil {
+		cols = append(cols, handler.NewCol(AppCASConfigColumnServiceURLs, database.TextArray[string](*e.ServiceURLs)))
+	}
+	if e.LoginVersion != nil {
+		cols = append(cols, handler.NewCol(AppCASConfigColumnLoginVersion, *e.LoginVersion))
+	}
+	if e.LoginBaseURI != nil {
+		cols = append(cols, handler.NewCol(AppCASConfigColumnLoginBaseURI, *e.LoginBaseURI))
+	}
+
+	if len(cols) == 0 {
+		return handler.NewNoOpStatement(e), nil
+	}
+
+	return handler.NewMultiStatement(
+		e,
+		handler.AddUpdateStatement(
+			cols,
+			[]handler.Condition{
+				handler.NewCond(AppCASConfigColumnAppID, e.AppID),
+				handler.NewCond(AppCASConfigColumnInstanceID, e.Aggregate().InstanceID),
+			},
+			handler.WithTableSuffix(appCASTableSuffix),
+		),
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(AppColumnChangeDate, e.CreationDate()),
+				handler.NewCol(AppColumnSequence, e.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(AppColumnID, e.AppID),
+				handler.NewCond(AppColumnInstanceID, e.Aggregate().InstanceID),
+			},
+		),
+	), nil
+}
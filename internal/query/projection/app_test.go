@@ -285,13 +285,14 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.apps7_api_configs (app_id, instance_id, client_id, client_secret, auth_method) VALUES ($1, $2, $3, $4, $5)",
+							expectedStmt: "INSERT INTO projections.apps7_api_configs (app_id, instance_id, client_id, client_secret, auth_method, resource_uri) VALUES ($1, $2, $3, $4, $5, $6)",
 							expectedArgs: []interface{}{
 								"app-id",
 								"instance-id",
 								"client-id",
 								"secret",
 								domain.APIAuthMethodTypePrivateKeyJWT,
+								"",
 							},
 						},
 						{
@@ -318,7 +319,8 @@ func TestAppProjection_reduces(t *testing.T) {
 		            "appId": "app-id",
 					"clientId": "client-id",
 					"hashedSecret": "secret",
-				    "authMethodType": 1
+				    "authMethodType": 1,
+				    "resourceUri": "https://api.example.com/orders"
 				}`),
 					), project.APIConfigAddedEventMapper),
 			},
@@ -329,13 +331,14 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.apps7_api_configs (app_id, instance_id, client_id, client_secret, auth_method) VALUES ($1, $2, $3, $4, $5)",
+							expectedStmt: "INSERT INTO projections.apps7_api_configs (app_id, instance_id, client_id, client_secret, auth_method, resource_uri) VALUES ($1, $2, $3, $4, $5, $6)",
 							expectedArgs: []interface{}{
 								"app-id",
 								"instance-id",
 								"client-id",
 								"secret",
 								domain.APIAuthMethodTypePrivateKeyJWT,
+								"https://api.example.com/orders",
 							},
 						},
 						{
@@ -361,7 +364,8 @@ func TestAppProjection_reduces(t *testing.T) {
 						[]byte(`{
 		            "appId": "app-id",
 					"clientId": "client-id",
-				    "authMethodType": 1
+				    "authMethodType": 1,
+				    "resourceUri": "https://api.example.com/orders"
 				}`),
 					), project.APIConfigChangedEventMapper),
 			},
@@ -372,9 +376,52 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.apps7_api_configs SET auth_method = $1 WHERE (app_id = $2) AND (instance_id = $3)",
+							expectedStmt: "UPDATE projections.apps7_api_configs SET (auth_method, resource_uri) = ($1, $2) WHERE (app_id = $3) AND (instance_id = $4)",
 							expectedArgs: []interface{}{
 								domain.APIAuthMethodTypePrivateKeyJWT,
+								"https://api.example.com/orders",
+								"app-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.apps7 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceAPIConfigChanged introspection",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.APIConfigChangedType,
+						project.AggregateType,
+						[]byte(`{
+		            "appId": "app-id",
+				    "introspectionSignedResponse": true,
+				    "introspectionEncryptionKey": "a2V5"
+				}`),
+					), project.APIConfigChangedEventMapper),
+			},
+			reduce: (&appProjection{}).reduceAPIConfigChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.apps7_api_configs SET (introspection_signed_response, introspection_encryption_key) = ($1, $2) WHERE (app_id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								true,
+								[]byte("key"),
 								"app-id",
 								"instance-id",
 							},
@@ -572,7 +619,7 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.apps7_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, login_base_uri) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)",
+							expectedStmt: "INSERT INTO projections.apps7_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, login_base_uri, refresh_token_rotation, refresh_token_lifetime, refresh_token_idle_lifetime, id_token_encrypted_response_alg, id_token_encrypted_response_enc, userinfo_encrypted_response_alg, userinfo_encrypted_response_enc, jwks, jwks_uri, front_channel_logout_uri) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32)",
 							expectedArgs: []interface{}{
 								"app-id",
 								"instance-id",
@@ -596,6 +643,16 @@ func TestAppProjection_reduces(t *testing.T) {
 								"back.channel.one.ch",
 								domain.LoginVersion2,
 								"https://login.ch/",
+								domain.OIDCRefreshTokenRotationUnspecified,
+								time.Duration(0),
+								time.Duration(0),
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
+								"",
 							},
 						},
 						{
@@ -639,7 +696,15 @@ func TestAppProjection_reduces(t *testing.T) {
 						"skipNativeAppSuccessPage": true,
 						"backChannelLogoutURI": "back.channel.one.ch",
 						"loginVersion": 2,
-						"loginBaseURI": "https://login.ch/"
+						"loginBaseURI": "https://login.ch/",
+						"refreshTokenRotation": 1,
+						"refreshTokenLifetime": 2000,
+						"refreshTokenIdleLifetime": 1000,
+						"idTokenEncryptedResponseAlg": "RSA-OAEP-256",
+						"userinfoEncryptedResponseAlg": "ECDH-ES+A256KW",
+						"userinfoEncryptedResponseEnc": "A256GCM",
+						"jwksUri": "https://client.ch/jwks",
+						"frontChannelLogoutURI": "front.channel.one.ch"
 		}`),
 					), project.OIDCConfigAddedEventMapper),
 			},
@@ -650,7 +715,7 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.apps7_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, login_base_uri) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)",
+							expectedStmt: "INSERT INTO projections.apps7_oidc_configs (app_id, instance_id, version, client_id, client_secret, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, login_base_uri, refresh_token_rotation, refresh_token_lifetime, refresh_token_idle_lifetime, id_token_encrypted_response_alg, id_token_encrypted_response_enc, userinfo_encrypted_response_alg, userinfo_encrypted_response_enc, jwks, jwks_uri, front_channel_logout_uri) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29, $30, $31, $32)",
 							expectedArgs: []interface{}{
 								"app-id",
 								"instance-id",
@@ -674,6 +739,16 @@ func TestAppProjection_reduces(t *testing.T) {
 								"back.channel.one.ch",
 								domain.LoginVersion2,
 								"https://login.ch/",
+								domain.OIDCRefreshTokenRotationOnEveryUse,
+								2 * time.Microsecond,
+								1 * time.Microsecond,
+								"RSA-OAEP-256",
+								"",
+								"ECDH-ES+A256KW",
+								"A256GCM",
+								"",
+								"https://client.ch/jwks",
+								"front.channel.one.ch",
 							},
 						},
 						{
@@ -714,7 +789,14 @@ func TestAppProjection_reduces(t *testing.T) {
                         "additionalOrigins": ["origin.one.ch", "origin.two.ch"],
 						"skipNativeAppSuccessPage": true,
 						"backChannelLogoutURI": "back.channel.one.ch",
-						"loginVersion": 2
+						"loginVersion": 2,
+						"refreshTokenRotation": 2,
+						"refreshTokenLifetime": 2000,
+						"refreshTokenIdleLifetime": 1000,
+						"idTokenEncryptedResponseAlg": "RSA-OAEP",
+						"jwks": "{\"keys\":[]}",
+						"jwksUri": "",
+						"frontChannelLogoutURI": "front.channel.one.ch"
 		}`),
 					), project.OIDCConfigChangedEventMapper),
 			},
@@ -725,7 +807,7 @@ func TestAppProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "UPDATE projections.apps7_oidc_configs SET (version, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17) WHERE (app_id = $18) AND (instance_id = $19)",
+							expectedStmt: "UPDATE projections.apps7_oidc_configs SET (version, redirect_uris, response_types, grant_types, application_type, auth_method_type, post_logout_redirect_uris, is_dev_mode, access_token_type, access_token_role_assertion, id_token_role_assertion, id_token_userinfo_assertion, clock_skew, additional_origins, skip_native_app_success_page, back_channel_logout_uri, login_version, refresh_token_rotation, refresh_token_lifetime, refresh_token_idle_lifetime, id_token_encrypted_response_alg, jwks, jwks_uri, front_channel_logout_uri) = ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24) WHERE (app_id = $25) AND (instance_id = $26)",
 							expectedArgs: []interface{}{
 								domain.OIDCVersionV1,
 								database.TextArray[string]{"redirect.one.ch", "redirect.two.ch"},
@@ -744,6 +826,13 @@ func TestAppProjection_reduces(t *testing.T) {
 								true,
 								"back.channel.one.ch",
 								domain.LoginVersion2,
+								domain.OIDCRefreshTokenRotationNever,
+								2 * time.Microsecond,
+								1 * time.Microsecond,
+								"RSA-OAEP",
+								`{"keys":[]}`,
+								"",
+								"front.channel.one.ch",
 								"app-id",
 								"instance-id",
 							},
@@ -862,6 +951,220 @@ func TestAppProjection_reduces(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "project reduceWSFedConfigAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.WSFedConfigAddedType,
+						project.AggregateType,
+						[]byte(`{
+			"appId": "app-id",
+			"realm": "urn:sharepoint:test",
+			"replyUrls": ["https://sharepoint.test.com/_trust/"],
+			"tokenType": 2
+		}`),
+					), project.WSFedConfigAddedEventMapper),
+			},
+			reduce: (&appProjection{}).reduceWSFedConfigAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.apps7_wsfed_configs (app_id, instance_id, realm, reply_urls, token_type) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+								"urn:sharepoint:test",
+								database.TextArray[string]{"https://sharepoint.test.com/_trust/"},
+								domain.WSFedTokenTypeSAML20,
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.apps7 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceWSFedConfigChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.WSFedConfigChangedType,
+						project.AggregateType,
+						[]byte(`{
+			"appId": "app-id",
+			"realm": "urn:sharepoint:test2",
+			"replyUrls": ["https://sharepoint2.test.com/_trust/"]
+		}`),
+					), project.WSFedConfigChangedEventMapper),
+			},
+			reduce: (&appProjection{}).reduceWSFedConfigChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.apps7_wsfed_configs SET (realm, reply_urls) = ($1, $2) WHERE (app_id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								"urn:sharepoint:test2",
+								database.TextArray[string]{"https://sharepoint2.test.com/_trust/"},
+								"app-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.apps7 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceWSFedConfigChanged noop",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.WSFedConfigChangedType,
+						project.AggregateType,
+						[]byte(`{
+			"appId": "app-id"
+		}`),
+					), project.WSFedConfigChangedEventMapper),
+			},
+			reduce: (&appProjection{}).reduceWSFedConfigChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
+		{
+			name: "project reduceCASConfigAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.CASConfigAddedType,
+						project.AggregateType,
+						[]byte(`{
+			"appId": "app-id",
+			"serviceUrls": ["https://moodle.test.com/login/"],
+			"loginVersion": 2,
+			"loginBaseURI": "https://login.test.com"
+		}`),
+					), project.CASConfigAddedEventMapper),
+			},
+			reduce: (&appProjection{}).reduceCASConfigAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.apps7_cas_configs (app_id, instance_id, service_urls, login_version, login_base_uri) VALUES ($1, $2, $3, $4, $5)",
+							expectedArgs: []interface{}{
+								"app-id",
+								"instance-id",
+								database.TextArray[string]{"https://moodle.test.com/login/"},
+								domain.LoginVersion2,
+								"https://login.test.com",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.apps7 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceCASConfigChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.CASConfigChangedType,
+						project.AggregateType,
+						[]byte(`{
+			"appId": "app-id",
+			"serviceUrls": ["https://moodle2.test.com/login/"],
+			"loginVersion": 1
+		}`),
+					), project.CASConfigChangedEventMapper),
+			},
+			reduce: (&appProjection{}).reduceCASConfigChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.apps7_cas_configs SET (service_urls, login_version) = ($1, $2) WHERE (app_id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								database.TextArray[string]{"https://moodle2.test.com/login/"},
+								domain.LoginVersion1,
+								"app-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.apps7 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"app-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "project reduceCASConfigChanged noop",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.CASConfigChangedType,
+						project.AggregateType,
+						[]byte(`{
+			"appId": "app-id"
+		}`),
+					), project.CASConfigChangedEventMapper),
+			},
+			reduce: (&appProjection{}).reduceCASConfigChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{},
+				},
+			},
+		},
 		{
 			name: "project reduceOIDCConfigSecretHashUpdated",
 			args: args{
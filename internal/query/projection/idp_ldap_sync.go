@@ -0,0 +1,265 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	LDAPSyncTable = "projections.idp_ldap_syncs"
+
+	LDAPSyncIDPIDCol             = "idp_id"
+	LDAPSyncInstanceIDCol        = "instance_id"
+	LDAPSyncResourceOwnerCol     = "resource_owner"
+	LDAPSyncCreationDateCol      = "creation_date"
+	LDAPSyncChangeDateCol        = "change_date"
+	LDAPSyncSequenceCol          = "sequence"
+	LDAPSyncEnabledCol           = "enabled"
+	LDAPSyncOrganizationIDCol    = "organization_id"
+	LDAPSyncFilterCol            = "filter"
+	LDAPSyncIntervalCol          = "sync_interval"
+	LDAPSyncFullSyncIntervalCol  = "full_sync_interval"
+	LDAPSyncDeltaAttributeCol    = "delta_attribute"
+	LDAPSyncDeactivateMissingCol = "deactivate_missing"
+	LDAPSyncNextRunAtCol         = "next_run_at"
+
+	LDAPSyncRunSuffix         = "runs"
+	LDAPSyncRunIDCol          = "id"
+	LDAPSyncRunIDPIDCol       = "idp_id"
+	LDAPSyncRunInstanceIDCol  = "instance_id"
+	LDAPSyncRunStartedAtCol   = "started_at"
+	LDAPSyncRunFinishedAtCol  = "finished_at"
+	LDAPSyncRunFullSyncCol    = "full_sync"
+	LDAPSyncRunCreatedCol     = "created"
+	LDAPSyncRunUpdatedCol     = "updated"
+	LDAPSyncRunUnchangedCol   = "unchanged"
+	LDAPSyncRunDeactivatedCol = "deactivated"
+	LDAPSyncRunReactivatedCol = "reactivated"
+	LDAPSyncRunFailedCol      = "failed"
+	LDAPSyncRunErrorCol       = "error"
+)
+
+type ldapSyncProjection struct{}
+
+func newLDAPSyncProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(ldapSyncProjection))
+}
+
+func (*ldapSyncProjection) Name() string {
+	return LDAPSyncTable
+}
+
+func (*ldapSyncProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(LDAPSyncIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncEnabledCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(LDAPSyncOrganizationIDCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(LDAPSyncFilterCol, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(LDAPSyncIntervalCol, handler.ColumnTypeInterval, handler.Nullable()),
+			handler.NewColumn(LDAPSyncFullSyncIntervalCol, handler.ColumnTypeInterval, handler.Nullable()),
+			handler.NewColumn(LDAPSyncDeltaAttributeCol, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(LDAPSyncDeactivateMissingCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(LDAPSyncNextRunAtCol, handler.ColumnTypeTimestamp, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(LDAPSyncInstanceIDCol, LDAPSyncIDPIDCol),
+			handler.WithIndex(handler.NewIndex("next_run", []string{LDAPSyncEnabledCol, LDAPSyncNextRunAtCol})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(LDAPSyncRunIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncRunIDPIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncRunInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncRunStartedAtCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncRunFinishedAtCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncRunFullSyncCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(LDAPSyncRunCreatedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunUpdatedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunUnchangedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunDeactivatedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunReactivatedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunFailedCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncRunErrorCol, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(LDAPSyncRunInstanceIDCol, LDAPSyncRunIDPIDCol, LDAPSyncRunIDCol),
+			LDAPSyncRunSuffix,
+			handler.WithForeignKey(handler.NewForeignKey("ldap_sync", []string{LDAPSyncRunInstanceIDCol, LDAPSyncRunIDPIDCol}, []string{LDAPSyncInstanceIDCol, LDAPSyncIDPIDCol})),
+			handler.WithIndex(handler.NewIndex("ldap_sync", []string{LDAPSyncRunInstanceIDCol, LDAPSyncRunIDPIDCol})),
+		),
+	)
+}
+
+func (p *ldapSyncProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.IDPLDAPSyncSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  instance.IDPLDAPSyncRunFinishedEventType,
+					Reduce: p.reduceRunFinished,
+				},
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(LDAPSyncInstanceIDCol),
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.IDPLDAPSyncSetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  org.IDPLDAPSyncRunFinishedEventType,
+					Reduce: p.reduceRunFinished,
+				},
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *ldapSyncProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	var e idp.LDAPSyncSetEvent
+	switch event := event.(type) {
+	case *instance.IDPLDAPSyncSetEvent:
+		e = event.LDAPSyncSetEvent
+	case *org.IDPLDAPSyncSetEvent:
+		e = event.LDAPSyncSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Qs5pm", "reduce.wrong.event.type %v", []eventstore.EventType{instance.IDPLDAPSyncSetEventType, org.IDPLDAPSyncSetEventType})
+	}
+
+	return handler.NewUpsertStatement(
+		&e,
+		[]handler.Column{
+			handler.NewCol(LDAPSyncInstanceIDCol, nil),
+			handler.NewCol(LDAPSyncIDPIDCol, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(LDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(LDAPSyncIDPIDCol, e.ID),
+			handler.NewCol(LDAPSyncResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(LDAPSyncCreationDateCol, handler.OnlySetValueOnInsert(LDAPSyncTable, e.CreationDate())),
+			handler.NewCol(LDAPSyncChangeDateCol, e.CreationDate()),
+			handler.NewCol(LDAPSyncSequenceCol, e.Sequence()),
+			handler.NewCol(LDAPSyncEnabledCol, e.Enabled),
+			handler.NewCol(LDAPSyncOrganizationIDCol, e.OrganizationID),
+			handler.NewCol(LDAPSyncFilterCol, e.Filter),
+			handler.NewCol(LDAPSyncIntervalCol, e.Interval),
+			handler.NewCol(LDAPSyncFullSyncIntervalCol, e.FullSyncInterval),
+			handler.NewCol(LDAPSyncDeltaAttributeCol, e.DeltaAttribute),
+			handler.NewCol(LDAPSyncDeactivateMissingCol, e.DeactivateMissing),
+			// a changed configuration is applied immediately
+			handler.NewCol(LDAPSyncNextRunAtCol, e.CreationDate()),
+		},
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceRunFinished(event eventstore.Event) (*handler.Statement, error) {
+	var e idp.LDAPSyncRunFinishedEvent
+	switch event := event.(type) {
+	case *instance.IDPLDAPSyncRunFinishedEvent:
+		e = event.LDAPSyncRunFinishedEvent
+	case *org.IDPLDAPSyncRunFinishedEvent:
+		e = event.LDAPSyncRunFinishedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Vd3ks", "reduce.wrong.event.type %v", []eventstore.EventType{instance.IDPLDAPSyncRunFinishedEventType, org.IDPLDAPSyncRunFinishedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&e,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(LDAPSyncChangeDateCol, e.CreationDate()),
+				handler.NewCol(LDAPSyncSequenceCol, e.Sequence()),
+				handler.NewCol(LDAPSyncNextRunAtCol, e.NextRunAt),
+			},
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCond(LDAPSyncIDPIDCol, e.ID),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(LDAPSyncRunIDCol, e.RunID),
+				handler.NewCol(LDAPSyncRunIDPIDCol, e.ID),
+				handler.NewCol(LDAPSyncRunInstanceIDCol, e.Aggregate().InstanceID),
+				handler.NewCol(LDAPSyncRunStartedAtCol, e.StartedAt),
+				handler.NewCol(LDAPSyncRunFinishedAtCol, e.FinishedAt),
+				handler.NewCol(LDAPSyncRunFullSyncCol, e.FullSync),
+				handler.NewCol(LDAPSyncRunCreatedCol, e.Stats.Created),
+				handler.NewCol(LDAPSyncRunUpdatedCol, e.Stats.Updated),
+				handler.NewCol(LDAPSyncRunUnchangedCol, e.Stats.Unchanged),
+				handler.NewCol(LDAPSyncRunDeactivatedCol, e.Stats.Deactivated),
+				handler.NewCol(LDAPSyncRunReactivatedCol, e.Stats.Reactivated),
+				handler.NewCol(LDAPSyncRunFailedCol, e.Stats.Failed),
+				handler.NewCol(LDAPSyncRunErrorCol, e.Error),
+			},
+			handler.WithTableSuffix(LDAPSyncRunSuffix),
+		),
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var e idp.RemovedEvent
+	switch event := event.(type) {
+	case *instance.IDPRemovedEvent:
+		e = event.RemovedEvent
+	case *org.IDPRemovedEvent:
+		e = event.RemovedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Ub8fz", "reduce.wrong.event.type %v", []eventstore.EventType{instance.IDPRemovedEventType, org.IDPRemovedEventType})
+	}
+
+	return handler.NewDeleteStatement(
+		&e,
+		[]handler.Condition{
+			handler.NewCond(LDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(LDAPSyncIDPIDCol, e.ID),
+		},
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(LDAPSyncInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(LDAPSyncResourceOwnerCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,194 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestLDAPSyncProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					instance.IDPLDAPSyncSetEventType,
+					instance.AggregateType,
+					[]byte(`{
+	"id": "idp-id",
+	"enabled": true,
+	"organizationId": "org-id",
+	"filter": "(department=sales)",
+	"interval": 900000000000,
+	"fullSyncInterval": 86400000000000,
+	"deltaAttribute": 1,
+	"deactivateMissing": true
+}`),
+				), instance.IDPLDAPSyncSetEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs (instance_id, idp_id, resource_owner, creation_date, change_date, sequence, enabled, organization_id, filter, sync_interval, full_sync_interval, delta_attribute, deactivate_missing, next_run_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, enabled, organization_id, filter, sync_interval, full_sync_interval, delta_attribute, deactivate_missing, next_run_at) = (EXCLUDED.resource_owner, projections.idp_ldap_syncs.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.enabled, EXCLUDED.organization_id, EXCLUDED.filter, EXCLUDED.sync_interval, EXCLUDED.full_sync_interval, EXCLUDED.delta_attribute, EXCLUDED.deactivate_missing, EXCLUDED.next_run_at)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"idp-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								true,
+								"org-id",
+								"(department=sales)",
+								15 * time.Minute,
+								24 * time.Hour,
+								domain.LDAPSyncDeltaAttributeModifyTimestamp,
+								true,
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceRunFinished",
+			args: args{
+				event: getEvent(testEvent(
+					org.IDPLDAPSyncRunFinishedEventType,
+					org.AggregateType,
+					[]byte(`{
+	"id": "idp-id",
+	"runId": "run-id",
+	"startedAt": "2024-01-01T10:00:00Z",
+	"finishedAt": "2024-01-01T10:01:00Z",
+	"fullSync": true,
+	"cursor": "20240101100000Z",
+	"stats": {"created": 2, "updated": 1, "unchanged": 10, "deactivated": 1, "failed": 1},
+	"nextRunAt": "2024-01-01T10:16:00Z"
+}`),
+				), org.IDPLDAPSyncRunFinishedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceRunFinished,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idp_ldap_syncs SET (change_date, sequence, next_run_at) = ($1, $2, $3) WHERE (instance_id = $4) AND (idp_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								time.Date(2024, 1, 1, 10, 16, 0, 0, time.UTC),
+								"instance-id",
+								"idp-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idp_ldap_syncs_runs (id, idp_id, instance_id, started_at, finished_at, full_sync, created, updated, unchanged, deactivated, reactivated, failed, error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"run-id",
+								"idp-id",
+								"instance-id",
+								time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+								time.Date(2024, 1, 1, 10, 1, 0, 0, time.UTC),
+								true,
+								uint32(2),
+								uint32(1),
+								uint32(10),
+								uint32(1),
+								uint32(0),
+								uint32(1),
+								"",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceIDPRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					instance.IDPRemovedEventType,
+					instance.AggregateType,
+					[]byte(`{"id": "idp-id"}`),
+				), instance.IDPRemovedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (instance_id = $1) AND (idp_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"idp-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					org.OrgRemovedEventType,
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.idp_ldap_syncs WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, LDAPSyncTable, tt.want)
+		})
+	}
+}
@@ -31,6 +31,8 @@ const (
 	IDPTemplateAppleTable            = IDPTemplateTable + "_" + IDPTemplateAppleSuffix
 	IDPTemplateSAMLTable             = IDPTemplateTable + "_" + IDPTemplateSAMLSuffix
 	IDPTemplateZitadelTable          = IDPTemplateTable + "_" + IDPTemplateZitadelSuffix
+	IDPTemplateX509Table             = IDPTemplateTable + "_" + IDPTemplateX509Suffix
+	IDPTemplateMappingTable          = IDPTemplateTable + "_" + IDPTemplateMappingSuffix
 
 	IDPTemplateOAuthSuffix            = "oauth2"
 	IDPTemplateOIDCSuffix             = "oidc"
@@ -45,6 +47,8 @@ const (
 	IDPTemplateAppleSuffix            = "apple"
 	IDPTemplateSAMLSuffix             = "saml"
 	IDPTemplateZitadelSuffix          = "zitadel"
+	IDPTemplateX509Suffix             = "x509"
+	IDPTemplateMappingSuffix          = "mapping"
 
 	IDPTemplateIDCol                = "id"
 	IDPTemplateCreationDateCol      = "creation_date"
@@ -185,6 +189,21 @@ const (
 	ZitadelClientSecretCol      = "client_secret"
 	ZitadelScopesCol            = "scopes"
 	ZitadelInstanceRolesInfoCol = "instance_roles_info"
+
+	X509IDCol                      = "idp_id"
+	X509InstanceIDCol              = "instance_id"
+	X509CertificateHeaderCol       = "certificate_header"
+	X509TrustedProxiesCol          = "trusted_proxies"
+	X509CACertificatesCol          = "ca_certificates"
+	X509CRLsCol                    = "crls"
+	X509OCSPResponsesCol           = "ocsp_responses"
+	X509RevocationCheckRequiredCol = "revocation_check_required"
+	X509IDFieldCol                 = "id_field"
+	X509UsernameFieldCol           = "username_field"
+
+	MappingIDCol         = "idp_id"
+	MappingInstanceIDCol = "instance_id"
+	MappingRulesCol      = "rules"
 )
 
 type idpTemplateProjection struct{}
@@ -409,6 +428,31 @@ func (*idpTemplateProjection) Init() *old_handler.Check {
 			IDPTemplateZitadelSuffix,
 			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
 		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(X509IDCol, handler.ColumnTypeText),
+			handler.NewColumn(X509InstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(X509CertificateHeaderCol, handler.ColumnTypeText),
+			handler.NewColumn(X509TrustedProxiesCol, handler.ColumnTypeTextArray, handler.Nullable()),
+			handler.NewColumn(X509CACertificatesCol, handler.ColumnTypeBytes),
+			handler.NewColumn(X509CRLsCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(X509OCSPResponsesCol, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(X509RevocationCheckRequiredCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(X509IDFieldCol, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(X509UsernameFieldCol, handler.ColumnTypeEnum, handler.Default(0)),
+		},
+			handler.NewPrimaryKey(X509InstanceIDCol, X509IDCol),
+			IDPTemplateX509Suffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(MappingIDCol, handler.ColumnTypeText),
+			handler.NewColumn(MappingInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(MappingRulesCol, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(MappingInstanceIDCol, MappingIDCol),
+			IDPTemplateMappingSuffix,
+			handler.WithForeignKey(handler.NewForeignKeyOfPublicKeys()),
+		),
 	)
 }
 
@@ -549,6 +593,18 @@ func (p *idpTemplateProjection) Reducers() []handler.AggregateReducer {
 					Event:  instance.ZitadelIDPAddedEventType,
 					Reduce: p.reduceZitadelIDPAdded,
 				},
+				{
+					Event:  instance.X509IDPAddedEventType,
+					Reduce: p.reduceX509IDPAdded,
+				},
+				{
+					Event:  instance.X509IDPChangedEventType,
+					Reduce: p.reduceX509IDPChanged,
+				},
+				{
+					Event:  instance.IDPMappingRulesSetEventType,
+					Reduce: p.reduceIDPMappingRulesSet,
+				},
 				{
 					Event:  instance.IDPConfigRemovedEventType,
 					Reduce: p.reduceIDPConfigRemoved,
@@ -698,6 +754,18 @@ func (p *idpTemplateProjection) Reducers() []handler.AggregateReducer {
 					Event:  org.ZitadelIDPAddedEventType,
 					Reduce: p.reduceZitadelIDPAdded,
 				},
+				{
+					Event:  org.X509IDPAddedEventType,
+					Reduce: p.reduceX509IDPAdded,
+				},
+				{
+					Event:  org.X509IDPChangedEventType,
+					Reduce: p.reduceX509IDPChanged,
+				},
+				{
+					Event:  org.IDPMappingRulesSetEventType,
+					Reduce: p.reduceIDPMappingRulesSet,
+				},
 				{
 					Event:  org.IDPConfigRemovedEventType,
 					Reduce: p.reduceIDPConfigRemoved,
@@ -2624,3 +2692,164 @@ func (p *idpTemplateProjection) reduceZitadelIDPAdded(event eventstore.Event) (*
 		),
 	), nil
 }
+
+func (p *idpTemplateProjection) reduceX509IDPAdded(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.X509IDPAddedEvent
+	var idpOwnerType domain.IdentityProviderType
+	switch e := event.(type) {
+	case *org.X509IDPAddedEvent:
+		idpEvent = e.X509IDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeOrg
+	case *instance.X509IDPAddedEvent:
+		idpEvent = e.X509IDPAddedEvent
+		idpOwnerType = domain.IdentityProviderTypeSystem
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Xk3vd", "reduce.wrong.event.type %v", []eventstore.EventType{org.X509IDPAddedEventType, instance.X509IDPAddedEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCol(IDPTemplateCreationDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+				handler.NewCol(IDPTemplateResourceOwnerCol, idpEvent.Aggregate().ResourceOwner),
+				handler.NewCol(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(IDPTemplateStateCol, domain.IDPStateActive),
+				handler.NewCol(IDPTemplateNameCol, idpEvent.Name),
+				handler.NewCol(IDPTemplateOwnerTypeCol, idpOwnerType),
+				handler.NewCol(IDPTemplateTypeCol, domain.IDPTypeX509),
+				handler.NewCol(IDPTemplateIsCreationAllowedCol, idpEvent.IsCreationAllowed),
+				handler.NewCol(IDPTemplateIsLinkingAllowedCol, idpEvent.IsLinkingAllowed),
+				handler.NewCol(IDPTemplateIsAutoCreationCol, idpEvent.IsAutoCreation),
+				handler.NewCol(IDPTemplateIsAutoUpdateCol, idpEvent.IsAutoUpdate),
+				handler.NewCol(IDPTemplateAutoLinkingCol, idpEvent.AutoLinkingOption),
+			},
+		),
+		handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(X509IDCol, idpEvent.ID),
+				handler.NewCol(X509InstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(X509CertificateHeaderCol, idpEvent.CertificateHeader),
+				handler.NewCol(X509TrustedProxiesCol, database.TextArray[string](idpEvent.TrustedProxies)),
+				handler.NewCol(X509CACertificatesCol, idpEvent.CACertificates),
+				handler.NewCol(X509CRLsCol, database.NewJSONArray(idpEvent.CRLs)),
+				handler.NewCol(X509OCSPResponsesCol, database.NewJSONArray(idpEvent.OCSPResponses)),
+				handler.NewCol(X509RevocationCheckRequiredCol, idpEvent.RevocationCheckRequired),
+				handler.NewCol(X509IDFieldCol, idpEvent.IDField),
+				handler.NewCol(X509UsernameFieldCol, idpEvent.UsernameField),
+			},
+			handler.WithTableSuffix(IDPTemplateX509Suffix),
+		),
+	), nil
+}
+
+func (p *idpTemplateProjection) reduceX509IDPChanged(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.X509IDPChangedEvent
+	switch e := event.(type) {
+	case *org.X509IDPChangedEvent:
+		idpEvent = e.X509IDPChangedEvent
+	case *instance.X509IDPChangedEvent:
+		idpEvent = e.X509IDPChangedEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Bq9ty", "reduce.wrong.event.type %v", []eventstore.EventType{org.X509IDPChangedEventType, instance.X509IDPChangedEventType})
+	}
+
+	ops := make([]func(eventstore.Event) handler.Exec, 0, 2)
+	ops = append(ops,
+		handler.AddUpdateStatement(
+			reduceIDPChangedTemplateColumns(idpEvent.Name, idpEvent.CreationDate(), idpEvent.Sequence(), idpEvent.OptionChanges),
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+	)
+	x509Cols := reduceX509IDPChangedColumns(idpEvent)
+	if len(x509Cols) > 0 {
+		ops = append(ops,
+			handler.AddUpdateStatement(
+				x509Cols,
+				[]handler.Condition{
+					handler.NewCond(X509IDCol, idpEvent.ID),
+					handler.NewCond(X509InstanceIDCol, idpEvent.Aggregate().InstanceID),
+				},
+				handler.WithTableSuffix(IDPTemplateX509Suffix),
+			),
+		)
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		ops...,
+	), nil
+}
+
+func reduceX509IDPChangedColumns(idpEvent idp.X509IDPChangedEvent) []handler.Column {
+	x509Cols := make([]handler.Column, 0, 8)
+	if idpEvent.CertificateHeader != nil {
+		x509Cols = append(x509Cols, handler.NewCol(X509CertificateHeaderCol, *idpEvent.CertificateHeader))
+	}
+	if idpEvent.TrustedProxies != nil {
+		x509Cols = append(x509Cols, handler.NewCol(X509TrustedProxiesCol, database.TextArray[string](*idpEvent.TrustedProxies)))
+	}
+	if idpEvent.CACertificates != nil {
+		x509Cols = append(x509Cols, handler.NewCol(X509CACertificatesCol, idpEvent.CACertificates))
+	}
+	if idpEvent.CRLs != nil {
+		x509Cols = append(x509Cols, handler.NewCol(X509CRLsCol, database.NewJSONArray(*idpEvent.CRLs)))
+	}
+	if idpEvent.OCSPResponses != nil {
+		x509Cols = append(x509Cols, handler.NewCol(X509OCSPResponsesCol, database.NewJSONArray(*idpEvent.OCSPResponses)))
+	}
+	if idpEvent.RevocationCheckRequired != nil {
+		x509Cols = append(x509Cols, handler.NewCol(X509RevocationCheckRequiredCol, *idpEvent.RevocationCheckRequired))
+	}
+	if idpEvent.IDField != nil {
+		x509Cols = append(x509Cols, handler.NewCol(X509IDFieldCol, *idpEvent.IDField))
+	}
+	if idpEvent.UsernameField != nil {
+		x509Cols = append(x509Cols, handler.NewCol(X509UsernameFieldCol, *idpEvent.UsernameField))
+	}
+	return x509Cols
+}
+
+func (p *idpTemplateProjection) reduceIDPMappingRulesSet(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.MappingRulesSetEvent
+	switch e := event.(type) {
+	case *org.IDPMappingRulesSetEvent:
+		idpEvent = e.MappingRulesSetEvent
+	case *instance.IDPMappingRulesSetEvent:
+		idpEvent = e.MappingRulesSetEvent
+	default:
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "HANDL-Wm5rd", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPMappingRulesSetEventType, instance.IDPMappingRulesSetEventType})
+	}
+
+	return handler.NewMultiStatement(
+		&idpEvent,
+		handler.AddUpdateStatement(
+			[]handler.Column{
+				handler.NewCol(IDPTemplateChangeDateCol, idpEvent.CreationDate()),
+				handler.NewCol(IDPTemplateSequenceCol, idpEvent.Sequence()),
+			},
+			[]handler.Condition{
+				handler.NewCond(IDPTemplateIDCol, idpEvent.ID),
+				handler.NewCond(IDPTemplateInstanceIDCol, idpEvent.Aggregate().InstanceID),
+			},
+		),
+		handler.AddUpsertStatement(
+			[]handler.Column{
+				handler.NewCol(MappingInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(MappingIDCol, idpEvent.ID),
+			},
+			[]handler.Column{
+				handler.NewCol(MappingInstanceIDCol, idpEvent.Aggregate().InstanceID),
+				handler.NewCol(MappingIDCol, idpEvent.ID),
+				handler.NewCol(MappingRulesCol, database.NewJSONArray(idpEvent.Rules)),
+			},
+			handler.WithTableSuffix(IDPTemplateMappingSuffix),
+		),
+	), nil
+}
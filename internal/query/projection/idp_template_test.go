@@ -4817,3 +4817,380 @@ func TestIDPTemplateProjection_reducesZitadel(t *testing.T) {
 		})
 	}
 }
+
+func TestIDPTemplateProjection_reducesX509(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceX509IDPAdded",
+			args: args{
+				event: getEvent(testEvent(
+					instance.X509IDPAddedEventType,
+					instance.AggregateType,
+					[]byte(`{
+	"id": "idp-id",
+	"name": "name",
+	"certificateHeader": "X-SSL-Client-Cert",
+	"trustedProxies": ["10.0.0.1"],
+	"caCertificates": `+stringToJSONByte("ca")+`,
+	"crls": [`+stringToJSONByte("crl")+`],
+	"ocspResponses": [`+stringToJSONByte("ocsp")+`],
+	"revocationCheckRequired": true,
+	"idField": 5,
+	"usernameField": 4,
+	"isCreationAllowed": true,
+	"isLinkingAllowed": true,
+	"isAutoCreation": true,
+	"isAutoUpdate": true,
+	"autoLinkingOption": 2
+}`),
+				), instance.X509IDPAddedEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceX509IDPAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: idpTemplateInsertStmt,
+							expectedArgs: []interface{}{
+								"idp-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								domain.IDPStateActive,
+								"name",
+								domain.IdentityProviderTypeSystem,
+								domain.IDPTypeX509,
+								true,
+								true,
+								true,
+								true,
+								domain.AutoLinkingOptionEmail,
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idp_templates6_x509 (idp_id, instance_id, certificate_header, trusted_proxies, ca_certificates, crls, ocsp_responses, revocation_check_required, id_field, username_field) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"X-SSL-Client-Cert",
+								database.TextArray[string]{"10.0.0.1"},
+								[]byte("ca"),
+								database.NewJSONArray([][]byte{[]byte("crl")}),
+								database.NewJSONArray([][]byte{[]byte("ocsp")}),
+								true,
+								domain.X509CertificateFieldSANUPN,
+								domain.X509CertificateFieldSANEmail,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceX509IDPAdded",
+			args: args{
+				event: getEvent(testEvent(
+					org.X509IDPAddedEventType,
+					org.AggregateType,
+					[]byte(`{
+	"id": "idp-id",
+	"name": "name",
+	"certificateHeader": "X-SSL-Client-Cert",
+	"caCertificates": `+stringToJSONByte("ca")+`
+}`),
+				), org.X509IDPAddedEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceX509IDPAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: idpTemplateInsertStmt,
+							expectedArgs: []interface{}{
+								"idp-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"ro-id",
+								"instance-id",
+								domain.IDPStateActive,
+								"name",
+								domain.IdentityProviderTypeOrg,
+								domain.IDPTypeX509,
+								false,
+								false,
+								false,
+								false,
+								domain.AutoLinkingOptionUnspecified,
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idp_templates6_x509 (idp_id, instance_id, certificate_header, trusted_proxies, ca_certificates, crls, ocsp_responses, revocation_check_required, id_field, username_field) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+							expectedArgs: []interface{}{
+								"idp-id",
+								"instance-id",
+								"X-SSL-Client-Cert",
+								database.TextArray[string](nil),
+								[]byte("ca"),
+								database.NewJSONArray[[]byte](nil),
+								database.NewJSONArray[[]byte](nil),
+								false,
+								domain.X509CertificateFieldUnspecified,
+								domain.X509CertificateFieldUnspecified,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceX509IDPChanged minimal",
+			args: args{
+				event: getEvent(testEvent(
+					instance.X509IDPChangedEventType,
+					instance.AggregateType,
+					[]byte(`{
+			"id": "idp-id",
+			"isCreationAllowed": true,
+			"certificateHeader": "X-Client-Cert"
+		}`),
+				), instance.X509IDPChangedEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceX509IDPChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: idpTemplateUpdateMinimalStmt,
+							expectedArgs: []interface{}{
+								true,
+								anyArg{},
+								uint64(15),
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idp_templates6_x509 SET certificate_header = $1 WHERE (idp_id = $2) AND (instance_id = $3)",
+							expectedArgs: []interface{}{
+								"X-Client-Cert",
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceX509IDPChanged",
+			args: args{
+				event: getEvent(testEvent(
+					org.X509IDPChangedEventType,
+					org.AggregateType,
+					[]byte(`{
+			"id": "idp-id",
+			"name": "name",
+			"certificateHeader": "X-Client-Cert",
+			"trustedProxies": ["10.0.0.0/8"],
+			"caCertificates": `+stringToJSONByte("ca")+`,
+			"crls": [],
+			"ocspResponses": [`+stringToJSONByte("ocsp")+`],
+			"revocationCheckRequired": false,
+			"idField": 2,
+			"usernameField": 5,
+			"isCreationAllowed": true,
+			"isLinkingAllowed": true,
+			"isAutoCreation": true,
+			"isAutoUpdate": true,
+			"autoLinkingOption": 1
+		}`),
+				), org.X509IDPChangedEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceX509IDPChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: idpTemplateUpdateStmt,
+							expectedArgs: []interface{}{
+								"name",
+								true,
+								true,
+								true,
+								true,
+								domain.AutoLinkingOptionUsername,
+								anyArg{},
+								uint64(15),
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.idp_templates6_x509 SET (certificate_header, trusted_proxies, ca_certificates, crls, ocsp_responses, revocation_check_required, id_field, username_field) = ($1, $2, $3, $4, $5, $6, $7, $8) WHERE (idp_id = $9) AND (instance_id = $10)",
+							expectedArgs: []interface{}{
+								"X-Client-Cert",
+								database.TextArray[string]{"10.0.0.0/8"},
+								[]byte("ca"),
+								database.NewJSONArray([][]byte{}),
+								database.NewJSONArray([][]byte{[]byte("ocsp")}),
+								false,
+								domain.X509CertificateFieldSubjectCommonName,
+								domain.X509CertificateFieldSANUPN,
+								"idp-id",
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, IDPTemplateTable, tt.want)
+		})
+	}
+}
+
+func TestIDPTemplateProjection_reducesMappingRules(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "instance reduceIDPMappingRulesSet",
+			args: args{
+				event: getEvent(testEvent(
+					instance.IDPMappingRulesSetEventType,
+					instance.AggregateType,
+					[]byte(`{
+	"id": "idp-id",
+	"rules": [
+		{"target": 1, "language": 1, "expression": "$.userinfo.given_name"},
+		{"target": 13, "key": "department", "language": 2, "expression": "claims.department"}
+	]
+}`),
+				), instance.IDPMappingRulesSetEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceIDPMappingRulesSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idp_templates6 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idp_templates6_mapping (instance_id, idp_id, rules) VALUES ($1, $2, $3) ON CONFLICT (instance_id, idp_id) DO UPDATE SET rules = EXCLUDED.rules",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"idp-id",
+								database.NewJSONArray([]domain.IDPMappingRule{
+									{
+										Target:     domain.IDPMappingTargetFirstName,
+										Language:   domain.IDPMappingLanguageJSONPath,
+										Expression: "$.userinfo.given_name",
+									},
+									{
+										Target:     domain.IDPMappingTargetMetadata,
+										Key:        "department",
+										Language:   domain.IDPMappingLanguageCEL,
+										Expression: "claims.department",
+									},
+								}),
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceIDPMappingRulesSet removed",
+			args: args{
+				event: getEvent(testEvent(
+					org.IDPMappingRulesSetEventType,
+					org.AggregateType,
+					[]byte(`{"id": "idp-id"}`),
+				), org.IDPMappingRulesSetEventMapper),
+			},
+			reduce: (&idpTemplateProjection{}).reduceIDPMappingRulesSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.idp_templates6 SET (change_date, sequence) = ($1, $2) WHERE (id = $3) AND (instance_id = $4)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"idp-id",
+								"instance-id",
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.idp_templates6_mapping (instance_id, idp_id, rules) VALUES ($1, $2, $3) ON CONFLICT (instance_id, idp_id) DO UPDATE SET rules = EXCLUDED.rules",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"idp-id",
+								database.NewJSONArray[domain.IDPMappingRule](nil),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !zerrors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, IDPTemplateTable, tt.want)
+		})
+	}
+}
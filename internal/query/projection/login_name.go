@@ -250,7 +250,7 @@ func (p *loginNameProjection) reduceUserCreated(event eventstore.Event) (*handle
 		event,
 		[]handler.Column{
 			handler.NewCol(LoginNameUserIDCol, event.Aggregate().ID),
-			handler.NewCol(LoginNameUserUserNameCol, userName),
+			handler.NewCol(LoginNameUserUserNameCol, projectedUsername(event, userName)),
 			handler.NewCol(LoginNameUserResourceOwnerCol, event.Aggregate().ResourceOwner),
 			handler.NewCol(LoginNameUserInstanceIDCol, event.Aggregate().InstanceID),
 		},
@@ -283,7 +283,7 @@ func (p *loginNameProjection) reduceUserNameChanged(event eventstore.Event) (*ha
 	return handler.NewUpdateStatement(
 		event,
 		[]handler.Column{
-			handler.NewCol(LoginNameUserUserNameCol, e.UserName),
+			handler.NewCol(LoginNameUserUserNameCol, projectedUsername(e, e.UserName)),
 		},
 		[]handler.Condition{
 			handler.NewCond(LoginNameUserIDCol, e.Aggregate().ID),
@@ -302,7 +302,7 @@ func (p *loginNameProjection) reduceUserDomainClaimed(event eventstore.Event) (*
 	return handler.NewUpdateStatement(
 		event,
 		[]handler.Column{
-			handler.NewCol(LoginNameUserUserNameCol, e.UserName),
+			handler.NewCol(LoginNameUserUserNameCol, projectedUsername(e, e.UserName)),
 		},
 		[]handler.Condition{
 			handler.NewCond(LoginNameUserIDCol, e.Aggregate().ID),
@@ -88,6 +88,8 @@ var (
 	DebugEventsProjection               *handler.Handler
 	HostedLoginTranslationProjection    *handler.Handler
 	OrganizationSettingsProjection      *handler.Handler
+	LDAPSyncProjection                  *handler.Handler
+	RADIUSClientProjection              *handler.Handler
 
 	RelationalTablesProjection *handler.Handler
 
@@ -196,6 +198,8 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	DebugEventsProjection = newDebugEventsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["debug_events"]))
 	HostedLoginTranslationProjection = newHostedLoginTranslationProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["hosted_login_translation"]))
 	OrganizationSettingsProjection = newOrganizationSettingsProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["organization_settings"]))
+	LDAPSyncProjection = newLDAPSyncProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_ldap_syncs"]))
+	RADIUSClientProjection = newRADIUSClientProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["radius_clients"]))
 
 	ProjectGrantFields = newFillProjectGrantFields(applyCustomConfig(projectionConfig, config.Customizations[fieldsProjectGrant]))
 	OrgDomainVerifiedFields = newFillOrgDomainVerifiedFields(applyCustomConfig(projectionConfig, config.Customizations[fieldsOrgDomainVerified]))
@@ -390,6 +394,8 @@ func newProjectionsList() {
 		DebugEventsProjection,
 		HostedLoginTranslationProjection,
 		OrganizationSettingsProjection,
+		LDAPSyncProjection,
+		RADIUSClientProjection,
 		GroupProjection,
 		GroupUsersProjection,
 
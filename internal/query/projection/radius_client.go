@@ -0,0 +1,171 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/radiusclient"
+)
+
+const (
+	RADIUSClientTable             = "projections.radius_clients"
+	RADIUSClientIDCol             = "id"
+	RADIUSClientCreationDateCol   = "creation_date"
+	RADIUSClientChangeDateCol     = "change_date"
+	RADIUSClientResourceOwnerCol  = "resource_owner"
+	RADIUSClientInstanceIDCol     = "instance_id"
+	RADIUSClientSequenceCol       = "sequence"
+	RADIUSClientNameCol           = "name"
+	RADIUSClientNetworkCol        = "network"
+	RADIUSClientNASIdentifierCol  = "nas_identifier"
+	RADIUSClientSecretCol         = "secret"
+	RADIUSClientProjectIDCol      = "project_id"
+	RADIUSClientRequireGrantCol   = "require_grant"
+	RADIUSClientRoleAttributesCol = "role_attributes"
+)
+
+type radiusClientProjection struct{}
+
+func newRADIUSClientProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(radiusClientProjection))
+}
+
+func (*radiusClientProjection) Name() string {
+	return RADIUSClientTable
+}
+
+func (*radiusClientProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(RADIUSClientIDCol, handler.ColumnTypeText),
+			handler.NewColumn(RADIUSClientCreationDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(RADIUSClientChangeDateCol, handler.ColumnTypeTimestamp),
+			handler.NewColumn(RADIUSClientResourceOwnerCol, handler.ColumnTypeText),
+			handler.NewColumn(RADIUSClientInstanceIDCol, handler.ColumnTypeText),
+			handler.NewColumn(RADIUSClientSequenceCol, handler.ColumnTypeInt64),
+			handler.NewColumn(RADIUSClientNameCol, handler.ColumnTypeText),
+			handler.NewColumn(RADIUSClientNetworkCol, handler.ColumnTypeText),
+			handler.NewColumn(RADIUSClientNASIdentifierCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(RADIUSClientSecretCol, handler.ColumnTypeJSONB),
+			handler.NewColumn(RADIUSClientProjectIDCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(RADIUSClientRequireGrantCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(RADIUSClientRoleAttributesCol, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(RADIUSClientInstanceIDCol, RADIUSClientIDCol),
+		),
+	)
+}
+
+func (p *radiusClientProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: radiusclient.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  radiusclient.AddedEventType,
+					Reduce: p.reduceRADIUSClientAdded,
+				},
+				{
+					Event:  radiusclient.ChangedEventType,
+					Reduce: p.reduceRADIUSClientChanged,
+				},
+				{
+					Event:  radiusclient.RemovedEventType,
+					Reduce: p.reduceRADIUSClientRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(RADIUSClientInstanceIDCol),
+				},
+			},
+		},
+	}
+}
+
+func (p *radiusClientProjection) reduceRADIUSClientAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*radiusclient.AddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(RADIUSClientInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCol(RADIUSClientResourceOwnerCol, e.Aggregate().ResourceOwner),
+			handler.NewCol(RADIUSClientIDCol, e.Aggregate().ID),
+			handler.NewCol(RADIUSClientCreationDateCol, handler.OnlySetValueOnInsert(RADIUSClientTable, e.CreationDate())),
+			handler.NewCol(RADIUSClientChangeDateCol, e.CreationDate()),
+			handler.NewCol(RADIUSClientSequenceCol, e.Sequence()),
+			handler.NewCol(RADIUSClientNameCol, e.Name),
+			handler.NewCol(RADIUSClientNetworkCol, e.Network),
+			handler.NewCol(RADIUSClientNASIdentifierCol, e.NASIdentifier),
+			handler.NewCol(RADIUSClientSecretCol, e.Secret),
+			handler.NewCol(RADIUSClientProjectIDCol, e.ProjectID),
+			handler.NewCol(RADIUSClientRequireGrantCol, e.RequireGrant),
+			handler.NewCol(RADIUSClientRoleAttributesCol, database.NewJSONArray(e.RoleAttributes)),
+		},
+	), nil
+}
+
+func (p *radiusClientProjection) reduceRADIUSClientChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*radiusclient.ChangedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	values := []handler.Column{
+		handler.NewCol(RADIUSClientChangeDateCol, e.CreationDate()),
+		handler.NewCol(RADIUSClientSequenceCol, e.Sequence()),
+	}
+	if e.Name != nil {
+		values = append(values, handler.NewCol(RADIUSClientNameCol, *e.Name))
+	}
+	if e.Network != nil {
+		values = append(values, handler.NewCol(RADIUSClientNetworkCol, *e.Network))
+	}
+	if e.NASIdentifier != nil {
+		values = append(values, handler.NewCol(RADIUSClientNASIdentifierCol, *e.NASIdentifier))
+	}
+	if e.Secret != nil {
+		values = append(values, handler.NewCol(RADIUSClientSecretCol, e.Secret))
+	}
+	if e.ProjectID != nil {
+		values = append(values, handler.NewCol(RADIUSClientProjectIDCol, *e.ProjectID))
+	}
+	if e.RequireGrant != nil {
+		values = append(values, handler.NewCol(RADIUSClientRequireGrantCol, *e.RequireGrant))
+	}
+	if e.RoleAttributes != nil {
+		values = append(values, handler.NewCol(RADIUSClientRoleAttributesCol, database.NewJSONArray(*e.RoleAttributes)))
+	}
+	return handler.NewUpdateStatement(
+		e,
+		values,
+		[]handler.Condition{
+			handler.NewCond(RADIUSClientInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(RADIUSClientIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *radiusClientProjection) reduceRADIUSClientRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*radiusclient.RemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(RADIUSClientInstanceIDCol, e.Aggregate().InstanceID),
+			handler.NewCond(RADIUSClientIDCol, e.Aggregate().ID),
+		},
+	), nil
+}
@@ -0,0 +1,168 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/radiusclient"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestRADIUSClientProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceRADIUSClientAdded",
+			args: args{
+				event: getEvent(
+					testEvent(
+						radiusclient.AddedEventType,
+						radiusclient.AggregateType,
+						[]byte(`{"name": "vpn", "network": "10.0.0.0/24", "nasIdentifier": "nas1", "secret": { "cryptoType": 0, "algorithm": "aes", "keyId": "key-id" }, "projectId": "project1", "requireGrant": true, "roleAttributes": [{"role": "admin", "type": 11}]}`),
+					),
+					eventstore.GenericEventMapper[radiusclient.AddedEvent],
+				),
+			},
+			reduce: (&radiusClientProjection{}).reduceRADIUSClientAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("radius_client"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.radius_clients (instance_id, resource_owner, id, creation_date, change_date, sequence, name, network, nas_identifier, secret, project_id, require_grant, role_attributes) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"ro-id",
+								"agg-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"vpn",
+								"10.0.0.0/24",
+								"nas1",
+								anyArg{},
+								"project1",
+								true,
+								anyArg{},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRADIUSClientChanged",
+			args: args{
+				event: getEvent(
+					testEvent(
+						radiusclient.ChangedEventType,
+						radiusclient.AggregateType,
+						[]byte(`{"name": "wifi", "network": "fd00::/64", "requireGrant": false}`),
+					),
+					eventstore.GenericEventMapper[radiusclient.ChangedEvent],
+				),
+			},
+			reduce: (&radiusClientProjection{}).reduceRADIUSClientChanged,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("radius_client"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.radius_clients SET (change_date, sequence, name, network, require_grant) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								"wifi",
+								"fd00::/64",
+								false,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRADIUSClientRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						radiusclient.RemovedEventType,
+						radiusclient.AggregateType,
+						[]byte(`{}`),
+					),
+					eventstore.GenericEventMapper[radiusclient.RemovedEvent],
+				),
+			},
+			reduce: (&radiusClientProjection{}).reduceRADIUSClientRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("radius_client"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.radius_clients WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					),
+					instance.InstanceRemovedEventMapper,
+				),
+			},
+			reduce: reduceInstanceRemovedHelper(RADIUSClientInstanceIDCol),
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.radius_clients WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if ok := zerrors.IsErrorInvalidArgument(err); !ok {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, RADIUSClientTable, tt.want)
+		})
+	}
+}
@@ -371,7 +371,7 @@ func (p *userProjection) reduceHumanAdded(event eventstore.Event) (*handler.Stat
 				handler.NewCol(UserInstanceIDCol, e.Aggregate().InstanceID),
 				handler.NewCol(UserStateCol, domain.UserStateActive),
 				handler.NewCol(UserSequenceCol, e.Sequence()),
-				handler.NewCol(UserUsernameCol, e.UserName),
+				handler.NewCol(UserUsernameCol, projectedUsername(e, e.UserName)),
 				handler.NewCol(UserTypeCol, domain.UserTypeHuman),
 			},
 		),
@@ -422,7 +422,7 @@ func (p *userProjection) reduceHumanRegistered(event eventstore.Event) (*handler
 				handler.NewCol(UserInstanceIDCol, e.Aggregate().InstanceID),
 				handler.NewCol(UserStateCol, domain.UserStateActive),
 				handler.NewCol(UserSequenceCol, e.Sequence()),
-				handler.NewCol(UserUsernameCol, e.UserName),
+				handler.NewCol(UserUsernameCol, projectedUsername(e, e.UserName)),
 				handler.NewCol(UserTypeCol, domain.UserTypeHuman),
 			},
 		),
@@ -595,7 +595,7 @@ func (p *userProjection) reduceUserNameChanged(event eventstore.Event) (*handler
 		e,
 		[]handler.Column{
 			handler.NewCol(UserChangeDateCol, e.CreationDate()),
-			handler.NewCol(UserUsernameCol, e.UserName),
+			handler.NewCol(UserUsernameCol, projectedUsername(e, e.UserName)),
 			handler.NewCol(UserSequenceCol, e.Sequence()),
 		},
 		[]handler.Condition{
@@ -615,7 +615,7 @@ func (p *userProjection) reduceDomainClaimed(event eventstore.Event) (*handler.S
 		e,
 		[]handler.Column{
 			handler.NewCol(UserChangeDateCol, e.CreationDate()),
-			handler.NewCol(UserUsernameCol, e.UserName),
+			handler.NewCol(UserUsernameCol, projectedUsername(e, e.UserName)),
 			handler.NewCol(UserSequenceCol, e.Sequence()),
 		},
 		[]handler.Condition{
@@ -1208,3 +1208,12 @@ func (p *userProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.St
 		},
 	), nil
 }
+
+// projectedUsername renders the username of an event whose personal data were redacted with the id of the user,
+// so the redacted usernames of removed users don't collide until their removal is reduced, e.g. during a rebuild.
+func projectedUsername(event eventstore.Event, username string) string {
+	if username != eventstore.PersonalDataRedacted || !eventstore.IsPersonalDataRedacted(event) {
+		return username
+	}
+	return eventstore.PersonalDataRedacted + ":" + event.Aggregate().ID
+}
@@ -50,7 +50,7 @@ func (p *relationalTablesProjection) reduceHumanAdded(event eventstore.Event) (*
 			InstanceID:     e.Aggregate().InstanceID,
 			OrganizationID: e.Aggregate().ResourceOwner,
 			ID:             e.Aggregate().ID,
-			Username:       e.UserName,
+			Username:       projectedUsername(e, e.UserName),
 			State:          domain.UserStateActive,
 			CreatedAt:      e.CreatedAt(),
 			UpdatedAt:      e.CreatedAt(),
@@ -105,7 +105,7 @@ func (p *relationalTablesProjection) reduceHumanRegistered(event eventstore.Even
 			InstanceID:     e.Aggregate().InstanceID,
 			OrganizationID: e.Aggregate().ResourceOwner,
 			ID:             e.Aggregate().ID,
-			Username:       e.UserName,
+			Username:       projectedUsername(e, e.UserName),
 			State:          domain.UserStateActive,
 			CreatedAt:      e.CreatedAt(),
 			UpdatedAt:      e.CreatedAt(),
@@ -289,7 +289,7 @@ func (p *relationalTablesProjection) reduceUsernameChanged(event eventstore.Even
 			ctx,
 			v3_sql.SQLTx(tx),
 			repo.PrimaryKeyCondition(e.Agg.InstanceID, e.Aggregate().ID),
-			repo.SetUsername(e.UserName),
+			repo.SetUsername(projectedUsername(e, e.UserName)),
 			repo.SetUpdatedAt(e.CreatedAt()),
 		)
 		return err
@@ -311,7 +311,7 @@ func (p *relationalTablesProjection) reduceUserDomainClaimed(event eventstore.Ev
 
 		_, err := userRepo.Update(ctx, v3_sql.SQLTx(tx),
 			userRepo.PrimaryKeyCondition(e.Aggregate().InstanceID, e.Aggregate().ID),
-			userRepo.SetUsername(e.UserName),
+			userRepo.SetUsername(projectedUsername(e, e.UserName)),
 			userRepo.SetUpdatedAt(e.CreatedAt()),
 		)
 
@@ -34,6 +34,7 @@ type Queries struct {
 	targetEncryptionAlgorithm crypto.EncryptionAlgorithm
 	smtpEncryptionAlgorithm   crypto.EncryptionAlgorithm
 	smsEncryptionAlgorithm    crypto.EncryptionAlgorithm
+	radiusEncryptionAlgorithm crypto.EncryptionAlgorithm
 	sessionTokenVerifier      func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error)
 	checkPermission           domain.PermissionCheck
 
@@ -55,7 +56,7 @@ func StartQueries(
 	cacheConnectors connector.Connectors,
 	projections projection.Config,
 	defaults sd.SystemDefaults,
-	idpConfigEncryption, otpEncryption, keyEncryptionAlgorithm, certEncryptionAlgorithm, targetEncryptionAlgorithm, smsEncryptionAlgorithm, smtpEncryptionAlgorithm crypto.EncryptionAlgorithm,
+	idpConfigEncryption, otpEncryption, keyEncryptionAlgorithm, certEncryptionAlgorithm, targetEncryptionAlgorithm, smsEncryptionAlgorithm, smtpEncryptionAlgorithm, radiusEncryptionAlgorithm crypto.EncryptionAlgorithm,
 	zitadelRoles []authz.RoleMapping,
 	sessionTokenVerifier func(ctx context.Context, sessionToken string, sessionID string, tokenID string) (err error),
 	permissionCheck func(q *Queries) domain.PermissionCheck,
@@ -76,6 +77,7 @@ func StartQueries(
 		targetEncryptionAlgorithm:           targetEncryptionAlgorithm,
 		smsEncryptionAlgorithm:              smsEncryptionAlgorithm,
 		smtpEncryptionAlgorithm:             smtpEncryptionAlgorithm,
+		radiusEncryptionAlgorithm:           radiusEncryptionAlgorithm,
 		sessionTokenVerifier:                sessionTokenVerifier,
 		multifactors: domain.MultifactorConfigs{
 			OTP: domain.OTPConfig{
@@ -0,0 +1,276 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/netip"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+var (
+	radiusClientTable = table{
+		name:          projection.RADIUSClientTable,
+		instanceIDCol: projection.RADIUSClientInstanceIDCol,
+	}
+	RADIUSClientColumnID = Column{
+		name:  projection.RADIUSClientIDCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnCreationDate = Column{
+		name:  projection.RADIUSClientCreationDateCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnChangeDate = Column{
+		name:  projection.RADIUSClientChangeDateCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnResourceOwner = Column{
+		name:  projection.RADIUSClientResourceOwnerCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnInstanceID = Column{
+		name:  projection.RADIUSClientInstanceIDCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnSequence = Column{
+		name:  projection.RADIUSClientSequenceCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnName = Column{
+		name:  projection.RADIUSClientNameCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnNetwork = Column{
+		name:  projection.RADIUSClientNetworkCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnNASIdentifier = Column{
+		name:  projection.RADIUSClientNASIdentifierCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnSecret = Column{
+		name:  projection.RADIUSClientSecretCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnProjectID = Column{
+		name:  projection.RADIUSClientProjectIDCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnRequireGrant = Column{
+		name:  projection.RADIUSClientRequireGrantCol,
+		table: radiusClientTable,
+	}
+	RADIUSClientColumnRoleAttributes = Column{
+		name:  projection.RADIUSClientRoleAttributesCol,
+		table: radiusClientTable,
+	}
+)
+
+type RADIUSClients struct {
+	SearchResponse
+	RADIUSClients []*RADIUSClient
+}
+
+func (c *RADIUSClients) SetState(s *State) {
+	c.State = s
+}
+
+type RADIUSClient struct {
+	domain.ObjectDetails
+
+	InstanceID     string
+	Name           string
+	Network        string
+	NASIdentifier  string
+	ProjectID      string
+	RequireGrant   bool
+	RoleAttributes []*domain.RADIUSRoleAttribute
+	// Secret is only set for clients returned by [Queries.RADIUSClientsByAddress]
+	Secret []byte
+
+	secret *crypto.CryptoValue
+}
+
+type RADIUSClientSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *RADIUSClientSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) SearchRADIUSClients(ctx context.Context, queries *RADIUSClientSearchQueries) (_ *RADIUSClients, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		RADIUSClientColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareRADIUSClientsQuery()
+	return genericRowsQueryWithState(ctx, q.client, radiusClientTable, combineToWhereStmt(query, queries.toQuery, eq), scan)
+}
+
+func (q *Queries) GetRADIUSClientByID(ctx context.Context, id string) (_ *RADIUSClient, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	eq := sq.Eq{
+		RADIUSClientColumnID.identifier():         id,
+		RADIUSClientColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}
+	query, scan := prepareRADIUSClientQuery()
+	return genericRowQuery(ctx, q.client, query.Where(eq), scan)
+}
+
+// RADIUSClientsByAddress returns the clients of all instances whose network contains the address, including their decrypted secret.
+// The RADIUS server receives the requests of all instances on the same port and identifies them by the source address.
+func (q *Queries) RADIUSClientsByAddress(ctx context.Context, addr netip.Addr) (_ []*RADIUSClient, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareRADIUSClientsQuery()
+	stmt, args, err := query.Where(
+		sq.Expr("?::INET <<= "+RADIUSClientColumnNetwork.identifier()+"::INET", addr.Unmap().String()),
+	).ToSql()
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Pv3ke", "Errors.Query.SQLStatement")
+	}
+	var clients *RADIUSClients
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		clients, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-Hx5ta", "Errors.Internal")
+	}
+	for _, client := range clients.RADIUSClients {
+		client.Secret, err = crypto.Decrypt(client.secret, q.radiusEncryptionAlgorithm)
+		if err != nil {
+			return nil, zerrors.ThrowInternal(err, "QUERY-Qe8vr", "Errors.Internal")
+		}
+	}
+	return clients.RADIUSClients, nil
+}
+
+func NewRADIUSClientNameSearchQuery(method TextComparison, value string) (SearchQuery, error) {
+	return NewTextQuery(RADIUSClientColumnName, value, method)
+}
+
+func prepareRADIUSClientsQuery() (sq.SelectBuilder, func(rows *sql.Rows) (*RADIUSClients, error)) {
+	return sq.Select(
+			RADIUSClientColumnID.identifier(),
+			RADIUSClientColumnCreationDate.identifier(),
+			RADIUSClientColumnChangeDate.identifier(),
+			RADIUSClientColumnResourceOwner.identifier(),
+			RADIUSClientColumnInstanceID.identifier(),
+			RADIUSClientColumnSequence.identifier(),
+			RADIUSClientColumnName.identifier(),
+			RADIUSClientColumnNetwork.identifier(),
+			RADIUSClientColumnNASIdentifier.identifier(),
+			RADIUSClientColumnSecret.identifier(),
+			RADIUSClientColumnProjectID.identifier(),
+			RADIUSClientColumnRequireGrant.identifier(),
+			RADIUSClientColumnRoleAttributes.identifier(),
+			countColumn.identifier(),
+		).From(radiusClientTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*RADIUSClients, error) {
+			clients := make([]*RADIUSClient, 0)
+			var count uint64
+			for rows.Next() {
+				client := new(RADIUSClient)
+				var roleAttributes database.JSONArray[*domain.RADIUSRoleAttribute]
+				err := rows.Scan(
+					&client.ID,
+					&client.CreationDate,
+					&client.EventDate,
+					&client.ResourceOwner,
+					&client.InstanceID,
+					&client.Sequence,
+					&client.Name,
+					&client.Network,
+					&client.NASIdentifier,
+					&client.secret,
+					&client.ProjectID,
+					&client.RequireGrant,
+					&roleAttributes,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				client.RoleAttributes = roleAttributes
+				clients = append(clients, client)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, zerrors.ThrowInternal(err, "QUERY-Wm2gy", "Errors.Query.CloseRows")
+			}
+
+			return &RADIUSClients{
+				RADIUSClients: clients,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareRADIUSClientQuery() (sq.SelectBuilder, func(row *sql.Row) (*RADIUSClient, error)) {
+	return sq.Select(
+			RADIUSClientColumnID.identifier(),
+			RADIUSClientColumnCreationDate.identifier(),
+			RADIUSClientColumnChangeDate.identifier(),
+			RADIUSClientColumnResourceOwner.identifier(),
+			RADIUSClientColumnInstanceID.identifier(),
+			RADIUSClientColumnSequence.identifier(),
+			RADIUSClientColumnName.identifier(),
+			RADIUSClientColumnNetwork.identifier(),
+			RADIUSClientColumnNASIdentifier.identifier(),
+			RADIUSClientColumnProjectID.identifier(),
+			RADIUSClientColumnRequireGrant.identifier(),
+			RADIUSClientColumnRoleAttributes.identifier(),
+		).From(radiusClientTable.identifier()).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*RADIUSClient, error) {
+			client := new(RADIUSClient)
+			var roleAttributes database.JSONArray[*domain.RADIUSRoleAttribute]
+			err := row.Scan(
+				&client.ID,
+				&client.CreationDate,
+				&client.EventDate,
+				&client.ResourceOwner,
+				&client.InstanceID,
+				&client.Sequence,
+				&client.Name,
+				&client.Network,
+				&client.NASIdentifier,
+				&client.ProjectID,
+				&client.RequireGrant,
+				&roleAttributes,
+			)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return nil, zerrors.ThrowNotFound(err, "QUERY-Ld4wn", "Errors.RADIUSClient.NotFound")
+				}
+				return nil, zerrors.ThrowInternal(err, "QUERY-Tj7cs", "Errors.Internal")
+			}
+			client.RoleAttributes = roleAttributes
+			return client, nil
+		}
+}
@@ -0,0 +1,1014 @@
+package query
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/muhlemmer/gu"
+	"github.com/shopspring/decimal"
+	"golang.org/x/exp/constraints"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// ResourceChangesType is the type of the resource whose changes are listed.
+// The policies are the custom policies of an organization.
+type ResourceChangesType int32
+
+const (
+	ResourceChangesTypeUnspecified ResourceChangesType = iota
+	ResourceChangesTypeUser
+	ResourceChangesTypeOrg
+	ResourceChangesTypeProject
+	ResourceChangesTypeApplication
+	ResourceChangesTypeLoginPolicy
+	ResourceChangesTypePasswordComplexityPolicy
+	ResourceChangesTypePasswordAgePolicy
+	ResourceChangesTypeLockoutPolicy
+	ResourceChangesTypePrivacyPolicy
+	ResourceChangesTypeNotificationPolicy
+	ResourceChangesTypeDomainPolicy
+)
+
+// ResourceChangesQuery selects the changes of a resource.
+type ResourceChangesQuery struct {
+	Type ResourceChangesType
+	// ID of the resource, the organization for policies
+	ID string
+	// Fields restricts the changes to the fields, all fields are returned if empty
+	Fields []string
+	// From and To restrict the changes to the period, zero values are unbounded
+	From time.Time
+	To   time.Time
+
+	Offset uint64
+	Limit  uint64
+	Asc    bool
+}
+
+// ResourceChanges are the changes of a resource, TotalCount is the amount of changes without offset and limit.
+type ResourceChanges struct {
+	Changes    []*ResourceChange
+	TotalCount uint64
+}
+
+// ResourceChange are the changes of the fields of a resource made by a single event.
+type ResourceChange struct {
+	EventType     eventstore.EventType
+	Sequence      uint64
+	Position      decimal.Decimal
+	CreationDate  time.Time
+	ResourceOwner string
+	EditorID      string
+	// UserAgent of the request which made the change, empty if it was not recorded
+	UserAgent string
+	Fields    []*ResourceFieldChange
+}
+
+// ResourceFieldChange is the value of a field before and after a change.
+// Hidden fields like secrets only indicate a change, their values are always empty.
+type ResourceFieldChange struct {
+	Name   string
+	Before string
+	After  string
+}
+
+// ResourceChanges returns the field changes of the resource.
+// The read model of the resource is reduced event by event and the fields are compared after each event,
+// events which do not change any of the fields are omitted.
+func (q *Queries) ResourceChanges(ctx context.Context, query *ResourceChangesQuery) (_ *ResourceChanges, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query.ID = strings.TrimSpace(query.ID)
+	if query.ID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Cg4vx", "Errors.IDMissing")
+	}
+	if !query.To.IsZero() && query.From.After(query.To) {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Hr8sk", "Errors.ResourceChanges.InvalidPeriod")
+	}
+	resource, err := q.changesResource(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	r := &resourceChangesReducer{
+		model:  resource.model,
+		fields: resource.fields,
+		query:  query,
+		before: resource.fields(),
+	}
+	// the changes after the period are not needed, but the events before are needed for the state before the changes
+	if err = q.eventstore.FilterToReducer(ctx, resource.query.CreationDateBefore(query.To).IncludeArchived(), r); err != nil {
+		return nil, err
+	}
+	if resource.exists != nil && !resource.exists() {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Ql2wd", "Errors.ResourceChanges.NotFound")
+	}
+	if err = resource.checkPermission(); err != nil {
+		return nil, err
+	}
+	changes := r.page()
+	if err = q.setUserAgents(ctx, changes.Changes); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// changesResource is a resource whose changes are listed.
+type changesResource struct {
+	query *eventstore.SearchQueryBuilder
+	model changesModel
+	// fields returns the current values of the model
+	fields func() []resourceField
+	// exists is nil for resources without a state, like the policies
+	exists          func() bool
+	checkPermission func() error
+}
+
+func (q *Queries) changesResource(ctx context.Context, query *ResourceChangesQuery) (*changesResource, error) {
+	switch query.Type {
+	case ResourceChangesTypeUser:
+		rm := newUserAsOfReadModel(query.ID)
+		return &changesResource{
+			query:  rm.Query(),
+			model:  rm,
+			fields: rm.changeFields,
+			exists: func() bool { return rm.user.State != domain.UserStateUnspecified },
+			checkPermission: func() error {
+				return userCheckPermission(ctx, rm.ResourceOwner, rm.AggregateID, q.checkPermission)
+			},
+		}, nil
+	case ResourceChangesTypeOrg:
+		rm := newOrgAsOfReadModel(query.ID)
+		return &changesResource{
+			query:  rm.Query(),
+			model:  rm,
+			fields: rm.changeFields,
+			exists: func() bool { return rm.org.State != domain.OrgStateUnspecified },
+			checkPermission: func() error {
+				return q.checkPermission(ctx, domain.PermissionOrgRead, rm.AggregateID, rm.AggregateID)
+			},
+		}, nil
+	case ResourceChangesTypeProject:
+		rm := newProjectAsOfReadModel(query.ID)
+		return &changesResource{
+			query:  rm.Query(),
+			model:  rm,
+			fields: rm.changeFields,
+			exists: func() bool { return rm.project.State != domain.ProjectStateUnspecified },
+			checkPermission: func() error {
+				return projectCheckPermission(ctx, rm.ResourceOwner, rm.AggregateID, q.checkPermission)
+			},
+		}, nil
+	case ResourceChangesTypeApplication:
+		return q.applicationChangesResource(ctx, query)
+	case ResourceChangesTypeLoginPolicy:
+		rm := &loginPolicyChangesReadModel{ReadModel: &eventstore.ReadModel{AggregateID: query.ID}}
+		return q.policyChangesResource(ctx, query.ID, rm.Query(), rm, rm.changeFields), nil
+	case ResourceChangesTypePasswordComplexityPolicy:
+		rm := &passwordComplexityPolicyChangesReadModel{ReadModel: &eventstore.ReadModel{AggregateID: query.ID}}
+		return q.policyChangesResource(ctx, query.ID, rm.Query(), rm, rm.changeFields), nil
+	case ResourceChangesTypePasswordAgePolicy:
+		rm := &passwordAgePolicyChangesReadModel{ReadModel: &eventstore.ReadModel{AggregateID: query.ID}}
+		return q.policyChangesResource(ctx, query.ID, rm.Query(), rm, rm.changeFields), nil
+	case ResourceChangesTypeLockoutPolicy:
+		rm := &lockoutPolicyChangesReadModel{ReadModel: &eventstore.ReadModel{AggregateID: query.ID}}
+		return q.policyChangesResource(ctx, query.ID, rm.Query(), rm, rm.changeFields), nil
+	case ResourceChangesTypePrivacyPolicy:
+		rm := &privacyPolicyChangesReadModel{ReadModel: &eventstore.ReadModel{AggregateID: query.ID}}
+		return q.policyChangesResource(ctx, query.ID, rm.Query(), rm, rm.changeFields), nil
+	case ResourceChangesTypeNotificationPolicy:
+		rm := &notificationPolicyChangesReadModel{ReadModel: &eventstore.ReadModel{AggregateID: query.ID}}
+		return q.policyChangesResource(ctx, query.ID, rm.Query(), rm, rm.changeFields), nil
+	case ResourceChangesTypeDomainPolicy:
+		rm := &domainPolicyChangesReadModel{ReadModel: &eventstore.ReadModel{AggregateID: query.ID}}
+		return q.policyChangesResource(ctx, query.ID, rm.Query(), rm, rm.changeFields), nil
+	case ResourceChangesTypeUnspecified:
+		fallthrough
+	default:
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Nv6yb", "Errors.ResourceChanges.TypeMissing")
+	}
+}
+
+func (q *Queries) applicationChangesResource(ctx context.Context, query *ResourceChangesQuery) (*changesResource, error) {
+	projectID, err := q.appProjectIDAsOf(ctx, query.ID, &AsOf{Date: query.To})
+	if err != nil {
+		return nil, err
+	}
+	if projectID == "" {
+		return nil, zerrors.ThrowNotFound(nil, "QUERY-Gd6ho", "Errors.Project.App.NotExisting")
+	}
+	rm := &appChangesReadModel{appAsOfReadModel: newAppAsOfReadModel(projectID, query.ID)}
+	return &changesResource{
+		query:  rm.Query(),
+		model:  rm,
+		fields: rm.changeFields,
+		exists: func() bool { return rm.app.State != domain.AppStateUnspecified },
+		checkPermission: func() error {
+			return appCheckPermission(ctx, rm.ResourceOwner, projectID, q.checkPermission)
+		},
+	}, nil
+}
+
+func (q *Queries) policyChangesResource(ctx context.Context, orgID string, query *eventstore.SearchQueryBuilder, rm changesModel, fields func() []resourceField) *changesResource {
+	return &changesResource{
+		query:  query,
+		model:  rm,
+		fields: fields,
+		checkPermission: func() error {
+			return q.checkPermission(ctx, domain.PermissionPolicyRead, orgID, orgID)
+		},
+	}
+}
+
+// setUserAgents sets the recorded user agents of the requests which made the changes.
+func (q *Queries) setUserAgents(ctx context.Context, changes []*ResourceChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	positions := make([]decimal.Decimal, len(changes))
+	for i, change := range changes {
+		positions[i] = change.Position
+	}
+	agents, err := q.eventstore.UserAgents(ctx, authz.GetInstance(ctx).InstanceID(), positions...)
+	if err != nil {
+		return err
+	}
+	for _, change := range changes {
+		change.UserAgent = agents[change.Position.String()]
+	}
+	return nil
+}
+
+type changesModel interface {
+	AppendEvents(...eventstore.Event)
+	Reduce() error
+}
+
+// resourceChangesReducer reduces the events one by one into the model
+// and compares its fields before and after each event.
+type resourceChangesReducer struct {
+	model   changesModel
+	fields  func() []resourceField
+	query   *ResourceChangesQuery
+	before  []resourceField
+	changes []*ResourceChange
+	err     error
+}
+
+func (r *resourceChangesReducer) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		if r.err != nil {
+			return
+		}
+		r.model.AppendEvents(event)
+		if r.err = r.model.Reduce(); r.err != nil {
+			return
+		}
+		after := r.fields()
+		r.appendChange(event, diffResourceFields(r.before, after))
+		r.before = after
+	}
+}
+
+func (r *resourceChangesReducer) Reduce() error {
+	return r.err
+}
+
+func (r *resourceChangesReducer) appendChange(event eventstore.Event, fields []*ResourceFieldChange) {
+	if event.CreatedAt().Before(r.query.From) {
+		return
+	}
+	if len(r.query.Fields) > 0 {
+		fields = slices.DeleteFunc(fields, func(field *ResourceFieldChange) bool {
+			return !slices.Contains(r.query.Fields, field.Name)
+		})
+	}
+	if len(fields) == 0 {
+		return
+	}
+	r.changes = append(r.changes, &ResourceChange{
+		EventType:     event.Type(),
+		Sequence:      event.Sequence(),
+		Position:      event.Position(),
+		CreationDate:  event.CreatedAt(),
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		EditorID:      event.Creator(),
+		Fields:        fields,
+	})
+}
+
+// page orders the changes and applies the offset and limit of the query.
+func (r *resourceChangesReducer) page() *ResourceChanges {
+	changes := r.changes
+	if !r.query.Asc {
+		slices.Reverse(changes)
+	}
+	total := uint64(len(changes))
+	changes = changes[min(r.query.Offset, total):]
+	if r.query.Limit > 0 && uint64(len(changes)) > r.query.Limit {
+		changes = changes[:r.query.Limit]
+	}
+	return &ResourceChanges{
+		Changes:    changes,
+		TotalCount: total,
+	}
+}
+
+// resourceField is the human-readable value of a field of a resource.
+type resourceField struct {
+	name  string
+	value string
+	// hidden values are compared but not returned, because they are secret or too large
+	hidden bool
+}
+
+// diffResourceFields returns the fields whose values differ, in the order of the fields after the change.
+func diffResourceFields(before, after []resourceField) []*ResourceFieldChange {
+	changes := make([]*ResourceFieldChange, 0, len(after))
+	previous := make(map[string]resourceField, len(before))
+	for _, field := range before {
+		previous[field.name] = field
+	}
+	for _, field := range after {
+		old, ok := previous[field.name]
+		delete(previous, field.name)
+		if ok && old.value == field.value {
+			continue
+		}
+		changes = append(changes, field.change(old.value))
+	}
+	// fields of the previous state which do not exist anymore
+	for _, field := range before {
+		if _, ok := previous[field.name]; !ok {
+			continue
+		}
+		removed := resourceField{name: field.name, hidden: field.hidden}
+		changes = append(changes, removed.change(field.value))
+	}
+	return changes
+}
+
+func (f resourceField) change(before string) *ResourceFieldChange {
+	if f.hidden {
+		return &ResourceFieldChange{Name: f.name}
+	}
+	return &ResourceFieldChange{
+		Name:   f.name,
+		Before: before,
+		After:  f.value,
+	}
+}
+
+func textField(name, value string) resourceField {
+	return resourceField{name: name, value: value}
+}
+
+func hiddenField(name, value string) resourceField {
+	return resourceField{name: name, value: value, hidden: true}
+}
+
+func boolField(name string, value bool) resourceField {
+	return resourceField{name: name, value: strconv.FormatBool(value)}
+}
+
+func numberField[T constraints.Integer](name string, value T) resourceField {
+	return resourceField{name: name, value: strconv.FormatInt(int64(value), 10)}
+}
+
+func durationField(name string, value time.Duration) resourceField {
+	if value == 0 {
+		return resourceField{name: name}
+	}
+	return resourceField{name: name, value: value.String()}
+}
+
+func listField(name string, values []string) resourceField {
+	return resourceField{name: name, value: strings.Join(values, ", ")}
+}
+
+// enumField renders the value by its name, values without a name are rendered as number.
+func enumField[T constraints.Integer](name string, value T, names ...string) resourceField {
+	if value >= 0 && int64(value) < int64(len(names)) {
+		return resourceField{name: name, value: names[value]}
+	}
+	return numberField(name, value)
+}
+
+func enumListField[T constraints.Integer](name string, values []T, names ...string) resourceField {
+	rendered := make([]string, len(values))
+	for i, value := range values {
+		rendered[i] = enumField(name, value, names...).value
+	}
+	return listField(name, rendered)
+}
+
+var (
+	appStateNames    = []string{"unspecified", "active", "inactive", "removed"}
+	policyStateNames = []string{"unspecified", "active", "removed"}
+	tokenTypeNames   = []string{"bearer", "jwt"}
+)
+
+func (rm *userAsOfReadModel) changeFields() []resourceField {
+	return []resourceField{
+		textField("username", rm.user.Username),
+		enumField("state", rm.user.State, "unspecified", "active", "inactive", "deleted", "locked", "suspended", "initial"),
+		textField("first_name", rm.human.FirstName),
+		textField("last_name", rm.human.LastName),
+		textField("nick_name", rm.human.NickName),
+		textField("display_name", rm.human.DisplayName),
+		textField("preferred_language", languageTagValue(rm.human.PreferredLanguage.String())),
+		enumField("gender", rm.human.Gender, "unspecified", "female", "male", "diverse"),
+		textField("email", string(rm.human.Email)),
+		boolField("email_verified", rm.human.IsEmailVerified),
+		textField("phone", string(rm.human.Phone)),
+		boolField("phone_verified", rm.human.IsPhoneVerified),
+		textField("avatar", rm.human.AvatarKey),
+		// the hash isn't kept by the read model, the date of the change is compared instead
+		hiddenField("password", rm.human.PasswordChanged.String()),
+		boolField("password_change_required", rm.human.PasswordChangeRequired),
+		textField("name", rm.machine.Name),
+		textField("description", rm.machine.Description),
+		enumField("access_token_type", rm.machine.AccessTokenType, tokenTypeNames...),
+	}
+}
+
+// languageTagValue renders an unset language as empty value.
+func languageTagValue(tag string) string {
+	if tag == "und" {
+		return ""
+	}
+	return tag
+}
+
+func (rm *orgAsOfReadModel) changeFields() []resourceField {
+	return []resourceField{
+		textField("name", rm.org.Name),
+		enumField("state", rm.org.State, "unspecified", "active", "inactive", "removed"),
+		textField("primary_domain", rm.org.Domain),
+	}
+}
+
+func (rm *projectAsOfReadModel) changeFields() []resourceField {
+	return []resourceField{
+		textField("name", rm.project.Name),
+		enumField("state", rm.project.State, "unspecified", "active", "inactive", "removed"),
+		boolField("project_role_assertion", rm.project.ProjectRoleAssertion),
+		boolField("project_role_check", rm.project.ProjectRoleCheck),
+		boolField("has_project_check", rm.project.HasProjectCheck),
+		enumField("private_labeling_setting", rm.project.PrivateLabelingSetting, "unspecified", "enforce_project_resource_owner_policy", "allow_login_user_resource_owner_policy"),
+	}
+}
+
+// appChangesReadModel extends the application with the hash of its client secret,
+// so the changes of the secret are listed without disclosing it.
+type appChangesReadModel struct {
+	*appAsOfReadModel
+
+	hashedSecret string
+}
+
+func (rm *appChangesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *project.OIDCConfigAddedEvent:
+			rm.hashedSecret = crypto.SecretOrEncodedHash(e.ClientSecret, e.HashedSecret)
+		case *project.OIDCConfigSecretChangedEvent:
+			rm.hashedSecret = crypto.SecretOrEncodedHash(e.ClientSecret, e.HashedSecret)
+		case *project.OIDCConfigSecretHashUpdatedEvent:
+			rm.hashedSecret = e.HashedSecret
+		case *project.APIConfigAddedEvent:
+			rm.hashedSecret = crypto.SecretOrEncodedHash(e.ClientSecret, e.HashedSecret)
+		case *project.APIConfigSecretChangedEvent:
+			rm.hashedSecret = crypto.SecretOrEncodedHash(e.ClientSecret, e.HashedSecret)
+		case *project.APIConfigSecretHashUpdatedEvent:
+			rm.hashedSecret = e.HashedSecret
+		}
+	}
+	return rm.appAsOfReadModel.Reduce()
+}
+
+func (rm *appChangesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return rm.appAsOfReadModel.Query().
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			project.OIDCConfigSecretChangedType,
+			project.OIDCConfigSecretHashUpdatedType,
+			project.APIConfigSecretChangedType,
+			project.APIConfigSecretHashUpdatedType,
+		).
+		Builder()
+}
+
+func (rm *appChangesReadModel) changeFields() []resourceField {
+	fields := []resourceField{
+		textField("name", rm.app.Name),
+		enumField("state", rm.app.State, appStateNames...),
+	}
+	switch {
+	case rm.app.OIDCConfig != nil:
+		return append(fields, oidcAppChangeFields(rm.app.OIDCConfig, rm.hashedSecret)...)
+	case rm.app.APIConfig != nil:
+		return append(fields, apiAppChangeFields(rm.app.APIConfig, rm.hashedSecret)...)
+	case rm.app.SAMLConfig != nil:
+		return append(fields, samlAppChangeFields(rm.app.SAMLConfig)...)
+	case rm.app.WSFedConfig != nil:
+		return append(fields, wsFedAppChangeFields(rm.app.WSFedConfig)...)
+	case rm.app.CASConfig != nil:
+		return append(fields, casAppChangeFields(rm.app.CASConfig)...)
+	}
+	return fields
+}
+
+func oidcAppChangeFields(config *OIDCApp, hashedSecret string) []resourceField {
+	return []resourceField{
+		textField("client_id", config.ClientID),
+		hiddenField("client_secret", hashedSecret),
+		listField("redirect_uris", config.RedirectURIs),
+		enumListField("response_types", config.ResponseTypes, "code", "id_token", "id_token token"),
+		enumListField("grant_types", config.GrantTypes, "authorization_code", "implicit", "refresh_token", "device_code", "token_exchange"),
+		enumField("app_type", config.AppType, "web", "user_agent", "native"),
+		enumField("auth_method_type", config.AuthMethodType, "basic", "post", "none", "private_key_jwt"),
+		listField("post_logout_redirect_uris", config.PostLogoutRedirectURIs),
+		boolField("dev_mode", config.IsDevMode),
+		enumField("access_token_type", config.AccessTokenType, tokenTypeNames...),
+		boolField("access_token_role_assertion", config.AssertAccessTokenRole),
+		boolField("id_token_role_assertion", config.AssertIDTokenRole),
+		boolField("id_token_userinfo_assertion", config.AssertIDTokenUserinfo),
+		durationField("clock_skew", config.ClockSkew),
+		listField("additional_origins", config.AdditionalOrigins),
+		boolField("skip_native_app_success_page", config.SkipNativeAppSuccessPage),
+		textField("back_channel_logout_uri", config.BackChannelLogoutURI),
+		textField("front_channel_logout_uri", config.FrontChannelLogoutURI),
+		textField("login_base_uri", gu.Value(config.LoginBaseURI)),
+		durationField("refresh_token_lifetime", config.RefreshTokenLifetime),
+		durationField("refresh_token_idle_lifetime", config.RefreshTokenIdleLifetime),
+		textField("jwks_uri", config.JWKSURI),
+	}
+}
+
+func apiAppChangeFields(config *APIApp, hashedSecret string) []resourceField {
+	return []resourceField{
+		textField("client_id", config.ClientID),
+		hiddenField("client_secret", hashedSecret),
+		enumField("auth_method_type", config.AuthMethodType, "basic", "private_key_jwt"),
+		textField("resource_uri", config.ResourceURI),
+		boolField("introspection_signed_response", config.IntrospectionSignedResponse),
+	}
+}
+
+func samlAppChangeFields(config *SAMLApp) []resourceField {
+	return []resourceField{
+		textField("entity_id", config.EntityID),
+		textField("metadata_url", config.MetadataURL),
+		hiddenField("metadata", string(config.Metadata)),
+		textField("login_base_uri", gu.Value(config.LoginBaseURI)),
+	}
+}
+
+func wsFedAppChangeFields(config *WSFedApp) []resourceField {
+	return []resourceField{
+		textField("realm", config.Realm),
+		listField("reply_urls", config.ReplyURLs),
+	}
+}
+
+func casAppChangeFields(config *CASApp) []resourceField {
+	return []resourceField{
+		listField("service_urls", config.ServiceURLs),
+		textField("login_base_uri", gu.Value(config.LoginBaseURI)),
+	}
+}
+
+// policyChangesQuery returns the query for the events of the custom policy of the organization.
+func policyChangesQuery(orgID string, eventTypes ...eventstore.EventType) *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(org.AggregateType).
+		AggregateIDs(orgID).
+		EventTypes(eventTypes...).
+		Builder()
+}
+
+// loginPolicyChangesReadModel reconstructs the custom login policy of an organization,
+// the state is kept separately as the login policy has none.
+type loginPolicyChangesReadModel struct {
+	*eventstore.ReadModel
+
+	policy LoginPolicy
+	state  domain.PolicyState
+}
+
+func (rm *loginPolicyChangesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.LoginPolicyAddedEvent:
+			rm.policy.AllowUsernamePassword = e.AllowUserNamePassword
+			rm.policy.AllowRegister = e.AllowRegister
+			rm.policy.AllowExternalIDPs = e.AllowExternalIDP
+			rm.policy.ForceMFA = e.ForceMFA
+			rm.policy.ForceMFALocalOnly = e.ForceMFALocalOnly
+			rm.policy.HidePasswordReset = e.HidePasswordReset
+			rm.policy.IgnoreUnknownUsernames = e.IgnoreUnknownUsernames
+			rm.policy.AllowDomainDiscovery = e.AllowDomainDiscovery
+			rm.policy.DisableLoginWithEmail = e.DisableLoginWithEmail
+			rm.policy.DisableLoginWithPhone = e.DisableLoginWithPhone
+			rm.policy.PasswordlessType = e.PasswordlessType
+			rm.policy.DefaultRedirectURI = e.DefaultRedirectURI
+			rm.policy.PasswordCheckLifetime = database.Duration(e.PasswordCheckLifetime)
+			rm.policy.ExternalLoginCheckLifetime = database.Duration(e.ExternalLoginCheckLifetime)
+			rm.policy.MFAInitSkipLifetime = database.Duration(e.MFAInitSkipLifetime)
+			rm.policy.SecondFactorCheckLifetime = database.Duration(e.SecondFactorCheckLifetime)
+			rm.policy.MultiFactorCheckLifetime = database.Duration(e.MultiFactorCheckLifetime)
+			rm.state = domain.PolicyStateActive
+		case *org.LoginPolicyChangedEvent:
+			rm.reduceChanged(e)
+		case *org.LoginPolicyRemovedEvent:
+			rm.state = domain.PolicyStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *loginPolicyChangesReadModel) reduceChanged(e *org.LoginPolicyChangedEvent) {
+	if e.AllowUserNamePassword != nil {
+		rm.policy.AllowUsernamePassword = *e.AllowUserNamePassword
+	}
+	if e.AllowRegister != nil {
+		rm.policy.AllowRegister = *e.AllowRegister
+	}
+	if e.AllowExternalIDP != nil {
+		rm.policy.AllowExternalIDPs = *e.AllowExternalIDP
+	}
+	if e.ForceMFA != nil {
+		rm.policy.ForceMFA = *e.ForceMFA
+	}
+	if e.ForceMFALocalOnly != nil {
+		rm.policy.ForceMFALocalOnly = *e.ForceMFALocalOnly
+	}
+	if e.HidePasswordReset != nil {
+		rm.policy.HidePasswordReset = *e.HidePasswordReset
+	}
+	if e.IgnoreUnknownUsernames != nil {
+		rm.policy.IgnoreUnknownUsernames = *e.IgnoreUnknownUsernames
+	}
+	if e.AllowDomainDiscovery != nil {
+		rm.policy.AllowDomainDiscovery = *e.AllowDomainDiscovery
+	}
+	if e.DisableLoginWithEmail != nil {
+		rm.policy.DisableLoginWithEmail = *e.DisableLoginWithEmail
+	}
+	if e.DisableLoginWithPhone != nil {
+		rm.policy.DisableLoginWithPhone = *e.DisableLoginWithPhone
+	}
+	if e.PasswordlessType != nil {
+		rm.policy.PasswordlessType = *e.PasswordlessType
+	}
+	if e.DefaultRedirectURI != nil {
+		rm.policy.DefaultRedirectURI = *e.DefaultRedirectURI
+	}
+	if e.PasswordCheckLifetime != nil {
+		rm.policy.PasswordCheckLifetime = database.Duration(*e.PasswordCheckLifetime)
+	}
+	if e.ExternalLoginCheckLifetime != nil {
+		rm.policy.ExternalLoginCheckLifetime = database.Duration(*e.ExternalLoginCheckLifetime)
+	}
+	if e.MFAInitSkipLifetime != nil {
+		rm.policy.MFAInitSkipLifetime = database.Duration(*e.MFAInitSkipLifetime)
+	}
+	if e.SecondFactorCheckLifetime != nil {
+		rm.policy.SecondFactorCheckLifetime = database.Duration(*e.SecondFactorCheckLifetime)
+	}
+	if e.MultiFactorCheckLifetime != nil {
+		rm.policy.MultiFactorCheckLifetime = database.Duration(*e.MultiFactorCheckLifetime)
+	}
+}
+
+func (rm *loginPolicyChangesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return policyChangesQuery(rm.AggregateID, org.LoginPolicyAddedEventType, org.LoginPolicyChangedEventType, org.LoginPolicyRemovedEventType)
+}
+
+func (rm *loginPolicyChangesReadModel) changeFields() []resourceField {
+	return []resourceField{
+		enumField("state", rm.state, policyStateNames...),
+		boolField("allow_username_password", rm.policy.AllowUsernamePassword),
+		boolField("allow_register", rm.policy.AllowRegister),
+		boolField("allow_external_idp", rm.policy.AllowExternalIDPs),
+		boolField("force_mfa", rm.policy.ForceMFA),
+		boolField("force_mfa_local_only", rm.policy.ForceMFALocalOnly),
+		boolField("hide_password_reset", rm.policy.HidePasswordReset),
+		boolField("ignore_unknown_usernames", rm.policy.IgnoreUnknownUsernames),
+		boolField("allow_domain_discovery", rm.policy.AllowDomainDiscovery),
+		boolField("disable_login_with_email", rm.policy.DisableLoginWithEmail),
+		boolField("disable_login_with_phone", rm.policy.DisableLoginWithPhone),
+		enumField("passwordless_type", rm.policy.PasswordlessType, "not_allowed", "allowed"),
+		textField("default_redirect_uri", rm.policy.DefaultRedirectURI),
+		durationField("password_check_lifetime", time.Duration(rm.policy.PasswordCheckLifetime)),
+		durationField("external_login_check_lifetime", time.Duration(rm.policy.ExternalLoginCheckLifetime)),
+		durationField("mfa_init_skip_lifetime", time.Duration(rm.policy.MFAInitSkipLifetime)),
+		durationField("second_factor_check_lifetime", time.Duration(rm.policy.SecondFactorCheckLifetime)),
+		durationField("multi_factor_check_lifetime", time.Duration(rm.policy.MultiFactorCheckLifetime)),
+	}
+}
+
+// passwordComplexityPolicyChangesReadModel reconstructs the custom password complexity policy of an organization
+type passwordComplexityPolicyChangesReadModel struct {
+	*eventstore.ReadModel
+
+	policy PasswordComplexityPolicy
+}
+
+func (rm *passwordComplexityPolicyChangesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.PasswordComplexityPolicyAddedEvent:
+			rm.policy.MinLength = e.MinLength
+			rm.policy.HasLowercase = e.HasLowercase
+			rm.policy.HasUppercase = e.HasUppercase
+			rm.policy.HasNumber = e.HasNumber
+			rm.policy.HasSymbol = e.HasSymbol
+			rm.policy.State = domain.PolicyStateActive
+		case *org.PasswordComplexityPolicyChangedEvent:
+			if e.MinLength != nil {
+				rm.policy.MinLength = *e.MinLength
+			}
+			if e.HasLowercase != nil {
+				rm.policy.HasLowercase = *e.HasLowercase
+			}
+			if e.HasUppercase != nil {
+				rm.policy.HasUppercase = *e.HasUppercase
+			}
+			if e.HasNumber != nil {
+				rm.policy.HasNumber = *e.HasNumber
+			}
+			if e.HasSymbol != nil {
+				rm.policy.HasSymbol = *e.HasSymbol
+			}
+		case *org.PasswordComplexityPolicyRemovedEvent:
+			rm.policy.State = domain.PolicyStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *passwordComplexityPolicyChangesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return policyChangesQuery(rm.AggregateID, org.PasswordComplexityPolicyAddedEventType, org.PasswordComplexityPolicyChangedEventType, org.PasswordComplexityPolicyRemovedEventType)
+}
+
+func (rm *passwordComplexityPolicyChangesReadModel) changeFields() []resourceField {
+	return []resourceField{
+		enumField("state", rm.policy.State, policyStateNames...),
+		numberField("min_length", rm.policy.MinLength),
+		boolField("has_lowercase", rm.policy.HasLowercase),
+		boolField("has_uppercase", rm.policy.HasUppercase),
+		boolField("has_number", rm.policy.HasNumber),
+		boolField("has_symbol", rm.policy.HasSymbol),
+	}
+}
+
+// passwordAgePolicyChangesReadModel reconstructs the custom password age policy of an organization
+type passwordAgePolicyChangesReadModel struct {
+	*eventstore.ReadModel
+
+	policy PasswordAgePolicy
+}
+
+func (rm *passwordAgePolicyChangesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.PasswordAgePolicyAddedEvent:
+			rm.policy.ExpireWarnDays = e.ExpireWarnDays
+			rm.policy.MaxAgeDays = e.MaxAgeDays
+			rm.policy.State = domain.PolicyStateActive
+		case *org.PasswordAgePolicyChangedEvent:
+			if e.ExpireWarnDays != nil {
+				rm.policy.ExpireWarnDays = *e.ExpireWarnDays
+			}
+			if e.MaxAgeDays != nil {
+				rm.policy.MaxAgeDays = *e.MaxAgeDays
+			}
+		case *org.PasswordAgePolicyRemovedEvent:
+			rm.policy.State = domain.PolicyStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *passwordAgePolicyChangesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return policyChangesQuery(rm.AggregateID, org.PasswordAgePolicyAddedEventType, org.PasswordAgePolicyChangedEventType, org.PasswordAgePolicyRemovedEventType)
+}
+
+func (rm *passwordAgePolicyChangesReadModel) changeFields() []resourceField {
+	return []resourceField{
+		enumField("state", rm.policy.State, policyStateNames...),
+		numberField("expire_warn_days", rm.policy.ExpireWarnDays),
+		numberField("max_age_days", rm.policy.MaxAgeDays),
+	}
+}
+
+// lockoutPolicyChangesReadModel reconstructs the custom lockout policy of an organization
+type lockoutPolicyChangesReadModel struct {
+	*eventstore.ReadModel
+
+	policy LockoutPolicy
+}
+
+func (rm *lockoutPolicyChangesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.LockoutPolicyAddedEvent:
+			rm.policy.MaxPasswordAttempts = e.MaxPasswordAttempts
+			rm.policy.MaxOTPAttempts = e.MaxOTPAttempts
+			rm.policy.ShowFailures = e.ShowLockOutFailures
+			rm.policy.State = domain.PolicyStateActive
+		case *org.LockoutPolicyChangedEvent:
+			if e.MaxPasswordAttempts != nil {
+				rm.policy.MaxPasswordAttempts = *e.MaxPasswordAttempts
+			}
+			if e.MaxOTPAttempts != nil {
+				rm.policy.MaxOTPAttempts = *e.MaxOTPAttempts
+			}
+			if e.ShowLockOutFailures != nil {
+				rm.policy.ShowFailures = *e.ShowLockOutFailures
+			}
+		case *org.LockoutPolicyRemovedEvent:
+			rm.policy.State = domain.PolicyStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *lockoutPolicyChangesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return policyChangesQuery(rm.AggregateID, org.LockoutPolicyAddedEventType, org.LockoutPolicyChangedEventType, org.LockoutPolicyRemovedEventType)
+}
+
+func (rm *lockoutPolicyChangesReadModel) changeFields() []resourceField {
+	return []resourceField{
+		enumField("state", rm.policy.State, policyStateNames...),
+		numberField("max_password_attempts", rm.policy.MaxPasswordAttempts),
+		numberField("max_otp_attempts", rm.policy.MaxOTPAttempts),
+		boolField("show_lockout_failures", rm.policy.ShowFailures),
+	}
+}
+
+// privacyPolicyChangesReadModel reconstructs the custom privacy policy of an organization
+type privacyPolicyChangesReadModel struct {
+	*eventstore.ReadModel
+
+	policy PrivacyPolicy
+}
+
+func (rm *privacyPolicyChangesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.PrivacyPolicyAddedEvent:
+			rm.policy.TOSLink = e.TOSLink
+			rm.policy.PrivacyLink = e.PrivacyLink
+			rm.policy.HelpLink = e.HelpLink
+			rm.policy.SupportEmail = e.SupportEmail
+			rm.policy.DocsLink = e.DocsLink
+			rm.policy.CustomLink = e.CustomLink
+			rm.policy.CustomLinkText = e.CustomLinkText
+			rm.policy.State = domain.PolicyStateActive
+		case *org.PrivacyPolicyChangedEvent:
+			rm.reduceChanged(e)
+		case *org.PrivacyPolicyRemovedEvent:
+			rm.policy.State = domain.PolicyStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *privacyPolicyChangesReadModel) reduceChanged(e *org.PrivacyPolicyChangedEvent) {
+	if e.TOSLink != nil {
+		rm.policy.TOSLink = *e.TOSLink
+	}
+	if e.PrivacyLink != nil {
+		rm.policy.PrivacyLink = *e.PrivacyLink
+	}
+	if e.HelpLink != nil {
+		rm.policy.HelpLink = *e.HelpLink
+	}
+	if e.SupportEmail != nil {
+		rm.policy.SupportEmail = *e.SupportEmail
+	}
+	if e.DocsLink != nil {
+		rm.policy.DocsLink = *e.DocsLink
+	}
+	if e.CustomLink != nil {
+		rm.policy.CustomLink = *e.CustomLink
+	}
+	if e.CustomLinkText != nil {
+		rm.policy.CustomLinkText = *e.CustomLinkText
+	}
+}
+
+func (rm *privacyPolicyChangesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return policyChangesQuery(rm.AggregateID, org.PrivacyPolicyAddedEventType, org.PrivacyPolicyChangedEventType, org.PrivacyPolicyRemovedEventType)
+}
+
+func (rm *privacyPolicyChangesReadModel) changeFields() []resourceField {
+	return []resourceField{
+		enumField("state", rm.policy.State, policyStateNames...),
+		textField("tos_link", rm.policy.TOSLink),
+		textField("privacy_link", rm.policy.PrivacyLink),
+		textField("help_link", rm.policy.HelpLink),
+		textField("support_email", string(rm.policy.SupportEmail)),
+		textField("docs_link", rm.policy.DocsLink),
+		textField("custom_link", rm.policy.CustomLink),
+		textField("custom_link_text", rm.policy.CustomLinkText),
+	}
+}
+
+// notificationPolicyChangesReadModel reconstructs the custom notification policy of an organization
+type notificationPolicyChangesReadModel struct {
+	*eventstore.ReadModel
+
+	policy NotificationPolicy
+}
+
+func (rm *notificationPolicyChangesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.NotificationPolicyAddedEvent:
+			rm.policy.PasswordChange = e.PasswordChange
+			rm.policy.State = domain.PolicyStateActive
+		case *org.NotificationPolicyChangedEvent:
+			if e.PasswordChange != nil {
+				rm.policy.PasswordChange = *e.PasswordChange
+			}
+		case *org.NotificationPolicyRemovedEvent:
+			rm.policy.State = domain.PolicyStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *notificationPolicyChangesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return policyChangesQuery(rm.AggregateID, org.NotificationPolicyAddedEventType, org.NotificationPolicyChangedEventType, org.NotificationPolicyRemovedEventType)
+}
+
+func (rm *notificationPolicyChangesReadModel) changeFields() []resourceField {
+	return []resourceField{
+		enumField("state", rm.policy.State, policyStateNames...),
+		boolField("password_change", rm.policy.PasswordChange),
+	}
+}
+
+// domainPolicyChangesReadModel reconstructs the custom domain policy of an organization
+type domainPolicyChangesReadModel struct {
+	*eventstore.ReadModel
+
+	policy DomainPolicy
+}
+
+func (rm *domainPolicyChangesReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *org.DomainPolicyAddedEvent:
+			rm.policy.UserLoginMustBeDomain = e.UserLoginMustBeDomain
+			rm.policy.ValidateOrgDomains = e.ValidateOrgDomains
+			rm.policy.SMTPSenderAddressMatchesInstanceDomain = e.SMTPSenderAddressMatchesInstanceDomain
+			rm.policy.State = domain.PolicyStateActive
+		case *org.DomainPolicyChangedEvent:
+			if e.UserLoginMustBeDomain != nil {
+				rm.policy.UserLoginMustBeDomain = *e.UserLoginMustBeDomain
+			}
+			if e.ValidateOrgDomains != nil {
+				rm.policy.ValidateOrgDomains = *e.ValidateOrgDomains
+			}
+			if e.SMTPSenderAddressMatchesInstanceDomain != nil {
+				rm.policy.SMTPSenderAddressMatchesInstanceDomain = *e.SMTPSenderAddressMatchesInstanceDomain
+			}
+		case *org.DomainPolicyRemovedEvent:
+			rm.policy.State = domain.PolicyStateRemoved
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *domainPolicyChangesReadModel) Query() *eventstore.SearchQueryBuilder {
+	return policyChangesQuery(rm.AggregateID, org.DomainPolicyAddedEventType, org.DomainPolicyChangedEventType, org.DomainPolicyRemovedEventType)
+}
+
+func (rm *domainPolicyChangesReadModel) changeFields() []resourceField {
+	return []resourceField{
+		enumField("state", rm.policy.State, policyStateNames...),
+		boolField("user_login_must_be_domain", rm.policy.UserLoginMustBeDomain),
+		boolField("validate_org_domains", rm.policy.ValidateOrgDomains),
+		boolField("smtp_sender_address_matches_instance_domain", rm.policy.SMTPSenderAddressMatchesInstanceDomain),
+	}
+}
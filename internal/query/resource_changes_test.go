@@ -0,0 +1,249 @@
+package query
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func Test_diffResourceFields(t *testing.T) {
+	tests := []struct {
+		name   string
+		before []resourceField
+		after  []resourceField
+		want   []*ResourceFieldChange
+	}{
+		{
+			name:   "unchanged",
+			before: []resourceField{textField("name", "org"), boolField("active", true)},
+			after:  []resourceField{textField("name", "org"), boolField("active", true)},
+			want:   []*ResourceFieldChange{},
+		},
+		{
+			name:   "changed",
+			before: []resourceField{textField("name", "org"), boolField("active", true)},
+			after:  []resourceField{textField("name", "org2"), boolField("active", true)},
+			want: []*ResourceFieldChange{
+				{Name: "name", Before: "org", After: "org2"},
+			},
+		},
+		{
+			name:   "hidden value changed",
+			before: []resourceField{hiddenField("client_secret", "hash1")},
+			after:  []resourceField{hiddenField("client_secret", "hash2")},
+			want: []*ResourceFieldChange{
+				{Name: "client_secret"},
+			},
+		},
+		{
+			name:   "field added and removed",
+			before: []resourceField{textField("name", "app"), textField("realm", "urn:app")},
+			after:  []resourceField{textField("name", "app"), listField("redirect_uris", []string{"https://a.com", "https://b.com"})},
+			want: []*ResourceFieldChange{
+				{Name: "redirect_uris", After: "https://a.com, https://b.com"},
+				{Name: "realm", Before: "urn:app"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffResourceFields(tt.before, tt.after))
+		})
+	}
+}
+
+func Test_enumField(t *testing.T) {
+	assert.Equal(t, "inactive", enumField("state", domain.OrgStateInactive, "unspecified", "active", "inactive", "removed").value)
+	assert.Equal(t, "-1", enumField("response_type", domain.OIDCResponseTypeUnspecified, "code").value)
+	assert.Equal(t, "7", enumField("state", domain.OrgState(7), "unspecified").value)
+}
+
+func TestQueries_ResourceChanges(t *testing.T) {
+	ctx := authz.NewMockContext("instance1", "org1", "user1")
+	orgAgg := &org.NewAggregate("org1").Aggregate
+	added := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	renamed := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	deactivated := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	withCreationDate := func(event *repository.Event, creationDate time.Time) *repository.Event {
+		event.CreationDate = creationDate
+		return event
+	}
+	orgEvents := func() expect {
+		return expectFilter(
+			withCreationDate(eventFromEventPusher(org.NewOrgAddedEvent(ctx, orgAgg, "org")), added),
+			withCreationDate(eventFromEventPusher(org.NewOrgChangedEvent(ctx, orgAgg, "org", "org2")), renamed),
+			withCreationDate(eventFromEventPusher(org.NewOrgDeactivatedEvent(ctx, orgAgg)), deactivated),
+		)
+	}
+	allowed := func(context.Context, string, string, string) error { return nil }
+	denied := func(context.Context, string, string, string) error {
+		return zerrors.ThrowPermissionDenied(nil, "id", "denied")
+	}
+	type fields struct {
+		eventstore      func(*testing.T) *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type res struct {
+		changes *ResourceChanges
+		err     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		query  *ResourceChangesQuery
+		res    res
+	}{
+		{
+			name: "missing id, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			query: &ResourceChangesQuery{Type: ResourceChangesTypeOrg},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "missing type, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			query: &ResourceChangesQuery{ID: "org1"},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "invalid period, error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			query: &ResourceChangesQuery{Type: ResourceChangesTypeOrg, ID: "org1", From: deactivated, To: added},
+			res: res{
+				err: zerrors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "org not found, error",
+			fields: fields{
+				eventstore: expectEventstore(
+					expectFilter(),
+				),
+			},
+			query: &ResourceChangesQuery{Type: ResourceChangesTypeOrg, ID: "org1"},
+			res: res{
+				err: zerrors.IsNotFound,
+			},
+		},
+		{
+			name: "missing permission, error",
+			fields: fields{
+				eventstore:      expectEventstore(orgEvents()),
+				checkPermission: denied,
+			},
+			query: &ResourceChangesQuery{Type: ResourceChangesTypeOrg, ID: "org1"},
+			res: res{
+				err: zerrors.IsPermissionDenied,
+			},
+		},
+		{
+			name: "all changes, descending",
+			fields: fields{
+				eventstore:      expectEventstore(orgEvents()),
+				checkPermission: allowed,
+			},
+			query: &ResourceChangesQuery{Type: ResourceChangesTypeOrg, ID: "org1"},
+			res: res{
+				changes: &ResourceChanges{
+					TotalCount: 3,
+					Changes: []*ResourceChange{
+						{
+							EventType:     org.OrgDeactivatedEventType,
+							CreationDate:  deactivated,
+							ResourceOwner: "org1",
+							EditorID:      "user1",
+							Fields: []*ResourceFieldChange{
+								{Name: "state", Before: "active", After: "inactive"},
+							},
+						},
+						{
+							EventType:     org.OrgChangedEventType,
+							CreationDate:  renamed,
+							ResourceOwner: "org1",
+							EditorID:      "user1",
+							Fields: []*ResourceFieldChange{
+								{Name: "name", Before: "org", After: "org2"},
+							},
+						},
+						{
+							EventType:     org.OrgAddedEventType,
+							CreationDate:  added,
+							ResourceOwner: "org1",
+							EditorID:      "user1",
+							Fields: []*ResourceFieldChange{
+								{Name: "name", Before: "", After: "org"},
+								{Name: "state", Before: "unspecified", After: "active"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "field and period, ascending with limit",
+			fields: fields{
+				eventstore:      expectEventstore(orgEvents()),
+				checkPermission: allowed,
+			},
+			query: &ResourceChangesQuery{
+				Type:   ResourceChangesTypeOrg,
+				ID:     "org1",
+				Fields: []string{"state"},
+				From:   renamed,
+				Asc:    true,
+				Limit:  1,
+			},
+			res: res{
+				changes: &ResourceChanges{
+					TotalCount: 1,
+					Changes: []*ResourceChange{
+						{
+							EventType:     org.OrgDeactivatedEventType,
+							CreationDate:  deactivated,
+							ResourceOwner: "org1",
+							EditorID:      "user1",
+							Fields: []*ResourceFieldChange{
+								{Name: "state", Before: "active", After: "inactive"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queries{
+				eventstore:      tt.fields.eventstore(t),
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := q.ResourceChanges(ctx, tt.query)
+			if tt.res.err != nil {
+				assert.True(t, tt.res.err(err))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.changes, got)
+		})
+	}
+}
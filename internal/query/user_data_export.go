@@ -0,0 +1,143 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// UserDataExport is an export of the personal data of a user.
+type UserDataExport struct {
+	ID             string
+	UserID         string
+	ResourceOwner  string
+	CreationDate   time.Time
+	ChangeDate     time.Time
+	State          domain.UserDataExportState
+	ObjectName     string
+	Size           int64
+	Code           *crypto.CryptoValue
+	ExpirationDate time.Time
+}
+
+// UserDataExportByID returns the export of the user.
+// Users can read their own exports, others need the permission to read the user.
+func (q *Queries) UserDataExportByID(ctx context.Context, userID, exportID string, permissionCheck domain.PermissionCheck) (_ *UserDataExport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if userID == "" || exportID == "" {
+		return nil, zerrors.ThrowInvalidArgument(nil, "QUERY-Xk3wd", "Errors.IDMissing")
+	}
+	exports, err := q.userDataExports(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, export := range exports {
+		if export.ID != exportID {
+			continue
+		}
+		if err = userCheckPermission(ctx, export.ResourceOwner, userID, permissionCheck); err != nil {
+			return nil, err
+		}
+		return export, nil
+	}
+	return nil, zerrors.ThrowNotFound(nil, "QUERY-Fe8ob", "Errors.User.DataExport.NotFound")
+}
+
+// UserDataExports returns all exports of the user, including the ones of removed users.
+// No permission is checked, it's used to clean up the stored archives.
+func (q *Queries) UserDataExports(ctx context.Context, userID string) (_ []*UserDataExport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	return q.userDataExports(ctx, userID)
+}
+
+func (q *Queries) userDataExports(ctx context.Context, userID string) ([]*UserDataExport, error) {
+	readModel := newUserDataExportsReadModel(userID)
+	if err := q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	return readModel.Exports, nil
+}
+
+type userDataExportsReadModel struct {
+	*eventstore.ReadModel
+
+	Exports []*UserDataExport
+}
+
+func newUserDataExportsReadModel(userID string) *userDataExportsReadModel {
+	return &userDataExportsReadModel{
+		ReadModel: &eventstore.ReadModel{
+			AggregateID: userID,
+		},
+	}
+}
+
+func (rm *userDataExportsReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *user.HumanDataExportRequestedEvent:
+			rm.Exports = append(rm.Exports, &UserDataExport{
+				ID:            e.ID,
+				UserID:        e.Aggregate().ID,
+				ResourceOwner: e.Aggregate().ResourceOwner,
+				CreationDate:  e.CreationDate(),
+				ChangeDate:    e.CreationDate(),
+				State:         domain.UserDataExportStateRequested,
+			})
+		case *user.HumanDataExportSucceededEvent:
+			export := rm.export(e.ID, e.CreationDate())
+			if export == nil {
+				continue
+			}
+			export.State = domain.UserDataExportStateReady
+			export.ObjectName = e.ObjectName
+			export.Size = e.Size
+			export.Code = e.Code
+			export.ExpirationDate = e.CreationDate().Add(e.Expiry)
+		case *user.HumanDataExportFailedEvent:
+			if export := rm.export(e.ID, e.CreationDate()); export != nil {
+				export.State = domain.UserDataExportStateFailed
+			}
+		}
+	}
+	for _, export := range rm.Exports {
+		if export.State == domain.UserDataExportStateReady && export.ExpirationDate.Before(time.Now()) {
+			export.State = domain.UserDataExportStateExpired
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *userDataExportsReadModel) export(id string, changeDate time.Time) *UserDataExport {
+	for _, export := range rm.Exports {
+		if export.ID == id {
+			export.ChangeDate = changeDate
+			return export
+		}
+	}
+	return nil
+}
+
+func (rm *userDataExportsReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			user.HumanDataExportRequestedType,
+			user.HumanDataExportSucceededType,
+			user.HumanDataExportFailedType,
+		).
+		Builder()
+}
@@ -103,21 +103,39 @@ type UserInfoUserGroup struct {
 //go:embed userinfo_client_by_id.sql
 var oidcUserinfoClientQuery string
 
-func (q *Queries) GetOIDCUserinfoClientByID(ctx context.Context, clientID string) (projectID string, projectRoleAssertion bool, err error) {
+// OIDCUserinfoClient is the part of the client configuration needed to respond on the userinfo endpoint.
+type OIDCUserinfoClient struct {
+	ProjectID                    string
+	ProjectRoleAssertion         bool
+	UserinfoEncryptedResponseAlg string
+	UserinfoEncryptedResponseEnc string
+	JWKS                         string
+	JWKSURI                      string
+}
+
+func (q *Queries) GetOIDCUserinfoClientByID(ctx context.Context, clientID string) (client *OIDCUserinfoClient, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
+	client = new(OIDCUserinfoClient)
 	scan := func(row *sql.Row) error {
-		err := row.Scan(&projectID, &projectRoleAssertion)
+		err := row.Scan(
+			&client.ProjectID,
+			&client.ProjectRoleAssertion,
+			&client.UserinfoEncryptedResponseAlg,
+			&client.UserinfoEncryptedResponseEnc,
+			&client.JWKS,
+			&client.JWKSURI,
+		)
 		return err
 	}
 
 	err = q.client.QueryRowContext(ctx, scan, oidcUserinfoClientQuery, authz.GetInstance(ctx).InstanceID(), clientID)
 	if errors.Is(err, sql.ErrNoRows) {
-		return "", false, zerrors.ThrowNotFound(err, "QUERY-beeW8", "Errors.App.NotFound")
+		return nil, zerrors.ThrowNotFound(err, "QUERY-beeW8", "Errors.App.NotFound")
 	}
 	if err != nil {
-		return "", false, zerrors.ThrowInternal(err, "QUERY-Ais4r", "Errors.Internal")
+		return nil, zerrors.ThrowInternal(err, "QUERY-Ais4r", "Errors.Internal")
 	}
-	return projectID, projectRoleAssertion, nil
+	return client, nil
 }
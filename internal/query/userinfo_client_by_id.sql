@@ -1,4 +1,5 @@
-select a.project_id, p.project_role_assertion
+select a.project_id, p.project_role_assertion,
+    c.userinfo_encrypted_response_alg, c.userinfo_encrypted_response_enc, c.jwks, c.jwks_uri
 from projections.apps7_oidc_configs c
 join projections.apps7 a on a.id = c.app_id and a.instance_id = c.instance_id
 join projections.projects4 p on p.id = a.project_id and p.instance_id = a.instance_id
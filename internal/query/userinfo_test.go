@@ -444,14 +444,13 @@ func TestQueries_GetOIDCUserInfo(t *testing.T) {
 
 func TestQueries_GetOIDCUserinfoClientByID(t *testing.T) {
 	expQuery := regexp.QuoteMeta(oidcUserinfoClientQuery)
-	cols := []string{"project_id", "project_role_assertion"}
+	cols := []string{"project_id", "project_role_assertion", "userinfo_encrypted_response_alg", "userinfo_encrypted_response_enc", "jwks", "jwks_uri"}
 
 	tests := []struct {
-		name                     string
-		mock                     sqlExpectation
-		wantProjectID            string
-		wantProjectRoleAssertion bool
-		wantErr                  error
+		name    string
+		mock    sqlExpectation
+		want    *OIDCUserinfoClient
+		wantErr error
 	}{
 		{
 			name:    "no rows",
@@ -464,10 +463,22 @@ func TestQueries_GetOIDCUserinfoClientByID(t *testing.T) {
 			wantErr: zerrors.ThrowInternal(sql.ErrConnDone, "QUERY-Ais4r", "Errors.Internal"),
 		},
 		{
-			name:                     "found",
-			mock:                     mockQuery(expQuery, cols, []driver.Value{"projectID", true}, "instanceID", "clientID"),
-			wantProjectID:            "projectID",
-			wantProjectRoleAssertion: true,
+			name: "found",
+			mock: mockQuery(expQuery, cols, []driver.Value{"projectID", true, "", "", "", ""}, "instanceID", "clientID"),
+			want: &OIDCUserinfoClient{
+				ProjectID:            "projectID",
+				ProjectRoleAssertion: true,
+			},
+		},
+		{
+			name: "found, encrypted response",
+			mock: mockQuery(expQuery, cols, []driver.Value{"projectID", false, "RSA-OAEP-256", "A256GCM", "", "https://client.example.com/jwks"}, "instanceID", "clientID"),
+			want: &OIDCUserinfoClient{
+				ProjectID:                    "projectID",
+				UserinfoEncryptedResponseAlg: "RSA-OAEP-256",
+				UserinfoEncryptedResponseEnc: "A256GCM",
+				JWKSURI:                      "https://client.example.com/jwks",
+			},
 		},
 	}
 	for _, tt := range tests {
@@ -479,10 +490,9 @@ func TestQueries_GetOIDCUserinfoClientByID(t *testing.T) {
 					},
 				}
 				ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
-				gotProjectID, gotProjectRoleAssertion, err := q.GetOIDCUserinfoClientByID(ctx, "clientID")
+				got, err := q.GetOIDCUserinfoClientByID(ctx, "clientID")
 				require.ErrorIs(t, err, tt.wantErr)
-				assert.Equal(t, tt.wantProjectID, gotProjectID)
-				assert.Equal(t, tt.wantProjectRoleAssertion, gotProjectRoleAssertion)
+				assert.Equal(t, tt.want, got)
 			})
 		})
 	}
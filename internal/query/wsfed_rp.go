@@ -0,0 +1,97 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	_ "embed"
+	"errors"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// WSFedRelyingParty is an active WS-Federation application, identified by its realm (wtrealm).
+type WSFedRelyingParty struct {
+	InstanceID           string                `json:"instance_id,omitempty"`
+	AppID                string                `json:"app_id,omitempty"`
+	State                domain.AppState       `json:"state,omitempty"`
+	Realm                string                `json:"realm,omitempty"`
+	ReplyURLs            []string              `json:"reply_urls,omitempty"`
+	TokenType            domain.WSFedTokenType `json:"token_type,omitempty"`
+	ProjectID            string                `json:"project_id,omitempty"`
+	ProjectRoleAssertion bool                  `json:"project_role_assertion,omitempty"`
+}
+
+// ReplyURL returns the requested reply url if it is registered,
+// the first registered one if none was requested
+// and false if the requested one is not allowed.
+func (rp *WSFedRelyingParty) ReplyURL(requested string) (string, bool) {
+	if requested == "" {
+		if len(rp.ReplyURLs) == 0 {
+			return "", false
+		}
+		return rp.ReplyURLs[0], true
+	}
+	return requested, slices.Contains(rp.ReplyURLs, requested)
+}
+
+//go:embed wsfed_rp_by_realm.sql
+var wsFedRPQuery string
+
+func (q *Queries) ActiveWSFedRelyingPartyByRealm(ctx context.Context, realm string) (rp *WSFedRelyingParty, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		rp, err = scanWSFedRelyingParty(row)
+		return err
+	}, wsFedRPQuery,
+		authz.GetInstance(ctx).InstanceID(),
+		realm,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, zerrors.ThrowNotFound(err, "QUERY-Gai5e", "Errors.App.NotFound")
+	}
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "QUERY-ooK7c", "Errors.Internal")
+	}
+	return rp, nil
+}
+
+func scanWSFedRelyingParty(row *sql.Row) (*WSFedRelyingParty, error) {
+	var instanceID, appID, realm, projectID sql.NullString
+	var replyURLs database.TextArray[string]
+	var state, tokenType sql.NullInt16
+	var projectRoleAssertion sql.NullBool
+
+	err := row.Scan(
+		&instanceID,
+		&appID,
+		&state,
+		&realm,
+		&replyURLs,
+		&tokenType,
+		&projectID,
+		&projectRoleAssertion,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, zerrors.ThrowNotFound(err, "QUERY-Jie0a", "Errors.App.NotFound")
+		}
+		return nil, zerrors.ThrowInternal(err, "QUERY-eeR5u", "Errors.Internal")
+	}
+	return &WSFedRelyingParty{
+		InstanceID:           instanceID.String,
+		AppID:                appID.String,
+		State:                domain.AppState(state.Int16),
+		Realm:                realm.String,
+		ReplyURLs:            replyURLs,
+		TokenType:            domain.WSFedTokenType(tokenType.Int16),
+		ProjectID:            projectID.String,
+		ProjectRoleAssertion: projectRoleAssertion.Bool,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+func TestQueries_ActiveWSFedRelyingPartyByRealm(t *testing.T) {
+	expQuery := regexp.QuoteMeta(wsFedRPQuery)
+	cols := []string{
+		"instance_id",
+		"app_id",
+		"state",
+		"realm",
+		"reply_urls",
+		"token_type",
+		"project_id",
+		"project_role_assertion",
+	}
+
+	tests := []struct {
+		name    string
+		mock    sqlExpectation
+		want    *WSFedRelyingParty
+		wantErr error
+	}{
+		{
+			name:    "no rows",
+			mock:    mockQueryErr(expQuery, sql.ErrNoRows, "instanceID", "urn:sharepoint:test"),
+			wantErr: zerrors.ThrowNotFound(sql.ErrNoRows, "QUERY-Gai5e", "Errors.App.NotFound"),
+		},
+		{
+			name:    "internal error",
+			mock:    mockQueryErr(expQuery, sql.ErrConnDone, "instanceID", "urn:sharepoint:test"),
+			wantErr: zerrors.ThrowInternal(sql.ErrConnDone, "QUERY-ooK7c", "Errors.Internal"),
+		},
+		{
+			name: "rp",
+			mock: mockQuery(expQuery, cols, []driver.Value{
+				"230690539048009730",
+				"236647088211886082",
+				domain.AppStateActive,
+				"urn:sharepoint:test",
+				database.TextArray[string]{"https://sharepoint.test.com/_trust/"},
+				domain.WSFedTokenTypeSAML20,
+				"236645808328409090",
+				true,
+			}, "instanceID", "urn:sharepoint:test"),
+			want: &WSFedRelyingParty{
+				InstanceID:           "230690539048009730",
+				AppID:                "236647088211886082",
+				State:                domain.AppStateActive,
+				Realm:                "urn:sharepoint:test",
+				ReplyURLs:            []string{"https://sharepoint.test.com/_trust/"},
+				TokenType:            domain.WSFedTokenTypeSAML20,
+				ProjectID:            "236645808328409090",
+				ProjectRoleAssertion: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			execMock(t, tt.mock, func(db *sql.DB) {
+				q := &Queries{
+					client: &database.DB{
+						DB: db,
+					},
+				}
+				ctx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+				got, err := q.ActiveWSFedRelyingPartyByRealm(ctx, "urn:sharepoint:test")
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Equal(t, tt.want, got)
+			})
+		})
+	}
+}
+
+func TestWSFedRelyingParty_ReplyURL(t *testing.T) {
+	rp := &WSFedRelyingParty{ReplyURLs: []string{"https://rp.test.com/", "https://rp.test.com/other"}}
+	tests := []struct {
+		name      string
+		requested string
+		want      string
+		wantOK    bool
+	}{
+		{"default", "", "https://rp.test.com/", true},
+		{"registered", "https://rp.test.com/other", "https://rp.test.com/other", true},
+		{"unregistered", "https://evil.test.com/", "https://evil.test.com/", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := rp.ReplyURL(tt.requested)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
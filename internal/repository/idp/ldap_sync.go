@@ -0,0 +1,137 @@
+package idp
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// LDAPSyncSetEvent replaces the synchronization configuration of an LDAP IdP.
+type LDAPSyncSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                string                        `json:"id"`
+	Enabled           bool                          `json:"enabled,omitempty"`
+	OrganizationID    string                        `json:"organizationId,omitempty"`
+	Filter            string                        `json:"filter,omitempty"`
+	Interval          time.Duration                 `json:"interval,omitempty"`
+	FullSyncInterval  time.Duration                 `json:"fullSyncInterval,omitempty"`
+	DeltaAttribute    domain.LDAPSyncDeltaAttribute `json:"deltaAttribute,omitempty"`
+	DeactivateMissing bool                          `json:"deactivateMissing,omitempty"`
+}
+
+func NewLDAPSyncSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	config *domain.LDAPSyncConfig,
+) *LDAPSyncSetEvent {
+	return &LDAPSyncSetEvent{
+		BaseEvent:         *base,
+		ID:                id,
+		Enabled:           config.Enabled,
+		OrganizationID:    config.OrganizationID,
+		Filter:            config.Filter,
+		Interval:          config.Interval,
+		FullSyncInterval:  config.FullSyncInterval,
+		DeltaAttribute:    config.DeltaAttribute,
+		DeactivateMissing: config.DeactivateMissing,
+	}
+}
+
+func (e *LDAPSyncSetEvent) Config() *domain.LDAPSyncConfig {
+	return &domain.LDAPSyncConfig{
+		Enabled:           e.Enabled,
+		OrganizationID:    e.OrganizationID,
+		Filter:            e.Filter,
+		Interval:          e.Interval,
+		FullSyncInterval:  e.FullSyncInterval,
+		DeltaAttribute:    e.DeltaAttribute,
+		DeactivateMissing: e.DeactivateMissing,
+	}
+}
+
+func (e *LDAPSyncSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LDAPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LDAPSyncSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Ls4nf", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+// LDAPSyncRunFinishedEvent records the result of a synchronization run of an LDAP IdP.
+type LDAPSyncRunFinishedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID         string               `json:"id"`
+	RunID      string               `json:"runId"`
+	StartedAt  time.Time            `json:"startedAt"`
+	FinishedAt time.Time            `json:"finishedAt"`
+	FullSync   bool                 `json:"fullSync,omitempty"`
+	Cursor     string               `json:"cursor,omitempty"`
+	Stats      domain.LDAPSyncStats `json:"stats"`
+	Error      string               `json:"error,omitempty"`
+	NextRunAt  time.Time            `json:"nextRunAt"`
+
+	DeactivatedUserIDs []string `json:"deactivatedUserIds,omitempty"`
+	ReleasedUserIDs    []string `json:"releasedUserIds,omitempty"`
+}
+
+func NewLDAPSyncRunFinishedEvent(
+	base *eventstore.BaseEvent,
+	id,
+	runID string,
+	run *domain.LDAPSyncRun,
+	nextRunAt time.Time,
+) *LDAPSyncRunFinishedEvent {
+	return &LDAPSyncRunFinishedEvent{
+		BaseEvent:  *base,
+		ID:         id,
+		RunID:      runID,
+		StartedAt:  run.StartedAt,
+		FinishedAt: run.FinishedAt,
+		FullSync:   run.FullSync,
+		Cursor:     run.Cursor,
+		Stats:      run.Stats,
+		Error:      run.Error,
+		NextRunAt:  nextRunAt,
+
+		DeactivatedUserIDs: run.DeactivatedUserIDs,
+		ReleasedUserIDs:    run.ReleasedUserIDs,
+	}
+}
+
+func (e *LDAPSyncRunFinishedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *LDAPSyncRunFinishedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func LDAPSyncRunFinishedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &LDAPSyncRunFinishedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Rk8wb", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
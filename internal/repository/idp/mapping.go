@@ -0,0 +1,48 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+// MappingRulesSetEvent replaces all attribute mapping rules of an IdP.
+type MappingRulesSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID    string                  `json:"id"`
+	Rules []domain.IDPMappingRule `json:"rules,omitempty"`
+}
+
+func NewMappingRulesSetEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	rules []domain.IDPMappingRule,
+) *MappingRulesSetEvent {
+	return &MappingRulesSetEvent{
+		BaseEvent: *base,
+		ID:        id,
+		Rules:     rules,
+	}
+}
+
+func (e *MappingRulesSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MappingRulesSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func MappingRulesSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &MappingRulesSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Mr7kq", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
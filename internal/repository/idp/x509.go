@@ -0,0 +1,191 @@
+package idp
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+type X509IDPAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                      string                      `json:"id"`
+	Name                    string                      `json:"name,omitempty"`
+	CertificateHeader       string                      `json:"certificateHeader,omitempty"`
+	TrustedProxies          []string                    `json:"trustedProxies,omitempty"`
+	CACertificates          []byte                      `json:"caCertificates,omitempty"`
+	CRLs                    [][]byte                    `json:"crls,omitempty"`
+	OCSPResponses           [][]byte                    `json:"ocspResponses,omitempty"`
+	RevocationCheckRequired bool                        `json:"revocationCheckRequired,omitempty"`
+	IDField                 domain.X509CertificateField `json:"idField,omitempty"`
+	UsernameField           domain.X509CertificateField `json:"usernameField,omitempty"`
+	Options
+}
+
+func NewX509IDPAddedEvent(
+	base *eventstore.BaseEvent,
+	id,
+	name,
+	certificateHeader string,
+	trustedProxies []string,
+	caCertificates []byte,
+	crls,
+	ocspResponses [][]byte,
+	revocationCheckRequired bool,
+	idField,
+	usernameField domain.X509CertificateField,
+	options Options,
+) *X509IDPAddedEvent {
+	return &X509IDPAddedEvent{
+		BaseEvent:               *base,
+		ID:                      id,
+		Name:                    name,
+		CertificateHeader:       certificateHeader,
+		TrustedProxies:          trustedProxies,
+		CACertificates:          caCertificates,
+		CRLs:                    crls,
+		OCSPResponses:           ocspResponses,
+		RevocationCheckRequired: revocationCheckRequired,
+		IDField:                 idField,
+		UsernameField:           usernameField,
+		Options:                 options,
+	}
+}
+
+func (e *X509IDPAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *X509IDPAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func X509IDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &X509IDPAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Xq8fe", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
+
+type X509IDPChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID                      string                       `json:"id"`
+	Name                    *string                      `json:"name,omitempty"`
+	CertificateHeader       *string                      `json:"certificateHeader,omitempty"`
+	TrustedProxies          *[]string                    `json:"trustedProxies,omitempty"`
+	CACertificates          []byte                       `json:"caCertificates,omitempty"`
+	CRLs                    *[][]byte                    `json:"crls,omitempty"`
+	OCSPResponses           *[][]byte                    `json:"ocspResponses,omitempty"`
+	RevocationCheckRequired *bool                        `json:"revocationCheckRequired,omitempty"`
+	IDField                 *domain.X509CertificateField `json:"idField,omitempty"`
+	UsernameField           *domain.X509CertificateField `json:"usernameField,omitempty"`
+	OptionChanges
+}
+
+func NewX509IDPChangedEvent(
+	base *eventstore.BaseEvent,
+	id string,
+	changes []X509IDPChanges,
+) (*X509IDPChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "IDP-Ruw3c", "Errors.NoChangesFound")
+	}
+	changedEvent := &X509IDPChangedEvent{
+		BaseEvent: *base,
+		ID:        id,
+	}
+	for _, change := range changes {
+		change(changedEvent)
+	}
+	return changedEvent, nil
+}
+
+type X509IDPChanges func(*X509IDPChangedEvent)
+
+func ChangeX509Name(name string) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.Name = &name
+	}
+}
+
+func ChangeX509CertificateHeader(certificateHeader string) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.CertificateHeader = &certificateHeader
+	}
+}
+
+func ChangeX509TrustedProxies(trustedProxies []string) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.TrustedProxies = &trustedProxies
+	}
+}
+
+func ChangeX509CACertificates(caCertificates []byte) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.CACertificates = caCertificates
+	}
+}
+
+func ChangeX509CRLs(crls [][]byte) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.CRLs = &crls
+	}
+}
+
+func ChangeX509OCSPResponses(ocspResponses [][]byte) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.OCSPResponses = &ocspResponses
+	}
+}
+
+func ChangeX509RevocationCheckRequired(revocationCheckRequired bool) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.RevocationCheckRequired = &revocationCheckRequired
+	}
+}
+
+func ChangeX509IDField(idField domain.X509CertificateField) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.IDField = &idField
+	}
+}
+
+func ChangeX509UsernameField(usernameField domain.X509CertificateField) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.UsernameField = &usernameField
+	}
+}
+
+func ChangeX509Options(options OptionChanges) func(*X509IDPChangedEvent) {
+	return func(e *X509IDPChangedEvent) {
+		e.OptionChanges = options
+	}
+}
+
+func (e *X509IDPChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *X509IDPChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func X509IDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &X509IDPChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "IDP-Wn5ko", "unable to unmarshal event")
+	}
+
+	return e, nil
+}
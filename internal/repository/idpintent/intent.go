@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/zerrors"
 )
@@ -86,6 +87,9 @@ type SucceededEvent struct {
 	IDPRefreshToken *crypto.CryptoValue `json:"idpRefreshToken,omitempty"`
 	IDPIDToken      string              `json:"idpIdToken,omitempty"`
 	ExpiresAt       time.Time           `json:"expiresAt,omitempty"`
+
+	// Assignments are the project roles and groups mapped by the rules of the IdP.
+	Assignments *domain.IDPAssignments `json:"assignments,omitempty"`
 }
 
 func NewSucceededEvent(
@@ -148,6 +152,9 @@ type SAMLSucceededEvent struct {
 
 	Assertion *crypto.CryptoValue `json:"assertion,omitempty"`
 	ExpiresAt time.Time           `json:"expiresAt,omitempty"`
+
+	// Assignments are the project roles and groups mapped by the rules of the IdP.
+	Assignments *domain.IDPAssignments `json:"assignments,omitempty"`
 }
 
 func NewSAMLSucceededEvent(
@@ -248,6 +255,9 @@ type LDAPSucceededEvent struct {
 
 	EntryAttributes map[string][]string `json:"user,omitempty"`
 	ExpiresAt       time.Time           `json:"expiresAt,omitempty"`
+
+	// Assignments are the project roles and groups mapped by the rules of the IdP.
+	Assignments *domain.IDPAssignments `json:"assignments,omitempty"`
 }
 
 func NewLDAPSucceededEvent(
@@ -39,6 +39,11 @@ const (
 	SAMLIDPChangedEventType             eventstore.EventType = "instance.idp.saml.changed"
 	IDPRemovedEventType                 eventstore.EventType = "instance.idp.removed"
 	ZitadelIDPAddedEventType            eventstore.EventType = "instance.idp.zitadel.added"
+	X509IDPAddedEventType               eventstore.EventType = "instance.idp.x509.added"
+	X509IDPChangedEventType             eventstore.EventType = "instance.idp.x509.changed"
+	IDPMappingRulesSetEventType         eventstore.EventType = "instance.idp.mapping.set"
+	IDPLDAPSyncSetEventType             eventstore.EventType = "instance.idp.ldap.sync.set"
+	IDPLDAPSyncRunFinishedEventType     eventstore.EventType = "instance.idp.ldap.sync.run.finished"
 )
 
 type OAuthIDPAddedEvent struct {
@@ -1096,6 +1101,92 @@ func SAMLIDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error)
 	return &SAMLIDPChangedEvent{SAMLIDPChangedEvent: *e.(*idp.SAMLIDPChangedEvent)}, nil
 }
 
+type X509IDPAddedEvent struct {
+	idp.X509IDPAddedEvent
+}
+
+func NewX509IDPAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	name,
+	certificateHeader string,
+	trustedProxies []string,
+	caCertificates []byte,
+	crls,
+	ocspResponses [][]byte,
+	revocationCheckRequired bool,
+	idField,
+	usernameField domain.X509CertificateField,
+	options idp.Options,
+) *X509IDPAddedEvent {
+
+	return &X509IDPAddedEvent{
+		X509IDPAddedEvent: *idp.NewX509IDPAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				X509IDPAddedEventType,
+			),
+			id,
+			name,
+			certificateHeader,
+			trustedProxies,
+			caCertificates,
+			crls,
+			ocspResponses,
+			revocationCheckRequired,
+			idField,
+			usernameField,
+			options,
+		),
+	}
+}
+
+func X509IDPAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.X509IDPAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &X509IDPAddedEvent{X509IDPAddedEvent: *e.(*idp.X509IDPAddedEvent)}, nil
+}
+
+type X509IDPChangedEvent struct {
+	idp.X509IDPChangedEvent
+}
+
+func NewX509IDPChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	changes []idp.X509IDPChanges,
+) (*X509IDPChangedEvent, error) {
+
+	changedEvent, err := idp.NewX509IDPChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			X509IDPChangedEventType,
+		),
+		id,
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &X509IDPChangedEvent{X509IDPChangedEvent: *changedEvent}, nil
+}
+
+func X509IDPChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.X509IDPChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &X509IDPChangedEvent{X509IDPChangedEvent: *e.(*idp.X509IDPChangedEvent)}, nil
+}
+
 type IDPRemovedEvent struct {
 	idp.RemovedEvent
 }
@@ -1169,3 +1260,115 @@ func NewZitadelIDPAddedEvent(
 func (e *ZitadelIDPAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
 	e.BaseEvent = *event
 }
+
+type IDPMappingRulesSetEvent struct {
+	idp.MappingRulesSetEvent
+}
+
+func NewIDPMappingRulesSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	rules []domain.IDPMappingRule,
+) *IDPMappingRulesSetEvent {
+	return &IDPMappingRulesSetEvent{
+		MappingRulesSetEvent: *idp.NewMappingRulesSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPMappingRulesSetEventType,
+			),
+			id,
+			rules,
+		),
+	}
+}
+
+func (e *IDPMappingRulesSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPMappingRulesSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.MappingRulesSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPMappingRulesSetEvent{MappingRulesSetEvent: *e.(*idp.MappingRulesSetEvent)}, nil
+}
+
+type IDPLDAPSyncSetEvent struct {
+	idp.LDAPSyncSetEvent
+}
+
+func NewIDPLDAPSyncSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id string,
+	config *domain.LDAPSyncConfig,
+) *IDPLDAPSyncSetEvent {
+	return &IDPLDAPSyncSetEvent{
+		LDAPSyncSetEvent: *idp.NewLDAPSyncSetEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPLDAPSyncSetEventType,
+			),
+			id,
+			config,
+		),
+	}
+}
+
+func (e *IDPLDAPSyncSetEvent) Payload() interface{} {
+	return e
+}
+
+func IDPLDAPSyncSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPSyncSetEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPLDAPSyncSetEvent{LDAPSyncSetEvent: *e.(*idp.LDAPSyncSetEvent)}, nil
+}
+
+type IDPLDAPSyncRunFinishedEvent struct {
+	idp.LDAPSyncRunFinishedEvent
+}
+
+func NewIDPLDAPSyncRunFinishedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	runID string,
+	run *domain.LDAPSyncRun,
+	nextRunAt time.Time,
+) *IDPLDAPSyncRunFinishedEvent {
+	return &IDPLDAPSyncRunFinishedEvent{
+		LDAPSyncRunFinishedEvent: *idp.NewLDAPSyncRunFinishedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				IDPLDAPSyncRunFinishedEventType,
+			),
+			id,
+			runID,
+			run,
+			nextRunAt,
+		),
+	}
+}
+
+func (e *IDPLDAPSyncRunFinishedEvent) Payload() interface{} {
+	return e
+}
+
+func IDPLDAPSyncRunFinishedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := idp.LDAPSyncRunFinishedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &IDPLDAPSyncRunFinishedEvent{LDAPSyncRunFinishedEvent: *e.(*idp.LDAPSyncRunFinishedEvent)}, nil
+}
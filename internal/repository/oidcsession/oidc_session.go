@@ -18,6 +18,7 @@ const (
 	RefreshTokenAddedType   = oidcSessionEventPrefix + "refresh_token.added"
 	RefreshTokenRenewedType = oidcSessionEventPrefix + "refresh_token.renewed"
 	RefreshTokenRevokedType = oidcSessionEventPrefix + "refresh_token.revoked"
+	RefreshTokenReusedType  = oidcSessionEventPrefix + "refresh_token.reused"
 )
 
 type AddedEvent struct {
@@ -90,6 +91,9 @@ type AccessTokenAddedEvent struct {
 	Lifetime time.Duration      `json:"lifetime,omitempty"`
 	Reason   domain.TokenReason `json:"reason,omitempty"`
 	Actor    *domain.TokenActor `json:"actor,omitempty"`
+	// Audience is set if the access token was restricted to a subset of the session audience,
+	// e.g. by a resource indicator (RFC 8707).
+	Audience []string `json:"audience,omitempty"`
 }
 
 func (e *AccessTokenAddedEvent) Payload() interface{} {
@@ -112,6 +116,7 @@ func NewAccessTokenAddedEvent(
 	lifetime time.Duration,
 	reason domain.TokenReason,
 	actor *domain.TokenActor,
+	audience []string,
 ) *AccessTokenAddedEvent {
 	return &AccessTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -124,6 +129,7 @@ func NewAccessTokenAddedEvent(
 		Lifetime: lifetime,
 		Reason:   reason,
 		Actor:    actor,
+		Audience: audience,
 	}
 }
 
@@ -259,3 +265,44 @@ func NewRefreshTokenRevokedEvent(
 		),
 	}
 }
+
+// RefreshTokenReusedEvent is pushed when an already rotated refresh token is presented again.
+// It revokes all tokens of the OIDC session and terminates it.
+type RefreshTokenReusedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ID       string `json:"id"`
+	UserID   string `json:"userID,omitempty"`
+	ClientID string `json:"clientID,omitempty"`
+}
+
+func (e *RefreshTokenReusedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *RefreshTokenReusedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *RefreshTokenReusedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewRefreshTokenReusedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	userID,
+	clientID string,
+) *RefreshTokenReusedEvent {
+	return &RefreshTokenReusedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			RefreshTokenReusedType,
+		),
+		ID:       id,
+		UserID:   userID,
+		ClientID: clientID,
+	}
+}
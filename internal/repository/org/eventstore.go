@@ -103,6 +103,11 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, AppleIDPChangedEventType, AppleIDPChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPAddedEventType, SAMLIDPAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLIDPChangedEventType, SAMLIDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, X509IDPAddedEventType, X509IDPAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, X509IDPChangedEventType, X509IDPChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPMappingRulesSetEventType, IDPMappingRulesSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPLDAPSyncSetEventType, IDPLDAPSyncSetEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, IDPLDAPSyncRunFinishedEventType, IDPLDAPSyncRunFinishedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, ZitadelIDPAddedEventType, eventstore.GenericEventMapper[ZitadelIDPAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, IDPRemovedEventType, IDPRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, TriggerActionsSetEventType, TriggerActionsSetEventMapper)
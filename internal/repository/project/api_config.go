@@ -28,6 +28,7 @@ type APIConfigAddedEvent struct {
 	HashedSecret string              `json:"hashedSecret,omitempty"`
 
 	AuthMethodType domain.APIAuthMethodType `json:"authMethodType,omitempty"`
+	ResourceURI    string                   `json:"resourceUri,omitempty"`
 }
 
 func (e *APIConfigAddedEvent) Payload() interface{} {
@@ -45,6 +46,7 @@ func NewAPIConfigAddedEvent(
 	clientID string,
 	hashedSecret string,
 	authMethodType domain.APIAuthMethodType,
+	resourceURI string,
 ) *APIConfigAddedEvent {
 	return &APIConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -56,6 +58,7 @@ func NewAPIConfigAddedEvent(
 		ClientID:       clientID,
 		HashedSecret:   hashedSecret,
 		AuthMethodType: authMethodType,
+		ResourceURI:    resourceURI,
 	}
 }
 
@@ -74,6 +77,9 @@ func (e *APIConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 	if e.AuthMethodType != c.AuthMethodType {
 		return false
 	}
+	if e.ResourceURI != c.ResourceURI {
+		return false
+	}
 
 	return true
 }
@@ -96,6 +102,10 @@ type APIConfigChangedEvent struct {
 
 	AppID          string                    `json:"appId"`
 	AuthMethodType *domain.APIAuthMethodType `json:"authMethodType,omitempty"`
+	ResourceURI    *string                   `json:"resourceUri,omitempty"`
+
+	IntrospectionSignedResponse *bool   `json:"introspectionSignedResponse,omitempty"`
+	IntrospectionEncryptionKey  *[]byte `json:"introspectionEncryptionKey,omitempty"`
 }
 
 func (e *APIConfigChangedEvent) Payload() interface{} {
@@ -138,6 +148,24 @@ func ChangeAPIAuthMethodType(authMethodType domain.APIAuthMethodType) func(event
 	}
 }
 
+func ChangeAPIResourceURI(resourceURI string) func(event *APIConfigChangedEvent) {
+	return func(e *APIConfigChangedEvent) {
+		e.ResourceURI = &resourceURI
+	}
+}
+
+func ChangeAPIIntrospectionSignedResponse(signedResponse bool) func(event *APIConfigChangedEvent) {
+	return func(e *APIConfigChangedEvent) {
+		e.IntrospectionSignedResponse = &signedResponse
+	}
+}
+
+func ChangeAPIIntrospectionEncryptionKey(encryptionKey []byte) func(event *APIConfigChangedEvent) {
+	return func(e *APIConfigChangedEvent) {
+		e.IntrospectionEncryptionKey = &encryptionKey
+	}
+}
+
 func APIConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &APIConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
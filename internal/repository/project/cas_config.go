@@ -0,0 +1,139 @@
+package project
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	CASConfigAddedType   = applicationEventTypePrefix + "config.cas.added"
+	CASConfigChangedType = applicationEventTypePrefix + "config.cas.changed"
+)
+
+type CASConfigAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID        string              `json:"appId"`
+	ServiceURLs  []string            `json:"serviceUrls,omitempty"`
+	LoginVersion domain.LoginVersion `json:"loginVersion,omitempty"`
+	LoginBaseURI string              `json:"loginBaseURI,omitempty"`
+}
+
+func (e *CASConfigAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *CASConfigAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewCASConfigAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	serviceURLs []string,
+	loginVersion domain.LoginVersion,
+	loginBaseURI string,
+) *CASConfigAddedEvent {
+	return &CASConfigAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CASConfigAddedType,
+		),
+		AppID:        appID,
+		ServiceURLs:  serviceURLs,
+		LoginVersion: loginVersion,
+		LoginBaseURI: loginBaseURI,
+	}
+}
+
+func CASConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &CASConfigAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "CAS-ooP5e", "unable to unmarshal cas config")
+	}
+
+	return e, nil
+}
+
+type CASConfigChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID        string               `json:"appId"`
+	ServiceURLs  *[]string            `json:"serviceUrls,omitempty"`
+	LoginVersion *domain.LoginVersion `json:"loginVersion,omitempty"`
+	LoginBaseURI *string              `json:"loginBaseURI,omitempty"`
+}
+
+func (e *CASConfigChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *CASConfigChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewCASConfigChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	changes []CASConfigChanges,
+) (*CASConfigChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "CAS-Eo3ai", "Errors.NoChangesFound")
+	}
+
+	changeEvent := &CASConfigChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			CASConfigChangedType,
+		),
+		AppID: appID,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type CASConfigChanges func(event *CASConfigChangedEvent)
+
+func ChangeCASServiceURLs(serviceURLs []string) func(event *CASConfigChangedEvent) {
+	return func(e *CASConfigChangedEvent) {
+		e.ServiceURLs = &serviceURLs
+	}
+}
+
+func ChangeCASLoginVersion(loginVersion domain.LoginVersion) func(event *CASConfigChangedEvent) {
+	return func(e *CASConfigChangedEvent) {
+		e.LoginVersion = &loginVersion
+	}
+}
+
+func ChangeCASLoginBaseURI(loginBaseURI string) func(event *CASConfigChangedEvent) {
+	return func(e *CASConfigChangedEvent) {
+		e.LoginBaseURI = &loginBaseURI
+	}
+}
+
+func CASConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &CASConfigChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "CAS-Fah4o", "unable to unmarshal cas config")
+	}
+
+	return e, nil
+}
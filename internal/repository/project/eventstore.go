@@ -44,4 +44,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, ApplicationKeyRemovedEventType, ApplicationKeyRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigAddedType, SAMLConfigAddedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, WSFedConfigAddedType, WSFedConfigAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, WSFedConfigChangedType, WSFedConfigChangedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, CASConfigAddedType, CASConfigAddedEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, CASConfigChangedType, CASConfigChangedEventMapper)
 }
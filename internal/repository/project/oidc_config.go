@@ -29,23 +29,33 @@ type OIDCConfigAddedEvent struct {
 	ClientSecret *crypto.CryptoValue `json:"clientSecret,omitempty"`
 	HashedSecret string              `json:"hashedSecret,omitempty"`
 
-	RedirectUris             []string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            []domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               []domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   []string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  bool                       `json:"devMode,omitempty"`
-	AccessTokenType          domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        []string                   `json:"additionalOrigins,omitempty"`
-	SkipNativeAppSuccessPage bool                       `json:"skipNativeAppSuccessPage,omitempty"`
-	BackChannelLogoutURI     string                     `json:"backChannelLogoutURI,omitempty"`
-	LoginVersion             domain.LoginVersion        `json:"loginVersion,omitempty"`
-	LoginBaseURI             string                     `json:"loginBaseURI,omitempty"`
+	RedirectUris                 []string                        `json:"redirectUris,omitempty"`
+	ResponseTypes                []domain.OIDCResponseType       `json:"responseTypes,omitempty"`
+	GrantTypes                   []domain.OIDCGrantType          `json:"grantTypes,omitempty"`
+	ApplicationType              domain.OIDCApplicationType      `json:"applicationType,omitempty"`
+	AuthMethodType               domain.OIDCAuthMethodType       `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris       []string                        `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                      bool                            `json:"devMode,omitempty"`
+	AccessTokenType              domain.OIDCTokenType            `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion     bool                            `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion         bool                            `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion     bool                            `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                    time.Duration                   `json:"clockSkew,omitempty"`
+	AdditionalOrigins            []string                        `json:"additionalOrigins,omitempty"`
+	SkipNativeAppSuccessPage     bool                            `json:"skipNativeAppSuccessPage,omitempty"`
+	BackChannelLogoutURI         string                          `json:"backChannelLogoutURI,omitempty"`
+	LoginVersion                 domain.LoginVersion             `json:"loginVersion,omitempty"`
+	LoginBaseURI                 string                          `json:"loginBaseURI,omitempty"`
+	RefreshTokenRotation         domain.OIDCRefreshTokenRotation `json:"refreshTokenRotation,omitempty"`
+	RefreshTokenLifetime         time.Duration                   `json:"refreshTokenLifetime,omitempty"`
+	RefreshTokenIdleLifetime     time.Duration                   `json:"refreshTokenIdleLifetime,omitempty"`
+	IDTokenEncryptedResponseAlg  string                          `json:"idTokenEncryptedResponseAlg,omitempty"`
+	IDTokenEncryptedResponseEnc  string                          `json:"idTokenEncryptedResponseEnc,omitempty"`
+	UserinfoEncryptedResponseAlg string                          `json:"userinfoEncryptedResponseAlg,omitempty"`
+	UserinfoEncryptedResponseEnc string                          `json:"userinfoEncryptedResponseEnc,omitempty"`
+	JWKS                         string                          `json:"jwks,omitempty"`
+	JWKSURI                      string                          `json:"jwksUri,omitempty"`
+	FrontChannelLogoutURI        string                          `json:"frontChannelLogoutURI,omitempty"`
 }
 
 func (e *OIDCConfigAddedEvent) Payload() interface{} {
@@ -80,6 +90,16 @@ func NewOIDCConfigAddedEvent(
 	backChannelLogoutURI string,
 	loginVersion domain.LoginVersion,
 	loginBaseURI string,
+	refreshTokenRotation domain.OIDCRefreshTokenRotation,
+	refreshTokenLifetime time.Duration,
+	refreshTokenIdleLifetime time.Duration,
+	idTokenEncryptedResponseAlg string,
+	idTokenEncryptedResponseEnc string,
+	userinfoEncryptedResponseAlg string,
+	userinfoEncryptedResponseEnc string,
+	jwks string,
+	jwksURI string,
+	frontChannelLogoutURI string,
 ) *OIDCConfigAddedEvent {
 	return &OIDCConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -87,27 +107,37 @@ func NewOIDCConfigAddedEvent(
 			aggregate,
 			OIDCConfigAddedType,
 		),
-		Version:                  version,
-		AppID:                    appID,
-		ClientID:                 clientID,
-		HashedSecret:             hashedSecret,
-		RedirectUris:             redirectUris,
-		ResponseTypes:            responseTypes,
-		GrantTypes:               grantTypes,
-		ApplicationType:          applicationType,
-		AuthMethodType:           authMethodType,
-		PostLogoutRedirectUris:   postLogoutRedirectUris,
-		DevMode:                  devMode,
-		AccessTokenType:          accessTokenType,
-		AccessTokenRoleAssertion: accessTokenRoleAssertion,
-		IDTokenRoleAssertion:     idTokenRoleAssertion,
-		IDTokenUserinfoAssertion: idTokenUserinfoAssertion,
-		ClockSkew:                clockSkew,
-		AdditionalOrigins:        additionalOrigins,
-		SkipNativeAppSuccessPage: skipNativeAppSuccessPage,
-		BackChannelLogoutURI:     backChannelLogoutURI,
-		LoginVersion:             loginVersion,
-		LoginBaseURI:             loginBaseURI,
+		Version:                      version,
+		AppID:                        appID,
+		ClientID:                     clientID,
+		HashedSecret:                 hashedSecret,
+		RedirectUris:                 redirectUris,
+		ResponseTypes:                responseTypes,
+		GrantTypes:                   grantTypes,
+		ApplicationType:              applicationType,
+		AuthMethodType:               authMethodType,
+		PostLogoutRedirectUris:       postLogoutRedirectUris,
+		DevMode:                      devMode,
+		AccessTokenType:              accessTokenType,
+		AccessTokenRoleAssertion:     accessTokenRoleAssertion,
+		IDTokenRoleAssertion:         idTokenRoleAssertion,
+		IDTokenUserinfoAssertion:     idTokenUserinfoAssertion,
+		ClockSkew:                    clockSkew,
+		AdditionalOrigins:            additionalOrigins,
+		SkipNativeAppSuccessPage:     skipNativeAppSuccessPage,
+		BackChannelLogoutURI:         backChannelLogoutURI,
+		LoginVersion:                 loginVersion,
+		LoginBaseURI:                 loginBaseURI,
+		RefreshTokenRotation:         refreshTokenRotation,
+		RefreshTokenLifetime:         refreshTokenLifetime,
+		RefreshTokenIdleLifetime:     refreshTokenIdleLifetime,
+		IDTokenEncryptedResponseAlg:  idTokenEncryptedResponseAlg,
+		IDTokenEncryptedResponseEnc:  idTokenEncryptedResponseEnc,
+		UserinfoEncryptedResponseAlg: userinfoEncryptedResponseAlg,
+		UserinfoEncryptedResponseEnc: userinfoEncryptedResponseEnc,
+		JWKS:                         jwks,
+		JWKSURI:                      jwksURI,
+		FrontChannelLogoutURI:        frontChannelLogoutURI,
 	}
 }
 
@@ -202,7 +232,37 @@ func (e *OIDCConfigAddedEvent) Validate(cmd eventstore.Command) bool {
 	if e.LoginVersion != c.LoginVersion {
 		return false
 	}
-	return e.LoginBaseURI == c.LoginBaseURI
+	if e.LoginBaseURI != c.LoginBaseURI {
+		return false
+	}
+	if e.RefreshTokenRotation != c.RefreshTokenRotation {
+		return false
+	}
+	if e.RefreshTokenLifetime != c.RefreshTokenLifetime {
+		return false
+	}
+	if e.RefreshTokenIdleLifetime != c.RefreshTokenIdleLifetime {
+		return false
+	}
+	if e.IDTokenEncryptedResponseAlg != c.IDTokenEncryptedResponseAlg {
+		return false
+	}
+	if e.IDTokenEncryptedResponseEnc != c.IDTokenEncryptedResponseEnc {
+		return false
+	}
+	if e.UserinfoEncryptedResponseAlg != c.UserinfoEncryptedResponseAlg {
+		return false
+	}
+	if e.UserinfoEncryptedResponseEnc != c.UserinfoEncryptedResponseEnc {
+		return false
+	}
+	if e.JWKS != c.JWKS {
+		return false
+	}
+	if e.JWKSURI != c.JWKSURI {
+		return false
+	}
+	return e.FrontChannelLogoutURI == c.FrontChannelLogoutURI
 }
 
 func OIDCConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
@@ -221,25 +281,35 @@ func OIDCConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error
 type OIDCConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	Version                  *domain.OIDCVersion         `json:"oidcVersion,omitempty"`
-	AppID                    string                      `json:"appId"`
-	RedirectUris             *[]string                   `json:"redirectUris,omitempty"`
-	ResponseTypes            *[]domain.OIDCResponseType  `json:"responseTypes,omitempty"`
-	GrantTypes               *[]domain.OIDCGrantType     `json:"grantTypes,omitempty"`
-	ApplicationType          *domain.OIDCApplicationType `json:"applicationType,omitempty"`
-	AuthMethodType           *domain.OIDCAuthMethodType  `json:"authMethodType,omitempty"`
-	PostLogoutRedirectUris   *[]string                   `json:"postLogoutRedirectUris,omitempty"`
-	DevMode                  *bool                       `json:"devMode,omitempty"`
-	AccessTokenType          *domain.OIDCTokenType       `json:"accessTokenType,omitempty"`
-	AccessTokenRoleAssertion *bool                       `json:"accessTokenRoleAssertion,omitempty"`
-	IDTokenRoleAssertion     *bool                       `json:"idTokenRoleAssertion,omitempty"`
-	IDTokenUserinfoAssertion *bool                       `json:"idTokenUserinfoAssertion,omitempty"`
-	ClockSkew                *time.Duration              `json:"clockSkew,omitempty"`
-	AdditionalOrigins        *[]string                   `json:"additionalOrigins,omitempty"`
-	SkipNativeAppSuccessPage *bool                       `json:"skipNativeAppSuccessPage,omitempty"`
-	BackChannelLogoutURI     *string                     `json:"backChannelLogoutURI,omitempty"`
-	LoginVersion             *domain.LoginVersion        `json:"loginVersion,omitempty"`
-	LoginBaseURI             *string                     `json:"loginBaseURI,omitempty"`
+	Version                      *domain.OIDCVersion              `json:"oidcVersion,omitempty"`
+	AppID                        string                           `json:"appId"`
+	RedirectUris                 *[]string                        `json:"redirectUris,omitempty"`
+	ResponseTypes                *[]domain.OIDCResponseType       `json:"responseTypes,omitempty"`
+	GrantTypes                   *[]domain.OIDCGrantType          `json:"grantTypes,omitempty"`
+	ApplicationType              *domain.OIDCApplicationType      `json:"applicationType,omitempty"`
+	AuthMethodType               *domain.OIDCAuthMethodType       `json:"authMethodType,omitempty"`
+	PostLogoutRedirectUris       *[]string                        `json:"postLogoutRedirectUris,omitempty"`
+	DevMode                      *bool                            `json:"devMode,omitempty"`
+	AccessTokenType              *domain.OIDCTokenType            `json:"accessTokenType,omitempty"`
+	AccessTokenRoleAssertion     *bool                            `json:"accessTokenRoleAssertion,omitempty"`
+	IDTokenRoleAssertion         *bool                            `json:"idTokenRoleAssertion,omitempty"`
+	IDTokenUserinfoAssertion     *bool                            `json:"idTokenUserinfoAssertion,omitempty"`
+	ClockSkew                    *time.Duration                   `json:"clockSkew,omitempty"`
+	AdditionalOrigins            *[]string                        `json:"additionalOrigins,omitempty"`
+	SkipNativeAppSuccessPage     *bool                            `json:"skipNativeAppSuccessPage,omitempty"`
+	BackChannelLogoutURI         *string                          `json:"backChannelLogoutURI,omitempty"`
+	LoginVersion                 *domain.LoginVersion             `json:"loginVersion,omitempty"`
+	LoginBaseURI                 *string                          `json:"loginBaseURI,omitempty"`
+	RefreshTokenRotation         *domain.OIDCRefreshTokenRotation `json:"refreshTokenRotation,omitempty"`
+	RefreshTokenLifetime         *time.Duration                   `json:"refreshTokenLifetime,omitempty"`
+	RefreshTokenIdleLifetime     *time.Duration                   `json:"refreshTokenIdleLifetime,omitempty"`
+	IDTokenEncryptedResponseAlg  *string                          `json:"idTokenEncryptedResponseAlg,omitempty"`
+	IDTokenEncryptedResponseEnc  *string                          `json:"idTokenEncryptedResponseEnc,omitempty"`
+	UserinfoEncryptedResponseAlg *string                          `json:"userinfoEncryptedResponseAlg,omitempty"`
+	UserinfoEncryptedResponseEnc *string                          `json:"userinfoEncryptedResponseEnc,omitempty"`
+	JWKS                         *string                          `json:"jwks,omitempty"`
+	JWKSURI                      *string                          `json:"jwksUri,omitempty"`
+	FrontChannelLogoutURI        *string                          `json:"frontChannelLogoutURI,omitempty"`
 }
 
 func (e *OIDCConfigChangedEvent) Payload() interface{} {
@@ -396,6 +466,66 @@ func ChangeOIDCLoginBaseURI(loginBaseURI string) func(event *OIDCConfigChangedEv
 	}
 }
 
+func ChangeRefreshTokenRotation(rotation domain.OIDCRefreshTokenRotation) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenRotation = &rotation
+	}
+}
+
+func ChangeRefreshTokenLifetime(lifetime time.Duration) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenLifetime = &lifetime
+	}
+}
+
+func ChangeRefreshTokenIdleLifetime(idleLifetime time.Duration) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.RefreshTokenIdleLifetime = &idleLifetime
+	}
+}
+
+func ChangeIDTokenEncryptedResponseAlg(algorithm string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.IDTokenEncryptedResponseAlg = &algorithm
+	}
+}
+
+func ChangeIDTokenEncryptedResponseEnc(encryption string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.IDTokenEncryptedResponseEnc = &encryption
+	}
+}
+
+func ChangeUserinfoEncryptedResponseAlg(algorithm string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.UserinfoEncryptedResponseAlg = &algorithm
+	}
+}
+
+func ChangeUserinfoEncryptedResponseEnc(encryption string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.UserinfoEncryptedResponseEnc = &encryption
+	}
+}
+
+func ChangeJWKS(jwks string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.JWKS = &jwks
+	}
+}
+
+func ChangeJWKSURI(jwksURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.JWKSURI = &jwksURI
+	}
+}
+
+func ChangeFrontChannelLogoutURI(frontChannelLogoutURI string) func(event *OIDCConfigChangedEvent) {
+	return func(e *OIDCConfigChangedEvent) {
+		e.FrontChannelLogoutURI = &frontChannelLogoutURI
+	}
+}
+
 func OIDCConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &OIDCConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
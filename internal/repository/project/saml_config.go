@@ -17,12 +17,14 @@ const (
 type SAMLConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID        string              `json:"appId"`
-	EntityID     string              `json:"entityId"`
-	Metadata     []byte              `json:"metadata,omitempty"`
-	MetadataURL  string              `json:"metadata_url,omitempty"`
-	LoginVersion domain.LoginVersion `json:"loginVersion,omitempty"`
-	LoginBaseURI string              `json:"loginBaseURI,omitempty"`
+	AppID             string                         `json:"appId"`
+	EntityID          string                         `json:"entityId"`
+	Metadata          []byte                         `json:"metadata,omitempty"`
+	MetadataURL       string                         `json:"metadata_url,omitempty"`
+	LoginVersion      domain.LoginVersion            `json:"loginVersion,omitempty"`
+	LoginBaseURI      string                         `json:"loginBaseURI,omitempty"`
+	NameIDSource      domain.SAMLNameIDSource        `json:"nameIdSource,omitempty"`
+	AttributeMappings []*domain.SAMLAttributeMapping `json:"attributeMappings,omitempty"`
 }
 
 func (e *SAMLConfigAddedEvent) Payload() interface{} {
@@ -55,6 +57,8 @@ func NewSAMLConfigAddedEvent(
 	metadataURL string,
 	loginVersion domain.LoginVersion,
 	loginBaseURI string,
+	nameIDSource domain.SAMLNameIDSource,
+	attributeMappings []*domain.SAMLAttributeMapping,
 ) *SAMLConfigAddedEvent {
 	return &SAMLConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -62,12 +66,14 @@ func NewSAMLConfigAddedEvent(
 			aggregate,
 			SAMLConfigAddedType,
 		),
-		AppID:        appID,
-		EntityID:     entityID,
-		Metadata:     metadata,
-		MetadataURL:  metadataURL,
-		LoginVersion: loginVersion,
-		LoginBaseURI: loginBaseURI,
+		AppID:             appID,
+		EntityID:          entityID,
+		Metadata:          metadata,
+		MetadataURL:       metadataURL,
+		LoginVersion:      loginVersion,
+		LoginBaseURI:      loginBaseURI,
+		NameIDSource:      nameIDSource,
+		AttributeMappings: attributeMappings,
 	}
 }
 
@@ -87,13 +93,15 @@ func SAMLConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error
 type SAMLConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID        string               `json:"appId"`
-	EntityID     string               `json:"entityId"`
-	Metadata     []byte               `json:"metadata,omitempty"`
-	MetadataURL  *string              `json:"metadata_url,omitempty"`
-	LoginVersion *domain.LoginVersion `json:"loginVersion,omitempty"`
-	LoginBaseURI *string              `json:"loginBaseURI,omitempty"`
-	oldEntityID  string
+	AppID             string                          `json:"appId"`
+	EntityID          string                          `json:"entityId"`
+	Metadata          []byte                          `json:"metadata,omitempty"`
+	MetadataURL       *string                         `json:"metadata_url,omitempty"`
+	LoginVersion      *domain.LoginVersion            `json:"loginVersion,omitempty"`
+	LoginBaseURI      *string                         `json:"loginBaseURI,omitempty"`
+	NameIDSource      *domain.SAMLNameIDSource        `json:"nameIdSource,omitempty"`
+	AttributeMappings *[]*domain.SAMLAttributeMapping `json:"attributeMappings,omitempty"`
+	oldEntityID       string
 }
 
 func (e *SAMLConfigChangedEvent) Payload() interface{} {
@@ -167,6 +175,18 @@ func ChangeSAMLLoginBaseURI(loginBaseURI string) func(event *SAMLConfigChangedEv
 	}
 }
 
+func ChangeSAMLNameIDSource(nameIDSource domain.SAMLNameIDSource) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.NameIDSource = &nameIDSource
+	}
+}
+
+func ChangeSAMLAttributeMappings(attributeMappings []*domain.SAMLAttributeMapping) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.AttributeMappings = &attributeMappings
+	}
+}
+
 func SAMLConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &SAMLConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -0,0 +1,157 @@
+package project
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	WSFedConfigAddedType   = applicationEventTypePrefix + "config.wsfed.added"
+	WSFedConfigChangedType = applicationEventTypePrefix + "config.wsfed.changed"
+)
+
+type WSFedConfigAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID     string                `json:"appId"`
+	Realm     string                `json:"realm"`
+	ReplyURLs []string              `json:"replyUrls,omitempty"`
+	TokenType domain.WSFedTokenType `json:"tokenType,omitempty"`
+}
+
+func (e *WSFedConfigAddedEvent) Payload() interface{} {
+	return e
+}
+
+// NewAddWSFedConfigRealmUniqueConstraint reserves the realm in the namespace of the SAML entity ids,
+// as both identify the relying party of the federation.
+func NewAddWSFedConfigRealmUniqueConstraint(realm string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueEntityIDType,
+		realm,
+		"Errors.Project.App.WSFedRealmAlreadyExists")
+}
+
+func (e *WSFedConfigAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddWSFedConfigRealmUniqueConstraint(e.Realm)}
+}
+
+func NewWSFedConfigAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	realm string,
+	replyURLs []string,
+	tokenType domain.WSFedTokenType,
+) *WSFedConfigAddedEvent {
+	return &WSFedConfigAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			WSFedConfigAddedType,
+		),
+		AppID:     appID,
+		Realm:     realm,
+		ReplyURLs: replyURLs,
+		TokenType: tokenType,
+	}
+}
+
+func WSFedConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &WSFedConfigAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WSFED-Ohh7a", "unable to unmarshal wsfed config")
+	}
+
+	return e, nil
+}
+
+type WSFedConfigChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID     string                 `json:"appId"`
+	Realm     string                 `json:"realm,omitempty"`
+	ReplyURLs *[]string              `json:"replyUrls,omitempty"`
+	TokenType *domain.WSFedTokenType `json:"tokenType,omitempty"`
+	oldRealm  string
+}
+
+func (e *WSFedConfigChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *WSFedConfigChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	if e.Realm != "" {
+		return []*eventstore.UniqueConstraint{
+			NewRemoveSAMLConfigEntityIDUniqueConstraint(e.oldRealm),
+			NewAddWSFedConfigRealmUniqueConstraint(e.Realm),
+		}
+	}
+	return nil
+}
+
+func NewWSFedConfigChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+	oldRealm string,
+	changes []WSFedConfigChanges,
+) (*WSFedConfigChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, zerrors.ThrowPreconditionFailed(nil, "WSFED-Tei3e", "Errors.NoChangesFound")
+	}
+
+	changeEvent := &WSFedConfigChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			WSFedConfigChangedType,
+		),
+		AppID:    appID,
+		oldRealm: oldRealm,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type WSFedConfigChanges func(event *WSFedConfigChangedEvent)
+
+func ChangeWSFedRealm(realm string) func(event *WSFedConfigChangedEvent) {
+	return func(e *WSFedConfigChangedEvent) {
+		e.Realm = realm
+	}
+}
+
+func ChangeWSFedReplyURLs(replyURLs []string) func(event *WSFedConfigChangedEvent) {
+	return func(e *WSFedConfigChangedEvent) {
+		e.ReplyURLs = &replyURLs
+	}
+}
+
+func ChangeWSFedTokenType(tokenType domain.WSFedTokenType) func(event *WSFedConfigChangedEvent) {
+	return func(e *WSFedConfigChangedEvent) {
+		e.TokenType = &tokenType
+	}
+}
+
+func WSFedConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &WSFedConfigChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, zerrors.ThrowInternal(err, "WSFED-Ahgh3", "unable to unmarshal wsfed config")
+	}
+
+	return e, nil
+}
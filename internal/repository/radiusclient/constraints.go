@@ -0,0 +1,25 @@
+package radiusclient
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueRADIUSClient    = "radius_client"
+	DuplicateRADIUSClient = "Errors.RADIUSClient.AlreadyExists"
+)
+
+func NewAddUniqueConstraint(name string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRADIUSClient,
+		name,
+		DuplicateRADIUSClient,
+	)
+}
+
+func NewRemoveUniqueConstraint(name string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueRADIUSClient,
+		name,
+	)
+}
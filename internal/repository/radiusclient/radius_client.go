@@ -0,0 +1,182 @@
+package radiusclient
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix  eventstore.EventType = "radius_client."
+	AddedEventType                        = eventTypePrefix + "added"
+	ChangedEventType                      = eventTypePrefix + "changed"
+	RemovedEventType                      = eventTypePrefix + "removed"
+)
+
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name           string                        `json:"name"`
+	Network        string                        `json:"network"`
+	NASIdentifier  string                        `json:"nasIdentifier,omitempty"`
+	Secret         *crypto.CryptoValue           `json:"secret"`
+	ProjectID      string                        `json:"projectId,omitempty"`
+	RequireGrant   bool                          `json:"requireGrant,omitempty"`
+	RoleAttributes []*domain.RADIUSRoleAttribute `json:"roleAttributes,omitempty"`
+}
+
+func (e *AddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *AddedEvent) Payload() any {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddUniqueConstraint(e.Name)}
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name string,
+	network string,
+	nasIdentifier string,
+	secret *crypto.CryptoValue,
+	projectID string,
+	requireGrant bool,
+	roleAttributes []*domain.RADIUSRoleAttribute,
+) *AddedEvent {
+	return &AddedEvent{
+		*eventstore.NewBaseEventForPush(
+			ctx, aggregate, AddedEventType,
+		),
+		name,
+		network,
+		nasIdentifier,
+		secret,
+		projectID,
+		requireGrant,
+		roleAttributes,
+	}
+}
+
+type ChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	Name           *string                        `json:"name,omitempty"`
+	Network        *string                        `json:"network,omitempty"`
+	NASIdentifier  *string                        `json:"nasIdentifier,omitempty"`
+	Secret         *crypto.CryptoValue            `json:"secret,omitempty"`
+	ProjectID      *string                        `json:"projectId,omitempty"`
+	RequireGrant   *bool                          `json:"requireGrant,omitempty"`
+	RoleAttributes *[]*domain.RADIUSRoleAttribute `json:"roleAttributes,omitempty"`
+
+	oldName string
+}
+
+func (e *ChangedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *ChangedEvent) Payload() any {
+	return e
+}
+
+func (e *ChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	if e.oldName == "" {
+		return nil
+	}
+	return []*eventstore.UniqueConstraint{
+		NewRemoveUniqueConstraint(e.oldName),
+		NewAddUniqueConstraint(*e.Name),
+	}
+}
+
+func NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []Changes,
+) *ChangedEvent {
+	changeEvent := &ChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ChangedEventType,
+		),
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent
+}
+
+type Changes func(event *ChangedEvent)
+
+func ChangeName(oldName, name string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Name = &name
+		e.oldName = oldName
+	}
+}
+
+func ChangeNetwork(network string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Network = &network
+	}
+}
+
+func ChangeNASIdentifier(nasIdentifier string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.NASIdentifier = &nasIdentifier
+	}
+}
+
+func ChangeSecret(secret *crypto.CryptoValue) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.Secret = secret
+	}
+}
+
+func ChangeProjectID(projectID string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.ProjectID = &projectID
+	}
+}
+
+func ChangeRequireGrant(requireGrant bool) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.RequireGrant = &requireGrant
+	}
+}
+
+func ChangeRoleAttributes(roleAttributes []*domain.RADIUSRoleAttribute) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.RoleAttributes = &roleAttributes
+	}
+}
+
+type RemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	name string
+}
+
+func (e *RemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = *b
+}
+
+func (e *RemovedEvent) Payload() any {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemoveUniqueConstraint(e.name)}
+}
+
+func NewRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate, name string) *RemovedEvent {
+	return &RemovedEvent{*eventstore.NewBaseEventForPush(ctx, aggregate, RemovedEventType), name}
+}
@@ -11,6 +11,9 @@ const (
 	backChannelEventTypePrefix      = eventTypePrefix + "back_channel."
 	BackChannelLogoutRegisteredType = backChannelEventTypePrefix + "registered"
 	BackChannelLogoutSentType       = backChannelEventTypePrefix + "sent"
+
+	frontChannelEventTypePrefix      = eventTypePrefix + "front_channel."
+	FrontChannelLogoutRegisteredType = frontChannelEventTypePrefix + "registered"
 )
 
 type BackChannelLogoutRegisteredEvent struct {
@@ -77,3 +80,39 @@ func NewBackChannelLogoutSentEvent(ctx context.Context, aggregate *eventstore.Ag
 		OIDCSessionID: oidcSessionID,
 	}
 }
+
+type FrontChannelLogoutRegisteredEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	OIDCSessionID         string `json:"oidc_session_id"`
+	UserID                string `json:"user_id"`
+	ClientID              string `json:"client_id"`
+	FrontChannelLogoutURI string `json:"front_channel_logout_uri"`
+}
+
+// Payload implements eventstore.Command.
+func (e *FrontChannelLogoutRegisteredEvent) Payload() any {
+	return e
+}
+
+func (e *FrontChannelLogoutRegisteredEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *FrontChannelLogoutRegisteredEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func NewFrontChannelLogoutRegisteredEvent(ctx context.Context, aggregate *eventstore.Aggregate, oidcSessionID, userID, clientID, frontChannelLogoutURI string) *FrontChannelLogoutRegisteredEvent {
+	return &FrontChannelLogoutRegisteredEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			FrontChannelLogoutRegisteredType,
+		),
+		OIDCSessionID:         oidcSessionID,
+		UserID:                userID,
+		ClientID:              clientID,
+		FrontChannelLogoutURI: frontChannelLogoutURI,
+	}
+}
@@ -5,11 +5,13 @@ import (
 )
 
 var (
-	BackChannelLogoutRegisteredEventMapper = eventstore.GenericEventMapper[BackChannelLogoutRegisteredEvent]
-	BackChannelLogoutSentEventMapper       = eventstore.GenericEventMapper[BackChannelLogoutSentEvent]
+	BackChannelLogoutRegisteredEventMapper  = eventstore.GenericEventMapper[BackChannelLogoutRegisteredEvent]
+	BackChannelLogoutSentEventMapper        = eventstore.GenericEventMapper[BackChannelLogoutSentEvent]
+	FrontChannelLogoutRegisteredEventMapper = eventstore.GenericEventMapper[FrontChannelLogoutRegisteredEvent]
 )
 
 func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, BackChannelLogoutRegisteredType, BackChannelLogoutRegisteredEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, BackChannelLogoutSentType, BackChannelLogoutSentEventMapper)
+	eventstore.RegisterFilterEventMapper(AggregateType, FrontChannelLogoutRegisteredType, FrontChannelLogoutRegisteredEventMapper)
 }
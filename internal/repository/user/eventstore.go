@@ -70,6 +70,8 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPLoginCheckSucceededType, UserIDPCheckSucceededEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPExternalIDMigratedType, eventstore.GenericEventMapper[UserIDPExternalIDMigratedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPExternalUsernameChangedType, eventstore.GenericEventMapper[UserIDPExternalUsernameEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPAssignmentAddedType, eventstore.GenericEventMapper[UserIDPAssignmentAddedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, UserIDPAssignmentRemovedType, eventstore.GenericEventMapper[UserIDPAssignmentRemovedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailChangedType, HumanEmailChangedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailVerifiedType, HumanEmailVerifiedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanEmailVerificationFailedType, HumanEmailVerificationFailedEventMapper)
@@ -138,8 +140,43 @@ func init() {
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretSetType, MachineSecretSetEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretRemovedType, MachineSecretRemovedEventMapper)
 	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretHashUpdatedType, eventstore.GenericEventMapper[MachineSecretHashUpdatedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretCheckSucceededType, eventstore.GenericEventMapper[MachineSecretCheckSucceededEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, MachineSecretCheckFailedType, eventstore.GenericEventMapper[MachineSecretCheckFailedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCodeAddedType, eventstore.GenericEventMapper[HumanInviteCodeAddedEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCodeSentType, eventstore.GenericEventMapper[HumanInviteCodeSentEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCheckSucceededType, eventstore.GenericEventMapper[HumanInviteCheckSucceededEvent])
 	eventstore.RegisterFilterEventMapper(AggregateType, HumanInviteCheckFailedType, eventstore.GenericEventMapper[HumanInviteCheckFailedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanDataExportRequestedType, eventstore.GenericEventMapper[HumanDataExportRequestedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanDataExportSucceededType, eventstore.GenericEventMapper[HumanDataExportSucceededEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanDataExportFailedType, eventstore.GenericEventMapper[HumanDataExportFailedEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanDataExportLinkSentType, eventstore.GenericEventMapper[HumanDataExportLinkSentEvent])
+	eventstore.RegisterFilterEventMapper(AggregateType, HumanDataExportDownloadedType, eventstore.GenericEventMapper[HumanDataExportDownloadedEvent])
+
+	registerPersonalData()
+}
+
+// registerPersonalData marks the fields of the user events which are encrypted with the key of the user,
+// the key is destroyed as soon as the user is removed.
+// The events of the first version contain the same personal data and are registered as well.
+func registerPersonalData() {
+	profileFields := []string{"firstName", "lastName", "nickName", "displayName"}
+	addressFields := []string{"country", "locality", "postalCode", "region", "streetAddress"}
+	humanFields := append(append([]string{"userName", "email", "phone"}, profileFields...), addressFields...)
+	for _, eventType := range []eventstore.EventType{HumanAddedType, HumanRegisteredType, UserV1AddedType, UserV1RegisteredType} {
+		eventstore.RegisterPersonalData(eventType, humanFields...)
+	}
+	eventstore.RegisterPersonalData(HumanEmailChangedType, "email")
+	eventstore.RegisterPersonalData(UserV1EmailChangedType, "email")
+	eventstore.RegisterPersonalData(HumanPhoneChangedType, "phone")
+	eventstore.RegisterPersonalData(UserV1PhoneChangedType, "phone")
+	eventstore.RegisterPersonalData(HumanProfileChangedType, profileFields...)
+	eventstore.RegisterPersonalData(UserV1ProfileChangedType, profileFields...)
+	eventstore.RegisterPersonalData(HumanAddressChangedType, addressFields...)
+	eventstore.RegisterPersonalData(UserV1AddressChangedType, addressFields...)
+	eventstore.RegisterPersonalData(UserUserNameChangedType, "userName")
+	eventstore.RegisterPersonalData(UserDomainClaimedType, "userName")
+	eventstore.RegisterPersonalData(UserIDPLinkAddedType, "displayName")
+	eventstore.RegisterPersonalData(UserIDPExternalUsernameChangedType, "username")
+	eventstore.RegisterPersonalData(MetadataSetType, "value")
+	eventstore.RegisterPersonalDataErasure(UserRemovedType)
 }
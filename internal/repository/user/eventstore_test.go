@@ -0,0 +1,57 @@
+package user
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+// personalDataFields are the payload fields of the user events which contain personal data
+var personalDataFields = []string{
+	"userName", "username",
+	"firstName", "lastName", "nickName", "displayName",
+	"email", "phone",
+	"country", "locality", "postalCode", "region", "streetAddress",
+	"value",
+}
+
+// notPersonalData are the event types whose fields don't belong to a person
+var notPersonalData = []eventstore.EventType{
+	MachineAddedEventType,
+}
+
+// Test_registerPersonalData fails if a user event keeps a personal data field which isn't registered,
+// register the field in registerPersonalData so it's encrypted with the key of the user.
+func Test_registerPersonalData(t *testing.T) {
+	// "cGlp" is a valid string and a valid base64 encoded []byte
+	const value = `"cGlp"`
+	for eventType, mapper := range eventstore.EventMappers(AggregateType) {
+		if slices.Contains(notPersonalData, eventType) {
+			continue
+		}
+		for _, field := range personalDataFields {
+			mapped, err := mapper(&repository.Event{
+				AggregateType: AggregateType,
+				Typ:           eventType,
+				Data:          []byte(`{"` + field + `":` + value + `}`),
+			})
+			if err != nil {
+				continue
+			}
+			payload, err := json.Marshal(mapped)
+			if err != nil {
+				continue
+			}
+			var fields map[string]json.RawMessage
+			if json.Unmarshal(payload, &fields) != nil || string(fields[field]) != value {
+				continue
+			}
+			assert.True(t, slices.Contains(eventstore.PersonalDataFields(eventType), field), "%s of %s is not registered as personal data", field, eventType)
+		}
+	}
+}
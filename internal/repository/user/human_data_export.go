@@ -0,0 +1,201 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	dataExportEventPrefix         = humanEventPrefix + "data.export."
+	HumanDataExportRequestedType  = dataExportEventPrefix + "requested"
+	HumanDataExportSucceededType  = dataExportEventPrefix + "succeeded"
+	HumanDataExportFailedType     = dataExportEventPrefix + "failed"
+	HumanDataExportLinkSentType   = dataExportEventPrefix + "link.sent"
+	HumanDataExportDownloadedType = dataExportEventPrefix + "downloaded"
+)
+
+// HumanDataExportRequestedEvent requests the export of the personal data of the user.
+type HumanDataExportRequestedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID                string `json:"id"`
+	TriggeredAtOrigin string `json:"triggerOrigin,omitempty"`
+}
+
+func (e *HumanDataExportRequestedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *HumanDataExportRequestedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanDataExportRequestedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanDataExportRequestedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewHumanDataExportRequestedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string) *HumanDataExportRequestedEvent {
+	return &HumanDataExportRequestedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanDataExportRequestedType,
+		),
+		ID:                id,
+		TriggeredAtOrigin: http.DomainContext(ctx).Origin(),
+	}
+}
+
+// HumanDataExportSucceededEvent is pushed as soon as the archive of the export is stored,
+// the archive can be downloaded using the code until it expires.
+type HumanDataExportSucceededEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID                string              `json:"id"`
+	ObjectName        string              `json:"objectName"`
+	Size              int64               `json:"size"`
+	Code              *crypto.CryptoValue `json:"code"`
+	Expiry            time.Duration       `json:"expiry"`
+	TriggeredAtOrigin string              `json:"triggerOrigin,omitempty"`
+}
+
+func (e *HumanDataExportSucceededEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *HumanDataExportSucceededEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanDataExportSucceededEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *HumanDataExportSucceededEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func NewHumanDataExportSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	id,
+	objectName string,
+	size int64,
+	code *crypto.CryptoValue,
+	expiry time.Duration,
+	triggeredAtOrigin string,
+) *HumanDataExportSucceededEvent {
+	return &HumanDataExportSucceededEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanDataExportSucceededType,
+		),
+		ID:                id,
+		ObjectName:        objectName,
+		Size:              size,
+		Code:              code,
+		Expiry:            expiry,
+		TriggeredAtOrigin: triggeredAtOrigin,
+	}
+}
+
+// HumanDataExportFailedEvent is pushed if the archive of the export could not be generated.
+type HumanDataExportFailedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID     string `json:"id"`
+	Reason string `json:"reason,omitempty"`
+}
+
+func (e *HumanDataExportFailedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *HumanDataExportFailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanDataExportFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanDataExportFailedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id, reason string) *HumanDataExportFailedEvent {
+	return &HumanDataExportFailedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanDataExportFailedType,
+		),
+		ID:     id,
+		Reason: reason,
+	}
+}
+
+// HumanDataExportLinkSentEvent is pushed after the user was notified about the ready export.
+type HumanDataExportLinkSentEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+}
+
+func (e *HumanDataExportLinkSentEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *HumanDataExportLinkSentEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanDataExportLinkSentEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanDataExportLinkSentEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string) *HumanDataExportLinkSentEvent {
+	return &HumanDataExportLinkSentEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanDataExportLinkSentType,
+		),
+		ID: id,
+	}
+}
+
+// HumanDataExportDownloadedEvent records each download of the archive.
+type HumanDataExportDownloadedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ID string `json:"id"`
+}
+
+func (e *HumanDataExportDownloadedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *HumanDataExportDownloadedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *HumanDataExportDownloadedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewHumanDataExportDownloadedEvent(ctx context.Context, aggregate *eventstore.Aggregate, id string) *HumanDataExportDownloadedEvent {
+	return &HumanDataExportDownloadedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			HumanDataExportDownloadedType,
+		),
+		ID: id,
+	}
+}
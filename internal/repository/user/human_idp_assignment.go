@@ -0,0 +1,103 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	userIDPAssignmentEventPrefix = UserIDPLinkEventPrefix + "assignment."
+
+	UserIDPAssignmentAddedType   = userIDPAssignmentEventPrefix + "added"
+	UserIDPAssignmentRemovedType = userIDPAssignmentEventPrefix + "removed"
+)
+
+// UserIDPAssignmentAddedEvent records that a project role or group was assigned to the user
+// by the mapping rules of an identity provider.
+// Either ProjectID and RoleKey or GroupID are set.
+type UserIDPAssignmentAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID string `json:"idpConfigId"`
+	ProjectID   string `json:"projectId,omitempty"`
+	RoleKey     string `json:"roleKey,omitempty"`
+	GroupID     string `json:"groupId,omitempty"`
+}
+
+func (e *UserIDPAssignmentAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPAssignmentAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPAssignmentAddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPAssignmentAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	projectID,
+	roleKey,
+	groupID string,
+) *UserIDPAssignmentAddedEvent {
+	return &UserIDPAssignmentAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPAssignmentAddedType,
+		),
+		IDPConfigID: idpConfigID,
+		ProjectID:   projectID,
+		RoleKey:     roleKey,
+		GroupID:     groupID,
+	}
+}
+
+// UserIDPAssignmentRemovedEvent records that a project role or group assigned by the mapping rules
+// of an identity provider was removed from the user, because the rules no longer returned it.
+type UserIDPAssignmentRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	IDPConfigID string `json:"idpConfigId"`
+	ProjectID   string `json:"projectId,omitempty"`
+	RoleKey     string `json:"roleKey,omitempty"`
+	GroupID     string `json:"groupId,omitempty"`
+}
+
+func (e *UserIDPAssignmentRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *UserIDPAssignmentRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *UserIDPAssignmentRemovedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewUserIDPAssignmentRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	idpConfigID,
+	projectID,
+	roleKey,
+	groupID string,
+) *UserIDPAssignmentRemovedEvent {
+	return &UserIDPAssignmentRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			UserIDPAssignmentRemovedType,
+		),
+		IDPConfigID: idpConfigID,
+		ProjectID:   projectID,
+		RoleKey:     roleKey,
+		GroupID:     groupID,
+	}
+}
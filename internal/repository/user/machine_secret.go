@@ -9,10 +9,13 @@ import (
 )
 
 const (
-	machineSecretPrefix          = machineEventPrefix + "secret."
-	MachineSecretSetType         = machineSecretPrefix + "set"
-	MachineSecretHashUpdatedType = machineSecretPrefix + "updated"
-	MachineSecretRemovedType     = machineSecretPrefix + "removed"
+	machineSecretPrefix             = machineEventPrefix + "secret."
+	MachineSecretSetType            = machineSecretPrefix + "set"
+	MachineSecretHashUpdatedType    = machineSecretPrefix + "updated"
+	MachineSecretRemovedType        = machineSecretPrefix + "removed"
+	machineSecretCheckPrefix        = machineSecretPrefix + "check."
+	MachineSecretCheckSucceededType = machineSecretCheckPrefix + "succeeded"
+	MachineSecretCheckFailedType    = machineSecretCheckPrefix + "failed"
 )
 
 type MachineSecretSetEvent struct {
@@ -127,3 +130,65 @@ func (e *MachineSecretHashUpdatedEvent) Payload() interface{} {
 func (e *MachineSecretHashUpdatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
 	return nil
 }
+
+// MachineSecretCheckSucceededEvent is only recorded for checks of the secret used as password (e.g. LDAP bind),
+// it resets the failed checks counted for the lockout policy.
+type MachineSecretCheckSucceededEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func NewMachineSecretCheckSucceededEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *MachineSecretCheckSucceededEvent {
+	return &MachineSecretCheckSucceededEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MachineSecretCheckSucceededType,
+		),
+	}
+}
+
+func (e *MachineSecretCheckSucceededEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *MachineSecretCheckSucceededEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MachineSecretCheckSucceededEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+// MachineSecretCheckFailedEvent is recorded for failed checks of the secret used as password (e.g. LDAP bind)
+// and counted for the lockout policy.
+type MachineSecretCheckFailedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func NewMachineSecretCheckFailedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *MachineSecretCheckFailedEvent {
+	return &MachineSecretCheckFailedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			MachineSecretCheckFailedType,
+		),
+	}
+}
+
+func (e *MachineSecretCheckFailedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *MachineSecretCheckFailedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *MachineSecretCheckFailedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
@@ -0,0 +1,532 @@
+package userdataexport
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// Archive is the machine-readable export of all personal data of a user.
+// Secrets like password hashes, codes and keys are never part of the archive.
+type Archive struct {
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Profile     *Profile          `json:"profile"`
+	Emails      []*ContactHistory `json:"emails"`
+	Phones      []*ContactHistory `json:"phones"`
+	Metadata    []*Metadata       `json:"metadata"`
+	Grants      []*Grant          `json:"grants"`
+	Memberships []*Membership     `json:"memberships"`
+	IDPLinks    []*IDPLink        `json:"idpLinks"`
+	AuthFactors []*AuthFactor     `json:"authFactors"`
+	Sessions    []*Session        `json:"sessions"`
+	Consents    []*Consent        `json:"consents"`
+	Events      []*Event          `json:"events"`
+}
+
+type Profile struct {
+	ID                 string           `json:"id"`
+	OrganizationID     string           `json:"organizationId"`
+	CreationDate       time.Time        `json:"creationDate"`
+	ChangeDate         time.Time        `json:"changeDate"`
+	State              domain.UserState `json:"state"`
+	Username           string           `json:"username"`
+	LoginNames         []string         `json:"loginNames"`
+	PreferredLoginName string           `json:"preferredLoginName"`
+	FirstName          string           `json:"firstName"`
+	LastName           string           `json:"lastName"`
+	NickName           string           `json:"nickName,omitempty"`
+	DisplayName        string           `json:"displayName"`
+	PreferredLanguage  string           `json:"preferredLanguage,omitempty"`
+	Gender             domain.Gender    `json:"gender,omitempty"`
+	Email              string           `json:"email"`
+	IsEmailVerified    bool             `json:"isEmailVerified"`
+	Phone              string           `json:"phone,omitempty"`
+	IsPhoneVerified    bool             `json:"isPhoneVerified"`
+	PasswordChanged    *time.Time       `json:"passwordChanged,omitempty"`
+}
+
+// ContactHistory is an email address or phone number the user had set at the time.
+type ContactHistory struct {
+	Value     string    `json:"value"`
+	SetAt     time.Time `json:"setAt"`
+	RemovedAt time.Time `json:"removedAt,omitzero"`
+}
+
+type Metadata struct {
+	Key          string    `json:"key"`
+	Value        []byte    `json:"value"`
+	CreationDate time.Time `json:"creationDate"`
+	ChangeDate   time.Time `json:"changeDate"`
+}
+
+type Grant struct {
+	ID             string                `json:"id"`
+	CreationDate   time.Time             `json:"creationDate"`
+	ChangeDate     time.Time             `json:"changeDate"`
+	State          domain.UserGrantState `json:"state"`
+	Roles          []string              `json:"roles"`
+	ProjectID      string                `json:"projectId"`
+	ProjectName    string                `json:"projectName"`
+	OrganizationID string                `json:"organizationId"`
+	ProjectGrantID string                `json:"projectGrantId,omitempty"`
+}
+
+type Membership struct {
+	CreationDate   time.Time `json:"creationDate"`
+	ChangeDate     time.Time `json:"changeDate"`
+	Roles          []string  `json:"roles"`
+	InstanceID     string    `json:"instanceId,omitempty"`
+	OrganizationID string    `json:"organizationId,omitempty"`
+	ProjectID      string    `json:"projectId,omitempty"`
+	ProjectGrantID string    `json:"projectGrantId,omitempty"`
+	Name           string    `json:"name,omitempty"`
+}
+
+type IDPLink struct {
+	IDPID    string         `json:"idpId"`
+	IDPName  string         `json:"idpName"`
+	IDPType  domain.IDPType `json:"idpType"`
+	UserID   string         `json:"userId"`
+	Username string         `json:"username"`
+}
+
+// AuthFactor is a registered authentication factor, the secret of the factor is not exported.
+type AuthFactor struct {
+	ID           string                    `json:"id,omitempty"`
+	Type         domain.UserAuthMethodType `json:"type"`
+	Name         string                    `json:"name,omitempty"`
+	State        domain.MFAState           `json:"state"`
+	CreationDate time.Time                 `json:"creationDate"`
+	ChangeDate   time.Time                 `json:"changeDate"`
+}
+
+type Session struct {
+	ID                string              `json:"id"`
+	CreationDate      time.Time           `json:"creationDate"`
+	ChangeDate        time.Time           `json:"changeDate"`
+	State             domain.SessionState `json:"state"`
+	Expiration        time.Time           `json:"expiration,omitzero"`
+	UserCheckedAt     time.Time           `json:"userCheckedAt,omitzero"`
+	PasswordCheckedAt time.Time           `json:"passwordCheckedAt,omitzero"`
+	IntentCheckedAt   time.Time           `json:"intentCheckedAt,omitzero"`
+	WebAuthNCheckedAt time.Time           `json:"webAuthNCheckedAt,omitzero"`
+	TOTPCheckedAt     time.Time           `json:"totpCheckedAt,omitzero"`
+	OTPSMSCheckedAt   time.Time           `json:"otpSmsCheckedAt,omitzero"`
+	OTPEmailCheckedAt time.Time           `json:"otpEmailCheckedAt,omitzero"`
+	UserAgent         *UserAgent          `json:"userAgent,omitempty"`
+}
+
+type UserAgent struct {
+	FingerprintID string              `json:"fingerprintId,omitempty"`
+	IP            net.IP              `json:"ip,omitempty"`
+	Description   string              `json:"description,omitempty"`
+	Header        map[string][]string `json:"header,omitempty"`
+}
+
+// Consent is the authorization of an application to access the data of the user.
+type Consent struct {
+	ClientID string    `json:"clientId"`
+	Scope    []string  `json:"scope"`
+	Audience []string  `json:"audience"`
+	AuthTime time.Time `json:"authTime"`
+}
+
+// Event is a change of the user, fields containing secrets are redacted from its payload.
+type Event struct {
+	Type         eventstore.EventType `json:"type"`
+	CreationDate time.Time            `json:"creationDate"`
+	Sequence     uint64               `json:"sequence"`
+	Editor       string               `json:"editor"`
+	Payload      map[string]any       `json:"payload,omitempty"`
+}
+
+// redactedFields are the payload fields of the events containing secrets.
+var redactedFields = map[string]bool{
+	"code":          true,
+	"codes":         true,
+	"encodedHash":   true,
+	"hashedSecret":  true,
+	"otpSecret":     true,
+	"secret":        true,
+	"clientSecret":  true,
+	"publicKey":     true,
+	"challenge":     true,
+	"refreshToken":  true,
+	"secretCrypto":  true,
+	"passwordHash":  true,
+	"hashedCodes":   true,
+	"encryptedCode": true,
+}
+
+const redacted = "[REDACTED]"
+
+func (w *Worker) collect(ctx context.Context, userID string) (_ *Archive, err error) {
+	archive := &Archive{
+		GeneratedAt: w.now(),
+	}
+	if archive.Profile, err = w.profile(ctx, userID); err != nil {
+		return nil, err
+	}
+	if archive.Metadata, err = w.metadata(ctx, userID); err != nil {
+		return nil, err
+	}
+	if archive.Grants, err = w.grants(ctx, userID); err != nil {
+		return nil, err
+	}
+	if archive.Memberships, err = w.memberships(ctx, userID); err != nil {
+		return nil, err
+	}
+	if archive.IDPLinks, err = w.idpLinks(ctx, userID); err != nil {
+		return nil, err
+	}
+	if archive.AuthFactors, err = w.authFactors(ctx, userID); err != nil {
+		return nil, err
+	}
+	if archive.Sessions, err = w.sessions(ctx, userID); err != nil {
+		return nil, err
+	}
+	if archive.Consents, err = w.consents(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err = w.history(ctx, userID, archive); err != nil {
+		return nil, err
+	}
+	return archive, nil
+}
+
+func (w *Worker) profile(ctx context.Context, userID string) (*Profile, error) {
+	u, err := w.queries.GetUserByID(ctx, false, userID)
+	if err != nil {
+		return nil, err
+	}
+	profile := &Profile{
+		ID:                 u.ID,
+		OrganizationID:     u.ResourceOwner,
+		CreationDate:       u.CreationDate,
+		ChangeDate:         u.ChangeDate,
+		State:              u.State,
+		Username:           u.Username,
+		LoginNames:         u.LoginNames,
+		PreferredLoginName: u.PreferredLoginName,
+	}
+	if u.Human == nil {
+		return profile, nil
+	}
+	profile.FirstName = u.Human.FirstName
+	profile.LastName = u.Human.LastName
+	profile.NickName = u.Human.NickName
+	profile.DisplayName = u.Human.DisplayName
+	if !u.Human.PreferredLanguage.IsRoot() {
+		profile.PreferredLanguage = u.Human.PreferredLanguage.String()
+	}
+	profile.Gender = u.Human.Gender
+	profile.Email = string(u.Human.Email)
+	profile.IsEmailVerified = u.Human.IsEmailVerified
+	profile.Phone = string(u.Human.Phone)
+	profile.IsPhoneVerified = u.Human.IsPhoneVerified
+	if !u.Human.PasswordChanged.IsZero() {
+		profile.PasswordChanged = &u.Human.PasswordChanged
+	}
+	return profile, nil
+}
+
+func (w *Worker) metadata(ctx context.Context, userID string) ([]*Metadata, error) {
+	list, err := w.queries.SearchUserMetadata(ctx, false, userID, &query.UserMetadataSearchQueries{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	metadata := make([]*Metadata, len(list.Metadata))
+	for i, md := range list.Metadata {
+		metadata[i] = &Metadata{
+			Key:          md.Key,
+			Value:        md.Value,
+			CreationDate: md.CreationDate,
+			ChangeDate:   md.ChangeDate,
+		}
+	}
+	return metadata, nil
+}
+
+func (w *Worker) grants(ctx context.Context, userID string) ([]*Grant, error) {
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := w.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]*Grant, len(list.UserGrants))
+	for i, grant := range list.UserGrants {
+		grants[i] = &Grant{
+			ID:             grant.ID,
+			CreationDate:   grant.CreationDate,
+			ChangeDate:     grant.ChangeDate,
+			State:          grant.State,
+			Roles:          grant.Roles,
+			ProjectID:      grant.ProjectID,
+			ProjectName:    grant.ProjectName,
+			OrganizationID: grant.ResourceOwner,
+			ProjectGrantID: grant.GrantID,
+		}
+	}
+	return grants, nil
+}
+
+func (w *Worker) memberships(ctx context.Context, userID string) ([]*Membership, error) {
+	userIDQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := w.queries.Memberships(ctx, &query.MembershipSearchQuery{Queries: []query.SearchQuery{userIDQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]*Membership, len(list.Memberships))
+	for i, m := range list.Memberships {
+		membership := &Membership{
+			CreationDate: m.CreationDate,
+			ChangeDate:   m.ChangeDate,
+			Roles:        m.Roles,
+		}
+		switch {
+		case m.IAM != nil:
+			membership.InstanceID = m.IAM.IAMID
+			membership.Name = m.IAM.Name
+		case m.Org != nil:
+			membership.OrganizationID = m.Org.OrgID
+			membership.Name = m.Org.Name
+		case m.Project != nil:
+			membership.ProjectID = m.Project.ProjectID
+			membership.Name = m.Project.Name
+		case m.ProjectGrant != nil:
+			membership.ProjectID = m.ProjectGrant.ProjectID
+			membership.ProjectGrantID = m.ProjectGrant.GrantID
+			membership.OrganizationID = m.ProjectGrant.GrantedOrgID
+			membership.Name = m.ProjectGrant.ProjectName
+		}
+		memberships[i] = membership
+	}
+	return memberships, nil
+}
+
+func (w *Worker) idpLinks(ctx context.Context, userID string) ([]*IDPLink, error) {
+	userIDQuery, err := query.NewIDPUserLinksUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := w.queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{userIDQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	links := make([]*IDPLink, len(list.Links))
+	for i, link := range list.Links {
+		links[i] = &IDPLink{
+			IDPID:    link.IDPID,
+			IDPName:  link.IDPName,
+			IDPType:  link.IDPType,
+			UserID:   link.ProvidedUserID,
+			Username: link.ProvidedUsername,
+		}
+	}
+	return links, nil
+}
+
+func (w *Worker) authFactors(ctx context.Context, userID string) ([]*AuthFactor, error) {
+	userIDQuery, err := query.NewUserAuthMethodUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := w.queries.SearchUserAuthMethods(ctx, &query.UserAuthMethodSearchQueries{Queries: []query.SearchQuery{userIDQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	factors := make([]*AuthFactor, len(list.AuthMethods))
+	for i, method := range list.AuthMethods {
+		factors[i] = &AuthFactor{
+			ID:           method.TokenID,
+			Type:         method.Type,
+			Name:         method.Name,
+			State:        method.State,
+			CreationDate: method.CreationDate,
+			ChangeDate:   method.ChangeDate,
+		}
+	}
+	return factors, nil
+}
+
+func (w *Worker) sessions(ctx context.Context, userID string) ([]*Session, error) {
+	userIDQuery, err := query.NewUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := w.queries.SearchSessions(ctx, &query.SessionsSearchQueries{Queries: []query.SearchQuery{userIDQuery}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	sessions := make([]*Session, len(list.Sessions))
+	for i, s := range list.Sessions {
+		sessions[i] = &Session{
+			ID:                s.ID,
+			CreationDate:      s.CreationDate,
+			ChangeDate:        s.ChangeDate,
+			State:             s.State,
+			Expiration:        s.Expiration,
+			UserCheckedAt:     s.UserFactor.UserCheckedAt,
+			PasswordCheckedAt: s.PasswordFactor.PasswordCheckedAt,
+			IntentCheckedAt:   s.IntentFactor.IntentCheckedAt,
+			WebAuthNCheckedAt: s.WebAuthNFactor.WebAuthNCheckedAt,
+			TOTPCheckedAt:     s.TOTPFactor.TOTPCheckedAt,
+			OTPSMSCheckedAt:   s.OTPSMSFactor.OTPCheckedAt,
+			OTPEmailCheckedAt: s.OTPEmailFactor.OTPCheckedAt,
+			UserAgent:         userAgent(&s.UserAgent),
+		}
+	}
+	return sessions, nil
+}
+
+func userAgent(ua *domain.UserAgent) *UserAgent {
+	if ua == nil || ua.IsEmpty() {
+		return nil
+	}
+	agent := &UserAgent{
+		IP:     ua.IP,
+		Header: ua.Header,
+	}
+	if ua.FingerprintID != nil {
+		agent.FingerprintID = *ua.FingerprintID
+	}
+	if ua.Description != nil {
+		agent.Description = *ua.Description
+	}
+	return agent
+}
+
+// consents returns the authorizations of the applications the user signed in to using OIDC.
+func (w *Worker) consents(ctx context.Context, userID string) ([]*Consent, error) {
+	events, err := w.events.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(oidcsession.AggregateType).
+		EventTypes(oidcsession.AddedType).
+		EventData(map[string]interface{}{
+			"userID": userID,
+		}).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	consents := make([]*Consent, 0, len(events))
+	for _, event := range events {
+		e, ok := event.(*oidcsession.AddedEvent)
+		if !ok {
+			continue
+		}
+		consents = append(consents, &Consent{
+			ClientID: e.ClientID,
+			Scope:    e.Scope,
+			Audience: e.Audience,
+			AuthTime: e.AuthTime,
+		})
+	}
+	return consents, nil
+}
+
+// history adds the events of the user to the archive
+// and derives the email addresses and phone numbers the user had set over time.
+func (w *Worker) history(ctx context.Context, userID string, archive *Archive) error {
+	events, err := w.events.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userID).
+		Builder(),
+	)
+	if err != nil {
+		return err
+	}
+	archive.Emails = make([]*ContactHistory, 0)
+	archive.Phones = make([]*ContactHistory, 0)
+	archive.Events = make([]*Event, len(events))
+	for i, event := range events {
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			archive.Emails = appendContact(archive.Emails, string(e.EmailAddress), e.CreatedAt())
+			archive.Phones = appendContact(archive.Phones, string(e.PhoneNumber), e.CreatedAt())
+		case *user.HumanRegisteredEvent:
+			archive.Emails = appendContact(archive.Emails, string(e.EmailAddress), e.CreatedAt())
+			archive.Phones = appendContact(archive.Phones, string(e.PhoneNumber), e.CreatedAt())
+		case *user.HumanEmailChangedEvent:
+			archive.Emails = appendContact(archive.Emails, string(e.EmailAddress), e.CreatedAt())
+		case *user.HumanPhoneChangedEvent:
+			archive.Phones = appendContact(archive.Phones, string(e.PhoneNumber), e.CreatedAt())
+		case *user.HumanPhoneRemovedEvent:
+			archive.Phones = appendContact(archive.Phones, "", e.CreatedAt())
+		}
+		archive.Events[i] = &Event{
+			Type:         event.Type(),
+			CreationDate: event.CreatedAt(),
+			Sequence:     event.Sequence(),
+			Editor:       event.Creator(),
+			Payload:      redactPayload(event),
+		}
+	}
+	return nil
+}
+
+// appendContact sets the removal date of the previous value and appends the new one if not empty.
+func appendContact(history []*ContactHistory, value string, setAt time.Time) []*ContactHistory {
+	if len(history) > 0 {
+		if previous := history[len(history)-1]; previous.RemovedAt.IsZero() {
+			if previous.Value == value {
+				return history
+			}
+			previous.RemovedAt = setAt
+		}
+	}
+	if value == "" {
+		return history
+	}
+	return append(history, &ContactHistory{
+		Value: value,
+		SetAt: setAt,
+	})
+}
+
+func redactPayload(event eventstore.Event) map[string]any {
+	var payload map[string]any
+	if err := event.Unmarshal(&payload); err != nil || len(payload) == 0 {
+		return nil
+	}
+	redact(payload)
+	return payload
+}
+
+func redact(payload map[string]any) {
+	for key, value := range payload {
+		if redactedFields[key] {
+			payload[key] = redacted
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			redact(v)
+		case []any:
+			for _, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					redact(m)
+				}
+			}
+		}
+	}
+}
+
+func marshalArchive(archive *Archive) ([]byte, error) {
+	return json.MarshalIndent(archive, "", "  ")
+}
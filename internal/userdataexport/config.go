@@ -0,0 +1,12 @@
+package userdataexport
+
+import "time"
+
+type Config struct {
+	// MaxAttempts of generating a single archive.
+	MaxAttempts uint8
+	// Workers is the amount of archives generated in parallel.
+	Workers int
+	// Expiry is the duration an archive can be downloaded, it's removed from the storage afterwards.
+	Expiry time.Duration
+}
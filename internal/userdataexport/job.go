@@ -0,0 +1,25 @@
+package userdataexport
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+// GenerateArchive is the job generating and storing the archive of a requested export.
+type GenerateArchive struct {
+	Aggregate *eventstore.Aggregate
+	ExportID  string
+}
+
+func (*GenerateArchive) Kind() string {
+	return "user_data_export"
+}
+
+// RemoveArchives is the job removing stored archives of a user,
+// either after they expired or after the user was removed.
+type RemoveArchives struct {
+	Aggregate *eventstore.Aggregate
+	// ExportID of the archive to remove, all archives of the user are removed if empty.
+	ExportID string
+}
+
+func (*RemoveArchives) Kind() string {
+	return "user_data_export_removal"
+}
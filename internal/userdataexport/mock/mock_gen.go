@@ -0,0 +1,7 @@
+package mock
+
+//go:generate mockgen -package mock -destination commands.mock.go github.com/zitadel/zitadel/internal/userdataexport Commands
+//go:generate mockgen -package mock -destination queries.mock.go github.com/zitadel/zitadel/internal/userdataexport Queries
+//go:generate mockgen -package mock -destination events.mock.go github.com/zitadel/zitadel/internal/userdataexport Events
+//go:generate mockgen -package mock -destination storage.mock.go github.com/zitadel/zitadel/internal/userdataexport Storage
+//go:generate mockgen -package mock -destination queue.mock.go github.com/zitadel/zitadel/internal/userdataexport Queue
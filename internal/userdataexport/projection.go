@@ -0,0 +1,108 @@
+package userdataexport
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/zerrors"
+)
+
+const (
+	JobsProjectionTable = "projections.user_data_export_jobs"
+)
+
+// jobScheduler inserts the jobs generating and removing the archives based on the events of the users.
+type jobScheduler struct {
+	queue  Queue
+	config *Config
+}
+
+func newJobScheduler(ctx context.Context, config handler.Config, queue Queue, exportConfig *Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, &jobScheduler{
+		queue:  queue,
+		config: exportConfig,
+	})
+}
+
+func (*jobScheduler) Name() string {
+	return JobsProjectionTable
+}
+
+func (s *jobScheduler) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.HumanDataExportRequestedType,
+					Reduce: s.reduceRequested,
+				},
+				{
+					Event:  user.HumanDataExportSucceededType,
+					Reduce: s.reduceSucceeded,
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: s.reduceUserRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (s *jobScheduler) reduceRequested(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanDataExportRequestedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "DATAEX-Vq4ts", "reduce.wrong.event.type %s", user.HumanDataExportRequestedType)
+	}
+	return handler.NewStatement(event, func(ctx context.Context, _ handler.Executer, _ string) error {
+		return s.queue.Insert(ctx,
+			&GenerateArchive{
+				Aggregate: e.Aggregate(),
+				ExportID:  e.ID,
+			},
+			queue.WithQueueName(QueueName),
+			queue.WithMaxAttempts(s.config.MaxAttempts),
+			queue.WithUniqueArgs(),
+		)
+	}), nil
+}
+
+// reduceSucceeded schedules the removal of the archive as soon as it expires.
+func (s *jobScheduler) reduceSucceeded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanDataExportSucceededEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "DATAEX-Ml2ob", "reduce.wrong.event.type %s", user.HumanDataExportSucceededType)
+	}
+	return handler.NewStatement(event, func(ctx context.Context, _ handler.Executer, _ string) error {
+		return s.queue.Insert(ctx,
+			&RemoveArchives{
+				Aggregate: e.Aggregate(),
+				ExportID:  e.ID,
+			},
+			queue.WithQueueName(QueueName),
+			queue.WithMaxAttempts(s.config.MaxAttempts),
+			queue.WithScheduledAt(e.CreatedAt().Add(e.Expiry)),
+		)
+	}), nil
+}
+
+// reduceUserRemoved removes all archives of the user immediately.
+func (s *jobScheduler) reduceUserRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.UserRemovedEvent)
+	if !ok {
+		return nil, zerrors.ThrowInvalidArgumentf(nil, "DATAEX-Rk8fe", "reduce.wrong.event.type %s", user.UserRemovedType)
+	}
+	return handler.NewStatement(event, func(ctx context.Context, _ handler.Executer, _ string) error {
+		return s.queue.Insert(ctx,
+			&RemoveArchives{
+				Aggregate: e.Aggregate(),
+			},
+			queue.WithQueueName(QueueName),
+			queue.WithMaxAttempts(s.config.MaxAttempts),
+		)
+	}), nil
+}
@@ -0,0 +1,219 @@
+package userdataexport
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/queue"
+	"github.com/zitadel/zitadel/internal/static"
+)
+
+const (
+	QueueName = "user_data_export"
+	// ExportUserID is the editor of all changes made while generating an export.
+	ExportUserID = "USER-DATA-EXPORT"
+	// codeLength of the code needed to download an archive.
+	codeLength = 32
+)
+
+var (
+	_ river.Worker[*GenerateArchive] = (*Worker)(nil)
+	_ river.Worker[*RemoveArchives]  = (*Remover)(nil)
+
+	projections []*handler.Handler
+)
+
+type Commands interface {
+	UserDataExport(ctx context.Context, userID, exportID string) (*command.UserDataExportWriteModel, error)
+	UserDataExportSucceeded(ctx context.Context, userID, exportID, objectName string, size int64, codeGenerator crypto.Generator) error
+	UserDataExportFailed(ctx context.Context, userID, exportID, reason string) error
+}
+
+type Queries interface {
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string) (*query.User, error)
+	SearchUserMetadata(ctx context.Context, shouldTriggerBulk bool, userID string, queries *query.UserMetadataSearchQueries, permissionCheck domain.PermissionCheck) (*query.UserMetadataList, error)
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk bool, permissionCheck domain.PermissionCheck) (*query.UserGrants, error)
+	Memberships(ctx context.Context, queries *query.MembershipSearchQuery, shouldTrigger bool) (*query.Memberships, error)
+	IDPUserLinks(ctx context.Context, queries *query.IDPUserLinksSearchQuery, permissionCheck domain.PermissionCheck) (*query.IDPUserLinks, error)
+	SearchUserAuthMethods(ctx context.Context, queries *query.UserAuthMethodSearchQueries, permissionCheck domain.PermissionCheck) (*query.AuthMethods, error)
+	SearchSessions(ctx context.Context, queries *query.SessionsSearchQueries, permissionCheck domain.PermissionCheck) (*query.Sessions, error)
+	UserDataExports(ctx context.Context, userID string) ([]*query.UserDataExport, error)
+}
+
+// Events are the stored events, implemented by [eventstore.Eventstore].
+type Events interface {
+	Filter(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+}
+
+// Storage stores the archives, implemented by [static.Storage].
+type Storage interface {
+	PutObject(ctx context.Context, instanceID, location, resourceOwner, name, contentType string, objectType static.ObjectType, object io.Reader, objectSize int64) (*static.Asset, error)
+	RemoveObject(ctx context.Context, instanceID, resourceOwner, name string) error
+}
+
+type Queue interface {
+	Insert(ctx context.Context, args river.JobArgs, opts ...queue.InsertOpt) error
+}
+
+// Worker generates the archive of a requested export and stores it.
+type Worker struct {
+	river.WorkerDefaults[*GenerateArchive]
+
+	commands    Commands
+	queries     Queries
+	events      Events
+	storage     Storage
+	userCodeAlg crypto.EncryptionAlgorithm
+	config      *Config
+	now         func() time.Time
+}
+
+// Register implements the [queue.Worker] interface.
+func (w *Worker) Register(workers *river.Workers, queues map[string]river.QueueConfig) {
+	river.AddWorker[*GenerateArchive](workers, w)
+	queues[QueueName] = river.QueueConfig{
+		MaxWorkers: w.config.Workers,
+	}
+}
+
+// Work implements the [river.Worker] interface.
+// The export is marked as failed after the last attempt, so the user can request a new one.
+func (w *Worker) Work(ctx context.Context, job *river.Job[*GenerateArchive]) error {
+	aggregate := job.Args.Aggregate
+	ctx = authz.WithInstanceID(authz.SetCtxData(ctx, authz.CtxData{UserID: ExportUserID, OrgID: aggregate.ResourceOwner}), aggregate.InstanceID)
+	export, err := w.commands.UserDataExport(ctx, aggregate.ID, job.Args.ExportID)
+	if err != nil {
+		return err
+	}
+	// the export was already generated by a previous attempt
+	if export.State != domain.UserDataExportStateRequested {
+		return nil
+	}
+	if !export.UserState.Exists() {
+		return w.commands.UserDataExportFailed(ctx, aggregate.ID, job.Args.ExportID, "user removed")
+	}
+	err = w.export(ctx, export)
+	if err == nil || job.Attempt < job.MaxAttempts {
+		return err
+	}
+	logging.WithFields("instance", aggregate.InstanceID, "user", aggregate.ID, "export", job.Args.ExportID).WithError(err).Warn("user data export failed")
+	return w.commands.UserDataExportFailed(ctx, aggregate.ID, job.Args.ExportID, err.Error())
+}
+
+func (w *Worker) export(ctx context.Context, export *command.UserDataExportWriteModel) error {
+	archive, err := w.collect(ctx, export.AggregateID)
+	if err != nil {
+		return err
+	}
+	data, err := marshalArchive(archive)
+	if err != nil {
+		return err
+	}
+	asset, err := w.storage.PutObject(ctx,
+		authz.GetInstance(ctx).InstanceID(),
+		"",
+		export.ResourceOwner,
+		domain.GetUserDataExportAssetPath(export.AggregateID, export.ExportID),
+		domain.UserDataExportContentType,
+		static.ObjectTypeUserDataExport,
+		bytes.NewReader(data),
+		int64(len(data)),
+	)
+	if err != nil {
+		return err
+	}
+	return w.commands.UserDataExportSucceeded(ctx, export.AggregateID, export.ExportID, asset.Name, asset.Size, w.codeGenerator())
+}
+
+// codeGenerator returns the generator of the download code, which also defines how long the archive can be downloaded.
+func (w *Worker) codeGenerator() crypto.Generator {
+	return crypto.NewEncryptionGenerator(crypto.GeneratorConfig{
+		Length:              codeLength,
+		Expiry:              w.config.Expiry,
+		IncludeLowerLetters: true,
+		IncludeUpperLetters: true,
+		IncludeDigits:       true,
+	}, w.userCodeAlg)
+}
+
+// Remover removes the stored archives of a user.
+type Remover struct {
+	river.WorkerDefaults[*RemoveArchives]
+
+	queries Queries
+	storage Storage
+}
+
+// Register implements the [queue.Worker] interface.
+// The queue itself is configured by the [Worker].
+func (r *Remover) Register(workers *river.Workers, _ map[string]river.QueueConfig) {
+	river.AddWorker[*RemoveArchives](workers, r)
+}
+
+// Work implements the [river.Worker] interface.
+func (r *Remover) Work(ctx context.Context, job *river.Job[*RemoveArchives]) error {
+	aggregate := job.Args.Aggregate
+	ctx = authz.WithInstanceID(authz.SetCtxData(ctx, authz.CtxData{UserID: ExportUserID, OrgID: aggregate.ResourceOwner}), aggregate.InstanceID)
+	exports, err := r.queries.UserDataExports(ctx, aggregate.ID)
+	if err != nil {
+		return err
+	}
+	for _, export := range exports {
+		if export.ObjectName == "" || (job.Args.ExportID != "" && export.ID != job.Args.ExportID) {
+			continue
+		}
+		if err = r.storage.RemoveObject(ctx, aggregate.InstanceID, export.ResourceOwner, export.ObjectName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func Register(
+	ctx context.Context,
+	q *queue.Queue,
+	commands *command.Commands,
+	queries *query.Queries,
+	es *eventstore.Eventstore,
+	storage static.Storage,
+	userCodeAlg crypto.EncryptionAlgorithm,
+	customConfig projection.CustomConfig,
+	config *Config,
+) {
+	q.ShouldStart()
+	projections = append(projections, newJobScheduler(ctx, projection.ApplyCustomConfig(customConfig), q, config))
+	q.AddWorkers(ctx,
+		&Worker{
+			commands:    commands,
+			queries:     queries,
+			events:      es,
+			storage:     storage,
+			userCodeAlg: userCodeAlg,
+			config:      config,
+			now:         time.Now,
+		},
+		&Remover{
+			queries: queries,
+			storage: storage,
+		},
+	)
+}
+
+func Start(ctx context.Context) {
+	for _, projection := range projections {
+		projection.Start(ctx)
+	}
+}
@@ -0,0 +1,322 @@
+package userdataexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/userdataexport/mock"
+)
+
+var (
+	testNow    = time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	errTest    = errors.New("test error")
+	testConfig = &Config{MaxAttempts: 3, Workers: 1, Expiry: 24 * time.Hour}
+	testAgg    = &eventstore.Aggregate{
+		ID:            "user",
+		Type:          user.AggregateType,
+		ResourceOwner: "org",
+		InstanceID:    "instance",
+	}
+)
+
+func exportWriteModel(state domain.UserDataExportState, userState domain.UserState) *command.UserDataExportWriteModel {
+	wm := command.NewUserDataExportWriteModel("user", "export", "org")
+	wm.State = state
+	wm.UserState = userState
+	return wm
+}
+
+func emailChanged(t *testing.T, email domain.EmailAddress, createdAt time.Time) *user.HumanEmailChangedEvent {
+	event := user.NewHumanEmailChangedEvent(context.Background(), testAgg, email)
+	// set the payload as if the event was read from the eventstore
+	data, err := json.Marshal(event)
+	require.NoError(t, err)
+	event.Data = data
+	event.Creation = createdAt
+	return event
+}
+
+func expectCollect(queries *mock.MockQueries) {
+	queries.EXPECT().GetUserByID(gomock.Any(), false, "user").Return(&query.User{
+		ID:            "user",
+		ResourceOwner: "org",
+		State:         domain.UserStateActive,
+		Username:      "username",
+		Human: &query.Human{
+			FirstName:       "first",
+			LastName:        "last",
+			DisplayName:     "first last",
+			Email:           "new@example.com",
+			IsEmailVerified: true,
+		},
+	}, nil)
+	queries.EXPECT().SearchUserMetadata(gomock.Any(), false, "user", gomock.Any(), nil).Return(&query.UserMetadataList{
+		Metadata: []*query.UserMetadata{{Key: "key", Value: []byte("value")}},
+	}, nil)
+	queries.EXPECT().UserGrants(gomock.Any(), gomock.Any(), false, nil).Return(&query.UserGrants{}, nil)
+	queries.EXPECT().Memberships(gomock.Any(), gomock.Any(), false).Return(&query.Memberships{
+		Memberships: []*query.Membership{{Roles: []string{"ORG_OWNER"}, Org: &query.OrgMembership{OrgID: "org", Name: "org name"}}},
+	}, nil)
+	queries.EXPECT().IDPUserLinks(gomock.Any(), gomock.Any(), nil).Return(&query.IDPUserLinks{}, nil)
+	queries.EXPECT().SearchUserAuthMethods(gomock.Any(), gomock.Any(), nil).Return(&query.AuthMethods{
+		AuthMethods: []*query.AuthMethod{{TokenID: "token", Type: domain.UserAuthMethodTypeU2F, Name: "key", State: domain.MFAStateReady}},
+	}, nil)
+	queries.EXPECT().SearchSessions(gomock.Any(), gomock.Any(), nil).Return(&query.Sessions{}, nil)
+}
+
+func TestWorker_Work(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempt  int
+		commands func(*testing.T) Commands
+		queries  func(*testing.T) Queries
+		events   func(*testing.T) Events
+		storage  func(*testing.T) Storage
+		wantErr  error
+	}{
+		{
+			name:    "already generated, skip",
+			attempt: 1,
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().UserDataExport(gomock.Any(), "user", "export").Return(exportWriteModel(domain.UserDataExportStateReady, domain.UserStateActive), nil)
+				return commands
+			},
+		},
+		{
+			name:    "user removed, failed",
+			attempt: 1,
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().UserDataExport(gomock.Any(), "user", "export").Return(exportWriteModel(domain.UserDataExportStateRequested, domain.UserStateDeleted), nil)
+				commands.EXPECT().UserDataExportFailed(gomock.Any(), "user", "export", "user removed").Return(nil)
+				return commands
+			},
+		},
+		{
+			name:    "query error, retried",
+			attempt: 1,
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().UserDataExport(gomock.Any(), "user", "export").Return(exportWriteModel(domain.UserDataExportStateRequested, domain.UserStateActive), nil)
+				return commands
+			},
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				queries.EXPECT().GetUserByID(gomock.Any(), false, "user").Return(nil, errTest)
+				return queries
+			},
+			wantErr: errTest,
+		},
+		{
+			name:    "query error on last attempt, failed",
+			attempt: 3,
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().UserDataExport(gomock.Any(), "user", "export").Return(exportWriteModel(domain.UserDataExportStateRequested, domain.UserStateActive), nil)
+				commands.EXPECT().UserDataExportFailed(gomock.Any(), "user", "export", errTest.Error()).Return(nil)
+				return commands
+			},
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				queries.EXPECT().GetUserByID(gomock.Any(), false, "user").Return(nil, errTest)
+				return queries
+			},
+		},
+		{
+			name:    "archive stored, succeeded",
+			attempt: 1,
+			commands: func(t *testing.T) Commands {
+				commands := mock.NewMockCommands(gomock.NewController(t))
+				commands.EXPECT().UserDataExport(gomock.Any(), "user", "export").Return(exportWriteModel(domain.UserDataExportStateRequested, domain.UserStateActive), nil)
+				commands.EXPECT().UserDataExportSucceeded(gomock.Any(), "user", "export", "users/user/data-exports/export.json", int64(42), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, _, _ string, _ int64, generator crypto.Generator) error {
+						assert.Equal(t, testConfig.Expiry, generator.Expiry())
+						return nil
+					},
+				)
+				return commands
+			},
+			queries: func(t *testing.T) Queries {
+				queries := mock.NewMockQueries(gomock.NewController(t))
+				expectCollect(queries)
+				return queries
+			},
+			events: func(t *testing.T) Events {
+				events := mock.NewMockEvents(gomock.NewController(t))
+				gomock.InOrder(
+					// consents
+					events.EXPECT().Filter(gomock.Any(), gomock.Any()).Return(nil, nil),
+					// history
+					events.EXPECT().Filter(gomock.Any(), gomock.Any()).Return([]eventstore.Event{
+						emailChanged(t, "old@example.com", testNow.Add(-2*time.Hour)),
+						emailChanged(t, "new@example.com", testNow.Add(-time.Hour)),
+					}, nil),
+				)
+				return events
+			},
+			storage: func(t *testing.T) Storage {
+				storage := mock.NewMockStorage(gomock.NewController(t))
+				storage.EXPECT().PutObject(gomock.Any(), "instance", "", "org", "users/user/data-exports/export.json", domain.UserDataExportContentType, static.ObjectTypeUserDataExport, gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _, _, _, name, _ string, _ static.ObjectType, object io.Reader, size int64) (*static.Asset, error) {
+						data, err := io.ReadAll(object)
+						require.NoError(t, err)
+						assert.Equal(t, int64(len(data)), size)
+						archive := new(Archive)
+						require.NoError(t, json.Unmarshal(data, archive))
+						assert.Equal(t, testNow, archive.GeneratedAt)
+						assert.Equal(t, "new@example.com", archive.Profile.Email)
+						assert.Equal(t, []*ContactHistory{
+							{Value: "old@example.com", SetAt: testNow.Add(-2 * time.Hour), RemovedAt: testNow.Add(-time.Hour)},
+							{Value: "new@example.com", SetAt: testNow.Add(-time.Hour)},
+						}, archive.Emails)
+						assert.Len(t, archive.Metadata, 1)
+						assert.Len(t, archive.Memberships, 1)
+						assert.Len(t, archive.AuthFactors, 1)
+						require.Len(t, archive.Events, 2)
+						assert.Equal(t, map[string]any{"email": "old@example.com"}, archive.Events[0].Payload)
+						return &static.Asset{Name: name, Size: 42}, nil
+					},
+				)
+				return storage
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Worker{
+				commands:    tt.commands(t),
+				userCodeAlg: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+				config:      testConfig,
+				now:         func() time.Time { return testNow },
+			}
+			if tt.queries != nil {
+				w.queries = tt.queries(t)
+			}
+			if tt.events != nil {
+				w.events = tt.events(t)
+			}
+			if tt.storage != nil {
+				w.storage = tt.storage(t)
+			}
+			err := w.Work(context.Background(), &river.Job[*GenerateArchive]{
+				JobRow: &rivertype.JobRow{
+					Attempt:     tt.attempt,
+					MaxAttempts: int(testConfig.MaxAttempts),
+				},
+				Args: &GenerateArchive{
+					Aggregate: testAgg,
+					ExportID:  "export",
+				},
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRemover_Work(t *testing.T) {
+	exports := []*query.UserDataExport{
+		{ID: "failed", ResourceOwner: "org", State: domain.UserDataExportStateFailed},
+		{ID: "expired", ResourceOwner: "org", State: domain.UserDataExportStateExpired, ObjectName: "users/user/data-exports/expired.json"},
+		{ID: "ready", ResourceOwner: "org", State: domain.UserDataExportStateReady, ObjectName: "users/user/data-exports/ready.json"},
+	}
+	tests := []struct {
+		name     string
+		exportID string
+		storage  func(*testing.T) Storage
+		wantErr  error
+	}{
+		{
+			name:     "single export",
+			exportID: "expired",
+			storage: func(t *testing.T) Storage {
+				storage := mock.NewMockStorage(gomock.NewController(t))
+				storage.EXPECT().RemoveObject(gomock.Any(), "instance", "org", "users/user/data-exports/expired.json").Return(nil)
+				return storage
+			},
+		},
+		{
+			name: "all exports",
+			storage: func(t *testing.T) Storage {
+				storage := mock.NewMockStorage(gomock.NewController(t))
+				storage.EXPECT().RemoveObject(gomock.Any(), "instance", "org", "users/user/data-exports/expired.json").Return(nil)
+				storage.EXPECT().RemoveObject(gomock.Any(), "instance", "org", "users/user/data-exports/ready.json").Return(nil)
+				return storage
+			},
+		},
+		{
+			name: "storage error",
+			storage: func(t *testing.T) Storage {
+				storage := mock.NewMockStorage(gomock.NewController(t))
+				storage.EXPECT().RemoveObject(gomock.Any(), "instance", "org", "users/user/data-exports/expired.json").Return(errTest)
+				return storage
+			},
+			wantErr: errTest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			queries := mock.NewMockQueries(gomock.NewController(t))
+			queries.EXPECT().UserDataExports(gomock.Any(), "user").Return(exports, nil)
+			r := &Remover{
+				queries: queries,
+				storage: tt.storage(t),
+			}
+			err := r.Work(context.Background(), &river.Job[*RemoveArchives]{
+				Args: &RemoveArchives{
+					Aggregate: testAgg,
+					ExportID:  tt.exportID,
+				},
+			})
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
+
+func Test_redact(t *testing.T) {
+	payload := map[string]any{
+		"email": "user@example.com",
+		"code": map[string]any{
+			"cryptoType": 0,
+		},
+		"webAuthN": []any{
+			map[string]any{"publicKey": "key", "name": "security key"},
+		},
+	}
+	redact(payload)
+	assert.Equal(t, map[string]any{
+		"email": "user@example.com",
+		"code":  redacted,
+		"webAuthN": []any{
+			map[string]any{"publicKey": redacted, "name": "security key"},
+		},
+	}, payload)
+}
+
+func Test_appendContact(t *testing.T) {
+	history := appendContact(nil, "first@example.com", testNow)
+	history = appendContact(history, "first@example.com", testNow.Add(time.Hour))
+	history = appendContact(history, "", testNow.Add(2*time.Hour))
+	history = appendContact(history, "second@example.com", testNow.Add(3*time.Hour))
+	assert.Equal(t, []*ContactHistory{
+		{Value: "first@example.com", SetAt: testNow, RemovedAt: testNow.Add(2 * time.Hour)},
+		{Value: "second@example.com", SetAt: testNow.Add(3 * time.Hour)},
+	}, history)
+}